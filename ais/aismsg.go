@@ -0,0 +1,179 @@
+// Package ais provides core functionality for the AIStore object storage.
+/*
+ * Copyright (c) 2024, NVIDIA CORPORATION. All rights reserved.
+ */
+package ais
+
+import (
+	"net/http"
+
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/cmn/cos"
+	"github.com/NVIDIA/aistore/core/meta"
+	jsoniter "github.com/json-iterator/go"
+	"github.com/tinylib/msgp/msgp"
+)
+
+// Binary (msgpack) encoding of the (high-rate) intra-cluster control messages:
+// - negotiated: used only when the destination advertises it (see meta.SnodeCodecMsgpack);
+//   otherwise (e.g., older nodes in a mixed-version cluster) - JSON;
+// - the receiver tells one from the other by content type (see readAisMsg);
+// - action-specific value: nil, string, bool, and float64 are encoded natively, everything
+//   else as embedded JSON that decodes as jsoniter.RawMessage - to be further unmarshaled
+//   (cos.MorphMarshal) directly into the concrete type, without the intermediate map[string]any;
+// - to use, set bcastArgs.amsg instead of (JSON-marshaled) request body.
+
+// field names (same as JSON)
+const (
+	amsgAction    = "action"
+	amsgName      = "name"
+	amsgValue     = "value"
+	amsgValueJSON = "value_json"
+	amsgUUID      = "uuid"
+	amsgBMDVer    = "bmdversion"
+	amsgRMDVer    = "rmdversion"
+)
+
+// interface guard
+var (
+	_ msgp.Marshaler   = (*aisMsg)(nil)
+	_ msgp.Unmarshaler = (*aisMsg)(nil)
+	_ msgp.Sizer       = (*aisMsg)(nil)
+)
+
+func (msg *aisMsg) Msgsize() (size int) {
+	size = msgp.MapHeaderSize +
+		msgp.StringPrefixSize + len(amsgAction) + msgp.StringPrefixSize + len(msg.Action) +
+		msgp.StringPrefixSize + len(amsgName) + msgp.StringPrefixSize + len(msg.Name) +
+		msgp.StringPrefixSize + len(amsgUUID) + msgp.StringPrefixSize + len(msg.UUID) +
+		msgp.StringPrefixSize + len(amsgBMDVer) + msgp.Int64Size +
+		msgp.StringPrefixSize + len(amsgRMDVer) + msgp.Int64Size
+	switch v := msg.Value.(type) {
+	case nil:
+	case string:
+		size += msgp.StringPrefixSize + len(amsgValue) + msgp.StringPrefixSize + len(v)
+	case bool:
+		size += msgp.StringPrefixSize + len(amsgValue) + msgp.BoolSize
+	case float64:
+		size += msgp.StringPrefixSize + len(amsgValue) + msgp.Float64Size
+	default:
+		size += msgp.StringPrefixSize + len(amsgValueJSON) + msgp.BytesPrefixSize // (+ JSON)
+	}
+	return size
+}
+
+func (msg *aisMsg) MarshalMsg(b []byte) (o []byte, err error) {
+	var (
+		vjson []byte
+		n     uint32 = 5
+	)
+	switch msg.Value.(type) {
+	case nil:
+	case string, bool, float64:
+		n++
+	default:
+		if vjson, err = jsoniter.Marshal(msg.Value); err != nil {
+			return b, msgp.WrapError(err, amsgValue)
+		}
+		n++
+	}
+	o = msgp.Require(b, msg.Msgsize()+len(vjson))
+	o = msgp.AppendMapHeader(o, n)
+	o = msgp.AppendString(o, amsgAction)
+	o = msgp.AppendString(o, msg.Action)
+	o = msgp.AppendString(o, amsgName)
+	o = msgp.AppendString(o, msg.Name)
+	o = msgp.AppendString(o, amsgUUID)
+	o = msgp.AppendString(o, msg.UUID)
+	o = msgp.AppendString(o, amsgBMDVer)
+	o = msgp.AppendInt64(o, msg.BMDVersion)
+	o = msgp.AppendString(o, amsgRMDVer)
+	o = msgp.AppendInt64(o, msg.RMDVersion)
+
+	switch v := msg.Value.(type) {
+	case nil:
+	case string:
+		o = msgp.AppendString(o, amsgValue)
+		o = msgp.AppendString(o, v)
+	case bool:
+		o = msgp.AppendString(o, amsgValue)
+		o = msgp.AppendBool(o, v)
+	case float64:
+		o = msgp.AppendString(o, amsgValue)
+		o = msgp.AppendFloat64(o, v)
+	default:
+		o = msgp.AppendString(o, amsgValueJSON)
+		o = msgp.AppendBytes(o, vjson)
+	}
+	return o, nil
+}
+
+func (msg *aisMsg) UnmarshalMsg(b []byte) (o []byte, err error) {
+	var (
+		key []byte
+		n   uint32
+	)
+	if n, b, err = msgp.ReadMapHeaderBytes(b); err != nil {
+		return b, err
+	}
+	for ; n > 0; n-- {
+		if key, b, err = msgp.ReadMapKeyZC(b); err != nil {
+			return b, err
+		}
+		switch msgp.UnsafeString(key) {
+		case amsgAction:
+			msg.Action, b, err = msgp.ReadStringBytes(b)
+		case amsgName:
+			msg.Name, b, err = msgp.ReadStringBytes(b)
+		case amsgUUID:
+			msg.UUID, b, err = msgp.ReadStringBytes(b)
+		case amsgBMDVer:
+			msg.BMDVersion, b, err = msgp.ReadInt64Bytes(b)
+		case amsgRMDVer:
+			msg.RMDVersion, b, err = msgp.ReadInt64Bytes(b)
+		case amsgValue:
+			msg.Value, b, err = msgp.ReadIntfBytes(b)
+		case amsgValueJSON:
+			var vjson []byte
+			if vjson, b, err = msgp.ReadBytesBytes(b, nil); err == nil {
+				msg.Value = jsoniter.RawMessage(vjson)
+			}
+		default:
+			b, err = msgp.Skip(b) // (newer version)
+		}
+		if err != nil {
+			return b, msgp.WrapError(err, string(key))
+		}
+	}
+	return b, nil
+}
+
+func (msg *aisMsg) mustMarshalMsg() []byte {
+	b, err := msg.MarshalMsg(nil)
+	cos.AssertNoErr(err)
+	return b
+}
+
+func (msg *aisMsg) readMsgpack(w http.ResponseWriter, r *http.Request) error {
+	b, err := cos.ReadAllN(r.Body, r.ContentLength)
+	cos.Close(r.Body)
+	if err == nil {
+		_, err = msg.UnmarshalMsg(b)
+	}
+	if err != nil {
+		return cmn.WriteErrJSON(w, r, msg, err)
+	}
+	return nil
+}
+
+// marshal `amsg` (JSON and/or msgpack) for the nodes in question - once per encoding
+func (bargs *bcastArgs) marshal(si *meta.Snode) {
+	switch {
+	case si.AcceptsMsgpack():
+		if bargs.mbody == nil {
+			bargs.mbody = bargs.amsg.mustMarshalMsg()
+		}
+	case bargs.req.Body == nil:
+		bargs.req.Body = cos.MustMarshal(bargs.amsg)
+	}
+}
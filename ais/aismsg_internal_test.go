@@ -6,8 +6,12 @@
 package ais
 
 import (
+	"bytes"
 	"fmt"
+	"net/http"
+	"net/http/httptest"
 	"reflect"
+	"strings"
 	"testing"
 
 	"github.com/NVIDIA/aistore/api/apc"
@@ -85,3 +89,114 @@ func TestAisMsgMarshal(t *testing.T) {
 		testAisMsgMarshal(t, test)
 	}
 }
+
+func TestAisMsgMsgpack(t *testing.T) {
+	lsmsg := &apc.LsoMsg{UUID: "lso-uuid", Props: apc.GetPropsNameSize, Prefix: "a/b/", PageSize: 1000, Flags: apc.LsObjCached}
+	values := []any{nil, "test-value", true, float64(12.5), lsmsg}
+	for _, value := range values {
+		t.Run(fmt.Sprintf("%T", value), func(t *testing.T) {
+			beforeMsg := &aisMsg{
+				ActMsg:     apc.ActMsg{Action: apc.ActList, Name: "test-name", Value: value},
+				UUID:       "outer-uuid",
+				BMDVersion: 12,
+				RMDVersion: 34,
+			}
+			b, err := beforeMsg.MarshalMsg(nil)
+			if err != nil {
+				t.Fatalf("Failed to msgpack beforeMsg: %v", err)
+			}
+			afterMsg := &aisMsg{}
+			if rest, err := afterMsg.UnmarshalMsg(b); err != nil || len(rest) != 0 {
+				t.Fatalf("Failed to unmarshal msgpack (rest %d): %v", len(rest), err)
+			}
+			if _, ok := value.(*apc.LsoMsg); ok {
+				after := &apc.LsoMsg{}
+				if err := cos.MorphMarshal(afterMsg.Value, after); err != nil {
+					t.Fatalf("Morph marshal failed for aisMsg.Value: %v, err: %v", afterMsg.Value, err)
+				}
+				afterMsg.Value = after
+			}
+			if !reflect.DeepEqual(*beforeMsg, *afterMsg) {
+				t.Errorf("Marshaled: %v and Unmarshalled: %v differ", beforeMsg, afterMsg)
+			}
+		})
+	}
+}
+
+// list/range delete and evict: the DELETE body is msgpack-encoded for the targets that accept it
+func TestAisMsgReadListRange(t *testing.T) {
+	lrmsg := &apc.ListRange{ObjNames: []string{"a/b/c", "d"}}
+	amsg := &aisMsg{ActMsg: apc.ActMsg{Action: apc.ActDeleteObjects, Value: lrmsg}, UUID: "xid"}
+	for _, ctype := range []string{cos.ContentJSON, cos.ContentMsgPack} {
+		t.Run(ctype, func(t *testing.T) {
+			body := cos.MustMarshal(amsg)
+			if ctype == cos.ContentMsgPack {
+				body = amsg.mustMarshalMsg()
+			}
+			r := httptest.NewRequest(http.MethodDelete, apc.URLPathBuckets.Join("bck"), bytes.NewReader(body))
+			r.Header.Set(cos.HdrContentType, ctype)
+			w := httptest.NewRecorder()
+
+			msg, err := (&htrun{}).readAisMsg(w, r)
+			if err != nil {
+				t.Fatalf("failed to read %s: %v", ctype, err)
+			}
+			after := &apc.ListRange{}
+			if err := cos.MorphMarshal(msg.Value, after); err != nil {
+				t.Fatalf("Morph marshal failed for aisMsg.Value: %v, err: %v", msg.Value, err)
+			}
+			if msg.Action != amsg.Action || msg.UUID != amsg.UUID || !reflect.DeepEqual(lrmsg, after) {
+				t.Errorf("sent %v (%+v), received %v (%+v)", amsg, lrmsg, msg, after)
+			}
+		})
+	}
+}
+
+//
+// benchmarks: marshal (once) and unmarshal a list-objects control message, including
+// the receiver's cos.MorphMarshal into the concrete type
+//
+
+func newBenchAisMsg() *aisMsg {
+	lsmsg := &apc.LsoMsg{
+		UUID:              cos.GenUUID(),
+		Props:             strings.Join(apc.GetPropsDefaultAIS, apc.LsPropsSepa),
+		Prefix:            "subdir/prefix/",
+		ContinuationToken: "subdir/prefix/object-name-0123456789",
+		PageSize:          apc.MaxPageSizeAIS,
+		Flags:             apc.LsObjCached | apc.LsNameSize,
+	}
+	return &aisMsg{ActMsg: apc.ActMsg{Action: apc.ActList, Value: lsmsg}, UUID: cos.GenUUID(), BMDVersion: 1234}
+}
+
+func BenchmarkAisMsgJSON(b *testing.B) {
+	msg := newBenchAisMsg()
+	b.ReportAllocs()
+	b.ResetTimer()
+	for range b.N {
+		body := cos.MustMarshal(msg)
+		rmsg, lsmsg := &aisMsg{}, &apc.LsoMsg{}
+		if err := jsoniter.Unmarshal(body, rmsg); err != nil {
+			b.Fatal(err)
+		}
+		if err := cos.MorphMarshal(rmsg.Value, lsmsg); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkAisMsgMsgpack(b *testing.B) {
+	msg := newBenchAisMsg()
+	b.ReportAllocs()
+	b.ResetTimer()
+	for range b.N {
+		body := msg.mustMarshalMsg()
+		rmsg, lsmsg := &aisMsg{}, &apc.LsoMsg{}
+		if _, err := rmsg.UnmarshalMsg(body); err != nil {
+			b.Fatal(err)
+		}
+		if err := cos.MorphMarshal(rmsg.Value, lsmsg); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
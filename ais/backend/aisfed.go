@@ -0,0 +1,133 @@
+// Package backend contains core/backend interface implementations for supported backend providers.
+/*
+ * Copyright (c) 2024, NVIDIA CORPORATION. All rights reserved.
+ */
+package backend
+
+import (
+	"errors"
+	"sync"
+
+	"github.com/NVIDIA/aistore/api"
+	"github.com/NVIDIA/aistore/api/apc"
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/cmn/mono"
+	"github.com/NVIDIA/aistore/core/meta"
+	"github.com/NVIDIA/aistore/stats"
+)
+
+// federation view: query all attached remote AIS clusters in parallel
+// and summarize their respective health, buckets, capacity, and stats
+
+// cumulative (target) counters to aggregate
+var fedStats = [...]string{
+	stats.GetCount, stats.PutCount, stats.DeleteCount,
+	stats.GetSize, stats.PutSize,
+	stats.ErrGetCount, stats.ErrPutCount,
+}
+
+func (m *AISbp) Federation(clusterConf cmn.BackendConfAIS) *meta.Federation {
+	var (
+		fed = &meta.Federation{}
+		wg  sync.WaitGroup
+	)
+	m.mu.RLock()
+	fed.Clusters = make([]*meta.FedCluster, 0, len(m.remote)+len(clusterConf))
+	for uuid, rais := range m.remote {
+		fc := &meta.FedCluster{UUID: uuid, URL: rais.url}
+		for a, u := range m.alias {
+			if uuid == u {
+				fc.Alias = a
+				break
+			}
+		}
+		var cachedVer int64
+		if rais.smap != nil {
+			cachedVer = rais.smap.Version
+		}
+		fed.Clusters = append(fed.Clusters, fc)
+		wg.Add(1)
+		go func(bp api.BaseParams, fc *meta.FedCluster, cachedVer int64) {
+			fedQuery(bp, fc, cachedVer)
+			wg.Done()
+		}(rais.bp, fc, cachedVer)
+	}
+	// defunct (configured but not attached)
+	for alias := range clusterConf {
+		if _, ok := m.alias[alias]; ok {
+			continue
+		}
+		if _, ok := m.remote[alias]; ok {
+			continue
+		}
+		fc := &meta.FedCluster{Alias: alias, UUID: remAisDefunct, Health: meta.FedOffline, Err: "not attached"}
+		fed.Clusters = append(fed.Clusters, fc)
+	}
+	m.mu.RUnlock()
+
+	wg.Wait()
+	fed.Sum()
+	return fed
+}
+
+func fedQuery(bp api.BaseParams, fc *meta.FedCluster, cachedVer int64) {
+	started := mono.NanoTime()
+	smap, err := api.GetClusterMap(bp)
+	fc.Latency = mono.SinceNano(started)
+	if err != nil {
+		fc.Health, fc.Err = meta.FedOffline, err.Error()
+		return
+	}
+	fc.Health = meta.FedOnline
+	fc.SmapVer = smap.Version
+	fc.SmapLag = max(smap.Version-cachedVer, 0)
+	if smap.Primary != nil {
+		fc.Primary = smap.Primary.ID()
+	}
+	fc.Proxies, fc.Targets, fc.ActiveTargets = smap.CountProxies(), smap.CountTargets(), smap.CountActiveTs()
+	if fc.ActiveTargets < fc.Targets {
+		fedDegraded(fc, errors.New("not all targets are active"))
+	}
+
+	// buckets (ditto blist)
+	bcks, err := api.ListBuckets(bp, cmn.QueryBcks{Provider: apc.AIS}, apc.FltPresent)
+	if err != nil {
+		fedDegraded(fc, err)
+	}
+	for i := range bcks {
+		bcks[i].Ns.UUID = fc.UUID
+	}
+	fc.Buckets = bcks
+
+	// capacity and stats
+	cs, err := api.GetClusterStats(bp)
+	if err != nil {
+		fedDegraded(fc, err)
+		return
+	}
+	fc.Stats = make(map[string]int64, len(fedStats))
+	for _, ds := range cs.Target {
+		fc.Used += ds.Tcdf.TotalUsed
+		fc.Avail += ds.Tcdf.TotalAvail
+		fc.PctMax = max(fc.PctMax, ds.Tcdf.PctMax)
+		if ds.Tcdf.CsErr != "" {
+			fedDegraded(fc, errors.New(ds.Tcdf.CsErr))
+		}
+		for _, name := range fedStats {
+			if v, ok := ds.Tracker[name]; ok {
+				fc.Stats[name] += v.Value
+			}
+		}
+	}
+	if len(cs.Target) < fc.ActiveTargets {
+		fedDegraded(fc, errors.New("partial stats: not all targets responded"))
+	}
+}
+
+// (keeping the first error)
+func fedDegraded(fc *meta.FedCluster, err error) {
+	fc.Health = meta.FedDegraded
+	if fc.Err == "" {
+		fc.Err = err.Error()
+	}
+}
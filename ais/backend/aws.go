@@ -60,8 +60,11 @@ var (
 	awsProfile string
 )
 
-// interface guard
-var _ core.Backend = (*s3bp)(nil)
+// interface guards
+var (
+	_ core.Backend      = (*s3bp)(nil)
+	_ core.BatchDeleter = (*s3bp)(nil)
+)
 
 // environment variables => static defaults that can still be overridden via bck.Props.Extra.AWS
 // in addition to these two (below), default bucket region = env.AwsDefaultRegion()
@@ -734,6 +737,70 @@ func (*s3bp) DeleteObj(lom *core.LOM) (ecode int, err error) {
 	return
 }
 
+// batched: up to core.MaxDeleteBatch keys per DeleteObjects call ("quiet" mode - errors only)
+func (*s3bp) DeleteObjs(bck *meta.Bck, objNames []string, ecodes []int, errs []error) (ecode int, err error) {
+	const tag = "[delete_objects]"
+	var (
+		svc      *s3.Client
+		cloudBck = bck.RemoteBck()
+		sessConf = sessConf{bck: cloudBck}
+	)
+	debug.Assert(len(objNames) <= core.MaxDeleteBatch && len(ecodes) == len(objNames) && len(errs) == len(objNames))
+	svc, err = sessConf.s3client(tag)
+	if err != nil {
+		return
+	}
+	objs := make([]types.ObjectIdentifier, len(objNames))
+	for i := range objNames {
+		objs[i].Key = aws.String(objNames[i])
+	}
+	out, err := svc.DeleteObjects(context.Background(), &s3.DeleteObjectsInput{
+		Bucket: aws.String(cloudBck.Name),
+		Delete: &types.Delete{Objects: objs, Quiet: aws.Bool(true)},
+	})
+	if err != nil {
+		ecode, err = awsErrorToAISError(err, cloudBck, "")
+		return
+	}
+	if len(out.Errors) > 0 {
+		idx := make(map[string]int, len(objNames))
+		for i, name := range objNames {
+			idx[name] = i
+		}
+		for _, e := range out.Errors {
+			i, ok := idx[aws.ToString(e.Key)]
+			if !ok {
+				continue
+			}
+			ecodes[i], errs[i] = _awsDelErr(&e, cloudBck)
+		}
+	}
+	if cmn.Rom.FastV(5, cos.SmoduleBackend) {
+		nlog.Infoln(tag, cloudBck.Cname(""), len(objNames), "errors:", len(out.Errors))
+	}
+	return 0, nil
+}
+
+// Ref: https://docs.aws.amazon.com/AmazonS3/latest/API/ErrorResponses.html#ErrorCodeList
+func _awsDelErr(e *types.Error, bck *cmn.Bck) (int, error) {
+	var (
+		code = aws.ToString(e.Code)
+		err  = fmt.Errorf("%s[%s: %s: %s]", aiss3.ErrPrefix, code, bck.Cname(aws.ToString(e.Key)), aws.ToString(e.Message))
+	)
+	switch code {
+	case "NoSuchKey":
+		return http.StatusNotFound, err
+	case "AccessDenied":
+		return http.StatusForbidden, err
+	case "SlowDown", "ServiceUnavailable":
+		return http.StatusServiceUnavailable, err
+	case "InternalError":
+		return http.StatusInternalServerError, err
+	default:
+		return http.StatusBadRequest, err
+	}
+}
+
 //
 // static helpers
 //
@@ -14,6 +14,7 @@ import (
 	"net/http"
 	"os"
 	"strings"
+	"sync"
 
 	"cloud.google.com/go/storage"
 	"github.com/NVIDIA/aistore/api/apc"
@@ -58,8 +59,9 @@ var (
 	// context placeholder
 	gctx context.Context
 
-	// interface guard
-	_ core.Backend = (*gsbp)(nil)
+	// interface guards
+	_ core.Backend      = (*gsbp)(nil)
+	_ core.BatchDeleter = (*gsbp)(nil)
 )
 
 func NewGCP(t core.TargetPut, tstats stats.Tracker) (_ core.Backend, err error) {
@@ -453,6 +455,39 @@ func (*gsbp) DeleteObj(lom *core.LOM) (ecode int, err error) {
 	return
 }
 
+// GCS has no multi-object delete - deleting the batch with bounded parallelism
+const gcpDeleteWorkers = 16
+
+func (*gsbp) DeleteObjs(bck *meta.Bck, objNames []string, ecodes []int, errs []error) (int, error) {
+	var (
+		wg       sync.WaitGroup
+		cloudBck = bck.RemoteBck()
+		gbck     = gcpClient.Bucket(cloudBck.Name)
+		workCh   = make(chan int, len(objNames))
+	)
+	debug.Assert(len(ecodes) == len(objNames) && len(errs) == len(objNames))
+	for i := range objNames {
+		workCh <- i
+	}
+	close(workCh)
+	for range min(gcpDeleteWorkers, len(objNames)) {
+		wg.Add(1)
+		go func() {
+			for i := range workCh {
+				if err := gbck.Object(objNames[i]).Delete(gctx); err != nil {
+					ecodes[i], errs[i] = gcpErrorToAISError(err, cloudBck)
+				}
+			}
+			wg.Done()
+		}()
+	}
+	wg.Wait()
+	if cmn.Rom.FastV(5, cos.SmoduleBackend) {
+		nlog.Infoln("[delete_objects]", cloudBck.Cname(""), len(objNames))
+	}
+	return 0, nil
+}
+
 //
 // static helpers
 //
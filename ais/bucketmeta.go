@@ -194,6 +194,13 @@ func (m *bucketMD) clone() *bucketMD {
 		}
 		dst.Providers[provider] = dstNamespaces
 	}
+	if len(m.Aliases) > 0 {
+		dst.Aliases = make(meta.BckAliases, len(m.Aliases))
+		for name, alias := range m.Aliases {
+			dstAlias := *alias
+			dst.Aliases[name] = &dstAlias
+		}
+	}
 
 	dst.vstr = m.vstr
 	dst._sgl = nil
@@ -116,6 +116,52 @@ var _ = Describe("BMD marshal and unmarshal", func() {
 					}
 				}
 			})
+
+			It("should clone, save, and load bucket aliases for "+node, func() {
+				bowner.init()
+				clone := bowner.get().clone()
+				clone.Aliases = meta.BckAliases{
+					"current": {Bck: cmn.Bck{Name: "bucket_1", Provider: apc.AWS}, ReadOnly: true},
+				}
+				clone2 := clone.clone()
+				clone2.Aliases["current"].Bck.Name = "bucket_2"
+				Expect(clone.Aliases["current"].Bck.Name).To(Equal("bucket_1"))
+
+				err := jsp.Save(testpath, clone, jsp.Plain(), nil)
+				Expect(err).NotTo(HaveOccurred())
+				loaded := newBucketMD()
+				_, err = jsp.Load(testpath, loaded, jsp.Plain())
+				Expect(err).NotTo(HaveOccurred())
+
+				alias, ok := loaded.GetAlias(&cmn.Bck{Name: "current", Provider: apc.AIS})
+				Expect(ok).To(BeTrue())
+				Expect(*alias).To(Equal(*clone.Aliases["current"]))
+				_, ok = loaded.GetAlias(&cmn.Bck{Name: "current", Provider: apc.AWS})
+				Expect(ok).To(BeFalse())
+			})
+
+			It("should resolve bucket aliases by name only (S3 API) for "+node, func() {
+				bowner.init()
+				clone := bowner.get().clone()
+				clone.Aliases = meta.BckAliases{
+					"current":  {Bck: cmn.Bck{Name: "bucket_1", Provider: apc.AWS, Ns: cmn.NsGlobal}},
+					"dangling": {Bck: cmn.Bck{Name: "nonexistent", Provider: apc.AIS, Ns: cmn.NsGlobal}},
+				}
+				Expect(bowner.putPersist(clone, nil)).NotTo(HaveOccurred())
+
+				bck, err, _ := meta.InitByNameOnly("current", bowner)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(bck.Bucket().Equal(&clone.Aliases["current"].Bck)).To(BeTrue())
+				Expect(bck.Props).NotTo(BeNil())
+
+				_, err, ecode := meta.InitByNameOnly("dangling", bowner)
+				Expect(err).To(HaveOccurred())
+				Expect(ecode).To(Equal(http.StatusNotFound))
+
+				// (bucket_1 is both ais:// and aws://)
+				_, err, _ = meta.InitByNameOnly("bucket_1", bowner)
+				Expect(err).To(HaveOccurred())
+			})
 		})
 	}
 })
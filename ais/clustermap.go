@@ -50,8 +50,9 @@ const clusterMap = "Smap"
 
 type (
 	smapX struct {
-		_sgl *memsys.SGL // jsp-formatted
-		vstr string      // itoa(Version)
+		_sgl    *memsys.SGL // jsp-formatted
+		vstr    string      // itoa(Version)
+		_digest uint64      // meta.Smap.Digest (computed once or received along with meta.SmapDelta)
 		meta.Smap
 	}
 	smapOwner struct {
@@ -147,38 +148,69 @@ func (m *smapX) init(tsize, psize int) {
 // begin IC -----------
 //
 
-// executed only by primary
-func (m *smapX) staffIC() (count int) {
+// IC size: configured or auto-scaled with the number of active proxies
+func (m *smapX) wantIC(config *cmn.Config) int {
+	if n := config.Proxy.ICCount; n > 0 {
+		return n
+	}
+	return meta.AutoCountIC(m.CountActivePs())
+}
+
+// whether staffIC (below) would change anything
+func (m *smapX) needStaffIC(config *cmn.Config) bool {
+	count, want := m.ICCount(), m.wantIC(config)
+	switch {
+	case count > want:
+		return true
+	case count == want:
+		return false
+	}
+	// any candidates?
+	for _, psi := range m.Pmap {
+		if !psi.IsIC() && !psi.Flags.IsSet(meta.SnodeNonElectable) && !psi.InMaintOrDecomm() {
+			return true
+		}
+	}
+	return false
+}
+
+// executed only by primary:
+// - add (or remove) IC members to match the wanted IC size (above);
+// - when adding, prefer lower-loaded proxies; when removing, the most loaded one goes first
+func (m *smapX) staffIC(loads *icLoads) (count int) {
 	_ = m._setIC(m.Primary)
 	m.Primary = m.GetNode(m.Primary.ID())
 
+	want := m.wantIC(cmn.GCO.Get())
 	count = m.ICCount()
-	if count < meta.DfltCountIC {
+	if count < want {
 		// assign additional IC members, if available
-		for _, psi := range m.Pmap {
-			if psi.Flags.IsSet(meta.SnodeNonElectable) || !m._setIC(psi) {
+		for _, psi := range loads.sorted(m, false /*IC members*/) {
+			if !m._setIC(psi) {
 				continue
 			}
 			count++
-			if count >= meta.DfltCountIC {
-				return count
+			if count >= want {
+				break
 			}
 		}
-	} else if count > meta.DfltCountIC {
-		if m.unstaffIC() {
+	} else if count > want {
+		for count > want && m.unstaffIC(loads) {
 			count--
 		}
 	}
 	return count
 }
 
-// remove one
-func (m *smapX) unstaffIC() bool {
-	for pid, psi := range m.Pmap {
-		if pid == m.Primary.ID() || !m.IsIC(psi) {
+// remove one (the most loaded)
+func (m *smapX) unstaffIC(loads *icLoads) bool {
+	members := loads.sorted(m, true /*IC members*/)
+	for i := len(members) - 1; i >= 0; i-- {
+		psi := members[i]
+		if psi.ID() == m.Primary.ID() {
 			continue
 		}
-		m.clearNodeFlags(pid, meta.SnodeIC)
+		m.clearNodeFlags(psi.ID(), meta.SnodeIC)
 		return true
 	}
 	return false
@@ -357,9 +389,22 @@ func (m *smapX) clone() *smapX {
 	}
 	dst.Primary = dst.GetProxy(m.Primary.ID())
 	dst._sgl = nil
+	dst._digest = 0
 	return dst
 }
 
+// (see meta.SmapDelta)
+func (m *smapX) digest() uint64 {
+	if d := ratomic.LoadUint64(&m._digest); d != 0 {
+		return d
+	}
+	d := m.Digest()
+	ratomic.StoreUint64(&m._digest, d)
+	return d
+}
+
+func (m *smapX) setDigest(d uint64) { ratomic.StoreUint64(&m._digest, d) }
+
 func (m *smapX) merge(dst *smapX, override bool) (added int, err error) {
 	for id, si := range m.Tmap {
 		err = dst.handleDuplicateNode(si, override)
@@ -190,6 +190,7 @@ func initDaemon(version, buildTime string) cos.Runner {
 	daemon.version, daemon.buildTime = version, buildTime
 	loghdr := _loghdr()
 	sys.GoEnvMaxprocs()
+	initAffinity(&config.NUMA)
 
 	daemon.rg = &rungroup{rs: make(map[string]cos.Runner, 6)}
 	hk.Init()
@@ -245,6 +246,13 @@ func initDaemon(version, buildTime string) cos.Runner {
 		return p
 	}
 
+	// checksumming: pipelined vs inline
+	hp, err := cos.InitHashPipe(os.Getenv(env.AIS.HashPipeline))
+	if err != nil {
+		cos.ExitLog(err)
+	}
+	nlog.Infoln("hashing pipeline:", hp)
+
 	// reg xaction factories
 	xs.Xreg(false /* x-ele only */)
 	space.Xreg()
@@ -263,6 +271,66 @@ func initDaemon(version, buildTime string) cos.Runner {
 	return t
 }
 
+// restrict this node to configured CPUs (NUMA node(s)) - see cmn.NumaConf
+func initAffinity(conf *cmn.NumaConf) {
+	if err := conf.Validate(); err != nil {
+		cos.ExitLog(err)
+	}
+	cpus, err := conf.Affinity()
+	if err != nil {
+		cos.ExitLogf("failed to resolve numa config: %v", err)
+	}
+	if len(cpus) == 0 {
+		return
+	}
+	if err := sys.SetAffinity(cpus); err != nil {
+		cos.ExitLogf("failed to set CPU affinity %s: %v", sys.FormatCPUList(cpus), err)
+	}
+	nlog.Infoln("CPU affinity:", sys.FormatCPUList(cpus))
+	for _, nic := range conf.NICs() {
+		steerIRQs("network interface "+nic, sys.NicIRQs(nic), cpus)
+	}
+	if _, exists := os.LookupEnv("GOMAXPROCS"); exists {
+		return
+	}
+	if maxprocs := runtime.GOMAXPROCS(0); maxprocs > len(cpus) {
+		nlog.Warningf("Reducing GOMAXPROCS (prev = %d) to %d", maxprocs, len(cpus))
+		runtime.GOMAXPROCS(len(cpus))
+	}
+}
+
+// best effort: not being able to steer IRQs (e.g., insufficient privileges) is not fatal
+func steerIRQs(what string, irqs, cpus []int) {
+	if len(irqs) == 0 {
+		nlog.Warningln("IRQ affinity:", what, "- no IRQs found")
+		return
+	}
+	n, err := sys.SetIRQAffinity(irqs, cpus)
+	if err != nil {
+		nlog.Warningf("IRQ affinity: %s - steered %d out of %d IRQs to CPUs %s: %v", what, n, len(irqs), sys.FormatCPUList(cpus), err)
+		return
+	}
+	nlog.Infof("IRQ affinity: %s - steered %d IRQs to CPUs %s", what, n, sys.FormatCPUList(cpus))
+}
+
+// target: steer IRQs of the mountpath disks to the CPUs of their respective (disk-local) NUMA nodes
+func steerDiskIRQs() {
+	for _, mi := range fs.GetAvail() {
+		if mi.NumaNode < 0 {
+			nlog.Warningln("IRQ affinity:", mi.String(), "- NUMA node unknown or mixed")
+			continue
+		}
+		cpus, err := sys.NodeCPUs(mi.NumaNode)
+		if err != nil {
+			nlog.Warningln("IRQ affinity:", mi.String(), err)
+			continue
+		}
+		for _, disk := range mi.Disks {
+			steerIRQs("disk "+disk, sys.DiskIRQs(disk), cpus)
+		}
+	}
+}
+
 func _loghdr2(si *meta.Snode, loghdr string) string {
 	var sb strings.Builder
 	sb.WriteString("Node ")
@@ -0,0 +1,133 @@
+// Package ais provides core functionality for the AIStore object storage.
+/*
+ * Copyright (c) 2024, NVIDIA CORPORATION. All rights reserved.
+ */
+package ais
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/NVIDIA/aistore/api/apc"
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/cmn/cos"
+	"github.com/NVIDIA/aistore/cmn/mono"
+	"github.com/NVIDIA/aistore/stats"
+)
+
+// Per-request deadline (GET and PUT):
+// - client specifies apc.HdrDeadline: absolute (Unix nanoseconds) or relative (e.g., "5s");
+// - proxy fails already expired requests; otherwise, converts relative deadline to absolute
+//   and propagates it via redirect URL (apc.QparamDeadline);
+// - target propagates it further on intra-cluster hops (e.g., get-from-neighbor), cancels
+//   remote backend (via context) and disk (via reader) work upon expiration, and
+//   fails the request with 504 and timing breakdown (cmn.ErrDeadline).
+
+type dlReader struct {
+	r        io.ReadCloser
+	deadline int64
+}
+
+// interface guard
+var _ io.ReadCloser = (*dlReader)(nil)
+
+func parseDeadline(s string, now int64) (int64, error) {
+	if d, err := time.ParseDuration(s); err == nil {
+		if d <= 0 {
+			return 0, fmt.Errorf("invalid %s %q: expecting positive duration", apc.HdrDeadline, s)
+		}
+		return now + int64(d), nil
+	}
+	deadline, err := cos.S2UnixNano(s)
+	if err != nil || deadline <= 0 {
+		return 0, fmt.Errorf("invalid %s %q: expecting Unix time (nanoseconds) or duration", apc.HdrDeadline, s)
+	}
+	return deadline, nil
+}
+
+// intra-cluster (apc.QparamDeadline) takes precedence over client-supplied (apc.HdrDeadline)
+func reqDeadline(r *http.Request, dpq *dpq, now int64) (int64, error) {
+	if dpq.deadline != "" {
+		return parseDeadline(dpq.deadline, now)
+	}
+	if s := r.Header.Get(apc.HdrDeadline); s != "" {
+		return parseDeadline(s, now)
+	}
+	return 0, nil
+}
+
+// validate, fail if expired, and set absolute deadline to be propagated via redirect URL
+// (see redirectURL)
+func (p *proxy) checkDeadline(w http.ResponseWriter, r *http.Request, started time.Time, op, cname string) bool {
+	s := r.Header.Get(apc.HdrDeadline)
+	if s == "" {
+		return true
+	}
+	now := started.UnixNano()
+	deadline, err := parseDeadline(s, now)
+	if err != nil {
+		p.writeErr(w, r, err)
+		return false
+	}
+	if now >= deadline {
+		p.statsT.IncErr(stats.ErrDeadlineCount)
+		err := cmn.NewErrDeadline(op+" "+cname, "expired upon arrival", time.Duration(now-deadline))
+		p.writeErr(w, r, err, http.StatusGatewayTimeout, Silent)
+		return false
+	}
+	r.Header.Set(apc.HdrDeadline, cos.UnixNano2S(deadline))
+	return true
+}
+
+// timing breakdown: [redirect (proxy => target),] local, [backend]
+func dlTiming(ptime string, atime, ltime, rstarttime int64) string {
+	var (
+		sb    strings.Builder
+		round = func(d int64) string { return time.Duration(d).Round(time.Microsecond).String() }
+	)
+	if ptime != "" {
+		if pts, err := cos.S2UnixNano(ptime); err == nil && atime > pts {
+			sb.WriteString("redirect ")
+			sb.WriteString(round(atime - pts))
+		}
+	}
+	if ltime == 0 { // not started
+		return sb.String()
+	}
+	if sb.Len() > 0 {
+		sb.WriteString(", ")
+	}
+	sb.WriteString("local ")
+	if rstarttime == 0 {
+		sb.WriteString(round(mono.SinceNano(ltime)))
+		return sb.String()
+	}
+	sb.WriteString(round(rstarttime - ltime))
+	sb.WriteString(", backend ")
+	sb.WriteString(round(mono.SinceNano(rstarttime)))
+	return sb.String()
+}
+
+func expired(deadline int64) (time.Duration, bool) {
+	if deadline == 0 {
+		return 0, false
+	}
+	over := time.Now().UnixNano() - deadline
+	return time.Duration(over), over >= 0
+}
+
+//
+// dlReader: to abort receiving (and writing) upon expiration
+//
+
+func (r *dlReader) Read(b []byte) (int, error) {
+	if _, ok := expired(r.deadline); ok {
+		return 0, cmn.NewErrDeadline("read", "", 0)
+	}
+	return r.r.Read(b)
+}
+
+func (r *dlReader) Close() error { return r.r.Close() }
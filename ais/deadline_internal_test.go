@@ -0,0 +1,120 @@
+// Package ais provides core functionality for the AIStore object storage.
+/*
+ * Copyright (c) 2024, NVIDIA CORPORATION. All rights reserved.
+ */
+package ais
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"time"
+
+	"github.com/NVIDIA/aistore/api/apc"
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/cmn/cos"
+	"github.com/NVIDIA/aistore/cmn/mono"
+	"github.com/NVIDIA/aistore/core"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+// reads one byte at a time, slowly
+type slowReader struct {
+	n     int
+	delay time.Duration
+}
+
+func (r *slowReader) Read(b []byte) (int, error) {
+	if r.n == 0 {
+		return 0, errors.New("unexpected EOF")
+	}
+	time.Sleep(r.delay)
+	b[0] = 'x'
+	r.n--
+	return 1, nil
+}
+
+var _ = Describe("Request deadline", func() {
+	newPOI := func(objName string, deadline int64) *putOI {
+		lom := core.AllocLOM(objName)
+		Expect(lom.InitBck(&cmn.Bck{Name: testBucket, Provider: apc.AIS, Ns: cmn.NsGlobal})).NotTo(HaveOccurred())
+		return &putOI{
+			atime:    time.Now().UnixNano(),
+			t:        t,
+			lom:      lom,
+			config:   cmn.GCO.Get(),
+			deadline: deadline,
+		}
+	}
+
+	It("should parse absolute and relative deadlines", func() {
+		now := time.Now().UnixNano()
+		deadline, err := parseDeadline("5s", now)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(deadline).To(Equal(now + int64(5*time.Second)))
+
+		deadline, err = parseDeadline(cos.UnixNano2S(now+1), now)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(deadline).To(Equal(now + 1))
+
+		for _, s := range []string{"0", "-1s", "0s", "tomorrow", "-12345"} {
+			_, err = parseDeadline(s, now)
+			Expect(err).To(HaveOccurred(), s)
+		}
+	})
+
+	It("should prefer propagated (intra-cluster) deadline", func() {
+		r := httptest.NewRequest(http.MethodGet, "/", http.NoBody)
+		r.Header.Set(apc.HdrDeadline, "1s")
+		deadline, err := reqDeadline(r, &dpq{deadline: "12345"}, 0)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(deadline).To(BeEquivalentTo(12345))
+		deadline, err = reqDeadline(r, &dpq{}, 0)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(deadline).To(BeEquivalentTo(time.Second))
+	})
+
+	It("should report timing breakdown", func() {
+		var (
+			atime = time.Now().UnixNano()
+			ptime = cos.UnixNano2S(atime - int64(time.Millisecond))
+			ltime = mono.NanoTime() - int64(3*time.Second)
+		)
+		timing := dlTiming(ptime, atime, ltime, ltime+int64(time.Second))
+		Expect(timing).To(HavePrefix("redirect 1ms, local 1s, backend 2"))
+		Expect(dlTiming("", atime, 0, 0)).To(BeEmpty())
+
+		err := cmn.NewErrDeadline("GET ais://abc/obj", timing, time.Second)
+		Expect(cmn.IsErrDeadline(err)).To(BeTrue())
+		Expect(errors.Is(err, context.DeadlineExceeded)).To(BeTrue())
+		Expect(err.Error()).To(ContainSubstring("deadline exceeded by 1s (redirect 1ms"))
+		herr := cmn.NewErrHTTP(nil, err, http.StatusGatewayTimeout)
+		Expect(herr.ErrKind()).To(Equal(cmn.ErrKindTimeout))
+	})
+
+	It("should fail expired PUT upon arrival", func() {
+		poi := newPOI("deadline-expired", time.Now().UnixNano()-1)
+		defer core.FreeLOM(poi.lom)
+		r := httptest.NewRequest(http.MethodPut, "/", strings.NewReader("payload"))
+		ecode, err := poi.do(make(http.Header), r, &dpq{})
+		Expect(ecode).To(Equal(http.StatusGatewayTimeout))
+		Expect(cmn.IsErrDeadline(err)).To(BeTrue())
+		Expect(poi.lom.Load(false, false)).To(HaveOccurred())
+	})
+
+	It("should abort PUT upon expiration", func() {
+		poi := newPOI("deadline-slow", time.Now().Add(100*time.Millisecond).UnixNano())
+		defer core.FreeLOM(poi.lom)
+		r := httptest.NewRequest(http.MethodPut, "/", &slowReader{n: 100, delay: 10 * time.Millisecond})
+		started := time.Now()
+		ecode, err := poi.do(make(http.Header), r, &dpq{})
+		Expect(time.Since(started)).To(BeNumerically("<", 500*time.Millisecond))
+		Expect(ecode).To(Equal(http.StatusGatewayTimeout))
+		Expect(cmn.IsErrDeadline(err)).To(BeTrue())
+		Expect(err.Error()).To(ContainSubstring("local "))
+		Expect(poi.lom.Load(false, false)).To(HaveOccurred())
+	})
+})
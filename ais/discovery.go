@@ -0,0 +1,53 @@
+// Package ais provides core functionality for the AIStore object storage.
+/*
+ * Copyright (c) 2024, NVIDIA CORPORATION. All rights reserved.
+ */
+package ais
+
+import (
+	"context"
+	"net"
+	"time"
+
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/cmn/nlog"
+)
+
+// DNS-based discovery of (candidate) proxies to join the cluster via - see `join` and config.Proxy.DiscoveryDNS:
+// - SRV record, e.g. "_ais._tcp.cluster.local" (the form "_service._proto.name"):
+//   resolves to proxy hostnames and ports;
+// - otherwise, a hostname (with an optional port), e.g. "ais-proxy.ais.svc.cluster.local:51080"
+//   (e.g., K8s headless service) resolves to all the corresponding IPs;
+//   port defaults to the node's own public port.
+// Resolved (via cmn.DNSResolver) every time the node (re)joins, which also takes care of proxies changing their IPs.
+
+const dnsTimeout = 5 * time.Second
+
+// (can be replaced in tests)
+var (
+	lookupSRV  = net.DefaultResolver.LookupSRV
+	lookupHost = net.DefaultResolver.LookupHost
+)
+
+func dnsCandidates(config *cmn.Config) []string {
+	name := config.Proxy.DiscoveryDNS
+	if name == "" {
+		return nil
+	}
+	urls, err := resolveDiscovery(name, config.HostNet.Port, config.Net.HTTP.UseHTTPS)
+	if err != nil {
+		nlog.Warningln("failed to resolve", name+":", err)
+		return nil
+	}
+	if len(urls) == 0 {
+		nlog.Warningln("no proxies discovered via", name)
+	}
+	return urls
+}
+
+func resolveDiscovery(name string, defPort int, useHTTPS bool) ([]string, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), dnsTimeout)
+	defer cancel()
+	r := &cmn.DNSResolver{LookupSRV: lookupSRV, LookupHost: lookupHost, Name: name, Port: defPort, HTTPS: useHTTPS}
+	return r.Resolve(ctx)
+}
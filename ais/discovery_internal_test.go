@@ -0,0 +1,59 @@
+// Package ais provides core functionality for the AIStore object storage.
+/*
+ * Copyright (c) 2024, NVIDIA CORPORATION. All rights reserved.
+ */
+package ais
+
+import (
+	"context"
+	"net"
+	"reflect"
+	"testing"
+)
+
+func TestResolveDiscovery(t *testing.T) {
+	oldSRV, oldHost := lookupSRV, lookupHost
+	defer func() {
+		lookupSRV, lookupHost = oldSRV, oldHost
+	}()
+	lookupSRV = func(_ context.Context, _, _, name string) (string, []*net.SRV, error) {
+		if name != "_ais._tcp.cluster.local" {
+			return "", nil, &net.DNSError{Err: "no such host", Name: name, IsNotFound: true}
+		}
+		return "", []*net.SRV{
+			{Target: "p1.ais.cluster.local.", Port: 51080},
+			{Target: "p2.ais.cluster.local.", Port: 51081},
+		}, nil
+	}
+	lookupHost = func(_ context.Context, host string) ([]string, error) {
+		if host != "ais-proxy" {
+			return nil, &net.DNSError{Err: "no such host", Name: host, IsNotFound: true}
+		}
+		return []string{"10.0.0.1", "fd00::1"}, nil
+	}
+
+	tests := []struct {
+		name     string
+		port     int
+		https    bool
+		expected []string
+	}{
+		{"_ais._tcp.cluster.local", 8080, false, []string{"http://p1.ais.cluster.local:51080", "http://p2.ais.cluster.local:51081"}},
+		{"_ais._tcp.cluster.local", 8080, true, []string{"https://p1.ais.cluster.local:51080", "https://p2.ais.cluster.local:51081"}},
+		{"ais-proxy", 8080, false, []string{"http://10.0.0.1:8080", "http://[fd00::1]:8080"}},
+		{"ais-proxy:9090", 8080, false, []string{"http://10.0.0.1:9090", "http://[fd00::1]:9090"}},
+	}
+	for _, test := range tests {
+		urls, err := resolveDiscovery(test.name, test.port, test.https)
+		if err != nil {
+			t.Fatalf("%s: %v", test.name, err)
+		}
+		if !reflect.DeepEqual(urls, test.expected) {
+			t.Errorf("%s: expected %v, got %v", test.name, test.expected, urls)
+		}
+	}
+
+	if _, err := resolveDiscovery("_unknown._tcp.cluster.local", 8080, false); err == nil {
+		t.Error("expecting error resolving unknown SRV")
+	}
+}
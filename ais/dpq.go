@@ -38,6 +38,7 @@ type dpq struct {
 	fltPresence string // QparamFltPresence
 	etlName     string // QparamETLName
 	binfo       string // bucket info, with or without requirement to summarize remote obj-s
+	deadline    string // QparamDeadline (propagated apc.HdrDeadline)
 
 	skipVC        bool // QparamSkipVC (skip loading existing object's metadata)
 	isGFN         bool // QparamIsGFNRequest
@@ -117,6 +118,8 @@ func (dpq *dpq) parse(rawQuery string) (err error) {
 			dpq.skipVC = cos.IsParseBool(value)
 		case apc.QparamUnixTime:
 			dpq.ptime = value
+		case apc.QparamDeadline:
+			dpq.deadline = value
 		case apc.QparamUUID:
 			dpq.uuid = value
 		case apc.QparamArchpath, apc.QparamArchmime, apc.QparamArchregx, apc.QparamArchmode:
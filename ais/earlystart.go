@@ -291,9 +291,9 @@ func (p *proxy) primaryStartup(loadedSmap *smapX, config *cmn.Config, ntargets i
 	}
 
 	// 5.5: try to start with a fully staffed IC
-	if count := smap.ICCount(); count < meta.DfltCountIC {
+	if count := smap.ICCount(); count != smap.wantIC(config) {
 		clone := smap.clone()
-		nc := clone.staffIC()
+		nc := clone.staffIC(&p.ic.loads)
 		if count != nc {
 			clone.Version++
 			smap = clone
@@ -344,6 +344,7 @@ func (p *proxy) primaryStartup(loadedSmap *smapX, config *cmn.Config, ntargets i
 	p.markClusterStarted()
 	nlog.Infoln(p.String(), "primary: cluster started up")
 	nlog.Infoln(smap.StringEx()+",", bmd.StringEx())
+	go p.initProbeBck()
 
 	if etlMD.Version > 0 {
 		_ = p.metasyncer.sync(revsPair{etlMD, aisMsg})
@@ -66,7 +66,7 @@ func (g *fsprungroup) _postAdd(action string, mi *fs.Mountpath) {
 		if cmn.GCO.Get().Resilver.Enabled {
 			g.t.runResilver(res.Args{}, nil /*wg*/)
 		}
-		xreg.RenewMakeNCopies(cos.GenUUID(), action)
+		xreg.RenewMakeNCopies(cos.GenUUID(), action, false /*all*/)
 	}()
 
 	g.checkEnable(action, mi)
@@ -122,6 +122,47 @@ func (g *fsprungroup) rescanMpath(mpath string, dontResilver bool) error {
 	return warn
 }
 
+//
+// replace (old disk => new disk; see xs.XactMpathReplace)
+//
+
+func (g *fsprungroup) replaceMpath(msg *apc.MpathReplaceMsg) (core.Xact, error) {
+	srcMi, dstMi, err := fs.NewReplacement(msg.Src, msg.Dst, ios.Label(msg.Label))
+	if err != nil {
+		return nil, err
+	}
+	if err := xreg.LimitedCoexistence(g.t.si, nil, apc.ActMountpathReplace); err != nil {
+		return nil, err
+	}
+	swap := func() error {
+		if err := fs.ReplaceMpath(g.t.SID(), srcMi, dstMi, g.redistributeMD); err != nil {
+			return err
+		}
+		g._postReplace(srcMi, dstMi)
+		return nil
+	}
+	rns := xreg.RenewMpathReplace(cos.GenUUID(), &xreg.MpathReplaceArgs{Msg: msg, Src: srcMi, Dst: dstMi, Swap: swap})
+	if rns.Err != nil {
+		return nil, rns.Err
+	}
+	return rns.Entry.Get(), nil
+}
+
+func (g *fsprungroup) _postReplace(srcMi, dstMi *fs.Mountpath) {
+	fspathsConfigReplace(srcMi.Path, dstMi.Path)
+	core.UncacheMountpath(srcMi)
+	fs.ComputeDiskSize()
+
+	// mirrored copies refer to each other by FQN
+	// (including objects with per-object overrides in buckets that are not mirrored)
+	go xreg.RenewMakeNCopies(cos.GenUUID(), apc.ActMountpathReplace, true /*all*/)
+
+	tstats := g.t.statsT.(*stats.Trunner)
+	for _, disk := range dstMi.Disks {
+		tstats.RegDiskMetrics(g.t.si, disk)
+	}
+}
+
 func (g *fsprungroup) doDD(action string, flags uint64, mpath string, dontResilver bool) (*fs.Mountpath, error) {
 	rmi, numAvail, noResil, err := fs.BeginDD(action, flags, mpath)
 	if err != nil || rmi == nil {
@@ -245,6 +286,23 @@ func fspathsConfigAddDel(mpath string, add bool) {
 	fspathsSave(config)
 }
 
+func fspathsConfigReplace(src, dst string) {
+	if cmn.Rom.TestingEnv() {
+		return
+	}
+	config := cmn.GCO.BeginUpdate()
+	localConfig := &config.LocalConfig
+	localConfig.DelPath(src)
+	localConfig.AddPath(dst)
+	if err := localConfig.FSP.Validate(config); err != nil {
+		debug.AssertNoErr(err)
+		cmn.GCO.DiscardUpdate()
+		nlog.Errorln(err)
+		return
+	}
+	fspathsSave(config)
+}
+
 func fspathsSave(config *cmn.Config) {
 	toUpdate := &cmn.ConfigToSet{FSP: &config.LocalConfig.FSP}
 	overrideConfig := cmn.GCO.SetLocalFSPaths(toUpdate)
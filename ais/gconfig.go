@@ -211,6 +211,17 @@ func setConfig(toUpdate *cmn.ConfigToSet, transient bool) (err error) {
 	return nil
 }
 
+// config schema with cluster-wide defaults from the initial (plain-text) config, if available
+func configSchema() []*cmn.ConfigFieldSchema {
+	dflt := &cmn.ClusterConfig{}
+	if _, err := jsp.Load(cmn.GCO.GetInitialGconfPath(), dflt, jsp.Plain()); err != nil {
+		nlog.Warningln("config schema: failed to load initial config (proceeding without defaults):", err)
+		return cmn.NewConfigSchema(nil)
+	}
+	dflt.Auth.Secret = "**********" // hide secret
+	return cmn.NewConfigSchema(dflt)
+}
+
 func setConfigInMem(toUpdate *cmn.ConfigToSet, config *cmn.Config, asType string) (err error) {
 	err = config.UpdateClusterConfig(toUpdate, asType)
 	return
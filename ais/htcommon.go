@@ -122,6 +122,7 @@ type (
 		cresv   cresv
 		si      *meta.Snode
 		req     cmn.HreqArgs
+		mbody   []byte // msgpack-encoded alternative to req.Body (see bcastArgs.amsg)
 		timeout time.Duration
 	}
 
@@ -138,6 +139,9 @@ type (
 		nodeCount         int            // m.b. greater or equal destination count
 		ignoreMaintenance bool           // do not skip nodes in maintenance mode
 		async             bool           // ignore results
+
+		amsg  *aisMsg // when set, marshaled into req.Body or mbody as per destination (see aismsg.go)
+		mbody []byte
 	}
 
 	networkHandler struct {
@@ -178,7 +182,9 @@ type (
 		sync.Mutex
 		s             *http.Server
 		muxers        httpMuxers
+		lim           *reqLimiter // public network only (see htlimits)
 		sndRcvBufSize int
+		tos           int // DSCP marking (IP_TOS)
 	}
 
 	nlogWriter struct{}
@@ -393,11 +399,16 @@ type (
 	cresEI struct{} // -> etl.InfoList
 	cresEL struct{} // -> etl.Logs
 	cresEM struct{} // -> etl.CPUMemUsed
+	cresES struct{} // -> etl.NodeStatus
 	cresIC struct{} // -> icBundle
 	cresBM struct{} // -> bucketMD
 
 	cresLso   struct{} // -> cmn.LsoRes
 	cresBsumm struct{} // -> cmn.AllBsummResults
+	cresTrash struct{} // -> apc.TrashEntries
+	cresDQ    struct{} // -> cmn.DeleteQueueInfo
+	cresFed   struct{} // -> meta.Federation
+	cresAT    struct{} // -> bckAtimes
 )
 
 var (
@@ -409,9 +420,14 @@ var (
 	_ cresv = cresEI{}
 	_ cresv = cresEL{}
 	_ cresv = cresEM{}
+	_ cresv = cresES{}
 	_ cresv = cresIC{}
 	_ cresv = cresBM{}
 	_ cresv = cresBsumm{}
+	_ cresv = cresTrash{}
+	_ cresv = cresDQ{}
+	_ cresv = cresFed{}
+	_ cresv = cresAT{}
 )
 
 func (res *callResult) read(body io.Reader, size int64) {
@@ -454,6 +470,9 @@ func (c cresEL) read(res *callResult, body io.Reader) { res.v = c.newV(); res.jr
 func (cresEM) newV() any                              { return &etl.CPUMemUsed{} }
 func (c cresEM) read(res *callResult, body io.Reader) { res.v = c.newV(); res.jread(body) }
 
+func (cresES) newV() any                              { return &etl.NodeStatus{} }
+func (c cresES) read(res *callResult, body io.Reader) { res.v = c.newV(); res.jread(body) }
+
 func (cresIC) newV() any                              { return &icBundle{} }
 func (c cresIC) read(res *callResult, body io.Reader) { res.v = c.newV(); res.jread(body) }
 
@@ -463,6 +482,18 @@ func (c cresBM) read(res *callResult, body io.Reader) { res.v = c.newV(); res.jr
 func (cresBsumm) newV() any                              { return &cmn.AllBsummResults{} }
 func (c cresBsumm) read(res *callResult, body io.Reader) { res.v = c.newV(); res.jread(body) }
 
+func (cresTrash) newV() any                              { return &apc.TrashEntries{} }
+func (c cresTrash) read(res *callResult, body io.Reader) { res.v = c.newV(); res.jread(body) }
+
+func (cresDQ) newV() any                              { return &cmn.DeleteQueueInfo{} }
+func (c cresDQ) read(res *callResult, body io.Reader) { res.v = c.newV(); res.jread(body) }
+
+func (cresFed) newV() any                              { return &meta.Federation{} }
+func (c cresFed) read(res *callResult, body io.Reader) { res.v = c.newV(); res.jread(body) }
+
+func (cresAT) newV() any                              { return &bckAtimes{} }
+func (c cresAT) read(res *callResult, body io.Reader) { res.v = c.newV(); res.jread(body) }
+
 ////////////////
 // nlogWriter //
 ////////////////
@@ -552,7 +583,13 @@ func (server *netServer) listen(addr string, logger *log.Logger, tlsConf *tls.Co
 	if timeout, isSet := cmn.ParseReadHeaderTimeout(); isSet { // optional env var
 		server.s.ReadHeaderTimeout = timeout
 	}
-	if server.sndRcvBufSize > 0 && !config.Net.HTTP.UseHTTPS {
+	if server.lim != nil {
+		server.s.Handler = server.lim
+		if n := config.Net.HTTP.Limits.MaxHeaderSize; n > 0 {
+			server.s.MaxHeaderBytes = int(n)
+		}
+	}
+	if (server.sndRcvBufSize > 0 && !config.Net.HTTP.UseHTTPS) || server.tos > 0 {
 		server.s.ConnState = server.connStateListener // setsockopt; see also cmn.NewTransport
 	}
 	server.s.TLSConfig = tlsConf
@@ -607,10 +644,14 @@ func (server *netServer) connStateListener(c net.Conn, cs http.ConnState) {
 	if cs != http.StateNew {
 		return
 	}
+	args := cmn.TransportArgs{SndRcvBufSize: server.sndRcvBufSize, TOS: server.tos}
+	if tlsconn, ok := c.(*tls.Conn); ok {
+		c = tlsconn.NetConn()
+		args.SndRcvBufSize = 0 // (DSCP marking only)
+	}
 	tcpconn, ok := c.(*net.TCPConn)
 	cos.Assert(ok)
 	rawconn, _ := tcpconn.SyscallConn()
-	args := cmn.TransportArgs{SndRcvBufSize: server.sndRcvBufSize}
 	rawconn.Control(args.ConnControl(rawconn))
 }
 
@@ -0,0 +1,185 @@
+// Package ais provides core functionality for the AIStore object storage.
+/*
+ * Copyright (c) 2024, NVIDIA CORPORATION. All rights reserved.
+ */
+package ais
+
+import (
+	"errors"
+	"io"
+	"net"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/NVIDIA/aistore/api/apc"
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/cmn/cos"
+	"github.com/NVIDIA/aistore/cmn/mono"
+	"github.com/NVIDIA/aistore/cmn/nlog"
+	"github.com/NVIDIA/aistore/core/meta"
+)
+
+// Public endpoint hardening (see cmn.HTTPLimitsConf):
+// - request headers: count and total size => 431
+// - request body: max size per endpoint class (control vs object PUT) with per-endpoint overrides => 413
+// - slow request body (slowloris): minimum average receive rate => 408
+// All limits are cluster-configurable at runtime (except http.Server.MaxHeaderBytes), apply to the
+// public network only, and do not apply to intra-cluster calls.
+// (slow headers, on the other hand, are handled by http.Server.ReadHeaderTimeout - see apc.ReadHeaderTimeout)
+
+type (
+	reqLimiter struct {
+		h    *htrun
+		next http.Handler
+	}
+	limBody struct {
+		r       io.ReadCloser
+		rc      *http.ResponseController
+		started int64
+		limit   int64 // max size (zero: no limit)
+		rate    int64 // min receive rate (bytes/s; zero: not enforced)
+		grace   time.Duration
+		n       int64
+	}
+)
+
+func (lim *reqLimiter) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	conf := &cmn.GCO.Get().Net.HTTP.Limits
+	if !conf.Enabled() || lim.isIntra(r) {
+		lim.next.ServeHTTP(w, r)
+		return
+	}
+	if err := lim.check(w, r, conf); err != nil {
+		if cmn.Rom.FastV(4, cos.SmoduleAIS) {
+			nlog.Warningln(lim.h.String(), r.Method, r.URL.Path, "from", r.RemoteAddr+":", err)
+		}
+		w.Header().Set("Connection", "close")
+		cmn.WriteErr(w, r, err, err.Status())
+		return
+	}
+	lim.next.ServeHTTP(w, r)
+}
+
+// intra-cluster callers identify themselves (see htrun.isIntraCall) - but the header
+// can be set by anyone: the caller must be in the Smap and the request must come from
+// one of its (Smap-registered) addresses
+func (lim *reqLimiter) isIntra(r *http.Request) bool {
+	callerID := r.Header.Get(apc.HdrCallerID)
+	if callerID == "" {
+		return false
+	}
+	smap := lim.h.owner.smap.get()
+	if smap == nil {
+		return false
+	}
+	si := smap.GetNode(callerID)
+	if si == nil {
+		return false
+	}
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		return false
+	}
+	ip := net.ParseIP(host)
+	for _, ni := range []meta.NetInfo{si.PubNet, si.ControlNet, si.DataNet} {
+		if ni.Hostname == host {
+			return true
+		}
+		if ip != nil && ip.Equal(net.ParseIP(ni.Hostname)) {
+			return true
+		}
+	}
+	return false
+}
+
+func (*reqLimiter) check(w http.ResponseWriter, r *http.Request, conf *cmn.HTTPLimitsConf) *cmn.ErrReqLimit {
+	// 1. headers
+	if conf.MaxHeaderCount > 0 || conf.MaxHeaderSize > 0 {
+		var cnt, size int64
+		for k, vals := range r.Header {
+			for _, v := range vals {
+				cnt++
+				size += int64(len(k) + len(v) + 4) // ": " and CRLF
+			}
+		}
+		if conf.MaxHeaderCount > 0 && cnt > int64(conf.MaxHeaderCount) {
+			return cmn.NewErrReqLimit("header count", cnt, int64(conf.MaxHeaderCount), http.StatusRequestHeaderFieldsTooLarge)
+		}
+		if conf.MaxHeaderSize > 0 && size > int64(conf.MaxHeaderSize) {
+			return cmn.NewErrReqLimit("header size", size, int64(conf.MaxHeaderSize), http.StatusRequestHeaderFieldsTooLarge)
+		}
+	}
+	if r.Body == nil || r.Body == http.NoBody {
+		return nil
+	}
+
+	// 2. body
+	var (
+		ep    = reqEndpoint(r.URL.Path)
+		isPut = r.Method == http.MethodPut && (ep == apc.Objects || ep == apc.S3)
+		limit = conf.MaxBody(ep, isPut)
+	)
+	if limit > 0 && r.ContentLength > limit {
+		return cmn.NewErrReqLimit("body", r.ContentLength, limit, http.StatusRequestEntityTooLarge)
+	}
+	// chunked transfer and/or slow-request detection
+	if (limit > 0 && r.ContentLength < 0) || conf.MinRecvRate > 0 {
+		lb := &limBody{r: r.Body, limit: limit, rate: int64(conf.MinRecvRate), grace: conf.SlowGrace.D(), started: mono.NanoTime()}
+		if lb.rate > 0 {
+			lb.rc = http.NewResponseController(w)
+			lb.deadline()
+		}
+		r.Body = lb
+	}
+	return nil
+}
+
+// "/v1/buckets/abc" => "buckets"; "/s3/abc/obj" => "s3"
+func reqEndpoint(path string) string {
+	path = strings.TrimPrefix(path, "/")
+	path = strings.TrimPrefix(path, apc.Version+"/")
+	if i := strings.IndexByte(path, '/'); i >= 0 {
+		return path[:i]
+	}
+	return path
+}
+
+/////////////
+// limBody //
+/////////////
+
+func (lb *limBody) Read(p []byte) (n int, err error) {
+	if lb.limit > 0 {
+		if lb.n > lb.limit {
+			return 0, cmn.NewErrReqLimit("body", 0, lb.limit, http.StatusRequestEntityTooLarge)
+		}
+		if int64(len(p)) > lb.limit-lb.n+1 {
+			p = p[:lb.limit-lb.n+1]
+		}
+	}
+	n, err = lb.r.Read(p)
+	lb.n += int64(n)
+	if lb.limit > 0 && lb.n > lb.limit {
+		n -= int(lb.n - lb.limit)
+		return n, cmn.NewErrReqLimit("body", 0, lb.limit, http.StatusRequestEntityTooLarge)
+	}
+	if lb.rate > 0 {
+		if err != nil && errors.Is(err, os.ErrDeadlineExceeded) {
+			return n, cmn.NewErrReqLimit("receive rate", 0, lb.rate, http.StatusRequestTimeout)
+		}
+		if err == nil {
+			lb.deadline()
+		}
+	}
+	return n, err
+}
+
+// the bytes received so far must have arrived at (or above) the min rate, upon grace period
+func (lb *limBody) deadline() {
+	d := lb.grace + time.Duration(float64(lb.n+1)/float64(lb.rate)*float64(time.Second))
+	_ = lb.rc.SetReadDeadline(time.Now().Add(d - mono.Since(lb.started)))
+}
+
+func (lb *limBody) Close() error { return lb.r.Close() }
@@ -0,0 +1,135 @@
+// Package ais provides core functionality for the AIStore object storage.
+/*
+ * Copyright (c) 2024, NVIDIA CORPORATION. All rights reserved.
+ */
+package ais
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+
+	"github.com/NVIDIA/aistore/api/apc"
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/cmn/cos"
+	"github.com/NVIDIA/aistore/core/meta"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Request limits", func() {
+	var (
+		lim     *reqLimiter
+		oldConf *cmn.Config
+		served  bool
+		readErr error
+	)
+	BeforeEach(func() {
+		oldConf = cmn.GCO.Get()
+		config := cmn.GCO.BeginUpdate()
+		config.Net.HTTP.Limits = cmn.HTTPLimitsConf{
+			MaxCtrlBody:      cos.KiB,
+			MaxPutBody:       cos.MiB,
+			MaxBodyOverrides: "etl=64KiB",
+			MaxHeaderCount:   8,
+		}
+		Expect(config.Net.HTTP.Limits.Validate()).NotTo(HaveOccurred())
+		cmn.GCO.CommitUpdate(config)
+
+		served, readErr = false, nil
+		lim = &reqLimiter{next: http.HandlerFunc(func(_ http.ResponseWriter, r *http.Request) {
+			served = true
+			_, readErr = io.Copy(io.Discard, r.Body)
+		})}
+	})
+	AfterEach(func() {
+		cmn.GCO.BeginUpdate()
+		cmn.GCO.CommitUpdate(oldConf)
+	})
+
+	serve := func(r *http.Request) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		lim.ServeHTTP(w, r)
+		return w
+	}
+
+	It("should apply control-plane and object PUT body limits", func() {
+		body := strings.Repeat("x", 4*cos.KiB)
+		w := serve(httptest.NewRequest(http.MethodPut, apc.URLPathBuckets.Join("abc"), strings.NewReader(body)))
+		Expect(w.Code).To(Equal(http.StatusRequestEntityTooLarge))
+		Expect(served).To(BeFalse())
+
+		w = serve(httptest.NewRequest(http.MethodPut, apc.URLPathObjects.Join("abc", "obj"), strings.NewReader(body)))
+		Expect(w.Code).To(Equal(http.StatusOK))
+		Expect(served).To(BeTrue())
+		Expect(readErr).NotTo(HaveOccurred())
+	})
+
+	It("should apply per-endpoint overrides", func() {
+		body := strings.Repeat("x", 4*cos.KiB)
+		w := serve(httptest.NewRequest(http.MethodPost, apc.URLPathETL.S, strings.NewReader(body)))
+		Expect(w.Code).To(Equal(http.StatusOK))
+		Expect(cmn.GCO.Get().Net.HTTP.Limits.MaxBody(apc.ETL, false)).To(BeEquivalentTo(64 * cos.KiB))
+	})
+
+	It("should reject too many headers with 431", func() {
+		r := httptest.NewRequest(http.MethodGet, apc.URLPathBuckets.S, http.NoBody)
+		for i := range 10 {
+			r.Header.Set("X-Test-"+string(rune('a'+i)), "v")
+		}
+		w := serve(r)
+		Expect(w.Code).To(Equal(http.StatusRequestHeaderFieldsTooLarge))
+		Expect(w.Body.String()).To(ContainSubstring("header count"))
+	})
+
+	It("should exempt only verified intra-cluster callers", func() {
+		var (
+			h    = &htrun{}
+			smap = newSmap()
+			ni   = meta.NetInfo{}
+		)
+		ni.Init("http", "10.0.0.1", "8081")
+		si := newSnode("t1", apc.Target, ni, ni, ni)
+		smap.addTarget(si)
+		psi := newSnode("p1", apc.Proxy, meta.NetInfo{}, meta.NetInfo{}, meta.NetInfo{})
+		smap.addProxy(psi)
+		smap.Primary = psi
+		h.owner.smap = newSmapOwner(cmn.GCO.Get())
+		h.owner.smap.put(smap)
+		lim.h = h
+
+		body := strings.Repeat("x", 4*cos.KiB)
+		tests := []struct {
+			callerID   string
+			remoteAddr string
+			code       int
+		}{
+			{"t1", "10.0.0.1:51234", http.StatusOK},
+			{"t1", "192.0.2.1:51234", http.StatusRequestEntityTooLarge}, // spoofed caller ID
+			{"t2", "10.0.0.1:51234", http.StatusRequestEntityTooLarge},  // not in Smap
+			{"", "10.0.0.1:51234", http.StatusRequestEntityTooLarge},
+		}
+		for _, test := range tests {
+			r := httptest.NewRequest(http.MethodPut, apc.URLPathBuckets.Join("abc"), strings.NewReader(body))
+			r.RemoteAddr = test.remoteAddr
+			if test.callerID != "" {
+				r.Header.Set(apc.HdrCallerID, test.callerID)
+			}
+			w := serve(r)
+			Expect(w.Code).To(Equal(test.code), "caller %q from %s", test.callerID, test.remoteAddr)
+		}
+	})
+
+	It("should enforce the limit on chunked bodies", func() {
+		r := httptest.NewRequest(http.MethodPost, apc.URLPathBuckets.S, strings.NewReader(strings.Repeat("x", 2*cos.KiB)))
+		r.ContentLength = -1
+		serve(r)
+		Expect(served).To(BeTrue())
+		Expect(readErr).To(HaveOccurred())
+		var e *cmn.ErrReqLimit
+		Expect(errors.As(readErr, &e)).To(BeTrue())
+		Expect(e.Status()).To(Equal(http.StatusRequestEntityTooLarge))
+	})
+})
@@ -0,0 +1,297 @@
+// Package ais provides core functionality for the AIStore object storage.
+/*
+ * Copyright (c) 2024, NVIDIA CORPORATION. All rights reserved.
+ */
+package ais
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/NVIDIA/aistore/api/apc"
+	"github.com/NVIDIA/aistore/cmn/cos"
+	"github.com/NVIDIA/aistore/cmn/nlog"
+)
+
+// what=log query: tail and filter the node's recent log lines by severity, regex, and time,
+// and (optionally) keep following - streaming new lines as they get logged.
+// Notes:
+// - only the current log is queried (older, rotated logs can be retrieved via `apc.QparamAllLogs`);
+// - filters apply to the first line of each log record; the rest of the record (if any) follows;
+// - log records are timestamped with time of day; the date comes from the log's title (see nlog.rotate).
+
+const (
+	logTailDflt   = 1000
+	logTailMax    = 100_000
+	logFollowPoll = time.Second
+)
+
+type (
+	logQuery struct {
+		re     *regexp.Regexp
+		since  int64 // Unix time (nanoseconds)
+		until  int64 // ditto
+		tail   int
+		minSev int // sevRank
+		follow bool
+	}
+	logScanner struct {
+		q     *logQuery
+		day   time.Time     // date of the log
+		prev  time.Duration // time of day of the previous record
+		match bool          // current record
+	}
+)
+
+func isLogQuery(query url.Values) bool {
+	return query.Has(apc.QparamLogRegex) || query.Has(apc.QparamLogTail) || query.Has(apc.QparamLogFollow) ||
+		query.Has(apc.QparamSince) || query.Has(apc.QparamUntil)
+}
+
+func parseLogQuery(query url.Values) (q *logQuery, err error) {
+	q = &logQuery{tail: logTailDflt}
+	if s := query.Get(apc.QparamLogRegex); s != "" {
+		if q.re, err = regexp.Compile(s); err != nil {
+			return nil, fmt.Errorf("invalid %q query: %v", apc.QparamLogRegex, err)
+		}
+	}
+	if s := query.Get(apc.QparamLogTail); s != "" {
+		if q.tail, err = strconv.Atoi(s); err != nil || q.tail <= 0 || q.tail > logTailMax {
+			return nil, fmt.Errorf("invalid %q query %q (expecting integer in the range [1, %d])", apc.QparamLogTail, s, logTailMax)
+		}
+	}
+	if s := query.Get(apc.QparamSince); s != "" {
+		if q.since, err = cos.S2UnixNano(s); err != nil {
+			return nil, fmt.Errorf("invalid %q query (expecting Unix time in nanoseconds): %v", apc.QparamSince, err)
+		}
+	}
+	if s := query.Get(apc.QparamUntil); s != "" {
+		if q.until, err = cos.S2UnixNano(s); err != nil {
+			return nil, fmt.Errorf("invalid %q query (expecting Unix time in nanoseconds): %v", apc.QparamUntil, err)
+		}
+	}
+	if q.until != 0 && q.until < q.since {
+		return nil, fmt.Errorf("invalid log query: until (%d) < since (%d)", q.until, q.since)
+	}
+	if s := query.Get(apc.QparamLogSev); s != "" {
+		switch strings.ToLower(s)[0] {
+		case apc.LogWarn[0]:
+			q.minSev = sevRank('W')
+		case apc.LogErr[0]:
+			q.minSev = sevRank('E')
+		}
+	}
+	q.follow = cos.IsParseBool(query.Get(apc.QparamLogFollow))
+	return q, nil
+}
+
+func (h *htrun) queryLog(w http.ResponseWriter, r *http.Request, query url.Values) {
+	q, err := parseLogQuery(query)
+	if err != nil {
+		h.writeErr(w, r, err)
+		return
+	}
+	// info log has it all; error log - warnings and errors
+	log, err := sev2Logname(query.Get(apc.QparamLogSev))
+	if err != nil {
+		h.writeErr(w, r, err)
+		return
+	}
+	nlog.Flush(nlog.ActFlush)
+	fh, err := os.Open(log)
+	if err != nil {
+		ecode := http.StatusInternalServerError
+		if os.IsNotExist(err) {
+			ecode = http.StatusNotFound
+		}
+		h.writeErr(w, r, err, ecode)
+		return
+	}
+
+	// 1. tail
+	var (
+		ls   = &logScanner{q: q}
+		ring = make([]string, 0, min(q.tail, 1024))
+	)
+	off, err := ls.scan(fh, 0, func(line string) error {
+		if len(ring) >= 2*q.tail {
+			ring = append(ring[:0], ring[len(ring)-q.tail:]...)
+		}
+		ring = append(ring, line)
+		return nil
+	})
+	if err != nil {
+		cos.Close(fh)
+		h.writeErr(w, r, err)
+		return
+	}
+	if len(ring) > q.tail {
+		ring = ring[len(ring)-q.tail:]
+	}
+	for _, line := range ring {
+		if _, err = io.WriteString(w, line); err != nil {
+			cos.Close(fh)
+			return
+		}
+	}
+
+	// 2. follow
+	if q.follow {
+		fh = h.followLog(w, r, log, fh, off, ls)
+	}
+	cos.Close(fh)
+}
+
+// poll the log for new lines until the client goes away; handle rotation
+func (h *htrun) followLog(w http.ResponseWriter, r *http.Request, log string, fh *os.File, off int64, ls *logScanner) *os.File {
+	var (
+		rc     = http.NewResponseController(w)
+		ticker = time.NewTicker(logFollowPoll)
+		emit   = func(line string) error {
+			_, err := io.WriteString(w, line)
+			return err
+		}
+	)
+	defer ticker.Stop()
+	if rc.Flush() != nil {
+		return fh
+	}
+	for {
+		select {
+		case <-r.Context().Done():
+			return fh
+		case <-ticker.C:
+		}
+		nlog.Flush(nlog.ActFlush)
+		if nfh, rotated := reopenRotated(log, fh); rotated {
+			// drain the rest of the old one
+			if _, err := ls.scan(fh, off, emit); err != nil {
+				cos.Close(nfh)
+				return fh
+			}
+			cos.Close(fh)
+			fh, off = nfh, 0
+			ls.day = time.Time{}
+		}
+		var err error
+		if off, err = ls.scan(fh, off, emit); err != nil {
+			return fh
+		}
+		if err := rc.Flush(); err != nil {
+			return fh
+		}
+	}
+}
+
+func reopenRotated(log string, fh *os.File) (*os.File, bool) {
+	finfo, err := os.Stat(log)
+	if err != nil {
+		return nil, false
+	}
+	if cur, err := fh.Stat(); err == nil && os.SameFile(cur, finfo) {
+		return nil, false
+	}
+	nfh, err := os.Open(log)
+	if err != nil {
+		return nil, false
+	}
+	return nfh, true
+}
+
+////////////////
+// logScanner //
+////////////////
+
+// scan complete lines starting at a given offset; return the offset of the first incomplete one
+func (ls *logScanner) scan(fh *os.File, off int64, emit func(string) error) (int64, error) {
+	if _, err := fh.Seek(off, io.SeekStart); err != nil {
+		return off, err
+	}
+	br := bufio.NewReaderSize(fh, 64*cos.KiB)
+	for {
+		line, err := br.ReadString('\n')
+		if err != nil {
+			if errors.Is(err, io.EOF) {
+				return off, nil
+			}
+			return off, err
+		}
+		off += int64(len(line))
+		if ls.next(line) {
+			if err := emit(line); err != nil {
+				return off, err
+			}
+		}
+	}
+}
+
+// "I 15:04:05.000000 file:line message"
+// "Started up at 2006/01/02 15:04:05, host ..." (and "Rotated at ...")
+func (ls *logScanner) next(line string) bool {
+	sev, tod, ok := parseLogHdr(line)
+	if !ok {
+		if i := strings.Index(line, " at "); i > 0 && (strings.HasPrefix(line, "Started") || strings.HasPrefix(line, "Rotated")) {
+			if day, err := time.ParseInLocation("2006/01/02", line[i+4:min(i+14, len(line))], time.Local); err == nil {
+				ls.day, ls.prev = day, 0
+			}
+			return false
+		}
+		return ls.match // (continued)
+	}
+	q := ls.q
+	if ls.day.IsZero() {
+		now := time.Now()
+		ls.day = time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.Local)
+	}
+	if tod < ls.prev-time.Hour { // past midnight
+		ls.day = ls.day.AddDate(0, 0, 1)
+	}
+	ls.prev = tod
+	ts := ls.day.Add(tod).UnixNano()
+	ls.match = sevRank(sev) >= q.minSev &&
+		(q.since == 0 || ts >= q.since) && (q.until == 0 || ts <= q.until) &&
+		(q.re == nil || q.re.MatchString(strings.TrimSuffix(line, "\n")))
+	return ls.match
+}
+
+// see nlog.formatHdr
+func sevRank(sev byte) int {
+	switch sev {
+	case 'W':
+		return 1
+	case 'E':
+		return 2
+	}
+	return 0
+}
+
+func parseLogHdr(line string) (sev byte, tod time.Duration, ok bool) {
+	const hdrLen = len("I 15:04:05.000000")
+	if len(line) < hdrLen || line[1] != ' ' || line[4] != ':' || line[7] != ':' || line[10] != '.' {
+		return
+	}
+	switch sev = line[0]; sev {
+	case 'I', 'W', 'E':
+	default:
+		return
+	}
+	h, err1 := strconv.Atoi(line[2:4])
+	m, err2 := strconv.Atoi(line[5:7])
+	s, err3 := strconv.Atoi(line[8:10])
+	us, err4 := strconv.Atoi(line[11:hdrLen])
+	if err1 != nil || err2 != nil || err3 != nil || err4 != nil {
+		return
+	}
+	tod = time.Duration(h)*time.Hour + time.Duration(m)*time.Minute + time.Duration(s)*time.Second +
+		time.Duration(us)*time.Microsecond
+	return sev, tod, true
+}
@@ -0,0 +1,121 @@
+// Package ais provides core functionality for the AIStore object storage.
+/*
+ * Copyright (c) 2024, NVIDIA CORPORATION. All rights reserved.
+ */
+package ais
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strconv"
+	"time"
+
+	"github.com/NVIDIA/aistore/api/apc"
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/cmn/nlog"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Log query", func() {
+	const logText = "Started up at 2024/03/01 23:59:58, host h1, go1.22\n" +
+		"I 23:59:58.000001 a.go:1 first\n" +
+		"W 23:59:59.000002 b.go:2 second\n" +
+		"  continued\n" +
+		"E 00:00:01.000003 c.go:3 third\n" +
+		"I 00:00:02.000004 d.go:4 fourth\n" +
+		"I 00:00:03.0000" // incomplete
+
+	var fh *os.File
+
+	BeforeEach(func() {
+		fqn := filepath.Join(GinkgoT().TempDir(), "log")
+		Expect(os.WriteFile(fqn, []byte(logText), 0o644)).NotTo(HaveOccurred())
+		var err error
+		fh, err = os.Open(fqn)
+		Expect(err).NotTo(HaveOccurred())
+	})
+	AfterEach(func() {
+		fh.Close()
+	})
+
+	scan := func(query url.Values) (lines []string, off int64) {
+		q, err := parseLogQuery(query)
+		Expect(err).NotTo(HaveOccurred())
+		ls := &logScanner{q: q}
+		off, err = ls.scan(fh, 0, func(line string) error {
+			lines = append(lines, line)
+			return nil
+		})
+		Expect(err).NotTo(HaveOccurred())
+		return lines, off
+	}
+
+	It("should return complete lines only", func() {
+		lines, off := scan(url.Values{apc.QparamLogTail: []string{"10"}})
+		Expect(lines).To(HaveLen(5))
+		Expect(off).To(BeEquivalentTo(len(logText) - len("I 00:00:03.0000")))
+	})
+
+	It("should filter by severity and include continuation lines", func() {
+		lines, _ := scan(url.Values{apc.QparamLogSev: []string{apc.LogWarn}})
+		Expect(lines).To(Equal([]string{
+			"W 23:59:59.000002 b.go:2 second\n",
+			"  continued\n",
+			"E 00:00:01.000003 c.go:3 third\n",
+		}))
+	})
+
+	It("should filter by regex", func() {
+		lines, _ := scan(url.Values{apc.QparamLogRegex: []string{"^E .*third$"}})
+		Expect(lines).To(HaveLen(1))
+		lines, _ = scan(url.Values{apc.QparamLogRegex: []string{"f(irst|ourth)"}})
+		Expect(lines).To(HaveLen(2))
+	})
+
+	It("should filter by time across midnight", func() {
+		since := time.Date(2024, 3, 2, 0, 0, 0, 0, time.Local).UnixNano()
+		lines, _ := scan(url.Values{apc.QparamSince: []string{strconv.FormatInt(since, 10)}})
+		Expect(lines).To(Equal([]string{
+			"E 00:00:01.000003 c.go:3 third\n",
+			"I 00:00:02.000004 d.go:4 fourth\n",
+		}))
+	})
+
+	It("should serve filtered log lines via what=log", func() {
+		nlog.Infoln("log query test") // (init logs)
+		dir := GinkgoT().TempDir()
+		Expect(os.WriteFile(filepath.Join(dir, nlog.InfoLogName()), []byte(logText), 0o644)).NotTo(HaveOccurred())
+		config := cmn.GCO.BeginUpdate()
+		logDir := config.LogDir
+		config.LogDir = dir
+		cmn.GCO.CommitUpdate(config)
+		DeferCleanup(func() {
+			config := cmn.GCO.BeginUpdate()
+			config.LogDir = logDir
+			cmn.GCO.CommitUpdate(config)
+		})
+
+		h := &htrun{}
+		query := url.Values{apc.QparamWhat: []string{apc.WhatLog}, apc.QparamLogRegex: []string{"f(irst|ourth)"}}
+		r := httptest.NewRequest(http.MethodGet, apc.URLPathDae.S+"?"+query.Encode(), http.NoBody)
+		w := httptest.NewRecorder()
+		h.httpdaeget(w, r, query, nil)
+		Expect(w.Code).To(Equal(http.StatusOK))
+		Expect(w.Body.String()).To(Equal("I 23:59:58.000001 a.go:1 first\nI 00:00:02.000004 d.go:4 fourth\n"))
+	})
+
+	It("should reject invalid queries", func() {
+		for _, query := range []url.Values{
+			{apc.QparamLogRegex: []string{"("}},
+			{apc.QparamLogTail: []string{"0"}},
+			{apc.QparamSince: []string{"2"}, apc.QparamUntil: []string{"1"}},
+		} {
+			_, err := parseLogQuery(query)
+			Expect(err).To(HaveOccurred())
+		}
+	})
+})
@@ -79,8 +79,9 @@ type htrun struct {
 		cluster atomic.Int64 // mono.NanoTime() since cluster startup, zero prior to that
 		node    atomic.Int64 // ditto - for the node
 	}
-	gmm *memsys.MMSA // system pagesize-based memory manager and slab allocator
-	smm *memsys.MMSA // system MMSA for small-size allocations
+	gmm    *memsys.MMSA // system pagesize-based memory manager and slab allocator
+	smm    *memsys.MMSA // system MMSA for small-size allocations
+	shadow shadower     // request shadowing (see shadow.go)
 }
 
 ///////////
@@ -178,22 +179,6 @@ func (h *htrun) cluMeta(opts cmetaFillOpt) (*cluMeta, error) {
 	return cm, nil
 }
 
-// usage: [API call => handler => ClusterStartedWithRetry ]
-func (h *htrun) cluStartedWithRetry() bool {
-	if clutime := h.startup.cluster.Load(); clutime > 0 {
-		return true
-	}
-	if !h.NodeStarted() {
-		return false
-	}
-	time.Sleep(time.Second)
-	clutime := h.startup.cluster.Load()
-	if clutime == 0 {
-		nlog.ErrorDepth(1, fmt.Sprintf("%s: cluster is starting up", h))
-	}
-	return clutime > 0
-}
-
 func (h *htrun) ClusterStarted() bool { return h.startup.cluster.Load() > 0 } // see also: p.ready()
 
 func (h *htrun) markClusterStarted() {
@@ -281,16 +266,17 @@ func (h *htrun) init(config *cmn.Config) {
 	}
 
 	muxers := newMuxers()
-	g.netServ.pub = &netServer{muxers: muxers, sndRcvBufSize: tcpbuf}
+	g.netServ.pub = &netServer{muxers: muxers, sndRcvBufSize: tcpbuf, tos: config.NetTOS(cmn.NetPublic)}
+	g.netServ.pub.lim = &reqLimiter{h: h, next: muxers}
 	g.netServ.control = g.netServ.pub // if not separately configured, intra-control net is public
 	if config.HostNet.UseIntraControl {
 		muxers = newMuxers()
-		g.netServ.control = &netServer{muxers: muxers, sndRcvBufSize: 0}
+		g.netServ.control = &netServer{muxers: muxers, sndRcvBufSize: 0, tos: config.NetTOS(cmn.NetIntraControl)}
 	}
 	g.netServ.data = g.netServ.control // if not configured, intra-data net is intra-control
 	if config.HostNet.UseIntraData {
 		muxers = newMuxers()
-		g.netServ.data = &netServer{muxers: muxers, sndRcvBufSize: tcpbuf}
+		g.netServ.data = &netServer{muxers: muxers, sndRcvBufSize: tcpbuf, tos: config.NetTOS(cmn.NetIntraData)}
 	}
 
 	h.owner.smap = newSmapOwner(config)
@@ -306,12 +292,13 @@ func (h *htrun) init(config *cmn.Config) {
 // steps 1 thru 4
 func (h *htrun) initSnode(config *cmn.Config) {
 	var (
-		pubAddr  meta.NetInfo
-		pubExtra []meta.NetInfo
-		ctrlAddr meta.NetInfo
-		dataAddr meta.NetInfo
-		port     = strconv.Itoa(config.HostNet.Port)
-		proto    = config.Net.HTTP.Proto
+		pubAddr   meta.NetInfo
+		pubExtra  []meta.NetInfo
+		ctrlAddr  meta.NetInfo
+		dataAddr  meta.NetInfo
+		dataExtra []meta.NetInfo
+		port      = strconv.Itoa(config.HostNet.Port)
+		proto     = config.Net.HTTP.Proto
 	)
 	addrList, err := getLocalIPv4s(config)
 	if err != nil {
@@ -385,6 +372,10 @@ func (h *htrun) initSnode(config *cmn.Config) {
 			s = " (config: " + config.HostNet.HostnameIntraData + ")"
 		}
 		nlog.Infof("%s access: %v%s", cmn.NetIntraData, dataAddr, s)
+
+		// multi-home (when config.HostNet.HostnameIntraDataExtra is specified)
+		// using the same intra-data port
+		dataExtra = dataMultihome(config.HostNet.HostnameIntraDataExtra, proto, idport)
 	}
 
 	// 3. validate
@@ -418,12 +409,18 @@ func (h *htrun) initSnode(config *cmn.Config) {
 		PubNet:     pubAddr,
 		ControlNet: ctrlAddr,
 		DataNet:    dataAddr,
+		Codecs:     meta.SnodeCodecMsgpack | meta.SnodeCodecZstd,
 	}
 	if l := len(pubExtra); l > 0 {
 		h.si.PubExtra = make([]meta.NetInfo, l)
 		copy(h.si.PubExtra, pubExtra)
 		nlog.Infof("%s (multihome) access: %v and %v", cmn.NetPublic, pubAddr, h.si.PubExtra)
 	}
+	if l := len(dataExtra); l > 0 {
+		h.si.DataExtra = dataExtra
+		nlog.Infof("%s (multihome) access: %v and %v", cmn.NetIntraData, dataAddr, h.si.DataExtra)
+	}
+	meta.SetLocalDataNets(localDataNets(addrList, h.si))
 }
 
 func mustDiffer(ip1 meta.NetInfo, port1 int, use1 bool, ip2 meta.NetInfo, port2 int, use2 bool, tag string) {
@@ -481,6 +478,10 @@ func (h *htrun) setDaemonConfigMsg(w http.ResponseWriter, r *http.Request, msg *
 		h.writeErrf(w, r, cmn.FmtErrMorphUnmarshal, h, msg.Action, msg.Value, err)
 		return
 	}
+	if cos.IsParseBool(query.Get(apc.QparamValidateOnly)) {
+		h.validateConfig(w, r, toUpdate, apc.Daemon)
+		return
+	}
 
 	co := h.owner.config
 	co.Lock()
@@ -501,6 +502,10 @@ func (h *htrun) setDaemonConfigQuery(w http.ResponseWriter, r *http.Request) {
 		h.writeErr(w, r, err)
 		return
 	}
+	if cos.IsParseBool(query.Get(apc.QparamValidateOnly)) {
+		h.validateConfig(w, r, toUpdate, apc.Daemon)
+		return
+	}
 
 	co := h.owner.config
 	co.Lock()
@@ -511,6 +516,23 @@ func (h *htrun) setDaemonConfigQuery(w http.ResponseWriter, r *http.Request) {
 	}
 }
 
+// set-config in validate-only mode: apply the update to a copy of the current config
+// and reply with all resulting violations (empty list when valid)
+func (h *htrun) validateConfig(w http.ResponseWriter, r *http.Request, toUpdate *cmn.ConfigToSet, asType string) {
+	var (
+		clone      = cmn.GCO.Clone()
+		violations = make([]string, 0, 4)
+	)
+	if err := clone.Apply(toUpdate, asType); err != nil {
+		violations = append(violations, err.Error())
+	} else {
+		for _, err := range clone.ValidateAll() {
+			violations = append(violations, err.Error())
+		}
+	}
+	h.writeJSON(w, r, violations, "validate-config")
+}
+
 func (h *htrun) run(config *cmn.Config) error {
 	var (
 		tlsConf *tls.Config
@@ -533,6 +555,15 @@ func (h *htrun) run(config *cmn.Config) error {
 		go func() {
 			_ = g.netServ.data.listen(h.si.DataNet.TCPEndpoint(), logger, tlsConf, config)
 		}()
+		for _, dataExtra := range h.si.DataExtra {
+			debug.Assert(dataExtra.Port == h.si.DataNet.Port, "expecting the same TCP port for all multi-home interfaces")
+			data := g.netServ.data
+			server := &netServer{muxers: data.muxers, sndRcvBufSize: data.sndRcvBufSize, tos: data.tos}
+			go func() {
+				_ = server.listen(dataExtra.TCPEndpoint(), logger, tlsConf, config)
+			}()
+			g.netServ.dataExtra = append(g.netServ.dataExtra, server)
+		}
 	}
 
 	ep := h.si.PubNet.TCPEndpoint()
@@ -541,7 +572,7 @@ func (h *htrun) run(config *cmn.Config) error {
 	} else if len(h.si.PubExtra) > 0 {
 		for _, pubExtra := range h.si.PubExtra {
 			debug.Assert(pubExtra.Port == h.si.PubNet.Port, "expecting the same TCP port for all multi-home interfaces")
-			server := &netServer{muxers: g.netServ.pub.muxers, sndRcvBufSize: g.netServ.pub.sndRcvBufSize}
+			server := &netServer{muxers: g.netServ.pub.muxers, lim: g.netServ.pub.lim, sndRcvBufSize: g.netServ.pub.sndRcvBufSize}
 			go func() {
 				_ = server.listen(pubExtra.TCPEndpoint(), logger, tlsConf, config)
 			}()
@@ -606,6 +637,7 @@ func (h *htrun) _call(si *meta.Snode, bargs *bcastArgs, results *bcastResults) {
 	{
 		cargs.si = si
 		cargs.req = bargs.req
+		cargs.mbody = bargs.mbody
 		cargs.timeout = bargs.timeout
 	}
 	cargs.req.Base = si.URL(bargs.network)
@@ -643,16 +675,21 @@ func (h *htrun) call(args *callArgs, smap *smapX) (res *callResult) {
 	if args.req.Base == "" && args.si != nil {
 		args.req.Base = args.si.ControlNet.URL // by default, use intra-cluster control network
 	}
+	hreq := &args.req
+	if args.mbody != nil && args.si != nil && args.si.AcceptsMsgpack() {
+		mreq := args.req
+		mreq.Body, hreq = args.mbody, &mreq
+	}
 
 	switch args.timeout {
 	case apc.DefaultTimeout:
-		req, res.err = args.req.Req()
+		req, res.err = hreq.Req()
 		if res.err != nil {
 			break
 		}
 		client = g.client.control // timeout = config.Client.Timeout ("client.client_timeout")
 	case apc.LongTimeout:
-		req, res.err = args.req.Req()
+		req, res.err = hreq.Req()
 		if res.err != nil {
 			break
 		}
@@ -662,7 +699,7 @@ func (h *htrun) call(args *callArgs, smap *smapX) (res *callResult) {
 		if args.timeout == 0 {
 			args.timeout = cmn.Rom.CplaneOperation()
 		}
-		req, _, cancel, res.err = args.req.ReqWithTimeout(args.timeout)
+		req, _, cancel, res.err = hreq.ReqWithTimeout(args.timeout)
 		if res.err != nil {
 			break
 		}
@@ -697,6 +734,9 @@ func (h *htrun) call(args *callArgs, smap *smapX) (res *callResult) {
 	req.Header.Set(apc.HdrCallerID, h.SID())
 	req.Header.Set(apc.HdrCallerName, h.si.Name())
 	req.Header.Set(cos.HdrUserAgent, ua)
+	if hreq != &args.req {
+		req.Header.Set(cos.HdrContentType, cos.ContentMsgPack)
+	}
 
 	resp, res.err = client.Do(req)
 	if res.err != nil {
@@ -856,6 +896,13 @@ func (h *htrun) bcastNodes(bargs *bcastArgs) sliceResults {
 	if !bargs.async {
 		results.s = allocBcastRes(len(bargs.nodes))
 	}
+	if bargs.amsg != nil {
+		for _, nodeMap := range bargs.nodes {
+			for _, si := range nodeMap {
+				bargs.marshal(si)
+			}
+		}
+	}
 	for _, nodeMap := range bargs.nodes {
 		for _, si := range nodeMap {
 			if si.ID() == h.si.ID() {
@@ -882,6 +929,11 @@ func (h *htrun) bcastSelected(bargs *bcastArgs) sliceResults {
 	if !bargs.async {
 		results.s = allocBcastRes(len(bargs.selected))
 	}
+	if bargs.amsg != nil {
+		for _, si := range bargs.selected {
+			bargs.marshal(si)
+		}
+	}
 	for _, si := range bargs.selected {
 		debug.Assert(si.ID() != h.si.ID())
 		wg.Add(1)
@@ -966,13 +1018,19 @@ func (h *htrun) writeMsgPack(w http.ResponseWriter, v msgp.Encodable, tag string
 }
 
 func (h *htrun) writeJSON(w http.ResponseWriter, r *http.Request, v any, tag string) {
-	if err := _writejs(w, r, v); err != nil {
-		h.logerr(tag, v, err)
-	}
+	h.writeJS(w, r, v, tag)
 }
 
 // same as above with boolean return to facilitate early termination
 func (h *htrun) writeJS(w http.ResponseWriter, r *http.Request, v any, tag string) bool {
+	if fields := qfields(r); fields != "" {
+		out, err := cos.SelectFields(v, fields)
+		if err != nil {
+			h.writeErr(w, r, err)
+			return false
+		}
+		v = out
+	}
 	if err := _writejs(w, r, v); err != nil {
 		h.logerr(tag, v, err)
 		return false
@@ -980,6 +1038,14 @@ func (h *htrun) writeJS(w http.ResponseWriter, r *http.Request, v any, tag strin
 	return true
 }
 
+// apc.QparamFields, if requested
+func qfields(r *http.Request) string {
+	if r == nil || !strings.Contains(r.URL.RawQuery, apc.QparamFields+"=") {
+		return ""
+	}
+	return r.URL.Query().Get(apc.QparamFields)
+}
+
 func _writejs(w http.ResponseWriter, r *http.Request, v any) (err error) {
 	hdr := w.Header()
 	hdr.Set(cos.HdrContentType, cos.ContentJSONCharsetUTF)
@@ -1092,6 +1158,8 @@ func (h *htrun) httpdaeget(w http.ResponseWriter, r *http.Request, query url.Val
 		out = *config
 		out.Auth.Secret = "**********"
 		body = &out
+	case apc.WhatConfigSchema:
+		body = configSchema()
 	case apc.WhatSmap:
 		body = h.owner.smap.get()
 	case apc.WhatBMD:
@@ -1111,15 +1179,23 @@ func (h *htrun) httpdaeget(w http.ResponseWriter, r *http.Request, query url.Val
 				err := os.RemoveAll(tempdir)
 				debug.AssertNoErr(err)
 			}
+		} else if isLogQuery(query) {
+			h.queryLog(w, r, query)
 		} else {
 			h.sendOneLog(w, r, query)
 		}
 		return
 	case apc.WhatNodeStats:
+		if h.statsHistory(w, r, query) {
+			return
+		}
 		statsNode := h.statsT.GetStats()
 		statsNode.Snode = h.si
 		body = statsNode
 	case apc.WhatNodeStatsV322:
+		if h.statsHistory(w, r, query) {
+			return
+		}
 		statsNode := h.statsT.GetStatsV322()
 		statsNode.Snode = h.si
 		body = statsNode
@@ -1139,6 +1215,36 @@ func (h *htrun) httpdaeget(w http.ResponseWriter, r *http.Request, query url.Val
 	h.writeJSON(w, r, body, "httpdaeget-"+what)
 }
 
+// what=(stats | node_stats) with (since, until) query
+// returns false when not a history request
+func (h *htrun) statsHistory(w http.ResponseWriter, r *http.Request, query url.Values) bool {
+	var (
+		since, until int64
+		err          error
+	)
+	if !query.Has(apc.QparamSince) && !query.Has(apc.QparamUntil) {
+		return false
+	}
+	if s := query.Get(apc.QparamSince); s != "" {
+		if since, err = cos.S2UnixNano(s); err != nil {
+			h.writeErrf(w, r, "invalid %q query (expecting Unix time in nanoseconds): %v", apc.QparamSince, err)
+			return true
+		}
+	}
+	if s := query.Get(apc.QparamUntil); s != "" {
+		if until, err = cos.S2UnixNano(s); err != nil {
+			h.writeErrf(w, r, "invalid %q query (expecting Unix time in nanoseconds): %v", apc.QparamUntil, err)
+			return true
+		}
+	}
+	if until != 0 && until < since {
+		h.writeErrf(w, r, "invalid stats history range: until (%d) < since (%d)", until, since)
+		return true
+	}
+	h.writeJSON(w, r, h.statsT.GetStatsHistory(since, until), "stats-history")
+	return true
+}
+
 func (h *htrun) statsAndStatus() (ds *stats.NodeStatus) {
 	smap := h.owner.smap.get()
 	ds = &stats.NodeStatus{
@@ -1604,15 +1710,22 @@ func (h *htrun) extractEtlMD(payload msPayload, caller string) (newMD *etlMD, ms
 
 func (h *htrun) extractSmap(payload msPayload, caller string, skipValidation bool) (newSmap *smapX, msg *aisMsg, err error) {
 	if _, ok := payload[revsSmapTag]; !ok {
-		return
-	}
-	newSmap, msg = &smapX{}, &aisMsg{}
-	smapValue := payload[revsSmapTag]
-	reader := bytes.NewBuffer(smapValue)
-	if _, err1 := jsp.Decode(io.NopCloser(reader), newSmap, newSmap.JspOpts(), "extractSmap"); err1 != nil {
-		err = fmt.Errorf(cmn.FmtErrUnmarshal, h, "new Smap", cos.BHead(smapValue), err1)
-		return
+		if _, ok := payload[revsSmapDeltaTag]; !ok {
+			return
+		}
+		if newSmap, err = h.applySmapDelta(payload[revsSmapDeltaTag]); err != nil {
+			return
+		}
+	} else {
+		newSmap = &smapX{}
+		smapValue := payload[revsSmapTag]
+		reader := bytes.NewBuffer(smapValue)
+		if _, err1 := jsp.Decode(io.NopCloser(reader), newSmap, newSmap.JspOpts(), "extractSmap"); err1 != nil {
+			err = fmt.Errorf(cmn.FmtErrUnmarshal, h, "new Smap", cos.BHead(smapValue), err1)
+			return
+		}
 	}
+	msg = &aisMsg{}
 	if msgValue, ok := payload[revsSmapTag+revsActionTag]; ok {
 		if err1 := jsoniter.Unmarshal(msgValue, msg); err1 != nil {
 			err = fmt.Errorf(cmn.FmtErrUnmarshal, h, "action message", cos.BHead(msgValue), err1)
@@ -1659,6 +1772,26 @@ func (h *htrun) extractSmap(payload msPayload, caller string, skipValidation boo
 	return
 }
 
+// (see metasyncer.smapDelta)
+func (h *htrun) applySmapDelta(b []byte) (*smapX, error) {
+	delta, err := meta.UnpackSmapDelta(b)
+	if err != nil {
+		return nil, fmt.Errorf(cmn.FmtErrUnmarshal, h, "Smap delta", cos.BHead(b), err)
+	}
+	smap := h.owner.smap.get()
+	if smap == nil || smap.Version != delta.Base {
+		// the primary will retry with the entire Smap (see metasyncer.handlePending)
+		return nil, fmt.Errorf("%s: cannot apply %s to %s", h, delta, smap)
+	}
+	applied, err := delta.Apply(&smap.Smap, smap.digest())
+	if err != nil {
+		return nil, fmt.Errorf("%s: %v", h, err)
+	}
+	newSmap := &smapX{Smap: *applied}
+	newSmap.setDigest(delta.Digest)
+	return newSmap, nil
+}
+
 func (h *htrun) extractRMD(payload msPayload, caller string) (newRMD *rebMD, msg *aisMsg, err error) {
 	if _, ok := payload[revsRMDTag]; !ok {
 		return
@@ -1837,7 +1970,9 @@ func (h *htrun) extractRevokedTokenList(payload msPayload, caller string) (*toke
 //   - config.Proxy.PrimaryURL   ("primary_url")
 //   - config.Proxy.DiscoveryURL ("discovery_url")
 //   - config.Proxy.OriginalURL  ("original_url")
-//   - if these fails we try the candidates provided by the caller.
+//   - if these fails we try the candidates provided by the caller
+//   - and the proxies resolved via config.Proxy.DiscoveryDNS ("discovery_dns"), if configured
+//     (re-resolved upon every retry - see discovery.go).
 //
 // ================================== Background =========================================
 func (h *htrun) join(query url.Values, htext htext, contactURLs ...string) (res *callResult, err error) {
@@ -1868,6 +2003,9 @@ func (h *htrun) join(query url.Values, htext htext, contactURLs ...string) (res
 
 	sleep := max(2*time.Second, cmn.Rom.MaxKeepalive())
 	for range 4 { // retry
+		for _, u := range dnsCandidates(config) {
+			candidates = _addCan(u, selfPublicURL.Host, selfIntraURL.Host, candidates)
+		}
 		for _, candidateURL := range candidates {
 			if nlog.Stopping() {
 				return res, h.errStopping()
@@ -1988,6 +2126,11 @@ func (h *htrun) fastKalive(smap *smapX, timeout time.Duration, ecActive bool) (s
 		hdr := make(http.Header, lenhdr)
 		hdr.Set(apc.HdrActiveEC, "true")
 		cargs.req.Header = hdr
+	} else if h.si.IsProxy() {
+		// (proxy => primary; see staffIC)
+		hdr := make(http.Header, lenhdr)
+		hdr.Set(apc.HdrNodeLoad, strconv.FormatInt(icLoad(), 10))
+		cargs.req.Header = hdr
 	}
 
 	res := h.call(cargs, smap)
@@ -2286,6 +2429,10 @@ func ptLatency(tts int64, ptime, isPrimary string) (dur int64) {
 
 func (*htrun) readAisMsg(w http.ResponseWriter, r *http.Request) (msg *aisMsg, err error) {
 	msg = &aisMsg{}
+	if r.Header.Get(cos.HdrContentType) == cos.ContentMsgPack {
+		err = msg.readMsgpack(w, r) // (negotiated - see aismsg.go)
+		return
+	}
 	err = cmn.ReadJSON(w, r, msg)
 	return
 }
@@ -13,10 +13,11 @@ import (
 
 type global struct {
 	netServ struct {
-		pub      *netServer
-		pubExtra []*netServer
-		control  *netServer
-		data     *netServer
+		pub       *netServer
+		pubExtra  []*netServer
+		control   *netServer
+		data      *netServer
+		dataExtra []*netServer
 	}
 	client struct {
 		control *http.Client // http client for intra-cluster comm
@@ -62,6 +63,7 @@ func initCtrlClient(config *cmn.Config) {
 		Timeout:         config.Client.Timeout.D(),
 		WriteBufferSize: defaultControlWriteBufferSize,
 		ReadBufferSize:  defaultControlReadBufferSize,
+		TOS:             config.NetTOS(cmn.NetIntraControl),
 	}
 	if config.Net.HTTP.UseHTTPS {
 		g.client.control = cmn.NewIntraClientTLS(cargs, config)
@@ -83,6 +85,7 @@ func initDataClient(config *cmn.Config) {
 		Timeout:         config.Client.TimeoutLong.D(),
 		WriteBufferSize: wbuf,
 		ReadBufferSize:  rbuf,
+		TOS:             config.NetTOS(cmn.NetIntraData),
 	}
 	if config.Net.HTTP.UseHTTPS {
 		g.client.data = cmn.NewIntraClientTLS(cargs, config)
@@ -103,4 +106,7 @@ func shuthttp() {
 	if config.HostNet.UseIntraData {
 		g.netServ.data.shutdown(config)
 	}
+	for _, server := range g.netServ.dataExtra {
+		server.shutdown(config)
+	}
 }
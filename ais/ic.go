@@ -10,7 +10,9 @@ import (
 	"io"
 	"net/http"
 	"net/url"
+	"sort"
 	"strconv"
+	"sync"
 	"time"
 
 	"github.com/NVIDIA/aistore/api/apc"
@@ -20,6 +22,7 @@ import (
 	"github.com/NVIDIA/aistore/cmn/nlog"
 	"github.com/NVIDIA/aistore/core/meta"
 	"github.com/NVIDIA/aistore/nl"
+	"github.com/NVIDIA/aistore/sys"
 	"github.com/NVIDIA/aistore/xact"
 	jsoniter "github.com/json-iterator/go"
 )
@@ -38,6 +41,9 @@ const (
 	// that have no associated cache other than start/end timestamps and stats counters
 	// (case in point: list/query-objects that MAY be cached, etc.)
 	equalIC = "\x00"
+
+	// upper bound on the server-side (long-poll) wait for xaction to finish (see apc.QparamWait)
+	maxXactWait = 5 * time.Minute
 )
 
 type (
@@ -60,12 +66,21 @@ type (
 	}
 
 	ic struct {
-		p *proxy
+		p     *proxy
+		loads icLoads
+	}
+
+	// proxies' load, as reported via keepalive (see staffIC)
+	// NOTE: primary only; not preserved across primary changes
+	icLoads struct {
+		m  map[string]int64 // [pid => load]
+		mu sync.RWMutex
 	}
 )
 
 func (ic *ic) init(p *proxy) {
 	ic.p = p
+	ic.loads.m = make(map[string]int64, 8)
 }
 
 func (ic *ic) reverseToOwner(w http.ResponseWriter, r *http.Request, uuid string, msg any) (reversedOrFailed bool) {
@@ -252,6 +267,16 @@ func (ic *ic) xstatusOne(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	// optional long-poll
+	if s := r.URL.Query().Get(apc.QparamWait); s != "" {
+		wait, err := time.ParseDuration(s)
+		if err != nil || wait < 0 {
+			ic.p.writeErrStatusf(w, r, http.StatusBadRequest, "invalid %s=%q: expecting non-negative duration", apc.QparamWait, s)
+			return
+		}
+		ic.waitFinished(r, nl, min(wait, maxXactWait))
+	}
+
 	// refresh NotifStatus
 	var (
 		config   = cmn.GCO.Get()
@@ -268,6 +293,33 @@ func (ic *ic) xstatusOne(w http.ResponseWriter, r *http.Request) {
 	w.Write(b)
 }
 
+// block until the xaction finishes (or aborts), the `wait` times out, or the client goes away
+func (*ic) waitFinished(r *http.Request, nl nl.Listener, wait time.Duration) {
+	if wait == 0 || nl.Finished() {
+		return
+	}
+	var (
+		timer  = time.NewTimer(wait)
+		ticker = time.NewTicker(cos.ProbingFrequency(wait))
+	)
+	defer func() {
+		timer.Stop()
+		ticker.Stop()
+	}()
+	for {
+		select {
+		case <-ticker.C:
+			if nl.Finished() {
+				return
+			}
+		case <-timer.C:
+			return
+		case <-r.Context().Done():
+			return
+		}
+	}
+}
+
 // verb /v1/ic
 func (ic *ic) handler(w http.ResponseWriter, r *http.Request) {
 	switch r.Method {
@@ -457,3 +509,68 @@ func (ic *ic) syncICBundle() error {
 	}
 	return nil
 }
+
+/////////////
+// icLoads //
+/////////////
+
+// this proxy's load: 1-minute load average as a percentage of available CPUs
+// (non-primary proxies report it to primary via fast keepalive)
+func icLoad() int64 {
+	avg, err := sys.LoadAverage()
+	if err != nil {
+		return 0
+	}
+	return int64(avg.One * 100 / float64(sys.NumCPU()))
+}
+
+// (primary) record load reported by a given proxy
+func (l *icLoads) recv(pid string, hdr http.Header) {
+	s := hdr.Get(apc.HdrNodeLoad)
+	if s == "" {
+		return
+	}
+	load, err := strconv.ParseInt(s, 10, 64)
+	if err != nil || load < 0 {
+		return
+	}
+	l.mu.Lock()
+	l.m[pid] = load
+	l.mu.Unlock()
+}
+
+// returns either current IC members or electable non-IC candidates, sorted by load in ascending order;
+// proxies that haven't reported their load yet (e.g., just joined) are considered idle
+func (l *icLoads) sorted(smap *smapX, members bool) []*meta.Snode {
+	var (
+		all   = make([]*meta.Snode, 0, len(smap.Pmap))
+		loads = make(map[string]int64, len(smap.Pmap))
+	)
+	l.mu.Lock()
+	for pid, psi := range smap.Pmap {
+		if members != smap.IsIC(psi) {
+			continue
+		}
+		if !members && (psi.Flags.IsSet(meta.SnodeNonElectable) || psi.InMaintOrDecomm()) {
+			continue
+		}
+		all = append(all, psi)
+		loads[pid] = l.m[pid]
+	}
+	// cleanup departed
+	for pid := range l.m {
+		if _, ok := smap.Pmap[pid]; !ok {
+			delete(l.m, pid)
+		}
+	}
+	l.mu.Unlock()
+
+	sort.Slice(all, func(i, j int) bool {
+		li, lj := loads[all[i].ID()], loads[all[j].ID()]
+		if li != lj {
+			return li < lj
+		}
+		return all[i].ID() < all[j].ID()
+	})
+	return all
+}
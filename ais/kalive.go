@@ -275,17 +275,7 @@ func (pkr *palive) updateSmap(config *cmn.Config) (stopped bool) {
 				continue
 			}
 
-			// direct call first
-			started := mono.NanoTime()
-			if _, _, err := pkr.p.reqHealth(si, config.Timeout.CplaneOperation.D(), nil, smap, false /*retry pub-addr*/); err == nil {
-				now := mono.NanoTime()
-				pkr.statsT.Add(stats.KeepAliveLatency, now-started)
-				pkr.hb.HeardFrom(si.ID(), now) // effectively, yes
-				continue
-			}
-			// otherwise, go keepalive with retries
-
-			pkr.statsT.IncErr(stats.ErrKaliveCount)
+			// (in very large clusters, the number of nodes to ping may be in the thousands)
 			wg.Add(1)
 			go pkr.goping(si, wg, smap, config)
 		}
@@ -296,6 +286,7 @@ func (pkr *palive) updateSmap(config *cmn.Config) (stopped bool) {
 		return
 	}
 	if len(pkr.toRemoveCh) == 0 {
+		pkr.restaffIC(smap, config)
 		return
 	}
 	ctx := &smapModifier{pre: pkr._pre, final: pkr._final}
@@ -310,12 +301,24 @@ func (pkr *palive) updateSmap(config *cmn.Config) (stopped bool) {
 	return
 }
 
-// "slow-ping"
+// ping, and "slow-ping" if need be
 func (pkr *palive) goping(si *meta.Snode, wg cos.WG, smap *smapX, config *cmn.Config) {
 	if len(pkr.stoppedCh) > 0 {
 		wg.Done()
 		return
 	}
+	// direct call first
+	started := mono.NanoTime()
+	if _, _, err := pkr.p.reqHealth(si, config.Timeout.CplaneOperation.D(), nil, smap, false /*retry pub-addr*/); err == nil {
+		now := mono.NanoTime()
+		pkr.statsT.Add(stats.KeepAliveLatency, now-started)
+		pkr.hb.HeardFrom(si.ID(), now) // effectively, yes
+		wg.Done()
+		return
+	}
+	// otherwise, keepalive with retries
+	pkr.statsT.IncErr(stats.ErrKaliveCount)
+
 	ok, stopped := pkr._pingRetry(si, smap, config)
 	if stopped {
 		pkr.stoppedCh <- struct{}{}
@@ -379,7 +382,7 @@ loop:
 			metaction += " ["
 			if clone.GetProxy(sid) != nil {
 				clone.delProxy(sid)
-				clone.staffIC()
+				clone.staffIC(&pkr.p.ic.loads)
 				metaction += apc.Proxy
 				cnt++
 			} else if clone.GetTarget(sid) != nil {
@@ -406,6 +409,31 @@ loop:
 	return nil
 }
 
+// IC size may change via config.Proxy.ICCount or, when auto-scaling, with the number of proxies
+// (the latter - upon join/remove/maintenance, the former - here)
+func (pkr *palive) restaffIC(smap *smapX, config *cmn.Config) {
+	if !smap.needStaffIC(config) {
+		return
+	}
+	ctx := &smapModifier{pre: pkr._preIC, final: pkr._final}
+	if err := pkr.p.owner.smap.modify(ctx); err != nil {
+		nlog.Warningln(err)
+	}
+}
+
+func (pkr *palive) _preIC(ctx *smapModifier, clone *smapX) error {
+	ctx.smap = pkr.p.owner.smap.get()
+	if !ctx.smap.isPrimary(pkr.p.si) {
+		return newErrNotPrimary(pkr.p.si, ctx.smap)
+	}
+	count := clone.ICCount()
+	if nc := clone.staffIC(&pkr.p.ic.loads); nc == count {
+		return fmt.Errorf("%s: nothing to do [%s, IC: %s]", pkr.p.si, ctx.smap.StringEx(), clone.StrIC(nil))
+	}
+	ctx.msg = &apc.ActMsg{Value: "keepalive: staffing IC [" + clone.StrIC(nil) + "]"}
+	return nil
+}
+
 func (pkr *palive) _final(ctx *smapModifier, clone *smapX) {
 	msg := pkr.p.newAmsg(ctx.msg, nil)
 	debug.Assert(clone._sgl != nil)
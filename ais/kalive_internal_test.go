@@ -5,8 +5,13 @@
 package ais
 
 import (
+	"fmt"
 	"testing"
 	"time"
+
+	"github.com/NVIDIA/aistore/api/apc"
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/core/meta"
 )
 
 func TestHB(t *testing.T) {
@@ -49,3 +54,55 @@ func TestHB(t *testing.T) {
 		t.Fatal("Expecting timeout")
 	}
 }
+
+func TestStaffIC(t *testing.T) {
+	var (
+		smap  = newSmap()
+		loads = icLoads{m: make(map[string]int64)}
+	)
+	addProxies := func(from, to int) {
+		for i := from; i < to; i++ {
+			pid := fmt.Sprintf("p%02d", i)
+			smap.addProxy(newSnode(pid, apc.Proxy, meta.NetInfo{}, meta.NetInfo{}, meta.NetInfo{}))
+			loads.m[pid] = int64(100 - i) // the higher the index, the lower the load
+		}
+	}
+	addProxies(0, 20)
+	smap.Primary = smap.GetProxy("p00")
+
+	// 20 proxies: default IC size, and the two least loaded (in addition to primary)
+	if count := smap.staffIC(&loads); count != meta.DfltCountIC {
+		t.Fatalf("expecting IC count %d, got %d", meta.DfltCountIC, count)
+	}
+	for _, pid := range []string{"p00", "p19", "p18"} {
+		if !smap.IsIC(smap.GetProxy(pid)) {
+			t.Fatalf("expecting %s to be IC member (%s)", pid, smap.StrIC(nil))
+		}
+	}
+
+	// auto-scale
+	addProxies(20, 40)
+	if count, want := smap.staffIC(&loads), meta.AutoCountIC(40); count != want {
+		t.Fatalf("expecting IC count %d, got %d", want, count)
+	}
+
+	// configured: unstaff the most loaded while keeping primary
+	oldConf := cmn.GCO.Get()
+	config := cmn.GCO.BeginUpdate()
+	config.Proxy.ICCount = 2
+	cmn.GCO.CommitUpdate(config)
+	defer func() {
+		cmn.GCO.BeginUpdate()
+		cmn.GCO.CommitUpdate(oldConf)
+	}()
+
+	if count := smap.staffIC(&loads); count != 2 {
+		t.Fatalf("expecting IC count 2, got %d", count)
+	}
+	if !smap.IsIC(smap.Primary) || !smap.IsIC(smap.GetProxy("p39")) {
+		t.Fatalf("expecting primary and the least loaded proxy to remain in IC (%s)", smap.StrIC(nil))
+	}
+	if smap.needStaffIC(cmn.GCO.Get()) {
+		t.Fatal("expecting fully staffed IC")
+	}
+}
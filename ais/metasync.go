@@ -17,11 +17,13 @@ import (
 	"github.com/NVIDIA/aistore/cmn/atomic"
 	"github.com/NVIDIA/aistore/cmn/cos"
 	"github.com/NVIDIA/aistore/cmn/debug"
+	"github.com/NVIDIA/aistore/cmn/feat"
 	"github.com/NVIDIA/aistore/cmn/jsp"
 	"github.com/NVIDIA/aistore/cmn/nlog"
 	"github.com/NVIDIA/aistore/core"
 	"github.com/NVIDIA/aistore/core/meta"
 	"github.com/NVIDIA/aistore/memsys"
+	"github.com/NVIDIA/aistore/stats"
 	jsoniter "github.com/json-iterator/go"
 )
 
@@ -89,6 +91,8 @@ const (
 
 	revsMaxTags   = 6         // NOTE
 	revsActionTag = "-action" // prefix revs tag
+
+	revsSmapDeltaTag = "Smap-delta" // in place of revsSmapTag - see feat.CompactSmap and meta.SmapDelta
 )
 
 const (
@@ -316,6 +320,14 @@ func (y *metasyncer) do(pairs []revsPair, reqT int) (failedCnt int) {
 			revsBody = revs.marshal()
 		} else {
 			revs = y.jit(pair)
+			if tag == revsSmapTag {
+				if delta := y.smapDelta(revs.(*smapX)); delta != nil {
+					y.lastSynced[tag] = revs
+					payload[revsSmapDeltaTag] = delta
+					payload[tag+revsActionTag] = cos.MustMarshal(msg)
+					continue
+				}
+			}
 
 			// in an unlikely event, the revs may still carry sgl that has been freed
 			// via becomeNonPrimary => y.free() sequence; checking sgl.IsNil() is a compromise
@@ -342,26 +354,39 @@ func (y *metasyncer) do(pairs []revsPair, reqT int) (failedCnt int) {
 
 	// step: bcast
 	var (
+		hdr     http.Header
+		saved   int64
 		urlPath = apc.URLPathMetasync.S
 		body    = payload.marshal(y.p.gmm)
 		to      = core.AllNodes
 		smap    = y.p.owner.smap.get()
 		retries = retrySyncRefused // connection-refused
 	)
-	defer body.Free()
-
 	if reqT == reqNotify {
 		to = core.Targets
 		retries = retryNotifyRefused
 	}
+	if above := cmn.GCO.Get().Net.HTTP.CompressAbove; above > 0 && body.Len() > int64(above) && acceptZstd(smap, to) {
+		if zbody := y.compress(body); zbody != nil {
+			saved = body.Len() - zbody.Len()
+			body.Free()
+			body, hdr = zbody, http.Header{cos.HdrContentEncoding: []string{cos.ContentZstd}}
+		}
+	}
+	defer body.Free()
+
 	args := allocBcArgs()
-	args.req = cmn.HreqArgs{Method: method, Path: urlPath, BodyR: body}
+	args.req = cmn.HreqArgs{Method: method, Path: urlPath, BodyR: body, Header: hdr}
 	args.smap = smap
 	args.timeout = cmn.Rom.MaxKeepalive() // making exception for this critical op
 	args.to = to
 	args.ignoreMaintenance = true
 	results := y.p.bcastGroup(args)
 	freeBcArgs(args)
+	if saved > 0 {
+		y.p.statsT.Inc(stats.CplaneZstdCount)
+		y.p.statsT.Add(stats.CplaneZstdSavedSize, saved*int64(len(results)))
+	}
 
 	// step: count failures and fill-in refused
 	for _, res := range results {
@@ -406,7 +431,7 @@ func (y *metasyncer) do(pairs []revsPair, reqT int) (failedCnt int) {
 			y.becomeNonPrimary()
 			return 0
 		}
-		if !y.handleRefused(method, urlPath, body, refused, pairs, smap) {
+		if !y.handleRefused(method, urlPath, body, hdr, refused, pairs, smap) {
 			break
 		}
 	}
@@ -423,6 +448,82 @@ func (y *metasyncer) do(pairs []revsPair, reqT int) (failedCnt int) {
 	return failedCnt
 }
 
+// when feat.CompactSmap is enabled: instead of the entire (JSON-encoded) Smap, send
+// only the nodes that have changed since the last sync-ed version - provided all
+// the nodes do have the latter; otherwise, returns nil (to send the entire Smap)
+func (y *metasyncer) smapDelta(smap *smapX) []byte {
+	if !cmn.Rom.Features().IsSet(feat.CompactSmap) {
+		return nil
+	}
+	last, ok := y.lastSynced[revsSmapTag]
+	if !ok {
+		return nil
+	}
+	prev := last.(*smapX)
+	if prev.Version >= smap.Version || prev.UUID != smap.UUID || prev.Ext != nil || smap.Ext != nil {
+		return nil
+	}
+	for _, mm := range []meta.NodeMap{smap.Tmap, smap.Pmap} {
+		for sid, si := range mm {
+			if sid == y.p.SID() {
+				continue
+			}
+			// nodes in maintenance still receive metasync but are not tracked (see syncDone)
+			if si.InMaintOrDecomm() {
+				return nil
+			}
+			if ndr, ok := y.nodesRevs[sid]; !ok || ndr[revsSmapTag] != prev.Version {
+				return nil
+			}
+		}
+	}
+	delta := meta.NewSmapDelta(&prev.Smap, &smap.Smap, prev.digest())
+	smap.setDigest(delta.Digest)
+	if cmn.Rom.FastV(4, cos.SmoduleAIS) {
+		nlog.Infoln(y.p.String(), "sync", delta.String())
+	}
+	return delta.Bytes()
+}
+
+// whether all recipients advertise zstd (older nodes don't - see meta.SnodeCodecZstd)
+func acceptZstd(smap *smapX, to int) bool {
+	for _, si := range smap.Tmap {
+		if !si.AcceptsZstd() {
+			return false
+		}
+	}
+	if to == core.Targets {
+		return true
+	}
+	for _, si := range smap.Pmap {
+		if !si.AcceptsZstd() {
+			return false
+		}
+	}
+	return true
+}
+
+// compress the payload (see cmn.HTTPConf.CompressAbove); nil if not worth it
+func (y *metasyncer) compress(body *memsys.SGL) *memsys.SGL {
+	var (
+		zbody = y.p.gmm.NewSGL(body.Len() >> 2)
+		zw    = cos.NewZstdWriter(zbody)
+		err   = body.WriteTo2(zw)
+	)
+	if err == nil {
+		err = zw.Close()
+	}
+	cos.FreeZstdWriter(zw)
+	if err != nil || zbody.Len() >= body.Len() {
+		if err != nil {
+			nlog.Errorln(y.p.String(), "failed to compress metasync payload:", err)
+		}
+		zbody.Free()
+		return nil
+	}
+	return zbody
+}
+
 func (y *metasyncer) jit(pair revsPair) revs {
 	var (
 		s              string
@@ -465,9 +566,10 @@ func (y *metasyncer) syncDone(si *meta.Snode, pairs []revsPair) {
 	}
 }
 
-func (y *metasyncer) handleRefused(method, urlPath string, body io.Reader, refused meta.NodeMap, pairs []revsPair, smap *smapX) (ok bool) {
+func (y *metasyncer) handleRefused(method, urlPath string, body io.Reader, hdr http.Header, refused meta.NodeMap, pairs []revsPair,
+	smap *smapX) (ok bool) {
 	args := allocBcArgs()
-	args.req = cmn.HreqArgs{Method: method, Path: urlPath, BodyR: body}
+	args.req = cmn.HreqArgs{Method: method, Path: urlPath, BodyR: body, Header: hdr}
 	args.network = cmn.NetIntraControl
 	args.timeout = cmn.Rom.MaxKeepalive()
 	args.nodes = []meta.NodeMap{refused}
@@ -721,6 +823,20 @@ func (payload msPayload) unmarshal(reader io.ReadCloser, tag string) (err error)
 	return
 }
 
+// (the payload may be compressed by the primary - see metasyncer.compress)
+func (payload msPayload) unmarshalReq(r *http.Request, tag string) error {
+	if r.Header.Get(cos.HdrContentEncoding) != cos.ContentZstd {
+		return payload.unmarshal(r.Body, tag)
+	}
+	zr, err := cos.NewZstdReader(r.Body)
+	if err != nil {
+		return err
+	}
+	err = payload.unmarshal(zr, tag)
+	cos.Close(zr)
+	return err
+}
+
 //////////////
 // errMsync //
 //////////////
@@ -22,6 +22,7 @@ import (
 	"github.com/NVIDIA/aistore/cmn"
 	"github.com/NVIDIA/aistore/cmn/atomic"
 	"github.com/NVIDIA/aistore/cmn/cos"
+	"github.com/NVIDIA/aistore/core"
 	"github.com/NVIDIA/aistore/core/meta"
 	"github.com/NVIDIA/aistore/core/mock"
 	"github.com/NVIDIA/aistore/memsys"
@@ -865,6 +866,27 @@ func TestMetasyncReceive(t *testing.T) {
 	}
 }
 
+func TestMetasyncAcceptZstd(t *testing.T) {
+	var (
+		smap = newSmap()
+		ni   = meta.NetInfo{}
+	)
+	for _, id := range []string{"t1", "t2"} {
+		si := newSnode(id, apc.Target, ni, ni, ni)
+		si.Codecs = meta.SnodeCodecMsgpack | meta.SnodeCodecZstd
+		smap.addTarget(si)
+	}
+	p1 := newSnode("p1", apc.Proxy, ni, ni, ni)
+	p1.Codecs = meta.SnodeCodecMsgpack // older node
+	smap.addProxy(p1)
+
+	tassert.Errorf(t, !acceptZstd(smap, core.AllNodes), "expecting no compression: %s does not advertise zstd", p1)
+	tassert.Errorf(t, acceptZstd(smap, core.Targets), "expecting compression: all targets advertise zstd")
+
+	p1.Codecs |= meta.SnodeCodecZstd
+	tassert.Errorf(t, acceptZstd(smap, core.AllNodes), "expecting compression: all nodes advertise zstd")
+}
+
 func testSyncer(p *proxy) (syncer *metasyncer) {
 	syncer = newMetasyncer(p)
 	return
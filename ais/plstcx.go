@@ -7,17 +7,24 @@ package ais
 import (
 	"fmt"
 	"net/http"
+	"os"
+	"path/filepath"
 	"strings"
 	"sync"
+	"time"
 
 	"github.com/NVIDIA/aistore/api/apc"
 	"github.com/NVIDIA/aistore/cmn"
 	"github.com/NVIDIA/aistore/cmn/atomic"
 	"github.com/NVIDIA/aistore/cmn/cos"
 	"github.com/NVIDIA/aistore/cmn/debug"
+	"github.com/NVIDIA/aistore/cmn/fname"
+	"github.com/NVIDIA/aistore/cmn/jsp"
+	"github.com/NVIDIA/aistore/cmn/mono"
 	"github.com/NVIDIA/aistore/cmn/nlog"
 	"github.com/NVIDIA/aistore/core"
 	"github.com/NVIDIA/aistore/core/meta"
+	"github.com/NVIDIA/aistore/hk"
 	"github.com/NVIDIA/aistore/xact"
 	"github.com/NVIDIA/aistore/xact/xs"
 )
@@ -44,9 +51,37 @@ type (
 		altmsg  apc.ActMsg
 		tcomsg  cmn.TCOMsg
 		stopped atomic.Bool
+		// checkpointing
+		ckpt struct {
+			tokens []string // continuation tokens of the most recent (up to tcbCkptLag) pages
+			fpath  string
+			last   int64
+		}
+		resume string // continuation token to resume from
+	}
+	// persistent checkpoint
+	tcbCkpt struct {
+		Xid     string     `json:"xid"`
+		Action  string     `json:"action"`
+		BckFrom cmn.Bck    `json:"bck_from"`
+		BckTo   cmn.Bck    `json:"bck_to"`
+		Msg     apc.TCBMsg `json:"msg"`
+		Token   string     `json:"token"` // continuation token to resume from
+		Cnt     int        `json:"cnt"`   // number of listed (and selected) objects, so far
+		Time    int64      `json:"time"`
 	}
 )
 
+// Copying (or transforming) remote buckets - the `lstcx` below - periodically checkpoints
+// its progress, namely: continuation token of a recently listed page. Interrupted jobs
+// resume upon (this) proxy restart.
+// Given that x-tco executes pages asynchronously, the checkpoint lags behind by
+// `tcbCkptLag` pages: upon resumption, some objects may get copied again.
+const (
+	tcbCkptIval = time.Minute
+	tcbCkptLag  = 4
+)
+
 func (a *lstca) add(c *lstcx) {
 	a.mu.Lock()
 	if a.a == nil {
@@ -95,9 +130,11 @@ func (c *lstcx) do() (string, error) {
 		UUID:     cos.GenUUID(),
 		Prefix:   c.tcomsg.TCBMsg.Prefix,
 		Props:    apc.GetPropsName,
-		PageSize: 0, // i.e., backend.MaxPageSize()
+		PageSize: 0,               // i.e., backend.MaxPageSize()
+		Filter:   c.tcomsg.Filter, // (filtering by targets)
 	}
 	c.lsmsg.SetFlag(apc.LsNameOnly | apc.LsNoDirs)
+	c.lsmsg.ContinuationToken = c.resume
 	c.smap = c.p.owner.smap.get()
 	tsi, err := c.smap.HrwTargetTask(c.lsmsg.UUID)
 	if err != nil {
@@ -106,13 +143,24 @@ func (c *lstcx) do() (string, error) {
 	c.tsi = tsi
 	c.lsmsg.SID = tsi.ID()
 
-	// 2. ls 1st page
-	var lst *cmn.LsoRes
-	lst, err = c.p.lsObjsR(c.bckFrom, &c.lsmsg, c.hdr, c.smap, tsi /*designated target*/, c.config, true)
-	if err != nil {
-		return "", err
+	// 2. ls 1st page (skipping filtered-out pages, if any)
+	var (
+		lst   *cmn.LsoRes
+		names []string
+		token = c.lsmsg.ContinuationToken
+	)
+	for {
+		lst, err = c.p.lsObjsR(c.bckFrom, &c.lsmsg, c.hdr, c.smap, tsi /*designated target*/, c.config, true)
+		if err != nil {
+			return "", err
+		}
+		if names = c.names(lst, names); len(names) > 0 || lst.ContinuationToken == "" {
+			break
+		}
+		token = lst.ContinuationToken
+		c.lsmsg.ContinuationToken = token
 	}
-	if len(lst.Entries) == 0 {
+	if len(names) == 0 {
 		//
 		// TODO: return http.StatusNoContent to indicate exactly that (#6393)
 		//
@@ -125,14 +173,8 @@ func (c *lstcx) do() (string, error) {
 
 	// 4. tcomsg
 	c.tcomsg.ToBck = c.bckTo.Clone()
-	lr, cnt := &c.tcomsg.ListRange, len(lst.Entries)
-	lr.ObjNames = make([]string, 0, cnt)
-	for _, e := range lst.Entries {
-		if e.IsDir() { // NOTE: always skip virtual dir (apc.EntryIsDir)
-			continue
-		}
-		lr.ObjNames = append(lr.ObjNames, e.Name)
-	}
+	c.tcomsg.ListRange.ObjNames = names
+	cnt := len(names)
 
 	// 5. multi-obj action: transform/copy 1st page
 	c.altmsg.Value = &c.tcomsg
@@ -153,6 +195,7 @@ func (c *lstcx) do() (string, error) {
 		// Run
 		nlog.Infoln("run", s, "...")
 		c.lsmsg.ContinuationToken = lst.ContinuationToken
+		c.ckpt.tokens = append(c.ckpt.tokens, token)
 		go c.pages(s, cnt)
 	} else {
 		nlog.Infoln(s, "count", cnt)
@@ -163,17 +206,38 @@ func (c *lstcx) do() (string, error) {
 func (c *lstcx) pages(s string, cnt int) {
 	c.cnt = cnt
 	c.p.lstca.add(c)
+	if !c.tcomsg.DryRun {
+		c.ckpt.fpath = filepath.Join(c.config.ConfigDir, fname.TcbCkptDir, c.xid)
+		c.checkpoint()
+	}
 
 	// pages 2, 3, ...
 	var err error
 	for !c.stopped.Load() && c.lsmsg.ContinuationToken != "" {
+		token := c.lsmsg.ContinuationToken
 		if cnt, err = c._page(); err != nil {
 			break
 		}
 		c.cnt += cnt
+		if c.ckpt.fpath != "" {
+			c.ckpt.tokens = append(c.ckpt.tokens, token)
+			if l := len(c.ckpt.tokens); l > tcbCkptLag {
+				c.ckpt.tokens = c.ckpt.tokens[l-tcbCkptLag:]
+			}
+			if mono.Since(c.ckpt.last) >= tcbCkptIval {
+				c.checkpoint()
+			}
+		}
 	}
 	c.p.lstca.del(c)
 	nlog.Infoln(s, "count", c.cnt, "stopped", c.stopped.Load(), "c-token", c.lsmsg.ContinuationToken, "err", err)
+
+	// keep the checkpoint (to resume upon restart) unless done or aborted
+	if c.ckpt.fpath != "" && (err == nil || c.stopped.Load()) {
+		if errV := cos.RemoveFile(c.ckpt.fpath); errV != nil {
+			nlog.Errorln(s, "failed to remove checkpoint:", errV)
+		}
+	}
 }
 
 // next page
@@ -190,12 +254,8 @@ func (c *lstcx) _page() (int, error) {
 
 	lr := &c.tcomsg.ListRange
 	clear(lr.ObjNames)
-	lr.ObjNames = lr.ObjNames[:0]
-	for _, e := range lst.Entries {
-		if e.IsDir() { // NOTE: always skip virtual dir (apc.EntryIsDir)
-			continue
-		}
-		lr.ObjNames = append(lr.ObjNames, e.Name)
+	if lr.ObjNames = c.names(lst, lr.ObjNames[:0]); len(lr.ObjNames) == 0 {
+		return 0, nil // (filtered out; note that empty list would otherwise mean "entire bucket")
 	}
 	c.altmsg.Name = c.xid
 	c.altmsg.Value = &c.tcomsg
@@ -203,6 +263,99 @@ func (c *lstcx) _page() (int, error) {
 	return len(lr.ObjNames), err
 }
 
+// object names to copy (or transform)
+func (c *lstcx) names(lst *cmn.LsoRes, names []string) []string {
+	for _, e := range lst.Entries {
+		if e.IsDir() { // NOTE: always skip virtual dir (apc.EntryIsDir)
+			continue
+		}
+		names = append(names, e.Name)
+	}
+	return names
+}
+
+func (c *lstcx) checkpoint() {
+	ckpt := &tcbCkpt{
+		Xid:     c.xid,
+		Action:  c.amsg.Action,
+		BckFrom: *c.bckFrom.Bucket(),
+		BckTo:   *c.bckTo.Bucket(),
+		Msg:     c.tcomsg.TCBMsg,
+		Cnt:     c.cnt,
+		Time:    time.Now().UnixNano(),
+	}
+	if len(c.ckpt.tokens) > 0 {
+		ckpt.Token = c.ckpt.tokens[0]
+	}
+	if err := jsp.Save(c.ckpt.fpath, ckpt, jsp.Plain(), nil); err != nil {
+		nlog.Errorln("failed to checkpoint", c.amsg.Action, c.xid+":", err)
+	}
+	c.ckpt.last = mono.NanoTime()
+}
+
+//
+// resume interrupted jobs upon restart
+//
+
+func (p *proxy) regTcbResume(config *cmn.Config) {
+	dir := filepath.Join(config.ConfigDir, fname.TcbCkptDir)
+	if des, err := os.ReadDir(dir); err != nil || len(des) == 0 {
+		return
+	}
+	hk.Reg("tcb-resume"+hk.NameSuffix, func(int64) time.Duration {
+		if !p.ClusterStarted() {
+			return config.Timeout.MaxKeepalive.D()
+		}
+		p.tcbResume(dir)
+		return hk.UnregInterval
+	}, config.Timeout.MaxKeepalive.D())
+}
+
+func (p *proxy) tcbResume(dir string) {
+	des, err := os.ReadDir(dir)
+	if err != nil {
+		nlog.Errorln(err)
+		return
+	}
+	for _, de := range des {
+		fpath := filepath.Join(dir, de.Name())
+		if de.IsDir() || strings.Contains(de.Name(), ".tmp.") {
+			continue
+		}
+		ckpt := &tcbCkpt{}
+		if _, err := jsp.Load(fpath, ckpt, jsp.Plain()); err != nil {
+			nlog.Errorln("failed to load checkpoint:", err)
+		} else if xid, err := p._tcbResume(ckpt); err != nil {
+			nlog.Errorln("failed to resume", ckpt.Action, ckpt.Xid+":", err)
+		} else {
+			nlog.Infoln("resumed", ckpt.Action, ckpt.Xid, "as", xid, "(count", ckpt.Cnt, "c-token", ckpt.Token+")")
+		}
+		if err := cos.RemoveFile(fpath); err != nil {
+			nlog.Errorln(err)
+		}
+	}
+}
+
+func (p *proxy) _tcbResume(ckpt *tcbCkpt) (string, error) {
+	bckFrom, bckTo := meta.CloneBck(&ckpt.BckFrom), meta.CloneBck(&ckpt.BckTo)
+	if err := bckFrom.Init(p.owner.bmd); err != nil {
+		return "", err
+	}
+	if err := bckTo.Init(p.owner.bmd); err != nil {
+		return "", err
+	}
+	c := &lstcx{
+		p:       p,
+		bckFrom: bckFrom,
+		bckTo:   bckTo,
+		amsg:    &apc.ActMsg{Action: ckpt.Action, Value: &ckpt.Msg},
+		config:  cmn.GCO.Get(),
+		resume:  ckpt.Token,
+	}
+	c.tcomsg.TCBMsg = ckpt.Msg
+	return c.do()
+}
+
 // calls t.httpxpost (TODO: slice of names is the only "delta" - optimize)
 func (c *lstcx) bcast() (err error) {
 	body := cos.MustMarshal(c.altmsg)
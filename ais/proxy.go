@@ -62,6 +62,12 @@ type (
 		rproxy     reverseProxy
 		notifs     notifs
 		lstca      lstca
+		pipes      pipelines // composite jobs (see prxpipe)
+		wsteps     wsteps    // gradual set-weight (see prxweight)
+		admit      admission
+		batime     bckAccess // ephemeral buckets: last access (see prxprov)
+		hprobe     hprobe    // deep health check (see prxhealth)
+		capt       capturer  // workload capture (see prxcapture)
 		reg        struct {
 			pool nodeRegPool
 			mu   sync.RWMutex
@@ -196,12 +202,17 @@ func (p *proxy) Run() error {
 	p.bootstrap()
 
 	p.authn = newAuthManager(config)
+	p.authn.embed(config)
 
 	p.rproxy.init()
 
 	p.notifs.init(p)
 	p.ic.init(p)
 	p.qm.init()
+	p.initProvision()
+	p.initFreeze()
+
+	p.regTcbResume(config) // interrupted copy-bucket jobs, if any
 
 	//
 	// REST API: register proxy handlers and start listening
@@ -215,6 +226,7 @@ func (p *proxy) Run() error {
 		{r: apc.Download, h: p.dloadHandler, net: accessNetPublic},
 		{r: apc.ETL, h: p.etlHandler, net: accessNetPublic},
 		{r: apc.Sort, h: p.dsortHandler, net: accessNetPublic},
+		{r: apc.Jobs, h: p.jobsHandler, net: accessNetPublic},
 
 		{r: apc.IC, h: p.ic.handler, net: accessNetIntraControl},
 		{r: apc.Daemon, h: p.daemonHandler, net: accessNetPublicControl},
@@ -444,8 +456,7 @@ func (p *proxy) _parseReqTry(w http.ResponseWriter, r *http.Request, bckArgs *bc
 
 // verb /v1/buckets/
 func (p *proxy) bucketHandler(w http.ResponseWriter, r *http.Request) {
-	if !p.cluStartedWithRetry() {
-		w.WriteHeader(http.StatusServiceUnavailable)
+	if !p.admitted(w, r) {
 		return
 	}
 	switch r.Method {
@@ -622,7 +633,7 @@ func (p *proxy) httpbckget(w http.ResponseWriter, r *http.Request, dpq *dpq) {
 		return
 	}
 
-	// switch (I) through (IV) --------------------------
+	// switch (I) through (V) --------------------------
 
 	// (I) summarize buckets
 	if msg.Action == apc.ActSummaryBck {
@@ -644,13 +655,72 @@ func (p *proxy) httpbckget(w http.ResponseWriter, r *http.Request, dpq *dpq) {
 		return
 	}
 
-	// (II) invalid action
+	// (II) list soft-deleted objects
+	if msg.Action == apc.ActListTrash {
+		if !qbck.IsBucket() {
+			p.writeErrf(w, r, "bad list-trash request: %q is not a bucket", qbck)
+			return
+		}
+		bckArgs := bctx{p: p, w: w, r: r, msg: msg, perms: apc.AceObjLIST, bck: (*meta.Bck)(qbck), dpq: dpq}
+		bckArgs.createAIS = false
+		bckArgs.dontHeadRemote = true
+		if bck, err := bckArgs.initAndTry(); err == nil {
+			p.listTrash(w, r, bck, msg)
+		}
+		return
+	}
+
+	// (II-bis) bulk stat
+	if msg.Action == apc.ActStatObjects {
+		if !qbck.IsBucket() {
+			p.writeErrf(w, r, "bad stat-objects request: %q is not a bucket", qbck)
+			return
+		}
+		smsg := &apc.StatObjsMsg{}
+		if err := cos.MorphMarshal(msg.Value, smsg); err != nil {
+			p.writeErrf(w, r, cmn.FmtErrMorphUnmarshal, p.si, msg.Action, msg.Value, err)
+			return
+		}
+		if strings.Contains(smsg.Prefix, "../") {
+			p.writeErrf(w, r, "bad stat-objects request: invalid prefix %q", smsg.Prefix)
+			return
+		}
+		if smsg.Format != "" && smsg.Format != apc.StatFmtNDJSON && smsg.Format != apc.StatFmtCSV {
+			p.writeErrf(w, r, "bad stat-objects request: invalid format %q (expecting %q or %q)",
+				smsg.Format, apc.StatFmtNDJSON, apc.StatFmtCSV)
+			return
+		}
+		bckArgs := bctx{p: p, w: w, r: r, msg: msg, perms: apc.AceObjLIST | apc.AceObjHEAD, bck: (*meta.Bck)(qbck), dpq: dpq}
+		bckArgs.createAIS = false
+		bckArgs.dontHeadRemote = true
+		if bck, err := bckArgs.initAndTry(); err == nil {
+			p.statObjects(w, r, bck, msg, smsg)
+		}
+		return
+	}
+
+	// (II-ter) deferred backend deletions (write-back)
+	if msg.Action == apc.ActDeleteQueue {
+		if !qbck.IsBucket() {
+			p.writeErrf(w, r, "bad %s request: %q is not a bucket", msg.Action, qbck)
+			return
+		}
+		bckArgs := bctx{p: p, w: w, r: r, msg: msg, perms: apc.AceObjLIST, bck: (*meta.Bck)(qbck), dpq: dpq}
+		bckArgs.createAIS = false
+		bckArgs.dontHeadRemote = true
+		if bck, err := bckArgs.initAndTry(); err == nil {
+			p.deleteQueue(w, r, bck, msg)
+		}
+		return
+	}
+
+	// (III) invalid action
 	if msg.Action != apc.ActList {
 		p.writeErrAct(w, r, msg.Action)
 		return
 	}
 
-	// (III) list buckets
+	// (IV) list buckets
 	if msg.Value == nil {
 		if qbck.Name != "" && qbck.Name != msg.Name {
 			p.writeErrf(w, r, "bad list-buckets request: %q vs %q (%+v, %+v)", qbck.Name, msg.Name, qbck, msg)
@@ -666,7 +736,7 @@ func (p *proxy) httpbckget(w http.ResponseWriter, r *http.Request, dpq *dpq) {
 		return
 	}
 
-	// (IV) list objects (NOTE -- TODO: currently, always forwarding)
+	// (V) list objects (NOTE -- TODO: currently, always forwarding)
 	if !qbck.IsBucket() {
 		p.writeErrf(w, r, "bad list-objects request: %q is not a bucket (is a bucket query?)", qbck)
 		return
@@ -739,6 +809,10 @@ func (p *proxy) httpobjget(w http.ResponseWriter, r *http.Request, origURLBck ..
 	}
 
 	started := time.Now()
+	if !p.checkDeadline(w, r, started, http.MethodGet, bck.Cname(objName)) {
+		p.statsT.IncErr(stats.ErrGetCount)
+		return
+	}
 
 	// 3. redirect
 	smap := p.owner.smap.get()
@@ -757,6 +831,12 @@ func (p *proxy) httpobjget(w http.ResponseWriter, r *http.Request, origURLBck ..
 
 	// 4. stats
 	p.statsT.Inc(stats.GetCount)
+	p.capture(http.MethodGet, bck, objName, r, started)
+
+	// 5. canary (optional)
+	if bck.Props.Shadow.Enabled() {
+		p.shadowGet(bck, objName, r)
+	}
 }
 
 // PUT /v1/objects/bucket-name/object-name
@@ -803,14 +883,29 @@ func (p *proxy) httpobjput(w http.ResponseWriter, r *http.Request, apireq *apiRe
 		return
 	}
 
-	// 3. redirect
+	// 3. object naming policy (normalize, if need be)
+	objName, err := bck.Props.ObjName.Apply(apireq.items[1])
+	if err != nil {
+		p.statsT.IncErr(errcnt)
+		p.writeErr(w, r, err)
+		return
+	}
+	if objName != apireq.items[1] {
+		r.URL.Path = apc.URLPathObjects.Join(bck.Name, objName)
+		r.URL.RawPath = ""
+	}
+
+	// 4. redirect
 	var (
 		tsi     *meta.Snode
 		smap    = p.owner.smap.get()
 		started = time.Now()
-		objName = apireq.items[1]
 		netPub  = cmn.NetPublic
 	)
+	if !appendTyProvided && !p.checkDeadline(w, r, started, http.MethodPut, bck.Cname(objName)) {
+		p.statsT.IncErr(errcnt)
+		return
+	}
 	if nodeID == "" {
 		tsi, netPub, err = smap.HrwMultiHome(bck.MakeUname(objName))
 		if err != nil {
@@ -843,9 +938,10 @@ func (p *proxy) httpobjput(w http.ResponseWriter, r *http.Request, apireq *apiRe
 	redirectURL := p.redirectURL(r, tsi, started, cmn.NetIntraData, netPub)
 	http.Redirect(w, r, redirectURL, http.StatusTemporaryRedirect)
 
-	// 4. stats
+	// 5. stats
 	if !appendTyProvided {
 		p.statsT.Inc(stats.PutCount)
+		p.capture(http.MethodPut, bck, objName, r, started)
 	} else {
 		p.statsT.Inc(stats.AppendCount)
 	}
@@ -875,10 +971,12 @@ func (p *proxy) httpobjdelete(w http.ResponseWriter, r *http.Request) {
 	if cmn.Rom.FastV(5, cos.SmoduleAIS) {
 		nlog.Infoln("DELETE", bck.Cname(objName), "=>", tsi.StringEx())
 	}
-	redirectURL := p.redirectURL(r, tsi, time.Now() /*started*/, cmn.NetIntraControl)
+	started := time.Now()
+	redirectURL := p.redirectURL(r, tsi, started, cmn.NetIntraControl)
 	http.Redirect(w, r, redirectURL, http.StatusTemporaryRedirect)
 
 	p.statsT.Inc(stats.DeleteCount)
+	p.capture(http.MethodDelete, bck, objName, r, started)
 }
 
 // DELETE { action } /v1/buckets
@@ -1008,7 +1106,7 @@ func (p *proxy) metasyncHandler(w http.ResponseWriter, r *http.Request) {
 	}
 
 	payload := make(msPayload)
-	if errP := payload.unmarshal(r.Body, "metasync put"); errP != nil {
+	if errP := payload.unmarshalReq(r, "metasync put"); errP != nil {
 		cmn.WriteErr(w, r, errP)
 		return
 	}
@@ -1076,13 +1174,14 @@ func (p *proxy) healthHandler(w http.ResponseWriter, r *http.Request) {
 	p.uptime2hdr(w.Header())
 
 	var (
-		prr, getCii, askPrimary bool
+		prr, getCii, askPrimary, deep bool
 	)
 	if r.URL.RawQuery != "" {
 		query := r.URL.Query()
 		prr = cos.IsParseBool(query.Get(apc.QparamPrimaryReadyReb))
 		getCii = cos.IsParseBool(query.Get(apc.QparamClusterInfo))
 		askPrimary = cos.IsParseBool(query.Get(apc.QparamAskPrimary))
+		deep = cos.IsParseBool(query.Get(apc.QparamHealthDeep))
 	}
 
 	if !prr {
@@ -1113,15 +1212,24 @@ func (p *proxy) healthHandler(w http.ResponseWriter, r *http.Request) {
 		p.keepalive.heardFrom(callerID)
 	}
 
+	// deep (end-to-end) check is performed by the primary
+	if deep {
+		if !p.ClusterStarted() {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		if p.forwardCP(w, r, nil, "deep health") {
+			return
+		}
+		p.deepHealth(w, r, smap)
+		return
+	}
+
 	// primary
 	if smap.isPrimary(p.si) {
 		if prr {
 			if err := p.pready(smap, true); err != nil {
-				if cmn.Rom.FastV(5, cos.SmoduleAIS) {
-					p.writeErr(w, r, err, http.StatusServiceUnavailable)
-				} else {
-					p.writeErr(w, r, err, http.StatusServiceUnavailable, Silent)
-				}
+				p.writeErrAdmit(w, r, err)
 				return
 			}
 		}
@@ -1262,6 +1370,9 @@ func (p *proxy) _bckpost(w http.ResponseWriter, r *http.Request, msg *apc.ActMsg
 	if bck, err = bckArgs.initAndTry(); err != nil {
 		return
 	}
+	if dtor.ConflictRebRes && !p.admittedReb(w, r, msg.Action) {
+		return
+	}
 
 	//
 	// POST {action} on bucket
@@ -1324,6 +1435,10 @@ func (p *proxy) _bckpost(w http.ResponseWriter, r *http.Request, msg *apc.ActMsg
 				p.writeErrf(w, r, cmn.FmtErrMorphUnmarshal, p.si, msg.Action, msg.Value, err)
 				return
 			}
+			if err := tcbmsg.Validate(false); err != nil {
+				p.writeErr(w, r, err)
+				return
+			}
 		}
 		if tcbmsg.Sync && tcbmsg.Prepend != "" {
 			p.writeErrf(w, r, errPrependSync, tcbmsg.Prepend)
@@ -1346,6 +1461,10 @@ func (p *proxy) _bckpost(w http.ResponseWriter, r *http.Request, msg *apc.ActMsg
 		if err != nil {
 			return
 		}
+		if tcbmsg.COW && (!bckFrom.IsAIS() || !bckTo.IsAIS() || msg.Action == apc.ActETLBck) {
+			p.writeErrf(w, r, "copy-on-write clone %s => %s: expecting ais:// buckets (and no transformation)", bckFrom, bckTo)
+			return
+		}
 		if ecode == http.StatusNotFound {
 			if p.forwardCP(w, r, msg, bucket) { // to create
 				return
@@ -1394,6 +1513,10 @@ func (p *proxy) _bckpost(w http.ResponseWriter, r *http.Request, msg *apc.ActMsg
 			p.writeErrf(w, r, errPrependSync, tcomsg.Prepend)
 			return
 		}
+		if err := tcomsg.CopyBckMsg.Validate(); err != nil {
+			p.writeErr(w, r, err)
+			return
+		}
 		bckTo = meta.CloneBck(&tcomsg.ToBck)
 
 		if bck.Equal(bckTo, true, true) {
@@ -1446,6 +1569,24 @@ func (p *proxy) _bckpost(w http.ResponseWriter, r *http.Request, msg *apc.ActMsg
 	case apc.ActInvalListCache:
 		p.qm.c.invalidate(bck.Bucket())
 		return
+	case apc.ActFlushDeletes, apc.ActCancelDeletes:
+		if err := p.checkAccess(w, r, bck, apc.AceObjDELETE); err != nil {
+			return
+		}
+		p.deleteQueue(w, r, bck, msg)
+		return
+	case apc.ActMakeManifest, apc.ActVerifyManifest:
+		if p.forwardCP(w, r, msg, bucket) {
+			return
+		}
+		p.manifest(w, r, msg, bck)
+		return
+	case apc.ActFreezeBck, apc.ActThawBck:
+		if p.forwardCP(w, r, msg, bucket) {
+			return
+		}
+		p.freezeBck(w, r, msg, bck)
+		return
 	case apc.ActMakeNCopies:
 		if xid, err = p.makeNCopies(msg, bck); err != nil {
 			p.writeErr(w, r, err)
@@ -1503,7 +1644,9 @@ func (p *proxy) _bcr(w http.ResponseWriter, r *http.Request, query url.Values, m
 		remoteHdr http.Header
 		bucket    = bck.Name
 	)
-	if err := p.checkAccess(w, r, nil, apc.AceCreateBucket); err != nil {
+	aerr := p.access(r, nil, apc.AceCreateBucket)
+	if aerr != nil && aceErrToCode(aerr) == http.StatusUnauthorized {
+		p.writeErr(w, r, aerr, http.StatusUnauthorized)
 		return
 	}
 	if err := bck.Validate(); err != nil {
@@ -1517,6 +1660,13 @@ func (p *proxy) _bcr(w http.ResponseWriter, r *http.Request, query url.Values, m
 		bck.Provider = apc.AIS
 	}
 
+	// self-service provisioning (see cmn/provision.go)
+	policy := p.provisionPolicy(r, bck)
+	if aerr != nil && policy == nil {
+		p.writeErr(w, r, aerr, aceErrToCode(aerr))
+		return
+	}
+
 	if bck.IsRemote() {
 		// (feature) add Cloud bucket to BMD, to further set its `Props.Extra`
 		// with alternative access profile and/or endpoint
@@ -1559,11 +1709,20 @@ func (p *proxy) _bcr(w http.ResponseWriter, r *http.Request, query url.Values, m
 		msg.Action = apc.ActAddRemoteBck // ditto
 	}
 	// props-to-update at creation time
-	if msg.Value != nil {
+	// (when provisioned under a policy: the policy's props template, if any, with user's props on top)
+	if msg.Value != nil || policy != nil {
 		propsToUpdate := cmn.BpropsToSet{}
-		if err := cos.MorphMarshal(msg.Value, &propsToUpdate); err != nil {
-			p.writeErrf(w, r, cmn.FmtErrMorphUnmarshal, p.si, msg.Action, msg.Value, err)
-			return
+		if policy != nil && policy.Props != nil {
+			if err := cos.MorphMarshal(policy.Props, &propsToUpdate); err != nil { // (deep copy)
+				p.writeErr(w, r, err)
+				return
+			}
+		}
+		if msg.Value != nil {
+			if err := cos.MorphMarshal(msg.Value, &propsToUpdate); err != nil {
+				p.writeErrf(w, r, cmn.FmtErrMorphUnmarshal, p.si, msg.Action, msg.Value, err)
+				return
+			}
 		}
 		// Make and validate new bucket props.
 		bck.Props = defaultBckProps(bckPropsArgs{bck: bck})
@@ -1573,6 +1732,9 @@ func (p *proxy) _bcr(w http.ResponseWriter, r *http.Request, query url.Values, m
 			return
 		}
 		bck.Props = nprops
+		if policy != nil {
+			bck.Props.Provision = cmn.BckProvision{Policy: policy.Name, Owner: p.userID(r), TTL: policy.TTL}
+		}
 		if backend := bck.Backend(); backend != nil {
 			if err := backend.Validate(); err != nil {
 				p.writeErrf(w, r, "cannot create %s: invalid backend %s, err: %v", bck, backend, err)
@@ -1647,9 +1809,43 @@ func (p *proxy) listObjects(w http.ResponseWriter, r *http.Request, bck *meta.Bc
 		lsmsg.SetFlag(apc.LsObjCached)
 	}
 
+	// server-side filtering (by targets)
+	if lsmsg.Filter != nil {
+		if _, err := lsmsg.Filter.Compile(); err != nil {
+			p.writeErr(w, r, err)
+			return
+		}
+		lsmsg.ClearFlag(apc.UseListObjsCache) // (the cache is keyed by bucket and prefix)
+	}
+
+	// server-side ordering: top-N by targets, merged by proxy
+	if lsmsg.Sort != nil {
+		if err := lsmsg.Sort.Validate(lsmsg); err != nil {
+			p.writeErr(w, r, err)
+			return
+		}
+		if bck.IsRemote() && !lsmsg.IsFlagSet(apc.LsObjCached) {
+			p.writeErrf(w, r, "%s: sorted list-objects is limited to in-cluster objects (use 'LsObjCached' flag)", bck.Cname(""))
+			return
+		}
+		if lsmsg.PageSize == 0 {
+			lsmsg.PageSize = apc.MaxLsoSortLimit
+		}
+		lsmsg.AddProps(lsmsg.Sort.By) // (apc.GetPropsSize or apc.GetPropsMtime)
+		lsmsg.ClearFlag(apc.LsNameOnly | apc.UseListObjsCache)
+	}
+
 	// do page
-	beg := mono.NanoTime()
-	lst, err := p.lsPage(bck, amsg, lsmsg, r.Header, p.owner.smap.get())
+	var (
+		lst *cmn.LsoRes
+		err error
+		beg = mono.NanoTime()
+	)
+	if lsmsg.Sort != nil {
+		lst, err = p.lsSorted(bck, lsmsg)
+	} else {
+		lst, err = p.lsPage(bck, amsg, lsmsg, r.Header, p.owner.smap.get())
+	}
 	if err != nil {
 		p.writeErr(w, r, err)
 		return
@@ -1822,13 +2018,19 @@ func (p *proxy) httpobjpost(w http.ResponseWriter, r *http.Request, apireq *apiR
 			return
 		}
 		objName, objNameTo := apireq.items[1], msg.Name
-		if objName == objNameTo {
-			p.writeErrMsg(w, r, "cannot rename "+bck.Cname(objName)+" to self, nothing to do")
+		if !p.isValidObjname(w, r, objNameTo) {
 			return
 		}
-		if !p.isValidObjname(w, r, objNameTo) {
+		objNameTo, err := bck.Props.ObjName.Apply(objNameTo)
+		if err != nil {
+			p.writeErr(w, r, err)
+			return
+		}
+		if objName == objNameTo {
+			p.writeErrMsg(w, r, "cannot rename "+bck.Cname(objName)+" to self, nothing to do")
 			return
 		}
+		msg.Name = objNameTo
 		p.redirectObjAction(w, r, bck, apireq.items[1], msg)
 	case apc.ActPromote:
 		if err := p.checkAccess(w, r, bck, apc.AcePromote); err != nil {
@@ -1875,6 +2077,26 @@ func (p *proxy) httpobjpost(w http.ResponseWriter, r *http.Request, apireq *apiR
 		}
 		objName := msg.Name
 		p.redirectObjAction(w, r, bck, objName, msg)
+	case apc.ActLockObject, apc.ActRenewObjLock, apc.ActUnlockObject:
+		if err := p.checkAccess(w, r, bck, apc.AcePUT); err != nil {
+			return
+		}
+		if !p.isValidObjname(w, r, msg.Name) {
+			return
+		}
+		p.redirectObjAction(w, r, bck, msg.Name, msg)
+	case apc.ActRestoreObject:
+		if err := p.checkAccess(w, r, bck, apc.AcePUT); err != nil {
+			return
+		}
+		if !bck.IsAIS() {
+			p.writeErrActf(w, r, msg.Action, "not supported for remote buckets (%s)", bck)
+			return
+		}
+		if !p.isValidObjname(w, r, msg.Name) {
+			return
+		}
+		p.redirectObjAction(w, r, bck, msg.Name, msg)
 	default:
 		p.writeErrAct(w, r, msg.Action)
 	}
@@ -1960,7 +2182,7 @@ func (p *proxy) httpbckhead(w http.ResponseWriter, r *http.Request, apireq *apiR
 				info.IsBckPresent = true
 			}
 		}
-		toHdr(w, bck, info, status, msg.UUID)
+		p.toHdr(w, r, bck, info, status, msg.UUID)
 		return
 	}
 
@@ -1970,7 +2192,7 @@ func (p *proxy) httpbckhead(w http.ResponseWriter, r *http.Request, apireq *apiR
 
 	// [filtering] when the bucket that must be present is not
 	if apc.IsFltPresent(fltPresence) {
-		toHdr(w, bck, nil, 0, "")
+		p.toHdr(w, r, bck, nil, 0, "")
 		return
 	}
 
@@ -1994,13 +2216,22 @@ func (p *proxy) httpbckhead(w http.ResponseWriter, r *http.Request, apireq *apiR
 			info.IsBckPresent = true
 		}
 	}
-	toHdr(w, bck, info, status, msg.UUID)
+	p.toHdr(w, r, bck, info, status, msg.UUID)
 }
 
-func toHdr(w http.ResponseWriter, bck *meta.Bck, info *cmn.BsummResult, status int, xid string) {
+func (p *proxy) toHdr(w http.ResponseWriter, r *http.Request, bck *meta.Bck, info *cmn.BsummResult, status int, xid string) {
 	hdr := w.Header()
 	if bck.Props != nil {
-		hdr.Set(apc.HdrBucketProps, cos.MustMarshalToString(bck.Props))
+		var props any = bck.Props
+		if fields := qfields(r); fields != "" {
+			out, err := cos.SelectFields(props, fields)
+			if err != nil {
+				p.writeErr(w, r, err)
+				return
+			}
+			props = out
+		}
+		hdr.Set(apc.HdrBucketProps, cos.MustMarshalToString(props))
 	}
 	if info != nil {
 		hdr.Set(apc.HdrBucketSumm, cos.MustMarshalToString(info))
@@ -2106,19 +2337,29 @@ func (p *proxy) httpobjhead(w http.ResponseWriter, r *http.Request, origURLBck .
 	if cmn.Rom.FastV(5, cos.SmoduleAIS) {
 		nlog.Infoln(r.Method, bck.Cname(objName), "=>", si.StringEx())
 	}
-	redirectURL := p.redirectURL(r, si, time.Now() /*started*/, cmn.NetIntraControl)
+	started := time.Now()
+	redirectURL := p.redirectURL(r, si, started, cmn.NetIntraControl)
 	http.Redirect(w, r, redirectURL, http.StatusTemporaryRedirect)
+
+	p.capture(http.MethodHead, bck, objName, r, started)
 }
 
 // PATCH /v1/objects/bucket-name/object-name
 func (p *proxy) httpobjpatch(w http.ResponseWriter, r *http.Request) {
-	started := time.Now()
+	var (
+		started  = time.Now()
+		perms    = apc.AceObjUpdate
+		netIntra = cmn.NetIntraControl
+	)
+	if r.Header.Get(cos.HdrContentRange) != "" { // write range
+		perms, netIntra = apc.AcePUT, cmn.NetIntraData
+	}
 	bckArgs := allocBctx()
 	{
 		bckArgs.p = p
 		bckArgs.w = w
 		bckArgs.r = r
-		bckArgs.perms = apc.AceObjUpdate
+		bckArgs.perms = perms
 		bckArgs.createAIS = false
 	}
 	bck, objName, err := p._parseReqTry(w, r, bckArgs)
@@ -2134,7 +2375,7 @@ func (p *proxy) httpobjpatch(w http.ResponseWriter, r *http.Request) {
 	if cmn.Rom.FastV(5, cos.SmoduleAIS) {
 		nlog.Infoln(r.Method, bck.Cname(objName), "=>", si.StringEx())
 	}
-	redirectURL := p.redirectURL(r, si, started, cmn.NetIntraControl)
+	redirectURL := p.redirectURL(r, si, started, netIntra)
 	http.Redirect(w, r, redirectURL, http.StatusTemporaryRedirect)
 }
 
@@ -2222,7 +2463,7 @@ func (p *proxy) redirectURL(r *http.Request, si *meta.Snode, ts time.Time, netIn
 			nodeURL = si.URL(netPub)
 		}
 	}
-	redirect = nodeURL + r.URL.Path + "?"
+	redirect = nodeURL + r.URL.EscapedPath() + "?"
 	if r.URL.RawQuery != "" {
 		redirect += r.URL.RawQuery + "&"
 	}
@@ -2231,6 +2472,9 @@ func (p *proxy) redirectURL(r *http.Request, si *meta.Snode, ts time.Time, netIn
 		apc.QparamProxyID:  []string{p.SID()},
 		apc.QparamUnixTime: []string{cos.UnixNano2S(ts.UnixNano())},
 	}
+	if deadline := r.Header.Get(apc.HdrDeadline); deadline != "" { // (absolute - see checkDeadline)
+		query.Set(apc.QparamDeadline, deadline)
+	}
 	redirect += query.Encode()
 	return
 }
@@ -2283,8 +2527,8 @@ func (p *proxy) lsObjsA(bck *meta.Bck, lsmsg *apc.LsoMsg) (allEntries *cmn.LsoRe
 		Method: http.MethodGet,
 		Path:   apc.URLPathBuckets.Join(bck.Name),
 		Query:  bck.NewQuery(),
-		Body:   cos.MustMarshal(aisMsg),
 	}
+	args.amsg = aisMsg
 	args.timeout = apc.LongTimeout
 	args.smap = smap
 	args.cresv = cresLso{} // -> cmn.LsoRes
@@ -2344,6 +2588,45 @@ end:
 	return allEntries, nil
 }
 
+// sorted list-objects (see apc.LsoSort): each target returns its (local) top-N,
+// and the merge keeps only the resulting N
+func (p *proxy) lsSorted(bck *meta.Bck, lsmsg *apc.LsoMsg) (*cmn.LsoRes, error) {
+	var (
+		flags uint32
+		topn  = cmn.NewLsoTopN(lsmsg.Sort, int(lsmsg.PageSize))
+		args  = allocBcArgs()
+	)
+	if lsmsg.UUID == "" {
+		lsmsg.UUID = cos.GenUUID()
+	}
+	args.req = cmn.HreqArgs{
+		Method: http.MethodGet,
+		Path:   apc.URLPathBuckets.Join(bck.Name),
+		Query:  bck.NewQuery(),
+	}
+	args.amsg = p.newAmsgActVal(apc.ActList, lsmsg)
+	args.timeout = apc.LongTimeout
+	args.smap = p.owner.smap.get()
+	args.cresv = cresLso{} // -> cmn.LsoRes
+
+	results := p.bcastGroup(args)
+	freeBcArgs(args)
+	for _, res := range results {
+		if res.err != nil {
+			err := res.toErr()
+			freeBcastRes(results)
+			return nil, err
+		}
+		lst := res.v.(*cmn.LsoRes)
+		flags |= lst.Flags
+		for _, e := range lst.Entries {
+			topn.Add(e)
+		}
+	}
+	freeBcastRes(results)
+	return &cmn.LsoRes{UUID: lsmsg.UUID, Entries: topn.Sorted(), Flags: flags}, nil
+}
+
 func (p *proxy) lsObjsR(bck *meta.Bck, lsmsg *apc.LsoMsg, hdr http.Header, smap *smapX, tsi *meta.Snode, config *cmn.Config,
 	wantOnlyRemote bool) (*cmn.LsoRes, error) {
 	var (
@@ -2441,21 +2724,23 @@ func (p *proxy) redirectObjAction(w http.ResponseWriter, r *http.Request, bck *m
 	redirectURL := p.redirectURL(r, si, started, cmn.NetIntraControl)
 	http.Redirect(w, r, redirectURL, http.StatusTemporaryRedirect)
 
-	p.statsT.Inc(stats.RenameCount)
+	if msg.Action == apc.ActRenameObject {
+		p.statsT.Inc(stats.RenameCount)
+	}
 }
 
 func (p *proxy) listrange(method, bucket string, msg *apc.ActMsg, query url.Values) (xid string, err error) {
 	var (
 		smap   = p.owner.smap.get()
 		aisMsg = p.newAmsg(msg, nil, cos.GenUUID())
-		body   = cos.MustMarshal(aisMsg)
 		path   = apc.URLPathBuckets.Join(bucket)
 	)
 	nlb := xact.NewXactNL(aisMsg.UUID, aisMsg.Action, &smap.Smap, nil)
 	nlb.SetOwner(equalIC)
 	p.ic.registerEqual(regIC{smap: smap, query: query, nl: nlb})
 	args := allocBcArgs()
-	args.req = cmn.HreqArgs{Method: method, Path: path, Query: query, Body: body}
+	args.req = cmn.HreqArgs{Method: method, Path: path, Query: query}
+	args.amsg = aisMsg
 	args.smap = smap
 	args.timeout = apc.DefaultTimeout
 	results := p.bcastGroup(args)
@@ -2633,7 +2918,7 @@ func (p *proxy) httpdaeget(w http.ResponseWriter, r *http.Request) {
 			p.handlePendingRenamedLB(renamedBucket)
 		}
 		fallthrough // fallthrough
-	case apc.WhatNodeConfig, apc.WhatSmapVote, apc.WhatSnode, apc.WhatLog,
+	case apc.WhatNodeConfig, apc.WhatConfigSchema, apc.WhatSmapVote, apc.WhatSnode, apc.WhatLog,
 		apc.WhatNodeStats, apc.WhatNodeStatsV322, apc.WhatMetricNames,
 		apc.WhatNodeStatsAndStatusV322:
 		p.htrun.httpdaeget(w, r, query, nil /*htext*/)
@@ -2648,6 +2933,9 @@ func (p *proxy) httpdaeget(w http.ResponseWriter, r *http.Request) {
 	case apc.WhatSysInfo:
 		p.writeJSON(w, r, apc.GetMemCPU(), what)
 
+	case whatBckAccess:
+		p.writeJSON(w, r, p.batime.snap(), what)
+
 	case apc.WhatSmap:
 		const retries = 16
 		var (
@@ -2978,7 +3266,7 @@ func (p *proxy) _becomePre(ctx *smapModifier, clone *smapX) error {
 
 	clone.Primary = clone.GetProxy(p.SID())
 	clone.Version += 100
-	clone.staffIC()
+	clone.staffIC(&p.ic.loads)
 	return nil
 }
 
@@ -3009,6 +3297,8 @@ func (p *proxy) _becomeFinal(ctx *smapModifier, clone *smapX) {
 
 	// synchronize IC tables
 	p.syncNewICOwners(ctx.smap, clone)
+
+	go p.initProbeBck()
 }
 
 func (p *proxy) ensureConfigURLs() (config *globalConfig, err error) {
@@ -3042,8 +3332,7 @@ func (p *proxy) _configURLs(_ *configModifier, clone *globalConfig) (updated boo
 
 // [METHOD] /v1/sort
 func (p *proxy) dsortHandler(w http.ResponseWriter, r *http.Request) {
-	if !p.cluStartedWithRetry() {
-		w.WriteHeader(http.StatusServiceUnavailable)
+	if !p.admitted(w, r) {
 		return
 	}
 	if err := p.checkAccess(w, r, nil, apc.AceAdmin); err != nil {
@@ -3064,6 +3353,13 @@ func (p *proxy) dsortHandler(w http.ResponseWriter, r *http.Request) {
 			p.writeErrStatusf(w, r, http.StatusInternalServerError, "failed to receive dsort request: %v", err)
 			return
 		}
+		// job queue is maintained by the primary
+		if dsort.QueueEnabled(&cmn.GCO.Get().Dsort) && p.forwardCP(w, r, nil, "dsort-start", body) {
+			return
+		}
+		if !p.admittedReb(w, r, apc.ActDsort) {
+			return
+		}
 		rs := &dsort.RequestSpec{}
 		if err := jsoniter.Unmarshal(body, rs); err != nil {
 			err = fmt.Errorf(cmn.FmtErrUnmarshal, p, "dsort request", cos.BHead(body), err)
@@ -3112,10 +3408,22 @@ func (p *proxy) dsortHandler(w http.ResponseWriter, r *http.Request) {
 				nlog.Warningf(warnfmt, p, "", bckTo, bck)
 			}
 		}
-		dsort.PstartHandler(w, r, parsc)
+		uid, roles := p.userIdentity(r)
+		dsort.PstartHandler(w, r, parsc, uid, roles)
 	case http.MethodGet:
+		if len(apiItems) == 1 && apiItems[0] == apc.Queue {
+			if p.forwardCP(w, r, nil, "dsort-queue") {
+				return
+			}
+			dsort.PqueueHandler(w, r)
+			return
+		}
 		dsort.PgetHandler(w, r)
 	case http.MethodDelete:
+		// (pending jobs - see above)
+		if dsort.QueueEnabled(&cmn.GCO.Get().Dsort) && p.forwardCP(w, r, nil, "dsort-abort-remove") {
+			return
+		}
 		if len(apiItems) == 1 && apiItems[0] == apc.Abort {
 			dsort.PabortHandler(w, r)
 		} else if len(apiItems) == 0 {
@@ -3130,8 +3438,7 @@ func (p *proxy) dsortHandler(w http.ResponseWriter, r *http.Request) {
 
 func (p *proxy) rootHandler(w http.ResponseWriter, r *http.Request) {
 	const fs3 = "/" + apc.S3
-	if !p.cluStartedWithRetry() {
-		w.WriteHeader(http.StatusServiceUnavailable)
+	if !p.admitted(w, r) {
 		return
 	}
 
@@ -3513,6 +3820,10 @@ func (p *proxy) Stop(err error) {
 		nlog.Warningf("%s: %v", s, err)
 	}
 	xreg.AbortAll(errors.New("p-stop"))
+	if p.authn != nil {
+		p.authn.stop()
+	}
+	p.capt.stop()
 
 	p.htrun.stop(&sync.WaitGroup{}, !isPrimary && smap.isValid() && !isEnu /*rmFromSmap*/)
 }
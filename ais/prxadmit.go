@@ -0,0 +1,104 @@
+// Package ais provides core functionality for the AIStore object storage.
+/*
+ * Copyright (c) 2024, NVIDIA CORPORATION. All rights reserved.
+ */
+package ais
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/NVIDIA/aistore/api/apc"
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/cmn/atomic"
+	"github.com/NVIDIA/aistore/cmn/cos"
+	"github.com/NVIDIA/aistore/cmn/mono"
+)
+
+// Admission control: requests that cannot be served yet - cluster starting up, primary
+// finalizing global rebalancing state, or rebalance running while the requested operation
+// cannot coexist with it (see xact.Descriptor.ConflictRebRes) - are held for up to `admitWait`
+// and then, if still not ready, rejected with 503 and:
+// - Retry-After: estimated time (in seconds) until ready;
+// - apc.HdrAdmitQueue: number of requests currently held (queue depth);
+// the api package retries such requests (and only such requests) automatically (bounded) -
+// see api/client.go
+
+const (
+	admitWait     = time.Second
+	admitPoll     = 100 * time.Millisecond
+	admitMaxQueue = 1024 // held at any given time; the rest get rejected right away
+
+	admitDfltETA = 5 * time.Second
+	admitMaxETA  = 30 * time.Second
+)
+
+type admission struct {
+	queued atomic.Int32
+}
+
+// cluster must be started
+func (p *proxy) admitted(w http.ResponseWriter, r *http.Request) bool {
+	if p.ClusterStarted() || p.admitWait(p.ClusterStarted) {
+		return true
+	}
+	p.writeErrAdmit(w, r, fmt.Errorf("%s: cluster is starting up", p))
+	return false
+}
+
+// rebalance must not be running (when `action` conflicts with it);
+// (running rebalance is tracked by the primary and other IC members - see fillNsti)
+func (p *proxy) admittedReb(w http.ResponseWriter, r *http.Request, action string) bool {
+	if !p.rebalancing() || p.admitWait(func() bool { return !p.rebalancing() }) {
+		return true
+	}
+	p.writeErrAdmit(w, r, fmt.Errorf("%s: rebalance is running, cannot %s concurrently", p, action))
+	return false
+}
+
+func (p *proxy) rebalancing() bool {
+	onl := true
+	return p.notifs.find(nlFilter{Kind: apc.ActRebalance, OnlyRunning: &onl}) != nil
+}
+
+// hold the request until ready, or `admitWait`, or the queue is full
+func (p *proxy) admitWait(ready func() bool) bool {
+	if n := p.admit.queued.Inc(); n > admitMaxQueue {
+		p.admit.queued.Dec()
+		return false
+	}
+	defer p.admit.queued.Dec()
+	for waited := time.Duration(0); waited < admitWait; waited += admitPoll {
+		time.Sleep(admitPoll)
+		if ready() {
+			return true
+		}
+	}
+	return false
+}
+
+// while starting up: up to the remaining primary's startup time
+func (p *proxy) admitETA() time.Duration {
+	started := p.startup.node.Load()
+	if started == 0 || p.ClusterStarted() {
+		return admitDfltETA
+	}
+	rem := cmn.GCO.Get().Timeout.Startup.D() - mono.Since(started)
+	return min(max(rem, time.Second), admitMaxETA)
+}
+
+// 503 with Retry-After and queue depth
+func (p *proxy) writeErrAdmit(w http.ResponseWriter, r *http.Request, err error) {
+	var (
+		eta   = p.admitETA()
+		secs  = int64((eta + time.Second - 1) / time.Second)
+		depth = p.admit.queued.Load()
+		hdr   = w.Header()
+	)
+	hdr.Set(cos.HdrRetryAfter, strconv.FormatInt(secs, 10))
+	hdr.Set(apc.HdrAdmitQueue, strconv.Itoa(int(depth)))
+	err = fmt.Errorf("%w (queued %d, retry in %ds)", err, depth, secs)
+	p.writeErr(w, r, err, http.StatusServiceUnavailable, Silent)
+}
@@ -0,0 +1,95 @@
+// Package ais provides core functionality for the AIStore object storage.
+/*
+ * Copyright (c) 2024, NVIDIA CORPORATION. All rights reserved.
+ */
+package ais
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"time"
+
+	"github.com/NVIDIA/aistore/api/apc"
+	"github.com/NVIDIA/aistore/cmn/cos"
+	"github.com/NVIDIA/aistore/core/meta"
+	"github.com/NVIDIA/aistore/core/mock"
+	"github.com/NVIDIA/aistore/xact"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Admission control", func() {
+	newProxy := func() *proxy {
+		info := serverTCPAddr("http://127.0.0.1:8080")
+		return &proxy{
+			htrun: htrun{
+				si:     newSnode("admit-proxy", apc.Proxy, info, info, info),
+				statsT: &mock.StatsTracker{},
+			},
+		}
+	}
+
+	It("should reject with 503, Retry-After, and queue depth while starting up", func() {
+		var (
+			p = newProxy()
+			w = httptest.NewRecorder()
+			r = httptest.NewRequest(http.MethodGet, apc.URLPathBuckets.S, http.NoBody)
+		)
+		started := time.Now()
+		Expect(p.admitted(w, r)).To(BeFalse())
+		Expect(time.Since(started)).To(BeNumerically(">=", admitWait))
+
+		Expect(w.Code).To(Equal(http.StatusServiceUnavailable))
+		secs, err := strconv.Atoi(w.Header().Get(cos.HdrRetryAfter))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(secs).To(BeNumerically(">=", 1))
+		Expect(w.Header().Get(apc.HdrAdmitQueue)).NotTo(BeEmpty())
+		Expect(w.Body.String()).To(ContainSubstring("starting up"))
+		Expect(p.admit.queued.Load()).To(BeZero())
+	})
+
+	It("should admit requests held while the cluster gets ready", func() {
+		var (
+			p = newProxy()
+			w = httptest.NewRecorder()
+			r = httptest.NewRequest(http.MethodGet, apc.URLPathBuckets.S, http.NoBody)
+		)
+		go func() {
+			time.Sleep(admitWait / 4)
+			p.markClusterStarted()
+		}()
+		Expect(p.admitted(w, r)).To(BeTrue())
+		Expect(w.Code).To(Equal(http.StatusOK)) // (nothing written)
+		Expect(p.admitted(w, r)).To(BeTrue())
+	})
+
+	It("should hold and then reject operations that cannot coexist with running rebalance", func() {
+		var (
+			p    = newProxy()
+			w    = httptest.NewRecorder()
+			r    = httptest.NewRequest(http.MethodPost, apc.URLPathBuckets.S, http.NoBody)
+			info = serverTCPAddr("http://127.0.0.1:8081")
+			tmap = meta.NodeMap{"t1": newSnode("t1", apc.Target, info, info, info)}
+			smap = &meta.Smap{Tmap: tmap}
+			nl   = xact.NewXactNL(cos.GenUUID(), apc.ActRebalance, smap, tmap)
+		)
+		p.notifs.nls, p.notifs.fin = newListeners(), newListeners()
+		Expect(p.admittedReb(w, r, apc.ActCopyBck)).To(BeTrue())
+
+		p.notifs.nls.add(nl, false)
+		Expect(p.admittedReb(w, r, apc.ActCopyBck)).To(BeFalse())
+		Expect(w.Code).To(Equal(http.StatusServiceUnavailable))
+		Expect(w.Header().Get(cos.HdrRetryAfter)).NotTo(BeEmpty())
+		Expect(w.Header().Get(apc.HdrAdmitQueue)).NotTo(BeEmpty())
+		Expect(w.Body.String()).To(ContainSubstring("rebalance is running"))
+
+		w = httptest.NewRecorder()
+		go func() {
+			time.Sleep(admitWait / 4)
+			p.notifs.nls.del(nl, false)
+		}()
+		Expect(p.admittedReb(w, r, apc.ActCopyBck)).To(BeTrue())
+		Expect(w.Code).To(Equal(http.StatusOK)) // (nothing written)
+	})
+})
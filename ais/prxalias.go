@@ -0,0 +1,150 @@
+// Package ais provides core functionality for the AIStore object storage.
+/*
+ * Copyright (c) 2024, NVIDIA CORPORATION. All rights reserved.
+ */
+package ais
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/NVIDIA/aistore/api/apc"
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/cmn/cos"
+	"github.com/NVIDIA/aistore/cmn/nlog"
+	"github.com/NVIDIA/aistore/core/meta"
+)
+
+// Bucket aliases:
+// - alias is an ais:// bucket name (global namespace) that maps onto another, possibly remote, bucket;
+// - aliases are stored in BMD and resolved by proxies - targets only ever see the aliased bucket;
+// - repointing an existing alias is a single (atomic) BMD update;
+// - read-only alias fails all requests that require anything other than apc.AccessRO permissions;
+// - alias and ais:// bucket cannot share the same name.
+
+// PUT /v1/cluster {action: apc.ActSetBckAlias, name: <alias>, value: meta.BckAlias}
+func (p *proxy) setBckAlias(w http.ResponseWriter, r *http.Request, msg *apc.ActMsg) {
+	if err := p.checkAccess(w, r, nil, apc.AceAdmin); err != nil {
+		return
+	}
+	alias := &meta.BckAlias{}
+	if err := cos.MorphMarshal(msg.Value, alias); err != nil {
+		p.writeErrf(w, r, cmn.FmtErrMorphUnmarshal, p.si, msg.Action, msg.Value, err)
+		return
+	}
+	aliasBck := meta.NewBck(msg.Name, apc.AIS, cmn.NsGlobal)
+	if err := aliasBck.Bucket().ValidateName(); err != nil {
+		p.writeErr(w, r, err)
+		return
+	}
+	// the aliased bucket must exist (and may not be an alias itself)
+	bckArgs := allocBctx()
+	{
+		bckArgs.p = p
+		bckArgs.w = w
+		bckArgs.r = r
+		bckArgs.bck = meta.CloneBck(&alias.Bck)
+		bckArgs.perms = apc.AceBckHEAD
+		bckArgs.skipAlias = true
+	}
+	bck, err := bckArgs.initAndTry()
+	freeBctx(bckArgs)
+	if err != nil {
+		return
+	}
+	alias.Bck = *bck.Bucket()
+
+	ctx := &bmdModifier{
+		pre: func(_ *bmdModifier, clone *bucketMD) error {
+			if _, present := clone.Get(aliasBck); present {
+				return fmt.Errorf("cannot create alias %q: %w", msg.Name, cmn.NewErrBckAlreadyExists(aliasBck.Bucket()))
+			}
+			if clone.Aliases == nil {
+				clone.Aliases = make(meta.BckAliases, 1)
+			}
+			clone.Aliases[msg.Name] = alias
+			clone.Version++
+			return nil
+		},
+		final: p.bmodSync,
+		msg:   msg,
+		wait:  true,
+	}
+	if _, err := p.owner.bmd.modify(ctx); err != nil {
+		p.writeErr(w, r, err)
+		return
+	}
+	nlog.Infoln(p.String(), msg.Action, aliasBck.Cname(""), "=>", alias.Bck.Cname(""), "read-only:", alias.ReadOnly)
+}
+
+// PUT /v1/cluster {action: apc.ActDelBckAlias, name: <alias>}
+func (p *proxy) delBckAlias(w http.ResponseWriter, r *http.Request, msg *apc.ActMsg) {
+	if err := p.checkAccess(w, r, nil, apc.AceAdmin); err != nil {
+		return
+	}
+	ctx := &bmdModifier{
+		pre: func(_ *bmdModifier, clone *bucketMD) error {
+			if _, ok := clone.Aliases[msg.Name]; !ok {
+				return cos.NewErrNotFound(p, "bucket alias "+msg.Name)
+			}
+			delete(clone.Aliases, msg.Name)
+			clone.Version++
+			return nil
+		},
+		final: p.bmodSync,
+		msg:   msg,
+		wait:  true,
+	}
+	if _, err := p.owner.bmd.modify(ctx); err != nil {
+		if cos.IsErrNotFound(err) {
+			p.writeErr(w, r, err, http.StatusNotFound)
+		} else {
+			p.writeErr(w, r, err)
+		}
+		return
+	}
+	nlog.Infoln(p.String(), msg.Action, msg.Name)
+}
+
+// resolve alias, if any, and rewrite the request (URL path and query) for the targets
+func (bctx *bctx) resolveAlias() {
+	bck := bctx.bck
+	alias, ok := bctx.p.owner.bmd.get().GetAlias(bck.Bucket())
+	if !ok {
+		return
+	}
+	r := bctx.r
+	for _, prefix := range []string{apc.URLPathObjects.S, apc.URLPathBuckets.S} {
+		if s := prefix + "/" + bck.Name; strings.HasPrefix(r.URL.Path, s) {
+			r.URL.Path = prefix + "/" + alias.Bck.Name + r.URL.Path[len(s):]
+			r.URL.RawPath = ""
+			break
+		}
+	}
+	query := bctx.query
+	if query == nil {
+		query = r.URL.Query()
+	}
+	query.Set(apc.QparamProvider, alias.Bck.Provider)
+	if alias.Bck.Ns.IsGlobal() {
+		query.Del(apc.QparamNamespace)
+	} else {
+		query.Set(apc.QparamNamespace, alias.Bck.Ns.Uname())
+	}
+	r.URL.RawQuery = query.Encode()
+
+	if cmn.Rom.FastV(5, cos.SmoduleAIS) {
+		nlog.Infoln("alias", bck.Cname(""), "=>", alias.Bck.Cname(""))
+	}
+	bck.Name, bck.Provider, bck.Ns = alias.Bck.Name, alias.Bck.Provider, alias.Bck.Ns
+	bctx.aliasRO = alias.ReadOnly
+	bctx.modified = true
+}
+
+func (bctx *bctx) checkAliasRO() error {
+	if !bctx.aliasRO || bctx.perms&^apc.AccessRO == 0 {
+		return nil
+	}
+	return cmn.NewBucketAccessDenied(bctx.bck.Cname(""), "write (via read-only alias)", apc.AccessRO)
+}
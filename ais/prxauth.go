@@ -8,16 +8,19 @@ import (
 	"fmt"
 	"net/http"
 	"os"
+	"path/filepath"
 	"sync"
 	"time"
 
 	"github.com/NVIDIA/aistore/api/apc"
 	"github.com/NVIDIA/aistore/api/authn"
 	"github.com/NVIDIA/aistore/api/env"
+	"github.com/NVIDIA/aistore/cmd/authn/svc"
 	"github.com/NVIDIA/aistore/cmd/authn/tok"
 	"github.com/NVIDIA/aistore/cmn"
 	"github.com/NVIDIA/aistore/cmn/cos"
 	"github.com/NVIDIA/aistore/cmn/debug"
+	"github.com/NVIDIA/aistore/cmn/fname"
 	"github.com/NVIDIA/aistore/cmn/nlog"
 	"github.com/NVIDIA/aistore/core/meta"
 	"github.com/NVIDIA/aistore/memsys"
@@ -36,6 +39,8 @@ type (
 		version       int64
 		// signing key secret
 		secret string
+		// in-process AuthN server, if enabled (see cmn.EmbeddedAuthNConf)
+		embedded *svc.Svc
 	}
 )
 
@@ -52,6 +57,36 @@ func newAuthManager(config *cmn.Config) *authManager {
 	}
 }
 
+// Run AuthN server in-process, sharing the cluster's secret. The server's API, config,
+// and database are exactly those of the standalone one - to migrate, run `authn -config`
+// with the same directory and disable the embedded one.
+func (a *authManager) embed(config *cmn.Config) {
+	if !config.AuthN.Enabled {
+		return
+	}
+	if !config.Auth.Enabled {
+		nlog.Warningln("running embedded AuthN with authentication disabled (config auth.enabled = false)")
+	}
+	dir := filepath.Join(config.ConfigDir, fname.HomeAuthN)
+	s, err := svc.Embed(dir, config.LogDir, a.secret, config.AuthN.Port)
+	if err != nil {
+		cos.ExitLogf("failed to start embedded AuthN: %v", err)
+	}
+	a.embedded = s
+	go func() {
+		if err := s.Run(); err != nil {
+			nlog.Errorln("embedded AuthN terminated:", err)
+		}
+	}()
+	nlog.Infoln("embedded AuthN:", dir)
+}
+
+func (a *authManager) stop() {
+	if a.embedded != nil {
+		a.embedded.Stop()
+	}
+}
+
 // Add tokens to the list of invalid ones and clean up the list from expired tokens.
 func (a *authManager) updateRevokedList(newRevoked *tokenList) (allRevoked *tokenList) {
 	a.Lock()
@@ -113,7 +148,7 @@ func (a *authManager) revokedTokenList() (allRevoked *tokenList) {
 
 // Checks if a token is valid:
 //   - must not be revoked one
-//   - must not be expired
+//   - must not be expired, or used before its not-before time
 //   - must have all mandatory fields: userID, creds, issued, expires
 //
 // Returns decrypted token information if it is valid
@@ -140,9 +175,11 @@ func (a *authManager) validateAddRm(token string, now time.Time) (*tok.Token, er
 		}
 		a.tkList[token] = tk
 	}
-	if tk.Expires.Before(now) {
-		delete(a.tkList, token)
-		return nil, fmt.Errorf("%v: %s", tok.ErrTokenExpired, tk)
+	if err := tk.CheckWindow(now); err != nil {
+		if tk.Expires.Before(now) {
+			delete(a.tkList, token)
+		}
+		return nil, err
 	}
 	return tk, nil
 }
@@ -212,13 +249,18 @@ func (p *proxy) delToken(w http.ResponseWriter, r *http.Request) {
 	}
 }
 
-// Validates a token from the request header
-func (p *proxy) validateToken(hdr http.Header) (*tok.Token, error) {
-	token, err := tok.ExtractToken(hdr)
+// Validates a token from the request header, and the requester's (source) address
+// when the token is restricted to given networks
+// (NOTE: the TCP peer address, not X-Forwarded-For, which is client-controlled)
+func (p *proxy) validateToken(r *http.Request) (*tok.Token, error) {
+	token, err := tok.ExtractToken(r.Header)
 	if err != nil {
 		return nil, err
 	}
 	tk, err := p.authn.validateToken(token)
+	if err == nil {
+		err = tk.CheckSource(r.RemoteAddr)
+	}
 	if err != nil {
 		nlog.Errorf("invalid token: %v", err)
 		return nil, err
@@ -226,6 +268,27 @@ func (p *proxy) validateToken(hdr http.Header) (*tok.Token, error) {
 	return tk, nil
 }
 
+// AuthN identity of the requester (empty when AuthN is disabled or the request carries no valid token)
+func (p *proxy) userID(r *http.Request) string {
+	uid, _ := p.userIdentity(r)
+	return uid
+}
+
+// ditto, including the user's roles
+func (p *proxy) userIdentity(r *http.Request) (uid string, roles []string) {
+	if !cmn.Rom.AuthEnabled() {
+		return "", nil
+	}
+	if _, err := tok.ExtractToken(r.Header); err != nil {
+		return "", nil
+	}
+	tk, err := p.validateToken(r)
+	if err != nil {
+		return "", nil
+	}
+	return tk.UserID, tk.Roles
+}
+
 // When AuthN is on, accessing a bucket requires two permissions:
 //   - access to the bucket is granted to a user
 //   - bucket ACL allows the required operation
@@ -237,7 +300,7 @@ func (p *proxy) validateToken(hdr http.Header) (*tok.Token, error) {
 //	- read-only access to a bucket is always granted
 //	- PATCH cannot be forbidden
 func (p *proxy) checkAccess(w http.ResponseWriter, r *http.Request, bck *meta.Bck, ace apc.AccessAttrs) (err error) {
-	if err = p.access(r.Header, bck, ace); err != nil {
+	if err = p.access(r, bck, ace); err != nil {
 		p.writeErr(w, r, err, aceErrToCode(err))
 	}
 	return
@@ -254,16 +317,16 @@ func aceErrToCode(err error) (status int) {
 	return status
 }
 
-func (p *proxy) access(hdr http.Header, bck *meta.Bck, ace apc.AccessAttrs) (err error) {
+func (p *proxy) access(r *http.Request, bck *meta.Bck, ace apc.AccessAttrs) (err error) {
 	var (
 		tk     *tok.Token
 		bucket *cmn.Bck
 	)
-	if p.isIntraCall(hdr, false /*from primary*/) == nil {
+	if p.isIntraCall(r.Header, false /*from primary*/) == nil {
 		return nil
 	}
 	if cmn.Rom.AuthEnabled() { // config.Auth.Enabled
-		tk, err = p.validateToken(hdr)
+		tk, err = p.validateToken(r)
 		if err != nil {
 			// NOTE: making exception to allow 3rd party clients read remote ht://bucket
 			if err == tok.ErrNoToken && bck != nil && bck.IsHT() {
@@ -275,8 +338,12 @@ func (p *proxy) access(hdr http.Header, bck *meta.Bck, ace apc.AccessAttrs) (err
 		if bck != nil {
 			bucket = bck.Bucket()
 		}
-		if err := tk.CheckPermissions(uid, bucket, ace); err != nil {
-			return err
+		// self-provisioned bucket: the owner needs no (user) permissions to read and write
+		// objects - bucket ACL still applies
+		if !isProvisionOwner(bck, tk.UserID, ace) {
+			if err := tk.CheckPermissions(uid, bucket, ace); err != nil {
+				return err
+			}
 		}
 	}
 	if bck == nil {
@@ -284,6 +351,13 @@ func (p *proxy) access(hdr http.Header, bck *meta.Bck, ace apc.AccessAttrs) (err
 		return nil
 	}
 
+	// frozen bucket: applies to all users, with or without AuthN (see apc.FreezeState)
+	if bck.Props != nil {
+		if err := bck.Props.Freeze.Check(bck.Cname(""), ace); err != nil {
+			return err
+		}
+	}
+
 	// bucket access conventions:
 	// - without AuthN: read-only access, PATCH, and ACL
 	// - with AuthN:    superuser can PATCH and change ACL
@@ -38,9 +38,10 @@ type bctx struct {
 	reqBody []byte          // request body of original request
 	perms   apc.AccessAttrs // apc.AceGET, apc.AcePATCH etc.
 
-	// 5 user or caller-provided control flags followed by
-	// 3 result flags
+	// 6 user or caller-provided control flags followed by
+	// 4 result flags
 	skipBackend    bool // initialize bucket via `bck.InitNoBackend`
+	skipAlias      bool // do not resolve bucket alias (see prxalias.go)
 	createAIS      bool // create ais bucket on the fly
 	dontAddRemote  bool // do not create (ie., add -> BMD) remote bucket on the fly
 	dontHeadRemote bool // do not HEAD remote bucket (to find out whether it exists and/or get properties)
@@ -48,6 +49,7 @@ type bctx struct {
 	isPresent      bool // the bucket is confirmed to be present (in the cluster's BMD)
 	exists         bool // remote bucket is confirmed to exist
 	modified       bool // bucket-defining control structure got modified
+	aliasRO        bool // resolved via read-only alias
 }
 
 ////////////////
@@ -117,6 +119,11 @@ func (bctx *bctx) init() (_ int, err error) {
 		}
 	}
 
+	// bucket aliasing
+	if !bctx.skipAlias && bck.IsAIS() {
+		bctx.resolveAlias()
+	}
+
 	if err = bctx.accessSupported(); err != nil {
 		return http.StatusMethodNotAllowed, err
 	}
@@ -133,6 +140,9 @@ func (bctx *bctx) init() (_ int, err error) {
 	}
 
 	bctx.isPresent = true
+	if bck.Props.Provision.TTL > 0 {
+		bctx.p.batime.touch(bck)
+	}
 
 	// if permissions are not explicitly specified check the default (msg.Action => permissions)
 	if bctx.perms == 0 && bctx.msg != nil {
@@ -142,6 +152,9 @@ func (bctx *bctx) init() (_ int, err error) {
 		}
 		bctx.perms = dtor.Access
 	}
+	if err = bctx.checkAliasRO(); err != nil {
+		return http.StatusForbidden, err
+	}
 	return bctx.accessAllowed(bck)
 }
 
@@ -176,7 +189,7 @@ rerr:
 
 // (compare w/ accessSupported)
 func (bctx *bctx) accessAllowed(bck *meta.Bck) (ecode int, err error) {
-	err = bctx.p.access(bctx.r.Header, bck, bctx.perms)
+	err = bctx.p.access(bctx.r, bck, bctx.perms)
 	ecode = aceErrToCode(err)
 	return ecode, err
 }
@@ -278,7 +291,7 @@ func (bctx *bctx) _try() (bck *meta.Bck, ecode int, err error) {
 		bck = backend
 	}
 	if bck.IsAIS() {
-		if err = bctx.p.access(bctx.r.Header, nil /*bck*/, apc.AceCreateBucket); err != nil {
+		if err = bctx.p.access(bctx.r, nil /*bck*/, apc.AceCreateBucket); err != nil {
 			return bck, aceErrToCode(err), err
 		}
 		nlog.Warningf("%s: %q doesn't exist, proceeding to create", bctx.p, bctx.bck)
@@ -57,8 +57,8 @@ func (p *proxy) bsummNew(qbck *cmn.QueryBcks, msg *apc.BsummCtrlMsg) (err error)
 		Method: http.MethodGet,
 		Path:   apc.URLPathBuckets.Join(qbck.Name, apc.ActBegin), // compare w/ txn
 		Query:  q,
-		Body:   cos.MustMarshal(aisMsg),
 	}
+	args.amsg = aisMsg
 	// not using default control-plane timeout -
 	// returning only _after_ all targets start running this new job
 	// (see Run() in nsumm.go)
@@ -91,8 +91,8 @@ func (p *proxy) bsummCollect(qbck *cmn.QueryBcks, msg *apc.BsummCtrlMsg) (_ cmn.
 	args.req = cmn.HreqArgs{
 		Method: http.MethodGet,
 		Path:   apc.URLPathBuckets.Join(qbck.Name, apc.ActQuery),
-		Body:   cos.MustMarshal(aisMsg),
 	}
+	args.amsg = aisMsg
 	args.smap = p.owner.smap.get()
 	if cnt := args.smap.CountActiveTs(); cnt < 1 {
 		return nil, 0, cmn.NewErrNoNodes(apc.Target, args.smap.CountTargets())
@@ -136,6 +136,7 @@ func (p *proxy) bsummCollect(qbck *cmn.QueryBcks, msg *apc.BsummCtrlMsg) (_ cmn.
 		}
 	}
 	summaries.Finalize(dsize, cmn.Rom.TestingEnv())
+	p.bsummFrozen(summaries)
 	freeBcastRes(results)
 
 	switch {
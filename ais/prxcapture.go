@@ -0,0 +1,207 @@
+// Package ais provides core functionality for the AIStore object storage.
+/*
+ * Copyright (c) 2024, NVIDIA CORPORATION. All rights reserved.
+ */
+package ais
+
+import (
+	"math/rand/v2"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/cmn/atomic"
+	"github.com/NVIDIA/aistore/cmn/cos"
+	"github.com/NVIDIA/aistore/cmn/nlog"
+	"github.com/NVIDIA/aistore/core/meta"
+	"github.com/NVIDIA/aistore/stats"
+)
+
+// Workload capture (see cmn.CaptureConf):
+// - sampled object requests are recorded by the gateway that redirects them;
+// - recording is asynchronous and never blocks (or fails) the request: when `captureBurst`
+//   records are pending the new one gets dropped (and counted);
+// - a single goroutine writes the trace; it also closes the trace when capture gets disabled;
+// - names are redacted (unless cmn.CaptureConf.KeepNames) by the same goroutine, with a random
+//   secret salt generated for each new trace - the salt is never recorded or shared.
+
+const (
+	captureBurst     = 4096
+	captureFlushIval = 4 * time.Second
+	captureSubdir    = "capture"
+)
+
+type (
+	capturer struct {
+		ch     chan *captRec
+		stopCh cos.StopCh
+		once   sync.Once
+		wg     sync.WaitGroup
+		sid    string
+		full   atomic.Bool
+
+		// writer state (goroutine)
+		fh      *os.File
+		tw      *cmn.TraceWriter
+		started int64  // Unix nanoseconds
+		size    int64  // bytes written
+		salt    uint64 // to redact names (see open)
+	}
+	captRec struct {
+		bck cmn.Bck // (rec.Bck when written)
+		rec cmn.TraceRec
+	}
+)
+
+func (c *capturer) init(sid string) {
+	c.sid = sid
+	c.ch = make(chan *captRec, captureBurst)
+	c.stopCh.Init()
+	c.wg.Add(1)
+	go c.run()
+}
+
+func (c *capturer) stop() {
+	c.once.Do(func() {}) // (no init after stop)
+	if c.ch != nil {
+		c.stopCh.Close()
+		c.wg.Wait()
+	}
+}
+
+// record object request
+// (op is HTTP method; `started` is the time the request was received)
+func (p *proxy) capture(op string, bck *meta.Bck, objName string, r *http.Request, started time.Time) {
+	conf := &cmn.GCO.Get().Proxy.Capture
+	if !conf.Enabled() || (conf.Pct < 100 && rand.IntN(100) >= conf.Pct) {
+		return
+	}
+	c := &p.capt
+	c.once.Do(func() { c.init(p.SID()) })
+	if c.full.Load() {
+		p.statsT.Inc(stats.CaptureDropCount)
+		return
+	}
+	crec := &captRec{bck: *bck.Bucket(), rec: cmn.TraceRec{Time: started.UnixNano(), Op: op, ObjName: objName}}
+	switch op {
+	case http.MethodPut:
+		crec.rec.Size = r.ContentLength
+	case http.MethodGet:
+		crec.rec.Range = r.Header.Get(cos.HdrRange)
+	}
+	select {
+	case c.ch <- crec:
+		p.statsT.Inc(stats.CaptureCount)
+	default:
+		p.statsT.Inc(stats.CaptureDropCount)
+	}
+}
+
+func (c *capturer) run() {
+	ticker := time.NewTicker(captureFlushIval)
+	defer func() {
+		ticker.Stop()
+		c.wg.Done()
+	}()
+	for {
+		select {
+		case rec := <-c.ch:
+			c.write(rec)
+		case <-ticker.C:
+			if conf := &cmn.GCO.Get().Proxy.Capture; conf.Enabled() {
+				c.flush()
+			} else {
+				c.close()
+				c.full.Store(false)
+			}
+		case <-c.stopCh.Listen():
+			for len(c.ch) > 0 {
+				c.write(<-c.ch)
+			}
+			c.close()
+			return
+		}
+	}
+}
+
+func (c *capturer) write(crec *captRec) {
+	if c.full.Load() {
+		return
+	}
+	conf := &cmn.GCO.Get().Proxy.Capture
+	if c.tw == nil {
+		if err := c.open(conf); err != nil {
+			nlog.Errorln("capture: failed to open workload trace:", err)
+			c.full.Store(true) // (until re-enabled)
+			return
+		}
+	}
+	rec := &crec.rec
+	if !conf.KeepNames {
+		crec.bck.Name, rec.ObjName = cmn.RedactName(crec.bck.Name, c.salt), cmn.RedactName(rec.ObjName, c.salt)
+	}
+	rec.Bck = crec.bck.Cname("")
+	rec.Time = max(rec.Time-c.started, 0)
+	if err := c.tw.Write(rec); err != nil {
+		nlog.Errorln("capture: failed to write", c.fh.Name()+":", err)
+		c.close()
+		c.full.Store(true)
+		return
+	}
+	if c.size >= conf.MaxSizeOrDflt() {
+		nlog.Warningln("capture:", c.fh.Name(), "reached max size", cos.ToSizeIEC(c.size, 0), "- stopping")
+		c.close()
+		c.full.Store(true)
+	}
+}
+
+func (c *capturer) open(conf *cmn.CaptureConf) (err error) {
+	dir := conf.Dir
+	if dir == "" {
+		dir = filepath.Join(cmn.GCO.Get().LogDir, captureSubdir)
+	}
+	if err = cos.CreateDir(dir); err != nil {
+		return err
+	}
+	now := time.Now()
+	fqn := filepath.Join(dir, "trace."+c.sid+"."+now.Format("20060102-150405")+".csv")
+	if c.fh, err = os.Create(fqn); err != nil {
+		return err
+	}
+	c.started, c.size = now.UnixNano(), 0
+	c.salt = cos.CryptoRandU64() // new trace, new salt
+	if c.tw, err = cmn.NewTraceWriter(c); err != nil {
+		c.close()
+		return err
+	}
+	nlog.Infoln("capture: recording workload trace", fqn)
+	return nil
+}
+
+func (c *capturer) flush() {
+	if c.tw == nil {
+		return
+	}
+	if err := c.tw.Flush(); err != nil {
+		nlog.Errorln("capture: failed to flush", c.fh.Name()+":", err)
+	}
+}
+
+func (c *capturer) close() {
+	if c.fh == nil {
+		return
+	}
+	c.flush()
+	cos.Close(c.fh)
+	c.fh, c.tw = nil, nil
+}
+
+// io.Writer (underneath csv writer) to keep track of the trace size
+func (c *capturer) Write(b []byte) (int, error) {
+	n, err := c.fh.Write(b)
+	c.size += int64(n)
+	return n, err
+}
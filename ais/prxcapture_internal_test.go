@@ -0,0 +1,127 @@
+// Package ais provides core functionality for the AIStore object storage.
+/*
+ * Copyright (c) 2024, NVIDIA CORPORATION. All rights reserved.
+ */
+package ais
+
+import (
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"time"
+
+	"github.com/NVIDIA/aistore/api/apc"
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/cmn/cos"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+func newCaptRec(op, objName string, size int64) *captRec {
+	return &captRec{
+		bck: cmn.Bck{Name: "abc", Provider: apc.AIS},
+		rec: cmn.TraceRec{Time: time.Now().UnixNano(), Op: op, ObjName: objName, Size: size},
+	}
+}
+
+var _ = Describe("Workload capture", func() {
+	var (
+		oldConf *cmn.Config
+		dir     string
+	)
+	BeforeEach(func() {
+		dir = GinkgoT().TempDir()
+		oldConf = cmn.GCO.Get()
+	})
+	AfterEach(func() {
+		cmn.GCO.BeginUpdate()
+		cmn.GCO.CommitUpdate(oldConf)
+	})
+
+	setConf := func(conf cmn.CaptureConf) {
+		config := cmn.GCO.BeginUpdate()
+		config.Proxy.Capture = conf
+		Expect(config.Proxy.Capture.Validate()).NotTo(HaveOccurred())
+		cmn.GCO.CommitUpdate(config)
+	}
+
+	readTrace := func() []*cmn.TraceRec {
+		fqns, err := filepath.Glob(filepath.Join(dir, "trace.p1.*.csv"))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(fqns).To(HaveLen(1))
+		fh, err := os.Open(fqns[0])
+		Expect(err).NotTo(HaveOccurred())
+		defer fh.Close()
+		tr, err := cmn.NewTraceReader(fh)
+		Expect(err).NotTo(HaveOccurred())
+		var recs []*cmn.TraceRec
+		for {
+			rec, err := tr.Next()
+			if err == io.EOF {
+				return recs
+			}
+			Expect(err).NotTo(HaveOccurred())
+			recs = append(recs, rec)
+		}
+	}
+
+	It("should record workload trace", func() {
+		setConf(cmn.CaptureConf{Pct: 100, Dir: dir, KeepNames: true})
+		c := &capturer{}
+		c.init("p1")
+		for i := range 10 {
+			c.ch <- newCaptRec("GET", "obj"+strconv.Itoa(i), 0)
+		}
+		c.stop()
+
+		recs := readTrace()
+		Expect(recs).To(HaveLen(10))
+		for i, rec := range recs {
+			Expect(rec.Bck).To(Equal("ais://abc"))
+			Expect(rec.ObjName).To(Equal("obj" + strconv.Itoa(i)))
+			Expect(rec.Time).To(BeNumerically(">=", 0))
+		}
+	})
+
+	It("should redact names with a different secret salt for each trace", func() {
+		setConf(cmn.CaptureConf{Pct: 100, Dir: dir})
+		names := make([]string, 0, 4)
+		for range 2 {
+			c := &capturer{}
+			c.init("p1")
+			c.ch <- newCaptRec("GET", "images/train/00001.jpg", 0)
+			c.ch <- newCaptRec("GET", "images/train/00001.jpg", 0)
+			c.stop()
+
+			recs := readTrace()
+			Expect(recs).To(HaveLen(2))
+			Expect(recs[0].ObjName).To(Equal(recs[1].ObjName)) // consistent within the trace
+			Expect(recs[0].ObjName).NotTo(ContainSubstring("train"))
+			Expect(recs[0].ObjName).To(HaveSuffix(".jpg"))
+			Expect(recs[0].Bck).NotTo(Equal("ais://abc"))
+			names = append(names, recs[0].ObjName, recs[0].Bck)
+
+			fqns, err := filepath.Glob(filepath.Join(dir, "trace.p1.*.csv"))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(os.Remove(fqns[0])).NotTo(HaveOccurred())
+		}
+		Expect(names[0]).NotTo(Equal(names[2]))
+		Expect(names[1]).NotTo(Equal(names[3]))
+	})
+
+	It("should stop recording upon reaching max size", func() {
+		setConf(cmn.CaptureConf{Pct: 100, Dir: dir, MaxSize: 8 * cos.KiB})
+		c := &capturer{}
+		c.init("p1")
+		for i := range 1000 {
+			c.ch <- newCaptRec("PUT", "obj"+strconv.Itoa(i), cos.MiB)
+		}
+		c.stop()
+
+		Expect(c.full.Load()).To(BeTrue())
+		recs := readTrace()
+		Expect(len(recs)).To(BeNumerically("<", 1000))
+		Expect(len(recs)).To(BeNumerically(">", 100))
+	})
+})
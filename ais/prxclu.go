@@ -23,6 +23,7 @@ import (
 	"github.com/NVIDIA/aistore/cmn/nlog"
 	"github.com/NVIDIA/aistore/core"
 	"github.com/NVIDIA/aistore/core/meta"
+	"github.com/NVIDIA/aistore/nl"
 	"github.com/NVIDIA/aistore/stats"
 	"github.com/NVIDIA/aistore/xact"
 	jsoniter "github.com/json-iterator/go"
@@ -81,6 +82,10 @@ func (p *proxy) httpcluget(w http.ResponseWriter, r *http.Request) {
 		p.qcluSysinfo(w, r, what, query)
 	case apc.WhatMountpaths:
 		p.qcluMountpaths(w, r, what, query)
+	case apc.WhatTop:
+		p.qcluTop(w, r, what, query)
+	case apc.WhatCapacity:
+		p.qcluCapacity(w, r, what, query)
 	case apc.WhatBackends:
 		config := cmn.GCO.Get()
 		out := make([]string, 0, len(config.Backend.Providers))
@@ -95,6 +100,13 @@ func (p *proxy) httpcluget(w http.ResponseWriter, r *http.Request) {
 			return
 		}
 		p.writeJSON(w, r, all, what)
+	case apc.WhatFederation:
+		fed, err := p.getFederation()
+		if err != nil {
+			p.writeErr(w, r, err)
+			return
+		}
+		p.writeJSON(w, r, fed, what)
 	case apc.WhatTargetIPs:
 		// Return comma-separated IPs of the targets.
 		// It can be used to easily fill the `--noproxy` parameter in cURL.
@@ -121,7 +133,9 @@ func (p *proxy) httpcluget(w http.ResponseWriter, r *http.Request) {
 		c := config.ClusterConfig
 		c.Auth.Secret = "**********"
 		p.writeJSON(w, r, &c, what)
-	case apc.WhatBMD, apc.WhatSmapVote, apc.WhatSnode, apc.WhatSmap:
+	case apc.WhatMDBundle:
+		p.exportMD(w, r)
+	case apc.WhatBMD, apc.WhatSmapVote, apc.WhatSnode, apc.WhatSmap, apc.WhatConfigSchema:
 		p.htrun.httpdaeget(w, r, query, nil /*htext*/)
 	default:
 		p.writeErrf(w, r, fmtUnknownQue, what)
@@ -265,6 +279,36 @@ func (p *proxy) getRemAisVec(refresh bool) (*meta.RemAisVec, error) {
 	return v, err
 }
 
+// federation view (by way of a random target that has all remote clusters attached)
+func (p *proxy) getFederation() (*meta.Federation, error) {
+	smap := p.owner.smap.get()
+	si, err := smap.GetRandTarget()
+	if err != nil {
+		return nil, err
+	}
+	cargs := allocCargs()
+	{
+		cargs.si = si
+		cargs.req = cmn.HreqArgs{
+			Method: http.MethodGet,
+			Path:   apc.URLPathDae.S,
+			Query:  url.Values{apc.QparamWhat: []string{apc.WhatFederation}},
+		}
+		cargs.timeout = cmn.GCO.Get().Client.TimeoutLong.D() // (remote clusters are queried in parallel)
+		cargs.cresv = cresFed{}
+	}
+	var (
+		fed *meta.Federation
+		res = p.call(cargs, smap)
+	)
+	if err = res.toErr(); err == nil {
+		fed = res.v.(*meta.Federation)
+	}
+	freeCargs(cargs)
+	freeCR(res)
+	return fed, err
+}
+
 func (p *proxy) _sysinfo(r *http.Request, timeout time.Duration, to int, query url.Values) (cos.JSONRawMsgs, error) {
 	args := allocBcArgs()
 	args.req = cmn.HreqArgs{Method: r.Method, Path: apc.URLPathDae.S, Query: query}
@@ -307,6 +351,59 @@ func (p *proxy) qcluMountpaths(w http.ResponseWriter, r *http.Request, what stri
 	p.writeJSON(w, r, out, what)
 }
 
+// merge per-target top-N reports
+func (p *proxy) qcluTop(w http.ResponseWriter, r *http.Request, what string, query url.Values) {
+	n, err := topN(query)
+	if err != nil {
+		p.writeErr(w, r, err)
+		return
+	}
+	targetTops, erred := p._queryTs(w, r, query)
+	if targetTops == nil || erred {
+		return
+	}
+	reps := make([]*stats.TopReport, 0, len(targetTops))
+	for tid, raw := range targetTops {
+		rep := &stats.TopReport{}
+		if err := jsoniter.Unmarshal(raw, rep); err != nil {
+			p.writeErrf(w, r, cmn.FmtErrUnmarshal, p, "top-N report from "+tid, cos.BHead(raw), err)
+			return
+		}
+		reps = append(reps, rep)
+	}
+	p.writeJSON(w, r, stats.MergeTop(reps, n), what)
+}
+
+// capacity planning report from all targets' capacity history
+func (p *proxy) qcluCapacity(w http.ResponseWriter, r *http.Request, what string, query url.Values) {
+	targetHists, erred := p._queryTs(w, r, query)
+	if targetHists == nil || erred {
+		return
+	}
+	hists := make(map[string]*stats.CapHistory, len(targetHists))
+	for tid, raw := range targetHists {
+		hist := &stats.CapHistory{}
+		if err := jsoniter.Unmarshal(raw, hist); err != nil {
+			p.writeErrf(w, r, cmn.FmtErrUnmarshal, p, "capacity history from "+tid, cos.BHead(raw), err)
+			return
+		}
+		hists[tid] = hist
+	}
+	p.writeJSON(w, r, stats.NewCapReport(hists, cmn.GCO.Get()), what)
+}
+
+func topN(query url.Values) (int, error) {
+	s := query.Get(apc.QparamTopN)
+	if s == "" {
+		return stats.DfltTopN, nil
+	}
+	n, err := strconv.Atoi(s)
+	if err != nil || n <= 0 {
+		return 0, fmt.Errorf("invalid %s=%q (expecting positive integer)", apc.QparamTopN, s)
+	}
+	return n, nil
+}
+
 // helper methods for querying targets
 
 func (p *proxy) _queryTs(w http.ResponseWriter, r *http.Request, query url.Values) (cos.JSONRawMsgs, bool) {
@@ -473,6 +570,7 @@ func (p *proxy) httpclupost(w http.ResponseWriter, r *http.Request) {
 	// node flags
 	if osi := smap.GetNode(nsi.ID()); osi != nil {
 		nsi.Flags = osi.Flags
+		nsi.Offload = osi.Offload // (administrative)
 	}
 	if nonElectable {
 		nsi.Flags = nsi.Flags.Set(meta.SnodeNonElectable)
@@ -592,6 +690,7 @@ func (p *proxy) fastKaliveRsp(w http.ResponseWriter, r *http.Request, smap *smap
 				if si.IsTarget() {
 					p._recvActiveEC(r.Header, now)
 				} else {
+					p.ic.loads.recv(sid, r.Header)
 					p._respActiveEC(w.Header(), now)
 				}
 				return
@@ -847,7 +946,7 @@ func (p *proxy) _joinedPre(ctx *smapModifier, clone *smapX) error {
 	}
 	clone.putNode(ctx.nsi, ctx.flags, true /*silent*/)
 	if ctx.nsi.IsProxy() {
-		clone.staffIC()
+		clone.staffIC(&p.ic.loads)
 	}
 	return nil
 }
@@ -976,7 +1075,7 @@ func (p *proxy) httpcluput(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 	if !p.NodeStarted() {
-		p.writeErrStatusf(w, r, http.StatusServiceUnavailable, "%s is not ready yet (starting up)", p)
+		p.writeErrAdmit(w, r, fmt.Errorf("%s is not ready yet (starting up)", p))
 		return
 	}
 	if len(apiItems) == 0 {
@@ -1001,7 +1100,7 @@ func (p *proxy) cluputMsg(w http.ResponseWriter, r *http.Request) {
 		// with two distinct exceptions
 		withRR := (msg.Action != apc.ActShutdownCluster && msg.Action != apc.ActXactStop)
 		if err := p.pready(nil, withRR); err != nil {
-			p.writeErr(w, r, err, http.StatusServiceUnavailable)
+			p.writeErrAdmit(w, r, err)
 			return
 		}
 	}
@@ -1014,6 +1113,10 @@ func (p *proxy) cluputMsg(w http.ResponseWriter, r *http.Request) {
 			return
 		}
 		query := r.URL.Query()
+		if cos.IsParseBool(query.Get(apc.QparamValidateOnly)) {
+			p.validateConfig(w, r, toUpdate, apc.Cluster)
+			return
+		}
 		if transient := cos.IsParseBool(query.Get(apc.ActTransient)); transient {
 			p.setCluCfgTransient(w, r, toUpdate, msg)
 		} else {
@@ -1065,6 +1168,20 @@ func (p *proxy) cluputMsg(w http.ResponseWriter, r *http.Request) {
 		p.xstart(w, r, msg)
 	case apc.ActXactStop:
 		p.xstop(w, r, msg)
+	case apc.ActPrefetchCursor:
+		p.prfCursor(w, r, msg)
+	case apc.ActSetPlacement:
+		p.setPlacement(w, r, msg)
+	case apc.ActSetWeight:
+		p.setWeight(w, r, msg)
+	case apc.ActNodeCapacity:
+		p.setNodeCap(w, r, msg)
+	case apc.ActImportMD:
+		p.importMD(w, r, msg)
+	case apc.ActSetBckAlias:
+		p.setBckAlias(w, r, msg)
+	case apc.ActDelBckAlias:
+		p.delBckAlias(w, r, msg)
 	case apc.ActSendOwnershipTbl:
 		p.sendOwnTbl(w, r, msg)
 	default:
@@ -1225,6 +1342,16 @@ func (p *proxy) xstart(w http.ResponseWriter, r *http.Request, msg *apc.ActMsg)
 		}
 		args._selected(tsi)
 		args.req.Body = cos.MustMarshal(apc.ActMsg{Action: msg.Action, Value: xargs, Name: msg.Name})
+	case xargs.Kind == apc.ActAuditPlacement:
+		// all targets; fix action (if any) in the name field
+		if err := apc.ValidateAuditFix(msg.Name); err != nil {
+			freeBcArgs(args)
+			p.writeErr(w, r, err)
+			return
+		}
+		args.to = core.Targets
+		xargs.ID = cos.GenUUID()
+		args.req.Body = cos.MustMarshal(apc.ActMsg{Action: msg.Action, Value: xargs, Name: msg.Name})
 	case xargs.Kind == apc.ActResilver && xargs.DaemonID != "":
 		args.smap = p.owner.smap.get()
 		tsi := args.smap.GetTarget(xargs.DaemonID)
@@ -1296,10 +1423,16 @@ func (p *proxy) xstop(w http.ResponseWriter, r *http.Request, msg *apc.ActMsg) {
 		p.writeErrf(w, r, cmn.FmtErrMorphUnmarshal, p.si, msg.Action, msg.Value, err)
 		return
 	}
+	if err := p.xabort(&xargs); err != nil {
+		p.writeErr(w, r, err)
+	}
+}
+
+func (p *proxy) xabort(xargs *xact.ArgsMsg) (err error) {
 	xargs.Kind, _ = xact.GetKindName(xargs.Kind) // display name => kind
 
 	// (lso + tco) special
-	p.lstca.abort(&xargs)
+	p.lstca.abort(xargs)
 
 	if xargs.Kind == apc.ActRebalance {
 		// disallow aborting rebalance during
@@ -1307,19 +1440,17 @@ func (p *proxy) xstop(w http.ResponseWriter, r *http.Request, msg *apc.ActMsg) {
 		smap := p.owner.smap.get()
 		for _, tsi := range smap.Tmap {
 			if tsi.Flags.IsAnySet(meta.SnodeMaint) && !tsi.Flags.IsAnySet(meta.SnodeMaintPostReb) {
-				p.writeErrf(w, r, "cannot abort %s: putting %s in maintenance mode - rebalancing...",
+				return fmt.Errorf("cannot abort %s: putting %s in maintenance mode - rebalancing...",
 					xargs.String(), tsi.StringEx())
-				return
 			}
 			if tsi.Flags.IsAnySet(meta.SnodeDecomm) {
-				p.writeErrf(w, r, "cannot abort %s: decommissioning %s - rebalancing...",
+				return fmt.Errorf("cannot abort %s: decommissioning %s - rebalancing...",
 					xargs.String(), tsi.StringEx())
-				return
 			}
 		}
 	}
 
-	body := cos.MustMarshal(apc.ActMsg{Action: msg.Action, Value: xargs})
+	body := cos.MustMarshal(apc.ActMsg{Action: apc.ActXactStop, Value: xargs})
 	args := allocBcArgs()
 	args.req = cmn.HreqArgs{Method: http.MethodPut, Path: apc.URLPathXactions.S, Body: body}
 	args.to = core.Targets
@@ -1328,6 +1459,33 @@ func (p *proxy) xstop(w http.ResponseWriter, r *http.Request, msg *apc.ActMsg) {
 
 	for _, res := range results {
 		if res.err != nil {
+			err = res.toErr()
+			break
+		}
+	}
+	freeBcastRes(results)
+	return err
+}
+
+// epoch-ahead prefetch: client-reported cursor => all targets
+func (p *proxy) prfCursor(w http.ResponseWriter, r *http.Request, msg *apc.ActMsg) {
+	var cmsg apc.PrefetchCursorMsg
+	if err := cos.MorphMarshal(msg.Value, &cmsg); err != nil {
+		p.writeErrf(w, r, cmn.FmtErrMorphUnmarshal, p.si, msg.Action, msg.Value, err)
+		return
+	}
+	if !xact.IsValidUUID(cmsg.ID) {
+		p.writeErrf(w, r, "%s: invalid xaction ID %q", msg.Action, cmsg.ID)
+		return
+	}
+	args := allocBcArgs()
+	args.req = cmn.HreqArgs{Method: http.MethodPut, Path: apc.URLPathXactions.S, Body: cos.MustMarshal(msg)}
+	args.to = core.Targets
+	results := p.bcastGroup(args)
+	freeBcArgs(args)
+	for _, res := range results {
+		// (not found: finished or not running on a given target)
+		if res.err != nil && res.status != http.StatusNotFound {
 			p.writeErr(w, r, res.toErr())
 			break
 		}
@@ -1437,11 +1595,7 @@ func (p *proxy) rmNode(w http.ResponseWriter, r *http.Request, msg *apc.ActMsg)
 		switch msg.Action {
 		case apc.ActDecommissionNode, apc.ActDecommissionCluster,
 			apc.ActShutdownNode, apc.ActShutdownCluster, apc.ActRmNodeUnsafe:
-			onl := true
-			flt := nlFilter{Kind: apc.ActRebalance, OnlyRunning: &onl}
-			if nl := p.notifs.find(flt); nl != nil {
-				p.writeErrf(w, r, "rebalance[%s] is currently running, please try (%s %s) later",
-					nl.UUID(), msg.Action, si.StringEx())
+			if !p.admittedReb(w, r, msg.Action+" "+sname) {
 				return
 			}
 			if !smap.InMaint(si) {
@@ -1571,7 +1725,7 @@ func (p *proxy) _markMaint(ctx *smapModifier, clone *smapX) error {
 		return newErrNotPrimary(p.si, clone, fmt.Sprintf("cannot put %s in maintenance", ctx.sid))
 	}
 	clone.setNodeFlags(ctx.sid, ctx.flags)
-	clone.staffIC()
+	clone.staffIC(&p.ic.loads)
 	return nil
 }
 
@@ -1669,14 +1823,14 @@ func (p *proxy) cluputItems(w http.ResponseWriter, r *http.Request, items []stri
 	switch action {
 	case apc.Proxy:
 		if err := p.pready(nil, true); err != nil {
-			p.writeErr(w, r, err, http.StatusServiceUnavailable)
+			p.writeErrAdmit(w, r, err)
 			return
 		}
 		// cluster-wide: designate a new primary proxy administratively
 		p.cluSetPrimary(w, r)
 	case apc.ActSetConfig: // set-config via query parameters and "?n1=v1&n2=v2..."
 		if err := p.pready(nil, true); err != nil {
-			p.writeErr(w, r, err, http.StatusServiceUnavailable)
+			p.writeErrAdmit(w, r, err)
 			return
 		}
 		var (
@@ -1688,6 +1842,10 @@ func (p *proxy) cluputItems(w http.ResponseWriter, r *http.Request, items []stri
 			p.writeErr(w, r, err)
 			return
 		}
+		if cos.IsParseBool(query.Get(apc.QparamValidateOnly)) {
+			p.validateConfig(w, r, toUpdate, apc.Cluster)
+			return
+		}
 		if transient := cos.IsParseBool(query.Get(apc.ActTransient)); transient {
 			p.setCluCfgTransient(w, r, toUpdate, msg)
 		} else {
@@ -1901,12 +2059,15 @@ func (p *proxy) _stopMaintPre(ctx *smapModifier, clone *smapX) error {
 	}
 	clone.clearNodeFlags(ctx.sid, ctx.flags)
 	if node.IsProxy() {
-		clone.staffIC()
+		clone.staffIC(&p.ic.loads)
 	}
 	return nil
 }
 
-func (p *proxy) _stopMaintRMD(ctx *smapModifier, clone *smapX) {
+func (p *proxy) _stopMaintRMD(ctx *smapModifier, clone *smapX) { p._rebRMD(ctx, clone, nil) }
+
+// start global rebalance; optional callback is invoked upon completion (instead of `rmdModifier.log`)
+func (p *proxy) _rebRMD(ctx *smapModifier, clone *smapX, cb func(*rmdModifier, nl.Listener)) {
 	if ctx.skipReb {
 		nlog.Infoln("ctx.skip-reb", ctx.skipReb)
 		return
@@ -1930,10 +2091,54 @@ func (p *proxy) _stopMaintRMD(ctx *smapModifier, clone *smapX) {
 		debug.AssertNoErr(err)
 		return
 	}
-	rmdCtx.listen(nil)
+	if cb == nil {
+		rmdCtx.listen(nil)
+	} else {
+		rmdCtx.listen(func(nl nl.Listener) { cb(rmdCtx, nl) })
+	}
 	ctx.rmdCtx = rmdCtx
 }
 
+// change cluster-wide object placement (apc.PlacementHRW <=> apc.PlacementCapacity);
+// new Smap is followed by global rebalance that migrates objects to their new locations
+func (p *proxy) setPlacement(w http.ResponseWriter, r *http.Request, msg *apc.ActMsg) {
+	var mode string
+	if err := cos.MorphMarshal(msg.Value, &mode); err != nil {
+		p.writeErrf(w, r, cmn.FmtErrMorphUnmarshal, p.si, msg.Action, msg.Value, err)
+		return
+	}
+	if err := apc.ValidatePlacement(mode); err != nil {
+		p.writeErr(w, r, err)
+		return
+	}
+	if mode == apc.PlacementHRW {
+		mode = "" // (default)
+	}
+	if smap := p.owner.smap.get(); smap.Placement == mode {
+		return // nothing to do
+	}
+	ctx := &smapModifier{
+		pre: func(_ *smapModifier, clone *smapX) error {
+			if !clone.isPrimary(p.si) {
+				return newErrNotPrimary(p.si, clone, "cannot set placement")
+			}
+			nlog.Infof("%s: placement %q => %q", p, clone.Placement, mode)
+			clone.Placement = mode
+			return nil
+		},
+		post:  p._stopMaintRMD, // (same rebalance-triggering logic)
+		final: p._syncFinal,
+		msg:   msg,
+	}
+	if err := p.owner.smap.modify(ctx); err != nil {
+		p.writeErr(w, r, err)
+		return
+	}
+	if ctx.rmdCtx != nil && ctx.rmdCtx.rebID != "" {
+		writeXid(w, ctx.rmdCtx.rebID)
+	}
+}
+
 func (p *proxy) cluSetPrimary(w http.ResponseWriter, r *http.Request) {
 	apiItems, err := p.parseURL(w, r, apc.URLPathCluProxy.L, 1, false)
 	if err != nil {
@@ -2067,13 +2272,13 @@ func (p *proxy) httpcludel(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 	if !p.NodeStarted() {
-		p.writeErrStatusf(w, r, http.StatusServiceUnavailable, "%s is not ready yet (starting up)", p)
+		p.writeErrAdmit(w, r, fmt.Errorf("%s is not ready yet (starting up)", p))
 		return
 	}
 
 	// primary (and cluster) to start and finalize rebalancing status _prior_ to removing invidual nodes
 	if err := p.pready(smap, true); err != nil {
-		p.writeErr(w, r, err, http.StatusServiceUnavailable)
+		p.writeErrAdmit(w, r, err)
 		return
 	}
 
@@ -2202,7 +2407,7 @@ func (p *proxy) _unregNodePre(ctx *smapModifier, clone *smapX) error {
 	if node.IsProxy() {
 		clone.delProxy(sid)
 		nlog.Infof("%s %s (num proxies %d)", verb, node.StringEx(), clone.CountProxies())
-		clone.staffIC()
+		clone.staffIC(&p.ic.loads)
 	} else {
 		clone.delTarget(sid)
 		nlog.Infof("%s %s (num targets %d)", verb, node.StringEx(), clone.CountTargets())
@@ -23,8 +23,7 @@ import (
 
 // [METHOD] /v1/download
 func (p *proxy) dloadHandler(w http.ResponseWriter, r *http.Request) {
-	if !p.ClusterStarted() {
-		w.WriteHeader(http.StatusServiceUnavailable)
+	if !p.admitted(w, r) {
 		return
 	}
 	switch r.Method {
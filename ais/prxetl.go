@@ -18,14 +18,14 @@ import (
 	"github.com/NVIDIA/aistore/cmn/k8s"
 	"github.com/NVIDIA/aistore/cmn/nlog"
 	"github.com/NVIDIA/aistore/ext/etl"
+	jsoniter "github.com/json-iterator/go"
 )
 
 // TODO: support start/stop/list using `xid`
 
 // [METHOD] /v1/etl
 func (p *proxy) etlHandler(w http.ResponseWriter, r *http.Request) {
-	if !p.cluStartedWithRetry() {
-		w.WriteHeader(http.StatusServiceUnavailable)
+	if !p.admitted(w, r) {
 		return
 	}
 	switch {
@@ -78,6 +78,12 @@ func (p *proxy) httpetlget(w http.ResponseWriter, r *http.Request) {
 	case apc.ETLMetrics:
 		// /v1/etl/<etl-name>/metrics
 		p.metricsETL(w, r)
+	case apc.ETLEstimate:
+		// /v1/etl/<etl-name>/estimate/<bucket-name>
+		p.estimateETL(w, r, apiItems[0], apiItems[2:])
+	case apc.ETLDetails:
+		// /v1/etl/<etl-name>/details
+		p.detailsETL(w, r, apiItems[0])
 	default:
 		p.writeErrURL(w, r)
 	}
@@ -282,6 +288,15 @@ func (p *proxy) infoETL(w http.ResponseWriter, r *http.Request, etlName string)
 
 // GET /v1/etl
 func (p *proxy) listETL(w http.ResponseWriter, r *http.Request) {
+	etls, err := p._listETL()
+	if err != nil {
+		p.writeErr(w, r, err)
+		return
+	}
+	p.writeJSON(w, r, etls, "list-etl")
+}
+
+func (p *proxy) _listETL() (etl.InfoList, error) {
 	var (
 		args = allocBcArgs()
 		etls *etl.InfoList
@@ -294,9 +309,9 @@ func (p *proxy) listETL(w http.ResponseWriter, r *http.Request) {
 
 	for _, res := range results {
 		if res.err != nil {
-			p.writeErr(w, r, res.toErr())
+			err := res.toErr()
 			freeBcastRes(results)
-			return
+			return nil, err
 		}
 
 		if etls == nil {
@@ -314,9 +329,9 @@ func (p *proxy) listETL(w http.ResponseWriter, r *http.Request) {
 	}
 	freeBcastRes(results)
 	if etls == nil {
-		etls = &etl.InfoList{}
+		return etl.InfoList{}, nil
 	}
-	p.writeJSON(w, r, *etls, "list-etl")
+	return *etls, nil
 }
 
 // GET /v1/etl/<etl-name>/logs[/<target_id>]
@@ -395,6 +410,41 @@ func (p *proxy) healthETL(w http.ResponseWriter, r *http.Request) {
 	p.writeJSON(w, r, healths, "health-etl")
 }
 
+// GET /v1/etl/<etl-name>/details
+// init message and per-target status (including fallback routing - see ais/tgtetlfb.go)
+func (p *proxy) detailsETL(w http.ResponseWriter, r *http.Request, etlName string) {
+	if err := k8s.ValidateEtlName(etlName); err != nil {
+		p.writeErr(w, r, err)
+		return
+	}
+	initMsg := p.owner.etl.get().get(etlName)
+	if initMsg == nil {
+		p.writeErr(w, r, cos.NewErrNotFound(p, "etl job "+etlName))
+		return
+	}
+	details := &etl.Details{InitMsg: cos.MustMarshal(initMsg)}
+
+	args := allocBcArgs()
+	args.req = cmn.HreqArgs{Method: http.MethodGet, Path: apc.URLPathETL.Join(etlName)}
+	args.timeout = apc.DefaultTimeout
+	args.cresv = cresES{} // -> etl.NodeStatus
+	results := p.bcastGroup(args)
+	freeBcArgs(args)
+
+	details.Nodes = make(etl.StatusByTarget, 0, len(results))
+	for _, res := range results {
+		if res.err != nil {
+			p.writeErr(w, r, res.toErr(), res.status)
+			freeBcastRes(results)
+			return
+		}
+		details.Nodes = append(details.Nodes, res.v.(*etl.NodeStatus))
+	}
+	freeBcastRes(results)
+	sort.Slice(details.Nodes, func(i, j int) bool { return details.Nodes[i].TargetID < details.Nodes[j].TargetID })
+	p.writeJSON(w, r, details, "details-etl")
+}
+
 // GET /v1/etl/<etl-name>/metrics
 func (p *proxy) metricsETL(w http.ResponseWriter, r *http.Request) {
 	var (
@@ -421,10 +471,82 @@ func (p *proxy) metricsETL(w http.ResponseWriter, r *http.Request) {
 	p.writeJSON(w, r, metrics, "metrics-etl")
 }
 
+// GET /v1/etl/<etl-name>/estimate/<bucket-name>
+// pre-run estimate of the offline (bucket-to-bucket) transformation
+func (p *proxy) estimateETL(w http.ResponseWriter, r *http.Request, etlName string, apiItems []string) {
+	if len(apiItems) != 1 {
+		p.writeErrURL(w, r)
+		return
+	}
+	if p.owner.etl.get().get(etlName) == nil {
+		p.writeErr(w, r, cos.NewErrNotFound(p, "etl job "+etlName))
+		return
+	}
+	query := r.URL.Query()
+	bck, err := newBckFromQ(apiItems[0], query, nil)
+	if err != nil {
+		p.writeErr(w, r, err)
+		return
+	}
+	if err := bck.Init(p.owner.bmd); err != nil {
+		p.writeErr(w, r, err)
+		return
+	}
+	msg := &apc.TCBMsg{}
+	if err := readJSON(w, r, msg); err != nil {
+		return
+	}
+	msg.Transform.Name = etlName
+	if err := msg.Validate(true); err != nil {
+		p.writeErr(w, r, err)
+		return
+	}
+	if v := query.Get(apc.QparamETLSamples); v != "" {
+		if n, err := strconv.Atoi(v); err != nil || n <= 0 {
+			p.writeErrf(w, r, "invalid number of samples %q (expecting positive integer)", v)
+			return
+		}
+	}
+
+	args := allocBcArgs()
+	args.req = cmn.HreqArgs{Method: http.MethodGet, Path: r.URL.Path, Query: query, Body: cos.MustMarshal(msg)}
+	args.timeout = apc.LongTimeout
+	results := p.bcastGroup(args)
+	freeBcArgs(args)
+	defer freeBcastRes(results)
+
+	est := &apc.TCBEstimate{Targets: make(map[string]*apc.TCBEstimate, len(results))}
+	for _, res := range results {
+		if res.err != nil {
+			p.writeErr(w, r, res.toErr(), res.status)
+			return
+		}
+		te := &apc.TCBEstimate{}
+		if err := jsoniter.Unmarshal(res.bytes, te); err != nil {
+			p.writeErr(w, r, err)
+			return
+		}
+		est.Targets[res.si.ID()] = te
+		est.ObjCount += te.ObjCount
+		est.Size += te.Size
+		est.SampleCount += te.SampleCount
+		est.SampleSize += te.SampleSize
+		est.SampleTime = max(est.SampleTime, te.SampleTime)
+		est.ETA = max(est.ETA, te.ETA)
+	}
+	p.writeJSON(w, r, est, "estimate-etl")
+}
+
 // POST /v1/etl/<etl-name>/stop
 func (p *proxy) stopETL(w http.ResponseWriter, r *http.Request) {
+	if err := p._stopETL(r.URL.Path); err != nil {
+		p.writeErr(w, r, err)
+	}
+}
+
+func (p *proxy) _stopETL(path string) (err error) {
 	args := allocBcArgs()
-	args.req = cmn.HreqArgs{Method: http.MethodPost, Path: r.URL.Path}
+	args.req = cmn.HreqArgs{Method: http.MethodPost, Path: path}
 	args.timeout = apc.LongTimeout
 	results := p.bcastGroup(args)
 	freeBcArgs(args)
@@ -432,8 +554,9 @@ func (p *proxy) stopETL(w http.ResponseWriter, r *http.Request) {
 		if res.err == nil {
 			continue
 		}
-		p.writeErr(w, r, res.toErr())
+		err = res.toErr()
 		break
 	}
 	freeBcastRes(results)
+	return err
 }
@@ -0,0 +1,236 @@
+// Package ais provides core functionality for the AIStore object storage.
+/*
+ * Copyright (c) 2024, NVIDIA CORPORATION. All rights reserved.
+ */
+package ais
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/NVIDIA/aistore/api/apc"
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/cmn/cos"
+	"github.com/NVIDIA/aistore/cmn/nlog"
+	"github.com/NVIDIA/aistore/core/meta"
+	"github.com/NVIDIA/aistore/hk"
+)
+
+// Bucket freeze (see cmn/freeze.go):
+// - freeze state is stored in BMD (bucket props) and enforced by proxies - see p.access;
+// - both freeze and thaw are executed by the primary;
+// - primary housekeeping: thaw expired freezes; for buckets with quota, run bucket
+//   summary (in-cluster objects) and freeze (thaw) those that exceed (no longer exceed) it;
+//   summary takes one housekeeping round to start and at least one more to collect.
+
+const freezeIval = time.Minute
+
+type freezeHK struct {
+	p       *proxy
+	pending map[string]string // bucket uname => bucket summary UUID (quota check in progress)
+}
+
+// POST {apc.ActFreezeBck, apc.ActThawBck} /v1/buckets/bucket-name
+func (p *proxy) freezeBck(w http.ResponseWriter, r *http.Request, msg *apc.ActMsg, bck *meta.Bck) {
+	if err := p.checkAccess(w, r, bck, apc.AceBckSetACL); err != nil {
+		return
+	}
+	var state apc.FreezeState // (thaw)
+	if msg.Action == apc.ActFreezeBck {
+		var fmsg apc.FreezeMsg
+		if err := cos.MorphMarshal(msg.Value, &fmsg); err != nil {
+			p.writeErrf(w, r, cmn.FmtErrMorphUnmarshal, p.si, msg.Action, msg.Value, err)
+			return
+		}
+		if err := fmsg.Validate(); err != nil {
+			p.writeErr(w, r, err)
+			return
+		}
+		now := time.Now().UnixNano()
+		state = apc.FreezeState{Mode: fmsg.Mode, Reason: fmsg.Reason, Trigger: apc.FreezeByUser, Since: now}
+		if fmsg.Duration > 0 {
+			state.Until = now + int64(fmsg.Duration)
+		}
+	}
+	if _, err := p.setFreeze(bck, &state, nil /*any*/, msg); err != nil {
+		p.writeErr(w, r, err)
+		return
+	}
+	nlog.Infoln(p.String(), msg.Action, bck.Cname(""), state.Mode, state.Reason)
+}
+
+// set (or clear, when `state` is zero) bucket's freeze state;
+// non-nil `prev` makes it conditional: only if the current state is still `prev`
+func (p *proxy) setFreeze(bck *meta.Bck, state, prev *apc.FreezeState, msg *apc.ActMsg) (bool, error) {
+	ctx := &bmdModifier{
+		pre: func(ctx *bmdModifier, clone *bucketMD) error {
+			bprops, present := clone.Get(bck)
+			if !present {
+				return cmn.NewErrBckNotFound(bck.Bucket())
+			}
+			if (prev != nil && bprops.Freeze.State != *prev) || bprops.Freeze.State == *state {
+				ctx.terminate = true
+				return nil
+			}
+			nprops := bprops.Clone()
+			nprops.Freeze.State = *state
+			clone.set(bck, nprops)
+			return nil
+		},
+		final: p.bmodSync,
+		msg:   msg,
+		bcks:  []*meta.Bck{bck},
+		wait:  true,
+	}
+	_, err := p.owner.bmd.modify(ctx)
+	return err == nil && !ctx.terminate, err
+}
+
+// (primary) integrity alert, e.g. manifest verification mismatch
+func (p *proxy) freezeOnIntegrity(bck *meta.Bck, reason string) {
+	var (
+		conf = &bck.Props.Freeze
+		prev = conf.State
+		now  = time.Now().UnixNano()
+	)
+	if conf.OnIntegrity == "" {
+		return
+	}
+	// already frozen (unless escalating read-only => no-access)
+	if prev.IsFrozen(now) && (prev.Mode == apc.FreezeNoAccess || conf.OnIntegrity == apc.FreezeRO) {
+		return
+	}
+	state := apc.FreezeState{Mode: conf.OnIntegrity, Reason: reason, Trigger: apc.FreezeByIntegrity, Since: now}
+	msg := &apc.ActMsg{Action: apc.ActFreezeBck, Name: apc.FreezeByIntegrity}
+	if ok, err := p.setFreeze(bck, &state, &prev, msg); err != nil {
+		nlog.Errorln(p.String(), "failed to freeze", bck.Cname(""), "upon integrity alert:", err)
+	} else if ok {
+		nlog.Warningln(p.String(), "froze", bck.Cname(""), "(", state.Mode, ") upon integrity alert:", reason)
+	}
+}
+
+// fill in bucket summaries (see bsummCollect)
+func (p *proxy) bsummFrozen(summaries cmn.AllBsummResults) {
+	var (
+		bmd = p.owner.bmd.get()
+		now = time.Now().UnixNano()
+	)
+	for _, summ := range summaries {
+		bprops, present := bmd.Get((*meta.Bck)(&summ.Bck))
+		if present && bprops.Freeze.State.IsFrozen(now) {
+			state := bprops.Freeze.State
+			summ.Frozen = &state
+		}
+	}
+}
+
+//////////////
+// freezeHK //
+//////////////
+
+func (p *proxy) initFreeze() {
+	fhk := &freezeHK{p: p, pending: make(map[string]string, 4)}
+	hk.Reg("bucket-freeze"+hk.NameSuffix, fhk.housekeep, freezeIval)
+}
+
+func (fhk *freezeHK) housekeep(int64) time.Duration {
+	p := fhk.p
+	if !p.ClusterStarted() {
+		return freezeIval
+	}
+	smap := p.owner.smap.get()
+	if !smap.isPrimary(p.si) {
+		clear(fhk.pending)
+		return freezeIval
+	}
+	var (
+		quota []*meta.Bck
+		bmd   = p.owner.bmd.get()
+		now   = time.Now().UnixNano()
+	)
+	bmd.Range(nil, nil, func(bck *meta.Bck) bool {
+		conf := &bck.Props.Freeze
+		switch {
+		case conf.State.Expired(now):
+			fhk.thaw(bck, "expired")
+		case conf.State.Trigger == apc.FreezeByQuota && conf.Quota == 0:
+			fhk.thaw(bck, "quota removed")
+		case conf.Quota > 0:
+			quota = append(quota, bck)
+		}
+		return false
+	})
+	for _, bck := range quota {
+		fhk.checkQuota(bck)
+	}
+	// forget buckets that no longer exist or have no quota
+	for uname := range fhk.pending {
+		b, _ := cmn.ParseUname(uname)
+		if bprops, present := bmd.Get((*meta.Bck)(&b)); !present || bprops.Freeze.Quota == 0 {
+			delete(fhk.pending, uname)
+		}
+	}
+	return freezeIval
+}
+
+func (fhk *freezeHK) thaw(bck *meta.Bck, why string) {
+	var (
+		p     = fhk.p
+		prev  = bck.Props.Freeze.State
+		state apc.FreezeState
+		msg   = &apc.ActMsg{Action: apc.ActThawBck, Name: why}
+	)
+	if ok, err := p.setFreeze(bck, &state, &prev, msg); err != nil {
+		nlog.Errorln(p.String(), "failed to thaw", bck.Cname(""), "err:", err)
+	} else if ok {
+		nlog.Infoln(p.String(), "thawed", bck.Cname(""), "[", prev.Trigger, prev.Mode, "]", why)
+	}
+}
+
+// start bucket summary or, if already started, collect and compare with the quota
+func (fhk *freezeHK) checkQuota(bck *meta.Bck) {
+	var (
+		p     = fhk.p
+		uname = string(bck.MakeUname(""))
+		qbck  = (*cmn.QueryBcks)(bck)
+		msg   = &apc.BsummCtrlMsg{UUID: fhk.pending[uname], ObjCached: true, BckPresent: true}
+	)
+	if msg.UUID == "" {
+		if err := p.bsummNew(qbck, msg); err != nil {
+			nlog.Warningln(p.String(), "bucket-freeze: failed to check", bck.Cname(""), "quota:", err)
+			return
+		}
+		fhk.pending[uname] = msg.UUID
+		return
+	}
+	summaries, status, err := p.bsummCollect(qbck, msg)
+	if err == nil && status != http.StatusOK {
+		return // (next time)
+	}
+	delete(fhk.pending, uname)
+	if err != nil || len(summaries) == 0 {
+		nlog.Warningln(p.String(), "bucket-freeze: failed to check", bck.Cname(""), "quota:", err)
+		return
+	}
+
+	var (
+		size = int64(summaries[0].TotalSize.PresentObjs)
+		conf = &bck.Props.Freeze
+		prev = conf.State
+		now  = time.Now().UnixNano()
+	)
+	switch {
+	case size > int64(conf.Quota) && !prev.IsFrozen(now):
+		reason := fmt.Sprintf("size %s exceeds quota %s", cos.ToSizeIEC(size, 2), conf.Quota)
+		state := apc.FreezeState{Mode: apc.FreezeRO, Reason: reason, Trigger: apc.FreezeByQuota, Since: now}
+		msg := &apc.ActMsg{Action: apc.ActFreezeBck, Name: apc.FreezeByQuota}
+		if ok, err := p.setFreeze(bck, &state, &prev, msg); err != nil {
+			nlog.Errorln(p.String(), "failed to freeze", bck.Cname(""), "err:", err)
+		} else if ok {
+			nlog.Warningln(p.String(), "froze", bck.Cname(""), "(", state.Mode, "):", reason)
+		}
+	case size <= int64(conf.Quota) && prev.Trigger == apc.FreezeByQuota:
+		fhk.thaw(bck, "within quota")
+	}
+}
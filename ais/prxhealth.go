@@ -0,0 +1,169 @@
+// Package ais provides core functionality for the AIStore object storage.
+/*
+ * Copyright (c) 2024, NVIDIA CORPORATION. All rights reserved.
+ */
+package ais
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"sync"
+	"time"
+
+	"github.com/NVIDIA/aistore/api/apc"
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/cmn/cos"
+	"github.com/NVIDIA/aistore/cmn/mono"
+	"github.com/NVIDIA/aistore/cmn/nlog"
+	"github.com/NVIDIA/aistore/core/meta"
+)
+
+// deep health check (apc.QparamHealthDeep):
+// - non-primary proxies forward the request to the primary;
+// - the primary writes, reads back, and deletes a tiny object via a random target
+//   (the one that HRW-owns a randomly named object) in a hidden system bucket;
+// - the bucket is created by the primary upon startup (and election) - never by
+//   the (unauthenticated) health check itself;
+// - the result (cmn.HealthProbe) is cached for a short while, so that multiple
+//   load balancers and monitors do not translate into as many probes;
+// - failure to probe results in http.StatusServiceUnavailable.
+
+const (
+	hprobeCacheTime = 2 * time.Second
+	hprobeSize      = 64
+	hprobeRetries   = 5
+)
+
+var hprobeBck = cmn.Bck{Name: "health", Provider: apc.AIS, Ns: cmn.NsSys}
+
+type hprobe struct {
+	last *cmn.HealthProbe
+	mu   sync.Mutex
+	tm   int64 // (mono time)
+}
+
+func (p *proxy) deepHealth(w http.ResponseWriter, r *http.Request, smap *smapX) {
+	p.hprobe.mu.Lock()
+	res := p.hprobe.last
+	if res == nil || mono.Since(p.hprobe.tm) > hprobeCacheTime {
+		res = p._probe(smap)
+		p.hprobe.last, p.hprobe.tm = res, mono.NanoTime()
+	}
+	p.hprobe.mu.Unlock()
+
+	if !res.OK {
+		w.Header().Set(cos.HdrContentType, cos.ContentJSONCharsetUTF)
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}
+	p.writeJSON(w, r, res, "deep-health")
+}
+
+func (p *proxy) _probe(smap *smapX) *cmn.HealthProbe {
+	var (
+		res     = &cmn.HealthProbe{Time: time.Now().UnixNano()}
+		started = mono.NanoTime()
+		err     = meta.CloneBck(&hprobeBck).Init(p.owner.bmd)
+	)
+	if err == nil {
+		err = p._probeObj(smap, res)
+	}
+	res.Total = mono.Since(started)
+	if err != nil {
+		res.Err = err.Error()
+		nlog.Warningln(p.String(), "deep health check failed:", err)
+	} else {
+		res.OK = true
+	}
+	return res
+}
+
+// create the (hidden) bucket if it does not exist yet
+// (runs asynchronously when the primary starts up or gets elected)
+func (p *proxy) initProbeBck() {
+	sleep := cmn.Rom.MaxKeepalive()
+	for i := range hprobeRetries {
+		if i > 0 {
+			time.Sleep(sleep)
+		}
+		if nlog.Stopping() || !p.owner.smap.get().isPrimary(p.si) {
+			return
+		}
+		bck := meta.CloneBck(&hprobeBck)
+		err := bck.Init(p.owner.bmd)
+		if err == nil {
+			return
+		}
+		if cmn.IsErrBckNotFound(err) {
+			err = p.createBucket(&apc.ActMsg{Action: apc.ActCreateBck}, bck, nil)
+			if err == nil || cmn.IsErrBucketAlreadyExists(err) {
+				return
+			}
+		}
+		nlog.Warningln(p.String(), "failed to create", hprobeBck.Cname(""), "for deep health check:", err)
+	}
+}
+
+func (p *proxy) _probeObj(smap *smapX, res *cmn.HealthProbe) error {
+	var (
+		objName = "probe-" + cos.GenTie()
+		data    = []byte(cos.CryptoRandS(hprobeSize))
+		uname   = hprobeBck.MakeUname(objName)
+	)
+	tsi, err := smap.HrwName2T(uname)
+	if err != nil {
+		return err
+	}
+	res.Target = tsi.ID()
+
+	// PUT
+	started := mono.NanoTime()
+	if _, err := p._probeCall(http.MethodPut, tsi, objName, data, smap); err != nil {
+		return fmt.Errorf("PUT via %s: %w", tsi.StringEx(), err)
+	}
+	res.Put = mono.Since(started)
+
+	// GET
+	started = mono.NanoTime()
+	b, err := p._probeCall(http.MethodGet, tsi, objName, nil, smap)
+	if err == nil && !bytes.Equal(b, data) {
+		err = errors.New("content mismatch")
+	}
+	res.Get = mono.Since(started)
+
+	// DELETE (always)
+	started = mono.NanoTime()
+	if _, errN := p._probeCall(http.MethodDelete, tsi, objName, nil, smap); errN != nil && err == nil {
+		return fmt.Errorf("DELETE via %s: %w", tsi.StringEx(), errN)
+	}
+	res.Del = mono.Since(started)
+	if err != nil {
+		return fmt.Errorf("GET via %s: %w", tsi.StringEx(), err)
+	}
+	return nil
+}
+
+func (p *proxy) _probeCall(method string, tsi *meta.Snode, objName string, body []byte, smap *smapX) ([]byte, error) {
+	query := hprobeBck.NewQuery()
+	query.Set(apc.QparamProxyID, p.SID())
+	query.Set(apc.QparamUnixTime, cos.UnixNano2S(time.Now().UnixNano()))
+	cargs := allocCargs()
+	{
+		cargs.si = tsi
+		cargs.req = cmn.HreqArgs{
+			Method: method,
+			Base:   tsi.URL(cmn.NetIntraData),
+			Path:   apc.URLPathObjects.Join(hprobeBck.Name, objName),
+			Query:  url.Values(query),
+			Body:   body,
+		}
+		cargs.timeout = cmn.Rom.MaxKeepalive()
+	}
+	res := p.call(cargs, smap)
+	freeCargs(cargs)
+	b, err := res.bytes, res.toErr()
+	freeCR(res)
+	return b, err
+}
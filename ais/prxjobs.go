@@ -0,0 +1,607 @@
+// Package ais provides core functionality for the AIStore object storage.
+/*
+ * Copyright (c) 2024, NVIDIA CORPORATION. All rights reserved.
+ */
+package ais
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/NVIDIA/aistore/api/apc"
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/cmn/cos"
+	"github.com/NVIDIA/aistore/cmn/nlog"
+	"github.com/NVIDIA/aistore/core"
+	"github.com/NVIDIA/aistore/ext/dload"
+	"github.com/NVIDIA/aistore/ext/dsort"
+	"github.com/NVIDIA/aistore/ext/etl"
+	"github.com/NVIDIA/aistore/xact"
+	jsoniter "github.com/json-iterator/go"
+)
+
+// Unified jobs API - see cmn.Job
+// - GET    /v1/jobs[?type=...&kind=...&only_active=true] - list
+// - GET    /v1/jobs/<job-id>[?wait=<duration>]           - get (optionally, wait for the job to finish)
+// - DELETE /v1/jobs/<job-id>                             - abort
+// - POST   /v1/jobs { apc.ActPipeline }                  - submit pipeline (see prxpipe)
+// where <job-id> is either unified ("<type>:<native-ID>") or native (e.g., xaction UUID).
+//
+// Each job type has its own adapter that maps the underlying subsystem onto cmn.Job;
+// dsort, download, and ETL-inline xactions are not listed as such - they are represented
+// by their respective jobs.
+
+type (
+	jobAdapter interface {
+		list(onlyActive bool) (cmn.Jobs, error)
+		get(nid string) (*cmn.Job, error)
+		abort(nid string) error
+	}
+	jxact  struct{ p *proxy }
+	jdsort struct{ p *proxy }
+	jdload struct{ p *proxy }
+	jetl   struct{ p *proxy }
+)
+
+// interface guard
+var (
+	_ jobAdapter = (*jxact)(nil)
+	_ jobAdapter = (*jdsort)(nil)
+	_ jobAdapter = (*jdload)(nil)
+	_ jobAdapter = (*jetl)(nil)
+)
+
+var jobTypes = []string{cmn.JobTypeXaction, cmn.JobTypeDsort, cmn.JobTypeDownload, cmn.JobTypeETL, cmn.JobTypePipeline}
+
+func (p *proxy) jadapter(typ string) jobAdapter {
+	switch typ {
+	case cmn.JobTypeXaction:
+		return &jxact{p}
+	case cmn.JobTypeDsort:
+		return &jdsort{p}
+	case cmn.JobTypeDownload:
+		return &jdload{p}
+	case cmn.JobTypeETL:
+		return &jetl{p}
+	case cmn.JobTypePipeline:
+		return &jpipe{p}
+	}
+	return nil
+}
+
+// [METHOD] /v1/jobs
+func (p *proxy) jobsHandler(w http.ResponseWriter, r *http.Request) {
+	if !p.admitted(w, r) {
+		return
+	}
+	apiItems, err := p.parseURL(w, r, apc.URLPathJobs.L, 0, false)
+	if err != nil {
+		return
+	}
+	switch r.Method {
+	case http.MethodGet:
+		if err := p.checkAccess(w, r, nil, apc.AceShowCluster); err != nil {
+			return
+		}
+	case http.MethodDelete:
+		if len(apiItems) != 1 {
+			p.writeErrURL(w, r)
+			return
+		}
+		if err := p.checkAccess(w, r, nil, apc.AceAdmin); err != nil {
+			return
+		}
+	case http.MethodPost:
+		if len(apiItems) != 0 {
+			p.writeErrURL(w, r)
+			return
+		}
+		if err := p.checkAccess(w, r, nil, apc.AceAdmin); err != nil {
+			return
+		}
+		p.startPipeline(w, r)
+		return
+	default:
+		cmn.WriteErr405(w, r, http.MethodDelete, http.MethodGet, http.MethodPost)
+		return
+	}
+	// (dsort job queue and xaction aborts are handled by the primary)
+	if p.forwardCP(w, r, nil, "jobs") {
+		return
+	}
+	if len(apiItems) == 0 {
+		p.listJobs(w, r)
+		return
+	}
+	typ, nid, err := resolveJobID(apiItems[0])
+	if err != nil {
+		p.writeErr(w, r, err)
+		return
+	}
+	if r.Method == http.MethodDelete {
+		if err := p.jadapter(typ).abort(nid); err != nil {
+			p.writeErr(w, r, err, _jobErrCode(err))
+		}
+		return
+	}
+	p.getJob(w, r, typ, nid)
+}
+
+func (p *proxy) listJobs(w http.ResponseWriter, r *http.Request) {
+	var (
+		query      = r.URL.Query()
+		typ        = query.Get(apc.QparamJobType)
+		kind       = query.Get(apc.QparamJobKind)
+		onlyActive = cos.IsParseBool(query.Get(apc.QparamOnlyActive))
+		all        = make(cmn.Jobs, 0, 16)
+	)
+	if typ != "" && p.jadapter(typ) == nil {
+		p.writeErrf(w, r, "invalid job type %q (expecting one of: %v)", typ, jobTypes)
+		return
+	}
+	if kind != "" {
+		kind, _ = xact.GetKindName(kind) // display name => kind
+	}
+	for _, t := range jobTypes {
+		if typ != "" && t != typ {
+			continue
+		}
+		jobs, err := p.jadapter(t).list(onlyActive)
+		if err != nil {
+			// (partial result is still better than none)
+			nlog.Warningln(p.String(), "failed to list", t, "jobs:", err)
+			continue
+		}
+		for _, job := range jobs {
+			if kind != "" && job.Kind != kind {
+				continue
+			}
+			if onlyActive && job.Finished() {
+				continue
+			}
+			all = append(all, job)
+		}
+	}
+	sort.Slice(all, func(i, j int) bool {
+		if all[i].StartTime.Equal(all[j].StartTime) {
+			return all[i].ID < all[j].ID
+		}
+		return all[i].StartTime.Before(all[j].StartTime)
+	})
+	p.writeJSON(w, r, all, "list-jobs")
+}
+
+func (p *proxy) getJob(w http.ResponseWriter, r *http.Request, typ, nid string) {
+	var (
+		jad  = p.jadapter(typ)
+		wait time.Duration
+	)
+	if s := r.URL.Query().Get(apc.QparamWait); s != "" {
+		var err error
+		if wait, err = time.ParseDuration(s); err != nil || wait < 0 {
+			p.writeErrf(w, r, "invalid %s=%q (expecting non-negative duration)", apc.QparamWait, s)
+			return
+		}
+		wait = min(wait, maxXactWait)
+	}
+	job, err := jad.get(nid)
+	if err != nil {
+		p.writeErr(w, r, err, _jobErrCode(err), Silent)
+		return
+	}
+	if wait > 0 && !job.Finished() {
+		var (
+			ticker = time.NewTicker(cos.ProbingFrequency(wait))
+			timer  = time.NewTimer(wait)
+		)
+	loop:
+		for {
+			select {
+			case <-ticker.C:
+				if job, err = jad.get(nid); err != nil {
+					p.writeErr(w, r, err, _jobErrCode(err))
+					break loop
+				}
+				if job.Finished() {
+					break loop
+				}
+			case <-timer.C:
+				break loop
+			case <-r.Context().Done():
+				break loop
+			}
+		}
+		ticker.Stop()
+		timer.Stop()
+		if err != nil {
+			return
+		}
+	}
+	p.writeJSON(w, r, job, "get-job")
+}
+
+// unified or native job ID => (type, native ID)
+func resolveJobID(id string) (typ, nid string, _ error) {
+	typ, nid = cmn.ParseJobID(id)
+	switch {
+	case nid == "":
+		return "", "", fmt.Errorf("invalid job ID %q", id)
+	case typ != "":
+	case strings.HasPrefix(nid, dsort.PrefixJobID):
+		typ = cmn.JobTypeDsort
+	case strings.HasPrefix(nid, dload.PrefixJobID):
+		typ = cmn.JobTypeDownload
+	case isPipeID(nid):
+		typ = cmn.JobTypePipeline
+	case xact.IsValidUUID(nid):
+		typ = cmn.JobTypeXaction
+	default:
+		typ = cmn.JobTypeETL // (ETL name)
+	}
+	return typ, nid, nil
+}
+
+func _jobErrCode(err error) int {
+	if cos.IsErrNotFound(err) {
+		return http.StatusNotFound
+	}
+	return http.StatusBadRequest
+}
+
+func _uniqueBcks(bcks ...cmn.Bck) (out []cmn.Bck) {
+	for i := range bcks {
+		bck := &bcks[i]
+		if bck.IsEmpty() {
+			continue
+		}
+		var found bool
+		for j := range out {
+			if out[j].Equal(bck) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			out = append(out, *bck)
+		}
+	}
+	return out
+}
+
+///////////
+// jxact //
+///////////
+
+// skipping xactions that are represented by other job types
+func (*jxact) skip(kind string) bool {
+	return kind == apc.ActDsort || kind == apc.ActDownload || kind == apc.ActETLInline
+}
+
+func (j *jxact) query(msg *xact.QueryMsg) (xact.MultiSnap, error) {
+	args := allocBcArgs()
+	args.req = cmn.HreqArgs{
+		Method: http.MethodGet,
+		Path:   apc.URLPathXactions.S,
+		Body:   cos.MustMarshal(msg),
+		Query:  url.Values{apc.QparamWhat: []string{apc.WhatQueryXactStats}},
+	}
+	args.to = core.Targets
+	args.timeout = cmn.GCO.Get().Client.Timeout.D()
+	results := j.p.bcastGroup(args)
+	freeBcArgs(args)
+
+	var (
+		xs  = make(xact.MultiSnap, len(results))
+		err error
+	)
+	for _, res := range results {
+		if res.status == http.StatusNotFound {
+			continue
+		}
+		if res.err != nil {
+			err = res.toErr()
+			break
+		}
+		var snaps []*core.Snap
+		if err = jsoniter.Unmarshal(res.bytes, &snaps); err != nil {
+			break
+		}
+		xs[res.si.ID()] = snaps
+	}
+	freeBcastRes(results)
+	return xs, err
+}
+
+func (j *jxact) list(onlyActive bool) (cmn.Jobs, error) {
+	msg := &xact.QueryMsg{}
+	if onlyActive {
+		msg.OnlyRunning = apc.Ptr(true) // (otherwise, all jobs - running and finished)
+	}
+	xs, err := j.query(msg)
+	if err != nil {
+		return nil, err
+	}
+	return j.toJobs(xs), nil
+}
+
+func (j *jxact) get(nid string) (*cmn.Job, error) {
+	xs, err := j.query(&xact.QueryMsg{ID: nid})
+	if err != nil {
+		return nil, err
+	}
+	for _, job := range j.toJobs(xs) {
+		if job.NativeID == nid {
+			return job, nil
+		}
+	}
+	return nil, cos.NewErrNotFound(j.p, "xaction "+nid)
+}
+
+func (j *jxact) abort(nid string) error {
+	if _, err := j.get(nid); err != nil {
+		return err
+	}
+	return j.p.xabort(&xact.ArgsMsg{ID: nid})
+}
+
+func (j *jxact) toJobs(xs xact.MultiSnap) cmn.Jobs {
+	var (
+		byID    = make(map[string]*cmn.Job, 8)
+		running = make(map[string]bool, 8)
+		jobs    = make(cmn.Jobs, 0, 8)
+	)
+	for _, snaps := range xs {
+		for _, snap := range snaps {
+			if j.skip(snap.Kind) {
+				continue
+			}
+			job, ok := byID[snap.ID]
+			if !ok {
+				job = &cmn.Job{
+					ID:        cmn.JobID(cmn.JobTypeXaction, snap.ID),
+					Type:      cmn.JobTypeXaction,
+					Kind:      snap.Kind,
+					NativeID:  snap.ID,
+					State:     cmn.JobFinished,
+					StartTime: snap.StartTime,
+					Bcks:      _uniqueBcks(snap.Bck, snap.SrcBck, snap.DstBck),
+				}
+				byID[snap.ID] = job
+				jobs = append(jobs, job)
+			}
+			job.Progress.Objs += snap.Stats.Objs
+			job.Progress.Bytes += snap.Stats.Bytes
+			if !snap.StartTime.IsZero() && (job.StartTime.IsZero() || snap.StartTime.Before(job.StartTime)) {
+				job.StartTime = snap.StartTime
+			}
+			if snap.EndTime.After(job.EndTime) {
+				job.EndTime = snap.EndTime
+			}
+			if job.Err == "" {
+				job.Err = cos.Left(snap.Err, snap.AbortErr)
+			}
+			switch {
+			case snap.IsAborted():
+				job.State = cmn.JobAborted
+			case job.State == cmn.JobAborted:
+			case snap.Running() && !snap.IsIdle():
+				job.State = cmn.JobRunning
+				running[snap.ID] = true
+			case snap.Running() && !running[snap.ID]:
+				job.State = cmn.JobIdle
+			}
+		}
+	}
+	for _, job := range jobs {
+		if !job.Finished() {
+			job.EndTime = time.Time{} // (still running on some targets)
+		}
+	}
+	return jobs
+}
+
+////////////
+// jdsort //
+////////////
+
+func (j *jdsort) list(onlyActive bool) (cmn.Jobs, error) {
+	var (
+		jobs   = make(cmn.Jobs, 0, 4)
+		owners = make(map[string]string, 4)
+		query  url.Values
+	)
+	if onlyActive {
+		query = url.Values{apc.QparamOnlyActive: []string{"true"}}
+	}
+	// queued jobs (when the queue is enabled)
+	if qs := dsort.PqueueStatus(); qs != nil {
+		for _, qj := range qs.Running {
+			owners[qj.ID] = qj.User
+		}
+		for _, qj := range qs.Pending {
+			job := j.queued(qj)
+			job.State = cmn.JobQueued
+			jobs = append(jobs, job)
+		}
+		if !onlyActive {
+			for _, qj := range qs.Failed {
+				job := j.queued(qj)
+				job.State, job.Err = cmn.JobFailed, qj.Err
+				jobs = append(jobs, job)
+			}
+		}
+	}
+	for _, info := range dsort.Plist(query) {
+		job := &cmn.Job{
+			ID:        cmn.JobID(cmn.JobTypeDsort, info.ID),
+			Type:      cmn.JobTypeDsort,
+			Kind:      apc.ActDsort,
+			NativeID:  info.ID,
+			Owner:     owners[info.ID],
+			StartTime: info.StartedTime,
+			Bcks:      _uniqueBcks(info.SrcBck, info.DstBck),
+			Progress:  cmn.JobProgress{Objs: info.Objs, Bytes: info.Bytes},
+		}
+		switch {
+		case info.Aborted:
+			job.State = cmn.JobAborted
+		case info.IsRunning():
+			job.State = cmn.JobRunning
+		default:
+			job.State = cmn.JobFinished
+			job.EndTime = info.FinishTime
+		}
+		jobs = append(jobs, job)
+	}
+	return jobs, nil
+}
+
+func (*jdsort) queued(qj *dsort.QueuedJob) *cmn.Job {
+	return &cmn.Job{
+		ID:       cmn.JobID(cmn.JobTypeDsort, qj.ID),
+		Type:     cmn.JobTypeDsort,
+		Kind:     apc.ActDsort,
+		NativeID: qj.ID,
+		Owner:    qj.User,
+		Bcks:     _uniqueBcks(qj.SrcBck, qj.DstBck),
+	}
+}
+
+func (j *jdsort) get(nid string) (*cmn.Job, error) {
+	jobs, err := j.list(false)
+	if err != nil {
+		return nil, err
+	}
+	for _, job := range jobs {
+		if job.NativeID == nid {
+			return job, nil
+		}
+	}
+	return nil, cos.NewErrNotFound(j.p, "dsort job "+nid)
+}
+
+func (*jdsort) abort(nid string) error {
+	_, err := dsort.Pabort(nid)
+	return err
+}
+
+////////////
+// jdload //
+////////////
+
+func (j *jdload) list(onlyActive bool) (cmn.Jobs, error) {
+	b, ecode, err := j.p.dladm(http.MethodGet, apc.URLPathDownload.S, &dload.AdminBody{OnlyActive: onlyActive})
+	if err != nil {
+		if ecode == http.StatusNotFound {
+			return nil, nil
+		}
+		return nil, err
+	}
+	var infos dload.JobInfos
+	if err := jsoniter.Unmarshal(b, &infos); err != nil {
+		return nil, err
+	}
+	jobs := make(cmn.Jobs, 0, len(infos))
+	for _, info := range infos {
+		jobs = append(jobs, j.toJob(info))
+	}
+	return jobs, nil
+}
+
+func (j *jdload) get(nid string) (*cmn.Job, error) {
+	b, ecode, err := j.p.dladm(http.MethodGet, apc.URLPathDownload.S, &dload.AdminBody{ID: nid})
+	if err != nil {
+		if ecode == http.StatusNotFound {
+			return nil, cos.NewErrNotFound(j.p, "download job "+nid)
+		}
+		return nil, err
+	}
+	var resp dload.StatusResp
+	if err := jsoniter.Unmarshal(b, &resp); err != nil {
+		return nil, err
+	}
+	return j.toJob(&resp.Job), nil
+}
+
+func (j *jdload) abort(nid string) error {
+	_, ecode, err := j.p.dladm(http.MethodDelete, apc.URLPathDownloadAbort.S, &dload.AdminBody{ID: nid})
+	if err != nil && ecode == http.StatusNotFound {
+		return cos.NewErrNotFound(j.p, "download job "+nid)
+	}
+	return err
+}
+
+func (*jdload) toJob(info *dload.Job) *cmn.Job {
+	job := &cmn.Job{
+		ID:        cmn.JobID(cmn.JobTypeDownload, info.ID),
+		Type:      cmn.JobTypeDownload,
+		Kind:      apc.ActDownload,
+		NativeID:  info.ID,
+		StartTime: info.StartedTime,
+		Progress:  cmn.JobProgress{Objs: int64(info.FinishedCnt), Total: int64(max(info.TotalCnt(), 0))},
+	}
+	switch {
+	case info.Aborted:
+		job.State = cmn.JobAborted
+	case info.JobRunning():
+		job.State = cmn.JobRunning
+	default:
+		job.State = cmn.JobFinished
+		job.EndTime = info.FinishedTime
+	}
+	if info.ErrorCnt > 0 {
+		job.Err = fmt.Sprintf("failed to download %d object(s)", info.ErrorCnt)
+	}
+	return job
+}
+
+//////////
+// jetl //
+//////////
+
+// (ETLs are long-running: listed while running; "abort" stops the ETL)
+func (j *jetl) list(bool) (cmn.Jobs, error) {
+	etls, err := j.p._listETL()
+	if err != nil {
+		return nil, err
+	}
+	jobs := make(cmn.Jobs, 0, len(etls))
+	for i := range etls {
+		jobs = append(jobs, j.toJob(&etls[i]))
+	}
+	return jobs, nil
+}
+
+func (*jetl) toJob(info *etl.Info) *cmn.Job {
+	return &cmn.Job{
+		ID:       cmn.JobID(cmn.JobTypeETL, info.Name),
+		Type:     cmn.JobTypeETL,
+		Kind:     apc.ActETLInline,
+		NativeID: info.Name,
+		State:    cmn.JobRunning,
+	}
+}
+
+func (j *jetl) get(nid string) (*cmn.Job, error) {
+	etls, err := j.list(false)
+	if err != nil {
+		return nil, err
+	}
+	for _, job := range etls {
+		if job.NativeID == nid {
+			return job, nil
+		}
+	}
+	return nil, cos.NewErrNotFound(j.p, "etl "+nid)
+}
+
+func (j *jetl) abort(nid string) error {
+	if _, err := j.get(nid); err != nil {
+		return err
+	}
+	return j.p._stopETL(apc.URLPathETL.Join(nid, apc.ETLStop))
+}
@@ -0,0 +1,193 @@
+// Package ais provides core functionality for the AIStore object storage.
+/*
+ * Copyright (c) 2024, NVIDIA CORPORATION. All rights reserved.
+ */
+package ais
+
+import (
+	"crypto/sha256"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/NVIDIA/aistore/api/apc"
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/cmn/cos"
+	"github.com/NVIDIA/aistore/cmn/nlog"
+	"github.com/NVIDIA/aistore/core/meta"
+)
+
+// Signed object manifests (dataset snapshots) - see cmn/manifest.go
+// - both make and verify are executed by the primary (that lists all pages);
+// - listed pages are streamed (make) and compared (verify) one at a time;
+// - signing key is derived from the cluster UUID and the configured auth secret,
+//   so that only this cluster can produce (and verify) the signature; without
+//   the secret (e.g., auth disabled) manifests cannot be signed.
+
+// POST {apc.ActMakeManifest, apc.ActVerifyManifest} /v1/buckets/bucket-name
+func (p *proxy) manifest(w http.ResponseWriter, r *http.Request, msg *apc.ActMsg, bck *meta.Bck) {
+	if err := p.checkAccess(w, r, bck, apc.AceObjLIST); err != nil {
+		return
+	}
+	key, err := p.manifestKey()
+	if err != nil {
+		p.writeErr(w, r, err)
+		return
+	}
+	switch msg.Action {
+	case apc.ActMakeManifest:
+		var mmsg apc.ManifestMsg
+		if err := cos.MorphMarshal(msg.Value, &mmsg); err != nil {
+			p.writeErrf(w, r, cmn.FmtErrMorphUnmarshal, p.si, msg.Action, msg.Value, err)
+			return
+		}
+		p.makeManifest(w, r, bck, mmsg.Prefix, key)
+	case apc.ActVerifyManifest:
+		var mf cmn.Manifest
+		if err := cos.MorphMarshal(msg.Value, &mf); err != nil {
+			p.writeErrf(w, r, cmn.FmtErrMorphUnmarshal, p.si, msg.Action, msg.Value, err)
+			return
+		}
+		if !mf.Bck.Equal(bck.Bucket()) {
+			p.writeErrf(w, r, "%s: manifest bucket %s does not match %s", msg.Action, mf.Bck.Cname(""), bck.Cname(""))
+			return
+		}
+		cmp := mf.NewCmp()
+		err := p.lsManifest(bck, mf.Prefix, func(page []cmn.ManifestEntry) error {
+			for i := range page {
+				cmp.Add(&page[i])
+			}
+			return nil
+		})
+		if err != nil {
+			p.writeErr(w, r, err)
+			return
+		}
+		diff := cmp.Finish()
+		diff.SigOK = mf.VerifySignature(key)
+		if !diff.Match() {
+			nlog.Warningln(msg.Action, bck.Cname(mf.Prefix), "mismatch: signature-ok", diff.SigOK,
+				"missing", len(diff.Missing), "changed", len(diff.Changed), "added", len(diff.Added))
+			reason := fmt.Sprintf("manifest mismatch: signature-ok %t, missing %d, changed %d, added %d",
+				diff.SigOK, len(diff.Missing), len(diff.Changed), len(diff.Added))
+			p.integrityAlert(r, bck, diff, reason)
+		}
+		p.writeJSON(w, r, diff, msg.Action)
+	}
+}
+
+// freeze (see freeze.on_integrity) only upon authentic manifest with missing or changed objects,
+// and only when requested by someone who could freeze the bucket explicitly (see freezeBck)
+func (p *proxy) integrityAlert(r *http.Request, bck *meta.Bck, diff *cmn.ManifestDiff, reason string) {
+	if bck.Props.Freeze.OnIntegrity == "" || !diff.Tampered() {
+		return
+	}
+	if err := p.access(r, bck, apc.AceBckSetACL); err != nil {
+		nlog.Warningln(p.String(), "not freezing", bck.Cname(""), "upon integrity alert:", err)
+		return
+	}
+	p.freezeOnIntegrity(bck, reason)
+}
+
+func (p *proxy) manifestKey() ([]byte, error) {
+	var (
+		config = cmn.GCO.Get()
+		smap   = p.owner.smap.get()
+	)
+	if config.Auth.Secret == "" {
+		return nil, errors.New("manifest: cannot sign or verify without a secret (see config auth.secret)")
+	}
+	key := sha256.Sum256([]byte(smap.UUID + "\x00" + config.Auth.Secret))
+	return key[:], nil
+}
+
+// stream the manifest as the pages get listed
+func (p *proxy) makeManifest(w http.ResponseWriter, r *http.Request, bck *meta.Bck, prefix string, key []byte) {
+	var (
+		mw *cmn.ManifestWriter
+		mf = &cmn.Manifest{
+			Created:     time.Now().UTC(),
+			Bck:         *bck.Bucket(),
+			Prefix:      prefix,
+			ClusterUUID: p.owner.smap.get().UUID,
+		}
+	)
+	if bck.Props != nil {
+		mf.CksumType = bck.Props.Cksum.Type
+	}
+	start := func() {
+		w.Header().Set(cos.HdrContentType, cos.ContentJSONCharsetUTF)
+		mw = cmn.NewManifestWriter(w, mf)
+	}
+	err := p.lsManifest(bck, prefix, func(page []cmn.ManifestEntry) error {
+		if mw == nil {
+			start() // upon the first page (until then, errors can be reported)
+		}
+		for i := range page {
+			if err := mw.Add(&page[i]); err != nil {
+				return err
+			}
+		}
+		return mw.Flush()
+	})
+	if err != nil {
+		if mw == nil {
+			p.writeErr(w, r, err)
+		} else {
+			// (partially written - the client fails to decode)
+			nlog.Errorln(p.String(), "failed to stream", bck.Cname(prefix), "manifest:", err)
+			mw.Free()
+		}
+		return
+	}
+	if mw == nil {
+		start()
+	}
+	if err := mw.Finish(key); err != nil {
+		nlog.Errorln(p.String(), "failed to stream", bck.Cname(prefix), "manifest:", err)
+	} else {
+		nlog.Infoln(apc.ActMakeManifest, bck.Cname(prefix), "entries:", mw.Count())
+	}
+	mw.Free()
+}
+
+// list all in-cluster objects, one page at a time
+func (p *proxy) lsManifest(bck *meta.Bck, prefix string, cb func(page []cmn.ManifestEntry) error) error {
+	if bck.Props == nil {
+		return errors.New("manifest: bucket " + bck.Cname("") + " has no props")
+	}
+	var (
+		smap    = p.owner.smap.get()
+		lsmsg   = &apc.LsoMsg{Prefix: prefix, Flags: apc.LsObjCached}
+		amsg    = &apc.ActMsg{Action: apc.ActList, Value: lsmsg}
+		entries []cmn.ManifestEntry
+	)
+	lsmsg.AddProps(apc.GetPropsSize, apc.GetPropsChecksum, apc.GetPropsVersion)
+	for {
+		page, err := p.lsPage(bck, amsg, lsmsg, http.Header{}, smap)
+		if err != nil {
+			return fmt.Errorf("manifest %s: %w", bck.Cname(prefix), err)
+		}
+		entries = entries[:0]
+		for _, en := range page.Entries {
+			if en.IsDir() {
+				continue
+			}
+			entries = append(entries, cmn.ManifestEntry{
+				Name:    en.Name,
+				Size:    en.Size,
+				Cksum:   en.Checksum,
+				Version: en.Version,
+			})
+		}
+		if err := cb(entries); err != nil {
+			return err
+		}
+		if page.ContinuationToken == "" { // listed all pages
+			return nil
+		}
+		lsmsg.ContinuationToken = page.ContinuationToken
+		amsg.Value = lsmsg
+	}
+}
@@ -0,0 +1,187 @@
+// Package ais provides core functionality for the AIStore object storage.
+/*
+ * Copyright (c) 2024, NVIDIA CORPORATION. All rights reserved.
+ */
+package ais
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/NVIDIA/aistore/api/apc"
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/cmn/cos"
+	"github.com/NVIDIA/aistore/cmn/jsp"
+	"github.com/NVIDIA/aistore/cmn/nlog"
+	"github.com/NVIDIA/aistore/core/meta"
+	"github.com/NVIDIA/aistore/ext/etl"
+	jsoniter "github.com/json-iterator/go"
+)
+
+// Disaster recovery of cluster-level metadata:
+// - export: GET /v1/cluster?what=md_bundle returns all cluster metadata (see meta.MDBundle)
+//   as a single compressed, checksummed, and signed blob;
+// - import: PUT /v1/cluster {action: apc.ActImportMD} restores BMD, cluster config, and EtlMD
+//   onto a (freshly bootstrapped) cluster, and reports how the exported targets map onto
+//   the current ones; with `force`, exported buckets get merged into the existing BMD.
+// Restored config retains the current cluster's `proxy` and `net` sections (deployment-specific),
+// as well as its own `auth.secret` (the export never contains the secret).
+// Smap and RMD are never restored - the current cluster keeps its own.
+
+// GET /v1/cluster?what=md_bundle
+func (p *proxy) exportMD(w http.ResponseWriter, r *http.Request) {
+	if err := p.checkAccess(w, r, nil, apc.AceAdmin); err != nil {
+		return
+	}
+	config, err := p.owner.config.get()
+	if err != nil {
+		p.writeErr(w, r, err)
+		return
+	}
+	if config == nil {
+		p.writeErrf(w, r, "%s: cluster config is not initialized yet", p)
+		return
+	}
+	// hide secret (upon import, the current cluster keeps its own)
+	c := config.ClusterConfig
+	c.Auth.Secret = "**********"
+	bundle := &meta.MDBundle{
+		Smap:    &p.owner.smap.get().Smap,
+		BMD:     &p.owner.bmd.get().BMD,
+		RMD:     &p.owner.rmd.get().RMD,
+		Config:  &c,
+		EtlMD:   cos.MustMarshal(p.owner.etl.Get()),
+		Version: cmn.VersionAIStore,
+		Created: time.Now().UnixNano(),
+	}
+	sgl := p.gmm.NewSGL(0)
+	defer sgl.Free()
+	if err := jsp.Encode(sgl, bundle, bundle.JspOpts()); err != nil {
+		p.writeErr(w, r, err)
+		return
+	}
+	w.Header().Set(cos.HdrContentType, cos.ContentBinary)
+	w.Header().Set(cos.HdrContentLength, strconv.FormatInt(sgl.Len(), 10))
+	if err := sgl.WriteTo2(w); err != nil {
+		nlog.Errorln(p.String(), "failed to export", bundle.String()+":", err)
+	}
+}
+
+// PUT /v1/cluster {action: apc.ActImportMD}
+func (p *proxy) importMD(w http.ResponseWriter, r *http.Request, msg *apc.ActMsg) {
+	var val apc.ActValImportMD
+	if err := cos.MorphMarshal(msg.Value, &val); err != nil {
+		p.writeErrf(w, r, cmn.FmtErrMorphUnmarshal, p.si, msg.Action, msg.Value, err)
+		return
+	}
+	bundle := &meta.MDBundle{}
+	if _, err := jsp.Decode(io.NopCloser(bytes.NewReader(val.Bundle)), bundle, bundle.JspOpts(), apc.WhatMDBundle); err != nil {
+		p.writeErr(w, r, err)
+		return
+	}
+	if err := bundle.Validate(); err != nil {
+		p.writeErr(w, r, err)
+		return
+	}
+	if bmd := p.owner.bmd.get(); !bmd.IsEmpty() && !val.Force {
+		p.writeErrf(w, r, "%s: cannot import %s - the cluster is not empty (%s); use 'force' to overwrite",
+			p, bundle, bmd.StringEx())
+		return
+	}
+	emd := &etl.MD{}
+	if len(bundle.EtlMD) > 0 {
+		if err := jsoniter.Unmarshal(bundle.EtlMD, emd); err != nil {
+			p.writeErrf(w, r, cmn.FmtErrUnmarshal, p, "EtlMD", cos.BHead(bundle.EtlMD), err)
+			return
+		}
+	}
+
+	// 1. targets
+	var (
+		res  = &apc.ImportMDResult{}
+		smap = p.owner.smap.get()
+		err  error
+	)
+	res.Targets, res.Unmapped, res.Unused, err = bundle.MapTargets(&smap.Smap, val.Remap)
+	if err != nil {
+		p.writeErr(w, r, err)
+		return
+	}
+
+	// (not to metasync the bundle itself)
+	amsg := &apc.ActMsg{Action: msg.Action}
+
+	// 2. config
+	cctx := &configModifier{
+		pre: func(_ *configModifier, clone *globalConfig) (bool, error) {
+			proxy, net, uuid, ver := clone.Proxy, clone.Net, clone.UUID, clone.Version
+			secret := clone.Auth.Secret
+			clone.ClusterConfig = *bundle.Config
+			clone.Proxy, clone.Net, clone.UUID = proxy, net, uuid
+			clone.Auth.Secret = secret // (never exported)
+			clone.Version = max(ver, bundle.Config.Version)
+			return true, nil
+		},
+		final: p._syncConfFinal,
+		msg:   amsg,
+		wait:  true,
+	}
+	config, err := p.owner.config.modify(cctx)
+	if err != nil {
+		p.writeErr(w, r, err)
+		return
+	}
+	res.ConfigVersion = config.Version
+
+	// 3. BMD
+	bctx := &bmdModifier{
+		pre: func(_ *bmdModifier, clone *bucketMD) error {
+			// merge (when forced, existing buckets that are not in the bundle remain intact)
+			bundle.BMD.Range(nil, nil, func(bck *meta.Bck) bool {
+				clone.Add(bck)
+				return false
+			})
+			if clone.Ext == nil {
+				clone.Ext = bundle.BMD.Ext
+			}
+			clone.Version = max(clone.Version, bundle.BMD.Version) + 1
+			return nil
+		},
+		final: p.bmodSync,
+		msg:   amsg,
+		wait:  true,
+	}
+	bmd, err := p.owner.bmd.modify(bctx)
+	if err != nil {
+		p.writeErr(w, r, err)
+		return
+	}
+	res.BMDVersion = bmd.Version
+
+	// 4. EtlMD
+	if len(emd.ETLs) > 0 {
+		ectx := &etlMDModifier{
+			pre: func(_ *etlMDModifier, clone *etlMD) error {
+				clone.ETLs = emd.ETLs
+				clone.Version = max(clone.Version, emd.Version) + 1
+				return nil
+			},
+			final: p._syncEtlMDFinal,
+			wait:  true,
+		}
+		clone, err := p.owner.etl.modify(ectx)
+		if err != nil {
+			p.writeErr(w, r, err)
+			return
+		}
+		res.EtlMDVersion = clone.Version
+	}
+
+	nlog.Infoln(p.String(), "imported", bundle.String(), fmt.Sprintf("(exported by v%s):", bundle.Version), res.Targets,
+		"unmapped:", res.Unmapped)
+	p.writeJSON(w, r, res, msg.Action)
+}
@@ -282,6 +282,7 @@ func (*notifs) markFinished(nl nl.Listener, tsi *meta.Snode, srcErr error, abort
 	nl.MarkFinished(tsi)
 	if aborted {
 		nl.SetAborted()
+		nl.SetAbortReason(abortReason(nl, tsi, srcErr))
 		if srcErr == nil {
 			detail := fmt.Sprintf("%s from %s", nl, tsi.StringEx())
 			srcErr = cmn.NewErrAborted(nl.String(), detail, nil)
@@ -328,6 +329,20 @@ func (n *notifs) done(nl nl.Listener) {
 	nl.Callback(nl, time.Now().UnixNano())
 }
 
+// the reason reported by the aborted notifier (in its final snapshot), or else the one we know
+func abortReason(nl nl.Listener, tsi *meta.Snode, srcErr error) *cmn.AbortReason {
+	if stats, ok := nl.NodeStats().Load(tsi.ID()); ok {
+		if snap, ok := stats.(*core.Snap); ok && snap.AbortReason != nil {
+			return snap.AbortReason
+		}
+	}
+	reason := &cmn.AbortReason{Initiator: tsi.StringEx(), Cause: "aborted", Time: time.Now().UnixNano()}
+	if srcErr != nil {
+		reason.Cause = srcErr.Error()
+	}
+	return reason
+}
+
 func abortReq(nl nl.Listener) cmn.HreqArgs {
 	if nl.Kind() == apc.ActDownload {
 		// downloader implements abort via http.MethodDelete
@@ -337,7 +352,7 @@ func abortReq(nl nl.Listener) cmn.HreqArgs {
 	msg := apc.ActMsg{
 		Action: apc.ActXactStop,
 		Name:   cmn.ErrXactICNotifAbort.Error(),
-		Value:  xact.ArgsMsg{ID: nl.UUID() /*xid*/, Kind: nl.Kind()},
+		Value:  xact.ArgsMsg{ID: nl.UUID() /*xid*/, Kind: nl.Kind(), Reason: nl.AbortReason()},
 	}
 	args := cmn.HreqArgs{Method: http.MethodPut}
 	args.Body = cos.MustMarshal(msg)
@@ -416,10 +431,10 @@ func (n *notifs) bcastGetStats(nl nl.Listener, dur time.Duration) {
 				continue
 			}
 			nl.Lock()
+			nl.SetStats(res.si.ID(), stats)
 			if finished {
 				done = done || n.markFinished(nl, res.si, nil, aborted)
 			}
-			nl.SetStats(res.si.ID(), stats)
 			nl.Unlock()
 		} else if res.status == http.StatusNotFound {
 			if mono.Since(nl.AddedTime()) < progressInterval {
@@ -460,6 +475,10 @@ func (n *notifs) ListenSmapChanged() {
 	}
 	n.smapVer = smap.Version
 
+	if smap.IsIC(n.p.si) {
+		n.reown(smap, n.nls)
+		n.reown(smap, n.fin)
+	}
 	if n.nls.l.Load() == 0 {
 		return
 	}
@@ -527,6 +546,29 @@ repeat:
 	clear(remid)
 }
 
+// IC membership changes: re-assign listeners owned by former IC members
+// (given the same Smap, all IC members make the same HRW choice - no need to synchronize)
+func (*notifs) reown(smap *smapX, l *listeners) {
+	l.mtx.RLock()
+	for uuid, nl := range l.m {
+		owner := nl.GetOwner()
+		if owner == "" || owner == equalIC {
+			continue
+		}
+		if psi := smap.GetProxy(owner); psi != nil && psi.IsIC() && !psi.InMaintOrDecomm() {
+			continue
+		}
+		psi, err := smap.HrwIC(uuid)
+		if err != nil {
+			break
+		}
+		nl.Lock()
+		nl.SetOwner(psi.ID())
+		nl.Unlock()
+	}
+	l.mtx.RUnlock()
+}
+
 func _remini() (map[string]nl.Listener, cos.StrKVs) {
 	return make(map[string]nl.Listener, 1), make(cos.StrKVs, 1)
 }
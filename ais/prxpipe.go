@@ -0,0 +1,460 @@
+// Package ais provides core functionality for the AIStore object storage.
+/*
+ * Copyright (c) 2024, NVIDIA CORPORATION. All rights reserved.
+ */
+package ais
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/NVIDIA/aistore/api/apc"
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/cmn/cos"
+	"github.com/NVIDIA/aistore/cmn/mono"
+	"github.com/NVIDIA/aistore/cmn/nlog"
+	"github.com/NVIDIA/aistore/core/meta"
+	"github.com/NVIDIA/aistore/xact"
+)
+
+// Pipeline (composite) jobs - see cmn.PipelineMsg
+// - POST /v1/jobs { apc.ActPipeline } - submit; returns unified job ID
+// - GET and DELETE - same as all other jobs (see prxjobs)
+//
+// Pipelines are executed by the primary: each step is a regular (xaction-based) job
+// started the same way the corresponding API would start it; the primary then polls the
+// steps' xactions and starts dependent steps as their dependencies finish.
+// Pipelines are not persistent - primary restart (or change) loses those that are running.
+
+const (
+	pipePrefixID = "pipe-"
+
+	pipePollIval  = 2 * time.Second
+	pipeStartTout = time.Minute // step's xaction must show up within
+	pipeKeepDone  = 32          // max number of finished pipelines to keep (and report)
+)
+
+type (
+	pipeline struct {
+		p       *proxy
+		stopCh  *cos.StopCh
+		msg     cmn.PipelineMsg
+		job     cmn.Job
+		order   []int
+		started []int64     // per step: mono-time when started
+		created []*meta.Bck // destination buckets created by the pipeline (to roll back)
+		err     error
+		mu      sync.Mutex
+	}
+	pipelines struct {
+		m  map[string]*pipeline
+		mu sync.Mutex
+	}
+	jpipe struct{ p *proxy }
+)
+
+// interface guard
+var _ jobAdapter = (*jpipe)(nil)
+
+var errPipeAborted = errors.New("pipeline aborted")
+
+// POST { apc.ActPipeline } /v1/jobs
+func (p *proxy) startPipeline(w http.ResponseWriter, r *http.Request) {
+	msg, err := p.readActionMsg(w, r)
+	if err != nil {
+		return
+	}
+	if msg.Action != apc.ActPipeline {
+		p.writeErrAct(w, r, msg.Action)
+		return
+	}
+	if p.forwardCP(w, r, msg, "jobs") {
+		return
+	}
+	pl := &pipeline{p: p, stopCh: cos.NewStopCh()}
+	if err := cos.MorphMarshal(msg.Value, &pl.msg); err != nil {
+		p.writeErrf(w, r, cmn.FmtErrMorphUnmarshal, p.si, msg.Action, msg.Value, err)
+		return
+	}
+	if pl.order, err = pl.msg.Validate(); err != nil {
+		p.writeErr(w, r, err)
+		return
+	}
+	if !pl.initBcks(w, r) {
+		return
+	}
+
+	id := pipePrefixID + cos.GenUUID()
+	pl.job = cmn.Job{
+		ID:        cmn.JobID(cmn.JobTypePipeline, id),
+		Type:      cmn.JobTypePipeline,
+		Kind:      apc.ActPipeline,
+		NativeID:  id,
+		Owner:     p.userID(r),
+		State:     cmn.JobRunning,
+		StartTime: time.Now(),
+		Steps:     make(cmn.Jobs, len(pl.msg.Steps)),
+		Progress:  cmn.JobProgress{Total: int64(len(pl.msg.Steps))},
+	}
+	pl.started = make([]int64, len(pl.msg.Steps))
+	for i := range pl.msg.Steps {
+		step := &pl.msg.Steps[i]
+		pl.job.Steps[i] = &cmn.Job{
+			Type:  cmn.JobTypeXaction,
+			Kind:  step.Action,
+			Name:  step.Name,
+			State: cmn.PipelinePending,
+			Bcks:  _uniqueBcks(step.Bck, step.ToBck),
+		}
+		pl.job.Bcks = _uniqueBcks(append(pl.job.Bcks, step.Bck, step.ToBck)...)
+	}
+	p.pipes.add(id, pl)
+	go pl.run()
+
+	nlog.Infoln(p.String(), "started", pl.job.ID, "steps:", len(pl.msg.Steps))
+	w.Write([]byte(pl.job.ID))
+}
+
+// validate access and existence of all buckets upfront (noting that the sources
+// may as well be produced by the preceding steps)
+func (pl *pipeline) initBcks(w http.ResponseWriter, r *http.Request) bool {
+	var (
+		p    = pl.p
+		dsts = make([]cmn.Bck, 0, len(pl.msg.Steps))
+	)
+	for _, i := range pl.order {
+		step := &pl.msg.Steps[i]
+		if !_containsBck(dsts, &step.Bck) {
+			bckArgs := bctx{p: p, w: w, r: r, perms: apc.AceObjLIST | apc.AceGET, bck: meta.CloneBck(&step.Bck)}
+			bckArgs.createAIS = false
+			bck, err := bckArgs.initAndTry()
+			if err != nil {
+				return false
+			}
+			step.Bck = *bck.Bucket()
+		}
+		if step.ToBck.IsEmpty() {
+			continue
+		}
+		bckTo, _, err := p.initBckTo(w, r, step.ToBck.NewQuery(), meta.CloneBck(&step.ToBck))
+		if err != nil {
+			return false
+		}
+		step.ToBck = *bckTo.Bucket()
+		dsts = append(dsts, step.ToBck)
+	}
+	return true
+}
+
+func _containsBck(bcks []cmn.Bck, bck *cmn.Bck) bool {
+	for i := range bcks {
+		if bcks[i].Equal(bck) {
+			return true
+		}
+	}
+	return false
+}
+
+func (pl *pipeline) run() {
+	ticker := time.NewTicker(pipePollIval)
+	for !pl.schedule() {
+		select {
+		case <-ticker.C:
+			pl.poll()
+		case <-pl.stopCh.Listen():
+			pl.fail(errPipeAborted)
+		}
+	}
+	ticker.Stop()
+	pl.fini()
+}
+
+// start the steps that are ready to run; abort all of them upon failure;
+// return true when done
+func (pl *pipeline) schedule() (done bool) {
+	pl.mu.Lock()
+	if pl.err != nil {
+		running := pl._abortSteps()
+		pl.mu.Unlock()
+		for _, xid := range running {
+			if err := pl.p.xabort(&xact.ArgsMsg{ID: xid}); err != nil {
+				nlog.Warningln(pl.job.ID, "failed to abort step", xid, "err:", err)
+			}
+		}
+		return true
+	}
+	var (
+		ready    []int
+		finished int
+	)
+	for _, i := range pl.order {
+		job := pl.job.Steps[i]
+		switch job.State {
+		case cmn.JobFinished:
+			finished++
+		case cmn.PipelinePending:
+			if pl._depsDone(i) {
+				job.State = cmn.JobQueued
+				ready = append(ready, i)
+			}
+		}
+	}
+	pl.mu.Unlock()
+
+	if finished == len(pl.order) {
+		return true
+	}
+	for _, i := range ready {
+		xid, err := pl.startStep(i)
+		pl.mu.Lock()
+		job := pl.job.Steps[i]
+		if err != nil {
+			job.State, job.Err = cmn.JobFailed, err.Error()
+			pl.mu.Unlock()
+			pl.fail(fmt.Errorf("step %q failed to start: %v", job.Name, err))
+			return false
+		}
+		job.ID, job.NativeID = cmn.JobID(cmn.JobTypeXaction, xid), xid
+		job.State, job.StartTime = cmn.JobRunning, time.Now()
+		pl.started[i] = mono.NanoTime()
+		pl.mu.Unlock()
+		nlog.Infoln(pl.job.ID, "step", job.Name, "started:", job.ID)
+	}
+	return false
+}
+
+func (pl *pipeline) _depsDone(i int) bool {
+	for _, dep := range pl.msg.Steps[i].DependsOn {
+		for j := range pl.msg.Steps {
+			if pl.msg.Steps[j].Name == dep && pl.job.Steps[j].State != cmn.JobFinished {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// returns running steps' xaction IDs
+func (pl *pipeline) _abortSteps() (running []string) {
+	for _, job := range pl.job.Steps {
+		switch job.State {
+		case cmn.PipelinePending, cmn.JobQueued:
+			job.State = cmn.PipelineSkipped
+		case cmn.JobRunning, cmn.JobIdle:
+			running = append(running, job.NativeID)
+			job.State = cmn.JobAborted
+			job.EndTime = time.Now()
+		}
+	}
+	return running
+}
+
+func (pl *pipeline) startStep(i int) (xid string, err error) {
+	var (
+		p    = pl.p
+		step = &pl.msg.Steps[i]
+		bck  = meta.CloneBck(&step.Bck)
+	)
+	if err := bck.Init(p.owner.bmd); err != nil {
+		return "", err
+	}
+	switch step.Action {
+	case apc.ActPrefetchObjects:
+		msg := &apc.ActMsg{Action: step.Action, Value: &apc.PrefetchMsg{ListRange: apc.ListRange{Template: step.Prefix}}}
+		return p.listrange(http.MethodPost, bck.Name, msg, bck.NewQuery())
+	default:
+		bckTo := meta.CloneBck(&step.ToBck)
+		if err := bckTo.Init(p.owner.bmd); err != nil {
+			if !cmn.IsErrBckNotFound(err) || !bckTo.IsAIS() {
+				return "", err
+			}
+			pl.mu.Lock()
+			pl.created = append(pl.created, bckTo)
+			pl.mu.Unlock()
+		}
+		msg := &apc.ActMsg{Action: step.Action}
+		if step.Action == apc.ActETLBck {
+			msg.Value = &apc.TCBMsg{Transform: apc.Transform{Name: step.ETLName}, CopyBckMsg: apc.CopyBckMsg{Prefix: step.Prefix}}
+		} else {
+			msg.Value = &apc.CopyBckMsg{Prefix: step.Prefix}
+		}
+		return p.tcb(bck, bckTo, msg, false /*dry-run*/)
+	}
+}
+
+// update running steps from their respective xactions
+func (pl *pipeline) poll() {
+	pl.mu.Lock()
+	running := make(map[int]string, len(pl.job.Steps))
+	for i, job := range pl.job.Steps {
+		if job.State == cmn.JobRunning || job.State == cmn.JobIdle {
+			running[i] = job.NativeID
+		}
+	}
+	pl.mu.Unlock()
+
+	jx := &jxact{pl.p}
+	for i, xid := range running {
+		xjob, err := jx.get(xid)
+		if err != nil {
+			if !cos.IsErrNotFound(err) || mono.Since(pl.started[i]) > pipeStartTout {
+				pl.fail(fmt.Errorf("step %q (%s): %v", pl.msg.Steps[i].Name, xid, err))
+				return
+			}
+			continue
+		}
+		pl.mu.Lock()
+		job := pl.job.Steps[i]
+		job.State, job.Err, job.Progress = xjob.State, xjob.Err, xjob.Progress
+		job.EndTime = xjob.EndTime
+		pl.mu.Unlock()
+		if xjob.State == cmn.JobAborted || xjob.State == cmn.JobFailed {
+			pl.fail(fmt.Errorf("step %q (%s) %s: %s", job.Name, xid, xjob.State, xjob.Err))
+			return
+		}
+	}
+}
+
+func (pl *pipeline) fail(err error) {
+	pl.mu.Lock()
+	if pl.err == nil {
+		pl.err = err
+	}
+	pl.mu.Unlock()
+}
+
+func (pl *pipeline) fini() {
+	pl.mu.Lock()
+	defer pl.mu.Unlock()
+	pl.job.EndTime = time.Now()
+	for _, job := range pl.job.Steps {
+		if job.State == cmn.JobFinished {
+			pl.job.Progress.Objs++
+		}
+	}
+	switch {
+	case pl.err == nil:
+		pl.job.State = cmn.JobFinished
+		nlog.Infoln(pl.job.ID, "finished")
+		return
+	case errors.Is(pl.err, errPipeAborted):
+		pl.job.State = cmn.JobAborted
+	default:
+		pl.job.State = cmn.JobFailed
+	}
+	pl.job.Err = pl.err.Error()
+	nlog.Errorln(pl.job.ID, pl.job.State+":", pl.err)
+
+	if !pl.msg.Rollback {
+		return
+	}
+	for i := len(pl.created) - 1; i >= 0; i-- {
+		bck := pl.created[i]
+		if err := bck.Init(pl.p.owner.bmd); err != nil {
+			continue // (e.g., removed upon abort by the step itself)
+		}
+		nlog.Infoln(pl.job.ID, "rollback: destroying", bck.String())
+		if err := pl.p.destroyBucket(&apc.ActMsg{Action: apc.ActDestroyBck}, bck); err != nil {
+			nlog.Errorln(pl.job.ID, "rollback: failed to destroy", bck.String(), "err:", err)
+		}
+	}
+}
+
+func (pl *pipeline) snap() *cmn.Job {
+	pl.mu.Lock()
+	job := pl.job
+	job.Steps = make(cmn.Jobs, len(pl.job.Steps))
+	for i, step := range pl.job.Steps {
+		clone := *step
+		job.Steps[i] = &clone
+	}
+	pl.mu.Unlock()
+	return &job
+}
+
+///////////////
+// pipelines //
+///////////////
+
+func (pls *pipelines) add(id string, pl *pipeline) {
+	pls.mu.Lock()
+	if pls.m == nil {
+		pls.m = make(map[string]*pipeline, 4)
+	}
+	pls.m[id] = pl
+	pls._gc()
+	pls.mu.Unlock()
+}
+
+// keep up to pipeKeepDone most recently finished
+func (pls *pipelines) _gc() {
+	done := make([]*pipeline, 0, len(pls.m))
+	for _, pl := range pls.m {
+		pl.mu.Lock()
+		if !pl.job.EndTime.IsZero() {
+			done = append(done, pl)
+		}
+		pl.mu.Unlock()
+	}
+	if len(done) <= pipeKeepDone {
+		return
+	}
+	sort.Slice(done, func(i, j int) bool { return done[i].job.EndTime.Before(done[j].job.EndTime) })
+	for _, pl := range done[:len(done)-pipeKeepDone] {
+		delete(pls.m, pl.job.NativeID)
+	}
+}
+
+func (pls *pipelines) get(id string) *pipeline {
+	pls.mu.Lock()
+	pl := pls.m[id]
+	pls.mu.Unlock()
+	return pl
+}
+
+func (pls *pipelines) all() []*pipeline {
+	pls.mu.Lock()
+	out := make([]*pipeline, 0, len(pls.m))
+	for _, pl := range pls.m {
+		out = append(out, pl)
+	}
+	pls.mu.Unlock()
+	return out
+}
+
+///////////
+// jpipe //
+///////////
+
+func (j *jpipe) list(bool) (cmn.Jobs, error) {
+	all := j.p.pipes.all()
+	jobs := make(cmn.Jobs, 0, len(all))
+	for _, pl := range all {
+		jobs = append(jobs, pl.snap())
+	}
+	return jobs, nil
+}
+
+func (j *jpipe) get(nid string) (*cmn.Job, error) {
+	pl := j.p.pipes.get(nid)
+	if pl == nil {
+		return nil, cos.NewErrNotFound(j.p, "pipeline "+nid)
+	}
+	return pl.snap(), nil
+}
+
+func (j *jpipe) abort(nid string) error {
+	pl := j.p.pipes.get(nid)
+	if pl == nil {
+		return cos.NewErrNotFound(j.p, "pipeline "+nid)
+	}
+	pl.stopCh.Close()
+	return nil
+}
+
+func isPipeID(nid string) bool { return strings.HasPrefix(nid, pipePrefixID) }
@@ -0,0 +1,244 @@
+// Package ais provides core functionality for the AIStore object storage.
+/*
+ * Copyright (c) 2024, NVIDIA CORPORATION. All rights reserved.
+ */
+package ais
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"sync"
+	"time"
+
+	"github.com/NVIDIA/aistore/api/apc"
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/cmn/atomic"
+	"github.com/NVIDIA/aistore/cmn/nlog"
+	"github.com/NVIDIA/aistore/core"
+	"github.com/NVIDIA/aistore/core/meta"
+	"github.com/NVIDIA/aistore/hk"
+)
+
+// Self-service bucket provisioning (see cmn/provision.go):
+// - create-bucket: the first policy that matches (user, bucket name) permits creation
+//   and provides defaults - props template, quota, and TTL;
+// - quota: enforced when adding the bucket to BMD (see bmodCreate);
+// - owner: reads and writes objects with no further (AuthN) user permissions - all the
+//   rest (bucket props including backend and ACL, destroy, etc.) requires those permissions;
+// - TTL: each proxy tracks the last time it accessed a given ephemeral bucket;
+//   primary periodically collects (and merges) all proxies' access times and
+//   destroys buckets that haven't been accessed for longer than their respective TTLs.
+//   Access times are kept in memory - a proxy that (re)starts reports its start time
+//   for all buckets, giving each one a full TTL.
+
+const (
+	whatBckAccess = "bck-access" // (internal) ephemeral buckets: last-access times
+
+	batimeStarted = "" // bckAtimes key: the time the proxy started tracking (see bckAccess.snap)
+
+	// object-level access the owner of a self-provisioned bucket is granted (see isProvisionOwner)
+	provisionOwnerAccess = apc.AccessRW
+
+	provisionIval = 10 * time.Minute
+)
+
+type (
+	bckAccess struct {
+		m       sync.Map // bucket uname => *atomic.Int64 (last access, Unix nano)
+		started int64    // Unix nano (see initProvision)
+	}
+	bckAtimes map[string]int64 // bucket uname => last access
+)
+
+///////////////
+// bckAccess //
+///////////////
+
+func batimeKey(bck *meta.Bck) string { return string(bck.MakeUname("")) }
+
+func (a *bckAccess) touch(bck *meta.Bck) {
+	now := time.Now().UnixNano()
+	if v, ok := a.m.Load(batimeKey(bck)); ok {
+		v.(*atomic.Int64).Store(now)
+		return
+	}
+	v := &atomic.Int64{}
+	v.Store(now)
+	a.m.Store(batimeKey(bck), v)
+}
+
+// (not tracked since the start - assume accessed)
+func (a *bckAccess) get(uname string) int64 {
+	if v, ok := a.m.Load(uname); ok {
+		return max(v.(*atomic.Int64).Load(), a.started)
+	}
+	return a.started
+}
+
+func (a *bckAccess) snap() bckAtimes {
+	atimes := make(bckAtimes, 8)
+	atimes[batimeStarted] = a.started
+	a.m.Range(func(k, v any) bool {
+		atimes[k.(string)] = v.(*atomic.Int64).Load()
+		return true
+	})
+	return atimes
+}
+
+// forget buckets that no longer exist
+func (a *bckAccess) prune(bmd *bucketMD) {
+	a.m.Range(func(k, _ any) bool {
+		b, _ := cmn.ParseUname(k.(string))
+		if _, present := bmd.Get((*meta.Bck)(&b)); !present {
+			a.m.Delete(k)
+		}
+		return true
+	})
+}
+
+///////////
+// proxy //
+///////////
+
+func (p *proxy) provisionPolicy(r *http.Request, bck *meta.Bck) *cmn.BckPolicy {
+	if !bck.IsAIS() {
+		return nil
+	}
+	return cmn.GCO.Get().Provision.Match(p.userID(r), bck.Name)
+}
+
+func isProvisionOwner(bck *meta.Bck, userID string, ace apc.AccessAttrs) bool {
+	if ace&^provisionOwnerAccess != 0 {
+		return false
+	}
+	return bck != nil && bck.Props != nil && userID != "" && bck.Props.Provision.Owner == userID
+}
+
+// (under BMD lock)
+func checkProvisionQuota(bmd *bucketMD, bck *meta.Bck, bprops *cmn.Bprops) error {
+	prov := &bprops.Provision
+	if prov.Policy == "" {
+		return nil
+	}
+	policy := cmn.GCO.Get().Provision.Find(prov.Policy)
+	if policy == nil || policy.MaxBuckets == 0 {
+		return nil
+	}
+	var (
+		cnt      int
+		provider = apc.AIS
+	)
+	bmd.Range(&provider, nil, func(b *meta.Bck) bool {
+		if b.Props.Provision.Policy == prov.Policy && b.Props.Provision.Owner == prov.Owner {
+			cnt++
+		}
+		return false
+	})
+	if cnt >= policy.MaxBuckets {
+		owner := prov.Owner
+		if owner == "" {
+			owner = "anonymous"
+		}
+		return fmt.Errorf("cannot create %s: user %q has reached the maximum number of buckets (%d) allowed by policy %q",
+			bck, owner, policy.MaxBuckets, policy.Name)
+	}
+	return nil
+}
+
+func (p *proxy) initProvision() {
+	p.batime.started = time.Now().UnixNano()
+	hk.Reg("provision-ttl"+hk.NameSuffix, p.provisionHK, provisionIval)
+}
+
+func (p *proxy) provisionHK(int64) time.Duration {
+	if !p.ClusterStarted() {
+		return provisionIval
+	}
+	bmd := p.owner.bmd.get()
+	p.batime.prune(bmd)
+
+	smap := p.owner.smap.get()
+	if !smap.isPrimary(p.si) {
+		return provisionIval
+	}
+
+	// 1. local candidates
+	var (
+		expired  []*meta.Bck
+		provider = apc.AIS
+		now      = time.Now().UnixNano()
+	)
+	bmd.Range(&provider, nil, func(bck *meta.Bck) bool {
+		ttl := bck.Props.Provision.TTL
+		if ttl == 0 {
+			return false
+		}
+		last := max(bck.Props.Created, p.batime.get(batimeKey(bck)))
+		if last+int64(ttl) < now {
+			expired = append(expired, bck)
+		}
+		return false
+	})
+	if len(expired) == 0 {
+		return provisionIval
+	}
+
+	// 2. other proxies (when in doubt - keep)
+	atimes, err := p.collectAtimes(smap)
+	if err != nil {
+		nlog.Warningln(p.String(), "provision-ttl: skipping this round:", err)
+		return provisionIval
+	}
+
+	// 3. destroy
+	for _, bck := range expired {
+		// (a proxy that has started within the TTL may not know)
+		ttl := bck.Props.Provision.TTL
+		if max(atimes[batimeKey(bck)], atimes[batimeStarted])+int64(ttl) >= now {
+			continue
+		}
+		msg := &apc.ActMsg{Action: apc.ActDestroyBck, Name: "ttl"}
+		if err := p.destroyBucket(msg, bck); err != nil {
+			if !cmn.IsErrBckNotFound(err) {
+				nlog.Errorln(p.String(), "provision-ttl: failed to destroy", bck.Cname(""), "err:", err)
+			}
+			continue
+		}
+		nlog.Infoln(p.String(), "provision-ttl: destroyed", bck.Cname(""), "[ policy", bck.Props.Provision.Policy,
+			"owner", bck.Props.Provision.Owner, "inactive for more than", ttl, "]")
+	}
+	return provisionIval
+}
+
+// GET what=bck-access from all other proxies; merge
+func (p *proxy) collectAtimes(smap *smapX) (bckAtimes, error) {
+	atimes := p.batime.snap()
+	if smap.CountActivePs() < 2 {
+		return atimes, nil
+	}
+	args := allocBcArgs()
+	args.req = cmn.HreqArgs{
+		Method: http.MethodGet,
+		Path:   apc.URLPathDae.S,
+		Query:  url.Values{apc.QparamWhat: []string{whatBckAccess}},
+	}
+	args.smap = smap
+	args.to = core.Proxies
+	args.cresv = cresAT{} // -> bckAtimes
+	results := p.bcastGroup(args)
+	freeBcArgs(args)
+
+	var err error
+	for _, res := range results {
+		if res.err != nil {
+			err = res.toErr()
+			break
+		}
+		for uname, last := range *res.v.(*bckAtimes) {
+			atimes[uname] = max(atimes[uname], last)
+		}
+	}
+	freeBcastRes(results)
+	return atimes, err
+}
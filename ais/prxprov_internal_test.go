@@ -0,0 +1,48 @@
+// Package ais provides core functionality for the AIStore object storage.
+/*
+ * Copyright (c) 2024, NVIDIA CORPORATION. All rights reserved.
+ */
+package ais
+
+import (
+	"testing"
+	"time"
+
+	"github.com/NVIDIA/aistore/api/apc"
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/core/meta"
+	"github.com/NVIDIA/aistore/tools/tassert"
+)
+
+func TestProvisionOwnerAccess(tt *testing.T) {
+	bck := meta.NewBck("scratch-alice-1", apc.AIS, cmn.NsGlobal)
+	bck.Props = &cmn.Bprops{}
+	bck.Props.Provision.Owner = "alice"
+
+	for _, ace := range []apc.AccessAttrs{apc.AceGET, apc.AcePUT, apc.AceObjDELETE, apc.AceObjLIST, apc.AccessRW} {
+		tassert.Errorf(tt, isProvisionOwner(bck, "alice", ace), "owner: expecting %s", ace.Describe(false))
+		tassert.Errorf(tt, !isProvisionOwner(bck, "bob", ace), "non-owner: not expecting %s", ace.Describe(false))
+	}
+	// bucket props (including backend), ACL, destroy, etc.
+	for _, ace := range []apc.AccessAttrs{apc.AcePATCH, apc.AceBckSetACL, apc.AcePATCH | apc.AceBckSetACL, apc.AceDestroyBucket, apc.AceAdmin} {
+		tassert.Errorf(tt, !isProvisionOwner(bck, "alice", ace), "owner: not expecting %s", ace.Describe(false))
+	}
+}
+
+func TestBckAccessStarted(tt *testing.T) {
+	var (
+		a   bckAccess
+		bck = meta.NewBck("scratch", apc.AIS, cmn.NsGlobal)
+		now = time.Now().UnixNano()
+	)
+	a.started = now
+	// not accessed since the start: assume accessed at the start
+	tassert.Errorf(tt, a.get(batimeKey(bck)) == now, "expecting start time")
+	atimes := a.snap()
+	tassert.Errorf(tt, atimes[batimeStarted] == now, "expecting start time in the snapshot")
+
+	a.touch(bck)
+	last := a.get(batimeKey(bck))
+	tassert.Errorf(tt, last >= now, "expecting last access >= start")
+	tassert.Errorf(tt, a.snap()[batimeKey(bck)] == last, "expecting last access in the snapshot")
+}
@@ -338,10 +338,13 @@ func (p *proxy) listObjectsS3(w http.ResponseWriter, r *http.Request, bucket str
 	// - "start-after"
 	// - "delimiter" (TODO: limited support: no recursion)
 	// - "continuation-token" (NOTE: base64 encoded, as in: base64.StdEncoding.DecodeString(token)
+	// - "encoding-type" (NOTE: "url" is the only supported value)
 	// TODO:
 	// - "fetch-owner"
-	// - "encoding-type"
-	s3.FillLsoMsg(q, lsmsg)
+	if err := s3.FillLsoMsg(q, lsmsg); err != nil {
+		s3.WriteErr(w, r, err, http.StatusBadRequest)
+		return
+	}
 
 	lst, err := p.lsAllPagesS3(bck, amsg, lsmsg, r.Header)
 	if cmn.Rom.FastV(5, cos.SmoduleS3) {
@@ -361,7 +364,9 @@ func (p *proxy) listObjectsS3(w http.ResponseWriter, r *http.Request, bucket str
 	//   becomes an issue - consider using native API.
 
 	resp := s3.NewListObjectResult(bucket)
-	resp.ContinuationToken = lsmsg.ContinuationToken
+	resp.ContinuationToken = q.Get(s3.QparamContinuationToken)
+	resp.Delimiter = q.Get(s3.QparamDelimiter)
+	resp.EncodingType = q.Get(s3.QparamEncodingType)
 	resp.FromLsoResult(lst, lsmsg)
 	sgl := p.gmm.NewSGL(0)
 	resp.MustMarshal(sgl)
@@ -550,7 +555,10 @@ func (p *proxy) listMultipart(w http.ResponseWriter, r *http.Request, bck *meta.
 		return
 	}
 	// bcast & aggregate
-	all := &s3.ListMptUploadsResult{}
+	var (
+		all     = &s3.ListMptUploadsResult{Bucket: bck.Name}
+		uploads []s3.UploadInfoResult
+	)
 	for _, si := range smap.Tmap {
 		var (
 			url   = si.URL(cmn.NetPublic)
@@ -565,16 +573,14 @@ func (p *proxy) listMultipart(w http.ResponseWriter, r *http.Request, bck *meta.
 		if err == nil {
 			results := &s3.ListMptUploadsResult{}
 			if err := xml.Unmarshal(b, results); err == nil {
-				if len(results.Uploads) > 0 {
-					if len(all.Uploads) == 0 {
-						*all = *results
-						all.Uploads = make([]s3.UploadInfoResult, 0)
-					}
-					all.Uploads = append(all.Uploads, results.Uploads...)
-				}
+				all.Prefix, all.KeyMarker, all.UploadIDMarker = results.Prefix, results.KeyMarker, results.UploadIDMarker
+				all.MaxUploads = results.MaxUploads
+				all.IsTruncated = all.IsTruncated || results.IsTruncated
+				uploads = append(uploads, results.Uploads...)
 			}
 		}
 	}
+	all.Finalize(uploads)
 	sgl := p.gmm.NewSGL(0)
 	all.MustMarshal(sgl)
 	w.Header().Set(cos.HdrContentType, cos.ContentXML)
@@ -713,6 +719,14 @@ func (p *proxy) initByNameOnly(w http.ResponseWriter, r *http.Request, bucket st
 		return nil
 	}
 	debug.Assert(bck != nil)
+	// read-only alias (compare with bctx.checkAliasRO)
+	if alias, ok := p.owner.bmd.get().GetAlias(&cmn.Bck{Name: bucket, Provider: apc.AIS}); ok && alias.ReadOnly {
+		if r.Method != http.MethodGet && r.Method != http.MethodHead {
+			err := cmn.NewBucketAccessDenied(bck.Cname(""), "write (via read-only alias)", apc.AccessRO)
+			s3.WriteErr(w, r, err, http.StatusForbidden)
+			return nil
+		}
+	}
 	return bck
 }
 
@@ -0,0 +1,229 @@
+// Package ais provides core functionality for the AIStore object storage.
+/*
+ * Copyright (c) 2024, NVIDIA CORPORATION. All rights reserved.
+ */
+package ais
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/csv"
+	"errors"
+	"io"
+	"net/http"
+	"sync"
+
+	"github.com/NVIDIA/aistore/api/apc"
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/cmn/cos"
+	"github.com/NVIDIA/aistore/cmn/nlog"
+	"github.com/NVIDIA/aistore/core/meta"
+)
+
+// bulk stat (apc.ActStatObjects):
+// - open streams to all active targets; fail the request if any target fails to respond;
+// - merge the streams (record by record, in no particular order) into the response;
+// - abort the connection if any stream breaks in the middle (so that the client
+//   would never mistake partial results for complete).
+
+const statBufSize = 64 * cos.KiB
+
+type statStream struct {
+	tsi  *meta.Snode
+	resp *http.Response
+}
+
+func (p *proxy) statObjects(w http.ResponseWriter, r *http.Request, bck *meta.Bck, msg *apc.ActMsg, smsg *apc.StatObjsMsg) {
+	var (
+		smap    = p.owner.smap.get()
+		body    = cos.MustMarshal(p.newAmsg(msg, nil))
+		streams = make([]*statStream, 0, smap.CountActiveTs())
+		client  = *g.client.data // (no timeout - streaming may take a while)
+		mu      sync.Mutex
+		wg      sync.WaitGroup
+		errs    cos.Errs
+	)
+	client.Timeout = 0
+	if cap(streams) == 0 {
+		p.writeErr(w, r, cmn.NewErrNoNodes(apc.Target, smap.CountTargets()))
+		return
+	}
+
+	// 1. open
+	for _, tsi := range smap.Tmap {
+		if tsi.InMaintOrDecomm() {
+			continue
+		}
+		wg.Add(1)
+		go func(tsi *meta.Snode) {
+			defer wg.Done()
+			resp, err := p._statOpen(r, &client, tsi, bck, body, smap)
+			mu.Lock()
+			if err != nil {
+				errs.Add(err)
+			} else {
+				streams = append(streams, &statStream{tsi: tsi, resp: resp})
+			}
+			mu.Unlock()
+		}(tsi)
+	}
+	wg.Wait()
+	if _, err := errs.JoinErr(); err != nil {
+		for _, s := range streams {
+			s.resp.Body.Close()
+		}
+		p.writeErr(w, r, err)
+		return
+	}
+
+	// 2. merge
+	if smsg.IsCSV() {
+		w.Header().Set(cos.HdrContentType, cos.ContentCSV)
+		csvw := csv.NewWriter(w)
+		csvw.Write(cmn.ObjStatCSVHeader)
+		csvw.Flush()
+	} else {
+		w.Header().Set(cos.HdrContentType, cos.ContentNDJSON)
+	}
+	var failed error
+	for _, s := range streams {
+		wg.Add(1)
+		go func(s *statStream) {
+			defer wg.Done()
+			err := _statCopy(w, s.resp.Body, &mu, smsg.IsCSV())
+			s.resp.Body.Close()
+			if err != nil {
+				mu.Lock()
+				if failed == nil {
+					failed = err
+				}
+				mu.Unlock()
+				nlog.Warningln(p.String(), "stat-objects", bck.Cname(smsg.Prefix), "from", s.tsi.StringEx(), "err:", err)
+			}
+		}(s)
+	}
+	wg.Wait()
+	if failed != nil {
+		panic(http.ErrAbortHandler) // (see above)
+	}
+}
+
+func (p *proxy) _statOpen(r *http.Request, client *http.Client, tsi *meta.Snode, bck *meta.Bck, body []byte, smap *smapX) (*http.Response, error) {
+	args := cmn.HreqArgs{
+		Method: http.MethodGet,
+		Base:   tsi.URL(cmn.NetIntraData),
+		Path:   apc.URLPathBuckets.Join(bck.Name),
+		Query:  bck.NewQuery(),
+		Body:   body,
+	}
+	req, err := args.Req()
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(r.Context())
+	req.Header.Set(cos.HdrContentType, cos.ContentJSON)
+	req.Header.Set(apc.HdrCallerID, p.SID())
+	req.Header.Set(apc.HdrCallerName, p.si.Name())
+	req.Header.Set(apc.HdrCallerSmapVer, smap.vstr)
+	if smap.IsPrimary(p.si) {
+		req.Header.Set(apc.HdrCallerIsPrimary, "true")
+	}
+	req.Header.Set(cos.HdrUserAgent, ua)
+
+	resp, err := client.Do(req) //nolint:bodyclose // closed by the caller
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode < http.StatusBadRequest {
+		return resp, nil
+	}
+	res := &callResult{si: tsi, status: resp.StatusCode}
+	b, _ := cos.ReadAll(resp.Body)
+	resp.Body.Close()
+	return nil, res.herr(req, string(b))
+}
+
+// copy whole lines (so that concurrent streams do not interleave)
+func _statCopy(w io.Writer, body io.Reader, mu *sync.Mutex, isCSV bool) error {
+	if isCSV {
+		return _statCopyCSV(w, body, mu)
+	}
+	br := bufio.NewReaderSize(body, statBufSize)
+	for {
+		line, err := br.ReadSlice('\n')
+		if err == bufio.ErrBufferFull {
+			var rest []byte
+			line = append([]byte{}, line...)
+			rest, err = br.ReadBytes('\n')
+			line = append(line, rest...)
+		}
+		if len(line) > 0 {
+			if err == nil {
+				mu.Lock()
+				_, err = w.Write(line)
+				mu.Unlock()
+			} else if err == io.EOF {
+				return errors.New("truncated stream")
+			}
+		}
+		switch {
+		case err == nil:
+		case err == io.EOF:
+			return nil
+		default:
+			return err
+		}
+	}
+}
+
+// ditto, whole CSV records - those may span multiple lines (quoted newlines, e.g. in custom metadata);
+// each record is written once the next one (or the end of the stream) confirms it is complete
+func _statCopyCSV(w io.Writer, body io.Reader, mu *sync.Mutex) error {
+	var (
+		lr   = &lastByteReader{r: body}
+		cr   = csv.NewReader(lr)
+		buf  bytes.Buffer
+		csvw = csv.NewWriter(&buf)
+	)
+	cr.FieldsPerRecord = len(cmn.ObjStatCSVHeader)
+	cr.ReuseRecord = true
+	for {
+		rec, err := cr.Read()
+		if err != nil && err != io.EOF {
+			return err
+		}
+		if buf.Len() > 0 {
+			if err == io.EOF && lr.last != '\n' {
+				return errors.New("truncated stream")
+			}
+			mu.Lock()
+			_, errW := w.Write(buf.Bytes())
+			mu.Unlock()
+			if errW != nil {
+				return errW
+			}
+			buf.Reset()
+		}
+		if err == io.EOF {
+			return nil
+		}
+		csvw.Write(rec)
+		csvw.Flush()
+		if err := csvw.Error(); err != nil {
+			return err
+		}
+	}
+}
+
+type lastByteReader struct {
+	r    io.Reader
+	last byte
+}
+
+func (lr *lastByteReader) Read(b []byte) (n int, err error) {
+	n, err = lr.r.Read(b)
+	if n > 0 {
+		lr.last = b[n-1]
+	}
+	return n, err
+}
@@ -0,0 +1,88 @@
+// Package ais provides core functionality for the AIStore object storage.
+/*
+ * Copyright (c) 2024, NVIDIA CORPORATION. All rights reserved.
+ */
+package ais
+
+import (
+	"bytes"
+	"encoding/csv"
+	"io"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"testing/iotest"
+
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/cmn/cos"
+	"github.com/NVIDIA/aistore/tools/tassert"
+)
+
+func statCSV(tt *testing.T, prefix string, num int) string {
+	var (
+		buf  bytes.Buffer
+		csvw = csv.NewWriter(&buf)
+	)
+	for i := range num {
+		st := &cmn.ObjStat{
+			Name:   prefix + strconv.Itoa(i),
+			Size:   int64(i),
+			Custom: cos.StrKVs{"note": "line one\nline two, \"quoted\""},
+		}
+		tassert.CheckFatal(tt, csvw.Write(st.CSV()))
+	}
+	csvw.Flush()
+	tassert.CheckFatal(tt, csvw.Error())
+	return buf.String()
+}
+
+func TestStatCopyCSV(tt *testing.T) {
+	const num = 100
+	var (
+		out bytes.Buffer
+		mu  sync.Mutex
+		wg  sync.WaitGroup
+	)
+	for _, prefix := range []string{"a/", "b/", "c/"} {
+		body := iotest.OneByteReader(strings.NewReader(statCSV(tt, prefix, num)))
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			tassert.CheckError(tt, _statCopy(&out, body, &mu, true /*csv*/))
+		}()
+	}
+	wg.Wait()
+
+	recs, err := csv.NewReader(&out).ReadAll()
+	tassert.CheckFatal(tt, err)
+	tassert.Fatalf(tt, len(recs) == 3*num, "expected %d records, got %d", 3*num, len(recs))
+	names := make(map[string]struct{}, len(recs))
+	for _, rec := range recs {
+		tassert.Fatalf(tt, len(rec) == len(cmn.ObjStatCSVHeader), "invalid record %q", rec)
+		tassert.Errorf(tt, strings.Contains(rec[len(rec)-1], "line one\nline two"), "custom metadata %q", rec[len(rec)-1])
+		names[rec[0]] = struct{}{}
+	}
+	tassert.Errorf(tt, len(names) == 3*num, "expected %d distinct names, got %d", 3*num, len(names))
+}
+
+func TestStatCopyTruncated(tt *testing.T) {
+	var mu sync.Mutex
+	full := statCSV(tt, "a/", 3)
+	for _, cut := range []int{1, len(full) / 2, len(full) - 1} {
+		var out bytes.Buffer
+		err := _statCopy(&out, strings.NewReader(full[:cut]), &mu, true /*csv*/)
+		tassert.Errorf(tt, err != nil, "csv cut at %d: expected error", cut)
+		_, err = csv.NewReader(&out).ReadAll()
+		tassert.CheckError(tt, err) // (only complete records)
+	}
+
+	lines := "{\"name\":\"a\"}\n{\"name\":\"b\"}\n"
+	var out bytes.Buffer
+	tassert.CheckError(tt, _statCopy(&out, strings.NewReader(lines), &mu, false))
+	tassert.Errorf(tt, out.String() == lines, "expected %q, got %q", lines, out.String())
+	out.Reset()
+	err := _statCopy(&out, io.MultiReader(strings.NewReader(lines), strings.NewReader("{\"na")), &mu, false)
+	tassert.Errorf(tt, err != nil, "ndjson: expected error")
+	tassert.Errorf(tt, out.String() == lines, "expected %q, got %q", lines, out.String())
+}
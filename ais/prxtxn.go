@@ -36,6 +36,7 @@ type txnCln struct {
 	uuid     string
 	path     string
 	req      cmn.HreqArgs
+	wmsg     aisMsg // on the wire: snapshot of the `msg` taken at init time and (optionally) updated prior to commit
 	selected meta.Nodes
 	timeout  struct {
 		netw time.Duration
@@ -66,8 +67,8 @@ func (c *txnCln) init(msg *apc.ActMsg, bck *meta.Bck, config *cmn.Config, waitms
 	query.Set(apc.QparamHostTimeout, cos.UnixNano2S(int64(c.timeout.host)))
 
 	c.msg = c.p.newAmsg(msg, nil, c.uuid)
-	body := cos.MustMarshal(c.msg)
-	c.req = cmn.HreqArgs{Method: http.MethodPost, Query: query, Body: body}
+	c.wmsg = *c.msg
+	c.req = cmn.HreqArgs{Method: http.MethodPost, Query: query} // (body: wmsg - see bcast)
 	return c
 }
 
@@ -140,6 +141,7 @@ func (c *txnCln) bcast(phase string, timeout time.Duration) (results sliceResult
 	defer freeBcArgs(args)
 
 	args.req = c.req
+	args.amsg = &c.wmsg // (JSON or msgpack, as per target)
 	args.smap = c.smap
 	args.timeout = timeout
 	args.to = core.Targets // the (0) default
@@ -236,6 +238,9 @@ func (p *proxy) _createBucketWithProps(msg *apc.ActMsg, bck *meta.Bck, bprops *c
 	if _, present := bmd.Get(bck); present {
 		return cmn.NewErrBckAlreadyExists(bck.Bucket())
 	}
+	if _, ok := bmd.GetAlias(bck.Bucket()); ok {
+		return fmt.Errorf("cannot create %s: the name is used by a bucket alias", bck)
+	}
 
 	// 2. begin
 	var (
@@ -271,6 +276,9 @@ func (p *proxy) _createBucketWithProps(msg *apc.ActMsg, bck *meta.Bck, bprops *c
 
 func bmodCreate(ctx *bmdModifier, clone *bucketMD) (err error) {
 	bck := ctx.bcks[0]
+	if err = checkProvisionQuota(clone, bck, ctx.setProps); err != nil {
+		return err
+	}
 	added := clone.add(bck, ctx.setProps)
 	if !added {
 		err = cmn.NewErrBckAlreadyExists(bck.Bucket())
@@ -402,6 +410,7 @@ func (p *proxy) setBprops(msg *apc.ActMsg, bck *meta.Bck, nprops *cmn.Bprops) (s
 			bargs.hdr = remoteBckProps
 		}
 		nprops = defaultBckProps(bargs)
+		nprops.Provision = bprops.Provision // provisioned buckets remain so
 	default:
 		return "", fmt.Errorf(fmtErrInvaldAction, msg.Action, []string{apc.ActSetBprops, apc.ActResetBprops})
 	}
@@ -467,6 +476,7 @@ func (p *proxy) bmodSetProps(ctx *bmdModifier, clone *bucketMD) (err error) {
 	ctx.needReMirror = _reMirror(bprops, ctx.setProps)
 	targetCnt, ctx.needReEC = _reEC(bprops, ctx.setProps, bck, p.owner.smap.get())
 	debug.Assert(!ctx.needReEC || ctx.setProps.Validate(targetCnt) == nil)
+	ctx.setProps.Freeze.State = bprops.Freeze.State // changed only by freeze and thaw (see prxfreeze)
 	clone.set(bck, ctx.setProps)
 	return nil
 }
@@ -536,7 +546,7 @@ func (p *proxy) renameBucket(bckFrom, bckTo *meta.Bck, msg *apc.ActMsg) (xid str
 	p.ic.registerEqual(regIC{smap: c.smap, nl: nl, query: c.req.Query})
 
 	// 5. commit
-	c.req.Body = cos.MustMarshal(c.msg)
+	c.wmsg = *c.msg
 	xid, _, err = c.commit(bckFrom, c.cmtTout(waitmsync))
 	debug.Assertf(xid == "" || xid == c.uuid, "committed %q vs generated %q", xid, c.uuid)
 	if err != nil {
@@ -873,17 +883,12 @@ func (p *proxy) destroyBucket(msg *apc.ActMsg, bck *meta.Bck) error {
 	*actMsg = *msg
 
 	// 1. begin
+	// (targets do not remove the data synchronously - they move it to 'deleted'
+	// and then remove in the background - see apc.ActReclaimBck)
 	var (
 		waitmsync = true
 		c         = p.prepTxnClient(actMsg, bck, waitmsync)
-		config    = cmn.GCO.Get()
 	)
-	// NOTE: testing only: to avoid premature aborts when loopback devices get 100% utilized
-	// (under heavy writing)
-	if config.TestingEnv() {
-		c.timeout.netw = config.Timeout.MaxHostBusy.D() + config.Timeout.MaxHostBusy.D()/2
-		c.timeout.host = c.timeout.netw
-	}
 	if err := c.begin(bck); err != nil {
 		return err
 	}
@@ -1104,6 +1109,16 @@ func (p *proxy) makeNewBckProps(bck *meta.Bck, propsToUpdate *cmn.BpropsToSet, c
 			return
 		}
 	}
+	if bprops.Publish.Enabled {
+		if nprops.Publish != bprops.Publish {
+			err = fmt.Errorf("%s: %s is published - once enabled, publish configuration cannot change", p.si, bck)
+			return
+		}
+		if bprops.Publish.ContentAddr && nprops.Cksum.Type != bprops.Cksum.Type {
+			err = fmt.Errorf("%s: %s is content-addressed - cannot change checksum type %q", p.si, bck, bprops.Cksum.Type)
+			return
+		}
+	}
 	if bprops.EC.Enabled && nprops.EC.Enabled {
 		sameSlices := bprops.EC.DataSlices == nprops.EC.DataSlices && bprops.EC.ParitySlices == nprops.EC.ParitySlices
 		sameLimit := bprops.EC.ObjSizeLimit == nprops.EC.ObjSizeLimit
@@ -1132,6 +1147,12 @@ func (p *proxy) makeNewBckProps(bck *meta.Bck, propsToUpdate *cmn.BpropsToSet, c
 			return
 		}
 	}
+	if name := nprops.ETL.Name; name != "" && name != bprops.ETL.Name {
+		if p.owner.etl.get().get(name) == nil {
+			err = cos.NewErrNotFound(p, "ETL "+name)
+			return
+		}
+	}
 	// cannot have re-mirroring and erasure coding on the same bucket at the same time
 	remirror := _reMirror(bprops, nprops)
 	targetCnt, reec := _reEC(bprops, nprops, bck, p.owner.smap.get())
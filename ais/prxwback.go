@@ -0,0 +1,51 @@
+// Package ais provides core functionality for the AIStore object storage.
+/*
+ * Copyright (c) 2024, NVIDIA CORPORATION. All rights reserved.
+ */
+package ais
+
+import (
+	"net/http"
+
+	"github.com/NVIDIA/aistore/api/apc"
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/cmn/cos"
+	"github.com/NVIDIA/aistore/core/meta"
+)
+
+// GET { apc.ActDeleteQueue } and POST { apc.ActFlushDeletes, apc.ActCancelDeletes } /v1/buckets/bucket-name
+// (pending backend deletions are queued by their respective targets - see tgtwback.go)
+func (p *proxy) deleteQueue(w http.ResponseWriter, r *http.Request, bck *meta.Bck, msg *apc.ActMsg) {
+	if !bck.Props.WriteBack.Enabled {
+		p.writeErrf(w, r, "%s: bucket %s is not configured for write-back (see %q)", msg.Action, bck.Cname(""), "write_back")
+		return
+	}
+	args := allocBcArgs()
+	args.req = cmn.HreqArgs{
+		Method: r.Method,
+		Path:   apc.URLPathBuckets.Join(bck.Name),
+		Query:  bck.NewQuery(),
+		Body:   cos.MustMarshal(p.newAmsg(msg, nil)),
+	}
+	args.smap = p.owner.smap.get()
+	if cnt := args.smap.CountActiveTs(); cnt < 1 {
+		freeBcArgs(args)
+		p.writeErr(w, r, cmn.NewErrNoNodes(apc.Target, args.smap.CountTargets()))
+		return
+	}
+	args.cresv = cresDQ{} // -> cmn.DeleteQueueInfo
+	results := p.bcastGroup(args)
+	freeBcArgs(args)
+
+	queue := make(cmn.DeleteQueue, len(results))
+	for _, res := range results {
+		if res.err != nil {
+			p.writeErr(w, r, res.toErr())
+			freeBcastRes(results)
+			return
+		}
+		queue[res.si.ID()] = res.v.(*cmn.DeleteQueueInfo)
+	}
+	freeBcastRes(results)
+	p.writeJSON(w, r, queue, msg.Action)
+}
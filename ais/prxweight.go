@@ -0,0 +1,241 @@
+// Package ais provides core functionality for the AIStore object storage.
+/*
+ * Copyright (c) 2024, NVIDIA CORPORATION. All rights reserved.
+ */
+package ais
+
+import (
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/NVIDIA/aistore/api/apc"
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/cmn/cos"
+	"github.com/NVIDIA/aistore/cmn/nlog"
+	"github.com/NVIDIA/aistore/nl"
+)
+
+// Target weight (apc.ActSetWeight):
+// - scales the target's HRW share (see Snode.Offload and meta.tscore);
+// - each change is followed by global rebalance that only moves objects off (or back onto) the target;
+// - gradual: step down (or up) by `Step` at a time, waiting for the previous rebalance to complete
+//   plus `Interval` - instead of one massive migration (e.g., prior to decommissioning);
+// - the schedule is maintained by the primary (in memory) and gets canceled by a new set-weight request
+//   for the same target.
+
+type (
+	wstep struct {
+		val apc.ActValWeight
+	}
+	wsteps struct {
+		m  map[string]*wstep // target ID => schedule in progress
+		mu sync.Mutex
+	}
+)
+
+func (p *proxy) setWeight(w http.ResponseWriter, r *http.Request, msg *apc.ActMsg) {
+	var val apc.ActValWeight
+	if err := cos.MorphMarshal(msg.Value, &val); err != nil {
+		p.writeErrf(w, r, cmn.FmtErrMorphUnmarshal, p.si, msg.Action, msg.Value, err)
+		return
+	}
+	if val.Weight < 0 || val.Weight > 100 || val.Step < 0 || val.Step > 100 || val.Interval < 0 {
+		p.writeErrf(w, r, "%s: invalid %s request %+v (expecting weight and step in [0, 100] range)", p, msg.Action, val)
+		return
+	}
+	smap := p.owner.smap.get()
+	tsi := smap.GetTarget(val.DaemonID)
+	if tsi == nil {
+		p.writeErr(w, r, &errNodeNotFound{msg.Action + " failure:", val.DaemonID, p.si, smap}, http.StatusNotFound)
+		return
+	}
+	if tsi.InMaintOrDecomm() {
+		p.writeErrf(w, r, "%s: cannot set weight of %s (in maintenance or being decommissioned)", p, tsi.StringEx())
+		return
+	}
+	ws := &wstep{val: val}
+	p.wsteps.set(val.DaemonID, ws) // (replacing previous schedule, if any)
+
+	rebID, err := p.stepWeight(ws, msg)
+	if err != nil {
+		p.wsteps.del(val.DaemonID, ws)
+		p.writeErr(w, r, err)
+		return
+	}
+	if rebID != "" {
+		writeXid(w, rebID)
+	}
+}
+
+// one step: update Smap and start rebalance
+func (p *proxy) stepWeight(ws *wstep, msg *apc.ActMsg) (string, error) {
+	var (
+		tid  = ws.val.DaemonID
+		smap = p.owner.smap.get()
+		tsi  = smap.GetTarget(tid)
+	)
+	if tsi == nil {
+		return "", fmt.Errorf("%s: target %s not found in %s", p, tid, smap)
+	}
+	cur, next := 100-int(tsi.Offload), ws.val.Weight
+	if step := ws.val.Step; step > 0 {
+		if cur > next {
+			next = max(cur-step, next)
+		} else {
+			next = min(cur+step, next)
+		}
+	}
+	last := next == ws.val.Weight
+	if last {
+		p.wsteps.del(tid, ws)
+	}
+	if next == cur {
+		return "", nil
+	}
+
+	ctx := &smapModifier{
+		pre: func(_ *smapModifier, clone *smapX) error {
+			if !clone.isPrimary(p.si) {
+				return newErrNotPrimary(p.si, clone, "cannot set weight")
+			}
+			nsi := clone.GetTarget(tid)
+			if nsi == nil {
+				return fmt.Errorf("target %s not found in %s", tid, clone)
+			}
+			nlog.Infof("%s: %s weight %d => %d", p, nsi.StringEx(), cur, next)
+			nsi.Offload = uint8(100 - next)
+			clone.Version++
+			return nil
+		},
+		post: func(ctx *smapModifier, clone *smapX) {
+			var cb func(*rmdModifier, nl.Listener)
+			if !last {
+				cb = func(m *rmdModifier, nl nl.Listener) {
+					m.log(nl)
+					if err := nl.Err(); err != nil || nl.Aborted() {
+						nlog.Warningln(p.String(), "stopping gradual set-weight of", tid, "- rebalance failed or aborted")
+						p.wsteps.del(tid, ws)
+						return
+					}
+					p.nextWeightStep(ws)
+				}
+			}
+			p._rebRMD(ctx, clone, cb)
+		},
+		final: p._syncFinal,
+		msg:   msg,
+		sid:   tid,
+	}
+	if err := p.owner.smap.modify(ctx); err != nil {
+		return "", err
+	}
+	if ctx.rmdCtx == nil || ctx.rmdCtx.rebID == "" {
+		// no rebalance (e.g., disabled) - nothing to wait for
+		if !last {
+			p.nextWeightStep(ws)
+		}
+		return "", nil
+	}
+	return ctx.rmdCtx.rebID, nil
+}
+
+func (p *proxy) nextWeightStep(ws *wstep) {
+	time.AfterFunc(ws.val.Interval.D(), func() {
+		tid := ws.val.DaemonID
+		if !p.wsteps.current(tid, ws) {
+			return // canceled or replaced
+		}
+		if !p.owner.smap.get().isPrimary(p.si) {
+			p.wsteps.del(tid, ws)
+			return
+		}
+		msg := &apc.ActMsg{Action: apc.ActSetWeight, Value: ws.val}
+		if _, err := p.stepWeight(ws, msg); err != nil {
+			nlog.Errorln(p.String(), "gradual set-weight of", tid, "failed:", err)
+			p.wsteps.del(tid, ws)
+		}
+	})
+}
+
+// target (re)announces its total capacity (Snode.Weight), e.g. upon filesystem resize;
+// with capacity-weighted placement, the change gets followed by global rebalance
+func (p *proxy) setNodeCap(w http.ResponseWriter, r *http.Request, msg *apc.ActMsg) {
+	var val apc.ActValNodeCap
+	if err := cos.MorphMarshal(msg.Value, &val); err != nil {
+		p.writeErrf(w, r, cmn.FmtErrMorphUnmarshal, p.si, msg.Action, msg.Value, err)
+		return
+	}
+	if val.Capacity == 0 {
+		p.writeErrf(w, r, "%s: invalid %s request %+v (zero capacity)", p, msg.Action, val)
+		return
+	}
+	smap := p.owner.smap.get()
+	tsi := smap.GetTarget(val.DaemonID)
+	if tsi == nil {
+		p.writeErr(w, r, &errNodeNotFound{msg.Action + " failure:", val.DaemonID, p.si, smap}, http.StatusNotFound)
+		return
+	}
+	if tsi.Weight == val.Capacity {
+		return // nothing to do
+	}
+	ctx := &smapModifier{
+		pre: func(_ *smapModifier, clone *smapX) error {
+			if !clone.isPrimary(p.si) {
+				return newErrNotPrimary(p.si, clone, "cannot update capacity")
+			}
+			nsi := clone.GetTarget(val.DaemonID)
+			if nsi == nil {
+				return fmt.Errorf("target %s not found in %s", val.DaemonID, clone)
+			}
+			nlog.Infof("%s: %s capacity %dGiB => %dGiB", p, nsi.StringEx(), nsi.Weight, val.Capacity)
+			nsi.Weight = val.Capacity
+			clone.Version++
+			return nil
+		},
+		post: func(ctx *smapModifier, clone *smapX) {
+			if clone.Placement == apc.PlacementCapacity {
+				p._rebRMD(ctx, clone, nil)
+			}
+		},
+		final: p._syncFinal,
+		msg:   msg,
+		sid:   val.DaemonID,
+	}
+	if err := p.owner.smap.modify(ctx); err != nil {
+		p.writeErr(w, r, err)
+		return
+	}
+	if ctx.rmdCtx != nil && ctx.rmdCtx.rebID != "" {
+		writeXid(w, ctx.rmdCtx.rebID)
+	}
+}
+
+////////////
+// wsteps //
+////////////
+
+func (s *wsteps) set(tid string, ws *wstep) {
+	s.mu.Lock()
+	if s.m == nil {
+		s.m = make(map[string]*wstep, 2)
+	}
+	s.m[tid] = ws
+	s.mu.Unlock()
+}
+
+func (s *wsteps) del(tid string, ws *wstep) {
+	s.mu.Lock()
+	if s.m[tid] == ws {
+		delete(s.m, tid)
+	}
+	s.mu.Unlock()
+}
+
+func (s *wsteps) current(tid string, ws *wstep) bool {
+	s.mu.Lock()
+	ok := s.m[tid] == ws
+	s.mu.Unlock()
+	return ok
+}
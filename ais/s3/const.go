@@ -19,6 +19,7 @@ const (
 	QparamContinuationToken = "continuation-token"
 	QparamStartAfter        = "start-after"
 	QparamDelimiter         = "delimiter"
+	QparamEncodingType      = "encoding-type"
 
 	// multipart
 	QparamMptUploads        = "uploads"
@@ -26,6 +27,7 @@ const (
 	QparamMptPartNo         = "partNumber"
 	QparamMptMaxUploads     = "max-uploads"
 	QparamMptUploadIDMarker = "upload-id-marker"
+	QparamMptKeyMarker      = "key-marker"
 
 	QparamAccessKeyID = "AWSAccessKeyId"
 	QparamExpires     = "Expires"
@@ -35,6 +37,9 @@ const (
 	HeaderPrefix      = "X-Amz-"
 	HeaderCredentials = "X-Amz-Credential" //nolint:gosec // This is just a header name definition...
 
+	// (the only) supported `encoding-type`
+	EncodingTypeURL = "url"
+
 	versioningEnabled  = "Enabled"
 	versioningDisabled = "Suspended"
 
@@ -42,6 +47,8 @@ const (
 	// https://docs.aws.amazon.com/AmazonS3/latest/userguide/qfacts.html
 	MaxPartsPerUpload = 10000
 
+	MaxUploadsDflt = 1000 // ListMultipartUploads: max number of uploads in a response
+
 	s3Namespace = "http://s3.amazonaws.com/doc/2006-03-01"
 	s3URL       = "https://%s.s3.%s.amazonaws.com/%s?%s"
 
@@ -7,8 +7,10 @@ package s3
 import (
 	"fmt"
 	"net/http"
+	"os"
 	"sort"
 	"strconv"
+	"strings"
 	"sync"
 	"time"
 
@@ -33,6 +35,12 @@ type (
 		ctime   time.Time  // InitUpload time
 	}
 	uploads map[string]*mpt // by upload ID
+
+	ExpiredUpload struct {
+		ID      string
+		BckName string
+		ObjName string
+	}
 )
 
 var (
@@ -55,6 +63,14 @@ func InitUpload(id, bckName, objName string) {
 	mu.Unlock()
 }
 
+// IsActive returns true if the upload is in progress (see also xs.XactAbortIncomplete).
+func IsActive(id string) bool {
+	mu.RLock()
+	_, ok := ups[id]
+	mu.RUnlock()
+	return ok
+}
+
 // Add part to an active upload.
 // Some clients may omit size and md5. Only partNum is must-have.
 // md5 and fqn is filled by a target after successful saving the data to a workfile.
@@ -145,35 +161,108 @@ func CleanupUpload(id, fqn string, aborted bool) (exists bool) {
 	return true
 }
 
-func ListUploads(bckName, idMarker string, maxUploads int) (result *ListMptUploadsResult) {
+// List active multipart uploads in a given bucket, in the S3 order: by object name
+// (key) and, within the same key, by initiation time.
+func ListUploads(bckName, prefix, keyMarker, idMarker string, maxUploads int) (result *ListMptUploadsResult) {
 	mu.RLock()
-	results := make([]UploadInfoResult, 0, len(ups))
+	results := make([]UploadInfoResult, 0, 16)
 	for id, mpt := range ups {
+		if mpt.bckName != bckName || !strings.HasPrefix(mpt.objName, prefix) {
+			continue
+		}
 		results = append(results, UploadInfoResult{Key: mpt.objName, UploadID: id, Initiated: mpt.ctime})
 	}
 	mu.RUnlock()
 
+	result = &ListMptUploadsResult{
+		Bucket:         bckName,
+		Prefix:         prefix,
+		KeyMarker:      keyMarker,
+		UploadIDMarker: idMarker,
+		MaxUploads:     maxUploads,
+	}
+	result.Finalize(results)
+	return result
+}
+
+// Sort, skip up to and including the marker(s), and truncate; is also used to
+// aggregate (per-target) results.
+func (r *ListMptUploadsResult) Finalize(results []UploadInfoResult) {
 	sort.Slice(results, func(i int, j int) bool {
+		if results[i].Key != results[j].Key {
+			return results[i].Key < results[j].Key
+		}
 		return results[i].Initiated.Before(results[j].Initiated)
 	})
-
-	var from int
-	if idMarker != "" {
-		// truncate
-		for i, res := range results {
-			if res.UploadID == idMarker {
+	if r.KeyMarker != "" {
+		from := len(results)
+		for i := range results {
+			if results[i].Key < r.KeyMarker {
+				continue
+			}
+			if results[i].Key > r.KeyMarker {
+				from = i
+				break
+			}
+			// same key: continue after the upload-id-marker, if specified
+			if r.UploadIDMarker == "" {
+				continue
+			}
+			if results[i].UploadID == r.UploadIDMarker {
 				from = i + 1
 				break
 			}
-			copy(results, results[from:])
-			results = results[:len(results)-from]
 		}
+		results = results[from:]
 	}
-	if maxUploads > 0 && len(results) > maxUploads {
-		results = results[:maxUploads]
+	if r.MaxUploads <= 0 || r.MaxUploads > MaxUploadsDflt {
+		r.MaxUploads = MaxUploadsDflt
 	}
-	result = &ListMptUploadsResult{Bucket: bckName, Uploads: results, IsTruncated: from > 0}
-	return
+	if len(results) > r.MaxUploads {
+		results = results[:r.MaxUploads]
+		r.IsTruncated = true
+	}
+	if r.IsTruncated && len(results) > 0 {
+		last := &results[len(results)-1]
+		r.NextKeyMarker, r.NextUploadIDMarker = last.Key, last.UploadID
+	}
+	r.Uploads = results
+}
+
+// Abort multipart uploads initiated before the cutoff time (Unix nanoseconds), optionally
+// only in a given bucket; remove their parts; return the aborted uploads and the total
+// size of their parts.
+// Note that it is the caller's responsibility to abort remote uploads as well, if need be.
+func AbortExpired(bckName string, cutoff int64) (aborted []*ExpiredUpload, size int64) {
+	var expired []*mpt
+	mu.Lock()
+	for id, mpt := range ups {
+		if bckName != "" && mpt.bckName != bckName {
+			continue
+		}
+		if mpt.ctime.UnixNano() > cutoff {
+			continue
+		}
+		delete(ups, id)
+		expired = append(expired, mpt)
+		aborted = append(aborted, &ExpiredUpload{ID: id, BckName: mpt.bckName, ObjName: mpt.objName})
+	}
+	mu.Unlock()
+
+	for _, mpt := range expired {
+		for _, part := range mpt.parts {
+			finfo, err := os.Stat(part.FQN)
+			if err != nil {
+				continue
+			}
+			if err := cos.RemoveFile(part.FQN); err != nil {
+				nlog.Errorln("failed to remove part [", mpt.bckName, mpt.objName, err, "]")
+				continue
+			}
+			size += finfo.Size()
+		}
+	}
+	return aborted, size
 }
 
 func ListParts(id string, lom *core.LOM) (parts []*PartInfo, ecode int, err error) {
@@ -0,0 +1,81 @@
+// Package s3 provides Amazon S3 compatibility layer
+/*
+ * Copyright (c) 2024, NVIDIA CORPORATION. All rights reserved.
+ */
+package s3
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestListAbortUploads(t *testing.T) {
+	var (
+		dir  = t.TempDir()
+		now  = time.Now()
+		objs = []string{"b/2", "a/1", "a/1", "a/3", "c/4"}
+	)
+	ups = nil
+	for i, objName := range objs {
+		id := "id" + string(rune('0'+i))
+		InitUpload(id, "bck", objName)
+		ups[id].ctime = now.Add(-time.Duration(len(objs)-i) * time.Hour) // ascending
+		fqn := filepath.Join(dir, id)
+		if err := os.WriteFile(fqn, make([]byte, 10), 0o644); err != nil {
+			t.Fatal(err)
+		}
+		if err := AddPart(id, &MptPart{FQN: fqn, Size: 10, Num: 1}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	InitUpload("other", "other-bck", "a/1")
+
+	// sorted by key, then by initiation time
+	res := ListUploads("bck", "", "", "", 0)
+	if len(res.Uploads) != len(objs) || res.IsTruncated {
+		t.Fatalf("expected %d uploads, got %+v", len(objs), res)
+	}
+	expected := []string{"id1", "id2", "id3", "id0", "id4"}
+	for i, up := range res.Uploads {
+		if up.UploadID != expected[i] {
+			t.Fatalf("%d: expected %s, got %s", i, expected[i], up.UploadID)
+		}
+	}
+
+	// prefix, markers, and truncation
+	res = ListUploads("bck", "a/", "", "", 2)
+	if len(res.Uploads) != 2 || !res.IsTruncated || res.NextKeyMarker != "a/1" || res.NextUploadIDMarker != "id2" {
+		t.Fatalf("unexpected (truncated) result %+v", res)
+	}
+	res = ListUploads("bck", "a/", res.NextKeyMarker, res.NextUploadIDMarker, 2)
+	if len(res.Uploads) != 1 || res.IsTruncated || res.Uploads[0].UploadID != "id3" {
+		t.Fatalf("unexpected (continued) result %+v", res)
+	}
+
+	// abort uploads initiated more than 2.5 hours ago
+	aborted, size := AbortExpired("bck", now.Add(-150*time.Minute).UnixNano())
+	if len(aborted) != 3 || size != 30 {
+		t.Fatalf("expected 3 aborted uploads (30 bytes), got %d (%d)", len(aborted), size)
+	}
+	for _, up := range aborted {
+		if _, err := os.Stat(filepath.Join(dir, up.ID)); !os.IsNotExist(err) {
+			t.Fatalf("upload %s: part not removed (%v)", up.ID, err)
+		}
+	}
+	for _, up := range aborted {
+		if IsActive(up.ID) {
+			t.Fatalf("aborted upload %s is still active", up.ID)
+		}
+	}
+	if !IsActive("id4") || !IsActive("other") {
+		t.Fatal("expected remaining uploads to be active")
+	}
+	if res = ListUploads("bck", "", "", "", 0); len(res.Uploads) != 2 {
+		t.Fatalf("expected 2 remaining uploads, got %+v", res)
+	}
+	if res = ListUploads("other-bck", "", "", "", 0); len(res.Uploads) != 1 {
+		t.Fatalf("expected other bucket's upload to remain, got %+v", res)
+	}
+}
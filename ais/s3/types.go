@@ -5,11 +5,14 @@
 package s3
 
 import (
+	"encoding/base64"
 	"encoding/xml"
+	"fmt"
 	"net/http"
 	"net/url"
 	"path"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/NVIDIA/aistore/api/apc"
@@ -31,6 +34,9 @@ type (
 		Name                  string          `xml:"Name"`
 		Ns                    string          `xml:"xmlns,attr"`
 		Prefix                string          `xml:"Prefix"`
+		Delimiter             string          `xml:"Delimiter,omitempty"`
+		StartAfter            string          `xml:"StartAfter,omitempty"`
+		EncodingType          string          `xml:"EncodingType,omitempty"`   // "url" or none (see FromLsoResult)
 		KeyCount              int             `xml:"KeyCount"`                 // number of object names in the response
 		MaxKeys               int             `xml:"MaxKeys"`                  // "The maximum number of keys returned ..." (s3)
 		IsTruncated           bool            `xml:"IsTruncated"`              // true if there are more pages to read
@@ -99,11 +105,15 @@ type (
 
 	// List of active multipart uploads response
 	ListMptUploadsResult struct {
-		Bucket         string             `xml:"Bucket"`
-		UploadIDMarker string             `xml:"UploadIdMarker"`
-		Uploads        []UploadInfoResult `xml:"Upload"`
-		MaxUploads     int
-		IsTruncated    bool
+		Bucket             string             `xml:"Bucket"`
+		KeyMarker          string             `xml:"KeyMarker"`
+		UploadIDMarker     string             `xml:"UploadIdMarker"`
+		NextKeyMarker      string             `xml:"NextKeyMarker,omitempty"`
+		NextUploadIDMarker string             `xml:"NextUploadIdMarker,omitempty"`
+		Prefix             string             `xml:"Prefix,omitempty"`
+		Uploads            []UploadInfoResult `xml:"Upload"`
+		MaxUploads         int
+		IsTruncated        bool
 	}
 
 	// Deleted result: list of deleted objects and errors
@@ -117,7 +127,12 @@ type (
 
 func ObjName(items []string) string { return path.Join(items[1:]...) }
 
-func FillLsoMsg(query url.Values, msg *apc.LsoMsg) {
+// FillLsoMsg translates S3 list-objects query into (native) list-objects message;
+// continuation tokens are opaque to S3 clients and base64 encoded - see FromLsoResult
+func FillLsoMsg(query url.Values, msg *apc.LsoMsg) error {
+	if et := query.Get(QparamEncodingType); et != "" && et != EncodingTypeURL {
+		return fmt.Errorf("invalid %s %q (expecting %q or none)", QparamEncodingType, et, EncodingTypeURL)
+	}
 	mxStr := query.Get(QparamMaxKeys)
 	if pageSize, err := strconv.Atoi(mxStr); err == nil && pageSize > 0 {
 		msg.PageSize = int64(pageSize)
@@ -127,8 +142,11 @@ func FillLsoMsg(query url.Values, msg *apc.LsoMsg) {
 	}
 	var token string
 	if token = query.Get(QparamContinuationToken); token != "" {
-		// base64 encoded, as in: base64.StdEncoding.DecodeString(token)
-		msg.ContinuationToken = token
+		b, err := base64.StdEncoding.DecodeString(token)
+		if err != nil {
+			return fmt.Errorf("invalid %s %q: %v", QparamContinuationToken, token, err)
+		}
+		msg.ContinuationToken = string(b)
 	}
 	// `start-after` is used only when starting to list pages, subsequent next-page calls
 	// utilize `continuation-token`
@@ -139,6 +157,24 @@ func FillLsoMsg(query url.Values, msg *apc.LsoMsg) {
 	if delimiter := query.Get(QparamDelimiter); delimiter != "" {
 		msg.SetFlag(apc.LsNoRecursion)
 	}
+	return nil
+}
+
+func EncodeToken(token string) string {
+	if token == "" {
+		return ""
+	}
+	return base64.StdEncoding.EncodeToString(cos.UnsafeB(token))
+}
+
+// as per `encoding-type=url`: URL-encode (application/x-www-form-urlencoded)
+// each '/'-separated segment of the key, prefix, etc.
+func EncodeKey(key string) string {
+	segs := strings.Split(key, "/")
+	for i, seg := range segs {
+		segs[i] = url.QueryEscape(seg)
+	}
+	return strings.Join(segs, "/")
 }
 
 func NewListObjectResult(bucket string) *ListObjectResult {
@@ -179,13 +215,25 @@ func entryToS3(entry *cmn.LsoEnt, lsmsg *apc.LsoMsg) *ObjInfo {
 	return objInfo
 }
 
+// NOTE: expecting the caller to set the original (encoded) ContinuationToken, Delimiter, and EncodingType
 func (r *ListObjectResult) FromLsoResult(lst *cmn.LsoRes, lsmsg *apc.LsoMsg) {
 	r.KeyCount = len(lst.Entries)
 	r.IsTruncated = lst.ContinuationToken != ""
-	r.NextContinuationToken = lst.ContinuationToken
+	r.NextContinuationToken = EncodeToken(lst.ContinuationToken)
+	r.Prefix, r.StartAfter = lsmsg.Prefix, lsmsg.StartAfter
 	for _, e := range lst.Entries {
 		r.Add(e, lsmsg)
 	}
+	if r.EncodingType != EncodingTypeURL {
+		return
+	}
+	r.Prefix, r.StartAfter, r.Delimiter = EncodeKey(r.Prefix), EncodeKey(r.StartAfter), EncodeKey(r.Delimiter)
+	for _, oi := range r.Contents {
+		oi.Key = EncodeKey(oi.Key)
+	}
+	for _, cp := range r.CommonPrefixes {
+		cp.Prefix = EncodeKey(cp.Prefix)
+	}
 }
 
 func SetEtag(hdr http.Header, lom *core.LOM) {
@@ -0,0 +1,227 @@
+// Package ais provides core functionality for the AIStore object storage.
+/*
+ * Copyright (c) 2024, NVIDIA CORPORATION. All rights reserved.
+ */
+package ais
+
+import (
+	"io"
+	"math/rand/v2"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/NVIDIA/aistore/api/apc"
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/cmn/cos"
+	"github.com/NVIDIA/aistore/cmn/nlog"
+	"github.com/NVIDIA/aistore/core"
+	"github.com/NVIDIA/aistore/core/meta"
+	"github.com/NVIDIA/aistore/stats"
+)
+
+// Request shadowing (canary testing) - see cmn.ShadowConf
+// - fire-and-forget: shadow requests are asynchronous and never affect the original ones;
+// - bounded: when `maxShadowInflight` requests are already in flight the new one gets dropped
+//   (and counted).
+
+const maxShadowInflight = 64
+
+type shadower struct {
+	client *http.Client
+	sema   chan struct{}
+	once   sync.Once
+}
+
+func (sh *shadower) init() {
+	config := cmn.GCO.Get()
+	cargs := cmn.TransportArgs{Timeout: config.Client.TimeoutLong.D()}
+	// (external client that does not present this node's certificate)
+	sh.client = cmn.NewClientTLS(cargs, cmn.TLSArgs{SkipVerify: config.Net.HTTP.SkipVerifyCrt}, false /*intra-cluster*/)
+	sh.sema = make(chan struct{}, maxShadowInflight)
+}
+
+func shadowTry(conf *cmn.ShadowConf) bool {
+	return conf.Enabled() && (conf.Pct >= 100 || rand.IntN(100) < conf.Pct)
+}
+
+// returns false when too many shadow requests are in flight
+func (sh *shadower) acquire(statsT stats.Tracker) bool {
+	sh.once.Do(sh.init)
+	select {
+	case sh.sema <- struct{}{}:
+		statsT.Inc(stats.ShadowCount)
+		return true
+	default:
+		statsT.Inc(stats.ShadowDropCount)
+		return false
+	}
+}
+
+func (sh *shadower) release() { <-sh.sema }
+
+func shadowURL(conf *cmn.ShadowConf, bck *meta.Bck, objName, rawQuery string) string {
+	u := strings.TrimSuffix(conf.Endpoint, "/") + apc.URLPathObjects.Join(bck.Name, objName)
+	if rawQuery != "" {
+		u += "?" + rawQuery
+	}
+	return u
+}
+
+//
+// GET (gateway)
+//
+
+// (same bucket, same object, same query; Range and archival reads included)
+func (p *proxy) shadowGet(bck *meta.Bck, objName string, r *http.Request) {
+	conf := &bck.Props.Shadow
+	if !shadowTry(conf) || !p.shadow.acquire(p.statsT) {
+		return
+	}
+	var (
+		u      = shadowURL(conf, bck, objName, r.URL.RawQuery)
+		rhdr   = r.Header.Get(cos.HdrRange)
+		sample = conf.SamplePct > 0 && rhdr == "" && r.URL.Query().Get(apc.QparamArchpath) == "" &&
+			rand.IntN(100) < conf.SamplePct
+	)
+	b := *bck // (request-scoped)
+	go p._shadowGet(&b, objName, u, rhdr, sample)
+}
+
+func (p *proxy) _shadowGet(bck *meta.Bck, objName, u, rhdr string, sample bool) {
+	defer p.shadow.release()
+	req, err := http.NewRequest(http.MethodGet, u, http.NoBody)
+	if err != nil {
+		p.statsT.IncErr(stats.ErrShadowCount)
+		return
+	}
+	if rhdr != "" {
+		req.Header.Set(cos.HdrRange, rhdr)
+	}
+	resp, err := p.shadow.client.Do(req) //nolint:bodyclose // closed below
+	if err != nil {
+		p.statsT.IncErr(stats.ErrShadowCount)
+		if cmn.Rom.FastV(4, cos.SmoduleAIS) {
+			nlog.Warningln("shadow GET", u, err)
+		}
+		return
+	}
+	size, err := io.Copy(io.Discard, resp.Body)
+	cos.Close(resp.Body)
+	if err != nil || (resp.StatusCode >= http.StatusBadRequest && resp.StatusCode != http.StatusNotFound) {
+		p.statsT.IncErr(stats.ErrShadowCount)
+	}
+	if sample && err == nil {
+		p.shadowCompare(bck, objName, resp, size)
+	}
+}
+
+// compare shadow GET response with production (object metadata via intra-cluster HEAD)
+func (p *proxy) shadowCompare(bck *meta.Bck, objName string, resp *http.Response, size int64) {
+	smap := p.owner.smap.get()
+	tsi, err := smap.HrwName2T(bck.MakeUname(objName))
+	if err != nil {
+		return
+	}
+	q := bck.NewQuery()
+	q.Set(apc.QparamSilent, "true")
+	cargs := allocCargs()
+	{
+		cargs.si = tsi
+		cargs.req = cmn.HreqArgs{
+			Method: http.MethodHead,
+			Base:   tsi.URL(cmn.NetIntraControl),
+			Path:   apc.URLPathObjects.Join(bck.Name, objName),
+			Query:  q,
+		}
+		cargs.timeout = cmn.Rom.CplaneOperation()
+	}
+	res := p.call(cargs, smap)
+	freeCargs(cargs)
+
+	var reason string
+	switch {
+	case res.status == http.StatusNotFound || resp.StatusCode == http.StatusNotFound:
+		if res.status != resp.StatusCode {
+			reason = "presence"
+		}
+	case res.err != nil || resp.StatusCode != http.StatusOK:
+		// (errors are counted elsewhere)
+	case res.header.Get(cos.HdrContentLength) != "" && res.header.Get(cos.HdrContentLength) != strconv.FormatInt(size, 10):
+		reason = "size"
+	default:
+		ty, val := res.header.Get(apc.HdrObjCksumType), res.header.Get(apc.HdrObjCksumVal)
+		if val != "" && ty == resp.Header.Get(apc.HdrObjCksumType) && val != resp.Header.Get(apc.HdrObjCksumVal) {
+			reason = "checksum"
+		}
+	}
+	freeCR(res)
+	if reason != "" {
+		p.statsT.Inc(stats.ShadowDivergeCount)
+		nlog.Warningln("shadow GET", bck.Cname(objName), "diverged:", reason)
+	}
+}
+
+//
+// PUT (target) - upon successful PUT, send the (new) object to the shadow cluster
+//
+
+func (t *target) shadowPut(lom *core.LOM) {
+	conf := &lom.Bprops().Shadow
+	if !conf.PUT || !shadowTry(conf) || !t.shadow.acquire(t.statsT) {
+		return
+	}
+	var (
+		bck = *lom.Bck() // (lom gets freed upon return)
+		u   = shadowURL(conf, &bck, lom.ObjName, bck.NewQuery().Encode())
+	)
+	go t._shadowPut(&bck, lom.ObjName, u)
+}
+
+func (t *target) _shadowPut(bck *meta.Bck, objName, u string) {
+	defer t.shadow.release()
+
+	lom := core.AllocLOM(objName)
+	defer core.FreeLOM(lom)
+	if err := lom.InitBck(bck.Bucket()); err != nil {
+		t.statsT.IncErr(stats.ErrShadowCount)
+		return
+	}
+	// open under r-lock; the open file remains readable after a concurrent overwrite
+	lom.Lock(false)
+	if err := lom.Load(false, true); err != nil {
+		lom.Unlock(false)
+		return // (removed in the meantime)
+	}
+	lmfh, err := lom.Open()
+	size := lom.Lsize()
+	lom.Unlock(false)
+	if err != nil {
+		t.statsT.IncErr(stats.ErrShadowCount)
+		return
+	}
+	defer cos.Close(lmfh)
+
+	req, err := http.NewRequest(http.MethodPut, u, io.NewSectionReader(lmfh, 0, size))
+	if err != nil {
+		t.statsT.IncErr(stats.ErrShadowCount)
+		return
+	}
+	req.ContentLength = size
+	// to follow shadow gateway's redirect
+	req.GetBody = func() (io.ReadCloser, error) { return io.NopCloser(io.NewSectionReader(lmfh, 0, size)), nil }
+	resp, err := t.shadow.client.Do(req) //nolint:bodyclose // closed below
+	if err != nil {
+		t.statsT.IncErr(stats.ErrShadowCount)
+		if cmn.Rom.FastV(4, cos.SmoduleAIS) {
+			nlog.Warningln("shadow PUT", u, err)
+		}
+		return
+	}
+	cos.DrainReader(resp.Body)
+	cos.Close(resp.Body)
+	if resp.StatusCode >= http.StatusBadRequest {
+		t.statsT.IncErr(stats.ErrShadowCount)
+	}
+}
@@ -27,6 +27,7 @@ import (
 	"github.com/NVIDIA/aistore/cmn/debug"
 	"github.com/NVIDIA/aistore/cmn/feat"
 	"github.com/NVIDIA/aistore/cmn/fname"
+	"github.com/NVIDIA/aistore/cmn/k8s"
 	"github.com/NVIDIA/aistore/cmn/kvdb"
 	"github.com/NVIDIA/aistore/cmn/mono"
 	"github.com/NVIDIA/aistore/cmn/nlog"
@@ -69,7 +70,11 @@ type (
 		reb          *reb.Reb
 		res          *res.Res
 		transactions transactions
+		olocks       objLocks
+		bconns       bckConns
+		hook         hooker
 		regstate     regstate
+		wbq          wbQueue
 	}
 )
 
@@ -221,6 +226,9 @@ func (t *target) init(config *cmn.Config) {
 	}
 	newVol := volume.Init(t, config, vini)
 	fs.ComputeDiskSize()
+	if config.NUMA.IrqDisks {
+		steerDiskIRQs()
+	}
 
 	t.initHostIP(config)
 	daemon.rg.add(t)
@@ -244,6 +252,17 @@ func (t *target) init(config *cmn.Config) {
 	if err := ts.InitCDF(config); err != nil {
 		cos.ExitLog(err)
 	}
+
+	// capacity-weighted placement (apc.PlacementCapacity): publish total capacity in GiB
+	cs := fs.Cap()
+	t.si.Weight = capWeight(&cs)
+
+	// network topology labels (see meta.Snode.Topo)
+	zone, rack := config.Topology.Zone, config.Topology.Rack
+	if zone == "" && rack == "" {
+		zone, rack = k8s.Zone, k8s.Rack
+	}
+	t.si.Topo = meta.Topo(zone, rack)
 }
 
 func (t *target) initHostIP(config *cmn.Config) {
@@ -328,9 +347,13 @@ func (t *target) Run() error {
 		nlog.Errorln("")
 	}
 
-	// register object type and workfile type
+	// register object, workfile, and trash (soft-deleted objects) content types
 	fs.CSM.Reg(fs.ObjectType, &fs.ObjectContentResolver{})
 	fs.CSM.Reg(fs.WorkfileType, &fs.WorkfileContentResolver{})
+	fs.CSM.Reg(fs.TrashType, &fs.TrashContentResolver{})
+	fs.CSM.Reg(fs.DedupType, &fs.DedupContentResolver{})
+	fs.CSM.Reg(fs.ETLCacheType, &fs.ETLCacheContentResolver{})
+	fs.CSM.Reg(fs.CustomMDType, &fs.CustomMDContentResolver{})
 
 	// Init meta-owners and load local instances
 	if prev := t.owner.bmd.init(); prev {
@@ -338,6 +361,11 @@ func (t *target) Run() error {
 	}
 	t.owner.etl.init()
 
+	// interrupted PUTs (see feat.JournalPUT)
+	if repairs := core.ReplayJournals(); len(repairs) > 0 {
+		nlog.Warningln(t.String(), "write journal: repaired", len(repairs), "interrupted PUT(s)")
+	}
+
 	smap, reliable := t.loadSmap()
 	if !reliable {
 		smap = newSmap()
@@ -384,8 +412,13 @@ func (t *target) Run() error {
 		nlog.Errorln(t.String(), "failed to initialize kvdb:", err)
 		return err
 	}
+	t.olocks.init(db)
 
 	t.transactions.init(t)
+	t.initTrash()
+	t.initWriteBack(db)
+	t.initAbortIncomplete()
+	t.initCapCheck()
 
 	t.reb = reb.New(config)
 	t.res = res.New()
@@ -397,6 +430,8 @@ func (t *target) Run() error {
 	mirror.Init()
 
 	xreg.RegWithHK()
+	xreg.RegResSampler(t.fgBytes)
+	xreg.RegPreempter(t.fgGets)
 
 	marked := xreg.GetResilverMarked()
 	if marked.Interrupted || daemon.resilver.required {
@@ -441,12 +476,29 @@ func (t *target) gojoin(config *cmn.Config) {
 }
 
 func (t *target) goresilver(interrupted bool) {
+	var args res.Args
 	if interrupted {
 		nlog.Infoln("Resuming resilver...")
 	} else if daemon.resilver.required {
 		nlog.Infoln("Starting resilver, reason:", daemon.resilver.reason)
 	}
-	t.runResilver(res.Args{}, nil /*wg*/)
+	// fast path: skip mountpaths that haven't changed since they were last resilvered
+	if !daemon.resilver.required {
+		scope, err := volume.ResilverScope(t.SID())
+		switch {
+		case err != nil:
+			nlog.Warningln(t.String(), "failed to determine resilver scope (resilvering all mountpaths):", err)
+		case len(scope) == 0:
+			nlog.Infoln(t.String(), "all mountpaths are up to date - skipping resilver")
+			if err := fs.RemoveMarker(fname.ResilverMarker); err != nil {
+				nlog.Warningln(t.String(), "failed to remove resilver marker:", err)
+			}
+			return
+		case len(scope) < len(fs.GetAvail()):
+			args.Mpaths = scope
+		}
+	}
+	t.runResilver(args, nil /*wg*/)
 }
 
 func (t *target) runResilver(args res.Args, wg *sync.WaitGroup) {
@@ -463,6 +515,16 @@ func (t *target) runResilver(args res.Args, wg *sync.WaitGroup) {
 	t.res.RunResilver(args)
 }
 
+// foreground (user) traffic, cumulative - see xreg.RegResSampler
+func (t *target) fgBytes() int64 {
+	return t.statsT.Get(stats.GetSize) + t.statsT.Get(stats.PutSize)
+}
+
+// foreground GETs and their total latency, cumulative - see xreg.RegPreempter
+func (t *target) fgGets() (cnt, ns int64) {
+	return t.statsT.Get(stats.GetCount), t.statsT.Get(stats.GetLatencyTotal)
+}
+
 func (t *target) endStartupStandby() (err error) {
 	smap := t.owner.smap.get()
 	if err = smap.validate(); err != nil {
@@ -677,6 +739,8 @@ func (t *target) httpobjget(w http.ResponseWriter, r *http.Request, apireq *apiR
 		}
 	}
 
+	w.Header().Set(apc.HdrNodeID, t.SID()) // (to help clients attribute latencies - see aisloader)
+
 	lom := core.AllocLOM(apireq.items[1])
 	lom, err = t.getObject(w, r, apireq.dpq, apireq.bck, lom)
 	if err != nil {
@@ -695,6 +759,18 @@ func (t *target) getObject(w http.ResponseWriter, r *http.Request, dpq *dpq, bck
 			return lom, err
 		}
 	}
+	cnt, err := t.bconns.inc(lom, r.RemoteAddr)
+	if err != nil {
+		if dpq.isS3 {
+			s3.WriteErr(w, r, err, http.StatusTooManyRequests)
+		} else {
+			t.writeErr(w, r, err, http.StatusTooManyRequests, Silent)
+		}
+		return lom, nil
+	}
+	if cnt != nil {
+		defer cnt.dec(r.RemoteAddr)
+	}
 
 	// two special flows
 	if dpq.etlName != "" {
@@ -723,9 +799,15 @@ func (t *target) getObject(w http.ResponseWriter, r *http.Request, dpq *dpq, bck
 	}
 
 	// GET: regular | archive | range
+	now := time.Now().UnixNano()
+	deadline, err := reqDeadline(r, dpq, now)
+	if err != nil {
+		return lom, err
+	}
 	goi := allocGOI()
 	{
-		goi.atime = time.Now().UnixNano()
+		goi.atime = now
+		goi.deadline = deadline
 		goi.ltime = mono.NanoTime()
 		if dpq.ptime != "" {
 			if d := ptLatency(goi.atime, dpq.ptime, r.Header.Get(apc.HdrCallerIsPrimary)); d > 0 {
@@ -760,6 +842,12 @@ func (t *target) getObject(w http.ResponseWriter, r *http.Request, dpq *dpq, bck
 		originalURL := dpq.origURL
 		goi.ctx = context.WithValue(goi.ctx, cos.CtxOriginalURL, originalURL)
 	}
+	// apc.HdrDeadline: cancel remote GET
+	if goi.deadline != 0 {
+		ctx, cancel := context.WithDeadline(goi.ctx, time.Unix(0, goi.deadline))
+		defer cancel()
+		goi.ctx = ctx
+	}
 
 	// do
 	if ecode, err := goi.getObject(); err != nil {
@@ -816,6 +904,7 @@ func (t *target) httpobjput(w http.ResponseWriter, r *http.Request, apireq *apiR
 		t.writeErrf(w, r, "%s: %s(obj) is expected to be redirected or replicated", t.si, r.Method)
 		return
 	}
+	w.Header().Set(apc.HdrNodeID, t.SID())
 	cs := fs.Cap()
 	if errCap := cs.Err(); errCap != nil || cs.PctMax > int32(config.Space.CleanupWM) {
 		cs = t.oos(config)
@@ -837,6 +926,28 @@ func (t *target) httpobjput(w http.ResponseWriter, r *http.Request, apireq *apiR
 			return
 		}
 	}
+	// published objects are immutable (overwrites are checked by putOI)
+	if (apireq.dpq.arch.path != "" || apireq.dpq.apnd.ty != "") && lom.Bprops().Publish.Enabled {
+		t.writeErr(w, r, cmn.NewErrImmutable(lom.Cname(), "append"), http.StatusConflict)
+		return
+	}
+	fence, err := t.olocks.fence(lom, r.Header)
+	if err != nil {
+		t.writeErr(w, r, err, http.StatusPreconditionFailed)
+		return
+	}
+	if err := t.throttlePut(lom, config); err != nil {
+		t.writeErr(w, r, err, http.StatusTooManyRequests, Silent)
+		return
+	}
+	cnt, errN := t.bconns.inc(lom, r.RemoteAddr)
+	if errN != nil {
+		t.writeErr(w, r, errN, http.StatusTooManyRequests, Silent)
+		return
+	}
+	if cnt != nil {
+		defer cnt.dec(r.RemoteAddr)
+	}
 
 	// load (maybe)
 	skipVC := lom.IsFeatureSet(feat.SkipVC) || apireq.dpq.skipVC
@@ -844,10 +955,15 @@ func (t *target) httpobjput(w http.ResponseWriter, r *http.Request, apireq *apiR
 		_ = lom.Load(true, false)
 	}
 
+	deadline, erd := reqDeadline(r, apireq.dpq, started)
+	if erd != nil {
+		t.writeErr(w, r, erd)
+		return
+	}
+
 	// do
 	var (
 		handle string
-		err    error
 		ecode  int
 	)
 	switch {
@@ -858,6 +974,13 @@ func (t *target) httpobjput(w http.ResponseWriter, r *http.Request, apireq *apiR
 		}
 		// do
 		lom.Lock(true)
+		if fence != 0 {
+			if err = t.olocks.check(lom, fence); err != nil {
+				lom.Unlock(true)
+				ecode = http.StatusPreconditionFailed
+				break
+			}
+		}
 		ecode, err = t.putApndArch(r, lom, started, apireq.dpq)
 		lom.Unlock(true)
 	case apireq.dpq.apnd.ty != "": // apc.QparamAppendType
@@ -868,6 +991,7 @@ func (t *target) httpobjput(w http.ResponseWriter, r *http.Request, apireq *apiR
 			lom:     lom,
 			r:       r.Body,
 			op:      apireq.dpq.apnd.ty, // apc.QparamAppendType
+			fence:   fence,
 		}
 		if err := a.parse(apireq.dpq.apnd.hdl /*apc.QparamAppendHandle*/); err != nil {
 			t.writeErr(w, r, err)
@@ -894,9 +1018,14 @@ func (t *target) httpobjput(w http.ResponseWriter, r *http.Request, apireq *apiR
 			poi.skipVC = skipVC // feat.SkipVC || apc.QparamSkipVC
 			poi.restful = true
 			poi.t2t = t2tput
+			poi.deadline = deadline
+			poi.fence = fence
 		}
 		ecode, err = poi.do(w.Header(), r, apireq.dpq)
 		freePOI(poi)
+		if err == nil && !t2tput && lom.Bprops().Shadow.PUT {
+			t.shadowPut(lom)
+		}
 	}
 	if err != nil {
 		t.FSHC(err, lom.Mountpath(), "") // TODO -- FIXME: removed from the place where happened, fqn missing...
@@ -929,8 +1058,22 @@ func (t *target) httpobjdelete(w http.ResponseWriter, r *http.Request, apireq *a
 		core.FreeLOM(lom)
 		return
 	}
+	fence, err := t.olocks.fence(lom, r.Header)
+	if err != nil {
+		t.writeErr(w, r, err, http.StatusPreconditionFailed)
+		core.FreeLOM(lom)
+		return
+	}
+
+	if !evict && lom.Bprops().Hook.Delete && lom.Bprops().Hook.Enabled() {
+		if ecode, err := t.callHook(lom, http.MethodDelete); err != nil {
+			t.writeErr(w, r, err, ecode)
+			core.FreeLOM(lom)
+			return
+		}
+	}
 
-	ecode, err := t.DeleteObject(lom, evict)
+	ecode, err := t.deleteObject(lom, evict, fence)
 	if err == nil && ecode == 0 {
 		// EC cleanup if EC is enabled
 		ec.ECM.CleanupObject(lom)
@@ -950,12 +1093,17 @@ func (t *target) httpobjpost(w http.ResponseWriter, r *http.Request, apireq *api
 	if err != nil {
 		return
 	}
-	if msg.Action == apc.ActBlobDl {
+	switch msg.Action {
+	case apc.ActBlobDl, apc.ActLockObject, apc.ActRenewObjLock, apc.ActUnlockObject, apc.ActRestoreObject:
 		apireq.after = 1
 	}
 	if t.parseReq(w, r, apireq) != nil {
 		return
 	}
+	if op := apireq.query.Get(apc.QparamTrashPeer); op != "" && msg.Action == apc.ActRestoreObject {
+		t.trashPeer(w, r, apireq.bck, msg, op)
+		return
+	}
 	if isRedirect(apireq.query) == "" {
 		t.writeErrf(w, r, "%s: %s-%s(obj) is expected to be redirected", t.si, r.Method, msg.Action)
 		return
@@ -967,6 +1115,10 @@ func (t *target) httpobjpost(w http.ResponseWriter, r *http.Request, apireq *api
 		if err = lom.InitBck(apireq.bck.Bucket()); err != nil {
 			break
 		}
+		if lom.Bprops().Publish.Enabled {
+			err = cmn.NewErrImmutable(lom.Cname(), "rename")
+			break
+		}
 		if err = t.objMv(lom, msg); err == nil {
 			t.statsT.Inc(stats.RenameCount)
 			core.FreeLOM(lom)
@@ -998,6 +1150,25 @@ func (t *target) httpobjpost(w http.ResponseWriter, r *http.Request, apireq *api
 
 			// lom is eventually freed by x-blob
 		}
+	case apc.ActLockObject, apc.ActRenewObjLock, apc.ActUnlockObject:
+		lom = core.AllocLOM(msg.Name)
+		if err = lom.InitBck(apireq.bck.Bucket()); err != nil {
+			break
+		}
+		t.objLock(w, r, lom, msg)
+		core.FreeLOM(lom)
+		return
+	case apc.ActRestoreObject:
+		lom = core.AllocLOM(msg.Name)
+		if err = lom.InitBck(apireq.bck.Bucket()); err != nil {
+			break
+		}
+		ecode, err := t.restoreObj(lom, msg)
+		if err != nil {
+			t.writeErr(w, r, err, ecode)
+		}
+		core.FreeLOM(lom)
+		return
 	default:
 		t.writeErrAct(w, r, msg.Action)
 		return
@@ -1164,6 +1335,7 @@ func (t *target) objHead(r *http.Request, whdr http.Header, q url.Values, bck *m
 // PATCH /v1/objects/<bucket-name>/<object-name>
 // By default, adds or updates existing custom keys. Will remove all existing keys and
 // replace them with the specified ones _iff_ `apc.QparamNewCustom` is set.
+// With Content-Range header, writes the specified byte range instead (see tgtpatch.go).
 func (t *target) httpobjpatch(w http.ResponseWriter, r *http.Request, apireq *apiRequest) {
 	if err := t.parseReq(w, r, apireq); err != nil {
 		return
@@ -1175,6 +1347,20 @@ func (t *target) httpobjpatch(w http.ResponseWriter, r *http.Request, apireq *ap
 			return
 		}
 	}
+	if r.Header.Get(cos.HdrContentRange) != "" {
+		lom := core.AllocLOM(apireq.items[1] /*objName*/)
+		if t.isValidObjname(w, r, lom.ObjName) {
+			if err := lom.InitBck(apireq.bck.Bucket()); err != nil {
+				t.writeErr(w, r, err)
+			} else if lom.Bprops().Publish.Enabled {
+				t.writeErr(w, r, cmn.NewErrImmutable(lom.Cname(), "write range"), http.StatusConflict)
+			} else if ecode, err := t.writeRange(r, lom, apireq.query); err != nil {
+				t.writeErr(w, r, err, ecode)
+			}
+		}
+		core.FreeLOM(lom)
+		return
+	}
 	msg, err := t.readActionMsg(w, r)
 	if err != nil {
 		return
@@ -1193,23 +1379,61 @@ func (t *target) httpobjpatch(w http.ResponseWriter, r *http.Request, apireq *ap
 		t.writeErr(w, r, err)
 		return
 	}
-	if err := lom.Load(true /*cache it*/, false /*locked*/); err != nil {
+	if ecode, err := t.setCustom(lom, custom, cos.IsParseBool(apireq.query.Get(apc.QparamNewCustom))); err != nil {
+		t.writeErr(w, r, err, ecode)
+	}
+}
+
+// (object PATCH) load, update, and persist custom metadata - all under the same wlock;
+// per-object replication override (api.SetObjectCopies), if changed, gets applied as well
+func (t *target) setCustom(lom *core.LOM, custom cos.StrKVs, delOldSetNew bool) (int, error) {
+	var rmOverride bool
+	if val, ok := custom[cmn.CopiesObjMD]; ok {
+		copies, err := cmn.ParseObjCopies(val)
+		if err == nil && copies > 0 {
+			err = fs.ValidateNCopies(t.si.Name(), copies)
+		}
+		if err != nil {
+			return http.StatusBadRequest, err
+		}
+		if copies == 0 { // removes the override
+			delete(custom, cmn.CopiesObjMD)
+			rmOverride = true
+		}
+	}
+
+	lom.Lock(true)
+	defer lom.Unlock(true)
+	if err := lom.Load(true /*cache it*/, true /*locked*/); err != nil {
 		if cos.IsNotExist(err, 0) {
-			t.writeErr(w, r, err, http.StatusNotFound)
-		} else {
-			t.writeErr(w, r, err)
+			return http.StatusNotFound, err
 		}
-		return
+		return http.StatusInternalServerError, err
 	}
-	delOldSetNew := cos.IsParseBool(apireq.query.Get(apc.QparamNewCustom))
+	prev := lom.CopiesOverride()
 	if delOldSetNew {
 		lom.SetCustomMD(custom)
 	} else {
 		for key, val := range custom {
 			lom.SetCustomKey(key, val)
 		}
+		if rmOverride {
+			lom.ObjAttrs().DelCustomKeys(cmn.CopiesObjMD)
+		}
+	}
+	if err := lom.Persist(); err != nil {
+		return http.StatusInternalServerError, err
+	}
+	if lom.CopiesOverride() == prev {
+		return 0, nil
 	}
-	lom.Persist()
+	buf, slab := t.gmm.Alloc()
+	_, err := mirror.SyncCopies(lom, buf)
+	slab.Free(buf)
+	if err != nil {
+		return http.StatusInternalServerError, err
+	}
+	return 0, nil
 }
 
 // called under lock
@@ -1263,10 +1487,28 @@ func (t *target) putApndArch(r *http.Request, lom *core.LOM, started int64, dpq
 	return a.do()
 }
 
-func (t *target) DeleteObject(lom *core.LOM, evict bool) (code int, err error) {
+func (t *target) DeleteObject(lom *core.LOM, evict bool) (int, error) {
+	return t.deleteObject(lom, evict, 0 /*fence*/)
+}
+
+// fence: apc.HdrObjLockToken (if non-zero), re-validated under wlock
+func (t *target) deleteObject(lom *core.LOM, evict bool, fence int64) (code int, err error) {
 	var isback bool
+	// published objects are immutable - evicting (cached copies of) remote objects is fine
+	if !evict && lom.Bprops().Publish.Enabled {
+		return http.StatusConflict, cmn.NewErrImmutable(lom.Cname(), "delete")
+	}
+	if !evict && lom.Bck().IsRemote() && lom.Bprops().WriteBack.Enabled {
+		return t.delWriteBack(lom, fence) // deferred backend deletion
+	}
 	lom.Lock(true)
-	code, err, isback = t.delobj(lom, evict)
+	if fence != 0 {
+		if err = t.olocks.check(lom, fence); err != nil {
+			lom.Unlock(true)
+			return http.StatusPreconditionFailed, err
+		}
+	}
+	code, err, isback = t.delobj(lom, evict, false /*local only*/)
 	lom.Unlock(true)
 
 	// special corner-case retry (quote):
@@ -1279,8 +1521,24 @@ func (t *target) DeleteObject(lom *core.LOM, evict bool) (code int, err error) {
 			code, err = t.Backend(lom.Bck()).DeleteObj(lom)
 		}
 	}
+	t.delstats(code, err, evict, isback)
+	return code, err
+}
+
+// DeleteLocal deletes in-cluster object that has been already deleted
+// from its remote backend (see core.BatchDeleter)
+func (t *target) DeleteLocal(lom *core.LOM) (code int, err error) {
+	lom.Lock(true)
+	code, err, _ = t.delobj(lom, false /*evict*/, true /*local only*/)
+	lom.Unlock(true)
+	if err != nil && cos.IsNotExist(err, code) {
+		code, err = 0, nil // (no local copy)
+	}
+	t.delstats(code, err, false /*evict*/, false /*isback*/)
+	return code, err
+}
 
-	// stats
+func (t *target) delstats(code int, err error, evict, isback bool) {
 	switch {
 	case err == nil:
 		t.statsT.Inc(stats.DeleteCount)
@@ -1296,16 +1554,15 @@ func (t *target) DeleteObject(lom *core.LOM, evict bool) (code int, err error) {
 			t.statsT.IncErr(stats.IOErrDeleteCount)
 		}
 	}
-	return code, err
 }
 
-func (t *target) delobj(lom *core.LOM, evict bool) (int, error, bool) {
+func (t *target) delobj(lom *core.LOM, evict, localOnly bool) (int, error, bool) {
 	var (
 		aisErr, backendErr         error
 		aisErrCode, backendErrCode int
 		delFromAIS, delFromBackend bool
 	)
-	delFromBackend = lom.Bck().IsRemote() && !evict
+	delFromBackend = lom.Bck().IsRemote() && !evict && !localOnly
 	err := lom.Load(false /*cache it*/, true /*locked*/)
 	if err != nil {
 		if !cos.IsNotExist(err, 0) {
@@ -1324,7 +1581,11 @@ func (t *target) delobj(lom *core.LOM, evict bool) (int, error, bool) {
 	}
 	if delFromAIS {
 		size := lom.Lsize()
-		aisErr = lom.RemoveObj()
+		if !evict && lom.Bprops().Trash.Enabled {
+			aisErr = t.trashObj(lom)
+		} else {
+			aisErr = lom.RemoveObj()
+		}
 		if aisErr != nil {
 			if !os.IsNotExist(aisErr) {
 				if backendErr != nil {
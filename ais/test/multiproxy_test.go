@@ -82,7 +82,10 @@ func TestMultiProxy(t *testing.T) {
 
 	defer tools.EnsureOrigClusterState(t)
 	for _, test := range voteTests {
-		t.Run(test.name, test.method)
+		t.Run(test.name, func(t *testing.T) {
+			tools.CheckQuarantine(t)
+			test.method(t)
+		})
 		if t.Failed() {
 			t.FailNow()
 		}
@@ -1007,10 +1010,10 @@ func setPrimaryTo(t *testing.T, proxyURL string, smap *meta.Smap, directURL, toI
 
 	baseParams := tools.BaseAPIParams(directURL)
 	tlog.Logf("Setting primary from %s to %s\n", smap.Primary.ID(), toID)
-	err := api.SetPrimaryProxy(baseParams, toID, false /*force*/)
-	tassert.CheckFatal(t, err)
+	// (the designated proxy may be still joining or restarting)
+	tools.RetryTransient(t, func() error { return api.SetPrimaryProxy(baseParams, toID, false /*force*/) })
 
-	newSmap, err = tools.WaitForNewSmap(proxyURL, smap.Version)
+	newSmap, err := tools.WaitForNewSmap(proxyURL, smap.Version)
 	tassert.CheckFatal(t, err)
 	if newSmap.Primary.ID() != toID {
 		t.Fatalf("Expected primary=%s, got %s", toID, newSmap.Primary.ID())
@@ -1628,6 +1628,46 @@ func TestPutObjectWithChecksum(t *testing.T) {
 	}
 }
 
+func TestPutObjectRetry(t *testing.T) {
+	var (
+		proxyURL   = tools.RandomProxyURL(t)
+		baseParams = tools.BaseAPIParams(proxyURL)
+		bck        = cmn.Bck{Name: trand.String(10), Provider: apc.AIS}
+		objData    = []byte(trand.String(64 * cos.KiB))
+		size       = int64(len(objData))
+		sent       int64
+	)
+	tools.CreateBucket(t, proxyURL, bck, nil, true /*cleanup*/)
+
+	sources := []api.PutRetryArgs{
+		{ReaderAt: bytes.NewReader(objData), Size: size},
+		{ReadSeeker: bytes.NewReader(objData)},
+		{Open: func() (io.ReadCloser, error) { return io.NopCloser(bytes.NewReader(objData)), nil }, Size: size},
+	}
+	for i := range sources {
+		args := &sources[i]
+		args.BaseParams = baseParams
+		args.Bck = bck
+		args.ObjName = "obj-" + strconv.Itoa(i)
+		args.Cksum = cos.NewCksum(cos.ChecksumXXHash, "") // computed prior to the first attempt
+		args.Progress = func(n, _ int64) { sent = n }
+
+		sent = 0
+		_, err := api.PutObjectRetry(args)
+		tassert.CheckFatal(t, err)
+		tassert.Errorf(t, sent == size, "%s: progress %d != %d size", args.ObjName, sent, size)
+
+		w := bytes.NewBuffer(nil)
+		_, err = api.GetObject(baseParams, bck, args.ObjName, &api.GetArgs{Writer: w})
+		tassert.CheckFatal(t, err)
+		tassert.Errorf(t, bytes.Equal(w.Bytes(), objData), "%s: content mismatch", args.ObjName)
+	}
+
+	// invalid: no source
+	_, err := api.PutObjectRetry(&api.PutRetryArgs{BaseParams: baseParams, Bck: bck, ObjName: "none"})
+	tassert.Errorf(t, err != nil, "expecting error (no source)")
+}
+
 func TestOperationsWithRanges(t *testing.T) {
 	const (
 		objCnt  = 50 // NOTE: must by a multiple of 10
@@ -0,0 +1,88 @@
+// Package ais provides core functionality for the AIStore object storage.
+/*
+ * Copyright (c) 2024, NVIDIA CORPORATION. All rights reserved.
+ */
+package ais
+
+import (
+	"time"
+
+	"github.com/NVIDIA/aistore/ais/backend"
+	"github.com/NVIDIA/aistore/ais/s3"
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/cmn/cos"
+	"github.com/NVIDIA/aistore/cmn/nlog"
+	"github.com/NVIDIA/aistore/core"
+	"github.com/NVIDIA/aistore/core/meta"
+	"github.com/NVIDIA/aistore/hk"
+	"github.com/NVIDIA/aistore/xact/xreg"
+)
+
+// Abort incomplete (see cmn.SpaceConf.AbortIncomplete):
+// - multipart uploads (S3 API) initiated more than `space.abort_incomplete` ago get aborted;
+// - workfiles of multipart uploads and (native API) appends that haven't been written to
+//   for as long get removed - unless owned by an upload in progress or locked (flush);
+//   all other workfiles (PUT, rebalance, EC, etc.) are left to their respective owners;
+// - runs periodically (below) and on demand (apc.ActAbortIncomplete), in which case
+//   `force` aborts all incomplete uploads and appends regardless of their age
+//   (except those initiated or written to within the last minute).
+
+const abortIncompleteIval = time.Hour
+
+func (t *target) initAbortIncomplete() {
+	hk.Reg("abort-incomplete"+hk.NameSuffix, t.abortIncomplete, abortIncompleteIval)
+}
+
+func (t *target) abortIncomplete(int64) time.Duration {
+	if !t.ClusterStarted() {
+		return abortIncompleteIval
+	}
+	if err := t.runAbortIncomplete(cos.GenUUID(), nil, false); err != nil {
+		nlog.Warningln(t.String(), "abort-incomplete:", err)
+	}
+	return abortIncompleteIval
+}
+
+func (t *target) runAbortIncomplete(uuid string, bck *meta.Bck, force bool) error {
+	age := time.Minute
+	if !force {
+		age = cmn.GCO.Get().Space.AbortIncomplete.D()
+	}
+	cutoff := time.Now().UnixNano() - int64(age)
+	args := &xreg.AbortIncompleteArgs{
+		Abort:  t.abortExpiredMpt,
+		Active: s3.IsActive,
+		Stats:  t.statsT,
+		Cutoff: cutoff,
+	}
+	rns := xreg.RenewAbortIncomplete(uuid, bck, args)
+	return rns.Err
+}
+
+// (is called by the xaction)
+func (t *target) abortExpiredMpt(bck *meta.Bck, cutoff int64) (n, size int64) {
+	var bckName string
+	if bck != nil && !bck.IsQuery() {
+		bckName = bck.Name
+	}
+	aborted, size := s3.AbortExpired(bckName, cutoff)
+	for _, upload := range aborted {
+		nlog.Infoln(t.String(), "aborted incomplete upload", upload.ID, "[", upload.BckName, upload.ObjName, "]")
+		t._abortRemoteMpt(upload)
+	}
+	return int64(len(aborted)), size
+}
+
+func (t *target) _abortRemoteMpt(upload *s3.ExpiredUpload) {
+	bck, err, _ := meta.InitByNameOnly(upload.BckName, t.owner.bmd)
+	if err != nil || !bck.IsRemoteS3() {
+		return
+	}
+	lom := core.AllocLOM(upload.ObjName)
+	if err := lom.InitBck(bck.Bucket()); err == nil {
+		if _, err := backend.AbortMpt(lom, nil, nil, upload.ID); err != nil {
+			nlog.Warningln(t.String(), "failed to abort remote upload", upload.ID, "[", lom.Cname(), err, "]")
+		}
+	}
+	core.FreeLOM(lom)
+}
@@ -102,7 +102,13 @@ func (t *target) httpbckget(w http.ResponseWriter, r *http.Request, dpq *dpq) {
 			t.writeErrf(w, r, "list-objects: invalid UUID %q", lsmsg.UUID)
 			return
 		}
-		if ok := t.listObjects(w, r, bck, lsmsg); !ok {
+		var ok bool
+		if lsmsg.Sort != nil {
+			ok = t.listSorted(w, r, bck, lsmsg)
+		} else {
+			ok = t.listObjects(w, r, bck, lsmsg)
+		}
+		if !ok {
 			t.statsT.IncErr(stats.ErrListCount)
 			return
 		}
@@ -111,6 +117,70 @@ func (t *target) httpbckget(w http.ResponseWriter, r *http.Request, dpq *dpq) {
 			cos.NamedVal64{Name: stats.ListCount, Value: 1},
 			cos.NamedVal64{Name: stats.ListLatency, Value: delta},
 		)
+	case apc.ActListTrash:
+		if len(apiItems) == 0 {
+			t.writeErrURL(w, r)
+			return
+		}
+		qbck, err := newQbckFromQ(apiItems[0], nil, dpq)
+		if err != nil {
+			t.writeErr(w, r, err)
+			return
+		}
+		bck := meta.CloneBck((*cmn.Bck)(qbck))
+		if err := bck.Init(t.owner.bmd); err != nil {
+			t.writeErr(w, r, err)
+			return
+		}
+		entries, err := t.listTrash(bck, msg.Name)
+		if err != nil {
+			t.writeErr(w, r, err)
+			return
+		}
+		t.writeJSON(w, r, entries, msg.Action)
+	case apc.ActDeleteQueue:
+		if len(apiItems) == 0 {
+			t.writeErrURL(w, r)
+			return
+		}
+		qbck, err := newQbckFromQ(apiItems[0], nil, dpq)
+		if err != nil {
+			t.writeErr(w, r, err)
+			return
+		}
+		bck := meta.CloneBck((*cmn.Bck)(qbck))
+		if err := bck.Init(t.owner.bmd); err != nil {
+			t.writeErr(w, r, err)
+			return
+		}
+		t.deleteQueue(w, r, bck, &msg.ActMsg)
+	case apc.ActStatObjects:
+		if len(apiItems) == 0 {
+			t.writeErrURL(w, r)
+			return
+		}
+		qbck, err := newQbckFromQ(apiItems[0], nil, dpq)
+		if err != nil {
+			t.writeErr(w, r, err)
+			return
+		}
+		bck := meta.CloneBck((*cmn.Bck)(qbck))
+		if err := bck.Init(t.owner.bmd); err != nil {
+			if cmn.IsErrRemoteBckNotFound(err) {
+				t.BMDVersionFixup(r)
+				err = bck.Init(t.owner.bmd)
+			}
+			if err != nil {
+				t.writeErr(w, r, err)
+				return
+			}
+		}
+		smsg := &apc.StatObjsMsg{}
+		if err := cos.MorphMarshal(msg.Value, smsg); err != nil {
+			t.writeErrf(w, r, cmn.FmtErrMorphUnmarshal, t.si, msg.Action, msg.Value, err)
+			return
+		}
+		t.statObjects(w, r, bck, smsg)
 	case apc.ActSummaryBck:
 		var bucket, phase string // txn
 		if len(apiItems) == 0 {
@@ -296,6 +366,20 @@ func (t *target) listObjects(w http.ResponseWriter, r *http.Request, bck *meta.B
 	return t.writeMsgPack(w, resp.Lst, "list_objects")
 }
 
+// sorted (top-N) list-objects: one page, no xaction (see apc.LsoSort)
+func (t *target) listSorted(w http.ResponseWriter, r *http.Request, bck *meta.Bck, lsmsg *apc.LsoMsg) bool {
+	lst, err := xs.ListSorted(bck, lsmsg)
+	if err != nil {
+		t.writeErr(w, r, err)
+		return false
+	}
+	marked := xreg.GetRebMarked()
+	if marked.Xact != nil || marked.Interrupted || reb.IsGFN() {
+		lst.Flags = 1
+	}
+	return t.writeMsgPack(w, lst, "list_objects")
+}
+
 func (t *target) bsumm(w http.ResponseWriter, r *http.Request, phase string, bck *meta.Bck, msg *apc.BsummCtrlMsg, dpq *dpq) {
 	if phase == apc.ActBegin {
 		rns := xreg.RenewBckSummary(bck, msg)
@@ -344,8 +428,8 @@ func (t *target) bsumm(w http.ResponseWriter, r *http.Request, phase string, bck
 // DELETE { action } /v1/buckets/bucket-name
 // (evict | delete) (list | range)
 func (t *target) httpbckdelete(w http.ResponseWriter, r *http.Request, apireq *apiRequest) {
-	msg := aisMsg{}
-	if err := readJSON(w, r, &msg); err != nil {
+	msg, err := t.readAisMsg(w, r) // (JSON or msgpack - see proxy listrange)
+	if err != nil {
 		return
 	}
 	if err := t.parseReq(w, r, apireq); err != nil {
@@ -418,7 +502,22 @@ func (t *target) httpbckpost(w http.ResponseWriter, r *http.Request, apireq *api
 	if err != nil {
 		return
 	}
-	if msg.Action != apc.ActPrefetchObjects {
+	switch msg.Action {
+	case apc.ActPrefetchObjects:
+	case apc.ActHandoverDeletes:
+		t.recvHandover(w, r, &msg.ActMsg)
+		return
+	case apc.ActFlushDeletes, apc.ActCancelDeletes:
+		if err := t.parseReq(w, r, apireq); err != nil {
+			return
+		}
+		if err := apireq.bck.Init(t.owner.bmd); err != nil {
+			t.writeErr(w, r, err)
+			return
+		}
+		t.deleteQueue(w, r, apireq.bck, &msg.ActMsg)
+		return
+	default:
 		t.writeErrAct(w, r, msg.Action)
 		return
 	}
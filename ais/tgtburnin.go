@@ -0,0 +1,99 @@
+// Package ais provides core functionality for the AIStore object storage.
+/*
+ * Copyright (c) 2024, NVIDIA CORPORATION. All rights reserved.
+ */
+package ais
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+
+	"github.com/NVIDIA/aistore/api/apc"
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/cmn/cos"
+	"github.com/NVIDIA/aistore/cmn/fname"
+	"github.com/NVIDIA/aistore/cmn/jsp"
+	"github.com/NVIDIA/aistore/cmn/nlog"
+	"github.com/NVIDIA/aistore/xact/xreg"
+	"github.com/NVIDIA/aistore/xact/xs"
+)
+
+// Burn-in (self-test) of a new target - typically, started with `-standby`:
+// 1. admin starts burn-in (apc.ActBurnIn) directly via the target's own endpoint;
+// 2. the last report gets persisted in the target's config dir (fname.BurnIn);
+// 3. admin-join (standby => join) is refused while burn-in is running or when the last one failed;
+//    (never having run burn-in is not an error)
+
+func (t *target) startBurnIn(w http.ResponseWriter, r *http.Request, msg *apc.ActMsg) {
+	var bmsg apc.BurnInMsg
+	if err := cos.MorphMarshal(msg.Value, &bmsg); err != nil {
+		t.writeErr(w, r, err)
+		return
+	}
+	if err := bmsg.Validate(); err != nil {
+		t.writeErr(w, r, err)
+		return
+	}
+	if err := xreg.LimitedCoexistence(t.si, nil, apc.ActBurnIn); err != nil {
+		t.writeErr(w, r, err, http.StatusConflict)
+		return
+	}
+	rns := xreg.RenewBurnIn(cos.GenUUID(), &xreg.BurnInArgs{Msg: &bmsg, Done: t.burnInDone})
+	if rns.Err != nil {
+		if cmn.IsErrXactUsePrev(rns.Err) {
+			t.writeErr(w, r, rns.Err, http.StatusConflict)
+		} else {
+			t.writeErr(w, r, rns.Err)
+		}
+		return
+	}
+	// (new report => stale one no longer applies)
+	if err := cos.RemoveFile(burnInPath()); err != nil {
+		nlog.Warningln(t.String(), err)
+	}
+	writeXid(w, rns.Entry.Get().ID())
+}
+
+func (t *target) burnInDone(rep *apc.BurnInReport) {
+	if err := jsp.Save(burnInPath(), rep, jsp.Plain(), nil); err != nil {
+		nlog.Errorln(t.String(), "failed to save burn-in report:", err)
+	}
+}
+
+// current (running or finished) burn-in, if any; otherwise, the last persisted report
+func (*target) burnInReport() (*apc.BurnInReport, error) {
+	if entry := xreg.GetLatest(xreg.Flt{Kind: apc.ActBurnIn}); entry != nil {
+		if xctn, ok := entry.Get().(*xs.XactBurnIn); ok {
+			return xctn.Report(), nil
+		}
+	}
+	rep := &apc.BurnInReport{}
+	if _, err := jsp.Load(burnInPath(), rep, jsp.Plain()); err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return rep, nil
+}
+
+// gate admin-join
+func (t *target) burnInCheck() error {
+	rep, err := t.burnInReport()
+	switch {
+	case err != nil:
+		return fmt.Errorf("%s: failed to load burn-in report: %w", t, err)
+	case rep == nil:
+		return nil
+	case rep.Running:
+		return cmn.NewErrBusy("node", t.String(), "burn-in "+rep.Xid+" is running")
+	case !rep.Passed:
+		return errors.New(t.String() + ": burn-in " + rep.Xid + " failed: " + rep.Reason)
+	}
+	return nil
+}
+
+func burnInPath() string { return filepath.Join(cmn.GCO.Get().ConfigDir, fname.BurnIn) }
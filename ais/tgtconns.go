@@ -0,0 +1,64 @@
+// Package ais provides core functionality for the AIStore object storage.
+/*
+ * Copyright (c) 2024, NVIDIA CORPORATION. All rights reserved.
+ */
+package ais
+
+import (
+	"strconv"
+	"sync"
+
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/core"
+)
+
+// Per-bucket limit on the number of concurrent client connections - bucket prop `max_conns`.
+// - a connection counts against the limit while it has GET and/or PUT requests to the bucket in flight;
+// - connections are identified by their remote (ip:port) address, so that pipelined and
+//   multiplexed (HTTP/2) requests on a given connection count only once;
+// - the limit is enforced by each target independently;
+// - requests that'd open a connection in excess of the limit fail with 429 (too many requests)
+//   and can be retried;
+// - zero (default) means unlimited and costs nothing.
+
+type (
+	bckConns struct {
+		m sync.Map // bucket ID => *bconns
+	}
+	bconns struct {
+		conns map[string]int // remote address => num requests in flight
+		mu    sync.Mutex
+	}
+)
+
+// returns nil when the bucket is not limited; otherwise, caller must call `dec` with the same `remAddr`
+func (bc *bckConns) inc(lom *core.LOM, remAddr string) (*bconns, error) {
+	bprops := lom.Bprops()
+	if bprops.MaxConns <= 0 {
+		return nil, nil
+	}
+	v, ok := bc.m.Load(bprops.BID)
+	if !ok {
+		v, _ = bc.m.LoadOrStore(bprops.BID, &bconns{conns: make(map[string]int, 16)})
+	}
+	c := v.(*bconns)
+	c.mu.Lock()
+	n, ok := c.conns[remAddr]
+	if !ok && len(c.conns) >= bprops.MaxConns {
+		c.mu.Unlock()
+		return nil, cmn.NewErrBusy("bucket", lom.Bck().Cname(""), "max_conns "+strconv.Itoa(bprops.MaxConns))
+	}
+	c.conns[remAddr] = n + 1
+	c.mu.Unlock()
+	return c, nil
+}
+
+func (c *bconns) dec(remAddr string) {
+	c.mu.Lock()
+	if n := c.conns[remAddr]; n > 1 {
+		c.conns[remAddr] = n - 1
+	} else {
+		delete(c.conns, remAddr)
+	}
+	c.mu.Unlock()
+}
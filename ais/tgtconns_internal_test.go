@@ -0,0 +1,57 @@
+// Package ais provides core functionality for the AIStore object storage.
+/*
+ * Copyright (c) 2024, NVIDIA CORPORATION. All rights reserved.
+ */
+package ais
+
+import (
+	"testing"
+
+	"github.com/NVIDIA/aistore/api/apc"
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/core"
+	"github.com/NVIDIA/aistore/tools/tassert"
+)
+
+func TestBckConns(t *testing.T) {
+	lom := core.AllocLOM("obj")
+	defer core.FreeLOM(lom)
+	tassert.CheckFatal(t, lom.InitBck(&cmn.Bck{Name: testBucket, Provider: apc.AIS, Ns: cmn.NsGlobal}))
+
+	var bc bckConns
+
+	// unlimited
+	c, err := bc.inc(lom, "10.0.0.1:1000")
+	tassert.Errorf(t, c == nil && err == nil, "expected no limit, got (%v, %v)", c, err)
+
+	bprops := lom.Bprops()
+	bprops.MaxConns = 2
+	defer func() { bprops.MaxConns = 0 }()
+
+	c1, err := bc.inc(lom, "10.0.0.1:1000")
+	tassert.CheckFatal(t, err)
+	// same connection: counts once
+	c2, err := bc.inc(lom, "10.0.0.1:1000")
+	tassert.CheckFatal(t, err)
+	c3, err := bc.inc(lom, "10.0.0.2:2000")
+	tassert.CheckFatal(t, err)
+
+	// third connection exceeds the limit
+	_, err = bc.inc(lom, "10.0.0.3:3000")
+	_, ok := err.(*cmn.ErrBusy)
+	tassert.Errorf(t, ok, "expected busy, got %v", err)
+
+	// still in flight on the first connection
+	c1.dec("10.0.0.1:1000")
+	_, err = bc.inc(lom, "10.0.0.3:3000")
+	tassert.Errorf(t, err != nil, "expected busy")
+
+	// first connection's done
+	c2.dec("10.0.0.1:1000")
+	c4, err := bc.inc(lom, "10.0.0.3:3000")
+	tassert.CheckFatal(t, err)
+
+	c3.dec("10.0.0.2:2000")
+	c4.dec("10.0.0.3:3000")
+	tassert.Errorf(t, len(c4.conns) == 0, "expected no connections, got %v", c4.conns)
+}
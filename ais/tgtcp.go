@@ -223,6 +223,8 @@ func (t *target) daeputMsg(w http.ResponseWriter, r *http.Request) {
 			return
 		}
 		t.cleanupMark(&ctx)
+	case apc.ActBurnIn:
+		t.startBurnIn(w, r, msg)
 	default:
 		t.writeErrAct(w, r, msg.Action)
 	}
@@ -378,19 +380,32 @@ func (t *target) httpdaeget(w http.ResponseWriter, r *http.Request) {
 		httpdaeWhat = "httpdaeget-" + what
 	)
 	switch what {
-	case apc.WhatNodeConfig, apc.WhatSmap, apc.WhatBMD, apc.WhatSmapVote,
+	case apc.WhatNodeConfig, apc.WhatConfigSchema, apc.WhatSmap, apc.WhatBMD, apc.WhatSmapVote,
 		apc.WhatSnode, apc.WhatLog, apc.WhatMetricNames:
 		t.htrun.httpdaeget(w, r, query, t /*htext*/)
 	case apc.WhatSysInfo:
 		tsysinfo := apc.TSysInfo{MemCPUInfo: apc.GetMemCPU(), CapacityInfo: fs.CapStatusGetWhat()}
+		if tsysinfo.Numa != nil {
+			avail := fs.GetAvail()
+			tsysinfo.MpathNuma = make(map[string]int, len(avail))
+			for mpath, mi := range avail {
+				tsysinfo.MpathNuma[mpath] = mi.NumaNode
+			}
+		}
 		t.writeJSON(w, r, tsysinfo, httpdaeWhat)
 	case apc.WhatNodeStats:
+		if t.statsHistory(w, r, query) {
+			return
+		}
 		ds := t.statsAndStatus()
 		daeStats := t.statsT.GetStats()
 		ds.Tracker = daeStats.Tracker
 		ds.Tcdf = daeStats.Tcdf
 		t.writeJSON(w, r, ds, httpdaeWhat)
 	case apc.WhatNodeStatsV322: // [backward compatibility] v3.22 and prior
+		if t.statsHistory(w, r, query) {
+			return
+		}
 		ds := t.statsAndStatusV322()
 		daeStats := t.statsT.GetStatsV322()
 		ds.Tracker = daeStats.Tracker
@@ -456,6 +471,32 @@ func (t *target) httpdaeget(w http.ResponseWriter, r *http.Request) {
 		debug.Assert(ok)
 
 		t.writeJSON(w, r, aisbp.GetInfo(aisConf), httpdaeWhat)
+	case apc.WhatFederation:
+		var aisConf cmn.BackendConfAIS
+		if anyConf := cmn.GCO.Get().Backend.Get(apc.AIS); anyConf != nil {
+			aisConf = anyConf.(cmn.BackendConfAIS)
+		}
+		t.writeJSON(w, r, t.aisbp().Federation(aisConf), httpdaeWhat)
+	case apc.WhatTop:
+		n, err := topN(query)
+		if err != nil {
+			t.writeErr(w, r, err)
+			return
+		}
+		t.writeJSON(w, r, t.statsT.GetTop(n), httpdaeWhat)
+	case apc.WhatCapacity:
+		t.writeJSON(w, r, t.statsT.GetCapHistory(), httpdaeWhat)
+	case apc.WhatBurnIn:
+		rep, err := t.burnInReport()
+		if err != nil {
+			t.writeErr(w, r, err)
+			return
+		}
+		if rep == nil {
+			t.writeErr(w, r, cos.NewErrNotFound(t, "burn-in report"), http.StatusNotFound, Silent)
+			return
+		}
+		t.writeJSON(w, r, rep, httpdaeWhat)
 	default:
 		t.htrun.httpdaeget(w, r, query, t /*htext*/)
 	}
@@ -501,6 +542,10 @@ func (t *target) httpdaepost(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 	if daemon.cli.target.standby {
+		if err := t.burnInCheck(); err != nil {
+			t.writeErr(w, r, err, http.StatusConflict)
+			return
+		}
 		nlog.Infof("%s: transitioning standby => join", t)
 	}
 	t.keepalive.ctrl(kaResumeMsg)
@@ -563,6 +608,10 @@ func (t *target) handleMountpathReq(w http.ResponseWriter, r *http.Request) {
 	if err != nil {
 		return
 	}
+	if msg.Action == apc.ActMountpathReplace {
+		t.replaceMpath(w, r, msg)
+		return
+	}
 	mpath, ok := msg.Value.(string)
 	if !ok {
 		t.writeErrMsg(w, r, "invalid mountpath value in request")
@@ -592,6 +641,31 @@ func (t *target) handleMountpathReq(w http.ResponseWriter, r *http.Request) {
 	fs.ComputeDiskSize()
 }
 
+func (t *target) replaceMpath(w http.ResponseWriter, r *http.Request, msg *apc.ActMsg) {
+	var rmsg apc.MpathReplaceMsg
+	if err := cos.MorphMarshal(msg.Value, &rmsg); err != nil {
+		t.writeErr(w, r, err)
+		return
+	}
+	if err := rmsg.Validate(); err != nil {
+		t.writeErr(w, r, err)
+		return
+	}
+	xctn, err := t.fsprg.replaceMpath(&rmsg)
+	if err != nil {
+		switch {
+		case cmn.IsErrMpathNotFound(err):
+			t.writeErr(w, r, err, http.StatusNotFound)
+		case cmn.IsErrXactUsePrev(err):
+			t.writeErr(w, r, err, http.StatusConflict)
+		default:
+			t.writeErr(w, r, err)
+		}
+		return
+	}
+	writeXid(w, xctn.ID())
+}
+
 func (t *target) enableMpath(w http.ResponseWriter, r *http.Request, mpath string) {
 	enabledMi, err := t.fsprg.enableMpath(mpath)
 	if err != nil {
@@ -662,6 +736,7 @@ func (t *target) disableMpath(w http.ResponseWriter, r *http.Request, mpath stri
 
 func (t *target) rescanMpath(w http.ResponseWriter, r *http.Request, mpath string) {
 	dontResilver := cos.IsParseBool(r.URL.Query().Get(apc.QparamDontResilver))
+	t.recheckCap(true /*force*/) // in addition, re-detect (and re-announce) capacity
 	err := t.fsprg.rescanMpath(mpath, dontResilver)
 	if err == nil {
 		return
@@ -748,7 +823,7 @@ func (t *target) applyBMD(newBMD *bucketMD, msg *aisMsg, payload msPayload, tag
 	}
 
 	t.owner.bmd.Lock()
-	rmbcks, oldVer, emsg, err := t._syncBMD(newBMD, msg, payload, psi)
+	rmbcks, rmdirs, oldVer, emsg, err := t._syncBMD(newBMD, msg, payload, psi)
 	t.owner.bmd.Unlock()
 
 	if err != nil {
@@ -756,6 +831,7 @@ func (t *target) applyBMD(newBMD *bucketMD, msg *aisMsg, payload msPayload, tag
 	} else if oldVer < newBMD.Version {
 		t.regstate.prevbmd.Store(false)
 		t._postBMD(newBMD, tag, rmbcks)
+		t.reclaim(msg, rmbcks, rmdirs)
 	}
 	if emsg != "" {
 		nlog.Errorln(emsg)
@@ -765,7 +841,7 @@ func (t *target) applyBMD(newBMD *bucketMD, msg *aisMsg, payload msPayload, tag
 
 // executes under lock
 func (t *target) _syncBMD(newBMD *bucketMD, msg *aisMsg, payload msPayload, psi *meta.Snode) (rmbcks []*meta.Bck,
-	oldVer int64, emsg string, err error) {
+	rmdirs [][]string, oldVer int64, emsg string, err error) {
 	var (
 		createErrs  []error
 		destroyErrs []error
@@ -808,14 +884,17 @@ func (t *target) _syncBMD(newBMD *bucketMD, msg *aisMsg, payload msPayload, psi
 	}
 
 	// 3. delete, ignore errors
+	// (move to 'deleted' and remove in the background - see reclaim below)
 	bmd.Range(nil, nil, func(obck *meta.Bck) bool {
 		f := &delb{obck: obck}
 		newBMD.Range(nil, nil, f.do)
 		if !f.present {
-			rmbcks = append(rmbcks, obck)
-			if errD := fs.DestroyBucket("recv-bmd-"+msg.Action, obck.Bucket(), obck.Props.BID); errD != nil {
+			dirs, errD := fs.MoveBucketToDeleted("recv-bmd-"+msg.Action, obck.Bucket(), obck.Props.BID)
+			if errD != nil {
 				destroyErrs = append(destroyErrs, errD)
 			}
+			rmbcks = append(rmbcks, obck)
+			rmdirs = append(rmdirs, dirs)
 		}
 		return false
 	})
@@ -868,6 +947,24 @@ func (t *target) _postBMD(newBMD *bucketMD, tag string, rmbcks []*meta.Bck) {
 	}
 }
 
+// remove destroyed buckets' data (apc.ActReclaimBck)
+func (t *target) reclaim(msg *aisMsg, rmbcks []*meta.Bck, rmdirs [][]string) {
+	for i, bck := range rmbcks {
+		if len(rmdirs[i]) == 0 {
+			continue
+		}
+		xid := msg.UUID // (destroy-bucket txn)
+		if xid == "" || len(rmbcks) > 1 {
+			xid = cos.GenUUID()
+		}
+		rns := xreg.RenewReclaim(xid, bck, &xreg.ReclaimArgs{Dirs: rmdirs[i]})
+		if rns.Err != nil {
+			nlog.Errorln(t.String(), "failed to start", apc.ActReclaimBck, bck.Cname(""), "err:", rns.Err,
+				"(the data will be removed by space cleanup)")
+		}
+	}
+}
+
 // is called under lock
 func (t *target) receiveRMD(newRMD *rebMD, msg *aisMsg) (err error) {
 	rmd := t.owner.rmd.get()
@@ -1097,7 +1194,7 @@ func (t *target) metasyncPut(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 	payload := make(msPayload)
-	if errP := payload.unmarshal(r.Body, "metasync put"); errP != nil {
+	if errP := payload.unmarshalReq(r, "metasync put"); errP != nil {
 		cmn.WriteErr(w, r, errP)
 		return
 	}
@@ -1203,7 +1300,7 @@ func (t *target) attachDetachRemAis(newConfig *globalConfig, msg *aisMsg) (err e
 // POST /v1/metasync
 func (t *target) metasyncPost(w http.ResponseWriter, r *http.Request) {
 	payload := make(msPayload)
-	if err := payload.unmarshal(r.Body, "metasync post"); err != nil {
+	if err := payload.unmarshalReq(r, "metasync post"); err != nil {
 		cmn.WriteErr(w, r, err)
 		return
 	}
@@ -8,6 +8,8 @@ import (
 	"fmt"
 	"net/http"
 	"net/url"
+	"strconv"
+	"time"
 
 	"github.com/NVIDIA/aistore/api/apc"
 	"github.com/NVIDIA/aistore/cmn"
@@ -19,8 +21,12 @@ import (
 	"github.com/NVIDIA/aistore/core/meta"
 	"github.com/NVIDIA/aistore/ext/etl"
 	"github.com/NVIDIA/aistore/fs"
+	"github.com/NVIDIA/aistore/xact/xs"
 )
 
+// default timeout to transform cold-GET object (see cmn.BckETLConf)
+const dfltColdETLTimeout = 5 * time.Minute
+
 // [METHOD] /v1/etl
 func (t *target) etlHandler(w http.ResponseWriter, r *http.Request) {
 	if !k8s.IsK8s() {
@@ -105,7 +111,7 @@ func (t *target) handleETLGet(w http.ResponseWriter, r *http.Request) {
 
 	// /v1/etl/<etl-name>
 	if len(apiItems) == 1 {
-		t.writeErr(w, r, fmt.Errorf("GET(ETL[%s] info) not implemented yet", apiItems[0]), http.StatusNotImplemented)
+		t.statusETL(w, r, apiItems[0])
 		return
 	}
 
@@ -118,6 +124,8 @@ func (t *target) handleETLGet(w http.ResponseWriter, r *http.Request) {
 	case apc.ETLMetrics:
 		k8s.InitMetricsClient()
 		t.metricsETL(w, r, apiItems[0])
+	case apc.ETLEstimate:
+		t.estimateETL(w, r, apiItems[0], apiItems[2:])
 	default:
 		t.writeErrURL(w, r)
 	}
@@ -126,14 +134,19 @@ func (t *target) handleETLGet(w http.ResponseWriter, r *http.Request) {
 // POST /v1/etl/<etl-name>/stop (or) TODO: /v1/etl/<etl-name>/start
 //
 // Handles starting/stopping ETL pods
+// (and, separately, intra-cluster POST /v1/etl/<etl-name>/_transform - see tgtetlfb.go)
 func (t *target) handleETLPost(w http.ResponseWriter, r *http.Request) {
 	apiItems, err := t.parseURL(w, r, apc.URLPathETL.L, 2, true)
 	if err != nil {
 		return
 	}
-	if apiItems[1] == apc.ETLStop {
+	switch apiItems[1] {
+	case apc.ETLStop:
 		t.stopETL(w, r, apiItems[0])
 		return
+	case apc.ETLTransform:
+		t.transformETL(w, r, apiItems[0])
+		return
 	}
 	// TODO: Implement ETLStart to start inactive ETLs
 	t.writeErrURL(w, r)
@@ -167,13 +180,48 @@ func (t *target) getETL(w http.ResponseWriter, r *http.Request, etlName string,
 		t.writeErr(w, r, err)
 		return
 	}
-	if err := comm.InlineTransform(w, r, lom); err != nil {
-		errV := cmn.NewErrETL(&cmn.ETLErrCtx{ETLName: etlName, PodName: comm.PodName(), SvcName: comm.SvcName()},
-			err.Error())
-		xetl := comm.Xact()
-		xetl.AddErr(errV)
-		t.writeErr(w, r, errV)
+	hlth := comm.Health()
+	if !hlth.Ok() && t.fallbackETL(w, r, etlName, comm, lom) {
+		return
+	}
+	ew := &etlRespWriter{ResponseWriter: w}
+	if cache := comm.Cache(); cache != nil {
+		err = cache.InlineTransform(ew, r, lom)
+	} else {
+		err = comm.InlineTransform(ew, r, lom)
+	}
+	if err == nil {
+		hlth.Success()
+		return
+	}
+	if !isETLIndependent(err) {
+		hlth.Failure(err)
+		// nothing written yet - try peers
+		if !ew.written && t.fallbackETL(w, r, etlName, comm, lom) {
+			return
+		}
 	}
+	errV := cmn.NewErrETL(&cmn.ETLErrCtx{ETLName: etlName, PodName: comm.PodName(), SvcName: comm.SvcName()},
+		err.Error())
+	xetl := comm.Xact()
+	xetl.AddErr(errV)
+	t.writeErr(w, r, errV)
+}
+
+// (space cleanup: keep cached inline transformations of the running ETLs)
+func etlRunning(etlName string) bool {
+	_, err := etl.GetCommunicator(etlName)
+	return err == nil
+}
+
+// local (per-node) ETL status - see etl.Health
+func (t *target) statusETL(w http.ResponseWriter, r *http.Request, etlName string) {
+	comm, err := etl.GetCommunicator(etlName)
+	if err != nil {
+		t.writeErr(w, r, err, http.StatusNotFound, Silent)
+		return
+	}
+	t.writeJSON(w, r, comm.Health().Status(t.SID()), "status-etl")
 }
 
 func (t *target) logsETL(w http.ResponseWriter, r *http.Request, etlName string) {
@@ -198,6 +246,47 @@ func (t *target) healthETL(w http.ResponseWriter, r *http.Request, etlName strin
 	writeXid(w, health)
 }
 
+// GET /v1/etl/<etl-name>/estimate/<bucket-name>
+func (t *target) estimateETL(w http.ResponseWriter, r *http.Request, etlName string, apiItems []string) {
+	if len(apiItems) != 1 {
+		t.writeErrURL(w, r)
+		return
+	}
+	query := r.URL.Query()
+	bck, err := newBckFromQ(apiItems[0], query, nil)
+	if err != nil {
+		t.writeErr(w, r, err)
+		return
+	}
+	if err := bck.Init(t.owner.bmd); err != nil {
+		t.writeErr(w, r, err)
+		return
+	}
+	msg := &apc.TCBMsg{}
+	if err := readJSON(w, r, msg); err != nil {
+		return
+	}
+	msg.Transform.Name = etlName
+	dp, err := etlDP(msg)
+	if err != nil {
+		t.writeErr(w, r, err)
+		return
+	}
+	nsamples := xs.DefaultEstimateSamples
+	if v := query.Get(apc.QparamETLSamples); v != "" {
+		if nsamples, err = strconv.Atoi(v); err != nil {
+			t.writeErr(w, r, err)
+			return
+		}
+	}
+	est, err := xs.EstimateTCB(bck, msg, dp, nsamples, cmn.GCO.Get())
+	if err != nil {
+		t.writeErr(w, r, err)
+		return
+	}
+	t.writeJSON(w, r, est, "estimate-etl")
+}
+
 func (t *target) metricsETL(w http.ResponseWriter, r *http.Request, etlName string) {
 	metricMsg, err := etl.PodMetrics(etlName)
 	if err != nil {
@@ -294,3 +383,41 @@ func (t *target) headObjectETL(w http.ResponseWriter, r *http.Request) {
 		t.writeErr(w, r, err, ecode, Silent)
 	}
 }
+
+// Transform cold-GET object with the ETL bound to its bucket (`bprops.etl.name`):
+//   - the original (remote) content is streamed directly to the ETL and never gets cached;
+//   - the transformed content is stored under the object's name and tagged with the ETL name
+//     (cmn.ETLObjMD) - warm GET treats content that's tagged differently (or not tagged at all)
+//     as stale (see etlStale);
+//   - upon entry, the object is wlocked; upon successful return, rlocked; otherwise, unlocked.
+//
+// NOTE: requires push communicator (see etl.Communicator.StreamTransform).
+// NOTE: transformed objects retain remote version (and other custom metadata) but not the size
+// and checksum - validating the latest version by size and/or checksum will trigger another cold GET.
+func (goi *getOI) _coldETL(etlName string, res *core.GetReaderResult) (int, error) {
+	lom := goi.lom
+	comm, err := etl.GetCommunicator(etlName)
+	if err != nil {
+		cos.Close(res.R)
+		lom.Unlock(true)
+		return http.StatusServiceUnavailable, err
+	}
+	timeout := lom.Bprops().ETL.Timeout.D()
+	if timeout == 0 {
+		timeout = dfltColdETLTimeout
+	}
+	r, err := comm.StreamTransform(res.R, res.Size, lom.Bck().Name+"/"+lom.ObjName, timeout)
+	if err != nil {
+		res.R.Close()
+		lom.Unlock(true)
+		return 0, fmt.Errorf("%s: failed to transform %s: %w", comm, lom.Cname(), err)
+	}
+	lom.SetCustomKey(cmn.ETLObjMD, etlName)
+	return goi._coldPut(&core.GetReaderResult{R: r, Size: r.Size()}) // (checksum to be computed)
+}
+
+// cached content produced by a different ETL (or by none) - see _coldETL
+func etlStale(lom *core.LOM) bool {
+	name, _ := lom.GetCustomKey(cmn.ETLObjMD)
+	return name != lom.Bprops().ETL.Name
+}
@@ -0,0 +1,181 @@
+// Package ais provides core functionality for the AIStore object storage.
+/*
+ * Copyright (c) 2024, NVIDIA CORPORATION. All rights reserved.
+ */
+package ais
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+
+	"github.com/NVIDIA/aistore/api/apc"
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/cmn/cos"
+	"github.com/NVIDIA/aistore/cmn/nlog"
+	"github.com/NVIDIA/aistore/core"
+	"github.com/NVIDIA/aistore/core/meta"
+	"github.com/NVIDIA/aistore/ext/etl"
+	"github.com/NVIDIA/aistore/memsys"
+)
+
+// Inline transform fallback: when the local ETL is unhealthy (see etl.Health) the target
+// that owns the object forwards its content to a peer target (in HRW order) which then
+// transforms it with its own ETL and responds with the result. At most `etlFallbackPeers`
+// peers are tried; peers with unhealthy ETL refuse (503) right away.
+
+const etlFallbackPeers = 2
+
+// wraps http.ResponseWriter to tell whether anything has been written yet
+type etlRespWriter struct {
+	http.ResponseWriter
+	written bool
+}
+
+func (ew *etlRespWriter) WriteHeader(code int) {
+	ew.written = true
+	ew.ResponseWriter.WriteHeader(code)
+}
+
+func (ew *etlRespWriter) Write(b []byte) (int, error) {
+	ew.written = true
+	return ew.ResponseWriter.Write(b)
+}
+
+func (ew *etlRespWriter) Unwrap() http.ResponseWriter { return ew.ResponseWriter }
+
+// failures that have nothing to do with the ETL itself
+func isETLIndependent(err error) bool {
+	return cos.IsNotExist(err, 0) || cmn.IsErrAborted(err)
+}
+
+// returns true if one of the peers has transformed the object
+func (t *target) fallbackETL(w http.ResponseWriter, r *http.Request, etlName string, comm etl.Communicator, lom *core.LOM) bool {
+	smap := t.owner.smap.get()
+	sis, err := smap.HrwTargetList(lom.UnamePtr(), smap.CountTargets())
+	if err != nil {
+		return false
+	}
+	var n int
+	for _, tsi := range sis {
+		if tsi.ID() == t.SID() {
+			continue
+		}
+		if n >= etlFallbackPeers {
+			break
+		}
+		n++
+		resp, err := t.fwdETL(r, tsi, etlName, lom)
+		if err != nil {
+			nlog.Warningln(t.String(), "ETL fallback", lom.Cname(), "=>", tsi.StringEx()+":", err)
+			if cos.IsNotExist(err, 0) {
+				return false
+			}
+			continue
+		}
+		if resp.ContentLength >= 0 {
+			w.Header().Set(cos.HdrContentLength, strconv.FormatInt(resp.ContentLength, 10))
+		}
+		buf, slab := t.gmm.AllocSize(memsys.DefaultBufSize)
+		_, err = io.CopyBuffer(w, resp.Body, buf)
+		slab.Free(buf)
+		resp.Body.Close()
+		if err != nil {
+			// (response already started - nothing else to do)
+			nlog.Warningln(t.String(), "ETL fallback", lom.Cname(), "<=", tsi.StringEx()+":", err)
+		}
+		comm.Health().IncFallback()
+		if cmn.Rom.FastV(4, cos.SmoduleETL) {
+			nlog.Infoln(t.String(), comm.String(), "fallback", lom.Cname(), "=>", tsi.StringEx())
+		}
+		return true
+	}
+	return false
+}
+
+// forward object's content to a peer target; upon success, the caller must close response body
+func (t *target) fwdETL(r *http.Request, tsi *meta.Snode, etlName string, lom *core.LOM) (*http.Response, error) {
+	// open under rlock; keep reading without
+	lom.Lock(false)
+	if err := lom.Load(false /*cache it*/, true /*locked*/); err != nil {
+		lom.Unlock(false)
+		return nil, err
+	}
+	fh, err := cos.NewFileHandle(lom.FQN)
+	size := lom.Lsize()
+	lom.Unlock(false)
+	if err != nil {
+		return nil, err
+	}
+
+	reqArgs := cmn.AllocHra()
+	{
+		reqArgs.Method = http.MethodPost
+		reqArgs.Base = tsi.URL(cmn.NetIntraData)
+		reqArgs.Path = apc.URLPathETL.Join(etlName, apc.ETLTransform)
+		reqArgs.Query = url.Values{apc.QparamETLObject: []string{lom.Bck().Name + "/" + lom.ObjName}}
+		reqArgs.Header = http.Header{
+			apc.HdrCallerID:    []string{t.SID()},
+			apc.HdrCallerName:  []string{t.callerName()},
+			cos.HdrContentType: []string{cos.ContentBinary},
+		}
+		reqArgs.BodyR = fh
+	}
+	req, err := reqArgs.Req()
+	cmn.FreeHra(reqArgs)
+	if err != nil {
+		cos.Close(fh)
+		return nil, err
+	}
+	req = req.WithContext(r.Context()) // (client gone => cancel)
+	req.ContentLength = size
+
+	resp, err := g.client.data.Do(req) //nolint:bodyclose // closed by the caller
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode >= http.StatusBadRequest {
+		b, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		resp.Body.Close()
+		return nil, fmt.Errorf("status %d: %s", resp.StatusCode, b)
+	}
+	return resp, nil
+}
+
+// POST /v1/etl/<etl-name>/_transform?etl_object=<name>
+// (intra-cluster) transform content forwarded by a peer target whose local ETL is unhealthy
+func (t *target) transformETL(w http.ResponseWriter, r *http.Request, etlName string) {
+	if err := t.isIntraCall(r.Header, false /*from primary*/); err != nil {
+		t.writeErr(w, r, err)
+		return
+	}
+	comm, err := etl.GetCommunicator(etlName)
+	if err != nil {
+		t.writeErr(w, r, err, http.StatusNotFound, Silent)
+		return
+	}
+	if !comm.Health().Healthy() {
+		t.writeErr(w, r, fmt.Errorf("%s: %s is unhealthy", t, comm), http.StatusServiceUnavailable, Silent)
+		return
+	}
+	name := r.URL.Query().Get(apc.QparamETLObject)
+	rc, err := comm.StreamTransform(r.Body, r.ContentLength, name, 0 /*timeout*/)
+	if err != nil {
+		t.writeErr(w, r, err, http.StatusServiceUnavailable)
+		return
+	}
+	if size := rc.Size(); size >= 0 {
+		w.Header().Set(cos.HdrContentLength, strconv.FormatInt(size, 10))
+	}
+	buf, slab := t.gmm.AllocSize(memsys.DefaultBufSize)
+	_, err = io.CopyBuffer(w, rc, buf)
+	slab.Free(buf)
+	rc.Close()
+	if err != nil {
+		nlog.Warningln(t.String(), comm.String(), "failed to transform", name, "for peer:", err)
+		return
+	}
+	comm.Health().IncServed()
+}
@@ -202,6 +202,10 @@ func (goi *getOI) _cleanup(revert string, lmfh io.Closer, buf []byte, slab *mems
 // NOTE:
 // Streaming cold GET feature (`feat.StreamingColdGET`) puts response header on the wire _prior_
 // to finalizing in-cluster object. Use it at your own risk.
+// Remote content gets "teed" - transmitted and, concurrently, written locally (see coldTee).
+// Failure to write locally does not fail the GET: the rest of the content gets transmitted
+// (pass-through), and the partially written object is removed. Conversely, when the client
+// goes away, cold GET continues to write and finalize the object.
 // (under wlock)
 func (goi *getOI) coldStream(res *core.GetReaderResult) error {
 	var (
@@ -215,24 +219,9 @@ func (goi *getOI) coldStream(res *core.GetReaderResult) error {
 			revert = ""
 		}
 	}
-	lmfh, err := lom.Create()
-	if err != nil {
-		cos.Close(res.R)
-		goi._cleanup(revert, nil, nil, nil, err, "(fcreate)")
-		return err
-	}
-
-	// read remote, write local, transmit --
-
-	var (
-		written   int64
-		buf, slab = t.gmm.AllocSize(min(res.Size, memsys.DefaultBuf2Size))
-		cksum     = cos.NewCksumHash(lom.CksumConf().Type)
-		mw        = cos.NewWriterMulti(goi.w, lmfh, cksum.H)
-		whdr      = goi.w.Header()
-	)
 
 	// response header
+	whdr := goi.w.Header()
 	whdr.Set(cos.HdrContentType, cos.ContentBinary)
 	cmn.ToHeader(lom.ObjAttrs(), whdr, res.Size)
 	if goi.dpq.isS3 {
@@ -240,14 +229,38 @@ func (goi *getOI) coldStream(res *core.GetReaderResult) error {
 		s3.SetEtag(whdr, goi.lom)
 	}
 
-	written, err = cos.CopyBuffer(mw, res.R, buf)
+	buf, slab := t.gmm.AllocSize(min(res.Size, memsys.DefaultBuf2Size))
+	lmfh, err := lom.Create()
+	if err != nil {
+		goi._cleanup(revert, nil, nil, nil, err, "(fcreate)")
+		t.FSHC(err, lom.Mountpath(), lom.FQN)
+		return goi.coldPassThru(res, buf, slab)
+	}
+
+	// read remote, write local, transmit --
+
+	var (
+		written int64
+		tee     = newColdTee(goi.w, lmfh, lom.CksumConf().Type)
+	)
+	written, err = cos.CopyBuffer(tee, res.R, buf)
+	tee.stop()
 	cos.Close(res.R)
 
-	if err != nil {
+	switch {
+	case err != nil:
+		// remote read (or both transmit and local write) failed
 		goi._cleanup(revert, lmfh, buf, slab, err, "(rr/wl)")
 		return errSendingResp // NOTE: cannot return err: whdr is already on the wire
+	case tee.ferr != nil:
+		// transmitted (pass-through) without caching
+		goi._cleanup(revert, lmfh, buf, slab, tee.ferr, "(wl)")
+		t.FSHC(tee.ferr, lom.Mountpath(), lom.FQN)
+		goi.rltime = mono.SinceNano(goi.rstarttime)
+		goi.stats(tee.tx)
+		return nil
 	}
-	debug.Assertf(written == res.Size, "%s: remote-size %d != %d written/transmitted", lom.Cname(), res.Size, written)
+	debug.Assertf(written == res.Size, "%s: remote-size %d != %d written", lom.Cname(), res.Size, written)
 
 	if lom.IsFeatureSet(feat.FsyncPUT) {
 		// fsync (flush)
@@ -266,8 +279,7 @@ func (goi *getOI) coldStream(res *core.GetReaderResult) error {
 
 	// lom (main replica)
 	lom.SetSize(written)
-	cksum.Finalize()
-	lom.SetCksum(&cksum.Cksum)
+	lom.SetCksum(tee.cksum())
 	if lom.HasCopies() {
 		if err := lom.DelAllCopies(); err != nil {
 			nlog.Errorln(err)
@@ -280,5 +292,92 @@ func (goi *getOI) coldStream(res *core.GetReaderResult) error {
 
 	slab.Free(buf)
 
-	return goi._fini(revert, res.Size, written)
+	if err = goi._fini(revert, res.Size, tee.tx); err != nil {
+		return err
+	}
+	if tee.werr != nil {
+		nlog.Infoln(ftcg, "(transmit)", lom.Cname(), "err:", tee.werr, "- cached anyway")
+		return errSendingResp
+	}
+	return nil
+}
+
+// failed to create local replica: transmit remote content as is
+// (is called with wlock released)
+func (goi *getOI) coldPassThru(res *core.GetReaderResult, buf []byte, slab *memsys.Slab) error {
+	written, err := cos.CopyBuffer(goi.w, res.R, buf)
+	cos.Close(res.R)
+	slab.Free(buf)
+	if err != nil {
+		nlog.Infoln(ftcg, "(pass-through)", goi.lom.Cname(), "err:", err)
+		return errSendingResp
+	}
+	goi.rltime = mono.SinceNano(goi.rstarttime)
+	goi.stats(written)
+	return nil
+}
+
+//////////////
+// coldTee //
+//////////////
+
+// io.Writer that transmits to the client and, concurrently, writes (and checksums) local replica;
+// keeps going for as long as at least one of the two succeeds
+type coldTee struct {
+	w      io.Writer      // client
+	lmfh   io.Writer      // local replica
+	cksumH *cos.CksumHash // local replica's checksum
+	ch     chan []byte    // => local writer
+	done   chan struct{}  // <= local writer (chunk written)
+	werr   error          // transmit error (client)
+	ferr   error          // local write error
+	tx     int64          // transmitted
+}
+
+// interface guard
+var _ io.Writer = (*coldTee)(nil)
+
+func newColdTee(w, lmfh io.Writer, cksumType string) *coldTee {
+	tee := &coldTee{
+		w:      w,
+		lmfh:   lmfh,
+		cksumH: cos.NewCksumHash(cksumType),
+		ch:     make(chan []byte),
+		done:   make(chan struct{}),
+	}
+	go tee.wlocal()
+	return tee
+}
+
+func (tee *coldTee) wlocal() {
+	for b := range tee.ch {
+		if tee.ferr == nil {
+			if _, tee.ferr = tee.lmfh.Write(b); tee.ferr == nil {
+				tee.cksumH.H.Write(b)
+			}
+		}
+		tee.done <- struct{}{}
+	}
+}
+
+// NOTE: `b` is reused upon return (see cos.CopyBuffer) - hence, waiting for the local writer
+func (tee *coldTee) Write(b []byte) (int, error) {
+	tee.ch <- b
+	if tee.werr == nil {
+		n, err := tee.w.Write(b)
+		tee.tx += int64(n)
+		tee.werr = err
+	}
+	<-tee.done
+	if tee.werr != nil && tee.ferr != nil {
+		return 0, tee.werr
+	}
+	return len(b), nil
+}
+
+func (tee *coldTee) stop() { close(tee.ch) }
+
+func (tee *coldTee) cksum() *cos.Cksum {
+	tee.cksumH.Finalize()
+	return &tee.cksumH.Cksum
 }
@@ -0,0 +1,98 @@
+// Package ais provides core functionality for the AIStore object storage.
+/*
+ * Copyright (c) 2024, NVIDIA CORPORATION. All rights reserved.
+ */
+package ais
+
+import (
+	"bytes"
+	"errors"
+	"io"
+
+	"github.com/NVIDIA/aistore/cmn/cos"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+// fails after writing `limit` bytes
+type failingWriter struct {
+	bytes.Buffer
+	limit int
+}
+
+func (w *failingWriter) Write(b []byte) (int, error) {
+	if w.Len()+len(b) > w.limit {
+		return 0, errors.New("write failed")
+	}
+	return w.Buffer.Write(b)
+}
+
+var _ = Describe("Cold GET tee", func() {
+	const size = 1024*1024 + 123
+
+	var (
+		payload  = cos.CryptoRandS(size)
+		expCksum = func() *cos.Cksum {
+			ck := cos.NewCksumHash(cos.ChecksumXXHash)
+			ck.H.Write([]byte(payload))
+			ck.Finalize()
+			return &ck.Cksum
+		}()
+	)
+
+	copyTee := func(tee *coldTee) (int64, error) {
+		buf := make([]byte, 32*1024)
+		written, err := io.CopyBuffer(struct{ io.Writer }{tee}, bytes.NewBufferString(payload), buf)
+		tee.stop()
+		return written, err
+	}
+
+	It("should transmit and write locally", func() {
+		var client, local bytes.Buffer
+		tee := newColdTee(&client, &local, cos.ChecksumXXHash)
+		written, err := copyTee(tee)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(written).To(BeEquivalentTo(size))
+		Expect(tee.tx).To(BeEquivalentTo(size))
+		Expect(client.String()).To(Equal(payload))
+		Expect(local.String()).To(Equal(payload))
+		Expect(tee.cksum().Equal(expCksum)).To(BeTrue())
+	})
+
+	It("should keep transmitting when local write fails", func() {
+		var (
+			client bytes.Buffer
+			local  = &failingWriter{limit: size / 3}
+		)
+		tee := newColdTee(&client, local, cos.ChecksumXXHash)
+		_, err := copyTee(tee)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(tee.ferr).To(HaveOccurred())
+		Expect(tee.werr).NotTo(HaveOccurred())
+		Expect(client.String()).To(Equal(payload))
+	})
+
+	It("should keep writing locally when client goes away", func() {
+		var (
+			client = &failingWriter{limit: size / 2}
+			local  bytes.Buffer
+		)
+		tee := newColdTee(client, &local, cos.ChecksumXXHash)
+		_, err := copyTee(tee)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(tee.werr).To(HaveOccurred())
+		Expect(tee.tx).To(BeNumerically("<", size))
+		Expect(local.String()).To(Equal(payload))
+		Expect(tee.cksum().Equal(expCksum)).To(BeTrue())
+	})
+
+	It("should fail when both fail", func() {
+		var (
+			client = &failingWriter{limit: size / 2}
+			local  = &failingWriter{limit: size / 4}
+		)
+		tee := newColdTee(client, local, cos.ChecksumXXHash)
+		_, err := copyTee(tee)
+		Expect(err).To(HaveOccurred())
+	})
+})
@@ -0,0 +1,130 @@
+// Package ais provides core functionality for the AIStore object storage.
+/*
+ * Copyright (c) 2024, NVIDIA CORPORATION. All rights reserved.
+ */
+package ais
+
+import (
+	"bytes"
+	"io"
+	"os"
+	"time"
+
+	"github.com/NVIDIA/aistore/cmn/nlog"
+	"github.com/NVIDIA/aistore/memsys"
+	"github.com/NVIDIA/aistore/stats"
+)
+
+// Hedged reads (n-way mirrored buckets with mirror.hedge_delay > 0):
+// - read the head (first chunk) of the (load-balanced) copy;
+// - if the read doesn't complete within the configured delay - or fails -
+//   issue the same read against another copy (mountpath);
+// - serve the one that completes first and stream the rest of the object from it;
+// - the other one gets discarded (closed) upon completion - local reads are not interruptible.
+
+type hedgeRead struct {
+	fh    *os.File
+	slab  *memsys.Slab
+	err   error
+	fqn   string
+	buf   []byte
+	n     int
+	hedge bool // true: second read
+}
+
+func (goi *getOI) hedgedOpen(fqn string, delay time.Duration) (*hedgeRead, error) {
+	var (
+		firstErr error
+		winner   *hedgeRead
+		size     = min(goi.lom.Lsize(), memsys.DefaultBufSize)
+		ch       = make(chan *hedgeRead, 2)
+		timer    = time.NewTimer(delay)
+		pending  = 1
+		hedged   bool
+	)
+	go goi.t.readHead(fqn, size, false, ch)
+
+	hedge := func() {
+		if hedged {
+			return
+		}
+		hedged = true
+		other := goi.lom.LBGetOther(fqn)
+		if other == "" {
+			return
+		}
+		pending++
+		goi.t.statsT.Inc(stats.GetHedgeCount)
+		go goi.t.readHead(other, size, true, ch)
+	}
+loop:
+	for pending > 0 {
+		select {
+		case hr := <-ch:
+			pending--
+			if hr.err == nil {
+				winner = hr
+				break loop
+			}
+			if firstErr == nil {
+				firstErr = hr.err
+			}
+			nlog.Warningln(goi.t.String(), "hedged read:", hr.err)
+			hedge() // (failing over right away)
+		case <-timer.C:
+			hedge()
+		}
+	}
+	timer.Stop()
+	if winner == nil {
+		return nil, firstErr
+	}
+
+	switch {
+	case winner.hedge:
+		goi.t.statsT.Inc(stats.GetHedgeWonCount)
+	case pending > 0:
+		goi.t.statsT.Inc(stats.GetHedgeCancelCount)
+	}
+	if pending > 0 {
+		go func() { (<-ch).free() }()
+	}
+	return winner, nil
+}
+
+func (t *target) readHead(fqn string, size int64, hedge bool, ch chan *hedgeRead) {
+	hr := &hedgeRead{fqn: fqn, hedge: hedge}
+	hr.fh, hr.err = os.Open(fqn)
+	if hr.err == nil {
+		hr.buf, hr.slab = t.gmm.AllocSize(size)
+		hr.n, hr.err = io.ReadFull(hr.fh, hr.buf[:size])
+		if hr.err == io.ErrUnexpectedEOF || hr.err == io.EOF {
+			hr.err = nil // (object size changed underneath?)
+		}
+		if hr.err != nil {
+			hr.free()
+		}
+	}
+	ch <- hr
+}
+
+// the head that's been read followed by the rest of the object
+func (hr *hedgeRead) reader() io.Reader {
+	return io.MultiReader(bytes.NewReader(hr.buf[:hr.n]), hr.fh)
+}
+
+// the caller closes the file - see goi.txfini
+func (hr *hedgeRead) freeBuf() {
+	if hr.slab != nil {
+		hr.slab.Free(hr.buf)
+		hr.buf, hr.slab = nil, nil
+	}
+}
+
+func (hr *hedgeRead) free() {
+	hr.freeBuf()
+	if hr.fh != nil {
+		hr.fh.Close()
+		hr.fh = nil
+	}
+}
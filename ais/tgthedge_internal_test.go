@@ -0,0 +1,115 @@
+// Package ais provides core functionality for the AIStore object storage.
+/*
+ * Copyright (c) 2024, NVIDIA CORPORATION. All rights reserved.
+ */
+package ais
+
+import (
+	"bytes"
+	"io"
+	"os"
+	"path"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/NVIDIA/aistore/api/apc"
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/cmn/cos"
+	"github.com/NVIDIA/aistore/core"
+	"github.com/NVIDIA/aistore/core/mock"
+	"github.com/NVIDIA/aistore/stats"
+	"github.com/NVIDIA/aistore/tools/readers"
+	"github.com/NVIDIA/aistore/tools/tassert"
+)
+
+type hedgeStats struct {
+	mock.StatsTracker
+	cnt map[string]int
+	mu  sync.Mutex
+}
+
+func (s *hedgeStats) Inc(name string) {
+	s.mu.Lock()
+	s.cnt[name]++
+	s.mu.Unlock()
+}
+
+func TestHedgedRead(tt *testing.T) {
+	const size = 3*cos.MiB + 17
+
+	lom := core.AllocLOM("hedged")
+	defer core.FreeLOM(lom)
+	tassert.CheckFatal(tt, lom.InitBck(&cmn.Bck{Name: testBucket, Provider: apc.AIS, Ns: cmn.NsGlobal}))
+
+	r, _ := readers.NewRand(size, cos.ChecksumNone)
+	poi := &putOI{
+		atime:   time.Now().UnixNano(),
+		t:       t,
+		lom:     lom,
+		r:       r,
+		workFQN: path.Join(testMountpath, "hedged.work"),
+		config:  cmn.GCO.Get(),
+	}
+	_, err := poi.putObject()
+	tassert.CheckFatal(tt, err)
+	defer lom.RemoveMain()
+	tassert.CheckFatal(tt, lom.Load(false, false))
+	data, err := os.ReadFile(lom.FQN)
+	tassert.CheckFatal(tt, err)
+
+	// second copy (same mountpath - good enough)
+	copyFQN := lom.FQN + ".copy"
+	tassert.CheckFatal(tt, os.WriteFile(copyFQN, data, cos.PermRWR))
+	defer os.Remove(copyFQN)
+	lom.Lock(true)
+	err = lom.AddCopy(copyFQN, lom.Mountpath())
+	lom.Unlock(true)
+	tassert.CheckFatal(tt, err)
+
+	// (selecting the other copy entails mountpath utilization)
+	config := cmn.GCO.Get()
+	disk := config.Disk
+	config.Disk.DiskUtilLowWM, config.Disk.DiskUtilHighWM = 20, 80
+	defer func() { config.Disk = disk }()
+
+	hs := &hedgeStats{cnt: make(map[string]int)}
+	statsT := t.statsT
+	t.statsT = hs
+	defer func() { t.statsT = statsT }()
+
+	read := func(fqn string, delay time.Duration) *hedgeRead {
+		goi := &getOI{t: t, lom: lom}
+		lom.Lock(false)
+		hr, err := goi.hedgedOpen(fqn, delay)
+		lom.Unlock(false)
+		tassert.CheckFatal(tt, err)
+		got, err := io.ReadAll(hr.reader())
+		tassert.CheckFatal(tt, err)
+		hr.free()
+		tassert.Fatalf(tt, bytes.Equal(got, data), "%s: content mismatch (%d vs %d bytes)", hr.fqn, len(got), len(data))
+		return hr
+	}
+
+	// primary completes well within the delay: no hedging
+	hr := read(lom.FQN, time.Minute)
+	tassert.Errorf(tt, !hr.hedge && hr.fqn == lom.FQN, "expected primary read, got %q (hedge %t)", hr.fqn, hr.hedge)
+	tassert.Errorf(tt, hs.cnt[stats.GetHedgeCount] == 0, "expected no hedges, got %v", hs.cnt)
+
+	// primary fails: failing over to the other copy right away (regardless of the delay)
+	tassert.CheckFatal(tt, os.Rename(lom.FQN, lom.FQN+".tmp"))
+	hr = read(lom.FQN, time.Minute)
+	tassert.CheckFatal(tt, os.Rename(lom.FQN+".tmp", lom.FQN))
+	tassert.Errorf(tt, hr.hedge && hr.fqn == copyFQN, "expected hedged read from %q, got %q (hedge %t)", copyFQN, hr.fqn, hr.hedge)
+	tassert.Errorf(tt, hs.cnt[stats.GetHedgeCount] == 1 && hs.cnt[stats.GetHedgeWonCount] == 1,
+		"expected one hedge won, got %v", hs.cnt)
+
+	// racing: either copy may win but the content is always the same
+	for range 10 {
+		read(lom.FQN, time.Nanosecond)
+	}
+	hs.mu.Lock()
+	tassert.Errorf(tt, hs.cnt[stats.GetHedgeWonCount]+hs.cnt[stats.GetHedgeCancelCount] <= hs.cnt[stats.GetHedgeCount],
+		"won + cancelled cannot exceed hedges issued: %v", hs.cnt)
+	hs.mu.Unlock()
+}
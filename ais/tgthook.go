@@ -0,0 +1,106 @@
+// Package ais provides core functionality for the AIStore object storage.
+/*
+ * Copyright (c) 2024, NVIDIA CORPORATION. All rights reserved.
+ */
+package ais
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/cmn/cos"
+	"github.com/NVIDIA/aistore/cmn/nlog"
+	"github.com/NVIDIA/aistore/core"
+	"github.com/NVIDIA/aistore/stats"
+)
+
+// Bucket-level validation webhook - see cmn.HookConf
+// - synchronous: called by the target right before committing (renaming work file => object)
+//   a client PUT, and before deleting an object (iff `hook.delete`);
+// - applies to single-object (native and S3 API) PUTs and DELETEs; intra-cluster writes
+//   (copies, rebalance, EC), multi-object (list/range) operations, and evictions are not validated.
+
+const maxHookReason = 1024 // max size of the deny reason returned to the client
+
+type hooker struct {
+	client *http.Client
+	once   sync.Once
+}
+
+func (hk *hooker) init() {
+	config := cmn.GCO.Get()
+	// (external client that does not present this node's certificate; timeouts are per bucket)
+	hk.client = cmn.NewClientTLS(cmn.TransportArgs{}, cmn.TLSArgs{SkipVerify: config.Net.HTTP.SkipVerifyCrt}, false /*intra-cluster*/)
+	// never follow redirects (3xx denies - see callHook)
+	hk.client.CheckRedirect = func(*http.Request, []*http.Request) error { return http.ErrUseLastResponse }
+}
+
+// returns nil when allowed (2xx), (403, error) when denied (3xx, 4xx),
+// and (503, error) when the webhook fails and the bucket is not `fail_open`
+func (t *target) callHook(lom *core.LOM, op string) (int, error) {
+	conf := &lom.Bprops().Hook
+	t.hook.once.Do(t.hook.init)
+	t.statsT.Inc(stats.HookCount)
+
+	hreq := &cmn.HookReq{Op: op, Bck: *lom.Bucket(), ObjName: lom.ObjName, Node: t.SID()}
+	if op == http.MethodPut {
+		hreq.Size = lom.Lsize()
+		if cksum := lom.Checksum(); cksum != nil {
+			hreq.CksumType, hreq.CksumValue = cksum.Get()
+		}
+		hreq.Custom = lom.GetCustomMD()
+	}
+	ecode, reason, err := t.hook.do(conf, hreq)
+	switch {
+	case err == nil && ecode >= http.StatusOK && ecode < http.StatusMultipleChoices:
+		return 0, nil
+	case err == nil && ecode >= http.StatusMultipleChoices && ecode < http.StatusBadRequest:
+		t.statsT.Inc(stats.HookDenyCount)
+		return http.StatusForbidden, fmt.Errorf("%s %s denied by validation webhook: unexpected redirect (%d)", op, lom.Cname(), ecode)
+	case err == nil && ecode < http.StatusInternalServerError:
+		t.statsT.Inc(stats.HookDenyCount)
+		if reason == "" {
+			reason = http.StatusText(ecode)
+		}
+		return http.StatusForbidden, fmt.Errorf("%s %s denied by validation webhook: %s", op, lom.Cname(), reason)
+	}
+
+	// webhook failure
+	t.statsT.IncErr(stats.ErrHookCount)
+	if err == nil {
+		err = fmt.Errorf("status %d: %s", ecode, reason)
+	}
+	if conf.FailOpen {
+		nlog.Warningln(t.String(), "validation webhook failed (fail-open):", op, lom.Cname(), err)
+		return 0, nil
+	}
+	return http.StatusServiceUnavailable, fmt.Errorf("%s %s: validation webhook failed (fail-closed): %v", op, lom.Cname(), err)
+}
+
+func (hk *hooker) do(conf *cmn.HookConf, hreq *cmn.HookReq) (int, string, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), conf.TimeoutOrDflt())
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, conf.URL, bytes.NewReader(cos.MustMarshal(hreq)))
+	if err != nil {
+		return 0, "", err
+	}
+	req.Header.Set(cos.HdrContentType, cos.ContentJSON)
+	resp, err := hk.client.Do(req) //nolint:bodyclose // closed below
+	if err != nil {
+		if errors.Is(err, context.DeadlineExceeded) {
+			err = fmt.Errorf("timed out after %v", conf.TimeoutOrDflt())
+		}
+		return 0, "", err
+	}
+	b, _ := io.ReadAll(io.LimitReader(resp.Body, maxHookReason))
+	cos.DrainReader(resp.Body)
+	cos.Close(resp.Body)
+	return resp.StatusCode, strings.TrimSpace(string(b)), nil
+}
@@ -0,0 +1,61 @@
+// Package ais provides core functionality for the AIStore object storage.
+/*
+ * Copyright (c) 2024, NVIDIA CORPORATION. All rights reserved.
+ */
+package ais
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/NVIDIA/aistore/api/apc"
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/cmn/atomic"
+	"github.com/NVIDIA/aistore/core"
+	"github.com/NVIDIA/aistore/tools/tassert"
+)
+
+func TestCallHook(tt *testing.T) {
+	var status atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		code := int(status.Load())
+		if code >= http.StatusMultipleChoices && code < http.StatusBadRequest {
+			w.Header().Set("Location", "/elsewhere")
+		}
+		w.WriteHeader(code)
+	}))
+	defer srv.Close()
+
+	lom := core.AllocLOM("hooked")
+	defer core.FreeLOM(lom)
+	tassert.CheckFatal(tt, lom.InitBck(&cmn.Bck{Name: testBucket, Provider: apc.AIS, Ns: cmn.NsGlobal}))
+	conf := &lom.Bprops().Hook
+	hook := *conf
+	defer func() { *conf = hook }()
+	conf.URL = srv.URL
+
+	tests := []struct {
+		status   int
+		failOpen bool
+		ecode    int
+	}{
+		{http.StatusOK, false, 0},
+		{http.StatusNoContent, false, 0},
+		{http.StatusFound, false, http.StatusForbidden},
+		{http.StatusTemporaryRedirect, true, http.StatusForbidden},
+		{http.StatusUnprocessableEntity, false, http.StatusForbidden},
+		{http.StatusUnprocessableEntity, true, http.StatusForbidden},
+		{http.StatusInternalServerError, false, http.StatusServiceUnavailable},
+		{http.StatusInternalServerError, true, 0},
+	}
+	for _, test := range tests {
+		status.Store(int32(test.status))
+		conf.FailOpen = test.failOpen
+		ecode, err := t.callHook(lom, http.MethodDelete)
+		tassert.Errorf(tt, ecode == test.ecode, "webhook status %d (fail-open %t): expecting %d, got %d (%v)",
+			test.status, test.failOpen, test.ecode, ecode, err)
+		tassert.Errorf(tt, (err == nil) == (test.ecode == 0), "webhook status %d (fail-open %t): unexpected error %v",
+			test.status, test.failOpen, err)
+	}
+}
@@ -97,6 +97,10 @@ func (t *target) FinalizeObj(lom *core.LOM, workFQN string, xctn core.Xact, owt
 		poi.owt = owt
 		poi.xctn = xctn
 	}
+	if err = poi.checkName(); err != nil {
+		freePOI(poi)
+		return http.StatusBadRequest, err
+	}
 	ecode, err = poi.finalize()
 	freePOI(poi)
 	return
@@ -139,6 +143,9 @@ func (t *target) CopyObject(lom *core.LOM, dm core.DM, params *core.CopyParams)
 	debug.Assert(ok)
 
 	size, err = coi.do(t, realDM, lom)
+	if coi.Sent != nil { // (not sending via data mover - see coi._dm)
+		coi.Sent(err)
+	}
 
 	coi.stats(size, err)
 	return size, err
@@ -228,13 +235,14 @@ func (t *target) HeadCold(lom *core.LOM, origReq *http.Request) (oa *cmn.ObjAttr
 func (t *target) Promote(params *core.PromoteParams) (ecode int, err error) {
 	lom := core.AllocLOM(params.ObjName)
 	if err = lom.InitBck(params.Bck.Bucket()); err == nil {
-		ecode, err = t._promote(params, lom)
+		ecode, err = t._promote(params, lom, 0 /*fence*/)
 	}
 	core.FreeLOM(lom)
 	return
 }
 
-func (t *target) _promote(params *core.PromoteParams, lom *core.LOM) (ecode int, err error) {
+// fence: apc.HdrObjLockToken (if non-zero) to re-validate under wlock (see poi.fini)
+func (t *target) _promote(params *core.PromoteParams, lom *core.LOM, fence int64) (ecode int, err error) {
 	smap := t.owner.smap.get()
 	tsi, local, erh := lom.HrwTarget(&smap.Smap)
 	if erh != nil {
@@ -242,7 +250,7 @@ func (t *target) _promote(params *core.PromoteParams, lom *core.LOM) (ecode int,
 	}
 	var size int64
 	if local {
-		size, ecode, err = t._promLocal(params, lom)
+		size, ecode, err = t._promLocal(params, lom, fence)
 	} else {
 		size, err = t._promRemote(params, lom, tsi, smap)
 		if err == nil && size >= 0 && params.Xact != nil {
@@ -263,7 +271,7 @@ func (t *target) _promote(params *core.PromoteParams, lom *core.LOM) (ecode int,
 	return
 }
 
-func (t *target) _promLocal(params *core.PromoteParams, lom *core.LOM) (fileSize int64, ecode int, err error) {
+func (t *target) _promLocal(params *core.PromoteParams, lom *core.LOM, fence int64) (fileSize int64, ecode int, err error) {
 	var (
 		cksum     *cos.CksumHash
 		workFQN   string
@@ -333,6 +341,7 @@ func (t *target) _promLocal(params *core.PromoteParams, lom *core.LOM) (fileSize
 		poi.workFQN = workFQN
 		poi.owt = cmn.OwtPromote
 		poi.xctn = params.Xact
+		poi.fence = fence
 	}
 	lom.SetSize(fileSize)
 	ecode, err = poi.finalize()
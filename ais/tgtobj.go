@@ -11,6 +11,7 @@ import (
 	"encoding/base64"
 	"errors"
 	"fmt"
+	"hash"
 	"io"
 	"net/http"
 	"os"
@@ -59,6 +60,8 @@ type (
 		atime      int64         // access time.Now()
 		ltime      int64         // mono.NanoTime, to measure latency
 		rltime     int64         // mono.NanoTime, to measure remote bucket latency
+		deadline   int64         // apc.HdrDeadline (Unix nanoseconds), if specified
+		fence      int64         // apc.HdrObjLockToken, if specified (re-validated under wlock)
 		size       int64         // aka Content-Length
 		owt        cmn.OWT       // object write transaction enum { OwtPut, ..., OwtGet* }
 		restful    bool          // being invoked via RESTful API
@@ -67,6 +70,8 @@ type (
 		skipVC     bool          // skip loading existing Version and skip comparing Checksums (skip VC)
 		coldGET    bool          // (one implication: proceed to write)
 		remoteErr  bool          // to exclude `putRemote` errors when counting soft IO errors
+		published  bool          // publish (immutable) bucket - see cmn.PublishConf
+		dup        bool          // content-addressed object already exists (PUT is a no-op)
 	}
 
 	getOI struct {
@@ -81,6 +86,7 @@ type (
 		ltime      int64      // mono.NanoTime, to measure latency
 		rstarttime int64      // mono.NanoTime, mark start of remote GET to measure latency
 		rltime     int64      // mono.NanoTime, to measure remote bucket latency
+		deadline   int64      // apc.HdrDeadline (Unix nanoseconds), if specified
 		chunked    bool       // chunked transfer (en)coding: https://tools.ietf.org/html/rfc7230#page-36
 		unlocked   bool       // internal
 		verchanged bool       // version changed
@@ -106,6 +112,7 @@ type (
 		hdl     aoHdl         // (packed)
 		op      string        // enum {apc.AppendOp, apc.FlushOp}
 		size    int64         // Content-Length
+		fence   int64         // apc.HdrObjLockToken, if specified (re-validated under wlock when flushing)
 	}
 
 	copyOI core.CopyParams
@@ -147,6 +154,26 @@ func (poi *putOI) do(resphdr http.Header, r *http.Request, dpq *dpq) (int, error
 		poi.cksumToUse = poi.lom.ObjAttrs().FromHeader(r.Header)
 		poi.owt = cmn.OwtPut // default
 	}
+	if poi.deadline != 0 {
+		if over, ok := expired(poi.deadline); ok {
+			return poi.errDeadline(dpq, over)
+		}
+		poi.r = &dlReader{r: r.Body, deadline: poi.deadline}
+	}
+	if s := r.Header.Get(apc.HdrObjCopies); s != "" {
+		copies, err := cmn.ParseObjCopies(s)
+		if err == nil && copies > 0 {
+			err = fs.ValidateNCopies(poi.t.si.Name(), copies)
+		}
+		if err != nil {
+			return http.StatusBadRequest, err
+		}
+		if copies > 0 {
+			poi.lom.SetCustomKey(cmn.CopiesObjMD, s)
+		} else {
+			poi.lom.ObjAttrs().DelCustomKeys(cmn.CopiesObjMD)
+		}
+	}
 	if dpq.owt != "" {
 		poi.owt.FromS(dpq.owt)
 	}
@@ -166,11 +193,38 @@ func (poi *putOI) do(resphdr http.Header, r *http.Request, dpq *dpq) (int, error
 			poi.size = size
 		}
 	}
-	return poi.putObject()
+	ecode, err := poi.putObject()
+	if err != nil {
+		if over, ok := expired(poi.deadline); ok {
+			ecode, err = poi.errDeadline(dpq, over)
+		}
+	}
+	return ecode, err
+}
+
+func (poi *putOI) errDeadline(dpq *dpq, over time.Duration) (int, error) {
+	poi.t.statsT.IncErr(stats.ErrDeadlineCount)
+	timing := dlTiming(dpq.ptime, poi.atime, poi.ltime, 0 /*rstarttime*/)
+	return http.StatusGatewayTimeout, cmn.NewErrDeadline(http.MethodPut+" "+poi.lom.Cname(), timing, over)
 }
 
 func (poi *putOI) putObject() (ecode int, err error) {
 	poi.ltime = mono.NanoTime()
+	if err := poi.checkName(); err != nil {
+		return http.StatusBadRequest, err
+	}
+	// publish (immutable) bucket: write-once (see also poi.fini)
+	if poi.owt < cmn.OwtRebalance && poi.lom.Bprops().Publish.Enabled {
+		poi.published = true
+		if err := poi.writeOnce(); err != nil {
+			poi.t.statsT.IncErr(stats.ErrPutCount) // (not an IO error)
+			return http.StatusConflict, err
+		}
+		if poi.dup {
+			cos.DrainReader(poi.r)
+			return 0, nil
+		}
+	}
 	// PUT is a no-op if the checksums do match
 	if !poi.skipVC && !poi.coldGET && !poi.cksumToUse.IsEmpty() {
 		if poi.lom.EqCksum(poi.cksumToUse) {
@@ -188,10 +242,50 @@ func (poi *putOI) putObject() (ecode int, err error) {
 		err, ecode = erw, http.StatusInternalServerError
 		goto rerr
 	}
+	if poi.xctn != nil {
+		poi.xctn.DiskWriteAdd(poi.lom.Lsize())
+	}
+
+	// do not proceed to finalize (and write remote backend) past the deadline
+	if over, ok := expired(poi.deadline); ok {
+		err, ecode = cmn.NewErrDeadline(http.MethodPut+" "+poi.lom.Cname(), "", over), http.StatusGatewayTimeout
+		if nerr := cos.RemoveFile(poi.workFQN); nerr != nil && !os.IsNotExist(nerr) {
+			nlog.Errorf(fmtNested, poi.t, err, "remove", poi.workFQN, nerr)
+		}
+		poi.lom.Uncache()
+		goto rerr
+	}
+
+	// content-addressed: the name must match the (computed) checksum
+	if poi.published && poi.lom.Bprops().Publish.ContentAddr {
+		if err = cmn.CheckContentAddr(poi.lom.ObjName, poi.lom.Checksum()); err != nil {
+			if nerr := cos.RemoveFile(poi.workFQN); nerr != nil && !os.IsNotExist(nerr) {
+				nlog.Errorf(fmtNested, poi.t, err, "remove", poi.workFQN, nerr)
+			}
+			poi.lom.Uncache()
+			poi.t.statsT.IncErr(stats.ErrPutCount) // (not an IO error)
+			return http.StatusBadRequest, err
+		}
+	}
+
+	// validation webhook (optional)
+	if poi.owt == cmn.OwtPut && poi.restful && !poi.t2t && poi.lom.Bprops().Hook.Enabled() {
+		if ecode, err = poi.t.callHook(poi.lom, http.MethodPut); err != nil {
+			if nerr := cos.RemoveFile(poi.workFQN); nerr != nil && !os.IsNotExist(nerr) {
+				nlog.Errorf(fmtNested, poi.t, err, "remove", poi.workFQN, nerr)
+			}
+			poi.lom.Uncache()
+			poi.t.statsT.IncErr(stats.ErrPutCount) // (not an IO error)
+			return ecode, err
+		}
+	}
 
 	if ecode, err = poi.finalize(); err != nil {
 		goto rerr
 	}
+	if poi.owt == cmn.OwtPut && poi.lom.Bprops().WriteBack.Enabled {
+		poi.t.wbq.drop(poi.lom) // supersedes pending deletion, if any
+	}
 
 	// resp. header & stats
 	if !poi.t2t {
@@ -217,13 +311,26 @@ func (poi *putOI) putObject() (ecode int, err error) {
 rerr:
 	if poi.owt == cmn.OwtPut && poi.restful && !poi.t2t {
 		poi.t.statsT.IncErr(stats.ErrPutCount)
-		if err != cmn.ErrSkip && !poi.remoteErr && err != io.ErrUnexpectedEOF && !cos.IsRetriableConnErr(err) {
+		if err != cmn.ErrSkip && !poi.remoteErr && err != io.ErrUnexpectedEOF && !cos.IsRetriableConnErr(err) &&
+			!cmn.IsErrImmutable(err) && !cmn.IsErrDeadline(err) {
 			poi.t.statsT.IncErr(stats.IOErrPutCount)
 		}
 	}
 	return ecode, err
 }
 
+// object naming policy (see cmn.ObjNameConf)
+func (poi *putOI) checkName() error {
+	if poi.owt >= cmn.OwtRebalance {
+		return nil
+	}
+	err := poi.lom.Bprops().ObjName.Check(poi.lom.ObjName)
+	if err != nil {
+		poi.t.statsT.IncErr(stats.ErrPutCount) // (not an IO error)
+	}
+	return err
+}
+
 func (poi *putOI) stats() {
 	var (
 		bck   = poi.lom.Bck()
@@ -237,6 +344,10 @@ func (poi *putOI) stats() {
 		cos.NamedVal64{Name: stats.PutLatency, Value: delta},
 		cos.NamedVal64{Name: stats.PutLatencyTotal, Value: delta},
 	)
+	if poi.owt == cmn.OwtPut {
+		poi.t.statsT.AddTop(bck.Bucket(), poi.lom.ObjName, size)
+		poi.t.statsT.AddLatHist(bck.Bucket(), stats.LatHistPut, delta)
+	}
 	if poi.rltime > 0 {
 		debug.Assert(bck.IsRemote())
 		backend := poi.t.Backend(bck)
@@ -272,7 +383,12 @@ func (poi *putOI) loghdr() string {
 }
 
 func (poi *putOI) finalize() (ecode int, err error) {
-	if ecode, err = poi.fini(); err != nil {
+	ecode, err = poi.fini()
+	if err == nil && poi.dup {
+		poi.lom.Uncache() // (content-addressed duplicate: nothing to finalize)
+		return 0, nil
+	}
+	if err != nil {
 		if err1 := cos.Stat(poi.workFQN); err1 == nil || !os.IsNotExist(err1) {
 			// cleanup: rm work-fqn
 			if err1 == nil {
@@ -347,6 +463,26 @@ func (poi *putOI) fini() (ecode int, err error) {
 		lom.SetAtimeUnix(poi.atime)
 	}
 
+	// fencing token: ditto
+	if poi.fence != 0 {
+		if err = poi.t.olocks.check(lom, poi.fence); err != nil {
+			return http.StatusPreconditionFailed, err
+		}
+	}
+
+	// publish: check again, under lock
+	if poi.published {
+		if err = poi.writeOnce(); err != nil {
+			return http.StatusConflict, err
+		}
+		if poi.dup {
+			if err := cos.RemoveFile(poi.workFQN); err != nil && !os.IsNotExist(err) {
+				nlog.Errorln(poi.loghdr(), "failed to remove", poi.workFQN, err)
+			}
+			return 0, nil
+		}
+	}
+
 	// ais versioning
 	if bck.IsAIS() && lom.VersionConf().Enabled {
 		if poi.owt < cmn.OwtRebalance {
@@ -362,7 +498,23 @@ func (poi *putOI) fini() (ecode int, err error) {
 	}
 
 	// done
-	if err = lom.RenameFinalize(poi.workFQN); err != nil {
+	jseq, err := lom.JournalBegin(poi.workFQN) // (feat.JournalPUT)
+	if err != nil {
+		return 0, err
+	}
+	defer lom.JournalEnd(jseq)
+	if poi.owt <= cmn.OwtRebalance && lom.Bprops().Dedup.Enabled && lom.Lsize() >= int64(lom.Bprops().Dedup.MinSize) {
+		var dedup bool
+		if dedup, err = lom.DedupFinalize(poi.workFQN); err != nil {
+			return 0, err
+		}
+		if dedup {
+			poi.t.statsT.AddMany(
+				cos.NamedVal64{Name: stats.DedupCount, Value: 1},
+				cos.NamedVal64{Name: stats.DedupSize, Value: lom.Lsize()},
+			)
+		}
+	} else if err = lom.RenameFinalize(poi.workFQN); err != nil {
 		return 0, err
 	}
 	if lom.HasCopies() {
@@ -434,7 +586,7 @@ func (poi *putOI) write() (buf []byte, slab *memsys.Slab, lmfh cos.LomWriter, er
 		// not using `ReadFrom` of the `*os.File` -
 		// ultimately, https://github.com/golang/go/blob/master/src/internal/poll/copy_file_range_linux.go#L100
 		written, err = cos.CopyBuffer(lmfh, poi.r, buf)
-	case !poi.cksumToUse.IsEmpty() && !poi.validateCksum(ckconf):
+	case !poi.cksumToUse.IsEmpty() && !poi.validateCksum(ckconf) && !poi.lom.Bprops().CksumRequired():
 		// if the corresponding validation is not configured/enabled we just go ahead
 		// and use the checksum that has arrived with the object
 		poi.lom.SetCksum(poi.cksumToUse)
@@ -454,6 +606,17 @@ func (poi *putOI) write() (buf []byte, slab *memsys.Slab, lmfh cos.LomWriter, er
 				writers = append(writers, cksums.compt.H)
 			}
 		}
+		if cos.IsHashPipe() {
+			// hash concurrently with receiving and writing (see cos.HashPipe)
+			hashes := make([]hash.Hash, 0, 2)
+			for _, w := range writers {
+				hashes = append(hashes, w.(hash.Hash))
+			}
+			hp := cos.NewHashPipe(hashes...)
+			written, err = cos.CopyBuffer(cos.NewWriterMulti(hp, lmfh), poi.r, buf) // (ditto)
+			hp.Wait()
+			break
+		}
 		writers = append(writers, lmfh)
 		written, err = cos.CopyBuffer(cos.NewWriterMulti(writers...), poi.r, buf) // (ditto)
 	}
@@ -476,7 +639,8 @@ func (poi *putOI) write() (buf []byte, slab *memsys.Slab, lmfh cos.LomWriter, er
 	}
 
 	// ok
-	if poi.lom.IsFeatureSet(feat.FsyncPUT) {
+	// (journaled PUT without checksum cannot be validated upon replay - see core.ReplayJournals)
+	if poi.lom.IsFeatureSet(feat.FsyncPUT) || (cksums.store == nil && poi.lom.Checksum().IsEmpty() && poi.lom.IsFeatureSet(feat.JournalPUT)) {
 		err = lmfh.Sync() // compare w/ cos.FlushClose
 		debug.AssertNoErr(err)
 	}
@@ -494,6 +658,19 @@ func (poi *putOI) write() (buf []byte, slab *memsys.Slab, lmfh cos.LomWriter, er
 	return
 }
 
+// published objects cannot be overwritten; content-addressed ones, however, are
+// identified by their content - to overwrite with the same name is to PUT the same content
+func (poi *putOI) writeOnce() error {
+	if err := cos.Stat(poi.lom.FQN); err != nil {
+		return nil // (nothing to overwrite)
+	}
+	if poi.lom.Bprops().Publish.ContentAddr {
+		poi.dup = true
+		return nil
+	}
+	return cmn.NewErrImmutable(poi.lom.Cname(), "overwrite")
+}
+
 // post-write close & cleanup
 func (poi *putOI) _cleanup(buf []byte, slab *memsys.Slab, lmfh cos.LomWriter, err error) {
 	if buf != nil {
@@ -535,14 +712,29 @@ func (poi *putOI) validateCksum(c *cmn.CksumConf) (v bool) {
 
 func (goi *getOI) getObject() (ecode int, err error) {
 	debug.Assert(!goi.unlocked)
+	if over, ok := expired(goi.deadline); ok {
+		return goi.errDeadline(over)
+	}
 	goi.lom.Lock(false)
 	ecode, err = goi.get()
 	if !goi.unlocked {
 		goi.lom.Unlock(false)
 	}
+	if err != nil && err != errSendingResp && !cos.IsNotExist(err, ecode) {
+		if over, ok := expired(goi.deadline); ok {
+			ecode, err = goi.errDeadline(over)
+		}
+	}
 	return ecode, err
 }
 
+func (goi *getOI) errDeadline(over time.Duration) (int, error) {
+	goi.isIOErr = false
+	goi.t.statsT.IncErr(stats.ErrDeadlineCount)
+	timing := dlTiming(goi.dpq.ptime, goi.atime, goi.ltime, goi.rstarttime)
+	return http.StatusGatewayTimeout, cmn.NewErrDeadline(http.MethodGet+" "+goi.lom.Cname(), timing, over)
+}
+
 // is under rlock
 func (goi *getOI) get() (ecode int, err error) {
 	var (
@@ -562,6 +754,9 @@ do:
 		if goi.lom.IsFeatureSet(feat.DisableColdGET) && goi.lom.Bck().IsRemote() {
 			return http.StatusNotFound, fmt.Errorf("%w (cold GET disabled)", err)
 		}
+		if goi.lom.Bprops().WriteBack.Enabled && goi.t.wbq.pending(goi.lom) {
+			return http.StatusNotFound, fmt.Errorf("%w (pending deletion)", err)
+		}
 		cs = fs.Cap()
 		if cs.IsOOS() {
 			return http.StatusInsufficientStorage, cs.Err()
@@ -603,6 +798,9 @@ do:
 		goto fin // ok, done
 	case cold:
 		// have remote backend - use it
+	case etlStale(goi.lom):
+		// transformed by a different ETL or not transformed at all (see _coldETL)
+		cold = true
 	case goi.latestVer:
 		// apc.QparamLatestVer or 'versioning.validate_warm_get'
 		res := goi.lom.CheckRemoteMD(true /* rlocked */, false /*synchronize*/, goi.req)
@@ -668,7 +866,8 @@ do:
 		goi.cold = true
 
 		// 3 alternative ways to perform cold GET
-		if goi.dpq.arch.path == "" && goi.dpq.arch.regx == "" &&
+		etlName := goi.lom.Bprops().ETL.Name
+		if etlName == "" && goi.dpq.arch.path == "" && goi.dpq.arch.regx == "" &&
 			(ckconf.Type == cos.ChecksumNone || (!ckconf.ValidateColdGet && !ckconf.EnableReadRange)) {
 			if goi.ranges.Range == "" && goi.lom.IsFeatureSet(feat.StreamingColdGET) {
 				err = goi.coldStream(&res)
@@ -679,7 +878,11 @@ do:
 			return 0, err
 		}
 		// otherwise, regular path
-		ecode, err = goi._coldPut(&res)
+		if etlName != "" {
+			ecode, err = goi._coldETL(etlName, &res)
+		} else {
+			ecode, err = goi._coldPut(&res)
+		}
 		if err != nil {
 			goi.unlocked = true
 			return ecode, err
@@ -865,14 +1068,14 @@ func (goi *getOI) restoreFromAny(skipLomRestore bool) (doubleCheck bool, ecode i
 		return
 	}
 	if !skipLomRestore {
-		// when resilvering:
+		// when resilvering or replacing mountpath (see fs.ReplaceMpath):
 		// (whether or not resilvering is active depends on the context: mountpath events vs GET)
 		var (
 			resMarked = xreg.GetResilverMarked()
 			running   = resMarked.Xact != nil
 			gfnActive = goi.t.res.IsActive(3 /*interval-of-inactivity multiplier*/)
 		)
-		if resMarked.Interrupted || running || gfnActive {
+		if resMarked.Interrupted || running || gfnActive || fs.GetReplaced() != nil {
 			if goi.lom.RestoreToLocation() { // from copies
 				nlog.Infof("%s restored to location", goi.lom)
 				return
@@ -942,8 +1145,17 @@ gfn:
 }
 
 func (goi *getOI) getFromNeighbor(lom *core.LOM, tsi *meta.Snode) bool {
+	config := cmn.GCO.Get()
+	timeout := config.Timeout.SendFile.D()
 	query := lom.Bck().NewQuery()
 	query.Set(apc.QparamIsGFNRequest, "true")
+	if goi.deadline != 0 {
+		if _, ok := expired(goi.deadline); ok {
+			return false
+		}
+		query.Set(apc.QparamDeadline, cos.UnixNano2S(goi.deadline))
+		timeout = min(timeout, time.Until(time.Unix(0, goi.deadline)))
+	}
 	reqArgs := cmn.AllocHra()
 	{
 		reqArgs.Method = http.MethodGet
@@ -955,8 +1167,7 @@ func (goi *getOI) getFromNeighbor(lom *core.LOM, tsi *meta.Snode) bool {
 		reqArgs.Path = apc.URLPathObjects.Join(lom.Bck().Name, lom.ObjName)
 		reqArgs.Query = query
 	}
-	config := cmn.GCO.Get()
-	req, _, cancel, err := reqArgs.ReqWithTimeout(config.Timeout.SendFile.D())
+	req, _, cancel, err := reqArgs.ReqWithTimeout(timeout)
 	if err != nil {
 		debug.AssertNoErr(err)
 		return false
@@ -999,26 +1210,32 @@ func (goi *getOI) txfini() (ecode int, err error) {
 	var (
 		lmfh *os.File
 		hrng *htrange
+		hr   *hedgeRead
 		fqn  = goi.lom.FQN
 		dpq  = goi.dpq
 	)
+	if goi.ramcache() {
+		return goi.txramc()
+	}
+	var delay time.Duration
 	if !goi.cold && !dpq.isGFN && !goi.lom.IsChunked() {
 		fqn = goi.lom.LBGet() // best-effort GET load balancing (see also mirror.findLeastUtilized())
+		if goi.lom.HasCopies() && goi.ranges.Range == "" && !dpq.isArch() && goi.lom.Lsize() > 0 {
+			delay = goi.lom.MirrorConf().HedgeDelay.D() // hedged reads, if enabled
+		}
 	}
 	// open
-	// TODO -- FIXME: use lom.Open() instead of os.Open(); TestECChecksum
-	lmfh, err = os.Open(fqn)
-	if err != nil {
-		if os.IsNotExist(err) {
-			// NOTE: retry only once and only when ec-enabled - see goi.restoreFromAny()
-			ecode = http.StatusNotFound
-			goi.retry = goi.lom.ECEnabled()
-		} else {
-			goi.t.FSHC(err, goi.lom.Mountpath(), fqn)
-			ecode = http.StatusInternalServerError
-			err = cmn.NewErrFailedTo(goi.t, "goi-finalize", goi.lom.Cname(), err, ecode)
+	if delay > 0 {
+		if hr, err = goi.hedgedOpen(fqn, delay); err == nil {
+			fqn, lmfh = hr.fqn, hr.fh
+			defer hr.freeBuf()
 		}
-		return ecode, err
+	} else {
+		// TODO -- FIXME: use lom.Open() instead of os.Open(); TestECChecksum
+		lmfh, err = os.Open(fqn)
+	}
+	if err != nil {
+		return goi._openErr(fqn, err)
 	}
 
 	whdr := goi.w.Header()
@@ -1037,6 +1254,8 @@ func (goi *getOI) txfini() (ecode int, err error) {
 		err = goi._txrng(fqn, lmfh, whdr, hrng)
 	case dpq.isArch():
 		err = goi._txarch(fqn, lmfh, whdr)
+	case hr != nil:
+		err = goi._txreg(fqn, hr.reader(), whdr)
 	default:
 		err = goi._txreg(fqn, lmfh, whdr)
 	}
@@ -1045,6 +1264,55 @@ func (goi *getOI) txfini() (ecode int, err error) {
 	return ecode, err
 }
 
+func (goi *getOI) _openErr(fqn string, err error) (ecode int, _ error) {
+	if os.IsNotExist(err) {
+		// NOTE: retry only once and only when ec-enabled - see goi.restoreFromAny()
+		ecode = http.StatusNotFound
+		goi.retry = goi.lom.ECEnabled()
+	} else {
+		goi.t.FSHC(err, goi.lom.Mountpath(), fqn)
+		ecode = http.StatusInternalServerError
+		err = cmn.NewErrFailedTo(goi.t, "goi-finalize", goi.lom.Cname(), err, ecode)
+	}
+	return ecode, err
+}
+
+// whether to serve from (and populate) the in-memory cache of small hot objects
+func (goi *getOI) ramcache() bool {
+	if goi.dpq.isGFN || goi.ranges.Range != "" || goi.dpq.isArch() || goi.lom.IsChunked() {
+		return false
+	}
+	conf := &goi.lom.Bprops().RAMCache
+	return conf.Enabled && goi.lom.Lsize() <= conf.MaxObjSizeOrDflt()
+}
+
+// transmit from memory; upon cache miss, read the entire object and cache it (see core/lramc.go)
+func (goi *getOI) txramc() (int, error) {
+	lom := goi.lom
+	rc := core.RAMCacheGet(lom)
+	if rc == nil {
+		lmfh, err := os.Open(lom.FQN)
+		if err != nil {
+			return goi._openErr(lom.FQN, err)
+		}
+		sgl := goi.t.gmm.NewSGL(lom.Lsize())
+		_, err = sgl.ReadFrom(lmfh)
+		cos.Close(lmfh)
+		if err == nil && sgl.Size() != lom.Lsize() {
+			err = fmt.Errorf("%s: size mismatch (%d vs %d)", lom.Cname(), sgl.Size(), lom.Lsize())
+		}
+		if err != nil {
+			sgl.Free()
+			goi.isIOErr = true
+			return http.StatusInternalServerError, err
+		}
+		rc = core.RAMCachePut(lom, sgl)
+	}
+	err := goi._txreg(lom.FQN, rc.NewReader(), goi.w.Header())
+	rc.Release()
+	return 0, err
+}
+
 func (goi *getOI) _txrng(fqn string, lmfh *os.File, whdr http.Header, hrng *htrange) (err error) {
 	var (
 		r     io.Reader
@@ -1085,7 +1353,7 @@ func (goi *getOI) _txrng(fqn string, lmfh *os.File, whdr http.Header, hrng *htra
 }
 
 // in particular, setup reader and writer and set headers
-func (goi *getOI) _txreg(fqn string, lmfh *os.File, whdr http.Header) (err error) {
+func (goi *getOI) _txreg(fqn string, r io.Reader, whdr http.Header) (err error) {
 	var (
 		dpq   = goi.dpq
 		lom   = goi.lom
@@ -1101,7 +1369,7 @@ func (goi *getOI) _txreg(fqn string, lmfh *os.File, whdr http.Header) (err error
 	}
 
 	buf, slab := goi.t.gmm.AllocSize(min(size, memsys.DefaultBuf2Size))
-	err = goi.transmit(lmfh, buf, fqn)
+	err = goi.transmit(r, buf, fqn)
 	slab.Free(buf)
 	return err
 }
@@ -1184,8 +1452,7 @@ func (goi *getOI) transmit(r io.Reader, buf []byte, fqn string) error {
 			nlog.Errorf("%s: GET post-transmission failure: %v", goi.t, err)
 			return errSendingResp
 		}
-		goi.lom.SetAtimeUnix(goi.atime)
-		goi.lom.Recache()
+		goi.lom.UpdateAtime(goi.atime)
 	}
 	//
 	// stats
@@ -1203,6 +1470,8 @@ func (goi *getOI) stats(written int64) {
 		cos.NamedVal64{Name: stats.GetLatency, Value: delta},      // see also: per-backend *LatencyTotal below
 		cos.NamedVal64{Name: stats.GetLatencyTotal, Value: delta}, // ditto
 	)
+	goi.t.statsT.AddTop(goi.lom.Bucket(), goi.lom.ObjName, written)
+	goi.t.statsT.AddLatHist(goi.lom.Bucket(), stats.LatHistGet, delta)
 	if goi.verchanged {
 		goi.t.statsT.AddMany(
 			cos.NamedVal64{Name: stats.VerChangeCount, Value: 1},
@@ -1374,6 +1643,9 @@ func (a *apndOI) flush() (int, error) {
 			DeleteSrc:    true, // NOTE: always overwrite and remove
 		},
 	}
+	if a.fence != 0 {
+		return a.t._promote(&params, a.lom, a.fence)
+	}
 	return a.t.Promote(&params)
 }
 
@@ -1519,9 +1791,12 @@ func (coi *copyOI) _regular(t *target, lom, dst *core.LOM) (size int64, _ error)
 	if lom.FQN == dst.FQN { // resilvering with a single mountpath?
 		return
 	}
-	lcopy := lom.Uname() == dst.Uname() // n-way copy
-	lom.Lock(lcopy)
-	defer lom.Unlock(lcopy)
+	var (
+		lcopy = lom.Uname() == dst.Uname() // n-way copy
+		wlock = lcopy || coi.COW           // (COW: updating source metadata)
+	)
+	lom.Lock(wlock)
+	defer lom.Unlock(wlock)
 
 	if err := lom.Load(false /*cache it*/, true /*locked*/); err != nil {
 		if !cos.IsNotExist(err, 0) {
@@ -1542,9 +1817,22 @@ func (coi *copyOI) _regular(t *target, lom, dst *core.LOM) (size int64, _ error)
 			return 0, err
 		}
 	}
+	if coi.COW && !lcopy {
+		linked, err := lom.CloneRef(dst)
+		if err != nil {
+			return 0, err
+		}
+		if linked {
+			return lom.Lsize(), nil
+		}
+	}
 	dst2, err := lom.Copy2FQN(dst.FQN, coi.Buf)
 	if err == nil {
 		size = lom.Lsize()
+		if coi.Xact != nil {
+			coi.Xact.DiskReadAdd(size)
+			coi.Xact.DiskWriteAdd(size)
+		}
 		if coi.Finalize {
 			t.putMirror(dst2)
 		}
@@ -1616,6 +1904,9 @@ func (coi *copyOI) _send(t *target, lom *core.LOM, sargs *sendArgs) (size int64,
 		}
 		size = lom.Lsize()
 		sargs.reader, sargs.objAttrs = reader, lom
+		if coi.Xact != nil {
+			coi.Xact.DiskReadAdd(size)
+		}
 	default:
 		// 3. DP transform (possibly, no-op)
 		// If the object is not present call t.Backend.GetObjReader
@@ -1635,6 +1926,9 @@ func (coi *copyOI) _send(t *target, lom *core.LOM, sargs *sendArgs) (size int64,
 		err = coi._dm(lom /*for attrs*/, sargs)
 	} else {
 		err = coi.put(t, sargs)
+		if err == nil && size > 0 && coi.Xact != nil {
+			coi.Xact.NetAdd(size) // (data mover counts transmit)
+		}
 	}
 	return size, err
 }
@@ -1651,8 +1945,13 @@ func (coi *copyOI) _dm(lom *core.LOM, sargs *sendArgs) error {
 		hdr.ObjName = sargs.objNameTo
 		hdr.ObjAttrs.CopyFrom(oa, false /*skip cksum*/)
 	}
-	o.Callback = func(_ *transport.ObjHdr, _ io.ReadCloser, _ any, _ error) {
+	sent := coi.Sent
+	coi.Sent = nil // (called upon completion)
+	o.Callback = func(_ *transport.ObjHdr, _ io.ReadCloser, _ any, err error) {
 		core.FreeLOM(lom)
+		if sent != nil {
+			sent(err)
+		}
 	}
 	return sargs.dm.Send(o, sargs.reader, sargs.tsi)
 }
@@ -1709,7 +2008,8 @@ func (a *putA2I) do() (int, error) {
 	}
 	// standard library does not support appending to tgz, zip, and such;
 	// for TAR there is an optimizing workaround not requiring a full copy
-	if a.mime == archive.ExtTar && !a.put /*append*/ && !a.lom.IsChunked() {
+	// (but not when sharing data with cloned object(s) - see core/lcow.go)
+	if a.mime == archive.ExtTar && !a.put /*append*/ && !a.lom.IsChunked() && !a.lom.HasRefs() {
 		var (
 			err       error
 			fh        *os.File
@@ -1858,18 +2158,18 @@ func (a *putA2I) finalize(size int64, cksum *cos.Cksum, fqn string) error {
 //
 
 func (t *target) putMirror(lom *core.LOM) {
-	mconfig := lom.MirrorConf()
-	if !mconfig.Enabled {
+	copies := lom.ExpCopies() // (per-object override or bucket's mirror config)
+	if copies < 2 {
 		return
 	}
-	if mpathCnt := fs.NumAvail(); mpathCnt < int(mconfig.Copies) {
+	if mpathCnt := fs.NumAvail(); mpathCnt < copies {
 		t.statsT.IncErr(stats.ErrPutMirrorCount)
 		nanotim := mono.NanoTime()
 		if nanotim&0x7 == 7 {
 			if mpathCnt == 0 {
 				nlog.Errorf("%s: %v", t, cmn.ErrNoMountpaths)
 			} else {
-				nlog.Errorf(fmtErrInsuffMpaths2, t, mpathCnt, lom, mconfig.Copies)
+				nlog.Errorf(fmtErrInsuffMpaths2, t, mpathCnt, lom, copies)
 			}
 		}
 		return
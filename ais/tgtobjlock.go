@@ -0,0 +1,183 @@
+// Package ais provides core functionality for the AIStore object storage.
+/*
+ * Copyright (c) 2024, NVIDIA CORPORATION. All rights reserved.
+ */
+package ais
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/NVIDIA/aistore/api/apc"
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/cmn/cos"
+	"github.com/NVIDIA/aistore/cmn/kvdb"
+	"github.com/NVIDIA/aistore/cmn/nlog"
+	"github.com/NVIDIA/aistore/core"
+	jsoniter "github.com/json-iterator/go"
+)
+
+// Advisory object locks (leases) with fencing tokens - see apc.ObjLockMsg.
+// - all lock requests for a given object are redirected to its HRW target (the owner);
+// - the lock table is persisted in the target's kvdb and survives restarts;
+// - fencing tokens are derived from wall-clock time to remain monotonic
+//   across restarts and (in most cases) across changes of ownership.
+// Writers that present `apc.HdrObjLockToken` are validated (below); others are not.
+
+const objLockCollection = "objlock"
+
+type objLocks struct {
+	db    kvdb.Driver
+	m     map[string]*apc.ObjLock // uname => lock
+	token int64                   // last issued
+	mu    sync.Mutex
+}
+
+func (ol *objLocks) init(db kvdb.Driver) {
+	ol.db = db
+	ol.m = make(map[string]*apc.ObjLock, 16)
+	all, err := db.GetAll(objLockCollection, "")
+	if err != nil {
+		if !cos.IsErrNotFound(err) {
+			nlog.Errorln("failed to load object locks:", err)
+		}
+		return
+	}
+	now := time.Now().UnixNano()
+	for uname, val := range all {
+		lock := &apc.ObjLock{}
+		if err := jsoniter.UnmarshalFromString(val, lock); err != nil || lock.Expires < now {
+			ol.db.Delete(objLockCollection, uname)
+			continue
+		}
+		ol.m[uname] = lock
+		ol.token = max(ol.token, lock.Token)
+	}
+}
+
+// (under lock)
+func (ol *objLocks) next() int64 {
+	ol.token = max(ol.token+1, time.Now().UnixNano())
+	return ol.token
+}
+
+// (under lock) returns active lock, if any
+func (ol *objLocks) active(uname string, now int64) *apc.ObjLock {
+	lock, ok := ol.m[uname]
+	if !ok {
+		return nil
+	}
+	if lock.Expires < now {
+		delete(ol.m, uname)
+		ol.db.Delete(objLockCollection, uname)
+		return nil
+	}
+	return lock
+}
+
+func (ol *objLocks) do(lom *core.LOM, action string, msg *apc.ObjLockMsg) (*apc.ObjLock, int, error) {
+	if msg.Owner == "" {
+		return nil, 0, fmt.Errorf("%s %s: lock owner must be specified", action, lom.Cname())
+	}
+	ttl := msg.TTL
+	if ttl == 0 {
+		ttl = apc.DfltObjLockTTL
+	}
+	if ttl < 0 || ttl > apc.MaxObjLockTTL {
+		return nil, 0, fmt.Errorf("%s %s: invalid TTL %v (expecting (0, %v])", action, lom.Cname(), ttl, apc.MaxObjLockTTL)
+	}
+
+	// serialize with in-flight writes
+	lom.Lock(true)
+	defer lom.Unlock(true)
+
+	var (
+		uname = lom.Uname()
+		now   = time.Now()
+	)
+	ol.mu.Lock()
+	defer ol.mu.Unlock()
+
+	lock := ol.active(uname, now.UnixNano())
+	switch action {
+	case apc.ActLockObject:
+		if lock != nil && lock.Owner != msg.Owner {
+			return nil, 0, cmn.NewErrBusy("object", lom.Cname(), "locked by "+lock.Owner)
+		}
+		lock = &apc.ObjLock{Owner: msg.Owner, Token: ol.next()}
+	case apc.ActRenewObjLock:
+		if err := validateObjLock(lock, lom, msg.Owner, msg.Token); err != nil {
+			return nil, http.StatusPreconditionFailed, err
+		}
+		lock = &apc.ObjLock{Owner: lock.Owner, Token: lock.Token}
+	case apc.ActUnlockObject:
+		if err := validateObjLock(lock, lom, msg.Owner, msg.Token); err != nil {
+			return nil, http.StatusPreconditionFailed, err
+		}
+		delete(ol.m, uname)
+		if err := ol.db.Delete(objLockCollection, uname); err != nil && !cos.IsErrNotFound(err) {
+			return nil, 0, err
+		}
+		return lock, 0, nil
+	}
+
+	lock.Expires = now.Add(ttl.D()).UnixNano()
+	if err := ol.db.Set(objLockCollection, uname, lock); err != nil {
+		return nil, 0, err
+	}
+	ol.m[uname] = lock
+	return lock, 0, nil
+}
+
+// fencing: validate `apc.HdrObjLockToken` (if present) against the current lock;
+// returns the token (zero if not present) for the caller to re-validate under wlock (see check)
+func (ol *objLocks) fence(lom *core.LOM, hdr http.Header) (int64, error) {
+	val := hdr.Get(apc.HdrObjLockToken)
+	if val == "" {
+		return 0, nil
+	}
+	token, err := strconv.ParseInt(val, 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("%s: invalid %s=%q: %v", lom.Cname(), apc.HdrObjLockToken, val, err)
+	}
+	return token, ol.check(lom, token)
+}
+
+// (the caller may hold lom wlock, which is why ol.do below takes it first)
+func (ol *objLocks) check(lom *core.LOM, token int64) error {
+	ol.mu.Lock()
+	lock := ol.active(lom.Uname(), time.Now().UnixNano())
+	ol.mu.Unlock()
+	return validateObjLock(lock, lom, "", token)
+}
+
+// POST { apc.ActLockObject | apc.ActRenewObjLock | apc.ActUnlockObject } /v1/objects/bucket-name
+func (t *target) objLock(w http.ResponseWriter, r *http.Request, lom *core.LOM, msg *apc.ActMsg) {
+	lmsg := &apc.ObjLockMsg{}
+	if err := cos.MorphMarshal(msg.Value, lmsg); err != nil {
+		t.writeErrf(w, r, cmn.FmtErrMorphUnmarshal, t, msg.Action, msg.Value, err)
+		return
+	}
+	lock, ecode, err := t.olocks.do(lom, msg.Action, lmsg)
+	if err != nil {
+		t.writeErr(w, r, err, ecode)
+		return
+	}
+	t.writeJSON(w, r, lock, msg.Action)
+}
+
+// nil lock means not locked or expired
+func validateObjLock(lock *apc.ObjLock, lom *core.LOM, owner string, token int64) error {
+	switch {
+	case lock == nil:
+		return fmt.Errorf("%s is not locked (or the lock has expired)", lom.Cname())
+	case token != lock.Token:
+		return fmt.Errorf("%s: stale or invalid fencing token %d (current %d)", lom.Cname(), token, lock.Token)
+	case owner != "" && owner != lock.Owner:
+		return fmt.Errorf("%s: locked by %s (not %s)", lom.Cname(), lock.Owner, owner)
+	}
+	return nil
+}
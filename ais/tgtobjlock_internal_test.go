@@ -0,0 +1,142 @@
+// Package ais provides core functionality for the AIStore object storage.
+/*
+ * Copyright (c) 2024, NVIDIA CORPORATION. All rights reserved.
+ */
+package ais
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+	"path/filepath"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/NVIDIA/aistore/api/apc"
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/cmn/cos"
+	"github.com/NVIDIA/aistore/cmn/kvdb"
+	"github.com/NVIDIA/aistore/core"
+	"github.com/NVIDIA/aistore/tools/tassert"
+)
+
+func TestObjLocks(tt *testing.T) {
+	dbPath := filepath.Join(tt.TempDir(), dbName)
+	db, err := kvdb.NewBuntDB(dbPath)
+	tassert.CheckFatal(tt, err)
+
+	lom := core.AllocLOM("checkpoint")
+	defer core.FreeLOM(lom)
+	tassert.CheckFatal(tt, lom.InitBck(&cmn.Bck{Name: testBucket, Provider: apc.AIS, Ns: cmn.NsGlobal}))
+
+	var ol objLocks
+	ol.init(db)
+
+	lock, _, err := ol.do(lom, apc.ActLockObject, &apc.ObjLockMsg{Owner: "w1", TTL: cos.Duration(time.Minute)})
+	tassert.CheckFatal(tt, err)
+
+	// held by another owner
+	_, _, err = ol.do(lom, apc.ActLockObject, &apc.ObjLockMsg{Owner: "w2"})
+	_, ok := err.(*cmn.ErrBusy)
+	tassert.Errorf(tt, ok, "expected busy, got %v", err)
+
+	// renew keeps the token
+	renewed, _, err := ol.do(lom, apc.ActRenewObjLock, &apc.ObjLockMsg{Owner: "w1", Token: lock.Token})
+	tassert.CheckFatal(tt, err)
+	tassert.Errorf(tt, renewed.Token == lock.Token, "renew changed token: %d vs %d", renewed.Token, lock.Token)
+
+	// fencing
+	hdr := http.Header{}
+	hdr.Set(apc.HdrObjLockToken, strconv.FormatInt(lock.Token-1, 10))
+	_, err = ol.fence(lom, hdr)
+	tassert.Errorf(tt, err != nil, "expected stale token to be rejected")
+	hdr.Set(apc.HdrObjLockToken, strconv.FormatInt(lock.Token, 10))
+	token, err := ol.fence(lom, hdr)
+	tassert.CheckError(tt, err)
+	tassert.Errorf(tt, token == lock.Token, "expected token %d, got %d", lock.Token, token)
+	token, err = ol.fence(lom, http.Header{})
+	tassert.CheckError(tt, err)
+	tassert.Errorf(tt, token == 0, "expected no token, got %d", token)
+
+	// survives restart
+	tassert.CheckFatal(tt, db.Close())
+	db, err = kvdb.NewBuntDB(dbPath)
+	tassert.CheckFatal(tt, err)
+	defer db.Close()
+	ol = objLocks{}
+	ol.init(db)
+
+	// release; re-acquire yields a higher token
+	_, _, err = ol.do(lom, apc.ActUnlockObject, &apc.ObjLockMsg{Owner: "w1", Token: lock.Token})
+	tassert.CheckFatal(tt, err)
+	next, _, err := ol.do(lom, apc.ActLockObject, &apc.ObjLockMsg{Owner: "w2"})
+	tassert.CheckFatal(tt, err)
+	tassert.Errorf(tt, next.Token > lock.Token, "expected monotonic tokens: %d vs %d", next.Token, lock.Token)
+	_, err = ol.fence(lom, hdr)
+	tassert.Errorf(tt, err != nil, "expected old token to be rejected")
+
+	// re-validation (under wlock) catches the token that's gone stale since the fence check
+	tassert.Errorf(tt, ol.check(lom, lock.Token) != nil, "expected re-validation to fail")
+	tassert.CheckError(tt, ol.check(lom, next.Token))
+}
+
+// stale fencing token: DELETE and APPEND (flush) are rejected under wlock
+func TestObjLockFence(tt *testing.T) {
+	db, err := kvdb.NewBuntDB(filepath.Join(tt.TempDir(), dbName))
+	tassert.CheckFatal(tt, err)
+	defer db.Close()
+	t.olocks = objLocks{}
+	t.olocks.init(db)
+	defer func() { t.olocks = objLocks{} }()
+	if t.owner.smap.get() == nil {
+		smap := newSmap()
+		smap.addTarget(t.si)
+		t.owner.smap.put(smap)
+	}
+
+	lom := core.AllocLOM("fenced")
+	defer core.FreeLOM(lom)
+	tassert.CheckFatal(tt, lom.InitBck(&cmn.Bck{Name: testBucket, Provider: apc.AIS, Ns: cmn.NsGlobal}))
+
+	// w1 takes the lock and loses it to w2
+	stale, _, err := t.olocks.do(lom, apc.ActLockObject, &apc.ObjLockMsg{Owner: "w1"})
+	tassert.CheckFatal(tt, err)
+	_, _, err = t.olocks.do(lom, apc.ActUnlockObject, &apc.ObjLockMsg{Owner: "w1", Token: stale.Token})
+	tassert.CheckFatal(tt, err)
+	lock, _, err := t.olocks.do(lom, apc.ActLockObject, &apc.ObjLockMsg{Owner: "w2"})
+	tassert.CheckFatal(tt, err)
+
+	apnd := func(fence int64) (int, error) {
+		a := &apndOI{started: time.Now().UnixNano(), t: t, config: cmn.GCO.Get(), lom: lom, op: apc.AppendOp}
+		a.r = io.NopCloser(bytes.NewReader([]byte("checkpoint")))
+		hdl, ecode, err := a.do(&http.Request{Header: http.Header{}})
+		if err != nil {
+			return ecode, err
+		}
+		a = &apndOI{started: time.Now().UnixNano(), t: t, config: cmn.GCO.Get(), lom: lom, op: apc.FlushOp, fence: fence}
+		tassert.CheckFatal(tt, a.parse(hdl))
+		_, ecode, err = a.do(&http.Request{Header: http.Header{}})
+		return ecode, err
+	}
+	exists := func() bool {
+		lom.Uncache()
+		return lom.Load(false /*cache it*/, false /*locked*/) == nil
+	}
+
+	// APPEND
+	ecode, err := apnd(stale.Token)
+	tassert.Errorf(tt, err != nil && ecode == http.StatusPreconditionFailed, "expected stale token to fail flush, got %v(%d)", err, ecode)
+	tassert.Errorf(tt, !exists(), "expected no object after rejected flush")
+	_, err = apnd(lock.Token)
+	tassert.CheckFatal(tt, err)
+	tassert.Fatalf(tt, exists(), "expected object after flush")
+
+	// DELETE
+	ecode, err = t.deleteObject(lom, false /*evict*/, stale.Token)
+	tassert.Errorf(tt, err != nil && ecode == http.StatusPreconditionFailed, "expected stale token to fail delete, got %v(%d)", err, ecode)
+	tassert.Errorf(tt, exists(), "expected object to survive rejected delete")
+	_, err = t.deleteObject(lom, false /*evict*/, lock.Token)
+	tassert.CheckError(tt, err)
+	tassert.Errorf(tt, !exists(), "expected object to be deleted")
+}
@@ -0,0 +1,191 @@
+// Package ais provides core functionality for the AIStore object storage.
+/*
+ * Copyright (c) 2024, NVIDIA CORPORATION. All rights reserved.
+ */
+package ais
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/NVIDIA/aistore/api/apc"
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/cmn/cos"
+	"github.com/NVIDIA/aistore/cmn/nlog"
+	"github.com/NVIDIA/aistore/core"
+	"github.com/NVIDIA/aistore/fs"
+	"github.com/NVIDIA/aistore/memsys"
+)
+
+// Range write: PATCH /v1/objects/<bucket-name>/<object-name> with "Content-Range: bytes <first>-<last>/*"
+// - writes the request body into an existing ais:// object at the specified offset;
+// - never in place: the (updated) content goes into a workfile that, once fully written and synced,
+//   replaces the object under its write lock - an interrupted write leaves the object intact;
+// - the range may extend the object but must not start beyond its current end (no holes);
+// - mirrored copies, if any, get updated as well; erasure-coded buckets are not supported;
+// - the object's version is incremented;
+// - the checksum is either recomputed right away (apc.QparamRecomputeCksum) or, by default,
+//   removed, to be recomputed and stored upon the next validation (see lom.ValidateContentChecksum)
+
+// (compare w/ parseMultiRange)
+func parseContentRange(s string) (r htrange, err error) {
+	if !strings.HasPrefix(s, cos.HdrContentRangeValPrefix) {
+		return r, fmt.Errorf("write range %q is invalid (prefix)", s)
+	}
+	s = s[len(cos.HdrContentRangeValPrefix):]
+	if i := strings.IndexByte(s, '/'); i >= 0 {
+		s = s[:i] // (the total size, if specified, is ignored)
+	}
+	i := strings.IndexByte(s, '-')
+	if i < 0 {
+		return r, fmt.Errorf("write range %q is invalid (-)", s)
+	}
+	first, err := strconv.ParseInt(strings.TrimSpace(s[:i]), 10, 64)
+	if err != nil || first < 0 {
+		return r, fmt.Errorf("write range %q is invalid (first)", s)
+	}
+	last, err := strconv.ParseInt(strings.TrimSpace(s[i+1:]), 10, 64)
+	if err != nil || last < first {
+		return r, fmt.Errorf("write range %q is invalid (last)", s)
+	}
+	r.Start, r.Length = first, last-first+1
+	return r, nil
+}
+
+func (t *target) writeRange(r *http.Request, lom *core.LOM, query url.Values) (int, error) {
+	bck := lom.Bck()
+	if !bck.IsAIS() {
+		return http.StatusNotImplemented, cmn.NewErrUnsupp("write range into", bck.Provider+":// bucket")
+	}
+	if bck.Props.EC.Enabled {
+		return http.StatusNotImplemented, cmn.NewErrUnsupp("write range into", "erasure-coded bucket")
+	}
+	rng, err := parseContentRange(r.Header.Get(cos.HdrContentRange))
+	if err != nil {
+		return http.StatusBadRequest, err
+	}
+	if r.ContentLength >= 0 && r.ContentLength != rng.Length {
+		return http.StatusBadRequest, fmt.Errorf("%s: write range length %d vs content length %d",
+			lom.Cname(), rng.Length, r.ContentLength)
+	}
+
+	lom.Lock(true)
+	defer lom.Unlock(true)
+	if err := lom.Load(false /*cache it*/, true /*locked*/); err != nil {
+		if cos.IsNotExist(err, 0) {
+			return http.StatusNotFound, err
+		}
+		return 0, err
+	}
+	size := lom.Lsize()
+	if rng.Start > size {
+		return http.StatusRequestedRangeNotSatisfiable,
+			cmn.NewErrRangeNotSatisfiable(nil, []string{r.Header.Get(cos.HdrContentRange)}, size)
+	}
+
+	var (
+		started   = time.Now()
+		workFQN   = fs.CSM.Gen(lom, fs.WorkfileType, fs.WorkfilePatch)
+		buf, slab = t.gmm.AllocSize(min(size+rng.Length, memsys.DefaultBufSize))
+	)
+	defer slab.Free(buf)
+	if err := _writeRange(lom.FQN, workFQN, r.Body, rng, buf); err != nil {
+		_rmWork(workFQN, err)
+		return 0, err
+	}
+	if err := lom.RenameFinalize(workFQN); err != nil {
+		_rmWork(workFQN, err)
+		return 0, err
+	}
+	if lom.HasCopies() {
+		if err := t._syncCopies(lom, buf); err != nil {
+			return 0, err
+		}
+	}
+
+	// update metadata
+	lom.SetSize(max(size, rng.Start+rng.Length))
+	lom.SetAtimeUnix(started.UnixNano())
+	if err := lom.IncVersion(); err != nil {
+		return 0, err
+	}
+	if cos.IsParseBool(query.Get(apc.QparamRecomputeCksum)) {
+		if _, err := lom.ComputeSetCksum(); err != nil {
+			return 0, err
+		}
+	} else {
+		lom.SetCksum(nil)
+	}
+	if err := lom.Persist(); err != nil {
+		return 0, err
+	}
+	lom.Recache()
+
+	if cmn.Rom.FastV(4, cos.SmoduleAIS) {
+		nlog.Infoln(t.String(), "write range", lom.Cname(), rng.contentRange(lom.Lsize()), time.Since(started))
+	}
+	return 0, nil
+}
+
+// update mirrored copies (same as the main replica: via workfile and rename)
+func (*target) _syncCopies(lom *core.LOM, buf []byte) error {
+	for copyFQN, mi := range lom.GetCopies() {
+		if copyFQN == lom.FQN {
+			continue
+		}
+		workFQN := mi.MakePathFQN(lom.Bucket(), fs.WorkfileType, fs.WorkfilePatch+"."+lom.ObjName)
+		if _, _, err := cos.CopyFile(lom.FQN, workFQN, buf, cos.ChecksumNone); err != nil {
+			return err
+		}
+		if err := cos.Rename(workFQN, copyFQN); err != nil {
+			_rmWork(workFQN, err)
+			return err
+		}
+	}
+	return nil
+}
+
+// copy the object's content into the workfile while writing the range over it
+func _writeRange(fqn, workFQN string, body io.Reader, rng htrange, buf []byte) error {
+	src, err := os.Open(fqn)
+	if err != nil {
+		return err
+	}
+	defer cos.Close(src)
+	wfh, err := cos.CreateFile(workFQN)
+	if err != nil {
+		return err
+	}
+	// head
+	_, err = io.CopyBuffer(wfh, io.NewSectionReader(src, 0, rng.Start), buf)
+	if err == nil {
+		// range
+		var n int64
+		n, err = io.CopyBuffer(wfh, io.LimitReader(body, rng.Length), buf)
+		if err == nil && n != rng.Length {
+			err = fmt.Errorf("%s: short write range (%d vs %d)", fqn, n, rng.Length)
+		}
+	}
+	if err == nil {
+		// tail, if any
+		if _, err = src.Seek(rng.Start+rng.Length, io.SeekStart); err == nil {
+			_, err = io.CopyBuffer(wfh, src, buf)
+		}
+	}
+	if errC := cos.FlushClose(wfh); err == nil {
+		err = errC
+	}
+	return err
+}
+
+func _rmWork(workFQN string, err error) {
+	if errRm := cos.RemoveFile(workFQN); errRm != nil && !os.IsNotExist(errRm) {
+		nlog.Errorf(fmtNested, "write range", err, "remove", workFQN, errRm)
+	}
+}
@@ -0,0 +1,169 @@
+// Package ais provides core functionality for the AIStore object storage.
+/*
+ * Copyright (c) 2024, NVIDIA CORPORATION. All rights reserved.
+ */
+package ais
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path"
+	"strings"
+	"testing"
+	"testing/iotest"
+	"time"
+
+	"github.com/NVIDIA/aistore/api/apc"
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/cmn/cos"
+	"github.com/NVIDIA/aistore/core"
+	"github.com/NVIDIA/aistore/fs"
+	"github.com/NVIDIA/aistore/tools/tassert"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Write range", func() {
+	DescribeTable("parseContentRange",
+		func(hdr string, start, length int64) {
+			r, err := parseContentRange(hdr)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(r.Start).To(Equal(start))
+			Expect(r.Length).To(Equal(length))
+		},
+		Entry("unknown total", "bytes 0-0/*", int64(0), int64(1)),
+		Entry("known total", "bytes 1024-2047/4096", int64(1024), int64(1024)),
+		Entry("no total", "bytes 10-19", int64(10), int64(10)),
+	)
+
+	DescribeTable("parseContentRange errors",
+		func(hdr string) {
+			_, err := parseContentRange(hdr)
+			Expect(err).To(HaveOccurred())
+		},
+		Entry("no prefix", "0-10/*"),
+		Entry("no dash", "bytes 10/*"),
+		Entry("suffix range", "bytes -10/*"),
+		Entry("open-ended", "bytes 10-/*"),
+		Entry("last < first", "bytes 10-9/*"),
+		Entry("not a number", "bytes a-b/*"),
+	)
+})
+
+func TestWriteRange(tt *testing.T) {
+	lom := core.AllocLOM("patched")
+	defer core.FreeLOM(lom)
+	tassert.CheckFatal(tt, lom.InitBck(&cmn.Bck{Name: testBucket, Provider: apc.AIS, Ns: cmn.NsGlobal}))
+
+	orig := []byte("0123456789")
+	poi := &putOI{
+		atime:   time.Now().UnixNano(),
+		t:       t,
+		lom:     lom,
+		r:       io.NopCloser(bytes.NewReader(orig)),
+		workFQN: path.Join(testMountpath, "patched.work"),
+		config:  cmn.GCO.Get(),
+	}
+	_, err := poi.putObject()
+	tassert.CheckFatal(tt, err)
+	defer lom.RemoveMain()
+
+	patch := func(body io.Reader, contentRange string) error {
+		r := httptest.NewRequest(http.MethodPatch, "/", body)
+		r.Header.Set(cos.HdrContentRange, contentRange)
+		_, err := t.writeRange(r, lom, url.Values{})
+		return err
+	}
+	check := func(expected string) {
+		data, err := os.ReadFile(lom.FQN)
+		tassert.CheckFatal(tt, err)
+		tassert.Errorf(tt, string(data) == expected, "expected %q, got %q", expected, data)
+		tassert.CheckFatal(tt, lom.Load(false, false))
+		tassert.Errorf(tt, lom.Lsize() == int64(len(expected)), "expected size %d, got %d", len(expected), lom.Lsize())
+		workFQN := fs.CSM.Gen(lom, fs.WorkfileType, fs.WorkfilePatch)
+		_, err = os.Stat(workFQN)
+		tassert.Errorf(tt, os.IsNotExist(err), "workfile %q must not exist (%v)", workFQN, err)
+	}
+
+	// overwrite in the middle
+	tassert.CheckFatal(tt, patch(strings.NewReader("abc"), "bytes 2-4/*"))
+	check("01abc56789")
+
+	// extend
+	tassert.CheckFatal(tt, patch(strings.NewReader("XYZ"), "bytes 9-11/*"))
+	check("01abc5678XYZ")
+
+	// client disconnects mid-write: the object remains intact
+	broken := io.MultiReader(strings.NewReader("ab"), iotest.ErrReader(io.ErrUnexpectedEOF))
+	err = patch(broken, "bytes 0-3/*")
+	tassert.Errorf(tt, err != nil, "expected write range to fail")
+	check("01abc5678XYZ")
+}
+
+// PATCH(object) custom metadata, including per-object replication override
+func TestObjPatchCopies(tt *testing.T) {
+	lom := core.AllocLOM("mirrored")
+	defer core.FreeLOM(lom)
+	tassert.CheckFatal(tt, lom.InitBck(&cmn.Bck{Name: testBucket, Provider: apc.AIS, Ns: cmn.NsGlobal}))
+
+	poi := &putOI{
+		atime:   time.Now().UnixNano(),
+		t:       t,
+		lom:     lom,
+		r:       io.NopCloser(strings.NewReader("0123456789")),
+		workFQN: path.Join(testMountpath, "mirrored.work"),
+		config:  cmn.GCO.Get(),
+	}
+	_, err := poi.putObject()
+	tassert.CheckFatal(tt, err)
+	defer lom.RemoveMain()
+
+	patch := func(objName string, custom cos.StrKVs) int {
+		body := cos.MustMarshal(apc.ActMsg{Value: custom})
+		r := httptest.NewRequest(http.MethodPatch, apc.URLPathObjects.Join(testBucket, objName), bytes.NewReader(body))
+		r.Header.Set(cos.HdrContentType, cos.ContentJSON)
+		w := httptest.NewRecorder()
+		t.objectHandler(w, r)
+		return w.Code
+	}
+	check := func(key, expected string) {
+		l := core.AllocLOM(lom.ObjName)
+		defer core.FreeLOM(l)
+		tassert.CheckFatal(tt, l.InitBck(lom.Bucket()))
+		l.UncacheUnless()
+		tassert.CheckFatal(tt, l.Load(false, false))
+		val, ok := l.GetCustomKey(key)
+		tassert.Errorf(tt, val == expected && ok == (expected != ""), "%s: expected %q, got %q (%t)", key, expected, val, ok)
+	}
+
+	code := patch(lom.ObjName, cos.StrKVs{"source": "camera-1", cmn.CopiesObjMD: "1"})
+	tassert.Fatalf(tt, code == http.StatusOK, "expected %d, got %d", http.StatusOK, code)
+	check("source", "camera-1")
+	check(cmn.CopiesObjMD, "1")
+
+	// exceeds the number of mountpaths: rejected, nothing changes
+	code = patch(lom.ObjName, cos.StrKVs{"source": "camera-2", cmn.CopiesObjMD: "2"})
+	tassert.Errorf(tt, code == http.StatusBadRequest, "expected %d, got %d", http.StatusBadRequest, code)
+	check("source", "camera-1")
+	check(cmn.CopiesObjMD, "1")
+
+	// zero copies removes the override
+	code = patch(lom.ObjName, cos.StrKVs{cmn.CopiesObjMD: "0"})
+	tassert.Errorf(tt, code == http.StatusOK, "expected %d, got %d", http.StatusOK, code)
+	check("source", "camera-1")
+	check(cmn.CopiesObjMD, "")
+
+	code = patch("does-not-exist", cos.StrKVs{"source": "camera-1"})
+	tassert.Errorf(tt, code == http.StatusNotFound, "expected %d, got %d", http.StatusNotFound, code)
+
+	// PUT with (invalid) replication override in the header
+	r := httptest.NewRequest(http.MethodPut, apc.URLPathObjects.Join(testBucket, lom.ObjName), strings.NewReader("abc"))
+	r.Header.Set(apc.HdrObjCopies, "2")
+	poi = &putOI{atime: time.Now().UnixNano(), t: t, lom: lom, config: cmn.GCO.Get()}
+	code, err = poi.do(make(http.Header), r, &dpq{})
+	tassert.Errorf(tt, err != nil && code == http.StatusBadRequest, "expected %d, got %d (%v)", http.StatusBadRequest, code, err)
+}
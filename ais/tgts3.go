@@ -190,6 +190,18 @@ func (t *target) putObjS3(w http.ResponseWriter, r *http.Request, bck *meta.Bck,
 			return
 		}
 	}
+	if err := t.throttlePut(lom, config); err != nil {
+		s3.WriteErr(w, r, err, http.StatusTooManyRequests)
+		return
+	}
+	cnt, err := t.bconns.inc(lom, r.RemoteAddr)
+	if err != nil {
+		s3.WriteErr(w, r, err, http.StatusTooManyRequests)
+		return
+	}
+	if cnt != nil {
+		defer cnt.dec(r.RemoteAddr)
+	}
 	started := time.Now()
 	lom.SetAtimeUnix(started.UnixNano())
 
@@ -356,6 +368,12 @@ func (t *target) delObjS3(w http.ResponseWriter, r *http.Request, items []string
 		s3.WriteErr(w, r, err, 0)
 		return
 	}
+	if lom.Bprops().Hook.Delete && lom.Bprops().Hook.Enabled() {
+		if ecode, err = t.callHook(lom, http.MethodDelete); err != nil {
+			s3.WriteErr(w, r, err, ecode)
+			return
+		}
+	}
 	ecode, err = t.DeleteObject(lom, false)
 	if err != nil {
 		name := lom.Cname()
@@ -63,6 +63,10 @@ func (t *target) startMpt(w http.ResponseWriter, r *http.Request, items []string
 		s3.WriteErr(w, r, err, 0)
 		return
 	}
+	if err := lom.Bprops().ObjName.Check(objName); err != nil {
+		s3.WriteErr(w, r, err, http.StatusBadRequest)
+		return
+	}
 	if bck.IsRemoteS3() {
 		uploadID, ecode, err = backend.StartMpt(lom, r, q)
 		if err != nil {
@@ -360,8 +364,8 @@ func (t *target) completeMpt(w http.ResponseWriter, r *http.Request, items []str
 	freePOI(poi)
 
 	// .6 cleanup parts - unconditionally
-	exists := s3.CleanupUpload(uploadID, lom.FQN, false /*aborted*/)
-	debug.Assert(exists)
+	// (not finding the upload at this point means it's been aborted in the meantime - see abortIncomplete)
+	s3.CleanupUpload(uploadID, lom.FQN, false /*aborted*/)
 
 	if errF != nil {
 		// NOTE: not failing if remote op. succeeded
@@ -480,17 +484,14 @@ func (t *target) listMptParts(w http.ResponseWriter, r *http.Request, bck *meta.
 // GET /?uploads&delimiter=Delimiter&encoding-type=EncodingType&key-marker=KeyMarker&
 // max-uploads=MaxUploads&prefix=Prefix&upload-id-marker=UploadIdMarker
 func (t *target) listMptUploads(w http.ResponseWriter, bck *meta.Bck, q url.Values) {
-	var (
-		maxUploads int
-		idMarker   string
-	)
+	var maxUploads int
 	if s := q.Get(s3.QparamMptMaxUploads); s != "" {
 		if v, err := strconv.Atoi(s); err == nil {
 			maxUploads = v
 		}
 	}
-	idMarker = q.Get(s3.QparamMptUploadIDMarker)
-	result := s3.ListUploads(bck.Name, idMarker, maxUploads)
+	result := s3.ListUploads(bck.Name, q.Get(s3.QparamPrefix), q.Get(s3.QparamMptKeyMarker),
+		q.Get(s3.QparamMptUploadIDMarker), maxUploads)
 	sgl := t.gmm.NewSGL(0)
 	result.MustMarshal(sgl)
 	w.Header().Set(cos.HdrContentType, cos.ContentXML)
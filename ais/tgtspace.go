@@ -5,6 +5,7 @@
 package ais
 
 import (
+	"net/http"
 	"sync"
 	"time"
 
@@ -17,6 +18,7 @@ import (
 	"github.com/NVIDIA/aistore/cmn/nlog"
 	"github.com/NVIDIA/aistore/core"
 	"github.com/NVIDIA/aistore/fs"
+	"github.com/NVIDIA/aistore/hk"
 	"github.com/NVIDIA/aistore/ios"
 	"github.com/NVIDIA/aistore/nl"
 	"github.com/NVIDIA/aistore/space"
@@ -29,6 +31,9 @@ const (
 	// - compare with cmn/cos/oom
 	// - compare with fs/health/fshc
 	minAutoDetectInterval = 10 * time.Minute
+
+	// periodic (statfs-based) detection of mountpath resizing - see recheckCap
+	capResizeIval = time.Minute
 )
 
 var (
@@ -139,11 +144,12 @@ func (t *target) runStoreCleanup(id string, wg *sync.WaitGroup, bcks ...cmn.Bck)
 		t.bcastAsyncIC(msg)
 	}
 	ini := space.IniCln{
-		Xaction: xcln.(*space.XactCln),
-		Config:  cmn.GCO.Get(),
-		StatsT:  t.statsT,
-		Buckets: bcks,
-		WG:      wg,
+		Xaction:    xcln.(*space.XactCln),
+		Config:     cmn.GCO.Get(),
+		StatsT:     t.statsT,
+		Buckets:    bcks,
+		WG:         wg,
+		ETLRunning: etlRunning,
 	}
 	xcln.AddNotif(&xact.NotifXact{
 		Base: nl.Base{When: core.UponTerm, Dsts: []string{equalIC}, F: t.notifyTerm},
@@ -151,3 +157,88 @@ func (t *target) runStoreCleanup(id string, wg *sync.WaitGroup, bcks ...cmn.Bck)
 	})
 	return space.RunCleanup(&ini)
 }
+
+//
+// online mountpath resize (e.g., LVM or cloud volume growth)
+//
+
+func (t *target) initCapCheck() {
+	hk.Reg("cap-resize"+hk.NameSuffix, func(int64) time.Duration {
+		t.recheckCap(false)
+		return capResizeIval
+	}, capResizeIval)
+}
+
+// capacity-weighted placement (apc.PlacementCapacity): total capacity in GiB
+func capWeight(cs *fs.CapStatus) uint32 {
+	return uint32(max((cs.TotalUsed+cs.TotalAvail)/cos.GiB, 1))
+}
+
+// re-detect filesystem sizes and, if changed (or forced via rescan-mp), refresh capacity
+// and re-evaluate OOS; in either case, re-announce capacity that differs from the one
+// in the current Smap (which also covers resizing while the target was down)
+func (t *target) recheckCap(force bool) {
+	var (
+		cs      fs.CapStatus
+		resized = fs.DetectResize()
+	)
+	if len(resized) > 0 || force {
+		var (
+			err, errCap error
+			config      = cmn.GCO.Get()
+		)
+		cs, err, errCap = fs.CapRefresh(config, nil /*tcdf*/)
+		if err != nil {
+			nlog.Errorln(t.String(), "failed to refresh capacity:", err)
+			return
+		}
+		if len(resized) > 0 {
+			nlog.Infoln(t.String(), "resized", resized, "- refreshed capacity:", cs.String())
+		}
+		if errCap != nil {
+			t.OOS(&cs, config, nil)
+		} else {
+			t.statsT.ClrFlag(cos.NodeAlerts, cos.OOS|cos.LowCapacity) // (grown)
+		}
+	} else {
+		cs = fs.Cap()
+	}
+	if cs.TotalUsed+cs.TotalAvail == 0 {
+		return
+	}
+	var (
+		weight = capWeight(&cs)
+		smap   = t.owner.smap.get()
+	)
+	if tsi := smap.GetTarget(t.SID()); tsi == nil || tsi.Weight == weight || smap.Primary == nil {
+		return
+	}
+	t.announceCap(smap, weight)
+}
+
+func (t *target) announceCap(smap *smapX, weight uint32) {
+	var (
+		psi = smap.Primary
+		msg = apc.ActMsg{Action: apc.ActNodeCapacity, Value: apc.ActValNodeCap{DaemonID: t.SID(), Capacity: weight}}
+	)
+	cargs := allocCargs()
+	{
+		cargs.si = psi
+		cargs.req = cmn.HreqArgs{
+			Method: http.MethodPut,
+			Base:   psi.URL(cmn.NetIntraControl),
+			Path:   apc.URLPathClu.S,
+			Body:   cos.MustMarshal(&msg),
+		}
+		cargs.timeout = cmn.Rom.CplaneOperation()
+	}
+	res := t.call(cargs, smap)
+	if res.err != nil {
+		nlog.Errorln(t.String(), "failed to announce capacity", weight, "GiB:", res.err)
+	} else {
+		t.si.Weight = weight // (so that keepalive won't revert it - see Snode.Eq)
+		nlog.Infoln(t.String(), "announced capacity", weight, "GiB")
+	}
+	freeCargs(cargs)
+	freeCR(res)
+}
@@ -0,0 +1,98 @@
+// Package ais provides core functionality for the AIStore object storage.
+/*
+ * Copyright (c) 2024, NVIDIA CORPORATION. All rights reserved.
+ */
+package ais
+
+import (
+	"encoding/csv"
+	"net/http"
+	"sync"
+
+	"github.com/NVIDIA/aistore/api/apc"
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/cmn/cos"
+	"github.com/NVIDIA/aistore/cmn/nlog"
+	"github.com/NVIDIA/aistore/core"
+	"github.com/NVIDIA/aistore/core/meta"
+	"github.com/NVIDIA/aistore/fs/mpather"
+	jsoniter "github.com/json-iterator/go"
+)
+
+// bulk stat (apc.ActStatObjects): walk local objects (all mountpaths in parallel,
+// skipping copies and misplaced) and stream their metadata, one object per line;
+// the proxy does the rest (see prxstat.go)
+
+type statw struct {
+	w    http.ResponseWriter
+	csvw *csv.Writer
+	mu   sync.Mutex
+	n    int64
+}
+
+func (t *target) statObjects(w http.ResponseWriter, r *http.Request, bck *meta.Bck, msg *apc.StatObjsMsg) {
+	sw := &statw{w: w}
+	if msg.IsCSV() {
+		sw.csvw = csv.NewWriter(w)
+		w.Header().Set(cos.HdrContentType, cos.ContentCSV)
+	} else {
+		w.Header().Set(cos.HdrContentType, cos.ContentNDJSON)
+	}
+	opts := &mpather.JgroupOpts{
+		VisitObj:              sw.visit,
+		Prefix:                msg.Prefix,
+		DoLoad:                mpather.Load,
+		SkipGloballyMisplaced: true,
+	}
+	opts.Bck.Copy(bck.Bucket())
+	jg := mpather.NewJoggerGroup(opts, cmn.GCO.Get(), nil)
+	jg.Run()
+	select {
+	case <-jg.ListenFinished():
+	case <-r.Context().Done(): // client (proxy) went away
+	}
+	err := jg.Stop()
+	if err == nil && sw.csvw != nil {
+		sw.csvw.Flush()
+		err = sw.csvw.Error()
+	}
+	if err != nil {
+		// the response is already (partially) written - the only way to
+		// let the proxy know is to abort the connection
+		nlog.Errorln(t.String(), "stat-objects", bck.Cname(msg.Prefix), "failed after", sw.n, "objects:", err)
+		panic(http.ErrAbortHandler)
+	}
+	if cmn.Rom.FastV(4, cos.SmoduleAIS) {
+		nlog.Infoln(t.String(), "stat-objects", bck.Cname(msg.Prefix), "num:", sw.n)
+	}
+}
+
+func (sw *statw) visit(lom *core.LOM, _ []byte) (err error) {
+	st := &cmn.ObjStat{
+		Name:    lom.ObjName,
+		Size:    lom.Lsize(),
+		Version: lom.Version(),
+		Atime:   lom.AtimeUnix(),
+		Custom:  lom.GetCustomMD(),
+	}
+	if cksum := lom.Checksum(); cksum != nil {
+		st.CksumType, st.CksumValue = cksum.Get()
+	}
+	if sw.csvw != nil {
+		sw.mu.Lock()
+		err = sw.csvw.Write(st.CSV())
+		sw.n++
+		sw.mu.Unlock()
+		return err
+	}
+	b, err := jsoniter.Marshal(st)
+	if err != nil {
+		return err
+	}
+	b = append(b, '\n')
+	sw.mu.Lock()
+	_, err = sw.w.Write(b)
+	sw.n++
+	sw.mu.Unlock()
+	return err
+}
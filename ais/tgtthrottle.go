@@ -0,0 +1,52 @@
+// Package ais provides core functionality for the AIStore object storage.
+/*
+ * Copyright (c) 2024, NVIDIA CORPORATION. All rights reserved.
+ */
+package ais
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/core"
+	"github.com/NVIDIA/aistore/fs"
+	"github.com/NVIDIA/aistore/stats"
+)
+
+// Utilization-based admission of client PUTs - config `disk.put_throttle_wm` and `disk.put_throttle_time`:
+// - when the utilization of the object's mountpath (as reported by ios) is at or above
+//   the watermark, the PUT is delayed, waiting for the utilization to drop;
+// - PUTs that are still over the watermark after `put_throttle_time` (capped at
+//   cmn.MaxPutThrottleTime) fail with 429 (too many requests) and can be retried;
+// - GETs are never throttled, and neither are other mountpaths - a single saturated disk
+//   does not slow down the entire target;
+// - zero watermark (default) disables throttling and costs nothing.
+
+func (t *target) throttlePut(lom *core.LOM, config *cmn.Config) error {
+	wm := config.Disk.PutThrottleWM
+	if wm <= 0 {
+		return nil
+	}
+	mpath := lom.Mountpath().Path
+	util := fs.GetMpathUtil(mpath)
+	if util < wm {
+		return nil
+	}
+	var (
+		total  = min(config.Disk.PutThrottleTime.D(), cmn.MaxPutThrottleTime)
+		ival   = config.Disk.IostatTimeShort.D() // (utilization refresh)
+		waited time.Duration
+	)
+	for waited < total {
+		sleep := min(ival, total-waited)
+		time.Sleep(sleep)
+		waited += sleep
+		if util = fs.GetMpathUtil(mpath); util < wm {
+			t.statsT.Inc(stats.PutThrottleCount)
+			return nil
+		}
+	}
+	t.statsT.Inc(stats.ErrPutThrottleCount)
+	return cmn.NewErrBusy("mountpath", mpath, "utilization "+strconv.FormatInt(util, 10)+"%")
+}
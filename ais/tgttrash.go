@@ -0,0 +1,322 @@
+// Package ais provides core functionality for the AIStore object storage.
+/*
+ * Copyright (c) 2024, NVIDIA CORPORATION. All rights reserved.
+ */
+package ais
+
+import (
+	"encoding/base64"
+	"fmt"
+	"io"
+	"io/fs"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/NVIDIA/aistore/api/apc"
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/cmn/cos"
+	"github.com/NVIDIA/aistore/cmn/nlog"
+	"github.com/NVIDIA/aistore/core"
+	"github.com/NVIDIA/aistore/core/meta"
+	mfs "github.com/NVIDIA/aistore/fs"
+	"github.com/NVIDIA/aistore/hk"
+	"github.com/NVIDIA/aistore/xact/xreg"
+)
+
+// Soft delete (see cmn.TrashConf):
+// - DELETE moves the object (main replica and its metadata) into the trash
+//   content type on the same mountpath, and removes all copies;
+// - trashed name is the original object name suffixed with deletion time - see fs.TrashContentResolver;
+// - trash is local to the target: it is neither rebalanced nor resilvered - instead,
+//   restoring target looks up (and pulls) soft-deleted objects from its peers (see restorePeer);
+// - expired content gets purged periodically (below) and on demand (apc.ActPurgeTrash).
+
+const trashPurgeIval = time.Hour
+
+func (t *target) initTrash() {
+	hk.Reg("trash-purge"+hk.NameSuffix, t.purgeTrash, trashPurgeIval)
+}
+
+// (under wlock) see t.delobj
+func (t *target) trashObj(lom *core.LOM) error {
+	tfqn := mfs.CSM.Gen(lom, mfs.TrashType, mfs.TrashTag(time.Now().UnixNano()))
+	err := lom.TrashObj(tfqn)
+	if os.IsNotExist(err) {
+		err = nil
+	}
+	return err
+}
+
+// GET { apc.ActListTrash } /v1/buckets/bucket-name
+// ActMsg.Name, if specified, is the object name prefix
+func (*target) listTrash(bck *meta.Bck, prefix string) (entries apc.TrashEntries, _ error) {
+	for _, mi := range mfs.GetAvail() {
+		dir := mi.MakePathCT(bck.Bucket(), mfs.TrashType)
+		err := filepath.WalkDir(dir, func(fqn string, de fs.DirEntry, err error) error {
+			if err != nil {
+				if os.IsNotExist(err) {
+					return nil
+				}
+				return err
+			}
+			if de.IsDir() {
+				return nil
+			}
+			rel, _ := filepath.Rel(dir, fqn)
+			objName, deleted, ok := mfs.ParseTrashName(filepath.ToSlash(rel))
+			if !ok || !strings.HasPrefix(objName, prefix) {
+				return nil
+			}
+			finfo, err := de.Info()
+			if err != nil {
+				return nil // (removed in the meantime)
+			}
+			entries = append(entries, &apc.TrashEntry{Name: objName, Deleted: deleted, Size: finfo.Size()})
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+	return entries, nil
+}
+
+// returns the latest soft-deleted version (or the one deleted at the specified time)
+func findTrashed(lom *core.LOM, deleted int64) (tfqn string, tmi *mfs.Mountpath, latest int64) {
+	for _, mi := range mfs.GetAvail() {
+		fqn := mi.MakePathFQN(lom.Bucket(), mfs.TrashType, lom.ObjName)
+		dir, base := filepath.Split(fqn)
+		names, err := os.ReadDir(dir)
+		if err != nil {
+			continue
+		}
+		for _, de := range names {
+			orig, ts, ok := mfs.ParseTrashName(de.Name())
+			if !ok || orig != base || de.IsDir() {
+				continue
+			}
+			if (deleted != 0 && ts == deleted) || (deleted == 0 && ts > latest) {
+				latest, tfqn, tmi = ts, filepath.Join(dir, de.Name()), mi
+			}
+		}
+	}
+	return tfqn, tmi, latest
+}
+
+// POST { apc.ActRestoreObject } /v1/objects/bucket-name
+func (t *target) restoreObj(lom *core.LOM, msg *apc.ActMsg) (int, error) {
+	var rmsg apc.RestoreMsg
+	if err := cos.MorphMarshal(msg.Value, &rmsg); err != nil {
+		return 0, fmt.Errorf(cmn.FmtErrMorphUnmarshal, t, msg.Action, msg.Value, err)
+	}
+
+	lom.Lock(true)
+	defer lom.Unlock(true)
+
+	err := lom.Load(false /*cache it*/, true /*locked*/)
+	if err == nil {
+		return http.StatusConflict, fmt.Errorf("%s: cannot restore %s - object exists", t, lom.Cname())
+	}
+	if !cos.IsNotExist(err, 0) {
+		return 0, err
+	}
+	tfqn, tmi, deleted := findTrashed(lom, rmsg.Deleted)
+	if tfqn == "" || rmsg.Deleted == 0 {
+		// cluster membership may have changed since the object was deleted
+		if tsi, pdeleted := t.findTrashedPeer(lom, rmsg.Deleted); tsi != nil && pdeleted > deleted {
+			return t.restorePeer(lom, tsi, pdeleted)
+		}
+	}
+	if tfqn == "" {
+		what := lom.Cname()
+		if rmsg.Deleted != 0 {
+			what += " (deleted at " + cos.FormatNanoTime(rmsg.Deleted, "") + ")"
+		}
+		return http.StatusNotFound, cos.NewErrNotFound(t, "soft-deleted "+what)
+	}
+
+	buf, slab := t.gmm.Alloc()
+	err = lom.RestoreObj(tfqn, tmi, buf)
+	slab.Free(buf)
+	if err != nil {
+		return 0, err
+	}
+	lom.Uncache()
+	return 0, lom.Load(true /*cache it*/, true /*locked*/)
+}
+
+//
+// restore soft-deleted object from another target
+//
+
+// returns the target that has the latest soft-deleted version (or the one deleted at the specified time)
+func (t *target) findTrashedPeer(lom *core.LOM, deleted int64) (tsi *meta.Snode, latest int64) {
+	smap := t.owner.smap.get()
+	if smap.CountActiveTs() < 2 {
+		return nil, 0
+	}
+	q := lom.Bck().NewQuery()
+	q.Set(apc.QparamTrashPeer, apc.TrashPeerFind)
+	args := allocBcArgs()
+	args.req = cmn.HreqArgs{
+		Method: http.MethodPost,
+		Path:   apc.URLPathObjects.Join(lom.Bck().Name),
+		Query:  q,
+		Body:   cos.MustMarshal(&apc.ActMsg{Action: apc.ActRestoreObject, Name: lom.ObjName, Value: &apc.RestoreMsg{Deleted: deleted}}),
+	}
+	args.smap = smap
+	results := t.bcastGroup(args)
+	freeBcArgs(args)
+	for _, res := range results {
+		if res.err != nil {
+			if res.status != http.StatusNotFound {
+				nlog.Warningln(t.String(), "failed to lookup soft-deleted", lom.Cname(), "at", res.si.StringEx(), res.err)
+			}
+			continue
+		}
+		if ts, err := strconv.ParseInt(string(res.bytes), 10, 64); err == nil && ts > latest {
+			tsi, latest = res.si, ts
+		}
+	}
+	freeBcastRes(results)
+	return tsi, latest
+}
+
+// (under wlock) pull soft-deleted object (along with its metadata) from `tsi`, and
+// remove it there once restored
+func (t *target) restorePeer(lom *core.LOM, tsi *meta.Snode, deleted int64) (int, error) {
+	body := cos.MustMarshal(&apc.ActMsg{Action: apc.ActRestoreObject, Name: lom.ObjName, Value: &apc.RestoreMsg{Deleted: deleted}})
+	q := lom.Bck().NewQuery()
+	q.Set(apc.QparamTrashPeer, apc.TrashPeerGet)
+	reqArgs := cmn.AllocHra()
+	{
+		reqArgs.Method = http.MethodPost
+		reqArgs.Base = tsi.URL(cmn.NetIntraData)
+		reqArgs.Header = http.Header{
+			apc.HdrCallerID:   []string{t.SID()},
+			apc.HdrCallerName: []string{t.callerName()},
+		}
+		reqArgs.Path = apc.URLPathObjects.Join(lom.Bck().Name)
+		reqArgs.Query = q
+		reqArgs.Body = body
+	}
+	req, _, cancel, err := reqArgs.ReqWithTimeout(cmn.GCO.Get().Timeout.SendFile.D())
+	cmn.FreeHra(reqArgs)
+	if err != nil {
+		return 0, err
+	}
+	defer cancel()
+	resp, err := g.client.data.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	if resp.StatusCode != http.StatusOK {
+		cos.DrainReader(resp.Body)
+		resp.Body.Close()
+		return resp.StatusCode, fmt.Errorf("%s: failed to restore %s from %s: %s", t, lom.Cname(), tsi.StringEx(), resp.Status)
+	}
+	md, err := base64.StdEncoding.DecodeString(resp.Header.Get(apc.HdrTrashedMD))
+	if err == nil {
+		buf, slab := t.gmm.Alloc()
+		err = lom.RestoreFrom(resp.Body, md, buf)
+		slab.Free(buf)
+	}
+	resp.Body.Close()
+	if err != nil {
+		return 0, err
+	}
+	lom.Uncache()
+	if err := lom.Load(true /*cache it*/, true /*locked*/); err != nil {
+		return 0, err
+	}
+
+	q.Set(apc.QparamTrashPeer, apc.TrashPeerRm)
+	cargs := allocCargs()
+	{
+		cargs.si = tsi
+		cargs.req = cmn.HreqArgs{Method: http.MethodPost, Path: apc.URLPathObjects.Join(lom.Bck().Name), Query: q, Body: body}
+		cargs.timeout = cmn.Rom.CplaneOperation()
+	}
+	res := t.call(cargs, t.owner.smap.get())
+	if res.err != nil {
+		// (will get purged upon expiration)
+		nlog.Warningln(t.String(), "restored", lom.Cname(), "but failed to remove it from", tsi.StringEx(), "trash:", res.err)
+	}
+	freeCargs(cargs)
+	freeCR(res)
+	return 0, nil
+}
+
+// POST { apc.ActRestoreObject } from the restoring target (see apc.QparamTrashPeer)
+func (t *target) trashPeer(w http.ResponseWriter, r *http.Request, bck *meta.Bck, msg *apc.ActMsg, op string) {
+	if err := t.isIntraCall(r.Header, false /*from primary*/); err != nil {
+		t.writeErr(w, r, err, http.StatusForbidden)
+		return
+	}
+	var rmsg apc.RestoreMsg
+	if err := cos.MorphMarshal(msg.Value, &rmsg); err != nil {
+		t.writeErrf(w, r, cmn.FmtErrMorphUnmarshal, t, msg.Action, msg.Value, err)
+		return
+	}
+	lom := core.AllocLOM(msg.Name)
+	defer core.FreeLOM(lom)
+	if err := lom.InitBck(bck.Bucket()); err != nil {
+		t.writeErr(w, r, err)
+		return
+	}
+	tfqn, _, deleted := findTrashed(lom, rmsg.Deleted)
+	if tfqn == "" {
+		t.writeErr(w, r, cos.NewErrNotFound(t, "soft-deleted "+lom.Cname()), http.StatusNotFound, Silent)
+		return
+	}
+	switch op {
+	case apc.TrashPeerFind:
+		w.Write(cos.UnsafeB(strconv.FormatInt(deleted, 10)))
+	case apc.TrashPeerGet:
+		md, err := mfs.GetXattr(tfqn, core.XattrLOM)
+		if err != nil {
+			t.writeErr(w, r, err)
+			return
+		}
+		fh, err := os.Open(tfqn)
+		if err != nil {
+			t.writeErr(w, r, err)
+			return
+		}
+		buf, slab := t.gmm.Alloc()
+		w.Header().Set(apc.HdrTrashedMD, base64.StdEncoding.EncodeToString(md))
+		_, err = io.CopyBuffer(w, fh, buf)
+		slab.Free(buf)
+		cos.Close(fh)
+		if err != nil {
+			nlog.Errorln(t.String(), "failed to send soft-deleted", lom.Cname(), "to", r.Header.Get(apc.HdrCallerName), err)
+		}
+	case apc.TrashPeerRm:
+		if err := cos.RemoveFile(tfqn); err != nil {
+			t.writeErr(w, r, err)
+		}
+	default:
+		t.writeErrf(w, r, "%s: invalid %s %q", t, apc.QparamTrashPeer, op)
+	}
+}
+
+// periodically purge expired content in all trash-enabled buckets
+func (t *target) purgeTrash(int64) time.Duration {
+	if !t.ClusterStarted() {
+		return trashPurgeIval
+	}
+	provider := apc.AIS
+	t.owner.bmd.get().Range(&provider, nil, func(bck *meta.Bck) bool {
+		if bck.Props.Trash.Enabled {
+			if rns := xreg.RenewPurgeTrash(cos.GenUUID(), bck, false); rns.Err != nil {
+				nlog.Warningln(t.String(), bck.Cname(""), rns.Err)
+			}
+		}
+		return false
+	})
+	return trashPurgeIval
+}
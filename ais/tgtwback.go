@@ -0,0 +1,562 @@
+// Package ais provides core functionality for the AIStore object storage.
+/*
+ * Copyright (c) 2024, NVIDIA CORPORATION. All rights reserved.
+ */
+package ais
+
+import (
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/NVIDIA/aistore/api/apc"
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/cmn/atomic"
+	"github.com/NVIDIA/aistore/cmn/cos"
+	"github.com/NVIDIA/aistore/cmn/kvdb"
+	"github.com/NVIDIA/aistore/cmn/nlog"
+	"github.com/NVIDIA/aistore/core"
+	"github.com/NVIDIA/aistore/core/meta"
+	"github.com/NVIDIA/aistore/hk"
+	jsoniter "github.com/json-iterator/go"
+)
+
+// Deferred backend deletion (write-back buckets - see cmn.WriteBackConf):
+// - DELETE removes the in-cluster copy right away and queues the backend DELETE
+//   to be executed after the bucket's `write_back.delete_delay`;
+// - cold GET of a pending-deleted object fails with http.StatusNotFound - until the
+//   backend DELETE succeeds (only then the entry gets dequeued);
+// - PUT of the same name supersedes (cancels) the pending deletion;
+// - pending deletions can be flushed (apc.ActFlushDeletes) or canceled (apc.ActCancelDeletes),
+//   and the queue itself can be inspected (apc.ActDeleteQueue);
+// - due deletions are batched when the backend supports it (core.BatchDeleter);
+// - the queue is persisted in the target's kvdb and survives restarts;
+// - upon cluster map change, entries that now belong to other targets (HRW) are
+//   handed over to those targets - the ones that will be serving (cold) GETs.
+
+const (
+	wbackIval     = 10 * time.Second
+	wbackRetries  = 3
+	wbackRetryDly = time.Minute
+
+	wbackCollection = "wback"
+)
+
+type (
+	wbdel struct {
+		Bck     cmn.Bck `json:"bck"`
+		ObjName string  `json:"name"`
+		Due     int64   `json:"due"` // Unix nanoseconds
+		Retries int     `json:"retries,omitempty"`
+	}
+	wbQueue struct {
+		db       kvdb.Driver
+		m        map[string]*wbdel // by uname
+		flushed  atomic.Int64
+		canceled atomic.Int64
+		errs     atomic.Int64
+		mu       sync.Mutex
+		running  atomic.Bool
+		again    atomic.Bool // (cluster map changed while running)
+		smapVer  int64       // handed over as of
+	}
+	// handover upon cluster map change
+	wbackSL struct {
+		t *target
+	}
+)
+
+// interface guard
+var _ meta.Slistener = (*wbackSL)(nil)
+
+func (t *target) initWriteBack(db kvdb.Driver) {
+	t.wbq.init(db)
+	t.owner.smap.Listeners().Reg(&wbackSL{t: t})
+	hk.Reg("write-back-del"+hk.NameSuffix, t.wbackHK, wbackIval)
+}
+
+// (see t.DeleteObject)
+func (t *target) delWriteBack(lom *core.LOM, fence int64) (code int, err error) {
+	lom.Lock(true)
+	if fence != 0 {
+		if err = t.olocks.check(lom, fence); err != nil {
+			lom.Unlock(true)
+			return http.StatusPreconditionFailed, err
+		}
+	}
+	code, err, _ = t.delobj(lom, false /*evict*/, true /*local only*/)
+	if err != nil && cos.IsNotExist(err, code) {
+		code, err = 0, nil // (not present in-cluster - queue anyway)
+	}
+	if err == nil {
+		t.wbq.add(lom)
+	}
+	lom.Unlock(true)
+	t.delstats(code, err, false /*evict*/, false /*isback*/)
+	return code, err
+}
+
+func (t *target) wbackHK(int64) time.Duration {
+	if t.ClusterStarted() {
+		t.wbackRun()
+	}
+	return wbackIval
+}
+
+func (t *target) wbackRun() {
+	if !t.wbq.running.CAS(false, true) {
+		t.wbq.again.Store(true)
+		return
+	}
+	go func() {
+		for {
+			t.wbq.again.Store(false)
+			t.wbackHandover()
+			t.wbackDue()
+			t.wbq.running.Store(false)
+			if !t.wbq.again.Load() || !t.wbq.running.CAS(false, true) {
+				return
+			}
+		}
+	}()
+}
+
+func (t *target) wbackDue() {
+	entries := t.wbq.due(time.Now().UnixNano())
+	if len(entries) == 0 {
+		return
+	}
+	// group by bucket
+	perBck := make(map[string][]*wbdel, 2)
+	for _, e := range entries {
+		key := cos.UnsafeS(e.Bck.MakeUname(""))
+		perBck[key] = append(perBck[key], e)
+	}
+	for _, entries := range perBck {
+		bck := meta.CloneBck(&entries[0].Bck)
+		if err := bck.Init(t.owner.bmd); err != nil {
+			nlog.Warningln(t.String(), "dropping", len(entries), "pending deletion(s) from", bck.Cname(""), "[", err, "]")
+			for _, e := range entries {
+				t.wbq.done(e)
+			}
+			continue
+		}
+		bd, ok := t.Backend(bck).(core.BatchDeleter)
+		if !ok || len(entries) == 1 {
+			for _, e := range entries {
+				t.wbackDel(bck, e)
+			}
+			continue
+		}
+		for len(entries) > 0 {
+			n := min(len(entries), core.MaxDeleteBatch)
+			t.wbackBatch(bck, bd, entries[:n])
+			entries = entries[n:]
+		}
+	}
+}
+
+func (t *target) wbackDel(bck *meta.Bck, e *wbdel) {
+	lom := core.AllocLOM(e.ObjName)
+	defer core.FreeLOM(lom)
+	if err := lom.InitBck(bck.Bucket()); err != nil {
+		nlog.Warningln(t.String(), err)
+		t.wbq.done(e)
+		return
+	}
+
+	lom.Lock(true)
+	defer lom.Unlock(true)
+
+	// canceled, superseded, or re-written in the meantime
+	if !t.wbq.queued(e) {
+		return
+	}
+	if err := lom.Load(false /*cache it*/, true /*locked*/); err == nil {
+		t.wbq.done(e)
+		t.wbq.canceled.Inc()
+		return
+	}
+	code, err := t.Backend(bck).DeleteObj(lom)
+	t.wbackFini(lom, e, code, err)
+}
+
+// same as above - a batch at a time (see also xs.evictDelete)
+func (t *target) wbackBatch(bck *meta.Bck, bd core.BatchDeleter, entries []*wbdel) {
+	var (
+		loms  = make([]*core.LOM, 0, len(entries))
+		batch = make([]*wbdel, 0, len(entries))
+		names = make([]string, 0, len(entries))
+	)
+	for _, e := range entries {
+		lom := core.AllocLOM(e.ObjName)
+		if err := lom.InitBck(bck.Bucket()); err != nil {
+			nlog.Warningln(t.String(), err)
+			t.wbq.done(e)
+			core.FreeLOM(lom)
+			continue
+		}
+		if !lom.TryLock(true) {
+			core.FreeLOM(lom) // (busy - next time)
+			continue
+		}
+		switch {
+		case !t.wbq.queued(e):
+		case lom.Load(false /*cache it*/, true /*locked*/) == nil:
+			t.wbq.done(e)
+			t.wbq.canceled.Inc()
+		default:
+			loms = append(loms, lom)
+			batch = append(batch, e)
+			names = append(names, e.ObjName)
+			continue
+		}
+		lom.Unlock(true)
+		core.FreeLOM(lom)
+	}
+	if len(names) > 0 {
+		var (
+			ecodes = make([]int, len(names))
+			errs   = make([]error, len(names))
+		)
+		ecode, err := bd.DeleteObjs(bck, names, ecodes, errs)
+		for i, lom := range loms {
+			if err != nil {
+				t.wbackFini(lom, batch[i], ecode, err)
+			} else {
+				t.wbackFini(lom, batch[i], ecodes[i], errs[i])
+			}
+		}
+	}
+	for _, lom := range loms {
+		lom.Unlock(true)
+		core.FreeLOM(lom)
+	}
+}
+
+func (t *target) wbackFini(lom *core.LOM, e *wbdel, code int, err error) {
+	if err == nil || cos.IsNotExist(err, code) {
+		t.wbq.done(e)
+		t.wbq.flushed.Inc()
+		return
+	}
+	if t.wbq.retry(e) {
+		nlog.Warningln(t.String(), "failed to delete", lom.Cname(), "from remote backend - will retry:", err, code)
+	} else {
+		nlog.Errorln(t.String(), "failed to delete", lom.Cname(), "from remote backend:", err, code)
+	}
+}
+
+// hand over pending deletions to their (new) HRW owners
+func (t *target) wbackHandover() {
+	smap := t.owner.smap.get()
+	if smap == nil || smap.validate() != nil || t.wbq.handedOver(smap.Version) {
+		return
+	}
+	perTgt := t.wbq.others(smap, t.SID())
+	for tid, entries := range perTgt {
+		tsi := smap.GetTarget(tid)
+		for len(entries) > 0 {
+			n := min(len(entries), core.MaxDeleteBatch)
+			if err := t._handover(tsi, entries[:n], smap); err != nil {
+				nlog.Warningln(t.String(), "failed to hand over", n, "pending deletion(s) to", tsi.StringEx(), "- will retry:", err)
+				return
+			}
+			t.wbq.remove(entries[:n])
+			entries = entries[n:]
+		}
+	}
+	t.wbq.setHandedOver(smap.Version)
+}
+
+func (t *target) _handover(tsi *meta.Snode, entries []*wbdel, smap *smapX) error {
+	msg := &apc.ActMsg{Action: apc.ActHandoverDeletes, Value: entries}
+	cargs := allocCargs()
+	{
+		cargs.si = tsi
+		cargs.req = cmn.HreqArgs{
+			Method: http.MethodPost,
+			Base:   tsi.URL(cmn.NetIntraControl),
+			Path:   apc.URLPathBuckets.Join(entries[0].Bck.Name),
+			Body:   cos.MustMarshal(msg),
+		}
+		cargs.timeout = cmn.Rom.CplaneOperation()
+	}
+	res := t.call(cargs, smap)
+	err := res.err
+	freeCargs(cargs)
+	freeCR(res)
+	return err
+}
+
+// POST { apc.ActHandoverDeletes } /v1/buckets (target => target)
+func (t *target) recvHandover(w http.ResponseWriter, r *http.Request, msg *apc.ActMsg) {
+	if err := t.isIntraCall(r.Header, false /*from primary*/); err != nil {
+		t.writeErr(w, r, err, http.StatusForbidden)
+		return
+	}
+	var entries []*wbdel
+	if err := cos.MorphMarshal(msg.Value, &entries); err != nil {
+		t.writeErrf(w, r, cmn.FmtErrMorphUnmarshal, t.si, msg.Action, msg.Value, err)
+		return
+	}
+	t.wbq.merge(entries)
+}
+
+// GET { apc.ActDeleteQueue } and POST { apc.ActFlushDeletes, apc.ActCancelDeletes } /v1/buckets/bucket-name
+// ActMsg.Name, if specified, is the object name prefix
+func (t *target) deleteQueue(w http.ResponseWriter, r *http.Request, bck *meta.Bck, msg *apc.ActMsg) {
+	var (
+		info   = &cmn.DeleteQueueInfo{}
+		bucket = bck.Bucket()
+	)
+	switch msg.Action {
+	case apc.ActDeleteQueue:
+	case apc.ActFlushDeletes:
+		info.Affected = t.wbq.flush(bucket, msg.Name)
+		if info.Affected > 0 {
+			t.wbackRun()
+		}
+	case apc.ActCancelDeletes:
+		info.Affected = t.wbq.cancel(bucket, msg.Name)
+	default:
+		t.writeErrAct(w, r, msg.Action)
+		return
+	}
+	t.wbq.info(bucket, msg.Name, info)
+	t.writeJSON(w, r, info, msg.Action)
+}
+
+/////////////
+// wbackSL //
+/////////////
+
+func (*wbackSL) String() string { return "write-back-del" }
+
+func (sl *wbackSL) ListenSmapChanged() {
+	if sl.t.ClusterStarted() {
+		sl.t.wbackRun()
+	}
+}
+
+/////////////
+// wbQueue //
+/////////////
+
+func (q *wbQueue) init(db kvdb.Driver) {
+	q.db = db
+	q.m = make(map[string]*wbdel, 64)
+	all, err := db.GetAll(wbackCollection, "")
+	if err != nil {
+		if !cos.IsErrNotFound(err) {
+			nlog.Errorln("failed to load pending write-back deletions:", err)
+		}
+		return
+	}
+	for uname, val := range all {
+		e := &wbdel{}
+		if err := jsoniter.UnmarshalFromString(val, e); err != nil {
+			nlog.Errorln("failed to load pending write-back deletion", uname, "err:", err)
+			q.db.Delete(wbackCollection, uname)
+			continue
+		}
+		q.m[uname] = e
+	}
+	if len(q.m) > 0 {
+		nlog.Infoln("loaded", len(q.m), "pending write-back deletion(s)")
+	}
+}
+
+func (e *wbdel) uname() string { return cos.UnsafeS(e.Bck.MakeUname(e.ObjName)) }
+
+func (e *wbdel) match(bck *cmn.Bck, prefix string) bool {
+	return e.Bck.Equal(bck) && strings.HasPrefix(e.ObjName, prefix)
+}
+
+// (under lock)
+func (q *wbQueue) _put(uname string, e *wbdel) {
+	q.m[uname] = e
+	if err := q.db.Set(wbackCollection, uname, e); err != nil {
+		nlog.Errorln("failed to persist pending deletion of", e.Bck.Cname(e.ObjName), "err:", err)
+	}
+}
+
+// (under lock)
+func (q *wbQueue) _del(uname string) {
+	delete(q.m, uname)
+	if err := q.db.Delete(wbackCollection, uname); err != nil && !cos.IsErrNotFound(err) {
+		nlog.Errorln("failed to remove pending deletion", uname, "err:", err)
+	}
+}
+
+func (q *wbQueue) add(lom *core.LOM) {
+	delay := lom.Bprops().WriteBack.DeleteDelayD()
+	e := &wbdel{Bck: *lom.Bucket(), ObjName: lom.ObjName, Due: time.Now().Add(delay).UnixNano()}
+	q.mu.Lock()
+	q._put(lom.Uname(), e)
+	q.mu.Unlock()
+}
+
+// received from another target (see wbackHandover) - keep the earliest deadline
+func (q *wbQueue) merge(entries []*wbdel) {
+	q.mu.Lock()
+	for _, e := range entries {
+		uname := e.uname()
+		if cur, ok := q.m[uname]; ok && cur.Due <= e.Due {
+			continue
+		}
+		q._put(uname, e)
+	}
+	q.mu.Unlock()
+}
+
+// PUT supersedes pending deletion
+func (q *wbQueue) drop(lom *core.LOM) {
+	q.mu.Lock()
+	if _, ok := q.m[lom.Uname()]; ok {
+		q._del(lom.Uname())
+		q.canceled.Inc()
+	}
+	q.mu.Unlock()
+}
+
+func (q *wbQueue) pending(lom *core.LOM) (ok bool) {
+	q.mu.Lock()
+	_, ok = q.m[lom.Uname()]
+	q.mu.Unlock()
+	return ok
+}
+
+// return due entries - those remain queued (and keep failing cold GETs)
+// until done or canceled
+func (q *wbQueue) due(now int64) (entries []*wbdel) {
+	q.mu.Lock()
+	for _, e := range q.m {
+		if e.Due <= now {
+			entries = append(entries, e)
+		}
+	}
+	q.mu.Unlock()
+	return entries
+}
+
+// whether still queued (not canceled or superseded)
+func (q *wbQueue) queued(e *wbdel) (ok bool) {
+	q.mu.Lock()
+	ok = q.m[e.uname()] == e
+	q.mu.Unlock()
+	return ok
+}
+
+func (q *wbQueue) done(e *wbdel) {
+	uname := e.uname()
+	q.mu.Lock()
+	if q.m[uname] == e {
+		q._del(uname)
+	}
+	q.mu.Unlock()
+}
+
+// returns false when out of retries
+func (q *wbQueue) retry(e *wbdel) bool {
+	uname := e.uname()
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	if q.m[uname] != e {
+		return true // (canceled in the meantime)
+	}
+	if e.Retries >= wbackRetries {
+		q._del(uname)
+		q.errs.Inc()
+		return false
+	}
+	e.Retries++
+	e.Due = time.Now().Add(wbackRetryDly).UnixNano()
+	q._put(uname, e)
+	return true
+}
+
+func (q *wbQueue) handedOver(ver int64) bool {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	return q.smapVer == ver
+}
+
+func (q *wbQueue) setHandedOver(ver int64) {
+	q.mu.Lock()
+	q.smapVer = ver
+	q.mu.Unlock()
+}
+
+// entries that belong to other targets, by target ID
+func (q *wbQueue) others(smap *smapX, self string) map[string][]*wbdel {
+	var perTgt map[string][]*wbdel
+	q.mu.Lock()
+	for uname, e := range q.m {
+		tsi, err := smap.HrwName2T(cos.UnsafeB(uname))
+		if err != nil || tsi.ID() == self {
+			continue
+		}
+		if perTgt == nil {
+			perTgt = make(map[string][]*wbdel, 4)
+		}
+		perTgt[tsi.ID()] = append(perTgt[tsi.ID()], e)
+	}
+	q.mu.Unlock()
+	return perTgt
+}
+
+// (handed over)
+func (q *wbQueue) remove(entries []*wbdel) {
+	q.mu.Lock()
+	for _, e := range entries {
+		if uname := e.uname(); q.m[uname] == e {
+			q._del(uname)
+		}
+	}
+	q.mu.Unlock()
+}
+
+func (q *wbQueue) flush(bck *cmn.Bck, prefix string) (n int) {
+	q.mu.Lock()
+	for uname, e := range q.m {
+		if e.match(bck, prefix) {
+			e.Due = 0
+			q._put(uname, e)
+			n++
+		}
+	}
+	q.mu.Unlock()
+	return n
+}
+
+func (q *wbQueue) cancel(bck *cmn.Bck, prefix string) (n int) {
+	q.mu.Lock()
+	for uname, e := range q.m {
+		if e.match(bck, prefix) {
+			q._del(uname)
+			n++
+		}
+	}
+	q.mu.Unlock()
+	q.canceled.Add(int64(n))
+	return n
+}
+
+func (q *wbQueue) info(bck *cmn.Bck, prefix string, info *cmn.DeleteQueueInfo) {
+	q.mu.Lock()
+	for _, e := range q.m {
+		if !e.match(bck, prefix) {
+			continue
+		}
+		info.Pending++
+		if info.NextDue == 0 || e.Due < info.NextDue {
+			info.NextDue = e.Due
+		}
+	}
+	q.mu.Unlock()
+	info.Flushed = q.flushed.Load()
+	info.Canceled = q.canceled.Load()
+	info.Errors = q.errs.Load()
+}
@@ -0,0 +1,138 @@
+// Package ais provides core functionality for the AIStore object storage.
+/*
+ * Copyright (c) 2024, NVIDIA CORPORATION. All rights reserved.
+ */
+package ais
+
+import (
+	"path/filepath"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/NVIDIA/aistore/api/apc"
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/cmn/cos"
+	"github.com/NVIDIA/aistore/cmn/kvdb"
+	"github.com/NVIDIA/aistore/core"
+	"github.com/NVIDIA/aistore/core/meta"
+	"github.com/NVIDIA/aistore/tools/tassert"
+)
+
+func TestWriteBackQueue(tt *testing.T) {
+	dbPath := filepath.Join(tt.TempDir(), dbName)
+	db, err := kvdb.NewBuntDB(dbPath)
+	tassert.CheckFatal(tt, err)
+
+	bck := &cmn.Bck{Name: testBucket, Provider: apc.AIS, Ns: cmn.NsGlobal}
+	loms := make([]*core.LOM, 3)
+	for i := range loms {
+		loms[i] = core.AllocLOM("obj-" + strconv.Itoa(i))
+		defer core.FreeLOM(loms[i])
+		tassert.CheckFatal(tt, loms[i].InitBck(bck))
+	}
+
+	var q wbQueue
+	q.init(db)
+	for _, lom := range loms {
+		q.add(lom)
+	}
+
+	// not due yet
+	now := time.Now().UnixNano()
+	tassert.Errorf(tt, len(q.due(now)) == 0, "expected nothing due")
+
+	// due but not dequeued until done
+	later := now + int64(2*cmn.DfltDeleteDelay)
+	due := q.due(later)
+	tassert.Fatalf(tt, len(due) == len(loms), "expected %d due, got %d", len(loms), len(due))
+	for _, lom := range loms {
+		tassert.Errorf(tt, q.pending(lom), "%s: expected pending", lom.Cname())
+	}
+	e0 := q.m[loms[0].Uname()]
+	q.done(e0)
+	tassert.Errorf(tt, !q.pending(loms[0]), "expected done")
+
+	// retries (then give up)
+	e1 := q.m[loms[1].Uname()]
+	for range wbackRetries {
+		tassert.Errorf(tt, q.retry(e1), "expected retry")
+		tassert.Errorf(tt, q.pending(loms[1]), "expected pending")
+	}
+	tassert.Errorf(tt, !q.retry(e1), "expected no more retries")
+	tassert.Errorf(tt, !q.pending(loms[1]) && q.errs.Load() == 1, "expected dropped")
+
+	// superseded in the meantime (PUT, and then DELETE again)
+	e2 := q.m[loms[2].Uname()]
+	q.drop(loms[2])
+	tassert.Errorf(tt, !q.queued(e2), "expected superseded")
+	q.add(loms[2])
+	q.done(e2)
+	tassert.Errorf(tt, q.pending(loms[2]), "expected pending (re-deleted)")
+
+	// handed over from another target: keep the earliest deadline
+	cur := q.m[loms[2].Uname()]
+	q.merge([]*wbdel{{Bck: *bck, ObjName: loms[2].ObjName, Due: cur.Due + 1}, {Bck: *bck, ObjName: loms[0].ObjName, Due: now}})
+	tassert.Errorf(tt, q.m[loms[2].Uname()] == cur, "expected the earlier deadline to stay")
+	tassert.Errorf(tt, q.pending(loms[0]), "expected handed over")
+
+	// survives restart
+	tassert.CheckFatal(tt, db.Close())
+	db, err = kvdb.NewBuntDB(dbPath)
+	tassert.CheckFatal(tt, err)
+	defer db.Close()
+	q = wbQueue{}
+	q.init(db)
+	tassert.Errorf(tt, q.pending(loms[0]) && !q.pending(loms[1]) && q.pending(loms[2]), "expected persisted state")
+	tassert.Errorf(tt, q.m[loms[0].Uname()].Due == now, "expected persisted deadline")
+
+	// cancel
+	tassert.Errorf(tt, q.cancel(bck, "obj-") == 2, "expected two canceled")
+	tassert.Errorf(tt, len(q.m) == 0, "expected empty queue")
+}
+
+func TestWriteBackHandover(tt *testing.T) {
+	db, err := kvdb.NewBuntDB(filepath.Join(tt.TempDir(), dbName))
+	tassert.CheckFatal(tt, err)
+	defer db.Close()
+
+	smap := newSmap()
+	for _, tid := range []string{"t1", "t2", "t3"} {
+		smap.addTarget(newSnode(tid, apc.Target, meta.NetInfo{}, meta.NetInfo{}, meta.NetInfo{}))
+	}
+
+	const num = 100
+	var (
+		q   wbQueue
+		bck = cmn.Bck{Name: testBucket, Provider: apc.AIS, Ns: cmn.NsGlobal}
+	)
+	q.init(db)
+	entries := make([]*wbdel, 0, num)
+	for i := range num {
+		entries = append(entries, &wbdel{Bck: bck, ObjName: "obj-" + strconv.Itoa(i), Due: time.Now().UnixNano()})
+	}
+	q.merge(entries)
+
+	var (
+		mine   int
+		others = q.others(smap, "t1")
+	)
+	for _, e := range entries {
+		tsi, err := smap.HrwName2T(cos.UnsafeB(e.uname()))
+		tassert.CheckFatal(tt, err)
+		if tsi.ID() == "t1" {
+			mine++
+		}
+	}
+	tassert.Errorf(tt, others["t1"] == nil, "expected no entries for self")
+	tassert.Fatalf(tt, len(others["t2"])+len(others["t3"]) == num-mine, "expected %d to hand over, got %d",
+		num-mine, len(others["t2"])+len(others["t3"]))
+	for tid, entries := range others {
+		for _, e := range entries {
+			tsi, _ := smap.HrwName2T(cos.UnsafeB(e.uname()))
+			tassert.Errorf(tt, tsi.ID() == tid, "%s: expected owner %s, got %s", e.ObjName, tsi.ID(), tid)
+		}
+		q.remove(entries)
+	}
+	tassert.Errorf(tt, len(q.m) == mine, "expected %d remaining, got %d", mine, len(q.m))
+}
@@ -87,7 +87,7 @@ func (t *target) httpxget(w http.ResponseWriter, r *http.Request) {
 	xactQuery := xreg.Flt{
 		ID: xactMsg.ID, Kind: xactMsg.Kind, Bck: bck, OnlyRunning: xactMsg.OnlyRunning,
 	}
-	t.xquery(w, r, what, xactQuery)
+	t.xquery(w, r, what, xactQuery, xactMsg.Log)
 }
 
 func (t *target) httpxput(w http.ResponseWriter, r *http.Request) {
@@ -99,6 +99,10 @@ func (t *target) httpxput(w http.ResponseWriter, r *http.Request) {
 	if err != nil {
 		return
 	}
+	if msg.Action == apc.ActPrefetchCursor {
+		t.prfCursor(w, r, msg)
+		return
+	}
 	if err := cos.MorphMarshal(msg.Value, &xargs); err != nil {
 		t.writeErrf(w, r, cmn.FmtErrMorphUnmarshal, t.si, msg.Action, msg.Value, err)
 		return
@@ -140,6 +144,9 @@ func (t *target) httpxput(w http.ResponseWriter, r *http.Request) {
 		if msg.Name == cmn.ErrXactICNotifAbort.Error() {
 			err = cmn.ErrXactICNotifAbort
 		}
+		if xargs.Reason != nil {
+			err = cmn.WithAbortReason(err, xargs.Reason)
+		}
 		flt := xreg.Flt{ID: xargs.ID, Kind: xargs.Kind, Bck: bck}
 		xreg.DoAbort(flt, err)
 	default:
@@ -147,6 +154,26 @@ func (t *target) httpxput(w http.ResponseWriter, r *http.Request) {
 	}
 }
 
+func (t *target) prfCursor(w http.ResponseWriter, r *http.Request, msg *apc.ActMsg) {
+	var cmsg apc.PrefetchCursorMsg
+	if err := cos.MorphMarshal(msg.Value, &cmsg); err != nil {
+		t.writeErrf(w, r, cmn.FmtErrMorphUnmarshal, t.si, msg.Action, msg.Value, err)
+		return
+	}
+	xctn, err := xreg.GetXact(cmsg.ID)
+	if err != nil {
+		t.writeErr(w, r, err)
+		return
+	}
+	if xctn == nil || xctn.Finished() {
+		t.writeErr(w, r, cmn.NewErrXactNotFoundError("["+cmsg.ID+"]"), http.StatusNotFound, Silent)
+		return
+	}
+	if err := xs.SetPrefetchCursor(xctn, cmsg.Cursor); err != nil {
+		t.writeErr(w, r, err)
+	}
+}
+
 func (t *target) xget(w http.ResponseWriter, r *http.Request, what, uuid string) {
 	if what != apc.WhatXactStats {
 		t.writeErrf(w, r, fmtUnknownQue, what)
@@ -165,9 +192,14 @@ func (t *target) xget(w http.ResponseWriter, r *http.Request, what, uuid string)
 	t.writeErr(w, r, err, http.StatusNotFound, Silent)
 }
 
-func (t *target) xquery(w http.ResponseWriter, r *http.Request, what string, xactQuery xreg.Flt) {
+func (t *target) xquery(w http.ResponseWriter, r *http.Request, what string, xactQuery xreg.Flt, withLog bool) {
 	stats, err := xreg.GetSnap(xactQuery)
 	if err == nil {
+		if !withLog {
+			for _, snap := range stats {
+				snap.Log, snap.LogDropped = nil, 0
+			}
+		}
 		t.writeJSON(w, r, stats, what)
 		return
 	}
@@ -229,6 +261,23 @@ func (t *target) xstart(args *xact.ArgsMsg, bck *meta.Bck, msg *apc.ActMsg) (xid
 	case apc.ActLoadLomCache:
 		rns := xreg.RenewBckLoadLomCache(args.ID, bck)
 		return xid, rns.Err
+	case apc.ActPurgeTrash:
+		rns := xreg.RenewPurgeTrash(args.ID, bck, args.Force)
+		return xid, rns.Err
+	case apc.ActAuditPlacement:
+		if err := apc.ValidateAuditFix(msg.Name); err != nil {
+			return xid, err
+		}
+		if err := xreg.LimitedCoexistence(t.si, bck, args.Kind); err != nil {
+			return xid, err
+		}
+		rns := xreg.RenewAuditPlacement(args.ID, bck, msg.Name)
+		return xid, rns.Err
+	case apc.ActMigrateCustomMD:
+		rns := xreg.RenewMigrateCustomMD(args.ID, bck)
+		return xid, rns.Err
+	case apc.ActAbortIncomplete:
+		return xid, t.runAbortIncomplete(args.ID, bck, args.Force)
 	case apc.ActBlobDl:
 		debug.Assert(msg.Name != "")
 		lom := core.AllocLOM(msg.Name)
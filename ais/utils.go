@@ -47,8 +47,9 @@ type (
 
 	// Local unicast IP info
 	localIPv4Info struct {
-		ipv4 string
-		mtu  int
+		ipnet *net.IPNet
+		ipv4  string
+		mtu   int
 	}
 )
 
@@ -105,6 +106,7 @@ func getLocalIPv4s(config *cmn.Config) (addrlist []*localIPv4Info, err error) {
 				continue
 			}
 			curr.ipv4 = ipnet.IP.String()
+			curr.ipnet = ipnet
 		}
 
 		for _, intf := range iflist {
@@ -280,6 +282,41 @@ func multihome(configuredIPv4s string) (pub string, extra []string) {
 	return pub, extra
 }
 
+// additional intra-cluster data NICs (see cmn.LocalNetConfig.HostnameIntraDataExtra)
+func dataMultihome(configuredIPv4s, proto, port string) (extra []meta.NetInfo) {
+	if configuredIPv4s == "" {
+		return nil
+	}
+	lst := strings.Split(configuredIPv4s, cmn.HostnameListSepa)
+	extra = make([]meta.NetInfo, 0, len(lst))
+	for _, addr := range lst {
+		addr = strings.TrimSpace(addr)
+		cos.ExitAssertLog(addr != "", "invalid format (empty value):", configuredIPv4s)
+		for i := range extra {
+			cos.ExitAssertLog(extra[i].Hostname != addr, "duplicated addr or hostname:", configuredIPv4s)
+		}
+		var ni meta.NetInfo
+		ni.Init(proto, addr, port)
+		extra = append(extra, ni)
+	}
+	return extra
+}
+
+// local subnets of the intra-cluster data NICs (see cmn.DataMultihomeSubnet)
+func localDataNets(addrList []*localIPv4Info, si *meta.Snode) (nets []*net.IPNet) {
+	hosts := make([]string, 0, 1+len(si.DataExtra))
+	hosts = append(hosts, si.DataNet.Hostname)
+	for i := range si.DataExtra {
+		hosts = append(hosts, si.DataExtra[i].Hostname)
+	}
+	for _, addr := range addrList {
+		if addr.ipnet != nil && cos.StringInSlice(addr.ipv4, hosts) {
+			nets = append(nets, addr.ipnet)
+		}
+	}
+	return nets
+}
+
 // choose one of the local IPv4s if local config doesn't contain (explicitly) specified
 func initNetInfo(ni *meta.NetInfo, addrList []*localIPv4Info, proto, configuredIPv4s, port string) (err error) {
 	var (
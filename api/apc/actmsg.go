@@ -34,6 +34,8 @@ const (
 	ActDsort    = "dsort"
 	ActDownload = "download"
 
+	ActPipeline = "pipeline" // composite job: DAG of prefetch, copy, and ETL steps (see cmn.PipelineMsg)
+
 	ActBlobDl = "blob-download"
 
 	ActMakeNCopies = "make-n-copies"
@@ -43,25 +45,66 @@ const (
 	ActMoveBck   = "move-bck"
 
 	ActResilver = "resilver"
+	ActBurnIn   = "burn-in" // target self-test (see BurnInMsg)
+
+	ActAuditPlacement = "audit-placement" // misplaced objects, stale copies, and orphaned workfiles (see AuditReport)
 
 	ActElection = "election"
 
-	ActLRU          = "lru"
-	ActStoreCleanup = "cleanup-store"
+	ActLRU             = "lru"
+	ActStoreCleanup    = "cleanup-store"
+	ActPurgeTrash      = "purge-trash"      // soft-deleted objects (see TrashEntry)
+	ActAbortIncomplete = "abort-incomplete" // stale multipart uploads and appends (see cmn.SpaceConf)
+	ActReclaimBck      = "reclaim-bck"      // remove destroyed bucket's data in the background (see ActDestroyBck)
+
+	ActMigrateCustomMD = "migrate-custom-md" // move custom object metadata between xattrs and sidecar files (see feat.CustomMDSidecar)
 
 	ActEvictRemoteBck = "evict-remote-bck" // evict remote bucket's data
 	ActInvalListCache = "inval-listobj-cache"
 	ActList           = "list"
+	ActStatObjects    = "stat-objects" // stream objects' metadata (see StatObjsMsg)
 	ActLoadLomCache   = "load-lom-cache"
 	ActNewPrimary     = "new-primary"
 	ActPromote        = "promote"
 	ActRenameObject   = "rename-obj"
 
+	// advisory object locks (see ObjLockMsg)
+	ActLockObject   = "lock-obj"
+	ActRenewObjLock = "renew-obj-lock"
+	ActUnlockObject = "unlock-obj"
+
+	// soft delete (see TrashEntry)
+	ActListTrash     = "list-trash"
+	ActRestoreObject = "restore-obj"
+
+	// deferred backend deletion (write-back buckets): ActMsg.Name, if specified, is the object name prefix
+	ActDeleteQueue   = "delete-queue"   // GET: show pending deletions (per target)
+	ActFlushDeletes  = "flush-deletes"  // POST: execute pending deletions now
+	ActCancelDeletes = "cancel-deletes" // POST: cancel pending deletions
+
+	// signed object manifests (dataset snapshots): Value is ManifestMsg and cmn.Manifest, respectively
+	ActMakeManifest   = "make-manifest"
+	ActVerifyManifest = "verify-manifest"
+
+	// bucket freeze: Value is FreezeMsg (freeze only)
+	ActFreezeBck = "freeze-bck"
+	ActThawBck   = "thaw-bck"
+
 	// cp (reverse)
 	ActResetStats  = "reset-stats"
 	ActResetConfig = "reset-config"
 	ActSetConfig   = "set-config"
 
+	ActSetPlacement = "set-placement" // cluster-wide object placement (see PlacementCapacity)
+	ActSetWeight    = "set-weight"    // target's weight (0..100) scales its share of objects (see ActValWeight)
+	ActNodeCapacity = "node-capacity" // (target => primary) re-announce total capacity, e.g. upon filesystem resize (see ActValNodeCap)
+
+	ActImportMD = "import-md" // restore exported cluster metadata (see ActValImportMD and WhatMDBundle)
+
+	// bucket aliases: Name is the alias; Value (set only) is meta.BckAlias
+	ActSetBckAlias = "set-bck-alias"
+	ActDelBckAlias = "del-bck-alias"
+
 	ActRotateLogs = "rotate-logs"
 
 	ActShutdownCluster = "shutdown" // see also: ActShutdownNode
@@ -72,7 +115,8 @@ const (
 	ActETLObjects      = "etl-listrange"
 	ActEvictObjects    = "evict-listrange"
 	ActPrefetchObjects = "prefetch-listrange"
-	ActArchive         = "archive" // see ArchiveMsg
+	ActPrefetchCursor  = "prefetch-cursor" // epoch-ahead prefetch: client-reported cursor (see PrefetchCursorMsg)
+	ActArchive         = "archive"         // see ArchiveMsg
 
 	ActAttachRemAis = "attach"
 	ActDetachRemAis = "detach"
@@ -103,12 +147,13 @@ const (
 
 // internal use
 const (
-	ActAddRemoteBck   = "add-remote-bck"         // add to BMD existing remote bucket, usually on the fly
-	ActRmNodeUnsafe   = "rm-unsafe"              // primary => the node to be removed
-	ActStartGFN       = "start-gfn"              // get-from-neighbor
-	ActStopGFN        = "stop-gfn"               // off
-	ActCleanupMarkers = "cleanup-markers"        // part of the target joining sequence
-	ActSelfRemove     = "self-initiated-removal" // e.g., when losing last mountpath
+	ActAddRemoteBck    = "add-remote-bck"         // add to BMD existing remote bucket, usually on the fly
+	ActRmNodeUnsafe    = "rm-unsafe"              // primary => the node to be removed
+	ActStartGFN        = "start-gfn"              // get-from-neighbor
+	ActStopGFN         = "stop-gfn"               // off
+	ActCleanupMarkers  = "cleanup-markers"        // part of the target joining sequence
+	ActSelfRemove      = "self-initiated-removal" // e.g., when losing last mountpath
+	ActHandoverDeletes = "handover-deletes"       // pending write-back deletions => new HRW owner
 )
 
 const (
@@ -121,6 +166,8 @@ const (
 	ActMountpathRescan = "rescan-mp"
 	ActMountpathFSHC   = "fshc-mp"
 
+	ActMountpathReplace = "replace-mp" // migrate contents to a new mountpath (see MpathReplaceMsg)
+
 	// Actions on xactions
 	ActXactStop  = Stop
 	ActXactStart = Start
@@ -162,6 +209,30 @@ type (
 		KeepInitialConfig bool   `json:"keep_initial_config"` // ditto (to be able to restart a node from scratch)
 		NoShutdown        bool   `json:"no_shutdown"`
 	}
+	// set target's weight, immediately or gradually - in steps:
+	// each step changes the weight by `Step` and triggers global rebalance;
+	// the next step follows `Interval` after the previous rebalance completes
+	ActValWeight struct {
+		DaemonID string       `json:"sid"`
+		Weight   int          `json:"weight"`             // [0, 100]; 100 (default) - full share; 0 - no objects
+		Step     int          `json:"step,omitempty"`     // [1, 100]; zero - no steps (all at once)
+		Interval cos.Duration `json:"interval,omitempty"` // between steps
+	}
+	// target's total capacity in GiB (Snode.Weight - see PlacementCapacity)
+	ActValNodeCap struct {
+		DaemonID string `json:"sid"`
+		Capacity uint32 `json:"capacity"`
+	}
+	// make manifest of the bucket's objects (all or only those that have the prefix)
+	ManifestMsg struct {
+		Prefix string `json:"prefix,omitempty"`
+	}
+	// import (restore) cluster metadata bundle previously exported via `WhatMDBundle`
+	ActValImportMD struct {
+		Bundle []byte     `json:"bundle"`          // as is
+		Remap  cos.StrKVs `json:"remap,omitempty"` // exported (old) target ID => current target ID
+		Force  bool       `json:"force,omitempty"` // overwrite existing buckets, if any
+	}
 )
 
 type (
@@ -169,6 +240,14 @@ type (
 		DaemonID    string `json:"daemon_id"`
 		RebalanceID string `json:"rebalance_id"`
 	}
+	ImportMDResult struct {
+		Targets       cos.StrKVs `json:"targets"`            // exported (old) target ID => current target ID
+		Unmapped      []string   `json:"unmapped,omitempty"` // exported targets with no match in the current cluster
+		Unused        []string   `json:"unused,omitempty"`   // current targets not mapped to
+		BMDVersion    int64      `json:"bmd_version,string"`
+		ConfigVersion int64      `json:"config_version,string"`
+		EtlMDVersion  int64      `json:"etlmd_version,string"`
+	}
 )
 
 // MountpathList contains two lists:
@@ -192,6 +271,7 @@ type (
 		PctUsed float64 `json:"pct_fs_used"`
 	}
 	TSysInfo struct {
+		MpathNuma map[string]int `json:"mpath_numa,omitempty"` // mountpath => NUMA node of its disk(s)
 		MemCPUInfo
 		CapacityInfo
 	}
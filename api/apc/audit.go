@@ -0,0 +1,85 @@
+// Package apc: API control messages and constants
+/*
+ * Copyright (c) 2024, NVIDIA CORPORATION. All rights reserved.
+ */
+package apc
+
+import (
+	"errors"
+	"sort"
+)
+
+// Placement audit: scan local storage for misplaced objects, stale copies, and orphaned
+// workfiles; report findings and (optionally) fix them - see xs/audit.go
+
+// fix actions (passed via ActMsg.Name when starting ActAuditPlacement)
+const (
+	AuditFixNone   = ""       // report only
+	AuditFixMove   = "move"   // relocate misplaced objects and stale copies to their HRW locations (when missing there)
+	AuditFixDelete = "delete" // remove redundant (identical) misplaced objects and stale copies, and orphaned workfiles
+	AuditFixAdopt  = "adopt"  // register redundant (identical) misplaced objects and stale copies as copies of the main replica
+)
+
+// findings
+const (
+	AuditMisplaced   = "misplaced"         // object at a non-HRW mountpath that is not a registered copy
+	AuditStaleCopy   = "stale-copy"        // copy not listed by the main replica (or the main replica is missing)
+	AuditWorkfile    = "orphaned-workfile" // workfile left behind by a previous run of the target
+	AuditWrongTarget = "wrong-target"      // object that belongs to another target (report only - see rebalance)
+)
+
+// max number of itemized findings per target (counting continues)
+const MaxAuditEntries = 1000
+
+type (
+	AuditEntry struct {
+		Kind  string `json:"kind"`
+		FQN   string `json:"fqn"`
+		Cname string `json:"cname,omitempty"` // bucket/object (n/a for workfiles)
+		Fixed string `json:"fixed,omitempty"` // fix action taken, if any
+		Err   string `json:"err,omitempty"`   // failed to fix, or why the fix did not apply
+		Size  int64  `json:"size"`
+	}
+	AuditCount struct {
+		Found int64 `json:"found"`
+		Fixed int64 `json:"fixed"`
+		Size  int64 `json:"size"` // total size of the found ones
+	}
+	// per target
+	AuditReport struct {
+		Counts    map[string]*AuditCount `json:"counts"` // by finding
+		Fix       string                 `json:"fix,omitempty"`
+		Entries   []*AuditEntry          `json:"entries,omitempty"`
+		Truncated bool                   `json:"truncated,omitempty"` // more than MaxAuditEntries
+	}
+)
+
+func ValidateAuditFix(fix string) error {
+	switch fix {
+	case AuditFixNone, AuditFixMove, AuditFixDelete, AuditFixAdopt:
+		return nil
+	}
+	return errors.New("invalid audit fix action '" + fix + "' (expecting one of: move, delete, adopt, or none)")
+}
+
+/////////////////
+// AuditReport //
+/////////////////
+
+func (rep *AuditReport) Found() (n int64) {
+	for _, c := range rep.Counts {
+		n += c.Found
+	}
+	return n
+}
+
+// sorted by kind, and then by FQN
+func (rep *AuditReport) Sort() {
+	sort.Slice(rep.Entries, func(i, j int) bool {
+		ei, ej := rep.Entries[i], rep.Entries[j]
+		if ei.Kind != ej.Kind {
+			return ei.Kind < ej.Kind
+		}
+		return ei.FQN < ej.FQN
+	})
+}
@@ -4,6 +4,8 @@
  */
 package apc
 
+import "github.com/NVIDIA/aistore/cmn/cos"
+
 type (
 	// to generate bucket summary (or summaries)
 	BsummCtrlMsg struct {
@@ -19,6 +21,7 @@ type (
 		ObjCount struct {
 			Present uint64 `json:"obj_count_present,string"`
 			Remote  uint64 `json:"obj_count_remote,string"`
+			Shared  uint64 `json:"obj_count_shared,string,omitempty"` // sharing data with other objects (copy-on-write clones)
 		}
 		ObjSize struct {
 			Min int64 `json:"obj_min_size"`
@@ -26,12 +29,40 @@ type (
 			Max int64 `json:"obj_max_size"`
 		}
 		TotalSize struct {
-			OnDisk      uint64 `json:"size_on_disk,string"`          // sum(dir sizes) aka "apparent size"
-			PresentObjs uint64 `json:"size_all_present_objs,string"` // sum(cached object sizes)
-			RemoteObjs  uint64 `json:"size_all_remote_objs,string"`  // sum(all object sizes in a remote bucket)
+			OnDisk      uint64 `json:"size_on_disk,string"`               // sum(dir sizes) aka "apparent size"
+			PresentObjs uint64 `json:"size_all_present_objs,string"`      // sum(cached object sizes)
+			RemoteObjs  uint64 `json:"size_all_remote_objs,string"`       // sum(all object sizes in a remote bucket)
+			SharedObjs  uint64 `json:"size_shared_objs,string,omitempty"` // sum(sizes of the objects that share data)
 			Disks       uint64 `json:"total_disks_size,string"`
 		}
 		UsedPct      uint64 `json:"used_pct"`
 		IsBckPresent bool   `json:"is_present"` // in BMD
+		// current freeze, if any (added by AIS gateway)
+		Frozen *FreezeState `json:"frozen,omitempty"`
+		// human-readable renderings of the above sizes and percentage (added by AIS gateway)
+		Units *BsummUnits `json:"units,omitempty"`
+	}
+	BsummUnits struct {
+		ObjMin      cos.Quantity `json:"obj_min_size"`
+		ObjAvg      cos.Quantity `json:"obj_avg_size"`
+		ObjMax      cos.Quantity `json:"obj_max_size"`
+		OnDisk      cos.Quantity `json:"size_on_disk"`
+		PresentObjs cos.Quantity `json:"size_all_present_objs"`
+		RemoteObjs  cos.Quantity `json:"size_all_remote_objs"`
+		Disks       cos.Quantity `json:"total_disks_size"`
+		UsedPct     cos.Quantity `json:"used_pct"`
 	}
 )
+
+func (bs *BsummResult) SetUnits() {
+	bs.Units = &BsummUnits{
+		ObjMin:      cos.BytesQty(bs.ObjSize.Min),
+		ObjAvg:      cos.BytesQty(bs.ObjSize.Avg),
+		ObjMax:      cos.BytesQty(bs.ObjSize.Max),
+		OnDisk:      cos.BytesQty(int64(bs.TotalSize.OnDisk)),
+		PresentObjs: cos.BytesQty(int64(bs.TotalSize.PresentObjs)),
+		RemoteObjs:  cos.BytesQty(int64(bs.TotalSize.RemoteObjs)),
+		Disks:       cos.BytesQty(int64(bs.TotalSize.Disks)),
+		UsedPct:     cos.PercentQty(int64(bs.UsedPct)),
+	}
+}
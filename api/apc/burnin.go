@@ -0,0 +1,124 @@
+// Package apc: API control messages and constants
+/*
+ * Copyright (c) 2024, NVIDIA CORPORATION. All rights reserved.
+ */
+package apc
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/NVIDIA/aistore/cmn/cos"
+)
+
+// Burn-in: synthetic write/read/verify load across all mountpaths of a given target
+// (typically, new hardware - prior to joining the cluster) - see xs/burnin.go
+
+const (
+	DfltBurnInDuration = 10 * time.Minute
+	DfltBurnInObjSize  = 4 * cos.MiB
+	DfltBurnInWorkers  = 2 // per mountpath
+
+	MaxBurnInObjSize = 64 * cos.MiB
+	MaxBurnInWorkers = 64
+)
+
+type (
+	BurnInMsg struct {
+		Duration cos.Duration `json:"duration,omitempty"` // DfltBurnInDuration when zero
+		ObjSize  int64        `json:"objsize,omitempty"`  // size of synthetic objects; DfltBurnInObjSize when zero
+		Workers  int          `json:"workers,omitempty"`  // intensity: concurrent workers per mountpath; DfltBurnInWorkers when zero
+
+		// pass/fail criteria (zero - do not check)
+		MinThroughput int64        `json:"min_throughput,omitempty"` // min write and read throughput per mountpath (bytes/s)
+		MaxLatency    cos.Duration `json:"max_latency,omitempty"`    // max average write and read latency
+		MaxErrors     int64        `json:"max_errors,omitempty"`     // max number of I/O and verification errors (zero: none allowed)
+	}
+
+	BurnInMpath struct {
+		Mpath      string `json:"mpath"`
+		WriteBytes int64  `json:"write_bytes"`
+		ReadBytes  int64  `json:"read_bytes"`
+		WriteBps   int64  `json:"write_bps"`
+		ReadBps    int64  `json:"read_bps"`
+		WriteLat   int64  `json:"write_lat_ns"` // average
+		ReadLat    int64  `json:"read_lat_ns"`  // ditto
+		Errors     int64  `json:"errors"`       // I/O errors
+		CksumErrs  int64  `json:"cksum_errors"` // verification (read-back) failures
+	}
+	BurnInReport struct {
+		Xid     string         `json:"xid"`
+		Msg     BurnInMsg      `json:"msg"`
+		Started time.Time      `json:"started"`
+		Ended   time.Time      `json:"ended,omitempty"`
+		Mpaths  []*BurnInMpath `json:"mpaths"`
+		Reason  string         `json:"reason,omitempty"` // when failed or aborted
+		Running bool           `json:"running"`
+		Passed  bool           `json:"passed"`
+	}
+)
+
+///////////////
+// BurnInMsg //
+///////////////
+
+// validates and fills in defaults
+func (msg *BurnInMsg) Validate() error {
+	if msg.Duration < 0 || msg.ObjSize < 0 || msg.Workers < 0 || msg.MinThroughput < 0 || msg.MaxLatency < 0 || msg.MaxErrors < 0 {
+		return fmt.Errorf("burn-in: invalid (negative) value in %+v", *msg)
+	}
+	if msg.Duration == 0 {
+		msg.Duration = cos.Duration(DfltBurnInDuration)
+	}
+	if msg.ObjSize == 0 {
+		msg.ObjSize = DfltBurnInObjSize
+	}
+	if msg.ObjSize > MaxBurnInObjSize {
+		return fmt.Errorf("burn-in: object size %s exceeds the maximum %s", cos.ToSizeIEC(msg.ObjSize, 0), cos.ToSizeIEC(MaxBurnInObjSize, 0))
+	}
+	if msg.Workers == 0 {
+		msg.Workers = DfltBurnInWorkers
+	}
+	if msg.Workers > MaxBurnInWorkers {
+		return fmt.Errorf("burn-in: number of workers %d exceeds the maximum %d", msg.Workers, MaxBurnInWorkers)
+	}
+	return nil
+}
+
+//////////////////
+// BurnInReport //
+//////////////////
+
+// Judge returns empty string if all (non-zero) pass/fail criteria are met;
+// otherwise, the first violation
+func (rep *BurnInReport) Judge() string {
+	var (
+		errs int64
+		msg  = &rep.Msg
+	)
+	if len(rep.Mpaths) == 0 {
+		return "no mountpaths"
+	}
+	for _, mp := range rep.Mpaths {
+		errs += mp.Errors + mp.CksumErrs
+		if msg.MinThroughput > 0 {
+			if mp.WriteBps < msg.MinThroughput {
+				return fmt.Sprintf("%s: write throughput %s/s below the minimum %s/s", mp.Mpath,
+					cos.ToSizeIEC(mp.WriteBps, 1), cos.ToSizeIEC(msg.MinThroughput, 1))
+			}
+			if mp.ReadBps < msg.MinThroughput {
+				return fmt.Sprintf("%s: read throughput %s/s below the minimum %s/s", mp.Mpath,
+					cos.ToSizeIEC(mp.ReadBps, 1), cos.ToSizeIEC(msg.MinThroughput, 1))
+			}
+		}
+		if msg.MaxLatency > 0 {
+			if lat := time.Duration(max(mp.WriteLat, mp.ReadLat)); lat > msg.MaxLatency.D() {
+				return fmt.Sprintf("%s: average latency %v exceeds the maximum %v", mp.Mpath, lat, msg.MaxLatency)
+			}
+		}
+	}
+	if errs > msg.MaxErrors {
+		return fmt.Sprintf("%d error(s) (max allowed %d)", errs, msg.MaxErrors)
+	}
+	return ""
+}
@@ -61,6 +61,17 @@ const (
 	HdrObjCustomMD  = aisPrefix + "Custom-Md"      // Object custom metadata.
 	HdrObjVersion   = aisPrefix + "Version"        // Object version/generation - ais or cloud.
 
+	// PUT: per-object replication override - number of copies regardless of the bucket's mirror config
+	HdrObjCopies = aisPrefix + "Obj-Copies"
+
+	// GET and PUT: client-supplied request deadline - either absolute (Unix time, nanoseconds)
+	// or relative to the time the request is received (e.g., "5s", "500ms");
+	// upon expiration, the request is aborted with 504 (Gateway Timeout)
+	HdrDeadline = aisPrefix + "Deadline"
+
+	// fencing token of the (advisory) object lock - see ObjLockMsg
+	HdrObjLockToken = aisPrefix + "Lock-Token"
+
 	// Append object header
 	HdrAppendHandle = aisPrefix + "Append-Handle"
 
@@ -76,6 +87,9 @@ const (
 	// uptimes, respectively
 	HdrNodeUptime    = aisPrefix + "Node-Uptime"
 	HdrClusterUptime = aisPrefix + "Cluster-Uptime"
+
+	// admission control: 503 responses also include standard Retry-After (seconds)
+	HdrAdmitQueue = aisPrefix + "Admit-Queue" // number of requests currently waiting for the cluster to get ready
 )
 
 // AuthN consts
@@ -104,6 +118,12 @@ const (
 
 	// EC
 	HdrActiveEC = aisPrefix + "Ec"
+
+	// proxy => primary (keepalive): node load (see IC staffing)
+	HdrNodeLoad = aisPrefix + "Node-Load"
+
+	// target => target: (packed) metadata of the soft-deleted object that's being restored
+	HdrTrashedMD = aisPrefix + "Trashed-Md"
 )
 
 const lais = len(aisPrefix)
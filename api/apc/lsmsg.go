@@ -129,6 +129,7 @@ const (
 	GetPropsEC       = "ec"
 	GetPropsCustom   = "custom"
 	GetPropsLocation = "location" // advanced usage
+	GetPropsMtime    = "mtime"    // last modified (as reported by remote backend or, if unavailable, the time the object was written)
 )
 
 const GetPropsNameSize = GetPropsName + LsPropsSepa + GetPropsSize
@@ -141,7 +142,8 @@ var (
 
 	GetPropsDefaultAIS = []string{GetPropsName, GetPropsSize, GetPropsChecksum, GetPropsAtime}
 	GetPropsAll        = []string{GetPropsName, GetPropsSize, GetPropsChecksum, GetPropsAtime,
-		GetPropsVersion, GetPropsCached, GetPropsStatus, GetPropsCopies, GetPropsEC, GetPropsCustom, GetPropsLocation}
+		GetPropsVersion, GetPropsCached, GetPropsStatus, GetPropsCopies, GetPropsEC, GetPropsCustom, GetPropsLocation,
+		GetPropsMtime}
 )
 
 type LsoMsg struct {
@@ -155,6 +157,8 @@ type LsoMsg struct {
 	Flags             uint64      `json:"flags,string"`          // enum {LsObjCached, ...} - "LsoMsg flags" above
 	PageSize          int64       `json:"pagesize"`              // max entries returned by list objects call
 	Header            http.Header `json:"hdr,omitempty"`         // (for pointers, see `ListArgs` in api/ls.go)
+	Filter            *ObjFilter  `json:"filter,omitempty"`      // (optional) server-side filtering by name, size, and/or mtime
+	Sort              *LsoSort    `json:"sort,omitempty"`        // (optional) server-side ordering by size or mtime (top-N, single page)
 }
 
 ////////////
@@ -0,0 +1,51 @@
+// Package apc: API control messages and constants
+/*
+ * Copyright (c) 2024, NVIDIA CORPORATION. All rights reserved.
+ */
+package apc
+
+import (
+	"errors"
+	"fmt"
+)
+
+// Sorted list-objects (LsoMsg.Sort): the N largest (smallest) or newest (oldest) objects
+// in a bucket or under a given prefix, where N is the requested page size:
+// - each target walks its local objects keeping only its own top N (bounded memory);
+// - the proxy merges the targets' results into the resulting top N - a single page
+//   without continuation token;
+// - supported for in-cluster objects: ais:// buckets and remote buckets with LsObjCached;
+// - incompatible with archive listing (LsArchDir), non-recursive listing (LsNoRecursion),
+//   and pagination (continuation token, start-after).
+// Ties are broken by object name (ascending).
+
+// sort keys
+const (
+	LsSortBySize  = "size"
+	LsSortByMtime = "mtime" // see GetPropsMtime
+)
+
+const MaxLsoSortLimit = MaxPageSizeAIS
+
+type LsoSort struct {
+	By   string `json:"by"`             // LsSortBySize | LsSortByMtime
+	Desc bool   `json:"desc,omitempty"` // largest (newest) first
+}
+
+func (s *LsoSort) Validate(lsmsg *LsoMsg) error {
+	switch s.By {
+	case LsSortBySize, LsSortByMtime:
+	default:
+		return fmt.Errorf("invalid sort key %q (expecting %q or %q)", s.By, LsSortBySize, LsSortByMtime)
+	}
+	if lsmsg.PageSize < 0 || lsmsg.PageSize > MaxLsoSortLimit {
+		return fmt.Errorf("invalid number of sorted entries %d (expecting (0, %d])", lsmsg.PageSize, MaxLsoSortLimit)
+	}
+	if lsmsg.ContinuationToken != "" || lsmsg.StartAfter != "" {
+		return errors.New("sorted list-objects returns a single page (continuation and start-after are not supported)")
+	}
+	if lsmsg.IsFlagSet(LsArchDir) || lsmsg.IsFlagSet(LsNoRecursion) {
+		return errors.New("sorted list-objects is incompatible with listing archived content and non-recursive listing")
+	}
+	return nil
+}
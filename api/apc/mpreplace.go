@@ -0,0 +1,61 @@
+// Package apc: API control messages and constants
+/*
+ * Copyright (c) 2024, NVIDIA CORPORATION. All rights reserved.
+ */
+package apc
+
+import (
+	"errors"
+	"time"
+)
+
+// Mountpath replacement: old disk => new disk on the same target, with no resilvering
+// and no rebalancing - see xs/mpreplace.go
+
+const (
+	MpathReplaceCopy    = "copy"     // copying contents (possibly, multiple passes)
+	MpathReplaceSwap    = "swap"     // replacing old mountpath with the new one
+	MpathReplaceCatchUp = "catch-up" // copying what's been written to the old one in the meantime
+	MpathReplaceDone    = "done"
+)
+
+type (
+	MpathReplaceMsg struct {
+		Src   string `json:"src"`             // existing (available) mountpath
+		Dst   string `json:"dst"`             // new mountpath (must exist and must not be attached)
+		Label string `json:"label,omitempty"` // new mountpath's label; the same as src when empty
+
+		MaxBps     int64 `json:"max_bps,omitempty"`     // copy throughput limit (bytes/s); zero - unlimited
+		SkipVerify bool  `json:"skip_verify,omitempty"` // do not read back and verify (xxhash) copied files
+	}
+
+	MpathReplaceReport struct {
+		Xid     string          `json:"xid"`
+		Msg     MpathReplaceMsg `json:"msg"`
+		Started time.Time       `json:"started"`
+		Ended   time.Time       `json:"ended,omitempty"`
+		Swapped time.Time       `json:"swapped,omitempty"` // when the new mountpath took over
+		Stage   string          `json:"stage"`             // one of the MpathReplace* enum above
+		Reason  string          `json:"reason,omitempty"`  // when aborted
+		Passes  int             `json:"passes"`            // copy passes, including the catch-up one
+		Files   int64           `json:"files"`             // copied
+		Bytes   int64           `json:"bytes"`             // ditto
+		Skipped int64           `json:"skipped"`           // files found to be already identical
+		Removed int64           `json:"removed"`           // files removed from the new mountpath (no longer present in the old one)
+		Errors  int64           `json:"errors"`
+		Running bool            `json:"running"`
+	}
+)
+
+func (msg *MpathReplaceMsg) Validate() error {
+	if msg.Src == "" || msg.Dst == "" {
+		return errors.New("replace-mountpath: source and destination mountpaths must be specified")
+	}
+	if msg.Src == msg.Dst {
+		return errors.New("replace-mountpath: source and destination mountpaths must be different")
+	}
+	if msg.MaxBps < 0 {
+		return errors.New("replace-mountpath: invalid (negative) throughput limit")
+	}
+	return nil
+}
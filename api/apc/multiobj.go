@@ -4,6 +4,8 @@
  */
 package apc
 
+import "github.com/NVIDIA/aistore/cmn/cos"
+
 // (common for all multi-object operations)
 type (
 	// List of object names _or_ a template specifying { optional Prefix, zero or more Ranges }
@@ -27,8 +29,24 @@ type PrefetchMsg struct {
 	NumWorkers      int   `json:"num-workers"`    // number of concurrent workers; 0 - number of mountpaths (default); (-1) none
 	ContinueOnError bool  `json:"coer"`           // ignore non-critical errors, keep going
 	LatestVer       bool  `json:"latest-ver"`     // when true & in-cluster: check with remote whether (deleted | version-changed)
+
+	// Epoch-ahead scheduling: the list (or range template) is the client's (e.g., next training epoch's)
+	// access order. Instead of prefetching everything at once, stay `Ahead` objects ahead of the client-reported
+	// cursor (see PrefetchCursorMsg), and/or pace the job to complete by `Deadline` (counting from the start).
+	// Objects behind the cursor (i.e., already read by the client) are skipped.
+	Ahead    int64        `json:"ahead,omitempty"`
+	Deadline cos.Duration `json:"deadline,omitempty"`
 }
 
+// client-reported position in the prefetch order, i.e. the number of objects
+// (from the beginning of the list or range) the client has already read
+type PrefetchCursorMsg struct {
+	ID     string `json:"xid"`
+	Cursor int64  `json:"cursor,string"`
+}
+
+func (msg *PrefetchMsg) IsPaced() bool { return msg.Ahead > 0 || msg.Deadline > 0 }
+
 // ArchiveMsg contains the parameters (all except the destination bucket)
 // for archiving mutiple objects as one of the supported archive.FileExtensions types
 // at the specified (bucket) destination.
@@ -0,0 +1,117 @@
+// Package apc: API control messages and constants
+/*
+ * Copyright (c) 2024, NVIDIA CORPORATION. All rights reserved.
+ */
+package apc
+
+import (
+	"fmt"
+	"regexp"
+	"time"
+)
+
+// Object filter: selects objects by name, size, and/or last-modified time.
+// Used by list-objects (LsoMsg.Filter) - to filter on the targets, before the results
+// fan in to the proxy - and copy/transform bucket (CopyBckMsg.Filter).
+// Last-modified time is the one reported by the remote backend or, for in-cluster
+// objects that have none, the time the object was written.
+
+type (
+	ObjFilter struct {
+		Include   string `json:"include,omitempty"`    // regex: select only objects with matching names
+		Exclude   string `json:"exclude,omitempty"`    // regex: skip objects with matching names
+		MinSize   int64  `json:"min_size,omitempty"`   // select objects that are at least this size
+		MaxSize   int64  `json:"max_size,omitempty"`   // ... and at most (zero: no limit)
+		ModAfter  string `json:"mod_after,omitempty"`  // RFC3339: select objects last modified at or after ...
+		ModBefore string `json:"mod_before,omitempty"` // ... and before
+	}
+	// compiled ObjFilter
+	ObjMatcher struct {
+		include, exclude *regexp.Regexp
+		minSize, maxSize int64
+		after, before    time.Time
+	}
+)
+
+///////////////
+// ObjFilter //
+///////////////
+
+func (f *ObjFilter) IsEmpty() bool {
+	return f == nil || (f.Include == "" && f.Exclude == "" && f.MinSize == 0 && f.MaxSize == 0 &&
+		f.ModAfter == "" && f.ModBefore == "")
+}
+
+// (object size and/or last-modified time are required to match)
+func (f *ObjFilter) NeedsProps() bool {
+	return f != nil && (f.MinSize != 0 || f.MaxSize != 0 || f.ModAfter != "" || f.ModBefore != "")
+}
+
+// Compile validates the filter; returns nil matcher when there's nothing to filter.
+func (f *ObjFilter) Compile() (m *ObjMatcher, err error) {
+	if f.IsEmpty() {
+		return nil, nil
+	}
+	m = &ObjMatcher{minSize: f.MinSize, maxSize: f.MaxSize}
+	if f.Include != "" {
+		if m.include, err = regexp.Compile(f.Include); err != nil {
+			return nil, fmt.Errorf("invalid filter (include): %w", err)
+		}
+	}
+	if f.Exclude != "" {
+		if m.exclude, err = regexp.Compile(f.Exclude); err != nil {
+			return nil, fmt.Errorf("invalid filter (exclude): %w", err)
+		}
+	}
+	if f.MinSize < 0 || f.MaxSize < 0 || (f.MaxSize > 0 && f.MinSize > f.MaxSize) {
+		return nil, fmt.Errorf("invalid filter (size range): [%d, %d]", f.MinSize, f.MaxSize)
+	}
+	if f.ModAfter != "" {
+		if m.after, err = time.Parse(time.RFC3339, f.ModAfter); err != nil {
+			return nil, fmt.Errorf("invalid filter (mod_after): %w", err)
+		}
+	}
+	if f.ModBefore != "" {
+		if m.before, err = time.Parse(time.RFC3339, f.ModBefore); err != nil {
+			return nil, fmt.Errorf("invalid filter (mod_before): %w", err)
+		}
+		if !m.after.IsZero() && !m.after.Before(m.before) {
+			return nil, fmt.Errorf("invalid filter (time range): [%s, %s)", f.ModAfter, f.ModBefore)
+		}
+	}
+	return m, nil
+}
+
+////////////////
+// ObjMatcher //
+////////////////
+
+func (m *ObjMatcher) NeedsMtime() bool { return !m.after.IsZero() || !m.before.IsZero() }
+
+func (m *ObjMatcher) MatchName(name string) bool {
+	if m.include != nil && !m.include.MatchString(name) {
+		return false
+	}
+	return m.exclude == nil || !m.exclude.MatchString(name)
+}
+
+// size < 0: unknown; zero mtime: unknown (in both cases, not filtering)
+func (m *ObjMatcher) Match(name string, size int64, mtime time.Time) bool {
+	if !m.MatchName(name) {
+		return false
+	}
+	if size >= 0 {
+		if size < m.minSize || (m.maxSize > 0 && size > m.maxSize) {
+			return false
+		}
+	}
+	if !mtime.IsZero() {
+		if !m.after.IsZero() && mtime.Before(m.after) {
+			return false
+		}
+		if !m.before.IsZero() && !mtime.Before(m.before) {
+			return false
+		}
+	}
+	return true
+}
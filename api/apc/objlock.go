@@ -0,0 +1,33 @@
+// Package apc: API control messages and constants
+/*
+ * Copyright (c) 2024, NVIDIA CORPORATION. All rights reserved.
+ */
+package apc
+
+import (
+	"time"
+
+	"github.com/NVIDIA/aistore/cmn/cos"
+)
+
+// Advisory object locks (leases) that distributed writers can use to coordinate
+// without a separate lock service. Each successful acquisition yields a fencing
+// token that (optionally) accompanies subsequent writes via `HdrObjLockToken`.
+
+const (
+	DfltObjLockTTL = cos.Duration(30 * time.Second)
+	MaxObjLockTTL  = cos.Duration(time.Hour)
+)
+
+type (
+	ObjLockMsg struct {
+		Owner string       `json:"owner"`                  // lock holder (client-defined, non-empty)
+		TTL   cos.Duration `json:"ttl,omitempty"`          // lease duration; DfltObjLockTTL when zero
+		Token int64        `json:"token,string,omitempty"` // fencing token (required to renew and release)
+	}
+	ObjLock struct {
+		Owner   string `json:"owner"`
+		Token   int64  `json:"token,string"`   // fencing token: monotonically increasing
+		Expires int64  `json:"expires,string"` // Unix time (nanoseconds)
+	}
+)
@@ -0,0 +1,22 @@
+// Package apc: API control messages and constants
+/*
+ * Copyright (c) 2024, NVIDIA CORPORATION. All rights reserved.
+ */
+package apc
+
+import "fmt"
+
+// cluster-wide object placement (see Smap.Placement and ActSetPlacement)
+const (
+	PlacementHRW      = "hrw"      // plain rendezvous hashing: uniform distribution (default)
+	PlacementCapacity = "capacity" // rendezvous hashing weighted by target capacity
+)
+
+var SupportedPlacement = [...]string{PlacementHRW, PlacementCapacity}
+
+func ValidatePlacement(mode string) error {
+	if mode == "" || mode == PlacementHRW || mode == PlacementCapacity {
+		return nil
+	}
+	return fmt.Errorf("invalid placement %q (expecting one of %v)", mode, SupportedPlacement)
+}
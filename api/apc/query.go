@@ -8,12 +8,20 @@ package apc
 const (
 	QparamWhat = "what" // "smap" | "bmd" | "config" | "stats" | "xaction" ... (enum below)
 
+	// select response fields: comma-separated JSON paths, e.g. "tmap,version" or "mirror.enabled"
+	// (applies to JSON responses and bucket props - see cos.SelectFields)
+	QparamFields = "fields"
+
 	QparamProps = "props" // e.g. "checksum, size"|"atime, size"|"cached"|"bucket, size"| ...
 
 	QparamUUID    = "uuid"     // xaction
 	QparamJobID   = "jobid"    // job
 	QparamETLName = "etl_name" // etl
 
+	QparamETLSamples = "etl_samples" // etl: number of objects to transform (per target) when estimating
+
+	QparamETLObject = "etl_object" // etl: name of the content transformed on behalf of a peer target
+
 	QparamRegex      = "regex"       // dsort: list regex
 	QparamOnlyActive = "only_active" // dsort: list only active
 
@@ -21,6 +29,10 @@ const (
 	// NOTE: making an s/_/-/ naming exception because of the namesake CLI usage
 	QparamNewCustom = "set-new-custom"
 
+	// write range (PATCH with Content-Range): recompute object checksum right away
+	// (default: remove it, to be lazily recomputed upon the next validation)
+	QparamRecomputeCksum = "recompute_cksum"
+
 	// Main bucket query params.
 	QparamProvider  = "provider" // aka backend provider or, simply, backend
 	QparamNamespace = "namespace"
@@ -65,10 +77,21 @@ const (
 	// HTTP bucket support.
 	QparamOrigURL = "original_url"
 
+	// node stats history: [since, until] Unix time in nanoseconds (see `config.Periodic.StatsHistory`)
+	QparamSince = "since"
+	QparamUntil = "until"
+
+	// what=top: number of entries (default: stats.DfltTopN)
+	QparamTopN = "top_n"
+
 	// Get logs
 	QparamLogSev  = "severity" // see { LogInfo, ...} enum
 	QparamLogOff  = "offset"
 	QparamAllLogs = "all"
+	// log query: filter (and tail) recent log lines; use QparamSince and QparamUntil for time filtering
+	QparamLogRegex  = "regex"
+	QparamLogTail   = "tail"   // max number of (most recent) lines
+	QparamLogFollow = "follow" // keep streaming new lines until the client disconnects
 
 	// The following 4 (four) QparamArch* parameters are all intended for usage with sharded datasets,
 	// whereby the shards are (.tar, .tgz (or .tar.gz), .zip, and/or .tar.lz4) formatted objects.
@@ -117,6 +140,9 @@ const (
 	// - attach invalid mountpath
 	QparamForce = "frc"
 
+	// AuthN bulk user provisioning: update existing users (see authn.UsersMsg.Update)
+	QparamUpdateUsers = "update-users"
+
 	// same as `Versioning.ValidateWarmGet` (cluster config and bucket props)
 	// - usage: GET and (copy|transform) x (bucket|multi-object) operations
 	// - implies remote backend
@@ -174,6 +200,7 @@ const (
 	QparamHealthReadiness = "readiness" // to be used by external watchdogs (e.g. K8s)
 	QparamAskPrimary      = "apr"       // true: the caller is directing health request to primary
 	QparamPrimaryReadyReb = "prr"       // true: check whether primary is ready to start rebalancing cluster
+	QparamHealthDeep      = "deep"      // true: (primary) end-to-end data-path probe - see cmn.HealthProbe
 )
 
 // Internal query params.
@@ -184,6 +211,7 @@ const (
 	QparamNonElectable     = "nel" // true: proxy is non-electable for the primary role
 	QparamUnixTime         = "utm" // Unix time since 01/01/70 UTC (nanoseconds)
 	QparamIsGFNRequest     = "gfn" // true if the request is a Get-From-Neighbor
+	QparamDeadline         = "dln" // request deadline (Unix time, nanoseconds) - propagates apc.HdrDeadline
 	QparamRebStatus        = "rbs" // true: get detailed rebalancing status
 	QparamRebData          = "rbd" // true: get EC rebalance data (pulling data if push way fails)
 	QparamClusterInfo      = "cii" // true: /Health to return `cos.NodeStateInfo` including cluster metadata versions and state flags
@@ -191,6 +219,9 @@ const (
 
 	QparamDontResilver = "dntres" // true: do not resilver data off of mountpaths that are being disabled/detached
 
+	// restore soft-deleted object that resides on another target: { TrashPeerFind, TrashPeerGet, TrashPeerRm }
+	QparamTrashPeer = "tpr"
+
 	// dsort
 	QparamTotalCompressedSize       = "tcs"
 	QparamTotalInputShardsExtracted = "tise"
@@ -207,17 +238,30 @@ const (
 
 	// Notification target's node ID (usually, the node that initiates the operation).
 	QparamNotifyMe = "nft"
+
+	// set-config: validate the update and return all violations (if any) without applying it
+	QparamValidateOnly = "validate_only"
+
+	// xaction status: wait (block) server-side up to the specified duration (e.g., "30s")
+	// for the xaction to finish - long-poll in place of client-side polling
+	QparamWait = "wait"
+
+	// unified jobs API: filter by job type (cmn.JobType* enum) and kind
+	QparamJobType = "type"
+	QparamJobKind = "kind"
 )
 
 // QparamWhat enum.
 const (
 	// cluster metadata
-	WhatSmap = "smap"
-	WhatBMD  = "bmd"
+	WhatSmap     = "smap"
+	WhatBMD      = "bmd"
+	WhatMDBundle = "md_bundle" // all cluster-level metadata (Smap, BMD, RMD, config, EtlMD) in a single signed blob
 
 	// config
 	WhatNodeConfig    = "config"         // query specific node for (cluster config + overrides, local config)
 	WhatClusterConfig = "cluster_config" // as the name implies; identical (compressed, checksummed, versioned) copy on each node
+	WhatConfigSchema  = "config_schema"  // all config fields: type, default, scope, whether updatable at runtime (see cmn.ConfigFieldSchema)
 
 	// configured backends
 	WhatBackends = "backends"
@@ -235,9 +279,13 @@ const (
 	// assorted
 	WhatMountpaths = "mountpaths"
 	WhatRemoteAIS  = "remote"
+	WhatFederation = "federation" // all attached remote AIS clusters: health, buckets, capacity, and stats
 	WhatSmapVote   = "smapvote"
 	WhatSysInfo    = "sysinfo"
+	WhatBurnIn     = "burn_in"    // target's (last or current) burn-in report
 	WhatTargetIPs  = "target_ips" // comma-separated list of all target IPs (compare w/ GetWhatSnode)
+	WhatTop        = "top"        // top-N objects and prefixes by request rate and bytes (see stats.TopReport and QparamTopN)
+	WhatCapacity   = "capacity"   // target: capacity history; cluster: capacity planning report (see stats.CapReport)
 
 	// log
 	WhatLog = "log"
@@ -255,6 +303,9 @@ const (
 
 	// tls
 	WhatCertificate = "tls_certificate"
+
+	// AuthN: state of propagating user and role changes to registered clusters
+	WhatACLSync = "acl_sync"
 )
 
 // QparamLogSev enum.
@@ -0,0 +1,23 @@
+// Package apc: API control messages and constants
+/*
+ * Copyright (c) 2024, NVIDIA CORPORATION. All rights reserved.
+ */
+package apc
+
+// Bulk stat (ActStatObjects): stream metadata of all objects in a bucket (or,
+// optionally, under a given prefix) - one object per line, unsorted.
+// Each target walks its own (local) objects and streams their metadata, while
+// the proxy merges the targets' streams - no list-objects paging involved.
+// Designed to build external indices of very large (100M+ objects) buckets.
+
+const (
+	StatFmtNDJSON = "ndjson" // one JSON-encoded cmn.ObjStat per line (default)
+	StatFmtCSV    = "csv"    // header (cmn.ObjStatCSVHeader) followed by comma-separated values
+)
+
+type StatObjsMsg struct {
+	Prefix string `json:"prefix,omitempty"`
+	Format string `json:"format,omitempty"` // enum { StatFmtNDJSON, StatFmtCSV }
+}
+
+func (msg *StatObjsMsg) IsCSV() bool { return msg.Format == StatFmtCSV }
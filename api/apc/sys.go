@@ -12,11 +12,12 @@ import (
 )
 
 type MemCPUInfo struct {
-	MemUsed    uint64      `json:"mem_used"`
-	MemAvail   uint64      `json:"mem_avail"`
-	PctMemUsed float64     `json:"pct_mem_used"`
-	PctCPUUsed float64     `json:"pct_cpu_used"`
-	LoadAvg    sys.LoadAvg `json:"load_avg"`
+	MemUsed    uint64        `json:"mem_used"`
+	MemAvail   uint64        `json:"mem_avail"`
+	PctMemUsed float64       `json:"pct_mem_used"`
+	PctCPUUsed float64       `json:"pct_cpu_used"`
+	LoadAvg    sys.LoadAvg   `json:"load_avg"`
+	Numa       *sys.NumaInfo `json:"numa,omitempty"` // topology and effective affinity (Linux)
 }
 
 func GetMemCPU() MemCPUInfo {
@@ -39,5 +40,6 @@ func GetMemCPU() MemCPUInfo {
 		PctMemUsed: float64(proc.Mem.Resident) * 100 / float64(mem.Total),
 		PctCPUUsed: proc.CPU.Percent,
 		LoadAvg:    load,
+		Numa:       sys.Numa(),
 	}
 }
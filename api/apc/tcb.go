@@ -6,6 +6,7 @@ package apc
 
 import (
 	"errors"
+	"fmt"
 	"strings"
 
 	"github.com/NVIDIA/aistore/cmn/cos"
@@ -20,6 +21,13 @@ type (
 		Force     bool   `json:"force"`       // force running in presence of "limited coexistence" type conflicts
 		LatestVer bool   `json:"latest-ver"`  // see also: QparamLatestVer, 'versioning.validate_warm_get', PrefetchMsg
 		Sync      bool   `json:"synchronize"` // see also: 'versioning.synchronize'
+		COW       bool   `json:"cow"`         // clone ais:// bucket: destination objects share data with the source (copy-on-write)
+
+		// (optional) selection of source objects, in addition to Prefix
+		Filter *ObjFilter `json:"filter,omitempty"`
+
+		// max copying throughput, in bytes per second per target (zero: unlimited)
+		MaxBW int64 `json:"max_bw,omitempty"`
 	}
 	Transform struct {
 		Name    string       `json:"id,omitempty"`
@@ -33,9 +41,26 @@ type (
 		// - this field might not be any longer required - TODO review
 		Ext cos.StrKVs `json:"ext"`
 
+		// (offline transform) ID of the interrupted job to resume: targets skip objects
+		// that have been transformed as per the job's last checkpoint
+		Resume string `json:"resume,omitempty"`
+
 		Transform
 		CopyBckMsg
 	}
+
+	// pre-run estimate of the offline (bucket-to-bucket) transformation - see api.ETLBucketEstimate;
+	// includes only objects present in the cluster and, when resuming, not yet transformed;
+	// cluster-wide: sums of the per-target values except SampleTime and ETA (the slowest target)
+	TCBEstimate struct {
+		Targets     map[string]*TCBEstimate `json:"targets,omitempty"` // per target (cluster-wide only)
+		ObjCount    int64                   `json:"obj_count"`         // number of source objects to transform
+		Size        int64                   `json:"size"`              // their total size
+		SampleCount int64                   `json:"sample_count"`      // number of transformed samples
+		SampleSize  int64                   `json:"sample_size"`       // total size of the samples (source bytes)
+		SampleTime  cos.Duration            `json:"sample_time"`       // time to transform the samples
+		ETA         cos.Duration            `json:"eta"`               // projected time to transform all
+	}
 )
 
 ////////////
@@ -44,9 +69,21 @@ type (
 
 func (msg *TCBMsg) Validate(isEtl bool) (err error) {
 	if isEtl && msg.Transform.Name == "" {
-		err = errors.New("ETL name can't be empty")
+		return errors.New("ETL name can't be empty")
+	}
+	return msg.CopyBckMsg.Validate()
+}
+
+////////////////
+// CopyBckMsg //
+////////////////
+
+func (msg *CopyBckMsg) Validate() error {
+	if msg.MaxBW < 0 {
+		return fmt.Errorf("invalid max_bw %d (expecting non-negative bytes per second)", msg.MaxBW)
 	}
-	return
+	_, err := msg.Filter.Compile()
+	return err
 }
 
 // Replace extension and add suffix if provided.
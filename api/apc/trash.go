@@ -0,0 +1,31 @@
+// Package apc: API control messages and constants
+/*
+ * Copyright (c) 2024, NVIDIA CORPORATION. All rights reserved.
+ */
+package apc
+
+// Soft delete (a.k.a. trash): when enabled on a bucket (see `cmn.TrashConf`),
+// deleted objects are retained for the bucket's configured period and can be
+// listed (ActListTrash), restored (ActRestoreObject), and purged (ActPurgeTrash).
+
+// QparamTrashPeer values (intra-cluster)
+const (
+	TrashPeerFind = "find" // lookup soft-deleted object - respond with its deletion time
+	TrashPeerGet  = "get"  // send soft-deleted object to the restoring target
+	TrashPeerRm   = "rm"   // remove soft-deleted object once restored
+)
+
+type (
+	TrashEntry struct {
+		Name    string `json:"name"`
+		Deleted int64  `json:"deleted,string"` // deletion time (Unix nanoseconds); identifies the version to restore
+		Size    int64  `json:"size,string"`
+	}
+	TrashEntries []*TrashEntry
+
+	RestoreMsg struct {
+		// deletion time of the version to restore (see TrashEntry);
+		// zero selects the most recently deleted one
+		Deleted int64 `json:"deleted,string,omitempty"`
+	}
+)
@@ -32,6 +32,7 @@ const (
 	Clusters  = "clusters" // AuthN
 	Roles     = "roles"    // AuthN
 	IC        = "ic"       // information center
+	Jobs      = "jobs"     // unified view: xactions, dsort, downloads, and ETLs
 
 	// l3 ---
 
@@ -63,6 +64,7 @@ const (
 	FinishedAck = "finished_ack"
 	UList       = "list"
 	Remove      = "remove"
+	Queue       = "queue"
 	Next        = "next"
 	Peek        = "peek"
 	Discard     = "discard"
@@ -71,15 +73,19 @@ const (
 	LoadX509 = "load-x509"
 
 	// ETL
-	ETL        = "etl"
-	ETLInfo    = "info"
-	ETLList    = UList
-	ETLLogs    = "logs"
-	ETLObject  = "_object"
-	ETLStop    = Stop
-	ETLStart   = Start
-	ETLHealth  = "health"
-	ETLMetrics = "metrics"
+	ETL         = "etl"
+	ETLInfo     = "info"
+	ETLList     = UList
+	ETLLogs     = "logs"
+	ETLObject   = "_object"
+	ETLStop     = Stop
+	ETLStart    = Start
+	ETLHealth   = "health"
+	ETLMetrics  = "metrics"
+	ETLEstimate = "estimate"
+
+	ETLDetails   = "details"    // init message and per-target status (see etl.Details)
+	ETLTransform = "_transform" // (intra-cluster) transform on behalf of a peer target
 )
 
 // RESTful l3, internal use
@@ -153,11 +159,14 @@ var (
 	URLPathdSortMetrics = urlpath(Version, Sort, Metrics)
 	URLPathdSortAck     = urlpath(Version, Sort, FinishedAck)
 	URLPathdSortRemove  = urlpath(Version, Sort, Remove)
+	URLPathdSortQueue   = urlpath(Version, Sort, Queue)
 
 	URLPathDownload       = urlpath(Version, Download)
 	URLPathDownloadAbort  = urlpath(Version, Download, Abort)
 	URLPathDownloadRemove = urlpath(Version, Download, Remove)
 
+	URLPathJobs = urlpath(Version, Jobs)
+
 	URLPathETL       = urlpath(Version, ETL)
 	URLPathETLObject = urlpath(Version, ETL, ETLObject)
 
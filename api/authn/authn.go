@@ -7,6 +7,7 @@ package authn
 import (
 	"errors"
 	"net/http"
+	"net/url"
 	"sort"
 	"time"
 
@@ -58,6 +59,64 @@ func DeleteUser(bp api.BaseParams, userID string) error {
 	return reqParams.DoRequest()
 }
 
+// AddUsers creates (and, if `msg.Update` is set, updates) multiple users in one call,
+// instantiating role templates with per-user parameters. Returns per-user results -
+// use `UsersResult.Err()` to check whether all users have been provisioned.
+// (see also: ParseUsersCSV)
+func AddUsers(bp api.BaseParams, msg *UsersMsg) (*UsersResult, error) {
+	bp.Method = http.MethodPut
+	reqParams := api.AllocRp()
+	defer api.FreeRp(reqParams)
+	{
+		reqParams.BaseParams = bp
+		reqParams.Path = apc.URLPathUsers.S
+		reqParams.Body = cos.MustMarshal(msg)
+		reqParams.Header = http.Header{cos.HdrContentType: []string{cos.ContentJSON}}
+	}
+	res := &UsersResult{}
+	if _, err := reqParams.DoReqAny(res); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// ChangePassword changes user's own password given either the current password or
+// admin-issued one-time reset token (see IssueResetToken). Does not require admin permissions.
+// The new password must comply with the AuthN password policy (see PasswordConf).
+func ChangePassword(bp api.BaseParams, userID string, msg *PasswordMsg) error {
+	if err := msg.Validate(); err != nil {
+		return err
+	}
+	bp.Method = http.MethodPost
+	reqParams := api.AllocRp()
+	defer api.FreeRp(reqParams)
+	{
+		reqParams.BaseParams = bp
+		reqParams.Path = apc.URLPathUsers.Join(userID, PathPassword)
+		reqParams.Body = cos.MustMarshal(msg)
+		reqParams.Header = http.Header{cos.HdrContentType: []string{cos.ContentJSON}}
+	}
+	return reqParams.DoRequest()
+}
+
+// IssueResetToken (admin) returns one-time password reset token for the specified user.
+// The token is valid for the configured `password.reset_token_ttl` - the user then
+// calls ChangePassword with PasswordMsg.ResetToken.
+func IssueResetToken(bp api.BaseParams, userID string) (*ResetTokenMsg, error) {
+	bp.Method = http.MethodPut
+	reqParams := api.AllocRp()
+	defer api.FreeRp(reqParams)
+	{
+		reqParams.BaseParams = bp
+		reqParams.Path = apc.URLPathUsers.Join(userID, PathReset)
+	}
+	res := &ResetTokenMsg{}
+	if _, err := reqParams.DoReqAny(res); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
 // Authorize a user and return a user token in case of success.
 // The token expires in `expire` time. If `expire` is `nil` the expiration
 // time is set by AuthN (default AuthN expiration time is 24 hours)
@@ -81,6 +140,30 @@ func LoginUser(bp api.BaseParams, userID, pass string, expire *time.Duration) (t
 	return token, nil
 }
 
+// Same as LoginUser with optional token restrictions: explicit validity window
+// and source networks (see LoginMsg)
+func LoginUserExt(bp api.BaseParams, userID string, msg *LoginMsg) (token *TokenMsg, err error) {
+	if err := msg.Validate(); err != nil {
+		return nil, err
+	}
+	bp.Method = http.MethodPost
+	reqParams := api.AllocRp()
+	defer api.FreeRp(reqParams)
+	{
+		reqParams.BaseParams = bp
+		reqParams.Path = apc.URLPathUsers.Join(userID)
+		reqParams.Body = cos.MustMarshal(msg)
+		reqParams.Header = http.Header{cos.HdrContentType: []string{cos.ContentJSON}}
+	}
+	if _, err = reqParams.DoReqAny(&token); err != nil {
+		return nil, err
+	}
+	if token.Token == "" {
+		return nil, errors.New("login failed: empty response from AuthN server")
+	}
+	return token, nil
+}
+
 func RegisterCluster(bp api.BaseParams, cluSpec CluACL) error {
 	msg := cos.MustMarshal(cluSpec)
 	bp.Method = http.MethodPost
@@ -144,6 +227,23 @@ func GetRegisteredClusters(bp api.BaseParams, spec CluACL) ([]*CluACL, error) {
 	return rec, err
 }
 
+// GetACLSync returns the state of propagating user and role changes to registered clusters
+func GetACLSync(bp api.BaseParams) (*ACLSync, error) {
+	bp.Method = http.MethodGet
+	reqParams := api.AllocRp()
+	defer api.FreeRp(reqParams)
+	{
+		reqParams.BaseParams = bp
+		reqParams.Path = apc.URLPathClusters.S
+		reqParams.Query = url.Values{apc.QparamWhat: []string{apc.WhatACLSync}}
+	}
+	res := &ACLSync{}
+	if _, err := reqParams.DoReqAny(res); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
 func GetRole(bp api.BaseParams, roleID string) (*Role, error) {
 	if roleID == "" {
 		return nil, errors.New("missing role ID")
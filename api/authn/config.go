@@ -10,18 +10,26 @@ import (
 	"strconv"
 	"sync"
 	"time"
+	"unicode"
 
 	"github.com/NVIDIA/aistore/cmn/cos"
 	"github.com/NVIDIA/aistore/cmn/debug"
 	"github.com/NVIDIA/aistore/cmn/jsp"
 )
 
+const (
+	DfltResetTTL       = time.Hour // see PasswordConf.ResetTTL
+	MaxPasswordLen     = 72        // (bcrypt)
+	MaxPasswordHistory = 24
+)
+
 type (
 	Config struct {
-		Log     LogConf     `json:"log"`
-		Net     NetConf     `json:"net"`
-		Server  ServerConf  `json:"auth"`
-		Timeout TimeoutConf `json:"timeout"`
+		Log      LogConf      `json:"log"`
+		Net      NetConf      `json:"net"`
+		Server   ServerConf   `json:"auth"`
+		Timeout  TimeoutConf  `json:"timeout"`
+		Password PasswordConf `json:"password"`
 		// private
 		mu sync.RWMutex `json:"-"`
 	}
@@ -48,13 +56,29 @@ type (
 	TimeoutConf struct {
 		Default cos.Duration `json:"default_timeout"`
 	}
+	// password policy (all zeros: no restrictions)
+	PasswordConf struct {
+		MinLen     int          `json:"min_len"`         // minimum length
+		MinClasses int          `json:"min_classes"`     // minimum number of character classes: lowercase, uppercase, digits, and other
+		MaxAge     cos.Duration `json:"max_age"`         // force rotation upon login when older (zero: never)
+		History    int          `json:"history"`         // number of most recent passwords (including the current one) that cannot be reused
+		ResetTTL   cos.Duration `json:"reset_token_ttl"` // validity of admin-issued one-time reset tokens (dflt. DfltResetTTL)
+	}
 	ConfigToUpdate struct {
-		Server *ServerConfToSet `json:"auth"`
+		Server   *ServerConfToSet   `json:"auth"`
+		Password *PasswordConfToSet `json:"password,omitempty"`
 	}
 	ServerConfToSet struct {
 		Secret *string `json:"secret,omitempty"`
 		Expire *string `json:"expiration_time,omitempty"`
 	}
+	PasswordConfToSet struct {
+		MinLen     *int    `json:"min_len,omitempty"`
+		MinClasses *int    `json:"min_classes,omitempty"`
+		MaxAge     *string `json:"max_age,omitempty"`
+		History    *int    `json:"history,omitempty"`
+		ResetTTL   *string `json:"reset_token_ttl,omitempty"`
+	}
 	// TokenList is a list of tokens pushed by authn
 	TokenList struct {
 		Tokens  []string `json:"tokens"`
@@ -94,9 +118,17 @@ func (c *Config) SetSecret(val *string) {
 }
 
 func (c *Config) ApplyUpdate(cu *ConfigToUpdate) error {
-	if cu.Server == nil {
+	if cu.Server == nil && cu.Password == nil {
 		return errors.New("configuration is empty")
 	}
+	if cu.Password != nil {
+		if err := c.Password.apply(cu.Password); err != nil {
+			return err
+		}
+	}
+	if cu.Server == nil {
+		return nil
+	}
 	if cu.Server.Secret != nil {
 		if *cu.Server.Secret == "" {
 			return errors.New("secret not defined")
@@ -114,3 +146,102 @@ func (c *Config) ApplyUpdate(cu *ConfigToUpdate) error {
 	}
 	return nil
 }
+
+func (c *Config) PassConf() (pc PasswordConf) {
+	c.mu.RLock()
+	pc = c.Password
+	c.mu.RUnlock()
+	return pc
+}
+
+//////////////////
+// PasswordConf //
+//////////////////
+
+func (pc *PasswordConf) apply(toSet *PasswordConfToSet) error {
+	np := *pc
+	if toSet.MinLen != nil {
+		np.MinLen = *toSet.MinLen
+	}
+	if toSet.MinClasses != nil {
+		np.MinClasses = *toSet.MinClasses
+	}
+	if toSet.History != nil {
+		np.History = *toSet.History
+	}
+	for _, d := range []struct {
+		val *string
+		dst *cos.Duration
+	}{{toSet.MaxAge, &np.MaxAge}, {toSet.ResetTTL, &np.ResetTTL}} {
+		if d.val == nil {
+			continue
+		}
+		dur, err := time.ParseDuration(*d.val)
+		if err != nil {
+			return fmt.Errorf("invalid time format %s: %v", *d.val, err)
+		}
+		*d.dst = cos.Duration(dur)
+	}
+	if err := np.Validate(); err != nil {
+		return err
+	}
+	*pc = np
+	return nil
+}
+
+func (pc *PasswordConf) Validate() error {
+	switch {
+	case pc.MinLen < 0 || pc.MinLen > MaxPasswordLen:
+		return fmt.Errorf("invalid password.min_len %d (expecting [0, %d])", pc.MinLen, MaxPasswordLen)
+	case pc.MinClasses < 0 || pc.MinClasses > 4:
+		return fmt.Errorf("invalid password.min_classes %d (expecting [0, 4])", pc.MinClasses)
+	case pc.MaxAge < 0:
+		return fmt.Errorf("invalid password.max_age %v (must be non-negative)", pc.MaxAge)
+	case pc.History < 0 || pc.History > MaxPasswordHistory:
+		return fmt.Errorf("invalid password.history %d (expecting [0, %d])", pc.History, MaxPasswordHistory)
+	case pc.ResetTTL < 0:
+		return fmt.Errorf("invalid password.reset_token_ttl %v (must be non-negative)", pc.ResetTTL)
+	}
+	return nil
+}
+
+func (pc *PasswordConf) ResetTTLD() time.Duration {
+	if pc.ResetTTL == 0 {
+		return DfltResetTTL
+	}
+	return pc.ResetTTL.D()
+}
+
+// Check validates password complexity (but not reuse - see `history`)
+func (pc *PasswordConf) Check(password string) error {
+	if password == "" {
+		return errors.New("password is empty")
+	}
+	if l := len(password); l < pc.MinLen {
+		return fmt.Errorf("password is too short: %d characters (minimum %d)", l, pc.MinLen)
+	}
+	if len(password) > MaxPasswordLen {
+		return fmt.Errorf("password is too long (maximum %d)", MaxPasswordLen)
+	}
+	if pc.MinClasses <= 1 {
+		return nil
+	}
+	var lower, upper, digit, other int
+	for _, c := range password {
+		switch {
+		case unicode.IsLower(c):
+			lower = 1
+		case unicode.IsUpper(c):
+			upper = 1
+		case unicode.IsDigit(c):
+			digit = 1
+		default:
+			other = 1
+		}
+	}
+	if n := lower + upper + digit + other; n < pc.MinClasses {
+		return fmt.Errorf("password is too simple: contains %d character class(es) out of required %d "+
+			"(lowercase, uppercase, digits, and other)", n, pc.MinClasses)
+	}
+	return nil
+}
@@ -5,6 +5,9 @@
 package authn
 
 import (
+	"errors"
+	"fmt"
+	"net"
 	"time"
 
 	"github.com/NVIDIA/aistore/api/apc"
@@ -16,11 +19,36 @@ const (
 	AdminRole = "Admin"
 )
 
+// password change and reset: POST /v1/users/<user-ID>/password and PUT /v1/users/<user-ID>/reset, respectively
+const (
+	PathPassword = "password"
+	PathReset    = "reset"
+)
+
+// login fails with this message (and http.StatusForbidden) when the password must be changed
+// (see PasswordConf.MaxAge and User.MustChange)
+const ErrPasswordChangeRequired = "password change required"
+
 type (
 	User struct {
 		ID       string  `json:"id"`
 		Password string  `json:"pass,omitempty"`
 		Roles    []*Role `json:"roles"`
+		// password rotation
+		PassHistory []string  `json:"pass_history,omitempty"` // previous passwords (hashed; never returned)
+		PassChanged time.Time `json:"pass_changed,omitempty"` // when the password was last set
+		MustChange  bool      `json:"must_change,omitempty"`  // force password change upon next login
+	}
+
+	// change own password given either the current one or admin-issued one-time reset token
+	PasswordMsg struct {
+		Password    string `json:"password,omitempty"`
+		ResetToken  string `json:"reset_token,omitempty"`
+		NewPassword string `json:"new_password"`
+	}
+	ResetTokenMsg struct {
+		Expires time.Time `json:"expires"`
+		Token   string    `json:"token"`
 	}
 
 	CluACL struct {
@@ -42,6 +70,12 @@ type (
 	LoginMsg struct {
 		Password  string         `json:"password"`
 		ExpiresIn *time.Duration `json:"expires_in"`
+		// optional restrictions:
+		// - explicit validity window: [not_before, expires_at] (the latter is mutually exclusive with expires_in);
+		// - source networks (CIDRs) the token can be used from
+		NotBefore *time.Time `json:"not_before,omitempty"`
+		ExpiresAt *time.Time `json:"expires_at,omitempty"`
+		CIDRs     []string   `json:"cidrs,omitempty"`
 	}
 
 	RegisteredClusters struct {
@@ -55,6 +89,23 @@ type (
 		BucketACLs  []*BckACL `json:"buckets"`
 		IsAdmin     bool      `json:"admin"`
 	}
+
+	// propagation of user and role changes to registered clusters:
+	// current version vs. the version (last) synchronized with each cluster
+	ACLSync struct {
+		Clusters map[string]*CluACLSync `json:"clusters"`
+		Version  int64                  `json:"version"`
+	}
+	CluACLSync struct {
+		SyncTime  time.Time `json:"sync_time,omitempty"`  // last successful sync
+		NextRetry time.Time `json:"next_retry,omitempty"` // when pending and failed
+		ID        string    `json:"id"`
+		Alias     string    `json:"alias,omitempty"`
+		Err       string    `json:"err,omitempty"` // last error, if any
+		Version   int64     `json:"version"`
+		Attempts  int       `json:"attempts,omitempty"` // consecutive failed attempts
+		Pending   bool      `json:"pending"`
+	}
 )
 
 //////////
@@ -72,6 +123,28 @@ func (u *User) IsAdmin() bool {
 	return false
 }
 
+// remove secrets prior to returning user info
+func (u *User) Sanitize() {
+	u.Password = ""
+	u.PassHistory = nil
+}
+
+/////////////////
+// PasswordMsg //
+/////////////////
+
+func (msg *PasswordMsg) Validate() error {
+	switch {
+	case msg.NewPassword == "":
+		return errors.New("new password is empty")
+	case msg.Password == "" && msg.ResetToken == "":
+		return errors.New("either current password or reset token must be provided")
+	case msg.Password != "" && msg.ResetToken != "":
+		return errors.New("current password and reset token are mutually exclusive")
+	}
+	return nil
+}
+
 ////////////
 // CluACL //
 ////////////
@@ -87,6 +160,31 @@ func (clu *CluACL) String() string {
 	return uuid
 }
 
+//////////////
+// LoginMsg //
+//////////////
+
+func (msg *LoginMsg) Validate() error {
+	if msg.ExpiresAt != nil {
+		if msg.ExpiresIn != nil {
+			return errors.New("expires_in and expires_at are mutually exclusive")
+		}
+		if msg.ExpiresAt.Before(time.Now()) {
+			return fmt.Errorf("expires_at %s is in the past", msg.ExpiresAt.Format(time.RFC3339))
+		}
+		if msg.NotBefore != nil && !msg.NotBefore.Before(*msg.ExpiresAt) {
+			return fmt.Errorf("not_before %s must precede expires_at %s",
+				msg.NotBefore.Format(time.RFC3339), msg.ExpiresAt.Format(time.RFC3339))
+		}
+	}
+	for _, s := range msg.CIDRs {
+		if _, _, err := net.ParseCIDR(s); err != nil {
+			return fmt.Errorf("invalid source network %q: %v", s, err)
+		}
+	}
+	return nil
+}
+
 //////////////
 // TokenMsg //
 //////////////
@@ -0,0 +1,237 @@
+// Package authn provides AuthN API over HTTP(S)
+/*
+ * Copyright (c) 2024, NVIDIA CORPORATION. All rights reserved.
+ */
+package authn
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// Role templates and bulk user provisioning
+//
+// A role template is a regular role whose name, description, cluster IDs, and/or
+// bucket names and namespaces contain `{{param}}` placeholders, e.g.:
+//
+//	{"name": "team-{{team}}", "buckets": [{"bck": {"name": "{{team}}-data", "provider": "ais"}, "perm": "..."}]}
+//
+// When assigned to a user via `UsersMsg`, the template gets instantiated with
+// the user's parameters; the resulting (concrete) role is stored with the user.
+
+type (
+	// (bulk) user spec
+	UserSpec struct {
+		ID       string            `json:"id"`
+		Password string            `json:"pass,omitempty"`
+		Roles    []string          `json:"roles"`            // names of existing roles and/or role templates
+		Params   map[string]string `json:"params,omitempty"` // template parameters
+	}
+	UsersMsg struct {
+		Users []*UserSpec `json:"users"`
+		// when true, update existing users (password if specified, and roles);
+		// otherwise, existing users are reported as errors
+		Update bool `json:"update,omitempty"`
+	}
+	UsersResult struct {
+		Created []string          `json:"created,omitempty"`
+		Updated []string          `json:"updated,omitempty"`
+		Errors  map[string]string `json:"errors,omitempty"` // user ID => error
+	}
+)
+
+const (
+	// CSV header columns (the rest are template parameters)
+	CSVColID    = "id"
+	CSVColPass  = "pass"
+	CSVColRoles = "roles"
+
+	csvRoleSepa = ";"
+)
+
+var reParam = regexp.MustCompile(`\{\{\s*([A-Za-z0-9_.-]+)\s*\}\}`)
+
+//////////
+// Role //
+//////////
+
+// TemplateParams returns (sorted) names of the role's template parameters, if any.
+func (r *Role) TemplateParams() []string {
+	var (
+		names []string
+		seen  = make(map[string]struct{}, 2)
+		scan  = func(s string) {
+			for _, m := range reParam.FindAllStringSubmatch(s, -1) {
+				if _, ok := seen[m[1]]; !ok {
+					seen[m[1]] = struct{}{}
+					names = append(names, m[1])
+				}
+			}
+		}
+	)
+	scan(r.Name)
+	scan(r.Description)
+	for _, clu := range r.ClusterACLs {
+		scan(clu.ID)
+	}
+	for _, bck := range r.BucketACLs {
+		scan(bck.Bck.Name)
+		scan(bck.Bck.Ns.Name)
+		scan(bck.Bck.Ns.UUID)
+	}
+	sort.Strings(names)
+	return names
+}
+
+func (r *Role) IsTemplate() bool { return len(r.TemplateParams()) > 0 }
+
+// Instantiate returns a new role with all template parameters substituted;
+// it is an error if any of the parameters is missing or empty.
+// Non-template roles are returned as is.
+func (r *Role) Instantiate(params map[string]string) (*Role, error) {
+	names := r.TemplateParams()
+	if len(names) == 0 {
+		return r, nil
+	}
+	for _, name := range names {
+		if params[name] == "" {
+			return nil, fmt.Errorf("role template %q: missing parameter %q", r.Name, name)
+		}
+	}
+	subst := func(s string) string {
+		return reParam.ReplaceAllStringFunc(s, func(m string) string {
+			return params[reParam.FindStringSubmatch(m)[1]]
+		})
+	}
+	role := &Role{
+		Name:        subst(r.Name),
+		Description: subst(r.Description),
+		IsAdmin:     r.IsAdmin,
+		ClusterACLs: make([]*CluACL, 0, len(r.ClusterACLs)),
+		BucketACLs:  make([]*BckACL, 0, len(r.BucketACLs)),
+	}
+	for _, clu := range r.ClusterACLs {
+		c := *clu
+		c.ID = subst(c.ID)
+		role.ClusterACLs = append(role.ClusterACLs, &c)
+	}
+	for _, bck := range r.BucketACLs {
+		b := *bck
+		b.Bck.Name = subst(b.Bck.Name)
+		b.Bck.Ns.Name = subst(b.Bck.Ns.Name)
+		b.Bck.Ns.UUID = subst(b.Bck.Ns.UUID)
+		if b.Bck.Name == bck.Bck.Name {
+			role.BucketACLs = append(role.BucketACLs, &b)
+			continue
+		}
+		if err := b.Bck.ValidateName(); err != nil {
+			return nil, fmt.Errorf("role template %q: %w", r.Name, err)
+		}
+		role.BucketACLs = append(role.BucketACLs, &b)
+	}
+	return role, nil
+}
+
+/////////////////
+// UsersResult //
+/////////////////
+
+func (res *UsersResult) AddErr(userID string, err error) {
+	if res.Errors == nil {
+		res.Errors = make(map[string]string, 2)
+	}
+	res.Errors[userID] = err.Error()
+}
+
+func (res *UsersResult) Err() error {
+	if len(res.Errors) == 0 {
+		return nil
+	}
+	ids := make([]string, 0, len(res.Errors))
+	for id := range res.Errors {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	return fmt.Errorf("failed to provision %d user(s), first error: %s: %s", len(ids), ids[0], res.Errors[ids[0]])
+}
+
+//
+// CSV
+//
+
+// ParseUsersCSV parses CSV that has a header and one user per row, e.g.:
+//
+//	id,pass,roles,team
+//	alice,secret1,team-{{team}};Guest-mycluster,alpha
+//
+// Roles are separated by semicolons; columns other than id, pass, and roles
+// are template parameters.
+func ParseUsersCSV(r io.Reader) (*UsersMsg, error) {
+	cr := csv.NewReader(r)
+	cr.TrimLeadingSpace = true
+	cr.Comment = '#'
+	hdr, err := cr.Read()
+	if err != nil {
+		if err == io.EOF {
+			return nil, errors.New("users CSV: missing header")
+		}
+		return nil, err
+	}
+	cols := make(map[string]int, len(hdr))
+	for i, h := range hdr {
+		h = strings.TrimSpace(h)
+		if h == "" {
+			return nil, fmt.Errorf("users CSV: empty column name (column %d)", i+1)
+		}
+		if _, ok := cols[h]; ok {
+			return nil, fmt.Errorf("users CSV: duplicate column %q", h)
+		}
+		cols[h] = i
+		hdr[i] = h
+	}
+	if _, ok := cols[CSVColID]; !ok {
+		return nil, fmt.Errorf("users CSV: missing %q column", CSVColID)
+	}
+	msg := &UsersMsg{}
+	for {
+		rec, err := cr.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		spec := &UserSpec{}
+		for i, val := range rec {
+			val = strings.TrimSpace(val)
+			switch hdr[i] {
+			case CSVColID:
+				spec.ID = val
+			case CSVColPass:
+				spec.Password = val
+			case CSVColRoles:
+				for _, role := range strings.Split(val, csvRoleSepa) {
+					if role = strings.TrimSpace(role); role != "" {
+						spec.Roles = append(spec.Roles, role)
+					}
+				}
+			default:
+				if spec.Params == nil {
+					spec.Params = make(map[string]string, len(hdr))
+				}
+				spec.Params[hdr[i]] = val
+			}
+		}
+		if spec.ID == "" {
+			line, _ := cr.FieldPos(0)
+			return nil, fmt.Errorf("users CSV: empty user ID (line %d)", line)
+		}
+		msg.Users = append(msg.Users, spec)
+	}
+	return msg, nil
+}
@@ -8,10 +8,13 @@ import (
 	"net/http"
 	"net/url"
 	"strconv"
+	"strings"
 
 	"github.com/NVIDIA/aistore/api/apc"
 	"github.com/NVIDIA/aistore/cmn"
 	"github.com/NVIDIA/aistore/cmn/cos"
+	"github.com/NVIDIA/aistore/core/meta"
+	"github.com/NVIDIA/aistore/xact"
 	jsoniter "github.com/json-iterator/go"
 )
 
@@ -85,6 +88,35 @@ func HeadBucket(bp BaseParams, bck cmn.Bck, dontAddRemote bool) (p *cmn.Bprops,
 	return
 }
 
+// HeadBucketFields is HeadBucket that returns only the selected bucket properties (apc.QparamFields),
+// e.g. "mirror.enabled,versioning". Does not add remote bucket to the cluster's metadata.
+// `out` is typically map[string]any or, alternatively, partially filled cmn.Bprops.
+func HeadBucketFields(bp BaseParams, bck cmn.Bck, fields []string, out any) (err error) {
+	var (
+		hdr    http.Header
+		q      = make(url.Values, 4)
+		status int
+	)
+	q.Set(apc.QparamDontAddRemote, "true")
+	q.Set(apc.QparamFields, strings.Join(fields, ","))
+	q = bck.AddToQuery(q)
+
+	bp.Method = http.MethodHead
+	reqParams := AllocRp()
+	{
+		reqParams.BaseParams = bp
+		reqParams.Path = apc.URLPathBuckets.Join(bck.Name)
+		reqParams.Query = q
+	}
+	if hdr, status, err = reqParams.doReqHdr(); err == nil {
+		err = jsoniter.Unmarshal([]byte(hdr.Get(apc.HdrBucketProps)), out)
+	} else {
+		err = hdr2msg(bck, status, err)
+	}
+	FreeRp(reqParams)
+	return err
+}
+
 // fill-in herr message (HEAD response will never contain one)
 func hdr2msg(bck cmn.Bck, status int, err error) error {
 	herr, ok := err.(*cmn.ErrHTTP)
@@ -202,6 +234,17 @@ func CopyBucket(bp BaseParams, bckFrom, bckTo cmn.Bck, msg *apc.CopyBckMsg, fltP
 	return
 }
 
+// CloneBucket makes a copy-on-write clone of ais:// bucket: destination objects reference
+// (share) the source data whenever possible and get materialized only upon modification.
+// Returns xaction ID if successful, an error otherwise. See also: CopyBucket
+func CloneBucket(bp BaseParams, bckFrom, bckTo cmn.Bck, msg *apc.CopyBckMsg) (string, error) {
+	if msg == nil {
+		msg = &apc.CopyBckMsg{}
+	}
+	msg.COW = true
+	return CopyBucket(bp, bckFrom, bckTo, msg)
+}
+
 // RenameBucket renames bckFrom as bckTo.
 // Returns xaction ID if successful, an error otherwise.
 func RenameBucket(bp BaseParams, bckFrom, bckTo cmn.Bck) (xid string, err error) {
@@ -246,6 +289,32 @@ func EvictRemoteBucket(bp BaseParams, bck cmn.Bck, keepMD bool) error {
 	return err
 }
 
+// SetBucketAlias creates or (atomically) repoints ais://<alias> to a given, possibly remote, bucket;
+// - readOnly: fail all requests (via alias) that would modify the bucket or its content;
+// - to list existing aliases, see GetBMD
+func SetBucketAlias(bp BaseParams, alias string, bck cmn.Bck, readOnly bool) error {
+	msg := apc.ActMsg{Action: apc.ActSetBckAlias, Name: alias, Value: &meta.BckAlias{Bck: bck, ReadOnly: readOnly}}
+	return _bckAlias(bp, &msg)
+}
+
+func RemoveBucketAlias(bp BaseParams, alias string) error {
+	return _bckAlias(bp, &apc.ActMsg{Action: apc.ActDelBckAlias, Name: alias})
+}
+
+func _bckAlias(bp BaseParams, msg *apc.ActMsg) error {
+	bp.Method = http.MethodPut
+	reqParams := AllocRp()
+	{
+		reqParams.BaseParams = bp
+		reqParams.Path = apc.URLPathClu.S
+		reqParams.Body = cos.MustMarshal(msg)
+		reqParams.Header = http.Header{cos.HdrContentType: []string{cos.ContentJSON}}
+	}
+	err := reqParams.DoRequest()
+	FreeRp(reqParams)
+	return err
+}
+
 // MakeNCopies starts an extended action (xaction) to bring a given bucket to a
 // certain redundancy level (num copies).
 // Returns xaction ID if successful, an error otherwise.
@@ -264,6 +333,12 @@ func MakeNCopies(bp BaseParams, bck cmn.Bck, copies int) (xid string, err error)
 	return
 }
 
+// MigrateCustomMD starts (apc.ActMigrateCustomMD) xaction to move objects' custom metadata
+// between xattrs and per-object sidecar files, as per the bucket's (feat.CustomMDSidecar) setting.
+func MigrateCustomMD(bp BaseParams, bck cmn.Bck) (xid string, err error) {
+	return StartXaction(bp, &xact.ArgsMsg{Kind: apc.ActMigrateCustomMD, Bck: bck}, "")
+}
+
 // Erasure-code entire `bck` bucket at a given `data`:`parity` redundancy.
 // The operation requires at least (`data + `parity` + 1) storage targets in the cluster.
 // Returns xaction ID if successful, an error otherwise.
@@ -11,7 +11,9 @@ import (
 	"io"
 	"net/http"
 	"net/url"
+	"strconv"
 	"sync"
+	"time"
 
 	"github.com/NVIDIA/aistore/api/apc"
 	"github.com/NVIDIA/aistore/cmn"
@@ -28,11 +30,12 @@ const (
 
 type (
 	BaseParams struct {
-		Client *http.Client
-		URL    string
-		Method string
-		Token  string
-		UA     string
+		Client    *http.Client
+		Endpoints *Endpoints // when set, overrides URL (see api/endpoints.go)
+		URL       string
+		Method    string
+		Token     string
+		UA        string
 	}
 
 	// ReqParams is used in constructing client-side API requests to aistore.
@@ -61,6 +64,9 @@ type (
 		client *http.Client
 		req    *http.Request
 		resp   *http.Response
+		// client-side load balancing
+		eps *Endpoints
+		ep  *endpoint
 	}
 	wrappedResp struct {
 		*http.Response
@@ -191,7 +197,13 @@ func (reqParams *ReqParams) do() (resp *http.Response, err error) {
 	if reqParams.Body != nil {
 		reqBody = bytes.NewBuffer(reqParams.Body)
 	}
-	urlPath := reqParams.BaseParams.URL + reqParams.Path
+	var ep *endpoint
+	urlBase := reqParams.BaseParams.URL
+	if eps := reqParams.BaseParams.Endpoints; eps != nil {
+		ep = eps.pick()
+		urlBase = ep.url
+	}
+	urlPath := urlBase + cos.EscapePath(reqParams.Path)
 	req, errR := http.NewRequest(reqParams.BaseParams.Method, urlPath, reqBody)
 	if errR != nil {
 		return nil, fmt.Errorf("failed to create http request: %w", errR)
@@ -199,7 +211,7 @@ func (reqParams *ReqParams) do() (resp *http.Response, err error) {
 	reqParams.setRequestOptParams(req)
 	SetAuxHeaders(req, &reqParams.BaseParams)
 
-	rr := reqResp{client: reqParams.BaseParams.Client, req: req}
+	rr := reqResp{client: reqParams.BaseParams.Client, req: req, eps: reqParams.BaseParams.Endpoints, ep: ep}
 	err = cmn.NetworkCallWithRetry(&cmn.RetryArgs{
 		Call:      rr.call,
 		Verbosity: cmn.RetryLogOff,
@@ -341,6 +353,9 @@ func (reqParams *ReqParams) checkResp(resp *http.Response) error {
 	if reqParams.BaseParams.Method == http.MethodHead {
 		// "A response to a HEAD method should not have a body."
 		if msg := resp.Header.Get(apc.HdrError); msg != "" {
+			if herr := cmn.Str2HTTPErr(msg); herr != nil && herr.Message != "" {
+				return herr
+			}
 			return &cmn.ErrHTTP{
 				TypeCode: cmn.TypeCodeHTTPErr(msg),
 				Message:  msg,
@@ -384,12 +399,93 @@ func (reqParams *ReqParams) checkResp(resp *http.Response) error {
 // reqResp //
 /////////////
 
+// in addition, honor admission control - 503 with apc.HdrAdmitQueue and Retry-After
+// (e.g., cluster starting up - see ais/prxadmit.go):
+// retry up to `admitMaxRetries` times, waiting as instructed (but no longer than `admitMaxWait`)
 func (rr *reqResp) call() (status int, err error) {
+	for i := 0; ; i++ {
+		if rr.eps != nil {
+			status, err = rr.callEp()
+		} else {
+			rr.resp, err = rr.client.Do(rr.req) //nolint:bodyclose // closed by a caller
+			if rr.resp != nil {
+				status = rr.resp.StatusCode
+			}
+		}
+		if err != nil || status != http.StatusServiceUnavailable || i >= admitMaxRetries {
+			return status, err
+		}
+		// only admission control (other 503s are not necessarily retriable)
+		if rr.resp.Header.Get(apc.HdrAdmitQueue) == "" {
+			return status, err
+		}
+		wait, ok := retryAfter(rr.resp)
+		if !ok || !rr.rewind() {
+			return status, err
+		}
+		cos.DrainReader(rr.resp.Body)
+		rr.resp.Body.Close()
+		time.Sleep(wait)
+	}
+}
+
+// (callEp does its own rewinding)
+func (rr *reqResp) rewind() bool {
+	if rr.req.Body == nil || rr.req.Body == http.NoBody {
+		return true
+	}
+	if rr.req.GetBody == nil {
+		return false
+	}
+	if rr.eps != nil {
+		return true
+	}
+	body, err := rr.req.GetBody()
+	if err != nil {
+		return false
+	}
+	rr.req.Body = body
+	return true
+}
+
+// Retry-After: seconds or HTTP-date
+func retryAfter(resp *http.Response) (time.Duration, bool) {
+	v := resp.Header.Get(cos.HdrRetryAfter)
+	if v == "" {
+		return 0, false
+	}
+	var wait time.Duration
+	if secs, err := strconv.ParseInt(v, 10, 64); err == nil {
+		wait = time.Duration(secs) * time.Second
+	} else if t, err := http.ParseTime(v); err == nil {
+		wait = time.Until(t)
+	} else {
+		return 0, false
+	}
+	return min(max(wait, httpRetrySleep), admitMaxWait), true
+}
+
+// same as above with each retry going to another (load-balanced) endpoint
+func (rr *reqResp) callEp() (status int, err error) {
+	if rr.ep == nil {
+		rr.ep = rr.eps.pick()
+		rr.req.URL.Scheme, rr.req.URL.Host = rr.ep.u.Scheme, rr.ep.u.Host
+		rr.req.Host = rr.ep.u.Host
+		if rr.req.GetBody != nil {
+			if rr.req.Body, err = rr.req.GetBody(); err != nil {
+				return 0, err
+			}
+		}
+	}
+	ep := rr.ep
+	ep.inflight.Inc()
 	rr.resp, err = rr.client.Do(rr.req) //nolint:bodyclose // closed by a caller
 	if rr.resp != nil {
 		status = rr.resp.StatusCode
 	}
-	return
+	rr.eps.done(ep, isEndpointFailure(status, err))
+	rr.ep = nil // next attempt (if any) - next endpoint
+	return status, err
 }
 
 //
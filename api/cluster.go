@@ -6,8 +6,10 @@ package api
 
 import (
 	"fmt"
+	"io"
 	"net/http"
 	"net/url"
+	"strings"
 
 	"github.com/NVIDIA/aistore/api/apc"
 	"github.com/NVIDIA/aistore/cmn"
@@ -38,6 +40,25 @@ func Health(bp BaseParams, readyToRebalance ...bool) error {
 	return err
 }
 
+// deep (end-to-end) health check: the primary writes, reads back, and deletes
+// a tiny object via a random target (non-primary proxies forward the request)
+func HealthDeep(bp BaseParams) (probe *cmn.HealthProbe, err error) {
+	bp.Method = http.MethodGet
+	reqParams := AllocRp()
+	{
+		reqParams.BaseParams = bp
+		reqParams.Path = apc.URLPathHealth.S
+		reqParams.Query = url.Values{apc.QparamHealthDeep: []string{"true"}}
+	}
+	probe = &cmn.HealthProbe{}
+	_, err = reqParams.DoReqAny(probe)
+	FreeRp(reqParams)
+	if err != nil {
+		probe = nil
+	}
+	return probe, err
+}
+
 func HealthUptime(bp BaseParams, readyToRebalance ...bool) (string, string, error) {
 	reqParams := mkhealth(bp, readyToRebalance...)
 	hdr, _, err := reqParams.doReqHdr()
@@ -142,6 +163,28 @@ func GetNodeMeta(bp BaseParams, sid, what string) (out any, err error) {
 	return
 }
 
+// GetNodeFields retrieves only the selected fields (apc.QparamFields) of the `what` response
+// (e.g., apc.WhatSmap or apc.WhatNodeStatsAndStatus) from the specified node or,
+// if `sid` is empty, from the BaseParams-referenced one.
+// Fields are dot-separated JSON paths, e.g. "version" or "proxy_si.daemon_id".
+// `out` is typically map[string]any or, alternatively, partially filled (typed) struct.
+func GetNodeFields(bp BaseParams, sid, what string, fields []string, out any) (err error) {
+	bp.Method = http.MethodGet
+	reqParams := AllocRp()
+	{
+		reqParams.BaseParams = bp
+		reqParams.Path = apc.URLPathDae.S
+		reqParams.Query = url.Values{apc.QparamWhat: []string{what}, apc.QparamFields: []string{strings.Join(fields, ",")}}
+		if sid != "" {
+			reqParams.Path = apc.URLPathReverseDae.S
+			reqParams.Header = http.Header{apc.HdrNodeID: []string{sid}}
+		}
+	}
+	_, err = reqParams.DoReqAny(out)
+	FreeRp(reqParams)
+	return err
+}
+
 // GetClusterSysInfo retrieves cluster's system information
 func GetClusterSysInfo(bp BaseParams) (info apc.ClusterSysInfo, err error) {
 	bp.Method = http.MethodGet
@@ -169,6 +212,63 @@ func GetRemoteAIS(bp BaseParams) (remais meta.RemAisVec, err error) {
 	return
 }
 
+// GetFederation returns a single-pane view of all attached remote AIS clusters:
+// per-cluster health, latency and lag, buckets, capacity, and (aggregated) stats
+func GetFederation(bp BaseParams) (fed *meta.Federation, err error) {
+	bp.Method = http.MethodGet
+	reqParams := AllocRp()
+	{
+		reqParams.BaseParams = bp
+		reqParams.Path = apc.URLPathClu.S
+		reqParams.Query = url.Values{apc.QparamWhat: []string{apc.WhatFederation}}
+	}
+	fed = &meta.Federation{}
+	_, err = reqParams.DoReqAny(fed)
+	FreeRp(reqParams)
+	return
+}
+
+// ExportClusterMD writes all cluster-level metadata (Smap, BMD, RMD, cluster config, and EtlMD)
+// as a single compressed, checksummed, and signed blob (see meta.MDBundle) to `w`;
+// returns the number of bytes written (compare w/ ImportClusterMD)
+func ExportClusterMD(bp BaseParams, w io.Writer) (int64, error) {
+	bp.Method = http.MethodGet
+	reqParams := AllocRp()
+	{
+		reqParams.BaseParams = bp
+		reqParams.Path = apc.URLPathClu.S
+		reqParams.Query = url.Values{apc.QparamWhat: []string{apc.WhatMDBundle}}
+	}
+	wresp, err := reqParams.doWriter(w)
+	FreeRp(reqParams)
+	if err != nil {
+		return 0, err
+	}
+	return wresp.n, nil
+}
+
+// ImportClusterMD restores previously exported cluster metadata onto a (freshly bootstrapped)
+// cluster; returns the versions of restored metadata and the mapping of exported targets
+// onto the current ones (see apc.ActValImportMD)
+func ImportClusterMD(bp BaseParams, val *apc.ActValImportMD) (res *apc.ImportMDResult, err error) {
+	msg := apc.ActMsg{
+		Action: apc.ActImportMD,
+		Value:  val,
+	}
+	bp.Method = http.MethodPut
+	reqParams := AllocRp()
+	{
+		reqParams.BaseParams = bp
+		reqParams.Path = apc.URLPathClu.S
+		reqParams.Body = cos.MustMarshal(msg)
+		reqParams.Header = http.Header{cos.HdrContentType: []string{cos.ContentJSON}}
+	}
+	res = &apc.ImportMDResult{}
+	_, err = reqParams.DoReqAny(res)
+	FreeRp(reqParams)
+	return res, err
+}
+
 // (see also enable/disable backend below)
 func GetConfiguredBackends(bp BaseParams) (out []string, err error) {
 	bp.Method = http.MethodGet
@@ -262,6 +362,26 @@ func SetClusterConfigUsingMsg(bp BaseParams, configToUpdate *cmn.ConfigToSet, tr
 	return err
 }
 
+// ValidateClusterConfig checks the (cluster-wide) config update without applying it
+// and returns all violations, if any (an empty list means the update is valid)
+func ValidateClusterConfig(bp BaseParams, nvs cos.StrKVs) (violations []string, err error) {
+	q := make(url.Values, len(nvs)+1)
+	for key, val := range nvs {
+		q.Set(key, val)
+	}
+	q.Set(apc.QparamValidateOnly, "true")
+	bp.Method = http.MethodPut
+	reqParams := AllocRp()
+	{
+		reqParams.BaseParams = bp
+		reqParams.Path = apc.URLPathCluSetConf.S
+		reqParams.Query = q
+	}
+	_, err = reqParams.DoReqAny(&violations)
+	FreeRp(reqParams)
+	return violations, err
+}
+
 func setRebalance(bp BaseParams, enabled bool) error {
 	configToSet := &cmn.ConfigToSet{
 		Rebalance: &cmn.RebalanceConfToSet{
@@ -322,6 +442,21 @@ func GetClusterConfig(bp BaseParams) (*cmn.ClusterConfig, error) {
 	return cluConfig, nil
 }
 
+// GetConfigSchema returns all configuration fields: type, cluster-wide default,
+// scope, and whether (and how) the field can be updated at runtime
+func GetConfigSchema(bp BaseParams) (schema []*cmn.ConfigFieldSchema, err error) {
+	bp.Method = http.MethodGet
+	reqParams := AllocRp()
+	{
+		reqParams.BaseParams = bp
+		reqParams.Path = apc.URLPathClu.S
+		reqParams.Query = url.Values{apc.QparamWhat: []string{apc.WhatConfigSchema}}
+	}
+	_, err = reqParams.DoReqAny(&schema)
+	FreeRp(reqParams)
+	return schema, err
+}
+
 func AttachRemoteAIS(bp BaseParams, alias, u string) error {
 	bp.Method = http.MethodPut
 	reqParams := AllocRp()
@@ -444,6 +579,46 @@ func StopMaintenance(bp BaseParams, actValue *apc.ActValRmNode) (xid string, err
 	return xid, err
 }
 
+// SetPlacement changes cluster-wide object placement (one of apc.SupportedPlacement);
+// returns ID of the rebalance that migrates existing objects, if any
+func SetPlacement(bp BaseParams, mode string) (xid string, err error) {
+	msg := apc.ActMsg{
+		Action: apc.ActSetPlacement,
+		Value:  mode,
+	}
+	bp.Method = http.MethodPut
+	reqParams := AllocRp()
+	{
+		reqParams.BaseParams = bp
+		reqParams.Path = apc.URLPathClu.S
+		reqParams.Body = cos.MustMarshal(msg)
+		reqParams.Header = http.Header{cos.HdrContentType: []string{cos.ContentJSON}}
+	}
+	_, err = reqParams.doReqStr(&xid)
+	FreeRp(reqParams)
+	return xid, err
+}
+
+// SetTargetWeight sets the target's weight (share of objects), either all at once or
+// gradually, in steps (see apc.ActValWeight); returns ID of the (first) rebalance, if any
+func SetTargetWeight(bp BaseParams, val *apc.ActValWeight) (xid string, err error) {
+	msg := apc.ActMsg{
+		Action: apc.ActSetWeight,
+		Value:  val,
+	}
+	bp.Method = http.MethodPut
+	reqParams := AllocRp()
+	{
+		reqParams.BaseParams = bp
+		reqParams.Path = apc.URLPathClu.S
+		reqParams.Body = cos.MustMarshal(msg)
+		reqParams.Header = http.Header{cos.HdrContentType: []string{cos.ContentJSON}}
+	}
+	_, err = reqParams.doReqStr(&xid)
+	FreeRp(reqParams)
+	return xid, err
+}
+
 // ShutdownCluster shuts down the whole cluster
 func ShutdownCluster(bp BaseParams) error {
 	msg := apc.ActMsg{Action: apc.ActShutdownCluster}
@@ -9,6 +9,7 @@ import (
 	"net/http"
 	"net/url"
 	"strconv"
+	"time"
 
 	"github.com/NVIDIA/aistore/api/apc"
 	"github.com/NVIDIA/aistore/cmn"
@@ -22,6 +23,14 @@ type GetLogInput struct {
 	Severity string // one of: {cmn.LogInfo, ...}
 	Offset   int64
 	All      bool
+
+	// log query (ignored when `All` is set):
+	// tail and filter recent log lines, and optionally keep following (streaming) new ones
+	Since  time.Time // filter by time
+	Until  time.Time // ditto
+	Regex  string    // filter by regular expression
+	Tail   int       // max number of (matching) lines; default 1000
+	Follow bool      // keep streaming new (matching) lines - until the request gets canceled
 }
 
 // GetMountpaths given the direct public URL of the target, returns the target's mountpaths or error.
@@ -89,6 +98,26 @@ func FshcMountpath(bp BaseParams, node *meta.Snode, mountpath string) error {
 	return _actMpath(bp, node, mountpath, apc.ActMountpathFSHC, nil)
 }
 
+// ReplaceMountpath migrates the contents of an existing mountpath to a new one and, upon
+// completion of the (copying) passes, swaps the two with no resilvering and no rebalancing;
+// returns the ID of the (target) xaction - see apc.MpathReplaceReport
+func ReplaceMountpath(bp BaseParams, node *meta.Snode, msg *apc.MpathReplaceMsg) (xid string, err error) {
+	bp.Method = http.MethodPut
+	reqParams := AllocRp()
+	{
+		reqParams.BaseParams = bp
+		reqParams.Path = apc.URLPathReverseDae.Join(apc.Mountpaths)
+		reqParams.Body = cos.MustMarshal(apc.ActMsg{Action: apc.ActMountpathReplace, Value: msg})
+		reqParams.Header = http.Header{
+			apc.HdrNodeID:      []string{node.ID()},
+			cos.HdrContentType: []string{cos.ContentJSON},
+		}
+	}
+	_, err = reqParams.doReqStr(&xid)
+	FreeRp(reqParams)
+	return xid, err
+}
+
 func _actMpath(bp BaseParams, node *meta.Snode, mountpath, action string, q url.Values) error {
 	reqParams := AllocRp()
 	{
@@ -151,6 +180,21 @@ func GetDaemonLog(bp BaseParams, node *meta.Snode, args GetLogInput) (int64, err
 	if args.All {
 		q.Set(apc.QparamAllLogs, "true")
 	}
+	if !args.Since.IsZero() {
+		q.Set(apc.QparamSince, strconv.FormatInt(args.Since.UnixNano(), 10))
+	}
+	if !args.Until.IsZero() {
+		q.Set(apc.QparamUntil, strconv.FormatInt(args.Until.UnixNano(), 10))
+	}
+	if args.Regex != "" {
+		q.Set(apc.QparamLogRegex, args.Regex)
+	}
+	if args.Tail > 0 {
+		q.Set(apc.QparamLogTail, strconv.Itoa(args.Tail))
+	}
+	if args.Follow {
+		q.Set(apc.QparamLogFollow, "true")
+	}
 	bp.Method = http.MethodGet
 	reqParams := AllocRp()
 	{
@@ -189,6 +233,27 @@ func SetDaemonConfig(bp BaseParams, nodeID string, nvs cos.StrKVs, transient ...
 	return err
 }
 
+// ValidateDaemonConfig checks the node's config update without applying it
+// and returns all violations, if any (see also: ValidateClusterConfig)
+func ValidateDaemonConfig(bp BaseParams, nodeID string, nvs cos.StrKVs) (violations []string, err error) {
+	bp.Method = http.MethodPut
+	query := url.Values{}
+	for key, val := range nvs {
+		query.Add(key, val)
+	}
+	query.Add(apc.QparamValidateOnly, "true")
+	reqParams := AllocRp()
+	{
+		reqParams.BaseParams = bp
+		reqParams.Path = apc.URLPathReverseDae.Join(apc.ActSetConfig)
+		reqParams.Query = query
+		reqParams.Header = http.Header{apc.HdrNodeID: []string{nodeID}}
+	}
+	_, err = reqParams.DoReqAny(&violations)
+	FreeRp(reqParams)
+	return violations, err
+}
+
 // reset node's configuration to cluster defaults
 func ResetDaemonConfig(bp BaseParams, nodeID string) error {
 	return _putDaemon(bp, nodeID, apc.ActMsg{Action: apc.ActResetConfig})
@@ -214,3 +279,34 @@ func _putDaemon(bp BaseParams, nodeID string, msg apc.ActMsg) error {
 	FreeRp(reqParams)
 	return err
 }
+
+// StartBurnIn starts burn-in (self-test) on the BaseParams-referenced target,
+// typically a new one (not yet joined, `-standby`) - see also: GetBurnInReport
+func StartBurnIn(bp BaseParams, msg *apc.BurnInMsg) (xid string, err error) {
+	bp.Method = http.MethodPut
+	reqParams := AllocRp()
+	{
+		reqParams.BaseParams = bp
+		reqParams.Path = apc.URLPathDae.S
+		reqParams.Body = cos.MustMarshal(apc.ActMsg{Action: apc.ActBurnIn, Value: msg})
+		reqParams.Header = http.Header{cos.HdrContentType: []string{cos.ContentJSON}}
+	}
+	_, err = reqParams.doReqStr(&xid)
+	FreeRp(reqParams)
+	return xid, err
+}
+
+// GetBurnInReport returns the current (running) or the last burn-in report
+// from the BaseParams-referenced target; 404 if burn-in never ran
+func GetBurnInReport(bp BaseParams) (rep *apc.BurnInReport, err error) {
+	bp.Method = http.MethodGet
+	reqParams := AllocRp()
+	{
+		reqParams.BaseParams = bp
+		reqParams.Path = apc.URLPathDae.S
+		reqParams.Query = url.Values{apc.QparamWhat: []string{apc.WhatBurnIn}}
+	}
+	_, err = reqParams.DoReqAny(&rep)
+	FreeRp(reqParams)
+	return rep, err
+}
@@ -77,6 +77,12 @@ func DownloadBackend(bp BaseParams, descr string, bck cmn.Bck, prefix, suffix st
 	return DownloadWithParam(bp, dload.TypeBackend, dlBody)
 }
 
+// DownloadSync mirrors HTTP(S) directory (`SyncBody.Source`) or remote bucket (when the source is empty)
+// into `SyncBody.Bck`, once or periodically (`SyncBody.Interval`)
+func DownloadSync(bp BaseParams, body *dload.SyncBody) (string, error) {
+	return DownloadWithParam(bp, dload.TypeSync, body)
+}
+
 func DownloadStatus(bp BaseParams, id string, onlyActive bool) (dlStatus *dload.StatusResp, err error) {
 	dlBody := dload.AdminBody{ID: id, OnlyActive: onlyActive}
 	bp.Method = http.MethodGet
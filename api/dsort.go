@@ -83,3 +83,17 @@ func MetricsDsort(bp BaseParams, managerUUID string) (metrics map[string]*dsort.
 	FreeRp(reqParams)
 	return metrics, err
 }
+
+// DsortQueue returns the state of the dsort job queue: running, pending (waiting to start),
+// and recently failed-to-start jobs (with queueing disabled, all lists are normally empty)
+func DsortQueue(bp BaseParams) (qs *dsort.QueueStatus, err error) {
+	bp.Method = http.MethodGet
+	reqParams := AllocRp()
+	{
+		reqParams.BaseParams = bp
+		reqParams.Path = apc.URLPathdSortQueue.S
+	}
+	_, err = reqParams.DoReqAny(&qs)
+	FreeRp(reqParams)
+	return
+}
@@ -0,0 +1,305 @@
+// Package api provides native Go-based API/SDK over HTTP(S).
+/*
+ * Copyright (c) 2024, NVIDIA CORPORATION. All rights reserved.
+ */
+package api
+
+import (
+	"context"
+	"errors"
+	"math/rand/v2"
+	"net/http"
+	"net/url"
+	"sync"
+	"time"
+
+	"github.com/NVIDIA/aistore/api/apc"
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/cmn/atomic"
+	"github.com/NVIDIA/aistore/cmn/cos"
+)
+
+// Client-side load balancing across multiple AIS gateways (proxies) ==========================
+//
+// Instead of sending all requests to a single `BaseParams.URL`, clients may set
+// `BaseParams.Endpoints` - a set of proxy URLs that are given explicitly (cmn.StaticResolver)
+// or resolved, and periodically re-resolved, via DNS (cmn.DNSResolver) or any other cmn.Resolver.
+// Endpoints:
+// - health-check all proxies in the background (GET /v1/health);
+// - load-balance requests among healthy proxies: of two (randomly chosen) candidates,
+//   the one with fewer requests in flight wins;
+// - eject outliers: a proxy that fails `EjectAfter` consecutive requests (connection errors
+//   and 502, 503, 504 responses) is taken out of rotation for `EjectTime` (doubling with
+//   each repeated ejection, up to 16x) and gets re-admitted only after passing a health check;
+// - when none is healthy, try all of them anyway.
+// Requests that fail to connect are retried against another proxy.
+//
+// NOTE: resolved URLs must not contain a path; with HTTPS and DNS-resolved IPs, the client's
+// tls.Config must specify the expected ServerName (or skip verification).
+
+const (
+	dfltHealthInterval  = 5 * time.Second
+	dfltResolveInterval = time.Minute
+	dfltEjectAfter      = 3
+	dfltEjectTime       = 30 * time.Second
+	maxEjectShift       = 4
+)
+
+type (
+	EndpointsArgs struct {
+		Resolver cmn.Resolver // required
+		Client   *http.Client // to execute health checks (required)
+
+		// optional; zero means default (see above)
+		HealthInterval  time.Duration
+		ResolveInterval time.Duration
+		EjectAfter      int // consecutive failures
+		EjectTime       time.Duration
+	}
+	EndpointStatus struct {
+		EjectedUntil time.Time `json:"ejected_until,omitempty"`
+		URL          string    `json:"url"`
+		Inflight     int64     `json:"inflight"`
+		Requests     int64     `json:"requests"`
+		Failures     int64     `json:"failures"`
+		Healthy      bool      `json:"healthy"`
+	}
+
+	Endpoints struct {
+		args   EndpointsArgs
+		eps    []*endpoint
+		stopCh cos.StopCh
+		mu     sync.RWMutex
+	}
+	endpoint struct {
+		u        *url.URL
+		url      string
+		inflight atomic.Int64
+		requests atomic.Int64
+		failures atomic.Int64
+		// protected by Endpoints.mu
+		ejectedUntil time.Time
+		consecutive  int // failures
+		ejections    int
+		healthy      bool
+	}
+)
+
+// NewEndpoints resolves endpoints and starts health-checking (and re-resolving) them
+// in the background; call Stop() when done
+func NewEndpoints(args *EndpointsArgs) (*Endpoints, error) {
+	if args.Resolver == nil || args.Client == nil {
+		return nil, errors.New("endpoints: resolver and client are required")
+	}
+	e := &Endpoints{args: *args}
+	if e.args.HealthInterval <= 0 {
+		e.args.HealthInterval = dfltHealthInterval
+	}
+	if e.args.ResolveInterval <= 0 {
+		e.args.ResolveInterval = dfltResolveInterval
+	}
+	if e.args.EjectAfter <= 0 {
+		e.args.EjectAfter = dfltEjectAfter
+	}
+	if e.args.EjectTime <= 0 {
+		e.args.EjectTime = dfltEjectTime
+	}
+	if err := e.resolve(); err != nil {
+		return nil, err
+	}
+	e.stopCh.Init()
+	go e.run()
+	return e, nil
+}
+
+func (e *Endpoints) Stop() { e.stopCh.Close() }
+
+func (e *Endpoints) Status() []EndpointStatus {
+	e.mu.RLock()
+	res := make([]EndpointStatus, len(e.eps))
+	for i, ep := range e.eps {
+		res[i] = EndpointStatus{
+			EjectedUntil: ep.ejectedUntil,
+			URL:          ep.url,
+			Inflight:     ep.inflight.Load(),
+			Requests:     ep.requests.Load(),
+			Failures:     ep.failures.Load(),
+			Healthy:      ep.healthy,
+		}
+	}
+	e.mu.RUnlock()
+	return res
+}
+
+func (e *Endpoints) run() {
+	var (
+		health  = time.NewTicker(e.args.HealthInterval)
+		resolve = time.NewTicker(e.args.ResolveInterval)
+	)
+	defer func() {
+		health.Stop()
+		resolve.Stop()
+	}()
+	for {
+		select {
+		case <-health.C:
+			e.check()
+		case <-resolve.C:
+			e.resolve() //nolint:errcheck // keeping current endpoints
+		case <-e.stopCh.Listen():
+			return
+		}
+	}
+}
+
+// (re)resolve and merge: existing endpoints retain their state, new ones start healthy
+func (e *Endpoints) resolve() error {
+	ctx, cancel := context.WithTimeout(context.Background(), e.args.HealthInterval)
+	urls, err := e.args.Resolver.Resolve(ctx)
+	cancel()
+	if err == nil && len(urls) == 0 {
+		err = errors.New("endpoints: resolved to none")
+	}
+	if err != nil {
+		return err
+	}
+	eps := make([]*endpoint, 0, len(urls))
+	e.mu.RLock()
+	for _, u := range urls {
+		var ep *endpoint
+		for _, old := range e.eps {
+			if old.url == u {
+				ep = old
+				break
+			}
+		}
+		if ep == nil {
+			parsed, err := url.Parse(u)
+			if err != nil {
+				e.mu.RUnlock()
+				return err
+			}
+			ep = &endpoint{u: parsed, url: u, healthy: true}
+		}
+		eps = append(eps, ep)
+	}
+	e.mu.RUnlock()
+
+	e.mu.Lock()
+	e.eps = eps
+	e.mu.Unlock()
+	return nil
+}
+
+// health-check all endpoints in parallel
+func (e *Endpoints) check() {
+	e.mu.RLock()
+	eps := e.eps
+	e.mu.RUnlock()
+
+	var (
+		ok = make([]bool, len(eps))
+		wg = &sync.WaitGroup{}
+	)
+	for i, ep := range eps {
+		wg.Add(1)
+		go func(i int, ep *endpoint) {
+			ok[i] = e.ping(ep)
+			wg.Done()
+		}(i, ep)
+	}
+	wg.Wait()
+
+	now := time.Now()
+	e.mu.Lock()
+	for i, ep := range eps {
+		ep.healthy = ok[i] && !now.Before(ep.ejectedUntil)
+	}
+	e.mu.Unlock()
+}
+
+func (e *Endpoints) ping(ep *endpoint) bool {
+	ctx, cancel := context.WithTimeout(context.Background(), e.args.HealthInterval)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, ep.url+apc.URLPathHealth.S, http.NoBody)
+	if err != nil {
+		return false
+	}
+	resp, err := e.args.Client.Do(req)
+	if err != nil {
+		return false
+	}
+	cos.DrainReader(resp.Body)
+	resp.Body.Close()
+	return resp.StatusCode == http.StatusOK
+}
+
+// power of two choices (among healthy); all unhealthy - any
+func (e *Endpoints) pick() *endpoint {
+	var a, b *endpoint
+	e.mu.RLock()
+	n := len(e.eps)
+	off := rand.IntN(n)
+	for i := range n {
+		ep := e.eps[(off+i)%n]
+		if !ep.healthy {
+			continue
+		}
+		if a == nil {
+			a = ep
+		} else {
+			b = ep
+			break
+		}
+	}
+	if a == nil {
+		a = e.eps[off]
+	}
+	e.mu.RUnlock()
+	if b != nil && b.inflight.Load() < a.inflight.Load() {
+		a = b
+	}
+	return a
+}
+
+// count the request and (on failure) eject the endpoint when it fails too many times in a row
+func (e *Endpoints) done(ep *endpoint, failed bool) {
+	ep.inflight.Dec()
+	ep.requests.Inc()
+	if failed {
+		ep.failures.Inc()
+	}
+	e.mu.Lock()
+	switch {
+	case !failed:
+		ep.consecutive, ep.ejections = 0, 0
+	case ep.healthy:
+		ep.consecutive++
+		if ep.consecutive >= e.args.EjectAfter {
+			ep.ejectedUntil = time.Now().Add(e.args.EjectTime << min(ep.ejections, maxEjectShift))
+			ep.ejections++
+			ep.consecutive = 0
+			ep.healthy = false
+		}
+	}
+	e.mu.Unlock()
+}
+
+func isEndpointFailure(status int, err error) bool {
+	if err != nil {
+		return true
+	}
+	return status == http.StatusBadGateway || status == http.StatusServiceUnavailable || status == http.StatusGatewayTimeout
+}
+
+////////////////
+// BaseParams //
+////////////////
+
+// base URL: given or chosen (load-balanced) by `Endpoints`
+func (bp *BaseParams) base() string {
+	if bp.Endpoints == nil {
+		return bp.URL
+	}
+	return bp.Endpoints.pick().url
+}
@@ -18,6 +18,7 @@ var (
 		// endpoint: client | primary startup
 		Endpoint  string
 		PrimaryEP string
+		Profile   string
 
 		// networking: two CIDR masks
 		LocalRedirectCIDR string
@@ -35,10 +36,17 @@ var (
 		SkipVerifyCrt string
 		// TLS: server (aistore, AuthN) side (NOTE comment below)
 
+		// target: hashing pipeline (see cmn/cos/hashpipe.go)
+		HashPipeline string
+
 		// tests, CI
 		NumTarget string
 		NumProxy  string
 
+		TestQuarantine     string
+		TestRunQuarantined string
+		TestArtifacts      string
+
 		// K8s
 		K8sPod       string
 		K8sNode      string
@@ -48,6 +56,9 @@ var (
 		Endpoint:  "AIS_ENDPOINT",
 		PrimaryEP: "AIS_PRIMARY_EP",
 
+		// client profile name (see api/profile.go)
+		Profile: "AIS_PROFILE",
+
 		// two CIDRs, respectively:
 		// 1. differentiate local (same CIDR) clients for faster HTTP redirect
 		// 2. at node startup: when present with multiple choices, select one matching local unicast IP
@@ -72,10 +83,20 @@ var (
 		// TLS: common
 		SkipVerifyCrt: "AIS_SKIP_VERIFY_CRT", // cluster config: "net.http.skip_verify"
 
+		// target only: "auto" (default), "on", or "off"
+		HashPipeline: "AIS_HASH_PIPELINE",
+
 		// variables used in tests and CI
 		NumTarget: "NUM_TARGET",
 		NumProxy:  "NUM_PROXY",
 
+		// test harness (see tools/harness.go):
+		// - file listing flaky tests to skip (quarantine), and whether to run them anyway;
+		// - directory to collect failed tests' artifacts (node logs, Smap, BMD)
+		TestQuarantine:     "AIS_TEST_QUARANTINE",
+		TestRunQuarantined: "AIS_TEST_RUN_QUARANTINED",
+		TestArtifacts:      "AIS_TEST_ARTIFACTS",
+
 		// via ais-k8s repo
 		// see also:
 		// * https://github.com/NVIDIA/ais-k8s/blob/main/operator/pkg/resources/cmn/env.go
@@ -114,6 +114,22 @@ func ETLHealth(params BaseParams, etlName string) (healths etl.HealthByTarget, e
 	return
 }
 
+// ETL init message and per-target status, including fallback routing of inline transforms
+// (use `details.Msg()` to unmarshal the former)
+func ETLDetails(params BaseParams, etlName string) (details *etl.Details, err error) {
+	params.Method = http.MethodGet
+	path := apc.URLPathETL.Join(etlName, apc.ETLDetails)
+	reqParams := AllocRp()
+	{
+		reqParams.BaseParams = params
+		reqParams.Path = path
+	}
+	details = &etl.Details{}
+	_, err = reqParams.DoReqAny(details)
+	FreeRp(reqParams)
+	return
+}
+
 func ETLDelete(bp BaseParams, etlName string) (err error) {
 	bp.Method = http.MethodDelete
 	reqParams := AllocRp()
@@ -168,6 +184,9 @@ func ETLObject(bp BaseParams, etlName string, bck cmn.Bck, objName string, w io.
 //
 // msg.Prefix, if specified, applies always and regardless.
 //
+// The job checkpoints its progress on each target; to resume an interrupted (aborted) job,
+// specify its ID in msg.Resume (same buckets, ETL, and prefix). See also: ETLBucketEstimate
+//
 // Returns xaction ID if successful, an error otherwise. See also: api.CopyBucket
 func ETLBucket(bp BaseParams, bckFrom, bckTo cmn.Bck, msg *apc.TCBMsg, fltPresence ...int) (xid string, err error) {
 	if err = bckTo.Validate(); err != nil {
@@ -191,3 +210,27 @@ func ETLBucket(bp BaseParams, bckFrom, bckTo cmn.Bck, msg *apc.TCBMsg, fltPresen
 	FreeRp(reqParams)
 	return
 }
+
+// ETLBucketEstimate estimates offline transformation of the source bucket (see ETLBucket):
+// number of objects and bytes to transform and projected time based on transforming
+// a random sample (`numSamples` per target; zero - default) of the objects.
+// Use `msg.Resume` to estimate the remaining part of an interrupted job.
+func ETLBucketEstimate(bp BaseParams, bckFrom cmn.Bck, msg *apc.TCBMsg, numSamples int) (est *apc.TCBEstimate, err error) {
+	bp.Method = http.MethodGet
+	q := bckFrom.NewQuery()
+	if numSamples > 0 {
+		q.Set(apc.QparamETLSamples, strconv.Itoa(numSamples))
+	}
+	reqParams := AllocRp()
+	{
+		reqParams.BaseParams = bp
+		reqParams.Path = apc.URLPathETL.Join(msg.Transform.Name, apc.ETLEstimate, bckFrom.Name)
+		reqParams.Body = cos.MustMarshal(msg)
+		reqParams.Header = http.Header{cos.HdrContentType: []string{cos.ContentJSON}}
+		reqParams.Query = q
+	}
+	est = &apc.TCBEstimate{}
+	_, err = reqParams.DoReqAny(est)
+	FreeRp(reqParams)
+	return
+}
@@ -0,0 +1,45 @@
+// Package api provides native Go-based API/SDK over HTTP(S).
+/*
+ * Copyright (c) 2024, NVIDIA CORPORATION. All rights reserved.
+ */
+package api
+
+import (
+	"net/http"
+
+	"github.com/NVIDIA/aistore/api/apc"
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/cmn/cos"
+)
+
+// Bucket freeze ======================================================================
+// frozen bucket rejects requests that its freeze mode doesn't permit with cmn.ErrBucketFrozen
+// (HTTP 403) that includes the mode, reason, trigger, and until when; current state is
+// reported in bucket props (`freeze.state`) and bucket summary (see apc.FreezeState)
+
+// FreezeBucket freezes a given bucket in either read-only or no-access mode;
+// non-zero `msg.Duration` thaws the bucket automatically upon expiration.
+func FreezeBucket(bp BaseParams, bck cmn.Bck, msg *apc.FreezeMsg) error {
+	return freezeBck(bp, bck, apc.ActMsg{Action: apc.ActFreezeBck, Value: msg})
+}
+
+// ThawBucket lifts (explicit or automatic) freeze.
+// Note that quota-triggered freeze will be re-applied unless the quota is raised or removed.
+func ThawBucket(bp BaseParams, bck cmn.Bck) error {
+	return freezeBck(bp, bck, apc.ActMsg{Action: apc.ActThawBck})
+}
+
+func freezeBck(bp BaseParams, bck cmn.Bck, actMsg apc.ActMsg) error {
+	bp.Method = http.MethodPost
+	reqParams := AllocRp()
+	{
+		reqParams.BaseParams = bp
+		reqParams.Path = apc.URLPathBuckets.Join(bck.Name)
+		reqParams.Body = cos.MustMarshal(actMsg)
+		reqParams.Header = http.Header{cos.HdrContentType: []string{cos.ContentJSON}}
+		reqParams.Query = bck.NewQuery()
+	}
+	err := reqParams.DoRequest()
+	FreeRp(reqParams)
+	return err
+}
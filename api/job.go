@@ -0,0 +1,94 @@
+// Package api provides native Go-based API/SDK over HTTP(S).
+/*
+ * Copyright (c) 2024, NVIDIA CORPORATION. All rights reserved.
+ */
+package api
+
+import (
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/NVIDIA/aistore/api/apc"
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/cmn/cos"
+)
+
+// Unified jobs API: xactions, dsort, downloads, ETLs, and pipelines - see cmn.Job
+// Job ID can be either unified ("<type>:<native-ID>", as in: cmn.Job.ID) or native.
+
+// ListJobs returns all jobs or, optionally, only those of a given type (cmn.JobType* enum)
+// and/or kind (e.g., apc.ActCopyBck); empty filters match all
+func ListJobs(bp BaseParams, typ, kind string, onlyActive bool) (jobs cmn.Jobs, err error) {
+	q := make(url.Values, 3)
+	if typ != "" {
+		q.Set(apc.QparamJobType, typ)
+	}
+	if kind != "" {
+		q.Set(apc.QparamJobKind, kind)
+	}
+	if onlyActive {
+		q.Set(apc.QparamOnlyActive, "true")
+	}
+	bp.Method = http.MethodGet
+	reqParams := AllocRp()
+	{
+		reqParams.BaseParams = bp
+		reqParams.Path = apc.URLPathJobs.S
+		reqParams.Query = q
+	}
+	_, err = reqParams.DoReqAny(&jobs)
+	FreeRp(reqParams)
+	return
+}
+
+// GetJob returns the job's current state; non-zero `wait` blocks server-side
+// (up to the specified duration) until the job finishes
+func GetJob(bp BaseParams, jobID string, wait time.Duration) (*cmn.Job, error) {
+	bp.Method = http.MethodGet
+	reqParams := AllocRp()
+	{
+		reqParams.BaseParams = bp
+		reqParams.Path = apc.URLPathJobs.Join(jobID)
+		if wait > 0 {
+			reqParams.Query = url.Values{apc.QparamWait: []string{wait.String()}}
+		}
+	}
+	job := &cmn.Job{}
+	_, err := reqParams.DoReqAny(job)
+	FreeRp(reqParams)
+	if err != nil {
+		return nil, err
+	}
+	return job, nil
+}
+
+// AbortJob aborts the job (for ETLs: stops the ETL)
+func AbortJob(bp BaseParams, jobID string) error {
+	bp.Method = http.MethodDelete
+	reqParams := AllocRp()
+	{
+		reqParams.BaseParams = bp
+		reqParams.Path = apc.URLPathJobs.Join(jobID)
+	}
+	err := reqParams.DoRequest()
+	FreeRp(reqParams)
+	return err
+}
+
+// StartPipeline submits a composite job - a DAG of prefetch, copy, and ETL steps (see cmn.PipelineMsg)
+// executed by the cluster in dependency order; returns unified job ID to be used with GetJob, AbortJob,
+// and ListJobs (type cmn.JobTypePipeline)
+func StartPipeline(bp BaseParams, msg *cmn.PipelineMsg) (jobID string, err error) {
+	bp.Method = http.MethodPost
+	reqParams := AllocRp()
+	{
+		reqParams.BaseParams = bp
+		reqParams.Path = apc.URLPathJobs.S
+		reqParams.Body = cos.MustMarshal(apc.ActMsg{Action: apc.ActPipeline, Value: msg})
+		reqParams.Header = http.Header{cos.HdrContentType: []string{cos.ContentJSON}}
+	}
+	_, err = reqParams.doReqStr(&jobID)
+	FreeRp(reqParams)
+	return
+}
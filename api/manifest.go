@@ -0,0 +1,57 @@
+// Package api provides native Go-based API/SDK over HTTP(S).
+/*
+ * Copyright (c) 2024, NVIDIA CORPORATION. All rights reserved.
+ */
+package api
+
+import (
+	"net/http"
+
+	"github.com/NVIDIA/aistore/api/apc"
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/cmn/cos"
+)
+
+// MakeManifest returns signed manifest (names, sizes, checksums, and versions) of
+// all in-cluster objects in a given bucket or, if non-empty, a given prefix (virtual
+// subdirectory); the manifest can be stored and later used with VerifyManifest
+// to prove that a dataset (e.g., ML training data) has not changed.
+func MakeManifest(bp BaseParams, bck cmn.Bck, prefix string) (*cmn.Manifest, error) {
+	var mf cmn.Manifest
+	bp.Method = http.MethodPost
+	reqParams := AllocRp()
+	{
+		reqParams.BaseParams = bp
+		reqParams.Path = apc.URLPathBuckets.Join(bck.Name)
+		reqParams.Body = cos.MustMarshal(apc.ActMsg{Action: apc.ActMakeManifest, Value: apc.ManifestMsg{Prefix: prefix}})
+		reqParams.Header = http.Header{cos.HdrContentType: []string{cos.ContentJSON}}
+		reqParams.Query = bck.NewQuery()
+	}
+	_, err := reqParams.DoReqAny(&mf)
+	FreeRp(reqParams)
+	if err != nil {
+		return nil, err
+	}
+	return &mf, nil
+}
+
+// VerifyManifest checks the manifest's signature and compares the current
+// content of the bucket (or prefix) with the manifest; see also ManifestDiff.Match
+func VerifyManifest(bp BaseParams, mf *cmn.Manifest) (*cmn.ManifestDiff, error) {
+	var diff cmn.ManifestDiff
+	bp.Method = http.MethodPost
+	reqParams := AllocRp()
+	{
+		reqParams.BaseParams = bp
+		reqParams.Path = apc.URLPathBuckets.Join(mf.Bck.Name)
+		reqParams.Body = cos.MustMarshal(apc.ActMsg{Action: apc.ActVerifyManifest, Value: mf})
+		reqParams.Header = http.Header{cos.HdrContentType: []string{cos.ContentJSON}}
+		reqParams.Query = mf.Bck.NewQuery()
+	}
+	_, err := reqParams.DoReqAny(&diff)
+	FreeRp(reqParams)
+	if err != nil {
+		return nil, err
+	}
+	return &diff, nil
+}
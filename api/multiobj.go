@@ -72,6 +72,23 @@ func Prefetch(bp BaseParams, bck cmn.Bck, msg apc.PrefetchMsg) (string, error) {
 	return dolr(bp, bck, apc.ActPrefetchObjects, msg, q)
 }
 
+// SetPrefetchCursor reports the client's current position in the epoch-ahead prefetch order
+// (the number of objects read so far) - see apc.PrefetchMsg.Ahead
+func SetPrefetchCursor(bp BaseParams, xid string, cursor int64) error {
+	msg := apc.ActMsg{Action: apc.ActPrefetchCursor, Value: &apc.PrefetchCursorMsg{ID: xid, Cursor: cursor}}
+	bp.Method = http.MethodPut
+	reqParams := AllocRp()
+	{
+		reqParams.BaseParams = bp
+		reqParams.Path = apc.URLPathClu.S
+		reqParams.Body = cos.MustMarshal(msg)
+		reqParams.Header = http.Header{cos.HdrContentType: []string{cos.ContentJSON}}
+	}
+	err := reqParams.DoRequest()
+	FreeRp(reqParams)
+	return err
+}
+
 // multi-object list-range (delete, prefetch, evict, archive, copy, and etl)
 func dolr(bp BaseParams, bck cmn.Bck, action string, msg any, q url.Values) (xid string, err error) {
 	reqParams := AllocRp()
@@ -22,6 +22,10 @@ const (
 	httpMaxRetries = 5                      // maximum number of retries for an HTTP request
 	httpRetrySleep = 100 * time.Millisecond // a sleep between HTTP request retries
 
+	// 503 with Retry-After (see reqResp.call)
+	admitMaxRetries = 5
+	admitMaxWait    = 10 * time.Second
+
 	// Sleep between HTTP retries for error[rate of change requests exceeds limit] - must be > 1s:
 	// From https://cloud.google.com/storage/quotas#objects
 	// * "There is an update limit on each object of once per second..."
@@ -92,6 +96,10 @@ type (
 		// - we massively write a new content into a bucket, and/or
 		// - we simply don't care.
 		SkipVC bool
+
+		// optional: number of copies of this object (replication override), regardless of
+		// the bucket's mirror config (see also SetObjectCopies)
+		Copies int
 	}
 )
 
@@ -134,6 +142,22 @@ type (
 	}
 )
 
+// PATCH(object): write range
+type (
+	WriteRangeArgs struct {
+		Reader     cos.ReadOpenCloser // exactly Size bytes to write
+		BaseParams BaseParams
+		Bck        cmn.Bck
+		ObjName    string
+		Offset     int64 // must not exceed the current object size
+		Size       int64
+
+		// recompute (and store) the object's checksum right away;
+		// otherwise, the checksum gets removed and later lazily recomputed (e.g., upon validation)
+		RecomputeCksum bool
+	}
+)
+
 // GET(object) =========================================================================================
 //
 // If GetArgs.Writer is specified GetObject will use it to write the response body;
@@ -282,6 +306,9 @@ func (args *PutArgs) put(reqArgs *cmn.HreqArgs) (*http.Request, error) {
 	if args.Size != 0 {
 		req.ContentLength = int64(args.Size) // as per https://tools.ietf.org/html/rfc7230#section-3.3.2
 	}
+	if args.Copies > 0 {
+		req.Header.Set(apc.HdrObjCopies, strconv.Itoa(args.Copies))
+	}
 	SetAuxHeaders(req, &args.BaseParams)
 	return req, nil
 }
@@ -297,7 +324,7 @@ func PutObject(args *PutArgs) (oah ObjAttrs, err error) {
 	reqArgs := cmn.AllocHra()
 	{
 		reqArgs.Method = http.MethodPut
-		reqArgs.Base = args.BaseParams.URL
+		reqArgs.Base = args.BaseParams.base()
 		reqArgs.Path = apc.URLPathObjects.Join(args.Bck.Name, args.ObjName)
 		reqArgs.Query = query
 		reqArgs.BodyR = args.Reader
@@ -400,6 +427,14 @@ func SetObjectCustomProps(bp BaseParams, bck cmn.Bck, objName string, custom cos
 	return err
 }
 
+// Set (or, with zero copies, remove) per-object replication override: the number of copies
+// of the object regardless of the bucket's mirror config; the copies get added or removed
+// synchronously.
+func SetObjectCopies(bp BaseParams, bck cmn.Bck, objName string, copies int) error {
+	custom := cos.StrKVs{cmn.CopiesObjMD: strconv.Itoa(copies)}
+	return SetObjectCustomProps(bp, bck, objName, custom, false /*setNew*/)
+}
+
 // DELETE(object) ======================================================================================
 
 func DeleteObject(bp BaseParams, bck cmn.Bck, objName string) error {
@@ -463,7 +498,7 @@ func PutApndArch(args *PutApndArchArgs) (err error) {
 	reqArgs := cmn.AllocHra()
 	{
 		reqArgs.Method = http.MethodPut
-		reqArgs.Base = args.BaseParams.URL
+		reqArgs.Base = args.BaseParams.base()
 		reqArgs.Path = apc.URLPathObjects.Join(args.Bck.Name, args.ObjName)
 		reqArgs.Query = q
 		reqArgs.BodyR = args.Reader
@@ -513,7 +548,7 @@ func AppendObject(args *AppendArgs) (string /*handle*/, error) {
 	reqArgs := cmn.AllocHra()
 	{
 		reqArgs.Method = http.MethodPut
-		reqArgs.Base = args.BaseParams.URL
+		reqArgs.Base = args.BaseParams.base()
 		reqArgs.Path = apc.URLPathObjects.Join(args.Bck.Name, args.Object)
 		reqArgs.Query = q
 		reqArgs.BodyR = args.Reader
@@ -558,6 +593,44 @@ func FlushObject(args *FlushArgs) error {
 	return err
 }
 
+// WriteObjectRange writes a byte range into an existing ais:// object, in place -
+// an alternative to re-uploading (PUT) the entire object when only a part of it changes.
+// The range [Offset, Offset+Size) may extend the object but must not start beyond its end.
+func WriteObjectRange(args *WriteRangeArgs) error {
+	q := args.Bck.NewQuery()
+	if args.RecomputeCksum {
+		q.Set(apc.QparamRecomputeCksum, "true")
+	}
+	reqArgs := cmn.AllocHra()
+	{
+		reqArgs.Method = http.MethodPatch
+		reqArgs.Base = args.BaseParams.base()
+		reqArgs.Path = apc.URLPathObjects.Join(args.Bck.Name, args.ObjName)
+		reqArgs.Query = q
+		reqArgs.BodyR = args.Reader
+		reqArgs.Header = http.Header{
+			cos.HdrContentRange: []string{fmt.Sprintf("%s%d-%d/*", cos.HdrContentRangeValPrefix,
+				args.Offset, args.Offset+args.Size-1)},
+		}
+	}
+	_, err := DoWithRetry(args.BaseParams.Client, args.patch, reqArgs) //nolint:bodyclose // is closed inside
+	cmn.FreeHra(reqArgs)
+	return err
+}
+
+func (args *WriteRangeArgs) getBody() (io.ReadCloser, error) { return args.Reader.Open() }
+
+func (args *WriteRangeArgs) patch(reqArgs *cmn.HreqArgs) (*http.Request, error) {
+	req, err := reqArgs.Req()
+	if err != nil {
+		return nil, newErrCreateHTTPRequest(err)
+	}
+	req.GetBody = args.getBody
+	req.ContentLength = args.Size
+	SetAuxHeaders(req, &args.BaseParams)
+	return req, nil
+}
+
 // Rename(object) ==============================================================================
 // renames object name from `oldName` to `newName`. Works only within a given specified bucket.
 
@@ -576,6 +649,49 @@ func RenameObject(bp BaseParams, bck cmn.Bck, oldName, newName string) error {
 	return err
 }
 
+// Advisory object locks ===========================================================
+// acquire, renew, and release object lock (lease); see apc.ObjLockMsg for details.
+// The returned fencing token can be passed along with subsequent writes
+// via `apc.HdrObjLockToken` header (e.g., `PutArgs.Header`).
+
+func LockObject(bp BaseParams, bck cmn.Bck, objName, owner string, ttl time.Duration) (*apc.ObjLock, error) {
+	msg := &apc.ObjLockMsg{Owner: owner, TTL: cos.Duration(ttl)}
+	return objLockAction(bp, bck, objName, apc.ActLockObject, msg)
+}
+
+func RenewObjLock(bp BaseParams, bck cmn.Bck, objName string, lock *apc.ObjLock, ttl time.Duration) (*apc.ObjLock, error) {
+	msg := &apc.ObjLockMsg{Owner: lock.Owner, TTL: cos.Duration(ttl), Token: lock.Token}
+	return objLockAction(bp, bck, objName, apc.ActRenewObjLock, msg)
+}
+
+func UnlockObject(bp BaseParams, bck cmn.Bck, objName string, lock *apc.ObjLock) error {
+	msg := &apc.ObjLockMsg{Owner: lock.Owner, Token: lock.Token}
+	_, err := objLockAction(bp, bck, objName, apc.ActUnlockObject, msg)
+	return err
+}
+
+func objLockAction(bp BaseParams, bck cmn.Bck, objName, action string, msg *apc.ObjLockMsg) (*apc.ObjLock, error) {
+	var (
+		lock   = &apc.ObjLock{}
+		actMsg = apc.ActMsg{Action: action, Name: objName, Value: msg}
+	)
+	bp.Method = http.MethodPost
+	reqParams := AllocRp()
+	{
+		reqParams.BaseParams = bp
+		reqParams.Path = apc.URLPathObjects.Join(bck.Name)
+		reqParams.Body = cos.MustMarshal(actMsg)
+		reqParams.Header = http.Header{cos.HdrContentType: []string{cos.ContentJSON}}
+		reqParams.Query = bck.NewQuery()
+	}
+	_, err := reqParams.DoReqAny(lock)
+	FreeRp(reqParams)
+	if err != nil {
+		return nil, err
+	}
+	return lock, nil
+}
+
 // Promote =========================================================================================
 // promote POSIX files and/or directories to (become) in-cluster objects.
 
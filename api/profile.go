@@ -0,0 +1,200 @@
+// Package api provides native Go-based API/SDK over HTTP(S).
+/*
+ * Copyright (c) 2024, NVIDIA CORPORATION. All rights reserved.
+ */
+package api
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/NVIDIA/aistore/api/env"
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/cmn/cos"
+	"github.com/NVIDIA/aistore/cmn/fname"
+	"github.com/NVIDIA/aistore/cmn/jsp"
+)
+
+// Client profiles ==========================================================================
+//
+// A single client-side file - $HOME/.config/ais/profiles - contains named profiles, each
+// specifying an AIS endpoint along with the credentials, TLS, and timeouts to access it:
+//
+// {
+//   "default": "prod",
+//   "profiles": {
+//     "prod":  {"endpoint": "https://ais.example.com:51080", "token_file": "/home/me/prod.token", "ca": "/etc/ssl/ca.pem"},
+//     "local": {"endpoint": "http://localhost:8080", "timeout": "2m"}
+//   }
+// }
+//
+// Selecting a profile: explicitly by name (e.g., aisloader's `-profile`), or via the AIS_PROFILE
+// environment, or else the "default" one (see LoadProfile).
+// Explicitly specified endpoint and credentials (AIS_ENDPOINT, AIS_AUTHN_TOKEN, command-line)
+// take precedence - it is up to the tool to apply them on top of the profile.
+
+const dfltProfileTimeout = 10 * time.Minute
+
+type (
+	Profile struct {
+		Name        string       `json:"-"`
+		Endpoint    string       `json:"endpoint"`
+		Token       string       `json:"token,omitempty"`      // token value (takes precedence over token_file)
+		TokenFile   string       `json:"token_file,omitempty"` // as in: `ais auth login ... -f`
+		Certificate string       `json:"certificate,omitempty"`
+		Key         string       `json:"key,omitempty"`
+		ClientCA    string       `json:"ca,omitempty"`
+		Timeout     cos.Duration `json:"timeout,omitempty"`      // request timeout (default: 10m; negative: none)
+		DialTimeout cos.Duration `json:"dial_timeout,omitempty"` // default: 30s (see cmn.NewTransport)
+		SkipVerify  bool         `json:"skip_verify,omitempty"`  // skip server certificate verification
+	}
+	Profiles struct {
+		Profiles map[string]*Profile `json:"profiles"`
+		Default  string              `json:"default,omitempty"`
+	}
+)
+
+// ProfilesPath returns the location of the profiles file.
+func ProfilesPath() string {
+	return cos.HomeConfigDir(fname.Profiles)
+}
+
+// LoadProfiles loads the profiles file; empty path means the default location.
+func LoadProfiles(fpath string) (*Profiles, error) {
+	if fpath == "" {
+		fpath = ProfilesPath()
+	}
+	profiles := &Profiles{}
+	if _, err := jsp.Load(fpath, profiles, jsp.Plain()); err != nil {
+		if os.IsNotExist(err) {
+			return nil, fmt.Errorf("profiles file %q does not exist", fpath)
+		}
+		return nil, fmt.Errorf("failed to load profiles from %q: %v", fpath, err)
+	}
+	for name, p := range profiles.Profiles {
+		if p == nil {
+			return nil, fmt.Errorf("%s: profile %q is empty", fpath, name)
+		}
+		p.Name = name
+		if err := p.validate(); err != nil {
+			return nil, fmt.Errorf("%s: %v", fpath, err)
+		}
+	}
+	if profiles.Default != "" {
+		if _, ok := profiles.Profiles[profiles.Default]; !ok {
+			return nil, fmt.Errorf("%s: default profile %q not found", fpath, profiles.Default)
+		}
+	}
+	return profiles, nil
+}
+
+// LoadProfile loads the named profile from the default location; when the name is empty,
+// selects the profile named by AIS_PROFILE environment, or else the default one.
+func LoadProfile(name string) (*Profile, error) {
+	if name == "" {
+		name = os.Getenv(env.AIS.Profile)
+	}
+	profiles, err := LoadProfiles("")
+	if err != nil {
+		return nil, err
+	}
+	return profiles.Get(name)
+}
+
+func (ps *Profiles) Get(name string) (*Profile, error) {
+	if name == "" {
+		if ps.Default == "" {
+			return nil, errors.New("profile not specified and no default profile (hint: " + env.AIS.Profile + ")")
+		}
+		name = ps.Default
+	}
+	if p, ok := ps.Profiles[name]; ok {
+		return p, nil
+	}
+	return nil, fmt.Errorf("profile %q not found (have: %v)", name, ps.Names())
+}
+
+func (ps *Profiles) Names() []string {
+	names := make([]string, 0, len(ps.Profiles))
+	for name := range ps.Profiles {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+/////////////
+// Profile //
+/////////////
+
+func (p *Profile) validate() error {
+	if p.Endpoint == "" {
+		return fmt.Errorf("profile %q: endpoint is empty", p.Name)
+	}
+	scheme, _ := cmn.ParseURLScheme(p.Endpoint)
+	if scheme != "http" && scheme != "https" {
+		return fmt.Errorf("profile %q: invalid endpoint %q (expecting http:// or https:// URL)", p.Name, p.Endpoint)
+	}
+	return nil
+}
+
+func (p *Profile) IsHTTPS() bool { return strings.HasPrefix(p.Endpoint, "https://") }
+
+// LoadToken returns the profile's token: token value, or else the one loaded from token_file.
+func (p *Profile) LoadToken() (string, error) {
+	if p.Token != "" || p.TokenFile == "" {
+		return p.Token, nil
+	}
+	var token struct {
+		Token string `json:"token"`
+	}
+	if _, err := jsp.Load(p.TokenFile, &token, jsp.Plain()); err != nil {
+		return "", fmt.Errorf("profile %q: failed to load token from %q: %v", p.Name, p.TokenFile, err)
+	}
+	return token.Token, nil
+}
+
+func (p *Profile) TransportArgs() cmn.TransportArgs {
+	cargs := cmn.TransportArgs{
+		DialTimeout: p.DialTimeout.D(),
+		Timeout:     p.Timeout.D(),
+	}
+	switch {
+	case cargs.Timeout == 0:
+		cargs.Timeout = dfltProfileTimeout
+	case cargs.Timeout < 0:
+		cargs.Timeout = 0
+	}
+	return cargs
+}
+
+func (p *Profile) TLSArgs() cmn.TLSArgs {
+	return cmn.TLSArgs{
+		Certificate: p.Certificate,
+		Key:         p.Key,
+		ClientCA:    p.ClientCA,
+		SkipVerify:  p.SkipVerify,
+	}
+}
+
+// NewClient returns HTTP(S) client configured as per profile.
+func (p *Profile) NewClient() *http.Client {
+	if p.IsHTTPS() {
+		return cmn.NewClientTLS(p.TransportArgs(), p.TLSArgs(), false /*intra-cluster*/)
+	}
+	return cmn.NewClient(p.TransportArgs())
+}
+
+// BaseParams returns API base params - endpoint, client, and token - as per profile.
+func (p *Profile) BaseParams() (BaseParams, error) {
+	token, err := p.LoadToken()
+	if err != nil {
+		return BaseParams{}, err
+	}
+	return BaseParams{Client: p.NewClient(), URL: p.Endpoint, Token: token}, nil
+}
@@ -0,0 +1,281 @@
+// Package api provides native Go-based API/SDK over HTTP(S).
+/*
+ * Copyright (c) 2024, NVIDIA CORPORATION. All rights reserved.
+ */
+package api
+
+import (
+	"encoding/hex"
+	"errors"
+	"io"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/NVIDIA/aistore/api/apc"
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/cmn/cos"
+)
+
+// PUT(object) with retries ====================================================================
+//
+// `PutObject` retries only failed connection attempts: once the transfer breaks midway,
+// the (consumed) reader cannot be replayed. `PutObjectRetry`, on the other hand, takes
+// a rewindable source and:
+// - retries transient errors: connection refused/reset, broken pipe, unexpected EOF,
+//   and 429, 502, 503, 504 responses;
+// - backs off between retries, starting from `RetrySleep` and increasing x1.5;
+// - reports upload progress via optional callback.
+//
+// NOTE: PUT is atomic - the target discards partially received content, and
+// there's currently no resume-from-offset; each retry re-sends the entire object
+// (and the progress starts from zero).
+
+type (
+	// opens a new reader positioned at the beginning of the object's content
+	PutSrcOpener func() (io.ReadCloser, error)
+
+	// (sent, size) - the number of bytes sent so far in the current attempt, and
+	// the object size or -1 when not specified
+	PutProgressCB func(sent, size int64)
+
+	PutRetryArgs struct {
+		// source: exactly one of the following three
+		ReaderAt   io.ReaderAt   // requires `Size`
+		ReadSeeker io.ReadSeeker // rewinds to the current (initial) offset; not closed
+		Open       PutSrcOpener  // the returned reader gets closed after each attempt
+
+		// optional; same as `PutArgs.Cksum` except that empty checksum value
+		// gets computed once, prior to the first attempt
+		Cksum *cos.Cksum
+
+		// optional
+		Progress PutProgressCB
+
+		BaseParams BaseParams
+
+		Bck     cmn.Bck
+		ObjName string
+
+		Size int64 // required with `ReaderAt`; otherwise, optional (recommended)
+
+		MaxRetries int           // default: 5
+		RetrySleep time.Duration // default: 100ms
+
+		SkipVC bool // see `PutArgs.SkipVC`
+	}
+
+	// implements cos.ReadOpenCloser (see `PutArgs.Reader`)
+	putSrc struct {
+		args   *PutRetryArgs
+		r      io.Reader
+		closer io.Closer
+		offset int64 // ReadSeeker's initial offset
+		sent   int64
+	}
+)
+
+// interface guard
+var _ cos.ReadOpenCloser = (*putSrc)(nil)
+
+func PutObjectRetry(args *PutRetryArgs) (oah ObjAttrs, err error) {
+	var (
+		src   *putSrc
+		hdr   http.Header
+		query = args.Bck.NewQuery()
+		maxn  = args.MaxRetries
+		sleep = args.RetrySleep
+	)
+	if src, err = args.src(); err != nil {
+		return
+	}
+	if args.Cksum != nil && args.Cksum.Ty() != cos.ChecksumNone && args.Cksum.Value() == "" {
+		// (the source gets consumed - rewind)
+		if err = args.cksum(src); err != nil {
+			return
+		}
+		if src, err = src.reopen(); err != nil {
+			return
+		}
+	}
+	if args.SkipVC {
+		query.Set(apc.QparamSkipVC, "true")
+	}
+	if maxn <= 0 {
+		maxn = httpMaxRetries
+	}
+	if sleep <= 0 {
+		sleep = httpRetrySleep
+	}
+	for i := 0; ; i++ {
+		if i > 0 {
+			if src, err = src.reopen(); err != nil {
+				return
+			}
+		}
+		hdr, err = args.do(src, query)
+		if err == nil {
+			oah.wrespHeader = hdr
+			return
+		}
+		if i >= maxn || !isRetriablePut(err) {
+			return
+		}
+		time.Sleep(sleep)
+		sleep += sleep / 2
+	}
+}
+
+func (args *PutRetryArgs) src() (*putSrc, error) {
+	var n int
+	if args.ReaderAt != nil {
+		n++
+	}
+	if args.ReadSeeker != nil {
+		n++
+	}
+	if args.Open != nil {
+		n++
+	}
+	if n != 1 {
+		return nil, errors.New("PUT with retry: expecting exactly one source (ReaderAt, ReadSeeker, or Open)")
+	}
+	src := &putSrc{args: args}
+	switch {
+	case args.ReaderAt != nil:
+		if args.Size <= 0 {
+			return nil, errors.New("PUT with retry: ReaderAt requires object size")
+		}
+		src.r = io.NewSectionReader(args.ReaderAt, 0, args.Size)
+	case args.ReadSeeker != nil:
+		offset, err := args.ReadSeeker.Seek(0, io.SeekCurrent)
+		if err != nil {
+			return nil, err
+		}
+		src.r, src.offset = args.ReadSeeker, offset
+	default:
+		r, err := args.Open()
+		if err != nil {
+			return nil, err
+		}
+		src.r, src.closer = r, r
+	}
+	return src, nil
+}
+
+func (args *PutRetryArgs) cksum(src *putSrc) error {
+	_, ckhash, err := cos.CopyAndChecksum(io.Discard, src.r, nil, args.Cksum.Ty())
+	src.Close()
+	if err != nil {
+		return err
+	}
+	args.Cksum = cos.NewCksum(args.Cksum.Ty(), hex.EncodeToString(ckhash.Sum()))
+	return nil
+}
+
+// single attempt; always closes the source
+func (args *PutRetryArgs) do(src *putSrc, query url.Values) (http.Header, error) {
+	putArgs := &PutArgs{
+		Reader:     src,
+		Cksum:      args.Cksum,
+		BaseParams: args.BaseParams,
+		Bck:        args.Bck,
+		ObjName:    args.ObjName,
+	}
+	if args.Size > 0 {
+		putArgs.Size = uint64(args.Size)
+	}
+	reqArgs := cmn.AllocHra()
+	{
+		reqArgs.Method = http.MethodPut
+		reqArgs.Base = args.BaseParams.base()
+		reqArgs.Path = apc.URLPathObjects.Join(args.Bck.Name, args.ObjName)
+		reqArgs.Query = query
+		reqArgs.BodyR = src
+	}
+	req, err := putArgs.put(reqArgs)
+	cmn.FreeHra(reqArgs)
+	if err != nil {
+		src.Close()
+		return nil, err
+	}
+	resp, err := args.BaseParams.Client.Do(req) // (closes request body)
+	if err != nil {
+		return nil, err
+	}
+	reqParams := AllocRp()
+	{
+		reqParams.BaseParams = args.BaseParams
+		reqParams.BaseParams.Method = http.MethodPut
+		reqParams.Path = req.URL.Path
+	}
+	err = reqParams.checkResp(resp)
+	FreeRp(reqParams)
+	cos.DrainReader(resp.Body)
+	cos.Close(resp.Body)
+	return resp.Header, err
+}
+
+func isRetriablePut(err error) bool {
+	if cos.IsRetriableConnErr(err) || errors.Is(err, io.ErrUnexpectedEOF) {
+		return true
+	}
+	herr, ok := err.(*cmn.ErrHTTP)
+	if !ok {
+		return false
+	}
+	switch herr.Status {
+	case http.StatusTooManyRequests, http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return true
+	default:
+		return false
+	}
+}
+
+////////////
+// putSrc //
+////////////
+
+func (src *putSrc) Read(b []byte) (n int, err error) {
+	n, err = src.r.Read(b)
+	if n > 0 && src.args.Progress != nil {
+		src.sent += int64(n)
+		size := src.args.Size
+		if size <= 0 {
+			size = -1
+		}
+		src.args.Progress(src.sent, size)
+	}
+	return n, err
+}
+
+func (src *putSrc) Close() error {
+	if src.closer == nil {
+		return nil
+	}
+	return src.closer.Close()
+}
+
+// Open is called (via http.Request.GetBody) to replay the content upon redirect
+func (src *putSrc) Open() (cos.ReadOpenCloser, error) { return src.reopen() }
+
+func (src *putSrc) reopen() (*putSrc, error) {
+	args := src.args
+	nsrc := &putSrc{args: args, offset: src.offset}
+	switch {
+	case args.ReaderAt != nil:
+		nsrc.r = io.NewSectionReader(args.ReaderAt, 0, args.Size)
+	case args.ReadSeeker != nil:
+		if _, err := args.ReadSeeker.Seek(src.offset, io.SeekStart); err != nil {
+			return nil, err
+		}
+		nsrc.r = args.ReadSeeker
+	default:
+		r, err := args.Open()
+		if err != nil {
+			return nil, err
+		}
+		nsrc.r, nsrc.closer = r, r
+	}
+	return nsrc, nil
+}
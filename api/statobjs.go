@@ -0,0 +1,35 @@
+// Package api provides native Go-based API/SDK over HTTP(S).
+/*
+ * Copyright (c) 2024, NVIDIA CORPORATION. All rights reserved.
+ */
+package api
+
+import (
+	"io"
+	"net/http"
+
+	"github.com/NVIDIA/aistore/api/apc"
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/cmn/cos"
+)
+
+// StatObjects returns a stream of objects' metadata (cmn.ObjStat) - one object per line,
+// in no particular order, NDJSON- or CSV-formatted as per apc.StatObjsMsg.
+// Only the objects stored in the cluster are included (for remote buckets, that means
+// "cached" or "present"). The caller must read the stream to completion and close it;
+// any read error (other than io.EOF) indicates an incomplete result.
+// Consider using BaseParams with a client that has no (or a long enough) timeout.
+func StatObjects(bp BaseParams, bck cmn.Bck, msg *apc.StatObjsMsg) (io.ReadCloser, error) {
+	bp.Method = http.MethodGet
+	reqParams := AllocRp()
+	{
+		reqParams.BaseParams = bp
+		reqParams.Path = apc.URLPathBuckets.Join(bck.Name)
+		reqParams.Body = cos.MustMarshal(apc.ActMsg{Action: apc.ActStatObjects, Value: msg})
+		reqParams.Header = http.Header{cos.HdrContentType: []string{cos.ContentJSON}}
+		reqParams.Query = bck.NewQuery()
+	}
+	r, _, err := reqParams.doReader()
+	FreeRp(reqParams)
+	return r, err
+}
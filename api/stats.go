@@ -7,6 +7,8 @@ package api
 import (
 	"net/http"
 	"net/url"
+	"strconv"
+	"time"
 
 	"github.com/NVIDIA/aistore/api/apc"
 	"github.com/NVIDIA/aistore/cmn/cos"
@@ -98,6 +100,30 @@ func GetStatsAndStatusV322(bp BaseParams, node *meta.Snode) (ds *stats.NodeStatu
 	return ds, err
 }
 
+// returns node's metrics history in the [since, until] time range
+// - zero `until` means "until now"
+// - history retention is configurable (see `config.Periodic.StatsHistory`)
+func GetStatsHistory(bp BaseParams, node *meta.Snode, since, until time.Time) (hist *stats.History, err error) {
+	q := make(url.Values, 3)
+	q.Set(apc.QparamWhat, apc.WhatNodeStats)
+	q.Set(apc.QparamSince, cos.UnixNano2S(since.UnixNano()))
+	if !until.IsZero() {
+		q.Set(apc.QparamUntil, cos.UnixNano2S(until.UnixNano()))
+	}
+	bp.Method = http.MethodGet
+	reqParams := AllocRp()
+	{
+		reqParams.BaseParams = bp
+		reqParams.Path = apc.URLPathReverseDae.S // NOTE: reverse, via p.reverseHandler
+		reqParams.Query = q
+		reqParams.Header = http.Header{apc.HdrNodeID: []string{node.ID()}}
+	}
+	hist = &stats.History{}
+	_, err = reqParams.DoReqAny(hist)
+	FreeRp(reqParams)
+	return hist, err
+}
+
 func GetAnyStats(bp BaseParams, sid, what string) (out []byte, err error) {
 	bp.Method = http.MethodGet
 	reqParams := AllocRp()
@@ -129,3 +155,48 @@ func ResetClusterStats(bp BaseParams, errorsOnly bool) (err error) {
 func ResetDaemonStats(bp BaseParams, node *meta.Snode, errorsOnly bool) error {
 	return _putDaemon(bp, node.ID(), apc.ActMsg{Action: apc.ActResetStats, Value: errorsOnly})
 }
+
+// GetTop returns top-N objects and prefixes by request rate and bytes over the trailing
+// window (see stats.TopReport):
+// - cluster-wide, when node is nil;
+// - otherwise, a given target's
+func GetTop(bp BaseParams, node *meta.Snode, n int) (rep *stats.TopReport, err error) {
+	q := make(url.Values, 2)
+	q.Set(apc.QparamWhat, apc.WhatTop)
+	if n > 0 {
+		q.Set(apc.QparamTopN, strconv.Itoa(n))
+	}
+	bp.Method = http.MethodGet
+	reqParams := AllocRp()
+	{
+		reqParams.BaseParams = bp
+		reqParams.Path = apc.URLPathClu.S
+		reqParams.Query = q
+		if node != nil {
+			reqParams.Path = apc.URLPathReverseDae.S // NOTE: reverse, via p.reverseHandler
+			reqParams.Header = http.Header{apc.HdrNodeID: []string{node.ID()}}
+		}
+	}
+	rep = &stats.TopReport{}
+	_, err = reqParams.DoReqAny(rep)
+	FreeRp(reqParams)
+	return rep, err
+}
+
+// GetCapacityReport returns cluster-wide capacity planning report: per-target and
+// per-bucket growth rates and projected time to reach high watermark, out-of-space,
+// and full capacity (see stats.CapReport)
+// - computed from targets' capacity snapshots retained for `config.Periodic.StatsHistory`
+func GetCapacityReport(bp BaseParams) (rep *stats.CapReport, err error) {
+	bp.Method = http.MethodGet
+	reqParams := AllocRp()
+	{
+		reqParams.BaseParams = bp
+		reqParams.Path = apc.URLPathClu.S
+		reqParams.Query = url.Values{apc.QparamWhat: []string{apc.WhatCapacity}}
+	}
+	rep = &stats.CapReport{}
+	_, err = reqParams.DoReqAny(rep)
+	FreeRp(reqParams)
+	return rep, err
+}
@@ -0,0 +1,59 @@
+// Package api provides native Go-based API/SDK over HTTP(S).
+/*
+ * Copyright (c) 2024, NVIDIA CORPORATION. All rights reserved.
+ */
+package api
+
+import (
+	"net/http"
+
+	"github.com/NVIDIA/aistore/api/apc"
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/cmn/cos"
+	"github.com/NVIDIA/aistore/xact"
+)
+
+// Soft delete ========================================================================
+// requires bucket property `trash.enabled` (see cmn.TrashConf)
+
+// ListTrash returns soft-deleted objects (optionally, only those with the given name prefix)
+// sorted by name and, for the same name, most recently deleted first.
+func ListTrash(bp BaseParams, bck cmn.Bck, prefix string) (entries apc.TrashEntries, err error) {
+	bp.Method = http.MethodGet
+	reqParams := AllocRp()
+	{
+		reqParams.BaseParams = bp
+		reqParams.Path = apc.URLPathBuckets.Join(bck.Name)
+		reqParams.Body = cos.MustMarshal(apc.ActMsg{Action: apc.ActListTrash, Name: prefix})
+		reqParams.Header = http.Header{cos.HdrContentType: []string{cos.ContentJSON}}
+		reqParams.Query = bck.NewQuery()
+	}
+	_, err = reqParams.DoReqAny(&entries)
+	FreeRp(reqParams)
+	return entries, err
+}
+
+// RestoreObject restores soft-deleted object - the one deleted at the specified time
+// (see apc.TrashEntry) or, if `deleted` is zero, the most recently deleted one.
+// Fails if the object exists.
+func RestoreObject(bp BaseParams, bck cmn.Bck, objName string, deleted int64) error {
+	actMsg := apc.ActMsg{Action: apc.ActRestoreObject, Name: objName, Value: &apc.RestoreMsg{Deleted: deleted}}
+	bp.Method = http.MethodPost
+	reqParams := AllocRp()
+	{
+		reqParams.BaseParams = bp
+		reqParams.Path = apc.URLPathObjects.Join(bck.Name)
+		reqParams.Body = cos.MustMarshal(actMsg)
+		reqParams.Header = http.Header{cos.HdrContentType: []string{cos.ContentJSON}}
+		reqParams.Query = bck.NewQuery()
+	}
+	err := reqParams.DoRequest()
+	FreeRp(reqParams)
+	return err
+}
+
+// PurgeTrash starts (apc.ActPurgeTrash) xaction to permanently remove soft-deleted objects
+// that are older than the bucket's retention or, when `all` is true, all of them.
+func PurgeTrash(bp BaseParams, bck cmn.Bck, all bool) (xid string, err error) {
+	return StartXaction(bp, &xact.ArgsMsg{Kind: apc.ActPurgeTrash, Bck: bck, Force: all}, "")
+}
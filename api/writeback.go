@@ -0,0 +1,52 @@
+// Package api provides native Go-based API/SDK over HTTP(S).
+/*
+ * Copyright (c) 2024, NVIDIA CORPORATION. All rights reserved.
+ */
+package api
+
+import (
+	"net/http"
+
+	"github.com/NVIDIA/aistore/api/apc"
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/cmn/cos"
+)
+
+// Deferred backend deletion ==========================================================
+// requires bucket property `write_back.enabled` (see cmn.WriteBackConf)
+// in all three cases, `prefix` (optional) selects pending deletions by object name prefix
+
+// GetDeleteQueue returns per-target state of the queue of pending backend deletions.
+func GetDeleteQueue(bp BaseParams, bck cmn.Bck, prefix string) (cmn.DeleteQueue, error) {
+	return deleteQueue(bp, bck, apc.ActDeleteQueue, prefix)
+}
+
+// FlushDeletes executes pending backend deletions without waiting for the bucket's `delete_delay`.
+// Returns the queue state (and, per target, the number of flushed deletions).
+func FlushDeletes(bp BaseParams, bck cmn.Bck, prefix string) (cmn.DeleteQueue, error) {
+	return deleteQueue(bp, bck, apc.ActFlushDeletes, prefix)
+}
+
+// CancelDeletes cancels pending backend deletions, thus leaving the objects in the remote bucket.
+// Returns the queue state (and, per target, the number of canceled deletions).
+func CancelDeletes(bp BaseParams, bck cmn.Bck, prefix string) (cmn.DeleteQueue, error) {
+	return deleteQueue(bp, bck, apc.ActCancelDeletes, prefix)
+}
+
+func deleteQueue(bp BaseParams, bck cmn.Bck, action, prefix string) (queue cmn.DeleteQueue, err error) {
+	bp.Method = http.MethodPost
+	if action == apc.ActDeleteQueue {
+		bp.Method = http.MethodGet
+	}
+	reqParams := AllocRp()
+	{
+		reqParams.BaseParams = bp
+		reqParams.Path = apc.URLPathBuckets.Join(bck.Name)
+		reqParams.Body = cos.MustMarshal(apc.ActMsg{Action: action, Name: prefix})
+		reqParams.Header = http.Header{cos.HdrContentType: []string{cos.ContentJSON}}
+		reqParams.Query = bck.NewQuery()
+	}
+	_, err = reqParams.DoReqAny(&queue)
+	FreeRp(reqParams)
+	return queue, err
+}
@@ -43,6 +43,34 @@ func StartXaction(bp BaseParams, args *xact.ArgsMsg, extra string) (xid string,
 	return
 }
 
+// StartAuditPlacement starts placement audit of a given bucket (or all buckets, when `bck` is empty)
+// on all targets; `fix` is one of the apc.AuditFix* actions (empty - report only)
+func StartAuditPlacement(bp BaseParams, bck cmn.Bck, fix string) (xid string, err error) {
+	return StartXaction(bp, &xact.ArgsMsg{Kind: apc.ActAuditPlacement, Bck: bck}, fix)
+}
+
+// GetAuditReports returns per-target placement audit reports (by target ID)
+func GetAuditReports(bp BaseParams, xid string) (reports map[string]*apc.AuditReport, err error) {
+	xs, err := QueryXactionSnaps(bp, &xact.ArgsMsg{ID: xid, Kind: apc.ActAuditPlacement})
+	if err != nil {
+		return nil, err
+	}
+	reports = make(map[string]*apc.AuditReport, len(xs))
+	for tid, snaps := range xs {
+		for _, snap := range snaps {
+			if snap.ID != xid || snap.Ext == nil {
+				continue
+			}
+			rep := &apc.AuditReport{}
+			if err := cos.MorphMarshal(snap.Ext, rep); err != nil {
+				return nil, err
+			}
+			reports[tid] = rep
+		}
+	}
+	return reports, nil
+}
+
 // Abort ("stop") xactions
 func AbortXaction(bp BaseParams, args *xact.ArgsMsg) (err error) {
 	msg := apc.ActMsg{Action: apc.ActXactStop, Value: args}
@@ -104,6 +132,27 @@ func QueryXactionSnaps(bp BaseParams, args *xact.ArgsMsg) (xs xact.MultiSnap, er
 	return
 }
 
+// GetXactionLog returns xaction snaps (by target ID) that include events captured by the
+// xaction (warnings, per-target errors, retries) - see core.Snap.Log
+func GetXactionLog(bp BaseParams, args *xact.ArgsMsg) (xs xact.MultiSnap, err error) {
+	msg := xact.QueryMsg{ID: args.ID, Kind: args.Kind, Bck: args.Bck, Log: true}
+	if args.OnlyRunning {
+		msg.OnlyRunning = apc.Ptr(true)
+	}
+	bp.Method = http.MethodGet
+	reqParams := AllocRp()
+	{
+		reqParams.BaseParams = bp
+		reqParams.Path = apc.URLPathClu.S
+		reqParams.Body = cos.MustMarshal(msg)
+		reqParams.Header = http.Header{cos.HdrContentType: []string{cos.ContentJSON}}
+		reqParams.Query = url.Values{apc.QparamWhat: []string{apc.WhatQueryXactStats}}
+	}
+	_, err = reqParams.DoReqAny(&xs)
+	FreeRp(reqParams)
+	return
+}
+
 // GetOneXactionStatus queries one of the IC (proxy) members for status
 // of the `args`-identified xaction.
 // NOTE:
@@ -114,8 +163,17 @@ func QueryXactionSnaps(bp BaseParams, args *xact.ArgsMsg) (xs xact.MultiSnap, er
 // the one that's finished most recently,
 // if exists
 func GetOneXactionStatus(bp BaseParams, args *xact.ArgsMsg) (status *nl.Status, err error) {
+	return waitOneXactionStatus(bp, args, 0)
+}
+
+// same as above, with the server (IC) blocking up to `wait` for the xaction to finish (long-poll)
+// - older servers ignore `wait` and respond immediately
+func waitOneXactionStatus(bp BaseParams, args *xact.ArgsMsg, wait time.Duration) (status *nl.Status, err error) {
 	status = &nl.Status{}
 	q := url.Values{apc.QparamWhat: []string{apc.WhatOneXactStatus}}
+	if wait > 0 {
+		q.Set(apc.QparamWait, wait.String())
+	}
 	err = getxst(status, q, bp, args)
 	return
 }
@@ -220,10 +278,15 @@ func _waitx(bp BaseParams, args *xact.ArgsMsg, fn func(xact.MultiSnap) (bool, bo
 		sleep           = xact.MinPollTime
 	)
 	for {
-		var done bool
+		var (
+			done   bool
+			polled time.Duration
+		)
 		if fn == nil {
-			status, err = GetOneXactionStatus(bp, args)
-			done = err == nil && status.Finished() && elapsed >= xact.MinPollTime
+			started := mono.NanoTime()
+			status, err = waitOneXactionStatus(bp, args, _longPoll(bp, total-elapsed))
+			polled = mono.Since(started)
+			done = err == nil && status.Finished() && elapsed+polled >= xact.MinPollTime
 		} else {
 			var (
 				snaps          xact.MultiSnap
@@ -241,7 +304,9 @@ func _waitx(bp BaseParams, args *xact.ArgsMsg, fn func(xact.MultiSnap) (bool, bo
 		if done || !canRetry /*fail*/ {
 			return
 		}
-		time.Sleep(sleep)
+		if polled < sleep {
+			time.Sleep(sleep - polled) // (not sleeping after a long-poll)
+		}
 		sleep = min(maxSleep, sleep+sleep/2)
 
 		if elapsed = mono.Since(begin); elapsed >= total {
@@ -251,6 +316,18 @@ func _waitx(bp BaseParams, args *xact.ArgsMsg, fn func(xact.MultiSnap) (bool, bo
 	}
 }
 
+// server-side wait: bounded by the remaining time and the client's own timeout (if any)
+func _longPoll(bp BaseParams, remaining time.Duration) time.Duration {
+	wait := min(remaining, xact.MaxLongPoll)
+	if bp.Client != nil && bp.Client.Timeout > 0 {
+		wait = min(wait, bp.Client.Timeout-bp.Client.Timeout/4)
+	}
+	if wait < xact.MinPollTime {
+		return 0
+	}
+	return wait
+}
+
 func _times(args *xact.ArgsMsg) (time.Duration, time.Duration) {
 	total := args.Timeout
 	switch {
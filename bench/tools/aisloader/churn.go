@@ -0,0 +1,273 @@
+// Package aisloader
+/*
+ * Copyright (c) 2024, NVIDIA CORPORATION. All rights reserved.
+ */
+
+package aisloader
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/NVIDIA/aistore/cmn/cos"
+	"github.com/OneOfOne/xxhash"
+)
+
+// Churn: overwrite (-pctupdate) and delete (-pctdel) workloads, with optional
+// generation-stamp verification (-verifygen):
+// - the pool of present object names is initialized from the bucket listing and
+//   then maintained by the main goroutine as PUTs and DELETEs complete;
+// - overwrites and deletes never target a name that has another overwrite (or delete)
+//   in flight; GETs, on the other hand, may race with both;
+// - with -verifygen, every PUT stamps the payload's leading bytes with (magic, generation,
+//   name hash, size), and every GET validates the stamp against the latest committed
+//   generation; GETs that race with writes (or follow a failed write) accept any
+//   generation in the [committed, latest] range, and a "not found" status;
+// - verification assumes a single aisloader writing to the bucket (and prefix).
+
+const (
+	stampMagic = "aisldgen"
+	stampLen   = len(stampMagic) + 3*cos.SizeofI64
+
+	churnPickRetries = 4
+)
+
+type (
+	// per object name
+	objGen struct {
+		gen       uint64 // latest (possibly, in-flight) generation
+		committed uint64 // latest successfully written generation (0 - pre-existing)
+		seq       uint64 // incremented upon every write and delete
+		busy      bool   // write (or delete) in flight
+		uncertain bool   // previous write or delete failed
+	}
+	// GET-time snapshot
+	genSnap struct {
+		gen       uint64
+		committed uint64
+		seq       uint64
+		relaxed   bool
+	}
+	churn struct {
+		objs  map[string]*objGen
+		idx   map[string]int // present names => index in `names`
+		names []string
+
+		verified int64
+		mismatch int64
+		misses   int64 // relaxed (racing) GETs that returned "not found"
+	}
+)
+
+var chrn *churn
+
+func (p *params) churnEnabled() bool { return p.updatePct > 0 || p.delPct > 0 || p.verifyGen }
+
+func newChurn(names []string) *churn {
+	c := &churn{
+		objs:  make(map[string]*objGen, len(names)),
+		idx:   make(map[string]int, len(names)),
+		names: make([]string, 0, len(names)),
+	}
+	for _, name := range names {
+		c.objs[name] = &objGen{}
+		c.add(name)
+	}
+	return c
+}
+
+func (c *churn) len() int { return len(c.names) }
+
+func (c *churn) add(name string) {
+	if _, ok := c.idx[name]; ok {
+		return
+	}
+	c.idx[name] = len(c.names)
+	c.names = append(c.names, name)
+}
+
+func (c *churn) remove(name string) {
+	i, ok := c.idx[name]
+	if !ok {
+		return
+	}
+	last := len(c.names) - 1
+	if i != last {
+		c.names[i] = c.names[last]
+		c.idx[c.names[i]] = i
+	}
+	c.names = c.names[:last]
+	delete(c.idx, name)
+}
+
+// random present name; when `idle` is true, skip names with writes (or deletes) in flight
+func (c *churn) pick(idle bool) (string, bool) {
+	if len(c.names) == 0 {
+		return "", false
+	}
+	for range churnPickRetries {
+		name := c.names[rnd.IntN(len(c.names))]
+		if !idle || !c.objs[name].busy {
+			return name, true
+		}
+	}
+	return "", false
+}
+
+//
+// work orders
+//
+
+// new PUT or overwrite
+func (c *churn) beginPut(objName string) uint64 {
+	og, ok := c.objs[objName]
+	if !ok {
+		og = &objGen{}
+		c.objs[objName] = og
+	}
+	og.gen++
+	og.seq++
+	og.busy = true
+	return og.gen
+}
+
+func (c *churn) endPut(wo *workOrder) {
+	og := c.objs[wo.objName]
+	og.busy = false
+	if wo.err != nil {
+		// may or may not have been written
+		og.uncertain = true
+		if og.committed == 0 && !c.present(wo.objName) {
+			delete(c.objs, wo.objName) // (failed to create)
+		}
+		return
+	}
+	og.committed, og.uncertain = wo.gen, false
+	c.add(wo.objName)
+}
+
+func (c *churn) beginDel(objName string) {
+	og := c.objs[objName]
+	og.seq++
+	og.busy = true
+	c.remove(objName)
+}
+
+func (c *churn) endDel(wo *workOrder) {
+	if wo.err == nil {
+		delete(c.objs, wo.objName)
+		return
+	}
+	og := c.objs[wo.objName]
+	og.busy, og.uncertain = false, true
+	c.add(wo.objName)
+}
+
+func (c *churn) present(objName string) bool {
+	_, ok := c.idx[objName]
+	return ok
+}
+
+func (c *churn) snap(objName string) (s genSnap) {
+	og := c.objs[objName]
+	s.gen, s.committed, s.seq = og.gen, og.committed, og.seq
+	s.relaxed = og.busy || og.uncertain
+	return s
+}
+
+// returns true if the GET (error) must be ignored
+func (c *churn) endGet(wo *workOrder) bool {
+	var (
+		og, ok  = c.objs[wo.objName]
+		relaxed = wo.snap.relaxed || !ok || og.seq != wo.snap.seq || og.busy || og.uncertain
+	)
+	if wo.err != nil {
+		var e *errBadStatus
+		if errors.As(wo.err, &e) && e.status == http.StatusNotFound && relaxed {
+			c.misses++
+			return true
+		}
+		return false
+	}
+	if !runParams.verifyGen {
+		return false
+	}
+
+	hi := wo.snap.gen
+	if ok {
+		hi = max(hi, og.gen)
+	}
+	if err := c.verify(wo, relaxed, hi); err != nil {
+		c.mismatch++
+		wo.err = err
+		return false
+	}
+	c.verified++
+	return false
+}
+
+func (c *churn) verify(wo *workOrder, relaxed bool, hi uint64) error {
+	lo := wo.snap.committed
+	gen, hash, size, ok := parseStamp(wo.head)
+	if !ok {
+		// pre-existing (or not yet overwritten) object
+		if lo == 0 {
+			return nil
+		}
+		return fmt.Errorf("%s: missing generation stamp (expecting gen %d)", wo.objName, lo)
+	}
+	if hash != xxhash.ChecksumString64S(wo.objName, cos.MLCG32) {
+		return fmt.Errorf("%s: generation stamp belongs to a different object", wo.objName)
+	}
+	if size != uint64(wo.size) {
+		return fmt.Errorf("%s: size %d does not match the stamped size %d (gen %d)", wo.objName, wo.size, size, gen)
+	}
+	switch {
+	case !relaxed && gen != lo:
+		return fmt.Errorf("%s: stale or unexpected generation %d (expecting %d)", wo.objName, gen, lo)
+	case relaxed && (gen < lo || gen > hi):
+		return fmt.Errorf("%s: generation %d out of range [%d, %d]", wo.objName, gen, lo, hi)
+	}
+	return nil
+}
+
+func (c *churn) report() error {
+	if c.misses > 0 {
+		fmt.Printf("GET: %d object%s not found while being overwritten or deleted (ignored)\n", c.misses, cos.Plural(int(c.misses)))
+	}
+	if !runParams.verifyGen {
+		return nil
+	}
+	fmt.Printf("Generation verification: %d verified, %d mismatched\n", c.verified, c.mismatch)
+	if c.mismatch > 0 {
+		return fmt.Errorf("generation verification failed: %d mismatched object%s", c.mismatch, cos.Plural(int(c.mismatch)))
+	}
+	return nil
+}
+
+//
+// generation stamp
+//
+
+func genStamp(objName string, gen uint64, size int64) []byte {
+	b := make([]byte, stampLen)
+	off := copy(b, stampMagic)
+	binary.BigEndian.PutUint64(b[off:], gen)
+	binary.BigEndian.PutUint64(b[off+cos.SizeofI64:], xxhash.ChecksumString64S(objName, cos.MLCG32))
+	binary.BigEndian.PutUint64(b[off+2*cos.SizeofI64:], uint64(size))
+	return b
+}
+
+func parseStamp(b []byte) (gen, hash, size uint64, ok bool) {
+	if len(b) < stampLen || !bytes.Equal(b[:len(stampMagic)], []byte(stampMagic)) {
+		return
+	}
+	off := len(stampMagic)
+	gen = binary.BigEndian.Uint64(b[off:])
+	hash = binary.BigEndian.Uint64(b[off+cos.SizeofI64:])
+	size = binary.BigEndian.Uint64(b[off+2*cos.SizeofI64:])
+	return gen, hash, size, gen > 0
+}
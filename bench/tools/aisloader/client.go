@@ -6,13 +6,16 @@
 package aisloader
 
 import (
+	"bytes"
 	"context"
+	"crypto/tls"
 	"fmt"
 	"io"
 	"net/http"
 	"net/http/httptrace"
 	"net/url"
 	"os"
+	"strings"
 	"time"
 
 	"github.com/NVIDIA/aistore/api"
@@ -22,7 +25,9 @@ import (
 	"github.com/NVIDIA/aistore/cmn/cos"
 	"github.com/NVIDIA/aistore/cmn/debug"
 	"github.com/NVIDIA/aistore/cmn/mono"
+	"github.com/NVIDIA/aistore/core/meta"
 	"github.com/aws/aws-sdk-go-v2/aws"
+	awshttp "github.com/aws/aws-sdk-go-v2/aws/transport/http"
 	"github.com/aws/aws-sdk-go-v2/config"
 	s3manager "github.com/aws/aws-sdk-go-v2/feature/s3/manager"
 	"github.com/aws/aws-sdk-go-v2/service/s3"
@@ -89,6 +94,20 @@ type (
 		TargetWroteRequest  time.Duration // from TargetWroteHeader to response body is written
 		TargetFirstResponse time.Duration // from TargetWroteRequest to first byte of response
 	}
+
+	// captures the leading bytes of the GET response (see churn and generation stamps)
+	headReader struct {
+		r    io.Reader
+		head []byte
+		n    int
+	}
+
+	// GET failed with (non-2xx) HTTP status
+	errBadStatus struct {
+		tag    string
+		msg    string
+		status int
+	}
 )
 
 ////////////////////////
@@ -205,7 +224,26 @@ func s3put(bck cmn.Bck, objName string, reader cos.ReadOpenCloser) (err error) {
 	return
 }
 
-func put(proxyURL string, bck cmn.Bck, objName string, cksum *cos.Cksum, reader cos.ReadOpenCloser) (err error) {
+// returns ID of the target that served the request (empty if unknown)
+func respTarget(resp *http.Response) string {
+	if tid := resp.Header.Get(apc.HdrNodeID); tid != "" {
+		return tid
+	}
+	if resp.Request != nil && resp.Request.URL != nil {
+		return resp.Request.URL.Host // (older clusters) redirect location
+	}
+	return ""
+}
+
+// ID of the target that failed the request (see cmn.ErrHTTP), if any
+func errTarget(err error) string {
+	if herr := cmn.Err2HTTPErr(err); herr != nil && strings.HasPrefix(herr.Node, meta.TnamePrefix) {
+		return meta.N2ID(herr.Node)
+	}
+	return ""
+}
+
+func put(proxyURL string, bck cmn.Bck, objName string, cksum *cos.Cksum, reader cos.ReadOpenCloser) (tid string, err error) {
 	var (
 		baseParams = api.BaseParams{
 			Client: runParams.bp.Client,
@@ -223,12 +261,27 @@ func put(proxyURL string, bck cmn.Bck, objName string, cksum *cos.Cksum, reader
 			SkipVC:     true,
 		}
 	)
-	_, err = api.PutObject(&args)
-	return
+	oah, err := api.PutObject(&args)
+	if err != nil {
+		return errTarget(err), err
+	}
+	return oah.RespHeader().Get(apc.HdrNodeID), nil
+}
+
+func del(proxyURL string, bck cmn.Bck, objName string) error {
+	baseParams := api.BaseParams{
+		Client: runParams.bp.Client,
+		URL:    proxyURL,
+		Method: http.MethodDelete,
+		Token:  loggedUserToken,
+		UA:     ua,
+	}
+	return api.DeleteObject(baseParams, bck, objName)
 }
 
 // PUT with HTTP trace
-func putWithTrace(proxyURL string, bck cmn.Bck, objName string, latencies *httpLatencies, cksum *cos.Cksum, reader cos.ReadOpenCloser) error {
+func putWithTrace(proxyURL string, bck cmn.Bck, objName string, latencies *httpLatencies, cksum *cos.Cksum,
+	reader cos.ReadOpenCloser) (string, error) {
 	reqArgs := cmn.AllocHra()
 	{
 		reqArgs.Method = http.MethodPut
@@ -242,16 +295,16 @@ func putWithTrace(proxyURL string, bck cmn.Bck, objName string, latencies *httpL
 		cksum:  cksum,
 		reader: reader,
 	}
-	_, err := api.DoWithRetry(putter.tctx.tracedClient, putter.do, reqArgs) //nolint:bodyclose // it's closed inside
+	resp, err := api.DoWithRetry(putter.tctx.tracedClient, putter.do, reqArgs) //nolint:bodyclose // it's closed inside
 	cmn.FreeHra(reqArgs)
 	if err != nil {
-		return err
+		return errTarget(err), err
 	}
 	tctx := putter.tctx
 	tctx.tr.tsHTTPEnd = time.Now()
 
 	tctx.tr.set(latencies)
-	return nil
+	return respTarget(resp), nil
 }
 
 func newTraceCtx(proxyURL string) *traceCtx {
@@ -335,44 +388,50 @@ func s3getDiscard(bck cmn.Bck, objName string) (int64, error) {
 }
 
 // getDiscard sends a GET request and discards returned data.
-func getDiscard(proxyURL string, bck cmn.Bck, objName string, offset, length int64, validate, latest bool) (int64, error) {
+// Returns the size and ID of the target that served the request.
+func getDiscard(proxyURL string, bck cmn.Bck, objName string, offset, length int64, validate, latest bool,
+	head []byte) (int64, string, error) {
 	req, err := newGetRequest(proxyURL, bck, objName, offset, length, latest)
 	if err != nil {
-		return 0, err
+		return 0, "", err
 	}
 	api.SetAuxHeaders(req, &runParams.bp)
 	resp, err := runParams.bp.Client.Do(req)
 	if err != nil {
-		return 0, err
+		return 0, "", err
 	}
 
-	var hdrCksumValue, hdrCksumType string
+	var (
+		hdrCksumValue, hdrCksumType string
+		tid                         = respTarget(resp)
+	)
 	if validate {
 		hdrCksumValue = resp.Header.Get(apc.HdrObjCksumVal)
 		hdrCksumType = resp.Header.Get(apc.HdrObjCksumType)
 	}
 	src := "GET " + bck.Cname(objName)
-	n, cksumValue, err := readDiscard(resp, src, hdrCksumType)
+	n, cksumValue, err := readDiscard(resp, src, hdrCksumType, head)
 
 	resp.Body.Close()
 	if err != nil {
-		return 0, err
+		return 0, tid, err
 	}
 	if validate && hdrCksumValue != cksumValue {
-		return 0, cmn.NewErrInvalidCksum(hdrCksumValue, cksumValue)
+		return 0, tid, cmn.NewErrInvalidCksum(hdrCksumValue, cksumValue)
 	}
-	return n, err
+	return n, tid, err
 }
 
 // Same as above, but with HTTP trace.
-func getTraceDiscard(proxyURL string, bck cmn.Bck, objName string, latencies *httpLatencies, offset, length int64, validate, latest bool) (int64, error) {
+func getTraceDiscard(proxyURL string, bck cmn.Bck, objName string, latencies *httpLatencies, offset, length int64,
+	validate, latest bool, head []byte) (int64, string, error) {
 	var (
 		hdrCksumValue string
 		hdrCksumType  string
 	)
 	req, err := newGetRequest(proxyURL, bck, objName, offset, length, latest)
 	if err != nil {
-		return 0, err
+		return 0, "", err
 	}
 
 	tctx := newTraceCtx(proxyURL)
@@ -380,9 +439,10 @@ func getTraceDiscard(proxyURL string, bck cmn.Bck, objName string, latencies *ht
 
 	resp, err := tctx.tracedClient.Do(req)
 	if err != nil {
-		return 0, err
+		return 0, "", err
 	}
 	defer resp.Body.Close()
+	tid := respTarget(resp)
 
 	tctx.tr.tsHTTPEnd = time.Now()
 	if validate {
@@ -391,16 +451,16 @@ func getTraceDiscard(proxyURL string, bck cmn.Bck, objName string, latencies *ht
 	}
 
 	src := "GET " + bck.Cname(objName)
-	n, cksumValue, err := readDiscard(resp, src, hdrCksumType)
+	n, cksumValue, err := readDiscard(resp, src, hdrCksumType, head)
 	if err != nil {
-		return 0, err
+		return 0, tid, err
 	}
 	if validate && hdrCksumValue != cksumValue {
 		err = cmn.NewErrInvalidCksum(hdrCksumValue, cksumValue)
 	}
 
 	tctx.tr.set(latencies)
-	return n, err
+	return n, tid, err
 }
 
 // getConfig sends a {what:config} request to the url and discard the message
@@ -419,7 +479,7 @@ func getConfig(proxyURL string) (httpLatencies, error) {
 	}
 	defer resp.Body.Close()
 
-	_, _, err = readDiscard(resp, "GetConfig", "" /*cksum type*/)
+	_, _, err = readDiscard(resp, "GetConfig", "" /*cksum type*/, nil)
 
 	l := httpLatencies{
 		ProxyConn: timeDelta(tctx.tr.tsProxyConn, tctx.tr.tsBegin),
@@ -473,10 +533,39 @@ func initS3Svc() error {
 			s3Profile = profile
 		}
 	}
-	cfg, err := config.LoadDefaultConfig(
-		context.Background(),
+
+	// http client: request timeout and TLS
+	// (CA bundle, if specified, gets added by the SDK - see config.WithCustomCABundle)
+	client := awshttp.NewBuildableClient().WithTimeout(s3Timeout)
+	if s3SkipVerify {
+		client = client.WithTransportOptions(func(tr *http.Transport) {
+			if tr.TLSClientConfig == nil {
+				tr.TLSClientConfig = &tls.Config{}
+			}
+			tr.TLSClientConfig.InsecureSkipVerify = true
+		})
+	}
+	opts := []func(*config.LoadOptions) error{
 		config.WithSharedConfigProfile(s3Profile),
-	)
+		config.WithHTTPClient(client),
+	}
+	if s3CACert != "" {
+		pem, err := os.ReadFile(s3CACert)
+		if err != nil {
+			return err
+		}
+		opts = append(opts, config.WithCustomCABundle(bytes.NewReader(pem)))
+	}
+
+	// retries (SDK defaults unless specified)
+	if s3RetryMode != "" {
+		opts = append(opts, config.WithRetryMode(aws.RetryMode(s3RetryMode)))
+	}
+	if s3MaxAttempts > 0 {
+		opts = append(opts, config.WithRetryMaxAttempts(s3MaxAttempts))
+	}
+
+	cfg, err := config.LoadDefaultConfig(context.Background(), opts...)
 	if err != nil {
 		return err
 	}
@@ -550,21 +639,25 @@ func s3ListObjects() ([]string, error) {
 	return names, nil
 }
 
-func readDiscard(r *http.Response, tag, cksumType string) (int64, string, error) {
+func readDiscard(r *http.Response, tag, cksumType string, head []byte) (int64, string, error) {
 	var (
 		n          int64
 		cksum      *cos.CksumHash
 		err        error
 		cksumValue string
+		body       io.Reader = r.Body
 	)
 	if r.StatusCode >= http.StatusBadRequest {
 		bytes, err := cos.ReadAll(r.Body)
 		if err == nil {
-			return 0, "", fmt.Errorf("bad status %d from %s, response: %s", r.StatusCode, tag, string(bytes))
+			return 0, "", &errBadStatus{tag: tag, msg: ", response: " + string(bytes), status: r.StatusCode}
 		}
-		return 0, "", fmt.Errorf("bad status %d from %s: %v", r.StatusCode, tag, err)
+		return 0, "", &errBadStatus{tag: tag, msg: ": " + err.Error(), status: r.StatusCode}
+	}
+	if head != nil {
+		body = &headReader{r: r.Body, head: head}
 	}
-	n, cksum, err = cos.CopyAndChecksum(io.Discard, r.Body, nil, cksumType)
+	n, cksum, err = cos.CopyAndChecksum(io.Discard, body, nil, cksumType)
 	if err != nil {
 		return 0, "", fmt.Errorf("failed to read HTTP response, err: %v", err)
 	}
@@ -580,3 +673,19 @@ func timeDelta(time1, time2 time.Time) time.Duration {
 	}
 	return time1.Sub(time2)
 }
+
+////////////////
+// headReader //
+////////////////
+
+func (hr *headReader) Read(p []byte) (n int, err error) {
+	n, err = hr.r.Read(p)
+	if hr.n < len(hr.head) {
+		hr.n += copy(hr.head[hr.n:], p[:n])
+	}
+	return n, err
+}
+
+func (e *errBadStatus) Error() string {
+	return fmt.Sprintf("bad status %d from %s%s", e.status, e.tag, e.msg)
+}
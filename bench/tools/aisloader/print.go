@@ -60,6 +60,8 @@ var examples = `# 1. Cleanup (i.e., destroy) an existing bucket:
      $ aisloader -bucket=s3://xyz -cleanup=false -numworkers=8 -pctput=0 -duration=10m -s3endpoint=https://s3.amazonaws.com
 # 13. PUT approx. 8000 files into s3 bucket directly, skip printing usage and defaults (NOTE: aistore is not being used):
      $ aisloader -bucket=s3://xyz -cleanup=false -minsize=16B -maxsize=16B -numworkers=8 -pctput=100 -totalputsize=128k -s3endpoint=https://s3.amazonaws.com -quiet
+# 14. Churn: 30% PUT (half of which overwrite existing objects), 20% DELETE, and 50% GET, with generation verification:
+     $ aisloader -bucket=ais://abc -duration 10m -numworkers=16 -minsize=4K -maxsize=64K -pctput=30 -pctupdate=50 -pctdel=20 -verifygen -cleanup=false
 `
 
 const readme = cmn.GitHubHome + "/blob/main/docs/howto_benchmark.md"
@@ -156,6 +158,9 @@ func finalizeStats(to io.Writer) {
 	accumulatedStats.aggregate(&intervalStats)
 	writeStats(to, runParams.jsonFormat, true /* final */, &intervalStats, &accumulatedStats)
 	postWriteStats(to, runParams.jsonFormat)
+	if !runParams.jsonFormat {
+		tgtStats.write(to)
+	}
 
 	// reset gauges, otherwise they would stay at last send value
 	stats.ResetMetricsGauges(statsdC)
@@ -198,10 +203,12 @@ func writeStatsJSON(to io.Writer, s *sts, withcomma ...bool) {
 		Get *jsonStats `json:"get"`
 		Put *jsonStats `json:"put"`
 		Cfg *jsonStats `json:"cfg"`
+		Del *jsonStats `json:"del"`
 	}{
 		Get: jsonStatsFromReq(s.get),
 		Put: jsonStatsFromReq(s.put),
 		Cfg: jsonStatsFromReq(s.getConfig),
+		Del: jsonStatsFromReq(s.del),
 	}
 
 	jsonOutput, err := json.MarshalIndent(jStats, "", "  ")
@@ -252,6 +259,18 @@ func writeHumanReadibleIntervalStats(to io.Writer, s, t *sts) {
 			ps(s.get.Throughput(s.get.Start(), time.Now()))+" ("+ps(t.get.Throughput(t.get.Start(), time.Now()))+")",
 			errs)
 	}
+	errs = "-"
+	if t.del.TotalErrs() != 0 {
+		errs = pn(s.del.TotalErrs()) + " (" + pn(t.del.TotalErrs()) + ")"
+	}
+	if s.del.Total() != 0 {
+		p(to, statsPrintHeader, pt(), "DEL",
+			pn(s.del.Total())+" ("+pn(t.del.Total())+" "+pn(delPending)+" "+pn(workOrderResLen)+")",
+			"-",
+			pl(s.del.MinLatency(), s.del.AvgLatency(), s.del.MaxLatency()),
+			"-",
+			errs)
+	}
 	if s.getConfig.Total() != 0 {
 		p(to, statsPrintHeader, pt(), "CFG",
 			pn(s.getConfig.Total())+" ("+pn(t.getConfig.Total())+")",
@@ -289,6 +308,15 @@ func writeHumanReadibleFinalStats(to io.Writer, t *sts) {
 			ps(sget.Throughput(sget.Start(), time.Now())),
 			pn(sget.TotalErrs()))
 	}
+	sdel := &t.del
+	if sdel.Total() > 0 {
+		p(to, statsPrintHeader, pt(), "DEL",
+			pn(sdel.Total()),
+			"-",
+			pl(sdel.MinLatency(), sdel.AvgLatency(), sdel.MaxLatency()),
+			"-",
+			pn(sdel.TotalErrs()))
+	}
 	sconfig := &t.getConfig
 	if sconfig.Total() > 0 {
 		p(to, statsPrintHeader, pt(), "CFG",
@@ -326,6 +354,9 @@ func printRunParams(p *params) {
 		Duration      string `json:"duration"`
 		MaxPutBytes   int64  `json:"PUT upper bound,string"`
 		PutPct        int    `json:"% PUT"`
+		UpdatePct     int    `json:"% PUT overwrites"`
+		DelPct        int    `json:"% DELETE"`
+		VerifyGen     bool   `json:"verify generations"`
 		MinSize       int64  `json:"minimum object size (bytes)"`
 		MaxSize       int64  `json:"maximum object size (bytes)"`
 		NumWorkers    int    `json:"# workers"`
@@ -341,6 +372,9 @@ func printRunParams(p *params) {
 		Duration:      d,
 		MaxPutBytes:   p.putSizeUpperBound,
 		PutPct:        p.putPct,
+		UpdatePct:     p.updatePct,
+		DelPct:        p.delPct,
+		VerifyGen:     p.verifyGen,
 		MinSize:       p.minSize,
 		MaxSize:       p.maxSize,
 		NumWorkers:    p.numWorkers,
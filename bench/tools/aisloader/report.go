@@ -0,0 +1,302 @@
+// Package aisloader
+/*
+ * Copyright (c) 2024, NVIDIA CORPORATION. All rights reserved.
+ */
+
+package aisloader
+
+import (
+	"fmt"
+	"html/template"
+	"io"
+	"os"
+	"sort"
+	"time"
+
+	"github.com/NVIDIA/aistore/bench/tools/aisloader/stats"
+)
+
+// Per-target breakdown: when running against AIS, each GET and PUT response identifies
+// the target that served it (apc.HdrNodeID). Latencies and throughput are then accumulated
+// per target and, in addition, per time slot - the latter to render (optional) HTML heatmaps
+// that help spot slow disks and overloaded nodes from the client side.
+
+const dfltHeatSlot = 10 * time.Second
+
+type (
+	heatCell struct {
+		cnt  int64
+		errs int64
+		lat  time.Duration // total
+	}
+	tgtOpStats struct {
+		req   stats.HTTPReq
+		cells []heatCell // heatmap row: one cell per time slot
+	}
+	tgtEntry struct {
+		get tgtOpStats
+		put tgtOpStats
+	}
+	tgtBreakdown struct {
+		m     map[string]*tgtEntry // target ID => stats
+		start time.Time
+		slot  time.Duration
+	}
+)
+
+var tgtStats tgtBreakdown
+
+func (tb *tgtBreakdown) init(start time.Time, interval int) {
+	tb.m = make(map[string]*tgtEntry, 8)
+	tb.start = start
+	tb.slot = dfltHeatSlot
+	if interval > 0 {
+		tb.slot = time.Duration(interval) * time.Second
+	}
+}
+
+// (single-threaded - see completeWorkOrder)
+func (tb *tgtBreakdown) add(wo *workOrder, delta time.Duration) {
+	if tb.m == nil {
+		return
+	}
+	entry, ok := tb.m[wo.tid]
+	if !ok {
+		entry = &tgtEntry{get: tgtOpStats{req: stats.NewHTTPReq(tb.start)}, put: tgtOpStats{req: stats.NewHTTPReq(tb.start)}}
+		tb.m[wo.tid] = entry
+	}
+	var ts *tgtOpStats
+	switch wo.op {
+	case opGet:
+		ts = &entry.get
+	case opPut:
+		ts = &entry.put
+	default:
+		return
+	}
+
+	idx := max(int(wo.end.Sub(tb.start)/tb.slot), 0)
+	for len(ts.cells) <= idx {
+		ts.cells = append(ts.cells, heatCell{})
+	}
+	cell := &ts.cells[idx]
+	if wo.err != nil {
+		ts.req.AddErr()
+		cell.errs++
+		return
+	}
+	ts.req.Add(wo.size, delta)
+	cell.cnt++
+	cell.lat += delta
+}
+
+func (tb *tgtBreakdown) tids() []string {
+	tids := make([]string, 0, len(tb.m))
+	for tid := range tb.m {
+		tids = append(tids, tid)
+	}
+	sort.Strings(tids)
+	return tids
+}
+
+func (tb *tgtBreakdown) write(to io.Writer) {
+	if len(tb.m) == 0 {
+		return
+	}
+	var (
+		p   = fprintf
+		pn  = prettyNumber
+		pl  = prettyLatency
+		ps  = prettySpeed
+		now = time.Now()
+	)
+	p(to, "\nPer-target breakdown:\n")
+	p(to, "%-16s%-6s%-12s%-36s%-12s%-16s%-10s\n", "Target", "OP", "Count", "Latency (min, avg, max)", "p99", "Throughput", "Errors")
+	for _, tid := range tb.tids() {
+		entry := tb.m[tid]
+		for _, op := range []struct {
+			name string
+			req  *stats.HTTPReq
+		}{{"PUT", &entry.put.req}, {"GET", &entry.get.req}} {
+			r := op.req
+			if r.Total() == 0 && r.TotalErrs() == 0 {
+				continue
+			}
+			p(to, "%-16s%-6s%-12s%-36s%-12s%-16s%-10s\n", tid, op.name, pn(r.Total()),
+				pl(r.MinLatency(), r.AvgLatency(), r.MaxLatency()),
+				prettyDuration(int64(r.Percentile(99))),
+				ps(r.Throughput(r.Start(), now)),
+				pn(r.TotalErrs()))
+		}
+	}
+}
+
+//
+// HTML report
+//
+
+type (
+	htmlCell struct {
+		Title string
+		Text  string
+		Color template.CSS
+	}
+	htmlRow struct {
+		Target string
+		Cells  []htmlCell
+	}
+	htmlHeatmap struct {
+		Op    string
+		Slots []string
+		Rows  []htmlRow
+	}
+	htmlSummary struct {
+		Target, Op, Count, Avg, P50, P99, Max, Throughput, Errs string
+	}
+	htmlReport struct {
+		Title    string
+		Summary  []htmlSummary
+		Heatmaps []htmlHeatmap
+	}
+)
+
+const htmlReportTmpl = `<!DOCTYPE html>
+<html>
+<head>
+<meta charset="utf-8">
+<title>{{.Title}}</title>
+<style>
+body { font-family: sans-serif; font-size: 13px; }
+table { border-collapse: collapse; margin-bottom: 24px; }
+th, td { border: 1px solid #ccc; padding: 3px 6px; text-align: right; white-space: nowrap; }
+th:first-child, td:first-child { text-align: left; }
+td.cell { min-width: 48px; font-size: 11px; }
+</style>
+</head>
+<body>
+<h2>{{.Title}}</h2>
+<h3>Per-target breakdown</h3>
+<table>
+<tr><th>Target</th><th>OP</th><th>Count</th><th>Avg</th><th>p50</th><th>p99</th><th>Max</th><th>Throughput</th><th>Errors</th></tr>
+{{range .Summary}}<tr><td>{{.Target}}</td><td>{{.Op}}</td><td>{{.Count}}</td><td>{{.Avg}}</td><td>{{.P50}}</td><td>{{.P99}}</td><td>{{.Max}}</td><td>{{.Throughput}}</td><td>{{.Errs}}</td></tr>
+{{end}}</table>
+{{range .Heatmaps}}<h3>{{.Op}} average latency (target x time)</h3>
+<table>
+<tr><th>Target</th>{{range .Slots}}<th>{{.}}</th>{{end}}</tr>
+{{range .Rows}}<tr><td>{{.Target}}</td>{{range .Cells}}<td class="cell" title="{{.Title}}" style="background-color: {{.Color}}">{{.Text}}</td>{{end}}</tr>
+{{end}}</table>
+{{end}}</body>
+</html>
+`
+
+func (tb *tgtBreakdown) writeHTML(fname string) error {
+	if len(tb.m) == 0 {
+		return fmt.Errorf("cannot generate %q: no per-target stats (is the benchmark running against AIS?)", fname)
+	}
+	tmpl, err := template.New("report").Parse(htmlReportTmpl)
+	if err != nil {
+		return err
+	}
+	var (
+		tids   = tb.tids()
+		now    = time.Now()
+		report = htmlReport{
+			Title: fmt.Sprintf("aisloader: %s, %s (%v)", runParams.bck.Cname(""), tb.start.Format(time.DateTime),
+				now.Sub(tb.start).Round(time.Second)),
+		}
+	)
+	for _, tid := range tids {
+		entry := tb.m[tid]
+		for _, op := range []struct {
+			name string
+			req  *stats.HTTPReq
+		}{{"PUT", &entry.put.req}, {"GET", &entry.get.req}} {
+			r := op.req
+			if r.Total() == 0 && r.TotalErrs() == 0 {
+				continue
+			}
+			report.Summary = append(report.Summary, htmlSummary{
+				Target:     tid,
+				Op:         op.name,
+				Count:      prettyNumber(r.Total()),
+				Avg:        prettyDuration(r.AvgLatency()),
+				P50:        prettyDuration(int64(r.Percentile(50))),
+				P99:        prettyDuration(int64(r.Percentile(99))),
+				Max:        prettyDuration(r.MaxLatency()),
+				Throughput: prettySpeed(r.Throughput(r.Start(), now)),
+				Errs:       prettyNumber(r.TotalErrs()),
+			})
+		}
+	}
+	if hm := tb.heatmap("PUT", tids, func(e *tgtEntry) *tgtOpStats { return &e.put }); hm != nil {
+		report.Heatmaps = append(report.Heatmaps, *hm)
+	}
+	if hm := tb.heatmap("GET", tids, func(e *tgtEntry) *tgtOpStats { return &e.get }); hm != nil {
+		report.Heatmaps = append(report.Heatmaps, *hm)
+	}
+
+	fh, err := os.Create(fname)
+	if err != nil {
+		return err
+	}
+	if err = tmpl.Execute(fh, &report); err != nil {
+		fh.Close()
+		return err
+	}
+	return fh.Close()
+}
+
+// color-code each cell's average latency relative to the maximum (across all targets and slots)
+func (tb *tgtBreakdown) heatmap(op string, tids []string, get func(*tgtEntry) *tgtOpStats) *htmlHeatmap {
+	var (
+		numSlots int
+		maxAvg   time.Duration
+	)
+	for _, tid := range tids {
+		ts := get(tb.m[tid])
+		numSlots = max(numSlots, len(ts.cells))
+		for _, c := range ts.cells {
+			if c.cnt > 0 {
+				maxAvg = max(maxAvg, c.lat/time.Duration(c.cnt))
+			}
+		}
+	}
+	if numSlots == 0 {
+		return nil
+	}
+	hm := &htmlHeatmap{Op: op, Slots: make([]string, numSlots)}
+	for i := range numSlots {
+		hm.Slots[i] = (time.Duration(i) * tb.slot).String()
+	}
+	for _, tid := range tids {
+		ts := get(tb.m[tid])
+		if len(ts.cells) == 0 {
+			continue
+		}
+		row := htmlRow{Target: tid, Cells: make([]htmlCell, numSlots)}
+		for i := range numSlots {
+			cell := &row.Cells[i]
+			cell.Color = "#eee"
+			if i >= len(ts.cells) {
+				continue
+			}
+			c := ts.cells[i]
+			if c.errs > 0 {
+				cell.Color = "#b0b"
+			}
+			cell.Title = fmt.Sprintf("count %d, errors %d", c.cnt, c.errs)
+			if c.cnt == 0 {
+				continue
+			}
+			avg := c.lat / time.Duration(c.cnt)
+			cell.Text = prettyDuration(int64(avg))
+			if c.errs == 0 {
+				// green (fastest) => red (slowest)
+				hue := 120 * (1 - float64(avg)/float64(maxAvg))
+				cell.Color = template.CSS(fmt.Sprintf("hsl(%.0f, 70%%, 60%%)", hue))
+			}
+		}
+		hm.Rows = append(hm.Rows, row)
+	}
+	return hm
+}
@@ -60,6 +60,7 @@ import (
 	"github.com/NVIDIA/aistore/tools/tetl"
 	"github.com/NVIDIA/aistore/xact"
 	"github.com/OneOfOne/xxhash"
+	"github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/aws/aws-sdk-go-v2/service/s3"
 	jsoniter "github.com/json-iterator/go"
 )
@@ -75,6 +76,8 @@ const (
 
 	defaultClusterIP   = "localhost"
 	defaultClusterIPv4 = "127.0.0.1"
+
+	dfltTimeout = 10 * time.Minute
 )
 
 type (
@@ -89,8 +92,11 @@ type (
 		maxputs           uint64
 		putShards         uint64
 		statsdPort        int
+		statsdFmt         statsd.Format
 		statsShowInterval int
 		putPct            int // % of puts, rest are gets
+		delPct            int // % of deletes (see churn.go)
+		updatePct         int // % of puts that overwrite existing objects (ditto)
 		numWorkers        int
 		batchSize         int // batch is used for bootstraping(list) and delete
 		loaderIDHashLen   uint
@@ -98,8 +104,11 @@ type (
 
 		duration DurationExt // stop after the run for at least that much
 
+		sla slaParams // SLA assertions (-assert-*)
+
 		bp   api.BaseParams
 		smap *meta.Smap
+		prof *api.Profile // when -profile (or AIS_PROFILE)
 
 		bck    cmn.Bck
 		bProps cmn.Bprops
@@ -109,8 +118,11 @@ type (
 		readerType           string
 		tmpDir               string // used only when usingFile
 		statsOutput          string
+		reportHTML           string // per-target breakdown and latency heatmaps (HTML)
 		cksumType            string
+		hashPipe             string // checksumming: "auto" | "on" | "off" (see cos.HashPipe)
 		statsdIP             string
+		statsdFormat         string // "plain" (default), "dogstatsd", or "influx" (tagged metrics)
 		bPropsStr            string
 		putSizeUpperBoundStr string // stop after writing that amount of data
 		minSizeStr           string
@@ -119,7 +131,11 @@ type (
 		readLenStr           string // read length
 		subDir               string
 		tokenFile            string
-		fileList             string // local file that contains object names (an alternative to running list-objects)
+		profile              string     // client profile (see api/profile.go)
+		flagsSet             cos.StrSet // command-line flags that were explicitly specified
+		fileList             string     // local file that contains object names (an alternative to running list-objects)
+
+		assertErrRateStr string // max error rate, e.g. "0.1%"
 
 		etlName     string // name of a ETL to apply to each object. Omitted when etlSpecPath specified.
 		etlSpecPath string // Path to a ETL spec to apply to each object.
@@ -141,6 +157,7 @@ type (
 		latest        bool // check in-cluster metadata and possibly GET the latest object version from the associated remote bucket
 		cached        bool // list in-cluster objects - only those objects from a remote bucket that are present (\"cached\")
 		listDirs      bool // do list virtual subdirectories (applies to remote buckets only)
+		verifyGen     bool // stamp PUT payloads with generations and verify them upon GET (see churn.go)
 	}
 
 	// sts records accumulated puts/gets information.
@@ -148,6 +165,7 @@ type (
 		put       stats.HTTPReq
 		get       stats.HTTPReq
 		getConfig stats.HTTPReq
+		del       stats.HTTPReq
 		statsd    stats.Metrics
 	}
 
@@ -174,6 +192,7 @@ var (
 	statsdC          *statsd.Client
 	getPending       int64
 	putPending       int64
+	delPending       int64
 	traceHTTPSig     atomic.Bool
 
 	flagUsage   bool
@@ -204,6 +223,13 @@ var (
 	s3Profile      string
 	s3UsePathStyle bool
 
+	// s3 direct: TLS, retries, and timeout
+	s3CACert      string
+	s3SkipVerify  bool
+	s3RetryMode   string
+	s3MaxAttempts int
+	s3Timeout     time.Duration
+
 	loggedUserToken string
 )
 
@@ -274,8 +300,16 @@ func Start(version, buildtime string) (err error) {
 			return fmt.Errorf("failed to get cluster map: %v", err)
 		}
 	}
-	loggedUserToken, err = authn.LoadToken(runParams.tokenFile)
-	if err != nil && runParams.tokenFile != "" {
+	if prof := runParams.prof; prof != nil && runParams.tokenFile == "" && os.Getenv(env.AuthN.Token) == "" &&
+		os.Getenv(env.AuthN.TokenFile) == "" && (prof.Token != "" || prof.TokenFile != "") {
+		loggedUserToken, err = prof.LoadToken()
+	} else {
+		loggedUserToken, err = authn.LoadToken(runParams.tokenFile)
+		if err != nil && runParams.tokenFile == "" {
+			err = nil
+		}
+	}
+	if err != nil {
 		return err
 	}
 	runParams.bp.Token = loggedUserToken
@@ -323,6 +357,9 @@ func Start(version, buildtime string) (err error) {
 		bucketObjsNames = &namegetter.RandomNameGetter{}
 		bucketObjsNames.Init([]string{}, rnd)
 	}
+	if runParams.churnEnabled() {
+		chrn = newChurn(bucketObjsNames.Names())
+	}
 
 	printRunParams(runParams)
 	if runParams.dryRun { // dry-run so just print the configurations and exit
@@ -342,13 +379,26 @@ func Start(version, buildtime string) (err error) {
 	if err != nil {
 		return fmt.Errorf("failed to get host name: %s", err.Error())
 	}
-	prefixC := fmt.Sprintf("aisloader.%s-%x", host, suffixID)
+	var (
+		loader  = fmt.Sprintf("%s-%x", host, suffixID)
+		prefixC = "aisloader." + loader
+	)
+	if runParams.statsdFmt != statsd.Plain {
+		prefixC = "aisloader" // loader ID is a tag
+	}
 	statsdC, err = statsd.New(runParams.statsdIP, runParams.statsdPort, prefixC, runParams.statsdProbe)
 	if err != nil {
 		fmt.Printf("%s", "Failed to connect to StatsD server")
 		time.Sleep(time.Second)
 	}
 	defer statsdC.Close()
+	if runParams.statsdFmt != statsd.Plain {
+		statsdC.SetTagged(&statsd.Tagged{
+			Format:    runParams.statsdFmt,
+			BucketTag: "op",
+			Tags:      []statsd.Tag{{Name: "loader", Value: loader}, {Name: "bucket", Value: runParams.bck.Cname("")}},
+		})
+	}
 
 	// init housekeeper and memsys;
 	// empty config to use memsys constants;
@@ -404,6 +454,7 @@ func Start(version, buildtime string) (err error) {
 	tsStart := time.Now()
 	intervalStats = newStats(tsStart)
 	accumulatedStats = newStats(tsStart)
+	tgtStats.init(tsStart, runParams.statsShowInterval)
 
 	statsWriter := os.Stdout
 
@@ -515,6 +566,21 @@ Done:
 
 	fmt.Printf("\nActual run duration: %v\n", time.Since(tsStart))
 
+	if runParams.reportHTML != "" {
+		if errR := tgtStats.writeHTML(runParams.reportHTML); errR != nil {
+			fmt.Fprintln(os.Stderr, errR)
+		} else {
+			fmt.Printf("HTML report written to %s\n", runParams.reportHTML)
+		}
+	}
+	if errSLA := runParams.sla.check(); errSLA != nil && err == nil {
+		err = errSLA
+	}
+	if chrn != nil {
+		if errV := chrn.report(); errV != nil && err == nil {
+			err = errV
+		}
+	}
 	return err
 }
 
@@ -522,7 +588,7 @@ func addCmdLine(f *flag.FlagSet, p *params) {
 	f.BoolVar(&flagUsage, "usage", false, "show command-line options, usage, and examples")
 	f.BoolVar(&flagVersion, "version", false, "show aisloader version")
 	f.BoolVar(&flagQuiet, "quiet", false, "when starting to run, do not print command line arguments, default settings, and usage examples")
-	f.DurationVar(&cargs.Timeout, "timeout", 10*time.Minute, "client HTTP timeout - used in LIST/GET/PUT/DELETE")
+	f.DurationVar(&cargs.Timeout, "timeout", dfltTimeout, "client HTTP timeout - used in LIST/GET/PUT/DELETE")
 	f.IntVar(&p.statsShowInterval, "statsinterval", 10, "interval in seconds to print performance counters; 0 - disabled")
 	f.StringVar(&p.bck.Name, "bucket", "", "bucket name or bucket URI. If empty, a bucket with random name will be created")
 	f.StringVar(&p.bck.Provider, "provider", apc.AIS,
@@ -537,6 +603,12 @@ func addCmdLine(f *flag.FlagSet, p *params) {
 	f.StringVar(&s3Endpoint, "s3endpoint", "", "S3 endpoint to read/write s3 bucket directly (with no aistore)")
 	f.StringVar(&s3Profile, "s3profile", "", "other then default S3 config profile referencing alternative credentials")
 	f.BoolVar(&s3UsePathStyle, "s3-use-path-style", false, "use older path-style addressing (as opposed to virtual-hosted style), e.g., https://s3.amazonaws.com/BUCKET/KEY. Should only be used with 's3endpoint' option")
+	f.StringVar(&s3CACert, "s3-cacert", "", "PEM-encoded CA bundle to verify S3 endpoint's certificate (e.g., self-signed); used only with 's3endpoint'")
+	f.BoolVar(&s3SkipVerify, "s3-skip-verify", false, "do not verify S3 endpoint's TLS certificate (insecure); used only with 's3endpoint'")
+	f.StringVar(&s3RetryMode, "s3-retry-mode", "",
+		"S3 retry mode: \"standard\" or \"adaptive\" (the latter rate-limits client-side upon throttling); empty - SDK default")
+	f.IntVar(&s3MaxAttempts, "s3-max-attempts", 0, "max number of S3 request attempts, including the first one (1 - no retries; 0 - SDK default)")
+	f.DurationVar(&s3Timeout, "s3-timeout", 0, "S3 request timeout, including reading (or writing) object content (0 - no timeout)")
 
 	DurationExtVar(f, &p.duration, "duration", time.Minute,
 		"Benchmark duration (0 - run forever or until Ctrl-C). \n"+
@@ -545,12 +617,18 @@ func addCmdLine(f *flag.FlagSet, p *params) {
 
 	f.IntVar(&p.numWorkers, "numworkers", 10, "number of goroutine workers operating on AIS in parallel")
 	f.IntVar(&p.putPct, "pctput", 0, "percentage of PUTs in the aisloader-generated workload")
+	f.IntVar(&p.delPct, "pctdel", 0, "percentage of DELETEs of existing objects in the aisloader-generated workload (PUT + DELETE <= 100%)")
+	f.IntVar(&p.updatePct, "pctupdate", 0, "percentage of PUTs that overwrite existing objects rather than create new ones")
+	f.BoolVar(&p.verifyGen, "verifygen", false,
+		"when true, stamp PUT payloads with per-object generations and verify that GET returns the latest committed generation")
 	f.StringVar(&p.tmpDir, "tmpdir", "/tmp/ais", "local directory to store temporary files")
 	f.StringVar(&p.putSizeUpperBoundStr, "totalputsize", "0",
 		"stop PUT workload once cumulative PUT size reaches or exceeds this value (can contain standard multiplicative suffix K, MB, GiB, etc.; 0 - unlimited")
 	BoolExtVar(f, &p.cleanUp, "cleanup", "when true, remove bucket upon benchmark termination (must be specified for aistore buckets)")
 	f.BoolVar(&p.verifyHash, "verifyhash", false,
 		"when true, checksum-validate GET: recompute object checksums and validate it against the one received with the GET metadata")
+	f.StringVar(&p.hashPipe, "hashpipe", cos.HashPipeAuto,
+		"compute checksums concurrently with reading (large objects): "+cos.HashPipeAuto+" | "+cos.HashPipeOn+" | "+cos.HashPipeOff)
 
 	f.StringVar(&p.minSizeStr, "minsize", "", "minimum object size (with or without multiplicative suffix K, MB, GiB, etc.)")
 	f.StringVar(&p.maxSizeStr, "maxsize", "", "maximum object size (with or without multiplicative suffix K, MB, GiB, etc.)")
@@ -559,7 +637,11 @@ func addCmdLine(f *flag.FlagSet, p *params) {
 	f.StringVar(&p.loaderID, "loaderid", "0", "ID to identify a loader among multiple concurrent instances")
 	f.StringVar(&p.statsdIP, "statsdip", "localhost", "StatsD IP address or hostname")
 	f.StringVar(&p.tokenFile, "tokenfile", "", "authentication token (FQN)") // see also: AIS_AUTHN_TOKEN_FILE
+	f.StringVar(&p.profile, "profile", "",
+		"client profile (endpoint, credentials, TLS, timeouts) from "+api.ProfilesPath()+" (see also: "+env.AIS.Profile+")")
 	f.IntVar(&p.statsdPort, "statsdport", 8125, "StatsD UDP port")
+	f.StringVar(&p.statsdFormat, "statsd-format", "plain",
+		"StatsD format: 'plain' (loader ID is part of the metric name), 'dogstatsd' or 'influx' (tagged metrics: loader, bucket, op)")
 	f.BoolVar(&p.statsdProbe, "test-probe StatsD server prior to benchmarks", false, "when enabled probes StatsD server prior to running")
 	f.IntVar(&p.batchSize, "batchsize", 100, "batch size to list and delete")
 	f.StringVar(&p.bPropsStr, "bprops", "", "JSON string formatted as per the SetBucketProps API and containing bucket properties to apply")
@@ -597,6 +679,7 @@ func addCmdLine(f *flag.FlagSet, p *params) {
 	f.BoolVar(&p.getConfig, "getconfig", false,
 		"when true, generate control plane load by reading AIS proxy configuration (that is, instead of reading/writing data exercise control path)")
 	f.StringVar(&p.statsOutput, "stats-output", "", "filename to log statistics (empty string translates as standard output (default))")
+	f.StringVar(&p.reportHTML, "report-html", "", "filename to write HTML report with per-target latency breakdown and heatmaps (AIS only)")
 	f.BoolVar(&p.stoppable, "stoppable", false, "when true, stop upon CTRL-C")
 	f.BoolVar(&p.dryRun, "dry-run", false, "when true, show the configuration and parameters that aisloader will use for benchmark")
 	f.BoolVar(&p.traceHTTP, "trace-http", false, "when true, trace HTTP latencies") // see httpLatencies
@@ -605,6 +688,12 @@ func addCmdLine(f *flag.FlagSet, p *params) {
 	f.BoolVar(&p.cached, "cached", false, "list in-cluster objects - only those objects from a remote bucket that are present (\"cached\")")
 	f.BoolVar(&p.listDirs, "list-dirs", false, "list virtual subdirectories (remote buckets only)")
 
+	// SLA assertions (non-zero exit upon violation)
+	f.DurationVar(&p.sla.p50, "assert-p50", 0, "fail (non-zero exit) if 50th percentile GET or PUT latency exceeds this duration (e.g. 10ms)")
+	f.DurationVar(&p.sla.p99, "assert-p99", 0, "fail (non-zero exit) if 99th percentile GET or PUT latency exceeds this duration (e.g. 50ms)")
+	f.StringVar(&p.assertErrRateStr, "assert-error-rate", "",
+		"fail (non-zero exit) if GET or PUT error rate exceeds this value, e.g. '0.1%' or '0.001'")
+
 	// ETL
 	f.StringVar(&p.etlName, "etl", "", "name of an ETL applied to each object on GET request. One of '', 'tar2tf', 'md5', 'echo'")
 	f.StringVar(&p.etlSpecPath, "etl-spec", "", "path to an ETL spec to be applied to each object on GET request.")
@@ -618,6 +707,9 @@ func addCmdLine(f *flag.FlagSet, p *params) {
 	f.Parse(os.Args[1:])
 	f.Usage = orig
 
+	p.flagsSet = cos.NewStrSet()
+	f.Visit(func(fl *flag.Flag) { p.flagsSet.Add(fl.Name) })
+
 	if len(os.Args[1:]) == 0 {
 		printUsage(f)
 		os.Exit(0)
@@ -700,6 +792,30 @@ func _init(p *params) (err error) {
 	if p.putPct < 0 || p.putPct > 100 {
 		return fmt.Errorf("invalid option: PUT percent %d", p.putPct)
 	}
+	if p.delPct < 0 || p.putPct+p.delPct > 100 {
+		return fmt.Errorf("invalid option: DELETE percent %d (PUT percent %d)", p.delPct, p.putPct)
+	}
+	if p.updatePct < 0 || p.updatePct > 100 {
+		return fmt.Errorf("invalid option: PUT overwrite percent %d", p.updatePct)
+	}
+	if p.verifyGen {
+		if p.readerType != readers.TypeSG {
+			return fmt.Errorf("invalid option: '-verifygen' requires '-readertype=%s'", readers.TypeSG)
+		}
+		if p.minSize < int64(stampLen) {
+			return fmt.Errorf("invalid option: '-verifygen' requires minimum object size of at least %dB", stampLen)
+		}
+		if p.readOffStr != "" || p.readLenStr != "" {
+			return errors.New("invalid option: '-verifygen' cannot be used with read range ('-readoff', '-readlen')")
+		}
+	}
+
+	if err = p.sla.init(p.assertErrRateStr); err != nil {
+		return err
+	}
+	if p.statsdFmt, err = statsd.ParseFormat(p.statsdFormat); err != nil {
+		return fmt.Errorf("invalid option: '-statsd-format': %v", err)
+	}
 
 	if p.skipList {
 		if p.fileList != "" {
@@ -732,6 +848,22 @@ func _init(p *params) (err error) {
 		if p.readOffStr != "" || p.readLenStr != "" {
 			return errors.New("direct S3 access via '-s3endpoint': Read range is not supported yet")
 		}
+		if p.churnEnabled() {
+			return errors.New("direct S3 access via '-s3endpoint': '-pctdel', '-pctupdate', and '-verifygen' are not supported yet")
+		}
+		if s3RetryMode != "" {
+			if _, err := aws.ParseRetryMode(s3RetryMode); err != nil {
+				return fmt.Errorf("invalid option '-s3-retry-mode': %v", err)
+			}
+		}
+		if s3MaxAttempts < 0 {
+			return fmt.Errorf("invalid option '-s3-max-attempts': %d (must be non-negative)", s3MaxAttempts)
+		}
+		if s3Timeout < 0 {
+			return fmt.Errorf("invalid option '-s3-timeout': %v (must be non-negative)", s3Timeout)
+		}
+	} else if s3CACert != "" || s3SkipVerify || s3RetryMode != "" || s3MaxAttempts != 0 || s3Timeout != 0 {
+		return errors.New("command line options '-s3-cacert', '-s3-skip-verify', '-s3-retry-mode', '-s3-max-attempts', and '-s3-timeout' require '-s3endpoint'")
 	}
 
 	if p.statsShowInterval < 0 {
@@ -808,6 +940,9 @@ func _init(p *params) (err error) {
 	if err := cos.ValidateCksumType(p.cksumType); err != nil {
 		return err
 	}
+	if _, err := cos.InitHashPipe(p.hashPipe); err != nil {
+		return err
+	}
 
 	if p.etlName != "" && p.etlSpecPath != "" {
 		return errors.New("etl and etl-spec flag can't be set both")
@@ -891,8 +1026,16 @@ func _init(p *params) (err error) {
 
 	var useHTTPS bool
 	if !isDirectS3() {
-		// AIS endpoint: http://ip:port _or_ AIS_ENDPOINT env
+		// AIS endpoint: http://ip:port _or_ AIS_ENDPOINT env _or_ client profile
 		aisEndpoint := "http://" + ip + ":" + port
+		if p.profile != "" || os.Getenv(env.AIS.Profile) != "" {
+			if err := p.applyProfile(); err != nil {
+				return err
+			}
+			if !p.flagsSet.Contains("ip") && !p.flagsSet.Contains("port") {
+				aisEndpoint = p.prof.Endpoint
+			}
+		}
 
 		// see also: tlsArgs
 		envEndpoint = os.Getenv(env.AIS.Endpoint)
@@ -932,6 +1075,30 @@ func _init(p *params) (err error) {
 	return nil
 }
 
+// profile's TLS and timeout settings apply unless specified otherwise (via command-line or environment)
+func (p *params) applyProfile() (err error) {
+	if p.prof, err = api.LoadProfile(p.profile); err != nil {
+		return err
+	}
+	// TLS: only what the profile specifies (the environment applies on top - see EnvToTLS)
+	if prof := p.prof.TLSArgs(); prof.Certificate != "" || prof.ClientCA != "" {
+		if prof.Certificate != "" {
+			sargs.Certificate, sargs.Key = prof.Certificate, prof.Key
+		}
+		if prof.ClientCA != "" {
+			sargs.ClientCA = prof.ClientCA
+		}
+		sargs.SkipVerify = prof.SkipVerify
+	}
+	if !p.flagsSet.Contains("timeout") && p.prof.Timeout != 0 {
+		cargs.Timeout = p.prof.TransportArgs().Timeout
+	}
+	if p.prof.DialTimeout != 0 {
+		cargs.DialTimeout = p.prof.DialTimeout.D()
+	}
+	return nil
+}
+
 func isDirectS3() bool {
 	debug.Assert(flag.Parsed())
 	return s3Endpoint != ""
@@ -970,6 +1137,7 @@ func newStats(t time.Time) sts {
 		put:       stats.NewHTTPReq(t),
 		get:       stats.NewHTTPReq(t),
 		getConfig: stats.NewHTTPReq(t),
+		del:       stats.NewHTTPReq(t),
 		statsd:    stats.NewStatsdMetrics(t),
 	}
 }
@@ -979,6 +1147,7 @@ func (s *sts) aggregate(other *sts) {
 	s.get.Aggregate(other.get)
 	s.put.Aggregate(other.put)
 	s.getConfig.Aggregate(other.getConfig)
+	s.del.Aggregate(other.del)
 }
 
 func setupBucket(runParams *params, created *bool) error {
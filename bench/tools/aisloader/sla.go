@@ -0,0 +1,84 @@
+// Package aisloader
+/*
+ * Copyright (c) 2024, NVIDIA CORPORATION. All rights reserved.
+ */
+
+package aisloader
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/NVIDIA/aistore/bench/tools/aisloader/stats"
+	"github.com/NVIDIA/aistore/cmn/cos"
+)
+
+// SLA assertion mode: upon completion, check accumulated GET, PUT, and DELETE stats against
+// user-specified thresholds (-assert-p50, -assert-p99, -assert-error-rate),
+// print violations (if any), and exit with non-zero status - e.g., to gate CI pipelines.
+
+type slaParams struct {
+	p50     time.Duration
+	p99     time.Duration
+	errRate float64 // fraction, [0, 1]; negative when not specified
+}
+
+func (sla *slaParams) init(errRateStr string) error {
+	if sla.p50 < 0 || sla.p99 < 0 {
+		return fmt.Errorf("invalid option: negative latency assertion (p50 %v, p99 %v)", sla.p50, sla.p99)
+	}
+	sla.errRate = -1
+	if errRateStr == "" {
+		return nil
+	}
+	rate, err := cos.ParsePercent(errRateStr)
+	if err != nil {
+		return fmt.Errorf("invalid option: '-assert-error-rate=%s': %v", errRateStr, err)
+	}
+	sla.errRate = rate
+	return nil
+}
+
+func (sla *slaParams) enabled() bool { return sla.p50 > 0 || sla.p99 > 0 || sla.errRate >= 0 }
+
+// returns (and prints) SLA violations, if any
+func (sla *slaParams) check() error {
+	if !sla.enabled() {
+		return nil
+	}
+	violations := sla.violations("GET", &accumulatedStats.get)
+	violations = append(violations, sla.violations("PUT", &accumulatedStats.put)...)
+	violations = append(violations, sla.violations("DELETE", &accumulatedStats.del)...)
+	if len(violations) == 0 {
+		fmt.Println("SLA assertions: passed")
+		return nil
+	}
+	fmt.Printf("\nSLA violations (%d):\n", len(violations))
+	for _, v := range violations {
+		fmt.Println("  " + v)
+	}
+	return errors.New("SLA violated")
+}
+
+func (sla *slaParams) violations(op string, s *stats.HTTPReq) (out []string) {
+	if s.Total() == 0 && s.TotalErrs() == 0 {
+		return nil // op not exercised
+	}
+	if sla.p50 > 0 {
+		if p50 := s.Percentile(50); p50 > sla.p50 {
+			out = append(out, fmt.Sprintf("%s p50 latency %v > %v", op, p50, sla.p50))
+		}
+	}
+	if sla.p99 > 0 {
+		if p99 := s.Percentile(99); p99 > sla.p99 {
+			out = append(out, fmt.Sprintf("%s p99 latency %v > %v", op, p99, sla.p99))
+		}
+	}
+	if sla.errRate >= 0 {
+		if rate := s.ErrRate(); rate > sla.errRate {
+			out = append(out, fmt.Sprintf("%s error rate %.4f%% > %.4f%% (%d errors)", op, rate*100, sla.errRate*100, s.TotalErrs()))
+		}
+	}
+	return out
+}
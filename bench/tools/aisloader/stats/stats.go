@@ -1,6 +1,6 @@
 // Package stats provides various structs for collecting stats
 /*
- * Copyright (c) 2018-2023, NVIDIA CORPORATION. All rights reserved.
+ * Copyright (c) 2018-2024, NVIDIA CORPORATION. All rights reserved.
  */
 package stats
 
@@ -9,6 +9,12 @@ import (
 	"time"
 )
 
+// latency histogram: log-scale buckets, `latSubBuckets` per power of two (precision ~9%)
+const (
+	latSubBuckets = 8
+	latNumBuckets = 64 * latSubBuckets
+)
+
 // HTTPReq is used for keeping track of http requests stats including number of ops, latency, throughput, etc.
 // Assume single threaded access, it doesn't provide any locking on updates.
 type HTTPReq struct {
@@ -21,6 +27,8 @@ type HTTPReq struct {
 	// self maintained fields
 	minLatency time.Duration
 	maxLatency time.Duration
+
+	hist [latNumBuckets]int64 // latency histogram (to compute percentiles)
 }
 
 // NewHTTPReq returns a new stats object with given time as the starting point
@@ -38,6 +46,7 @@ func (s *HTTPReq) Add(size int64, delta time.Duration) {
 	s.latency += delta
 	s.minLatency = min(s.minLatency, delta)
 	s.maxLatency = max(s.maxLatency, delta)
+	s.hist[latBucket(delta)]++
 }
 
 // AddErr increases the number of failed count by 1
@@ -106,4 +115,43 @@ func (s *HTTPReq) Aggregate(other HTTPReq) {
 
 	s.minLatency = min(s.minLatency, other.minLatency)
 	s.maxLatency = max(s.maxLatency, other.maxLatency)
+	for i := range s.hist {
+		s.hist[i] += other.hist[i]
+	}
+}
+
+// Percentile returns (an approximation of) the given latency percentile, e.g. 99 for p99.
+// The result is the upper bound of the corresponding histogram bucket but never exceeds max latency.
+func (s *HTTPReq) Percentile(pct float64) time.Duration {
+	if s.cnt == 0 {
+		return 0
+	}
+	var (
+		rank = int64(math.Ceil(pct / 100 * float64(s.cnt)))
+		cum  int64
+	)
+	rank = max(rank, 1)
+	for i, n := range s.hist {
+		cum += n
+		if cum >= rank {
+			upper := time.Duration(math.Exp2(float64(i+1) / latSubBuckets))
+			return min(upper, s.maxLatency)
+		}
+	}
+	return s.maxLatency
+}
+
+// ErrRate returns failed requests / all requests ratio.
+func (s *HTTPReq) ErrRate() float64 {
+	if total := s.cnt + s.errs; total > 0 {
+		return float64(s.errs) / float64(total)
+	}
+	return 0
+}
+
+func latBucket(d time.Duration) int {
+	if d <= 1 {
+		return 0
+	}
+	return min(int(math.Log2(float64(d))*latSubBuckets), latNumBuckets-1)
 }
@@ -70,3 +70,30 @@ func TestStats(t *testing.T) {
 	verify(t, "Max latency", 100000000, total.MaxLatency())
 	verify(t, "Throughput", 5, total.Throughput(start, start.Add(70*time.Second)))
 }
+
+func TestPercentile(t *testing.T) {
+	s := stats.NewHTTPReq(time.Now())
+	for i := 1; i <= 100; i++ {
+		s.Add(1, time.Duration(i)*time.Millisecond)
+	}
+	for _, test := range []struct {
+		pct float64
+		exp time.Duration
+	}{{50, 50 * time.Millisecond}, {99, 99 * time.Millisecond}, {100, 100 * time.Millisecond}} {
+		act := s.Percentile(test.pct)
+		// histogram precision: 2^(1/8) ~ 9%
+		if act < test.exp || float64(act) > float64(test.exp)*1.1 {
+			t.Fatalf("Error: p%.0f, expected ~%v, actual %v", test.pct, test.exp, act)
+		}
+	}
+
+	s.AddErr()
+	total := stats.NewHTTPReq(time.Now())
+	total.Aggregate(s)
+	if act := total.Percentile(99); act < 99*time.Millisecond || act > 100*time.Millisecond {
+		t.Fatalf("Error: aggregated p99 = %v", act)
+	}
+	if rate := total.ErrRate(); rate < 0.0099 || rate > 0.01 {
+		t.Fatalf("Error: error rate = %f", rate)
+	}
+}
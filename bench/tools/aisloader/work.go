@@ -29,6 +29,7 @@ const (
 	opPut = iota
 	opGet
 	opConfig
+	opDel
 )
 
 type (
@@ -37,6 +38,7 @@ type (
 		proxyURL  string
 		bck       cmn.Bck
 		objName   string // In the format of 'virtual dir' + "/" + objName
+		tid       string // target that served the request (when known)
 		size      int64
 		err       error
 		start     time.Time
@@ -44,6 +46,12 @@ type (
 		latencies httpLatencies
 		cksumType string
 		sgl       *memsys.SGL
+
+		// churn (see churn.go)
+		head      []byte  // GET: leading bytes of the object (generation stamp)
+		snap      genSnap // GET: object's generation state at post time
+		gen       uint64  // PUT: generation being written
+		overwrite bool    // PUT: existing object
 	}
 )
 
@@ -52,6 +60,15 @@ func postNewWorkOrder() (err error) {
 	switch {
 	case runParams.getConfig:
 		wo = newGetConfigWorkOrder()
+	case runParams.delPct > 0:
+		switch n := rnd.IntN(100); {
+		case n < runParams.putPct:
+			wo, err = newPutWorkOrder()
+		case n < runParams.putPct+runParams.delPct:
+			wo, err = newDelWorkOrder()
+		default:
+			wo, err = newGetWorkOrder()
+		}
 	case runParams.putPct == 100:
 		wo, err = newPutWorkOrder()
 	case runParams.putPct == 0:
@@ -76,7 +93,7 @@ func postNewWorkOrder() (err error) {
 }
 
 func validateWorkOrder(wo *workOrder, delta time.Duration) error {
-	if wo.op == opGet || wo.op == opPut {
+	if wo.op == opGet || wo.op == opPut || wo.op == opDel {
 		if delta == 0 {
 			return fmt.Errorf("%s has the same start time as end time", wo)
 		}
@@ -115,27 +132,39 @@ func completeWorkOrder(wo *workOrder, terminating bool) {
 		return
 	}
 
+	if wo.tid != "" {
+		tgtStats.add(wo, delta)
+	}
+
 	switch wo.op {
 	case opGet:
 		getPending--
 		intervalStats.statsd.Get.AddPending(getPending)
+		if chrn != nil && chrn.endGet(wo) {
+			break
+		}
 		if wo.err == nil {
 			intervalStats.get.Add(wo.size, delta)
 			intervalStats.statsd.Get.Add(wo.size, delta)
 		} else {
-			fmt.Println("GET failed: ", wo.err)
+			fmt.Println("GET failed:", wo.tidErr())
 			intervalStats.statsd.Get.AddErr()
 			intervalStats.get.AddErr()
 		}
 	case opPut:
 		putPending--
 		intervalStats.statsd.Put.AddPending(putPending)
+		if chrn != nil {
+			chrn.endPut(wo)
+		}
 		if wo.err == nil {
-			bucketObjsNames.AddObjName(wo.objName)
+			if !wo.overwrite {
+				bucketObjsNames.AddObjName(wo.objName)
+			}
 			intervalStats.put.Add(wo.size, delta)
 			intervalStats.statsd.Put.Add(wo.size, delta)
 		} else {
-			fmt.Println("PUT failed: ", wo.err)
+			fmt.Println("PUT failed:", wo.tidErr())
 			intervalStats.put.AddErr()
 			intervalStats.statsd.Put.AddErr()
 		}
@@ -174,6 +203,15 @@ func completeWorkOrder(wo *workOrder, terminating bool) {
 			fmt.Println("GET config failed: ", wo.err)
 			intervalStats.getConfig.AddErr()
 		}
+	case opDel:
+		delPending--
+		chrn.endDel(wo)
+		if wo.err == nil {
+			intervalStats.del.Add(0, delta)
+		} else {
+			fmt.Println("DELETE failed: ", wo.err)
+			intervalStats.del.AddErr()
+		}
 	default:
 		debug.Assert(false) // Should never be here
 	}
@@ -188,13 +226,17 @@ func doPut(wo *workOrder) {
 		sgl = gmm.NewSGL(wo.size)
 		wo.sgl = sgl
 	}
-	r, err := readers.New(readers.Params{
+	rp := readers.Params{
 		Type: runParams.readerType,
 		SGL:  sgl,
 		Path: runParams.tmpDir,
 		Name: wo.objName,
 		Size: wo.size,
-	}, wo.cksumType)
+	}
+	if runParams.verifyGen {
+		rp.Prefix = genStamp(wo.objName, wo.gen, wo.size)
+	}
+	r, err := readers.New(rp, wo.cksumType)
 
 	if err != nil {
 		wo.err = err
@@ -213,11 +255,11 @@ func doPut(wo *workOrder) {
 		if isDirectS3() {
 			wo.err = s3put(wo.bck, wo.objName, r)
 		} else {
-			wo.err = put(url, wo.bck, wo.objName, r.Cksum(), r)
+			wo.tid, wo.err = put(url, wo.bck, wo.objName, r.Cksum(), r)
 		}
 	} else {
 		debug.Assert(!isDirectS3())
-		wo.err = putWithTrace(url, wo.bck, wo.objName, &wo.latencies, r.Cksum(), r)
+		wo.tid, wo.err = putWithTrace(url, wo.bck, wo.objName, &wo.latencies, r.Cksum(), r)
 	}
 	if runParams.readerType == readers.TypeFile {
 		r.Close()
@@ -229,6 +271,9 @@ func doGet(wo *workOrder) {
 	var (
 		url = wo.proxyURL
 	)
+	if runParams.verifyGen {
+		wo.head = make([]byte, stampLen)
+	}
 	if runParams.randomProxy {
 		debug.Assert(!isDirectS3())
 		psi, err := runParams.smap.GetRandProxy(false /*excl. primary*/)
@@ -242,14 +287,27 @@ func doGet(wo *workOrder) {
 		if isDirectS3() {
 			wo.size, wo.err = s3getDiscard(wo.bck, wo.objName)
 		} else {
-			wo.size, wo.err = getDiscard(url, wo.bck,
-				wo.objName, runParams.readOff, runParams.readLen, runParams.verifyHash, runParams.latest)
+			wo.size, wo.tid, wo.err = getDiscard(url, wo.bck,
+				wo.objName, runParams.readOff, runParams.readLen, runParams.verifyHash, runParams.latest, wo.head)
 		}
 	} else {
 		debug.Assert(!isDirectS3())
-		wo.size, wo.err = getTraceDiscard(url, wo.bck,
-			wo.objName, &wo.latencies, runParams.readOff, runParams.readLen, runParams.verifyHash, runParams.latest)
+		wo.size, wo.tid, wo.err = getTraceDiscard(url, wo.bck,
+			wo.objName, &wo.latencies, runParams.readOff, runParams.readLen, runParams.verifyHash, runParams.latest, wo.head)
+	}
+}
+
+func doDel(wo *workOrder) {
+	url := wo.proxyURL
+	if runParams.randomProxy {
+		psi, err := runParams.smap.GetRandProxy(false /*excl. primary*/)
+		if err != nil {
+			fmt.Printf("DELETE(wo): %v\n", err)
+			os.Exit(1)
+		}
+		url = psi.URL(cmn.NetPublic)
 	}
+	wo.err = del(url, wo.bck, wo.objName)
 }
 
 func doGetConfig(wo *workOrder) {
@@ -275,6 +333,8 @@ func worker(wos <-chan *workOrder, results chan<- *workOrder, wg *sync.WaitGroup
 			numGets.Inc()
 		case opConfig:
 			doGetConfig(wo)
+		case opDel:
+			doDel(wo)
 		default:
 			// Should not come here
 		}
@@ -289,23 +349,53 @@ func worker(wos <-chan *workOrder, results chan<- *workOrder, wg *sync.WaitGroup
 ///////////////
 
 func newPutWorkOrder() (*workOrder, error) {
-	objName, err := _genObjName()
-	if err != nil {
-		return nil, err
+	var (
+		objName   string
+		overwrite bool
+		err       error
+	)
+	if chrn != nil && runParams.updatePct > rnd.IntN(100) {
+		objName, overwrite = chrn.pick(true /*idle*/)
+	}
+	if !overwrite {
+		if objName, err = _genObjName(); err != nil {
+			return nil, err
+		}
 	}
 	size := runParams.minSize
 	if runParams.maxSize != runParams.minSize {
 		d := rnd.Int64N(runParams.maxSize + 1 - runParams.minSize)
 		size = runParams.minSize + d
 	}
-	putPending++
-	return &workOrder{
+	wo := &workOrder{
 		proxyURL:  runParams.proxyURL,
 		bck:       runParams.bck,
 		op:        opPut,
 		objName:   objName,
 		size:      size,
 		cksumType: runParams.cksumType,
+		overwrite: overwrite,
+	}
+	if chrn != nil {
+		wo.gen = chrn.beginPut(objName)
+	}
+	putPending++
+	return wo, nil
+}
+
+// (when there's nothing to delete, PUT instead)
+func newDelWorkOrder() (*workOrder, error) {
+	objName, ok := chrn.pick(true /*idle*/)
+	if !ok {
+		return newPutWorkOrder()
+	}
+	chrn.beginDel(objName)
+	delPending++
+	return &workOrder{
+		proxyURL: runParams.proxyURL,
+		bck:      runParams.bck,
+		op:       opDel,
+		objName:  objName,
 	}, nil
 }
 
@@ -344,6 +434,9 @@ func _genObjName() (string, error) {
 }
 
 func newGetWorkOrder() (*workOrder, error) {
+	if chrn != nil {
+		return newChurnGetWorkOrder()
+	}
 	if bucketObjsNames.Len() == 0 {
 		return nil, errors.New("no objects in bucket")
 	}
@@ -357,6 +450,24 @@ func newGetWorkOrder() (*workOrder, error) {
 	}, nil
 }
 
+func newChurnGetWorkOrder() (*workOrder, error) {
+	objName, ok := chrn.pick(false /*idle*/)
+	if !ok {
+		if runParams.putPct > 0 {
+			return newPutWorkOrder() // (e.g., all deleted)
+		}
+		return nil, errors.New("no objects in bucket")
+	}
+	getPending++
+	return &workOrder{
+		proxyURL: runParams.proxyURL,
+		bck:      runParams.bck,
+		op:       opGet,
+		objName:  objName,
+		snap:     chrn.snap(objName),
+	}, nil
+}
+
 func newGetConfigWorkOrder() *workOrder {
 	return &workOrder{
 		proxyURL: runParams.proxyURL,
@@ -373,6 +484,8 @@ func (wo *workOrder) String() string {
 		opName = http.MethodPut
 	case opConfig:
 		opName = "CONFIG"
+	case opDel:
+		opName = http.MethodDelete
 	}
 
 	if wo.err != nil {
@@ -382,3 +495,11 @@ func (wo *workOrder) String() string {
 	return fmt.Sprintf("WO: %s/%s, start:%s end:%s, size: %d, type: %s%s",
 		wo.bck, wo.objName, wo.start.Format(time.StampMilli), wo.end.Format(time.StampMilli), wo.size, opName, errstr)
 }
+
+// error message that includes the target that served (failed) the request, when known
+func (wo *workOrder) tidErr() string {
+	if wo.tid == "" {
+		return wo.err.Error()
+	}
+	return fmt.Sprintf("%v (target %s)", wo.err, wo.tid)
+}
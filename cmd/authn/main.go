@@ -9,17 +9,14 @@ import (
 	"fmt"
 	"os"
 	"os/signal"
-	"path/filepath"
 	"strings"
 	"syscall"
 	"time"
 
 	"github.com/NVIDIA/aistore/api/env"
+	"github.com/NVIDIA/aistore/cmd/authn/svc"
 	"github.com/NVIDIA/aistore/cmn"
 	"github.com/NVIDIA/aistore/cmn/cos"
-	"github.com/NVIDIA/aistore/cmn/fname"
-	"github.com/NVIDIA/aistore/cmn/jsp"
-	"github.com/NVIDIA/aistore/cmn/kvdb"
 	"github.com/NVIDIA/aistore/cmn/nlog"
 )
 
@@ -27,11 +24,11 @@ var (
 	build     string
 	buildtime string
 
-	configPath string
+	configDir string
 )
 
 func init() {
-	flag.StringVar(&configPath, "config", "", svcName+" configuration")
+	flag.StringVar(&configDir, "config", "", "AuthN configuration")
 }
 
 func logFlush() {
@@ -42,7 +39,6 @@ func logFlush() {
 }
 
 func main() {
-	var configDir string
 	if len(os.Args) == 2 && os.Args[1] == "version" {
 		printVer()
 		os.Exit(0)
@@ -63,50 +59,42 @@ func main() {
 		configDir = os.Getenv(env.AuthN.ConfDir)
 	}
 	if configDir == "" {
-		cos.ExitLogf("Missing %s configuration file (to specify, use '-%s' option or '%s' environment)",
-			svcName, confDirFlag.Name, env.AuthN.ConfDir)
+		cos.ExitLogf("Missing AuthN configuration file (to specify, use '-%s' option or '%s' environment)",
+			confDirFlag.Name, env.AuthN.ConfDir)
 	}
-	configPath = filepath.Join(configDir, fname.AuthNConfig)
-	if _, err := jsp.LoadMeta(configPath, Conf); err != nil {
-		cos.ExitLogf("Failed to load configuration from %q: %v", configPath, err)
+	if err := svc.LoadConfig(configDir); err != nil {
+		cos.ExitLogf("Failed to load configuration from %q: %v", configDir, err)
 	}
-	Conf.Init()
 	if val := os.Getenv(env.AuthN.SecretKey); val != "" {
-		Conf.SetSecret(&val)
+		svc.Conf.SetSecret(&val)
 	}
 	if err := updateLogOptions(); err != nil {
 		cos.ExitLogf("Failed to set up logger: %v", err)
 	}
-	if Conf.Verbose() {
-		nlog.Infof("Loaded configuration from %s", configPath)
+	if svc.Conf.Verbose() {
+		nlog.Infof("Loaded configuration from %s", configDir)
 	}
 
-	dbPath := filepath.Join(configDir, fname.AuthNDB)
-	driver, err := kvdb.NewBuntDB(dbPath)
+	s, err := svc.New(configDir)
 	if err != nil {
-		cos.ExitLogf("Failed to init local database: %v", err)
-	}
-	mgr, err := newMgr(driver)
-	if err != nil {
-		cos.ExitLogf("Failed to init manager: %v", err)
+		cos.ExitLogf("%v", err)
 	}
 
 	nlog.Infof("Version %s (build %s)\n", cmn.VersionAuthN+"."+build, buildtime)
 
 	go logFlush()
 
-	srv := newServer(mgr)
-	err = srv.Run()
+	err = s.Run()
 
 	nlog.Flush(nlog.ActExit)
-	cos.Close(mgr.db)
+	s.Stop()
 	if err != nil {
 		cos.ExitLogf("Server failed: %v", err)
 	}
 }
 
 func updateLogOptions() error {
-	logDir := cos.GetEnvOrDefault(env.AuthN.LogDir, Conf.Log.Dir)
+	logDir := cos.GetEnvOrDefault(env.AuthN.LogDir, svc.Conf.Log.Dir)
 	if err := cos.CreateDir(logDir); err != nil {
 		return fmt.Errorf("failed to create log dir %q, err: %v", logDir, err)
 	}
@@ -0,0 +1,204 @@
+// Package svc implements AIStore authentication server (AuthN).
+/*
+ * Copyright (c) 2024, NVIDIA CORPORATION. All rights reserved.
+ */
+package svc
+
+import (
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/NVIDIA/aistore/api/apc"
+	"github.com/NVIDIA/aistore/api/authn"
+	"github.com/NVIDIA/aistore/cmn/cos"
+	"github.com/NVIDIA/aistore/cmn/nlog"
+)
+
+// Propagation of user and role changes to all registered clusters:
+// - clusters do not store users and roles - the ACLs are carried by tokens;
+//   changing (or deleting) a user or a role therefore revokes all outstanding
+//   tokens of the affected users (see mgr.aclChanged);
+// - each change bumps the version; the syncer then pushes the (entire) list of
+//   revoked tokens to every cluster that is behind, in parallel;
+// - failed clusters are retried in the background with exponential backoff;
+// - upon startup all clusters are pending, and so is any newly registered cluster.
+
+const (
+	syncRetryMin = 10 * time.Second
+	syncRetryMax = 10 * time.Minute
+	syncIdle     = time.Hour
+)
+
+type aclSync struct {
+	m      *mgr
+	clus   map[string]*authn.CluACLSync
+	kickCh chan struct{}
+	stopCh cos.StopCh
+	ver    int64
+	mu     sync.Mutex
+}
+
+func newACLSync(m *mgr) *aclSync {
+	s := &aclSync{
+		m:      m,
+		clus:   make(map[string]*authn.CluACLSync, 4),
+		kickCh: make(chan struct{}, 1),
+		ver:    1,
+	}
+	s.stopCh.Init()
+	return s
+}
+
+func (s *aclSync) run() {
+	timer := time.NewTimer(0)
+	for {
+		select {
+		case <-s.kickCh:
+			if !timer.Stop() {
+				select {
+				case <-timer.C:
+				default:
+				}
+			}
+		case <-timer.C:
+		case <-s.stopCh.Listen():
+			timer.Stop()
+			return
+		}
+		timer.Reset(s.do())
+	}
+}
+
+// bump the version and wake up the syncer
+func (s *aclSync) changed() {
+	s.mu.Lock()
+	s.ver++
+	s.mu.Unlock()
+	s.kick()
+}
+
+func (s *aclSync) kick() {
+	select {
+	case s.kickCh <- struct{}{}:
+	default:
+	}
+}
+
+// sync all pending clusters that are due; return time till the next retry
+func (s *aclSync) do() time.Duration {
+	clus, err := s.m.clus()
+	if err != nil {
+		nlog.Errorf("Failed to read cluster list: %v", err)
+		return syncRetryMin
+	}
+
+	var (
+		now     = time.Now()
+		due     = make([]*authn.CluACL, 0, len(clus))
+		ver     int64
+		nextTry = syncIdle
+	)
+	s.mu.Lock()
+	ver = s.ver
+	for cid := range s.clus {
+		if _, ok := clus[cid]; !ok {
+			delete(s.clus, cid) // unregistered
+		}
+	}
+	for cid, clu := range clus {
+		cs, ok := s.clus[cid]
+		if !ok {
+			cs = &authn.CluACLSync{ID: cid}
+			s.clus[cid] = cs
+		}
+		cs.Alias = clu.Alias
+		if cs.Version >= ver {
+			continue
+		}
+		if wait := cs.NextRetry.Sub(now); wait > 0 {
+			nextTry = min(nextTry, wait)
+			continue
+		}
+		due = append(due, clu)
+	}
+	s.mu.Unlock()
+	if len(due) == 0 {
+		return nextTry
+	}
+
+	tokenList, err := s.m.generateRevokedTokenList()
+	if err != nil {
+		nlog.Errorf("Failed to generate revoked token list: %v", err)
+		return syncRetryMin
+	}
+	var (
+		body = cos.MustMarshal(authn.TokenList{Tokens: tokenList})
+		errs = make([]error, len(due))
+		wg   = &sync.WaitGroup{}
+	)
+	if len(tokenList) > 0 {
+		for i, clu := range due {
+			wg.Add(1)
+			go func(i int, clu *authn.CluACL) {
+				errs[i] = s.m.pushTokens(clu, body, "sync-acl")
+				wg.Done()
+			}(i, clu)
+		}
+		wg.Wait()
+	}
+
+	now = time.Now()
+	s.mu.Lock()
+	for i, clu := range due {
+		cs, ok := s.clus[clu.ID]
+		if !ok {
+			continue
+		}
+		if err := errs[i]; err != nil {
+			cs.Attempts++
+			cs.Err = err.Error()
+			backoff := min(syncRetryMin<<min(cs.Attempts-1, 16), syncRetryMax)
+			cs.NextRetry = now.Add(backoff)
+			nextTry = min(nextTry, backoff)
+			nlog.Errorf("failed to sync ACL v%d with %s (attempt %d, retrying in %v): %v", ver, clu, cs.Attempts, backoff, err)
+			continue
+		}
+		cs.Version = ver
+		cs.SyncTime = now
+		cs.Err, cs.Attempts, cs.NextRetry = "", 0, time.Time{}
+		if Conf.Verbose() {
+			nlog.Infof("synced ACL v%d with %s", ver, clu)
+		}
+	}
+	if s.ver > ver {
+		nextTry = 0 // changed in the meantime
+	}
+	s.mu.Unlock()
+	return nextTry
+}
+
+func (s *aclSync) status(clus map[string]*authn.CluACL) *authn.ACLSync {
+	s.mu.Lock()
+	res := &authn.ACLSync{Version: s.ver, Clusters: make(map[string]*authn.CluACLSync, len(clus))}
+	for cid, clu := range clus {
+		c := authn.CluACLSync{ID: cid, Alias: clu.Alias} // (registered but not yet seen by the syncer)
+		if cs, ok := s.clus[cid]; ok {
+			c = *cs
+		}
+		c.Pending = c.Version < s.ver
+		res.Clusters[cid] = &c
+	}
+	s.mu.Unlock()
+	return res
+}
+
+// send revoked tokens to the first responding URL of the cluster
+func (m *mgr) pushTokens(clu *authn.CluACL, body []byte, tag string) (err error) {
+	for _, u := range clu.URLs {
+		if err = m.call(http.MethodDelete, u, apc.Tokens, body, tag); err == nil {
+			return nil
+		}
+	}
+	return err
+}
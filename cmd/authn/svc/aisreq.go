@@ -1,8 +1,8 @@
-// Package authn is authentication server for AIStore.
+// Package svc implements AIStore authentication server (AuthN).
 /*
  * Copyright (c) 2018-2023, NVIDIA CORPORATION. All rights reserved.
  */
-package main
+package svc
 
 import (
 	"bytes"
@@ -78,29 +78,6 @@ func (m *mgr) broadcast(method, path string, body []byte, tag string) {
 	wg.Wait()
 }
 
-// Send valid and non-expired revoked token list to a cluster.
-func (m *mgr) syncTokenList(clu *authn.CluACL) {
-	const tag = "sync-tokens"
-	tokenList, err := m.generateRevokedTokenList()
-	if err != nil {
-		nlog.Errorf("failed to sync token list with %q(%q): %v", clu.ID, clu.Alias, err)
-		return
-	}
-	if len(tokenList) == 0 {
-		return
-	}
-	body := cos.MustMarshal(authn.TokenList{Tokens: tokenList})
-	for _, u := range clu.URLs {
-		if err = m.call(http.MethodDelete, u, apc.Tokens, body, tag); err == nil {
-			break
-		}
-		err = fmt.Errorf("failed to %s with %s: %v", tag, clu, err)
-	}
-	if err != nil {
-		nlog.Errorln(err)
-	}
-}
-
 // TODO: reuse api/client.go reqParams.do()
 func (m *mgr) call(method, proxyURL, path string, injson []byte, tag string) error {
 	var (
@@ -1,8 +1,8 @@
-// Package authn is authentication server for AIStore.
+// Package svc implements AIStore authentication server (AuthN).
 /*
  * Copyright (c) 2018-2024, NVIDIA CORPORATION. All rights reserved.
  */
-package main
+package svc
 
 import (
 	"net/http"
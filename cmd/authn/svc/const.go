@@ -1,8 +1,8 @@
-// Package authn is authentication server for AIStore.
+// Package svc implements AIStore authentication server (AuthN).
 /*
  * Copyright (c) 2018-2022, NVIDIA CORPORATION. All rights reserved.
  */
-package main
+package svc
 
 import "time"
 
@@ -17,6 +17,8 @@ const (
 	rolesCollection    = "role"
 	revokedCollection  = "revoked"
 	clustersCollection = "cluster"
+	issuedCollection   = "issued" // "user-ID/token" - tokens to revoke when the user (or one of its roles) changes
+	resetCollection    = "reset"  // "user-ID/sha256(token)" - one-time password reset tokens
 
 	adminUserID   = "admin"
 	adminUserPass = "admin"
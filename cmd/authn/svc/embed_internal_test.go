@@ -0,0 +1,184 @@
+//go:build debug
+
+// Package svc implements AIStore authentication server (AuthN).
+/*
+ * Copyright (c) 2024, NVIDIA CORPORATION. All rights reserved.
+ */
+package svc
+
+// NOTE go:build debug (above) =====================================
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/NVIDIA/aistore/api/apc"
+	"github.com/NVIDIA/aistore/api/authn"
+	"github.com/NVIDIA/aistore/cmd/authn/tok"
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/cmn/cos"
+	"github.com/NVIDIA/aistore/cmn/fname"
+	"github.com/NVIDIA/aistore/tools/tassert"
+	jsoniter "github.com/json-iterator/go"
+)
+
+// integration harness: AuthN (as embedded by AIS proxy) serving its HTTP API via httptest,
+// and a fake AIS cluster that validates the secret and records pushed (revoked) tokens
+type (
+	harness struct {
+		t   *testing.T
+		s   *Svc
+		srv *httptest.Server
+	}
+	fakeClu struct {
+		srv     *httptest.Server
+		secret  string
+		mu      sync.Mutex
+		revoked cos.StrSet
+	}
+)
+
+func newHarness(t *testing.T, dir, secret string) *harness {
+	s, err := Embed(dir, dir, secret, 0)
+	tassert.CheckFatal(t, err)
+	go s.mgr.sync.run()
+	return &harness{t: t, s: s, srv: httptest.NewServer(s.Handler())}
+}
+
+func (h *harness) close() {
+	h.srv.Close()
+	h.s.Stop()
+}
+
+func (h *harness) do(method, path, token string, in, out any) int {
+	var body []byte
+	if in != nil {
+		body = cos.MustMarshal(in)
+	}
+	req, err := http.NewRequest(method, h.srv.URL+path, bytes.NewReader(body))
+	tassert.CheckFatal(h.t, err)
+	if token != "" {
+		req.Header.Set(apc.HdrAuthorization, apc.AuthenticationTypeBearer+" "+token)
+	}
+	resp, err := h.srv.Client().Do(req)
+	tassert.CheckFatal(h.t, err)
+	defer resp.Body.Close()
+	if out != nil && resp.StatusCode == http.StatusOK {
+		tassert.CheckFatal(h.t, jsoniter.NewDecoder(resp.Body).Decode(out))
+	}
+	return resp.StatusCode
+}
+
+func (h *harness) login(uid, pass string) (string, int) {
+	res := &struct {
+		Token string `json:"token"`
+	}{}
+	status := h.do(http.MethodPost, apc.URLPathUsers.Join(uid), "", &authn.LoginMsg{Password: pass}, res)
+	return res.Token, status
+}
+
+func newFakeClu(secret string) *fakeClu {
+	c := &fakeClu{secret: secret, revoked: make(cos.StrSet)}
+	c.srv = httptest.NewServer(http.HandlerFunc(c.handle))
+	return c
+}
+
+func (c *fakeClu) handle(w http.ResponseWriter, r *http.Request) {
+	if r.URL.Path != apc.URLPathTokens.S {
+		cmn.WriteErrMsg(w, r, "invalid path "+r.URL.Path, http.StatusNotFound)
+		return
+	}
+	switch r.Method {
+	case http.MethodPost: // secret handshake (see ais/prxauth.go)
+		conf := &authn.ServerConf{}
+		cksum := cos.NewCksumHash(cos.ChecksumSHA256)
+		cksum.H.Write([]byte(c.secret))
+		cksum.Finalize()
+		if err := jsoniter.NewDecoder(r.Body).Decode(conf); err != nil || conf.Secret != cksum.Val() {
+			cmn.WriteErrMsg(w, r, "invalid secret")
+		}
+	case http.MethodDelete:
+		tl := &authn.TokenList{}
+		if err := jsoniter.NewDecoder(r.Body).Decode(tl); err != nil {
+			cmn.WriteErr(w, r, err)
+			return
+		}
+		c.mu.Lock()
+		c.revoked.Add(tl.Tokens...)
+		c.mu.Unlock()
+	}
+}
+
+func (c *fakeClu) isRevoked(token string) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.revoked.Contains(token)
+}
+
+func TestEmbedded(t *testing.T) {
+	const (
+		secret = "embedded-secret"
+		uid    = "user-embedded"
+		pass   = "pass-embedded"
+	)
+	var (
+		dir   = t.TempDir()
+		h     = newHarness(t, dir, secret)
+		clu   = newFakeClu(secret)
+		other = newFakeClu("other-secret")
+	)
+	defer clu.srv.Close()
+	defer other.srv.Close()
+
+	// admin: the token must be verifiable with the cluster's secret
+	adminToken, status := h.login(adminUserID, adminUserPass)
+	tassert.Fatalf(t, status == http.StatusOK, "admin login: status %d", status)
+	tk, err := tok.DecryptToken(adminToken, secret)
+	tassert.CheckFatal(t, err)
+	tassert.Errorf(t, tk.IsAdmin, "expected admin token, got %s", tk)
+
+	// register the cluster (secret handshake)
+	status = h.do(http.MethodPost, apc.URLPathClusters.S, adminToken,
+		&authn.CluACL{ID: "embedded-clu", URLs: []string{clu.srv.URL}}, nil)
+	tassert.Fatalf(t, status == http.StatusOK, "register cluster: status %d", status)
+	status = h.do(http.MethodPost, apc.URLPathClusters.S, adminToken,
+		&authn.CluACL{ID: "other-clu", URLs: []string{other.srv.URL}}, nil)
+	tassert.Errorf(t, status != http.StatusOK, "expected secret mismatch to fail registration")
+
+	// users
+	status = h.do(http.MethodPost, apc.URLPathUsers.S, adminToken, &authn.User{ID: uid, Password: pass}, nil)
+	tassert.Fatalf(t, status == http.StatusOK, "add user: status %d", status)
+	userToken, status := h.login(uid, pass)
+	tassert.Fatalf(t, status == http.StatusOK, "user login: status %d", status)
+	_, status = h.login(uid, "wrong")
+	tassert.Errorf(t, status == http.StatusUnauthorized, "expected 401 upon invalid password, got %d", status)
+	status = h.do(http.MethodPost, apc.URLPathUsers.S, userToken, &authn.User{ID: "other", Password: pass}, nil)
+	tassert.Errorf(t, status == http.StatusUnauthorized, "expected 401 when not admin, got %d", status)
+
+	// migration path: the same directory, as standalone AuthN would load it
+	h.close()
+	tassert.CheckFatal(t, LoadConfig(dir))
+	tassert.Errorf(t, Conf.Secret() == secret, "expected persisted secret, got %q", Conf.Secret())
+	_, err = os.Stat(filepath.Join(dir, fname.AuthNDB))
+	tassert.CheckFatal(t, err)
+
+	h = newHarness(t, dir, secret)
+	defer h.close()
+	_, status = h.login(uid, pass)
+	tassert.Fatalf(t, status == http.StatusOK, "user login after restart: status %d", status)
+
+	// deleting the user revokes its tokens - on the registered cluster as well
+	status = h.do(http.MethodDelete, apc.URLPathUsers.Join(uid), adminToken, nil, nil)
+	tassert.Fatalf(t, status == http.StatusOK, "delete user: status %d", status)
+	deadline := time.Now().Add(10 * time.Second)
+	for !clu.isRevoked(userToken) && time.Now().Before(deadline) {
+		time.Sleep(100 * time.Millisecond)
+	}
+	tassert.Errorf(t, clu.isRevoked(userToken), "expected user token to be revoked on the cluster")
+}
@@ -1,13 +1,14 @@
-// Package authn is authentication server for AIStore.
+// Package svc implements AIStore authentication server (AuthN).
 /*
  * Copyright (c) 2018-2023, NVIDIA CORPORATION. All rights reserved.
  */
-package main
+package svc
 
 import (
 	"fmt"
 	"net/http"
 	"os"
+	"strings"
 	"time"
 
 	"github.com/NVIDIA/aistore/api/apc"
@@ -31,7 +32,8 @@ type hserv struct {
 func newServer(mgr *mgr) *hserv {
 	srv := &hserv{mgr: mgr}
 	srv.mux = http.NewServeMux()
-
+	srv.registerPublicHandlers()
+	srv.init()
 	return srv
 }
 
@@ -44,26 +46,14 @@ func parseURL(w http.ResponseWriter, r *http.Request, itemsAfter int, items []st
 	return items, err
 }
 
-// Run public server to manage users and generate tokens
-func (h *hserv) Run() error {
-	var (
-		portStr    string
-		err        error
-		useHTTPS   bool
-		serverCert string
-		serverKey  string
-	)
-
+func (h *hserv) init() {
 	// Retrieve and set the port
-	portStr = os.Getenv(env.AuthN.Port)
+	portStr := os.Getenv(env.AuthN.Port)
 	if portStr == "" {
 		portStr = fmt.Sprintf(":%d", Conf.Net.HTTP.Port)
 	} else {
 		portStr = ":" + portStr
 	}
-	nlog.Infof("Listening on %s", portStr)
-
-	h.registerPublicHandlers()
 	h.s = &http.Server{
 		Addr:              portStr,
 		Handler:           h.mux,
@@ -72,6 +62,18 @@ func (h *hserv) Run() error {
 	if timeout, isSet := cmn.ParseReadHeaderTimeout(); isSet { // optional env var
 		h.s.ReadHeaderTimeout = timeout
 	}
+}
+
+// Run public server to manage users and generate tokens
+func (h *hserv) Run() error {
+	var (
+		portStr    = h.s.Addr
+		err        error
+		useHTTPS   bool
+		serverCert string
+		serverKey  string
+	)
+	nlog.Infof("Listening on %s", portStr)
 
 	// Retrieve and set HTTPS configuration with environment variables taking precedence
 	useHTTPS, err = cos.IsParseEnvBoolOrDefault(env.AuthN.UseHTTPS, Conf.Net.HTTP.UseHTTPS)
@@ -193,22 +195,80 @@ func (h *hserv) httpUserPost(w http.ResponseWriter, r *http.Request) {
 	if err != nil {
 		return
 	}
-	if len(apiItems) == 0 {
+	switch {
+	case len(apiItems) == 0:
 		h.userAdd(w, r)
-	} else {
+	case len(apiItems) == 2 && apiItems[1] == authn.PathPassword:
+		h.userPassword(w, r, apiItems[0])
+	default:
 		h.userLogin(w, r)
 	}
 }
 
-// Updates user credentials
+// Changes user's own password given either the current one or one-time reset token
+// (no admin permissions required - see authn.PasswordMsg)
+func (h *hserv) userPassword(w http.ResponseWriter, r *http.Request, userID string) {
+	msg := &authn.PasswordMsg{}
+	if err := cmn.ReadJSON(w, r, msg); err != nil {
+		return
+	}
+	if err := msg.Validate(); err != nil {
+		cmn.WriteErr(w, r, err)
+		return
+	}
+	if err := h.mgr.changePassword(userID, msg); err != nil {
+		status := http.StatusBadRequest
+		if err == errInvalidCredentials || err == errInvalidReset {
+			status = http.StatusUnauthorized
+		}
+		cmn.WriteErr(w, r, err, status)
+		return
+	}
+	if Conf.Verbose() {
+		nlog.Infof("User %q changed password", userID)
+	}
+}
+
+// (admin) issues one-time password reset token
+func (h *hserv) userReset(w http.ResponseWriter, r *http.Request, userID string) {
+	res, err := h.mgr.issueResetToken(userID)
+	if err != nil {
+		if cos.IsErrNotFound(err) {
+			cmn.WriteErr(w, r, err, http.StatusNotFound)
+		} else {
+			cmn.WriteErr(w, r, err)
+		}
+		return
+	}
+	nlog.Infof("Issued password reset token for user %q (expires %s)", userID, res.Expires.Format(time.RFC3339))
+	writeJSON(w, res, "reset password")
+}
+
+// Updates user credentials or, when user ID is omitted, provisions
+// multiple users at once (see authn.UsersMsg)
 func (h *hserv) httpUserPut(w http.ResponseWriter, r *http.Request) {
-	apiItems, err := parseURL(w, r, 1, apc.URLPathUsers.L)
+	apiItems, err := parseURL(w, r, 0, apc.URLPathUsers.L)
 	if err != nil {
 		return
 	}
 	if err = validateAdminPerms(w, r); err != nil {
 		return
 	}
+	switch len(apiItems) {
+	case 0:
+		h.usersBulk(w, r)
+		return
+	case 1:
+	case 2:
+		if apiItems[1] == authn.PathReset {
+			h.userReset(w, r, apiItems[0])
+			return
+		}
+		fallthrough
+	default:
+		cmn.WriteErrMsg(w, r, "invalid request")
+		return
+	}
 	var (
 		userID    = apiItems[0]
 		updateReq = &authn.User{}
@@ -227,6 +287,36 @@ func (h *hserv) httpUserPut(w http.ResponseWriter, r *http.Request) {
 	}
 }
 
+// Creates and/or updates multiple users from JSON or CSV payload
+func (h *hserv) usersBulk(w http.ResponseWriter, r *http.Request) {
+	var (
+		msg *authn.UsersMsg
+		err error
+	)
+	if strings.HasPrefix(r.Header.Get(cos.HdrContentType), cos.ContentCSV) {
+		msg, err = authn.ParseUsersCSV(r.Body)
+		if err == nil {
+			msg.Update = cos.IsParseBool(r.URL.Query().Get(apc.QparamUpdateUsers))
+		}
+	} else {
+		msg = &authn.UsersMsg{}
+		err = jsoniter.NewDecoder(r.Body).Decode(msg)
+	}
+	if err != nil {
+		cmn.WriteErr(w, r, err)
+		return
+	}
+	if len(msg.Users) == 0 {
+		cmn.WriteErrMsg(w, r, "no users to provision")
+		return
+	}
+	res := h.mgr.addUsers(msg)
+	if Conf.Verbose() {
+		nlog.Infof("Provisioned users: created %d, updated %d, failed %d", len(res.Created), len(res.Updated), len(res.Errors))
+	}
+	writeJSON(w, res, "provision users")
+}
+
 // Adds h new user to user list
 func (h *hserv) userAdd(w http.ResponseWriter, r *http.Request) {
 	if err := validateAdminPerms(w, r); err != nil {
@@ -263,7 +353,7 @@ func (h *hserv) httpUserGet(w http.ResponseWriter, r *http.Request) {
 			return
 		}
 		for _, uInfo := range users {
-			uInfo.Password = ""
+			uInfo.Sanitize()
 		}
 		writeJSON(w, users, "list users")
 		return
@@ -274,7 +364,7 @@ func (h *hserv) httpUserGet(w http.ResponseWriter, r *http.Request) {
 		cmn.WriteErr(w, r, err)
 		return
 	}
-	uInfo.Password = ""
+	uInfo.Sanitize()
 	writeJSON(w, uInfo, "get user")
 }
 
@@ -292,8 +382,13 @@ func validateAdminPerms(w http.ResponseWriter, r *http.Request) error {
 		cmn.WriteErr(w, r, err, http.StatusUnauthorized)
 		return err
 	}
-	if tk.Expires.Before(time.Now()) {
-		err := fmt.Errorf("not authorized: %s", tk)
+	if err := tk.CheckWindow(time.Now()); err != nil {
+		err = fmt.Errorf("not authorized: %v", err)
+		cmn.WriteErr(w, r, err, http.StatusUnauthorized)
+		return err
+	}
+	if err := tk.CheckSource(r.RemoteAddr); err != nil {
+		err = fmt.Errorf("not authorized: %v", err)
 		cmn.WriteErr(w, r, err, http.StatusUnauthorized)
 		return err
 	}
@@ -321,6 +416,10 @@ func (h *hserv) userLogin(w http.ResponseWriter, r *http.Request) {
 		cmn.WriteErrMsg(w, r, "empty password", http.StatusUnauthorized)
 		return
 	}
+	if err := msg.Validate(); err != nil {
+		cmn.WriteErr(w, r, err)
+		return
+	}
 
 	var (
 		token  string
@@ -328,7 +427,11 @@ func (h *hserv) userLogin(w http.ResponseWriter, r *http.Request) {
 	)
 	if token, err = h.mgr.issueToken(userID, msg.Password, msg); err != nil {
 		nlog.Errorf("failed to generate token for user %q: %v\n", userID, err)
-		cmn.WriteErr(w, r, err, http.StatusUnauthorized)
+		status := http.StatusUnauthorized
+		if err == errPasswordChange {
+			status = http.StatusForbidden
+		}
+		cmn.WriteErr(w, r, err, status)
 		return
 	}
 
@@ -411,6 +514,10 @@ func (h *hserv) httpSrvGet(w http.ResponseWriter, r *http.Request) {
 	if err != nil {
 		return
 	}
+	if r.URL.Query().Get(apc.QparamWhat) == apc.WhatACLSync {
+		h.getACLSync(w, r)
+		return
+	}
 	var cluList *authn.RegisteredClusters
 	if len(apiItems) != 0 {
 		cid := apiItems[0]
@@ -437,6 +544,19 @@ func (h *hserv) httpSrvGet(w http.ResponseWriter, r *http.Request) {
 	writeJSON(w, cluList, "get cluster")
 }
 
+// Returns the state of propagating user and role changes to each registered cluster
+func (h *hserv) getACLSync(w http.ResponseWriter, r *http.Request) {
+	if err := validateAdminPerms(w, r); err != nil {
+		return
+	}
+	clus, err := h.mgr.clus()
+	if err != nil {
+		cmn.WriteErr(w, r, err, http.StatusInternalServerError)
+		return
+	}
+	writeJSON(w, h.mgr.sync.status(clus), "get ACL sync")
+}
+
 func (h *hserv) roleHandler(w http.ResponseWriter, r *http.Request) {
 	switch r.Method {
 	case http.MethodPost:
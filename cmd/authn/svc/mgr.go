@@ -1,8 +1,8 @@
-// Package authn is authentication server for AIStore.
+// Package svc implements AIStore authentication server (AuthN).
 /*
  * Copyright (c) 2018-2024, NVIDIA CORPORATION. All rights reserved.
  */
-package main
+package svc
 
 import (
 	"encoding/hex"
@@ -10,6 +10,7 @@ import (
 	"fmt"
 	"net/http"
 	"os"
+	"strings"
 	"time"
 
 	"github.com/NVIDIA/aistore/api/apc"
@@ -29,6 +30,7 @@ type mgr struct {
 	clientH   *http.Client
 	clientTLS *http.Client
 	db        kvdb.Driver
+	sync      *aclSync
 }
 
 var (
@@ -51,6 +53,7 @@ func newMgr(driver kvdb.Driver) (m *mgr, err error) {
 		db: driver,
 	}
 	m.clientH, m.clientTLS = cmn.NewDefaultClients(time.Duration(Conf.Timeout.Default))
+	m.sync = newACLSync(m)
 	err = initializeDB(driver)
 	return
 }
@@ -72,7 +75,11 @@ func (m *mgr) addUser(info *authn.User) error {
 	if err == nil {
 		return fmt.Errorf("user %q already registered", info.ID)
 	}
-	info.Password = encryptPassword(info.Password)
+	password := info.Password
+	info.Password, info.PassHistory = "", nil
+	if err := setPassword(info, password); err != nil {
+		return err
+	}
 	return m.db.Set(usersCollection, info.ID, info)
 }
 
@@ -81,7 +88,11 @@ func (m *mgr) delUser(userID string) error {
 	if userID == adminUserID {
 		return fmt.Errorf("cannot remove built-in %q account", adminUserID)
 	}
-	return m.db.Delete(usersCollection, userID)
+	if err := m.db.Delete(usersCollection, userID); err != nil {
+		return err
+	}
+	m.aclChanged(userID)
+	return nil
 }
 
 // Updates an existing user. The function invalidates user tokens after
@@ -97,12 +108,21 @@ func (m *mgr) updateUser(userID string, updateReq *authn.User) error {
 	}
 
 	if updateReq.Password != "" {
-		uInfo.Password = encryptPassword(updateReq.Password)
+		if err := setPassword(uInfo, updateReq.Password); err != nil {
+			return err
+		}
+	}
+	if updateReq.MustChange {
+		uInfo.MustChange = true
 	}
 	if len(updateReq.Roles) != 0 {
 		uInfo.Roles = updateReq.Roles
 	}
-	return m.db.Set(usersCollection, userID, uInfo)
+	if err := m.db.Set(usersCollection, userID, uInfo); err != nil {
+		return err
+	}
+	m.aclChanged(userID)
+	return nil
 }
 
 func (m *mgr) lookupUser(userID string) (*authn.User, error) {
@@ -129,6 +149,87 @@ func (m *mgr) userList() (map[string]*authn.User, error) {
 	return users, nil
 }
 
+// Creates (and, optionally, updates) multiple users at once, instantiating
+// role templates with the users' parameters. Errors are per user - a failure
+// to provision one user does not affect the others.
+func (m *mgr) addUsers(msg *authn.UsersMsg) *authn.UsersResult {
+	var (
+		res   = &authn.UsersResult{}
+		roles = make(map[string]*authn.Role, 4) // (role lookup cache)
+		seen  = make(map[string]struct{}, len(msg.Users))
+	)
+	defer func() {
+		if len(res.Updated) > 0 {
+			m.aclChanged(res.Updated...)
+		}
+	}()
+	for _, spec := range msg.Users {
+		if _, ok := seen[spec.ID]; ok {
+			res.AddErr(spec.ID, errors.New("duplicate user ID"))
+			continue
+		}
+		seen[spec.ID] = struct{}{}
+		created, err := m._addUser(spec, msg.Update, roles)
+		switch {
+		case err != nil:
+			res.AddErr(spec.ID, err)
+		case created:
+			res.Created = append(res.Created, spec.ID)
+		default:
+			res.Updated = append(res.Updated, spec.ID)
+		}
+	}
+	return res
+}
+
+func (m *mgr) _addUser(spec *authn.UserSpec, update bool, roles map[string]*authn.Role) (created bool, _ error) {
+	if spec.ID == "" {
+		return false, errInvalidCredentials
+	}
+	if spec.ID == adminUserID {
+		return false, fmt.Errorf("cannot modify built-in %q account", adminUserID)
+	}
+	uInfo := &authn.User{}
+	exists := m.db.Get(usersCollection, spec.ID, uInfo) == nil
+	switch {
+	case exists && !update:
+		return false, fmt.Errorf("user %q already registered", spec.ID)
+	case !exists && spec.Password == "":
+		return false, errInvalidCredentials
+	}
+
+	userRoles := make([]*authn.Role, 0, len(spec.Roles))
+	for _, name := range spec.Roles {
+		role, ok := roles[name]
+		if !ok {
+			var err error
+			if role, err = m.lookupRole(name); err != nil {
+				return false, cos.NewErrNotFound(m, "role "+name)
+			}
+			roles[name] = role
+		}
+		if role.IsAdmin {
+			return false, fmt.Errorf("cannot assign %q role", role.Name)
+		}
+		r, err := role.Instantiate(spec.Params)
+		if err != nil {
+			return false, err
+		}
+		userRoles = append(userRoles, r)
+	}
+
+	uInfo.ID = spec.ID
+	if spec.Password != "" {
+		if err := setPassword(uInfo, spec.Password); err != nil {
+			return false, err
+		}
+	}
+	if len(userRoles) != 0 || !exists {
+		uInfo.Roles = userRoles
+	}
+	return !exists, m.db.Set(usersCollection, spec.ID, uInfo)
+}
+
 //
 // roles ============================================================
 //
@@ -154,7 +255,11 @@ func (m *mgr) delRole(role string) error {
 	if role == authn.AdminRole {
 		return fmt.Errorf("cannot remove built-in %q role", authn.AdminRole)
 	}
-	return m.db.Delete(rolesCollection, role)
+	if err := m.db.Delete(rolesCollection, role); err != nil {
+		return err
+	}
+	m.aclChanged(m.applyRole(role, nil)...)
+	return nil
 }
 
 // Updates an existing role
@@ -174,7 +279,53 @@ func (m *mgr) updateRole(role string, updateReq *authn.Role) error {
 	rInfo.ClusterACLs = mergeClusterACLs(rInfo.ClusterACLs, updateReq.ClusterACLs, "")
 	rInfo.BucketACLs = mergeBckACLs(rInfo.BucketACLs, updateReq.BucketACLs, "")
 
-	return m.db.Set(rolesCollection, role, rInfo)
+	if err := m.db.Set(rolesCollection, role, rInfo); err != nil {
+		return err
+	}
+	m.aclChanged(m.applyRole(role, rInfo)...)
+	return nil
+}
+
+// Users store copies of their roles: replace (or, when deleted, remove) the
+// role in all users that have it, and return the IDs of the updated users.
+// Role templates are skipped - users hold instantiated roles that can only be
+// updated by (re)provisioning (see addUsers).
+func (m *mgr) applyRole(name string, updated *authn.Role) []string {
+	if updated != nil && updated.IsTemplate() {
+		return nil
+	}
+	users, err := m.userList()
+	if err != nil {
+		nlog.Errorln(err)
+		return nil
+	}
+	uids := make([]string, 0, 4)
+	for uid, uInfo := range users {
+		var (
+			roles = make([]*authn.Role, 0, len(uInfo.Roles))
+			found bool
+		)
+		for _, r := range uInfo.Roles {
+			if r.Name != name {
+				roles = append(roles, r)
+				continue
+			}
+			found = true
+			if updated != nil {
+				roles = append(roles, updated)
+			}
+		}
+		if !found {
+			continue
+		}
+		uInfo.Roles = roles
+		if err := m.db.Set(usersCollection, uid, uInfo); err != nil {
+			nlog.Errorf("failed to update user %q with role %q: %v", uid, name, err)
+			continue
+		}
+		uids = append(uids, uid)
+	}
+	return uids
 }
 
 func (m *mgr) lookupRole(roleID string) (*authn.Role, error) {
@@ -301,7 +452,7 @@ func (m *mgr) addCluster(clu *authn.CluACL) error {
 	}
 	m.createRolesForCluster(clu)
 
-	go m.syncTokenList(clu)
+	m.sync.kick()
 	return nil
 }
 
@@ -367,6 +518,9 @@ func (m *mgr) issueToken(uid, pwd string, msg *authn.LoginMsg) (token string, er
 	if !isSamePassword(pwd, uInfo.Password) {
 		return "", errInvalidCredentials
 	}
+	if err := m.checkRotation(uInfo); err != nil {
+		return "", err
+	}
 
 	// update ACLs with roles' ones
 	for _, role := range uInfo.Roles {
@@ -392,12 +546,32 @@ func (m *mgr) _token(msg *authn.LoginMsg, uInfo *authn.User, cluACLs []*authn.Cl
 	// when it expires and credentials to log in AWS, GCP etc.
 	// If a user is a super user, it is enough to pass only isAdmin marker
 	expires := time.Now().Add(expDelta)
-	uid := uInfo.ID
+	if msg.ExpiresAt != nil {
+		expires = *msg.ExpiresAt
+	}
+	var rs *tok.Restrictions
+	if msg.NotBefore != nil || len(msg.CIDRs) > 0 {
+		rs = &tok.Restrictions{CIDRs: msg.CIDRs}
+		if msg.NotBefore != nil {
+			rs.NotBefore = *msg.NotBefore
+		}
+	}
+	var (
+		uid   = uInfo.ID
+		roles = make([]string, 0, len(uInfo.Roles))
+	)
+	for _, role := range uInfo.Roles {
+		roles = append(roles, role.Name)
+	}
 	if uInfo.IsAdmin() {
-		token, err = tok.AdminJWT(expires, uid, Conf.Secret())
+		token, err = tok.AdminJWT(expires, uid, roles, rs, Conf.Secret())
 	} else {
 		m.fixClusterIDs(cluACLs)
-		token, err = tok.JWT(expires, uid, bckACLs, cluACLs, Conf.Secret())
+		token, err = tok.JWT(expires, uid, roles, bckACLs, cluACLs, rs, Conf.Secret())
+	}
+	if err == nil {
+		m.pruneIssued(uid)
+		err = m.db.Set(issuedCollection, uid+"/"+token, "!")
 	}
 	return token, err
 }
@@ -435,6 +609,56 @@ func (m *mgr) revokeToken(token string) error {
 	return nil
 }
 
+// User (or role) changed: revoke the users' outstanding tokens and
+// propagate the change to all registered clusters (see aclSync).
+func (m *mgr) aclChanged(uids ...string) {
+	for _, uid := range uids {
+		m.revokeIssued(uid)
+	}
+	m.sync.changed()
+}
+
+func (m *mgr) revokeIssued(uid string) {
+	issued, err := m.db.GetAll(issuedCollection, uid+"/")
+	if err != nil {
+		nlog.Errorln(err)
+		return
+	}
+	for key := range issued {
+		token := strings.TrimPrefix(key, uid+"/")
+		if strings.IndexByte(token, '/') >= 0 {
+			continue // (another user's, with uid+"/" prefix)
+		}
+		if err := m.db.Set(revokedCollection, token, "!"); err != nil {
+			nlog.Errorf("failed to revoke %q token: %v", uid, err)
+			continue
+		}
+		m.db.Delete(issuedCollection, key)
+	}
+}
+
+// remove expired (and invalid) tokens
+func (m *mgr) pruneIssued(uid string) {
+	issued, err := m.db.GetAll(issuedCollection, uid+"/")
+	if err != nil {
+		return
+	}
+	var (
+		now    = time.Now()
+		secret = Conf.Secret()
+	)
+	for key := range issued {
+		token := strings.TrimPrefix(key, uid+"/")
+		if strings.IndexByte(token, '/') >= 0 {
+			continue
+		}
+		tk, err := tok.DecryptToken(token, secret)
+		if err != nil || tk.Expires.Before(now) {
+			m.db.Delete(issuedCollection, key)
+		}
+	}
+}
+
 // Create a list of non-expired and valid revoked tokens.
 // Obsolete and invalid tokens are removed from the database.
 func (m *mgr) generateRevokedTokenList() ([]string, error) {
@@ -502,13 +726,20 @@ func initializeDB(driver kvdb.Driver) error {
 
 	// environment override
 	userName := cos.Right(adminUserID, os.Getenv(env.AuthN.AdminUsername))
-	password := cos.Right(adminUserPass, os.Getenv(env.AuthN.AdminPassword))
+	envPass := os.Getenv(env.AuthN.AdminPassword)
 
-	// Create the admin user
+	// Create the admin user - subject to password policies (see passwd.go)
 	su := &authn.User{
-		ID:       userName,
-		Password: encryptPassword(password),
-		Roles:    []*authn.Role{role},
+		ID:    userName,
+		Roles: []*authn.Role{role},
+	}
+	if envPass != "" {
+		if err := setPassword(su, envPass); err != nil {
+			return fmt.Errorf("invalid %s: %w", env.AuthN.AdminPassword, err)
+		}
+	} else if err := setPassword(su, adminUserPass); err != nil {
+		// the well-known default that does not satisfy the policy: must be changed upon first login
+		su.Password, su.PassChanged, su.MustChange = encryptPassword(adminUserPass), time.Now(), true
 	}
 
 	return driver.Set(usersCollection, userName, su)
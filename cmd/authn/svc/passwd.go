@@ -0,0 +1,177 @@
+// Package svc implements AIStore authentication server (AuthN).
+/*
+ * Copyright (c) 2024, NVIDIA CORPORATION. All rights reserved.
+ */
+package svc
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/NVIDIA/aistore/api/authn"
+	"github.com/NVIDIA/aistore/cmn/cos"
+	"github.com/NVIDIA/aistore/cmn/nlog"
+)
+
+// Password policies (see authn.PasswordConf):
+// - complexity (minimum length and number of character classes) is enforced whenever
+//   the password is set: new user, admin update, and self-service change or reset;
+// - reuse history: the new password must differ from the `history` most recent ones;
+// - rotation: login fails with authn.ErrPasswordChangeRequired (http.StatusForbidden)
+//   when the password is older than `max_age` or the user is flagged `must_change`;
+//   the user then changes the password given the current one (no token required);
+// - reset: admin issues one-time reset token (valid for `reset_token_ttl`) that the user
+//   presents in lieu of the current password.
+
+const resetTokenLen = 32
+
+type resetRec struct {
+	Expires time.Time `json:"expires"`
+}
+
+var (
+	errPasswordChange = errors.New(authn.ErrPasswordChangeRequired)
+	errInvalidReset   = errors.New("invalid or expired reset token")
+	errPasswordReused = errors.New("password was used recently and cannot be reused")
+)
+
+// (policy-enforcing) set user's password
+func setPassword(uInfo *authn.User, password string) error {
+	pc := Conf.PassConf()
+	if err := pc.Check(password); err != nil {
+		return err
+	}
+	if uInfo.Password != "" && pc.History > 0 {
+		if isSamePassword(password, uInfo.Password) {
+			return errPasswordReused
+		}
+		for i := 0; i < len(uInfo.PassHistory) && i < pc.History-1; i++ {
+			if isSamePassword(password, uInfo.PassHistory[i]) {
+				return errPasswordReused
+			}
+		}
+	}
+	if uInfo.Password != "" && pc.History > 1 {
+		hist := make([]string, 0, pc.History-1)
+		hist = append(hist, uInfo.Password)
+		for i := 0; i < len(uInfo.PassHistory) && len(hist) < pc.History-1; i++ {
+			hist = append(hist, uInfo.PassHistory[i])
+		}
+		uInfo.PassHistory = hist
+	} else {
+		uInfo.PassHistory = nil
+	}
+	uInfo.Password = encryptPassword(password)
+	uInfo.PassChanged = time.Now()
+	uInfo.MustChange = false
+	return nil
+}
+
+// (upon successful login)
+func (m *mgr) checkRotation(uInfo *authn.User) error {
+	if uInfo.MustChange {
+		return errPasswordChange
+	}
+	if uInfo.PassChanged.IsZero() {
+		// (created prior to password policies) - start the clock
+		uInfo.PassChanged = time.Now()
+		if err := m.db.Set(usersCollection, uInfo.ID, uInfo); err != nil {
+			nlog.Errorln(err)
+		}
+		return nil
+	}
+	if maxAge := Conf.PassConf().MaxAge; maxAge > 0 && time.Since(uInfo.PassChanged) > maxAge.D() {
+		return errPasswordChange
+	}
+	return nil
+}
+
+// self-service password change given either the current password or reset token;
+// unknown user and wrong password (or token) result in the same error
+func (m *mgr) changePassword(uid string, msg *authn.PasswordMsg) error {
+	uInfo := &authn.User{}
+	if msg.ResetToken != "" {
+		if err := m.consumeResetToken(uid, msg.ResetToken); err != nil {
+			return err
+		}
+		if err := m.db.Get(usersCollection, uid, uInfo); err != nil {
+			return errInvalidReset
+		}
+	} else {
+		if err := m.db.Get(usersCollection, uid, uInfo); err != nil {
+			return errInvalidCredentials
+		}
+		if !isSamePassword(msg.Password, uInfo.Password) {
+			return errInvalidCredentials
+		}
+	}
+	if err := setPassword(uInfo, msg.NewPassword); err != nil {
+		return err
+	}
+	if err := m.db.Set(usersCollection, uid, uInfo); err != nil {
+		return err
+	}
+	m.delResetTokens(uid)
+	m.revokeIssued(uid)
+	return nil
+}
+
+// (admin) issue one-time reset token
+func (m *mgr) issueResetToken(uid string) (*authn.ResetTokenMsg, error) {
+	if _, err := m.db.GetString(usersCollection, uid); err != nil {
+		return nil, cos.NewErrNotFound(m, "user "+uid)
+	}
+	m.pruneResetTokens(uid)
+	var (
+		pc    = Conf.PassConf()
+		token = cos.CryptoRandS(resetTokenLen)
+		rec   = &resetRec{Expires: time.Now().Add(pc.ResetTTLD())}
+	)
+	if err := m.db.Set(resetCollection, resetKey(uid, token), rec); err != nil {
+		return nil, err
+	}
+	return &authn.ResetTokenMsg{Token: token, Expires: rec.Expires}, nil
+}
+
+func (m *mgr) consumeResetToken(uid, token string) error {
+	var (
+		rec = &resetRec{}
+		key = resetKey(uid, token)
+	)
+	if err := m.db.Get(resetCollection, key, rec); err != nil {
+		return errInvalidReset
+	}
+	m.db.Delete(resetCollection, key) // one-time
+	if rec.Expires.Before(time.Now()) {
+		return errInvalidReset
+	}
+	return nil
+}
+
+func (m *mgr) pruneResetTokens(uid string) { m._resetTokens(uid, false) }
+func (m *mgr) delResetTokens(uid string)   { m._resetTokens(uid, true) }
+
+func (m *mgr) _resetTokens(uid string, all bool) {
+	recs, err := m.db.GetAll(resetCollection, uid+"/")
+	if err != nil {
+		return
+	}
+	now := time.Now()
+	for key, s := range recs {
+		if strings.IndexByte(strings.TrimPrefix(key, uid+"/"), '/') >= 0 {
+			continue // (another user's, with uid+"/" prefix)
+		}
+		rec := &resetRec{}
+		if all || cos.JSON.UnmarshalFromString(s, rec) != nil || rec.Expires.Before(now) {
+			m.db.Delete(resetCollection, key)
+		}
+	}
+}
+
+func resetKey(uid, token string) string {
+	h := sha256.Sum256([]byte(token))
+	return uid + "/" + hex.EncodeToString(h[:])
+}
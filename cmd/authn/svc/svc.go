@@ -0,0 +1,116 @@
+// Package svc implements AIStore authentication server (AuthN).
+/*
+ * Copyright (c) 2024, NVIDIA CORPORATION. All rights reserved.
+ */
+package svc
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/NVIDIA/aistore/cmn/cos"
+	"github.com/NVIDIA/aistore/cmn/fname"
+	"github.com/NVIDIA/aistore/cmn/jsp"
+	"github.com/NVIDIA/aistore/cmn/kvdb"
+	"github.com/NVIDIA/aistore/cmn/nlog"
+)
+
+// AuthN runs either:
+// - standalone (see cmd/authn), or
+// - embedded, in-process, by an AIS proxy (see Embed) - for single-node development
+//   and test deployments that do not need (or want) to deploy a separate server.
+// Either way, it is the same HTTP API on a separate port, and the same configuration
+// (fname.AuthNConfig) and database (fname.AuthNDB), both in the same directory.
+// Migrating from embedded to standalone AuthN therefore amounts to running
+// `authn -config <dir>` with the embedded one's directory (or a copy of it).
+
+const (
+	dfltPort    = 52001
+	dfltExpire  = 24 * time.Hour
+	dfltTimeout = 30 * time.Second
+
+	stopTimeout = 10 * time.Second
+)
+
+type Svc struct {
+	mgr *mgr
+	srv *hserv
+}
+
+var configPath string
+
+// load configuration from the given directory
+func LoadConfig(configDir string) error {
+	configPath = filepath.Join(configDir, fname.AuthNConfig)
+	if _, err := jsp.LoadMeta(configPath, Conf); err != nil {
+		return err
+	}
+	Conf.Init()
+	return nil
+}
+
+// open (or create) the database in the given directory; must be called after LoadConfig
+func New(configDir string) (*Svc, error) {
+	driver, err := kvdb.NewBuntDB(filepath.Join(configDir, fname.AuthNDB))
+	if err != nil {
+		return nil, fmt.Errorf("failed to init local database: %v", err)
+	}
+	mgr, err := newMgr(driver)
+	if err != nil {
+		cos.Close(driver)
+		return nil, fmt.Errorf("failed to init manager: %v", err)
+	}
+	return &Svc{mgr: mgr, srv: newServer(mgr)}, nil
+}
+
+// Embed loads (or, upon first run, creates) configuration in the given directory,
+// and returns AuthN to run in-process.
+// The cluster's secret always takes precedence - the proxy must be able to validate
+// the tokens; non-zero port overrides the configured one.
+func Embed(configDir, logDir, secret string, port int) (*Svc, error) {
+	if err := cos.CreateDir(configDir); err != nil {
+		return nil, err
+	}
+	if err := LoadConfig(configDir); err != nil {
+		if !os.IsNotExist(err) {
+			return nil, err
+		}
+		Conf.Log.Dir, Conf.Log.Level = logDir, "3"
+		Conf.Net.HTTP.Port = dfltPort
+		Conf.Server.Expire = cos.Duration(dfltExpire)
+		Conf.Timeout.Default = cos.Duration(dfltTimeout)
+		Conf.Init()
+	}
+	Conf.SetSecret(&secret)
+	if port != 0 {
+		Conf.Net.HTTP.Port = port
+	}
+	if err := jsp.SaveMeta(configPath, Conf, nil); err != nil {
+		return nil, err
+	}
+	return New(configDir)
+}
+
+// AuthN HTTP API (e.g., to serve via httptest)
+func (s *Svc) Handler() http.Handler { return s.srv.mux }
+
+// start synchronizing ACLs with registered clusters and serve AuthN API on the
+// configured port; block until stopped
+func (s *Svc) Run() error {
+	go s.mgr.sync.run()
+	return s.srv.Run()
+}
+
+func (s *Svc) Stop() {
+	s.mgr.sync.stopCh.Close()
+	ctx, cancel := context.WithTimeout(context.Background(), stopTimeout)
+	if err := s.srv.s.Shutdown(ctx); err != nil {
+		nlog.Warningln("failed to shutdown", svcName, "server:", err)
+	}
+	cancel()
+	cos.Close(s.mgr.db)
+}
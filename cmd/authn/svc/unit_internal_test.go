@@ -1,19 +1,21 @@
 //go:build debug
 
-// Package authn
+// Package svc implements AIStore authentication server (AuthN).
 /*
  * Copyright (c) 2018-2024, NVIDIA CORPORATION. All rights reserved.
  */
-package main
+package svc
 
 // NOTE go:build debug (above) =====================================
 
 import (
+	"strings"
 	"testing"
 	"time"
 
 	"github.com/NVIDIA/aistore/api/apc"
 	"github.com/NVIDIA/aistore/api/authn"
+	"github.com/NVIDIA/aistore/api/env"
 	"github.com/NVIDIA/aistore/cmd/authn/tok"
 	"github.com/NVIDIA/aistore/cmn"
 	"github.com/NVIDIA/aistore/cmn/cos"
@@ -204,6 +206,60 @@ func TestToken(t *testing.T) {
 	}
 }
 
+func TestTokenRestrictions(t *testing.T) {
+	var (
+		secret = Conf.Secret()
+		now    = time.Now()
+	)
+	driver := mock.NewDBDriver()
+	mgr, err := newMgr(driver)
+	tassert.CheckFatal(t, err)
+	createUsers(mgr, t)
+	defer deleteUsers(mgr, false, t)
+
+	loginMsg := &authn.LoginMsg{
+		NotBefore: apc.Ptr(now.Add(time.Hour)),
+		ExpiresAt: apc.Ptr(now.Add(2 * time.Hour)),
+		CIDRs:     []string{"10.1.0.0/16", "fd00::/8"},
+	}
+	tassert.CheckFatal(t, loginMsg.Validate())
+	token, err := mgr.issueToken(users[0], passs[0], loginMsg)
+	tassert.CheckFatal(t, err)
+	tk, err := tok.DecryptToken(token, secret)
+	tassert.CheckFatal(t, err)
+
+	// time window
+	err = tk.CheckWindow(now)
+	tassert.Errorf(t, err != nil && strings.Contains(err.Error(), tok.ErrTokenNotYet.Error()), "expecting %v, got %v", tok.ErrTokenNotYet, err)
+	tassert.CheckError(t, tk.CheckWindow(now.Add(90*time.Minute)))
+	err = tk.CheckWindow(now.Add(3 * time.Hour))
+	tassert.Errorf(t, err != nil && strings.Contains(err.Error(), tok.ErrTokenExpired.Error()), "expecting %v, got %v", tok.ErrTokenExpired, err)
+
+	// source networks
+	tassert.CheckError(t, tk.CheckSource("10.1.2.3:51080"))
+	tassert.CheckError(t, tk.CheckSource("[fd00::1]:51080"))
+	tassert.Errorf(t, tk.CheckSource("10.2.2.3:51080") != nil, "expecting source address to be rejected")
+	tassert.Errorf(t, tk.CheckSource("192.168.0.1:51080") != nil, "expecting source address to be rejected")
+
+	// invalid requests
+	for _, msg := range []*authn.LoginMsg{
+		{CIDRs: []string{"10.1.0.0"}},
+		{ExpiresAt: apc.Ptr(now.Add(-time.Minute))},
+		{ExpiresAt: apc.Ptr(now.Add(time.Hour)), ExpiresIn: apc.Ptr(time.Hour)},
+		{ExpiresAt: apc.Ptr(now.Add(time.Hour)), NotBefore: apc.Ptr(now.Add(2 * time.Hour))},
+	} {
+		tassert.Errorf(t, msg.Validate() != nil, "expecting invalid login request: %+v", msg)
+	}
+
+	// unrestricted
+	token, err = mgr.issueToken(users[0], passs[0], &authn.LoginMsg{})
+	tassert.CheckFatal(t, err)
+	tk, err = tok.DecryptToken(token, secret)
+	tassert.CheckFatal(t, err)
+	tassert.CheckError(t, tk.CheckWindow(now))
+	tassert.CheckError(t, tk.CheckSource("192.168.0.1:51080"))
+}
+
 func TestMergeCluACLS(t *testing.T) {
 	tests := []struct {
 		title    string
@@ -637,3 +693,188 @@ func TestMergeBckACLS(t *testing.T) {
 		}
 	}
 }
+
+func TestAddUsers(t *testing.T) {
+	driver := mock.NewDBDriver()
+	mgr, err := newMgr(driver)
+	tassert.CheckFatal(t, err)
+
+	tmpl := &authn.Role{
+		Name:        "team-{{team}}",
+		Description: "Full access to {{team}} buckets",
+		BucketACLs: []*authn.BckACL{
+			{Bck: cmn.Bck{Name: "{{team}}-data", Provider: apc.AIS}, Access: apc.AccessRW},
+		},
+	}
+	tassert.CheckFatal(t, mgr.addRole(tmpl))
+	tassert.CheckFatal(t, mgr.addRole(guestRole))
+
+	csv := `id,pass,roles,team
+alice,pass1,team-{{team}};Guest,alpha
+bob,pass2,team-{{team}},beta
+carol,pass3,team-{{team}},
+dave,,Guest,
+`
+	msg, err := authn.ParseUsersCSV(strings.NewReader(csv))
+	tassert.CheckFatal(t, err)
+	tassert.Fatalf(t, len(msg.Users) == 4, "expected 4 users, got %d", len(msg.Users))
+
+	res := mgr.addUsers(msg)
+	tassert.Errorf(t, len(res.Created) == 2, "expected 2 created, got %v (errors: %v)", res.Created, res.Errors)
+	// dave: no password
+	tassert.Errorf(t, res.Errors["dave"] != "", "expected error for user without password")
+
+	alice, err := mgr.lookupUser("alice")
+	tassert.CheckFatal(t, err)
+	tassert.Fatalf(t, len(alice.Roles) == 2, "expected 2 roles, got %d", len(alice.Roles))
+	role := alice.Roles[0]
+	tassert.Errorf(t, role.Name == "team-alpha", "expected instantiated role name, got %q", role.Name)
+	tassert.Errorf(t, role.BucketACLs[0].Bck.Name == "alpha-data", "expected instantiated bucket, got %q", role.BucketACLs[0].Bck.Name)
+
+	// carol: empty template parameter
+	tassert.Errorf(t, res.Errors["carol"] != "", "expected error for empty template parameter")
+	_, err = mgr.lookupUser("carol")
+	tassert.Errorf(t, err != nil, "user %q must not be created", "carol")
+
+	// existing users
+	msg = &authn.UsersMsg{Users: []*authn.UserSpec{
+		{ID: "bob", Roles: []string{"team-{{team}}"}, Params: map[string]string{"team": "gamma"}},
+		{ID: "erin", Password: "pass5", Roles: []string{"team-{{team}}"}},
+		{ID: adminUserID, Password: "pass6"},
+	}}
+	res = mgr.addUsers(msg)
+	tassert.Errorf(t, len(res.Errors) == 3, "expected 3 errors, got %v", res.Errors)
+
+	msg.Update = true
+	res = mgr.addUsers(msg)
+	tassert.Errorf(t, len(res.Updated) == 1 && res.Updated[0] == "bob", "expected bob updated, got %v", res.Updated)
+	tassert.Errorf(t, res.Errors["erin"] != "", "expected missing parameter error")
+	tassert.Errorf(t, res.Errors[adminUserID] != "", "expected error modifying %q", adminUserID)
+
+	bob, err := mgr.lookupUser("bob")
+	tassert.CheckFatal(t, err)
+	tassert.Errorf(t, bob.Roles[0].Name == "team-gamma", "expected updated role, got %q", bob.Roles[0].Name)
+	tassert.Errorf(t, isSamePassword("pass2", bob.Password), "password must not change")
+}
+
+func TestACLChange(t *testing.T) {
+	driver := mock.NewDBDriver()
+	mgr, err := newMgr(driver)
+	tassert.CheckFatal(t, err)
+
+	role := &authn.Role{
+		Name:       "readers",
+		BucketACLs: []*authn.BckACL{{Bck: cmn.Bck{Name: "data", Provider: apc.AIS}, Access: apc.AccessRO}},
+	}
+	tassert.CheckFatal(t, mgr.addRole(role))
+	tassert.CheckFatal(t, mgr.addUser(&authn.User{ID: users[0], Password: passs[0], Roles: []*authn.Role{role}}))
+	tassert.CheckFatal(t, mgr.addUser(&authn.User{ID: users[1], Password: passs[1], Roles: []*authn.Role{guestRole}}))
+
+	token0, err := mgr.issueToken(users[0], passs[0], &authn.LoginMsg{})
+	tassert.CheckFatal(t, err)
+	token1, err := mgr.issueToken(users[1], passs[1], &authn.LoginMsg{})
+	tassert.CheckFatal(t, err)
+
+	clus := map[string]*authn.CluACL{"ABCD": {ID: "ABCD"}}
+	st := mgr.sync.status(clus)
+	tassert.Errorf(t, st.Clusters["ABCD"].Pending, "expected newly registered cluster to be pending")
+	ver := st.Version
+
+	// update role => users' copies get updated, their tokens revoked
+	update := &authn.Role{
+		BucketACLs: []*authn.BckACL{{Bck: cmn.Bck{Name: "data", Provider: apc.AIS}, Access: apc.AccessRW}},
+	}
+	tassert.CheckFatal(t, mgr.updateRole(role.Name, update))
+
+	uInfo, err := mgr.lookupUser(users[0])
+	tassert.CheckFatal(t, err)
+	tassert.Fatalf(t, len(uInfo.Roles) == 1, "expected 1 role, got %d", len(uInfo.Roles))
+	tassert.Errorf(t, uInfo.Roles[0].BucketACLs[0].Access == apc.AccessRW, "expected updated role copy, got %v",
+		uInfo.Roles[0].BucketACLs[0].Access)
+
+	revoked, err := mgr.generateRevokedTokenList()
+	tassert.CheckFatal(t, err)
+	tassert.Errorf(t, cos.StringInSlice(token0, revoked), "expected %q token to be revoked", users[0])
+	tassert.Errorf(t, !cos.StringInSlice(token1, revoked), "%q token must not be revoked", users[1])
+	tassert.Errorf(t, mgr.sync.status(clus).Version > ver, "expected sync version to be incremented")
+
+	// delete user => revoked
+	tassert.CheckFatal(t, mgr.delUser(users[1]))
+	revoked, err = mgr.generateRevokedTokenList()
+	tassert.CheckFatal(t, err)
+	tassert.Errorf(t, cos.StringInSlice(token1, revoked), "expected %q token to be revoked", users[1])
+
+	// delete role => removed from users
+	tassert.CheckFatal(t, mgr.delRole(role.Name))
+	uInfo, err = mgr.lookupUser(users[0])
+	tassert.CheckFatal(t, err)
+	tassert.Errorf(t, len(uInfo.Roles) == 0, "expected role to be removed, got %d", len(uInfo.Roles))
+}
+
+func TestPasswordPolicy(t *testing.T) {
+	saved := Conf.Password
+	defer func() { Conf.Password = saved }()
+	Conf.Password = authn.PasswordConf{MinLen: 8, MinClasses: 3, History: 2}
+
+	mgr, err := newMgr(mock.NewDBDriver())
+	tassert.CheckFatal(t, err)
+
+	// the default admin password does not satisfy the policy
+	admin := &authn.User{}
+	tassert.CheckFatal(t, mgr.db.Get(usersCollection, adminUserID, admin))
+	tassert.Errorf(t, admin.MustChange, "expecting default admin password to require change")
+	_, err = mgr.issueToken(adminUserID, adminUserPass, &authn.LoginMsg{})
+	tassert.Errorf(t, err == errPasswordChange, "expecting %v, got %v", errPasswordChange, err)
+
+	// admin password from the environment must satisfy the policy
+	t.Setenv(env.AuthN.AdminPassword, "weak")
+	_, err = newMgr(mock.NewDBDriver())
+	tassert.Errorf(t, err != nil, "expecting weak %s to be rejected", env.AuthN.AdminPassword)
+	t.Setenv(env.AuthN.AdminPassword, "Strong-123")
+	amgr, err := newMgr(mock.NewDBDriver())
+	tassert.CheckFatal(t, err)
+	_, err = amgr.issueToken(adminUserID, "Strong-123", &authn.LoginMsg{})
+	tassert.CheckFatal(t, err)
+
+	// complexity
+	for _, pass := range []string{"Ab1", "abcdefgh", "abcdEFGH"} {
+		err := mgr.addUser(&authn.User{ID: "u1", Password: pass})
+		tassert.Errorf(t, err != nil, "expecting %q to be rejected", pass)
+	}
+	tassert.CheckFatal(t, mgr.addUser(&authn.User{ID: "u1", Password: "abcdEF12"}))
+
+	// self-service change: current password and reuse history
+	err = mgr.changePassword("u1", &authn.PasswordMsg{Password: "wrong", NewPassword: "Xyzw1234"})
+	tassert.Errorf(t, err == errInvalidCredentials, "expecting %v, got %v", errInvalidCredentials, err)
+	err = mgr.changePassword("nobody", &authn.PasswordMsg{Password: "wrong", NewPassword: "Xyzw1234"})
+	tassert.Errorf(t, err == errInvalidCredentials, "unknown user: expecting %v, got %v", errInvalidCredentials, err)
+	err = mgr.changePassword("u1", &authn.PasswordMsg{Password: "abcdEF12", NewPassword: "abcdEF12"})
+	tassert.Errorf(t, err == errPasswordReused, "expecting %v, got %v", errPasswordReused, err)
+	tassert.CheckFatal(t, mgr.changePassword("u1", &authn.PasswordMsg{Password: "abcdEF12", NewPassword: "Xyzw1234"}))
+	err = mgr.changePassword("u1", &authn.PasswordMsg{Password: "Xyzw1234", NewPassword: "abcdEF12"})
+	tassert.Errorf(t, err == errPasswordReused, "expecting %v, got %v", errPasswordReused, err)
+
+	// forced rotation
+	tassert.CheckFatal(t, mgr.updateUser("u1", &authn.User{MustChange: true}))
+	_, err = mgr.issueToken("u1", "Xyzw1234", &authn.LoginMsg{})
+	tassert.Errorf(t, err == errPasswordChange, "expecting %v, got %v", errPasswordChange, err)
+
+	// one-time reset token
+	res, err := mgr.issueResetToken("u1")
+	tassert.CheckFatal(t, err)
+	err = mgr.changePassword("u1", &authn.PasswordMsg{ResetToken: "bogus", NewPassword: "Reset-1234"})
+	tassert.Errorf(t, err == errInvalidReset, "expecting %v, got %v", errInvalidReset, err)
+	tassert.CheckFatal(t, mgr.changePassword("u1", &authn.PasswordMsg{ResetToken: res.Token, NewPassword: "Reset-1234"}))
+	err = mgr.changePassword("u1", &authn.PasswordMsg{ResetToken: res.Token, NewPassword: "Again-1234"})
+	tassert.Errorf(t, err == errInvalidReset, "expecting reset token to be one-time, got %v", err)
+
+	token, err := mgr.issueToken("u1", "Reset-1234", &authn.LoginMsg{})
+	tassert.CheckFatal(t, err)
+	tassert.Errorf(t, token != "", "expecting token")
+
+	// max age
+	Conf.Password.MaxAge = cos.Duration(time.Millisecond)
+	time.Sleep(5 * time.Millisecond)
+	_, err = mgr.issueToken("u1", "Reset-1234", &authn.LoginMsg{})
+	tassert.Errorf(t, err == errPasswordChange, "expecting %v, got %v", errPasswordChange, err)
+}
@@ -1,8 +1,8 @@
-// Package authn is authentication server for AIStore.
+// Package svc implements AIStore authentication server (AuthN).
 /*
  * Copyright (c) 2018-2022, NVIDIA CORPORATION. All rights reserved.
  */
-package main
+package svc
 
 import (
 	"github.com/NVIDIA/aistore/api/authn"
@@ -8,6 +8,7 @@ package tok
 import (
 	"errors"
 	"fmt"
+	"net"
 	"net/http"
 	"strings"
 	"time"
@@ -19,14 +20,26 @@ import (
 	"github.com/golang-jwt/jwt/v4"
 )
 
-type Token struct {
-	UserID      string          `json:"username"`
-	Expires     time.Time       `json:"expires"`
-	Token       string          `json:"token"`
-	ClusterACLs []*authn.CluACL `json:"clusters"`
-	BucketACLs  []*authn.BckACL `json:"buckets,omitempty"`
-	IsAdmin     bool            `json:"admin"`
-}
+type (
+	Token struct {
+		UserID      string          `json:"username"`
+		Expires     time.Time       `json:"expires"`
+		NotBefore   time.Time       `json:"not_before,omitempty"`
+		Token       string          `json:"token"`
+		ClusterACLs []*authn.CluACL `json:"clusters"`
+		BucketACLs  []*authn.BckACL `json:"buckets,omitempty"`
+		CIDRs       []string        `json:"cidrs,omitempty"` // source networks; empty - any
+		IsAdmin     bool            `json:"admin"`
+
+		Roles []string `json:"roles,omitempty"` // names of the user's roles (e.g., dsort job queueing)
+	}
+
+	// optional restrictions: time window (in addition to expiration) and source networks
+	Restrictions struct {
+		NotBefore time.Time
+		CIDRs     []string
+	}
+)
 
 var (
 	ErrNoPermissions = errors.New("insufficient permissions")
@@ -35,30 +48,56 @@ var (
 	ErrNoBearerToken = errors.New("invalid token: no bearer")
 	ErrTokenExpired  = errors.New("token expired")
 	ErrTokenRevoked  = errors.New("token revoked")
+	ErrTokenNotYet   = errors.New("token not yet valid")
+	ErrTokenSource   = errors.New("token not valid from this source address")
 )
 
 // TODO: cos.Unsafe* and other micro-optimization and refactoring
 
-func AdminJWT(expires time.Time, userID, secret string) (string, error) {
-	t := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{
+func AdminJWT(expires time.Time, userID string, roles []string, rs *Restrictions, secret string) (string, error) {
+	claims := jwt.MapClaims{
 		"expires":  expires,
 		"username": userID,
 		"admin":    true,
-	})
+	}
+	_roles(claims, roles)
+	rs.claims(claims)
+	t := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
 	return t.SignedString([]byte(secret))
 }
 
-func JWT(expires time.Time, userID string, bucketACLs []*authn.BckACL, clusterACLs []*authn.CluACL,
-	secret string) (string, error) {
-	t := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{
+func JWT(expires time.Time, userID string, roles []string, bucketACLs []*authn.BckACL, clusterACLs []*authn.CluACL,
+	rs *Restrictions, secret string) (string, error) {
+	claims := jwt.MapClaims{
 		"expires":  expires,
 		"username": userID,
 		"buckets":  bucketACLs,
 		"clusters": clusterACLs,
-	})
+	}
+	_roles(claims, roles)
+	rs.claims(claims)
+	t := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
 	return t.SignedString([]byte(secret))
 }
 
+func _roles(claims jwt.MapClaims, roles []string) {
+	if len(roles) > 0 {
+		claims["roles"] = roles
+	}
+}
+
+func (rs *Restrictions) claims(claims jwt.MapClaims) {
+	if rs == nil {
+		return
+	}
+	if !rs.NotBefore.IsZero() {
+		claims["not_before"] = rs.NotBefore
+	}
+	if len(rs.CIDRs) > 0 {
+		claims["cidrs"] = rs.CIDRs
+	}
+}
+
 // Header format: 'Authorization: Bearer <token>'
 func ExtractToken(hdr http.Header) (string, error) {
 	s := hdr.Get(apc.HdrAuthorization)
@@ -101,6 +140,39 @@ func (tk *Token) String() string {
 	return fmt.Sprintf("user %s, %s", tk.UserID, expiresIn(tk.Expires))
 }
 
+// CheckWindow returns an error if the token is used before its not-before time, or after it expires
+func (tk *Token) CheckWindow(now time.Time) error {
+	if tk.Expires.Before(now) {
+		return fmt.Errorf("%v: %s", ErrTokenExpired, tk)
+	}
+	if !tk.NotBefore.IsZero() && now.Before(tk.NotBefore) {
+		return fmt.Errorf("%v: %s, valid from %s", ErrTokenNotYet, tk, tk.NotBefore.Format(time.RFC3339))
+	}
+	return nil
+}
+
+// CheckSource validates the requester's address against the token's CIDRs, if any
+// (the address is expected to be the TCP peer - see http.Request.RemoteAddr)
+func (tk *Token) CheckSource(remoteAddr string) error {
+	if len(tk.CIDRs) == 0 {
+		return nil
+	}
+	host, _, err := net.SplitHostPort(remoteAddr)
+	if err != nil {
+		host = remoteAddr
+	}
+	ip := net.ParseIP(host)
+	if ip == nil {
+		return fmt.Errorf("%v: %s, failed to parse %q", ErrTokenSource, tk, remoteAddr)
+	}
+	for _, s := range tk.CIDRs {
+		if _, ipnet, err := net.ParseCIDR(s); err == nil && ipnet.Contains(ip) {
+			return nil
+		}
+	}
+	return fmt.Errorf("%v: %s, source %s", ErrTokenSource, tk, ip)
+}
+
 // A user has two-level permissions: cluster-wide and on per bucket basis.
 // To be able to access data, a user must have either permission. This
 // allows creating users, e.g, with read-only access to the entire cluster,
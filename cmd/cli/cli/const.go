@@ -236,6 +236,7 @@ const (
 	jobIDArgument                 = "JOB_ID"
 	optionalJobIDArgument         = "[JOB_ID]"
 	optionalJobIDDaemonIDArgument = "[JOB_ID [NODE_ID]]"
+	jobIDDaemonIDArgument         = "JOB_ID [NODE_ID]"
 
 	jobAnyArg                = "[NAME] [JOB_ID] [NODE_ID] [BUCKET]"
 	jobShowRebalanceArgument = "[REB_ID] [NODE_ID]"
@@ -39,8 +39,21 @@ func Init(args []string) (err error) {
 
 	loggedUserToken, _ = authn.LoadToken("") // No error handling as token might not be needed
 
+	// client profile (see api/profile.go): applies unless specified otherwise via environment
+	var prof *api.Profile
+	if os.Getenv(env.AIS.Profile) != "" {
+		if prof, err = api.LoadProfile(""); err != nil {
+			return err
+		}
+		if token == "" && tokenFile == "" {
+			if loggedUserToken, err = prof.LoadToken(); err != nil {
+				return err
+			}
+		}
+	}
+
 	// http clients: the main one and the auth, if enabled
-	clusterURL = _clusterURL(cfg)
+	clusterURL = _clusterURL(cfg, prof)
 
 	var (
 		cargs = cmn.TransportArgs{
@@ -55,6 +68,17 @@ func Init(args []string) (err error) {
 		}
 	)
 
+	if prof != nil {
+		if prof.Timeout != 0 {
+			cargs.Timeout = prof.TransportArgs().Timeout
+		}
+		if prof.DialTimeout != 0 {
+			cargs.DialTimeout = prof.DialTimeout.D()
+		}
+		if prof.IsHTTPS() {
+			sargs = prof.TLSArgs()
+		}
+	}
 	cmn.EnvToTLS(&sargs)
 
 	apiBP = api.BaseParams{
@@ -94,14 +118,19 @@ func Init(args []string) (err error) {
 }
 
 // resolving order:
-// 1. cfg.Cluster.URL; if empty:
-// 2. Proxy docker container IP address; if not successful:
-// 3. Docker default; if not present:
-// 4. Default as cfg.Cluster.DefaultAISHost
-func _clusterURL(cfg *config.Config) string {
+// 1. AIS_ENDPOINT environment; if empty:
+// 2. client profile (AIS_PROFILE); if not specified:
+// 3. cfg.Cluster.URL; if empty:
+// 4. Proxy docker container IP address; if not successful:
+// 5. Docker default; if not present:
+// 6. Default as cfg.Cluster.DefaultAISHost
+func _clusterURL(cfg *config.Config, prof *api.Profile) string {
 	if envURL := os.Getenv(env.AIS.Endpoint); envURL != "" {
 		return envURL
 	}
+	if prof != nil {
+		return prof.Endpoint
+	}
 	if cfg.Cluster.URL != "" {
 		return cfg.Cluster.URL
 	}
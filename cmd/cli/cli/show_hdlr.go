@@ -203,6 +203,17 @@ var (
 		Flags:        showCmdsFlags[commandJob],
 		Action:       showJobsHandler,
 		BashComplete: runningJobCompletions,
+		Subcommands: []cli.Command{
+			showCmdJobLog,
+		},
+	}
+	showCmdJobLog = cli.Command{
+		Name:         cmdLog,
+		Usage:        "show events captured by a given job: warnings, per-target errors, retries",
+		ArgsUsage:    jobIDDaemonIDArgument,
+		Flags:        []cli.Flag{jsonFlag, noHeaderFlag},
+		Action:       showJobLogHandler,
+		BashComplete: runningJobCompletions,
 	}
 )
 
@@ -273,6 +284,71 @@ func showJobsDo(c *cli.Context, name, xid, daemonID string, bck cmn.Bck) (int, e
 	return ll, err
 }
 
+// ais show job log JOB_ID [NODE_ID]
+func showJobLogHandler(c *cli.Context) error {
+	if c.NArg() == 0 {
+		return missingArgumentsError(c, c.Command.ArgsUsage)
+	}
+	var (
+		tid string
+		xid = c.Args().Get(0)
+	)
+	if c.NArg() > 1 {
+		node, _, err := getNode(c, c.Args().Get(1))
+		if err != nil {
+			return err
+		}
+		if !node.IsTarget() {
+			return incorrectUsageMsg(c, "%s is not a target", node.StringEx())
+		}
+		tid = node.ID()
+	}
+	xs, err := api.GetXactionLog(apiBP, &xact.ArgsMsg{ID: xid})
+	if err != nil {
+		return V(err)
+	}
+	if tid != "" {
+		xs = xact.MultiSnap{tid: xs[tid]}
+	}
+	if flagIsSet(c, jsonFlag) {
+		return teb.Print(xs, "", teb.Jopts(true))
+	}
+
+	tids := make([]string, 0, len(xs))
+	for t, snaps := range xs {
+		if len(snaps) > 0 {
+			tids = append(tids, t)
+		}
+	}
+	if len(tids) == 0 {
+		return fmt.Errorf("job %q not found", xid)
+	}
+	sort.Strings(tids)
+
+	var (
+		dropped int
+		tw      = &tabwriter.Writer{}
+	)
+	tw.Init(c.App.Writer, 0, 8, 2, ' ', 0)
+	if !flagIsSet(c, noHeaderFlag) {
+		fmt.Fprintln(tw, "TIME\tTARGET\tLEVEL\tNODE\tMESSAGE")
+	}
+	for _, t := range tids {
+		for _, snap := range xs[t] {
+			for _, e := range snap.Log {
+				fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%s\n",
+					e.Time.Format(time.StampMilli), meta.Tname(t), e.Level, e.Node, e.Msg)
+			}
+			dropped += snap.LogDropped
+		}
+	}
+	tw.Flush()
+	if dropped > 0 {
+		actionNote(c, fmt.Sprintf("%d older event%s not shown (overwritten)", dropped, cos.Plural(dropped)))
+	}
+	return nil
+}
+
 func jobCptn(c *cli.Context, name string, onlyActive bool, xid string, byTarget bool) {
 	var (
 		s, tip string
@@ -0,0 +1,98 @@
+// Package cmn provides common constants, types, and utilities for AIS clients
+// and AIStore.
+/*
+ * Copyright (c) 2024, NVIDIA CORPORATION. All rights reserved.
+ */
+package cmn
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+// Abort reason: who aborted a given xaction, and why.
+// The node where the abort originates records the reason, which then propagates -
+// via IC notifications and the subsequent abort requests - to all other participating
+// nodes, so that each reports the same (original) reason in its final xaction snapshot.
+
+const AbortByUser = "user" // (see AbortReason.Initiator)
+
+type (
+	AbortReason struct {
+		Initiator string   `json:"initiator"`        // AbortByUser or the node where the abort originated
+		Cause     string   `json:"cause"`            // error message
+		Chain     []string `json:"chain,omitempty"`  // wrapped errors, outermost first
+		Object    string   `json:"object,omitempty"` // offending object, if known (see ErrWithObject)
+		Time      int64    `json:"time,string"`      // when aborted (Unix nano)
+	}
+
+	// annotates error with the object that caused it
+	ErrWithObject struct {
+		err   error
+		cname string
+	}
+
+	// carries abort reason received from another node
+	errAbortReason struct {
+		err    error
+		reason *AbortReason
+	}
+)
+
+/////////////////
+// AbortReason //
+/////////////////
+
+func NewAbortReason(err error) *AbortReason {
+	reason := &AbortReason{Initiator: thisNodeName, Cause: err.Error(), Time: time.Now().UnixNano()}
+	if errors.Is(err, ErrXactUserAbort) {
+		reason.Initiator = AbortByUser
+	}
+	if e := errors.Unwrap(err); e != nil {
+		for ; e != nil; e = errors.Unwrap(e) {
+			reason.Chain = append(reason.Chain, e.Error())
+		}
+	}
+	var errObj *ErrWithObject
+	if errors.As(err, &errObj) {
+		reason.Object = errObj.cname
+	}
+	return reason
+}
+
+func (reason *AbortReason) String() (s string) {
+	s = reason.Initiator + ": " + reason.Cause
+	if reason.Object != "" {
+		s += " [" + reason.Object + "]"
+	}
+	return s
+}
+
+// WithAbortReason wraps abort error with the (propagated) reason - see AsAbortReason.
+func WithAbortReason(err error, reason *AbortReason) error {
+	return &errAbortReason{err: err, reason: reason}
+}
+
+func (e *errAbortReason) Error() string { return e.err.Error() }
+func (e *errAbortReason) Unwrap() error { return e.err }
+
+// AsAbortReason returns the reason and the wrapped error, if any.
+func AsAbortReason(err error) (*AbortReason, error) {
+	var e *errAbortReason
+	if errors.As(err, &e) {
+		return e.reason, e.err
+	}
+	return nil, err
+}
+
+///////////////////
+// ErrWithObject //
+///////////////////
+
+func NewErrWithObject(err error, cname string) *ErrWithObject {
+	return &ErrWithObject{err: err, cname: cname}
+}
+
+func (e *ErrWithObject) Error() string { return fmt.Sprintf("%v [%s]", e.err, e.cname) }
+func (e *ErrWithObject) Unwrap() error { return e.err }
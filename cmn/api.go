@@ -11,6 +11,7 @@ import (
 	"reflect"
 	"sort"
 	"strings"
+	"time"
 
 	"github.com/NVIDIA/aistore/api/apc"
 	"github.com/NVIDIA/aistore/cmn/cos"
@@ -40,22 +41,59 @@ const (
 	PropBackendBckProvider = PropBackendBck + ".provider"
 )
 
+// default time to keep soft-deleted objects (see TrashConf)
+const DfltTrashRetention = 24 * time.Hour
+
 type (
 	Bprops struct {
 		BackendBck  Bck             `json:"backend_bck,omitempty"` // makes remote bucket out of a given ais bucket
 		Extra       ExtraProps      `json:"extra,omitempty" list:"omitempty"`
 		WritePolicy WritePolicyConf `json:"write_policy"`
-		Provider    string          `json:"provider" list:"readonly"`       // backend provider
-		Renamed     string          `list:"omit"`                           // non-empty if the bucket has been renamed
-		Cksum       CksumConf       `json:"checksum"`                       // the bucket's checksum
-		EC          ECConf          `json:"ec"`                             // erasure coding
-		LRU         LRUConf         `json:"lru"`                            // LRU (watermarks and enabled/disabled)
-		Mirror      MirrorConf      `json:"mirror"`                         // mirroring
-		Access      apc.AccessAttrs `json:"access,string"`                  // access permissions
-		Features    feat.Flags      `json:"features,string"`                // assorted features from feat.Bucket
-		BID         uint64          `json:"bid,string" list:"omit"`         // unique ID
-		Created     int64           `json:"created,string" list:"readonly"` // creation timestamp
-		Versioning  VersionConf     `json:"versioning"`                     // versioning (see "inherit")
+		Provider    string          `json:"provider" list:"readonly"`              // backend provider
+		Renamed     string          `list:"omit"`                                  // non-empty if the bucket has been renamed
+		Cksum       CksumConf       `json:"checksum"`                              // the bucket's checksum
+		EC          ECConf          `json:"ec"`                                    // erasure coding
+		LRU         LRUConf         `json:"lru"`                                   // LRU (watermarks and enabled/disabled)
+		Mirror      MirrorConf      `json:"mirror"`                                // mirroring
+		Access      apc.AccessAttrs `json:"access,string"`                         // access permissions
+		Features    feat.Flags      `json:"features,string"`                       // assorted features from feat.Bucket
+		BID         uint64          `json:"bid,string" list:"omit"`                // unique ID
+		Created     int64           `json:"created,string" list:"readonly"`        // creation timestamp
+		Versioning  VersionConf     `json:"versioning"`                            // versioning (see "inherit")
+		ETL         BckETLConf      `json:"etl,omitempty" list:"omitempty"`        // transform upon cold GET
+		ObjName     ObjNameConf     `json:"objname,omitempty" list:"omitempty"`    // object naming policy
+		Trash       TrashConf       `json:"trash,omitempty" list:"omitempty"`      // soft delete
+		Shadow      ShadowConf      `json:"shadow,omitempty" list:"omitempty"`     // request shadowing (canary testing)
+		Atime       AtimeConf       `json:"atime"`                                 // access time persistence policy
+		MaxConns    int             `json:"max_conns,omitempty"`                   // max concurrent client connections (per target; zero: unlimited)
+		Hook        HookConf        `json:"hook,omitempty" list:"omitempty"`       // validation webhook (pre-PUT and pre-DELETE)
+		RAMCache    RAMCacheConf    `json:"ram_cache,omitempty" list:"omitempty"`  // in-memory caching of small hot objects
+		Publish     PublishConf     `json:"publish,omitempty" list:"omitempty"`    // immutable (and, optionally, content-addressed)
+		Dedup       DedupConf       `json:"dedup,omitempty" list:"omitempty"`      // content-hash based deduplication
+		WriteBack   WriteBackConf   `json:"write_back,omitempty" list:"omitempty"` // deferred backend deletion
+		Provision   BckProvision    `json:"provision,omitempty" list:"omitempty"`  // self-service (see ProvisionConf)
+		Freeze      FreezeConf      `json:"freeze,omitempty" list:"omitempty"`     // read-only and no-access modes (see apc.FreezeState)
+	}
+
+	// ETL bound to a (remote) bucket: cold-GET objects get transformed prior to being cached
+	BckETLConf struct {
+		Name    string       `json:"name,omitempty"`
+		Timeout cos.Duration `json:"timeout,omitempty"` // transform timeout (system default when zero)
+	}
+	BckETLConfToSet struct {
+		Name    *string       `json:"name,omitempty"`
+		Timeout *cos.Duration `json:"timeout,omitempty"`
+	}
+
+	// Soft delete: when enabled, deleted objects are moved to a hidden (per-mountpath) trash
+	// and can be listed and restored within the retention window - see fs.TrashType
+	TrashConf struct {
+		Enabled   bool         `json:"enabled,omitempty"`
+		Retention cos.Duration `json:"retention,omitempty"` // (dflt. DfltTrashRetention)
+	}
+	TrashConfToSet struct {
+		Enabled   *bool         `json:"enabled,omitempty"`
+		Retention *cos.Duration `json:"retention,omitempty"`
 	}
 
 	ExtraProps struct {
@@ -126,6 +164,18 @@ type (
 		Features    *feat.Flags           `json:"features,string,omitempty"`
 		WritePolicy *WritePolicyConfToSet `json:"write_policy,omitempty"`
 		Extra       *ExtraToSet           `json:"extra,omitempty"`
+		ETL         *BckETLConfToSet      `json:"etl,omitempty"`
+		ObjName     *ObjNameConfToSet     `json:"objname,omitempty"`
+		Trash       *TrashConfToSet       `json:"trash,omitempty"`
+		Shadow      *ShadowConfToSet      `json:"shadow,omitempty"`
+		Atime       *AtimeConfToSet       `json:"atime,omitempty"`
+		MaxConns    *int                  `json:"max_conns,omitempty"`
+		Hook        *HookConfToSet        `json:"hook,omitempty"`
+		RAMCache    *RAMCacheConfToSet    `json:"ram_cache,omitempty"`
+		Publish     *PublishConfToSet     `json:"publish,omitempty"`
+		Dedup       *DedupConfToSet       `json:"dedup,omitempty"`
+		WriteBack   *WriteBackConfToSet   `json:"write_back,omitempty"`
+		Freeze      *FreezeConfToSet      `json:"freeze,omitempty"`
 		Force       bool                  `json:"force,omitempty" copy:"skip" list:"omit"`
 	}
 
@@ -176,6 +226,7 @@ func (bck *Bck) DefaultProps(c *ClusterConfig) *Bprops {
 		EC:          c.EC,
 		WritePolicy: wp,
 		Features:    c.Features,
+		Atime:       AtimeConf{Policy: AtimeLazy, MaxStale: cos.Duration(DfltAtimeMaxStale)},
 	}
 }
 
@@ -218,7 +269,7 @@ func (bp *Bprops) Validate(targetCnt int) error {
 
 	// run assorted props validators
 	var softErr error
-	for _, pv := range []PropsValidator{&bp.Cksum, &bp.Mirror, &bp.EC, &bp.Extra, &bp.WritePolicy} {
+	for _, pv := range []PropsValidator{&bp.Cksum, &bp.Mirror, &bp.EC, &bp.Extra, &bp.WritePolicy, &bp.ObjName, &bp.Shadow, &bp.Atime, &bp.Hook, &bp.RAMCache} {
 		var err error
 		if pv == &bp.EC {
 			err = bp.EC.ValidateAsProps(targetCnt)
@@ -234,6 +285,40 @@ func (bp *Bprops) Validate(targetCnt int) error {
 			softErr = err
 		}
 	}
+	if bp.ETL.Name != "" && bp.Provider == apc.AIS && bp.BackendBck.IsEmpty() {
+		return fmt.Errorf("cannot bind ETL %q to %s bucket: transform upon cold GET requires remote backend",
+			bp.ETL.Name, apc.DisplayProvider(bp.Provider))
+	}
+	if bp.ETL.Timeout < 0 {
+		return fmt.Errorf("invalid etl.timeout %v (must be non-negative)", bp.ETL.Timeout)
+	}
+	if bp.Trash.Enabled {
+		if bp.Provider != apc.AIS || !bp.BackendBck.IsEmpty() {
+			return fmt.Errorf("cannot enable trash (soft delete) for %s bucket: objects deleted from remote backends cannot be restored",
+				apc.DisplayProvider(bp.Provider))
+		}
+		if bp.EC.Enabled {
+			return errors.New("trash (soft delete) and erasure coding cannot be enabled on the same bucket")
+		}
+	}
+	if bp.Trash.Retention < 0 {
+		return fmt.Errorf("invalid trash.retention %v (must be non-negative)", bp.Trash.Retention)
+	}
+	if bp.MaxConns < 0 {
+		return fmt.Errorf("invalid max_conns %d (must be non-negative)", bp.MaxConns)
+	}
+	if err := bp.Publish.validate(bp); err != nil {
+		return err
+	}
+	if err := bp.Dedup.validate(bp); err != nil {
+		return err
+	}
+	if err := bp.WriteBack.validate(bp); err != nil {
+		return err
+	}
+	if err := bp.Freeze.validate(); err != nil {
+		return err
+	}
 	if bp.Mirror.Enabled && bp.EC.Enabled {
 		nlog.Warningln("n-way mirroring and EC are both enabled at the same time on the same bucket")
 	}
@@ -253,6 +338,13 @@ func (bp *Bprops) Apply(propsToSet *BpropsToSet) {
 	debug.AssertNoErr(err)
 }
 
+func (c *TrashConf) RetentionD() time.Duration {
+	if c.Retention == 0 {
+		return DfltTrashRetention
+	}
+	return c.Retention.D()
+}
+
 //
 // BpropsToSet
 //
@@ -325,9 +417,11 @@ func aggr(from, to *BsummResult) {
 	}
 	to.ObjCount.Present += from.ObjCount.Present
 	to.ObjCount.Remote += from.ObjCount.Remote
+	to.ObjCount.Shared += from.ObjCount.Shared
 	to.TotalSize.OnDisk += from.TotalSize.OnDisk
 	to.TotalSize.PresentObjs += from.TotalSize.PresentObjs
 	to.TotalSize.RemoteObjs += from.TotalSize.RemoteObjs
+	to.TotalSize.SharedObjs += from.TotalSize.SharedObjs
 }
 
 func (s AllBsummResults) Finalize(dsize map[string]uint64, testingEnv bool) {
@@ -346,6 +440,7 @@ func (s AllBsummResults) Finalize(dsize map[string]uint64, testingEnv bool) {
 		if totalDisksSize > 0 {
 			summ.UsedPct = cos.DivRoundU64(summ.TotalSize.OnDisk*100, totalDisksSize)
 		}
+		summ.SetUnits()
 	}
 }
 
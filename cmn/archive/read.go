@@ -18,6 +18,7 @@ import (
 	"github.com/NVIDIA/aistore/cmn/cos"
 	"github.com/NVIDIA/aistore/cmn/debug"
 	"github.com/pierrec/lz4/v3"
+	"golang.org/x/text/unicode/norm"
 )
 
 const (
@@ -356,15 +357,27 @@ func (csf *cslFile) Read(b []byte) (int, error) { return csf.file.Read(b) }
 func (csf *cslFile) Size() int64                { return csf.size }
 func (csf *cslFile) Close() error               { return csf.file.Close() }
 
-// in re `--absolute-names` (simplified)
+// - in re `--absolute-names` (simplified) and "./" prefixed names;
+// - in re Unicode: compare canonical (NFC) forms - e.g., archives created on macOS (NFD)
 func namesEq(n1, n2 string) bool {
-	if n1[0] == filepath.Separator {
-		n1 = n1[1:]
+	n1, n2 = trimName(n1), trimName(n2)
+	if n1 == n2 {
+		return true
 	}
-	if n2[0] == filepath.Separator {
-		n2 = n2[1:]
+	return norm.NFC.String(n1) == norm.NFC.String(n2)
+}
+
+func trimName(n string) string {
+	for {
+		switch {
+		case strings.HasPrefix(n, "./"):
+			n = n[2:]
+		case n != "" && n[0] == filepath.Separator:
+			n = n[1:]
+		default:
+			return n
+		}
 	}
-	return n1 == n2
 }
 
 //////////////////
@@ -0,0 +1,81 @@
+// Package cmn provides common constants, types, and utilities for AIS clients
+// and AIStore.
+/*
+ * Copyright (c) 2024, NVIDIA CORPORATION. All rights reserved.
+ */
+package cmn
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/NVIDIA/aistore/cmn/cos"
+)
+
+// Object access time (atime) persistence policy.
+// In memory, atime gets updated upon every access; the policy determines when (and whether)
+// it is written to stable storage:
+// - "precise": upon every access, synchronously;
+// - "lazy" (default): batched in the background, with the on-disk atime lagging behind
+//   by at most `max_stale`;
+// - "off": never (except for new objects) - the best option for read-heavy workloads
+//   that do not rely on atime (e.g., no LRU eviction).
+// See also: core/latime.go
+
+const (
+	AtimeLazy    = "lazy"
+	AtimePrecise = "precise"
+	AtimeOff     = "off"
+)
+
+const (
+	DfltAtimeMaxStale = time.Hour
+	MinAtimeMaxStale  = time.Minute // (granularity of the background flush)
+)
+
+type (
+	AtimeConf struct {
+		Policy   string       `json:"policy"`
+		MaxStale cos.Duration `json:"max_stale"` // effective staleness: zero when precise or off
+	}
+	AtimeConfToSet struct {
+		Policy   *string       `json:"policy,omitempty"`
+		MaxStale *cos.Duration `json:"max_stale,omitempty"`
+	}
+)
+
+// (empty policy - buckets created prior to atime policy - is lazy)
+func (c *AtimeConf) IsLazy() bool    { return c.Policy == "" || c.Policy == AtimeLazy }
+func (c *AtimeConf) IsPrecise() bool { return c.Policy == AtimePrecise }
+func (c *AtimeConf) IsOff() bool     { return c.Policy == AtimeOff }
+
+// StaleD returns effective staleness
+func (c *AtimeConf) StaleD() time.Duration {
+	switch {
+	case !c.IsLazy():
+		return 0
+	case c.MaxStale == 0:
+		return DfltAtimeMaxStale
+	default:
+		return c.MaxStale.D()
+	}
+}
+
+// validates and normalizes, so that bucket props always show the effective values
+func (c *AtimeConf) ValidateAsProps(...any) error {
+	switch c.Policy {
+	case "", AtimeLazy:
+		c.Policy = AtimeLazy
+		if c.MaxStale == 0 {
+			c.MaxStale = cos.Duration(DfltAtimeMaxStale)
+		}
+		if c.MaxStale.D() < MinAtimeMaxStale {
+			return fmt.Errorf("invalid atime.max_stale %v (expecting %v or greater)", c.MaxStale, MinAtimeMaxStale)
+		}
+	case AtimePrecise, AtimeOff:
+		c.MaxStale = 0
+	default:
+		return fmt.Errorf("invalid atime.policy %q (expecting one of: %q, %q, %q)", c.Policy, AtimeLazy, AtimePrecise, AtimeOff)
+	}
+	return nil
+}
@@ -65,6 +65,9 @@ var (
 	// NsAnyRemote represents any remote cluster. As such, NsGlobalRemote applies
 	// exclusively to AIS (provider) given that other Backend providers are remote by definition.
 	NsAnyRemote = Ns{UUID: string(apc.NsUUIDPrefix)}
+	// NsSys is reserved for the buckets used internally (e.g., by deep health check);
+	// system buckets are not listed unless explicitly requested (see meta.BMD.Select)
+	NsSys = Ns{Name: "_sys"}
 )
 
 // A note on validation logic: cmn.Bck vs cmn.QueryBcks - same structures,
@@ -345,6 +348,7 @@ func (b *Bck) ubuf(buf []byte, nsUname, objName string) []byte {
 func (n Ns) IsGlobal() bool    { return n == NsGlobal }
 func (n Ns) IsAnyRemote() bool { return n == NsAnyRemote }
 func (n Ns) IsRemote() bool    { return n.UUID != "" }
+func (n Ns) IsSys() bool       { return n == NsSys }
 
 func (b *Bck) Backend() *Bck {
 	bprops := b.Props
@@ -0,0 +1,172 @@
+// Package cmn provides common constants, types, and utilities for AIS clients
+// and AIStore.
+/*
+ * Copyright (c) 2024, NVIDIA CORPORATION. All rights reserved.
+ */
+package cmn
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"path"
+	"strconv"
+	"strings"
+
+	"github.com/NVIDIA/aistore/cmn/cos"
+	"github.com/OneOfOne/xxhash"
+)
+
+// Workload capture: AIS gateways record a given percentage of object requests (GET, PUT, HEAD,
+// and DELETE) into a workload trace - CSV file that can be replayed by aisloader against
+// another (e.g., staging) cluster.
+// - privacy: unless `keep_names` is set, bucket and object names are recorded as (salted)
+//   hashes that preserve the naming structure (prefixes and extensions); request headers
+//   (except Range) and query parameters are never recorded;
+// - each gateway writes its own trace; capture stops once the trace reaches `max_size` -
+//   to restart, disable and re-enable capture.
+
+type (
+	CaptureConf struct {
+		Dir       string      `json:"dir,omitempty"` // destination directory (empty: <log dir>/capture)
+		Pct       int         `json:"pct"`           // percentage of requests to record [0, 100]; zero: disabled
+		MaxSize   cos.SizeIEC `json:"max_size"`      // max trace size (zero: DfltCaptureMaxSize)
+		KeepNames bool        `json:"keep_names"`    // record bucket and object names as is
+	}
+	CaptureConfToSet struct {
+		Dir       *string      `json:"dir,omitempty"`
+		Pct       *int         `json:"pct,omitempty"`
+		MaxSize   *cos.SizeIEC `json:"max_size,omitempty"`
+		KeepNames *bool        `json:"keep_names,omitempty"`
+	}
+)
+
+const DfltCaptureMaxSize = cos.GiB
+
+func (c *CaptureConf) Enabled() bool { return c.Pct > 0 }
+
+func (c *CaptureConf) Validate() error {
+	if c.Pct < 0 || c.Pct > 100 {
+		return fmt.Errorf("invalid proxy.capture.pct %d (expecting [0, 100] range)", c.Pct)
+	}
+	if c.MaxSize < 0 {
+		return fmt.Errorf("invalid proxy.capture.max_size %d (expecting non-negative)", c.MaxSize)
+	}
+	if c.Dir != "" && !path.IsAbs(c.Dir) {
+		return fmt.Errorf("invalid proxy.capture.dir %q (expecting absolute path)", c.Dir)
+	}
+	return nil
+}
+
+func (c *CaptureConf) MaxSizeOrDflt() int64 {
+	if c.MaxSize > 0 {
+		return int64(c.MaxSize)
+	}
+	return DfltCaptureMaxSize
+}
+
+// hash each '/'-separated component, keep extension (if any)
+func RedactName(name string, salt uint64) string {
+	var (
+		sb    strings.Builder
+		comps = strings.Split(name, "/")
+	)
+	sb.Grow(len(comps) * 16)
+	for i, comp := range comps {
+		if i > 0 {
+			sb.WriteByte('/')
+		}
+		if comp == "" {
+			continue
+		}
+		ext := path.Ext(comp)
+		if ext == comp || len(ext) > 8 {
+			ext = ""
+		}
+		h := xxhash.Checksum64S(cos.UnsafeB(comp), salt)
+		sb.WriteString(strconv.FormatUint(h, 36))
+		sb.WriteString(ext)
+	}
+	return sb.String()
+}
+
+////////////////////
+// workload trace //
+////////////////////
+
+// CSV, one recorded request per line, following the header (`traceHdr`):
+// - time:   nanoseconds since the start of capture (by a given gateway);
+// - op:     GET, PUT, HEAD, or DELETE;
+// - bucket: e.g., "ais://abc", "s3://xyz";
+// - object: object name;
+// - size:   PUT: content length (-1 when unknown), zero otherwise;
+// - range:  GET: HTTP Range header, if specified.
+
+type (
+	TraceRec struct {
+		Bck     string
+		ObjName string
+		Op      string
+		Range   string
+		Time    int64
+		Size    int64
+	}
+	TraceWriter struct {
+		w *csv.Writer
+	}
+	TraceReader struct {
+		r *csv.Reader
+	}
+)
+
+var traceHdr = []string{"time", "op", "bucket", "object", "size", "range"}
+
+func NewTraceWriter(w io.Writer) (*TraceWriter, error) {
+	tw := &TraceWriter{w: csv.NewWriter(w)}
+	return tw, tw.w.Write(traceHdr)
+}
+
+func (tw *TraceWriter) Write(rec *TraceRec) error {
+	return tw.w.Write([]string{
+		strconv.FormatInt(rec.Time, 10), rec.Op, rec.Bck, rec.ObjName, strconv.FormatInt(rec.Size, 10), rec.Range,
+	})
+}
+
+func (tw *TraceWriter) Flush() error {
+	tw.w.Flush()
+	return tw.w.Error()
+}
+
+func NewTraceReader(r io.Reader) (*TraceReader, error) {
+	tr := &TraceReader{r: csv.NewReader(r)}
+	tr.r.FieldsPerRecord = len(traceHdr)
+	tr.r.ReuseRecord = true
+	hdr, err := tr.r.Read()
+	if err != nil {
+		return nil, fmt.Errorf("invalid workload trace: %w", err)
+	}
+	if strings.Join(hdr, ",") != strings.Join(traceHdr, ",") {
+		return nil, fmt.Errorf("invalid workload trace header %q", hdr)
+	}
+	return tr, nil
+}
+
+// returns io.EOF at the end
+func (tr *TraceReader) Next() (*TraceRec, error) {
+	fields, err := tr.r.Read()
+	if err != nil {
+		return nil, err
+	}
+	rec := &TraceRec{Op: fields[1], Bck: fields[2], ObjName: fields[3], Range: fields[5]}
+	if rec.Time, err = strconv.ParseInt(fields[0], 10, 64); err != nil {
+		return nil, fmt.Errorf("invalid workload trace record %q: %w", fields, err)
+	}
+	if rec.Size, err = strconv.ParseInt(fields[4], 10, 64); err != nil {
+		return nil, fmt.Errorf("invalid workload trace record %q: %w", fields, err)
+	}
+	if rec.Op == "" || rec.Bck == "" {
+		return nil, errors.New("invalid workload trace record: missing op or bucket")
+	}
+	return rec, nil
+}
@@ -28,6 +28,7 @@ type (
 		IdleConnsPerHost int
 		MaxIdleConns     int
 		SndRcvBufSize    int
+		TOS              int // IP_TOS (DSCP << 2); see DSCPConf
 		WriteBufferSize  int
 		ReadBufferSize   int
 		UseHTTPProxyEnv  bool
@@ -55,7 +56,7 @@ func NewTransport(cargs TransportArgs) *http.Transport {
 		KeepAlive: 30 * time.Second,
 	}
 	// setsockopt when non-zero, otherwise use TCP defaults
-	if cargs.SndRcvBufSize > 0 {
+	if cargs.SndRcvBufSize > 0 || cargs.TOS > 0 {
 		dialer.Control = cargs.setSockOpt
 	}
 	transport := &http.Transport{
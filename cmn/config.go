@@ -25,6 +25,7 @@ import (
 	"github.com/NVIDIA/aistore/cmn/fname"
 	"github.com/NVIDIA/aistore/cmn/jsp"
 	"github.com/NVIDIA/aistore/cmn/nlog"
+	"github.com/NVIDIA/aistore/sys"
 	jsoniter "github.com/json-iterator/go"
 )
 
@@ -57,6 +58,11 @@ type (
 		HostNet   LocalNetConfig `json:"host_net"`
 		FSP       FSPConf        `json:"fspaths"`
 		TestFSP   TestFSPConf    `json:"test_fspaths"`
+		NUMA      NumaConf       `json:"numa"`
+		Topology  TopologyConf   `json:"topology"`
+
+		// proxy only (and typically, single-node dev/test deployments)
+		AuthN EmbeddedAuthNConf `json:"authn"`
 	}
 
 	// ais node: (local) network config
@@ -67,6 +73,8 @@ type (
 		Port                 int    `json:"port,string"`               // listening port
 		PortIntraControl     int    `json:"port_intra_control,string"` // --/-- for intra-cluster control
 		PortIntraData        int    `json:"port_intra_data,string"`    // --/-- for intra-cluster data
+		// additional (comma-separated) intra-cluster data NICs (multihoming): same port_intra_data
+		HostnameIntraDataExtra string `json:"hostname_intra_data_extra,omitempty"`
 		// omit
 		UseIntraControl bool `json:"-"`
 		UseIntraData    bool `json:"-"`
@@ -86,6 +94,31 @@ type (
 		Count    int    `json:"count"`
 		Instance int    `json:"instance"`
 	}
+
+	// ais node: CPU affinity (applies upon startup; see sys/numa.go)
+	NumaConf struct {
+		CPUs      string `json:"cpus,omitempty"`       // CPU list (e.g. "0-15,32-47") to run this node on - all goroutines incl. HTTP handlers
+		Nodes     string `json:"nodes,omitempty"`      // alternatively, NUMA node list (e.g. "0") - to run on their CPUs
+		DiskLocal bool   `json:"disk_local,omitempty"` // pin per-mountpath joggers to the CPUs of the disk-local NUMA node
+
+		// IRQ affinity (best effort - requires privileges; conflicts with `irqbalance`)
+		IrqNICs  string `json:"irq_nics,omitempty"`  // network interfaces (e.g. "eth0,eth1") to steer IRQs of - to the CPUs above
+		IrqDisks bool   `json:"irq_disks,omitempty"` // steer IRQs of the mountpath disks to the CPUs of their (disk-local) NUMA node
+	}
+
+	// ais node: (optional) network topology labels - see meta.Snode.Topo
+	// when empty, K8s deployments use the respective node labels (see cmn/k8s)
+	TopologyConf struct {
+		Zone string `json:"zone,omitempty"`
+		Rack string `json:"rack,omitempty"`
+	}
+
+	// ais proxy: run AuthN server in-process, with its config and DB in <confdir>/authn
+	// (see cmd/authn/svc); the cluster's `auth.secret` is used to sign tokens
+	EmbeddedAuthNConf struct {
+		Enabled bool `json:"enabled,omitempty"`
+		Port    int  `json:"port,omitempty"` // AuthN API port (0: as configured in authn.json, default 52001)
+	}
 )
 
 // global configuration
@@ -122,6 +155,9 @@ type (
 		// metadata write policy: (immediate | delayed | never)
 		WritePolicy WritePolicyConf `json:"write_policy"`
 
+		// self-service bucket provisioning: create-bucket policies
+		Provision ProvisionConf `json:"provision" allow:"cluster"`
+
 		// standalone enumerated features that can be configured
 		// to flip assorted global defaults (see cmn/feat/feat.go)
 		Features feat.Flags `json:"features,string" allow:"cluster"`
@@ -158,6 +194,7 @@ type (
 		TCB         *TCBConfToSet         `json:"tcb,omitempty"`
 		WritePolicy *WritePolicyConfToSet `json:"write_policy,omitempty"`
 		Proxy       *ProxyConfToSet       `json:"proxy,omitempty"`
+		Provision   *ProvisionConfToSet   `json:"provision,omitempty"`
 		Features    *feat.Flags           `json:"features,string,omitempty"`
 
 		// LocalConfig
@@ -171,14 +208,19 @@ type (
 	BackendConfAIS map[string][]string // cluster alias -> [urls...]
 
 	MirrorConf struct {
-		Copies  int64 `json:"copies"`       // num copies
-		Burst   int   `json:"burst_buffer"` // xaction channel (buffer) size
-		Enabled bool  `json:"enabled"`      // enabled (to generate copies)
+		Copies int64 `json:"copies"`       // num copies
+		Burst  int   `json:"burst_buffer"` // xaction channel (buffer) size
+		// hedged reads: when reading the (load-balanced) copy takes longer than the specified delay,
+		// issue a second read against another copy and serve whichever completes first;
+		// zero (default) disables hedging; a good starting value would be observed p95 GET latency
+		HedgeDelay cos.Duration `json:"hedge_delay"`
+		Enabled    bool         `json:"enabled"` // enabled (to generate copies)
 	}
 	MirrorConfToSet struct {
-		Copies  *int64 `json:"copies,omitempty"`
-		Burst   *int   `json:"burst_buffer,omitempty"`
-		Enabled *bool  `json:"enabled,omitempty"`
+		Copies     *int64        `json:"copies,omitempty"`
+		Burst      *int          `json:"burst_buffer,omitempty"`
+		HedgeDelay *cos.Duration `json:"hedge_delay,omitempty"`
+		Enabled    *bool         `json:"enabled,omitempty"`
 	}
 
 	ECConf struct {
@@ -231,14 +273,31 @@ type (
 		FlushTime cos.Duration `json:"flush_time"` // log flush interval
 		StatsTime cos.Duration `json:"stats_time"` // (not used)
 		ToStderr  bool         `json:"to_stderr"`  // Log only to stderr instead of files.
+		Ship      LogShipConf  `json:"ship"`       // ship logs to a central endpoint (see nlog.Ship)
+	}
+	// log shipping is disabled when the URL is empty (default)
+	LogShipConf struct {
+		URL       string       `json:"url"`        // HTTP(S) endpoint
+		Format    string       `json:"format"`     // "json" (newline-delimited, default) or "fluentd" (JSON array for Fluentd `in_http`)
+		BatchSize int          `json:"batch_size"` // max number of log lines per request
+		FlushTime cos.Duration `json:"flush_time"` // max time to hold pending log lines
+		QueueSize int          `json:"queue_size"` // max number of pending lines; the excess gets dropped (never blocking logging)
 	}
 	LogConfToSet struct {
-		Level     *cos.LogLevel `json:"level,omitempty"`
-		ToStderr  *bool         `json:"to_stderr,omitempty"`
-		MaxSize   *cos.SizeIEC  `json:"max_size,omitempty"`
-		MaxTotal  *cos.SizeIEC  `json:"max_total,omitempty"`
+		Level     *cos.LogLevel     `json:"level,omitempty"`
+		ToStderr  *bool             `json:"to_stderr,omitempty"`
+		MaxSize   *cos.SizeIEC      `json:"max_size,omitempty"`
+		MaxTotal  *cos.SizeIEC      `json:"max_total,omitempty"`
+		FlushTime *cos.Duration     `json:"flush_time,omitempty"`
+		StatsTime *cos.Duration     `json:"stats_time,omitempty"`
+		Ship      *LogShipConfToSet `json:"ship,omitempty"`
+	}
+	LogShipConfToSet struct {
+		URL       *string       `json:"url,omitempty"`
+		Format    *string       `json:"format,omitempty"`
+		BatchSize *int          `json:"batch_size,omitempty"`
 		FlushTime *cos.Duration `json:"flush_time,omitempty"`
-		StatsTime *cos.Duration `json:"stats_time,omitempty"`
+		QueueSize *int          `json:"queue_size,omitempty"`
 	}
 
 	// NOTE: StatsTime is a one important timer
@@ -246,11 +305,20 @@ type (
 		StatsTime     cos.Duration `json:"stats_time"`      // collect and publish stats; other house-keeping
 		RetrySyncTime cos.Duration `json:"retry_sync_time"` // metasync retry
 		NotifTime     cos.Duration `json:"notif_time"`      // (IC notifications)
+		// retain stats_time-resolution history of node metrics for this long (zero: disabled)
+		// see also: apc.QparamSince, apc.QparamUntil
+		StatsHistory cos.Duration `json:"stats_history,omitempty"`
+		// histogram buckets for per-bucket GET and PUT latencies: comma-separated upper bounds,
+		// e.g. "10ms,50ms,250ms,1s,5s" (empty: no histograms) - see ParseLatencyBuckets
+		LatencyBuckets string `json:"latency_buckets,omitempty"`
 	}
 	PeriodConfToSet struct {
 		StatsTime     *cos.Duration `json:"stats_time,omitempty"`
 		RetrySyncTime *cos.Duration `json:"retry_sync_time,omitempty"`
 		NotifTime     *cos.Duration `json:"notif_time,omitempty"`
+		StatsHistory  *cos.Duration `json:"stats_history,omitempty"`
+
+		LatencyBuckets *string `json:"latency_buckets,omitempty"`
 	}
 
 	// maximum intra-cluster latencies (in the increasing order)
@@ -289,13 +357,28 @@ type (
 		PrimaryURL   string `json:"primary_url"`
 		OriginalURL  string `json:"original_url"`
 		DiscoveryURL string `json:"discovery_url"`
+		// DNS name that resolves to (candidate) proxies to join the cluster via:
+		// either SRV, e.g. "_ais._tcp.cluster.local", or hostname[:port] (e.g., K8s headless service)
+		DiscoveryDNS string `json:"discovery_dns,omitempty"`
 		NonElectable bool   `json:"non_electable"`
+
+		// number of proxies in the Information Center (IC);
+		// zero (default) - auto-scale with the number of proxies (see meta.AutoCountIC)
+		ICCount int `json:"ic_count,omitempty"`
+
+		// workload capture (see CaptureConf)
+		Capture CaptureConf `json:"capture"`
 	}
 	ProxyConfToSet struct {
 		PrimaryURL   *string `json:"primary_url,omitempty"`
 		OriginalURL  *string `json:"original_url,omitempty"`
 		DiscoveryURL *string `json:"discovery_url,omitempty"`
+		DiscoveryDNS *string `json:"discovery_dns,omitempty"`
 		NonElectable *bool   `json:"non_electable,omitempty"`
+
+		ICCount *int `json:"ic_count,omitempty"`
+
+		Capture *CaptureConfToSet `json:"capture,omitempty"`
 	}
 
 	SpaceConf struct {
@@ -315,12 +398,18 @@ type (
 		// Out-of-Space: if exceeded, the target starts failing new PUTs and keeps
 		// failing them until its local used-cap gets back below HighWM (see above)
 		OOS int64 `json:"out_of_space"`
+
+		// AbortIncomplete: multipart uploads (S3 API) and appends (native API) that remain
+		// incomplete for longer than this get periodically aborted, and their leftovers
+		// (part files, workfiles) removed (see apc.ActAbortIncomplete)
+		AbortIncomplete cos.Duration `json:"abort_incomplete,omitempty"`
 	}
 	SpaceConfToSet struct {
-		CleanupWM *int64 `json:"cleanupwm,omitempty"`
-		LowWM     *int64 `json:"lowwm,omitempty"`
-		HighWM    *int64 `json:"highwm,omitempty"`
-		OOS       *int64 `json:"out_of_space,omitempty"`
+		CleanupWM       *int64        `json:"cleanupwm,omitempty"`
+		LowWM           *int64        `json:"lowwm,omitempty"`
+		HighWM          *int64        `json:"highwm,omitempty"`
+		OOS             *int64        `json:"out_of_space,omitempty"`
+		AbortIncomplete *cos.Duration `json:"abort_incomplete,omitempty"`
 	}
 
 	LRUConf struct {
@@ -346,6 +435,19 @@ type (
 		DiskUtilMaxWM   int64        `json:"disk_util_max_wm"`
 		IostatTimeLong  cos.Duration `json:"iostat_time_long"`
 		IostatTimeShort cos.Duration `json:"iostat_time_short"`
+		// utilization-based admission of client PUTs (reads are never throttled):
+		// new PUTs to a mountpath utilized at or above `PutThrottleWM` percent are delayed
+		// for up to `PutThrottleTime` and then, if still above the watermark, fail with 429;
+		// zero watermark (default) disables throttling
+		PutThrottleWM   int64        `json:"put_throttle_wm,omitempty"`
+		PutThrottleTime cos.Duration `json:"put_throttle_time,omitempty"`
+
+		// preemption of background xactions (LRU, space cleanup, etc. - see xact.PrioBackground):
+		// pause when any mountpath is utilized at or above `PreemptUtilWM` percent, or when
+		// average GET latency exceeds `PreemptGetLatency`; resume once the load subsides;
+		// zero (default) disables the respective trigger
+		PreemptUtilWM     int64        `json:"preempt_util_wm,omitempty"`
+		PreemptGetLatency cos.Duration `json:"preempt_get_latency,omitempty"`
 	}
 	DiskConfToSet struct {
 		DiskUtilLowWM   *int64        `json:"disk_util_low_wm,omitempty"`
@@ -353,19 +455,28 @@ type (
 		DiskUtilMaxWM   *int64        `json:"disk_util_max_wm,omitempty"`
 		IostatTimeLong  *cos.Duration `json:"iostat_time_long,omitempty"`
 		IostatTimeShort *cos.Duration `json:"iostat_time_short,omitempty"`
+		PutThrottleWM   *int64        `json:"put_throttle_wm,omitempty"`
+		PutThrottleTime *cos.Duration `json:"put_throttle_time,omitempty"`
+
+		PreemptUtilWM     *int64        `json:"preempt_util_wm,omitempty"`
+		PreemptGetLatency *cos.Duration `json:"preempt_get_latency,omitempty"`
 	}
 
 	RebalanceConf struct {
-		Compression   string       `json:"compression"`       // enum { CompressAlways, ... } in api/apc/compression.go
-		DestRetryTime cos.Duration `json:"dest_retry_time"`   // max wait for ACKs & neighbors to complete
-		SbundleMult   int          `json:"bundle_multiplier"` // stream-bundle multiplier: num streams to destination
-		Enabled       bool         `json:"enabled"`           // true=auto-rebalance | manual rebalancing
+		Compression   string       `json:"compression"`         // enum { CompressAlways, ... } in api/apc/compression.go
+		DestRetryTime cos.Duration `json:"dest_retry_time"`     // max wait for ACKs & neighbors to complete
+		HotAge        cos.Duration `json:"hot_age,omitempty"`   // accessed within (dflt. DfltRebHotAge) => "hot" (see HotFirst)
+		SbundleMult   int          `json:"bundle_multiplier"`   // stream-bundle multiplier: num streams to destination
+		Enabled       bool         `json:"enabled"`             // true=auto-rebalance | manual rebalancing
+		HotFirst      bool         `json:"hot_first,omitempty"` // migrate recently accessed ("hot") objects first
 	}
 	RebalanceConfToSet struct {
 		DestRetryTime *cos.Duration `json:"dest_retry_time,omitempty"`
 		Compression   *string       `json:"compression,omitempty"`
+		HotAge        *cos.Duration `json:"hot_age,omitempty"`
 		SbundleMult   *int          `json:"bundle_multiplier"`
 		Enabled       *bool         `json:"enabled,omitempty"`
+		HotFirst      *bool         `json:"hot_first,omitempty"`
 	}
 
 	ResilverConf struct {
@@ -436,14 +547,29 @@ type (
 	NetConf struct {
 		L4   L4Conf   `json:"l4"`
 		HTTP HTTPConf `json:"http"`
+		// how to choose between multiple intra-cluster data endpoints of a (multi-homed) peer:
+		// enum { DataMultihomeStripe (default), DataMultihomeSubnet } - see LocalNetConfig.HostnameIntraDataExtra
+		DataMultihome string `json:"data_multihome,omitempty"`
 	}
 	NetConfToSet struct {
-		HTTP *HTTPConfToSet `json:"http,omitempty"`
+		HTTP          *HTTPConfToSet `json:"http,omitempty"`
+		DataMultihome *string        `json:"data_multihome,omitempty"`
 	}
 
 	L4Conf struct {
-		Proto         string `json:"proto"`           // tcp, udp
-		SndRcvBufSize int    `json:"sndrcv_buf_size"` // SO_RCVBUF and SO_SNDBUF
+		Proto         string   `json:"proto"`           // tcp, udp
+		SndRcvBufSize int      `json:"sndrcv_buf_size"` // SO_RCVBUF and SO_SNDBUF
+		DSCP          DSCPConf `json:"dscp"`            // QoS marking (IP_TOS) - see below
+	}
+	// DSCP (RFC 2474) code points to mark outgoing packets with - separately for each network
+	// (must be in the range [0, 63]; zero - do not mark, use system default)
+	// - applies to both accepted and dialed (intra-cluster) connections
+	// - when intra-control and/or intra-data networks are not configured, the traffic
+	//   is carried by the public network and marked accordingly
+	DSCPConf struct {
+		Public       int `json:"public"`
+		IntraControl int `json:"intra_control"`
+		IntraData    int `json:"intra_data"`
 	}
 
 	HTTPConf struct {
@@ -458,18 +584,54 @@ type (
 		UseHTTPS        bool   `json:"use_https"`         // use HTTPS
 		SkipVerifyCrt   bool   `json:"skip_verify"`       // skip X.509 cert verification (used with self-signed certs)
 		Chunked         bool   `json:"chunked_transfer"`  // (https://tools.ietf.org/html/rfc7230#page-36; not used since 02/23)
+		// zstd-compress intra-cluster control-plane payloads (metasync, dsort records) larger than this size;
+		// zero (default) disables compression
+		CompressAbove cos.SizeIEC `json:"compress_above,omitempty"`
+		// request size limits and slow-request detection
+		Limits HTTPLimitsConf `json:"limits"`
+	}
+	// limits apply to requests arriving from outside the cluster (public API);
+	// zero means "no limit" (default)
+	HTTPLimitsConf struct {
+		// request body: control-plane (API messages) and object PUT (including S3 and append), respectively
+		MaxCtrlBody cos.SizeIEC `json:"max_ctrl_body"`
+		MaxPutBody  cos.SizeIEC `json:"max_put_body"`
+		// per-endpoint overrides, e.g. "buckets=1MiB, cluster=64KiB" where endpoint is the first URL path
+		// element following the API version ("s3" for S3 API); zero size - no limit
+		MaxBodyOverrides string `json:"max_body_overrides,omitempty"`
+		// request headers: max count and total size (the latter is also passed to http.Server
+		// as MaxHeaderBytes and requires restart)
+		MaxHeaderCount int         `json:"max_header_count"`
+		MaxHeaderSize  cos.SizeIEC `json:"max_header_size"`
+		// slow request (slowloris): upon `slow_grace` (default 10s) the body must keep arriving
+		// at `min_recv_rate` bytes per second (or faster) on average
+		MinRecvRate cos.SizeIEC  `json:"min_recv_rate"`
+		SlowGrace   cos.Duration `json:"slow_grace"`
+
+		overrides map[string]int64 `json:"-"` // parsed MaxBodyOverrides
+	}
+	HTTPLimitsConfToSet struct {
+		MaxCtrlBody      *cos.SizeIEC  `json:"max_ctrl_body,omitempty"`
+		MaxPutBody       *cos.SizeIEC  `json:"max_put_body,omitempty"`
+		MaxBodyOverrides *string       `json:"max_body_overrides,omitempty"`
+		MaxHeaderCount   *int          `json:"max_header_count,omitempty"`
+		MaxHeaderSize    *cos.SizeIEC  `json:"max_header_size,omitempty"`
+		MinRecvRate      *cos.SizeIEC  `json:"min_recv_rate,omitempty"`
+		SlowGrace        *cos.Duration `json:"slow_grace,omitempty"`
 	}
 	HTTPConfToSet struct {
-		Certificate     *string `json:"server_crt,omitempty"`
-		CertKey         *string `json:"server_key,omitempty"`
-		ServerNameTLS   *string `json:"domain_tls,omitempty"`
-		ClientCA        *string `json:"client_ca_tls,omitempty"`
-		WriteBufferSize *int    `json:"write_buffer_size,omitempty" list:"readonly"`
-		ReadBufferSize  *int    `json:"read_buffer_size,omitempty" list:"readonly"`
-		ClientAuthTLS   *int    `json:"client_auth_tls,omitempty"`
-		UseHTTPS        *bool   `json:"use_https,omitempty"`
-		SkipVerifyCrt   *bool   `json:"skip_verify,omitempty"`
-		Chunked         *bool   `json:"chunked_transfer,omitempty"`
+		Certificate     *string              `json:"server_crt,omitempty"`
+		CertKey         *string              `json:"server_key,omitempty"`
+		ServerNameTLS   *string              `json:"domain_tls,omitempty"`
+		ClientCA        *string              `json:"client_ca_tls,omitempty"`
+		WriteBufferSize *int                 `json:"write_buffer_size,omitempty" list:"readonly"`
+		ReadBufferSize  *int                 `json:"read_buffer_size,omitempty" list:"readonly"`
+		ClientAuthTLS   *int                 `json:"client_auth_tls,omitempty"`
+		UseHTTPS        *bool                `json:"use_https,omitempty"`
+		SkipVerifyCrt   *bool                `json:"skip_verify,omitempty"`
+		Chunked         *bool                `json:"chunked_transfer,omitempty"`
+		CompressAbove   *cos.SizeIEC         `json:"compress_above,omitempty"`
+		Limits          *HTTPLimitsConfToSet `json:"limits,omitempty"`
 	}
 
 	FSHCConf struct {
@@ -549,6 +711,10 @@ type (
 		DsorterMemThreshold string       `json:"dsorter_mem_threshold"`
 		Compression         string       `json:"compression"`       // {CompressAlways,...} in api/apc/compression.go
 		SbundleMult         int          `json:"bundle_multiplier"` // stream-bundle multiplier: num to destination
+		// job queueing (zero: unlimited, no queueing)
+		MaxJobs        int `json:"max_jobs"`          // max number of concurrently running jobs (cluster-wide)
+		MaxJobsPerUser int `json:"max_jobs_per_user"` // ditto, per user (AuthN identity)
+		MaxJobsPerRole int `json:"max_jobs_per_role"` // ditto, per AuthN role (jobs of all users that have the role)
 	}
 	DsortConfToSet struct {
 		DuplicatedRecords   *string       `json:"duplicated_records,omitempty"`
@@ -560,6 +726,9 @@ type (
 		DsorterMemThreshold *string       `json:"dsorter_mem_threshold,omitempty"`
 		Compression         *string       `json:"compression,omitempty"`
 		SbundleMult         *int          `json:"bundle_multiplier,omitempty"`
+		MaxJobs             *int          `json:"max_jobs,omitempty"`
+		MaxJobsPerUser      *int          `json:"max_jobs_per_user,omitempty"`
+		MaxJobsPerRole      *int          `json:"max_jobs_per_role,omitempty"`
 	}
 
 	TransportConf struct {
@@ -592,6 +761,9 @@ type (
 		HousekeepTime  cos.Duration `json:"hk_time"`
 		MinPctTotal    int          `json:"min_pct_total"`
 		MinPctFree     int          `json:"min_pct_free"`
+		// total capacity of the (target's) in-memory cache of small hot objects
+		// that belong to buckets with `ram_cache` enabled; zero (default) disables caching
+		RAMCacheSize cos.SizeIEC `json:"ram_cache_size,omitempty"`
 	}
 	MemsysConfToSet struct {
 		MinFree        *cos.SizeIEC  `json:"min_free,omitempty"`
@@ -600,6 +772,7 @@ type (
 		HousekeepTime  *cos.Duration `json:"hk_time,omitempty"`
 		MinPctTotal    *int          `json:"min_pct_total,omitempty"`
 		MinPctFree     *int          `json:"min_pct_free,omitempty"`
+		RAMCacheSize   *cos.SizeIEC  `json:"ram_cache_size,omitempty"`
 	}
 
 	TCBConf struct {
@@ -678,6 +851,7 @@ var (
 	_ Validator = (*MemsysConf)(nil)
 	_ Validator = (*TCBConf)(nil)
 	_ Validator = (*WritePolicyConf)(nil)
+	_ Validator = (*ProvisionConf)(nil)
 
 	_ PropsValidator = (*CksumConf)(nil)
 	_ PropsValidator = (*SpaceConf)(nil)
@@ -700,36 +874,51 @@ var (
 
 // main config validator
 func (c *Config) Validate() error {
-	if c.ConfigDir == "" {
-		return errors.New("invalid confdir value (must be non-empty)")
+	if errs := c.validate(false /*all*/); len(errs) > 0 {
+		return errs[0]
+	}
+	return nil
+}
+
+// same as above but does not stop at the first error (see set-config: validate-only)
+func (c *Config) ValidateAll() []error { return c.validate(true) }
+
+func (c *Config) validate(all bool) (errs []error) {
+	// returns true to keep going
+	add := func(err error) bool {
+		if err != nil {
+			errs = append(errs, err)
+			return all
+		}
+		return true
+	}
+	if c.ConfigDir == "" && !add(errors.New("invalid confdir value (must be non-empty)")) {
+		return errs
 	}
-	if c.LogDir == "" {
-		return errors.New("invalid log dir value (must be non-empty)")
+	if c.LogDir == "" && !add(errors.New("invalid log dir value (must be non-empty)")) {
+		return errs
 	}
 
 	// NOTE: These two validations require more context and so we call them explicitly;
 	//       The rest all implement generic interface.
-	if err := c.LocalConfig.HostNet.Validate(c); err != nil {
-		return err
+	if !add(c.LocalConfig.HostNet.Validate(c)) {
+		return errs
 	}
-	if err := c.LocalConfig.FSP.Validate(c); err != nil {
-		return err
+	if !add(c.LocalConfig.FSP.Validate(c)) {
+		return errs
 	}
-	if err := c.LocalConfig.TestFSP.Validate(c); err != nil {
-		return err
+	if !add(c.LocalConfig.TestFSP.Validate(c)) {
+		return errs
 	}
 
 	opts := IterOpts{VisitAll: true}
-	return IterFields(c, vdate, opts)
-}
-
-func vdate(_ string, field IterField) (error, bool) {
-	if v, ok := field.Value().(Validator); ok {
-		if err := v.Validate(); err != nil {
-			return err, false
+	IterFields(c, func(_ string, field IterField) (error, bool) {
+		if v, ok := field.Value().(Validator); ok {
+			return nil, !add(v.Validate())
 		}
-	}
-	return nil, false
+		return nil, false
+	}, opts)
+	return errs
 }
 
 func (c *Config) SetRole(role string) {
@@ -753,6 +942,20 @@ func (c *Config) TestingEnv() bool {
 	return c.LocalConfig.TestingEnv()
 }
 
+// NetTOS returns IP_TOS for the traffic carried by a given network,
+// falling back to intra-control and, subsequently, public network when not separately configured
+func (c *Config) NetTOS(network string) int {
+	dscp := &c.Net.L4.DSCP
+	switch {
+	case network == NetIntraData && c.HostNet.UseIntraData:
+		return dscp2tos(dscp.IntraData)
+	case network != NetPublic && c.HostNet.UseIntraControl:
+		return dscp2tos(dscp.IntraControl)
+	default:
+		return dscp2tos(dscp.Public)
+	}
+}
+
 ///////////////////
 // ClusterConfig //
 ///////////////////
@@ -786,6 +989,69 @@ func (c *LocalConfig) DelPath(mpath string) {
 	c.FSP.Paths.Delete(mpath)
 }
 
+//////////////
+// NumaConf //
+//////////////
+
+func (c *NumaConf) Validate() error {
+	if c.CPUs != "" && c.Nodes != "" {
+		return errors.New("invalid numa config: 'cpus' and 'nodes' are mutually exclusive")
+	}
+	if _, err := sys.ParseCPUList(c.CPUs); err != nil {
+		return fmt.Errorf("invalid numa.cpus: %v", err)
+	}
+	if _, err := sys.ParseCPUList(c.Nodes); err != nil {
+		return fmt.Errorf("invalid numa.nodes: %v", err)
+	}
+	if c.IrqNICs != "" && c.CPUs == "" && c.Nodes == "" {
+		return errors.New("invalid numa config: 'irq_nics' requires either 'cpus' or 'nodes'")
+	}
+	return nil
+}
+
+func (c *NumaConf) NICs() []string {
+	if c.IrqNICs == "" {
+		return nil
+	}
+	return strings.Split(c.IrqNICs, ",")
+}
+
+// returns CPUs to restrict this node to (nil: no restriction)
+func (c *NumaConf) Affinity() ([]int, error) {
+	if c.Nodes == "" {
+		return sys.ParseCPUList(c.CPUs)
+	}
+	nodes, err := sys.ParseCPUList(c.Nodes) // (same format)
+	if err != nil {
+		return nil, err
+	}
+	return sys.NodeCPUs(nodes...)
+}
+
+///////////////////////
+// EmbeddedAuthNConf //
+///////////////////////
+
+func (c *EmbeddedAuthNConf) Validate() error {
+	if c.Port < 0 || c.Port > 0xffff {
+		return fmt.Errorf("invalid authn.port %d", c.Port)
+	}
+	return nil
+}
+
+//////////////////
+// TopologyConf //
+//////////////////
+
+func (c *TopologyConf) Validate() error {
+	for _, label := range []string{c.Zone, c.Rack} {
+		if strings.ContainsAny(label, "/ ") {
+			return fmt.Errorf("invalid topology label %q (may not contain spaces or slashes)", label)
+		}
+	}
+	return nil
+}
+
 ////////////////
 // PeriodConf //
 ////////////////
@@ -803,9 +1069,45 @@ func (c *PeriodConf) Validate() error {
 		return fmt.Errorf("invalid periodic.notif_time=%s (expected range [1s, 1m])",
 			c.StatsTime)
 	}
+	if c.StatsHistory != 0 && (c.StatsHistory < c.StatsTime || c.StatsHistory.D() > 7*24*time.Hour) {
+		return fmt.Errorf("invalid periodic.stats_history=%s (expected zero or range [periodic.stats_time, 7d])",
+			c.StatsHistory)
+	}
+	if _, err := ParseLatencyBuckets(c.LatencyBuckets); err != nil {
+		return fmt.Errorf("invalid periodic.latency_buckets=%q: %v", c.LatencyBuckets, err)
+	}
 	return nil
 }
 
+// MaxLatencyBuckets (not counting the implicit +Inf)
+const MaxLatencyBuckets = 32
+
+// parse and validate comma-separated increasing upper bounds (empty string: none)
+func ParseLatencyBuckets(s string) (bounds []time.Duration, err error) {
+	if s == "" {
+		return nil, nil
+	}
+	items := strings.Split(s, ",")
+	if len(items) > MaxLatencyBuckets {
+		return nil, fmt.Errorf("too many buckets (%d > %d)", len(items), MaxLatencyBuckets)
+	}
+	bounds = make([]time.Duration, 0, len(items))
+	for _, item := range items {
+		d, err := time.ParseDuration(strings.TrimSpace(item))
+		if err != nil {
+			return nil, err
+		}
+		if d <= 0 {
+			return nil, fmt.Errorf("non-positive bucket %q", item)
+		}
+		if l := len(bounds); l > 0 && d <= bounds[l-1] {
+			return nil, fmt.Errorf("buckets must be strictly increasing (%v <= %v)", d, bounds[l-1])
+		}
+		bounds = append(bounds, d)
+	}
+	return bounds, nil
+}
+
 /////////////
 // LogConf //
 /////////////
@@ -832,6 +1134,50 @@ func (c *LogConf) Validate() error {
 	return nil
 }
 
+/////////////////
+// LogShipConf //
+/////////////////
+
+const (
+	LogShipBatchDflt = 512
+	LogShipFlushDflt = 5 * time.Second
+	LogShipQueueDflt = 64 * 1024
+)
+
+func (c *LogShipConf) Validate() error {
+	if c.BatchSize < 0 || c.QueueSize < 0 || c.FlushTime < 0 {
+		return fmt.Errorf("invalid log.ship: batch_size %d, queue_size %d, flush_time %v (expecting non-negative)",
+			c.BatchSize, c.QueueSize, c.FlushTime)
+	}
+	if c.URL == "" {
+		return nil
+	}
+	u, err := url.Parse(c.URL)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return fmt.Errorf("invalid log.ship.url %q (expecting http(s)://host[:port][/path])", c.URL)
+	}
+	switch c.Format {
+	case "":
+		c.Format = nlog.ShipJSON
+	case nlog.ShipJSON, nlog.ShipFluentd:
+	default:
+		return fmt.Errorf("invalid log.ship.format %q (expecting %q or %q)", c.Format, nlog.ShipJSON, nlog.ShipFluentd)
+	}
+	if c.BatchSize == 0 {
+		c.BatchSize = LogShipBatchDflt
+	}
+	if c.FlushTime == 0 {
+		c.FlushTime = cos.Duration(LogShipFlushDflt)
+	}
+	if c.QueueSize == 0 {
+		c.QueueSize = LogShipQueueDflt
+	}
+	if c.QueueSize < c.BatchSize {
+		return fmt.Errorf("invalid log.ship: queue_size %d must be >= batch_size %d", c.QueueSize, c.BatchSize)
+	}
+	return nil
+}
+
 ////////////////
 // ClientConf //
 ////////////////
@@ -959,6 +1305,9 @@ func (c BackendConfAIS) String() (s string) {
 // DiskConf //
 //////////////
 
+// upper bound on delaying a PUT to a highly utilized mountpath (see PutThrottleTime)
+const MaxPutThrottleTime = 10 * time.Second
+
 func (c *DiskConf) Validate() (err error) {
 	lwm, hwm, maxwm := c.DiskUtilLowWM, c.DiskUtilHighWM, c.DiskUtilMaxWM
 	if lwm <= 0 || hwm <= lwm || maxwm <= hwm || maxwm > 100 {
@@ -974,6 +1323,18 @@ func (c *DiskConf) Validate() (err error) {
 		return fmt.Errorf("disk.iostat_time_long %v shorter than disk.iostat_time_short %v",
 			c.IostatTimeLong, c.IostatTimeShort)
 	}
+	if c.PutThrottleWM < 0 || c.PutThrottleWM > 100 {
+		return fmt.Errorf("invalid disk.put_throttle_wm %d (expecting range [0 - 100])", c.PutThrottleWM)
+	}
+	if c.PutThrottleTime < 0 || c.PutThrottleTime.D() > MaxPutThrottleTime {
+		return fmt.Errorf("invalid disk.put_throttle_time %v (expecting range [0 - %v])", c.PutThrottleTime, MaxPutThrottleTime)
+	}
+	if c.PreemptUtilWM < 0 || c.PreemptUtilWM > 100 {
+		return fmt.Errorf("invalid disk.preempt_util_wm %d (expecting range [0 - 100])", c.PreemptUtilWM)
+	}
+	if c.PreemptGetLatency < 0 {
+		return fmt.Errorf("invalid disk.preempt_get_latency %v (expecting non-negative)", c.PreemptGetLatency)
+	}
 	return nil
 }
 
@@ -981,9 +1342,21 @@ func (c *DiskConf) Validate() (err error) {
 // SpaceConf //
 ///////////////
 
+const (
+	DfltAbortIncomplete = 24 * time.Hour
+	minAbortIncomplete  = time.Minute
+)
+
 func (c *SpaceConf) Validate() (err error) {
 	if c.CleanupWM <= 0 || c.LowWM < c.CleanupWM || c.HighWM < c.LowWM || c.OOS < c.HighWM || c.OOS > 100 {
-		err = fmt.Errorf("invalid %s (expecting: 0 < cleanup < low < high < OOS < 100)", c)
+		return fmt.Errorf("invalid %s (expecting: 0 < cleanup < low < high < OOS < 100)", c)
+	}
+	switch d := c.AbortIncomplete.D(); {
+	case d == 0:
+		c.AbortIncomplete = cos.Duration(DfltAbortIncomplete)
+	case d < minAbortIncomplete:
+		err = fmt.Errorf("invalid space.abort_incomplete=%v (expecting 0 (default %v) or >= %v)",
+			d, DfltAbortIncomplete, minAbortIncomplete)
 	}
 	return
 }
@@ -1086,6 +1459,9 @@ func (c *MirrorConf) Validate() error {
 	if c.Copies < 2 || c.Copies > 32 {
 		return fmt.Errorf("invalid mirror.copies: %d (expected value in range [2, 32])", c.Copies)
 	}
+	if c.HedgeDelay < 0 {
+		return fmt.Errorf("invalid mirror.hedge_delay: %v (expected >= 0)", c.HedgeDelay)
+	}
 	return nil
 }
 
@@ -1104,6 +1480,28 @@ func (c *MirrorConf) String() string {
 	return fmt.Sprintf("%d copies", c.Copies)
 }
 
+///////////////
+// ProxyConf //
+///////////////
+
+// maximum number of proxies in the Information Center (IC)
+const MaxCountIC = 16
+
+func (c *ProxyConf) Validate() error {
+	if c.ICCount < 0 || c.ICCount > MaxCountIC {
+		return fmt.Errorf("invalid proxy.ic_count %d (expecting range [0, %d], where 0 means auto-scale)",
+			c.ICCount, MaxCountIC)
+	}
+	if c.DiscoveryDNS == "" {
+		return nil
+	}
+	if strings.Contains(c.DiscoveryDNS, "://") || strings.ContainsAny(c.DiscoveryDNS, " /") {
+		return fmt.Errorf("invalid proxy.discovery_dns %q (expecting SRV name, e.g. \"_ais._tcp.cluster.local\", or hostname[:port])",
+			c.DiscoveryDNS)
+	}
+	return nil
+}
+
 ////////////
 // ECConf //
 ////////////
@@ -1227,6 +1625,9 @@ func (c *NetConf) Validate() (err error) {
 	if c.L4.Proto != "tcp" {
 		return fmt.Errorf("l4 proto %q is not recognized (expecting %s)", c.L4.Proto, "tcp")
 	}
+	if err := c.L4.DSCP.Validate(); err != nil {
+		return err
+	}
 	c.HTTP.Proto = "http" // not validating: read-only, and can take only two values
 	if c.HTTP.UseHTTPS {
 		c.HTTP.Proto = "https"
@@ -1235,6 +1636,24 @@ func (c *NetConf) Validate() (err error) {
 		return fmt.Errorf("invalid client_auth_tls %d (expecting range [0 - %d])", c.HTTP.ClientAuthTLS,
 			tls.RequireAndVerifyClientCert)
 	}
+	if c.HTTP.CompressAbove < 0 {
+		return fmt.Errorf("invalid compress_above %d (expecting non-negative)", c.HTTP.CompressAbove)
+	}
+	switch c.DataMultihome {
+	case "", DataMultihomeStripe, DataMultihomeSubnet:
+	default:
+		return fmt.Errorf("invalid data_multihome %q (expecting %q or %q)", c.DataMultihome,
+			DataMultihomeStripe, DataMultihomeSubnet)
+	}
+	return nil
+}
+
+func (c *DSCPConf) Validate() error {
+	for _, v := range []int{c.Public, c.IntraControl, c.IntraData} {
+		if v < 0 || v > MaxDSCP {
+			return fmt.Errorf("invalid l4.dscp %d (expecting range [0 - %d])", v, MaxDSCP)
+		}
+	}
 	return nil
 }
 
@@ -1245,6 +1664,60 @@ func (c *HTTPConf) Validate() error {
 	return nil
 }
 
+////////////////////
+// HTTPLimitsConf //
+////////////////////
+
+const SlowGraceDflt = 10 * time.Second
+
+func (c *HTTPLimitsConf) Validate() error {
+	for _, v := range []cos.SizeIEC{c.MaxCtrlBody, c.MaxPutBody, c.MaxHeaderSize, c.MinRecvRate} {
+		if v < 0 {
+			return fmt.Errorf("invalid net.http.limits: negative size %d", v)
+		}
+	}
+	if c.MaxHeaderCount < 0 || c.SlowGrace < 0 {
+		return fmt.Errorf("invalid net.http.limits: max_header_count %d, slow_grace %v", c.MaxHeaderCount, c.SlowGrace)
+	}
+	if c.MinRecvRate > 0 && c.SlowGrace == 0 {
+		c.SlowGrace = cos.Duration(SlowGraceDflt)
+	}
+	c.overrides = nil
+	if c.MaxBodyOverrides == "" {
+		return nil
+	}
+	c.overrides = make(map[string]int64, 4)
+	for _, kv := range strings.Split(c.MaxBodyOverrides, ",") {
+		ep, val, ok := strings.Cut(strings.TrimSpace(kv), "=")
+		if !ok || ep == "" {
+			return fmt.Errorf("invalid net.http.limits.max_body_overrides %q (expecting comma-separated endpoint=size pairs)",
+				c.MaxBodyOverrides)
+		}
+		size, err := cos.ParseSize(strings.TrimSpace(val), cos.UnitsIEC)
+		if err != nil || size < 0 {
+			return fmt.Errorf("invalid net.http.limits.max_body_overrides %q: bad size %q", c.MaxBodyOverrides, val)
+		}
+		c.overrides[strings.TrimSpace(ep)] = size
+	}
+	return nil
+}
+
+// max request body size for a given endpoint (see above), zero - no limit
+func (c *HTTPLimitsConf) MaxBody(endpoint string, isPut bool) int64 {
+	if size, ok := c.overrides[endpoint]; ok {
+		return size
+	}
+	if isPut {
+		return int64(c.MaxPutBody)
+	}
+	return int64(c.MaxCtrlBody)
+}
+
+func (c *HTTPLimitsConf) Enabled() bool {
+	return c.MaxCtrlBody > 0 || c.MaxPutBody > 0 || c.overrides != nil || c.MaxHeaderCount > 0 ||
+		c.MaxHeaderSize > 0 || c.MinRecvRate > 0
+}
+
 // used intra-clients; see related: EnvToTLS()
 func (c *HTTPConf) ToTLS() TLSArgs {
 	return TLSArgs{
@@ -1323,10 +1796,17 @@ func (c *FSHCConf) Validate() error {
 
 const HostnameListSepa = ","
 
+// NetConf.DataMultihome
+const (
+	DataMultihomeStripe = "stripe" // round-robin all data endpoints of a given peer
+	DataMultihomeSubnet = "subnet" // prefer peer's endpoints that share subnet with (any of) local data NICs
+)
+
 func (c *LocalNetConfig) Validate(contextConfig *Config) (err error) {
 	c.Hostname = strings.ReplaceAll(c.Hostname, " ", "")
 	c.HostnameIntraControl = strings.ReplaceAll(c.HostnameIntraControl, " ", "")
 	c.HostnameIntraData = strings.ReplaceAll(c.HostnameIntraData, " ", "")
+	c.HostnameIntraDataExtra = strings.ReplaceAll(c.HostnameIntraDataExtra, " ", "")
 
 	if addr, over := ipsOverlap(c.Hostname, c.HostnameIntraControl); over {
 		return fmt.Errorf("public (%s) and intra-cluster control (%s) share the same: %q",
@@ -1370,6 +1850,21 @@ func (c *LocalNetConfig) Validate(contextConfig *Config) (err error) {
 	differentPorts = c.Port != c.PortIntraData
 	c.UseIntraData = (contextConfig.TestingEnv() || c.HostnameIntraData != "") &&
 		c.PortIntraData != 0 && (differentIPs || differentPorts)
+
+	if c.HostnameIntraDataExtra != "" {
+		if !c.UseIntraData {
+			return fmt.Errorf("hostname_intra_data_extra (%s) requires separate intra-cluster data network (%s:%d)",
+				c.HostnameIntraDataExtra, c.HostnameIntraData, c.PortIntraData)
+		}
+		if addr, over := ipsOverlap(c.HostnameIntraDataExtra, c.Hostname); over {
+			return fmt.Errorf("public (%s) and extra intra-cluster data (%s) share the same: %q",
+				c.Hostname, c.HostnameIntraDataExtra, addr)
+		}
+		if addr, over := ipsOverlap(c.HostnameIntraDataExtra, c.HostnameIntraData); over {
+			return fmt.Errorf("intra-cluster data (%s) and extra intra-cluster data (%s) share the same: %q",
+				c.HostnameIntraData, c.HostnameIntraDataExtra, addr)
+		}
+	}
 	return
 }
 
@@ -1383,6 +1878,10 @@ func (c *DsortConf) Validate() (err error) {
 	if c.SbundleMult < 0 || c.SbundleMult > 16 {
 		return fmt.Errorf(_idsort+"bundle_multiplier: %v (expected range [0, 16])", c.SbundleMult)
 	}
+	if c.MaxJobs < 0 || c.MaxJobsPerUser < 0 || c.MaxJobsPerRole < 0 {
+		return fmt.Errorf(_idsort+"max_jobs (%d), max_jobs_per_user (%d), and max_jobs_per_role (%d) cannot be negative",
+			c.MaxJobs, c.MaxJobsPerUser, c.MaxJobsPerRole)
+	}
 	if !apc.IsValidCompression(c.Compression) {
 		return fmt.Errorf(_idsort+"compression: %q (expecting one of: %v)", c.Compression, apc.SupportedCompression)
 	}
@@ -1592,6 +2091,9 @@ func (c *MemsysConf) Validate() (err error) {
 	if c.MinPctFree < 0 || c.MinPctFree > 95 {
 		return fmt.Errorf("invalid memsys.min_pct_free %d%%", c.MinPctFree)
 	}
+	if c.RAMCacheSize < 0 || c.RAMCacheSize > cos.TiB {
+		return fmt.Errorf("invalid memsys.ram_cache_size %s (expected range [0, 1TB])", c.RAMCacheSize)
+	}
 	return nil
 }
 
@@ -1702,10 +2204,18 @@ func (c *DownloaderConf) Validate() error {
 // RebalanceConf //
 ///////////////////
 
+const (
+	DfltRebHotAge = 24 * time.Hour
+	maxRebHotAge  = 365 * 24 * time.Hour
+)
+
 func (c *RebalanceConf) Validate() error {
 	if j := c.DestRetryTime.D(); j < time.Second || j > 10*time.Minute {
 		return fmt.Errorf("invalid rebalance.dest_retry_time=%s (expected range [1s, 10m])", j)
 	}
+	if j := c.HotAge.D(); j != 0 && (j < time.Minute || j > maxRebHotAge) {
+		return fmt.Errorf("invalid rebalance.hot_age=%s (expected zero (default) or range [1m, %s])", j, maxRebHotAge)
+	}
 	if c.SbundleMult < 0 || c.SbundleMult > 16 {
 		return fmt.Errorf("invalid rebalance.bundle_multiplier: %v (expected range [0, 16])", c.SbundleMult)
 	}
@@ -1716,6 +2226,13 @@ func (c *RebalanceConf) Validate() error {
 	return nil
 }
 
+func (c *RebalanceConf) HotAgeOrDflt() time.Duration {
+	if c.HotAge > 0 {
+		return c.HotAge.D()
+	}
+	return DfltRebHotAge
+}
+
 func (c *RebalanceConf) String() string {
 	if c.Enabled {
 		return "Enabled"
@@ -1740,7 +2257,7 @@ func (c *ResilverConf) String() string {
 func (ctu *ConfigToSet) FillFromQuery(query url.Values) error {
 	var anyExists bool
 	for key := range query {
-		if key == apc.ActTransient {
+		if key == apc.ActTransient || key == apc.QparamValidateOnly {
 			continue
 		}
 		anyExists = true
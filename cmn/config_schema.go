@@ -0,0 +1,191 @@
+// Package cmn provides common constants, types, and utilities for AIS clients
+// and AIStore.
+/*
+ * Copyright (c) 2024, NVIDIA CORPORATION. All rights reserved.
+ */
+package cmn
+
+import (
+	"crypto/tls"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/NVIDIA/aistore/api/apc"
+	"github.com/NVIDIA/aistore/cmn/cos"
+	"github.com/NVIDIA/aistore/cmn/feat"
+)
+
+// Config schema: all (leaf) configuration fields, generated from the Go structs.
+// - names are the same as in set-config (e.g. "space.cleanupwm");
+// - updatable at runtime: the field is present in ConfigToSet and is not read-only;
+// - per-node: can be overridden for a given node (i.e., not restricted via `allow:"cluster"` tag);
+// - min and max: fixed (inclusive) bounds enforced by the respective validators, when known;
+// - cross-field constraints (e.g., space watermarks) are not part of the schema -
+//   use set-config in validate-only mode to check a given update.
+
+type ConfigFieldSchema struct {
+	Name    string   `json:"name"`
+	Type    string   `json:"type"`              // Go type, e.g. "int64", "cos.Duration"
+	Format  string   `json:"format,omitempty"`  // "duration", "size", etc.
+	Enum    []string `json:"enum,omitempty"`    // allowed values (when enumerated)
+	Default any      `json:"default,omitempty"` // cluster-wide default (initial config), when known
+	Min     any      `json:"min,omitempty"`     // minimum allowed value (inclusive)
+	Max     any      `json:"max,omitempty"`     // maximum --/--
+	Scope   string   `json:"scope"`             // apc.Cluster (global, replicated) or "local" (node-specific)
+	Runtime bool     `json:"runtime"`           // updatable at runtime (set-config)
+	PerNode bool     `json:"per_node"`          // ditto, on a per-node basis
+}
+
+const schemaScopeLocal = "local"
+
+func schemaEnums() map[string][]string {
+	var (
+		compression = apc.SupportedCompression[:]
+		wpolicy     = apc.SupportedWritePolicy[:]
+	)
+	return map[string][]string{
+		"checksum.type":                       cos.SupportedChecksums(),
+		"ec.compression":                      compression,
+		"rebalance.compression":               compression,
+		"tcb.compression":                     compression,
+		"distributed_sort.compression":        compression,
+		"distributed_sort.duplicated_records": SupportedReactions,
+		"distributed_sort.missing_shards":     SupportedReactions,
+		"distributed_sort.ekm_malformed_line": SupportedReactions,
+		"distributed_sort.ekm_missing_key":    SupportedReactions,
+		"write_policy.data":                   wpolicy,
+		"write_policy.md":                     wpolicy,
+		"features":                            feat.Cluster[:],
+	}
+}
+
+// fixed bounds (see the respective validators); must be of the same type as the field
+// NOTE: not including fields that, in addition to a given range, allow zero (default)
+func schemaRanges() map[string][2]any {
+	var (
+		sec = cos.Duration(time.Second)
+		mnt = cos.Duration(time.Minute)
+	)
+	return map[string][2]any{
+		"periodic.stats_time":                {sec, mnt},
+		"periodic.retry_sync_time":           {cos.Duration(10 * time.Millisecond), 10 * sec},
+		"periodic.notif_time":                {sec, mnt},
+		"log.flush_time":                     {nil, cos.Duration(time.Hour)},
+		"log.stats_time":                     {nil, 10 * mnt},
+		"client.client_timeout":              {sec, 2 * mnt},
+		"client.client_long_timeout":         {30 * sec, 30 * mnt},
+		"client.list_timeout":                {2 * sec, 15 * mnt},
+		"disk.disk_util_low_wm":              {int64(1), int64(98)},
+		"disk.disk_util_high_wm":             {int64(2), int64(99)},
+		"disk.disk_util_max_wm":              {int64(3), int64(100)},
+		"disk.put_throttle_wm":               {int64(0), int64(100)},
+		"disk.preempt_util_wm":               {int64(0), int64(100)},
+		"disk.put_throttle_time":             {cos.Duration(0), cos.Duration(MaxPutThrottleTime)},
+		"space.cleanupwm":                    {int64(1), int64(100)},
+		"space.lowwm":                        {int64(1), int64(100)},
+		"space.highwm":                       {int64(1), int64(100)},
+		"space.out_of_space":                 {int64(1), int64(100)},
+		"keepalivetracker.retry_factor":      {uint8(1), uint8(10)},
+		"mirror.burst_buffer":                {0, nil},
+		"proxy.ic_count":                     {0, MaxCountIC},
+		"ec.data_slices":                     {MinSliceCount, MaxSliceCount},
+		"ec.parity_slices":                   {MinSliceCount, MaxSliceCount},
+		"ec.bundle_multiplier":               {0, 16},
+		"rebalance.bundle_multiplier":        {0, 16},
+		"tcb.bundle_multiplier":              {0, 16},
+		"distributed_sort.bundle_multiplier": {0, 16},
+		"distributed_sort.max_jobs":          {0, nil},
+		"distributed_sort.max_jobs_per_user": {0, nil},
+		"distributed_sort.max_jobs_per_role": {0, nil},
+		"net.http.client_auth_tls":           {int(tls.NoClientCert), int(tls.RequireAndVerifyClientCert)},
+		"net.l4.dscp.public":                 {0, MaxDSCP},
+		"net.l4.dscp.intra_control":          {0, MaxDSCP},
+		"net.l4.dscp.intra_data":             {0, MaxDSCP},
+		"net.http.limits.max_header_count":   {0, nil},
+		"memsys.min_pct_total":               {0, 95},
+		"memsys.min_pct_free":                {0, 95},
+		"memsys.ram_cache_size":              {cos.SizeIEC(0), cos.SizeIEC(cos.TiB)},
+		"transport.idle_teardown":            {sec, nil},
+		"transport.quiescent":                {8 * sec, nil},
+		"timeout.cplane_operation":           {cos.Duration(10 * time.Millisecond), nil},
+		"timeout.max_host_busy":              {10 * sec, nil},
+		"timeout.startup_time":               {30 * sec, nil},
+		"timeout.send_file_time":             {mnt, nil},
+		"downloader.timeout":                 {sec, cos.Duration(time.Hour)},
+		"rebalance.dest_retry_time":          {sec, 10 * mnt},
+		"authn.port":                         {0, 0xffff},
+	}
+}
+
+// NewConfigSchema walks cluster and local configs; `dflt` (optional) provides default values
+func NewConfigSchema(dflt *ClusterConfig) []*ConfigFieldSchema {
+	var (
+		schema  = make([]*ConfigFieldSchema, 0, 256)
+		enums   = schemaEnums()
+		ranges  = schemaRanges()
+		toSet   = make(cos.StrSet, 256)
+		perNode = make(cos.StrSet, 256)
+		clu     = &ClusterConfig{}
+	)
+	// settable
+	IterFields(&ConfigToSet{}, func(name string, fld IterField) (error, bool) {
+		if f, ok := fld.(*field); !ok || f.listTag != tagReadonly {
+			toSet.Add(name)
+		}
+		return nil, false
+	}, IterOpts{OnlyRead: true})
+	IterFields(clu, func(name string, _ IterField) (error, bool) {
+		perNode.Add(name)
+		return nil, false
+	}, IterOpts{Allowed: apc.Daemon, OnlyRead: true})
+
+	add := func(scope string, withDflt bool) func(string, IterField) (error, bool) {
+		return func(name string, fld IterField) (error, bool) {
+			v := fld.Value()
+			if v == nil {
+				return nil, false // (e.g., ext)
+			}
+			fs := &ConfigFieldSchema{
+				Name:    name,
+				Type:    fmt.Sprintf("%T", v),
+				Format:  schemaFormat(v),
+				Enum:    enums[name],
+				Scope:   scope,
+				Runtime: toSet.Contains(name),
+			}
+			if r, ok := ranges[name]; ok {
+				fs.Min, fs.Max = r[0], r[1]
+			}
+			fs.PerNode = fs.Runtime && (scope == schemaScopeLocal || perNode.Contains(name))
+			if withDflt {
+				fs.Default = v
+			}
+			schema = append(schema, fs)
+			return nil, false
+		}
+	}
+	if dflt != nil {
+		clu = dflt
+	}
+	IterFields(clu, add(apc.Cluster, dflt != nil), IterOpts{OnlyRead: true})
+	IterFields(&LocalConfig{}, add(schemaScopeLocal, false), IterOpts{OnlyRead: true})
+
+	sort.Slice(schema, func(i, j int) bool { return schema[i].Name < schema[j].Name })
+	return schema
+}
+
+func schemaFormat(v any) string {
+	switch v.(type) {
+	case cos.Duration:
+		return "duration" // e.g. "10s", "1h"
+	case cos.SizeIEC:
+		return "size" // e.g. "4MiB"
+	case feat.Flags:
+		return "flags" // comma-separated names (see "enum")
+	case bool:
+		return "boolean"
+	default:
+		return ""
+	}
+}
@@ -0,0 +1,44 @@
+// Package cos provides common low-level types and utilities for all aistore projects
+/*
+ * Copyright (c) 2024, NVIDIA CORPORATION. All rights reserved.
+ */
+package cos
+
+import (
+	"sync"
+	"time"
+)
+
+// BwLimiter throttles (aggregated) throughput to approximately the configured
+// number of bytes per second; allows bursts of up to one second worth of bytes.
+// Safe for concurrent use.
+type BwLimiter struct {
+	last  time.Time
+	bps   float64
+	avail float64 // bytes; negative when in debt
+	mu    sync.Mutex
+}
+
+func NewBwLimiter(bps int64) *BwLimiter {
+	return &BwLimiter{bps: float64(bps), avail: float64(bps), last: time.Now()}
+}
+
+// Wait accounts for `n` transferred bytes and sleeps, if need be, to
+// keep the rate within the limit.
+func (b *BwLimiter) Wait(n int64) {
+	var (
+		sleep time.Duration
+		now   = time.Now()
+	)
+	b.mu.Lock()
+	b.avail = min(b.avail+now.Sub(b.last).Seconds()*b.bps, b.bps)
+	b.last = now
+	b.avail -= float64(n)
+	if b.avail < 0 {
+		sleep = time.Duration(-b.avail / b.bps * float64(time.Second))
+	}
+	b.mu.Unlock()
+	if sleep > 0 {
+		time.Sleep(sleep)
+	}
+}
@@ -0,0 +1,40 @@
+// Package cos provides common low-level types and utilities for all aistore projects
+/*
+ * Copyright (c) 2024, NVIDIA CORPORATION. All rights reserved.
+ */
+package cos_test
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/NVIDIA/aistore/cmn/cos"
+	"github.com/NVIDIA/aistore/tools/tassert"
+)
+
+func TestBwLimiter(t *testing.T) {
+	const (
+		bps   = 4 * cos.MiB
+		chunk = 64 * cos.KiB
+		total = 6 * cos.MiB // 1s burst + 0.5s
+	)
+	var (
+		wg      sync.WaitGroup
+		lim     = cos.NewBwLimiter(bps)
+		started = time.Now()
+	)
+	for range 4 {
+		wg.Add(1)
+		go func() {
+			for range total / chunk / 4 {
+				lim.Wait(chunk)
+			}
+			wg.Done()
+		}()
+	}
+	wg.Wait()
+	elapsed := time.Since(started)
+	tassert.Errorf(t, elapsed >= 400*time.Millisecond, "too fast: %v", elapsed)
+	tassert.Errorf(t, elapsed < 2*time.Second, "too slow: %v", elapsed)
+}
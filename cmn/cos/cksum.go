@@ -109,6 +109,13 @@ func (ck *CksumHash) Init(ty string) {
 	switch ty {
 	case ChecksumNone, "":
 		ck.ty, ck.H = ChecksumNone, newNoopHash()
+		return
+	}
+	if f, ok := hashFactories[ty]; ok {
+		ck.H = f() // registered (e.g., hardware-accelerated) implementation
+		return
+	}
+	switch ty {
 	case ChecksumXXHash:
 		ck.H = xxhash.New64()
 	case ChecksumMD5:
@@ -28,11 +28,20 @@ func (d Duration) String() (s string) {
 	return
 }
 
+func (d Duration) Qty() Quantity { return DurationQty(time.Duration(d)) }
+
+// accepts both human-readable ("1m30s") and machine (nanoseconds) representations
 func (d *Duration) UnmarshalJSON(b []byte) (err error) {
 	var (
 		dur time.Duration
 		val string
 	)
+	if len(b) > 0 && b[0] != '"' {
+		var n int64
+		err = jsoniter.Unmarshal(b, &n)
+		*d = Duration(n)
+		return
+	}
 	if err = jsoniter.Unmarshal(b, &val); err != nil {
 		return
 	}
@@ -0,0 +1,109 @@
+// Package cos provides common low-level types and utilities for all aistore projects.
+/*
+ * Copyright (c) 2024, NVIDIA CORPORATION. All rights reserved.
+ */
+package cos
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	jsoniter "github.com/json-iterator/go"
+)
+
+// SelectFields returns the subset of v's JSON representation that contains only the specified
+// fields, where:
+// - `fields` is a comma-separated list of dot-separated JSON paths, e.g. "tmap,version" or "mirror.enabled";
+// - arrays are transparent: the path applies to each element;
+// - nonexistent (or omitted-empty) fields are silently skipped;
+// - selected values are copied verbatim (no float64 conversion of large integers).
+func SelectFields(v any, fields string) (any, error) {
+	b, err := JSON.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	var (
+		root = jsoniter.RawMessage(b)
+		out  any
+	)
+	for _, field := range strings.Split(fields, ",") {
+		field = strings.TrimSpace(field)
+		if field == "" {
+			continue
+		}
+		path := strings.Split(field, ".")
+		for _, name := range path {
+			if name == "" {
+				return nil, fmt.Errorf("invalid field %q", field)
+			}
+		}
+		if out, err = selectPath(root, out, path); err != nil {
+			return nil, fmt.Errorf("failed to select field %q: %w", field, err)
+		}
+	}
+	if out == nil {
+		return nil, errors.New("no fields to select")
+	}
+	return out, nil
+}
+
+func selectPath(src jsoniter.RawMessage, dst any, path []string) (any, error) {
+	if len(path) == 0 {
+		return src, nil
+	}
+	if whole, ok := dst.(jsoniter.RawMessage); ok {
+		return whole, nil // already selected in its entirety
+	}
+	switch _first(src) {
+	case '{':
+		var m map[string]jsoniter.RawMessage
+		if err := JSON.Unmarshal(src, &m); err != nil {
+			return nil, err
+		}
+		d, _ := dst.(map[string]any)
+		if d == nil {
+			d = make(map[string]any, 4)
+		}
+		sv, ok := m[path[0]]
+		if !ok {
+			return d, nil
+		}
+		nv, err := selectPath(sv, d[path[0]], path[1:])
+		if err != nil {
+			return nil, err
+		}
+		d[path[0]] = nv
+		return d, nil
+	case '[':
+		var a []jsoniter.RawMessage
+		if err := JSON.Unmarshal(src, &a); err != nil {
+			return nil, err
+		}
+		d, _ := dst.([]any)
+		if d == nil {
+			d = make([]any, len(a))
+		}
+		for i := range a {
+			nv, err := selectPath(a[i], d[i], path)
+			if err != nil {
+				return nil, err
+			}
+			d[i] = nv
+		}
+		return d, nil
+	case 'n', 0:
+		return dst, nil // null
+	default:
+		return nil, fmt.Errorf("%q: not an object", path[0])
+	}
+}
+
+func _first(b []byte) byte {
+	for _, c := range b {
+		if c != ' ' && c != '\t' && c != '\n' && c != '\r' {
+			return c
+		}
+	}
+	return 0
+}
@@ -0,0 +1,58 @@
+// Package cos provides common low-level types and utilities for all aistore projects
+/*
+ * Copyright (c) 2024, NVIDIA CORPORATION. All rights reserved.
+ */
+package cos_test
+
+import (
+	"testing"
+
+	"github.com/NVIDIA/aistore/cmn/cos"
+	"github.com/NVIDIA/aistore/tools/tassert"
+)
+
+func TestSelectFields(t *testing.T) {
+	type (
+		node struct {
+			ID   string `json:"id"`
+			Port int    `json:"port"`
+		}
+		meta struct {
+			Nodes   []node          `json:"nodes"`
+			Tmap    map[string]node `json:"tmap"`
+			Owner   *node           `json:"owner"`
+			Version int64           `json:"version,string"`
+			Created int64           `json:"created"`
+			Empty   string          `json:"empty,omitempty"`
+		}
+	)
+	v := &meta{
+		Nodes:   []node{{ID: "a", Port: 1}, {ID: "b", Port: 2}},
+		Tmap:    map[string]node{"t1": {ID: "t1", Port: 3}},
+		Version: 42,
+		Created: 1729123456789012345, // (beyond float64 precision)
+	}
+	tests := []struct {
+		fields string
+		expect string
+	}{
+		{"version", `{"version":"42"}`},
+		{"version, created", `{"created":1729123456789012345,"version":"42"}`},
+		{"nodes.id", `{"nodes":[{"id":"a"},{"id":"b"}]}`},
+		{"tmap.t1.port", `{"tmap":{"t1":{"port":3}}}`},
+		{"tmap.t1,tmap.t1.port", `{"tmap":{"t1":{"id":"t1","port":3}}}`},
+		{"tmap.t1.port,tmap.t1", `{"tmap":{"t1":{"id":"t1","port":3}}}`},
+		{"empty,nonexistent,owner.id", `{"owner":null}`},
+	}
+	for _, test := range tests {
+		out, err := cos.SelectFields(v, test.fields)
+		tassert.CheckFatal(t, err)
+		s := cos.MustMarshalToString(out)
+		tassert.Errorf(t, s == test.expect, "%q: expected %s, got %s", test.fields, test.expect, s)
+	}
+
+	for _, fields := range []string{"version.x", "tmap..port", " , "} {
+		_, err := cos.SelectFields(v, fields)
+		tassert.Errorf(t, err != nil, "%q: expecting error", fields)
+	}
+}
@@ -0,0 +1,212 @@
+// Package cos provides common low-level types and utilities for all aistore projects
+/*
+ * Copyright (c) 2024, NVIDIA CORPORATION. All rights reserved.
+ */
+package cos
+
+import (
+	"fmt"
+	"hash"
+	"runtime"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/NVIDIA/aistore/cmn/atomic"
+	cxxhash "github.com/cespare/xxhash/v2"
+	"golang.org/x/sys/cpu"
+)
+
+// Hashing pipeline:
+// - pluggable: any supported checksum type can be (re)implemented - e.g., SIMD-accelerated -
+//   and registered via RegisterHash (see CksumHash.Init);
+// - pipelined: HashPipe computes checksums in a separate goroutine, concurrently with reading
+//   (from network or disk) and writing (to disk), so that a single large-object PUT or GET
+//   is no longer bounded by single-threaded hashing;
+// - small objects (up to hashPipeInline bytes) are always hashed inline;
+// - enabled per node: on, off, or auto (default) - the latter based on the available CPUs;
+// - accelerated: at startup, each node selects the fastest bundled implementation given
+//   its CPU (see selectHashers); sha256 and crc32c, in turn, are computed by the Go standard
+//   library that itself uses CPU extensions (SHA-NI, SSE4.2, ARMv8 CRC and SHA2) when present;
+// - users: object PUT, CopyAndChecksum, and EC encoding (that, in addition, checksums
+//   all data and parity slices concurrently).
+//
+// NOTE: ISA-L is not bundled - a cgo-based implementation can be plugged in via RegisterHash.
+
+const (
+	HashPipeAuto = "auto"
+	HashPipeOn   = "on"
+	HashPipeOff  = "off"
+)
+
+const (
+	hashPipeInline  = 1024 * 1024 // hash inline up to this size
+	hashPipeDepth   = 4           // max in-flight chunks
+	hashPipeMinCPUs = 4           // auto: min number of usable CPUs
+)
+
+type (
+	HashFactory func() hash.Hash
+
+	// io.Writer that hashes asynchronously - call Wait() before using the resulting hashes
+	HashPipe struct {
+		hashes  []hash.Hash
+		ch      chan []byte
+		done    chan struct{}
+		written int64
+	}
+)
+
+var (
+	hashFactories = map[string]HashFactory{}
+	hashAccel     []string // selected at startup (see selectHashers)
+	hashPipe      atomic.Bool
+	chunkPool     sync.Pool
+)
+
+func init() { selectHashers() }
+
+// selectHashers replaces the default implementations with the ones that
+// run faster on this CPU; digests remain identical
+func selectHashers() {
+	// xxhash64 (seed 0) in assembly: amd64 (any) and arm64 with Advanced SIMD
+	if runtime.GOARCH == "amd64" || (runtime.GOARCH == "arm64" && cpu.ARM64.HasASIMD) {
+		hashFactories[ChecksumXXHash] = func() hash.Hash { return cxxhash.New() }
+		hashAccel = append(hashAccel, ChecksumXXHash+"(asm)")
+	}
+}
+
+// RegisterHash overrides the default (or auto-selected) implementation of
+// a given checksum type; must be called at init time
+func RegisterHash(ty string, f HashFactory) {
+	if err := ValidateCksumType(ty, false /*empty OK*/); err != nil {
+		AssertMsg(false, err.Error())
+	}
+	hashFactories[ty] = f
+	for i, name := range hashAccel {
+		if strings.HasPrefix(name, ty+"(") {
+			hashAccel = append(hashAccel[:i], hashAccel[i+1:]...)
+			break
+		}
+	}
+	hashAccel = append(hashAccel, ty+"(registered)")
+}
+
+// InitHashPipe enables (or disables) the hashing pipeline and returns
+// a human-readable summary, including detected CPU features
+func InitHashPipe(mode string) (string, error) {
+	ncpu := runtime.GOMAXPROCS(0)
+	switch mode {
+	case HashPipeOn:
+		hashPipe.Store(true)
+	case HashPipeOff:
+		hashPipe.Store(false)
+	case HashPipeAuto, "":
+		mode = HashPipeAuto
+		hashPipe.Store(ncpu >= hashPipeMinCPUs)
+	default:
+		return "", fmt.Errorf("invalid hashing pipeline mode %q (expecting one of: %s, %s, %s)", mode, HashPipeAuto, HashPipeOn, HashPipeOff)
+	}
+	var sb strings.Builder
+	sb.WriteString(mode)
+	if hashPipe.Load() {
+		sb.WriteString(" (enabled")
+	} else {
+		sb.WriteString(" (disabled")
+	}
+	sb.WriteString(", CPUs: ")
+	sb.WriteString(strconv.Itoa(ncpu))
+	if f := cpuHashFeatures(); f != "" {
+		sb.WriteString(", features: ")
+		sb.WriteString(f)
+	}
+	if len(hashAccel) > 0 {
+		sb.WriteString(", hashers: ")
+		sb.WriteString(strings.Join(hashAccel, ","))
+	}
+	sb.WriteByte(')')
+	return sb.String(), nil
+}
+
+func IsHashPipe() bool { return hashPipe.Load() }
+
+// CPU extensions relevant to hashing (logged at startup)
+func cpuHashFeatures() string {
+	var f []string
+	if cpu.X86.HasAVX2 {
+		f = append(f, "avx2")
+	}
+	if cpu.X86.HasAVX512 {
+		f = append(f, "avx512")
+	}
+	if cpu.X86.HasSSE42 {
+		f = append(f, "sse4.2") // crc32c
+	}
+	if cpu.ARM64.HasSHA2 {
+		f = append(f, "sha2")
+	}
+	if cpu.ARM64.HasCRC32 {
+		f = append(f, "crc32")
+	}
+	return strings.Join(f, ",")
+}
+
+//////////////
+// HashPipe //
+//////////////
+
+func NewHashPipe(hashes ...hash.Hash) *HashPipe {
+	return &HashPipe{hashes: hashes}
+}
+
+func (hp *HashPipe) Write(b []byte) (int, error) {
+	if hp.ch == nil {
+		if hp.written+int64(len(b)) <= hashPipeInline {
+			hp.written += int64(len(b))
+			hp.hash(b)
+			return len(b), nil
+		}
+		hp.ch = make(chan []byte, hashPipeDepth)
+		hp.done = make(chan struct{})
+		go hp.run()
+	}
+	chunk := allocChunk(len(b))
+	copy(chunk, b)
+	hp.ch <- chunk
+	hp.written += int64(len(b))
+	return len(b), nil
+}
+
+// waits for all pending chunks to get hashed; must be called exactly once
+func (hp *HashPipe) Wait() {
+	if hp.ch == nil {
+		return
+	}
+	close(hp.ch)
+	<-hp.done
+}
+
+func (hp *HashPipe) run() {
+	for chunk := range hp.ch {
+		hp.hash(chunk)
+		freeChunk(chunk)
+	}
+	close(hp.done)
+}
+
+func (hp *HashPipe) hash(b []byte) {
+	for _, h := range hp.hashes {
+		h.Write(b)
+	}
+}
+
+func allocChunk(size int) []byte {
+	if v := chunkPool.Get(); v != nil {
+		if chunk := *v.(*[]byte); cap(chunk) >= size {
+			return chunk[:size]
+		}
+	}
+	return make([]byte, size)
+}
+
+func freeChunk(chunk []byte) { chunkPool.Put(&chunk) }
@@ -0,0 +1,76 @@
+// Package cos provides common low-level types and utilities for all aistore projects
+/*
+ * Copyright (c) 2024, NVIDIA CORPORATION. All rights reserved.
+ */
+package cos_test
+
+import (
+	"bytes"
+	"encoding/hex"
+	"io"
+	"math/rand/v2"
+	"testing"
+
+	"github.com/NVIDIA/aistore/cmn/cos"
+	"github.com/NVIDIA/aistore/tools/tassert"
+	"github.com/OneOfOne/xxhash"
+)
+
+func TestHashPipe(t *testing.T) {
+	defer cos.InitHashPipe(cos.HashPipeOff)
+
+	for _, size := range []int{0, 1000, cos.MiB, 5*cos.MiB + 17} {
+		data := make([]byte, size)
+		for i := range data {
+			data[i] = byte(rand.IntN(256))
+		}
+		for _, ty := range []string{cos.ChecksumXXHash, cos.ChecksumCRC32C, cos.ChecksumSHA256} {
+			_, err := cos.InitHashPipe(cos.HashPipeOff)
+			tassert.CheckFatal(t, err)
+			_, inline, err := cos.CopyAndChecksum(io.Discard, bytes.NewReader(data), nil, ty)
+			tassert.CheckFatal(t, err)
+
+			_, err = cos.InitHashPipe(cos.HashPipeOn)
+			tassert.CheckFatal(t, err)
+			var w bytes.Buffer
+			n, piped, err := cos.CopyAndChecksum(&w, bytes.NewReader(data), make([]byte, 32*cos.KiB), ty)
+			tassert.CheckFatal(t, err)
+
+			tassert.Errorf(t, n == int64(size), "%s: size %d vs %d", ty, n, size)
+			tassert.Errorf(t, bytes.Equal(w.Bytes(), data), "%s: data mismatch (size %d)", ty, size)
+			tassert.Errorf(t, inline.Equal(&piped.Cksum), "%s: checksum mismatch (size %d): %s vs %s",
+				ty, size, inline.Value(), piped.Value())
+		}
+	}
+}
+
+func TestHashPipeMode(t *testing.T) {
+	defer cos.InitHashPipe(cos.HashPipeOff)
+
+	_, err := cos.InitHashPipe("maybe")
+	tassert.Errorf(t, err != nil, "expecting error on invalid mode")
+	s, err := cos.InitHashPipe(cos.HashPipeOn)
+	tassert.CheckFatal(t, err)
+	tassert.Errorf(t, cos.IsHashPipe(), "expecting enabled (%s)", s)
+	_, err = cos.InitHashPipe(cos.HashPipeOff)
+	tassert.CheckFatal(t, err)
+	tassert.Errorf(t, !cos.IsHashPipe(), "expecting disabled")
+}
+
+// auto-selected (accelerated) implementations must produce identical digests
+func TestHashSelect(t *testing.T) {
+	for _, size := range []int{0, 1, 31, 32, 1000, cos.MiB + 3} {
+		data := make([]byte, size)
+		for i := range data {
+			data[i] = byte(rand.IntN(256))
+		}
+		h := xxhash.New64()
+		h.Write(data)
+		expected := hex.EncodeToString(h.Sum(nil))
+
+		ck := cos.NewCksumHash(cos.ChecksumXXHash)
+		ck.H.Write(data)
+		ck.Finalize()
+		tassert.Errorf(t, ck.Value() == expected, "xxhash mismatch (size %d): %s vs %s", size, ck.Value(), expected)
+	}
+}
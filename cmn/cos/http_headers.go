@@ -13,11 +13,15 @@ const (
 	ContentMsgPack        = "application/msgpack"
 	ContentXML            = "application/xml"
 	ContentBinary         = "application/octet-stream"
+	ContentCSV            = "text/csv"
 
 	// not present in IANA registry
 	// mozilla.org has it though, and also https://en.wikipedia.org/wiki/List_of_archive_formats
 	ContentTar = "application/x-tar"
 
+	// newline-delimited JSON (https://github.com/ndjson/ndjson-spec)
+	ContentNDJSON = "application/x-ndjson"
+
 	// not currently used
 	ContentZip = "application/zip"
 )
@@ -37,6 +41,9 @@ const (
 	HdrContentTypeOptions = "X-Content-Type-Options"
 	HdrContentLength      = "Content-Length"
 
+	// content encoding (intra-cluster compression; see ContentZstd)
+	HdrContentEncoding = "Content-Encoding"
+
 	// misc. gen
 	HdrUserAgent = "User-Agent"
 	HdrAccept    = "Accept"
@@ -44,6 +51,10 @@ const (
 	HdrServer    = "Server"
 	HdrETag      = "ETag" // Ref: https://developer.mozilla.org/en-US/docs/Web/HTTP/Headers/ETag
 
+	HdrRetryAfter = "Retry-After" // Ref: https://www.rfc-editor.org/rfc/rfc9110#section-10.2.3
+
+	HdrLastModified = "Last-Modified"
+
 	HdrHSTS = "Strict-Transport-Security"
 )
 
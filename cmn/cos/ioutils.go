@@ -295,11 +295,22 @@ func CopyAndChecksum(w io.Writer, r io.Reader, buf []byte, cksumType string) (n
 	}
 
 	cksum = NewCksumHash(cksumType)
-	var mw io.Writer = cksum.H
+	var (
+		hp *HashPipe
+		hw io.Writer = cksum.H
+	)
+	if IsHashPipe() {
+		hp = NewHashPipe(cksum.H)
+		hw = hp
+	}
+	mw := hw
 	if w != io.Discard {
-		mw = NewWriterMulti(cksum.H, w)
+		mw = NewWriterMulti(hw, w)
 	}
 	n, err = io.CopyBuffer(mw, r, buf)
+	if hp != nil {
+		hp.Wait()
+	}
 	cksum.Finalize()
 	return n, cksum, err
 }
@@ -8,12 +8,15 @@ import (
 	"fmt"
 	"strconv"
 	"strings"
+	"time"
 	"unicode"
 )
 
+// Quantity types
 const (
-	QuantityPercent = "percent"
-	QuantityBytes   = "bytes"
+	QuantityPercent  = "percent"
+	QuantityBytes    = "bytes"
+	QuantityDuration = "duration"
 )
 
 type (
@@ -21,8 +24,59 @@ type (
 		Type  string
 		Value uint64
 	}
+
+	// Quantity carries both machine (int64) and human-readable renderings of the same value,
+	// to be used in API responses, e.g.:
+	// {"type": "bytes", "value": "1073741824", "human": "1.00GiB"}
+	// Value units: bytes (QuantityBytes), nanoseconds (QuantityDuration), and percent (QuantityPercent).
+	Quantity struct {
+		Type  string `json:"type"`
+		Value int64  `json:"value,string"`
+		Human string `json:"human"`
+	}
 )
 
+//////////////
+// Quantity //
+//////////////
+
+func BytesQty(n int64) Quantity {
+	return Quantity{Type: QuantityBytes, Value: n, Human: ToSizeIEC(n, 2)}
+}
+
+func DurationQty(d time.Duration) Quantity {
+	return Quantity{Type: QuantityDuration, Value: int64(d), Human: Duration(d).String()}
+}
+
+func PercentQty(pct int64) Quantity {
+	return Quantity{Type: QuantityPercent, Value: pct, Human: strconv.FormatInt(pct, 10) + "%"}
+}
+
+func (q Quantity) String() string { return q.Human }
+
+// ParsePercent returns fraction in the [0, 1] range; the input is either a percentage
+// ("2.5%") or the fraction itself ("0.025")
+func ParsePercent(s string) (float64, error) {
+	var (
+		v   = strings.TrimSpace(s)
+		pct = strings.HasSuffix(v, "%")
+	)
+	if pct {
+		v = strings.TrimSpace(strings.TrimSuffix(v, "%"))
+	}
+	f, err := strconv.ParseFloat(v, 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid percent %q: %v", s, err)
+	}
+	if pct {
+		f /= 100
+	}
+	if f < 0 || f > 1 {
+		return 0, fmt.Errorf("invalid percent %q (expecting [0, 100%%] or, equivalently, [0, 1])", s)
+	}
+	return f, nil
+}
+
 ///////////////////
 // ParseQuantity //
 ///////////////////
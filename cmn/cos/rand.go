@@ -45,6 +45,7 @@ func (*crand) Uint64() uint64 {
 func (*crand) Seed(int64) {}
 
 func CryptoRandS(n int) string { return RandStringWithSrc(crnd, n) }
+func CryptoRandU64() uint64    { return crnd.Uint64() }
 
 //
 // misc. rand utils
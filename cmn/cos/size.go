@@ -47,11 +47,17 @@ type SizeIEC int64
 func (siz SizeIEC) MarshalJSON() ([]byte, error) { return jsoniter.Marshal(siz.String()) }
 func (siz SizeIEC) String() string               { return ToSizeIEC(int64(siz), 0) }
 
+// accepts both human-readable ("10MiB") and machine (number of bytes) representations
 func (siz *SizeIEC) UnmarshalJSON(b []byte) (err error) {
 	var (
 		n   int64
 		val string
 	)
+	if len(b) > 0 && b[0] != '"' {
+		err = jsoniter.Unmarshal(b, &n)
+		*siz = SizeIEC(n)
+		return
+	}
 	if err = jsoniter.Unmarshal(b, &val); err != nil {
 		return
 	}
@@ -60,6 +66,8 @@ func (siz *SizeIEC) UnmarshalJSON(b []byte) (err error) {
 	return
 }
 
+func (siz SizeIEC) Qty() Quantity { return BytesQty(int64(siz)) }
+
 // (compare w/ CLI `ToSizeIS`)
 func ToSizeIEC(b int64, digits int) string {
 	switch {
@@ -69,6 +69,37 @@ func ReparseQuery(r *http.Request) {
 	r.URL.RawQuery = q.Encode()
 }
 
+// EscapePath escapes URL path (that may include object name) as per RFC 3986,
+// one '/'-separated segment at a time; returns the original path when there's
+// nothing to escape. The receiving side (net/http) unescapes `r.URL.Path`.
+func EscapePath(p string) string {
+	for i := range len(p) {
+		if !isUnreserved(p[i]) && p[i] != '/' {
+			return escapeSegments(p)
+		}
+	}
+	return p
+}
+
+func escapeSegments(p string) string {
+	segs := strings.Split(p, "/")
+	for i, seg := range segs {
+		segs[i] = url.PathEscape(seg)
+	}
+	return strings.Join(segs, "/")
+}
+
+// RFC 3986, section 2.3
+func isUnreserved(c byte) bool {
+	switch {
+	case 'a' <= c && c <= 'z', 'A' <= c && c <= 'Z', '0' <= c && c <= '9':
+		return true
+	case c == '-', c == '.', c == '_', c == '~':
+		return true
+	}
+	return false
+}
+
 // JoinWords uses forward slash to join any number of words into a single path.
 // Returned path is prefixed with a slash.
 func JoinWords(w string, words ...string) (path string) {
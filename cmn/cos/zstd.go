@@ -0,0 +1,77 @@
+// Package cos provides common low-level types and utilities for all aistore projects
+/*
+ * Copyright (c) 2024, NVIDIA CORPORATION. All rights reserved.
+ */
+package cos
+
+import (
+	"io"
+	"sync"
+
+	"github.com/klauspost/compress/zstd"
+)
+
+// zstd compression of intra-cluster control-plane payloads:
+// the sender compresses and sets `Content-Encoding: zstd` (HdrContentEncoding),
+// the receiver checks the header and decompresses (see NewZstdReader).
+// Encoders and decoders are pooled and single-threaded (no background goroutines).
+
+const ContentZstd = "zstd"
+
+type zstdReader struct {
+	dec  *zstd.Decoder
+	body io.ReadCloser
+}
+
+var (
+	zencPool sync.Pool
+	zdecPool sync.Pool
+)
+
+// NewZstdWriter returns (pooled) encoder that writes compressed output to `w`;
+// the caller must Close it to flush the output, and then FreeZstdWriter
+func NewZstdWriter(w io.Writer) *zstd.Encoder {
+	if v := zencPool.Get(); v != nil {
+		enc := v.(*zstd.Encoder)
+		enc.Reset(w)
+		return enc
+	}
+	enc, err := zstd.NewWriter(w, zstd.WithEncoderConcurrency(1), zstd.WithEncoderLevel(zstd.SpeedDefault))
+	AssertNoErr(err) // (only invalid options)
+	return enc
+}
+
+func FreeZstdWriter(enc *zstd.Encoder) {
+	enc.Reset(nil)
+	zencPool.Put(enc)
+}
+
+// NewZstdReader returns (pooled) decoder that reads and decompresses `body`;
+// closing the returned reader closes the body and frees the decoder
+func NewZstdReader(body io.ReadCloser) (io.ReadCloser, error) {
+	var (
+		dec *zstd.Decoder
+		err error
+	)
+	if v := zdecPool.Get(); v != nil {
+		dec = v.(*zstd.Decoder)
+		err = dec.Reset(body)
+	} else {
+		dec, err = zstd.NewReader(body, zstd.WithDecoderConcurrency(1), zstd.WithDecoderLowmem(true))
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &zstdReader{dec: dec, body: body}, nil
+}
+
+func (zr *zstdReader) Read(b []byte) (int, error) { return zr.dec.Read(b) }
+
+func (zr *zstdReader) Close() error {
+	if zr.dec != nil {
+		zr.dec.Reset(nil) //nolint:errcheck // (nil reader)
+		zdecPool.Put(zr.dec)
+		zr.dec = nil
+	}
+	return zr.body.Close()
+}
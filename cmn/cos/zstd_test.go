@@ -0,0 +1,37 @@
+// Package cos provides common low-level types and utilities for all aistore projects
+/*
+ * Copyright (c) 2024, NVIDIA CORPORATION. All rights reserved.
+ */
+package cos_test
+
+import (
+	"bytes"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/NVIDIA/aistore/cmn/cos"
+	"github.com/NVIDIA/aistore/tools/tassert"
+)
+
+func TestZstdRoundTrip(t *testing.T) {
+	orig := []byte(strings.Repeat(`{"name":"bucket","provider":"ais","props":{"mirror":{"copies":2}}},`, 1000))
+	for range 3 { // (pooled encoders and decoders)
+		var (
+			zbuf bytes.Buffer
+			zw   = cos.NewZstdWriter(&zbuf)
+		)
+		_, err := zw.Write(orig)
+		tassert.CheckFatal(t, err)
+		tassert.CheckFatal(t, zw.Close())
+		cos.FreeZstdWriter(zw)
+		tassert.Fatalf(t, zbuf.Len() < len(orig)/10, "poor compression: %d => %d", len(orig), zbuf.Len())
+
+		zr, err := cos.NewZstdReader(io.NopCloser(&zbuf))
+		tassert.CheckFatal(t, err)
+		out, err := io.ReadAll(zr)
+		tassert.CheckFatal(t, err)
+		tassert.CheckFatal(t, zr.Close())
+		tassert.Fatalf(t, bytes.Equal(out, orig), "decompressed payload differs")
+	}
+}
@@ -0,0 +1,55 @@
+// Package cmn provides common constants, types, and utilities for AIS clients
+// and AIStore.
+/*
+ * Copyright (c) 2024, NVIDIA CORPORATION. All rights reserved.
+ */
+package cmn
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/NVIDIA/aistore/api/apc"
+	"github.com/NVIDIA/aistore/cmn/cos"
+)
+
+// Deduplication: objects with identical content (as per the bucket's strong checksum) are stored
+// once - subsequent PUTs of the same content share (clone) the already stored data - see
+// core/ldedup.go. Deduplication is per target and per mountpath and applies to objects of size
+// greater or equal `min_size`.
+
+type (
+	DedupConf struct {
+		Enabled bool        `json:"enabled,omitempty"`
+		MinSize cos.SizeIEC `json:"min_size,omitempty"` // smaller objects are stored as usual
+	}
+	DedupConfToSet struct {
+		Enabled *bool        `json:"enabled,omitempty"`
+		MinSize *cos.SizeIEC `json:"min_size,omitempty"`
+	}
+)
+
+func (c *DedupConf) validate(bp *Bprops) error {
+	if c.MinSize < 0 {
+		return fmt.Errorf("invalid dedup.min_size %d (must be non-negative)", c.MinSize)
+	}
+	if !c.Enabled {
+		return nil
+	}
+	if bp.Provider != apc.AIS || !bp.BackendBck.IsEmpty() {
+		return fmt.Errorf("cannot enable deduplication for %s bucket (ais:// buckets only)", apc.DisplayProvider(bp.Provider))
+	}
+	if bp.Cksum.Type != cos.ChecksumSHA256 && bp.Cksum.Type != cos.ChecksumSHA512 {
+		return fmt.Errorf("deduplication requires strong checksum (%s or %s), have checksum.type %q",
+			cos.ChecksumSHA256, cos.ChecksumSHA512, bp.Cksum.Type)
+	}
+	if bp.Mirror.Enabled {
+		return errors.New("deduplication and n-way mirroring cannot be enabled on the same bucket")
+	}
+	return nil
+}
+
+// whether targets must compute object checksums rather than trust the ones provided by clients
+func (bp *Bprops) CksumRequired() bool {
+	return bp.Dedup.Enabled || bp.Publish.ContentAddr
+}
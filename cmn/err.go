@@ -7,6 +7,7 @@ package cmn
 
 import (
 	"bytes"
+	"context"
 	"errors"
 	"fmt"
 	"io/fs"
@@ -16,6 +17,7 @@ import (
 	"strconv"
 	"strings"
 	"sync"
+	"time"
 
 	"github.com/NVIDIA/aistore/api/apc"
 	"github.com/NVIDIA/aistore/cmn/cos"
@@ -58,8 +60,11 @@ type (
 		RemoteAddr string `json:"remote_addr"`
 		Caller     string `json:"caller"`
 		Node       string `json:"node"`
+		Kind       string `json:"kind,omitempty"`     // enum { ErrKindNotFound, ... } (see err_kind.go)
+		Resource   string `json:"resource,omitempty"` // e.g., bucket or object name, when available
 		trace      []byte
-		Status     int `json:"status"`
+		Status     int  `json:"status"`
+		Retriable  bool `json:"retriable,omitempty"`
 	}
 )
 
@@ -97,6 +102,38 @@ type (
 		cause error
 	}
 
+	// publish (immutable) bucket - see PublishConf
+	ErrImmutable struct {
+		cname string
+		op    string
+	}
+	ErrContentAddr struct {
+		cksum   *cos.Cksum
+		objName string
+	}
+
+	// frozen bucket - see FreezeConf
+	ErrBucketFrozen struct {
+		cname string
+		op    string
+		state apc.FreezeState
+	}
+
+	// request exceeds net.http.limits (see HTTPLimitsConf)
+	ErrReqLimit struct {
+		what   string
+		value  int64
+		limit  int64
+		status int // 413, 431, or 408 (slow request)
+	}
+
+	// client-supplied request deadline (apc.HdrDeadline) exceeded
+	ErrDeadline struct {
+		what   string        // e.g., "GET ais://abc/obj"
+		timing string        // breakdown, e.g., "redirect 1ms, local 2ms, backend 5.1s"
+		over   time.Duration // by how much
+	}
+
 	ErrCapExceeded struct {
 		totalBytes     uint64
 		totalBytesUsed uint64
@@ -222,7 +259,8 @@ type (
 		detail string
 	}
 	ErrInvalidObjName struct {
-		name string
+		name   string
+		reason string // (optional)
 	}
 	ErrNotRemoteBck struct {
 		act string
@@ -478,11 +516,111 @@ func (e *ErrCapExceeded) Error() string {
 		e.usedPct, e.highWM, suffix)
 }
 
+func isErrReqLimit(err error, status *int) bool {
+	var e *ErrReqLimit
+	if errors.As(err, &e) {
+		*status = e.status
+		return true
+	}
+	return false
+}
+
 func IsErrCapExceeded(err error) bool {
 	_, ok := err.(*ErrCapExceeded)
 	return ok || cos.IsErrOOS(err) // NOTE: a superset
 }
 
+// ErrImmutable & ErrContentAddr
+
+func NewErrImmutable(cname, op string) *ErrImmutable { return &ErrImmutable{cname: cname, op: op} }
+
+func (e *ErrImmutable) Error() string {
+	return fmt.Sprintf("cannot %s %s: published objects are immutable", e.op, e.cname)
+}
+
+func IsErrImmutable(err error) bool {
+	var e *ErrImmutable
+	return errors.As(err, &e)
+}
+
+func (e *ErrContentAddr) Error() string {
+	if e.cksum == nil {
+		return fmt.Sprintf("content-addressed %q: missing checksum", e.objName)
+	}
+	return fmt.Sprintf("content-addressed %q: name does not match the content's %s", e.objName, e.cksum.String())
+}
+
+// ErrBucketFrozen
+
+func (e *ErrBucketFrozen) Error() string {
+	var (
+		state = &e.state
+		since = time.Unix(0, state.Since).UTC().Format(time.RFC3339)
+		until = "thawed"
+		s     string
+	)
+	if state.Until != 0 {
+		until = time.Unix(0, state.Until).UTC().Format(time.RFC3339)
+	}
+	if state.Reason != "" {
+		s = fmt.Sprintf(", reason: %q", state.Reason)
+	}
+	return fmt.Sprintf("bucket %s is frozen (%s): %s access denied [trigger: %s%s, since: %s, until: %s]",
+		e.cname, state.Mode, e.op, state.Trigger, s, since, until)
+}
+
+func (e *ErrBucketFrozen) State() apc.FreezeState { return e.state }
+
+func IsErrBucketFrozen(err error) bool {
+	var e *ErrBucketFrozen
+	return errors.As(err, &e)
+}
+
+// ErrReqLimit
+
+func NewErrReqLimit(what string, value, limit int64, status int) *ErrReqLimit {
+	return &ErrReqLimit{what: what, value: value, limit: limit, status: status}
+}
+
+func (e *ErrReqLimit) Error() string {
+	switch e.status {
+	case http.StatusRequestTimeout:
+		return fmt.Sprintf("slow request: %s below the minimum %s/s", e.what, cos.ToSizeIEC(e.limit, 0))
+	case http.StatusRequestHeaderFieldsTooLarge:
+		if e.what == "header count" {
+			return fmt.Sprintf("request %s %d exceeds the limit %d", e.what, e.value, e.limit)
+		}
+	}
+	if e.value > 0 {
+		return fmt.Sprintf("request %s (%s) exceeds the limit %s", e.what, cos.ToSizeIEC(e.value, 0), cos.ToSizeIEC(e.limit, 0))
+	}
+	return fmt.Sprintf("request %s exceeds the limit %s", e.what, cos.ToSizeIEC(e.limit, 0))
+}
+
+func (e *ErrReqLimit) Status() int { return e.status }
+
+// ErrDeadline
+
+func NewErrDeadline(what, timing string, over time.Duration) *ErrDeadline {
+	return &ErrDeadline{what: what, timing: timing, over: over}
+}
+
+func (e *ErrDeadline) Error() string {
+	s := fmt.Sprintf("%s: deadline exceeded by %v", e.what, e.over.Round(time.Millisecond))
+	if e.timing != "" {
+		s += " (" + e.timing + ")"
+	}
+	return s
+}
+
+// (classified as ErrKindTimeout)
+func (*ErrDeadline) Unwrap() error { return context.DeadlineExceeded }
+
+func IsErrDeadline(err error) bool {
+	var e *ErrDeadline
+	return errors.As(err, &e)
+}
+
 // ErrGetCap
 
 func NewErrGetCap(err error) *ErrGetCap {
@@ -861,12 +999,15 @@ func IsErrXactUsePrev(err error) bool {
 
 func ValidateObjName(name string) (err *ErrInvalidObjName) {
 	if cos.IsLastB(name, filepath.Separator) || strings.Contains(name, "../") {
-		err = &ErrInvalidObjName{name}
+		err = &ErrInvalidObjName{name: name}
 	}
 	return err
 }
 
 func (e *ErrInvalidObjName) Error() string {
+	if e.reason != "" {
+		return fmt.Sprintf("invalid object name %q: %s", e.name, e.reason)
+	}
 	return fmt.Sprintf("invalid object name %q", e.name)
 }
 
@@ -1011,6 +1152,9 @@ func (e *ErrHTTP) init(r *http.Request, err error, ecode int) {
 	}
 	_clean(err)
 	e.Message = err.Error()
+	kind := errKind(err, e.Status)
+	e.Kind, e.Retriable = string(kind), kind.Retriable()
+	e.Resource = errResourceOf(err)
 	if r != nil {
 		e.Method, e.URLPath = r.Method, r.URL.Path
 		e.RemoteAddr = r.RemoteAddr
@@ -1028,6 +1172,22 @@ func (e *ErrHTTP) Error() (s string) {
 	return e.Message
 }
 
+// when missing (e.g., older server), derive the kind from HTTP status
+func (e *ErrHTTP) ErrKind() ErrKind {
+	if e.Kind != "" {
+		return ErrKind(e.Kind)
+	}
+	return statusKind(e.Status)
+}
+
+// errors.Is(err, cmn.ErrKindNotFound) and similar
+func (e *ErrHTTP) Is(target error) bool {
+	if kind, ok := target.(ErrKind); ok {
+		return e.ErrKind() == kind
+	}
+	return false
+}
+
 func _clean(err error) {
 	if cleanPathErr != nil {
 		cleanPathErr(err)
@@ -1182,6 +1342,13 @@ func WriteErr(w http.ResponseWriter, r *http.Request, err error, opts ...int /*[
 			status = http.StatusNotFound
 		case IsErrCapExceeded(err):
 			status = http.StatusInsufficientStorage
+		case isErrReqLimit(err, &status):
+		case IsErrDeadline(err):
+			status = http.StatusGatewayTimeout
+		case IsErrImmutable(err):
+			status = http.StatusConflict
+		case IsErrBucketFrozen(err):
+			status = http.StatusForbidden
 		case IsErrRangeNotSatisfiable(err):
 			status = http.StatusRequestedRangeNotSatisfiable
 		case isErrUnsupp(err), isErrNotImpl(err):
@@ -0,0 +1,154 @@
+// Package cmn provides common constants, types, and utilities for AIS clients
+// and AIStore.
+/*
+ * Copyright (c) 2018-2024, NVIDIA CORPORATION. All rights reserved.
+ */
+package cmn
+
+import (
+	"context"
+	"errors"
+	"net/http"
+)
+
+// Machine-readable error classification carried by ErrHTTP (`kind`, `resource`, `retriable`)
+// and surfaced to API callers as typed errors, e.g.:
+//
+//	if errors.Is(err, cmn.ErrKindNotFound) { ... }
+//	if cmn.IsRetriable(err) { ... }
+
+type ErrKind string
+
+// ErrKind enum
+const (
+	ErrKindInvalid      ErrKind = "invalid"   // bad request, invalid argument(s)
+	ErrKindNotFound     ErrKind = "not-found" // bucket, object, xaction, mountpath, etc.
+	ErrKindExists       ErrKind = "already-exists"
+	ErrKindAccess       ErrKind = "access-denied" // permissions (bucket/object access attributes)
+	ErrKindUnauthorized ErrKind = "unauthorized"  // authn: invalid or missing token
+	ErrKindBusy         ErrKind = "busy"          // conflicting operation in progress (retriable)
+	ErrKindUnavailable  ErrKind = "unavailable"   // node starting up, remote offline, etc. (retriable)
+	ErrKindTimeout      ErrKind = "timeout"       // (retriable)
+	ErrKindCapacity     ErrKind = "capacity"      // out of space
+	ErrKindRange        ErrKind = "range"         // range not satisfiable
+	ErrKindUnsupported  ErrKind = "unsupported"   // not supported or not implemented
+	ErrKindAborted      ErrKind = "aborted"
+	ErrKindIntegrity    ErrKind = "integrity" // checksum mismatch, corrupted metadata
+	ErrKindInternal     ErrKind = "internal"
+)
+
+// errors.Is support: ErrKind is itself an error
+func (k ErrKind) Error() string { return string(k) }
+
+func (k ErrKind) Retriable() bool {
+	return k == ErrKindBusy || k == ErrKindUnavailable || k == ErrKindTimeout
+}
+
+// implemented by assorted errors (below) to report the entity in question
+type errResource interface {
+	resource() string
+}
+
+func (e *ErrBucketAlreadyExists) resource() string { return e.bck.Cname("") }
+func (e *ErrRemoteBckNotFound) resource() string   { return e.bck.Cname("") }
+func (e *ErrRemoteBucketOffline) resource() string { return e.bck.Cname("") }
+func (e *ErrBckNotFound) resource() string         { return e.bck.Cname("") }
+func (e *errAccessDenied) resource() string        { return e.entity }
+func (e *ErrObjDefunct) resource() string          { return e.name }
+func (e *ErrInvalidObjName) resource() string      { return e.name }
+func (e *ErrMpathNotFound) resource() string       { return e.mpath }
+func (e *ErrBusy) resource() string                { return e.what }
+func (e *ErrBucketFrozen) resource() string        { return e.cname }
+
+// classify error by its type and, secondly, by HTTP status
+func errKind(err error, status int) ErrKind {
+	var (
+		errResAb *ErrAborted
+		errFail  *ErrFailedTo
+	)
+	switch {
+	case IsErrBckNotFound(err), IsErrRemoteBckNotFound(err), IsErrXactNotFound(err), IsErrMpathNotFound(err),
+		IsErrLmetaNotFound(err), isErrObjDefunct(err):
+		return ErrKindNotFound
+	case IsErrBucketAlreadyExists(err):
+		return ErrKindExists
+	case isErrRemoteBucketOffline(err):
+		return ErrKindUnavailable
+	case IsErrCapExceeded(err):
+		return ErrKindCapacity
+	case IsErrRangeNotSatisfiable(err):
+		return ErrKindRange
+	case isErrUnsupp(err), isErrNotImpl(err):
+		return ErrKindUnsupported
+	case IsErrLmetaCorrupted(err):
+		return ErrKindIntegrity
+	case errors.As(err, &errResAb):
+		return ErrKindAborted
+	case errors.Is(err, context.DeadlineExceeded):
+		return ErrKindTimeout
+	case errors.As(err, &errFail) && errFail.err != nil:
+		if kind := errKind(errFail.err, 0); kind != "" {
+			return kind
+		}
+	}
+	switch err.(type) {
+	case *ErrBusy, *ErrLimitedCoexistence:
+		return ErrKindBusy
+	case *ErrBucketAccessDenied, *ErrObjectAccessDenied, *ErrBucketFrozen:
+		return ErrKindAccess
+	case *ErrInvalidCksum:
+		return ErrKindIntegrity
+	case *ErrInvalidObjName, *ErrNotRemoteBck, *ErrInvalidBackendProvider:
+		return ErrKindInvalid
+	}
+	return statusKind(status)
+}
+
+func statusKind(status int) ErrKind {
+	switch status {
+	case 0:
+		return ""
+	case http.StatusNotFound, http.StatusGone:
+		return ErrKindNotFound
+	case http.StatusConflict:
+		return ErrKindExists
+	case http.StatusUnauthorized:
+		return ErrKindUnauthorized
+	case http.StatusForbidden:
+		return ErrKindAccess
+	case http.StatusTooManyRequests:
+		return ErrKindBusy
+	case http.StatusServiceUnavailable, http.StatusBadGateway:
+		return ErrKindUnavailable
+	case http.StatusRequestTimeout, http.StatusGatewayTimeout:
+		return ErrKindTimeout
+	case http.StatusInsufficientStorage:
+		return ErrKindCapacity
+	case http.StatusRequestedRangeNotSatisfiable:
+		return ErrKindRange
+	case http.StatusNotImplemented, http.StatusMethodNotAllowed:
+		return ErrKindUnsupported
+	}
+	if status >= http.StatusInternalServerError {
+		return ErrKindInternal
+	}
+	return ErrKindInvalid
+}
+
+func errResourceOf(err error) string {
+	var er errResource
+	if errors.As(err, &er) {
+		return er.resource()
+	}
+	return ""
+}
+
+// IsRetriable returns true if the (API) error indicates a transient condition
+// that is expected to clear - e.g., node starting up or a conflicting job in progress.
+func IsRetriable(err error) bool {
+	if herr := Err2HTTPErr(err); herr != nil {
+		return herr.Retriable || herr.ErrKind().Retriable()
+	}
+	var kind ErrKind
+	return errors.As(err, &kind) && kind.Retriable()
+}
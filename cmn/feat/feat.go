@@ -38,6 +38,9 @@ const (
 	StreamingColdGET          // write and transmit cold-GET content back to user in parallel, without _finalizing_ in-cluster object
 	S3ReverseProxy            // intra-cluster communications: instead of regular HTTP redirects reverse-proxy S3 API calls to designated targets
 	S3UsePathStyle            // use older path-style addressing (as opposed to virtual-hosted style), e.g., https://s3.amazonaws.com/BUCKET/KEY
+	CompactSmap               // metasync: when possible, send Smap changes in compact binary form (see meta.SmapDelta)
+	CustomMDSidecar           // (*) store custom object metadata in per-object sidecar files rather than xattr (see also apc.ActMigrateCustomMD)
+	JournalPUT                // (*) journal PUT finalization (per mountpath) to survive crashes and power loss (see core/lom_journal.go)
 )
 
 var Cluster = [...]string{
@@ -56,6 +59,9 @@ var Cluster = [...]string{
 	"Streaming-Cold-GET",
 	"S3-Reverse-Proxy",
 	"S3-Use-Path-Style", // https://aws.amazon.com/blogs/aws/amazon-s3-path-deprecation-plan-the-rest-of-the-story
+	"Compact-Smap",
+	"Custom-MD-Sidecar",
+	"Journal-PUT",
 	// "none" ====================
 }
 
@@ -66,6 +72,8 @@ var Bucket = [...]string{
 	"Disable-Cold-GET",
 	"Streaming-Cold-GET",
 	"S3-Use-Path-Style", // https://aws.amazon.com/blogs/aws/amazon-s3-path-deprecation-plan-the-rest-of-the-story
+	"Custom-MD-Sidecar",
+	"Journal-PUT",
 	// "none" ====================
 }
 
@@ -1,6 +1,6 @@
 // Package fname contains filename constants and common system directories
 /*
- * Copyright (c) 2018-2022, NVIDIA CORPORATION. All rights reserved.
+ * Copyright (c) 2018-2024, NVIDIA CORPORATION. All rights reserved.
  */
 package fname
 
@@ -11,6 +11,7 @@ const (
 	HomeAIS        = "ais"     // join(cos.HomeDir(), HomeConfigsDir, HomeAisDir)
 	HomeCLI        = "cli"     // ditto
 	HomeAuthN      = "authn"
+	Profiles       = "profiles" // client profiles: join(cos.HomeDir(), HomeConfigsDir, HomeAIS, Profiles)
 )
 
 const (
@@ -43,6 +44,18 @@ const (
 	// Token
 	Token = "auth.token"
 
+	// proxy: copy (transform) bucket checkpoints (one file per job)
+	TcbCkptDir = ".ais.tcb"
+
+	// target: offline transform (ETL bucket) checkpoints (one file per job)
+	EtlCkptDir = ".ais.etl"
+
+	// target: last burn-in report (see xs/burnin.go)
+	BurnIn = ".ais.burnin"
+
+	// target: PUT finalization intent journal (per mountpath; see feat.JournalPUT)
+	WriteJournal = ".ais.journal"
+
 	// Markers: per mountpath
 	MarkersDir          = ".ais.markers"
 	ResilverMarker      = "resilver"
@@ -0,0 +1,57 @@
+// Package cmn provides common constants, types, and utilities for AIS clients
+// and AIStore.
+/*
+ * Copyright (c) 2024, NVIDIA CORPORATION. All rights reserved.
+ */
+package cmn
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/NVIDIA/aistore/api/apc"
+	"github.com/NVIDIA/aistore/cmn/cos"
+)
+
+// Bucket freeze (see apc.FreezeState for modes and permitted operations):
+// - explicit: apc.ActFreezeBck and apc.ActThawBck;
+// - automatic: primary periodically checks buckets with non-zero quota and freezes
+//   (read-only) those that exceed it; quota-triggered freeze is lifted once the quota
+//   is raised (or removed);
+// - automatic: integrity alert (manifest verification mismatch) freezes the bucket in
+//   the configured `on_integrity` mode;
+// - current state (freeze.state) is part of the bucket props and can only be changed
+//   via freeze/thaw - see ais/prxfreeze.go.
+
+type (
+	FreezeConf struct {
+		State       apc.FreezeState `json:"state,omitempty" list:"omitempty"`
+		Quota       cos.SizeIEC     `json:"quota,omitempty"`        // max total size of in-cluster objects (zero: unlimited)
+		OnIntegrity string          `json:"on_integrity,omitempty"` // freeze mode upon integrity alert (empty: don't freeze)
+	}
+	FreezeConfToSet struct {
+		Quota       *cos.SizeIEC `json:"quota,omitempty"`
+		OnIntegrity *string      `json:"on_integrity,omitempty"`
+	}
+)
+
+func (c *FreezeConf) validate() error {
+	if c.Quota < 0 {
+		return fmt.Errorf("invalid freeze.quota %d (must be non-negative)", c.Quota)
+	}
+	if c.OnIntegrity != "" {
+		if err := apc.ValidateFreezeMode(c.OnIntegrity); err != nil {
+			return fmt.Errorf("invalid freeze.on_integrity: %w", err)
+		}
+	}
+	return nil
+}
+
+// returns ErrBucketFrozen if the bucket is currently frozen and doesn't permit `ace`
+func (c *FreezeConf) Check(cname string, ace apc.AccessAttrs) error {
+	state := &c.State
+	if state.Mode == "" || !state.IsFrozen(time.Now().UnixNano()) || state.Allows(ace) {
+		return nil
+	}
+	return &ErrBucketFrozen{cname: cname, op: apc.AccessOp(ace), state: *state}
+}
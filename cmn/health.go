@@ -0,0 +1,21 @@
+// Package cmn provides common constants, types, and utilities for AIS clients
+// and AIStore.
+/*
+ * Copyright (c) 2024, NVIDIA CORPORATION. All rights reserved.
+ */
+package cmn
+
+import "time"
+
+// deep health check (apc.QparamHealthDeep): the primary writes, reads back, and
+// deletes a tiny object via a random target (in a hidden system bucket - see NsSys)
+type HealthProbe struct {
+	Target string        `json:"target"` // ID of the target that was probed
+	Err    string        `json:"error,omitempty"`
+	Put    time.Duration `json:"put"`
+	Get    time.Duration `json:"get"`
+	Del    time.Duration `json:"delete"`
+	Total  time.Duration `json:"total"`
+	Time   int64         `json:"time"` // when probed (Unix nanoseconds)
+	OK     bool          `json:"ok"`
+}
@@ -0,0 +1,74 @@
+// Package cmn provides common constants, types, and utilities for AIS clients
+// and AIStore.
+/*
+ * Copyright (c) 2024, NVIDIA CORPORATION. All rights reserved.
+ */
+package cmn
+
+import (
+	"fmt"
+	"net/url"
+	"time"
+
+	"github.com/NVIDIA/aistore/cmn/cos"
+)
+
+// Bucket-level validation webhook: before committing a PUT (and, optionally, a DELETE)
+// AIS target POSTs object metadata (HookReq) to the configured endpoint and expects:
+// - 2xx: allow;
+// - 4xx: deny - the operation fails with 403, and the response body (if any) is returned
+//   to the client as the reason;
+// - anything else (including timeout and connection errors): webhook failure - allow
+//   or deny depending on the `fail_open` policy.
+
+const DfltHookTimeout = 5 * time.Second
+
+type (
+	HookConf struct {
+		URL      string       `json:"url,omitempty"`       // http(s) endpoint (empty: disabled)
+		Timeout  cos.Duration `json:"timeout,omitempty"`   // (dflt. DfltHookTimeout)
+		FailOpen bool         `json:"fail_open,omitempty"` // allow when the webhook fails (is unreachable, times out, etc.)
+		Delete   bool         `json:"delete,omitempty"`    // validate DELETEs as well
+	}
+	HookConfToSet struct {
+		URL      *string       `json:"url,omitempty"`
+		Timeout  *cos.Duration `json:"timeout,omitempty"`
+		FailOpen *bool         `json:"fail_open,omitempty"`
+		Delete   *bool         `json:"delete,omitempty"`
+	}
+
+	// webhook request body
+	HookReq struct {
+		Op         string     `json:"op"` // http.MethodPut | http.MethodDelete
+		Bck        Bck        `json:"bucket"`
+		ObjName    string     `json:"name"`
+		Size       int64      `json:"size,omitempty"`
+		CksumType  string     `json:"checksum_type,omitempty"`
+		CksumValue string     `json:"checksum_value,omitempty"`
+		Custom     cos.StrKVs `json:"custom-md,omitempty"`
+		Node       string     `json:"node"` // calling target
+	}
+)
+
+func (c *HookConf) Enabled() bool { return c.URL != "" }
+
+func (c *HookConf) TimeoutOrDflt() time.Duration {
+	if c.Timeout > 0 {
+		return c.Timeout.D()
+	}
+	return DfltHookTimeout
+}
+
+func (c *HookConf) ValidateAsProps(...any) error {
+	if c.Timeout < 0 {
+		return fmt.Errorf("invalid hook.timeout %v (must be non-negative)", c.Timeout)
+	}
+	if c.URL == "" {
+		return nil
+	}
+	u, err := url.Parse(c.URL)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return fmt.Errorf("invalid hook.url %q (expecting http(s)://host[:port]/path)", c.URL)
+	}
+	return nil
+}
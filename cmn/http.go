@@ -45,7 +45,7 @@ type (
 		RawQuery string      // raw query
 		Method   string
 		Base     string // base URL, e.g. http://xyz.abc
-		Path     string // path URL, e.g. /x/y/z
+		Path     string // path URL, e.g. /x/y/z (unescaped - see URL())
 		Body     []byte
 	}
 
@@ -282,7 +282,7 @@ func FreeHra(a *HreqArgs) {
 }
 
 func (u *HreqArgs) URL() string {
-	url := cos.JoinPath(u.Base, u.Path)
+	url := cos.JoinPath(u.Base, cos.EscapePath(u.Path))
 	if u.RawQuery != "" {
 		return url + "?" + u.RawQuery
 	}
@@ -0,0 +1,77 @@
+// Package cmn provides common constants, types, and utilities for AIS clients
+// and AIStore.
+/*
+ * Copyright (c) 2024, NVIDIA CORPORATION. All rights reserved.
+ */
+package cmn
+
+import (
+	"strings"
+	"time"
+)
+
+// Unified view of all long-running jobs (`/v1/jobs`): xactions, dsort, downloads, ETLs, and pipelines.
+// Each job is identified by "<type>:<native-ID>", where the native ID is the one returned
+// by the corresponding subsystem (xaction UUID, dsort and download job IDs, ETL name).
+
+// job types
+const (
+	JobTypeXaction  = "xaction"
+	JobTypeDsort    = "dsort"
+	JobTypeDownload = "download"
+	JobTypeETL      = "etl"
+	JobTypePipeline = "pipeline" // composite (see PipelineMsg)
+)
+
+// job states
+const (
+	JobQueued   = "queued"
+	JobRunning  = "running"
+	JobIdle     = "idle" // (xactions that are waiting for work)
+	JobFinished = "finished"
+	JobAborted  = "aborted"
+	JobFailed   = "failed"
+)
+
+type (
+	Job struct {
+		StartTime time.Time   `json:"start_time,omitempty"`
+		EndTime   time.Time   `json:"end_time,omitempty"`
+		ID        string      `json:"id"`        // "<type>:<native-ID>"
+		Type      string      `json:"type"`      // JobType* enum
+		Kind      string      `json:"kind"`      // xaction kind, download type, etc.
+		NativeID  string      `json:"native_id"` // as per the subsystem that runs the job
+		Owner     string      `json:"owner,omitempty"`
+		State     string      `json:"state"` // Job* enum (above)
+		Err       string      `json:"err,omitempty"`
+		Bcks      []Bck       `json:"buckets,omitempty"`
+		Progress  JobProgress `json:"progress"`
+		Name      string      `json:"name,omitempty"`  // pipeline step name
+		Steps     Jobs        `json:"steps,omitempty"` // pipeline steps, in the order of submission
+	}
+	JobProgress struct {
+		Objs  int64 `json:"objs,string"`
+		Bytes int64 `json:"bytes,string"`
+		Total int64 `json:"total,string,omitempty"` // total number of objects (tasks), when known
+	}
+	Jobs []*Job
+)
+
+func JobID(typ, nativeID string) string { return typ + ":" + nativeID }
+
+// returns (type, native ID) or ("", id) when id is not prefixed with a known type
+func ParseJobID(id string) (typ, nativeID string) {
+	if i := strings.IndexByte(id, ':'); i > 0 {
+		switch typ = id[:i]; typ {
+		case JobTypeXaction, JobTypeDsort, JobTypeDownload, JobTypeETL, JobTypePipeline:
+			return typ, id[i+1:]
+		}
+	}
+	return "", id
+}
+
+func (j *Job) Finished() bool {
+	return j.State == JobFinished || j.State == JobAborted || j.State == JobFailed
+}
+
+func (j *Job) String() string { return j.ID + "[" + j.Kind + ", " + j.State + "]" }
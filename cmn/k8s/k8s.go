@@ -28,9 +28,18 @@ const (
 
 const nonK8s = "non-Kubernetes deployment"
 
+// node labels: network topology (see cmn.TopologyConf)
+const (
+	LabelZone = v1.LabelTopologyZone          // "topology.kubernetes.io/zone" (well-known)
+	LabelRack = "topology.kubernetes.io/rack" // (not well-known - must be assigned by the admin)
+)
+
 var (
 	NodeName string // assign upon successful initialization
 
+	// topology labels of the node (empty when not labeled)
+	Zone, Rack string
+
 	ErrK8sRequired = errors.New("the operation requires Kubernetes")
 )
 
@@ -87,6 +96,10 @@ checkNode: // always check Node
 	if node.Namespace != "" {
 		nlog.Infoln("Node", NodeName, "Namespace", node.Namespace)
 	}
+	Zone, Rack = node.Labels[LabelZone], node.Labels[LabelRack]
+	if Zone != "" || Rack != "" {
+		nlog.Infoln("Node", NodeName, "zone:", Zone, "rack:", Rack)
+	}
 }
 
 func _ppvols(volumes []v1.Volume) {
@@ -0,0 +1,253 @@
+// Package cmn provides common constants, types, and utilities for AIS clients
+// and AIStore.
+/*
+ * Copyright (c) 2024, NVIDIA CORPORATION. All rights reserved.
+ */
+package cmn
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"hash"
+	"io"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/NVIDIA/aistore/cmn/cos"
+	jsoniter "github.com/json-iterator/go"
+)
+
+// Signed object manifest: point-in-time listing of a bucket (or a bucket's virtual
+// subdirectory) - object names, sizes, checksums, and versions - that serves as a
+// dataset fingerprint (e.g., for reproducible ML):
+// - `Digest` (SHA-256) is computed over canonical (sorted) entries and can be recomputed
+//   by anyone (see ComputeDigest);
+// - `Signature` (HMAC-SHA256) covers the digest and all the other manifest's fields and
+//   can only be produced (and verified) by the cluster.
+// - large manifests are generated (ManifestWriter) and verified (ManifestCmp) page by page
+//   without having to hold the entire listing in memory.
+// See also: apc.ActMakeManifest, apc.ActVerifyManifest
+
+type (
+	ManifestEntry struct {
+		Name    string `json:"name"`
+		Cksum   string `json:"checksum,omitempty"`
+		Version string `json:"version,omitempty"`
+		Size    int64  `json:"size,string"`
+	}
+	Manifest struct {
+		Created     time.Time       `json:"created"`
+		Bck         Bck             `json:"bck"`
+		Prefix      string          `json:"prefix,omitempty"`
+		ClusterUUID string          `json:"cluster_uuid"`
+		CksumType   string          `json:"checksum_type"` // (bucket's)
+		Digest      string          `json:"digest"`
+		Signature   string          `json:"signature"`
+		Entries     []ManifestEntry `json:"entries"`
+	}
+
+	// streaming (page by page) JSON encoding of a signed manifest; entries must be added
+	// in order (sorted by name), as listed
+	ManifestWriter struct {
+		mf     *Manifest
+		stream *jsoniter.Stream
+		h      hash.Hash
+		last   string
+		n      int
+	}
+	// streaming comparison of the current content with a given manifest
+	ManifestCmp struct {
+		diff *ManifestDiff
+		m    map[string]*ManifestEntry // remaining (not yet seen) manifest entries
+		mf   *Manifest
+	}
+
+	// result of verifying current bucket content against a manifest
+	ManifestDiff struct {
+		Missing  []string `json:"missing,omitempty"` // in the manifest but not in the bucket
+		Changed  []string `json:"changed,omitempty"` // different size, checksum, or version
+		Added    []string `json:"added,omitempty"`   // in the bucket (under the manifest's prefix) but not in the manifest
+		Verified int      `json:"verified"`          // number of matching objects
+		SigOK    bool     `json:"signature_ok"`      // the manifest was signed by this cluster and was not tampered with
+	}
+)
+
+func (m *Manifest) sort() {
+	sort.Slice(m.Entries, func(i, j int) bool { return m.Entries[i].Name < m.Entries[j].Name })
+}
+
+// SHA-256 over canonical (sorted by name) entries
+func (m *Manifest) ComputeDigest() string {
+	if !sort.SliceIsSorted(m.Entries, func(i, j int) bool { return m.Entries[i].Name < m.Entries[j].Name }) {
+		m.sort()
+	}
+	h := sha256.New()
+	for i := range m.Entries {
+		_hashEntry(h, &m.Entries[i])
+	}
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+func _hashEntry(h hash.Hash, e *ManifestEntry) {
+	_write(h, e.Name, strconv.FormatInt(e.Size, 10), e.Cksum, e.Version)
+}
+
+// HMAC-SHA256 over the digest and all the rest (except entries)
+func (m *Manifest) ComputeSignature(key []byte) string {
+	h := hmac.New(sha256.New, key)
+	_write(h, m.Created.UTC().Format(time.RFC3339Nano), m.Bck.Cname(""), m.Prefix, m.ClusterUUID, m.CksumType, m.Digest)
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+func (m *Manifest) Sign(key []byte) {
+	m.Digest = m.ComputeDigest()
+	m.Signature = m.ComputeSignature(key)
+}
+
+// both digest and signature must match
+func (m *Manifest) VerifySignature(key []byte) bool {
+	if m.Digest != m.ComputeDigest() {
+		return false
+	}
+	return hmac.Equal([]byte(m.Signature), []byte(m.ComputeSignature(key)))
+}
+
+// compare with the current content (listed as another manifest)
+func (m *Manifest) Diff(cur *Manifest) *ManifestDiff {
+	cmp := m.NewCmp()
+	for i := range cur.Entries {
+		cmp.Add(&cur.Entries[i])
+	}
+	return cmp.Finish()
+}
+
+////////////////////
+// ManifestWriter //
+////////////////////
+
+// writes all fields except entries, digest, and signature (that follow)
+func NewManifestWriter(w io.Writer, mf *Manifest) *ManifestWriter {
+	mw := &ManifestWriter{mf: mf, stream: cos.JSON.BorrowStream(w), h: sha256.New()}
+	s := mw.stream
+	s.WriteObjectStart()
+	s.WriteObjectField("created")
+	s.WriteVal(mf.Created)
+	s.WriteMore()
+	s.WriteObjectField("bck")
+	s.WriteVal(&mf.Bck)
+	if mf.Prefix != "" {
+		s.WriteMore()
+		s.WriteObjectField("prefix")
+		s.WriteString(mf.Prefix)
+	}
+	s.WriteMore()
+	s.WriteObjectField("cluster_uuid")
+	s.WriteString(mf.ClusterUUID)
+	s.WriteMore()
+	s.WriteObjectField("checksum_type")
+	s.WriteString(mf.CksumType)
+	s.WriteMore()
+	s.WriteObjectField("entries")
+	s.WriteArrayStart()
+	return mw
+}
+
+func (mw *ManifestWriter) Add(e *ManifestEntry) error {
+	if mw.n > 0 {
+		if e.Name <= mw.last {
+			return errors.New("manifest: entries are not sorted (" + mw.last + ", " + e.Name + ")")
+		}
+		mw.stream.WriteMore()
+	}
+	mw.stream.WriteVal(e)
+	_hashEntry(mw.h, e)
+	mw.last = e.Name
+	mw.n++
+	return mw.stream.Error
+}
+
+// flush buffered entries (e.g., upon each listed page)
+func (mw *ManifestWriter) Flush() error { return mw.stream.Flush() }
+
+func (mw *ManifestWriter) Count() int { return mw.n }
+
+// write digest and signature, and flush
+func (mw *ManifestWriter) Finish(key []byte) error {
+	mf, s := mw.mf, mw.stream
+	mf.Digest = hex.EncodeToString(mw.h.Sum(nil))
+	mf.Signature = mf.ComputeSignature(key)
+	s.WriteArrayEnd()
+	s.WriteMore()
+	s.WriteObjectField("digest")
+	s.WriteString(mf.Digest)
+	s.WriteMore()
+	s.WriteObjectField("signature")
+	s.WriteString(mf.Signature)
+	s.WriteObjectEnd()
+	return mw.Flush()
+}
+
+func (mw *ManifestWriter) Free() {
+	cos.JSON.ReturnStream(mw.stream)
+	mw.stream = nil
+}
+
+/////////////////
+// ManifestCmp //
+/////////////////
+
+func (m *Manifest) NewCmp() *ManifestCmp {
+	cmp := &ManifestCmp{diff: &ManifestDiff{}, m: make(map[string]*ManifestEntry, len(m.Entries)), mf: m}
+	for i := range m.Entries {
+		cmp.m[m.Entries[i].Name] = &m.Entries[i]
+	}
+	return cmp
+}
+
+// add current (listed) entry
+func (cmp *ManifestCmp) Add(c *ManifestEntry) {
+	e, ok := cmp.m[c.Name]
+	switch {
+	case !ok:
+		cmp.diff.Added = append(cmp.diff.Added, c.Name)
+		return
+	case c.Size != e.Size || c.Cksum != e.Cksum || c.Version != e.Version:
+		cmp.diff.Changed = append(cmp.diff.Changed, c.Name)
+	default:
+		cmp.diff.Verified++
+	}
+	delete(cmp.m, c.Name)
+}
+
+// manifest entries that were not listed are missing
+func (cmp *ManifestCmp) Finish() *ManifestDiff {
+	diff := cmp.diff
+	for name := range cmp.m {
+		diff.Missing = append(diff.Missing, name)
+	}
+	sort.Strings(diff.Missing)
+	sort.Strings(diff.Changed)
+	sort.Strings(diff.Added)
+	return diff
+}
+
+func (d *ManifestDiff) Match() bool {
+	return d.SigOK && len(d.Missing) == 0 && len(d.Changed) == 0 && len(d.Added) == 0
+}
+
+// authentic (signed by this cluster) manifest whose objects are no longer there or have changed;
+// unlike unsigned or forged manifests, this one is an integrity alert (see freeze.on_integrity)
+func (d *ManifestDiff) Tampered() bool {
+	return d.SigOK && (len(d.Missing) > 0 || len(d.Changed) > 0)
+}
+
+// (each field is NUL-terminated to avoid ambiguity)
+func _write(h hash.Hash, fields ...string) {
+	for _, f := range fields {
+		h.Write([]byte(f))
+		h.Write([]byte{0})
+	}
+}
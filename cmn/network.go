@@ -36,6 +36,11 @@ const (
 	DefaultSendRecvBufferSize  = 128 * cos.KiB
 )
 
+// DSCP: 6 most significant bits of the IPv4 TOS (IPv6 traffic class) octet
+const MaxDSCP = 63
+
+func dscp2tos(dscp int) int { return dscp << 2 }
+
 var KnownNetworks = [...]string{NetPublic, NetIntraControl, NetIntraData}
 
 func NetworkIsKnown(net string) bool {
@@ -1,7 +1,7 @@
 // Package cmn provides common constants, types, and utilities for AIS clients
 // and AIStore.
 /*
- * Copyright (c) 2018-2021, NVIDIA CORPORATION. All rights reserved.
+ * Copyright (c) 2018-2024, NVIDIA CORPORATION. All rights reserved.
  */
 package cmn
 
@@ -9,6 +9,7 @@ import (
 	"syscall"
 
 	"github.com/NVIDIA/aistore/cmn/debug"
+	"github.com/NVIDIA/aistore/cmn/nlog"
 )
 
 func (args *TransportArgs) setSockOpt(_, _ string, c syscall.RawConn) (err error) {
@@ -17,12 +18,25 @@ func (args *TransportArgs) setSockOpt(_, _ string, c syscall.RawConn) (err error
 
 func (args *TransportArgs) ConnControl(_ syscall.RawConn) (cntl func(fd uintptr)) {
 	cntl = func(fd uintptr) {
-		// NOTE: is limited by /proc/sys/net/core/rmem_max
-		err := syscall.SetsockoptInt(int(fd), syscall.SOL_SOCKET, syscall.SO_RCVBUF, args.SndRcvBufSize)
-		debug.AssertNoErr(err)
-		// NOTE: is limited by /proc/sys/net/core/wmem_max
-		err = syscall.SetsockoptInt(int(fd), syscall.SOL_SOCKET, syscall.SO_SNDBUF, args.SndRcvBufSize)
-		debug.AssertNoErr(err)
+		if args.SndRcvBufSize > 0 {
+			// NOTE: is limited by /proc/sys/net/core/rmem_max
+			err := syscall.SetsockoptInt(int(fd), syscall.SOL_SOCKET, syscall.SO_RCVBUF, args.SndRcvBufSize)
+			debug.AssertNoErr(err)
+			// NOTE: is limited by /proc/sys/net/core/wmem_max
+			err = syscall.SetsockoptInt(int(fd), syscall.SOL_SOCKET, syscall.SO_SNDBUF, args.SndRcvBufSize)
+			debug.AssertNoErr(err)
+		}
+		if args.TOS > 0 {
+			setTOS(int(fd), args.TOS)
+		}
 	}
 	return
 }
+
+// DSCP marking: IPv4 or else IPv6 (dual-stack sockets take both)
+func setTOS(fd, tos int) {
+	err := syscall.SetsockoptInt(fd, syscall.IPPROTO_IP, syscall.IP_TOS, tos)
+	if err6 := syscall.SetsockoptInt(fd, syscall.IPPROTO_IPV6, syscall.IPV6_TCLASS, tos); err != nil && err6 != nil {
+		nlog.Warningln("failed to set IP_TOS", tos, "err:", err, err6)
+	}
+}
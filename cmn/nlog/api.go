@@ -15,6 +15,7 @@ const (
 	ActNone = iota
 	ActExit
 	ActRotate
+	ActFlush // unconditionally (e.g., prior to reading the log)
 )
 
 var LogToStderr bool
@@ -49,6 +50,8 @@ func InfoLogName() string { return sname() + ".INFO" }
 func ErrLogName() string  { return sname() + ".ERROR" }
 
 func Flush(action int) {
+	flushMu.Lock()
+	defer flushMu.Unlock()
 	now := mono.NanoTime()
 	for _, sev := range []severity{sevInfo, sevErr} {
 		var (
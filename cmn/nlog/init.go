@@ -40,6 +40,7 @@ var (
 	pid int
 
 	onceInitFiles sync.Once
+	flushMu       sync.Mutex // serializes flushing (stats runner, log readers, exit)
 
 	stopping atomic.Bool // true when exiting
 )
@@ -61,6 +61,7 @@ func log(sev severity, depth int, format string, args ...any) {
 		fb := alloc()
 		sprintf(sev, depth, format, fb, args...)
 		fb.flush(os.Stderr)
+		ship(fb.buf[:fb.woff])
 		free(fb)
 	case sev >= sevWarn:
 		fb := alloc()
@@ -115,6 +116,9 @@ func (nlog *nlog) printf(sev severity, depth int, format string, args ...any) {
 func (nlog *nlog) write(line *fixed) {
 	buf := line.buf[:line.woff]
 	nlog.pw.Write(buf)
+	if nlog.sev == sevInfo { // (all lines, once)
+		ship(buf)
+	}
 
 	if nlog.pw.avail() > maxLineSize {
 		return
@@ -0,0 +1,261 @@
+// Package nlog - aistore logger, provides buffering, timestamping, writing, and
+// flushing/syncing/rotating
+/*
+ * Copyright (c) 2024, NVIDIA CORPORATION. All rights reserved.
+ */
+package nlog
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// Log shipping: all log lines get (optionally) shipped, in batches, to a central HTTP endpoint
+// in one of the two formats:
+// - "json":    newline-delimited JSON records (Vector, Logstash, and similar collectors)
+// - "fluentd": JSON array of records (Fluentd `in_http` input)
+// Shipping never blocks logging: when the endpoint is slow or unavailable, pending lines
+// get queued up to the configured maximum, and the excess is dropped (and counted).
+
+const (
+	ShipJSON    = "json"
+	ShipFluentd = "fluentd"
+)
+
+const (
+	shipTimeout    = 10 * time.Second
+	shipMaxBackoff = time.Minute
+)
+
+type (
+	ShipArgs struct {
+		URL       string
+		Format    string        // ShipJSON or ShipFluentd
+		Node      string        // (added to each record)
+		BatchSize int           // max lines per request
+		FlushTime time.Duration // max time to hold pending lines
+		QueueSize int           // max pending lines
+	}
+	ShipStats struct {
+		Shipped int64 // lines
+		Dropped int64 // ditto
+		Errors  int64 // failed requests
+	}
+	shipRec struct {
+		Time  string `json:"time"`
+		Level string `json:"level"`
+		Node  string `json:"node"`
+		Msg   string `json:"msg"`
+	}
+	shline struct {
+		line string
+		ts   int64
+	}
+	shipper struct {
+		client *http.Client
+		kick   chan struct{}
+		stop   chan struct{}
+		q      []shline
+		args   ShipArgs
+		mu     sync.Mutex
+		failed bool
+	}
+)
+
+var (
+	shp       atomic.Pointer[shipper]
+	shipStats struct {
+		shipped, dropped, errors atomic.Int64
+	}
+)
+
+// Ship starts, reconfigures, or (given nil or empty URL) stops log shipping.
+func Ship(args *ShipArgs) {
+	var s *shipper
+	if args != nil && args.URL != "" {
+		s = &shipper{
+			args:   *args,
+			client: &http.Client{Timeout: shipTimeout},
+			kick:   make(chan struct{}, 1),
+			stop:   make(chan struct{}),
+			q:      make([]shline, 0, args.BatchSize),
+		}
+		go s.run()
+	}
+	if prev := shp.Swap(s); prev != nil {
+		close(prev.stop) // (flushes what's pending and exits)
+	}
+}
+
+func Shipping() (args *ShipArgs) {
+	if s := shp.Load(); s != nil {
+		args = &s.args
+	}
+	return
+}
+
+func GetShipStats() ShipStats {
+	return ShipStats{
+		Shipped: shipStats.shipped.Load(),
+		Dropped: shipStats.dropped.Load(),
+		Errors:  shipStats.errors.Load(),
+	}
+}
+
+// is called for every log line
+func ship(line []byte) {
+	if s := shp.Load(); s != nil {
+		s.add(line)
+	}
+}
+
+func (s *shipper) add(line []byte) {
+	if l := len(line); l > 0 && line[l-1] == '\n' {
+		line = line[:l-1]
+	}
+	s.mu.Lock()
+	if len(s.q) >= s.args.QueueSize {
+		s.mu.Unlock()
+		shipStats.dropped.Add(1)
+		return
+	}
+	s.q = append(s.q, shline{line: string(line), ts: time.Now().UnixNano()})
+	n := len(s.q)
+	s.mu.Unlock()
+	if n >= s.args.BatchSize {
+		select {
+		case s.kick <- struct{}{}:
+		default:
+		}
+	}
+}
+
+func (s *shipper) run() {
+	var (
+		ticker  = time.NewTicker(s.args.FlushTime)
+		backoff time.Duration
+		retry   time.Time
+	)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-s.stop:
+			s.flush()
+			return
+		case <-s.kick:
+		case <-ticker.C:
+		}
+		if backoff > 0 && time.Now().Before(retry) {
+			continue
+		}
+		if err := s.flush(); err != nil {
+			backoff = min(max(2*backoff, s.args.FlushTime), shipMaxBackoff)
+			retry = time.Now().Add(backoff)
+			if !s.failed {
+				s.failed = true
+				Errorln("log shipping to", s.args.URL, "failed:", err, "- will keep retrying")
+			}
+		} else if backoff > 0 {
+			backoff = 0
+			s.failed = false
+			Infoln("log shipping to", s.args.URL, "resumed")
+		}
+	}
+}
+
+func (s *shipper) flush() error {
+	for {
+		s.mu.Lock()
+		n := min(len(s.q), s.args.BatchSize)
+		if n == 0 {
+			s.mu.Unlock()
+			return nil
+		}
+		batch := make([]shline, n)
+		copy(batch, s.q)
+		s.q = append(s.q[:0], s.q[n:]...)
+		s.mu.Unlock()
+
+		if err := s.post(batch); err != nil {
+			shipStats.errors.Add(1)
+			s.requeue(batch)
+			return err
+		}
+		shipStats.shipped.Add(int64(n))
+	}
+}
+
+// put the failed batch back in front of the queue (backpressure: what doesn't fit gets dropped)
+func (s *shipper) requeue(batch []shline) {
+	s.mu.Lock()
+	room := s.args.QueueSize - len(s.q)
+	if room < len(batch) {
+		shipStats.dropped.Add(int64(len(batch) - max(room, 0)))
+		batch = batch[:max(room, 0)]
+	}
+	s.q = append(batch, s.q...)
+	s.mu.Unlock()
+}
+
+func (s *shipper) post(batch []shline) error {
+	var (
+		body    bytes.Buffer
+		fluentd = s.args.Format == ShipFluentd
+		ctype   = "application/x-ndjson"
+	)
+	if fluentd {
+		body.WriteByte('[')
+		ctype = "application/json"
+	}
+	for i := range batch {
+		rec := shipRec{
+			Time:  time.Unix(0, batch[i].ts).Format(time.RFC3339Nano),
+			Level: lineLevel(batch[i].line),
+			Node:  s.args.Node,
+			Msg:   batch[i].line,
+		}
+		b, err := json.Marshal(&rec)
+		if err != nil {
+			continue
+		}
+		if fluentd && i > 0 {
+			body.WriteByte(',')
+		}
+		body.Write(b)
+		if !fluentd {
+			body.WriteByte('\n')
+		}
+	}
+	if fluentd {
+		body.WriteByte(']')
+	}
+	resp, err := s.client.Post(s.args.URL, ctype, &body)
+	if err != nil {
+		return err
+	}
+	io.Copy(io.Discard, resp.Body) //nolint:errcheck // drain
+	resp.Body.Close()
+	if resp.StatusCode >= http.StatusMultipleChoices {
+		return fmt.Errorf("%s: status %d", s.args.URL, resp.StatusCode)
+	}
+	return nil
+}
+
+// see formatHdr
+func lineLevel(line string) string {
+	if line != "" {
+		switch line[0] {
+		case 'W':
+			return "warning"
+		case 'E':
+			return "error"
+		}
+	}
+	return "info"
+}
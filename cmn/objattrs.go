@@ -35,10 +35,20 @@ const (
 
 	OrigURLObjMD = "orig_url"
 
+	// per-object replication override: number of copies regardless of the bucket's mirror
+	// config (see also apc.HdrObjCopies)
+	CopiesObjMD = "copies"
+
+	// name of the ETL that produced (transformed) cold-GET content (see BckETLConf)
+	ETLObjMD = "etl"
+
 	// additional backend
 	LastModified = "LastModified"
 )
 
+// max per-object replication override (see CopiesObjMD and fs.ValidateNCopies)
+const MaxObjCopies = 16
+
 // object properties
 // NOTE: embeds system `ObjAttrs` that in turn includes custom user-defined
 // NOTE: compare with `apc.LsoMsg`
@@ -97,6 +107,15 @@ func PropToHeader(prop string) string {
 	return headerName
 }
 
+// parse and validate per-object replication override (zero: remove the override)
+func ParseObjCopies(s string) (int, error) {
+	n, err := strconv.Atoi(s)
+	if err != nil || n < 0 || n > MaxObjCopies {
+		return 0, fmt.Errorf("invalid number of object copies %q (expecting integer in range [0, %d])", s, MaxObjCopies)
+	}
+	return n, nil
+}
+
 func (oa *ObjAttrs) String() string {
 	return fmt.Sprintf("%dB, v%q, %s, %+v", oa.Size, oa.Version(), oa.Cksum, oa.CustomMD)
 }
@@ -147,6 +166,7 @@ func S2CustomMD(custom, version string) (md cos.StrKVs) {
 	parseCustom(md, lst, CRC32CObjMD)
 	parseCustom(md, lst, MD5ObjMD)
 	parseCustom(md, lst, ETag)
+	parseCustom(md, lst, LastModified)
 	return md
 }
 
@@ -6,6 +6,7 @@
 package cmn
 
 import (
+	"container/heap"
 	"path/filepath"
 	"sort"
 	"strings"
@@ -119,6 +120,9 @@ func (be *LsoEnt) CopyWithProps(propsSet cos.StrSet) (ne *LsoEnt) {
 	if propsSet.Contains(apc.GetPropsCopies) {
 		ne.Copies = be.Copies
 	}
+	if propsSet.Contains(apc.GetPropsMtime) {
+		ne.Mtime = be.Mtime
+	}
 	return
 }
 
@@ -215,6 +219,93 @@ func MergeLso(lists []*LsoRes, lsmsg *apc.LsoMsg, maxSize int) *LsoRes {
 	return resList
 }
 
+// LsoTopN selects the first N entries in the apc.LsoSort order, in bounded memory:
+//   - min-heap (so to speak) with the "last" of the N selected at the root;
+//   - used by targets (to select local top-N) and proxy (to merge the targets' results);
+//   - selected names are unique: a duplicate (e.g., misplaced object or its copy, or the same object
+//     listed by two targets during rebalance) replaces the selected entry only if the latter is not
+//     in its proper location while the former is (see IsStatusOK).
+type LsoTopN struct {
+	entries LsoEntries
+	idx     map[string]int // name => index in entries
+	by      string
+	desc    bool
+	n       int
+}
+
+// interface guard
+var _ heap.Interface = (*LsoTopN)(nil)
+
+func NewLsoTopN(s *apc.LsoSort, n int) *LsoTopN {
+	l := min(n, 1024)
+	return &LsoTopN{entries: make(LsoEntries, 0, l), idx: make(map[string]int, l), by: s.By, desc: s.Desc, n: n}
+}
+
+// true if `a` comes before `b` (ties are broken by name)
+func (t *LsoTopN) before(a, b *LsoEnt) bool {
+	var ka, kb int64
+	if t.by == apc.LsSortByMtime {
+		ka, kb = a.Mtime, b.Mtime
+	} else {
+		ka, kb = a.Size, b.Size
+	}
+	if ka != kb {
+		return (ka > kb) == t.desc
+	}
+	return a.Name < b.Name
+}
+
+func (t *LsoTopN) Len() int           { return len(t.entries) }
+func (t *LsoTopN) Less(i, j int) bool { return t.before(t.entries[j], t.entries[i]) }
+
+func (t *LsoTopN) Swap(i, j int) {
+	t.entries[i], t.entries[j] = t.entries[j], t.entries[i]
+	t.idx[t.entries[i].Name] = i
+	t.idx[t.entries[j].Name] = j
+}
+
+func (t *LsoTopN) Push(x any) {
+	e := x.(*LsoEnt)
+	t.idx[e.Name] = len(t.entries)
+	t.entries = append(t.entries, e)
+}
+
+func (t *LsoTopN) Pop() any {
+	l := len(t.entries)
+	e := t.entries[l-1]
+	t.entries[l-1] = nil
+	t.entries = t.entries[:l-1]
+	delete(t.idx, e.Name)
+	return e
+}
+
+func (t *LsoTopN) Add(e *LsoEnt) {
+	if i, ok := t.idx[e.Name]; ok {
+		if e.IsStatusOK() && !t.entries[i].IsStatusOK() {
+			t.entries[i] = e
+			heap.Fix(t, i)
+		}
+		return
+	}
+	switch {
+	case len(t.entries) < t.n:
+		heap.Push(t, e)
+	case t.before(e, t.entries[0]):
+		delete(t.idx, t.entries[0].Name)
+		t.entries[0] = e
+		t.idx[e.Name] = 0
+		heap.Fix(t, 0)
+	}
+}
+
+// returns selected entries in order
+func (t *LsoTopN) Sorted() LsoEntries {
+	entries := t.entries
+	sort.Slice(entries, func(i, j int) bool { return t.before(entries[i], entries[j]) })
+	t.entries, t.idx = nil, nil
+	return entries
+}
+
 // Returns true if the continuation token >= object's name (in other words, the object is
 // already listed and must be skipped). Note that string `>=` is lexicographic.
 func TokenGreaterEQ(token, objName string) bool { return token >= objName }
@@ -0,0 +1,129 @@
+// Package cmn provides common constants, types, and utilities for AIS clients
+// and AIStore.
+/*
+ * Copyright (c) 2024, NVIDIA CORPORATION. All rights reserved.
+ */
+package cmn
+
+import (
+	"fmt"
+	"strings"
+	"unicode/utf8"
+
+	"golang.org/x/text/unicode/norm"
+)
+
+// Per-bucket object naming policy:
+// - AIS gateways apply it (and normalize names, if need be) upon native PUT, APPEND, and rename;
+// - targets check it (see Check) upon all other writes: S3 PUT and multipart upload, promote,
+//   copy/transform, archive, dsort and downloader outputs, etc.
+// Zero value means "no policy" (other than the basic validation - see ValidateObjName).
+
+// ObjNameConf.Normalize enum
+const (
+	ObjNameNFC  = "nfc"
+	ObjNameNFD  = "nfd"
+	ObjNameNFKC = "nfkc"
+	ObjNameNFKD = "nfkd"
+)
+
+type (
+	ObjNameConf struct {
+		// max object name length, in bytes (zero: unlimited)
+		MaxLen int `json:"max_len,omitempty"`
+		// max number of virtual directories ("/"-separated path components minus one) (zero: unlimited)
+		MaxDepth int `json:"max_depth,omitempty"`
+		// characters that are not permitted in object names, e.g. "\\:*?<>|"
+		Forbidden string `json:"forbidden_chars,omitempty"`
+		// Unicode normalization form (one of the enum above, or empty for none);
+		// names that are not normalized get normalized (rather than rejected)
+		Normalize string `json:"normalize,omitempty"`
+	}
+	ObjNameConfToSet struct {
+		MaxLen    *int    `json:"max_len,omitempty"`
+		MaxDepth  *int    `json:"max_depth,omitempty"`
+		Forbidden *string `json:"forbidden_chars,omitempty"`
+		Normalize *string `json:"normalize,omitempty"`
+	}
+)
+
+func (c *ObjNameConf) IsZero() bool { return *c == ObjNameConf{} }
+
+func (c *ObjNameConf) ValidateAsProps(...any) error {
+	if c.MaxLen < 0 {
+		return fmt.Errorf("invalid objname.max_len %d (must be non-negative)", c.MaxLen)
+	}
+	if c.MaxDepth < 0 {
+		return fmt.Errorf("invalid objname.max_depth %d (must be non-negative)", c.MaxDepth)
+	}
+	if strings.Contains(c.Forbidden, "/") {
+		return fmt.Errorf("invalid objname.forbidden_chars %q: cannot forbid path separator", c.Forbidden)
+	}
+	if !utf8.ValidString(c.Forbidden) {
+		return fmt.Errorf("invalid objname.forbidden_chars %q: not a valid UTF-8 string", c.Forbidden)
+	}
+	if _, ok := c.form(); !ok {
+		return fmt.Errorf("invalid objname.normalize %q (expecting one of: %s, %s, %s, %s, or empty)",
+			c.Normalize, ObjNameNFC, ObjNameNFD, ObjNameNFKC, ObjNameNFKD)
+	}
+	return nil
+}
+
+func (c *ObjNameConf) form() (norm.Form, bool) {
+	switch strings.ToLower(c.Normalize) {
+	case "":
+		return 0, true
+	case ObjNameNFC:
+		return norm.NFC, true
+	case ObjNameNFD:
+		return norm.NFD, true
+	case ObjNameNFKC:
+		return norm.NFKC, true
+	case ObjNameNFKD:
+		return norm.NFKD, true
+	}
+	return 0, false
+}
+
+// Apply validates object name against the policy and returns the (possibly normalized) name.
+// Normalization happens first, so that length and depth limits apply to the resulting name.
+func (c *ObjNameConf) Apply(name string) (string, error) {
+	if c.IsZero() {
+		return name, nil
+	}
+	if c.Normalize != "" {
+		if !utf8.ValidString(name) {
+			return name, &ErrInvalidObjName{name: name, reason: "not a valid UTF-8 string"}
+		}
+		if form, _ := c.form(); !form.IsNormalString(name) {
+			name = form.String(name)
+		}
+	}
+	if c.MaxLen > 0 && len(name) > c.MaxLen {
+		return name, &ErrInvalidObjName{name: name, reason: fmt.Sprintf("length %d exceeds bucket limit %d", len(name), c.MaxLen)}
+	}
+	if c.MaxDepth > 0 {
+		if depth := strings.Count(strings.Trim(name, "/"), "/"); depth > c.MaxDepth {
+			return name, &ErrInvalidObjName{name: name, reason: fmt.Sprintf("depth %d exceeds bucket limit %d", depth, c.MaxDepth)}
+		}
+	}
+	if c.Forbidden != "" {
+		if i := strings.IndexAny(name, c.Forbidden); i >= 0 {
+			r, _ := utf8.DecodeRuneInString(name[i:])
+			return name, &ErrInvalidObjName{name: name, reason: fmt.Sprintf("contains forbidden character %q", r)}
+		}
+	}
+	return name, nil
+}
+
+// Check is Apply that does not normalize: names that are not normalized get rejected
+func (c *ObjNameConf) Check(name string) error {
+	normalized, err := c.Apply(name)
+	if err != nil {
+		return err
+	}
+	if normalized != name {
+		return &ErrInvalidObjName{name: name, reason: "not in " + strings.ToUpper(c.Normalize) + " normalization form"}
+	}
+	return nil
+}
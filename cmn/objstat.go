@@ -0,0 +1,49 @@
+// Package cmn provides common constants, types, and utilities for AIS clients
+// and AIStore.
+/*
+ * Copyright (c) 2024, NVIDIA CORPORATION. All rights reserved.
+ */
+package cmn
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/NVIDIA/aistore/cmn/cos"
+)
+
+// object metadata, as streamed by bulk stat (see apc.StatObjsMsg)
+type ObjStat struct {
+	Name       string     `json:"name"`
+	Size       int64      `json:"size,string"`
+	CksumType  string     `json:"cksum_type,omitempty"`
+	CksumValue string     `json:"cksum_value,omitempty"`
+	Version    string     `json:"version,omitempty"`
+	Atime      int64      `json:"atime,string,omitempty"` // Unix nanoseconds
+	Custom     cos.StrKVs `json:"custom-md,omitempty"`
+}
+
+var ObjStatCSVHeader = []string{"name", "size", "cksum_type", "cksum_value", "version", "atime", "custom-md"}
+
+// CSV record; custom metadata is formatted as sorted "key=value" pairs separated by semicolons
+func (st *ObjStat) CSV() []string {
+	var custom string
+	if len(st.Custom) > 0 {
+		kvs := make([]string, 0, len(st.Custom))
+		for k, v := range st.Custom {
+			kvs = append(kvs, k+"="+v)
+		}
+		sort.Strings(kvs)
+		custom = strings.Join(kvs, ";")
+	}
+	return []string{
+		st.Name,
+		strconv.FormatInt(st.Size, 10),
+		st.CksumType,
+		st.CksumValue,
+		st.Version,
+		strconv.FormatInt(st.Atime, 10),
+		custom,
+	}
+}
@@ -0,0 +1,140 @@
+// Package cmn provides common constants, types, and utilities for AIS clients
+// and AIStore.
+/*
+ * Copyright (c) 2024, NVIDIA CORPORATION. All rights reserved.
+ */
+package cmn
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/NVIDIA/aistore/api/apc"
+)
+
+// Pipeline: a small DAG of data-prep steps (e.g., prefetch remote prefix => copy to ais:// bucket
+// => transform (ETL) into yet another bucket) that the cluster runs and monitors as a single
+// composite job (JobTypePipeline):
+// - a step starts when all the steps it depends on have finished successfully;
+// - independent steps run concurrently;
+// - when any step fails (or the pipeline gets aborted) the remaining steps are aborted or skipped
+//   and, if requested, the destination buckets created by the pipeline are destroyed (rollback).
+
+// pipeline step actions
+var PipelineActions = []string{apc.ActPrefetchObjects, apc.ActCopyBck, apc.ActETLBck}
+
+// pipeline step states (in addition to Job* states)
+const (
+	PipelinePending = "pending"
+	PipelineSkipped = "skipped"
+)
+
+type (
+	PipelineStep struct {
+		Name      string   `json:"name"`
+		Action    string   `json:"action"`             // one of the PipelineActions
+		Bck       Bck      `json:"bck"`                // source bucket
+		ToBck     Bck      `json:"to_bck,omitempty"`   // destination (copy and ETL)
+		Prefix    string   `json:"prefix,omitempty"`   // source objects to prefetch, copy, or transform
+		ETLName   string   `json:"etl_name,omitempty"` // (ETL only)
+		DependsOn []string `json:"depends_on,omitempty"`
+	}
+	PipelineMsg struct {
+		Steps    []PipelineStep `json:"steps"`
+		Rollback bool           `json:"rollback"` // destroy destination buckets created by the pipeline upon failure or abort
+	}
+)
+
+// validates the steps and returns them in (one of the possible) topological order
+func (msg *PipelineMsg) Validate() (order []int, err error) {
+	if len(msg.Steps) == 0 {
+		return nil, errors.New("pipeline: no steps")
+	}
+	idx := make(map[string]int, len(msg.Steps))
+	for i := range msg.Steps {
+		step := &msg.Steps[i]
+		if step.Name == "" {
+			step.Name = fmt.Sprintf("step-%d", i)
+		}
+		if _, ok := idx[step.Name]; ok {
+			return nil, fmt.Errorf("pipeline: duplicate step name %q", step.Name)
+		}
+		idx[step.Name] = i
+		if err := step.validate(); err != nil {
+			return nil, err
+		}
+	}
+
+	// Kahn's
+	var (
+		indeg = make([]int, len(msg.Steps))
+		next  = make([][]int, len(msg.Steps))
+	)
+	for i := range msg.Steps {
+		for _, dep := range msg.Steps[i].DependsOn {
+			j, ok := idx[dep]
+			if !ok {
+				return nil, fmt.Errorf("pipeline: step %q depends on unknown step %q", msg.Steps[i].Name, dep)
+			}
+			if j == i {
+				return nil, fmt.Errorf("pipeline: step %q depends on itself", dep)
+			}
+			indeg[i]++
+			next[j] = append(next[j], i)
+		}
+	}
+	order = make([]int, 0, len(msg.Steps))
+	for i := range indeg {
+		if indeg[i] == 0 {
+			order = append(order, i)
+		}
+	}
+	for k := 0; k < len(order); k++ {
+		for _, i := range next[order[k]] {
+			if indeg[i]--; indeg[i] == 0 {
+				order = append(order, i)
+			}
+		}
+	}
+	if len(order) != len(msg.Steps) {
+		return nil, errors.New("pipeline: dependency cycle")
+	}
+	return order, nil
+}
+
+func (step *PipelineStep) validate() error {
+	for _, bck := range []*Bck{&step.Bck, &step.ToBck} {
+		if bck.Provider == "" && bck.Name != "" {
+			bck.Provider = apc.AIS
+		}
+	}
+	if err := step.Bck.Validate(); err != nil {
+		return fmt.Errorf("pipeline step %q: %v", step.Name, err)
+	}
+	if err := ValidatePrefix(step.Prefix); err != nil {
+		return fmt.Errorf("pipeline step %q: %v", step.Name, err)
+	}
+	switch step.Action {
+	case apc.ActPrefetchObjects:
+		if !step.ToBck.IsEmpty() {
+			return fmt.Errorf("pipeline step %q: %s does not have a destination bucket", step.Name, step.Action)
+		}
+		if err := ValidateRemoteBck(step.Action, &step.Bck); err != nil {
+			return err
+		}
+		return nil
+	case apc.ActCopyBck, apc.ActETLBck:
+		if err := step.ToBck.Validate(); err != nil {
+			return fmt.Errorf("pipeline step %q: destination: %v", step.Name, err)
+		}
+		if step.Bck.Equal(&step.ToBck) {
+			return fmt.Errorf("pipeline step %q: cannot %s bucket %s onto itself", step.Name, step.Action, step.Bck.String())
+		}
+		if step.Action == apc.ActETLBck && step.ETLName == "" {
+			return fmt.Errorf("pipeline step %q: ETL name can't be empty", step.Name)
+		}
+		return nil
+	default:
+		return fmt.Errorf("pipeline step %q: invalid action %q (expecting one of: %v)", step.Name, step.Action, PipelineActions)
+	}
+}
@@ -0,0 +1,56 @@
+// Package cmn provides common constants, types, and utilities for AIS clients
+// and AIStore.
+/*
+ * Copyright (c) 2024, NVIDIA CORPORATION. All rights reserved.
+ */
+package cmn_test
+
+import (
+	"testing"
+
+	"github.com/NVIDIA/aistore/api/apc"
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/tools/tassert"
+)
+
+func TestPipelineValidate(t *testing.T) {
+	var (
+		remote = cmn.Bck{Name: "a", Provider: apc.AWS}
+		b      = cmn.Bck{Name: "b"}
+		c      = cmn.Bck{Name: "c", Provider: apc.AIS}
+	)
+	msg := &cmn.PipelineMsg{Steps: []cmn.PipelineStep{
+		{Name: "etl", Action: apc.ActETLBck, Bck: b, ToBck: c, ETLName: "md5", DependsOn: []string{"copy"}},
+		{Name: "copy", Action: apc.ActCopyBck, Bck: remote, ToBck: b, Prefix: "train/", DependsOn: []string{"prefetch"}},
+		{Name: "prefetch", Action: apc.ActPrefetchObjects, Bck: remote, Prefix: "train/"},
+	}}
+	order, err := msg.Validate()
+	tassert.CheckFatal(t, err)
+	tassert.Fatalf(t, len(order) == 3 && order[0] == 2 && order[1] == 1 && order[2] == 0, "unexpected order %v", order)
+	tassert.Errorf(t, msg.Steps[0].Bck.Provider == apc.AIS, "expecting default provider, got %q", msg.Steps[0].Bck.Provider)
+
+	tests := []struct {
+		name  string
+		steps []cmn.PipelineStep
+	}{
+		{"empty", nil},
+		{"cycle", []cmn.PipelineStep{
+			{Name: "x", Action: apc.ActCopyBck, Bck: b, ToBck: c, DependsOn: []string{"y"}},
+			{Name: "y", Action: apc.ActCopyBck, Bck: c, ToBck: b, DependsOn: []string{"x"}},
+		}},
+		{"unknown-dep", []cmn.PipelineStep{{Name: "x", Action: apc.ActCopyBck, Bck: b, ToBck: c, DependsOn: []string{"z"}}}},
+		{"duplicate", []cmn.PipelineStep{
+			{Name: "x", Action: apc.ActCopyBck, Bck: b, ToBck: c},
+			{Name: "x", Action: apc.ActCopyBck, Bck: c, ToBck: b},
+		}},
+		{"prefetch-ais", []cmn.PipelineStep{{Action: apc.ActPrefetchObjects, Bck: b}}},
+		{"onto-itself", []cmn.PipelineStep{{Action: apc.ActCopyBck, Bck: b, ToBck: b}}},
+		{"etl-no-name", []cmn.PipelineStep{{Action: apc.ActETLBck, Bck: b, ToBck: c}}},
+		{"bad-action", []cmn.PipelineStep{{Action: apc.ActDsort, Bck: b}}},
+	}
+	for _, test := range tests {
+		msg := &cmn.PipelineMsg{Steps: test.steps}
+		_, err := msg.Validate()
+		tassert.Errorf(t, err != nil, "%s: expecting error", test.name)
+	}
+}
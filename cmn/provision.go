@@ -0,0 +1,139 @@
+// Package cmn provides common constants, types, and utilities for AIS clients
+// and AIStore.
+/*
+ * Copyright (c) 2024, NVIDIA CORPORATION. All rights reserved.
+ */
+package cmn
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/NVIDIA/aistore/cmn/cos"
+)
+
+// Self-service bucket provisioning: admin-configured create-bucket policies.
+// A policy permits the listed users to create ais:// buckets with names matching the
+// policy's prefix - with or without the (cluster-level) permission to create buckets.
+// In addition, each policy specifies defaults for the buckets created under it:
+// - props template (that the user can further override at creation time);
+// - quota: max number of buckets per user;
+// - TTL: ephemeral buckets get destroyed after the specified time of inactivity.
+// Policies are evaluated in order; the first one that matches (user, bucket name) applies.
+
+const (
+	ProvisionAnyUser   = "*"      // (see BckPolicy.Users)
+	ProvisionUserMacro = "{user}" // (see BckPolicy.Prefix)
+
+	minProvisionTTL = time.Hour
+)
+
+type (
+	ProvisionConf struct {
+		Policies []BckPolicy `json:"policies,omitempty"`
+	}
+	ProvisionConfToSet struct {
+		Policies *[]BckPolicy `json:"policies,omitempty"`
+	}
+	BckPolicy struct {
+		Props      *BpropsToSet `json:"props,omitempty"`       // props template
+		Name       string       `json:"name"`                  // policy name
+		Prefix     string       `json:"prefix,omitempty"`      // bucket name prefix; may include ProvisionUserMacro
+		Users      []string     `json:"users"`                 // AuthN user IDs or ProvisionAnyUser
+		MaxBuckets int          `json:"max_buckets,omitempty"` // quota: max buckets per user (zero: unlimited)
+		TTL        cos.Duration `json:"ttl,omitempty"`         // destroy after this much inactivity (zero: never)
+	}
+
+	// (bucket props) provisioned under a given policy
+	BckProvision struct {
+		Policy string       `json:"policy,omitempty"`
+		Owner  string       `json:"owner,omitempty"` // user ID that created the bucket (empty when AuthN is disabled)
+		TTL    cos.Duration `json:"ttl,omitempty"`   // (see BckPolicy.TTL)
+	}
+)
+
+///////////////////
+// ProvisionConf //
+///////////////////
+
+func (c *ProvisionConf) Validate() error {
+	names := make(cos.StrSet, len(c.Policies))
+	for i := range c.Policies {
+		policy := &c.Policies[i]
+		if policy.Name == "" {
+			return fmt.Errorf("invalid provision policy #%d: name is empty", i)
+		}
+		if names.Contains(policy.Name) {
+			return fmt.Errorf("invalid provision policy %q: duplicate name", policy.Name)
+		}
+		names.Add(policy.Name)
+		if err := policy.validate(); err != nil {
+			return fmt.Errorf("invalid provision policy %q: %v", policy.Name, err)
+		}
+	}
+	return nil
+}
+
+// returns the first policy that permits a given user to create a given (ais://) bucket
+func (c *ProvisionConf) Match(user, bckName string) *BckPolicy {
+	for i := range c.Policies {
+		if policy := &c.Policies[i]; policy.Match(user, bckName) {
+			return policy
+		}
+	}
+	return nil
+}
+
+func (c *ProvisionConf) Find(name string) *BckPolicy {
+	for i := range c.Policies {
+		if c.Policies[i].Name == name {
+			return &c.Policies[i]
+		}
+	}
+	return nil
+}
+
+///////////////
+// BckPolicy //
+///////////////
+
+func (policy *BckPolicy) validate() error {
+	if len(policy.Users) == 0 {
+		return errors.New("no users (use \"" + ProvisionAnyUser + "\" to permit any)")
+	}
+	if policy.MaxBuckets < 0 {
+		return fmt.Errorf("negative max_buckets (%d)", policy.MaxBuckets)
+	}
+	if ttl := policy.TTL.D(); ttl != 0 && ttl < minProvisionTTL {
+		return fmt.Errorf("ttl %v is too short (expecting zero or >= %v)", ttl, minProvisionTTL)
+	}
+	if prefix := strings.ReplaceAll(policy.Prefix, ProvisionUserMacro, ""); prefix != "" {
+		if err := cos.CheckAlphaPlus(prefix, "bucket name prefix"); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (policy *BckPolicy) Match(user, bckName string) bool {
+	var ok bool
+	for _, u := range policy.Users {
+		if u == ProvisionAnyUser || (u == user && user != "") {
+			ok = true
+			break
+		}
+	}
+	if !ok {
+		return false
+	}
+	prefix := policy.Prefix
+	if strings.Contains(prefix, ProvisionUserMacro) {
+		if user == "" {
+			return false
+		}
+		prefix = strings.ReplaceAll(prefix, ProvisionUserMacro, user)
+	}
+	return strings.HasPrefix(bckName, prefix)
+}
@@ -0,0 +1,60 @@
+// Package cmn provides common constants, types, and utilities for AIS clients
+// and AIStore.
+/*
+ * Copyright (c) 2024, NVIDIA CORPORATION. All rights reserved.
+ */
+package cmn_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/cmn/cos"
+	"github.com/NVIDIA/aistore/tools/tassert"
+)
+
+func TestProvisionMatch(t *testing.T) {
+	conf := cmn.ProvisionConf{Policies: []cmn.BckPolicy{
+		{Name: "personal", Prefix: "u-" + cmn.ProvisionUserMacro + "-", Users: []string{cmn.ProvisionAnyUser}, MaxBuckets: 3},
+		{Name: "team", Prefix: "team-", Users: []string{"alice", "bob"}, TTL: cos.Duration(7 * 24 * time.Hour)},
+	}}
+	tassert.CheckFatal(t, conf.Validate())
+
+	tests := []struct {
+		user, bck, policy string
+	}{
+		{"alice", "u-alice-scratch", "personal"},
+		{"alice", "u-bob-scratch", ""},
+		{"", "u--scratch", ""},
+		{"bob", "team-datasets", "team"},
+		{"carol", "team-datasets", ""},
+		{"", "team-datasets", ""},
+	}
+	for _, test := range tests {
+		var name string
+		if policy := conf.Match(test.user, test.bck); policy != nil {
+			name = policy.Name
+		}
+		tassert.Errorf(t, name == test.policy, "(%q, %q): expected policy %q, got %q", test.user, test.bck, test.policy, name)
+	}
+}
+
+func TestProvisionValidate(t *testing.T) {
+	invalid := []cmn.BckPolicy{
+		{Name: "", Users: []string{"*"}},
+		{Name: "no-users"},
+		{Name: "quota", Users: []string{"*"}, MaxBuckets: -1},
+		{Name: "ttl", Users: []string{"*"}, TTL: cos.Duration(time.Minute)},
+		{Name: "prefix", Users: []string{"*"}, Prefix: "a/b"},
+	}
+	for _, policy := range invalid {
+		conf := cmn.ProvisionConf{Policies: []cmn.BckPolicy{policy}}
+		tassert.Errorf(t, conf.Validate() != nil, "expected policy %+v to be invalid", policy)
+	}
+	conf := cmn.ProvisionConf{Policies: []cmn.BckPolicy{
+		{Name: "dup", Users: []string{"*"}},
+		{Name: "dup", Users: []string{"*"}},
+	}}
+	tassert.Errorf(t, conf.Validate() != nil, "expected duplicate policy names to fail validation")
+}
@@ -0,0 +1,63 @@
+// Package cmn provides common constants, types, and utilities for AIS clients
+// and AIStore.
+/*
+ * Copyright (c) 2024, NVIDIA CORPORATION. All rights reserved.
+ */
+package cmn
+
+import (
+	"errors"
+	"fmt"
+	"path"
+	"strings"
+
+	"github.com/NVIDIA/aistore/api/apc"
+	"github.com/NVIDIA/aistore/cmn/cos"
+)
+
+// Publish (immutable) bucket: objects, once written, can be neither overwritten nor modified
+// (appended to, patched, renamed) nor deleted. Optionally, the bucket is content-addressed:
+// object's base name (less extension) must be the hex-encoded checksum of its content, as per
+// the bucket's checksum type - computed and enforced by targets upon PUT.
+// Once enabled, publish configuration (and, for content-addressed buckets, checksum type) cannot
+// change; destroying the entire bucket remains an admin operation.
+
+type (
+	PublishConf struct {
+		Enabled     bool `json:"enabled,omitempty"`
+		ContentAddr bool `json:"content_addressed,omitempty"`
+	}
+	PublishConfToSet struct {
+		Enabled     *bool `json:"enabled,omitempty"`
+		ContentAddr *bool `json:"content_addressed,omitempty"`
+	}
+)
+
+func (c *PublishConf) validate(bp *Bprops) error {
+	if c.ContentAddr && !c.Enabled {
+		return errors.New("publish.content_addressed requires publish.enabled")
+	}
+	if !c.Enabled {
+		return nil
+	}
+	if bp.Provider != apc.AIS || !bp.BackendBck.IsEmpty() {
+		return fmt.Errorf("cannot publish %s bucket: immutability cannot be enforced for objects stored in remote backends",
+			apc.DisplayProvider(bp.Provider))
+	}
+	if c.ContentAddr && (bp.Cksum.Type == "" || bp.Cksum.Type == cos.ChecksumNone) {
+		return errors.New("content-addressed bucket requires checksum (checksum.type cannot be \"none\")")
+	}
+	return nil
+}
+
+// validate content-addressed object name: "[virtual-dir/]<hex-checksum>[.ext]"
+func CheckContentAddr(objName string, cksum *cos.Cksum) error {
+	base := path.Base(objName)
+	if i := strings.IndexByte(base, '.'); i > 0 {
+		base = base[:i]
+	}
+	if cksum == nil || !strings.EqualFold(base, cksum.Val()) {
+		return &ErrContentAddr{objName: objName, cksum: cksum}
+	}
+	return nil
+}
@@ -0,0 +1,34 @@
+// Package cmn provides common constants, types, and utilities for AIS clients
+// and AIStore.
+/*
+ * Copyright (c) 2024, NVIDIA CORPORATION. All rights reserved.
+ */
+package cmn_test
+
+import (
+	"testing"
+
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/cmn/cos"
+	"github.com/NVIDIA/aistore/tools/tassert"
+)
+
+func TestCheckContentAddr(t *testing.T) {
+	cksum := cos.NewCksum(cos.ChecksumSHA256, "9f86d081884c7d659a2feaa0c55ad015a3bf4f1b2b0b822cd15d6c15b0f00a08")
+	tests := []struct {
+		objName string
+		ok      bool
+	}{
+		{"9f86d081884c7d659a2feaa0c55ad015a3bf4f1b2b0b822cd15d6c15b0f00a08", true},
+		{"models/v1/9F86D081884C7D659A2FEAA0C55AD015A3BF4F1B2B0B822CD15D6C15B0F00A08.safetensors", true},
+		{"9f86d081884c7d659a2feaa0c55ad015a3bf4f1b2b0b822cd15d6c15b0f00a08.tar.gz", true},
+		{"9f86d081884c7d659a2feaa0c55ad015a3bf4f1b2b0b822cd15d6c15b0f00a08/model.bin", false},
+		{"model.bin", false},
+		{"9f86d081", false},
+	}
+	for _, test := range tests {
+		err := cmn.CheckContentAddr(test.objName, cksum)
+		tassert.Errorf(t, (err == nil) == test.ok, "%q: expected ok=%t, got %v", test.objName, test.ok, err)
+	}
+	tassert.Errorf(t, cmn.CheckContentAddr("abc", nil) != nil, "expected error for missing checksum")
+}
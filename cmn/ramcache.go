@@ -0,0 +1,47 @@
+// Package cmn provides common constants, types, and utilities for AIS clients
+// and AIStore.
+/*
+ * Copyright (c) 2024, NVIDIA CORPORATION. All rights reserved.
+ */
+package cmn
+
+import (
+	"fmt"
+
+	"github.com/NVIDIA/aistore/cmn/cos"
+)
+
+// Per-bucket in-memory caching of small hot objects (GET responses).
+// The cache itself is per target and is capped by the cluster-wide `memsys.ram_cache_size`
+// (zero disables caching regardless of bucket props).
+
+const (
+	DfltRAMCacheMaxObjSize = cos.MiB
+	MaxRAMCacheMaxObjSize  = 64 * cos.MiB
+)
+
+type (
+	RAMCacheConf struct {
+		MaxObjSize cos.SizeIEC `json:"max_obj_size,omitempty"` // larger objects are never cached (dflt. DfltRAMCacheMaxObjSize)
+		Enabled    bool        `json:"enabled,omitempty"`
+	}
+	RAMCacheConfToSet struct {
+		MaxObjSize *cos.SizeIEC `json:"max_obj_size,omitempty"`
+		Enabled    *bool        `json:"enabled,omitempty"`
+	}
+)
+
+func (c *RAMCacheConf) MaxObjSizeOrDflt() int64 {
+	if c.MaxObjSize > 0 {
+		return int64(c.MaxObjSize)
+	}
+	return DfltRAMCacheMaxObjSize
+}
+
+func (c *RAMCacheConf) ValidateAsProps(...any) error {
+	if c.MaxObjSize < 0 || c.MaxObjSize > MaxRAMCacheMaxObjSize {
+		return fmt.Errorf("invalid ram_cache.max_obj_size %s (expected range [0, %s])",
+			c.MaxObjSize, cos.ToSizeIEC(MaxRAMCacheMaxObjSize, 0))
+	}
+	return nil
+}
@@ -0,0 +1,101 @@
+// Package cmn provides common constants, types, and utilities for AIS clients
+// and AIStore.
+/*
+ * Copyright (c) 2024, NVIDIA CORPORATION. All rights reserved.
+ */
+package cmn
+
+import (
+	"context"
+	"errors"
+	"net"
+	"strconv"
+	"strings"
+)
+
+// Pluggable resolution of (AIS) endpoint URLs, e.g.:
+// - StaticResolver: fixed list of URLs;
+// - DNSResolver: SRV record or a hostname that resolves to many IPs (e.g., K8s headless service).
+// Used by clients (api.Endpoints) and by nodes joining the cluster (config.Proxy.DiscoveryDNS).
+
+type (
+	Resolver interface {
+		Resolve(ctx context.Context) (urls []string, err error)
+	}
+
+	StaticResolver []string
+
+	DNSResolver struct {
+		// optional (can be replaced, e.g., in tests); default: net.DefaultResolver
+		LookupSRV  func(ctx context.Context, service, proto, name string) (string, []*net.SRV, error)
+		LookupHost func(ctx context.Context, host string) ([]string, error)
+
+		// SRV name in the form "_service._proto.name" (e.g., "_ais._tcp.cluster.local")
+		// or hostname with an optional port (e.g., "ais-proxy.ais.svc.cluster.local:51080")
+		Name string
+
+		Port  int  // default port (when not specified by `Name`)
+		HTTPS bool // URL scheme
+	}
+)
+
+// interface guard
+var (
+	_ Resolver = StaticResolver(nil)
+	_ Resolver = (*DNSResolver)(nil)
+)
+
+func (r StaticResolver) Resolve(context.Context) ([]string, error) {
+	if len(r) == 0 {
+		return nil, errors.New("no endpoints")
+	}
+	return r, nil
+}
+
+// SRV records resolve to (target hostname, port) pairs ordered by priority and weight;
+// hostnames - to all the corresponding IPs
+func (r *DNSResolver) Resolve(ctx context.Context) (urls []string, err error) {
+	scheme := "http"
+	if r.HTTPS {
+		scheme = "https"
+	}
+
+	// SRV
+	if strings.HasPrefix(r.Name, "_") {
+		var (
+			addrs     []*net.SRV
+			lookupSRV = r.LookupSRV
+		)
+		if lookupSRV == nil {
+			lookupSRV = net.DefaultResolver.LookupSRV
+		}
+		if _, addrs, err = lookupSRV(ctx, "", "", r.Name); err != nil {
+			return nil, err
+		}
+		for _, srv := range addrs {
+			host := strings.TrimSuffix(srv.Target, ".")
+			urls = append(urls, scheme+"://"+net.JoinHostPort(host, strconv.Itoa(int(srv.Port))))
+		}
+		return urls, nil
+	}
+
+	// host[:port]
+	var (
+		ips        []string
+		host, port = r.Name, strconv.Itoa(r.Port)
+		lookupHost = r.LookupHost
+	)
+	if h, p, e := net.SplitHostPort(r.Name); e == nil {
+		host, port = h, p
+	}
+	if lookupHost == nil {
+		lookupHost = net.DefaultResolver.LookupHost
+	}
+	if ips, err = lookupHost(ctx, host); err != nil {
+		return nil, err
+	}
+	for _, ip := range ips {
+		urls = append(urls, scheme+"://"+net.JoinHostPort(ip, port))
+	}
+	return urls, nil
+}
@@ -24,6 +24,7 @@ type readMostly struct {
 	level, modules int
 	testingEnv     bool
 	authEnabled    bool
+	dataSubnet     bool
 }
 
 var Rom readMostly
@@ -42,6 +43,7 @@ func (rom *readMostly) Set(cfg *ClusterConfig) {
 	}
 	rom.features = cfg.Features
 	rom.authEnabled = cfg.Auth.Enabled
+	rom.dataSubnet = cfg.Net.DataMultihome == DataMultihomeSubnet
 
 	// pre-parse for FastV (below)
 	rom.level, rom.modules = cfg.Log.Level.Parse()
@@ -53,6 +55,7 @@ func (rom *readMostly) EcStreams() time.Duration       { return rom.timeout.ecst
 func (rom *readMostly) Features() feat.Flags           { return rom.features }
 func (rom *readMostly) TestingEnv() bool               { return rom.testingEnv }
 func (rom *readMostly) AuthEnabled() bool              { return rom.authEnabled }
+func (rom *readMostly) DataSubnet() bool               { return rom.dataSubnet }
 
 func (rom *readMostly) FastV(verbosity, fl int) bool {
 	return rom.level >= verbosity || rom.modules&fl != 0
@@ -0,0 +1,53 @@
+// Package cmn provides common constants, types, and utilities for AIS clients
+// and AIStore.
+/*
+ * Copyright (c) 2024, NVIDIA CORPORATION. All rights reserved.
+ */
+package cmn
+
+import (
+	"fmt"
+	"net/url"
+)
+
+// Request shadowing (canary testing): a given percentage of GET (and, optionally, PUT)
+// requests gets asynchronously duplicated to the same-name bucket in another AIS cluster
+// (e.g., running a new version). Shadow responses never affect production; shadow errors,
+// dropped requests, and divergence (sampled comparisons with production) are counted -
+// see stats.ShadowCount and friends.
+//
+// GETs get shadowed by AIS gateways, PUTs - by AIS targets, upon success.
+
+type (
+	ShadowConf struct {
+		Endpoint  string `json:"endpoint,omitempty"`   // remote AIS gateway URL (empty: disabled)
+		Pct       int    `json:"pct,omitempty"`        // percentage of requests to shadow [0, 100]
+		SamplePct int    `json:"sample_pct,omitempty"` // percentage of shadowed GETs to compare with production [0, 100]
+		PUT       bool   `json:"put,omitempty"`        // shadow PUTs as well
+	}
+	ShadowConfToSet struct {
+		Endpoint  *string `json:"endpoint,omitempty"`
+		Pct       *int    `json:"pct,omitempty"`
+		SamplePct *int    `json:"sample_pct,omitempty"`
+		PUT       *bool   `json:"put,omitempty"`
+	}
+)
+
+func (c *ShadowConf) Enabled() bool { return c.Endpoint != "" && c.Pct > 0 }
+
+func (c *ShadowConf) ValidateAsProps(...any) error {
+	if c.Pct < 0 || c.Pct > 100 {
+		return fmt.Errorf("invalid shadow.pct %d (expecting [0, 100] range)", c.Pct)
+	}
+	if c.SamplePct < 0 || c.SamplePct > 100 {
+		return fmt.Errorf("invalid shadow.sample_pct %d (expecting [0, 100] range)", c.SamplePct)
+	}
+	if c.Endpoint == "" {
+		return nil
+	}
+	u, err := url.Parse(c.Endpoint)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return fmt.Errorf("invalid shadow.endpoint %q (expecting http(s)://host:port)", c.Endpoint)
+	}
+	return nil
+}
@@ -0,0 +1,57 @@
+// Package test provides tests for common low-level types and utilities for all aistore projects
+/*
+ * Copyright (c) 2024, NVIDIA CORPORATION. All rights reserved.
+ */
+package tests_test
+
+import (
+	"archive/tar"
+	"bytes"
+	"io"
+	"strings"
+	"testing"
+	"unicode/utf8"
+
+	"github.com/NVIDIA/aistore/cmn/archive"
+	"github.com/NVIDIA/aistore/tools/tassert"
+	"golang.org/x/text/unicode/norm"
+)
+
+// archived file (aka archpath) lookup: absolute and "./" prefixed names, Unicode normalization
+func FuzzArchpath(f *testing.F) {
+	for _, name := range []string{"a.txt", "dir/b.jpg", "./c/d", "/e/f", "résumé.pdf", "日本/語.json", "what?#%.cls"} {
+		f.Add(name)
+	}
+	f.Fuzz(func(t *testing.T, name string) {
+		trimmed := name
+		for strings.HasPrefix(trimmed, "./") || strings.HasPrefix(trimmed, "/") {
+			trimmed = strings.TrimPrefix(strings.TrimPrefix(trimmed, "./"), "/")
+		}
+		if trimmed == "" || !utf8.ValidString(name) || strings.ContainsRune(name, 0) || strings.HasSuffix(name, "/") {
+			t.Skip()
+		}
+		var (
+			buf     bytes.Buffer
+			content = []byte("content of " + name)
+			tw      = tar.NewWriter(&buf)
+			nfd     = norm.NFD.String(name) // as in: created on macOS
+		)
+		err := tw.WriteHeader(&tar.Header{Typeflag: tar.TypeReg, Name: nfd, Size: int64(len(content)), Mode: 0o644})
+		tassert.CheckFatal(t, err)
+		_, err = tw.Write(content)
+		tassert.CheckFatal(t, err)
+		tassert.CheckFatal(t, tw.Close())
+
+		for _, archpath := range []string{name, norm.NFC.String(name), trimmed, "/" + trimmed, "./" + trimmed} {
+			ar, err := archive.NewReader(archive.ExtTar, bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+			tassert.CheckFatal(t, err)
+			csl, err := ar.ReadOne(archpath)
+			tassert.CheckFatal(t, err)
+			tassert.Fatalf(t, csl != nil, "archived %q: not found by %q", nfd, archpath)
+			b, err := io.ReadAll(csl)
+			tassert.CheckFatal(t, err)
+			csl.Close()
+			tassert.Fatalf(t, bytes.Equal(b, content), "archived %q: invalid content read by %q", nfd, archpath)
+		}
+	})
+}
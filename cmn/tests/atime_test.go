@@ -0,0 +1,36 @@
+// Package test provides tests for common low-level types and utilities for all aistore projects
+/*
+ * Copyright (c) 2024, NVIDIA CORPORATION. All rights reserved.
+ */
+package tests_test
+
+import (
+	"time"
+
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/cmn/cos"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("AtimeConf", func() {
+	DescribeTable("should validate and normalize atime policy",
+		func(conf cmn.AtimeConf, valid bool, policy string, stale time.Duration) {
+			err := conf.ValidateAsProps()
+			if !valid {
+				Expect(err).To(HaveOccurred())
+				return
+			}
+			Expect(err).NotTo(HaveOccurred())
+			Expect(conf.Policy).To(Equal(policy))
+			Expect(conf.StaleD()).To(Equal(stale))
+			Expect(conf.MaxStale.D()).To(Equal(stale))
+		},
+		Entry("zero value", cmn.AtimeConf{}, true, cmn.AtimeLazy, cmn.DfltAtimeMaxStale),
+		Entry("lazy", cmn.AtimeConf{Policy: cmn.AtimeLazy, MaxStale: cos.Duration(10 * time.Minute)}, true, cmn.AtimeLazy, 10*time.Minute),
+		Entry("precise", cmn.AtimeConf{Policy: cmn.AtimePrecise, MaxStale: cos.Duration(time.Hour)}, true, cmn.AtimePrecise, time.Duration(0)),
+		Entry("off", cmn.AtimeConf{Policy: cmn.AtimeOff}, true, cmn.AtimeOff, time.Duration(0)),
+		Entry("staleness too small", cmn.AtimeConf{Policy: cmn.AtimeLazy, MaxStale: cos.Duration(time.Second)}, false, "", time.Duration(0)),
+		Entry("unknown policy", cmn.AtimeConf{Policy: "sometimes"}, false, "", time.Duration(0)),
+	)
+})
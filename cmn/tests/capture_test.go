@@ -0,0 +1,85 @@
+// Package test provides tests for common low-level types and utilities for all aistore projects
+/*
+ * Copyright (c) 2024, NVIDIA CORPORATION. All rights reserved.
+ */
+package tests_test
+
+import (
+	"bytes"
+	"io"
+	"strings"
+
+	"github.com/NVIDIA/aistore/cmn"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Workload capture", func() {
+	DescribeTable("should validate capture config",
+		func(conf cmn.CaptureConf, valid, enabled bool) {
+			err := conf.Validate()
+			if !valid {
+				Expect(err).To(HaveOccurred())
+				return
+			}
+			Expect(err).NotTo(HaveOccurred())
+			Expect(conf.Enabled()).To(Equal(enabled))
+		},
+		Entry("zero value", cmn.CaptureConf{}, true, false),
+		Entry("enabled", cmn.CaptureConf{Pct: 10, Dir: "/tmp/capture", MaxSize: 1024}, true, true),
+		Entry("pct out of range", cmn.CaptureConf{Pct: 101}, false, false),
+		Entry("negative max size", cmn.CaptureConf{Pct: 1, MaxSize: -1}, false, false),
+		Entry("relative dir", cmn.CaptureConf{Pct: 1, Dir: "capture"}, false, false),
+	)
+
+	It("should redact names preserving structure", func() {
+		const salt = 1234
+		a := cmn.RedactName("images/train/00001.jpg", salt)
+		b := cmn.RedactName("images/val/00001.jpg", salt)
+		Expect(strings.Split(a, "/")).To(HaveLen(3))
+		Expect(a).To(HaveSuffix(".jpg"))
+		Expect(a).NotTo(ContainSubstring("images"))
+		Expect(a).NotTo(ContainSubstring("00001"))
+
+		// same prefix, same name => same hash
+		Expect(strings.Split(a, "/")[0]).To(Equal(strings.Split(b, "/")[0]))
+		Expect(strings.Split(a, "/")[2]).To(Equal(strings.Split(b, "/")[2]))
+		Expect(cmn.RedactName("images/train/00001.jpg", salt)).To(Equal(a))
+
+		// different salt
+		Expect(cmn.RedactName("images/train/00001.jpg", salt+1)).NotTo(Equal(a))
+
+		// trailing slash, no extension
+		c := cmn.RedactName("dir/.hidden/", salt)
+		Expect(c).To(HaveSuffix("/"))
+		Expect(c).NotTo(ContainSubstring("hidden"))
+	})
+
+	It("should write and read workload trace", func() {
+		recs := []cmn.TraceRec{
+			{Time: 0, Op: "PUT", Bck: "ais://abc", ObjName: "a,b\"c", Size: 1024},
+			{Time: 1000, Op: "GET", Bck: "ais://abc", ObjName: "a,b\"c", Range: "bytes=0-99"},
+			{Time: 2000, Op: "DELETE", Bck: "s3://xyz", ObjName: "d/e/f"},
+		}
+		var buf bytes.Buffer
+		tw, err := cmn.NewTraceWriter(&buf)
+		Expect(err).NotTo(HaveOccurred())
+		for i := range recs {
+			Expect(tw.Write(&recs[i])).NotTo(HaveOccurred())
+		}
+		Expect(tw.Flush()).NotTo(HaveOccurred())
+
+		tr, err := cmn.NewTraceReader(&buf)
+		Expect(err).NotTo(HaveOccurred())
+		for i := range recs {
+			rec, err := tr.Next()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(*rec).To(Equal(recs[i]))
+		}
+		_, err = tr.Next()
+		Expect(err).To(Equal(io.EOF))
+
+		_, err = cmn.NewTraceReader(strings.NewReader("a,b,c\n"))
+		Expect(err).To(HaveOccurred())
+	})
+})
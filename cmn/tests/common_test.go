@@ -10,8 +10,10 @@ import (
 	"os"
 	"path/filepath"
 	"reflect"
+	"time"
 
 	"github.com/NVIDIA/aistore/cmn/cos"
+	jsoniter "github.com/json-iterator/go"
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 )
@@ -282,6 +284,69 @@ var _ = Describe("Common file", func() {
 		)
 	})
 
+	Context("ParsePercent", func() {
+		DescribeTable("parse percent without error",
+			func(s string, expected float64) {
+				f, err := cos.ParsePercent(s)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(f).To(BeNumerically("~", expected, 1e-9))
+			},
+			Entry("percent", "2.5%", 0.025),
+			Entry("percent with spaces", " 50 % ", 0.5),
+			Entry("fraction", "0.1", 0.1),
+			Entry("zero", "0%", 0.0),
+			Entry("hundred percent", "100%", 1.0),
+		)
+
+		DescribeTable("parse percent with error",
+			func(s string) {
+				_, err := cos.ParsePercent(s)
+				Expect(err).To(HaveOccurred())
+			},
+			Entry("empty", ""),
+			Entry("not a number", "abc%"),
+			Entry("negative", "-1%"),
+			Entry("over 100%", "101%"),
+			Entry("fraction over 1", "1.5"),
+		)
+	})
+
+	Context("Quantity", func() {
+		DescribeTable("unmarshal size and duration from both human and machine representations",
+			func(in string, expectedSize cos.SizeIEC, expectedDur cos.Duration) {
+				var (
+					siz cos.SizeIEC
+					dur cos.Duration
+				)
+				if expectedSize != 0 {
+					Expect(jsoniter.Unmarshal([]byte(in), &siz)).NotTo(HaveOccurred())
+					Expect(siz).To(Equal(expectedSize))
+				} else {
+					Expect(jsoniter.Unmarshal([]byte(in), &dur)).NotTo(HaveOccurred())
+					Expect(dur).To(Equal(expectedDur))
+				}
+			},
+			Entry("size string", `"10MiB"`, cos.SizeIEC(10*cos.MiB), cos.Duration(0)),
+			Entry("size number", `10485760`, cos.SizeIEC(10*cos.MiB), cos.Duration(0)),
+			Entry("duration string", `"1m30s"`, cos.SizeIEC(0), cos.Duration(90*time.Second)),
+			Entry("duration number", `90000000000`, cos.SizeIEC(0), cos.Duration(90*time.Second)),
+		)
+
+		It("should render machine and human-readable values", func() {
+			q := cos.SizeIEC(cos.GiB).Qty()
+			Expect(q).To(Equal(cos.Quantity{Type: cos.QuantityBytes, Value: cos.GiB, Human: "1.00GiB"}))
+			b, err := jsoniter.Marshal(q)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(string(b)).To(Equal(`{"type":"bytes","value":"1073741824","human":"1.00GiB"}`))
+
+			q = cos.Duration(2 * time.Minute).Qty()
+			Expect(q.Value).To(Equal(int64(2 * time.Minute)))
+			Expect(q.Human).To(Equal("2m"))
+
+			Expect(cos.PercentQty(42).Human).To(Equal("42%"))
+		})
+	})
+
 	Context("ParseBool", func() {
 		It("should correctly parse different values into bools", func() {
 			trues := []string{"y", "yes", "on", "1", "t", "T", "true", "TRUE", "True"}
@@ -5,12 +5,16 @@
 package tests_test
 
 import (
+	"fmt"
 	"path/filepath"
 	"runtime"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/NVIDIA/aistore/api/apc"
 	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/cmn/cos"
 	"github.com/NVIDIA/aistore/cmn/jsp"
 	"github.com/NVIDIA/aistore/tools/tassert"
 )
@@ -72,3 +76,143 @@ func TestValidateMpath(t *testing.T) {
 		}
 	}
 }
+
+func TestConfigValidateAll(t *testing.T) {
+	oldConfig := cmn.GCO.Get()
+	defer func() {
+		cmn.GCO.BeginUpdate()
+		cmn.GCO.CommitUpdate(oldConfig)
+	}()
+
+	confPath := filepath.Join(thisFileDir(t), "configs", "config.json")
+	localConfPath := filepath.Join(thisFileDir(t), "configs", "confignet.json")
+	config := cmn.Config{}
+	err := cmn.LoadConfig(confPath, localConfPath, apc.Proxy, &config)
+	tassert.CheckFatal(t, err)
+	tassert.Fatalf(t, len(config.ValidateAll()) == 0, "expecting valid config, got %v", config.ValidateAll())
+
+	config.LRU.CapacityUpdTime = cos.Duration(time.Second)
+	config.Cksum.Type = "bogus"
+	config.Dsort.MaxJobs = -1
+	tassert.Errorf(t, config.Validate() != nil, "expecting validation error")
+	errs := config.ValidateAll()
+	tassert.Errorf(t, len(errs) == 3, "expecting 3 violations, got %d: %v", len(errs), errs)
+}
+
+func TestConfigUpdateValidate(t *testing.T) {
+	confPath := filepath.Join(thisFileDir(t), "configs", "config.json")
+	localConfPath := filepath.Join(thisFileDir(t), "configs", "confignet.json")
+	tests := []struct {
+		dns   string
+		valid bool
+	}{
+		{"_ais._tcp.cluster.local", true},
+		{"ais-proxy.ais.svc.cluster.local:51080", true},
+		{"http://ais-proxy:51080", false},
+		{"ais-proxy/path", false},
+	}
+	for _, test := range tests {
+		config := cmn.Config{}
+		tassert.CheckFatal(t, cmn.LoadConfig(confPath, localConfPath, apc.Proxy, &config))
+		toUpdate := &cmn.ConfigToSet{Proxy: &cmn.ProxyConfToSet{DiscoveryDNS: apc.Ptr(test.dns)}}
+		err := config.UpdateClusterConfig(toUpdate, apc.Cluster)
+		if test.valid {
+			tassert.CheckError(t, err)
+			tassert.Errorf(t, config.Proxy.DiscoveryDNS == test.dns, "expecting %q, got %q", test.dns, config.Proxy.DiscoveryDNS)
+		} else {
+			tassert.Errorf(t, err != nil, "expecting update with discovery_dns %q to fail", test.dns)
+		}
+	}
+}
+
+func TestConfigPutThrottleTime(t *testing.T) {
+	confPath := filepath.Join(thisFileDir(t), "configs", "config.json")
+	localConfPath := filepath.Join(thisFileDir(t), "configs", "confignet.json")
+	for _, d := range []time.Duration{0, time.Second, cmn.MaxPutThrottleTime, cmn.MaxPutThrottleTime + time.Second, -time.Second} {
+		config := cmn.Config{}
+		tassert.CheckFatal(t, cmn.LoadConfig(confPath, localConfPath, apc.Proxy, &config))
+		toUpdate := &cmn.ConfigToSet{Disk: &cmn.DiskConfToSet{PutThrottleTime: apc.Ptr(cos.Duration(d))}}
+		err := config.UpdateClusterConfig(toUpdate, apc.Cluster)
+		if d >= 0 && d <= cmn.MaxPutThrottleTime {
+			tassert.CheckError(t, err)
+		} else {
+			tassert.Errorf(t, err != nil, "expecting update with put_throttle_time %v to fail", d)
+		}
+	}
+}
+
+func TestConfigSchema(t *testing.T) {
+	dflt := &cmn.ClusterConfig{}
+	_, err := jsp.Load(filepath.Join(thisFileDir(t), "configs", "config.json"), dflt, jsp.Plain())
+	tassert.CheckFatal(t, err)
+
+	schema := cmn.NewConfigSchema(dflt)
+	fields := make(map[string]*cmn.ConfigFieldSchema, len(schema))
+	for _, fs := range schema {
+		fields[fs.Name] = fs
+	}
+	tests := []struct {
+		name    string
+		scope   string
+		runtime bool
+		perNode bool
+	}{
+		{"space.cleanupwm", apc.Cluster, true, true},
+		{"ec.enabled", apc.Cluster, true, false},
+		{"rebalance.enabled", apc.Cluster, true, false},
+		{"confdir", "local", false, false},
+	}
+	for _, test := range tests {
+		fs, ok := fields[test.name]
+		if !ok {
+			t.Fatalf("%q not found in config schema", test.name)
+		}
+		tassert.Errorf(t, fs.Scope == test.scope, "%s: scope %q (expecting %q)", test.name, fs.Scope, test.scope)
+		tassert.Errorf(t, fs.Runtime == test.runtime, "%s: runtime %t (expecting %t)", test.name, fs.Runtime, test.runtime)
+		tassert.Errorf(t, fs.PerNode == test.perNode, "%s: per-node %t (expecting %t)", test.name, fs.PerNode, test.perNode)
+	}
+
+	cksum := fields["checksum.type"]
+	tassert.Errorf(t, cksum.Default == dflt.Cksum.Type, "checksum.type: default %v (expecting %q)", cksum.Default, dflt.Cksum.Type)
+	tassert.Errorf(t, len(cksum.Enum) > 0, "checksum.type: expecting enumerated values")
+	tassert.Errorf(t, fields["timeout.max_keepalive"].Format == "duration", "timeout.max_keepalive: expecting duration")
+
+	// bounds
+	ranges := []struct {
+		name     string
+		min, max any
+	}{
+		{"space.cleanupwm", int64(1), int64(100)},
+		{"periodic.stats_time", cos.Duration(time.Second), cos.Duration(time.Minute)},
+		{"ec.data_slices", cmn.MinSliceCount, cmn.MaxSliceCount},
+		{"distributed_sort.max_jobs", 0, nil},
+	}
+	for _, test := range ranges {
+		fs := fields[test.name]
+		tassert.Errorf(t, fs.Min == test.min && fs.Max == test.max, "%s: range [%v, %v] (expecting [%v, %v])",
+			test.name, fs.Min, fs.Max, test.min, test.max)
+	}
+	for _, fs := range schema {
+		for _, v := range []any{fs.Min, fs.Max} {
+			if v != nil {
+				tassert.Errorf(t, fmt.Sprintf("%T", v) == fs.Type, "%s: bound %v of type %T (expecting %s)", fs.Name, v, v, fs.Type)
+			}
+		}
+	}
+}
+
+func TestParseLatencyBuckets(t *testing.T) {
+	bounds, err := cmn.ParseLatencyBuckets("10ms, 100ms,1s")
+	tassert.CheckFatal(t, err)
+	tassert.Fatalf(t, len(bounds) == 3 && bounds[0] == 10*time.Millisecond && bounds[2] == time.Second,
+		"unexpected buckets %v", bounds)
+
+	bounds, err = cmn.ParseLatencyBuckets("")
+	tassert.CheckFatal(t, err)
+	tassert.Errorf(t, len(bounds) == 0, "expecting no buckets, got %v", bounds)
+
+	for _, s := range []string{"1s,100ms", "10ms,10ms", "0s", "-1s", "abc", "1s,", strings.Repeat("1s,", cmn.MaxLatencyBuckets) + "2s"} {
+		_, err := cmn.ParseLatencyBuckets(s)
+		tassert.Errorf(t, err != nil, "expecting %q to fail", s)
+	}
+}
@@ -0,0 +1,45 @@
+// Package test provides tests for common low-level types and utilities for all aistore projects
+/*
+ * Copyright (c) 2024, NVIDIA CORPORATION. All rights reserved.
+ */
+package tests_test
+
+import (
+	"github.com/NVIDIA/aistore/cmn"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("DSCPConf", func() {
+	DescribeTable("should validate DSCP code points",
+		func(conf cmn.DSCPConf, valid bool) {
+			err := conf.Validate()
+			if valid {
+				Expect(err).NotTo(HaveOccurred())
+			} else {
+				Expect(err).To(HaveOccurred())
+			}
+		},
+		Entry("zero value", cmn.DSCPConf{}, true),
+		Entry("AF41 and EF", cmn.DSCPConf{Public: 34, IntraData: 46}, true),
+		Entry("max", cmn.DSCPConf{IntraControl: cmn.MaxDSCP}, true),
+		Entry("out of range", cmn.DSCPConf{IntraData: cmn.MaxDSCP + 1}, false),
+		Entry("negative", cmn.DSCPConf{Public: -1}, false),
+	)
+
+	DescribeTable("should resolve per-network TOS",
+		func(useCtrl, useData bool, pub, ctrl, data int) {
+			config := &cmn.Config{}
+			config.Net.L4.DSCP = cmn.DSCPConf{Public: 10, IntraControl: 20, IntraData: 30}
+			config.HostNet.UseIntraControl, config.HostNet.UseIntraData = useCtrl, useData
+
+			Expect(config.NetTOS(cmn.NetPublic)).To(Equal(pub << 2))
+			Expect(config.NetTOS(cmn.NetIntraControl)).To(Equal(ctrl << 2))
+			Expect(config.NetTOS(cmn.NetIntraData)).To(Equal(data << 2))
+		},
+		Entry("all networks", true, true, 10, 20, 30),
+		Entry("public only", false, false, 10, 10, 10),
+		Entry("no intra-data", true, false, 10, 20, 20),
+		Entry("no intra-control", false, true, 10, 10, 30),
+	)
+})
@@ -1,15 +1,20 @@
 // Package test provides tests for common low-level types and utilities for all aistore projects
 /*
- * Copyright (c) 2018-2023, NVIDIA CORPORATION. All rights reserved.
+ * Copyright (c) 2018-2024, NVIDIA CORPORATION. All rights reserved.
  */
 package tests_test
 
 import (
+	"errors"
 	"fmt"
+	"net/http"
 	"testing"
 
+	"github.com/NVIDIA/aistore/api/apc"
 	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/cmn/cos"
 	"github.com/NVIDIA/aistore/tools/tassert"
+	jsoniter "github.com/json-iterator/go"
 )
 
 func TestAbortedErrorAs(t *testing.T) {
@@ -22,3 +27,23 @@ func TestAbortedErrorAs(t *testing.T) {
 	mockError := fmt.Errorf("wrapping aborted error %w", abortedError)
 	tassert.Fatalf(t, cmn.IsErrAborted(mockError), "expected errors.As to return true on a wrapped error")
 }
+
+func TestErrHTTPKind(t *testing.T) {
+	bck := cmn.Bck{Name: "abc", Provider: apc.AIS}
+	herr := cmn.NewErrHTTP(nil, cmn.NewErrBckNotFound(&bck), 0)
+	tassert.Fatalf(t, herr.Kind == string(cmn.ErrKindNotFound), "expected %q kind, got %q", cmn.ErrKindNotFound, herr.Kind)
+	tassert.Fatalf(t, herr.Resource == bck.Cname(""), "expected %q resource, got %q", bck.Cname(""), herr.Resource)
+	tassert.Fatalf(t, !herr.Retriable, "expected non-retriable error")
+
+	// round-trip
+	var out cmn.ErrHTTP
+	tassert.CheckFatal(t, jsoniter.Unmarshal(cos.MustMarshal(herr), &out))
+	wrapped := fmt.Errorf("wrapping %w", &out)
+	tassert.Fatalf(t, errors.Is(wrapped, cmn.ErrKindNotFound), "expected errors.Is to match %q", cmn.ErrKindNotFound)
+	tassert.Fatalf(t, !errors.Is(wrapped, cmn.ErrKindBusy), "expected errors.Is to not match %q", cmn.ErrKindBusy)
+
+	// status-based
+	herr = &cmn.ErrHTTP{Message: "starting up", Status: http.StatusServiceUnavailable}
+	tassert.Fatalf(t, cmn.IsRetriable(herr), "expected retriable error")
+	tassert.Fatalf(t, errors.Is(herr, cmn.ErrKindUnavailable), "expected errors.Is to match %q", cmn.ErrKindUnavailable)
+}
@@ -0,0 +1,91 @@
+// Package test provides tests for common low-level types and utilities for all aistore projects
+/*
+ * Copyright (c) 2024, NVIDIA CORPORATION. All rights reserved.
+ */
+package tests_test
+
+import (
+	"time"
+
+	"github.com/NVIDIA/aistore/api/apc"
+	"github.com/NVIDIA/aistore/cmn"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Bucket freeze", func() {
+	const cname = "ais://frozen"
+
+	frozen := func(mode string, until int64) *cmn.FreezeConf {
+		return &cmn.FreezeConf{
+			State: apc.FreezeState{
+				Mode:    mode,
+				Reason:  "testing",
+				Trigger: apc.FreezeByUser,
+				Since:   time.Now().Add(-time.Minute).UnixNano(),
+				Until:   until,
+			},
+		}
+	}
+
+	DescribeTable("should permit or deny access",
+		func(mode string, ace apc.AccessAttrs, allowed bool) {
+			err := frozen(mode, 0).Check(cname, ace)
+			if allowed {
+				Expect(err).NotTo(HaveOccurred())
+				return
+			}
+			Expect(err).To(HaveOccurred())
+			Expect(cmn.IsErrBucketFrozen(err)).To(BeTrue())
+			Expect(err.Error()).To(ContainSubstring(mode))
+			Expect(err.Error()).To(ContainSubstring("testing"))
+			Expect(err.Error()).To(ContainSubstring("until: thawed"))
+		},
+		Entry("read-only: GET", apc.FreezeRO, apc.AceGET, true),
+		Entry("read-only: list", apc.FreezeRO, apc.AceObjLIST, true),
+		Entry("read-only: set ACL", apc.FreezeRO, apc.AceBckSetACL, true),
+		Entry("read-only: PUT", apc.FreezeRO, apc.AcePUT, false),
+		Entry("read-only: DELETE", apc.FreezeRO, apc.AceObjDELETE, false),
+		Entry("read-only: destroy", apc.FreezeRO, apc.AceDestroyBucket, false),
+		Entry("no-access: HEAD bucket", apc.FreezeNoAccess, apc.AceBckHEAD, true),
+		Entry("no-access: PATCH", apc.FreezeNoAccess, apc.AcePATCH, true),
+		Entry("no-access: GET", apc.FreezeNoAccess, apc.AceGET, false),
+		Entry("no-access: list", apc.FreezeNoAccess, apc.AceObjLIST, false),
+	)
+
+	It("should classify frozen-bucket errors", func() {
+		err := frozen(apc.FreezeRO, 0).Check(cname, apc.AcePUT)
+		Expect(cmn.IsErrBucketFrozen(err)).To(BeTrue())
+
+		// by error type (irrespective of the status)
+		herr := cmn.NewErrHTTP(nil, err, 0)
+		Expect(herr.Kind).To(Equal(string(cmn.ErrKindAccess)))
+		Expect(herr.Resource).To(Equal(cname))
+		Expect(cmn.IsRetriable(herr)).To(BeFalse())
+	})
+
+	It("should not deny access when not frozen", func() {
+		Expect((&cmn.FreezeConf{}).Check(cname, apc.AcePUT)).NotTo(HaveOccurred())
+	})
+
+	It("should thaw upon expiration", func() {
+		var (
+			now     = time.Now()
+			expired = frozen(apc.FreezeNoAccess, now.Add(-time.Second).UnixNano())
+			active  = frozen(apc.FreezeNoAccess, now.Add(time.Hour).UnixNano())
+		)
+		Expect(expired.Check(cname, apc.AceGET)).NotTo(HaveOccurred())
+		Expect(expired.State.Expired(now.UnixNano())).To(BeTrue())
+
+		err := active.Check(cname, apc.AceGET)
+		Expect(cmn.IsErrBucketFrozen(err)).To(BeTrue())
+		Expect(err.Error()).NotTo(ContainSubstring("until: thawed"))
+		Expect(active.State.Expired(now.UnixNano())).To(BeFalse())
+	})
+
+	It("should validate", func() {
+		Expect((&apc.FreezeMsg{Mode: "frozen"}).Validate()).To(HaveOccurred())
+		Expect((&apc.FreezeMsg{Mode: apc.FreezeRO, Duration: -1}).Validate()).To(HaveOccurred())
+		Expect((&apc.FreezeMsg{Mode: apc.FreezeNoAccess}).Validate()).NotTo(HaveOccurred())
+	})
+})
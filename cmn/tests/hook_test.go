@@ -0,0 +1,39 @@
+// Package test provides tests for common low-level types and utilities for all aistore projects
+/*
+ * Copyright (c) 2024, NVIDIA CORPORATION. All rights reserved.
+ */
+package tests_test
+
+import (
+	"time"
+
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/cmn/cos"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("HookConf", func() {
+	DescribeTable("should validate webhook props",
+		func(conf cmn.HookConf, valid, enabled bool) {
+			err := conf.ValidateAsProps()
+			if !valid {
+				Expect(err).To(HaveOccurred())
+				return
+			}
+			Expect(err).NotTo(HaveOccurred())
+			Expect(conf.Enabled()).To(Equal(enabled))
+		},
+		Entry("zero value", cmn.HookConf{}, true, false),
+		Entry("enabled", cmn.HookConf{URL: "http://validator:8080/check", FailOpen: true}, true, true),
+		Entry("enabled with timeout", cmn.HookConf{URL: "https://validator/check", Timeout: cos.Duration(time.Second)}, true, true),
+		Entry("negative timeout", cmn.HookConf{URL: "http://validator", Timeout: -1}, false, false),
+		Entry("bad scheme", cmn.HookConf{URL: "ftp://validator"}, false, false),
+		Entry("no host", cmn.HookConf{URL: "http://"}, false, false),
+	)
+
+	It("should default timeout", func() {
+		Expect((&cmn.HookConf{}).TimeoutOrDflt()).To(Equal(cmn.DfltHookTimeout))
+		Expect((&cmn.HookConf{Timeout: cos.Duration(time.Second)}).TimeoutOrDflt()).To(Equal(time.Second))
+	})
+})
@@ -68,6 +68,7 @@ var _ = Describe("IterFields", func() {
 					"mirror.enabled":      false,
 					"mirror.copies":       int64(0),
 					"mirror.burst_buffer": 0,
+					"mirror.hedge_delay":  cos.Duration(0),
 
 					"ec.enabled":           true,
 					"ec.parity_slices":     1024,
@@ -102,6 +103,11 @@ var _ = Describe("IterFields", func() {
 
 					"write_policy.data": apc.WritePolicy(""),
 					"write_policy.md":   apc.WritePolicy(""),
+
+					"atime.policy":    "",
+					"atime.max_stale": cos.Duration(0),
+
+					"max_conns": 0,
 				},
 			),
 			Entry("list BpropsToSet fields",
@@ -127,6 +133,7 @@ var _ = Describe("IterFields", func() {
 					"mirror.enabled":      (*bool)(nil),
 					"mirror.copies":       (*int64)(nil),
 					"mirror.burst_buffer": (*int)(nil),
+					"mirror.hedge_delay":  (*cos.Duration)(nil),
 
 					"ec.enabled":           apc.Ptr(true),
 					"ec.parity_slices":     apc.Ptr(1024),
@@ -162,6 +169,47 @@ var _ = Describe("IterFields", func() {
 					"extra.aws.profile":        (*string)(nil),
 					"extra.aws.max_pagesize":   (*int64)(nil),
 					"extra.http.original_url":  (*string)(nil),
+
+					"etl.name":    (*string)(nil),
+					"etl.timeout": (*cos.Duration)(nil),
+
+					"objname.max_len":         (*int)(nil),
+					"objname.max_depth":       (*int)(nil),
+					"objname.forbidden_chars": (*string)(nil),
+					"objname.normalize":       (*string)(nil),
+
+					"trash.enabled":   (*bool)(nil),
+					"trash.retention": (*cos.Duration)(nil),
+
+					"shadow.endpoint":   (*string)(nil),
+					"shadow.pct":        (*int)(nil),
+					"shadow.sample_pct": (*int)(nil),
+					"shadow.put":        (*bool)(nil),
+
+					"atime.policy":    (*string)(nil),
+					"atime.max_stale": (*cos.Duration)(nil),
+
+					"max_conns": (*int)(nil),
+
+					"hook.url":       (*string)(nil),
+					"hook.timeout":   (*cos.Duration)(nil),
+					"hook.fail_open": (*bool)(nil),
+					"hook.delete":    (*bool)(nil),
+
+					"ram_cache.max_obj_size": (*cos.SizeIEC)(nil),
+					"ram_cache.enabled":      (*bool)(nil),
+
+					"publish.enabled":           (*bool)(nil),
+					"publish.content_addressed": (*bool)(nil),
+
+					"dedup.enabled":  (*bool)(nil),
+					"dedup.min_size": (*cos.SizeIEC)(nil),
+
+					"write_back.enabled":      (*bool)(nil),
+					"write_back.delete_delay": (*cos.Duration)(nil),
+
+					"freeze.quota":        (*cos.SizeIEC)(nil),
+					"freeze.on_integrity": (*string)(nil),
 				},
 			),
 			Entry("check for omit tag",
@@ -0,0 +1,37 @@
+// Package test provides tests for common low-level types and utilities for all aistore projects
+/*
+ * Copyright (c) 2024, NVIDIA CORPORATION. All rights reserved.
+ */
+package tests_test
+
+import (
+	"github.com/NVIDIA/aistore/cmn"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Job", func() {
+	DescribeTable("should parse unified job ID",
+		func(id, typ, nid string) {
+			t, n := cmn.ParseJobID(id)
+			Expect(t).To(Equal(typ))
+			Expect(n).To(Equal(nid))
+		},
+		Entry("xaction", cmn.JobID(cmn.JobTypeXaction, "Hk3Zx9Fq"), cmn.JobTypeXaction, "Hk3Zx9Fq"),
+		Entry("dsort", "dsort:srt-Hk3Zx9Fq", cmn.JobTypeDsort, "srt-Hk3Zx9Fq"),
+		Entry("download", "download:dnl-Hk3Zx9Fq", cmn.JobTypeDownload, "dnl-Hk3Zx9Fq"),
+		Entry("etl", "etl:md5", cmn.JobTypeETL, "md5"),
+		Entry("native", "Hk3Zx9Fq", "", "Hk3Zx9Fq"),
+		Entry("unknown type", "foo:bar", "", "foo:bar"),
+		Entry("empty native", "etl:", cmn.JobTypeETL, ""),
+	)
+
+	It("should tell finished from running", func() {
+		for state, finished := range map[string]bool{
+			cmn.JobQueued: false, cmn.JobRunning: false, cmn.JobIdle: false,
+			cmn.JobFinished: true, cmn.JobAborted: true, cmn.JobFailed: true,
+		} {
+			Expect((&cmn.Job{State: state}).Finished()).To(Equal(finished), state)
+		}
+	})
+})
@@ -0,0 +1,106 @@
+// Package test provides tests for common low-level types and utilities for all aistore projects
+/*
+ * Copyright (c) 2024, NVIDIA CORPORATION. All rights reserved.
+ */
+package tests_test
+
+import (
+	"math/rand/v2"
+	"sort"
+	"strconv"
+
+	"github.com/NVIDIA/aistore/api/apc"
+	"github.com/NVIDIA/aistore/cmn"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("LsoTopN", func() {
+	const (
+		num = 1000
+		n   = 17
+	)
+	entries := make(cmn.LsoEntries, num)
+	for i := range entries {
+		entries[i] = &cmn.LsoEnt{
+			Name:  "obj-" + strconv.Itoa(i),
+			Size:  rand.Int64N(num / 4), // (with ties)
+			Mtime: rand.Int64N(num * 4),
+		}
+	}
+
+	DescribeTable("should select and merge top-N",
+		func(by string, desc bool) {
+			key := func(e *cmn.LsoEnt) int64 {
+				if by == apc.LsSortByMtime {
+					return e.Mtime
+				}
+				return e.Size
+			}
+			// expected
+			all := make(cmn.LsoEntries, num)
+			copy(all, entries)
+			sort.Slice(all, func(i, j int) bool {
+				ki, kj := key(all[i]), key(all[j])
+				if ki != kj {
+					return (ki > kj) == desc
+				}
+				return all[i].Name < all[j].Name
+			})
+
+			// two "targets" and the "proxy" merge
+			s := &apc.LsoSort{By: by, Desc: desc}
+			t1, t2 := cmn.NewLsoTopN(s, n), cmn.NewLsoTopN(s, n)
+			for i, e := range entries {
+				if i%2 == 0 {
+					t1.Add(e)
+				} else {
+					t2.Add(e)
+				}
+			}
+			merged := cmn.NewLsoTopN(s, n)
+			for _, e := range append(t1.Sorted(), t2.Sorted()...) {
+				merged.Add(e)
+			}
+			Expect(merged.Sorted()).To(Equal(all[:n]))
+		},
+		Entry("smallest", apc.LsSortBySize, false),
+		Entry("largest", apc.LsSortBySize, true),
+		Entry("oldest", apc.LsSortByMtime, false),
+		Entry("newest", apc.LsSortByMtime, true),
+	)
+
+	It("should select unique names", func() {
+		var (
+			s      = &apc.LsoSort{By: apc.LsSortBySize, Desc: true}
+			t1, t2 = cmn.NewLsoTopN(s, n), cmn.NewLsoTopN(s, n)
+		)
+		for i := range num {
+			size := int64(num - i)
+			// e.g., misplaced (or copy) on one target and in its proper location on the other
+			t1.Add(&cmn.LsoEnt{Name: "obj-" + strconv.Itoa(i), Size: size, Flags: apc.LocMisplacedNode})
+			t2.Add(&cmn.LsoEnt{Name: "obj-" + strconv.Itoa(i), Size: size})
+			t2.Add(&cmn.LsoEnt{Name: "obj-" + strconv.Itoa(i), Size: size, Flags: apc.LocIsCopy})
+		}
+		merged := cmn.NewLsoTopN(s, n)
+		for _, e := range append(t1.Sorted(), t2.Sorted()...) {
+			merged.Add(e)
+		}
+		lst := merged.Sorted()
+		Expect(lst).To(HaveLen(n))
+		for i, e := range lst {
+			Expect(e.Name).To(Equal("obj-" + strconv.Itoa(i)))
+			Expect(e.IsStatusOK()).To(BeTrue())
+		}
+	})
+
+	DescribeTable("should reject invalid requests",
+		func(lsmsg apc.LsoMsg) {
+			Expect(lsmsg.Sort.Validate(&lsmsg)).To(HaveOccurred())
+		},
+		Entry("sort key", apc.LsoMsg{Sort: &apc.LsoSort{By: "name"}}),
+		Entry("too many", apc.LsoMsg{Sort: &apc.LsoSort{By: apc.LsSortBySize}, PageSize: apc.MaxLsoSortLimit + 1}),
+		Entry("continuation", apc.LsoMsg{Sort: &apc.LsoSort{By: apc.LsSortBySize}, ContinuationToken: "abc"}),
+		Entry("archive", apc.LsoMsg{Sort: &apc.LsoSort{By: apc.LsSortByMtime}, Flags: apc.LsArchDir}),
+	)
+})
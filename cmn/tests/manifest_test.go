@@ -0,0 +1,158 @@
+// Package test provides tests for common low-level types and utilities for all aistore projects
+/*
+ * Copyright (c) 2024, NVIDIA CORPORATION. All rights reserved.
+ */
+package tests_test
+
+import (
+	"bytes"
+	"time"
+
+	"github.com/NVIDIA/aistore/api/apc"
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/cmn/cos"
+	jsoniter "github.com/json-iterator/go"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Manifest", func() {
+	var (
+		key   = []byte("cluster-key")
+		newMf = func() *cmn.Manifest {
+			return &cmn.Manifest{
+				Created:     time.Now(),
+				Bck:         cmn.Bck{Name: "data", Provider: apc.AIS},
+				Prefix:      "train/",
+				ClusterUUID: "uuid",
+				CksumType:   cos.ChecksumXXHash,
+				Entries: []cmn.ManifestEntry{
+					{Name: "train/b", Size: 20, Cksum: "bb"},
+					{Name: "train/a", Size: 10, Cksum: "aa", Version: "1"},
+					{Name: "train/c", Size: 30, Cksum: "cc"},
+				},
+			}
+		}
+	)
+
+	It("should compute the same digest regardless of the entries' order", func() {
+		mf1, mf2 := newMf(), newMf()
+		mf2.Entries[0], mf2.Entries[2] = mf2.Entries[2], mf2.Entries[0]
+		Expect(mf1.ComputeDigest()).To(Equal(mf2.ComputeDigest()))
+
+		mf2.Entries[1].Size++
+		Expect(mf1.ComputeDigest()).NotTo(Equal(mf2.ComputeDigest()))
+	})
+
+	It("should survive JSON round-trip and detect tampering", func() {
+		mf := newMf()
+		mf.Sign(key)
+		Expect(mf.VerifySignature(key)).To(BeTrue())
+		Expect(mf.VerifySignature([]byte("another-key"))).To(BeFalse())
+
+		var out cmn.Manifest
+		Expect(jsoniter.Unmarshal(cos.MustMarshal(mf), &out)).NotTo(HaveOccurred())
+		Expect(out.VerifySignature(key)).To(BeTrue())
+
+		out.Entries[0].Cksum = "xx"
+		Expect(out.VerifySignature(key)).To(BeFalse())
+
+		// recomputed digest without the key is not enough
+		out.Digest = out.ComputeDigest()
+		Expect(out.VerifySignature(key)).To(BeFalse())
+
+		out = *newMf()
+		out.Sign(key)
+		out.Prefix = ""
+		Expect(out.VerifySignature(key)).To(BeFalse())
+	})
+
+	It("should stream the same signed manifest", func() {
+		var (
+			mf  = newMf()
+			buf bytes.Buffer
+		)
+		mf.Sign(key) // (sorts the entries)
+
+		mw := cmn.NewManifestWriter(&buf, mf)
+		for i := range mf.Entries {
+			Expect(mw.Add(&mf.Entries[i])).NotTo(HaveOccurred())
+			Expect(mw.Flush()).NotTo(HaveOccurred())
+		}
+		Expect(mw.Finish(key)).NotTo(HaveOccurred())
+		mw.Free()
+
+		var out cmn.Manifest
+		Expect(jsoniter.Unmarshal(buf.Bytes(), &out)).NotTo(HaveOccurred())
+		Expect(out.Entries).To(Equal(mf.Entries))
+		Expect(out.Digest).To(Equal(mf.ComputeDigest()))
+		Expect(out.VerifySignature(key)).To(BeTrue())
+
+		// empty
+		buf.Reset()
+		mw = cmn.NewManifestWriter(&buf, newMf())
+		Expect(mw.Finish(key)).NotTo(HaveOccurred())
+		mw.Free()
+		out = cmn.Manifest{}
+		Expect(jsoniter.Unmarshal(buf.Bytes(), &out)).NotTo(HaveOccurred())
+		Expect(out.Entries).To(BeEmpty())
+		Expect(out.VerifySignature(key)).To(BeTrue())
+	})
+
+	It("should refuse to stream unsorted entries", func() {
+		var (
+			mf  = newMf()
+			buf bytes.Buffer
+		)
+		mw := cmn.NewManifestWriter(&buf, mf)
+		defer mw.Free()
+		Expect(mw.Add(&mf.Entries[0])).NotTo(HaveOccurred()) // train/b
+		Expect(mw.Add(&mf.Entries[1])).To(HaveOccurred())    // train/a
+	})
+
+	It("should diff against the current content", func() {
+		var (
+			mf  = newMf()
+			cur = newMf()
+		)
+		mf.Sign(key)
+		diff := mf.Diff(cur)
+		diff.SigOK = mf.VerifySignature(key)
+		Expect(diff.Match()).To(BeTrue())
+		Expect(diff.Verified).To(Equal(3))
+		Expect(diff.Tampered()).To(BeFalse())
+
+		cur.Entries = []cmn.ManifestEntry{
+			{Name: "train/a", Size: 10, Cksum: "aa", Version: "2"}, // changed
+			{Name: "train/c", Size: 30, Cksum: "cc"},
+			{Name: "train/d", Size: 40, Cksum: "dd"}, // added
+		}
+		diff = mf.Diff(cur)
+		diff.SigOK = true
+		Expect(diff.Match()).To(BeFalse())
+		Expect(diff.Tampered()).To(BeTrue())
+		Expect(diff.Missing).To(Equal([]string{"train/b"}))
+		Expect(diff.Changed).To(Equal([]string{"train/a"}))
+		Expect(diff.Added).To(Equal([]string{"train/d"}))
+		Expect(diff.Verified).To(Equal(1))
+	})
+
+	It("should not consider unsigned or merely stale manifests tampered with", func() {
+		var (
+			mf  = newMf()
+			cur = newMf()
+		)
+		mf.Sign(key)
+		cur.Entries = cur.Entries[1:] // missing
+		diff := mf.Diff(cur)
+		Expect(diff.Tampered()).To(BeFalse()) // signature not verified
+
+		// objects added since
+		cur = newMf()
+		cur.Entries = append(cur.Entries, cmn.ManifestEntry{Name: "train/d", Size: 40, Cksum: "dd"})
+		diff = mf.Diff(cur)
+		diff.SigOK = mf.VerifySignature(key)
+		Expect(diff.Match()).To(BeFalse())
+		Expect(diff.Tampered()).To(BeFalse())
+	})
+})
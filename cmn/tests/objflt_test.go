@@ -0,0 +1,103 @@
+// Package test provides tests for common low-level types and utilities for all aistore projects
+/*
+ * Copyright (c) 2024, NVIDIA CORPORATION. All rights reserved.
+ */
+package tests_test
+
+import (
+	"time"
+
+	"github.com/NVIDIA/aistore/api/apc"
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/cmn/cos"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("ObjFilter", func() {
+	var (
+		t0 = time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)
+		t1 = t0.Add(24 * time.Hour)
+	)
+
+	It("should compile empty filter to nil", func() {
+		var flt *apc.ObjFilter
+		m, err := flt.Compile()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(m).To(BeNil())
+		m, err = (&apc.ObjFilter{}).Compile()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(m).To(BeNil())
+	})
+
+	It("should tell whether object props are needed", func() {
+		flt := &apc.ObjFilter{Include: "^a"}
+		Expect(flt.NeedsProps()).To(BeFalse())
+		m, err := flt.Compile()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(m.NeedsMtime()).To(BeFalse())
+
+		flt = &apc.ObjFilter{MinSize: 1}
+		Expect(flt.NeedsProps()).To(BeTrue())
+		m, err = flt.Compile()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(m.NeedsMtime()).To(BeFalse())
+
+		flt = &apc.ObjFilter{ModBefore: t1.Format(time.RFC3339)}
+		Expect(flt.NeedsProps()).To(BeTrue())
+		m, err = flt.Compile()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(m.NeedsMtime()).To(BeTrue())
+	})
+
+	It("should filter remote listing by last-modified", func() {
+		// remote backends report last-modified as custom metadata (see LsoEnt.Custom)
+		custom := cmn.CustomMD2S(cos.StrKVs{
+			cmn.SourceObjMD:  apc.AWS,
+			cmn.ETag:         "abc",
+			cmn.LastModified: t0.Format(time.RFC3339),
+		})
+		v, ok := cmn.S2CustomMD(custom, "")[cmn.LastModified]
+		Expect(ok).To(BeTrue())
+		mtime, err := time.Parse(time.RFC3339, v)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(mtime.Equal(t0)).To(BeTrue())
+
+		m, err := (&apc.ObjFilter{ModAfter: t1.Format(time.RFC3339)}).Compile()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(m.Match("x", 1, mtime)).To(BeFalse())
+		m, err = (&apc.ObjFilter{ModBefore: t1.Format(time.RFC3339)}).Compile()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(m.Match("x", 1, mtime)).To(BeTrue())
+	})
+
+	DescribeTable("should reject invalid filters",
+		func(flt apc.ObjFilter) {
+			_, err := flt.Compile()
+			Expect(err).To(HaveOccurred())
+		},
+		Entry("include regex", apc.ObjFilter{Include: "a("}),
+		Entry("exclude regex", apc.ObjFilter{Exclude: "[z"}),
+		Entry("negative size", apc.ObjFilter{MinSize: -1}),
+		Entry("size range", apc.ObjFilter{MinSize: 10, MaxSize: 5}),
+		Entry("time format", apc.ObjFilter{ModAfter: "yesterday"}),
+		Entry("time range", apc.ObjFilter{ModAfter: t1.Format(time.RFC3339), ModBefore: t0.Format(time.RFC3339)}),
+	)
+
+	DescribeTable("should match",
+		func(flt apc.ObjFilter, name string, size int64, mtime time.Time, expected bool) {
+			m, err := flt.Compile()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(m.Match(name, size, mtime)).To(Equal(expected))
+		},
+		Entry("include", apc.ObjFilter{Include: `\.tar$`}, "a/b.tar", int64(1), time.Time{}, true),
+		Entry("not included", apc.ObjFilter{Include: `\.tar$`}, "a/b.tgz", int64(1), time.Time{}, false),
+		Entry("excluded", apc.ObjFilter{Include: `^a/`, Exclude: `tmp`}, "a/tmp/b", int64(1), time.Time{}, false),
+		Entry("min size", apc.ObjFilter{MinSize: 100}, "x", int64(99), time.Time{}, false),
+		Entry("max size", apc.ObjFilter{MaxSize: 100}, "x", int64(100), time.Time{}, true),
+		Entry("unknown size", apc.ObjFilter{MinSize: 100}, "x", int64(-1), time.Time{}, true),
+		Entry("modified after", apc.ObjFilter{ModAfter: t0.Format(time.RFC3339)}, "x", int64(1), t0, true),
+		Entry("modified before", apc.ObjFilter{ModBefore: t1.Format(time.RFC3339)}, "x", int64(1), t1, false),
+		Entry("unknown mtime", apc.ObjFilter{ModAfter: t1.Format(time.RFC3339)}, "x", int64(1), time.Time{}, true),
+	)
+})
@@ -0,0 +1,62 @@
+// Package test provides tests for common low-level types and utilities for all aistore projects
+/*
+ * Copyright (c) 2024, NVIDIA CORPORATION. All rights reserved.
+ */
+package tests_test
+
+import (
+	"github.com/NVIDIA/aistore/cmn"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("ObjNameConf", func() {
+	DescribeTable("should apply object naming policy",
+		func(conf cmn.ObjNameConf, name, expected string, valid bool) {
+			Expect(conf.ValidateAsProps()).NotTo(HaveOccurred())
+			out, err := conf.Apply(name)
+			if !valid {
+				Expect(err).To(HaveOccurred())
+				return
+			}
+			Expect(err).NotTo(HaveOccurred())
+			Expect(out).To(Equal(expected))
+		},
+		Entry("no policy", cmn.ObjNameConf{}, "a/b/c:d", "a/b/c:d", true),
+		Entry("max length", cmn.ObjNameConf{MaxLen: 5}, "a/b/c", "a/b/c", true),
+		Entry("max length exceeded", cmn.ObjNameConf{MaxLen: 4}, "a/b/c", "", false),
+		Entry("max depth", cmn.ObjNameConf{MaxDepth: 2}, "a/b/c", "a/b/c", true),
+		Entry("max depth exceeded", cmn.ObjNameConf{MaxDepth: 1}, "a/b/c", "", false),
+		Entry("forbidden char", cmn.ObjNameConf{Forbidden: `\:*?`}, "a/b?c", "", false),
+		Entry("forbidden multi-byte char", cmn.ObjNameConf{Forbidden: "ä"}, "a/bä", "", false),
+		Entry("NFC", cmn.ObjNameConf{Normalize: cmn.ObjNameNFC}, "cafe\u0301", "caf\u00e9", true),
+		Entry("NFD", cmn.ObjNameConf{Normalize: cmn.ObjNameNFD}, "caf\u00e9", "cafe\u0301", true),
+		Entry("NFC then max length", cmn.ObjNameConf{Normalize: cmn.ObjNameNFC, MaxLen: 5}, "cafe\u0301", "caf\u00e9", true),
+		Entry("invalid UTF-8", cmn.ObjNameConf{Normalize: cmn.ObjNameNFC}, "a\xffb", "", false),
+	)
+
+	DescribeTable("should check (but not normalize) object name",
+		func(conf cmn.ObjNameConf, name string, valid bool) {
+			err := conf.Check(name)
+			if valid {
+				Expect(err).NotTo(HaveOccurred())
+			} else {
+				Expect(err).To(HaveOccurred())
+			}
+		},
+		Entry("no policy", cmn.ObjNameConf{}, "a/b/c:d", true),
+		Entry("max depth exceeded", cmn.ObjNameConf{MaxDepth: 1}, "a/b/c", false),
+		Entry("already NFC", cmn.ObjNameConf{Normalize: cmn.ObjNameNFC}, "caf\u00e9", true),
+		Entry("not NFC", cmn.ObjNameConf{Normalize: cmn.ObjNameNFC}, "cafe\u0301", false),
+	)
+
+	DescribeTable("should fail to validate invalid policy",
+		func(conf cmn.ObjNameConf) {
+			Expect(conf.ValidateAsProps()).To(HaveOccurred())
+		},
+		Entry("negative length", cmn.ObjNameConf{MaxLen: -1}),
+		Entry("negative depth", cmn.ObjNameConf{MaxDepth: -1}),
+		Entry("forbidden separator", cmn.ObjNameConf{Forbidden: "/"}),
+		Entry("unknown normalization form", cmn.ObjNameConf{Normalize: "nfx"}),
+	)
+})
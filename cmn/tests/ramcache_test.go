@@ -0,0 +1,31 @@
+// Package test provides tests for common low-level types and utilities for all aistore projects
+/*
+ * Copyright (c) 2024, NVIDIA CORPORATION. All rights reserved.
+ */
+package tests_test
+
+import (
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/cmn/cos"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("RAMCacheConf", func() {
+	DescribeTable("should validate ram_cache props",
+		func(conf cmn.RAMCacheConf, valid bool, maxObjSize int64) {
+			err := conf.ValidateAsProps()
+			if !valid {
+				Expect(err).To(HaveOccurred())
+				return
+			}
+			Expect(err).NotTo(HaveOccurred())
+			Expect(conf.MaxObjSizeOrDflt()).To(Equal(maxObjSize))
+		},
+		Entry("zero value", cmn.RAMCacheConf{}, true, int64(cmn.DfltRAMCacheMaxObjSize)),
+		Entry("enabled", cmn.RAMCacheConf{Enabled: true}, true, int64(cmn.DfltRAMCacheMaxObjSize)),
+		Entry("custom max size", cmn.RAMCacheConf{Enabled: true, MaxObjSize: 256 * cos.KiB}, true, int64(256*cos.KiB)),
+		Entry("negative max size", cmn.RAMCacheConf{MaxObjSize: -1}, false, int64(0)),
+		Entry("max size too large", cmn.RAMCacheConf{MaxObjSize: cos.GiB}, false, int64(0)),
+	)
+})
@@ -0,0 +1,48 @@
+// Package test provides tests for common low-level types and utilities for all aistore projects
+/*
+ * Copyright (c) 2024, NVIDIA CORPORATION. All rights reserved.
+ */
+package tests_test
+
+import (
+	"time"
+
+	"github.com/NVIDIA/aistore/api/apc"
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/cmn/cos"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("RebalanceConf", func() {
+	conf := func(hotAge time.Duration) cmn.RebalanceConf {
+		return cmn.RebalanceConf{
+			Compression:   apc.CompressNever,
+			DestRetryTime: cos.Duration(2 * time.Minute),
+			HotAge:        cos.Duration(hotAge),
+			HotFirst:      true,
+		}
+	}
+
+	DescribeTable("should validate hot_age",
+		func(c cmn.RebalanceConf, valid bool) {
+			err := c.Validate()
+			if valid {
+				Expect(err).NotTo(HaveOccurred())
+			} else {
+				Expect(err).To(HaveOccurred())
+			}
+		},
+		Entry("default", conf(0), true),
+		Entry("one hour", conf(time.Hour), true),
+		Entry("too short", conf(time.Second), false),
+		Entry("too long", conf(400*24*time.Hour), false),
+	)
+
+	It("should default hot_age", func() {
+		c := conf(0)
+		Expect(c.HotAgeOrDflt()).To(Equal(cmn.DfltRebHotAge))
+		c = conf(time.Hour)
+		Expect(c.HotAgeOrDflt()).To(Equal(time.Hour))
+	})
+})
@@ -0,0 +1,56 @@
+// Package test provides tests for common low-level types and utilities for all aistore projects
+/*
+ * Copyright (c) 2024, NVIDIA CORPORATION. All rights reserved.
+ */
+package tests_test
+
+import (
+	"context"
+	"net"
+
+	"github.com/NVIDIA/aistore/cmn"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Resolver", func() {
+	lookupSRV := func(_ context.Context, _, _, name string) (string, []*net.SRV, error) {
+		if name != "_ais._tcp.cluster.local" {
+			return "", nil, &net.DNSError{Err: "no such host", Name: name, IsNotFound: true}
+		}
+		return "", []*net.SRV{{Target: "p1.cluster.local.", Port: 51080}, {Target: "p2.cluster.local.", Port: 51081}}, nil
+	}
+	lookupHost := func(_ context.Context, host string) ([]string, error) {
+		if host != "ais-proxy" {
+			return nil, &net.DNSError{Err: "no such host", Name: host, IsNotFound: true}
+		}
+		return []string{"10.0.0.1", "fd00::1"}, nil
+	}
+
+	DescribeTable("DNS resolver",
+		func(name string, https bool, expected []string) {
+			r := &cmn.DNSResolver{LookupSRV: lookupSRV, LookupHost: lookupHost, Name: name, Port: 8080, HTTPS: https}
+			urls, err := r.Resolve(context.Background())
+			if expected == nil {
+				Expect(err).To(HaveOccurred())
+				return
+			}
+			Expect(err).NotTo(HaveOccurred())
+			Expect(urls).To(Equal(expected))
+		},
+		Entry("SRV", "_ais._tcp.cluster.local", false, []string{"http://p1.cluster.local:51080", "http://p2.cluster.local:51081"}),
+		Entry("SRV https", "_ais._tcp.cluster.local", true, []string{"https://p1.cluster.local:51080", "https://p2.cluster.local:51081"}),
+		Entry("host", "ais-proxy", false, []string{"http://10.0.0.1:8080", "http://[fd00::1]:8080"}),
+		Entry("host:port", "ais-proxy:9090", false, []string{"http://10.0.0.1:9090", "http://[fd00::1]:9090"}),
+		Entry("unknown SRV", "_unknown._tcp.cluster.local", false, nil),
+		Entry("unknown host", "unknown", false, nil),
+	)
+
+	It("static resolver", func() {
+		urls, err := cmn.StaticResolver{"http://a:8080", "http://b:8080"}.Resolve(context.Background())
+		Expect(err).NotTo(HaveOccurred())
+		Expect(urls).To(HaveLen(2))
+		_, err = cmn.StaticResolver{}.Resolve(context.Background())
+		Expect(err).To(HaveOccurred())
+	})
+})
@@ -0,0 +1,33 @@
+// Package test provides tests for common low-level types and utilities for all aistore projects
+/*
+ * Copyright (c) 2024, NVIDIA CORPORATION. All rights reserved.
+ */
+package tests_test
+
+import (
+	"github.com/NVIDIA/aistore/cmn"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("ShadowConf", func() {
+	DescribeTable("should validate request shadowing props",
+		func(conf cmn.ShadowConf, valid, enabled bool) {
+			err := conf.ValidateAsProps()
+			if !valid {
+				Expect(err).To(HaveOccurred())
+				return
+			}
+			Expect(err).NotTo(HaveOccurred())
+			Expect(conf.Enabled()).To(Equal(enabled))
+		},
+		Entry("zero value", cmn.ShadowConf{}, true, false),
+		Entry("no endpoint", cmn.ShadowConf{Pct: 10}, true, false),
+		Entry("zero pct", cmn.ShadowConf{Endpoint: "http://canary:8080"}, true, false),
+		Entry("enabled", cmn.ShadowConf{Endpoint: "https://canary:8080", Pct: 5, SamplePct: 10, PUT: true}, true, true),
+		Entry("pct out of range", cmn.ShadowConf{Endpoint: "http://canary:8080", Pct: 101}, false, false),
+		Entry("sample pct out of range", cmn.ShadowConf{Endpoint: "http://canary:8080", Pct: 1, SamplePct: -1}, false, false),
+		Entry("bad scheme", cmn.ShadowConf{Endpoint: "ftp://canary", Pct: 1}, false, false),
+		Entry("no host", cmn.ShadowConf{Endpoint: "http://", Pct: 1}, false, false),
+	)
+})
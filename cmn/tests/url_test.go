@@ -8,6 +8,7 @@ import (
 	"fmt"
 	"net/http"
 	"net/url"
+	"strings"
 	"testing"
 
 	"github.com/NVIDIA/aistore/api/apc"
@@ -53,3 +54,39 @@ func TestReparseQuery(t *testing.T) {
 	tassert.Errorf(t, actualUUID == uuid, "expected %s to be %q, got %q", apc.QparamUUID, uuid, actualUUID)
 	tassert.Errorf(t, r.URL.Path == basePath, "expected path to be %q, got %q", basePath, r.URL.Path)
 }
+
+func TestEscapePath(t *testing.T) {
+	testCases := []struct{ path, expected string }{
+		{"/v1/objects/bck/obj", "/v1/objects/bck/obj"},
+		{"/v1/objects/bck/a b/c", "/v1/objects/bck/a%20b/c"},
+		{"/v1/objects/bck/what?#%", "/v1/objects/bck/what%3F%23%25"},
+		{"/v1/objects/bck/日本/語", "/v1/objects/bck/%E6%97%A5%E6%9C%AC/%E8%AA%9E"},
+		{"", ""},
+	}
+	for _, tc := range testCases {
+		actual := cos.EscapePath(tc.path)
+		tassert.Errorf(t, actual == tc.expected, "expected %q, got %q", tc.expected, actual)
+	}
+}
+
+// round-trip: client-side escaping (see cmn.HreqArgs) => net/http => r.URL.Path
+func FuzzEscapePath(f *testing.F) {
+	for _, objName := range []string{"obj", "a/b/c", "a b+c", "what?#%25", "日本/語", "./../x", "a;b,c=d&e@f$", "%", "/"} {
+		f.Add(objName)
+	}
+	f.Fuzz(func(t *testing.T, objName string) {
+		if strings.ContainsRune(objName, 0) {
+			t.Skip()
+		}
+		reqArgs := cmn.HreqArgs{Method: http.MethodGet, Base: "http://localhost:8080", Path: apc.URLPathObjects.Join("bck", objName)}
+		req, err := reqArgs.Req()
+		tassert.CheckFatal(t, err)
+		tassert.Fatalf(t, req.URL.RawQuery == "" && req.URL.Fragment == "", "%q: unexpected query %q or fragment %q",
+			objName, req.URL.RawQuery, req.URL.Fragment)
+
+		// as seen by the receiving side
+		u, err := url.ParseRequestURI(req.URL.RequestURI())
+		tassert.CheckFatal(t, err)
+		tassert.Fatalf(t, u.Path == reqArgs.Path, "expected %q, got %q", reqArgs.Path, u.Path)
+	})
+}
@@ -39,6 +39,8 @@ const (
 	MetaverVMD   = 2 // Volume MD (jsp)
 	MetaverEtlMD = 1 // ETL MD (jsp)
 
+	MetaverMDBundle = 1 // exported cluster metadata (disaster recovery; see core/meta/mdbundle.go)
+
 	MetaverLOM   = 1 // LOM
 	MetaverChunk = 2 // LOM chunk
 
@@ -0,0 +1,66 @@
+// Package cmn provides common constants, types, and utilities for AIS clients
+// and AIStore.
+/*
+ * Copyright (c) 2024, NVIDIA CORPORATION. All rights reserved.
+ */
+package cmn
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/NVIDIA/aistore/api/apc"
+	"github.com/NVIDIA/aistore/cmn/cos"
+)
+
+// Write-back (remote buckets only): deleting an object removes its in-cluster copy right away
+// while the corresponding backend DELETE gets queued and executed only after `delete_delay`
+// (the "undo window"). Pending deletions can be flushed (executed now) or canceled - see
+// apc.ActFlushDeletes, apc.ActCancelDeletes, and ais/tgtwback.go.
+
+// default delay of the deferred backend DELETE (see WriteBackConf)
+const DfltDeleteDelay = 10 * time.Minute
+
+type (
+	WriteBackConf struct {
+		Enabled     bool         `json:"enabled,omitempty"`
+		DeleteDelay cos.Duration `json:"delete_delay,omitempty"` // (dflt. DfltDeleteDelay)
+	}
+	WriteBackConfToSet struct {
+		Enabled     *bool         `json:"enabled,omitempty"`
+		DeleteDelay *cos.Duration `json:"delete_delay,omitempty"`
+	}
+
+	// by target ID
+	DeleteQueue map[string]*DeleteQueueInfo
+
+	// per-target state of the deferred deletion queue (apc.ActDeleteQueue)
+	DeleteQueueInfo struct {
+		Pending  int   `json:"pending"`            // number of queued backend deletions
+		Flushed  int64 `json:"flushed"`            // executed so far (cumulative)
+		Canceled int64 `json:"canceled"`           // canceled so far (cumulative)
+		Errors   int64 `json:"errors"`             // failed backend deletions (cumulative)
+		NextDue  int64 `json:"next_due,omitempty"` // earliest pending deadline (Unix nanoseconds)
+		Affected int   `json:"affected,omitempty"` // flushed or canceled by the request in question
+	}
+)
+
+func (c *WriteBackConf) validate(bp *Bprops) error {
+	if c.DeleteDelay < 0 {
+		return fmt.Errorf("invalid write_back.delete_delay %v (must be non-negative)", c.DeleteDelay)
+	}
+	if !c.Enabled {
+		return nil
+	}
+	if bp.Provider == apc.AIS && bp.BackendBck.IsEmpty() {
+		return fmt.Errorf("cannot enable write-back for %s bucket (remote buckets only)", apc.DisplayProvider(bp.Provider))
+	}
+	return nil
+}
+
+func (c *WriteBackConf) DeleteDelayD() time.Duration {
+	if c.DeleteDelay == 0 {
+		return DfltDeleteDelay
+	}
+	return c.DeleteDelay.D()
+}
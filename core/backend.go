@@ -57,4 +57,16 @@ type (
 		GetBucketInv(bck *meta.Bck, ctx *LsoInvCtx) (ecode int, err error)
 		ListObjectsInv(bck *meta.Bck, msg *apc.LsoMsg, lst *cmn.LsoRes, ctx *LsoInvCtx) error
 	}
+
+	// optional Backend extension: batched (multi-object) deletion
+	// - deletes up to MaxDeleteBatch objects at a time;
+	// - fills in per-object status codes and errors (nil: deleted) - both slices are caller-allocated;
+	// - returns non-nil error when the entire batch fails
+	// (see also: xs.evictDelete)
+	BatchDeleter interface {
+		DeleteObjs(bck *meta.Bck, objNames []string, ecodes []int, errs []error) (ecode int, err error)
+	}
 )
+
+// max number of objects in a single BatchDeleter call (S3 DeleteObjects limit)
+const MaxDeleteBatch = 1000
@@ -0,0 +1,129 @@
+// Package core provides core metadata and in-cluster API
+/*
+ * Copyright (c) 2024, NVIDIA CORPORATION. All rights reserved.
+ */
+package core
+
+import (
+	"time"
+
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/cmn/atomic"
+	"github.com/NVIDIA/aistore/hk"
+)
+
+// Access time (atime) persistence
+//
+// In-memory atime (`lmeta.Atime`) gets updated upon every access, while `lmeta.atimefs` holds
+// the atime that was last written to stable storage. Writing the former, or not, is governed by
+// the bucket's atime policy (cmn.AtimeConf):
+// - precise: upon every access (see `UpdateAtime`);
+// - lazy:    in batches - the housekeeper below periodically walks LOM caches and flushes
+//            atimes that have been stale for longer than the bucket's `max_stale`;
+//            also, upon eviction from the cache (lcache.go);
+// - off:     never.
+
+const atimeFlushIval = cmn.MinAtimeMaxStale
+
+type atimeFlusher struct {
+	now     time.Time
+	stale   map[uint64]time.Duration // bucket ID => max staleness (this run only)
+	cnt     int64
+	running atomic.Bool
+}
+
+func regAtimeWithHK() {
+	hk.Reg("atime"+hk.NameSuffix, g.atf.housekeep, atimeFlushIval)
+}
+
+func (md *lmeta) atimeOnDisk() int64 { return int64(md.atimefs & ^lomDirtyMask) }
+
+func (md *lmeta) setAtimeOnDisk(atime int64) {
+	md.atimefs = (md.atimefs & lomDirtyMask) | uint64(atime)
+}
+
+func (lom *LOM) AtimeConf() *cmn.AtimeConf { return &lom.Bprops().Atime }
+
+// UpdateAtime updates the (cached) access time and, if the bucket's policy is precise,
+// writes it through. Caller must (at least) r-lock.
+func (lom *LOM) UpdateAtime(atime int64) {
+	lom.SetAtimeUnix(atime)
+	if lom.AtimeConf().IsPrecise() {
+		if err := lom.flushAtime(time.Unix(0, atime)); err == nil {
+			lom.md.setAtimeOnDisk(atime)
+		}
+	}
+	lom.Recache()
+}
+
+//
+// lazy (batched) atime flushing
+//
+
+func (atf *atimeFlusher) housekeep(int64) time.Duration {
+	if !atf.running.CAS(false, true) {
+		return atimeFlushIval
+	}
+	go func() {
+		atf.run()
+		atf.running.Store(false)
+	}()
+	return atimeFlushIval
+}
+
+func (atf *atimeFlusher) run() {
+	atf.now = time.Now()
+	atf.stale = make(map[uint64]time.Duration, 8)
+	atf.cnt = 0
+	for _, cache := range lomCaches() {
+		cache.Range(atf.do)
+	}
+	if atf.cnt > 0 {
+		g.tstats.Add(LcacheFlushAtimeCount, atf.cnt)
+	}
+}
+
+func (atf *atimeFlusher) do(_, value any) bool {
+	md := value.(*lmeta)
+	if md.Atime <= 0 /*prefetched, not yet accessed*/ || md.Atime == md.atimeOnDisk() {
+		return true
+	}
+	if atf.now.Sub(time.Unix(0, md.atimeOnDisk())) < cmn.MinAtimeMaxStale {
+		return true
+	}
+	var (
+		bid          = md.lid.bid()
+		maxStale, ok = atf.stale[bid]
+	)
+	if ok && (maxStale == 0 || atf.now.Sub(time.Unix(0, md.atimeOnDisk())) < maxStale) {
+		return true
+	}
+
+	lif := LIF{uname: *md.uname, lid: md.lid}
+	lom, err := lif.LOM()
+	if err != nil {
+		return true
+	}
+	if !ok {
+		maxStale = lom.AtimeConf().StaleD() // zero unless lazy
+		atf.stale[bid] = maxStale
+	}
+	if maxStale > 0 && atf.now.Sub(time.Unix(0, md.atimeOnDisk())) >= maxStale {
+		atf.flush(lom, md)
+	}
+	FreeLOM(lom)
+	return true
+}
+
+// (skipping objects that are currently being written or modified)
+func (atf *atimeFlusher) flush(lom *LOM, md *lmeta) {
+	if !lom.TryLock(true) {
+		return
+	}
+	atime := md.Atime
+	if err := lom.flushAtime(time.Unix(0, atime)); err == nil {
+		md.setAtimeOnDisk(atime)
+		atf.cnt++
+	}
+	lom.Unlock(true)
+}
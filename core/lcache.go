@@ -57,6 +57,7 @@ func UncacheBck(b *meta.Bck) {
 		n      = max(sys.NumCPU()/4, 4)
 		wg     = cos.NewLimitedWaitGroup(n, len(caches))
 	)
+	uncacheRAMBck(b)
 	for _, lcache := range caches {
 		wg.Add(1)
 		go func(cache *sync.Map) {
@@ -149,6 +150,7 @@ func (lchk *lchk) evictOlder(d time.Duration) {
 	}
 
 	if _, tag := lchk.mp(); tag != "" {
+		g.ramc.purge() // memory pressure: drop cached objects
 		nlog.Infoln("post-evict memory pressure:", tag, "total:", lchk.totalCnt, "evicted:", lchk.evictedCnt)
 	}
 
@@ -8,6 +8,7 @@ import (
 	"fmt"
 	"os"
 
+	"github.com/NVIDIA/aistore/cmn"
 	"github.com/NVIDIA/aistore/cmn/cos"
 	"github.com/NVIDIA/aistore/cmn/debug"
 	"github.com/NVIDIA/aistore/cmn/nlog"
@@ -31,6 +32,30 @@ func (lom *LOM) whingeCopy() (yes bool) {
 func (lom *LOM) HasCopies() bool { return len(lom.md.copies) > 1 }
 func (lom *LOM) NumCopies() int  { return max(len(lom.md.copies), 1) } // metadata-wise
 
+// per-object replication override (cmn.CopiesObjMD), if any; zero otherwise
+func (lom *LOM) CopiesOverride() int {
+	s, ok := lom.GetCustomKey(cmn.CopiesObjMD)
+	if !ok {
+		return 0
+	}
+	n, err := cmn.ParseObjCopies(s)
+	if err != nil {
+		return 0
+	}
+	return n
+}
+
+// expected number of copies: per-object override, if any, or else the bucket's mirror config
+func (lom *LOM) ExpCopies() int {
+	if n := lom.CopiesOverride(); n > 0 {
+		return n
+	}
+	if mirror := lom.MirrorConf(); mirror.Enabled {
+		return int(mirror.Copies)
+	}
+	return 1
+}
+
 // GetCopies returns all copies
 // - copies include lom.FQN aka "main repl."
 // - caller must take a lock
@@ -53,7 +78,7 @@ func (lom *LOM) IsCopy() bool {
 // determines whether the two LOM _structures_ represent objects that must be _copies_ of each other
 // (compare with IsCopy above)
 func (lom *LOM) isMirror(dst *LOM) bool {
-	return lom.MirrorConf().Enabled &&
+	return (lom.MirrorConf().Enabled || lom.CopiesOverride() > 1) &&
 		lom.ObjName == dst.ObjName &&
 		lom.Bck().Equal(dst.Bck(), true /* must have same BID*/, true /* same backend */)
 }
@@ -79,9 +104,13 @@ func (lom *LOM) DelCopies(copiesFQN ...string) (err error) {
 	numCopies := lom.NumCopies()
 	// 1. Delete all copies from the metadata
 	for _, copyFQN := range copiesFQN {
-		if _, ok := lom.md.copies[copyFQN]; !ok {
+		mi, ok := lom.md.copies[copyFQN]
+		if !ok {
 			return fmt.Errorf("lom %s(num: %d): copy %s does not exist", lom, numCopies, copyFQN)
 		}
+		if lom.md.scar && copyFQN != lom.FQN {
+			lom.delSidecarAt(mi)
+		}
 		lom.delCopyMd(copyFQN)
 	}
 
@@ -201,11 +230,43 @@ func (lom *LOM) RestoreToLocation() (exists bool) {
 			FreeLOM(dst)
 		}
 	}
+	if !exists {
+		exists = lom.restoreReplaced(buf)
+	}
 	lom.Unlock(true)
 	slab.Free(buf)
 	return
 }
 
+// mountpath replacement: restore the object that's been written to the old mountpath
+// and is yet to be caught up with (see fs.ReplaceMpath)
+func (lom *LOM) restoreReplaced(buf []byte) bool {
+	r := fs.GetReplaced()
+	if r == nil {
+		return false
+	}
+	fqn := r.SrcFQN(lom.FQN)
+	if fqn == "" || cos.Stat(fqn) != nil {
+		return false
+	}
+	wfqn := fs.CSM.Gen(lom, fs.WorkfileType, fs.WorkfileRestore)
+	_, _, err := cos.CopyFile(fqn, wfqn, buf, cos.ChecksumNone)
+	if err == nil {
+		if err = fs.CopyXattrs(fqn, wfqn); err == nil {
+			err = lom.RenameToMain(wfqn)
+		}
+	}
+	if err != nil {
+		if errV := cos.RemoveFile(wfqn); errV != nil && !os.IsNotExist(errV) {
+			nlog.Errorln(errV)
+		}
+		nlog.Warningln("failed to restore", lom.Cname(), "from", r.Src.String()+":", err)
+		return false
+	}
+	lom.Uncache()
+	return lom.Load(true /*cache it*/, true /*locked*/) == nil
+}
+
 func (lom *LOM) _restore(fqn string, buf []byte) (dst *LOM, err error) {
 	src := lom.CloneMD(fqn)
 	defer FreeLOM(src)
@@ -376,6 +437,23 @@ func (lom *LOM) leastUtilCopy() (fqn string) {
 	return
 }
 
+// the least utilized copy other than the specified one (hedged reads); empty if none
+func (lom *LOM) LBGetOther(fqn string) (other string) {
+	var (
+		mpathUtils = fs.GetAllMpathUtils()
+		minUtil    = int64(101)
+	)
+	for copyFQN, copyMPI := range lom.GetCopies() {
+		if copyFQN == fqn {
+			continue
+		}
+		if util := mpathUtils.Get(copyMPI.Path); util < minUtil {
+			other, minUtil = copyFQN, util
+		}
+	}
+	return
+}
+
 // returns the least utilized mountpath that does _not_ have a copy of this `lom` yet
 // (compare with leastUtilCopy())
 func (lom *LOM) LeastUtilNoCopy() (mi *fs.Mountpath) {
@@ -425,13 +503,12 @@ func (lom *LOM) ToMpath() (mi *fs.Mountpath, isHrw bool) {
 	if lom.mi.Path != hrwMi.Path {
 		return hrwMi, true
 	}
-	mirror := lom.MirrorConf()
-	if !mirror.Enabled || mirror.Copies < 2 {
+	expCopies, gotCopies := lom.ExpCopies(), 0
+	if expCopies < 2 {
 		return
 	}
-	// count copies vs. configuration
+	// count copies vs. configuration (or per-object override)
 	// take into account mountpath flags but stop short of `fstat`-ing
-	expCopies, gotCopies := int(mirror.Copies), 0
 	for fqn, mpi := range lom.md.copies {
 		mpathInfo, ok := avail[mpi.Path]
 		if !ok || mpathInfo.IsAnySet(fs.FlagWaitingDD) {
@@ -0,0 +1,146 @@
+// Package core provides core metadata and in-cluster API
+/*
+ * Copyright (c) 2024, NVIDIA CORPORATION. All rights reserved.
+ */
+package core
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"syscall"
+
+	"github.com/NVIDIA/aistore/cmn/cos"
+	"github.com/NVIDIA/aistore/cmn/debug"
+	"github.com/NVIDIA/aistore/fs"
+)
+
+// Copy-on-write (COW) object references
+//
+// Cloning ais:// bucket (see apc.CopyBckMsg.COW) makes destination objects share data
+// with their respective sources by way of hard links - whenever the destination is local,
+// i.e., resides on the same target and the same mountpath. Otherwise, the object gets copied
+// as usual.
+//
+// The number of names sharing the data (`refs`) is stored in the LOM metadata (that is, in
+// the metadata of the shared inode) and triggers copy-on-write prior to any in-place
+// modification - persisting metadata, in particular. Overwriting (PUT, APPEND, etc.) always
+// goes through a work file and rename, and removal only drops the name - neither affects
+// other names.
+//
+// After removal, the stored count may become stale - the filesystem link count is
+// the source of truth that gets consulted upon the next write and, periodically,
+// by storage cleanup (see `SyncRefs`).
+
+var errNotRegular = errors.New("not a regular file")
+
+func (lom *LOM) HasRefs() bool { return lom.md.refs > 0 }
+
+func (lom *LOM) nlink() (uint64, error) { return nlink(lom.FQN) }
+
+func nlink(fqn string) (uint64, error) {
+	finfo, err := os.Stat(fqn)
+	if err != nil {
+		return 0, err
+	}
+	st, ok := finfo.Sys().(*syscall.Stat_t)
+	if !ok || !finfo.Mode().IsRegular() {
+		return 0, errNotRegular
+	}
+	return uint64(st.Nlink), nil //nolint:unconvert // (Nlink is uint16 on darwin)
+}
+
+// CloneRef makes `dst` reference the data of this (source) object.
+// Returns false when referencing is not possible - in which case the caller
+// is expected to fall back to a regular copy.
+// Both source and destination must be w-locked; source must be loaded.
+func (lom *LOM) CloneRef(dst *LOM) (bool, error) {
+	debug.Assert(lom.isLockedExcl(), lom.Cname())
+	if lom.mi.Path != dst.mi.Path || lom.HasCopies() || lom.IsChunked() {
+		return false, nil
+	}
+	if err := os.Link(lom.FQN, dst.FQN); err != nil {
+		if !os.IsNotExist(err) {
+			return false, nil // destination exists (overwrite), EXDEV, EMLINK, etc.
+		}
+		// slow path: create sub-directories
+		if err = dst._checkBdir(); err != nil {
+			return false, err
+		}
+		if err = cos.CreateDir(filepath.Dir(dst.FQN)); err != nil {
+			return false, err
+		}
+		if err = os.Link(lom.FQN, dst.FQN); err != nil {
+			return false, err
+		}
+	}
+	nlink, err := lom.nlink()
+	if err != nil {
+		cos.RemoveFile(dst.FQN)
+		return false, err
+	}
+	// NOTE: not calling lom.Persist() that'd break the reference we have just created
+	lom.md.refs = uint32(nlink)
+	buf := lom.pack()
+	err = fs.SetXattr(lom.FQN, XattrLOM, buf)
+	g.smm.Free(buf)
+	if err != nil {
+		cos.RemoveFile(dst.FQN)
+		lom.Uncache()
+		return false, err
+	}
+	lom.md.clearDirty()
+	lom.Recache()
+	dst.Uncache()
+	return true, nil
+}
+
+// breakRef materializes private copy of the (shared) data (copy-on-write);
+// must be called under w-lock prior to modifying the object in place.
+func (lom *LOM) breakRef() error {
+	nlink, err := lom.nlink()
+	if err != nil {
+		return err
+	}
+	if nlink > 1 {
+		var (
+			wfqn      = fs.CSM.Gen(lom, fs.WorkfileType, fs.WorkfileCOW)
+			buf, slab = g.pmm.Alloc()
+		)
+		_, _, err = cos.CopyFile(lom.FQN, wfqn, buf, cos.ChecksumNone)
+		slab.Free(buf)
+		if err == nil {
+			err = lom.RenameToMain(wfqn)
+		}
+		if err != nil {
+			cos.RemoveFile(wfqn)
+			return err
+		}
+	}
+	lom.md.refs = 0
+	return nil
+}
+
+// SyncRefs reconciles stored reference count with the actual number of hard links
+// (the count becomes stale when one of the names sharing the data gets removed).
+// Returns true if the stored count has changed. Caller must w-lock.
+func (lom *LOM) SyncRefs() (bool, error) {
+	debug.Assert(lom.isLockedExcl(), lom.Cname())
+	nlink, err := lom.nlink()
+	if err != nil {
+		return false, err
+	}
+	refs := uint32(nlink)
+	if refs <= 1 {
+		refs = 0
+	}
+	if refs == lom.md.refs {
+		return false, nil
+	}
+	lom.md.refs = refs
+	buf := lom.pack()
+	err = fs.SetXattr(lom.FQN, XattrLOM, buf)
+	g.smm.Free(buf)
+	lom.Uncache()
+	return err == nil, err
+}
@@ -0,0 +1,97 @@
+// Package core provides core metadata and in-cluster API
+/*
+ * Copyright (c) 2024, NVIDIA CORPORATION. All rights reserved.
+ */
+package core
+
+import (
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/cmn/cos"
+	"github.com/NVIDIA/aistore/cmn/debug"
+	"github.com/NVIDIA/aistore/cmn/nlog"
+	"github.com/NVIDIA/aistore/fs"
+)
+
+// Deduplication (see cmn.DedupConf)
+//
+// Each mountpath keeps (per bucket) an index of stored content: one dedup entry (fs.DedupType)
+// per unique checksum - a reference blob that shares data extents with the objects that have
+// this content. When a newly written object turns out to be a duplicate, the object gets
+// re-created as a clone (reflink) of the entry, and the work file is discarded. Otherwise,
+// the (newly written) object itself gets cloned to become the entry.
+//
+// Unlike hard links, clones are separate files, each with its own metadata (version, custom
+// metadata, atime, etc.); the data is shared by the filesystem and copied on write. On
+// filesystems that do not support cloning, objects are stored as usual.
+//
+// Removing an entry does not affect objects that share its data - only future PUTs of the
+// same content. Storage cleanup removes entries that were not used for a while.
+
+// DedupFinalize is the dedup variant of RenameFinalize: puts in place either the newly
+// written work file or, if the content is already stored, its clone. Returns true if deduplicated.
+// Must be called under w-lock; object's size and checksum must be already set.
+func (lom *LOM) DedupFinalize(wfqn string) (dedup bool, err error) {
+	debug.Assert(lom.isLockedExcl(), lom.Cname())
+	cksum := lom.Checksum()
+	debug.Assert(!cksum.IsEmpty(), lom.Cname())
+
+	efqn := lom.mi.MakePathFQN(lom.Bucket(), fs.DedupType, fs.DedupName(cksum))
+	if finfo, err := os.Stat(efqn); err == nil && finfo.Size() == lom.Lsize() {
+		dedup = lom.cloneEntry(efqn)
+	} else {
+		lom.addEntry(wfqn, efqn)
+	}
+	if !dedup {
+		return false, lom.RenameFinalize(wfqn)
+	}
+	if err := cos.RemoveFile(wfqn); err != nil && !os.IsNotExist(err) {
+		nlog.Errorln("failed to remove", wfqn, err)
+	}
+	return true, nil
+}
+
+// re-create the object as a clone of existing dedup entry
+func (lom *LOM) cloneEntry(efqn string) bool {
+	tfqn := fs.CSM.Gen(lom, fs.WorkfileType, fs.WorkfileDedup)
+	if err := fs.CloneFile(efqn, tfqn); err != nil {
+		return false // ENOTSUP, EXDEV, etc.
+	}
+	if err := lom.RenameFinalize(tfqn); err != nil {
+		cos.RemoveFile(tfqn)
+		return false
+	}
+	now := time.Now()
+	os.Chtimes(efqn, now, now) // (used - see IsDedupEntryUnused)
+	return true
+}
+
+// index new content: clone the work file (that is about to become the object) into a new entry
+func (lom *LOM) addEntry(wfqn, efqn string) {
+	tfqn := efqn + "." + cos.GenTie()
+	err := fs.CloneFile(wfqn, tfqn)
+	if os.IsNotExist(err) {
+		// (first entry with this prefix)
+		if err = cos.CreateDir(filepath.Dir(efqn)); err == nil {
+			err = fs.CloneFile(wfqn, tfqn)
+		}
+	}
+	if err == nil {
+		if err = os.Rename(tfqn, efqn); err != nil {
+			cos.RemoveFile(tfqn)
+		}
+	}
+	if err != nil && cmn.Rom.FastV(4, cos.SmoduleCore) {
+		nlog.Warningln("failed to index", lom.Cname(), "content:", err, "- storing as usual")
+	}
+}
+
+// IsDedupEntryUnused returns true if the dedup entry was not used (cloned) for
+// the specified time (see space cleanup).
+func IsDedupEntryUnused(fqn string, now int64, unused time.Duration) bool {
+	finfo, err := os.Stat(fqn)
+	return err == nil && finfo.ModTime().UnixNano()+int64(unused) < now
+}
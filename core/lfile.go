@@ -6,6 +6,7 @@ package core
 
 import (
 	"fmt"
+	"io"
 	"os"
 	"path/filepath"
 	"syscall"
@@ -13,6 +14,8 @@ import (
 	"github.com/NVIDIA/aistore/cmn"
 	"github.com/NVIDIA/aistore/cmn/cos"
 	"github.com/NVIDIA/aistore/cmn/debug"
+	"github.com/NVIDIA/aistore/cmn/nlog"
+	"github.com/NVIDIA/aistore/fs"
 )
 
 const (
@@ -109,6 +112,7 @@ func (lom *LOM) RemoveMain() (err error) {
 	if os.IsNotExist(err) {
 		err = nil
 	}
+	fs.Unlinked(lom.FQN)
 	return err
 }
 
@@ -121,16 +125,101 @@ func (lom *LOM) RemoveObj(force ...bool) (err error) {
 		return len(force) > 0 && force[0] && lom.isLockedRW()
 	})
 	lom.Uncache()
+	lom.uncacheRAM()
+	lom.delSidecar()
 	err = lom.RemoveMain()
 	for copyFQN := range lom.md.copies {
 		if erc := cos.RemoveFile(copyFQN); erc != nil && !os.IsNotExist(erc) && err == nil {
 			err = erc
 		}
+		fs.Unlinked(copyFQN)
 	}
 	lom.md.lid = 0
 	return err
 }
 
+//
+// soft delete (see fs.TrashType)
+//
+
+// TrashObj moves the main replica to `tfqn` along with its (persisted) metadata
+// and removes all copies, if any
+func (lom *LOM) TrashObj(tfqn string) (err error) {
+	debug.Assert(lom.isLockedExcl(), lom.Cname()) // caller must wlock
+	lom.Uncache()
+	lom.uncacheRAM()
+	for copyFQN, mi := range lom.md.copies {
+		if copyFQN == lom.FQN {
+			continue
+		}
+		if erc := cos.RemoveFile(copyFQN); erc != nil && !os.IsNotExist(erc) {
+			nlog.Errorln(erc)
+		}
+		fs.Unlinked(copyFQN)
+		if lom.md.scar {
+			lom.delSidecarAt(mi)
+		}
+	}
+	lom.md.copies = nil
+
+	// write-delayed and friends: the metadata may not be persisted yet
+	// (and custom metadata, if any, must travel along) - except copy-on-write
+	// where the shared xattr already carries the metadata and must stay intact
+	// (renaming does not break the reference; see lcow.go)
+	if lom.md.refs == 0 {
+		buf := lom.packx(false)
+		err = fs.SetXattr(lom.FQN, XattrLOM, buf)
+		g.smm.Free(buf)
+	}
+	if err == nil {
+		err = cos.Rename(lom.FQN, tfqn)
+		fs.Unlinked(lom.FQN)
+	}
+	lom.md.lid = 0
+	return err
+}
+
+// RestoreObj is the reverse of TrashObj whereby `tfqn` may reside on a different mountpath
+// (e.g., when mountpaths were added or removed after the object had been deleted)
+func (lom *LOM) RestoreObj(tfqn string, tmi *fs.Mountpath, buf []byte) error {
+	debug.Assert(lom.isLockedExcl(), lom.Cname()) // caller must wlock
+	if tmi.Path == lom.mi.Path {
+		return cos.Rename(tfqn, lom.FQN)
+	}
+	md, err := fs.GetXattr(tfqn, XattrLOM)
+	if err != nil {
+		return err
+	}
+	fh, err := os.Open(tfqn)
+	if err != nil {
+		return err
+	}
+	err = lom.RestoreFrom(fh, md, buf)
+	cos.Close(fh)
+	if err != nil {
+		return err
+	}
+	return cos.RemoveFile(tfqn)
+}
+
+// RestoreFrom restores soft-deleted content given its (packed) metadata `md` - in particular,
+// content that's been soft-deleted on a different target (see ais/tgttrash.go)
+func (lom *LOM) RestoreFrom(r io.Reader, md, buf []byte) error {
+	debug.Assert(lom.isLockedExcl(), lom.Cname()) // caller must wlock
+	wfqn := fs.CSM.Gen(lom, fs.WorkfileType, fs.WorkfileRestore)
+	if _, err := cos.SaveReader(wfqn, r, buf, cos.ChecksumNone, -1); err != nil {
+		return err
+	}
+	err := fs.SetXattr(wfqn, XattrLOM, md)
+	if err == nil {
+		err = lom.RenameToMain(wfqn)
+	}
+	if err != nil {
+		cos.RemoveFile(wfqn)
+	}
+	return err
+}
+
 //
 // rename
 //
@@ -148,6 +237,7 @@ func (lom *LOM) RenameFinalize(wfqn string) error {
 	if err := cos.Stat(bdir); err != nil {
 		return &errBdir{lom.Cname(), err}
 	}
+	lom.uncacheRAM() // overwrite
 	if err := lom.RenameToMain(wfqn); err != nil {
 		T.FSHC(err, lom.Mountpath(), wfqn)
 		return cmn.NewErrFailedTo(T, "finalize", lom.Cname(), err)
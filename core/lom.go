@@ -41,9 +41,15 @@ const (
 	RemoteDeletedDelCount = "remote.deleted.del.n"
 
 	// lcache stats
-	LcacheCollisionCount = "lcache.collision.n"
-	LcacheEvictedCount   = "lcache.evicted.n"
-	LcacheFlushColdCount = "lcache.flush.cold.n"
+	LcacheCollisionCount  = "lcache.collision.n"
+	LcacheEvictedCount    = "lcache.evicted.n"
+	LcacheFlushColdCount  = "lcache.flush.cold.n"
+	LcacheFlushAtimeCount = "lcache.flush.atime.n"
+
+	// RAM cache (small hot objects) stats
+	RAMCacheHitCount     = "ramcache.hit.n"
+	RAMCacheMissCount    = "ramcache.miss.n"
+	RAMCacheEvictedCount = "ramcache.evicted.n"
 )
 
 type (
@@ -53,6 +59,8 @@ type (
 		cmn.ObjAttrs
 		atimefs uint64 // (high bit `lomDirtyMask` | int64: atime)
 		lid     lomBID
+		refs    uint32 // number of names sharing the data (copy-on-write clones; see lcow.go)
+		scar    bool   // custom metadata is stored in the sidecar (see lom_sidecar.go)
 	}
 	LOM struct {
 		mi      *fs.Mountpath
@@ -72,6 +80,9 @@ type (
 		maxLmeta atomic.Int64
 		locker   nameLocker
 		lchk     lchk
+		atf      atimeFlusher
+		ramc     ramc
+		jrnl     wjournals
 	}
 )
 
@@ -104,9 +115,13 @@ func Tinit(t Target, tstats cos.StatsUpdater, runHK bool) {
 		g.tstats = tstats
 		g.pmm = t.PageMM()
 		g.smm = t.ByteMM()
+		g.ramc.init()
+		g.jrnl.init()
 	}
 	if runHK {
 		regLomCacheWithHK()
+		regAtimeWithHK()
+		regJournalWithHK()
 	}
 	for i := range recordSepa {
 		recdupSepa[i] = recordSepa[i]
@@ -119,6 +134,7 @@ func Term() {
 		time.Sleep(sleep)
 	}
 	g.lchk.evictOlder(termDuration)
+	g.jrnl.term()
 }
 
 /////////
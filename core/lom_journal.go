@@ -0,0 +1,487 @@
+// Package core provides core metadata and in-cluster API
+/*
+ * Copyright (c) 2024, NVIDIA CORPORATION. All rights reserved.
+ */
+package core
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/cmn/cos"
+	"github.com/NVIDIA/aistore/cmn/feat"
+	"github.com/NVIDIA/aistore/cmn/fname"
+	"github.com/NVIDIA/aistore/cmn/nlog"
+	"github.com/NVIDIA/aistore/fs"
+	"github.com/NVIDIA/aistore/hk"
+	"github.com/OneOfOne/xxhash"
+)
+
+// Write journal (see feat.JournalPUT):
+// - per-mountpath append-only log (fname.WriteJournal) of PUT finalization intents:
+//   prior to renaming the workfile into the object, the target appends (and fsyncs)
+//   the intent that includes the object's new metadata; once the metadata is persisted,
+//   appends "done" (no fsync);
+// - at startup, prior to joining the cluster, the target replays journals (ReplayJournals);
+//   for each pending (not "done") intent:
+//   * workfile exists (i.e., the rename did not happen) - remove it, the previous version stays;
+//   * object exists and its metadata matches the journaled one - nothing to do;
+//   * object exists without metadata, and its size and content checksum match - persist
+//     the journaled metadata and remove copies of the previous version;
+//   * otherwise (torn object) - remove it;
+// - the journaled checksum is what detects renamed but not yet flushed (e.g., zero-filled)
+//   content upon power loss; objects without one get fsync-ed prior to the rename
+//   (see ais/tgtobj.go);
+// - the journal gets rewritten with only pending intents once it grows beyond jrnlMaxSize;
+// - a mountpath journal that fails to open is retried upon housekeeping while PUTs
+//   proceed without journaling.
+
+const (
+	jrnlIntent byte = iota + 1
+	jrnlDone
+)
+
+const (
+	jrnlHdrLen  = cos.SizeofI32 + cos.SizeofI64 // length, checksum
+	jrnlMaxSize = 4 * cos.MiB
+	jrnlHKName  = "lom-journal" + hk.NameSuffix
+	jrnlHKIval  = time.Minute
+)
+
+// replay outcomes
+const (
+	JrnlRolledBack = "rolled-back"    // removed workfile
+	JrnlRolledFwd  = "rolled-forward" // persisted journaled metadata
+	JrnlRemoved    = "removed-torn"   // removed torn object
+)
+
+type (
+	wjournal struct {
+		fh      *os.File
+		pending map[uint64][]byte // intents in flight (framed records)
+		fpath   string
+		seq     uint64
+		size    int64
+		mu      sync.Mutex
+	}
+	wjournals struct {
+		m  map[string]*wjournal // by mountpath; nil value when failed to open (retried upon housekeeping)
+		mu sync.RWMutex
+	}
+	jintent struct {
+		wfqn string
+		fqn  string
+		md   []byte
+		seq  uint64
+	}
+	// reported by ReplayJournals
+	JrnlRepair struct {
+		FQN    string
+		Action string // one of the Jrnl* (above)
+		Err    error
+	}
+)
+
+var errJrnlShort = errors.New("write journal: short record")
+
+///////////////
+// wjournals //
+///////////////
+
+func (wjs *wjournals) init() {
+	wjs.m = make(map[string]*wjournal, 4)
+}
+
+func (wjs *wjournals) get(mi *fs.Mountpath) *wjournal {
+	wjs.mu.RLock()
+	j, ok := wjs.m[mi.Path]
+	wjs.mu.RUnlock()
+	if ok {
+		return j
+	}
+
+	wjs.mu.Lock()
+	if j, ok = wjs.m[mi.Path]; !ok {
+		fpath := filepath.Join(mi.Path, fname.WriteJournal)
+		fh, err := os.OpenFile(fpath, os.O_CREATE|os.O_WRONLY|os.O_APPEND, cos.PermRWR)
+		if err != nil {
+			nlog.Errorln("failed to open write journal", fpath, "err:", err, "- proceeding without")
+		} else {
+			j = &wjournal{fh: fh, fpath: fpath, pending: make(map[uint64][]byte, 16)}
+			j.seq = uint64(time.Now().UnixNano()) // (monotonic across restarts and reopens)
+			if finfo, err := fh.Stat(); err == nil {
+				j.size = finfo.Size()
+			}
+		}
+		wjs.m[mi.Path] = j
+	}
+	wjs.mu.Unlock()
+	return j
+}
+
+// close journals of detached (or disabled) mountpaths, unless busy; retry failed ones
+func (wjs *wjournals) housekeep(int64) time.Duration {
+	avail := fs.GetAvail()
+	wjs.mu.Lock()
+	for mpath, j := range wjs.m {
+		if _, ok := avail[mpath]; ok && j != nil {
+			continue
+		}
+		if j != nil && !j.close(false) {
+			continue
+		}
+		delete(wjs.m, mpath)
+	}
+	wjs.mu.Unlock()
+	return jrnlHKIval
+}
+
+func (wjs *wjournals) term() {
+	wjs.mu.Lock()
+	for mpath, j := range wjs.m {
+		if j != nil {
+			j.close(true)
+		}
+		delete(wjs.m, mpath)
+	}
+	wjs.mu.Unlock()
+}
+
+func regJournalWithHK() {
+	hk.Reg(jrnlHKName, g.jrnl.housekeep, jrnlHKIval)
+}
+
+//////////////
+// wjournal //
+//////////////
+
+func (j *wjournal) begin(in *jintent) (uint64, error) {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	if j.fh == nil {
+		return 0, nil // (closed)
+	}
+	j.seq++
+	in.seq = j.seq
+	rec := in.frame()
+	if _, err := j.fh.Write(rec); err != nil {
+		return 0, err
+	}
+	if err := j.fh.Sync(); err != nil {
+		return 0, err
+	}
+	j.size += int64(len(rec))
+	j.pending[in.seq] = rec
+	return in.seq, nil
+}
+
+func (j *wjournal) end(seq uint64) {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	if _, ok := j.pending[seq]; !ok || j.fh == nil {
+		return
+	}
+	delete(j.pending, seq)
+	rec := frameDone(seq)
+	if _, err := j.fh.Write(rec); err != nil {
+		nlog.Errorln("write journal", j.fpath, "err:", err)
+		return
+	}
+	j.size += int64(len(rec))
+	if j.size > jrnlMaxSize {
+		if err := j.rewrite(); err != nil {
+			nlog.Errorln("failed to rewrite", j.fpath, "err:", err)
+		}
+	}
+}
+
+// (under lock) new journal containing only pending intents
+func (j *wjournal) rewrite() error {
+	var (
+		size int64
+		tmp  = j.fpath + ".tmp"
+	)
+	fh, err := os.OpenFile(tmp, os.O_CREATE|os.O_TRUNC|os.O_WRONLY|os.O_APPEND, cos.PermRWR)
+	if err != nil {
+		return err
+	}
+	for _, rec := range j.pending {
+		if _, err = fh.Write(rec); err != nil {
+			break
+		}
+		size += int64(len(rec))
+	}
+	if err == nil {
+		err = fh.Sync()
+	}
+	if err == nil {
+		err = os.Rename(tmp, j.fpath)
+	}
+	if err != nil {
+		fh.Close()
+		os.Remove(tmp)
+		return err
+	}
+	j.fh.Close()
+	j.fh, j.size = fh, size
+	return nil
+}
+
+// returns false if busy (and not forced)
+func (j *wjournal) close(force bool) bool {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	if len(j.pending) > 0 && !force {
+		return false
+	}
+	if j.fh == nil {
+		return true
+	}
+	if err := j.fh.Close(); err != nil {
+		nlog.Errorln("failed to close", j.fpath, "err:", err)
+	}
+	j.fh = nil
+	if len(j.pending) == 0 {
+		os.Remove(j.fpath) // nothing to replay
+	}
+	return true
+}
+
+/////////////
+// jintent //
+/////////////
+
+func (in *jintent) frame() []byte {
+	size := 1 + cos.SizeofI64 + cos.PackedStrLen(in.wfqn) + cos.PackedStrLen(in.fqn) + cos.PackedBytesLen(in.md)
+	packer := cos.NewPacker(nil, size)
+	packer.WriteByte(jrnlIntent)
+	packer.WriteUint64(in.seq)
+	packer.WriteString(in.wfqn)
+	packer.WriteString(in.fqn)
+	packer.WriteBytes(in.md)
+	return _frame(packer.Bytes())
+}
+
+func frameDone(seq uint64) []byte {
+	packer := cos.NewPacker(nil, 1+cos.SizeofI64)
+	packer.WriteByte(jrnlDone)
+	packer.WriteUint64(seq)
+	return _frame(packer.Bytes())
+}
+
+// [length | checksum | body]
+func _frame(body []byte) []byte {
+	rec := make([]byte, jrnlHdrLen+len(body))
+	binary.BigEndian.PutUint32(rec, uint32(len(body)))
+	binary.BigEndian.PutUint64(rec[cos.SizeofI32:], xxhash.Checksum64S(body, cos.MLCG32))
+	copy(rec[jrnlHdrLen:], body)
+	return rec
+}
+
+// parse records up until the end or the first torn (or corrupted) one
+func parseJournal(b []byte) (intents map[uint64]*jintent, err error) {
+	intents = make(map[uint64]*jintent, 16)
+	for off := 0; off < len(b); {
+		if len(b)-off < jrnlHdrLen {
+			return intents, errJrnlShort
+		}
+		var (
+			l    = int(binary.BigEndian.Uint32(b[off:]))
+			ck   = binary.BigEndian.Uint64(b[off+cos.SizeofI32:])
+			body []byte
+		)
+		off += jrnlHdrLen
+		if len(b)-off < l {
+			return intents, errJrnlShort
+		}
+		body = b[off : off+l]
+		off += l
+		if xxhash.Checksum64S(body, cos.MLCG32) != ck {
+			return intents, cos.NewErrMetaCksum(ck, xxhash.Checksum64S(body, cos.MLCG32), "write journal record")
+		}
+		var (
+			unpacker = cos.NewUnpacker(body)
+			typ, _   = unpacker.ReadByte()
+			seq, e   = unpacker.ReadUint64()
+		)
+		if e != nil {
+			return intents, e
+		}
+		switch typ {
+		case jrnlIntent:
+			in := &jintent{seq: seq}
+			if in.wfqn, e = unpacker.ReadString(); e == nil {
+				if in.fqn, e = unpacker.ReadString(); e == nil {
+					in.md, e = unpacker.ReadBytes()
+				}
+			}
+			if e != nil {
+				return intents, e
+			}
+			intents[seq] = in
+		case jrnlDone:
+			delete(intents, seq)
+		default:
+			return intents, fmt.Errorf("write journal: unknown record type %d", typ)
+		}
+	}
+	return intents, nil
+}
+
+// ReplayJournals recovers interrupted PUT finalizations on all available mountpaths
+// (see above) and removes the journals; to be called once at startup
+func ReplayJournals() (repairs []*JrnlRepair) {
+	avail := fs.GetAvail()
+	for _, mi := range avail {
+		fpath := filepath.Join(mi.Path, fname.WriteJournal)
+		b, err := os.ReadFile(fpath)
+		if err != nil {
+			if !os.IsNotExist(err) {
+				nlog.Errorln("failed to read write journal", fpath, "err:", err)
+			}
+			continue
+		}
+		intents, err := parseJournal(b)
+		if err != nil {
+			nlog.Warningln(fpath, "[", err, "] - replaying up to the first bad record")
+		}
+		// the latest intent (if pending) per object
+		latest := make(map[string]uint64, len(intents))
+		for seq, in := range intents {
+			latest[in.fqn] = max(latest[in.fqn], seq)
+		}
+		for seq, in := range intents {
+			var r *JrnlRepair
+			if latest[in.fqn] == seq {
+				r = in.recover()
+			} else {
+				r = in.rollback() // superseded
+			}
+			if r != nil {
+				nlog.Warningln("write journal:", r.Action, r.FQN, "err:", r.Err)
+				repairs = append(repairs, r)
+			}
+		}
+		if err := os.Remove(fpath); err != nil {
+			nlog.Errorln("failed to remove write journal", fpath, "err:", err)
+		}
+	}
+	return repairs
+}
+
+func (in *jintent) rollback() *JrnlRepair {
+	if err := os.Remove(in.wfqn); err != nil {
+		if os.IsNotExist(err) {
+			return nil
+		}
+		return &JrnlRepair{FQN: in.wfqn, Action: JrnlRolledBack, Err: err}
+	}
+	return &JrnlRepair{FQN: in.wfqn, Action: JrnlRolledBack}
+}
+
+func (in *jintent) recover() *JrnlRepair {
+	if r := in.rollback(); r != nil {
+		return r // the rename did not happen
+	}
+	lom := AllocLOM("")
+	defer FreeLOM(lom)
+	if err := lom.InitFQN(in.fqn, nil); err != nil {
+		return &JrnlRepair{FQN: in.fqn, Action: JrnlRolledFwd, Err: err}
+	}
+	jmd := &lmeta{}
+	if err := jmd.unpack(in.md); err != nil {
+		return &JrnlRepair{FQN: in.fqn, Action: JrnlRolledFwd, Err: err}
+	}
+
+	lom.Lock(true)
+	defer lom.Unlock(true)
+	size, _, _, err := lom.Fstat(false /*get-atime*/)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil // (deleted)
+		}
+		return &JrnlRepair{FQN: in.fqn, Action: JrnlRolledFwd, Err: err}
+	}
+	if err := lom.FromFS(); err == nil {
+		if lom.md.Size != jmd.Size || !lom.md.Cksum.Equal(jmd.Cksum) || lom.md.Version() != jmd.Version() {
+			nlog.Warningln("write journal:", lom.Cname(), "metadata does not match the journaled one - skipping")
+		}
+		return nil // (persisted)
+	} else if !cmn.IsErrLmetaNotFound(err) && !cmn.IsErrLmetaCorrupted(err) {
+		return &JrnlRepair{FQN: in.fqn, Action: JrnlRolledFwd, Err: err}
+	}
+
+	lom.Uncache() // (previous version)
+	if size != jmd.Size {
+		return &JrnlRepair{FQN: in.fqn, Action: JrnlRemoved, Err: cos.RemoveFile(in.fqn)}
+	}
+	// renamed but possibly not flushed (JournalPUT does not imply FsyncPUT) - validate the content
+	if !jmd.Cksum.IsEmpty() {
+		cksum, err := lom.ComputeCksum(jmd.Cksum.Ty())
+		if err != nil {
+			return &JrnlRepair{FQN: in.fqn, Action: JrnlRolledFwd, Err: err}
+		}
+		if !jmd.Cksum.Equal(&cksum.Cksum) {
+			return &JrnlRepair{FQN: in.fqn, Action: JrnlRemoved, Err: cos.RemoveFile(in.fqn)}
+		}
+	}
+	// copies of the previous version
+	for cfqn := range jmd.copies {
+		if cfqn != in.fqn {
+			if err := cos.RemoveFile(cfqn); err != nil {
+				nlog.Warningln("write journal: failed to remove copy", cfqn, "err:", err)
+			}
+		}
+	}
+	jmd.copies, jmd.uname = nil, lom.md.uname
+	lom.md = *jmd
+	lom.SetAtimeUnix(time.Now().UnixNano())
+	buf := lom.pack()
+	err = fs.SetXattr(lom.FQN, XattrLOM, buf)
+	g.smm.Free(buf)
+	return &JrnlRepair{FQN: in.fqn, Action: JrnlRolledFwd, Err: err}
+}
+
+/////////
+// LOM //
+/////////
+
+// JournalBegin records PUT finalization intent (see feat.JournalPUT);
+// must be called under wlock with the object's metadata ready to be persisted;
+// returns zero when not journaling
+func (lom *LOM) JournalBegin(wfqn string) (uint64, error) {
+	if !lom.IsFeatureSet(feat.JournalPUT) {
+		return 0, nil
+	}
+	j := g.jrnl.get(lom.mi)
+	if j == nil {
+		return 0, nil
+	}
+	md := lom.md
+	md.refs = 0
+	buf := md.pack(g.maxLmeta.Load(), false /*scar*/)
+	in := &jintent{wfqn: wfqn, fqn: lom.FQN, md: buf}
+	seq, err := j.begin(in)
+	g.smm.Free(buf)
+	if err != nil {
+		T.FSHC(err, lom.Mountpath(), j.fpath)
+		return 0, cmn.NewErrFailedTo(T, "journal", lom.Cname(), err)
+	}
+	return seq, nil
+}
+
+// JournalEnd marks the intent (see JournalBegin) as done
+func (lom *LOM) JournalEnd(seq uint64) {
+	if seq == 0 {
+		return
+	}
+	if j := g.jrnl.get(lom.mi); j != nil {
+		j.end(seq)
+	}
+}
@@ -0,0 +1,165 @@
+// Package core_test provides tests for cluster package
+/*
+ * Copyright (c) 2024, NVIDIA CORPORATION. All rights reserved.
+ */
+package core_test
+
+import (
+	"os"
+
+	"github.com/NVIDIA/aistore/api/apc"
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/cmn/cos"
+	"github.com/NVIDIA/aistore/cmn/feat"
+	"github.com/NVIDIA/aistore/core"
+	"github.com/NVIDIA/aistore/core/meta"
+	"github.com/NVIDIA/aistore/core/mock"
+	"github.com/NVIDIA/aistore/fs"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("LOM write journal", func() {
+	const (
+		tmpDir     = "/tmp/lom_journal_test"
+		jrnlMpath  = tmpDir + "/mpath"
+		bucketName = "LOM_TEST_Journal"
+		bucketNone = "LOM_TEST_NoJournal"
+		objName    = "journal/test-obj"
+		oldSize    = 123
+		newSize    = 456
+	)
+
+	bck := cmn.Bck{Name: bucketName, Provider: apc.AIS, Ns: cmn.NsGlobal}
+
+	fs.CSM.Reg(fs.ObjectType, &fs.ObjectContentResolver{}, true)
+	fs.CSM.Reg(fs.WorkfileType, &fs.WorkfileContentResolver{}, true)
+
+	var (
+		mix     = fs.Mountpath{Path: jrnlMpath}
+		fqn     = mix.MakePathFQN(&bck, fs.ObjectType, objName)
+		wfqn    = mix.MakePathFQN(&bck, fs.WorkfileType, objName+".put")
+		bmdMock = mock.NewBaseBownerMock(
+			meta.NewBck(
+				bucketName, apc.AIS, cmn.NsGlobal,
+				&cmn.Bprops{
+					Cksum:    cmn.CksumConf{Type: cos.ChecksumXXHash},
+					Features: feat.JournalPUT,
+					BID:      301,
+				},
+			),
+			meta.NewBck(
+				bucketNone, apc.AIS, cmn.NsGlobal,
+				&cmn.Bprops{Cksum: cmn.CksumConf{Type: cos.ChecksumXXHash}, BID: 302},
+			),
+		)
+	)
+
+	BeforeEach(func() {
+		_ = cos.CreateDir(jrnlMpath)
+		_, _ = fs.Add(jrnlMpath, "daeID")
+		_ = mock.NewTarget(bmdMock)
+
+		filePut(fqn, oldSize) // previous version
+	})
+
+	AfterEach(func() {
+		_, _ = fs.Remove(jrnlMpath)
+		_ = os.RemoveAll(tmpDir)
+	})
+
+	// new version: workfile and metadata (not persisted) with the intent journaled
+	begin := func() (*core.LOM, uint64) {
+		lom := NewBasicLom(fqn)
+		Expect(lom.Load(false, false)).NotTo(HaveOccurred())
+		createTestFile(wfqn, newSize)
+		lom.SetSize(newSize)
+		lom.SetCksum(cos.NewCksum(cos.ChecksumXXHash, getTestFileHash(wfqn)))
+		lom.IncVersion()
+		seq, err := lom.JournalBegin(wfqn)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(seq).NotTo(BeZero())
+		return lom, seq
+	}
+
+	load := func() *core.LOM {
+		lom := NewBasicLom(fqn)
+		Expect(lom.Load(false, false)).NotTo(HaveOccurred())
+		return lom
+	}
+
+	It("should roll back when the workfile was not renamed", func() {
+		begin()
+
+		repairs := core.ReplayJournals()
+		Expect(repairs).To(HaveLen(1))
+		Expect(repairs[0].Action).To(Equal(core.JrnlRolledBack))
+		Expect(repairs[0].Err).NotTo(HaveOccurred())
+		Expect(wfqn).NotTo(BeAnExistingFile())
+
+		lom := load()
+		Expect(lom.Lsize()).To(BeEquivalentTo(oldSize))
+		Expect(lom.Version()).To(Equal("1"))
+	})
+
+	It("should roll forward when the metadata was not persisted", func() {
+		begin()
+		Expect(os.Rename(wfqn, fqn)).NotTo(HaveOccurred())
+
+		repairs := core.ReplayJournals()
+		Expect(repairs).To(HaveLen(1))
+		Expect(repairs[0].Action).To(Equal(core.JrnlRolledFwd))
+		Expect(repairs[0].Err).NotTo(HaveOccurred())
+
+		lom := load()
+		Expect(lom.Lsize()).To(BeEquivalentTo(newSize))
+		Expect(lom.Version()).To(Equal("2"))
+	})
+
+	It("should remove torn object", func() {
+		begin()
+		Expect(os.Rename(wfqn, fqn)).NotTo(HaveOccurred())
+		Expect(os.Truncate(fqn, newSize/2)).NotTo(HaveOccurred())
+
+		repairs := core.ReplayJournals()
+		Expect(repairs).To(HaveLen(1))
+		Expect(repairs[0].Action).To(Equal(core.JrnlRemoved))
+		Expect(repairs[0].Err).NotTo(HaveOccurred())
+		Expect(fqn).NotTo(BeAnExistingFile())
+	})
+
+	It("should remove object with torn content", func() {
+		begin()
+		Expect(os.Rename(wfqn, fqn)).NotTo(HaveOccurred())
+		// renamed but not flushed: right size, zero-filled
+		Expect(os.Truncate(fqn, 0)).NotTo(HaveOccurred())
+		Expect(os.Truncate(fqn, newSize)).NotTo(HaveOccurred())
+
+		repairs := core.ReplayJournals()
+		Expect(repairs).To(HaveLen(1))
+		Expect(repairs[0].Action).To(Equal(core.JrnlRemoved))
+		Expect(repairs[0].Err).NotTo(HaveOccurred())
+		Expect(fqn).NotTo(BeAnExistingFile())
+	})
+
+	It("should not replay completed PUTs", func() {
+		lom, seq := begin()
+		Expect(os.Rename(wfqn, fqn)).NotTo(HaveOccurred())
+		Expect(persist(lom)).NotTo(HaveOccurred())
+		lom.JournalEnd(seq)
+
+		Expect(core.ReplayJournals()).To(BeEmpty())
+
+		lom = load()
+		Expect(lom.Lsize()).To(BeEquivalentTo(newSize))
+		Expect(lom.Version()).To(Equal("2"))
+	})
+
+	It("should not journal when the feature is not set", func() {
+		bck := cmn.Bck{Name: bucketNone, Provider: apc.AIS, Ns: cmn.NsGlobal}
+		lom := filePut(mix.MakePathFQN(&bck, fs.ObjectType, objName), oldSize)
+		seq, err := lom.JournalBegin(wfqn)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(seq).To(BeZero())
+	})
+})
@@ -0,0 +1,164 @@
+// Package core provides core metadata and in-cluster API
+/*
+ * Copyright (c) 2024, NVIDIA CORPORATION. All rights reserved.
+ */
+package core
+
+import (
+	"os"
+	"time"
+
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/cmn/cos"
+	"github.com/NVIDIA/aistore/cmn/debug"
+	"github.com/NVIDIA/aistore/cmn/feat"
+	"github.com/NVIDIA/aistore/cmn/jsp"
+	"github.com/NVIDIA/aistore/cmn/nlog"
+	"github.com/NVIDIA/aistore/fs"
+)
+
+// Custom metadata sidecar (see feat.CustomMDSidecar):
+// - when enabled for a given bucket, objects' custom metadata is stored on disk, in a separate
+//   (fs.CustomMDType) file per object replica, rather than in the object's xattr that, in turn,
+//   only carries the corresponding (packedSidecar) marker;
+// - sidecars are regular files (checksummed, written atomically) that live next to the objects
+//   on the same mountpath, and therefore scale with the filesystem - nothing is kept in memory;
+// - each replica (copy) has its own sidecar on its own mountpath;
+// - the sidecar is always written first, and the xattr (with the marker) next - a crash in
+//   between leaves an orphaned sidecar that storage cleanup eventually removes (see
+//   IsCustomMDOrphan); the marker, on the other hand, never refers to a missing sidecar;
+// - failure to write the sidecar falls back to xattr;
+// - loading is transparent (driven by the marker) and does not depend on the current
+//   bucket configuration; to move existing objects from one storage mode to another,
+//   run apc.ActMigrateCustomMD.
+
+const scarMetaver = 1
+
+func scarFQN(mi *fs.Mountpath, lom *LOM) string {
+	return mi.MakePathFQN(lom.Bucket(), fs.CustomMDType, lom.ObjName)
+}
+
+/////////
+// LOM //
+/////////
+
+func (lom *LOM) useSidecar() bool {
+	return lom.Bprops() != nil && lom.IsFeatureSet(feat.CustomMDSidecar)
+}
+
+// store custom metadata in the sidecar (or not), and update the in-memory marker
+// that is then used to pack lmeta
+func (lom *LOM) prepSidecar(scar bool) bool {
+	custom := lom.md.GetCustomMD()
+	scar = scar && len(custom) > 0
+	if scar {
+		if err := lom.putSidecar(lom.mi); err != nil {
+			nlog.Warningln(lom.Cname()+":", err, "- storing custom metadata in xattr")
+			scar = false
+		}
+	}
+	if !scar && lom.md.scar {
+		lom.delSidecarAt(lom.mi) // (no longer used)
+	}
+	lom.md.scar = scar
+	return scar
+}
+
+func (lom *LOM) putSidecar(mi *fs.Mountpath) error {
+	if mi == nil {
+		return cos.NewErrNotFound(T, "custom metadata sidecar mountpath")
+	}
+	return jsp.Save(scarFQN(mi, lom), lom.md.GetCustomMD(), jsp.CksumSign(scarMetaver), nil)
+}
+
+// (upon loading lmeta with the packedSidecar marker)
+func (lom *LOM) loadSidecar(md *lmeta) {
+	var custom cos.StrKVs
+	if _, err := jsp.Load(scarFQN(lom.mi, lom), &custom, jsp.CksumSign(scarMetaver)); err != nil {
+		// keep the object accessible, sans custom metadata
+		nlog.Errorln(lom.Cname()+": failed to load custom metadata:", err)
+		return
+	}
+	md.SetCustomMD(custom)
+}
+
+func (lom *LOM) delSidecarAt(mi *fs.Mountpath) {
+	if mi == nil {
+		return
+	}
+	if err := cos.RemoveFile(scarFQN(mi, lom)); err != nil && !os.IsNotExist(err) {
+		nlog.Warningln("failed to delete", lom.Cname(), "custom metadata:", err)
+	}
+}
+
+func (lom *LOM) delSidecar() {
+	if !lom.md.scar {
+		return
+	}
+	lom.delSidecarAt(lom.mi)
+	for copyFQN, mi := range lom.md.copies {
+		if copyFQN != lom.FQN {
+			lom.delSidecarAt(mi)
+		}
+	}
+}
+
+// MigrateCustomMD (re)writes object's metadata to conform to the bucket's current
+// custom-metadata storage mode (see feat.CustomMDSidecar); returns true if migrated.
+// Caller must load the object and take wlock.
+func (lom *LOM) MigrateCustomMD() (bool, error) {
+	debug.Assert(lom.isLockedExcl(), lom.Cname())
+	want := lom.useSidecar() && len(lom.md.GetCustomMD()) > 0
+	if want == lom.md.scar {
+		return false, nil
+	}
+	if lom.md.refs > 0 {
+		if err := lom.breakRef(); err != nil {
+			return false, err
+		}
+	}
+	buf := lom.pack()
+	err := fs.SetXattr(lom.FQN, XattrLOM, buf)
+	g.smm.Free(buf)
+	if err != nil {
+		lom.Uncache()
+		T.FSHC(err, lom.Mountpath(), lom.FQN)
+		return false, err
+	}
+	if len(lom.md.copies) > 1 {
+		if copyFQN, err := lom.persistMdOnCopies(); err != nil {
+			lom.Uncache()
+			return false, cmn.NewErrFailedTo(T, "migrate custom metadata", copyFQN, err)
+		}
+	}
+	lom.md.clearDirty()
+	lom.Recache()
+	return lom.md.scar == want, nil
+}
+
+// IsCustomMDOrphan returns true if a given sidecar (fs.CustomMDType) is older than `age`
+// and its object replica either does not exist or does not refer to it (see space cleanup).
+func IsCustomMDOrphan(fqn string, bck *cmn.Bck, now int64, age time.Duration) bool {
+	finfo, err := os.Stat(fqn)
+	if err != nil || finfo.ModTime().UnixNano()+int64(age) > now {
+		return false
+	}
+	var parsed fs.ParsedFQN
+	if err := parsed.Init(fqn); err != nil {
+		return false
+	}
+	ofqn := parsed.Mountpath.MakePathFQN(bck, fs.ObjectType, parsed.ObjName)
+	if err := cos.Stat(ofqn); err != nil {
+		return os.IsNotExist(err)
+	}
+	lom := AllocLOM(parsed.ObjName)
+	defer FreeLOM(lom)
+	if err := lom.InitFQN(ofqn, bck); err != nil {
+		return false
+	}
+	md, err := lom.lmfs(false /*populate*/)
+	if err != nil {
+		return cmn.IsErrLmetaNotFound(err)
+	}
+	return !md.scar
+}
@@ -21,6 +21,8 @@ import (
 	"github.com/NVIDIA/aistore/core/meta"
 	"github.com/NVIDIA/aistore/core/mock"
 	"github.com/NVIDIA/aistore/fs"
+	"github.com/NVIDIA/aistore/ios"
+	"github.com/NVIDIA/aistore/memsys"
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 )
@@ -38,6 +40,8 @@ var _ = Describe("LOM", func() {
 		bucketCloudB = "LOM_TEST_Cloud_B"
 
 		sameBucketName = "LOM_TEST_Local_and_Cloud"
+
+		bucketAtime = "LOM_TEST_Local_Atime"
 	)
 
 	var (
@@ -94,6 +98,8 @@ var _ = Describe("LOM", func() {
 		meta.NewBck(bucketCloudA, apc.AWS, cmn.NsGlobal, &cmn.Bprops{BID: 5}),
 		meta.NewBck(bucketCloudB, apc.AWS, cmn.NsGlobal, &cmn.Bprops{BID: 6}),
 		meta.NewBck(sameBucketName, apc.AWS, cmn.NsGlobal, &cmn.Bprops{BID: 7}),
+		meta.NewBck(bucketAtime, apc.AIS, cmn.NsGlobal,
+			&cmn.Bprops{Atime: cmn.AtimeConf{Policy: cmn.AtimePrecise}, BID: 8}),
 	)
 
 	BeforeEach(func() {
@@ -323,6 +329,35 @@ var _ = Describe("LOM", func() {
 
 				Expect(time.Unix(0, lom.AtimeUnix())).To(BeEquivalentTo(desiredAtime))
 			})
+			DescribeTable("should update atime according to bucket policy",
+				func(bckName string, precise bool) {
+					bck := cmn.Bck{Name: bckName, Provider: apc.AIS, Ns: cmn.NsGlobal}
+					localFQN := mis[0].MakePathFQN(&bck, fs.ObjectType, testObjectName)
+					createTestFile(localFQN, 0)
+					Expect(os.Chtimes(localFQN, desiredAtime, desiredAtime)).ShouldNot(HaveOccurred())
+
+					lom := &core.LOM{}
+					Expect(lom.InitFQN(localFQN, nil)).NotTo(HaveOccurred())
+					lom.AcquireAtimefs()
+					Expect(lom.Persist()).NotTo(HaveOccurred())
+					Expect(lom.Load(false, false)).NotTo(HaveOccurred())
+
+					accessed := desiredAtime.Add(time.Hour)
+					lom.UpdateAtime(accessed.UnixNano())
+					Expect(lom.Atime()).To(BeEquivalentTo(accessed))
+
+					finfo, err := os.Stat(localFQN)
+					Expect(err).NotTo(HaveOccurred())
+					if precise {
+						Expect(ios.GetATime(finfo)).To(BeEquivalentTo(accessed))
+					} else {
+						Expect(ios.GetATime(finfo)).To(BeEquivalentTo(desiredAtime))
+					}
+					lom.Uncache()
+				},
+				Entry("lazy (default)", bucketLocalA, false),
+				Entry("precise", bucketAtime, true),
+			)
 		})
 
 		Describe("checksum", func() {
@@ -995,6 +1030,73 @@ var _ = Describe("LOM", func() {
 		})
 	})
 
+	Describe("RAM cache", func() {
+		const size = 4 * cos.KiB
+		testObject := "foldr/test-obj-ramc"
+		localFQN := mis[0].MakePathFQN(&localBckA, fs.ObjectType, testObject)
+
+		BeforeEach(func() {
+			config := cmn.GCO.BeginUpdate()
+			config.Memsys.RAMCacheSize = 2 * size
+			cmn.GCO.CommitUpdate(config)
+		})
+		AfterEach(func() {
+			config := cmn.GCO.BeginUpdate()
+			config.Memsys.RAMCacheSize = 0
+			cmn.GCO.CommitUpdate(config)
+		})
+
+		cacheIt := func(lom *core.LOM) *core.RAMCached {
+			sgl := memsys.PageMM().NewSGL(size)
+			fh, err := os.Open(lom.FQN)
+			Expect(err).NotTo(HaveOccurred())
+			_, err = sgl.ReadFrom(fh)
+			fh.Close()
+			Expect(err).NotTo(HaveOccurred())
+			return core.RAMCachePut(lom, sgl)
+		}
+
+		It("should serve cached content and invalidate upon overwrite and delete", func() {
+			lom := filePut(localFQN, size)
+			Expect(core.RAMCacheGet(lom)).To(BeNil())
+
+			rc := cacheIt(lom)
+			rc.Release()
+			rc = core.RAMCacheGet(lom)
+			Expect(rc).NotTo(BeNil())
+			b, err := io.ReadAll(rc.NewReader())
+			Expect(err).NotTo(HaveOccurred())
+			Expect(int64(len(b))).To(Equal(rc.Size()))
+			rc.Release()
+
+			// new version
+			lom.IncVersion()
+			Expect(core.RAMCacheGet(lom)).To(BeNil())
+
+			rc = cacheIt(lom)
+			rc.Release()
+			lom.Lock(true)
+			Expect(lom.RemoveObj()).NotTo(HaveOccurred())
+			lom.Unlock(true)
+			Expect(core.RAMCacheGet(lom)).To(BeNil())
+		})
+
+		It("should evict least recently used", func() {
+			var loms [3]*core.LOM
+			for i := range loms {
+				fqn := mis[0].MakePathFQN(&localBckA, fs.ObjectType, testObject+strconv.Itoa(i))
+				loms[i] = filePut(fqn, size)
+				cacheIt(loms[i]).Release()
+			}
+			Expect(core.RAMCacheGet(loms[0])).To(BeNil())
+			for _, lom := range loms[1:] {
+				rc := core.RAMCacheGet(lom)
+				Expect(rc).NotTo(BeNil())
+				rc.Release()
+			}
+		})
+	})
+
 	Describe("local and cloud bucket with the same name", func() {
 		It("should have different fqn", func() {
 			testObject := "foldr/test-obj.ext"
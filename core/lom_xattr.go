@@ -76,6 +76,8 @@ const (
 	packedCustom
 	packedNum
 	packedChunk
+	packedRefs
+	packedSidecar // custom md is stored in the sidecar (see lom_sidecar.go)
 )
 
 // packing format: separators
@@ -172,6 +174,9 @@ func (lom *LOM) unpack(b []byte, mdSize int64, populate bool) (md *lmeta, err er
 	err = md.unpack(b)
 	if err == nil {
 		_mdsize(size, mdSize)
+		if md.scar {
+			lom.loadSidecar(md)
+		}
 	} else {
 		err = cmn.NewErrLmetaCorrupted(err)
 	}
@@ -187,6 +192,11 @@ func (lom *LOM) PersistMain() (err error) {
 		return
 	}
 	// write-immediate (default)
+	if lom.md.refs > 0 {
+		if err = lom.breakRef(); err != nil {
+			return err
+		}
+	}
 	buf := lom.pack()
 	if err = fs.SetXattr(lom.FQN, XattrLOM, buf); err != nil {
 		lom.Uncache()
@@ -215,6 +225,11 @@ func (lom *LOM) Persist() (err error) {
 		return
 	}
 
+	if lom.md.refs > 0 {
+		if err = lom.breakRef(); err != nil {
+			return err
+		}
+	}
 	buf := lom.pack()
 	if err = fs.SetXattr(lom.FQN, XattrLOM, buf); err != nil {
 		lom.Uncache()
@@ -233,24 +248,40 @@ func (lom *LOM) Persist() (err error) {
 }
 
 func (lom *LOM) persistMdOnCopies() (copyFQN string, err error) {
-	buf := lom.pack()
+	var (
+		mi   *fs.Mountpath
+		ibuf []byte // (custom md in xattr when copy's sidecar is unavailable)
+		buf  = lom.pack()
+	)
 	// replicate across copies
-	for copyFQN = range lom.md.copies {
+	for copyFQN, mi = range lom.md.copies {
 		if copyFQN == lom.FQN {
 			continue
 		}
-		if err = fs.SetXattr(copyFQN, XattrLOM, buf); err != nil {
+		b := buf
+		if lom.md.scar && lom.putSidecar(mi) != nil {
+			if ibuf == nil {
+				ibuf = lom.md.pack(g.maxLmeta.Load(), false)
+			}
+			b = ibuf
+		}
+		if err = fs.SetXattr(copyFQN, XattrLOM, b); err != nil {
 			break
 		}
 	}
 	g.smm.Free(buf)
+	if ibuf != nil {
+		g.smm.Free(ibuf)
+	}
 	return
 }
 
 // NOTE: not clearing dirty flag as the caller will uncache anyway
 func (lom *LOM) flushCold(md *lmeta, atime time.Time) {
-	if err := lom.flushAtime(atime); err != nil {
-		return
+	if !lom.AtimeConf().IsOff() {
+		if err := lom.flushAtime(atime); err != nil {
+			return
+		}
 	}
 	if !md.isDirty() || lom.WritePolicy() == apc.WriteNever {
 		return
@@ -259,6 +290,11 @@ func (lom *LOM) flushCold(md *lmeta, atime time.Time) {
 	if err := lom.syncMetaWithCopies(); err != nil {
 		return
 	}
+	if md.refs > 0 {
+		if err := lom.breakRef(); err != nil {
+			return
+		}
+	}
 	buf := lom.pack()
 	if err := fs.SetXattr(lom.FQN, XattrLOM, buf); err != nil {
 		T.FSHC(err, lom.Mountpath(), lom.FQN)
@@ -274,9 +310,13 @@ func (lom *LOM) flushAtime(atime time.Time) error {
 	return os.Chtimes(lom.FQN, atime, mtime)
 }
 
-func (lom *LOM) pack() (buf []byte) {
+func (lom *LOM) pack() []byte { return lom.packx(lom.useSidecar()) }
+
+// scar: store custom md in the sidecar (see lom_sidecar.go)
+func (lom *LOM) packx(scar bool) (buf []byte) {
 	lmsize := g.maxLmeta.Load()
-	buf = lom.md.pack(lmsize)
+	scar = lom.prepSidecar(scar)
+	buf = lom.md.pack(lmsize, scar)
 	size := int64(len(buf))
 	debug.Assert(size <= xattrMaxSize)
 	_mdsize(size, lmsize)
@@ -323,6 +363,7 @@ func (md *lmeta) unpack(buf []byte) error {
 	if len(buf) < prefLen {
 		return fmt.Errorf("%s: too short (%d)", badLmeta, len(buf))
 	}
+	md.scar = false
 	if buf[0] != cmn.MetaverLOM {
 		return fmt.Errorf("%s: unknown version %d", badLmeta, buf[0])
 	}
@@ -401,6 +442,10 @@ func (md *lmeta) unpack(buf []byte) error {
 				}
 				md.copies[copyFQN] = mpathInfo
 			}
+		case packedRefs:
+			md.refs = binary.BigEndian.Uint32(record[cos.SizeofI16:])
+		case packedSidecar:
+			md.scar = true
 		case packedCustom:
 			val := string(record[cos.SizeofI16:])
 			entries := strings.Split(val, customSepa)
@@ -423,7 +468,7 @@ func (md *lmeta) unpack(buf []byte) error {
 	return nil
 }
 
-func (md *lmeta) pack(mdSize int64) (buf []byte) {
+func (md *lmeta) pack(mdSize int64, scar bool) (buf []byte) {
 	buf, _ = g.smm.AllocSize(mdSize)
 	buf = buf[:prefLen] // hold it for md-xattr checksum (below)
 
@@ -449,11 +494,23 @@ func (md *lmeta) pack(mdSize int64) (buf []byte) {
 		buf = _packCopies(buf, md.copies)
 	}
 
+	// copy-on-write references
+	if md.refs > 0 {
+		var b4 [cos.SizeofI32]byte
+		binary.BigEndian.PutUint32(b4[:], md.refs)
+		buf = g.smm.Append(buf, recordSepa)
+		buf = _packRecord(buf, packedRefs, cos.UnsafeS(b4[:]), false)
+	}
+
 	// custom md
 	if custom := md.GetCustomMD(); len(custom) > 0 {
 		buf = g.smm.Append(buf, recordSepa)
-		buf = _packRecord(buf, packedCustom, "", false)
-		buf = _packCustom(buf, custom)
+		if scar {
+			buf = _packRecord(buf, packedSidecar, "", false)
+		} else {
+			buf = _packRecord(buf, packedCustom, "", false)
+			buf = _packCustom(buf, custom)
+		}
 	}
 
 	// checksum, prepend, and return
@@ -5,11 +5,14 @@
 package core_test
 
 import (
+	"fmt"
 	"os"
+	"time"
 
 	"github.com/NVIDIA/aistore/api/apc"
 	"github.com/NVIDIA/aistore/cmn"
 	"github.com/NVIDIA/aistore/cmn/cos"
+	"github.com/NVIDIA/aistore/cmn/feat"
 	"github.com/NVIDIA/aistore/core"
 	"github.com/NVIDIA/aistore/core/meta"
 	"github.com/NVIDIA/aistore/core/mock"
@@ -26,13 +29,16 @@ var _ = Describe("LOM Xattributes", func() {
 
 		bucketLocal  = "LOM_TEST_Local"
 		bucketCached = "LOM_TEST_Cached"
+		bucketScar   = "LOM_TEST_Sidecar"
 	)
 
 	localBck := cmn.Bck{Name: bucketLocal, Provider: apc.AIS, Ns: cmn.NsGlobal}
 	cachedBck := cmn.Bck{Name: bucketCached, Provider: apc.AIS, Ns: cmn.NsGlobal}
+	scarBck := cmn.Bck{Name: bucketScar, Provider: apc.AIS, Ns: cmn.NsGlobal}
 
 	fs.CSM.Reg(fs.ObjectType, &fs.ObjectContentResolver{}, true)
 	fs.CSM.Reg(fs.WorkfileType, &fs.WorkfileContentResolver{}, true)
+	fs.CSM.Reg(fs.CustomMDType, &fs.CustomMDContentResolver{}, true)
 
 	var (
 		copyMpathInfo *fs.Mountpath
@@ -50,6 +56,14 @@ var _ = Describe("LOM Xattributes", func() {
 					BID:         202,
 				},
 			),
+			meta.NewBck(
+				bucketScar, apc.AIS, cmn.NsGlobal,
+				&cmn.Bprops{
+					Cksum:    cmn.CksumConf{Type: cos.ChecksumXXHash},
+					Features: feat.CustomMDSidecar,
+					BID:      203,
+				},
+			),
 		)
 	)
 
@@ -80,6 +94,8 @@ var _ = Describe("LOM Xattributes", func() {
 			// Bucket needs to have checksum enabled
 			localFQN  = mix.MakePathFQN(&localBck, fs.ObjectType, testObjectName+".qqq")
 			cachedFQN = mix.MakePathFQN(&cachedBck, fs.ObjectType, testObjectName)
+			scarFQN   = mix.MakePathFQN(&scarBck, fs.ObjectType, testObjectName)
+			scarMdFQN = mix.MakePathFQN(&scarBck, fs.CustomMDType, testObjectName)
 
 			fqns []string
 		)
@@ -257,6 +273,225 @@ var _ = Describe("LOM Xattributes", func() {
 			})
 		})
 
+		Describe("Sidecar", func() {
+			It("should store custom metadata in the sidecar", func() {
+				custom := cos.StrKVs{
+					cmn.SourceObjMD: apc.GCP,
+					cmn.ETag:        "etag-in-sidecar",
+					cmn.CRC32CObjMD: "crc32",
+				}
+				lom := filePut(scarFQN, testFileSize)
+				lom.Lock(true)
+				defer lom.Unlock(true)
+				lom.SetCustomMD(custom)
+				Expect(persist(lom)).NotTo(HaveOccurred())
+
+				// xattr carries the marker but not the values
+				b, err := fs.GetXattr(scarFQN, core.XattrLOM)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(string(b)).NotTo(ContainSubstring("etag-in-sidecar"))
+				Expect(scarMdFQN).To(BeARegularFile())
+				now := time.Now().Add(time.Hour).UnixNano()
+				Expect(core.IsCustomMDOrphan(scarMdFQN, &scarBck, now, 0)).To(BeFalse())
+
+				lom.UncacheUnless()
+				newLom := NewBasicLom(scarFQN)
+				Expect(newLom.LoadMetaFromFS()).NotTo(HaveOccurred())
+				Expect(newLom.GetCustomMD()).To(BeEquivalentTo(custom))
+
+				// already conforms
+				migrated, err := lom.MigrateCustomMD()
+				Expect(err).NotTo(HaveOccurred())
+				Expect(migrated).To(BeFalse())
+
+				// no custom metadata - nothing to store
+				lom.SetCustomMD(nil)
+				Expect(persist(lom)).NotTo(HaveOccurred())
+				newLom = NewBasicLom(scarFQN)
+				Expect(newLom.LoadMetaFromFS()).NotTo(HaveOccurred())
+				Expect(newLom.GetCustomMD()).To(BeEmpty())
+				Expect(scarMdFQN).NotTo(BeAnExistingFile())
+			})
+
+			It("should identify orphaned sidecars", func() {
+				var (
+					now   = time.Now().Add(time.Hour).UnixNano()
+					stray = mix.MakePathFQN(&scarBck, fs.CustomMDType, "stray/obj")
+				)
+				lom := filePut(scarFQN, testFileSize)
+				lom.Lock(true)
+				defer lom.Unlock(true)
+				lom.SetCustomMD(cos.StrKVs{cmn.ETag: "etag"})
+				Expect(persist(lom)).NotTo(HaveOccurred())
+				Expect(core.IsCustomMDOrphan(scarMdFQN, &scarBck, now, 0)).To(BeFalse())
+
+				// crash after writing the sidecar but before the xattr (that has no marker)
+				nomarker := filePut(mix.MakePathFQN(&scarBck, fs.ObjectType, "stray/obj"), testFileSize)
+				Expect(persist(nomarker)).NotTo(HaveOccurred())
+				createTestFile(stray, 10)
+				Expect(core.IsCustomMDOrphan(stray, &scarBck, now, 0)).To(BeTrue())
+				Expect(core.IsCustomMDOrphan(stray, &scarBck, now, 2*time.Hour)).To(BeFalse()) // too early
+
+				// object removed, sidecar left behind
+				Expect(lom.RemoveMain()).NotTo(HaveOccurred())
+				Expect(core.IsCustomMDOrphan(scarMdFQN, &scarBck, now, 0)).To(BeTrue())
+			})
+		})
+
+		Describe("CloneRef", func() {
+			It("should share data and copy it on write", func() {
+				src := filePut(localFQN, testFileSize)
+				Expect(src.IsHRW()).To(BeTrue())
+
+				// destination must land on the same mountpath
+				var dst *core.LOM
+				for i := 0; dst == nil; i++ {
+					lom := NewBasicLom(mix.MakePathFQN(&localBck, fs.ObjectType, fmt.Sprintf("clone/obj-%d", i)))
+					if lom.IsHRW() {
+						dst = lom
+					}
+				}
+				src.Lock(true)
+				defer src.Unlock(true)
+				Expect(src.Load(false, true)).NotTo(HaveOccurred())
+				linked, err := src.CloneRef(dst)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(linked).To(BeTrue())
+				Expect(src.HasRefs()).To(BeTrue())
+
+				srcInfo, err := os.Stat(src.FQN)
+				Expect(err).NotTo(HaveOccurred())
+				dstInfo, err := os.Stat(dst.FQN)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(os.SameFile(srcInfo, dstInfo)).To(BeTrue())
+
+				// metadata (including refs) is shared
+				Expect(dst.LoadMetaFromFS()).NotTo(HaveOccurred())
+				Expect(dst.HasRefs()).To(BeTrue())
+				Expect(dst.Version(true)).To(Equal(src.Version(true)))
+
+				// modifying destination materializes its own copy
+				dst.Lock(true)
+				dst.SetVersion("cloned-and-modified")
+				Expect(persist(dst)).NotTo(HaveOccurred())
+				dst.Unlock(true)
+				Expect(dst.HasRefs()).To(BeFalse())
+
+				dstInfo, err = os.Stat(dst.FQN)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(os.SameFile(srcInfo, dstInfo)).To(BeFalse())
+				Expect(getTestFileHash(dst.FQN)).To(Equal(getTestFileHash(src.FQN)))
+
+				// source is not affected (other than now-stale refs)
+				Expect(src.LoadMetaFromFS()).NotTo(HaveOccurred())
+				Expect(src.Version(true)).NotTo(Equal(dst.Version(true)))
+				Expect(src.HasRefs()).To(BeTrue())
+				changed, err := src.SyncRefs()
+				Expect(err).NotTo(HaveOccurred())
+				Expect(changed).To(BeTrue())
+				Expect(src.HasRefs()).To(BeFalse())
+			})
+
+			It("should keep sharing data when trashed", func() {
+				src := filePut(localFQN, testFileSize)
+				var dst *core.LOM
+				for i := 0; dst == nil; i++ {
+					lom := NewBasicLom(mix.MakePathFQN(&localBck, fs.ObjectType, fmt.Sprintf("clone/trash-%d", i)))
+					if lom.IsHRW() {
+						dst = lom
+					}
+				}
+				src.Lock(true)
+				defer src.Unlock(true)
+				Expect(src.Load(false, true)).NotTo(HaveOccurred())
+				linked, err := src.CloneRef(dst)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(linked).To(BeTrue())
+
+				dst.Lock(true)
+				Expect(dst.Load(false, true)).NotTo(HaveOccurred())
+				tfqn := fs.CSM.Gen(dst, fs.WorkfileType, "trash")
+				Expect(dst.TrashObj(tfqn)).NotTo(HaveOccurred())
+				dst.Unlock(true)
+				Expect(dst.FQN).NotTo(BeAnExistingFile())
+
+				srcInfo, err := os.Stat(src.FQN)
+				Expect(err).NotTo(HaveOccurred())
+				trashInfo, err := os.Stat(tfqn)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(os.SameFile(srcInfo, trashInfo)).To(BeTrue())
+
+				Expect(src.LoadMetaFromFS()).NotTo(HaveOccurred())
+				Expect(src.HasRefs()).To(BeTrue())
+				Expect(getTestFileHash(tfqn)).To(Equal(getTestFileHash(src.FQN)))
+			})
+		})
+
+		Describe("DedupFinalize", func() {
+			It("should store identical content once, with per-object metadata", func() {
+				var (
+					loms   []*core.LOM
+					dedups []bool
+					data   = make([]byte, testFileSize)
+					cksum  = cos.NewCksum(cos.ChecksumSHA256, "0123456789abcdef0123456789abcdef0123456789abcdef0123456789abcdef")
+				)
+				// both objects must land on the same mountpath
+				for i := 0; len(loms) < 2; i++ {
+					lom := NewBasicLom(mix.MakePathFQN(&localBck, fs.ObjectType, fmt.Sprintf("dedup/obj-%d", i)))
+					if lom.IsHRW() {
+						loms = append(loms, lom)
+					}
+				}
+				for i, lom := range loms {
+					wfqn := fs.CSM.Gen(lom, fs.WorkfileType, "test")
+					fh, err := cos.CreateFile(wfqn)
+					Expect(err).NotTo(HaveOccurred())
+					_, err = fh.Write(data)
+					fh.Close()
+					Expect(err).NotTo(HaveOccurred())
+
+					lom.SetSize(int64(testFileSize))
+					lom.SetCksum(cksum)
+					lom.SetAtimeUnix(time.Now().UnixNano())
+					lom.SetCustomKey("name", fmt.Sprintf("obj-%d", i))
+					lom.Lock(true)
+					dedup, err := lom.DedupFinalize(wfqn)
+					if err == nil {
+						err = lom.PersistMain()
+					}
+					lom.Unlock(true)
+					Expect(err).NotTo(HaveOccurred())
+					Expect(cos.Stat(wfqn)).To(HaveOccurred())
+					dedups = append(dedups, dedup)
+				}
+
+				// (not every filesystem supports cloning)
+				efqn := loms[0].Mountpath().MakePathFQN(&localBck, fs.DedupType, fs.DedupName(cksum))
+				if cos.Stat(efqn) == nil {
+					Expect(dedups).To(Equal([]bool{false, true}))
+					now := time.Now().UnixNano()
+					Expect(core.IsDedupEntryUnused(efqn, now, time.Hour)).To(BeFalse())
+					Expect(core.IsDedupEntryUnused(efqn, now+int64(2*time.Hour), time.Hour)).To(BeTrue())
+				} else {
+					Expect(dedups).To(Equal([]bool{false, false}))
+				}
+
+				// separate files, each with its own metadata
+				info0, err := os.Stat(loms[0].FQN)
+				Expect(err).NotTo(HaveOccurred())
+				info1, err := os.Stat(loms[1].FQN)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(os.SameFile(info0, info1)).To(BeFalse())
+				for i, lom := range loms {
+					Expect(lom.LoadMetaFromFS()).NotTo(HaveOccurred())
+					Expect(lom.HasRefs()).To(BeFalse())
+					Expect(lom.Checksum().Equal(cksum)).To(BeTrue())
+					v, _ := lom.GetCustomKey("name")
+					Expect(v).To(Equal(fmt.Sprintf("obj-%d", i)))
+				}
+			})
+		})
+
 		Describe("LoadMetaFromFS", func() {
 			It("should read fresh meta from fs", func() {
 				createTestFile(localFQN, testFileSize)
@@ -0,0 +1,206 @@
+// Package core provides core metadata and in-cluster API
+/*
+ * Copyright (c) 2024, NVIDIA CORPORATION. All rights reserved.
+ */
+package core
+
+import (
+	"container/list"
+	"strings"
+	"sync"
+
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/cmn/cos"
+	"github.com/NVIDIA/aistore/cmn/debug"
+	"github.com/NVIDIA/aistore/core/meta"
+	"github.com/NVIDIA/aistore/memsys"
+)
+
+// In-memory (RAM) cache of small hot objects:
+// - per target, size-capped (`memsys.ram_cache_size`), least recently used get evicted first;
+// - only buckets with `ram_cache` enabled, only objects not larger than `ram_cache.max_obj_size`;
+// - content is kept in page-based SGLs; cached objects are reference counted, so that
+//   eviction (or invalidation) never frees an SGL that is still being read;
+// - a cached object is served only if it matches (size, version, checksum) the object's
+//   current metadata; in addition, overwrites and deletions invalidate the cached copy
+//   explicitly (see lom.RemoveObj, lom.TrashObj, and lom.RenameFinalize).
+
+type (
+	// reference-counted (read-only) cached object
+	RAMCached struct {
+		sgl     *memsys.SGL
+		cksum   *cos.Cksum
+		elem    *list.Element
+		uname   string
+		version string
+		size    int64
+		refs    int32 // (protected by ramc.mu)
+		gone    bool  // evicted or invalidated
+	}
+	ramc struct {
+		m    map[string]*RAMCached
+		lru  *list.List // front: most recently used
+		size int64      // total cached
+		mu   sync.Mutex
+	}
+)
+
+func (c *ramc) init() {
+	c.m = make(map[string]*RAMCached, 64)
+	c.lru = list.New()
+}
+
+// returns nil when disabled, not cached, or stale
+// (the caller must release the returned object)
+func RAMCacheGet(lom *LOM) *RAMCached {
+	c := &g.ramc
+	if ramCacheSize() == 0 {
+		c.purge()
+		return nil
+	}
+	uname := lom.Uname()
+	c.mu.Lock()
+	e, ok := c.m[uname]
+	if !ok {
+		c.mu.Unlock()
+		g.tstats.Inc(RAMCacheMissCount)
+		return nil
+	}
+	if !e.matches(lom) {
+		c.del(e)
+		c.mu.Unlock()
+		g.tstats.Inc(RAMCacheMissCount)
+		return nil
+	}
+	e.refs++
+	c.lru.MoveToFront(e.elem)
+	c.mu.Unlock()
+	g.tstats.Inc(RAMCacheHitCount)
+	return e
+}
+
+// RAMCachePut takes ownership of the SGL that must contain the object's entire content;
+// the returned (and already referenced) object must be released by the caller
+func RAMCachePut(lom *LOM, sgl *memsys.SGL) *RAMCached {
+	debug.Assert(sgl.Size() == lom.Lsize(), sgl.Size(), " vs ", lom.Lsize())
+	var (
+		c     = &g.ramc
+		limit = ramCacheSize()
+		e     = &RAMCached{
+			sgl:     sgl,
+			uname:   lom.Uname(),
+			version: lom.Version(),
+			size:    sgl.Size(),
+			refs:    1,
+		}
+	)
+	if ck := lom.Checksum(); ck != nil {
+		e.cksum = ck.Clone()
+	}
+	if limit == 0 || e.size > limit {
+		e.gone = true // not cached; freed upon release
+		return e
+	}
+	var evicted int64
+	c.mu.Lock()
+	if prev, ok := c.m[e.uname]; ok {
+		c.del(prev)
+	}
+	for c.size+e.size > limit {
+		last := c.lru.Back()
+		c.del(last.Value.(*RAMCached))
+		evicted++
+	}
+	e.elem = c.lru.PushFront(e)
+	c.m[e.uname] = e
+	c.size += e.size
+	c.mu.Unlock()
+	if evicted > 0 {
+		g.tstats.Add(RAMCacheEvictedCount, evicted)
+	}
+	return e
+}
+
+func ramCacheSize() int64 { return int64(cmn.GCO.Get().Memsys.RAMCacheSize) }
+
+func (lom *LOM) uncacheRAM() {
+	c := &g.ramc
+	c.mu.Lock()
+	if e, ok := c.m[lom.Uname()]; ok {
+		c.del(e)
+	}
+	c.mu.Unlock()
+}
+
+func uncacheRAMBck(b *meta.Bck) {
+	var (
+		c      = &g.ramc
+		prefix = string(b.MakeUname(""))
+	)
+	c.mu.Lock()
+	for uname, e := range c.m {
+		if strings.HasPrefix(uname, prefix) {
+			c.del(e)
+		}
+	}
+	c.mu.Unlock()
+}
+
+// evict all (disabled or memory pressure)
+func (c *ramc) purge() {
+	c.mu.Lock()
+	if len(c.m) == 0 {
+		c.mu.Unlock()
+		return
+	}
+	n := int64(len(c.m))
+	for _, e := range c.m {
+		c.del(e)
+	}
+	c.mu.Unlock()
+	g.tstats.Add(RAMCacheEvictedCount, n)
+}
+
+// is called under lock
+func (c *ramc) del(e *RAMCached) {
+	debug.Assert(!e.gone, e.uname)
+	delete(c.m, e.uname)
+	c.lru.Remove(e.elem)
+	c.size -= e.size
+	e.gone = true
+	if e.refs == 0 {
+		e.sgl.Free()
+	}
+}
+
+///////////////
+// RAMCached //
+///////////////
+
+func (e *RAMCached) Size() int64 { return e.size }
+
+// concurrent readers are fine - each one gets its own read offset
+func (e *RAMCached) NewReader() *memsys.Reader { return memsys.NewReader(e.sgl) }
+
+func (e *RAMCached) Release() {
+	c := &g.ramc
+	c.mu.Lock()
+	e.refs--
+	debug.Assert(e.refs >= 0, e.uname)
+	free := e.refs == 0 && e.gone
+	c.mu.Unlock()
+	if free {
+		e.sgl.Free()
+	}
+}
+
+func (e *RAMCached) matches(lom *LOM) bool {
+	if e.size != lom.Lsize() || e.version != lom.Version() {
+		return false
+	}
+	ck := lom.Checksum()
+	if e.cksum.IsEmpty() || ck.IsEmpty() {
+		return e.cksum.IsEmpty() && ck.IsEmpty()
+	}
+	return e.cksum.Equal(ck)
+}
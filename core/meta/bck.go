@@ -184,8 +184,16 @@ func (b *Bck) init(bmd *BMD) error {
 // to support s3 clients:
 // find an already existing bucket by name (and nothing else)
 // returns an error when name cannot be unambiguously resolved to a single bucket
+// (bucket alias, if any, resolves to the aliased bucket - same as with the native API)
 func InitByNameOnly(bckName string, bowner Bowner) (bck *Bck, err error, ecode int) {
 	bmd := bowner.Get()
+	if alias, ok := bmd.GetAlias(&cmn.Bck{Name: bckName, Provider: apc.AIS}); ok {
+		bck = CloneBck(&alias.Bck)
+		if err = bck.Init(bowner); err != nil && cmn.IsErrBucketNought(err) {
+			ecode = http.StatusNotFound
+		}
+		return bck, err, ecode
+	}
 	all := bmd.getAllByName(bckName)
 	switch {
 	case all == nil:
@@ -225,6 +233,9 @@ func (b *Bck) VersionConf() cmn.VersionConf {
 func (b *Bck) Allow(bit apc.AccessAttrs) error { return b.checkAccess(bit) }
 
 func (b *Bck) checkAccess(bit apc.AccessAttrs) (err error) {
+	if err = b.Props.Freeze.Check(b.Cname(""), bit); err != nil {
+		return
+	}
 	if b.Props.Access.Has(bit) {
 		return
 	}
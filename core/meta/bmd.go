@@ -25,15 +25,24 @@ type (
 	Namespaces map[string]Buckets
 	Providers  map[string]Namespaces
 
+	// bucket alias: a stable ais:// (global namespace) name that resolves to another,
+	// possibly remote, bucket - and can be atomically repointed (e.g., to a new dataset version)
+	BckAlias struct {
+		Bck      cmn.Bck `json:"bck"`
+		ReadOnly bool    `json:"read_only,omitempty"`
+	}
+	BckAliases map[string]*BckAlias // alias name => alias
+
 	// - BMD is the root of the (providers, namespaces, buckets) hierarchy
 	// - BMD (instance) can be obtained via Bowner.Get()
 	// - BMD is immutable and versioned
 	// - BMD versioning is monotonic and incremental
 	BMD struct {
-		Ext       any       `json:"ext,omitempty"`  // within meta-version extensions
-		Providers Providers `json:"providers"`      // (provider, namespace, bucket) hierarchy
-		UUID      string    `json:"uuid"`           // unique & immutable
-		Version   int64     `json:"version,string"` // gets incremented on every update
+		Ext       any        `json:"ext,omitempty"`     // within meta-version extensions
+		Providers Providers  `json:"providers"`         // (provider, namespace, bucket) hierarchy
+		Aliases   BckAliases `json:"aliases,omitempty"` // bucket aliases
+		UUID      string     `json:"uuid"`              // unique & immutable
+		Version   int64      `json:"version,string"`    // gets incremented on every update
 	}
 )
 
@@ -115,6 +124,15 @@ func (m *BMD) Add(bck *Bck) {
 	buckets[bck.Name] = bck.Props
 }
 
+// returns alias (if exists) of a given ais:// bucket
+func (m *BMD) GetAlias(bck *cmn.Bck) (*BckAlias, bool) {
+	if len(m.Aliases) == 0 || !bck.IsAIS() || !bck.Ns.IsGlobal() {
+		return nil, false
+	}
+	alias, ok := m.Aliases[bck.Name]
+	return alias, ok
+}
+
 func (m *BMD) IsEmpty() bool {
 	na, nar, nc, no := m.numBuckets(true)
 	return na+nar+nc+no == 0
@@ -165,6 +183,9 @@ func (m *BMD) Select(qbck *cmn.QueryBcks) cmn.Bcks {
 	}
 	m.Range(cp, nil, func(bck *Bck) bool {
 		b := bck.Bucket()
+		if b.Ns.IsSys() && !qbck.Ns.IsSys() {
+			return false // (hidden)
+		}
 		if qbck.Equal(b) || qbck.Contains(b) {
 			if len(bcks) == 0 {
 				bcks = make(cmn.Bcks, 0, 8)
@@ -6,6 +6,7 @@ package meta
 
 import (
 	"fmt"
+	"math"
 
 	"github.com/NVIDIA/aistore/api/apc"
 	"github.com/NVIDIA/aistore/cmn"
@@ -18,6 +19,49 @@ import (
 // A variant of consistent hash based on rendezvous algorithm by Thaler and Ravishankar,
 // aka highest random weight (HRW)
 // See also: fs/hrw.go
+//
+// With Smap.Placement == apc.PlacementCapacity, object-to-target placement becomes
+// weighted rendezvous hashing (Schindelhauer and Schomaker): each target's score
+// gets scaled by its Snode.Weight (capacity) so that the expected share of objects
+// is proportional to the weight. Task and proxy (IC, primary) selection remain unweighted.
+//
+// In addition, a target can be administratively assigned a lower weight (Snode.Offload = 100 - weight)
+// to gradually drain it (apc.ActSetWeight) - with or without capacity-weighted placement.
+// Reducing the weight of a given target only moves objects _off_ that target.
+
+// tscore returns HRW score of a given target for a given (object name) digest
+func (smap *Smap) tscore(tsi *Snode, digest uint64) uint64 {
+	cs := xoshiro256.Hash(tsi.Digest() ^ digest)
+	if smap.Placement != apc.PlacementCapacity {
+		if tsi.Offload == 0 {
+			return cs
+		}
+		return oscore(cs, tsi.Offload)
+	}
+	return wscore(cs, tsi.Weight, tsi.Offload)
+}
+
+// score = weight / -ln(u), with u uniformly distributed in (0, 1);
+// (bit pattern of a positive float64 is monotonic in its value)
+func wscore(cs uint64, weight uint32, offload uint8) uint64 {
+	w := float64(max(weight, 1)) * float64(100-min(offload, 100)) / 100
+	if w == 0 {
+		return 0
+	}
+	u := (float64(cs>>11) + 0.5) / (1 << 53)
+	return math.Float64bits(w / -math.Log(u))
+}
+
+// same as above in terms of u^(1/weight) - the (unweighted) HRW scores of all other targets
+// are u itself, and so the two remain comparable
+func oscore(cs uint64, offload uint8) uint64 {
+	if offload >= 100 {
+		return 0
+	}
+	u := (float64(cs>>11) + 0.5) / (1 << 53)
+	s := math.Pow(u, 100/float64(100-offload))
+	return uint64(math.Ldexp(s, 64) * (1 - 0x1p-53)) // (< 2^64)
+}
 
 func (smap *Smap) HrwName2T(uname []byte) (*Snode, error) {
 	digest := xxhash.Checksum64S(uname, cos.MLCG32)
@@ -41,7 +85,7 @@ func (smap *Smap) HrwHash2T(digest uint64) (si *Snode, err error) {
 		if tsi.InMaintOrDecomm() { // always skipping targets 'in maintenance mode'
 			continue
 		}
-		cs := xoshiro256.Hash(tsi.Digest() ^ digest)
+		cs := smap.tscore(tsi, digest)
 		if cs >= maxH {
 			maxH = cs
 			si = tsi
@@ -57,7 +101,7 @@ func (smap *Smap) HrwHash2T(digest uint64) (si *Snode, err error) {
 func (smap *Smap) HrwHash2Tall(digest uint64) (si *Snode, err error) {
 	var maxH uint64
 	for _, tsi := range smap.Tmap {
-		cs := xoshiro256.Hash(tsi.Digest() ^ digest)
+		cs := smap.tscore(tsi, digest)
 		if cs >= maxH {
 			maxH = cs
 			si = tsi
@@ -164,11 +208,10 @@ func (smap *Smap) HrwTargetList(uname *string, count int) (sis Nodes, err error)
 	hlist := newHrwList(count)
 
 	for _, tsi := range smap.Tmap {
-		cs := xoshiro256.Hash(tsi.Digest() ^ digest)
 		if tsi.InMaintOrDecomm() {
 			continue
 		}
-		hlist.add(cs, tsi)
+		hlist.add(smap.tscore(tsi, digest), tsi)
 	}
 	sis = hlist.get()
 	if count != cnt && len(sis) < count {
@@ -0,0 +1,114 @@
+// Package meta_test: unit tests for the package
+/*
+ * Copyright (c) 2024, NVIDIA CORPORATION. All rights reserved.
+ */
+package meta_test
+
+import (
+	"strconv"
+
+	"github.com/NVIDIA/aistore/api/apc"
+	"github.com/NVIDIA/aistore/core/meta"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("HRW", func() {
+	const numObjs = 40000
+
+	newSmap := func(weights ...uint32) *meta.Smap {
+		smap := &meta.Smap{Tmap: make(meta.NodeMap, len(weights))}
+		for i, w := range weights {
+			si := &meta.Snode{}
+			si.Init("t"+strconv.Itoa(i), apc.Target)
+			si.Weight = w
+			smap.Tmap[si.ID()] = si
+		}
+		return smap
+	}
+	place := func(smap *meta.Smap) map[string]string {
+		m := make(map[string]string, numObjs)
+		for i := range numObjs {
+			uname := "ais/@#/bck/obj-" + strconv.Itoa(i)
+			si, err := smap.HrwName2T([]byte(uname))
+			Expect(err).NotTo(HaveOccurred())
+			m[uname] = si.ID()
+		}
+		return m
+	}
+
+	It("should not change placement when all weights are equal", func() {
+		smap := newSmap(100, 100, 100, 100)
+		hrw := place(smap)
+		smap.Placement = apc.PlacementCapacity
+		Expect(place(smap)).To(Equal(hrw))
+	})
+
+	It("should ignore weights by default", func() {
+		hrw := place(newSmap(0, 0, 0))
+		Expect(place(newSmap(10, 1000, 1))).To(Equal(hrw))
+	})
+
+	It("should place proportionally to capacity", func() {
+		smap := newSmap(100, 100, 200, 400)
+		smap.Placement = apc.PlacementCapacity
+		cnt := make(map[string]int, 4)
+		for _, tid := range place(smap) {
+			cnt[tid]++
+		}
+		for i, share := range []float64{0.125, 0.125, 0.25, 0.5} {
+			Expect(float64(cnt["t"+strconv.Itoa(i)]) / numObjs).To(BeNumerically("~", share, 0.02))
+		}
+	})
+
+	It("should move only what's needed when a target gets added", func() {
+		smap := newSmap(100, 200, 300)
+		smap.Placement = apc.PlacementCapacity
+		prev := place(smap)
+
+		si := &meta.Snode{}
+		si.Init("t3", apc.Target)
+		si.Weight = 400
+		smap.Tmap[si.ID()] = si
+		for uname, tid := range place(smap) {
+			if tid != prev[uname] {
+				Expect(tid).To(Equal(si.ID()))
+			}
+		}
+	})
+
+	It("should only move objects off the target with reduced weight", func() {
+		for _, placement := range []string{apc.PlacementHRW, apc.PlacementCapacity} {
+			smap := newSmap(100, 200, 300, 400)
+			smap.Placement = placement
+			prev := place(smap)
+
+			tsi := smap.GetTarget("t2")
+			tsi.Offload = 40
+			for uname, tid := range place(smap) {
+				if tid != prev[uname] {
+					Expect(prev[uname]).To(Equal(tsi.ID()))
+				}
+			}
+		}
+	})
+
+	It("should scale target's share by its weight (100 - offload)", func() {
+		for _, placement := range []string{apc.PlacementHRW, apc.PlacementCapacity} {
+			smap := newSmap(100, 100, 100, 100)
+			smap.Placement = placement
+			smap.GetTarget("t0").Offload = 50
+			cnt := make(map[string]int, 4)
+			for _, tid := range place(smap) {
+				cnt[tid]++
+			}
+			// 0.5 / (0.5 + 3)
+			Expect(float64(cnt["t0"]) / numObjs).To(BeNumerically("~", 1.0/7, 0.02))
+
+			smap.GetTarget("t0").Offload = 100
+			for _, tid := range place(smap) {
+				Expect(tid).NotTo(Equal("t0"))
+			}
+		}
+	})
+})
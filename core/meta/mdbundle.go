@@ -0,0 +1,145 @@
+// Package meta: cluster-level metadata
+/*
+ * Copyright (c) 2024, NVIDIA CORPORATION. All rights reserved.
+ */
+package meta
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/cmn/cos"
+	"github.com/NVIDIA/aistore/cmn/jsp"
+	jsoniter "github.com/json-iterator/go"
+)
+
+// MDBundle is the complete set of cluster-level metadata exported (as a single compressed,
+// checksummed, and signed jsp blob) for disaster recovery, and subsequently imported
+// onto a freshly bootstrapped cluster.
+// Smap and RMD are carried for reference: the new cluster keeps its own (current) Smap,
+// and the exported one is only used to map old targets to new ones (see MapTargets).
+type MDBundle struct {
+	Smap    *Smap               `json:"smap"`
+	BMD     *BMD                `json:"bmd"`
+	RMD     *RMD                `json:"rmd"`
+	Config  *cmn.ClusterConfig  `json:"config"`
+	EtlMD   jsoniter.RawMessage `json:"etlmd,omitempty"` // etl.MD (see ext/etl)
+	Version string              `json:"aistore_version"` // exporting node's cmn.VersionAIStore
+	Created int64               `json:"created,string"`
+}
+
+// interface guard
+var _ jsp.Opts = (*MDBundle)(nil)
+
+var mdbJspOpts = jsp.CCSign(cmn.MetaverMDBundle)
+
+func (*MDBundle) JspOpts() jsp.Options { return mdbJspOpts }
+
+func (b *MDBundle) String() string {
+	if b.Smap == nil || b.BMD == nil {
+		return "MDBundle <invalid>"
+	}
+	return fmt.Sprintf("MDBundle[%s, %s, %s]", b.Smap.UUID, b.Smap.StringEx(), b.BMD.StringEx())
+}
+
+func (b *MDBundle) Validate() error {
+	switch {
+	case b.Smap == nil:
+		return errors.New("invalid metadata bundle: missing Smap")
+	case b.BMD == nil:
+		return errors.New("invalid metadata bundle: missing BMD")
+	case b.Config == nil:
+		return errors.New("invalid metadata bundle: missing cluster config")
+	}
+	return nil
+}
+
+// MapTargets maps targets of the exported Smap (old) to the targets of the current one (new):
+// - explicitly, via `remap` (old ID => new ID), and then
+// - implicitly, by the same node ID, same public endpoint, or same (public or intra-cluster) hostname.
+// Returns the resulting mapping, old targets that remain unmapped, and new targets
+// that were not mapped to.
+func (b *MDBundle) MapTargets(smap *Smap, remap cos.StrKVs) (mapped cos.StrKVs, unmapped, unused []string, _ error) {
+	var (
+		olds  = _sortedIDs(b.Smap.Tmap)
+		taken = make(cos.StrSet, len(smap.Tmap))
+	)
+	mapped = make(cos.StrKVs, len(olds))
+	for oid, nid := range remap {
+		if b.Smap.GetTarget(oid) == nil {
+			return nil, nil, nil, fmt.Errorf("remap: target %q not found in the exported %s", oid, b.Smap)
+		}
+		if smap.GetTarget(nid) == nil {
+			return nil, nil, nil, fmt.Errorf("remap: target %q not found in the current %s", nid, smap)
+		}
+		if taken.Contains(nid) {
+			return nil, nil, nil, fmt.Errorf("remap: target %q is mapped more than once", nid)
+		}
+		mapped[oid] = nid
+		taken.Add(nid)
+	}
+	// same ID
+	for _, oid := range olds {
+		if _, ok := mapped[oid]; ok {
+			continue
+		}
+		if smap.GetTarget(oid) != nil && !taken.Contains(oid) {
+			mapped[oid] = oid
+			taken.Add(oid)
+		}
+	}
+	// same public endpoint (hostname and port), and then same hostname
+	news := _sortedIDs(smap.Tmap)
+	for _, port := range []bool{true, false} {
+		for _, oid := range olds {
+			if _, ok := mapped[oid]; ok {
+				continue
+			}
+			osi := b.Smap.Tmap[oid]
+			for _, nid := range news {
+				if !taken.Contains(nid) && _sameHost(osi, smap.Tmap[nid], port) {
+					mapped[oid] = nid
+					taken.Add(nid)
+					break
+				}
+			}
+		}
+	}
+	for _, oid := range olds {
+		if _, ok := mapped[oid]; !ok {
+			unmapped = append(unmapped, oid)
+		}
+	}
+	for _, nid := range news {
+		if !taken.Contains(nid) {
+			unused = append(unused, nid)
+		}
+	}
+	return mapped, unmapped, unused, nil
+}
+
+func _sortedIDs(m NodeMap) []string {
+	ids := make([]string, 0, len(m))
+	for id := range m {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	return ids
+}
+
+func _sameHost(a, b *Snode, port bool) bool {
+	if port {
+		return a.PubNet.Hostname == b.PubNet.Hostname && a.PubNet.Port == b.PubNet.Port
+	}
+	for _, ha := range []string{a.PubNet.Hostname, a.ControlNet.Hostname, a.DataNet.Hostname} {
+		if ha == "" {
+			continue
+		}
+		if ha == b.PubNet.Hostname || ha == b.ControlNet.Hostname || ha == b.DataNet.Hostname {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,113 @@
+// Package meta_test: unit tests for the package
+/*
+ * Copyright (c) 2024, NVIDIA CORPORATION. All rights reserved.
+ */
+package meta_test
+
+import (
+	"bytes"
+	"io"
+
+	"github.com/NVIDIA/aistore/api/apc"
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/cmn/cos"
+	"github.com/NVIDIA/aistore/cmn/jsp"
+	"github.com/NVIDIA/aistore/core/meta"
+	"github.com/NVIDIA/aistore/memsys"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("MDBundle", func() {
+	newTarget := func(id, host, port string) *meta.Snode {
+		return &meta.Snode{
+			DaeID:   id,
+			DaeType: apc.Target,
+			PubNet:  meta.NetInfo{Hostname: host, Port: port},
+		}
+	}
+	newSmap := func(tsis ...*meta.Snode) *meta.Smap {
+		smap := &meta.Smap{Tmap: make(meta.NodeMap, len(tsis)), Pmap: make(meta.NodeMap)}
+		for _, tsi := range tsis {
+			smap.Tmap.Add(tsi)
+		}
+		return smap
+	}
+
+	Describe("MapTargets", func() {
+		old := newSmap(
+			newTarget("t1", "10.0.0.1", "8081"),
+			newTarget("t2", "10.0.0.2", "8081"),
+			newTarget("t3", "10.0.0.3", "8081"),
+			newTarget("t4", "10.0.0.4", "8081"),
+		)
+		bundle := &meta.MDBundle{Smap: old}
+
+		It("should map by ID, endpoint, and hostname", func() {
+			cur := newSmap(
+				newTarget("t1", "10.0.0.9", "8081"), // same ID
+				newTarget("n2", "10.0.0.2", "8081"), // same endpoint
+				newTarget("n3", "10.0.0.3", "9999"), // same host
+				newTarget("n5", "10.0.0.5", "8081"), // new
+			)
+			mapped, unmapped, unused, err := bundle.MapTargets(cur, nil)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(mapped).To(Equal(cos.StrKVs{"t1": "t1", "t2": "n2", "t3": "n3"}))
+			Expect(unmapped).To(Equal([]string{"t4"}))
+			Expect(unused).To(Equal([]string{"n5"}))
+		})
+
+		It("should prefer explicit remapping", func() {
+			cur := newSmap(
+				newTarget("n1", "10.0.0.1", "8081"),
+				newTarget("n5", "10.0.0.5", "8081"),
+			)
+			mapped, unmapped, unused, err := bundle.MapTargets(cur, cos.StrKVs{"t1": "n5", "t4": "n1"})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(mapped).To(Equal(cos.StrKVs{"t1": "n5", "t4": "n1"}))
+			Expect(unmapped).To(Equal([]string{"t2", "t3"}))
+			Expect(unused).To(BeEmpty())
+		})
+
+		It("should fail to remap unknown or duplicate targets", func() {
+			cur := newSmap(newTarget("n1", "10.0.0.1", "8081"))
+			_, _, _, err := bundle.MapTargets(cur, cos.StrKVs{"t9": "n1"})
+			Expect(err).To(HaveOccurred())
+			_, _, _, err = bundle.MapTargets(cur, cos.StrKVs{"t1": "n9"})
+			Expect(err).To(HaveOccurred())
+			_, _, _, err = bundle.MapTargets(cur, cos.StrKVs{"t1": "n1", "t2": "n1"})
+			Expect(err).To(HaveOccurred())
+		})
+	})
+
+	It("should encode and decode (checksummed and signed)", func() {
+		bmd := &meta.BMD{Providers: make(meta.Providers), UUID: "uuid", Version: 7}
+		bck := meta.NewBck("bucket", apc.AIS, cmn.NsGlobal)
+		bck.Props = &cmn.Bprops{BID: 1}
+		bmd.Add(bck)
+		bundle := &meta.MDBundle{
+			Smap:    newSmap(newTarget("t1", "10.0.0.1", "8081")),
+			BMD:     bmd,
+			Config:  &cmn.ClusterConfig{Version: 3},
+			Version: cmn.VersionAIStore,
+		}
+		sgl := memsys.PageMM().NewSGL(0)
+		defer sgl.Free()
+		Expect(jsp.Encode(sgl, bundle, bundle.JspOpts())).NotTo(HaveOccurred())
+		b := sgl.ReadAll()
+
+		out := &meta.MDBundle{}
+		_, err := jsp.Decode(io.NopCloser(bytes.NewReader(b)), out, out.JspOpts(), "test")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(out.Validate()).NotTo(HaveOccurred())
+		Expect(out.BMD.Version).To(BeEquivalentTo(7))
+		_, present := out.BMD.Get(bck)
+		Expect(present).To(BeTrue())
+		Expect(out.Config.Version).To(BeEquivalentTo(3))
+
+		// corrupted
+		b[len(b)-1] ^= 0xff
+		_, err = jsp.Decode(io.NopCloser(bytes.NewReader(b)), &meta.MDBundle{}, out.JspOpts(), "test")
+		Expect(err).To(HaveOccurred())
+	})
+})
@@ -5,6 +5,7 @@
 package meta
 
 import (
+	"net"
 	"sync/atomic"
 
 	"github.com/NVIDIA/aistore/cmn"
@@ -22,13 +23,24 @@ type (
 		robin  atomic.Uint64 // round
 		numIfs uint64        // ## interfaces
 	}
+
+	// intra-cluster data multihoming (see Snode.DataExtra and cmn.NetConf.DataMultihome)
+	dataNamer struct {
+		urls  []string // DataNet.URL followed by DataExtra URLs
+		local []string // subset of the above that shares subnet with (any of) this node's data NICs
+		robin atomic.Uint64
+	}
 )
 
+// this node's intra-cluster data subnets (see SetLocalDataNets)
+var localDataNets atomic.Pointer[[]*net.IPNet]
+
 // interface guard
 var _ NetNamer = (*netNamerSingle)(nil)
 var _ NetNamer = (*netNamerMulti)(nil)
 
 func (d *Snode) InitNetNamer() {
+	d.initDataNamer()
 	l := len(d.PubExtra)
 	if l == 0 {
 		d.nmr = &netNamerSingle{d}
@@ -48,3 +60,68 @@ func (nmr *netNamerMulti) name() string {
 	}
 	return nmr.parent.PubExtra[i-1].URL
 }
+
+//
+// intra-cluster data
+//
+
+// to prefer same-subnet data endpoints of multi-homed peers (cmn.DataMultihomeSubnet)
+func SetLocalDataNets(nets []*net.IPNet) { localDataNets.Store(&nets) }
+
+func (d *Snode) initDataNamer() {
+	if len(d.DataExtra) == 0 {
+		d.dnmr = nil
+		return
+	}
+	dn := &dataNamer{urls: make([]string, 0, len(d.DataExtra)+1)}
+	dn.urls = append(dn.urls, d.DataNet.URL)
+	for i := range d.DataExtra {
+		dn.urls = append(dn.urls, d.DataExtra[i].URL)
+	}
+	if pnets := localDataNets.Load(); pnets != nil {
+		for i, ni := range d.dataNets() {
+			ip := net.ParseIP(ni.Hostname)
+			if ip == nil {
+				continue // (DNS hostname)
+			}
+			for _, ipnet := range *pnets {
+				if ipnet.Contains(ip) {
+					dn.local = append(dn.local, dn.urls[i])
+					break
+				}
+			}
+		}
+	}
+	d.dnmr = dn
+}
+
+func (d *Snode) dataNets() []*NetInfo {
+	nets := make([]*NetInfo, 0, 1+len(d.DataExtra))
+	nets = append(nets, &d.DataNet)
+	for i := range d.DataExtra {
+		nets = append(nets, &d.DataExtra[i])
+	}
+	return nets
+}
+
+// round-robin all (or same-subnet) intra-cluster data endpoints
+func (d *Snode) dataURL() string {
+	dn := d.dnmr
+	if dn == nil {
+		return d.DataNet.URL
+	}
+	urls := dn.urls
+	if len(dn.local) > 0 && cmn.Rom.DataSubnet() {
+		urls = dn.local
+	}
+	i := dn.robin.Add(1) % uint64(len(urls))
+	return urls[i]
+}
+
+// all intra-cluster data endpoints, the primary one first
+func (d *Snode) DataURLs() []string {
+	if dn := d.dnmr; dn != nil {
+		return dn.urls
+	}
+	return []string{d.DataNet.URL}
+}
@@ -0,0 +1,108 @@
+// Package meta_test: unit tests for the package
+/*
+ * Copyright (c) 2024, NVIDIA CORPORATION. All rights reserved.
+ */
+package meta_test
+
+import (
+	"net"
+
+	"github.com/NVIDIA/aistore/api/apc"
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/core/meta"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("DataMultihome", func() {
+	newTarget := func(extra ...string) *meta.Snode {
+		si := &meta.Snode{DaeID: "t1", DaeType: apc.Target}
+		si.PubNet.Init("http", "10.0.0.1", "51081")
+		si.ControlNet.Init("http", "10.1.0.1", "51082")
+		si.DataNet.Init("http", "10.2.0.1", "51083")
+		si.DataExtra = make([]meta.NetInfo, len(extra))
+		for i, host := range extra {
+			si.DataExtra[i].Init("http", host, "51083")
+		}
+		si.InitNetNamer()
+		return si
+	}
+	setMultihome := func(mode string) {
+		config := &cmn.ClusterConfig{}
+		config.Net.DataMultihome = mode
+		cmn.Rom.Set(config)
+	}
+	subnet := func(cidr string) *net.IPNet {
+		_, ipnet, err := net.ParseCIDR(cidr)
+		Expect(err).NotTo(HaveOccurred())
+		return ipnet
+	}
+	collect := func(si *meta.Snode, n int) map[string]int {
+		urls := make(map[string]int, n)
+		for range n {
+			urls[si.URL(cmn.NetIntraData)]++
+		}
+		return urls
+	}
+
+	AfterEach(func() {
+		meta.SetLocalDataNets(nil)
+		setMultihome("")
+	})
+
+	It("should use the primary data endpoint when not multi-homed", func() {
+		si := newTarget()
+		Expect(collect(si, 10)).To(Equal(map[string]int{si.DataNet.URL: 10}))
+		Expect(si.DataURLs()).To(Equal([]string{si.DataNet.URL}))
+	})
+
+	It("should round-robin all data endpoints", func() {
+		si := newTarget("10.3.0.1", "10.4.0.1")
+		Expect(si.DataURLs()).To(Equal([]string{si.DataNet.URL, si.DataExtra[0].URL, si.DataExtra[1].URL}))
+		Expect(collect(si, 30)).To(Equal(map[string]int{
+			si.DataNet.URL:      10,
+			si.DataExtra[0].URL: 10,
+			si.DataExtra[1].URL: 10,
+		}))
+	})
+
+	It("should prefer same-subnet endpoints", func() {
+		meta.SetLocalDataNets([]*net.IPNet{subnet("10.3.0.0/16"), subnet("10.4.0.0/16")})
+		setMultihome(cmn.DataMultihomeSubnet)
+		si := newTarget("10.3.0.1", "10.4.0.1", "10.5.0.1")
+		Expect(collect(si, 20)).To(Equal(map[string]int{
+			si.DataExtra[0].URL: 10,
+			si.DataExtra[1].URL: 10,
+		}))
+	})
+
+	It("should ignore subnets when striping", func() {
+		meta.SetLocalDataNets([]*net.IPNet{subnet("10.3.0.0/16")})
+		setMultihome(cmn.DataMultihomeStripe)
+		si := newTarget("10.3.0.1")
+		Expect(collect(si, 20)).To(Equal(map[string]int{
+			si.DataNet.URL:      10,
+			si.DataExtra[0].URL: 10,
+		}))
+	})
+
+	It("should fall back to all endpoints when none shares subnet", func() {
+		meta.SetLocalDataNets([]*net.IPNet{subnet("192.168.0.0/16")})
+		setMultihome(cmn.DataMultihomeSubnet)
+		si := newTarget("10.3.0.1", "data-nic.example.com")
+		Expect(collect(si, 30)).To(Equal(map[string]int{
+			si.DataNet.URL:      10,
+			si.DataExtra[0].URL: 10,
+			si.DataExtra[1].URL: 10,
+		}))
+	})
+
+	It("should detect changed data NICs", func() {
+		si := newTarget("10.3.0.1")
+		Expect(si.NetEq(newTarget("10.3.0.1"))).To(Succeed())
+		Expect(si.NetEq(newTarget("10.3.0.2"))).NotTo(Succeed())
+		Expect(si.NetEq(newTarget())).NotTo(Succeed())
+		Expect(si.NetEq(newTarget("10.3.0.1", "10.4.0.1"))).NotTo(Succeed())
+		Expect(si.Eq(newTarget("10.3.0.2"))).To(BeFalse())
+	})
+})
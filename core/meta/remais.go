@@ -1,9 +1,11 @@
 // Package meta: cluster-level metadata
 /*
- * Copyright (c) 2018-2023, NVIDIA CORPORATION. All rights reserved.
+ * Copyright (c) 2018-2024, NVIDIA CORPORATION. All rights reserved.
  */
 package meta
 
+import "github.com/NVIDIA/aistore/cmn"
+
 type (
 	RemAis struct {
 		URL   string `json:"url"`
@@ -16,3 +18,66 @@ type (
 		Ver int64     `json:"ver"`
 	}
 )
+
+// federation view: all attached remote AIS clusters at a glance (see apc.WhatFederation)
+const (
+	FedOnline   = "online"
+	FedDegraded = "degraded" // reachable but not all targets are active, low on space, or partial stats
+	FedOffline  = "offline"
+)
+
+type (
+	FedCluster struct {
+		Alias   string `json:"alias"`
+		UUID    string `json:"uuid"`
+		URL     string `json:"url"`
+		Health  string `json:"health"`        // FedOnline, et al.
+		Err     string `json:"err,omitempty"` // (offline or degraded)
+		Primary string `json:"primary,omitempty"`
+		// round-trip time to query the remote cluster's map, in nanoseconds
+		Latency int64 `json:"latency,string"`
+		// lag: remote Smap versions that were not yet seen by the attaching cluster
+		SmapVer       int64            `json:"smap_version,string"`
+		SmapLag       int64            `json:"smap_lag,string"`
+		Proxies       int              `json:"proxies"`
+		Targets       int              `json:"targets"`
+		ActiveTargets int              `json:"active_targets"`
+		Buckets       cmn.Bcks         `json:"buckets"`
+		Used          uint64           `json:"used,string"`  // bytes
+		Avail         uint64           `json:"avail,string"` // bytes
+		PctMax        int32            `json:"pct_max"`      // max used across all mountpaths (%)
+		Stats         map[string]int64 `json:"stats,omitempty"`
+	}
+	FedTotal struct {
+		Clusters int              `json:"clusters"`
+		Online   int              `json:"online"` // including degraded
+		Buckets  int              `json:"buckets"`
+		Targets  int              `json:"targets"`
+		Used     uint64           `json:"used,string"`
+		Avail    uint64           `json:"avail,string"`
+		Stats    map[string]int64 `json:"stats,omitempty"`
+	}
+	Federation struct {
+		Clusters []*FedCluster `json:"clusters"`
+		Total    FedTotal      `json:"total"`
+	}
+)
+
+// (re)computes federation-wide totals
+func (fed *Federation) Sum() {
+	tot := &fed.Total
+	*tot = FedTotal{Clusters: len(fed.Clusters), Stats: make(map[string]int64, 8)}
+	for _, fc := range fed.Clusters {
+		if fc.Health == FedOffline {
+			continue
+		}
+		tot.Online++
+		tot.Buckets += len(fc.Buckets)
+		tot.Targets += fc.ActiveTargets
+		tot.Used += fc.Used
+		tot.Avail += fc.Avail
+		for name, v := range fc.Stats {
+			tot.Stats[name] += v
+		}
+	}
+}
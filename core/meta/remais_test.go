@@ -0,0 +1,41 @@
+// Package meta_test: unit tests for the package
+/*
+ * Copyright (c) 2024, NVIDIA CORPORATION. All rights reserved.
+ */
+package meta_test
+
+import (
+	"github.com/NVIDIA/aistore/api/apc"
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/core/meta"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Federation", func() {
+	It("should summarize online clusters only", func() {
+		fed := &meta.Federation{
+			Clusters: []*meta.FedCluster{
+				{
+					Alias: "one", Health: meta.FedOnline, ActiveTargets: 3, Used: 100, Avail: 900,
+					Buckets: cmn.Bcks{{Name: "a", Provider: apc.AIS}, {Name: "b", Provider: apc.AIS}},
+					Stats:   map[string]int64{"get.n": 10, "put.n": 1},
+				},
+				{
+					Alias: "two", Health: meta.FedDegraded, ActiveTargets: 1, Used: 50, Avail: 50,
+					Buckets: cmn.Bcks{{Name: "c", Provider: apc.AIS}},
+					Stats:   map[string]int64{"get.n": 5},
+				},
+				{Alias: "three", Health: meta.FedOffline, ActiveTargets: 7, Used: 1000},
+			},
+		}
+		fed.Sum()
+		Expect(fed.Total.Clusters).To(Equal(3))
+		Expect(fed.Total.Online).To(Equal(2))
+		Expect(fed.Total.Buckets).To(Equal(3))
+		Expect(fed.Total.Targets).To(Equal(4))
+		Expect(fed.Total.Used).To(BeEquivalentTo(150))
+		Expect(fed.Total.Avail).To(BeEquivalentTo(950))
+		Expect(fed.Total.Stats).To(Equal(map[string]int64{"get.n": 15, "put.n": 1}))
+	})
+})
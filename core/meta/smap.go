@@ -32,9 +32,18 @@ const (
 
 const SnodeMaintDecomm = SnodeMaint | SnodeDecomm
 
+// enum Snode.Codecs: intra-cluster control message encodings (other than JSON) the node can decode
+const (
+	SnodeCodecMsgpack cos.BitFlags = 1 << iota
+	SnodeCodecZstd                 // zstd-compressed payloads (see cos.ContentZstd)
+)
+
 // desirable gateway count in the Information Center (IC)
 const DfltCountIC = 3
 
+// IC auto-scaling: one IC member per so many proxies (see AutoCountIC and config.Proxy.ICCount)
+const proxiesPerIC = 8
+
 type (
 	// interface to Get current (immutable, versioned) cluster map (Smap) instance
 	Sowner interface {
@@ -71,14 +80,20 @@ type (
 		LocalNet   *net.IPNet   `json:"-"`
 		PubNet     NetInfo      `json:"public_net"` // cmn.NetPublic
 		PubExtra   []NetInfo    `json:"pub_extra,omitempty"`
-		DataNet    NetInfo      `json:"intra_data_net"`    // cmn.NetIntraData
+		DataNet    NetInfo      `json:"intra_data_net"` // cmn.NetIntraData
+		DataExtra  []NetInfo    `json:"data_extra,omitempty"`
 		ControlNet NetInfo      `json:"intra_control_net"` // cmn.NetIntraControl
 		DaeType    string       `json:"daemon_type"`       // "target" or "proxy"
 		DaeID      string       `json:"daemon_id"`
 		name       string       // cached
-		Flags      cos.BitFlags `json:"flags"` // enum { SnodeNonElectable, SnodeIC, ... }
+		Flags      cos.BitFlags `json:"flags"`             // enum { SnodeNonElectable, SnodeIC, ... }
+		Weight     uint32       `json:"weight,omitempty"`  // target capacity (GiB) - see Smap.Placement
+		Offload    uint8        `json:"offload,omitempty"` // percentage of the target's HRW share to offload (100 - weight) - see apc.ActSetWeight
+		Codecs     cos.BitFlags `json:"codecs,omitempty"`  // advertised by the node itself - see SnodeCodecMsgpack
+		Topo       string       `json:"topo,omitempty"`    // network topology labels: "zone/rack" (see cmn.TopologyConf)
 		idDigest   uint64       // cached
 		nmr        NetNamer     // (multihoming)
+		dnmr       *dataNamer   // (intra-cluster data multihoming)
 	}
 
 	Nodes   []*Snode          // slice of Snodes
@@ -89,9 +104,10 @@ type (
 		Ext          any     `json:"ext,omitempty"`
 		Pmap         NodeMap `json:"pmap"` // [pid => Snode]
 		Primary      *Snode  `json:"proxy_si"`
-		Tmap         NodeMap `json:"tmap"`          // [tid => Snode]
-		UUID         string  `json:"uuid"`          // is assigned once at creation time, never changes
-		CreationTime string  `json:"creation_time"` // creation timestamp
+		Tmap         NodeMap `json:"tmap"`                // [tid => Snode]
+		UUID         string  `json:"uuid"`                // is assigned once at creation time, never changes
+		CreationTime string  `json:"creation_time"`       // creation timestamp
+		Placement    string  `json:"placement,omitempty"` // enum { apc.PlacementHRW (default), apc.PlacementCapacity }
 		Version      int64   `json:"version,string"`
 	}
 )
@@ -168,7 +184,7 @@ func (d *Snode) URL(network string) (u string) {
 	case cmn.NetIntraControl:
 		u = d.ControlNet.URL
 	case cmn.NetIntraData:
-		u = d.DataNet.URL
+		u = d.dataURL()
 	default: // (exclusively via HrwMultiHome)
 		debug.Assert(strings.Contains(network, "://"), network) // "is URI" per rfc2396.txt
 		u = network
@@ -185,6 +201,9 @@ func (d *Snode) Eq(o *Snode) (eq bool) {
 		if err := d.NetEq(o); err != nil {
 			nlog.Warningln(err)
 			eq = false
+		} else if d.Weight != o.Weight {
+			nlog.Warningf("%s: capacity weight changed: %d => %d", d.StringEx(), d.Weight, o.Weight)
+			eq = false
 		}
 	}
 	return eq
@@ -202,9 +221,20 @@ func (d *Snode) NetEq(o *Snode) error {
 	if !d.DataNet.eq(&o.DataNet) {
 		return &errNetInfoChanged{name, "data", d.DataNet.TCPEndpoint(), o.DataNet.TCPEndpoint()}
 	}
+	if !netsEq(d.DataExtra, o.DataExtra) {
+		return &errNetInfoChanged{name, "data-extra", _eps(d.DataExtra), _eps(o.DataExtra)}
+	}
 	return nil
 }
 
+func _eps(nets []NetInfo) string {
+	eps := make([]string, len(nets))
+	for i := range nets {
+		eps[i] = nets[i].TCPEndpoint()
+	}
+	return "[" + strings.Join(eps, ",") + "]"
+}
+
 func (d *Snode) Validate() error {
 	if d == nil {
 		return errors.New("invalid Snode: nil")
@@ -229,6 +259,12 @@ func (d *Snode) isDupNet(n *Snode, smap *Smap) error {
 		du = []string{d.PubNet.URL, d.ControlNet.URL, d.DataNet.URL}
 		nu = []string{n.PubNet.URL, n.ControlNet.URL, n.DataNet.URL}
 	)
+	for i := range d.DataExtra {
+		du = append(du, d.DataExtra[i].URL)
+	}
+	for i := range n.DataExtra {
+		nu = append(nu, n.DataExtra[i].URL)
+	}
 	for _, ni := range nu {
 		np, err := url.Parse(ni)
 		if err != nil {
@@ -313,6 +349,33 @@ func (d *Snode) HasURL(rawURL string) bool {
 func (d *Snode) IsProxy() bool  { return d.DaeType == apc.Proxy }
 func (d *Snode) IsTarget() bool { return d.DaeType == apc.Target }
 
+// whether the node can decode msgpack-encoded control messages (older nodes can't)
+func (d *Snode) AcceptsMsgpack() bool { return d.Codecs.IsSet(SnodeCodecMsgpack) }
+
+// whether the node can decode zstd-compressed control-plane payloads (ditto)
+func (d *Snode) AcceptsZstd() bool { return d.Codecs.IsSet(SnodeCodecZstd) }
+
+// network topology (see Snode.Topo and cmn.TopologyConf)
+func Topo(zone, rack string) string {
+	if zone == "" && rack == "" {
+		return ""
+	}
+	return zone + "/" + rack
+}
+
+func (d *Snode) Zone() string {
+	zone, _, _ := strings.Cut(d.Topo, "/")
+	return zone
+}
+
+func (d *Snode) Rack() string {
+	_, rack, _ := strings.Cut(d.Topo, "/")
+	return rack
+}
+
+// both nodes are labeled and located in different racks (or zones)
+func (d *Snode) CrossRack(o *Snode) bool { return d.Topo != "" && o.Topo != "" && d.Topo != o.Topo }
+
 // node flags
 func (d *Snode) InMaintOrDecomm() bool { return d.Flags.IsAnySet(SnodeMaintDecomm) }
 func (d *Snode) InMaint() bool         { return d.Flags.IsAnySet(SnodeMaint) }
@@ -656,6 +719,13 @@ func (m *Smap) StrIC(node *Snode) string {
 	return strings.Join(all, ",")
 }
 
+// IC size that scales with the number of proxies: DfltCountIC in clusters with
+// up to 3*proxiesPerIC proxies, plus one IC member per each additional proxiesPerIC
+// (but no more than cmn.MaxCountIC)
+func AutoCountIC(numProxies int) int {
+	return min(max(DfltCountIC, numProxies/proxiesPerIC), cmn.MaxCountIC)
+}
+
 func (m *Smap) ICCount() (count int) {
 	for _, psi := range m.Pmap {
 		if psi.IsIC() {
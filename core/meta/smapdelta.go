@@ -0,0 +1,621 @@
+// Package meta: cluster-level metadata
+/*
+ * Copyright (c) 2024, NVIDIA CORPORATION. All rights reserved.
+ */
+package meta
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+
+	"github.com/NVIDIA/aistore/cmn/cos"
+	"github.com/OneOfOne/xxhash"
+)
+
+// Compact cluster map - for very large clusters (thousands of nodes) where JSON-encoding
+// and broadcasting the entire Smap upon every change becomes a bottleneck:
+// - SmapDelta carries only the nodes that were added, changed, or removed since a given
+//   (base) version; zero base means all nodes, i.e., the entire Smap;
+// - the delta is packed (cos.BytePack) with interned strings: each unique string (hostname,
+//   port, node type) is written once and referenced by its index; URLs that are derived
+//   from (scheme, hostname, port) - the usual case - are not written at all;
+// - Smap digest is an order-independent (XOR) combination of per-node digests, which
+//   makes it possible to compute the new digest incrementally - from the base digest and
+//   only the nodes that have changed; receivers verify the digest upon applying the delta.
+
+const (
+	smapDeltaVer1 = 1 // no intra-cluster data multihoming (Snode.DataExtra)
+	smapDeltaVer2 = 2 // no network topology labels (Snode.Topo)
+	smapDeltaVer3 = 3 // no control message codecs (Snode.Codecs)
+	smapDeltaVer  = 4
+)
+
+// NetInfo.URL (see packNet)
+const (
+	urlLiteral = iota
+	urlHTTP
+	urlHTTPS
+)
+
+type (
+	SmapDelta struct {
+		UUID         string
+		CreationTime string
+		Primary      string // primary ID
+		Placement    string
+		Nodes        Nodes    // added or changed (all nodes when Base == 0)
+		Removed      []string // node IDs
+		Base         int64    // base version (zero: entire Smap)
+		Version      int64
+		Digest       uint64 // of the resulting Smap
+	}
+	// string interning (pack)
+	strTab struct {
+		idx  map[string]uint32
+		strs []string
+	}
+)
+
+// interface guard
+var _ cos.Packer = (*SmapDelta)(nil)
+
+//
+// digest
+//
+
+func nodeDigest(d *Snode) uint64 {
+	h := xxhash.New64()
+	h.WriteString(d.DaeID)
+	h.WriteString(d.DaeType)
+	for _, ni := range []*NetInfo{&d.PubNet, &d.ControlNet, &d.DataNet} {
+		h.WriteString(ni.Hostname)
+		h.WriteString(ni.Port)
+		h.WriteString(ni.URL)
+	}
+	for i := range d.PubExtra {
+		ni := &d.PubExtra[i]
+		h.WriteString(ni.Hostname)
+		h.WriteString(ni.Port)
+		h.WriteString(ni.URL)
+	}
+	for i := range d.DataExtra {
+		ni := &d.DataExtra[i]
+		h.WriteString(ni.Hostname)
+		h.WriteString(ni.Port)
+		h.WriteString(ni.URL)
+	}
+	var b [13]byte
+	for i := range 8 {
+		b[i] = byte(d.Flags >> (8 * i))
+	}
+	for i := range 4 {
+		b[8+i] = byte(d.Weight >> (8 * i))
+	}
+	b[12] = d.Offload
+	h.Write(b[:])
+	if d.Topo != "" { // (digest remains the same for unlabeled nodes)
+		h.WriteString(d.Topo)
+	}
+	if d.Codecs != 0 { // (ditto)
+		for i := range 8 {
+			b[i] = byte(d.Codecs >> (8 * i))
+		}
+		h.Write(b[:8])
+	}
+	return h.Sum64()
+}
+
+func hdrDigest(uuid, ctime, pid, placement string) uint64 {
+	h := xxhash.New64()
+	for _, s := range []string{uuid, ctime, pid, placement} {
+		h.WriteString(s)
+		h.WriteString("\x00")
+	}
+	return h.Sum64()
+}
+
+func (m *Smap) hdrDigest() uint64 {
+	var pid string
+	if m.Primary != nil {
+		pid = m.Primary.ID()
+	}
+	return hdrDigest(m.UUID, m.CreationTime, pid, m.Placement)
+}
+
+// Digest computes the digest of the entire Smap (see also SmapDelta)
+func (m *Smap) Digest() (digest uint64) {
+	digest = m.hdrDigest()
+	for _, mm := range []NodeMap{m.Tmap, m.Pmap} {
+		for _, si := range mm {
+			digest ^= nodeDigest(si)
+		}
+	}
+	return digest
+}
+
+func nodeEq(a, b *Snode) bool {
+	if a.DaeType != b.DaeType || a.Flags != b.Flags || a.Weight != b.Weight || a.Offload != b.Offload || a.Codecs != b.Codecs {
+		return false
+	}
+	if a.Topo != b.Topo {
+		return false
+	}
+	if a.PubNet != b.PubNet || a.ControlNet != b.ControlNet || a.DataNet != b.DataNet {
+		return false
+	}
+	return netsEq(a.PubExtra, b.PubExtra) && netsEq(a.DataExtra, b.DataExtra)
+}
+
+func netsEq(a, b []NetInfo) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i].Hostname != b[i].Hostname || a[i].Port != b[i].Port || a[i].URL != b[i].URL {
+			return false
+		}
+	}
+	return true
+}
+
+///////////////
+// SmapDelta //
+///////////////
+
+// NewSmapDelta returns the delta that transforms `prev` into `cur`; `prevDigest` is the digest
+// of `prev` (see Smap.Digest). Nil `prev` results in the delta containing the entire `cur`.
+func NewSmapDelta(prev, cur *Smap, prevDigest uint64) *SmapDelta {
+	delta := &SmapDelta{
+		UUID:         cur.UUID,
+		CreationTime: cur.CreationTime,
+		Placement:    cur.Placement,
+		Version:      cur.Version,
+	}
+	if cur.Primary != nil {
+		delta.Primary = cur.Primary.ID()
+	}
+	if prev == nil {
+		delta.Nodes = make(Nodes, 0, cur.Count())
+		for _, mm := range []NodeMap{cur.Tmap, cur.Pmap} {
+			for _, si := range mm {
+				delta.Nodes = append(delta.Nodes, si)
+			}
+		}
+		delta.Digest = cur.Digest()
+		return delta
+	}
+
+	delta.Base = prev.Version
+	digest := prevDigest ^ prev.hdrDigest() ^ cur.hdrDigest()
+	for _, mm := range []NodeMap{cur.Tmap, cur.Pmap} {
+		for id, si := range mm {
+			osi := prev.GetNode(id)
+			switch {
+			case osi == nil:
+				digest ^= nodeDigest(si)
+			case !nodeEq(osi, si):
+				digest ^= nodeDigest(osi) ^ nodeDigest(si)
+			default:
+				continue
+			}
+			delta.Nodes = append(delta.Nodes, si)
+		}
+	}
+	for _, mm := range []NodeMap{prev.Tmap, prev.Pmap} {
+		for id, osi := range mm {
+			if cur.GetNode(id) == nil {
+				digest ^= nodeDigest(osi)
+				delta.Removed = append(delta.Removed, id)
+			}
+		}
+	}
+	delta.Digest = digest
+	return delta
+}
+
+// Apply returns the new Smap - the result of applying the delta to the `base`
+// (nil when the delta contains the entire Smap); `baseDigest` is the digest of the base.
+func (delta *SmapDelta) Apply(base *Smap, baseDigest uint64) (*Smap, error) {
+	var (
+		digest uint64
+		smap   = &Smap{
+			UUID:         delta.UUID,
+			CreationTime: delta.CreationTime,
+			Placement:    delta.Placement,
+			Version:      delta.Version,
+		}
+	)
+	if delta.Base == 0 {
+		smap.Tmap, smap.Pmap = make(NodeMap, len(delta.Nodes)), make(NodeMap, 8)
+	} else {
+		if base == nil || base.Version != delta.Base {
+			return nil, fmt.Errorf("smap-delta v%d: base version mismatch (have %s, expecting v%d)",
+				delta.Version, base, delta.Base)
+		}
+		if base.UUID != delta.UUID {
+			return nil, fmt.Errorf("smap-delta v%d: cluster UUID mismatch (%q vs %q)", delta.Version, base.UUID, delta.UUID)
+		}
+		digest = baseDigest ^ base.hdrDigest()
+		smap.Tmap, smap.Pmap = make(NodeMap, len(base.Tmap)+len(delta.Nodes)), make(NodeMap, len(base.Pmap)+1)
+		for id, si := range base.Tmap {
+			smap.Tmap[id] = si.Clone()
+		}
+		for id, si := range base.Pmap {
+			smap.Pmap[id] = si.Clone()
+		}
+		for _, id := range delta.Removed {
+			osi := smap.GetNode(id)
+			if osi == nil {
+				return nil, fmt.Errorf("smap-delta v%d: removed node %q not found in %s", delta.Version, id, base)
+			}
+			digest ^= nodeDigest(osi)
+			delete(smap.Tmap, id)
+			delete(smap.Pmap, id)
+		}
+	}
+	for _, si := range delta.Nodes {
+		if osi := smap.GetNode(si.ID()); osi != nil {
+			digest ^= nodeDigest(osi)
+			delete(smap.Tmap, si.ID())
+			delete(smap.Pmap, si.ID())
+		}
+		digest ^= nodeDigest(si)
+		if si.IsProxy() {
+			smap.Pmap[si.ID()] = si
+		} else {
+			smap.Tmap[si.ID()] = si
+		}
+	}
+	if smap.Primary = smap.GetProxy(delta.Primary); smap.Primary == nil {
+		return nil, fmt.Errorf("smap-delta v%d: primary %q not found", delta.Version, delta.Primary)
+	}
+	digest ^= smap.hdrDigest()
+	if digest != delta.Digest {
+		return nil, fmt.Errorf("smap-delta v%d: digest mismatch (%x vs %x)", delta.Version, digest, delta.Digest)
+	}
+	return smap, nil
+}
+
+func (delta *SmapDelta) String() string {
+	return "smap-delta v" + strconv.FormatInt(delta.Base, 10) + "=>v" + strconv.FormatInt(delta.Version, 10) +
+		"(" + strconv.Itoa(len(delta.Nodes)) + ", -" + strconv.Itoa(len(delta.Removed)) + ")"
+}
+
+//
+// pack/unpack
+//
+
+func (tab *strTab) add(s string) {
+	if _, ok := tab.idx[s]; !ok {
+		tab.idx[s] = uint32(len(tab.strs))
+		tab.strs = append(tab.strs, s)
+	}
+}
+
+func (delta *SmapDelta) strTab() *strTab {
+	tab := &strTab{idx: make(map[string]uint32, 2*len(delta.Nodes)+4)}
+	for _, si := range delta.Nodes {
+		tab.add(si.DaeID)
+		tab.add(si.DaeType)
+		tab.add(si.Topo)
+		for _, ni := range si.nets() {
+			tab.add(ni.Hostname)
+			tab.add(ni.Port)
+			if urlKind(ni) == urlLiteral {
+				tab.add(ni.URL)
+			}
+		}
+	}
+	return tab
+}
+
+// packing order: pub, control, data, extra pub, extra data
+func (d *Snode) nets() []*NetInfo {
+	nets := make([]*NetInfo, 0, 3+len(d.PubExtra)+len(d.DataExtra))
+	nets = append(nets, &d.PubNet, &d.ControlNet, &d.DataNet)
+	for i := range d.PubExtra {
+		nets = append(nets, &d.PubExtra[i])
+	}
+	for i := range d.DataExtra {
+		nets = append(nets, &d.DataExtra[i])
+	}
+	return nets
+}
+
+// to remain compatible with (older) nodes that don't support data multihoming,
+// topology labels, and/or control message codecs
+func (delta *SmapDelta) ver() byte {
+	ver := byte(smapDeltaVer1)
+	for _, si := range delta.Nodes {
+		switch {
+		case si.Codecs != 0:
+			return smapDeltaVer
+		case si.Topo != "":
+			ver = smapDeltaVer3
+		case len(si.DataExtra) > 0:
+			ver = max(ver, smapDeltaVer2)
+		}
+	}
+	return ver
+}
+
+func urlKind(ni *NetInfo) byte {
+	ep := _ep(ni.Hostname, ni.Port)
+	switch ni.URL {
+	case "http://" + ep:
+		return urlHTTP
+	case "https://" + ep:
+		return urlHTTPS
+	default:
+		return urlLiteral
+	}
+}
+
+func (delta *SmapDelta) PackedSize() int {
+	return delta.packedSize(delta.strTab())
+}
+
+func (delta *SmapDelta) packedSize(tab *strTab) (size int) {
+	size = 1 + 3*cos.SizeofI64 // ver, base, version, digest
+	size += cos.PackedStrLen(delta.UUID) + cos.PackedStrLen(delta.CreationTime) +
+		cos.PackedStrLen(delta.Primary) + cos.PackedStrLen(delta.Placement)
+	size += cos.SizeofLen
+	for _, s := range tab.strs {
+		size += cos.PackedStrLen(s)
+	}
+	size += cos.SizeofLen
+	ver := delta.ver()
+	for _, si := range delta.Nodes {
+		// id, type, flags, weight, offload, num-nets
+		size += 2*cos.SizeofI32 + cos.SizeofI64 + cos.SizeofI32 + 1 + 1
+		if ver > smapDeltaVer1 {
+			size++ // num-data-extra
+		}
+		if ver > smapDeltaVer2 {
+			size += cos.SizeofI32 // topo
+		}
+		if ver > smapDeltaVer3 {
+			size += cos.SizeofI64 // codecs
+		}
+		for _, ni := range si.nets() {
+			size += 2*cos.SizeofI32 + 1 // hostname, port, url-kind
+			if urlKind(ni) == urlLiteral {
+				size += cos.SizeofI32
+			}
+		}
+	}
+	size += cos.SizeofLen
+	for _, id := range delta.Removed {
+		size += cos.PackedStrLen(id)
+	}
+	return size
+}
+
+func (delta *SmapDelta) Pack(packer *cos.BytePack) {
+	delta.pack(packer, delta.strTab())
+}
+
+func (delta *SmapDelta) pack(packer *cos.BytePack, tab *strTab) {
+	ver := delta.ver()
+	packer.WriteByte(ver)
+	packer.WriteInt64(delta.Base)
+	packer.WriteInt64(delta.Version)
+	packer.WriteUint64(delta.Digest)
+	packer.WriteString(delta.UUID)
+	packer.WriteString(delta.CreationTime)
+	packer.WriteString(delta.Primary)
+	packer.WriteString(delta.Placement)
+
+	packer.WriteUint32(uint32(len(tab.strs)))
+	for _, s := range tab.strs {
+		packer.WriteString(s)
+	}
+	packer.WriteUint32(uint32(len(delta.Nodes)))
+	for _, si := range delta.Nodes {
+		packer.WriteUint32(tab.idx[si.DaeID])
+		packer.WriteUint32(tab.idx[si.DaeType])
+		packer.WriteUint64(uint64(si.Flags))
+		packer.WriteUint32(si.Weight)
+		packer.WriteByte(si.Offload)
+		nets := si.nets()
+		packer.WriteByte(byte(len(nets) - len(si.DataExtra)))
+		if ver > smapDeltaVer1 {
+			packer.WriteByte(byte(len(si.DataExtra)))
+		}
+		if ver > smapDeltaVer2 {
+			packer.WriteUint32(tab.idx[si.Topo])
+		}
+		if ver > smapDeltaVer3 {
+			packer.WriteUint64(uint64(si.Codecs))
+		}
+		for _, ni := range nets {
+			packer.WriteUint32(tab.idx[ni.Hostname])
+			packer.WriteUint32(tab.idx[ni.Port])
+			kind := urlKind(ni)
+			packer.WriteByte(kind)
+			if kind == urlLiteral {
+				packer.WriteUint32(tab.idx[ni.URL])
+			}
+		}
+	}
+	packer.WriteUint32(uint32(len(delta.Removed)))
+	for _, id := range delta.Removed {
+		packer.WriteString(id)
+	}
+}
+
+// Bytes returns the packed delta.
+func (delta *SmapDelta) Bytes() []byte {
+	var (
+		tab    = delta.strTab()
+		packer = cos.NewPacker(nil, delta.packedSize(tab))
+	)
+	delta.pack(packer, tab)
+	return packer.Bytes()
+}
+
+func (delta *SmapDelta) Unpack(unpacker *cos.ByteUnpack) (err error) {
+	var (
+		ver  byte
+		strs []string
+		cnt  uint32
+	)
+	if ver, err = unpacker.ReadByte(); err != nil {
+		return err
+	}
+	if ver < smapDeltaVer1 || ver > smapDeltaVer {
+		return fmt.Errorf("smap-delta: unsupported version %d (expecting %d thru %d)", ver, smapDeltaVer1, smapDeltaVer)
+	}
+	if delta.Base, err = unpacker.ReadInt64(); err != nil {
+		return err
+	}
+	if delta.Version, err = unpacker.ReadInt64(); err != nil {
+		return err
+	}
+	if delta.Digest, err = unpacker.ReadUint64(); err != nil {
+		return err
+	}
+	for _, ps := range []*string{&delta.UUID, &delta.CreationTime, &delta.Primary, &delta.Placement} {
+		if *ps, err = unpacker.ReadString(); err != nil {
+			return err
+		}
+	}
+
+	if cnt, err = unpacker.ReadUint32(); err != nil {
+		return err
+	}
+	if int(cnt) > unpacker.Len() {
+		return errors.New("smap-delta: invalid number of strings")
+	}
+	strs = make([]string, cnt)
+	for i := range strs {
+		if strs[i], err = unpacker.ReadString(); err != nil {
+			return err
+		}
+	}
+	str := func() (string, error) {
+		i, err := unpacker.ReadUint32()
+		if err != nil {
+			return "", err
+		}
+		if int(i) >= len(strs) {
+			return "", fmt.Errorf("smap-delta: string index %d out of range [0, %d)", i, len(strs))
+		}
+		return strs[i], nil
+	}
+
+	if cnt, err = unpacker.ReadUint32(); err != nil {
+		return err
+	}
+	if int(cnt) > unpacker.Len() {
+		return errors.New("smap-delta: invalid number of nodes")
+	}
+	delta.Nodes = make(Nodes, cnt)
+	for i := range delta.Nodes {
+		si := &Snode{}
+		if si.DaeID, err = str(); err != nil {
+			return err
+		}
+		if si.DaeType, err = str(); err != nil {
+			return err
+		}
+		flags, err := unpacker.ReadUint64()
+		if err != nil {
+			return err
+		}
+		si.Flags = cos.BitFlags(flags)
+		if si.Weight, err = unpacker.ReadUint32(); err != nil {
+			return err
+		}
+		if si.Offload, err = unpacker.ReadByte(); err != nil {
+			return err
+		}
+		n, err := unpacker.ReadByte()
+		if err != nil {
+			return err
+		}
+		if n < 3 {
+			return fmt.Errorf("smap-delta: %s: invalid number of networks %d", si.DaeID, n)
+		}
+		if n > 3 {
+			si.PubExtra = make([]NetInfo, n-3)
+		}
+		if ver > smapDeltaVer1 {
+			nd, err := unpacker.ReadByte()
+			if err != nil {
+				return err
+			}
+			if nd > 0 {
+				si.DataExtra = make([]NetInfo, nd)
+			}
+		}
+		if ver > smapDeltaVer2 {
+			if si.Topo, err = str(); err != nil {
+				return err
+			}
+		}
+		if ver > smapDeltaVer3 {
+			codecs, err := unpacker.ReadUint64()
+			if err != nil {
+				return err
+			}
+			si.Codecs = cos.BitFlags(codecs)
+		}
+		for _, ni := range si.nets() {
+			if err := unpackNet(unpacker, ni, str); err != nil {
+				return err
+			}
+		}
+		delta.Nodes[i] = si
+	}
+
+	if cnt, err = unpacker.ReadUint32(); err != nil {
+		return err
+	}
+	if int(cnt) > unpacker.Len() {
+		return errors.New("smap-delta: invalid number of removed nodes")
+	}
+	if cnt > 0 {
+		delta.Removed = make([]string, cnt)
+		for i := range delta.Removed {
+			if delta.Removed[i], err = unpacker.ReadString(); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func unpackNet(unpacker *cos.ByteUnpack, ni *NetInfo, str func() (string, error)) (err error) {
+	if ni.Hostname, err = str(); err != nil {
+		return err
+	}
+	if ni.Port, err = str(); err != nil {
+		return err
+	}
+	kind, err := unpacker.ReadByte()
+	if err != nil {
+		return err
+	}
+	switch kind {
+	case urlHTTP:
+		ni.URL = "http://" + _ep(ni.Hostname, ni.Port)
+	case urlHTTPS:
+		ni.URL = "https://" + _ep(ni.Hostname, ni.Port)
+	case urlLiteral:
+		ni.URL, err = str()
+	default:
+		err = fmt.Errorf("smap-delta: invalid URL kind %d", kind)
+	}
+	return err
+}
+
+// UnpackSmapDelta unpacks the delta (see SmapDelta.Bytes).
+func UnpackSmapDelta(b []byte) (*SmapDelta, error) {
+	delta := &SmapDelta{}
+	if err := delta.Unpack(cos.NewUnpacker(b)); err != nil {
+		return nil, err
+	}
+	return delta, nil
+}
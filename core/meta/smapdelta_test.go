@@ -0,0 +1,211 @@
+// Package meta_test: unit tests for the package
+/*
+ * Copyright (c) 2024, NVIDIA CORPORATION. All rights reserved.
+ */
+package meta_test
+
+import (
+	"fmt"
+
+	"github.com/NVIDIA/aistore/api/apc"
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/core/meta"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("SmapDelta", func() {
+	newNode := func(id, daeType string, i int) *meta.Snode {
+		si := &meta.Snode{DaeID: id, DaeType: daeType}
+		host := fmt.Sprintf("10.0.%d.%d", i/256, i%256)
+		si.PubNet.Init("http", host, "51081")
+		si.ControlNet.Init("http", host, "51082")
+		si.DataNet.Init("http", host, "51083")
+		return si
+	}
+	newSmap := func(numTargets int) *meta.Smap {
+		smap := &meta.Smap{
+			Tmap:         make(meta.NodeMap, numTargets),
+			Pmap:         make(meta.NodeMap, 1),
+			UUID:         "uuid",
+			CreationTime: "now",
+			Version:      10,
+		}
+		smap.Primary = newNode("p1", apc.Proxy, 0)
+		smap.Pmap.Add(smap.Primary)
+		for i := range numTargets {
+			smap.Tmap.Add(newNode(fmt.Sprintf("t%d", i), apc.Target, i+1))
+		}
+		return smap
+	}
+	clone := func(smap *meta.Smap) *meta.Smap {
+		dst := &meta.Smap{
+			Tmap:         make(meta.NodeMap, len(smap.Tmap)),
+			Pmap:         make(meta.NodeMap, len(smap.Pmap)),
+			UUID:         smap.UUID,
+			CreationTime: smap.CreationTime,
+			Version:      smap.Version,
+		}
+		for id, si := range smap.Tmap {
+			dst.Tmap[id] = si.Clone()
+		}
+		for id, si := range smap.Pmap {
+			dst.Pmap[id] = si.Clone()
+		}
+		dst.Primary = dst.GetProxy(smap.Primary.ID())
+		return dst
+	}
+	roundtrip := func(delta *meta.SmapDelta) *meta.SmapDelta {
+		unpacked, err := meta.UnpackSmapDelta(delta.Bytes())
+		Expect(err).NotTo(HaveOccurred())
+		return unpacked
+	}
+	expectSame := func(a, b *meta.Smap) {
+		Expect(a.Version).To(Equal(b.Version))
+		Expect(a.Primary.ID()).To(Equal(b.Primary.ID()))
+		Expect(a.Digest()).To(Equal(b.Digest()))
+		Expect(len(a.Tmap)).To(Equal(len(b.Tmap)))
+		Expect(len(a.Pmap)).To(Equal(len(b.Pmap)))
+		for id, si := range b.Tmap {
+			Expect(a.Tmap[id]).NotTo(BeNil())
+			Expect(a.Tmap[id].URL(cmn.NetPublic)).To(Equal(si.URL(cmn.NetPublic)))
+			Expect(a.Tmap[id].Flags).To(Equal(si.Flags))
+		}
+	}
+
+	It("should pack and apply the entire Smap", func() {
+		smap := newSmap(100)
+		delta := roundtrip(meta.NewSmapDelta(nil, smap, 0))
+		applied, err := delta.Apply(nil, 0)
+		Expect(err).NotTo(HaveOccurred())
+		expectSame(applied, smap)
+	})
+
+	It("should apply added, changed, and removed nodes", func() {
+		prev := newSmap(100)
+		cur := clone(prev)
+		cur.Version++
+		cur.Tmap.Add(newNode("t-new", apc.Target, 1000))
+		delete(cur.Tmap, "t7")
+		cur.Tmap["t9"].Flags = cur.Tmap["t9"].Flags.Set(meta.SnodeMaint)
+		cur.Tmap["t11"].PubNet.URL = "http://example.com:8080" // (not derived)
+
+		delta := roundtrip(meta.NewSmapDelta(prev, cur, prev.Digest()))
+		Expect(delta.Base).To(Equal(prev.Version))
+		Expect(delta.Nodes).To(HaveLen(3))
+		Expect(delta.Removed).To(Equal([]string{"t7"}))
+		Expect(delta.Digest).To(Equal(cur.Digest()))
+
+		applied, err := delta.Apply(prev, prev.Digest())
+		Expect(err).NotTo(HaveOccurred())
+		expectSame(applied, cur)
+		Expect(applied.Tmap["t11"].URL(cmn.NetPublic)).To(Equal("http://example.com:8080"))
+
+		// base remains intact
+		Expect(prev.Tmap["t7"]).NotTo(BeNil())
+		Expect(prev.Tmap["t9"].Flags.IsSet(meta.SnodeMaint)).To(BeFalse())
+	})
+
+	It("should fail to apply to a different base", func() {
+		prev := newSmap(10)
+		cur := clone(prev)
+		cur.Version++
+		delete(cur.Tmap, "t1")
+		delta := meta.NewSmapDelta(prev, cur, prev.Digest())
+
+		other := clone(prev)
+		other.Version--
+		_, err := delta.Apply(other, other.Digest())
+		Expect(err).To(HaveOccurred())
+
+		// same version, different content
+		other = clone(prev)
+		other.Tmap["t2"].Flags = other.Tmap["t2"].Flags.Set(meta.SnodeMaint)
+		_, err = delta.Apply(other, other.Digest())
+		Expect(err).To(HaveOccurred())
+	})
+
+	It("should pack multi-homed intra-cluster data endpoints", func() {
+		prev := newSmap(10)
+		cur := clone(prev)
+		cur.Version++
+		tsi := cur.Tmap["t3"]
+		tsi.PubExtra = make([]meta.NetInfo, 1)
+		tsi.PubExtra[0].Init("http", "10.1.0.4", "51081")
+		tsi.DataExtra = make([]meta.NetInfo, 2)
+		tsi.DataExtra[0].Init("http", "10.2.0.4", "51083")
+		tsi.DataExtra[1].Init("http", "10.3.0.4", "51083")
+
+		delta := roundtrip(meta.NewSmapDelta(prev, cur, prev.Digest()))
+		applied, err := delta.Apply(prev, prev.Digest())
+		Expect(err).NotTo(HaveOccurred())
+		expectSame(applied, cur)
+		asi := applied.Tmap["t3"]
+		Expect(asi.PubExtra).To(HaveLen(1))
+		Expect(asi.PubExtra[0].URL).To(Equal(tsi.PubExtra[0].URL))
+		Expect(asi.DataExtra).To(HaveLen(2))
+		Expect(asi.DataExtra[1].URL).To(Equal(tsi.DataExtra[1].URL))
+
+		// stripe
+		asi.InitNetNamer()
+		Expect(asi.DataURLs()).To(HaveLen(3))
+		seen := make(map[string]bool, 3)
+		for range 3 {
+			seen[asi.URL(cmn.NetIntraData)] = true
+		}
+		Expect(seen).To(HaveLen(3))
+	})
+
+	It("should pack topology labels", func() {
+		prev := newSmap(10)
+		cur := clone(prev)
+		cur.Version++
+		cur.Tmap["t1"].Topo = meta.Topo("z1", "r1")
+		cur.Tmap["t2"].Topo = meta.Topo("z1", "r1")
+		cur.Tmap["t3"].Topo = meta.Topo("z1", "r2")
+
+		delta := roundtrip(meta.NewSmapDelta(prev, cur, prev.Digest()))
+		Expect(delta.Nodes).To(HaveLen(3))
+		Expect(delta.Digest).To(Equal(cur.Digest()))
+		applied, err := delta.Apply(prev, prev.Digest())
+		Expect(err).NotTo(HaveOccurred())
+		expectSame(applied, cur)
+
+		t1, t2, t3, t4 := applied.Tmap["t1"], applied.Tmap["t2"], applied.Tmap["t3"], applied.Tmap["t4"]
+		Expect(t1.Zone()).To(Equal("z1"))
+		Expect(t1.Rack()).To(Equal("r1"))
+		Expect(t1.CrossRack(t2)).To(BeFalse())
+		Expect(t1.CrossRack(t3)).To(BeTrue())
+		Expect(t4.Topo).To(BeEmpty())
+		Expect(t1.CrossRack(t4)).To(BeFalse()) // (unlabeled)
+	})
+
+	It("should carry control message codecs", func() {
+		prev := newSmap(10)
+		cur := clone(prev)
+		cur.Version++
+		cur.Tmap["t1"].Codecs = meta.SnodeCodecMsgpack | meta.SnodeCodecZstd
+		cur.Tmap["t2"].Codecs = meta.SnodeCodecMsgpack
+
+		delta := roundtrip(meta.NewSmapDelta(prev, cur, prev.Digest()))
+		Expect(delta.Nodes).To(HaveLen(2))
+		Expect(delta.Digest).To(Equal(cur.Digest()))
+		Expect(delta.Digest).NotTo(Equal(prev.Digest()))
+		applied, err := delta.Apply(prev, prev.Digest())
+		Expect(err).NotTo(HaveOccurred())
+		expectSame(applied, cur)
+
+		Expect(applied.Tmap["t1"].AcceptsMsgpack()).To(BeTrue())
+		Expect(applied.Tmap["t1"].AcceptsZstd()).To(BeTrue())
+		Expect(applied.Tmap["t2"].AcceptsZstd()).To(BeFalse())
+		Expect(applied.Tmap["t3"].Codecs).To(BeZero())
+	})
+
+	It("should be much smaller than JSON", func() {
+		prev := newSmap(5000)
+		cur := clone(prev)
+		cur.Version++
+		cur.Tmap.Add(newNode("t-new", apc.Target, 6000))
+		Expect(len(meta.NewSmapDelta(prev, cur, prev.Digest()).Bytes())).To(BeNumerically("<", 1024))
+	})
+})
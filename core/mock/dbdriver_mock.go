@@ -85,7 +85,7 @@ func (bd *DBDriver) List(collection, pattern string) ([]string, error) {
 		if strings.HasPrefix(k, filter) {
 			_, key := kvdb.ParsePath(k)
 			if key != "" {
-				keys = append(keys, k)
+				keys = append(keys, key)
 			}
 		}
 	}
@@ -101,7 +101,7 @@ func (bd *DBDriver) DeleteCollection(collection string) error {
 		return err
 	}
 	for _, k := range keys {
-		delete(bd.values, k)
+		delete(bd.values, bd.makePath(collection, k))
 	}
 	return nil
 }
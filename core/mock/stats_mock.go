@@ -5,6 +5,7 @@
 package mock
 
 import (
+	"github.com/NVIDIA/aistore/cmn"
 	"github.com/NVIDIA/aistore/cmn/cos"
 	"github.com/NVIDIA/aistore/core/meta"
 	"github.com/NVIDIA/aistore/stats"
@@ -34,5 +35,10 @@ func (*StatsTracker) RegExtMetric(*meta.Snode, string, string, *stats.Extra)
 func (*StatsTracker) GetMetricNames() cos.StrKVs                                { return nil }
 func (*StatsTracker) GetStats() *stats.Node                                     { return nil }
 func (*StatsTracker) GetStatsV322() *stats.NodeV322                             { return nil }
+func (*StatsTracker) GetStatsHistory(int64, int64) *stats.History               { return nil }
+func (*StatsTracker) AddTop(*cmn.Bck, string, int64)                            {}
+func (*StatsTracker) GetTop(int) *stats.TopReport                               { return nil }
+func (*StatsTracker) GetCapHistory() *stats.CapHistory                          { return nil }
+func (*StatsTracker) AddLatHist(*cmn.Bck, string, int64)                        {}
 func (*StatsTracker) ResetStats(bool)                                           {}
 func (*StatsTracker) IsPrometheus() bool                                        { return false }
@@ -52,6 +52,7 @@ func (*TargetMock) PutObject(*core.LOM, *core.PutParams) error
 func (*TargetMock) FinalizeObj(*core.LOM, string, core.Xact, cmn.OWT) (int, error) { return 0, nil }
 func (*TargetMock) EvictObject(*core.LOM) (int, error)                             { return 0, nil }
 func (*TargetMock) DeleteObject(*core.LOM, bool) (int, error)                      { return 0, nil }
+func (*TargetMock) DeleteLocal(*core.LOM) (int, error)                             { return 0, nil }
 func (*TargetMock) Promote(*core.PromoteParams) (int, error)                       { return 0, nil }
 func (t *TargetMock) Backend(bck *meta.Bck) core.Backend                           { return t.Backends[bck.Provider] }
 func (*TargetMock) HeadObjT2T(*core.LOM, *meta.Snode) bool                         { return false }
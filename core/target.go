@@ -62,6 +62,11 @@ type (
 		DryRun    bool
 		LatestVer bool // can be used without changing bucket's 'versioning.validate_warm_get'; see also: QparamLatestVer
 		Sync      bool // ditto -  bucket's 'versioning.synchronize'
+		COW       bool // when local, reference source data rather than copying it (see lcow.go)
+
+		// (optional) called exactly once upon completion: asynchronously, when the object
+		// gets transmitted via data mover; otherwise, prior to returning from CopyObject
+		Sent func(err error)
 	}
 
 	// blob
@@ -119,6 +124,7 @@ type (
 		FinalizeObj(lom *LOM, workFQN string, xctn Xact, owt cmn.OWT) (ecode int, err error)
 		EvictObject(lom *LOM) (ecode int, err error)
 		DeleteObject(lom *LOM, evict bool) (ecode int, err error)
+		DeleteLocal(lom *LOM) (ecode int, err error) // when already deleted via BatchDeleter
 
 		GetCold(ctx context.Context, lom *LOM, owt cmn.OWT) (ecode int, err error)
 
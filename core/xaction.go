@@ -66,10 +66,33 @@ type (
 		InObjsAdd(int, int64)  // receive
 		InBytes() int64
 		OutBytes() int64
+
+		// resource accounting (see Res below)
+		DiskReadAdd(int64)
+		DiskWriteAdd(int64)
+		NetAdd(int64) // in addition to transmit and receive (above), e.g. remote backend traffic
+		CPUAdd(time.Duration)
+		ResActivity() int64
 	}
 )
 
+// xaction log levels (see XactLogEntry)
+const (
+	XlogInfo  = "info"
+	XlogWarn  = "warning"
+	XlogError = "error"
+	XlogRetry = "retry"
+)
+
 type (
+	// structured log event captured by (and retrievable with) the xaction - see xact/xlog.go
+	XactLogEntry struct {
+		Time  time.Time `json:"time"`
+		Level string    `json:"level"`          // Xlog* enum above
+		Node  string    `json:"node,omitempty"` // peer node (e.g., target that failed to respond), if any
+		Msg   string    `json:"msg"`
+	}
+
 	Stats struct {
 		Objs     int64 `json:"loc-objs,string"`  // locally processed
 		Bytes    int64 `json:"loc-bytes,string"` //
@@ -78,6 +101,13 @@ type (
 		InObjs   int64 `json:"in-objs,string"`   // receive
 		InBytes  int64 `json:"in-bytes,string"`
 	}
+	// node resources consumed by (attributed to) a given xaction
+	Res struct {
+		DiskRead  int64 `json:"disk-read,string"`  // bytes read from local mountpaths
+		DiskWrite int64 `json:"disk-write,string"` // bytes written to local mountpaths
+		Net       int64 `json:"net,string"`        // bytes transferred over network: intra-cluster and remote backends
+		CPU       int64 `json:"cpu-ns,string"`     // approximate CPU time, in nanoseconds (see xreg.RegResSampler)
+	}
 	Snap struct {
 		// xaction-specific stats counters
 		Ext any `json:"ext"`
@@ -92,16 +122,27 @@ type (
 		Kind      string    `json:"kind"`
 
 		// extended error info
-		AbortErr string `json:"abort-err"`
-		Err      string `json:"err"`
+		AbortErr    string           `json:"abort-err"`
+		AbortReason *cmn.AbortReason `json:"abort-reason,omitempty"` // who aborted and why
+		Err         string           `json:"err"`
 
 		// rebalance-only
 		RebID int64 `json:"glob.id,string"`
 
+		// captured log events (only when requested - see xact.QueryMsg.Log)
+		Log        []XactLogEntry `json:"log,omitempty"`
+		LogDropped int            `json:"log-dropped,omitempty"` // overwritten (oldest) events
+
+		// background xactions: paused (preempted) under foreground load - see xact.PrioBackground
+		PausedTime time.Duration `json:"paused-time,omitempty"` // cumulative, including the current pause
+		PausedCnt  int64         `json:"paused-cnt,omitempty"`  // number of pauses
+
 		// common runtime: stats counters (above) and state
 		Stats    Stats `json:"stats"`
+		Res      Res   `json:"res"`
 		AbortedX bool  `json:"aborted"`
 		IdleX    bool  `json:"is_idle"`
+		PausedX  bool  `json:"is_paused,omitempty"`
 	}
 	AllRunningInOut struct {
 		Kind    string
@@ -116,6 +157,7 @@ type (
 
 func (snp *Snap) IsAborted() bool { return snp.AbortedX }
 func (snp *Snap) IsIdle() bool    { return snp.IdleX }
+func (snp *Snap) IsPaused() bool  { return snp.PausedX }
 func (snp *Snap) Started() bool   { return !snp.StartTime.IsZero() }
 func (snp *Snap) Running() bool   { return snp.Started() && !snp.IsAborted() && snp.EndTime.IsZero() }
 func (snp *Snap) Finished() bool  { return snp.Started() && !snp.EndTime.IsZero() }
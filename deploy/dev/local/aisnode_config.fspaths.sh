@@ -28,7 +28,8 @@ cat > $AIS_CONF_FILE <<EOL
 	"periodic": {
 		"stats_time":        "10s",
 		"notif_time":        "30s",
-		"retry_sync_time":   "2s"
+		"retry_sync_time":   "2s",
+		"stats_history":     "${AIS_STATS_HISTORY:-24h}"
 	},
 	"timeout": {
 		"cplane_operation":     "2s",
@@ -107,7 +108,12 @@ cat > $AIS_CONF_FILE <<EOL
 	"net": {
 		"l4": {
 			"proto":              "tcp",
-			"sndrcv_buf_size":    ${SNDRCV_BUF_SIZE:-131072}
+			"sndrcv_buf_size":    ${SNDRCV_BUF_SIZE:-131072},
+			"dscp": {
+				"public":        0,
+				"intra_control": 0,
+				"intra_data":    0
+			}
 		},
 		"http": {
 			"use_https":         ${AIS_USE_HTTPS:-false},
@@ -787,6 +787,7 @@ func (c *getJogger) restoreEncoded(ctx *restoreCtx) error {
 	}
 
 	c.parent.ObjsAdd(1, ctx.meta.Size)
+	c.parent.DiskWriteAdd(ctx.meta.Size)
 
 	// main replica is ready to download by a client.
 	if err := c.uploadRestoredSlices(ctx, restored); err != nil {
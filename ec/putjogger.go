@@ -37,6 +37,7 @@ type (
 		dataSlices   int              // the number of data slices
 		paritySlices int              // the number of parity slices
 		cksums       []*cos.CksumHash // checksums of parity slices (filled by reed-solomon)
+		hpipes       []*cos.HashPipe  // parity slices' hashing pipelines (when enabled - see cos.IsHashPipe)
 		slices       []*slice         // all EC slices (in the order of slice IDs)
 		targets      []*meta.Snode    // target list (in the order of slice IDs: targets[i] receives slices[i])
 	}
@@ -344,16 +345,57 @@ func (c *putJogger) createCopies(ctx *encodeCtx) error {
 
 func checksumDataSlices(ctx *encodeCtx, cksmReaders []io.Reader, cksumType string) error {
 	debug.Assert(cksumType != "") // caller checks for 'none'
+	if !cos.IsHashPipe() {
+		for i, reader := range cksmReaders {
+			if err := checksumDataSlice(ctx, i, reader, cksumType); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	// hashing pipeline enabled: checksum all data slices concurrently
+	// (the readers are independent sections of the replica)
+	var (
+		wg   sync.WaitGroup
+		errs = make([]error, len(cksmReaders))
+	)
 	for i, reader := range cksmReaders {
-		_, cksum, err := cos.CopyAndChecksum(io.Discard, reader, nil, cksumType)
+		wg.Add(1)
+		go func(i int, reader io.Reader) {
+			errs[i] = checksumDataSlice(ctx, i, reader, cksumType)
+			wg.Done()
+		}(i, reader)
+	}
+	wg.Wait()
+	for _, err := range errs {
 		if err != nil {
 			return err
 		}
-		ctx.slices[i].cksum = cksum.Clone()
 	}
 	return nil
 }
 
+func checksumDataSlice(ctx *encodeCtx, i int, reader io.Reader, cksumType string) error {
+	_, cksum, err := cos.CopyAndChecksum(io.Discard, reader, nil, cksumType)
+	if err != nil {
+		return err
+	}
+	ctx.slices[i].cksum = cksum.Clone()
+	return nil
+}
+
+// parity slice writer that also computes the slice's checksum
+// (asynchronously, when the hashing pipeline is enabled)
+func (ctx *encodeCtx) cksumWriter(i int, w io.Writer, cksumType string) io.Writer {
+	ctx.cksums[i] = cos.NewCksumHash(cksumType)
+	if !cos.IsHashPipe() {
+		return cos.NewWriterMulti(w, ctx.cksums[i].H)
+	}
+	hp := cos.NewHashPipe(ctx.cksums[i].H)
+	ctx.hpipes = append(ctx.hpipes, hp)
+	return cos.NewWriterMulti(w, hp)
+}
+
 // generateSlicesToMemory gets FQN to the original file and encodes it into EC slices
 // writers are slices created by EC encoding process(memory is allocated)
 func generateSlicesToMemory(ctx *encodeCtx) error {
@@ -368,8 +410,7 @@ func generateSlicesToMemory(ctx *encodeCtx) error {
 		if cksumType == cos.ChecksumNone {
 			sliceWriters[i] = writer
 		} else {
-			ctx.cksums[i] = cos.NewCksumHash(cksumType)
-			sliceWriters[i] = cos.NewWriterMulti(writer, ctx.cksums[i].H)
+			sliceWriters[i] = ctx.cksumWriter(i, writer, cksumType)
 		}
 	}
 
@@ -418,7 +459,11 @@ func finalizeSlices(ctx *encodeCtx, writers []io.Writer) error {
 	for i := range ctx.dataSlices {
 		readers[i] = ctx.slices[i].reader
 	}
-	if err := stream.Encode(readers, writers); err != nil {
+	err = stream.Encode(readers, writers)
+	for _, hp := range ctx.hpipes {
+		hp.Wait()
+	}
+	if err != nil {
 		return err
 	}
 
@@ -459,8 +504,7 @@ func generateSlicesToDisk(ctx *encodeCtx) error {
 		if cksumType == cos.ChecksumNone {
 			sliceWriters[i] = writer
 		} else {
-			ctx.cksums[i] = cos.NewCksumHash(cksumType)
-			sliceWriters[i] = cos.NewWriterMulti(writer, ctx.cksums[i].H)
+			sliceWriters[i] = ctx.cksumWriter(i, writer, cksumType)
 		}
 	}
 
@@ -227,6 +227,7 @@ func (r *XactRespond) dispatchResp(iReq intraReq, hdr *transport.ObjHdr, object
 			return
 		}
 		r.ObjsAdd(1, hdr.ObjAttrs.Size)
+		r.DiskWriteAdd(hdr.ObjAttrs.Size)
 	default:
 		debug.Assert(false, "opcode", hdr.Opcode)
 		nlog.Errorf("Invalid request type: %d", hdr.Opcode)
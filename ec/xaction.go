@@ -180,6 +180,7 @@ func (r *xactECBase) dataResponse(act intraReqType, hdr *transport.ObjHdr, fqn s
 	o.Hdr, o.Callback = rHdr, r.sendCb
 
 	r.ObjsAdd(1, objAttrs.Size)
+	r.DiskReadAdd(objAttrs.Size)
 	r.IncPending()
 	return r.sendByDaemonID([]string{hdr.SID}, o, reader, false)
 }
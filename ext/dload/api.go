@@ -8,12 +8,14 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"net/url"
 	"path"
 	"regexp"
 	"strings"
 	"time"
 
 	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/cmn/archive"
 	"github.com/NVIDIA/aistore/cmn/cos"
 	"github.com/NVIDIA/aistore/cmn/debug"
 	jsoniter "github.com/json-iterator/go"
@@ -26,12 +28,32 @@ const (
 	TypeRange   Type = "range"
 	TypeMulti   Type = "multi"
 	TypeBackend Type = "backend"
+	TypeSync    Type = "sync"
 )
 
 const PrefixJobID = "dnl-"
 
 const DownloadProgressInterval = 10 * time.Second
 
+const minSyncInterval = time.Minute
+
+// pack-on-ingest defaults
+const (
+	DfltPackPrefix    = "shard-"
+	DfltPackShardSize = 256 * cos.MiB
+)
+
+// download verification: action upon checksum (or size) mismatch
+const (
+	VerifyRetry = "retry" // (default) retry the download, fail when out of retries
+	VerifyFail  = "fail"  // fail the download right away
+)
+
+var (
+	errPackRemote   = errors.New("cannot pack-on-ingest objects downloaded from remote buckets")
+	errVerifyRemote = errors.New("cannot verify objects downloaded from remote buckets (the respective backend does)")
+)
+
 type (
 	// NOTE: Changing this structure requires changes in `MarshalJSON` and `UnmarshalJSON` methods.
 	Body struct {
@@ -57,6 +79,10 @@ type (
 		Total         int       `json:"total"`          // total number of tasks, negative if unknown
 		AllDispatched bool      `json:"all_dispatched"` // if true, dispatcher has already scheduled all tasks for given job
 		Aborted       bool      `json:"aborted"`
+
+		// download verification (see VerifyConf)
+		VerifiedCnt int `json:"verified_cnt,omitempty"` // number of successfully validated downloads
+		MismatchCnt int `json:"mismatch_cnt,omitempty"` // number of detected checksum or size mismatches (including retried ones)
 	}
 
 	JobInfos []*Job
@@ -73,12 +99,33 @@ type (
 		BytesPerHour int `json:"bytes_per_hour"`
 	}
 
+	// pack-on-ingest: instead of creating individual objects, pack downloaded files
+	// into (WebDataset-style) shards of (approximately) the given size
+	PackConf struct {
+		Prefix    string `json:"prefix"`            // shard name prefix (default: DfltPackPrefix)
+		Format    string `json:"format"`            // archive format (extension), one of archive.FileExtensions (default: .tar)
+		ShardSize int64  `json:"shard_size,string"` // (default: DfltPackShardSize)
+	}
+
+	// validate downloaded objects against the source checksums and sizes:
+	// - checksum manifest, i.e. the output of `md5sum` or `sha256sum` (e.g., MD5SUMS or SHA256SUMS file), and/or
+	// - per-object MD5 provided by the source (Content-MD5, x-goog-hash, or single-part S3 ETag);
+	// in addition, the number of downloaded bytes must match Content-Length (if provided)
+	VerifyConf struct {
+		Manifest   string `json:"manifest,omitempty"`    // manifest URL
+		Type       string `json:"type,omitempty"`        // manifest checksum type: md5 or sha256 (default: derived from the manifest name)
+		Headers    bool   `json:"headers,omitempty"`     // validate against per-object checksum, if provided by the source
+		OnMismatch string `json:"on_mismatch,omitempty"` // VerifyRetry (default) or VerifyFail
+	}
+
 	Base struct {
-		Description      string  `json:"description"`
-		Bck              cmn.Bck `json:"bucket"`
-		Timeout          string  `json:"timeout"`
-		ProgressInterval string  `json:"progress_interval"`
-		Limits           Limits  `json:"limits"`
+		Description      string      `json:"description"`
+		Bck              cmn.Bck     `json:"bucket"`
+		Timeout          string      `json:"timeout"`
+		ProgressInterval string      `json:"progress_interval"`
+		Limits           Limits      `json:"limits"`
+		Pack             *PackConf   `json:"pack,omitempty"`
+		Verify           *VerifyConf `json:"verify,omitempty"`
 	}
 
 	SingleObj struct {
@@ -115,6 +162,16 @@ type (
 		Sync   bool   `json:"synchronize"`
 	}
 
+	// mirror HTTP(S) directory or remote bucket (prefix), once or periodically
+	SyncBody struct {
+		Base
+		Source   string `json:"source,omitempty"`   // HTTP(S) directory URL; empty when syncing remote bucket (`Bck`)
+		Prefix   string `json:"prefix,omitempty"`   // destination object name prefix (HTTP) or remote prefix to sync
+		Suffix   string `json:"suffix,omitempty"`   // ditto
+		Interval string `json:"interval,omitempty"` // re-sync period; empty means run once
+		Delete   bool   `json:"delete,omitempty"`   // delete in-cluster objects that no longer exist at the source
+	}
+
 	SingleBody struct {
 		Base
 		SingleObj
@@ -134,7 +191,7 @@ type (
 
 func IsType(a string) bool {
 	b := Type(a)
-	return b == TypeMulti || b == TypeBackend || b == TypeSingle || b == TypeRange
+	return b == TypeMulti || b == TypeBackend || b == TypeSingle || b == TypeRange || b == TypeSync
 }
 
 /////////
@@ -146,6 +203,8 @@ func (j *Job) Aggregate(rhs *Job) {
 	j.ScheduledCnt += rhs.ScheduledCnt
 	j.SkippedCnt += rhs.SkippedCnt
 	j.ErrorCnt += rhs.ErrorCnt
+	j.VerifiedCnt += rhs.VerifiedCnt
+	j.MismatchCnt += rhs.MismatchCnt
 	j.Total += rhs.Total
 	j.AllDispatched = j.AllDispatched && rhs.AllDispatched
 	j.Aborted = j.Aborted || rhs.Aborted
@@ -301,6 +360,80 @@ func (b *Base) Validate() error {
 	if b.Limits.BytesPerHour < 0 {
 		return fmt.Errorf("'limit.bytes_per_hour' must be non-negative (got: %d)", b.Limits.BytesPerHour)
 	}
+	if b.Pack != nil {
+		if err := b.Pack.Validate(); err != nil {
+			return err
+		}
+	}
+	if b.Verify != nil {
+		return b.Verify.Validate()
+	}
+	return nil
+}
+
+//////////////
+// PackConf //
+//////////////
+
+func (pc *PackConf) Validate() error {
+	if pc.Prefix == "" {
+		pc.Prefix = DfltPackPrefix
+	}
+	if pc.Format == "" {
+		pc.Format = archive.ExtTar
+	}
+	mime, err := archive.Mime(pc.Format, "")
+	if err != nil {
+		return fmt.Errorf("invalid 'pack.format': %v", err)
+	}
+	pc.Format = mime
+	if pc.ShardSize == 0 {
+		pc.ShardSize = DfltPackShardSize
+	}
+	if pc.ShardSize < 0 {
+		return fmt.Errorf("'pack.shard_size' must be positive (got: %d)", pc.ShardSize)
+	}
+	return nil
+}
+
+////////////////
+// VerifyConf //
+////////////////
+
+func (vc *VerifyConf) Validate() error {
+	if vc.Manifest != "" {
+		u, err := url.Parse(vc.Manifest)
+		if err != nil {
+			return fmt.Errorf("invalid 'verify.manifest' %q: %v", vc.Manifest, err)
+		}
+		if u.Scheme != "http" && u.Scheme != "https" {
+			return fmt.Errorf("invalid 'verify.manifest' %q: expecting HTTP(S) URL", vc.Manifest)
+		}
+		if vc.Type == "" {
+			name := strings.ToLower(path.Base(u.Path))
+			switch {
+			case strings.Contains(name, cos.ChecksumSHA256):
+				vc.Type = cos.ChecksumSHA256
+			case strings.Contains(name, cos.ChecksumMD5):
+				vc.Type = cos.ChecksumMD5
+			default:
+				return fmt.Errorf("cannot determine checksum type of the %q manifest (specify 'verify.type')", vc.Manifest)
+			}
+		}
+	}
+	if vc.Type != "" && vc.Type != cos.ChecksumMD5 && vc.Type != cos.ChecksumSHA256 {
+		return fmt.Errorf("invalid 'verify.type' %q (expecting %q or %q)", vc.Type, cos.ChecksumMD5, cos.ChecksumSHA256)
+	}
+	if vc.Type != "" && vc.Manifest == "" {
+		return errors.New("'verify.type' requires 'verify.manifest'")
+	}
+	switch vc.OnMismatch {
+	case "":
+		vc.OnMismatch = VerifyRetry
+	case VerifyRetry, VerifyFail:
+	default:
+		return fmt.Errorf("invalid 'verify.on_mismatch' %q (expecting %q or %q)", vc.OnMismatch, VerifyRetry, VerifyFail)
+	}
 	return nil
 }
 
@@ -362,6 +495,12 @@ func (b *SingleBody) Validate() error {
 	if err := b.Base.Validate(); err != nil {
 		return err
 	}
+	if b.Pack != nil && b.FromRemote {
+		return errPackRemote
+	}
+	if b.Verify != nil && b.FromRemote {
+		return errVerifyRemote
+	}
 	return b.SingleObj.Validate()
 }
 
@@ -467,7 +606,15 @@ func (b *MultiBody) String() string {
 // BackendBody //
 /////////////////
 
-func (b *BackendBody) Validate() error { return b.Base.Validate() }
+func (b *BackendBody) Validate() error {
+	if b.Pack != nil {
+		return errPackRemote
+	}
+	if b.Verify != nil {
+		return errVerifyRemote
+	}
+	return b.Base.Validate()
+}
 
 func (b *BackendBody) Describe() string {
 	if b.Description != "" {
@@ -475,3 +622,54 @@ func (b *BackendBody) Describe() string {
 	}
 	return fmt.Sprintf("remote bucket prefetch -> %s", b.Bck)
 }
+
+//////////////
+// SyncBody //
+//////////////
+
+func (b *SyncBody) Validate() error {
+	if b.Pack != nil {
+		return errors.New("cannot pack-on-ingest when synchronizing (mirroring) source")
+	}
+	if err := b.Base.Validate(); err != nil {
+		return err
+	}
+	if b.Source != "" {
+		u, err := url.Parse(b.Source)
+		if err != nil {
+			return fmt.Errorf("invalid 'source' %q: %v", b.Source, err)
+		}
+		if u.Scheme != "http" && u.Scheme != "https" {
+			return fmt.Errorf("invalid 'source' %q: expecting HTTP(S) directory URL", b.Source)
+		}
+		if !strings.HasSuffix(u.Path, "/") {
+			b.Source += "/"
+		}
+	} else if b.Verify != nil {
+		return errVerifyRemote
+	}
+	if b.Interval != "" {
+		ival, err := time.ParseDuration(b.Interval)
+		if err != nil {
+			return fmt.Errorf("failed to parse interval field: %v", err)
+		}
+		if ival < minSyncInterval {
+			return fmt.Errorf("sync interval %v is too short (minimum %v)", ival, minSyncInterval)
+		}
+	}
+	return nil
+}
+
+func (b *SyncBody) Describe() string {
+	if b.Description != "" {
+		return b.Description
+	}
+	src := b.Source
+	if src == "" {
+		src = b.Bck.Cname(b.Prefix)
+	}
+	if b.Interval != "" {
+		return fmt.Sprintf("sync %s -> %s every %s", src, b.Bck, b.Interval)
+	}
+	return fmt.Sprintf("sync %s -> %s", src, b.Bck)
+}
@@ -120,7 +120,9 @@ mloop:
 			// all joggers are busy downloading the tasks (jobs with limits
 			// may not saturate the full downloader throughput).
 			d.mtx.Lock()
-			d.abortJob[job.ID()] = cos.NewStopCh()
+			if _, ok := d.abortJob[job.ID()]; !ok { // (next round of a periodic job keeps its own)
+				d.abortJob[job.ID()] = cos.NewStopCh()
+			}
 			d.mtx.Unlock()
 
 			select {
@@ -195,8 +197,45 @@ func (d *dispatcher) finish(job jobif) {
 
 // forward request to designated jogger
 func (d *dispatcher) dispatchDownload(job jobif) (ok bool) {
-	defer d.finish(job)
+	ival := job.Interval()
+	if ok = d.dispatchRound(job); !ok || ival == 0 || d.checkAbortedJob(job) {
+		d.finish(job)
+		return ok
+	}
+
+	// periodic sync: wait for this round to complete and release the dispatch slot;
+	// the next round gets scheduled via d.workCh (see nextRound)
+	d.waitFor(job.ID())
+	d.xdl.IncPending() // keep the downloader from going idle between rounds
+	go d.nextRound(job, ival)
+	return true
+}
+
+func (d *dispatcher) nextRound(job jobif, ival time.Duration) {
+	defer d.xdl.DecPending()
+	timer := time.NewTimer(ival)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+	case <-d.jobAbortedCh(job.ID()).Listen():
+		d.finish(job)
+		return
+	case <-d.stopCh.Listen():
+		d.finish(job)
+		return
+	}
+	job.rewind()
+	g.store.setAllDispatched(job.ID(), false)
+	select {
+	case d.workCh <- job:
+	case <-d.jobAbortedCh(job.ID()).Listen():
+		d.finish(job)
+	case <-d.stopCh.Listen():
+		d.finish(job)
+	}
+}
 
+func (d *dispatcher) dispatchRound(job jobif) (ok bool) {
 	if aborted := d.checkAborted(); aborted || d.checkAbortedJob(job) {
 		return !aborted
 	}
@@ -275,6 +314,12 @@ func (d *dispatcher) dispatchDownload(job jobif) (ok bool) {
 		case DiffResolverSend:
 			requiresSync := job.Sync()
 			debug.Assert(requiresSync)
+			// mirrored HTTP directory: the object no longer exists at the source
+			if sj, ok := job.(*syncDlJob); ok && sj.isWebMirrored(result.Src) {
+				if _, err := core.T.EvictObject(result.Src); err != nil {
+					nlog.Errorln(job.String(), "failed to delete", result.Src.Cname()+":", err)
+				}
+			}
 		case DiffResolverEOF:
 			g.store.setAllDispatched(job.ID(), true)
 			return true
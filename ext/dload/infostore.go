@@ -78,6 +78,14 @@ func (is *infoStore) incFinished(id string) {
 	dljob.finishedCnt.Inc()
 }
 
+// finished (downloaded) but then discarded - see packer.drop
+func (is *infoStore) unfinish(id string) {
+	dljob, err := is.getJob(id)
+	debug.AssertNoErr(err)
+	dljob.finishedCnt.Dec()
+	dljob.errorCnt.Inc()
+}
+
 func (is *infoStore) incSkipped(id string) {
 	dljob, err := is.getJob(id)
 	debug.AssertNoErr(err)
@@ -97,6 +105,18 @@ func (is *infoStore) incErrorCnt(id string) {
 	dljob.errorCnt.Inc()
 }
 
+func (is *infoStore) incVerified(id string) {
+	dljob, err := is.getJob(id)
+	debug.AssertNoErr(err)
+	dljob.verifiedCnt.Inc()
+}
+
+func (is *infoStore) incMismatch(id string) {
+	dljob, err := is.getJob(id)
+	debug.AssertNoErr(err)
+	dljob.mismatchCnt.Inc()
+}
+
 func (is *infoStore) setAllDispatched(id string, dispatched bool) {
 	dljob, err := is.getJob(id)
 	debug.AssertNoErr(err)
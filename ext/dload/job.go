@@ -8,6 +8,7 @@ import (
 	"errors"
 	"fmt"
 	"path"
+	"sort"
 	"strings"
 	"time"
 
@@ -32,6 +33,7 @@ var (
 	_ jobif = (*sliceDlJob)(nil)
 	_ jobif = (*backendDlJob)(nil)
 	_ jobif = (*rangeDlJob)(nil)
+	_ jobif = (*syncDlJob)(nil)
 )
 
 type (
@@ -58,6 +60,11 @@ type (
 		// Determines if it requires also syncing.
 		Sync() bool
 
+		// Periodic (sync) jobs only: time to wait between rounds; zero otherwise.
+		Interval() time.Duration
+		// ditto: reset the state to start a new round
+		rewind()
+
 		// Checks if object name matches the request.
 		checkObj(objName string) bool
 
@@ -68,6 +75,12 @@ type (
 		// via tryAcquire and release
 		throttler() *throttler
 
+		// pack-on-ingest, if requested (nil otherwise)
+		packer() *packer
+
+		// download verification, if requested (nil otherwise)
+		verifier() *verifier
+
 		// job cleanup
 		cleanup()
 	}
@@ -80,6 +93,8 @@ type (
 		description string
 		timeout     time.Duration
 		throt       throttler
+		pk          *packer
+		vf          *verifier
 	}
 
 	sliceDlJob struct {
@@ -113,6 +128,16 @@ type (
 		done              bool
 	}
 
+	// periodically mirror HTTP(S) directory or remote bucket
+	syncDlJob struct {
+		backendDlJob               // remote bucket, when `source` is empty
+		source       string        // HTTP(S) directory URL
+		links        []dlObj       // listed (and sorted) HTTP objects that belong to this target
+		current      int           // next to dispatch (in links)
+		interval     time.Duration // between rounds
+		listed       bool          // listed HTTP directory in the current round
+	}
+
 	dljob struct {
 		id            string
 		xid           string
@@ -123,6 +148,8 @@ type (
 		scheduledCnt  atomic.Int32
 		skippedCnt    atomic.Int32
 		errorCnt      atomic.Int32
+		verifiedCnt   atomic.Int32
+		mismatchCnt   atomic.Int32
 		total         int
 		aborted       atomic.Bool
 		allDispatched atomic.Bool
@@ -156,6 +183,8 @@ func (j *baseDlJob) Bck() *cmn.Bck          { return j.bck.Bucket() }
 func (j *baseDlJob) Timeout() time.Duration { return j.timeout }
 func (j *baseDlJob) Description() string    { return j.description }
 func (*baseDlJob) Sync() bool               { return false }
+func (*baseDlJob) Interval() time.Duration  { return 0 }
+func (*baseDlJob) rewind()                  { debug.Assert(false) }
 
 func (j *baseDlJob) String() (s string) {
 	s = fmt.Sprintf("dl-job[%s]-%s", j.ID(), j.Bck())
@@ -189,11 +218,31 @@ func (j *baseDlJob) ActiveStats() (*StatusResp, error) {
 
 func (*baseDlJob) checkObj(string) bool    { debug.Assert(false); return false }
 func (j *baseDlJob) throttler() *throttler { return &j.throt }
+func (j *baseDlJob) packer() *packer       { return j.pk }
+func (j *baseDlJob) verifier() *verifier   { return j.vf }
+
+func (j *baseDlJob) initPack(conf *PackConf) {
+	if conf != nil {
+		j.pk = newPacker(j.id, j.bck, conf, j.xdl)
+	}
+}
+
+func (j *baseDlJob) initVerify(conf *VerifyConf) (err error) {
+	if conf != nil {
+		j.vf, err = newVerifier(conf)
+	}
+	return err
+}
 
 func (j *baseDlJob) cleanup() {
 	j.throttler().stop()
 	err, aborted := g.store.markFinished(j.ID())
 	aborted = aborted || j.xdl.IsAborted() // TODO: assert equality
+	if j.pk != nil {
+		if errPack := j.pk.fini(); errPack != nil && err == nil {
+			err = errPack
+		}
+	}
 	if err != nil {
 		nlog.Errorln(j.String()+":", err, aborted)
 	}
@@ -236,6 +285,10 @@ func newMultiDlJob(id string, bck *meta.Bck, payload *MultiBody, xdl *Xact) (mj
 
 	mj = &multiDlJob{}
 	mj.baseDlJob.init(id, bck, payload.Timeout, payload.Describe(), payload.Limits, xdl)
+	mj.initPack(payload.Pack)
+	if err = mj.initVerify(payload.Verify); err != nil {
+		return nil, err
+	}
 
 	if objs, err = payload.ExtractPayload(); err != nil {
 		return nil, err
@@ -251,6 +304,10 @@ func newSingleDlJob(id string, bck *meta.Bck, payload *SingleBody, xdl *Xact) (s
 
 	sj = &singleDlJob{}
 	sj.baseDlJob.init(id, bck, payload.Timeout, payload.Describe(), payload.Limits, xdl)
+	sj.initPack(payload.Pack)
+	if err = sj.initVerify(payload.Verify); err != nil {
+		return nil, err
+	}
 
 	if objs, err = payload.ExtractPayload(); err != nil {
 		return nil, err
@@ -274,6 +331,10 @@ func newRangeDlJob(id string, bck *meta.Bck, payload *RangeBody, xdl *Xact) (rj
 		return nil, err
 	}
 	rj.baseDlJob.init(id, bck, payload.Timeout, payload.Describe(), payload.Limits, xdl)
+	rj.initPack(payload.Pack)
+	if err = rj.initVerify(payload.Verify); err != nil {
+		return nil, err
+	}
 
 	if rj.count, err = countObjects(rj.pt, payload.Subdir, rj.bck); err != nil {
 		return nil, err
@@ -329,11 +390,18 @@ func (j *rangeDlJob) getNextObjs() error {
 // backendDlJob //
 //////////////////
 
-func newBackendDlJob(id string, bck *meta.Bck, payload *BackendBody, xdl *Xact) (bj *backendDlJob, err error) {
+func validateBackendDl(bck *meta.Bck) error {
 	if !bck.IsRemote() {
-		return nil, errors.New("bucket download requires a remote bucket")
+		return errors.New("bucket download requires a remote bucket")
 	} else if bck.IsHT() {
-		return nil, errors.New("bucket download does not support HTTP buckets")
+		return errors.New("bucket download does not support HTTP buckets")
+	}
+	return nil
+}
+
+func newBackendDlJob(id string, bck *meta.Bck, payload *BackendBody, xdl *Xact) (bj *backendDlJob, err error) {
+	if err := validateBackendDl(bck); err != nil {
+		return nil, err
 	}
 	bj = &backendDlJob{}
 	bj.baseDlJob.init(id, bck, payload.Timeout, payload.Describe(), payload.Limits, xdl)
@@ -407,6 +475,90 @@ func (j *backendDlJob) getNextObjs() error {
 	return nil
 }
 
+///////////////
+// syncDlJob //
+///////////////
+
+func newSyncDlJob(id string, bck *meta.Bck, payload *SyncBody, xdl *Xact) (*syncDlJob, error) {
+	if payload.Source == "" {
+		if err := validateBackendDl(bck); err != nil {
+			return nil, err
+		}
+	}
+	sj := &syncDlJob{source: payload.Source}
+	sj.baseDlJob.init(id, bck, payload.Timeout, payload.Describe(), payload.Limits, xdl)
+	if err := sj.initVerify(payload.Verify); err != nil {
+		return nil, err
+	}
+	{
+		sj.sync = payload.Delete
+		sj.prefix = payload.Prefix
+		sj.suffix = payload.Suffix
+	}
+	if payload.Interval != "" {
+		sj.interval, _ = time.ParseDuration(payload.Interval) // validated
+	}
+	return sj, nil
+}
+
+func (j *syncDlJob) Interval() time.Duration { return j.interval }
+
+func (j *syncDlJob) String() (s string) {
+	if j.source == "" {
+		return "sync-" + j.backendDlJob.String()
+	}
+	return fmt.Sprintf("sync-%s-%s", &j.baseDlJob, j.source)
+}
+
+func (j *syncDlJob) genNext() ([]dlObj, bool, error) {
+	if j.source == "" {
+		return j.backendDlJob.genNext()
+	}
+	if !j.listed {
+		if err := j.listSource(); err != nil {
+			return nil, false, err
+		}
+		j.listed = true
+	}
+	if j.current == len(j.links) {
+		return nil, false, nil
+	}
+	end := min(j.current+downloadBatchSize, len(j.links))
+	objs := j.links[j.current:end]
+	j.current = end
+	return objs, true, nil
+}
+
+// NOTE: diff-resolver requires sorted order
+func (j *syncDlJob) listSource() error {
+	objects, err := listHTTPDir(j.source, j.prefix, j.suffix)
+	if err != nil {
+		return err
+	}
+	if j.links, err = buildDlObjs(j.bck, objects); err != nil {
+		return err
+	}
+	sort.Slice(j.links, func(i, k int) bool { return j.links[i].objName < j.links[k].objName })
+	return nil
+}
+
+func (j *syncDlJob) rewind() {
+	j.continuationToken, j.done = "", false
+	j.links, j.current, j.listed = j.links[:0], 0, false
+	if j.vf != nil {
+		j.vf.refresh() // (the source may have changed along with its manifest)
+	}
+}
+
+// (when deleting) objects that were previously downloaded from the HTTP source
+func (j *syncDlJob) isWebMirrored(lom *core.LOM) bool {
+	if j.source == "" || !j.checkObj(lom.ObjName) {
+		return false
+	}
+	src, ok := lom.GetCustomKey(cmn.SourceObjMD)
+	return ok && src == cmn.WebObjMD
+}
+
 ///////////
 // dljob //
 ///////////
@@ -420,6 +572,8 @@ func (j *dljob) clone() Job {
 		ScheduledCnt:  int(j.scheduledCnt.Load()),
 		SkippedCnt:    int(j.skippedCnt.Load()),
 		ErrorCnt:      int(j.errorCnt.Load()),
+		VerifiedCnt:   int(j.verifiedCnt.Load()),
+		MismatchCnt:   int(j.mismatchCnt.Load()),
 		Total:         j.total,
 		AllDispatched: j.allDispatched.Load(),
 		Aborted:       j.aborted.Load(),
@@ -0,0 +1,327 @@
+// Package dload implements functionality to download resources into AIS cluster from external source.
+/*
+ * Copyright (c) 2024, NVIDIA CORPORATION. All rights reserved.
+ */
+package dload
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/cmn/archive"
+	"github.com/NVIDIA/aistore/cmn/cos"
+	"github.com/NVIDIA/aistore/cmn/nlog"
+	"github.com/NVIDIA/aistore/core"
+	"github.com/NVIDIA/aistore/core/meta"
+	"github.com/NVIDIA/aistore/fs"
+	"github.com/NVIDIA/aistore/memsys"
+	"github.com/NVIDIA/aistore/stats"
+)
+
+// Pack-on-ingest (see PackConf):
+// - each target packs the files it downloads into its own shards, one shard at a time;
+// - shard names are "<prefix><seq><format>", e.g. "shard-000017.tar"; a given target only
+//   uses sequence numbers that map (HRW) onto itself - that is, the names are unique cluster-wide
+//   without any coordination, and the shards are stored where they belong;
+// - when a job finishes, each target also stores its manifest "<prefix>manifest-<seq>.json"
+//   listing the shards and their contents (file names, sizes, and source links).
+// - downloaded files are buffered in memory up to packMaxInMem bytes, and spill to a workfile
+//   otherwise - the shard must not end up with a partially written file, and the size may not
+//   be known in advance;
+// - files in a shard that gets discarded (failure to write or finalize) have already been
+//   counted as downloaded - they get reported as failed (see packer.drop).
+
+const (
+	packWorkfile       = "dl-pack"
+	packMemberWorkfile = "dl-pack-member"
+
+	packMaxInMem = 8 * cos.MiB
+)
+
+type (
+	PackEntry struct {
+		Name  string `json:"name"`  // name in archive (ie., object name)
+		Link  string `json:"link"`  // source
+		Shard string `json:"shard"` // shard (object) name
+		Size  int64  `json:"size,string"`
+	}
+	PackManifest struct {
+		JobID   string       `json:"job_id"`
+		Target  string       `json:"target"`
+		Shards  []string     `json:"shards"`
+		Entries []*PackEntry `json:"entries"`
+	}
+
+	packShard struct {
+		lom   *core.LOM
+		wfh   cos.LomWriter
+		aw    archive.Writer
+		fqn   string
+		cksum cos.CksumHashSize
+		size  int64
+		cnt   int
+	}
+	packer struct {
+		bck  *meta.Bck
+		xdl  *Xact
+		cur  *packShard
+		drop func(jobID string, entries []*PackEntry, err error) // report files of a discarded shard
+		man  PackManifest
+		conf PackConf
+		seq  int // next shard
+		mseq int // next manifest
+		mu   sync.Mutex
+	}
+
+	// downloaded file to pack: in memory or spilled to a workfile
+	packMember struct {
+		sgl  *memsys.SGL
+		fqn  string
+		size int64
+	}
+)
+
+func newPacker(jobID string, bck *meta.Bck, conf *PackConf, xdl *Xact) *packer {
+	pk := &packer{bck: bck, conf: *conf, xdl: xdl, drop: dropPacked}
+	pk.man.JobID = jobID
+	pk.man.Target = core.T.SID()
+	return pk
+}
+
+// (the files have been counted as downloaded)
+func dropPacked(jobID string, entries []*PackEntry, err error) {
+	for _, e := range entries {
+		g.tstats.IncErr(stats.ErrDownloadCount)
+		g.store.persistError(jobID, e.Name, "discarded shard "+e.Shard+": "+err.Error())
+		g.store.unfinish(jobID)
+	}
+}
+
+// read the (downloaded) file in its entirety
+func (*packer) buffer(lom *core.LOM, r io.Reader, size int64) (*packMember, error) {
+	m := &packMember{sgl: core.T.PageMM().NewSGL(min(max(size, 0), packMaxInMem))}
+	n, err := io.CopyN(m.sgl, r, packMaxInMem+1)
+	if err == io.EOF {
+		m.size = n
+		return m, nil
+	}
+	if err != nil {
+		return m, err
+	}
+	// spill
+	m.fqn = fs.CSM.Gen(lom, fs.WorkfileType, packMemberWorkfile)
+	wfh, err := lom.CreateWork(m.fqn)
+	if err != nil {
+		m.fqn = ""
+		return m, err
+	}
+	if m.size, err = io.Copy(wfh, m.sgl); err == nil {
+		n, err = io.Copy(wfh, r)
+		m.size += n
+	}
+	if errC := wfh.Close(); err == nil {
+		err = errC
+	}
+	m.sgl.Free()
+	m.sgl = nil
+	return m, err
+}
+
+func (m *packMember) free() {
+	if m.sgl != nil {
+		m.sgl.Free()
+	}
+	if m.fqn != "" {
+		if err := cos.RemoveFile(m.fqn); err != nil {
+			nlog.Errorln("failed to remove", m.fqn, err)
+		}
+	}
+}
+
+// add the (downloaded and buffered) file to the current shard; start new shard if need be
+func (pk *packer) add(name, link string, m *packMember) error {
+	var (
+		size = m.size
+		r    io.Reader
+	)
+	if m.sgl != nil {
+		r = m.sgl
+	} else {
+		fh, err := os.Open(m.fqn)
+		if err != nil {
+			return err
+		}
+		defer cos.Close(fh)
+		r = fh
+	}
+	pk.mu.Lock()
+	defer pk.mu.Unlock()
+	if pk.cur == nil {
+		if err := pk.open(); err != nil {
+			return err
+		}
+	}
+	shard := pk.cur
+	oah := cos.SimpleOAH{Size: size, Atime: time.Now().UnixNano()}
+	if err := shard.aw.Write(name, oah, r); err != nil {
+		pk.abort(err)
+		return err
+	}
+	shard.size += size
+	shard.cnt++
+	pk.man.Entries = append(pk.man.Entries, &PackEntry{Name: name, Link: link, Shard: shard.lom.ObjName, Size: size})
+	if shard.size >= pk.conf.ShardSize {
+		// (upon failure, all files in the shard, this one included, get reported via pk.drop)
+		pk.close() //nolint:errcheck // (see comment)
+	}
+	return nil
+}
+
+// job cleanup: store the last (partial) shard and the manifest
+// (all files in the shard are complete and have been reported as downloaded - aborted or not)
+func (pk *packer) fini() error {
+	pk.mu.Lock()
+	defer pk.mu.Unlock()
+	if pk.cur != nil {
+		if err := pk.close(); err != nil {
+			return err
+		}
+	}
+	if len(pk.man.Shards) == 0 {
+		return nil
+	}
+	return pk.putManifest()
+}
+
+// select the next locally-owned (and not yet taken) name
+func (pk *packer) nextName(format string, seq *int) (string, error) {
+	var (
+		smap = core.T.Sowner().Get()
+		sid  = core.T.SID()
+	)
+	for {
+		name := fmt.Sprintf(format, pk.conf.Prefix, *seq)
+		*seq++
+		si, err := smap.HrwName2T(pk.bck.MakeUname(name))
+		if err != nil {
+			return "", err
+		}
+		if si.ID() != sid {
+			continue
+		}
+		lom := core.AllocLOM(name)
+		err = lom.InitBck(pk.bck.Bucket())
+		if err == nil {
+			err = lom.Load(false /*cache it*/, false /*locked*/)
+		}
+		core.FreeLOM(lom)
+		if cos.IsNotExist(err, 0) {
+			return name, nil
+		}
+		if err != nil {
+			return "", err
+		}
+	}
+}
+
+func (pk *packer) open() error {
+	name, err := pk.nextName("%s%06d"+pk.conf.Format, &pk.seq)
+	if err != nil {
+		return err
+	}
+	shard := &packShard{lom: core.AllocLOM(name)}
+	if err := shard.lom.InitBck(pk.bck.Bucket()); err != nil {
+		core.FreeLOM(shard.lom)
+		return err
+	}
+	shard.fqn = fs.CSM.Gen(shard.lom, fs.WorkfileType, packWorkfile)
+	if shard.wfh, err = shard.lom.CreateWork(shard.fqn); err != nil {
+		core.FreeLOM(shard.lom)
+		return err
+	}
+	shard.cksum.Init(shard.lom.CksumType())
+	shard.aw = archive.NewWriter(pk.conf.Format, shard.wfh, &shard.cksum, &archive.Opts{})
+	pk.cur = shard
+	return nil
+}
+
+func (pk *packer) close() (err error) {
+	shard := pk.cur
+	pk.cur = nil
+	shard.aw.Fini()
+	err = shard.wfh.Close()
+	shard.wfh = nil
+	if err != nil {
+		cos.RemoveFile(shard.fqn)
+		pk.dropEntries(shard, err)
+		core.FreeLOM(shard.lom)
+		return err
+	}
+	shard.cksum.Finalize()
+	shard.lom.SetCksum(&shard.cksum.Cksum)
+	shard.lom.SetSize(shard.cksum.Size)
+	shard.lom.SetAtimeUnix(time.Now().UnixNano())
+	if _, err = core.T.FinalizeObj(shard.lom, shard.fqn, pk.xdl, cmn.OwtPut); err == nil {
+		pk.man.Shards = append(pk.man.Shards, shard.lom.ObjName)
+		if cmn.Rom.FastV(4, cos.SmoduleDload) {
+			nlog.Infoln(pk.xdl.Name(), "packed", shard.lom.Cname(), "files:", shard.cnt, "size:", shard.cksum.Size)
+		}
+	} else {
+		pk.dropEntries(shard, err)
+	}
+	core.FreeLOM(shard.lom)
+	return err
+}
+
+// discard the current shard along with its (manifest) entries
+func (pk *packer) abort(err error) {
+	shard := pk.cur
+	pk.cur = nil
+	shard.aw.Fini()
+	cos.Close(shard.wfh)
+	cos.RemoveFile(shard.fqn)
+	pk.dropEntries(shard, err)
+	core.FreeLOM(shard.lom)
+}
+
+// (entries are appended in order, one shard at a time)
+func (pk *packer) dropEntries(shard *packShard, err error) {
+	i := len(pk.man.Entries)
+	for i > 0 && pk.man.Entries[i-1].Shard == shard.lom.ObjName {
+		i--
+	}
+	if dropped := pk.man.Entries[i:]; len(dropped) > 0 {
+		nlog.Warningln("dl-job["+pk.man.JobID+"]: discarding", shard.lom.Cname(), "files:", len(dropped), "err:", err)
+		pk.drop(pk.man.JobID, dropped, err)
+	}
+	pk.man.Entries = pk.man.Entries[:i]
+}
+
+func (pk *packer) putManifest() error {
+	name, err := pk.nextName("%smanifest-%04d.json", &pk.mseq)
+	if err != nil {
+		return err
+	}
+	lom := core.AllocLOM(name)
+	defer core.FreeLOM(lom)
+	if err := lom.InitBck(pk.bck.Bucket()); err != nil {
+		return err
+	}
+	b := cos.MustMarshal(&pk.man)
+	params := core.AllocPutParams()
+	{
+		params.WorkTag = packWorkfile
+		params.Reader = cos.NewByteHandle(b)
+		params.OWT = cmn.OwtPut
+		params.Atime = time.Now()
+		params.Size = int64(len(b))
+		params.Xact = pk.xdl
+	}
+	err = core.T.PutObject(lom, params)
+	core.FreePutParams(params)
+	return err
+}
@@ -0,0 +1,146 @@
+// Package dload implements functionality to download resources into AIS cluster from external source.
+/*
+ * Copyright (c) 2024, NVIDIA CORPORATION. All rights reserved.
+ */
+package dload
+
+import (
+	"archive/tar"
+	"bytes"
+	"errors"
+	"io"
+	"os"
+	"testing"
+
+	"github.com/NVIDIA/aistore/api/apc"
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/cmn/cos"
+	"github.com/NVIDIA/aistore/core"
+	"github.com/NVIDIA/aistore/core/meta"
+	"github.com/NVIDIA/aistore/core/mock"
+	"github.com/NVIDIA/aistore/fs"
+	"github.com/NVIDIA/aistore/tools/tassert"
+)
+
+type packSowner struct{ smap meta.Smap }
+
+func (o *packSowner) Get() *meta.Smap             { return &o.smap }
+func (*packSowner) Listeners() meta.SmapListeners { return nil }
+
+func newTestPacker(t *testing.T, shardSize int64) (*packer, *meta.Bck) {
+	bck := meta.NewBck("pack", apc.AIS, cmn.NsGlobal)
+	bck.Props = &cmn.Bprops{Cksum: cmn.CksumConf{Type: cos.ChecksumXXHash}, BID: 0xa5b6e7d8}
+
+	fs.TestNew(mock.NewIOS())
+	fs.CSM.Reg(fs.WorkfileType, &fs.WorkfileContentResolver{}, true)
+	fs.CSM.Reg(fs.ObjectType, &fs.ObjectContentResolver{}, true)
+	_, err := fs.Add(t.TempDir(), "daeID")
+	tassert.CheckFatal(t, err)
+
+	tmock := mock.NewTarget(mock.NewBaseBownerMock(bck))
+	so := &packSowner{}
+	si := tmock.Snode()
+	so.smap.Tmap = meta.NodeMap{si.ID(): si}
+	so.smap.InitDigests()
+	tmock.SO = so
+	core.T = tmock
+	if errs := fs.CreateBucket(bck.Bucket(), false /*nilbmd*/); len(errs) > 0 {
+		tassert.CheckFatal(t, errs[0])
+	}
+	return newPacker("job", bck, &PackConf{Prefix: "shard-", Format: ".tar", ShardSize: shardSize}, nil), bck
+}
+
+func TestPack(t *testing.T) {
+	const shardSize = 64 * cos.KiB
+	var (
+		pk, bck = newTestPacker(t, shardSize)
+		files   = make(map[string][]byte)
+		dropped []*PackEntry
+	)
+	pk.drop = func(_ string, entries []*PackEntry, _ error) { dropped = append(dropped, entries...) }
+
+	add := func(name string, size int) {
+		data := bytes.Repeat([]byte(name[:1]), size)
+		lom := core.AllocLOM(name)
+		defer core.FreeLOM(lom)
+		tassert.CheckFatal(t, lom.InitBck(bck.Bucket()))
+
+		m, err := pk.buffer(lom, bytes.NewReader(data), -1 /*unknown size*/)
+		defer m.free()
+		tassert.CheckFatal(t, err)
+		spilled := size > packMaxInMem
+		tassert.Errorf(t, (m.fqn != "") == spilled && (m.sgl == nil) == spilled && m.size == int64(size),
+			"%s: unexpected buffering (spilled %t, size %d)", name, m.fqn != "", m.size)
+		tassert.CheckFatal(t, pk.add(name, "https://example.com/"+name, m))
+		files[name] = data
+	}
+
+	// 1. small file followed by a large one that spills to disk and fills up the shard
+	add("a.txt", 1000)
+	tassert.Fatalf(t, pk.cur != nil, "expecting open shard")
+	shardFQN := pk.cur.fqn
+	add("b.bin", packMaxInMem+cos.MiB)
+	tassert.Fatalf(t, pk.cur == nil && len(pk.man.Shards) == 1, "expecting one closed shard, got %v", pk.man.Shards)
+
+	fh, err := os.Open(shardFQN)
+	tassert.CheckFatal(t, err)
+	tr := tar.NewReader(fh)
+	for _, name := range []string{"a.txt", "b.bin"} {
+		hdr, err := tr.Next()
+		tassert.CheckFatal(t, err)
+		data, err := io.ReadAll(tr)
+		tassert.CheckFatal(t, err)
+		tassert.Errorf(t, hdr.Name == name && bytes.Equal(data, files[name]), "%s: unexpected member %q (size %d)",
+			name, hdr.Name, len(data))
+	}
+	fh.Close()
+
+	// 2. failure to write: the files already packed into the shard get reported
+	add("c.txt", 100)
+	tassert.CheckFatal(t, pk.cur.wfh.Close())
+	lom := core.AllocLOM("d.txt")
+	tassert.CheckFatal(t, lom.InitBck(bck.Bucket()))
+	m, err := pk.buffer(lom, bytes.NewReader([]byte("d")), 1)
+	tassert.CheckFatal(t, err)
+	err = pk.add("d.txt", "https://example.com/d.txt", m)
+	m.free()
+	core.FreeLOM(lom)
+	tassert.Errorf(t, err != nil, "expecting failure to write")
+	tassert.Errorf(t, len(dropped) == 1 && dropped[0].Name == "c.txt", "expecting c.txt reported as dropped, got %v", dropped)
+	tassert.Errorf(t, pk.cur == nil, "expecting the shard to be discarded")
+
+	// 3. (aborted or not) job cleanup stores the last partial shard
+	add("e.txt", 100)
+	tassert.CheckFatal(t, pk.fini())
+	tassert.Errorf(t, len(pk.man.Shards) == 2, "expecting 2 shards, got %v", pk.man.Shards)
+	var names []string
+	for _, e := range pk.man.Entries {
+		names = append(names, e.Name)
+	}
+	tassert.Errorf(t, len(names) == 3 && names[0] == "a.txt" && names[1] == "b.bin" && names[2] == "e.txt",
+		"unexpected manifest entries %v", names)
+	tassert.Errorf(t, len(dropped) == 1, "unexpected dropped %v", dropped)
+}
+
+func TestPackBufferErr(t *testing.T) {
+	pk, bck := newTestPacker(t, cos.MiB)
+	lom := core.AllocLOM("x")
+	defer core.FreeLOM(lom)
+	tassert.CheckFatal(t, lom.InitBck(bck.Bucket()))
+
+	for _, size := range []int{100, packMaxInMem + 100} {
+		r := io.MultiReader(bytes.NewReader(make([]byte, size)), &errReader{})
+		m, err := pk.buffer(lom, r, -1)
+		fqn := m.fqn
+		m.free()
+		tassert.Errorf(t, err != nil, "size %d: expecting read error", size)
+		if fqn != "" {
+			_, err := os.Stat(fqn)
+			tassert.Errorf(t, os.IsNotExist(err), "workfile %q must be removed", fqn)
+		}
+	}
+}
+
+type errReader struct{}
+
+func (*errReader) Read([]byte) (int, error) { return 0, errors.New("connection reset") }
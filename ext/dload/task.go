@@ -16,6 +16,7 @@ import (
 	"github.com/NVIDIA/aistore/cmn"
 	"github.com/NVIDIA/aistore/cmn/atomic"
 	"github.com/NVIDIA/aistore/cmn/cos"
+	"github.com/NVIDIA/aistore/cmn/debug"
 	"github.com/NVIDIA/aistore/cmn/nlog"
 	"github.com/NVIDIA/aistore/core"
 	"github.com/NVIDIA/aistore/nl"
@@ -101,6 +102,7 @@ func (task *singleTask) download(lom *core.LOM) {
 		cos.NamedVal64{Name: stats.DownloadLatency, Value: int64(task.ended.Load().Sub(task.started.Load()))},
 	)
 	task.xdl.ObjsAdd(1, task.currentSize.Load())
+	task.xdl.NetAdd(task.currentSize.Load())
 }
 
 func (task *singleTask) _dlocal(lom *core.LOM, timeout time.Duration) (bool /*err is fatal*/, error) {
@@ -144,6 +146,37 @@ func (task *singleTask) _dput(lom *core.LOM, req *http.Request, resp *http.Respo
 	size := attrsFromLink(task.obj.link, resp, lom)
 	task.setTotalSize(size)
 
+	vf := task.job.verifier()
+	var vr *vreader
+	if vf != nil {
+		var err error
+		if vr, err = vf.begin(&task.obj, resp, lom); err != nil {
+			return true, err
+		}
+		r = vr.wrap(r)
+	}
+
+	if pk := task.job.packer(); pk != nil {
+		// pack-on-ingest: buffer (and retry upon failure to read)
+		m, err := pk.buffer(lom, r, size)
+		defer m.free()
+		if vr != nil && vr.done && vr.err != nil {
+			return !vf.retry, task.verify(vr)
+		}
+		if err != nil {
+			return false, err
+		}
+		if vr != nil {
+			if err := task.verify(vr); err != nil {
+				return !vf.retry, err
+			}
+		}
+		if err := pk.add(task.obj.objName, task.obj.link, m); err != nil {
+			return true, err
+		}
+		return false, nil
+	}
+
 	params := core.AllocPutParams()
 	{
 		params.WorkTag = "dl"
@@ -155,19 +188,37 @@ func (task *singleTask) _dput(lom *core.LOM, req *http.Request, resp *http.Respo
 	}
 	erp := core.T.PutObject(lom, params)
 	core.FreePutParams(params)
+	if vr != nil && vr.done && vr.err != nil {
+		// mismatch upon EOF fails the PUT prior to finalizing (see vreader.Read)
+		return !vf.retry, task.verify(vr)
+	}
 	if erp != nil {
 		return true, erp
 	}
+	if vr != nil {
+		debug.Assert(vr.done, task.obj.link) // PUT reads until EOF
+		g.store.incVerified(task.jobID())
+	}
 	if err := lom.Load(true /*cache it*/, false /*locked*/); err != nil {
 		return true, err
 	}
 	return false, nil
 }
 
+func (task *singleTask) verify(vr *vreader) error {
+	if err := vr.check(); err != nil {
+		g.store.incMismatch(task.jobID())
+		return err
+	}
+	g.store.incVerified(task.jobID())
+	return nil
+}
+
 func (task *singleTask) downloadLocal(lom *core.LOM) (err error) {
 	var (
 		timeout = task.initialTimeout()
 		fatal   bool
+		emis    *errMismatch
 	)
 	for i := range retryCnt {
 		fatal, err = task._dlocal(lom, timeout)
@@ -182,6 +233,8 @@ func (task *singleTask) downloadLocal(lom *core.LOM) (err error) {
 		if errors.Is(err, context.DeadlineExceeded) {
 			nlog.Warningf("%s [retries: %d/%d]: timeout (%v) - increasing and retrying", task, i, retryCnt, timeout)
 			timeout = time.Duration(float64(timeout) * reqTimeoutFactor)
+		} else if errors.As(err, &emis) {
+			nlog.Warningf("%s [retries: %d/%d]: %v - retrying", task, i, retryCnt, err)
 		} else if herr := cmn.Err2HTTPErr(err); herr != nil {
 			nlog.Warningf("%s [retries: %d/%d]: failed to perform request: %v (code: %d)", task, i, retryCnt, err, herr.Status)
 			if _, exists := terminalStatuses[herr.Status]; exists {
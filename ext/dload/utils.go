@@ -1,15 +1,17 @@
 // Package dload implements functionality to download resources into AIS cluster from external source.
 /*
- * Copyright (c) 2018-2022, NVIDIA CORPORATION. All rights reserved.
+ * Copyright (c) 2018-2024, NVIDIA CORPORATION. All rights reserved.
  */
 package dload
 
 import (
 	"context"
 	"errors"
+	"fmt"
 	"net/http"
 	"net/url"
 	"path"
+	"regexp"
 	"strings"
 	"time"
 
@@ -25,6 +27,12 @@ import (
 
 const headReqTimeout = 5 * time.Second
 
+// max nesting depth when listing (mirroring) HTTP directory
+const maxHTTPDirDepth = 16
+
+// autoindex (Apache, nginx, python http.server, etc.) links excluding sorting queries and fragments
+var hrefRe = regexp.MustCompile(`(?i)href\s*=\s*["']([^"'#?]+)["']`)
+
 var errInvalidTarget = errors.New("downloader: invalid target")
 
 func clientForURL(u string) *http.Client {
@@ -159,8 +167,18 @@ func ParseStartRequest(bck *meta.Bck, id string, dlb Body, xdl *Xact) (jobif, er
 			return nil, err
 		}
 		return newSingleDlJob(id, bck, dp, xdl)
+	case TypeSync:
+		dp := &SyncBody{}
+		err := jsoniter.Unmarshal(dlb.RawMessage, dp)
+		if err != nil {
+			return nil, err
+		}
+		if err := dp.Validate(); err != nil {
+			return nil, err
+		}
+		return newSyncDlJob(id, bck, dp, xdl)
 	default:
-		return nil, errors.New("input does not match any of the supported formats (single, range, multi, backend)")
+		return nil, errors.New("input does not match any of the supported formats (single, range, multi, backend, sync)")
 	}
 }
 
@@ -207,6 +225,12 @@ func attrsFromLink(link string, resp *http.Response, oah cos.OAH) (size int64) {
 		}
 	default:
 		oah.SetCustomKey(cmn.SourceObjMD, cmn.WebObjMD)
+		if v := resp.Header.Get(cos.HdrETag); v != "" {
+			oah.SetCustomKey(cmn.ETag, v)
+		}
+		if v := resp.Header.Get(cos.HdrLastModified); v != "" {
+			oah.SetCustomKey(cmn.LastModified, v)
+		}
 	}
 	return resp.ContentLength
 }
@@ -226,6 +250,64 @@ func parseGoogleCksumHeader(hdr []string) cos.StrKVs {
 	return cksums
 }
 
+// listHTTPDir recursively lists HTTP(S) directory (index page) and returns
+// (object name => link) for all the files under the `root` URL
+func listHTTPDir(root, prefix, suffix string) (cos.StrKVs, error) {
+	var (
+		objects = make(cos.StrKVs, 64)
+		visited = make(cos.StrSet, 8)
+	)
+	err := _listDir(root, root, prefix, suffix, objects, visited, 0)
+	return objects, err
+}
+
+func _listDir(root, dir, prefix, suffix string, objects cos.StrKVs, visited cos.StrSet, depth int) error {
+	if depth > maxHTTPDirDepth || visited.Contains(dir) {
+		return nil
+	}
+	visited.Set(dir)
+
+	base, err := url.Parse(dir)
+	if err != nil {
+		return err
+	}
+	resp, err := clientForURL(dir).Get(dir) //nolint:bodyclose // cos.Close
+	if err != nil {
+		return err
+	}
+	body, err := cos.ReadAll(resp.Body)
+	cos.Close(resp.Body)
+	if err != nil {
+		return err
+	}
+	if resp.StatusCode >= http.StatusBadRequest {
+		return fmt.Errorf("failed to list %q: status %d", dir, resp.StatusCode)
+	}
+
+	for _, m := range hrefRe.FindAllSubmatch(body, -1) {
+		u, err := base.Parse(string(m[1]))
+		if err != nil {
+			continue
+		}
+		link := u.String()
+		if link == dir || !strings.HasPrefix(link, root) { // self, parent, or elsewhere
+			continue
+		}
+		if strings.HasSuffix(link, "/") {
+			if err := _listDir(root, link, prefix, suffix, objects, visited, depth+1); err != nil {
+				return err
+			}
+			continue
+		}
+		name, err := url.PathUnescape(link[len(root):])
+		if err != nil || !strings.HasSuffix(name, suffix) {
+			continue
+		}
+		objects[prefix+name] = link
+	}
+	return nil
+}
+
 func headLink(link string) (resp *http.Response, err error) {
 	var (
 		req         *http.Request
@@ -257,7 +339,16 @@ func CompareObjects(lom *core.LOM, dst *DstElement) (bool /*equal*/, error) {
 	oa := &cmn.ObjAttrs{}
 	oa.Size = attrsFromLink(dst.Link, resp, oa) // fill in from resp
 
-	return lom.CheckEq(oa) == nil, nil
+	if lom.CheckEq(oa) == nil {
+		return true, nil
+	}
+	// web server that does not provide ETag: fall back to Last-Modified (and size)
+	if _, ok := oa.GetCustomKey(cmn.ETag); ok {
+		return false, nil
+	}
+	remLM, okr := oa.GetCustomKey(cmn.LastModified)
+	locLM, okl := lom.GetCustomKey(cmn.LastModified)
+	return okr && okl && remLM == locLM && (oa.Size <= 0 || oa.Size == lom.Lsize()), nil
 }
 
 // called via ais/prxnotifs generic mechanism
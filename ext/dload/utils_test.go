@@ -5,6 +5,7 @@
 package dload_test
 
 import (
+	"strings"
 	"testing"
 	"time"
 
@@ -114,3 +115,83 @@ func prepareObject(t *testing.T) *core.LOM {
 	tassert.CheckFatal(t, err)
 	return lom
 }
+
+func TestSyncBodyValidate(t *testing.T) {
+	tests := []struct {
+		body  dload.SyncBody
+		valid bool
+	}{
+		{dload.SyncBody{Base: dload.Base{Bck: cmn.Bck{Name: "b"}}}, true},
+		{dload.SyncBody{Base: dload.Base{Bck: cmn.Bck{Name: "b"}}, Source: "https://example.com/data", Interval: "1h"}, true},
+		{dload.SyncBody{Base: dload.Base{Bck: cmn.Bck{Name: "b"}}, Source: "ftp://example.com/data/"}, false},
+		{dload.SyncBody{Base: dload.Base{Bck: cmn.Bck{Name: "b"}}, Interval: "1s"}, false},
+		{dload.SyncBody{Base: dload.Base{Bck: cmn.Bck{Name: "b"}}, Interval: "often"}, false},
+		{dload.SyncBody{Source: "https://example.com/data/"}, false},
+	}
+	for _, test := range tests {
+		err := test.body.Validate()
+		tassert.Errorf(t, (err == nil) == test.valid, "%+v: expected valid=%t, got %v", test.body, test.valid, err)
+		if err == nil && test.body.Source != "" {
+			tassert.Errorf(t, test.body.Source[len(test.body.Source)-1] == '/', "expecting trailing slash: %q", test.body.Source)
+		}
+	}
+}
+
+func TestParseCksumManifest(t *testing.T) {
+	const manifest = `# generated by sha256sum
+e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855  empty.bin
+9F86D081884C7D659A2FEAA0C55AD015A3BF4F1B2B0B822CD15D6C15B0F00A08 *./dir/test.bin
+\5d41402abc4b2a76b9719d911017c592  dir\\name.txt
+
+SHA256 (bsd.bin) = 2c26b46b68ffc68ff99b453c1d30413413422d706483bfa0f98a5e886266e7ae
+`
+	sums, err := dload.ParseCksumManifest(strings.NewReader(manifest))
+	tassert.CheckFatal(t, err)
+	expected := cos.StrKVs{
+		"empty.bin":    "e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855",
+		"dir/test.bin": "9f86d081884c7d659a2feaa0c55ad015a3bf4f1b2b0b822cd15d6c15b0f00a08",
+		`dir\name.txt`: "5d41402abc4b2a76b9719d911017c592",
+		"bsd.bin":      "2c26b46b68ffc68ff99b453c1d30413413422d706483bfa0f98a5e886266e7ae",
+	}
+	tassert.Fatalf(t, len(sums) == len(expected), "expected %d entries, got %v", len(expected), sums)
+	for name, cksum := range expected {
+		tassert.Errorf(t, sums[name] == cksum, "%q: expected %q, got %q", name, cksum, sums[name])
+	}
+
+	for _, invalid := range []string{"nothex  file", "e3b0c442", "e3b0c442 -file"} {
+		_, err := dload.ParseCksumManifest(strings.NewReader(invalid))
+		tassert.Errorf(t, err != nil, "expected error parsing %q", invalid)
+	}
+}
+
+func TestVerifyConfValidate(t *testing.T) {
+	tests := []struct {
+		conf   dload.VerifyConf
+		valid  bool
+		cktype string
+	}{
+		{dload.VerifyConf{Headers: true}, true, ""},
+		{dload.VerifyConf{Manifest: "https://example.com/data/SHA256SUMS"}, true, cos.ChecksumSHA256},
+		{dload.VerifyConf{Manifest: "http://example.com/data/md5sums.txt"}, true, cos.ChecksumMD5},
+		{dload.VerifyConf{Manifest: "https://example.com/data/CHECKSUMS", Type: cos.ChecksumMD5}, true, cos.ChecksumMD5},
+		{dload.VerifyConf{Manifest: "https://example.com/data/CHECKSUMS"}, false, ""},
+		{dload.VerifyConf{Manifest: "ftp://example.com/data/MD5SUMS"}, false, ""},
+		{dload.VerifyConf{Manifest: "https://example.com/data/MD5SUMS", Type: cos.ChecksumXXHash}, false, ""},
+		{dload.VerifyConf{Type: cos.ChecksumMD5}, false, ""},
+		{dload.VerifyConf{Headers: true, OnMismatch: "ignore"}, false, ""},
+	}
+	for _, test := range tests {
+		err := test.conf.Validate()
+		tassert.Errorf(t, (err == nil) == test.valid, "%+v: expected valid=%t, got %v", test.conf, test.valid, err)
+		if err == nil {
+			tassert.Errorf(t, test.conf.Type == test.cktype, "%+v: expected type %q", test.conf, test.cktype)
+			tassert.Errorf(t, test.conf.OnMismatch == dload.VerifyRetry, "%+v: expected default %q", test.conf, dload.VerifyRetry)
+		}
+	}
+
+	body := dload.SingleBody{
+		Base:      dload.Base{Bck: cmn.Bck{Name: "b"}, Verify: &dload.VerifyConf{Headers: true}},
+		SingleObj: dload.SingleObj{ObjName: "o", FromRemote: true},
+	}
+	tassert.Errorf(t, body.Validate() != nil, "expected error verifying remote download")
+}
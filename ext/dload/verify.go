@@ -0,0 +1,292 @@
+// Package dload implements functionality to download resources into AIS cluster from external source.
+/*
+ * Copyright (c) 2024, NVIDIA CORPORATION. All rights reserved.
+ */
+package dload
+
+import (
+	"bufio"
+	"context"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"path"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/cmn/cos"
+	"github.com/NVIDIA/aistore/cmn/nlog"
+	"github.com/NVIDIA/aistore/core"
+)
+
+// Download verification (see VerifyConf):
+// - the manifest is fetched once per job (and once per round, in case of periodic sync);
+//   names in the manifest are resolved relative to the manifest's own location, with
+//   the fallback to the link's base name and, finally, the destination object name;
+// - every downloaded object is checksummed on the fly and compared with the manifest
+//   and/or source-provided MD5; in addition, the received size must match Content-Length;
+// - verification completes upon reading the last byte, while the content is still a workfile:
+//   mismatch fails the PUT (so that invalid content is never visible and the previous
+//   version, if any, remains intact), and the download is either retried (VerifyRetry)
+//   or fails (VerifyFail); objects not listed in the manifest always fail.
+
+const hdrContentMD5 = "Content-MD5"
+
+var bsdCksumRe = regexp.MustCompile(`^(?i:md5|sha256) \((.+)\) = ([0-9a-fA-F]+)$`)
+
+type (
+	verifier struct {
+		sums  cos.StrKVs // name => checksum (hex), as per manifest
+		conf  VerifyConf
+		host  string // manifest's host
+		dir   string // and directory (URL path)
+		mu    sync.RWMutex
+		retry bool
+	}
+	// (one download)
+	vreader struct {
+		io.ReadCloser
+		w      io.Writer
+		hman   *cos.CksumHash // manifest checksum type
+		hmd5   *cos.CksumHash // source-provided MD5
+		err    error          // result of the check (below)
+		link   string
+		expMan string
+		expMD5 string
+		size   int64 // Content-Length, if provided
+		n      int64 // received
+		done   bool  // checked
+	}
+
+	errMismatch struct {
+		link string
+		what string
+		exp  string
+		got  string
+	}
+)
+
+func newVerifier(conf *VerifyConf) (*verifier, error) {
+	vf := &verifier{conf: *conf, retry: conf.OnMismatch != VerifyFail}
+	if conf.Manifest == "" {
+		return vf, nil
+	}
+	u, err := url.Parse(conf.Manifest)
+	if err != nil {
+		return nil, err
+	}
+	vf.host, vf.dir = u.Host, path.Dir(u.Path)+"/"
+	if vf.sums, err = fetchManifest(conf.Manifest); err != nil {
+		return nil, err
+	}
+	return vf, nil
+}
+
+func fetchManifest(link string) (cos.StrKVs, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), cmn.GCO.Get().Downloader.Timeout.D())
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, link, http.NoBody)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := clientForURL(link).Do(req) //nolint:bodyclose // cos.Close
+	if err != nil {
+		return nil, err
+	}
+	defer cos.Close(resp.Body)
+	if resp.StatusCode >= http.StatusBadRequest {
+		return nil, fmt.Errorf("failed to fetch checksum manifest %q: status %d", link, resp.StatusCode)
+	}
+	sums, err := ParseCksumManifest(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("invalid checksum manifest %q: %v", link, err)
+	}
+	return sums, nil
+}
+
+// (periodic sync) re-fetch the manifest; keep the current one upon failure
+func (vf *verifier) refresh() {
+	if vf.conf.Manifest == "" {
+		return
+	}
+	sums, err := fetchManifest(vf.conf.Manifest)
+	if err != nil {
+		nlog.Errorln(err, "- keeping the previous version")
+		return
+	}
+	vf.mu.Lock()
+	vf.sums = sums
+	vf.mu.Unlock()
+}
+
+func (vf *verifier) lookup(obj *dlObj) (string, bool) {
+	vf.mu.RLock()
+	defer vf.mu.RUnlock()
+	u, err := url.Parse(obj.link)
+	if err != nil {
+		return "", false
+	}
+	if u.Host == vf.host && strings.HasPrefix(u.Path, vf.dir) {
+		if v, ok := vf.sums[u.Path[len(vf.dir):]]; ok {
+			return v, true
+		}
+	}
+	if v, ok := vf.sums[path.Base(u.Path)]; ok {
+		return v, true
+	}
+	v, ok := vf.sums[obj.objName]
+	return v, ok
+}
+
+// start verifying a given download
+func (vf *verifier) begin(obj *dlObj, resp *http.Response, lom *core.LOM) (*vreader, error) {
+	var (
+		vr = &vreader{link: obj.link, size: resp.ContentLength}
+		ws = make([]io.Writer, 0, 2)
+	)
+	if vf.conf.Manifest != "" {
+		exp, ok := vf.lookup(obj)
+		if !ok {
+			return nil, fmt.Errorf("%q is not listed in the checksum manifest %q", obj.link, vf.conf.Manifest)
+		}
+		vr.expMan, vr.hman = exp, cos.NewCksumHash(vf.conf.Type)
+		ws = append(ws, vr.hman.H)
+	}
+	if vf.conf.Headers {
+		if exp := srcMD5(resp, lom); exp != "" {
+			vr.expMD5, vr.hmd5 = exp, cos.NewCksumHash(cos.ChecksumMD5)
+			ws = append(ws, vr.hmd5.H)
+		}
+	}
+	vr.w = io.MultiWriter(ws...)
+	return vr, nil
+}
+
+// source-provided MD5, if any (see also attrsFromLink)
+func srcMD5(resp *http.Response, lom *core.LOM) string {
+	if v := resp.Header.Get(hdrContentMD5); v != "" {
+		if b, err := base64.StdEncoding.DecodeString(v); err == nil && len(b) == 16 {
+			return hex.EncodeToString(b)
+		}
+	}
+	// GCS (x-goog-hash) and S3 (ETag, unless multipart)
+	if v, ok := lom.GetCustomKey(cmn.MD5ObjMD); ok {
+		if b, err := hex.DecodeString(v); err == nil && len(b) == 16 {
+			return strings.ToLower(v)
+		}
+	}
+	return ""
+}
+
+/////////////
+// vreader //
+/////////////
+
+func (vr *vreader) wrap(r io.ReadCloser) io.ReadCloser {
+	vr.ReadCloser = r
+	return vr
+}
+
+// fails the (last) read upon mismatch - before the writer (e.g., PUT) gets to finalize
+func (vr *vreader) Read(b []byte) (n int, err error) {
+	n, err = vr.ReadCloser.Read(b)
+	if n > 0 {
+		vr.w.Write(b[:n])
+		vr.n += int64(n)
+	}
+	if err == io.EOF {
+		if errV := vr.check(); errV != nil {
+			err = errV
+		}
+	}
+	return n, err
+}
+
+// (idempotent)
+func (vr *vreader) check() error {
+	if !vr.done {
+		vr.done = true
+		vr.err = vr._check()
+	}
+	return vr.err
+}
+
+func (vr *vreader) _check() error {
+	if vr.size > 0 && vr.n != vr.size {
+		return &errMismatch{vr.link, "size", strconv.FormatInt(vr.size, 10), strconv.FormatInt(vr.n, 10)}
+	}
+	if vr.hman != nil {
+		vr.hman.Finalize()
+		if got := vr.hman.Value(); got != vr.expMan {
+			return &errMismatch{vr.link, vr.hman.Type() + " checksum", vr.expMan, got}
+		}
+	}
+	if vr.hmd5 != nil {
+		vr.hmd5.Finalize()
+		if got := vr.hmd5.Value(); got != vr.expMD5 {
+			return &errMismatch{vr.link, "source md5 checksum", vr.expMD5, got}
+		}
+	}
+	return nil
+}
+
+func (e *errMismatch) Error() string {
+	return fmt.Sprintf("%s mismatch for %q: expected %s, got %s", e.what, e.link, e.exp, e.got)
+}
+
+// ParseCksumManifest parses the output of `md5sum`, `sha256sum`, and similar tools, i.e.:
+// - "<hex-checksum> <name>" or "<hex-checksum> *<name>" (binary mode) lines;
+// - BSD-style "MD5 (<name>) = <hex-checksum>" and "SHA256 (<name>) = <hex-checksum>" lines;
+// empty lines and lines starting with '#' are ignored. Returns (name => checksum) map
+// with lowercase checksums and names stripped of the leading "./", if any.
+func ParseCksumManifest(r io.Reader) (cos.StrKVs, error) {
+	var (
+		sums    = make(cos.StrKVs, 64)
+		scanner = bufio.NewScanner(r)
+		num     int
+	)
+	scanner.Buffer(make([]byte, 0, 64*cos.KiB), cos.MiB)
+	for scanner.Scan() {
+		num++
+		line := strings.TrimRight(scanner.Text(), "\r")
+		if strings.TrimSpace(line) == "" || line[0] == '#' {
+			continue
+		}
+		var name, cksum string
+		if m := bsdCksumRe.FindStringSubmatch(line); m != nil {
+			name, cksum = m[1], m[2]
+		} else {
+			// escaped (GNU coreutils): names containing '\\' or '\n'
+			escaped := line[0] == '\\'
+			if escaped {
+				line = line[1:]
+			}
+			i := strings.IndexByte(line, ' ')
+			if i <= 0 || i+2 > len(line) || (line[i+1] != ' ' && line[i+1] != '*') {
+				return nil, fmt.Errorf("line %d: invalid format %q", num, line)
+			}
+			cksum, name = line[:i], line[i+2:]
+			if escaped {
+				name = strings.NewReplacer(`\\`, `\`, `\n`, "\n").Replace(name)
+			}
+		}
+		if _, err := hex.DecodeString(cksum); err != nil {
+			return nil, fmt.Errorf("line %d: invalid checksum %q", num, cksum)
+		}
+		name = strings.TrimPrefix(name, "./")
+		if name == "" {
+			return nil, fmt.Errorf("line %d: missing name", num)
+		}
+		sums[name] = strings.ToLower(cksum)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return sums, nil
+}
@@ -0,0 +1,73 @@
+// Package dload implements functionality to download resources into AIS cluster from external source.
+/*
+ * Copyright (c) 2024, NVIDIA CORPORATION. All rights reserved.
+ */
+package dload
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/NVIDIA/aistore/cmn/cos"
+	"github.com/NVIDIA/aistore/tools/tassert"
+)
+
+// mismatch must fail the (last) read - i.e., before the PUT gets to finalize the workfile
+func TestVerifyReader(t *testing.T) {
+	var (
+		good  = []byte("the quick brown fox jumps over the lazy dog")
+		bad   = []byte("the quick brown fox jumps over the lazy cat")
+		sum   = sha256.Sum256(good)
+		link  = "http://example.com/data/fox.txt"
+		vconf = VerifyConf{Manifest: "http://example.com/data/SHA256SUMS", Type: cos.ChecksumSHA256}
+		vf    = &verifier{
+			sums: cos.StrKVs{"fox.txt": hex.EncodeToString(sum[:])},
+			conf: vconf,
+			host: "example.com",
+			dir:  "/data/",
+		}
+		obj = &dlObj{objName: "fox.txt", link: link}
+	)
+	tests := []struct {
+		name     string
+		body     []byte
+		size     int64
+		mismatch bool
+	}{
+		{"valid", good, int64(len(good)), false},
+		{"valid-no-content-length", good, -1, false},
+		{"checksum", bad, int64(len(bad)), true},
+		{"size", good[:10], int64(len(good)), true},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			resp := &http.Response{ContentLength: test.size}
+			vr, err := vf.begin(obj, resp, nil)
+			tassert.CheckFatal(t, err)
+
+			var (
+				dst  bytes.Buffer
+				r    = vr.wrap(io.NopCloser(bytes.NewReader(test.body)))
+				emis *errMismatch
+			)
+			_, err = cos.CopyBuffer(&dst, r, make([]byte, 8))
+			tassert.Fatalf(t, vr.done, "expecting verification upon EOF")
+			if !test.mismatch {
+				tassert.CheckFatal(t, err)
+				tassert.CheckFatal(t, vr.check())
+				return
+			}
+			tassert.Fatalf(t, errors.As(err, &emis), "expecting mismatch to fail the copy, got %v", err)
+			tassert.Errorf(t, vr.check() == err, "expecting the same (cached) error, got %v", vr.check())
+		})
+	}
+
+	// not listed
+	_, err := vf.begin(&dlObj{objName: "other", link: "http://example.com/data/other"}, &http.Response{}, nil)
+	tassert.Errorf(t, err != nil, "expecting unlisted object to fail")
+}
@@ -8,6 +8,7 @@ import (
 	"github.com/NVIDIA/aistore/api/apc"
 	"github.com/NVIDIA/aistore/cmn"
 	"github.com/NVIDIA/aistore/cmn/archive"
+	"github.com/NVIDIA/aistore/cmn/cos"
 )
 
 const DefaultExt = archive.ExtTar // default shard extension/format/MIME when spec's input_extension is empty
@@ -19,9 +20,10 @@ const (
 	MD5          = "md5"          // compare md5(name)
 	Shuffle      = "shuffle"      // random shuffle (use with the same seed to reproduce)
 	Content      = "content"      // extract (int, string, float) from a given file, and compare
+	Expr         = "expr"         // compute (int, string, float) key from record name via user-defined expression
 )
 
-var algorithms = []string{algDefault, Alphanumeric, MD5, Shuffle, Content, None}
+var algorithms = []string{algDefault, Alphanumeric, MD5, Shuffle, Content, Expr, None}
 
 type Algorithm struct {
 	// one of the `algorithms` above
@@ -38,9 +40,26 @@ type Algorithm struct {
 	// NOTE: not to confuse with shards "input_extension"
 	Ext string `json:"extension"`
 
-	// ditto: Content only
+	// Content and Expr
 	// `shard.contentKeyTypes` enum values: {"int", "string", "float" }
 	ContentKeyType string `json:"content_key_type"`
+
+	// usage: exclusively for Expr sorting
+	// e.g.: `int(split(name, "_", 1)) * 1000000 + hash(name) % 1000000` - see shard/expr.go
+	Expression string `json:"expr,omitempty"`
+}
+
+// ETL to transform record objects on the fly, during extraction - e.g., to decode and resize
+// images while resharding (combining two cluster-wide passes into one).
+// The ETL must be already running and use push (`hpush://` or `io://`) communication.
+type ETLSpec struct {
+	Name string `json:"name"`
+
+	// record object extensions to transform, e.g. [".jpg", ".png"]; empty - all
+	Exts []string `json:"extensions,omitempty"`
+
+	// per record object; zero - no timeout
+	Timeout cos.Duration `json:"timeout,omitempty"`
 }
 
 // RequestSpec defines the user specification for requests to the endpoint /v1/sort.
@@ -73,6 +92,22 @@ type RequestSpec struct {
 	ExtractConcMaxLimit int `json:"extract_concurrency_max_limit" yaml:"extract_concurrency_max_limit"`
 	// Default: calcMaxLimit()
 	CreateConcMaxLimit int `json:"create_concurrency_max_limit" yaml:"create_concurrency_max_limit"`
+	// Default: no transformation
+	ETL ETLSpec `json:"etl" yaml:"etl"`
+	// Default: false (always create new output shards, overwriting existing ones)
+	// When true, records get appended to the existing output shards, if any
+	// (TAR-based output formats only)
+	AppendToShard bool `json:"append_to_shard" yaml:"append_to_shard"`
+	// Default: false
+	// When true, output shards generated via external key map (EKM) get re-packed
+	// toward (total size / number of shards) - splitting oversized and merging undersized
+	// ones; records (and EKM templates) are never split or mixed
+	BalanceShards bool `json:"balance_shards" yaml:"balance_shards"`
+	// Default: false
+	// When true, output shards created by an aborted (or failed) job get removed
+	// from the output bucket - see Metrics.Cleanup for the resulting report
+	// (shards that existed prior to the job - appended to or overwritten - are never removed)
+	CleanupOnAbort bool `json:"cleanup_on_abort" yaml:"cleanup_on_abort"`
 
 	// debug
 	DsorterType string `json:"dsorter_type"`
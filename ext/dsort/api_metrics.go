@@ -11,6 +11,7 @@ import (
 
 	"github.com/NVIDIA/aistore/cmn"
 	"github.com/NVIDIA/aistore/cmn/atomic"
+	"github.com/NVIDIA/aistore/ext/dsort/shard"
 )
 
 const (
@@ -34,6 +35,19 @@ type (
 		AvgMs int64 `json:"avg_ms,string"`
 	}
 
+	// SizeStats contains the final size distribution of the output shards
+	// (computed once, by the target that generates output shards).
+	SizeStats struct {
+		Count  int64 `json:"count,string"`
+		Total  int64 `json:"total,string"`
+		Min    int64 `json:"min,string"`
+		Max    int64 `json:"max,string"`
+		Avg    int64 `json:"avg,string"`
+		StdDev int64 `json:"std_dev,string"`
+		// Balanced specifies if the shards were re-packed (see RequestSpec.BalanceShards)
+		Balanced bool `json:"balanced"`
+	}
+
 	// included by 3 actual phases below
 	phaseBase struct {
 		Start time.Time `json:"started_time"`
@@ -82,6 +96,9 @@ type (
 		SentStats *TimeStats `json:"sent_stats,omitempty"`
 		// RecvStats - time statistics about records receivied from another target
 		RecvStats *TimeStats `json:"recv_stats,omitempty"`
+		// CrossRackSize - size of the records sent to a target in a different rack
+		// (network topology - see meta.Snode.CrossRack)
+		CrossRackSize int64 `json:"cross_rack_size,string"`
 	}
 
 	// ShardCreation contains metrics for third and last phase of Dsort.
@@ -97,10 +114,34 @@ type (
 		// data. Sometimes, rather than creating at the destination, it is faster
 		// to create a shard on a specific target and send it over (to the destination).
 		MovedShardCnt int64 `json:"moved_shard_count,string"`
+		// AppendedCnt - number of existing shards that got appended with new
+		// records (rather than created anew) - see RequestSpec.AppendToShard
+		AppendedCnt int64 `json:"appended_count,string"`
 		// RequestStats - time statistics: requests to other targets.
 		RequestStats *TimeStats `json:"req_stats,omitempty"`
 		// ResponseStats - time statistics: responses to other targets.
 		ResponseStats *TimeStats `json:"resp_stats,omitempty"`
+		// SizeStats - output shard sizes
+		SizeStats *SizeStats `json:"size_stats,omitempty"`
+		// CrossRackSize - size of the record contents sent to targets in different racks
+		// (to create their respective output shards)
+		CrossRackSize int64 `json:"cross_rack_size,string"`
+	}
+
+	// AbortCleanup reports output shards removed by this target upon abort
+	// (see RequestSpec.CleanupOnAbort). Note that a shard created here and
+	// moved to its HRW destination is removed by both targets.
+	AbortCleanup struct {
+		// Removed - names of the removed shards (up to maxCleanupNames)
+		Removed []string `json:"removed,omitempty"`
+		// Errors - failures to remove, if any
+		Errors []string `json:"errors,omitempty"`
+		// RemovedCnt - total number of removed shards
+		RemovedCnt int64 `json:"removed_count,string"`
+		// RemovedSize - total size of removed shards
+		RemovedSize int64 `json:"removed_size,string"`
+		// Elapsed - time it took to remove
+		Elapsed time.Duration `json:"elapsed"`
 	}
 )
 
@@ -120,6 +161,9 @@ type (
 		// errors, if any
 		Errors []string `json:"errors,omitempty"`
 
+		// partial output removed upon abort, if requested
+		Cleanup *AbortCleanup `json:"cleanup,omitempty"`
+
 		// has been aborted
 		Aborted atomic.Bool `json:"aborted,omitempty"`
 		// has been archived to persistent storage
@@ -298,3 +342,31 @@ func (ts *TimeStats) updateTime(newTime time.Duration) {
 	ts.MaxMs = max(ts.MaxMs, t)
 	ts.AvgMs = ts.Total / ts.Count
 }
+
+///////////////
+// SizeStats //
+///////////////
+
+func newSizeStats(shards []*shard.Shard, balanced bool) *SizeStats {
+	ss := &SizeStats{Count: int64(len(shards)), Balanced: balanced}
+	if ss.Count == 0 {
+		return ss
+	}
+	ss.Min = math.MaxInt64
+	for _, s := range shards {
+		ss.Total += s.Size
+		ss.Min = min(ss.Min, s.Size)
+		ss.Max = max(ss.Max, s.Size)
+	}
+	ss.Avg = ss.Total / ss.Count
+	var (
+		avg = float64(ss.Total) / float64(ss.Count)
+		v   float64
+	)
+	for _, s := range shards {
+		d := float64(s.Size) - avg
+		v += d * d
+	}
+	ss.StdDev = int64(math.Sqrt(v / float64(ss.Count)))
+	return ss
+}
@@ -0,0 +1,95 @@
+// Package dsort provides distributed massively parallel resharding for very large datasets.
+/*
+ * Copyright (c) 2024, NVIDIA CORPORATION. All rights reserved.
+ */
+package dsort
+
+import (
+	"fmt"
+
+	"github.com/NVIDIA/aistore/cmn/cos"
+	"github.com/NVIDIA/aistore/ext/dsort/shard"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("BalanceShards", func() {
+	recordSize := func(r *shard.Record) int64 { return r.TotalSize() }
+
+	// same as generateShardsWithOrderingFile: new shard when the last one exceeds maxSize
+	genShards := func(tmpl *cos.ParsedTemplate, maxSize int64, sizes ...int64) []*shard.Shard {
+		shards := make([]*shard.Shard, 0)
+		tmpl.InitIter()
+		for i, size := range sizes {
+			r := &shard.Record{
+				Key:     i,
+				Name:    fmt.Sprintf("record-%d", i),
+				Objects: []*shard.RecordObj{{Size: size}},
+			}
+			if len(shards) == 0 || shards[len(shards)-1].Size > maxSize {
+				name, _ := tmpl.Next()
+				shards = append(shards, &shard.Shard{Name: name, Records: shard.NewRecords(1)})
+			}
+			last := shards[len(shards)-1]
+			last.Size += size
+			last.Records.Insert(r)
+		}
+		return shards
+	}
+	newTmpl := func() *cos.ParsedTemplate {
+		tmpl, err := cos.NewParsedTemplate("shard-{0..99}")
+		Expect(err).NotTo(HaveOccurred())
+		return &tmpl
+	}
+	// all records, in order
+	records := func(shards []*shard.Shard) (names []string) {
+		for _, s := range shards {
+			for _, r := range s.Records.All() {
+				names = append(names, r.Name)
+			}
+		}
+		return names
+	}
+
+	It("should split oversized and merge undersized shards", func() {
+		var (
+			tmpl    = newTmpl()
+			maxSize = int64(100)
+			sizes   = []int64{10, 95, 90, 30, 30, 30, 30, 30, 30, 5}
+			shards  = genShards(tmpl, maxSize, sizes...)
+		)
+		// skewed: 105, 120, 120, 5
+		before := newSizeStats(shards, false)
+
+		balanced, err := balanceShards(shards, tmpl, maxSize, recordSize)
+		Expect(err).NotTo(HaveOccurred())
+		after := newSizeStats(balanced, true)
+
+		Expect(after.Total).To(Equal(before.Total))
+		Expect(after.StdDev).To(BeNumerically("<", before.StdDev))
+		Expect(records(balanced)).To(Equal(records(genShards(newTmpl(), maxSize, sizes...))))
+		for i, s := range balanced {
+			Expect(s.Name).To(Equal(fmt.Sprintf("shard-%d", i)))
+		}
+	})
+
+	It("should not split records", func() {
+		var (
+			tmpl    = newTmpl()
+			maxSize = int64(100)
+			shards  = genShards(tmpl, maxSize, 500, 1, 1, 1)
+		)
+		balanced, err := balanceShards(shards, tmpl, maxSize, recordSize)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(balanced[0].Records.All()[0].TotalSize()).To(Equal(int64(500)))
+		Expect(records(balanced)).To(HaveLen(4))
+	})
+
+	It("should fail when running out of shard names", func() {
+		tmpl, err := cos.NewParsedTemplate("shard-{0..1}")
+		Expect(err).NotTo(HaveOccurred())
+		shards := genShards(&tmpl, 1000, 100, 100, 100, 100)
+		_, err = balanceShards(shards, &tmpl, 100, recordSize)
+		Expect(err).To(HaveOccurred())
+	})
+})
@@ -0,0 +1,138 @@
+// Package dsort provides distributed massively parallel resharding for very large datasets.
+/*
+ * Copyright (c) 2024, NVIDIA CORPORATION. All rights reserved.
+ */
+package dsort
+
+import (
+	"crypto/rand"
+	"os"
+	"time"
+
+	"github.com/NVIDIA/aistore/api/apc"
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/cmn/cos"
+	"github.com/NVIDIA/aistore/core"
+	"github.com/NVIDIA/aistore/core/meta"
+	"github.com/NVIDIA/aistore/core/mock"
+	"github.com/NVIDIA/aistore/fs"
+	"github.com/NVIDIA/aistore/transport"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+const cleanupTestDir = "/tmp/dsort-cleanup-tests"
+
+// target that actually writes and deletes output shards
+type cleanupTarget struct {
+	*mock.TargetMock
+}
+
+func (*cleanupTarget) PutObject(lom *core.LOM, params *core.PutParams) error {
+	return saveShard(lom, params.Size)
+}
+
+func (*cleanupTarget) DeleteObject(lom *core.LOM, _ bool) (int, error) {
+	if err := os.Remove(lom.FQN); err != nil {
+		return 0, err
+	}
+	lom.Uncache()
+	return 0, nil
+}
+
+func saveShard(lom *core.LOM, size int64) error {
+	if _, err := cos.SaveReader(lom.FQN, rand.Reader, make([]byte, size), cos.ChecksumNone, size); err != nil {
+		return err
+	}
+	lom.SetSize(size)
+	lom.IncVersion()
+	lom.SetAtimeUnix(time.Now().UnixNano())
+	return lom.Persist()
+}
+
+var _ = Describe("Cleanup on abort", func() {
+	const size = cos.KiB
+
+	var (
+		bck = cmn.Bck{Name: "output-shards", Provider: apc.AIS, Ns: cmn.NsGlobal}
+		m   *Manager
+
+		newLOM = func(name string) *core.LOM {
+			lom := &core.LOM{ObjName: name}
+			Expect(lom.InitBck(&bck)).NotTo(HaveOccurred())
+			return lom
+		}
+		exists = func(name string) bool {
+			_, err := os.Stat(newLOM(name).FQN)
+			return err == nil
+		}
+		recv = func(name string) {
+			hdr := &transport.ObjHdr{ObjName: name, ObjAttrs: cmn.ObjAttrs{Size: size}}
+			hdr.Bck.Copy(&bck)
+			Expect(m.recvShard(hdr, nil, nil)).NotTo(HaveOccurred())
+		}
+	)
+
+	BeforeEach(func() {
+		Expect(cos.CreateDir(cleanupTestDir)).NotTo(HaveOccurred())
+		fs.TestNew(nil)
+		_, err := fs.Add(cleanupTestDir, "daeID")
+		Expect(err).NotTo(HaveOccurred())
+		fs.CSM.Reg(fs.ObjectType, &fs.ObjectContentResolver{}, true)
+		fs.CSM.Reg(fs.WorkfileType, &fs.WorkfileContentResolver{}, true)
+
+		bmd := mock.NewBaseBownerMock(meta.NewBck(bck.Name, bck.Provider, bck.Ns,
+			&cmn.Bprops{Cksum: cmn.CksumConf{Type: cos.ChecksumNone}, BID: 0xa1b2c3d4}))
+		core.T = &cleanupTarget{mock.NewTarget(bmd)}
+
+		m = &Manager{
+			ManagerUUID: "cleanup-test",
+			Metrics:     newMetrics(""),
+			Pars:        &parsedReqSpec{OutputBck: bck, CleanupOnAbort: true},
+		}
+	})
+
+	AfterEach(func() {
+		Expect(os.RemoveAll(cleanupTestDir)).NotTo(HaveOccurred())
+	})
+
+	It("should remove only the shards that did not exist prior to the job", func() {
+		Expect(saveShard(newLOM("existing.tar"), size)).NotTo(HaveOccurred())
+
+		recv("created.tar")
+		recv("existing.tar") // overwritten (different checksum)
+		Expect(m.created.names).To(ConsistOf("created.tar"))
+
+		m.rmPartial(m.created.names)
+		Expect(exists("created.tar")).To(BeFalse())
+		Expect(exists("existing.tar")).To(BeTrue())
+
+		rep := m.Metrics.Cleanup
+		Expect(rep).NotTo(BeNil())
+		Expect(rep.RemovedCnt).To(BeEquivalentTo(1))
+		Expect(rep.RemovedSize).To(BeEquivalentTo(size))
+		Expect(rep.Removed).To(ConsistOf("created.tar"))
+		Expect(rep.Errors).To(BeEmpty())
+	})
+
+	It("should skip the shards that are already gone", func() {
+		for _, name := range []string{"a.tar", "b.tar"} {
+			recv(name)
+		}
+		Expect(os.Remove(newLOM("a.tar").FQN)).NotTo(HaveOccurred())
+
+		m.rmPartial(m.created.names)
+		Expect(exists("b.tar")).To(BeFalse())
+
+		rep := m.Metrics.Cleanup
+		Expect(rep.RemovedCnt).To(BeEquivalentTo(1))
+		Expect(rep.Removed).To(ConsistOf("b.tar"))
+		Expect(rep.Errors).To(BeEmpty())
+	})
+
+	It("should not track the shards when not asked to cleanup", func() {
+		m.Pars.CleanupOnAbort = false
+		recv("created.tar")
+		Expect(m.created.names).To(BeEmpty())
+	})
+})
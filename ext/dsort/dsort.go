@@ -35,6 +35,7 @@ import (
 	"github.com/NVIDIA/aistore/transport"
 	"github.com/OneOfOne/xxhash"
 	jsoniter "github.com/json-iterator/go"
+	"github.com/klauspost/compress/zstd"
 	"github.com/pkg/errors"
 	"github.com/tinylib/msgp/msgp"
 	"golang.org/x/sync/errgroup"
@@ -120,6 +121,7 @@ func (m *Manager) start() (err error) {
 			core.T, m.ManagerUUID, ratio, shardSize, m.Pars.OutputShardSize)
 		if err := m.phase3(shardSize); err != nil {
 			nlog.Errorf("%s: [dsort] %s phase3 err: %v", core.T, m.ManagerUUID, err)
+			m.xctn.Xlog(core.XlogError, "", "phase 3 (creating shards) failed: %v", err)
 			return err
 		}
 	}
@@ -144,25 +146,42 @@ func (m *Manager) start() (err error) {
 	return nil
 }
 
-// returns a slice of targets in a pseudorandom order
+// returns a slice of targets in a pseudorandom order;
+// targets labeled with network topology (meta.Snode.Topo) are grouped by zone and, within
+// a zone, by rack - so that the first rounds of record distribution, where adjacent targets
+// merge their records (see participateInRecordDistribution), stay intra-rack
 func _torder(salt uint64, tmap meta.NodeMap) []*meta.Snode {
-	var (
-		targets = make(map[uint64]*meta.Snode, len(tmap))
-		keys    = make([]uint64, 0, len(tmap))
-	)
+	type tkey struct {
+		d              *meta.Snode
+		zone, rack, id uint64
+	}
+	keys := make([]tkey, 0, len(tmap))
 	for i, d := range tmap {
 		if d.InMaintOrDecomm() {
 			continue
 		}
-		c := xxhash.Checksum64S(cos.UnsafeB(i), salt)
-		targets[c] = d
-		keys = append(keys, c)
-	}
-	sort.Slice(keys, func(i, j int) bool { return keys[i] < keys[j] })
+		k := tkey{d: d, id: xxhash.Checksum64S(cos.UnsafeB(i), salt)}
+		if d.Topo != "" {
+			k.zone = xxhash.Checksum64S(cos.UnsafeB(d.Zone()), salt)
+			k.rack = xxhash.Checksum64S(cos.UnsafeB(d.Topo), salt)
+		}
+		keys = append(keys, k)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		a, b := &keys[i], &keys[j]
+		switch {
+		case a.zone != b.zone:
+			return a.zone < b.zone
+		case a.rack != b.rack:
+			return a.rack < b.rack
+		default:
+			return a.id < b.id
+		}
+	})
 
 	t := make(meta.Nodes, len(keys))
-	for i, k := range keys {
-		t[i] = targets[k]
+	for i := range keys {
+		t[i] = keys[i].d
 	}
 	return t
 }
@@ -277,6 +296,30 @@ func (m *Manager) createShard(s *shard.Shard, lom *core.LOM) (err error) {
 		return
 	}
 
+	// append-to-shard: the existing shard, if any, gets opened prior to (and read during) PUT
+	// (only by its owner - any other target would append to a stale replica, or none at all,
+	// and then overwrite the owner's copy)
+	var (
+		lmfh    cos.LomReader
+		existed bool // pre-existing output shard is never removed upon abort (see addCreated)
+	)
+	if m.Pars.AppendToShard {
+		if err = checkOwner(cos.UnsafeB(lom.Uname()), core.T.SID(), m.smap, core.T.Sowner().Get()); err != nil {
+			return err
+		}
+		if lmfh, err = openShard(lom); err != nil {
+			return err
+		}
+		if lmfh != nil {
+			defer cos.Close(lmfh)
+			existed = true
+		}
+	} else if m.Pars.CleanupOnAbort && !m.Pars.DryRun {
+		if existed, err = shardExists(lom); err != nil {
+			return err
+		}
+	}
+
 	beforeCreation := time.Now()
 
 	var (
@@ -297,8 +340,10 @@ func (m *Manager) createShard(s *shard.Shard, lom *core.LOM) (err error) {
 				// on error as it'll cause writer (below) to panic
 				params.Reader = io.NopCloser(r)
 
-				// TODO: params.Xact - in part, to count PUTs and bytes in a generic fashion
+				// (on behalf of: resource accounting)
+				// TODO: count PUTs and bytes in a generic fashion as well
 				// (vs metrics.ShardCreationStats.updateThroughput - see below)
+				params.Xact = m.xctn
 
 				// TODO: add params.Size = (size resulting from shardRW.Create below)
 			}
@@ -322,7 +367,11 @@ func (m *Manager) createShard(s *shard.Shard, lom *core.LOM) (err error) {
 		debug.Assert(shardRW != nil, m.Pars.OutputExtension)
 	}
 
-	_, err = shardRW.Create(s, w, m.dsorter)
+	if lmfh != nil {
+		err = m.appendShard(s, w, shardRW, lmfh)
+	} else {
+		_, err = shardRW.Create(s, w, m.dsorter)
+	}
 	w.CloseWithError(err)
 	if err != nil {
 		r.CloseWithError(err)
@@ -347,6 +396,9 @@ func (m *Manager) createShard(s *shard.Shard, lom *core.LOM) (err error) {
 	if err != nil {
 		return err
 	}
+	if !m.Pars.DryRun && !existed {
+		m.addCreated(shardName)
+	}
 
 	si, err := m.smap.HrwHash2T(lom.Digest())
 	if err != nil {
@@ -398,6 +450,8 @@ func (m *Manager) createShard(s *shard.Shard, lom *core.LOM) (err error) {
 		if err := <-errCh; err != nil {
 			return err
 		}
+		m.xctn.DiskReadAdd(lom.Lsize())
+		m.xctn.NetAdd(lom.Lsize())
 	}
 
 exit:
@@ -406,11 +460,82 @@ exit:
 	if si.ID() != core.T.SID() {
 		metrics.MovedShardCnt++
 	}
+	if lmfh != nil {
+		metrics.AppendedCnt++
+	}
 	metrics.mu.Unlock()
 
 	return nil
 }
 
+// returns nil reader when the shard does not exist
+// output shard is created (or appended to) by its HRW owner
+func shardOwner(smap *meta.Smap, uname []byte) (*meta.Snode, error) { return smap.HrwName2T(uname) }
+
+// returns nil iff the target `tid` owns the shard in each of the given cluster maps
+// (e.g., the one the job started with and the current one)
+func checkOwner(uname []byte, tid string, smaps ...*meta.Smap) error {
+	for _, smap := range smaps {
+		si, err := shardOwner(smap, uname)
+		if err != nil {
+			return err
+		}
+		if si.ID() != tid {
+			return fmt.Errorf("t[%s]: cannot append to shard %q owned by %s (%s)", tid, uname, si.StringEx(), smap)
+		}
+	}
+	return nil
+}
+
+func shardExists(lom *core.LOM) (bool, error) {
+	err := lom.Load(false /*cache it*/, false /*locked*/)
+	if err == nil {
+		return true, nil
+	}
+	if cos.IsNotExist(err, 0) {
+		return false, nil
+	}
+	return false, err
+}
+
+func openShard(lom *core.LOM) (cos.LomReader, error) {
+	lom.Lock(false)
+	defer lom.Unlock(false)
+	if err := lom.Load(false /*cache it*/, true /*locked*/); err != nil {
+		if cos.IsNotExist(err, 0) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return lom.Open()
+}
+
+// write existing shard's content followed by the newly created records
+// (compare with ais/tgtobj.go putA2I "copy + append")
+func (m *Manager) appendShard(s *shard.Shard, w io.Writer, shardRW shard.RW, lmfh io.Reader) (err error) {
+	var (
+		aw     = archive.NewWriter(m.Pars.OutputExtension, w, nil /*cksum*/, nil /*opts*/)
+		pr, pw = io.Pipe()
+		errCh  = make(chan error, 1)
+	)
+	go func() {
+		_, err := shardRW.Create(s, pw, m.dsorter)
+		pw.CloseWithError(err)
+		errCh <- err
+	}()
+	if err = aw.Copy(lmfh); err == nil {
+		if err = aw.Copy(pr); err == nil {
+			_, err = io.Copy(io.Discard, pr) // trailer and padding, if any
+		}
+	}
+	pr.CloseWithError(err)
+	if errC := <-errCh; err == nil {
+		err = errC
+	}
+	aw.Fini()
+	return err
+}
+
 // participateInRecordDistribution coordinates the distributed merging and
 // sorting of each target's SortedRecords based on the order defined by
 // targetOrder. It returns a bool, currentTargetIsFinal, which is true iff the
@@ -464,30 +589,16 @@ func (m *Manager) participateInRecordDistribution(targetOrder meta.Nodes) (curre
 				beforeSend = time.Now()
 				group      = &errgroup.Group{}
 				r, w       = io.Pipe()
+				sendTo     = targetOrder[i+1]
+				compress   = cmn.GCO.Get().Net.HTTP.CompressAbove > 0 && sendTo.AcceptsZstd() // (streamed - size unknown)
+				sent       int64
 			)
-			group.Go(func() error {
-				var (
-					buf, slab = g.mem.AllocSize(serializationBufSize)
-					msgpw     = msgp.NewWriterBuf(w, buf)
-				)
-				defer slab.Free(buf)
-
-				if err := m.recm.Records.EncodeMsg(msgpw); err != nil {
-					w.CloseWithError(err)
-					return errors.Errorf("failed to marshal msgp: %v", err)
-				}
-				err := msgpw.Flush()
-				w.CloseWithError(err)
-				if err != nil {
-					return errors.Errorf("failed to flush msgp: %v", err)
-				}
-				return nil
+			group.Go(func() (err error) {
+				sent, err = m.encodeRecords(w, compress)
+				return err
 			})
 			group.Go(func() error {
-				var (
-					query  = url.Values{}
-					sendTo = targetOrder[i+1]
-				)
+				query := url.Values{}
 				query.Add(apc.QparamTotalCompressedSize, strconv.FormatInt(m.totalShardSize(), 10))
 				query.Add(apc.QparamTotalUncompressedSize, strconv.FormatInt(m.totalExtractedSize(), 10))
 				query.Add(apc.QparamTotalInputShardsExtracted, strconv.Itoa(m.recm.Records.Len()))
@@ -498,6 +609,9 @@ func (m *Manager) participateInRecordDistribution(targetOrder meta.Nodes) (curre
 					Query:  query,
 					BodyR:  r,
 				}
+				if compress {
+					reqArgs.Header = http.Header{cos.HdrContentEncoding: []string{cos.ContentZstd}}
+				}
 				err := m._do(reqArgs, sendTo, "send sorted records")
 				r.CloseWithError(err)
 				return err
@@ -510,6 +624,9 @@ func (m *Manager) participateInRecordDistribution(targetOrder meta.Nodes) (curre
 
 			metrics.mu.Lock()
 			metrics.SentStats.updateTime(time.Since(beforeSend))
+			if core.T.Snode().CrossRack(sendTo) {
+				metrics.CrossRackSize += sent
+			}
 			metrics.mu.Unlock()
 			return
 		}
@@ -731,7 +848,7 @@ func (m *Manager) generateShardsWithOrderingFile(maxSize int64) ([]*shard.Shard,
 			}
 		}
 
-		recordSize := r.TotalSize() + m.shardRW.MetadataSize()*int64(len(r.Objects))
+		recordSize := m.recordSize(r)
 
 		// retrieve all shards created using the current template format
 		shards := shardsBuilder[shardNameFmt]
@@ -759,13 +876,82 @@ func (m *Manager) generateShardsWithOrderingFile(maxSize int64) ([]*shard.Shard,
 		}
 	}
 
-	for _, s := range shardsBuilder {
+	for shardNameFmt, s := range shardsBuilder {
+		if m.Pars.BalanceShards {
+			if s, err = balanceShards(s, shardTemplates[shardNameFmt], maxSize, m.recordSize); err != nil {
+				return nil, err
+			}
+		}
 		shards = append(shards, s...)
 	}
 
 	return shards, nil
 }
 
+func (m *Manager) recordSize(r *shard.Record) int64 {
+	return r.TotalSize() + m.shardRW.MetadataSize()*int64(len(r.Objects))
+}
+
+// balanceShards re-packs (in order) the records of the shards generated with a given
+// EKM template, targeting the same size for all shards: total / ceil(total / maxSize).
+// This splits oversized shards and merges undersized ones (in particular, the last shard
+// of each template that, otherwise, contains whatever remains). Records are never split,
+// and the shards get renamed using the template from the start.
+func balanceShards(shards []*shard.Shard, tmpl *cos.ParsedTemplate, maxSize int64,
+	recordSize func(*shard.Record) int64) ([]*shard.Shard, error) {
+	var total, cnt int64
+	for _, s := range shards {
+		total += s.Size
+		cnt += int64(s.Records.Len())
+	}
+	if cnt < 2 || total <= 0 {
+		return shards, nil
+	}
+	var (
+		n      = (total + maxSize - 1) / maxSize
+		target = (total + n - 1) / n
+		out    = make([]*shard.Shard, 0, n)
+		cur    *shard.Shard
+	)
+	for _, s := range shards {
+		for _, r := range s.Records.All() {
+			size := recordSize(r)
+			// cut when adding the record would overshoot the target
+			// by more than the current shard is short of it
+			if cur != nil && 2*cur.Size+size > 2*target {
+				out = append(out, cur)
+				cur = nil
+			}
+			if cur == nil {
+				cur = &shard.Shard{Records: shard.NewRecords(int(cnt/n) + 1)}
+			}
+			cur.Size += size
+			cur.Records.Insert(r)
+		}
+	}
+	// merge the remainder, if small
+	if l := len(out); l > 0 && 2*cur.Size < target {
+		last := out[l-1]
+		last.Size += cur.Size
+		last.Records.Insert(cur.Records.All()...)
+	} else {
+		out = append(out, cur)
+	}
+
+	tmpl.InitIter()
+	for _, s := range out {
+		name, hasNext := tmpl.Next()
+		if !hasNext {
+			return nil, fmt.Errorf(
+				"number of balanced shards to be created using %s template exceeds expected number of shards (%d)",
+				tmpl.Prefix, tmpl.Count(),
+			)
+		}
+		s.Name = name
+	}
+	return out, nil
+}
+
 // Create `maxSize` output shard structures in the order defined by dsortManager.Records.
 // Each output shard structure is "distributed" (via m._dist below)
 // to one of the targets - to create the corresponding output shard.
@@ -778,6 +964,9 @@ func (m *Manager) generateShardsWithOrderingFile(maxSize int64) ([]*shard.Shard,
 //     The target is determined firstly by locality (i.e. the target with the most local records)
 //     and secondly (if there is a tie), by least load
 //     (i.e. the target with the least number of pending shard creation requests).
+//
+// Given network topology labels, each target creates its shards in the order of increasing
+// cross-rack transfers (see orderByTopo); the placement itself is always HRW.
 func (m *Manager) phase3(maxSize int64) error {
 	var (
 		shards         []*shard.Shard
@@ -803,13 +992,20 @@ func (m *Manager) phase3(maxSize int64) error {
 	if err != nil {
 		return err
 	}
+	sizeStats := newSizeStats(shards, m.Pars.BalanceShards)
+	m.Metrics.Creation.mu.Lock()
+	m.Metrics.Creation.SizeStats = sizeStats
+	m.Metrics.Creation.mu.Unlock()
+	nlog.Infof("%s: [dsort] %s output shards: %d, size min/avg/max/std-dev: %s/%s/%s/%s", core.T, m.ManagerUUID,
+		sizeStats.Count, cos.ToSizeIEC(sizeStats.Min, 1), cos.ToSizeIEC(sizeStats.Avg, 1),
+		cos.ToSizeIEC(sizeStats.Max, 1), cos.ToSizeIEC(sizeStats.StdDev, 1))
 
 	bck := meta.CloneBck(&m.Pars.OutputBck)
 	if err := bck.Init(core.T.Bowner()); err != nil {
 		return err
 	}
 	for _, s := range shards {
-		si, err := m.smap.HrwName2T(bck.MakeUname(s.Name))
+		si, err := shardOwner(m.smap, bck.MakeUname(s.Name))
 		if err != nil {
 			return err
 		}
@@ -835,6 +1031,14 @@ func (m *Manager) phase3(maxSize int64) error {
 		}
 	}
 
+	var xrack int64
+	for si, s := range shardsToTarget {
+		xrack += orderByTopo(si, s, m.smap.Tmap, m.recordSize)
+	}
+	if xrack > 0 {
+		nlog.Infoln(core.T.String(), "[dsort]", m.ManagerUUID, "cross-rack records:", cos.ToSizeIEC(xrack, 2))
+	}
+
 	m.recm.Records.Drain()
 
 	wg := cos.NewLimitedWaitGroup(cmn.MaxParallelism(), len(shardsToTarget))
@@ -848,12 +1052,48 @@ func (m *Manager) phase3(maxSize int64) error {
 
 	for err := range errCh {
 		nlog.Errorf("%s: [dsort] %s err while sending shards: %v", core.T, m.ManagerUUID, err)
+		m.xctn.Xlog(core.XlogError, "", "failed to send shards: %v", err)
 		return err
 	}
 	nlog.Infof("%s: [dsort] %s finished sending shards", core.T, m.ManagerUUID)
 	return nil
 }
 
+// (network topology) stable-sort the shards to be created by a given target
+// by the size of their records that are located in other racks - intra-rack first;
+// returns the total size of such (cross-rack) records
+func orderByTopo(tsi *meta.Snode, shards []*shard.Shard, tmap meta.NodeMap, recordSize func(*shard.Record) int64) (xrack int64) {
+	if tsi.Topo == "" || len(shards) == 0 {
+		return 0
+	}
+	xsizes := make(map[*shard.Shard]int64, len(shards))
+	for _, s := range shards {
+		var size int64
+		for _, r := range s.Records.All() {
+			if si := tmap[r.DaemonID]; si != nil && tsi.CrossRack(si) {
+				size += recordSize(r)
+			}
+		}
+		xsizes[s] = size
+		xrack += size
+	}
+	if xrack > 0 {
+		sort.SliceStable(shards, func(i, j int) bool { return xsizes[shards[i]] < xsizes[shards[j]] })
+	}
+	return xrack
+}
+
+// (network topology) record contents sent to a target in a different rack
+func (m *Manager) addCrossRack(tsi *meta.Snode, size int64) {
+	if !core.T.Snode().CrossRack(tsi) {
+		return
+	}
+	metrics := m.Metrics.Creation
+	metrics.mu.Lock()
+	metrics.CrossRackSize += size
+	metrics.mu.Unlock()
+}
+
 func (m *Manager) _dist(si *meta.Snode, s []*shard.Shard, order map[string]*shard.Shard, errCh chan error, wg cos.WG) {
 	var (
 		group = &errgroup.Group{}
@@ -893,6 +1133,49 @@ func (m *Manager) _dist(si *meta.Snode, s []*shard.Shard, order map[string]*shar
 	wg.Done()
 }
 
+// serialize records into the pipe, zstd-compressing them if requested;
+// returns the number of bytes written into the pipe
+func (m *Manager) encodeRecords(w *io.PipeWriter, compress bool) (int64, error) {
+	var (
+		err       error
+		buf, slab = g.mem.AllocSize(serializationBufSize)
+		zw        *zstd.Encoder
+		raw, wire *wcounter
+		out       io.Writer
+	)
+	wire = &wcounter{w: w}
+	out = wire
+	if compress {
+		zw = cos.NewZstdWriter(wire)
+		raw = &wcounter{w: zw}
+		out = raw
+	}
+	msgpw := msgp.NewWriterBuf(out, buf)
+	if err = m.recm.Records.EncodeMsg(msgpw); err != nil {
+		err = errors.Errorf("failed to marshal msgp: %v", err)
+	} else if err = msgpw.Flush(); err != nil {
+		err = errors.Errorf("failed to flush msgp: %v", err)
+	}
+	if zw != nil {
+		if err == nil {
+			if err = zw.Close(); err != nil {
+				err = errors.Errorf("failed to compress records: %v", err)
+			}
+		}
+		cos.FreeZstdWriter(zw)
+	}
+	slab.Free(buf)
+	w.CloseWithError(err)
+
+	if err == nil && compress {
+		g.tstats.Inc(stats.CplaneZstdCount)
+		if saved := raw.n - wire.n; saved > 0 {
+			g.tstats.Add(stats.CplaneZstdSavedSize, saved)
+		}
+	}
+	return wire.n, err
+}
+
 func (m *Manager) _do(reqArgs *cmn.HreqArgs, tsi *meta.Snode, act string) error {
 	req, errV := reqArgs.Req()
 	if errV != nil {
@@ -1023,7 +1306,9 @@ func (es *extractShard) _do(lom *core.LOM) error {
 		return errors.Errorf("failed to extract shard %s: %v", lom.Cname(), err)
 	}
 
+	m.xctn.DiskReadAdd(lom.Lsize())
 	if toDisk {
+		m.xctn.DiskWriteAdd(extractedSize)
 		g.tstats.Add(stats.DsortExtractShardDskCnt, 1)
 	} else {
 		g.tstats.Add(stats.DsortExtractShardMemCnt, 1)
@@ -1063,3 +1348,18 @@ func (es *extractShard) _do(lom *core.LOM) error {
 	}
 	return nil
 }
+
+//////////////
+// wcounter //
+//////////////
+
+type wcounter struct {
+	w io.Writer
+	n int64
+}
+
+func (c *wcounter) Write(p []byte) (n int, err error) {
+	n, err = c.w.Write(p)
+	c.n += int64(n)
+	return n, err
+}
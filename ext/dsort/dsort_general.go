@@ -422,6 +422,8 @@ func (ds *dsorterGeneral) sentCallback(_ *transport.ObjHdr, _ io.ReadCloser, arg
 	req := arg.(*remoteRequest)
 	nlog.Errorf("%s: [dsort] %s failed to send remore-req %s: %v",
 		core.T, ds.m.ManagerUUID, req.Record.MakeUniqueName(req.RecordObj), err)
+	ds.m.xctn.Xlog(core.XlogError, req.Record.DaemonID, "failed to send remote request %s: %v",
+		req.Record.MakeUniqueName(req.RecordObj), err)
 }
 
 func (ds *dsorterGeneral) errHandler(err error, node *meta.Snode, o *transport.Obj) {
@@ -464,7 +466,7 @@ func (ds *dsorterGeneral) recvReq(hdr *transport.ObjHdr, objReader io.Reader, er
 
 	o := transport.AllocSend()
 	o.Hdr = transport.ObjHdr{ObjName: req.Record.MakeUniqueName(req.RecordObj)}
-	o.Callback = ds.responseCallback
+	o.Callback, o.CmplArg = ds.responseCallback, fromNode
 
 	fullContentPath := ds.m.recm.FullContentPath(req.RecordObj)
 
@@ -513,12 +515,14 @@ func (ds *dsorterGeneral) recvReq(hdr *transport.ObjHdr, objReader io.Reader, er
 	return nil
 }
 
-func (ds *dsorterGeneral) responseCallback(hdr *transport.ObjHdr, rc io.ReadCloser, _ any, err error) {
+func (ds *dsorterGeneral) responseCallback(hdr *transport.ObjHdr, rc io.ReadCloser, arg any, err error) {
 	if sgl, ok := rc.(*memsys.SGL); ok {
 		sgl.Free()
 	}
 	ds.m.decrementRef(1)
-	if err != nil {
+	if err == nil {
+		ds.m.addCrossRack(arg.(*meta.Snode), hdr.ObjAttrs.Size)
+	} else {
 		nlog.Errorf("%s: [dsort] %s failed to send rsp %s (size %d): %v - aborting...",
 			core.T, ds.m.ManagerUUID, hdr.ObjName, hdr.ObjAttrs.Size, err)
 		ds.m.abort(err)
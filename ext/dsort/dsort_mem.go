@@ -622,7 +622,7 @@ func (es *dsmExtractShard) do() error {
 		return err
 	}
 	smap := core.T.Sowner().Get()
-	tsi, err := smap.HrwName2T(bck.MakeUname(shard.Name))
+	tsi, err := shardOwner(smap, bck.MakeUname(shard.Name))
 	if err != nil {
 		return err
 	}
@@ -659,6 +659,9 @@ func (resp *dsmCS) connectOrSend(r cos.ReadOpenCloser) (err error) {
 		o.Callback, o.CmplArg = resp.ds.sentCallback, &resp.rsp
 		err = resp.ds.streams.records.Send(o, r, resp.tsi)
 		resp.decRef = true // sentCallback will call decrementRef
+		if err == nil {
+			resp.ds.m.addCrossRack(resp.tsi, resp.hdr.ObjAttrs.Size)
+		}
 	}
 	return
 }
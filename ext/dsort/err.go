@@ -21,6 +21,9 @@ const (
 )
 
 var (
+	errAppendFormat      = errors.New("can only append to TAR-based output shards (.tar, .tgz, .tar.gz, .tar.lz4)")
+	errAppendDryRun      = errors.New("cannot append in dry-run mode")
+	errBalanceNoEKM      = errors.New("requires external key map ('ekm_file')")
 	errAlgExt            = errors.New("algorithm: invalid extension")
 	errNegConcLimit      = errors.New("negative concurrency limit")
 	errMissingOutputSize = errors.New("output shard size must be set (cannot be 0 and cannot be omitted)")
@@ -10,6 +10,7 @@ import (
 	"net/url"
 	"regexp"
 	"strconv"
+	"time"
 
 	"github.com/NVIDIA/aistore/api/apc"
 	"github.com/NVIDIA/aistore/cmn"
@@ -41,11 +42,55 @@ type response struct {
 var psi core.Node
 
 // POST /v1/sort
-func PstartHandler(w http.ResponseWriter, r *http.Request, parsc *ParsedReq) {
+// (with queueing enabled - see queue.go - the job may start later; either way, respond with its ID)
+func PstartHandler(w http.ResponseWriter, r *http.Request, parsc *ParsedReq, user string, roles []string) {
 	var (
-		err  error
-		pars = parsc.pars
+		managerUUID = PrefixJobID + cos.GenUUID() // compare w/ p.httpdlpost
+		config      = cmn.GCO.Get()
 	)
+	if !QueueEnabled(&config.Dsort) {
+		if ecode, err := pstart(managerUUID, parsc.pars); err != nil {
+			cmn.WriteErr(w, r, err, ecode)
+			return
+		}
+		writeJobID(w, managerUUID)
+		return
+	}
+
+	if user == "" {
+		user = AnonUser
+	}
+	job := &QueuedJob{
+		ID:        managerUUID,
+		User:      user,
+		Roles:     roles,
+		SrcBck:    parsc.InputBck,
+		DstBck:    parsc.OutputBck,
+		Submitted: time.Now(),
+		pars:      parsc.pars,
+	}
+	if !pq.submit(job, &config.Dsort) {
+		nlog.Infoln("[dsort] queued job", managerUUID, "user", user)
+		writeJobID(w, managerUUID)
+		return
+	}
+	if ecode, err := pstart(managerUUID, parsc.pars); err != nil {
+		pq.fail(job, err)
+		cmn.WriteErr(w, r, err, ecode)
+		return
+	}
+	pq.started(job)
+	writeJobID(w, managerUUID)
+}
+
+func writeJobID(w http.ResponseWriter, managerUUID string) {
+	w.Header().Set(cos.HdrContentLength, strconv.Itoa(len(managerUUID)))
+	w.Write(cos.UnsafeB(managerUUID))
+}
+
+// start dsort job on all targets
+func pstart(managerUUID string, pars *parsedReqSpec) (int, error) {
+	var err error
 	pars.TargetOrderSalt = []byte(cos.FormatNowStamp())
 
 	// TODO: handle case when bucket was removed during dsort job - this should
@@ -55,21 +100,15 @@ func PstartHandler(w http.ResponseWriter, r *http.Request, parsc *ParsedReq) {
 
 	pars.DsorterType, err = dsorterType(pars)
 	if err != nil {
-		cmn.WriteErr(w, r, err)
-		return
+		return 0, err
 	}
 
 	b, err := js.Marshal(pars)
 	if err != nil {
-		s := fmt.Sprintf("unable to marshal RequestSpec: %+v, err: %v", pars, err)
-		cmn.WriteErrMsg(w, r, s, http.StatusInternalServerError)
-		return
+		return http.StatusInternalServerError, fmt.Errorf("unable to marshal RequestSpec: %+v, err: %v", pars, err)
 	}
 
-	var (
-		managerUUID = PrefixJobID + cos.GenUUID() // compare w/ p.httpdlpost
-		smap        = psi.Sowner().Get()
-	)
+	smap := psi.Sowner().Get()
 
 	// Starting dsort has two phases:
 	// 1. Initialization, ensures that all targets successfully initialized all
@@ -88,8 +127,8 @@ func PstartHandler(w http.ResponseWriter, r *http.Request, parsc *ParsedReq) {
 	}
 	path := apc.URLPathdSortInit.Join(managerUUID)
 	responses := bcast(http.MethodPost, path, nil, b, smap)
-	if err := _handleResp(w, r, smap, managerUUID, responses); err != nil {
-		return
+	if err := _handleResp(smap, managerUUID, responses); err != nil {
+		return http.StatusInternalServerError, err
 	}
 
 	// phase 2
@@ -98,15 +137,13 @@ func PstartHandler(w http.ResponseWriter, r *http.Request, parsc *ParsedReq) {
 	}
 	path = apc.URLPathdSortStart.Join(managerUUID)
 	responses = bcast(http.MethodPost, path, nil, nil, smap)
-	if err := _handleResp(w, r, smap, managerUUID, responses); err != nil {
-		return
+	if err := _handleResp(smap, managerUUID, responses); err != nil {
+		return http.StatusInternalServerError, err
 	}
-
-	w.Header().Set(cos.HdrContentLength, strconv.Itoa(len(managerUUID)))
-	w.Write(cos.UnsafeB(managerUUID))
+	return 0, nil
 }
 
-func _handleResp(w http.ResponseWriter, r *http.Request, smap *meta.Smap, managerUUID string, responses []response) error {
+func _handleResp(smap *meta.Smap, managerUUID string, responses []response) error {
 	for _, resp := range responses {
 		if resp.err == nil {
 			continue
@@ -115,9 +152,7 @@ func _handleResp(w http.ResponseWriter, r *http.Request, smap *meta.Smap, manage
 		path := apc.URLPathdSortAbort.Join(managerUUID)
 		_ = bcast(http.MethodDelete, path, nil, nil, smap)
 
-		msg := fmt.Sprintf("failed to start [dsort] %s: %v(%d)", managerUUID, resp.err, resp.statusCode)
-		cmn.WriteErrMsg(w, r, msg, http.StatusInternalServerError)
-		return resp.err
+		return fmt.Errorf("failed to start [dsort] %s: %v(%d)", managerUUID, resp.err, resp.statusCode)
 	}
 	return nil
 }
@@ -139,17 +174,18 @@ func PgetHandler(w http.ResponseWriter, r *http.Request) {
 
 // GET /v1/sort?regex=...
 func plistHandler(w http.ResponseWriter, r *http.Request, query url.Values) {
-	var (
-		path     = apc.URLPathdSortList.S
-		regexStr = query.Get(apc.QparamRegex)
-	)
-	if regexStr != "" {
+	if regexStr := query.Get(apc.QparamRegex); regexStr != "" {
 		if _, err := regexp.CompilePOSIX(regexStr); err != nil {
 			cmn.WriteErr(w, r, err)
 			return
 		}
 	}
-	responses := bcast(http.MethodGet, path, query, nil, psi.Sowner().Get())
+	w.Write(cos.MustMarshal(Plist(query)))
+}
+
+// all (or matching) dsort jobs, aggregated across targets
+func Plist(query url.Values) []*JobInfo {
+	responses := bcast(http.MethodGet, apc.URLPathdSortList.S, query, nil, psi.Sowner().Get())
 
 	resultList := make([]*JobInfo, 0, 4)
 	for _, r := range responses {
@@ -176,8 +212,7 @@ func plistHandler(w http.ResponseWriter, r *http.Request, query url.Values) {
 			}
 		}
 	}
-
-	w.Write(cos.MustMarshal(resultList))
+	return resultList
 }
 
 // GET /v1/sort?id=...
@@ -226,11 +261,21 @@ func PabortHandler(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	managerUUID := r.URL.Query().Get(apc.QparamUUID)
+	if ecode, err := Pabort(managerUUID); err != nil {
+		cmn.WriteErr(w, r, err, ecode)
+	}
+}
+
+// abort queued or running dsort job
+func Pabort(managerUUID string) (int, error) {
+	if pq.remove(managerUUID) {
+		nlog.Infoln("[dsort] aborted queued job", managerUUID)
+		return 0, nil
+	}
 	var (
-		query       = r.URL.Query()
-		managerUUID = query.Get(apc.QparamUUID)
-		path        = apc.URLPathdSortAbort.Join(managerUUID)
-		responses   = bcast(http.MethodDelete, path, nil, nil, psi.Sowner().Get())
+		path      = apc.URLPathdSortAbort.Join(managerUUID)
+		responses = bcast(http.MethodDelete, path, nil, nil, psi.Sowner().Get())
 	)
 	allNotFound := true
 	for _, resp := range responses {
@@ -240,15 +285,13 @@ func PabortHandler(w http.ResponseWriter, r *http.Request) {
 		allNotFound = false
 
 		if resp.err != nil {
-			cmn.WriteErr(w, r, resp.err, resp.statusCode)
-			return
+			return resp.statusCode, resp.err
 		}
 	}
 	if allNotFound {
-		err := cos.NewErrNotFound(core.T, "dsort job "+managerUUID)
-		cmn.WriteErr(w, r, err, http.StatusNotFound)
-		return
+		return http.StatusNotFound, cos.NewErrNotFound(core.T, "dsort job "+managerUUID)
 	}
+	return 0, nil
 }
 
 // DELETE /v1/sort
@@ -265,8 +308,14 @@ func PremoveHandler(w http.ResponseWriter, r *http.Request) {
 		smap        = psi.Sowner().Get()
 		query       = r.URL.Query()
 		managerUUID = query.Get(apc.QparamUUID)
-		path        = apc.URLPathdSortMetrics.Join(managerUUID)
-		responses   = bcast(http.MethodGet, path, nil, nil, smap)
+	)
+	if pq.remove(managerUUID) {
+		nlog.Infoln("[dsort] removed queued job", managerUUID)
+		return
+	}
+	var (
+		path      = apc.URLPathdSortMetrics.Join(managerUUID)
+		responses = bcast(http.MethodGet, path, nil, nil, smap)
 	)
 
 	// First, broadcast to see if process is cleaned up first
@@ -635,10 +684,18 @@ func (mg *managerGroup) recordsHandler(w http.ResponseWriter, r *http.Request) {
 	var (
 		buf, slab = g.mem.AllocSize(serializationBufSize)
 		records   = shard.NewRecords(int(d))
+		body      = r.Body
 	)
 	defer slab.Free(buf)
 
-	if err := records.DecodeMsg(msgp.NewReaderBuf(r.Body, buf)); err != nil {
+	if r.Header.Get(cos.HdrContentEncoding) == cos.ContentZstd {
+		if body, err = cos.NewZstdReader(r.Body); err != nil {
+			cmn.WriteErr(w, r, err, http.StatusInternalServerError)
+			return
+		}
+		defer cos.Close(body)
+	}
+	if err := records.DecodeMsg(msgp.NewReaderBuf(body, buf)); err != nil {
 		err = fmt.Errorf(cmn.FmtErrUnmarshal, apc.ActDsort, "records", "-", err)
 		cmn.WriteErr(w, r, err, http.StatusInternalServerError)
 		return
@@ -49,6 +49,9 @@ const (
 const (
 	// Size of the buffer used for serialization of the shards/records.
 	serializationBufSize = 10 * cos.MiB
+
+	// max number of removed shard names to report (see AbortCleanup)
+	maxCleanupNames = 1000
 )
 
 type (
@@ -115,6 +118,10 @@ type (
 			mu sync.Mutex
 			m  map[string]struct{} // finished acks: tid -> ack
 		}
+		created struct {
+			mu    sync.Mutex
+			names []string // output shards created by this target (see Pars.CleanupOnAbort)
+		}
 		dsorter        dsorter
 		dsorterStarted sync.WaitGroup
 		callTimeout    time.Duration // max time to wait for another node to respond
@@ -376,12 +383,21 @@ func (m *Manager) finalCleanup() {
 
 	m.finishedAck.m = nil
 
+	m.created.mu.Lock()
+	created := m.created.names
+	m.created.names = nil
+	m.created.mu.Unlock()
+
 	// Update clean state.
 	m.state.cleaned = finallyCleanedState
 	// If there is another `finalCleanup` waiting it should be woken up to check the state and exit.
 	m.state.cleanWait.Signal()
 	m.unlock()
 
+	if m.aborted() && m.Pars.CleanupOnAbort {
+		m.rmPartial(created)
+	}
+
 	m.mg.persist(m.ManagerUUID)
 	nlog.Infof("%s: [dsort] %s finished final cleanup in %v", core.T, m.ManagerUUID, time.Since(now))
 }
@@ -457,6 +473,8 @@ func (m *Manager) setRW() (err error) {
 		ke, err = shard.NewContentKeyExtractor(m.Pars.Algorithm.ContentKeyType, m.Pars.Algorithm.Ext)
 	case MD5:
 		ke, err = shard.NewMD5KeyExtractor()
+	case Expr:
+		ke, err = shard.NewExprKeyExtractor(m.Pars.Algorithm.Expression, m.Pars.Algorithm.ContentKeyType)
 	default:
 		ke, err = shard.NewNameKeyExtractor()
 	}
@@ -475,6 +493,13 @@ func (m *Manager) setRW() (err error) {
 	}
 
 	m.recm = shard.NewRecordManager(m.Pars.InputBck, m.shardRW, ke, m.onDupRecs)
+	if m.Pars.ETL != nil && !m.Pars.DryRun {
+		xf, err := newETLXformer(m.Pars.ETL)
+		if err != nil {
+			return err
+		}
+		m.recm.SetTransformer(xf)
+	}
 	return nil
 }
 
@@ -620,7 +645,8 @@ func (m *Manager) recvShard(hdr *transport.ObjHdr, objReader io.Reader, err erro
 		m.abort(err)
 		return err
 	}
-	if err == nil {
+	exists := err == nil
+	if exists {
 		if lom.EqCksum(hdr.ObjAttrs.Cksum) {
 			if cmn.Rom.FastV(4, cos.SmoduleDsort) {
 				nlog.Infof("[dsort] %s shard (%s) already exists and checksums are equal, skipping",
@@ -648,9 +674,82 @@ func (m *Manager) recvShard(hdr *transport.ObjHdr, objReader io.Reader, err erro
 		m.abort(err)
 		return erp
 	}
+	if !exists {
+		m.addCreated(hdr.ObjName)
+	}
 	return nil
 }
 
+// track output shards to remove them if aborted - only those that did not exist prior to this job
+// (i.e., not including the ones that were appended to or overwritten)
+func (m *Manager) addCreated(shardName string) {
+	if !m.Pars.CleanupOnAbort {
+		return
+	}
+	m.created.mu.Lock()
+	m.created.names = append(m.created.names, shardName)
+	m.created.mu.Unlock()
+}
+
+// remove output shards created by this (aborted) job and report the results;
+// invoked upon final cleanup when there are no more in-flight shards
+func (m *Manager) rmPartial(names []string) {
+	var (
+		now = time.Now()
+		rep = &AbortCleanup{}
+	)
+	for _, name := range names {
+		size, err := m.rmShard(name)
+		if err != nil {
+			if len(rep.Errors) < maxCleanupNames {
+				rep.Errors = append(rep.Errors, err.Error())
+			}
+			continue
+		}
+		if size < 0 {
+			continue // (already removed, e.g. by the other target that has it)
+		}
+		rep.RemovedCnt++
+		rep.RemovedSize += size
+		if len(rep.Removed) < maxCleanupNames {
+			rep.Removed = append(rep.Removed, name)
+		}
+	}
+	rep.Elapsed = time.Since(now)
+
+	m.Metrics.lock()
+	m.Metrics.Cleanup = rep
+	m.Metrics.unlock()
+
+	nlog.Infof("%s: [dsort] %s aborted: removed %d partially created output shard%s (%s) in %v, errors: %d",
+		core.T, m.ManagerUUID, rep.RemovedCnt, cos.Plural(int(rep.RemovedCnt)), cos.ToSizeIEC(rep.RemovedSize, 2),
+		rep.Elapsed, len(rep.Errors))
+}
+
+// returns the size of the removed shard or -1 if it does not exist
+func (m *Manager) rmShard(name string) (int64, error) {
+	lom := core.AllocLOM(name)
+	defer core.FreeLOM(lom)
+	err := lom.InitBck(&m.Pars.OutputBck)
+	if err == nil {
+		err = lom.Load(false /*cache it*/, false /*locked*/)
+	}
+	if err != nil {
+		if cos.IsNotExist(err, 0) {
+			return -1, nil
+		}
+		return 0, err
+	}
+	size := lom.Lsize()
+	if ecode, err := core.T.DeleteObject(lom, false /*evict*/); err != nil {
+		if cos.IsNotExist(err, ecode) {
+			return -1, nil
+		}
+		return 0, err
+	}
+	return size, nil
+}
+
 func (m *Manager) freeMemory() uint64 {
 	var mem sys.MemStat
 	if err := mem.Get(); err != nil {
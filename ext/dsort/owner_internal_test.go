@@ -0,0 +1,86 @@
+// Package dsort provides distributed massively parallel resharding for very large datasets.
+/*
+ * Copyright (c) 2024, NVIDIA CORPORATION. All rights reserved.
+ */
+package dsort
+
+import (
+	"fmt"
+
+	"github.com/NVIDIA/aistore/api/apc"
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/core/meta"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Shard owner", func() {
+	const numTargets, numShards = 12, 1000
+
+	var (
+		bck     = meta.NewBck("shards", apc.AIS, cmn.NsGlobal)
+		newSmap = func(tids ...string) *meta.Smap {
+			smap := &meta.Smap{Tmap: make(meta.NodeMap, len(tids)), Version: int64(len(tids))}
+			for _, tid := range tids {
+				smap.Tmap[tid] = &meta.Snode{DaeID: tid, DaeType: apc.Target}
+			}
+			smap.InitDigests()
+			return smap
+		}
+		tids = func(n int) (out []string) {
+			for i := range n {
+				out = append(out, fmt.Sprintf("t%d", i))
+			}
+			return out
+		}
+		uname = func(i int) []byte { return bck.MakeUname(fmt.Sprintf("shard-%04d.tar", i)) }
+	)
+
+	It("should have exactly one target appending to each shard", func() {
+		var (
+			smap  = newSmap(tids(numTargets)...)
+			owned = make(map[string]int, numTargets)
+		)
+		for i := range numShards {
+			si, err := shardOwner(smap, uname(i))
+			Expect(err).NotTo(HaveOccurred())
+			for tid := range smap.Tmap {
+				err := checkOwner(uname(i), tid, smap)
+				if tid == si.ID() {
+					Expect(err).NotTo(HaveOccurred())
+					owned[tid]++
+				} else {
+					Expect(err).To(HaveOccurred(), "%s is not the owner of %s (%s is)", tid, uname(i), si)
+				}
+			}
+		}
+		// all targets get to create (some) shards
+		Expect(owned).To(HaveLen(numTargets))
+	})
+
+	It("should refuse to append when ownership changes in the middle of the job", func() {
+		var (
+			started = newSmap(tids(numTargets)...)
+			current = newSmap(tids(numTargets + 1)...) // one target joined
+			moved   int
+		)
+		for i := range numShards {
+			before, err := shardOwner(started, uname(i))
+			Expect(err).NotTo(HaveOccurred())
+			after, err := shardOwner(current, uname(i))
+			Expect(err).NotTo(HaveOccurred())
+
+			err = checkOwner(uname(i), before.ID(), started, current)
+			if before.ID() == after.ID() {
+				Expect(err).NotTo(HaveOccurred())
+				continue
+			}
+			// (shards can only move to the new target)
+			Expect(after.ID()).To(Equal(fmt.Sprintf("t%d", numTargets)))
+			Expect(err).To(HaveOccurred())
+			Expect(checkOwner(uname(i), after.ID(), started, current)).To(HaveOccurred())
+			moved++
+		}
+		Expect(moved).To(BeNumerically(">", 0))
+	})
+})
@@ -128,6 +128,41 @@ var _ = Describe("RequestSpec", func() {
 			Expect(pars.InputExtension).To(Equal(archive.ExtTgz))
 		})
 
+		It("should parse spec with expr algorithm", func() {
+			rs := RequestSpec{
+				InputBck:        cmn.Bck{Name: "test"},
+				InputExtension:  archive.ExtTar,
+				InputFormat:     newInputFormat("prefix-{0010..0111}-suffix"),
+				OutputFormat:    "prefix-{0010..0111}-suffix",
+				OutputShardSize: "10KB",
+				Algorithm:       Algorithm{Kind: Expr, Expression: `int(split(name, "-", 1)) % 7`, ContentKeyType: "int"},
+			}
+			pars, err := rs.parse()
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(pars.Algorithm.Kind).To(Equal(Expr))
+		})
+
+		It("should parse spec with etl", func() {
+			rs := RequestSpec{
+				InputBck:        cmn.Bck{Name: "test"},
+				InputExtension:  archive.ExtTar,
+				InputFormat:     newInputFormat("prefix-{0010..0111}-suffix"),
+				OutputFormat:    "prefix-{0010..0111}-suffix",
+				OutputShardSize: "10KB",
+				ETL:             ETLSpec{Name: "resize", Exts: []string{"jpg", ".png", " "}},
+			}
+			pars, err := rs.parse()
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(pars.ETL).NotTo(BeNil())
+			Expect(pars.ETL.Name).To(Equal("resize"))
+			Expect(pars.ETL.Exts).To(Equal([]string{".jpg", ".png"}))
+
+			rs.ETL = ETLSpec{}
+			pars, err = rs.parse()
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(pars.ETL).To(BeNil())
+		})
+
 		It("should parse spec with .tar.gz extension", func() {
 			rs := RequestSpec{
 				InputBck:        cmn.Bck{Name: "test"},
@@ -290,6 +325,52 @@ var _ = Describe("RequestSpec", func() {
 			_, err = rs.parse()
 			Expect(err).ShouldNot(HaveOccurred())
 		})
+
+		It("should parse spec with append-to-shard", func() {
+			rs := RequestSpec{
+				InputBck:        cmn.Bck{Name: "test"},
+				InputExtension:  archive.ExtTar,
+				InputFormat:     newInputFormat("prefix-{0010..0111..2}-suffix"),
+				OutputFormat:    "prefix-{10..111}-suffix",
+				OutputExtension: archive.ExtTgz,
+				OutputShardSize: "10KB",
+				MaxMemUsage:     "80%",
+				AppendToShard:   true,
+			}
+			pars, err := rs.parse()
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(pars.AppendToShard).To(BeTrue())
+		})
+
+		It("should parse spec with balance-shards", func() {
+			rs := RequestSpec{
+				InputBck:        cmn.Bck{Name: "test"},
+				InputExtension:  archive.ExtTar,
+				InputFormat:     newInputFormat("prefix-{0010..0111..2}-suffix"),
+				OutputShardSize: "10KB",
+				EKMFileURL:      "http://localhost:8080/ekm.json",
+				MaxMemUsage:     "80%",
+				BalanceShards:   true,
+			}
+			pars, err := rs.parse()
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(pars.BalanceShards).To(BeTrue())
+		})
+
+		It("should parse spec with cleanup-on-abort", func() {
+			rs := RequestSpec{
+				InputBck:        cmn.Bck{Name: "test"},
+				InputExtension:  archive.ExtTar,
+				InputFormat:     newInputFormat("prefix-{0010..0111..2}-suffix"),
+				OutputFormat:    "prefix-{10..111}-suffix",
+				OutputShardSize: "10KB",
+				MaxMemUsage:     "80%",
+				CleanupOnAbort:  true,
+			}
+			pars, err := rs.parse()
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(pars.CleanupOnAbort).To(BeTrue())
+		})
 	})
 
 	Context("request specs which shall NOT pass", func() {
@@ -478,6 +559,69 @@ var _ = Describe("RequestSpec", func() {
 			Expect(err).Should(HaveOccurred())
 		})
 
+		It("should fail due to invalid sorting key expression", func() {
+			rs := RequestSpec{
+				InputBck:        cmn.Bck{Name: "test"},
+				InputExtension:  archive.ExtTar,
+				InputFormat:     newInputFormat("prefix-{0010..0111}-suffix"),
+				OutputFormat:    "prefix-{0010..0111}-suffix",
+				OutputShardSize: "10KB",
+				Algorithm:       Algorithm{Kind: Expr, Expression: `len(name`},
+			}
+			_, err := rs.parse()
+			Expect(err).Should(HaveOccurred())
+		})
+
+		It("should fail due to etl spec without etl name", func() {
+			rs := RequestSpec{
+				InputBck:        cmn.Bck{Name: "test"},
+				InputExtension:  archive.ExtTar,
+				InputFormat:     newInputFormat("prefix-{0010..0111}-suffix"),
+				OutputFormat:    "prefix-{0010..0111}-suffix",
+				OutputShardSize: "10KB",
+				ETL:             ETLSpec{Exts: []string{".jpg"}},
+			}
+			_, err := rs.parse()
+			Expect(err).Should(HaveOccurred())
+		})
+
+		It("should fail to append to zip shards or in dry-run mode", func() {
+			rs := RequestSpec{
+				InputBck:        cmn.Bck{Name: "test"},
+				InputExtension:  archive.ExtTar,
+				InputFormat:     newInputFormat("prefix-{0010..0111..2}-suffix"),
+				OutputFormat:    "prefix-{10..111}-suffix",
+				OutputExtension: archive.ExtZip,
+				OutputShardSize: "10KB",
+				MaxMemUsage:     "80%",
+				AppendToShard:   true,
+			}
+			_, err := rs.parse()
+			Expect(err).Should(HaveOccurred())
+			Expect(errors.Is(err, errAppendFormat)).To(BeTrue())
+
+			rs.OutputExtension = archive.ExtTar
+			rs.DryRun = true
+			_, err = rs.parse()
+			Expect(err).Should(HaveOccurred())
+			Expect(errors.Is(err, errAppendDryRun)).To(BeTrue())
+		})
+
+		It("should fail to balance shards without external key map", func() {
+			rs := RequestSpec{
+				InputBck:        cmn.Bck{Name: "test"},
+				InputExtension:  archive.ExtTar,
+				InputFormat:     newInputFormat("prefix-{0010..0111..2}-suffix"),
+				OutputFormat:    "prefix-{10..111}-suffix",
+				OutputShardSize: "10KB",
+				MaxMemUsage:     "80%",
+				BalanceShards:   true,
+			}
+			_, err := rs.parse()
+			Expect(err).Should(HaveOccurred())
+			Expect(errors.Is(err, errBalanceNoEKM)).To(BeTrue())
+		})
+
 		It("should fail when output shard size is empty and output format is %06d", func() {
 			rs := RequestSpec{
 				InputBck:       cmn.Bck{Name: "test"},
@@ -0,0 +1,312 @@
+// Package dsort provides distributed massively parallel resharding for very large datasets.
+/*
+ * Copyright (c) 2024, NVIDIA CORPORATION. All rights reserved.
+ */
+package dsort
+
+import (
+	"errors"
+	"net/http"
+	"net/url"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/NVIDIA/aistore/api/apc"
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/cmn/atomic"
+	"github.com/NVIDIA/aistore/cmn/cos"
+	"github.com/NVIDIA/aistore/cmn/nlog"
+	"github.com/NVIDIA/aistore/hk"
+	jsoniter "github.com/json-iterator/go"
+)
+
+// Multi-tenant job queueing (proxy side)
+// - enabled when any of the `max_jobs`, `max_jobs_per_user`, and `max_jobs_per_role` (dsort config) is non-zero;
+//   otherwise, jobs start immediately upon submission (no queueing);
+// - the queue is maintained by the primary - other proxies forward;
+// - a job counts against the per-role limit of each of its user's (AuthN) roles;
+// - fair share: the next job to start is the one of the user with the fewest running jobs
+//   (ties: the least recently served user, and then the longest-waiting job), subject to all limits;
+// - jobs that are running get periodically checked - a job is done when no target reports it as active;
+// - the queue is in-memory: pending (not yet started) jobs do not survive primary change or restart.
+
+const (
+	queueHkIval   = 10 * time.Second
+	queueMaxFailN = 32 // max number of failed-to-start jobs to keep (for status)
+
+	AnonUser = "anonymous" // when AuthN is disabled or the request carries no token
+)
+
+type (
+	QueuedJob struct {
+		ID        string    `json:"id"`
+		User      string    `json:"user"`
+		Roles     []string  `json:"roles,omitempty"`
+		SrcBck    cmn.Bck   `json:"src-bck"`
+		DstBck    cmn.Bck   `json:"dst-bck"`
+		Submitted time.Time `json:"submitted"`
+		Started   time.Time `json:"started,omitempty"`
+		Err       string    `json:"error,omitempty"` // failed to start
+		pars      *parsedReqSpec
+		starting  bool
+	}
+	QueueStatus struct {
+		Running        []*QueuedJob `json:"running"`
+		Pending        []*QueuedJob `json:"pending"`
+		Failed         []*QueuedJob `json:"failed,omitempty"`
+		MaxJobs        int          `json:"max_jobs"`
+		MaxJobsPerUser int          `json:"max_jobs_per_user"`
+		MaxJobsPerRole int          `json:"max_jobs_per_role"`
+	}
+
+	jobQueue struct {
+		running map[string]*QueuedJob
+		pending []*QueuedJob // in submission order
+		failed  []*QueuedJob
+		served  map[string]int64 // user => sequence number of the last job started
+		seq     int64
+		mu      sync.Mutex
+		hkreg   atomic.Bool
+	}
+)
+
+var pq = newJobQueue()
+
+func newJobQueue() *jobQueue {
+	return &jobQueue{running: make(map[string]*QueuedJob, 8), served: make(map[string]int64, 8)}
+}
+
+func QueueEnabled(config *cmn.DsortConf) bool {
+	return config.MaxJobs > 0 || config.MaxJobsPerUser > 0 || config.MaxJobsPerRole > 0
+}
+
+// returns true if the job can start right away (in which case it is already accounted as running)
+func (q *jobQueue) submit(job *QueuedJob, config *cmn.DsortConf) (now bool) {
+	if q.hkreg.CAS(false, true) {
+		hk.Reg(apc.ActDsort+"-queue"+hk.NameSuffix, q.housekeep, queueHkIval)
+	}
+	q.mu.Lock()
+	q.pending = append(q.pending, job)
+	for _, j := range q.pick(config) {
+		if j == job {
+			now = true // (caller starts it and calls `started` or `fail`)
+			continue
+		}
+		go q.start(j)
+	}
+	q.mu.Unlock()
+	return now
+}
+
+// under lock: fair-share selection of the next job(s) to run
+func (q *jobQueue) pick(config *cmn.DsortConf) (out []*QueuedJob) {
+	var (
+		nrun  = make(map[string]int, len(q.running)) // user => num running
+		nrole = make(map[string]int, len(q.running)) // role => ditto
+	)
+	for _, j := range q.running {
+		nrun[j.User]++
+		for _, role := range j.Roles {
+			nrole[role]++
+		}
+	}
+	for len(q.pending) > 0 {
+		if config.MaxJobs > 0 && len(q.running) >= config.MaxJobs {
+			break
+		}
+		idx := -1
+		for i, j := range q.pending {
+			n := nrun[j.User]
+			if config.MaxJobsPerUser > 0 && n >= config.MaxJobsPerUser {
+				continue
+			}
+			if config.MaxJobsPerRole > 0 && j.roleLimited(nrole, config.MaxJobsPerRole) {
+				continue
+			}
+			if idx < 0 {
+				idx = i
+				continue
+			}
+			user := q.pending[idx].User
+			if n < nrun[user] || (n == nrun[user] && q.served[j.User] < q.served[user]) {
+				idx = i
+			}
+		}
+		if idx < 0 {
+			break
+		}
+		j := q.pending[idx]
+		q.pending = append(q.pending[:idx], q.pending[idx+1:]...)
+		j.Started, j.starting = time.Now(), true
+		q.running[j.ID] = j
+		nrun[j.User]++
+		for _, role := range j.Roles {
+			nrole[role]++
+		}
+		q.seq++
+		q.served[j.User] = q.seq
+		out = append(out, j)
+	}
+	return out
+}
+
+func (q *jobQueue) start(job *QueuedJob) {
+	nlog.Infoln("[dsort] starting queued job", job.ID, "user", job.User, "waited", time.Since(job.Submitted))
+	if _, err := pstart(job.ID, job.pars); err != nil {
+		nlog.Errorln("[dsort] failed to start queued job", job.ID+":", err)
+		q.fail(job, err)
+		return
+	}
+	q.started(job)
+}
+
+func (q *jobQueue) started(job *QueuedJob) {
+	q.mu.Lock()
+	job.starting = false
+	q.mu.Unlock()
+}
+
+func (q *jobQueue) fail(job *QueuedJob, err error) {
+	q.mu.Lock()
+	delete(q.running, job.ID)
+	job.Err, job.starting = err.Error(), false
+	q.failed = append(q.failed, job)
+	if l := len(q.failed); l > queueMaxFailN {
+		q.failed = q.failed[l-queueMaxFailN:]
+	}
+	q.mu.Unlock()
+	q.schedule()
+}
+
+func (q *jobQueue) schedule() {
+	config := cmn.GCO.Get()
+	q.mu.Lock()
+	for _, j := range q.pick(&config.Dsort) {
+		go q.start(j)
+	}
+	q.mu.Unlock()
+}
+
+// remove pending job (abort, remove); returns false if not found
+func (q *jobQueue) remove(id string) bool {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	for i, j := range q.pending {
+		if j.ID == id {
+			q.pending = append(q.pending[:i], q.pending[i+1:]...)
+			return true
+		}
+	}
+	return false
+}
+
+func (q *jobQueue) status(config *cmn.DsortConf) *QueueStatus {
+	q.mu.Lock()
+	qs := &QueueStatus{
+		Running:        make([]*QueuedJob, 0, len(q.running)),
+		Pending:        make([]*QueuedJob, 0, len(q.pending)),
+		Failed:         make([]*QueuedJob, 0, len(q.failed)),
+		MaxJobs:        config.MaxJobs,
+		MaxJobsPerUser: config.MaxJobsPerUser,
+		MaxJobsPerRole: config.MaxJobsPerRole,
+	}
+	for _, j := range q.running {
+		qs.Running = append(qs.Running, j.clone())
+	}
+	for _, j := range q.pending {
+		qs.Pending = append(qs.Pending, j.clone())
+	}
+	for _, j := range q.failed {
+		qs.Failed = append(qs.Failed, j.clone())
+	}
+	q.mu.Unlock()
+	sort.Slice(qs.Running, func(i, j int) bool { return qs.Running[i].Started.Before(qs.Running[j].Started) })
+	return qs
+}
+
+func (j *QueuedJob) roleLimited(nrole map[string]int, limit int) bool {
+	for _, role := range j.Roles {
+		if nrole[role] >= limit {
+			return true
+		}
+	}
+	return false
+}
+
+func (j *QueuedJob) clone() *QueuedJob {
+	c := *j
+	c.pars = nil
+	return &c
+}
+
+// check running jobs and, when any of them is done, start the next one(s)
+func (q *jobQueue) housekeep(int64) time.Duration {
+	q.mu.Lock()
+	n := len(q.running)
+	q.mu.Unlock()
+	if n == 0 {
+		q.schedule() // (e.g., limits updated)
+		return queueHkIval
+	}
+	active, err := activeJobs()
+	if err != nil {
+		nlog.Warningln("[dsort] failed to check running jobs:", err)
+		return queueHkIval
+	}
+	q.mu.Lock()
+	for id, j := range q.running {
+		if _, ok := active[id]; !ok && !j.starting {
+			delete(q.running, id)
+			if cmn.Rom.FastV(4, cos.SmoduleDsort) {
+				nlog.Infoln("[dsort] queued job", id, "done")
+			}
+		}
+	}
+	q.mu.Unlock()
+	q.schedule()
+	return queueHkIval
+}
+
+// IDs of the jobs that are active on any target
+func activeJobs() (map[string]struct{}, error) {
+	var (
+		query     = url.Values{apc.QparamOnlyActive: []string{"true"}}
+		responses = bcast(http.MethodGet, apc.URLPathdSortList.S, query, nil, psi.Sowner().Get())
+		active    = make(map[string]struct{}, 8)
+	)
+	if len(responses) == 0 {
+		return nil, errors.New("no active targets")
+	}
+	for _, resp := range responses {
+		if resp.err != nil {
+			return nil, resp.err
+		}
+		var jobs []*JobInfo
+		if err := jsoniter.Unmarshal(resp.res, &jobs); err != nil {
+			return nil, err
+		}
+		for _, j := range jobs {
+			active[j.ID] = struct{}{}
+		}
+	}
+	return active, nil
+}
+
+// queued (pending, running, and failed) jobs; nil when queueing is disabled
+func PqueueStatus() *QueueStatus {
+	config := cmn.GCO.Get()
+	if !QueueEnabled(&config.Dsort) {
+		return nil
+	}
+	return pq.status(&config.Dsort)
+}
+
+// GET /v1/sort/queue
+func PqueueHandler(w http.ResponseWriter, r *http.Request) {
+	if !checkHTTPMethod(w, r, http.MethodGet) {
+		return
+	}
+	config := cmn.GCO.Get()
+	w.Write(cos.MustMarshal(pq.status(&config.Dsort)))
+}
@@ -0,0 +1,109 @@
+// Package dsort provides distributed massively parallel resharding for very large datasets.
+/*
+ * Copyright (c) 2024, NVIDIA CORPORATION. All rights reserved.
+ */
+package dsort
+
+import (
+	"strconv"
+
+	"github.com/NVIDIA/aistore/cmn"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("JobQueue", func() {
+	var (
+		q   *jobQueue
+		seq int
+	)
+	enqueue := func(user string, n int) {
+		for range n {
+			seq++
+			q.pending = append(q.pending, &QueuedJob{ID: strconv.Itoa(seq), User: user})
+		}
+	}
+	users := func(jobs []*QueuedJob) (out []string) {
+		for _, j := range jobs {
+			out = append(out, j.User)
+		}
+		return out
+	}
+	finish := func(user string) {
+		for id, j := range q.running {
+			if j.User == user {
+				delete(q.running, id)
+				return
+			}
+		}
+	}
+
+	BeforeEach(func() {
+		q = newJobQueue()
+		seq = 0
+	})
+
+	It("should start all jobs when unlimited", func() {
+		enqueue("a", 5)
+		Expect(q.pick(&cmn.DsortConf{})).To(HaveLen(5))
+		Expect(q.pending).To(BeEmpty())
+	})
+
+	It("should respect cluster-wide limit", func() {
+		enqueue("a", 5)
+		Expect(q.pick(&cmn.DsortConf{MaxJobs: 2})).To(HaveLen(2))
+		Expect(q.pick(&cmn.DsortConf{MaxJobs: 2})).To(BeEmpty())
+		Expect(q.pending).To(HaveLen(3))
+	})
+
+	It("should respect per-user limit", func() {
+		enqueue("a", 5)
+		enqueue("b", 1)
+		started := q.pick(&cmn.DsortConf{MaxJobsPerUser: 2})
+		Expect(users(started)).To(ConsistOf("a", "a", "b"))
+		Expect(q.pending).To(HaveLen(3))
+	})
+
+	It("should respect per-role limit", func() {
+		for _, user := range []string{"a", "b", "c"} {
+			seq++
+			q.pending = append(q.pending, &QueuedJob{ID: strconv.Itoa(seq), User: user, Roles: []string{"team-x"}})
+		}
+		seq++
+		q.pending = append(q.pending, &QueuedJob{ID: strconv.Itoa(seq), User: "d", Roles: []string{"team-y"}})
+		enqueue("e", 1) // no roles
+
+		config := &cmn.DsortConf{MaxJobsPerRole: 2}
+		Expect(users(q.pick(config))).To(ConsistOf("a", "b", "d", "e"))
+		Expect(q.pick(config)).To(BeEmpty())
+		finish("a")
+		Expect(users(q.pick(config))).To(Equal([]string{"c"}))
+	})
+
+	It("should not let one user starve the others", func() {
+		config := &cmn.DsortConf{MaxJobs: 2}
+		enqueue("a", 50)
+		enqueue("b", 2)
+		enqueue("c", 1)
+
+		// the user with the fewest running jobs goes first
+		Expect(users(q.pick(config))).To(Equal([]string{"a", "b"}))
+
+		// same number of running jobs: the least recently served goes first
+		finish("a")
+		Expect(users(q.pick(config))).To(Equal([]string{"c"}))
+		finish("b")
+		Expect(users(q.pick(config))).To(Equal([]string{"a"}))
+		finish("c")
+		Expect(users(q.pick(config))).To(Equal([]string{"b"}))
+		finish("a")
+		Expect(users(q.pick(config))).To(Equal([]string{"a"}))
+	})
+
+	It("should remove pending job", func() {
+		enqueue("a", 3)
+		Expect(q.remove("2")).To(BeTrue())
+		Expect(q.remove("2")).To(BeFalse())
+		Expect(q.pending).To(HaveLen(2))
+	})
+})
@@ -5,6 +5,7 @@
 package dsort
 
 import (
+	"errors"
 	"fmt"
 	"math"
 	"net/url"
@@ -52,6 +53,10 @@ type parsedReqSpec struct {
 	ExtractConcMaxLimit int                   `json:"extract_concurrency_max_limit"`
 	CreateConcMaxLimit  int                   `json:"create_concurrency_max_limit"`
 	SbundleMult         int                   `json:"bundle_multiplier"`
+	ETL                 *ETLSpec              `json:"etl,omitempty"`
+	AppendToShard       bool                  `json:"append_to_shard"`
+	BalanceShards       bool                  `json:"balance_shards"`
+	CleanupOnAbort      bool                  `json:"cleanup_on_abort"`
 
 	// debug
 	DsorterType string `json:"dsorter_type"`
@@ -191,6 +196,25 @@ func (rs *RequestSpec) parse() (*parsedReqSpec, error) {
 		}
 	}
 
+	// append-to-shard (see also: createShard)
+	if rs.AppendToShard {
+		if pars.OutputExtension == "" || pars.OutputExtension == archive.ExtZip {
+			return nil, specErr("append_to_shard", errAppendFormat)
+		}
+		if rs.DryRun {
+			return nil, specErr("append_to_shard", errAppendDryRun)
+		}
+		pars.AppendToShard = true
+	}
+
+	// balance (EKM only - see generateShardsWithOrderingFile)
+	if rs.BalanceShards {
+		if pars.EKMFileURL == "" {
+			return nil, specErr("balance_shards", errBalanceNoEKM)
+		}
+		pars.BalanceShards = true
+	}
+
 	// mem & conc
 	if rs.MaxMemUsage == "" {
 		rs.MaxMemUsage = cfg.DefaultMaxMemUsage
@@ -210,6 +234,12 @@ func (rs *RequestSpec) parse() (*parsedReqSpec, error) {
 	pars.CreateConcMaxLimit = rs.CreateConcMaxLimit
 	pars.DsorterType = rs.DsorterType
 	pars.DryRun = rs.DryRun
+	pars.CleanupOnAbort = rs.CleanupOnAbort
+
+	// etl
+	if pars.ETL, err = parseETL(&rs.ETL); err != nil {
+		return nil, err
+	}
 
 	// `cfg` here contains inherited (aka global) part of the dsort config -
 	// apply this request's rs.Config values to override or assign defaults
@@ -242,6 +272,30 @@ func (rs *RequestSpec) parse() (*parsedReqSpec, error) {
 	return pars, nil
 }
 
+func parseETL(spec *ETLSpec) (*ETLSpec, error) {
+	if spec.Name == "" {
+		if len(spec.Exts) > 0 || spec.Timeout != 0 {
+			return nil, specErr("etl", errors.New("missing ETL name"))
+		}
+		return nil, nil
+	}
+	if spec.Timeout < 0 {
+		return nil, specErr("etl.timeout", fmt.Errorf("invalid negative value %v", spec.Timeout))
+	}
+	etl := &ETLSpec{Name: spec.Name, Timeout: spec.Timeout}
+	for _, ext := range spec.Exts {
+		ext = strings.TrimSpace(ext)
+		if ext == "" {
+			continue
+		}
+		if ext[0] != '.' {
+			ext = "." + ext
+		}
+		etl.Exts = append(etl.Exts, ext)
+	}
+	return etl, nil
+}
+
 func parseAlgorithm(alg Algorithm) (*Algorithm, error) {
 	if !cos.StringInSlice(alg.Kind, algorithms) {
 		return nil, fmt.Errorf(fmtErrInvalidAlg, algorithms)
@@ -251,7 +305,8 @@ func parseAlgorithm(alg Algorithm) (*Algorithm, error) {
 			return nil, fmt.Errorf(fmtErrSeed, alg.Seed)
 		}
 	}
-	if alg.Kind == Content {
+	switch alg.Kind {
+	case Content:
 		alg.Ext = strings.TrimSpace(alg.Ext)
 		if alg.Ext == "" || alg.Ext[0] != '.' {
 			return nil, fmt.Errorf("%w %q", errAlgExt, alg.Ext)
@@ -259,7 +314,14 @@ func parseAlgorithm(alg Algorithm) (*Algorithm, error) {
 		if err := shard.ValidateContentKeyTy(alg.ContentKeyType); err != nil {
 			return nil, err
 		}
-	} else {
+	case Expr:
+		if alg.ContentKeyType == "" {
+			alg.ContentKeyType = shard.ContentKeyString
+		}
+		if _, err := shard.NewExprKeyExtractor(alg.Expression, alg.ContentKeyType); err != nil {
+			return nil, err
+		}
+	default:
 		alg.ContentKeyType = shard.ContentKeyString
 	}
 
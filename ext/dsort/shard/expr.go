@@ -0,0 +1,781 @@
+// Package shard provides Extract(shard), Create(shard), and associated methods
+// across all suppported archival formats (see cmn/archive/mime.go)
+/*
+ * Copyright (c) 2024, NVIDIA CORPORATION. All rights reserved.
+ */
+package shard
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"path"
+	"strconv"
+	"strings"
+
+	"github.com/NVIDIA/aistore/cmn/cos"
+	"github.com/OneOfOne/xxhash"
+)
+
+// Sorting key expression: user-defined (custom) ordering of records without forking dsort.
+//
+// The expression gets compiled once (when parsing the request spec) and evaluated on
+// each target for each extracted record. It is sandboxed by construction: no I/O,
+// no state, no loops - the cost of evaluation is linear in the size of the expression,
+// which itself is limited (see `maxExprLen`).
+//
+// Grammar (in the order of increasing precedence):
+//
+//	expr    := or [ "?" expr ":" expr ]
+//	or      := and { "||" and }
+//	and     := cmp { "&&" cmp }
+//	cmp     := add [ ( "==" | "!=" | "<" | "<=" | ">" | ">=" ) add ]
+//	add     := mul { ( "+" | "-" ) mul }
+//	mul     := unary { ( "*" | "/" | "%" ) unary }
+//	unary   := ( "-" | "!" ) unary | primary
+//	primary := number | "string" | name | func "(" [ expr { "," expr } ] ")" | "(" expr ")"
+//
+// where `name` is the record name, and `func` is one of the `exprFuncs` below.
+// Types: int (int64), float (float64), string, and bool; "+" with a string operand
+// concatenates. E.g., curriculum order by difficulty level encoded in the name
+// and, within each level, pseudo-random:
+//
+//	int(split(name, "_", 1)) * 1000000 + hash(name) % 1000000
+
+const (
+	maxExprLen   = 1024
+	maxExprDepth = 64
+)
+
+type (
+	efn func(name string) (any, error)
+
+	Expr struct {
+		fn  efn
+		src string
+	}
+
+	exprParser struct {
+		src   string
+		pos   int
+		depth int
+	}
+	exprFunc struct {
+		fn         func(args []any) (any, error)
+		minA, maxA int
+	}
+)
+
+var errExprDiv0 = errors.New("division by zero")
+
+var exprFuncs map[string]exprFunc
+
+func init() {
+	exprFuncs = map[string]exprFunc{
+		"len":        {_len, 1, 1},
+		"int":        {_int, 1, 1},
+		"float":      {_float, 1, 1},
+		"str":        {_str, 1, 1},
+		"substr":     {_substr, 2, 3},
+		"index":      {_index, 2, 2},
+		"split":      {_split, 3, 3},
+		"hash":       {_hash, 1, 1},
+		"lower":      {_lower, 1, 1},
+		"upper":      {_upper, 1, 1},
+		"base":       {_base, 1, 1},
+		"dir":        {_dir, 1, 1},
+		"has_prefix": {_hasPrefix, 2, 2},
+		"has_suffix": {_hasSuffix, 2, 2},
+		"min":        {_min, 2, 2},
+		"max":        {_max, 2, 2},
+		"abs":        {_abs, 1, 1},
+	}
+}
+
+func CompileExpr(src string) (*Expr, error) {
+	if strings.TrimSpace(src) == "" {
+		return nil, errors.New("sorting key expression is empty")
+	}
+	if len(src) > maxExprLen {
+		return nil, fmt.Errorf("sorting key expression is too long (%d > %d)", len(src), maxExprLen)
+	}
+	p := &exprParser{src: src}
+	fn, err := p.expr()
+	if err == nil {
+		if p.skip(); p.pos < len(p.src) {
+			err = p.errf("unexpected %q", p.src[p.pos:])
+		}
+	}
+	if err != nil {
+		return nil, fmt.Errorf("invalid sorting key expression %q: %v", src, err)
+	}
+	return &Expr{fn: fn, src: src}, nil
+}
+
+func (e *Expr) String() string { return e.src }
+
+// Eval computes the key of a given record and converts it to the key type (one of `contentKeyTypes`).
+func (e *Expr) Eval(name, ty string) (any, error) {
+	v, err := e.fn(name)
+	if err != nil {
+		return nil, fmt.Errorf("%q(%s): %v", e.src, name, err)
+	}
+	switch ty {
+	case ContentKeyInt:
+		v, err = _int([]any{v})
+	case ContentKeyFloat:
+		v, err = _float([]any{v})
+	case ContentKeyString:
+		v, err = _str([]any{v})
+	default:
+		return nil, &ErrSortingKeyType{ty}
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%q(%s): %v", e.src, name, err)
+	}
+	return v, nil
+}
+
+////////////////
+// exprParser //
+////////////////
+
+func (p *exprParser) errf(format string, a ...any) error {
+	return fmt.Errorf("at offset %d: "+format, append([]any{p.pos}, a...)...)
+}
+
+func (p *exprParser) skip() {
+	for p.pos < len(p.src) && (p.src[p.pos] == ' ' || p.src[p.pos] == '\t' || p.src[p.pos] == '\n') {
+		p.pos++
+	}
+}
+
+// consume `tok` if it's next (and is not a prefix of a longer operator)
+func (p *exprParser) accept(tok string) bool {
+	p.skip()
+	if !strings.HasPrefix(p.src[p.pos:], tok) {
+		return false
+	}
+	if next := p.pos + len(tok); len(tok) == 1 && next < len(p.src) && p.src[next] == '=' &&
+		strings.ContainsRune("=!<>", rune(tok[0])) {
+		return false
+	}
+	p.pos += len(tok)
+	return true
+}
+
+func (p *exprParser) expr() (efn, error) {
+	if p.depth++; p.depth > maxExprDepth {
+		return nil, p.errf("nesting is too deep")
+	}
+	defer func() { p.depth-- }()
+
+	cond, err := p.or()
+	if err != nil || !p.accept("?") {
+		return cond, err
+	}
+	yes, err := p.expr()
+	if err != nil {
+		return nil, err
+	}
+	if !p.accept(":") {
+		return nil, p.errf("expecting ':'")
+	}
+	no, err := p.expr()
+	if err != nil {
+		return nil, err
+	}
+	return func(name string) (any, error) {
+		c, err := truth(cond, name)
+		if err != nil {
+			return nil, err
+		}
+		if c {
+			return yes(name)
+		}
+		return no(name)
+	}, nil
+}
+
+func (p *exprParser) or() (efn, error) {
+	l, err := p.and()
+	for err == nil && p.accept("||") {
+		var r efn
+		if r, err = p.and(); err == nil {
+			l = logical(l, r, true)
+		}
+	}
+	return l, err
+}
+
+func (p *exprParser) and() (efn, error) {
+	l, err := p.cmp()
+	for err == nil && p.accept("&&") {
+		var r efn
+		if r, err = p.cmp(); err == nil {
+			l = logical(l, r, false)
+		}
+	}
+	return l, err
+}
+
+func (p *exprParser) cmp() (efn, error) {
+	l, err := p.add()
+	if err != nil {
+		return nil, err
+	}
+	for _, op := range []string{"==", "!=", "<=", ">=", "<", ">"} {
+		if p.accept(op) {
+			r, err := p.add()
+			if err != nil {
+				return nil, err
+			}
+			return binary(op, l, r), nil
+		}
+	}
+	return l, nil
+}
+
+func (p *exprParser) add() (efn, error) {
+	l, err := p.mul()
+	for err == nil {
+		var op string
+		switch {
+		case p.accept("+"):
+			op = "+"
+		case p.accept("-"):
+			op = "-"
+		default:
+			return l, nil
+		}
+		var r efn
+		if r, err = p.mul(); err == nil {
+			l = binary(op, l, r)
+		}
+	}
+	return nil, err
+}
+
+func (p *exprParser) mul() (efn, error) {
+	l, err := p.unary()
+	for err == nil {
+		var op string
+		switch {
+		case p.accept("*"):
+			op = "*"
+		case p.accept("/"):
+			op = "/"
+		case p.accept("%"):
+			op = "%"
+		default:
+			return l, nil
+		}
+		var r efn
+		if r, err = p.unary(); err == nil {
+			l = binary(op, l, r)
+		}
+	}
+	return nil, err
+}
+
+func (p *exprParser) unary() (efn, error) {
+	switch {
+	case p.accept("-"):
+		x, err := p.unary()
+		if err != nil {
+			return nil, err
+		}
+		return binary("-", func(string) (any, error) { return int64(0), nil }, x), nil
+	case p.accept("!"):
+		x, err := p.unary()
+		if err != nil {
+			return nil, err
+		}
+		return func(name string) (any, error) {
+			c, err := truth(x, name)
+			return !c, err
+		}, nil
+	}
+	return p.primary()
+}
+
+func (p *exprParser) primary() (efn, error) {
+	p.skip()
+	if p.pos >= len(p.src) {
+		return nil, p.errf("unexpected end of expression")
+	}
+	c := p.src[p.pos]
+	switch {
+	case c == '(':
+		p.pos++
+		x, err := p.expr()
+		if err != nil {
+			return nil, err
+		}
+		if !p.accept(")") {
+			return nil, p.errf("expecting ')'")
+		}
+		return x, nil
+	case c == '"':
+		return p.str()
+	case c >= '0' && c <= '9' || c == '.':
+		return p.num()
+	case c == '_' || c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z':
+		return p.ident()
+	}
+	return nil, p.errf("unexpected %q", c)
+}
+
+func (p *exprParser) str() (efn, error) {
+	start := p.pos
+	for p.pos++; p.pos < len(p.src); p.pos++ {
+		switch p.src[p.pos] {
+		case '\\':
+			p.pos++
+		case '"':
+			p.pos++
+			s, err := strconv.Unquote(p.src[start:p.pos])
+			if err != nil {
+				return nil, p.errf("invalid string literal %s", p.src[start:p.pos])
+			}
+			return func(string) (any, error) { return s, nil }, nil
+		}
+	}
+	return nil, p.errf("unterminated string literal")
+}
+
+func (p *exprParser) num() (efn, error) {
+	start := p.pos
+	for p.pos < len(p.src) && (p.src[p.pos] >= '0' && p.src[p.pos] <= '9' || p.src[p.pos] == '.') {
+		p.pos++
+	}
+	lit := p.src[start:p.pos]
+	if i, err := strconv.ParseInt(lit, 10, 64); err == nil {
+		return func(string) (any, error) { return i, nil }, nil
+	}
+	f, err := strconv.ParseFloat(lit, 64)
+	if err != nil {
+		return nil, p.errf("invalid number %q", lit)
+	}
+	return func(string) (any, error) { return f, nil }, nil
+}
+
+func (p *exprParser) ident() (efn, error) {
+	start := p.pos
+	for p.pos < len(p.src) {
+		c := p.src[p.pos]
+		if c != '_' && (c < 'a' || c > 'z') && (c < 'A' || c > 'Z') && (c < '0' || c > '9') {
+			break
+		}
+		p.pos++
+	}
+	id := p.src[start:p.pos]
+	if !p.accept("(") {
+		if id == "name" {
+			return func(name string) (any, error) { return name, nil }, nil
+		}
+		return nil, p.errf("unknown identifier %q (expecting \"name\" or function call)", id)
+	}
+	f, ok := exprFuncs[id]
+	if !ok {
+		return nil, p.errf("unknown function %q", id)
+	}
+	var args []efn
+	if !p.accept(")") {
+		for {
+			a, err := p.expr()
+			if err != nil {
+				return nil, err
+			}
+			args = append(args, a)
+			if p.accept(")") {
+				break
+			}
+			if !p.accept(",") {
+				return nil, p.errf("expecting ',' or ')'")
+			}
+		}
+	}
+	if len(args) < f.minA || len(args) > f.maxA {
+		return nil, p.errf("%s(): invalid number of arguments %d", id, len(args))
+	}
+	return func(name string) (any, error) {
+		vals := make([]any, len(args))
+		for i, a := range args {
+			v, err := a(name)
+			if err != nil {
+				return nil, err
+			}
+			vals[i] = v
+		}
+		return f.fn(vals)
+	}, nil
+}
+
+/////////////////
+// evaluation //
+/////////////////
+
+func truth(x efn, name string) (bool, error) {
+	v, err := x(name)
+	if err != nil {
+		return false, err
+	}
+	switch v := v.(type) {
+	case bool:
+		return v, nil
+	case int64:
+		return v != 0, nil
+	case float64:
+		return v != 0, nil
+	case string:
+		return v != "", nil
+	}
+	return false, nil
+}
+
+func logical(l, r efn, or bool) efn {
+	return func(name string) (any, error) {
+		c, err := truth(l, name)
+		if err != nil || c == or { // short-circuit
+			return c, err
+		}
+		return truth(r, name)
+	}
+}
+
+func binary(op string, l, r efn) efn {
+	return func(name string) (any, error) {
+		a, err := l(name)
+		if err != nil {
+			return nil, err
+		}
+		b, err := r(name)
+		if err != nil {
+			return nil, err
+		}
+		return _binary(op, a, b)
+	}
+}
+
+func _binary(op string, a, b any) (any, error) {
+	sa, aok := a.(string)
+	sb, bok := b.(string)
+	switch {
+	case aok && bok:
+		switch op {
+		case "+":
+			return sa + sb, nil
+		case "==", "!=", "<", "<=", ">", ">=":
+			return compare(op, strings.Compare(sa, sb)), nil
+		}
+		return nil, fmt.Errorf("invalid operation %q on strings", op)
+	case aok || bok:
+		if op == "+" {
+			x, _ := _str([]any{a})
+			y, _ := _str([]any{b})
+			return x.(string) + y.(string), nil
+		}
+		return nil, fmt.Errorf("invalid operation %q on mismatched types %T and %T", op, a, b)
+	}
+
+	ia, aok := a.(int64)
+	ib, bok := b.(int64)
+	if aok && bok {
+		switch op {
+		case "+":
+			return ia + ib, nil
+		case "-":
+			return ia - ib, nil
+		case "*":
+			return ia * ib, nil
+		case "/", "%":
+			if ib == 0 {
+				return nil, errExprDiv0
+			}
+			if op == "/" {
+				return ia / ib, nil
+			}
+			return ia % ib, nil
+		}
+		return compare(op, cmpInt(ia, ib)), nil
+	}
+
+	fa, err := _float([]any{a})
+	if err != nil {
+		return nil, err
+	}
+	fb, err := _float([]any{b})
+	if err != nil {
+		return nil, err
+	}
+	x, y := fa.(float64), fb.(float64)
+	switch op {
+	case "+":
+		return x + y, nil
+	case "-":
+		return x - y, nil
+	case "*":
+		return x * y, nil
+	case "/":
+		if y == 0 {
+			return nil, errExprDiv0
+		}
+		return x / y, nil
+	case "%":
+		if y == 0 {
+			return nil, errExprDiv0
+		}
+		return math.Mod(x, y), nil
+	}
+	switch {
+	case x < y:
+		return compare(op, -1), nil
+	case x > y:
+		return compare(op, 1), nil
+	}
+	return compare(op, 0), nil
+}
+
+func cmpInt(a, b int64) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	}
+	return 0
+}
+
+func compare(op string, c int) bool {
+	switch op {
+	case "==":
+		return c == 0
+	case "!=":
+		return c != 0
+	case "<":
+		return c < 0
+	case "<=":
+		return c <= 0
+	case ">":
+		return c > 0
+	default: // ">="
+		return c >= 0
+	}
+}
+
+///////////////
+// functions //
+///////////////
+
+func argStr(fname string, v any) (string, error) {
+	if s, ok := v.(string); ok {
+		return s, nil
+	}
+	return "", fmt.Errorf("%s(): expecting string, got %T", fname, v)
+}
+
+func argInt(fname string, v any) (int64, error) {
+	if i, ok := v.(int64); ok {
+		return i, nil
+	}
+	return 0, fmt.Errorf("%s(): expecting int, got %T", fname, v)
+}
+
+func _len(args []any) (any, error) {
+	s, err := argStr("len", args[0])
+	return int64(len(s)), err
+}
+
+func _int(args []any) (any, error) {
+	switch v := args[0].(type) {
+	case int64:
+		return v, nil
+	case float64:
+		return int64(v), nil
+	case bool:
+		if v {
+			return int64(1), nil
+		}
+		return int64(0), nil
+	case string:
+		return strconv.ParseInt(strings.TrimSpace(v), 10, 64)
+	}
+	return nil, fmt.Errorf("int(): unexpected %T", args[0])
+}
+
+func _float(args []any) (any, error) {
+	switch v := args[0].(type) {
+	case int64:
+		return float64(v), nil
+	case float64:
+		return v, nil
+	case bool:
+		if v {
+			return float64(1), nil
+		}
+		return float64(0), nil
+	case string:
+		return strconv.ParseFloat(strings.TrimSpace(v), 64)
+	}
+	return nil, fmt.Errorf("float(): unexpected %T", args[0])
+}
+
+func _str(args []any) (any, error) {
+	switch v := args[0].(type) {
+	case string:
+		return v, nil
+	case int64:
+		return strconv.FormatInt(v, 10), nil
+	case float64:
+		return strconv.FormatFloat(v, 'g', -1, 64), nil
+	case bool:
+		return strconv.FormatBool(v), nil
+	}
+	return nil, fmt.Errorf("str(): unexpected %T", args[0])
+}
+
+// substr(s, start[, end]) - byte offsets, clamped; negative offsets count from the end
+func _substr(args []any) (any, error) {
+	s, err := argStr("substr", args[0])
+	if err != nil {
+		return nil, err
+	}
+	l := int64(len(s))
+	clamp := func(i int64) int64 {
+		if i < 0 {
+			i += l
+		}
+		return min(max(i, 0), l)
+	}
+	start, err := argInt("substr", args[1])
+	if err != nil {
+		return nil, err
+	}
+	end := l
+	if len(args) > 2 {
+		if end, err = argInt("substr", args[2]); err != nil {
+			return nil, err
+		}
+	}
+	i, j := clamp(start), clamp(end)
+	if i >= j {
+		return "", nil
+	}
+	return s[i:j], nil
+}
+
+func _index(args []any) (any, error) {
+	s, err := argStr("index", args[0])
+	if err != nil {
+		return nil, err
+	}
+	sub, err := argStr("index", args[1])
+	return int64(strings.Index(s, sub)), err
+}
+
+// split(s, sep, i) - i-th field (negative: from the end), or empty string if out of range
+func _split(args []any) (any, error) {
+	s, err := argStr("split", args[0])
+	if err != nil {
+		return nil, err
+	}
+	sep, err := argStr("split", args[1])
+	if err != nil {
+		return nil, err
+	}
+	i, err := argInt("split", args[2])
+	if err != nil {
+		return nil, err
+	}
+	fields := strings.Split(s, sep)
+	if i < 0 {
+		i += int64(len(fields))
+	}
+	if i < 0 || i >= int64(len(fields)) {
+		return "", nil
+	}
+	return fields[i], nil
+}
+
+// non-negative pseudo-random (but deterministic) int - e.g., to shuffle within a group
+func _hash(args []any) (any, error) {
+	s, err := _str(args)
+	if err != nil {
+		return nil, err
+	}
+	return int64(xxhash.Checksum64S(cos.UnsafeB(s.(string)), cos.MLCG32) >> 1), nil
+}
+
+func _lower(args []any) (any, error) {
+	s, err := argStr("lower", args[0])
+	return strings.ToLower(s), err
+}
+
+func _upper(args []any) (any, error) {
+	s, err := argStr("upper", args[0])
+	return strings.ToUpper(s), err
+}
+
+func _base(args []any) (any, error) {
+	s, err := argStr("base", args[0])
+	return path.Base(s), err
+}
+
+func _dir(args []any) (any, error) {
+	s, err := argStr("dir", args[0])
+	return path.Dir(s), err
+}
+
+func _hasPrefix(args []any) (any, error) {
+	s, err := argStr("has_prefix", args[0])
+	if err != nil {
+		return nil, err
+	}
+	p, err := argStr("has_prefix", args[1])
+	return strings.HasPrefix(s, p), err
+}
+
+func _hasSuffix(args []any) (any, error) {
+	s, err := argStr("has_suffix", args[0])
+	if err != nil {
+		return nil, err
+	}
+	p, err := argStr("has_suffix", args[1])
+	return strings.HasSuffix(s, p), err
+}
+
+func _min(args []any) (any, error) {
+	less, err := _binary("<", args[1], args[0])
+	if err != nil {
+		return nil, err
+	}
+	if less.(bool) {
+		return args[1], nil
+	}
+	return args[0], nil
+}
+
+func _max(args []any) (any, error) {
+	less, err := _binary("<", args[0], args[1])
+	if err != nil {
+		return nil, err
+	}
+	if less.(bool) {
+		return args[1], nil
+	}
+	return args[0], nil
+}
+
+func _abs(args []any) (any, error) {
+	switch v := args[0].(type) {
+	case int64:
+		if v < 0 {
+			return -v, nil
+		}
+		return v, nil
+	case float64:
+		return math.Abs(v), nil
+	}
+	return nil, fmt.Errorf("abs(): expecting number, got %T", args[0])
+}
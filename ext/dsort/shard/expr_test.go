@@ -0,0 +1,73 @@
+// Package shard provides Extract(shard), Create(shard), and associated methods
+// across all suppported archival formats (see cmn/archive/mime.go)
+/*
+ * Copyright (c) 2024, NVIDIA CORPORATION. All rights reserved.
+ */
+package shard_test
+
+import (
+	"strings"
+
+	"github.com/NVIDIA/aistore/ext/dsort/shard"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Expr", func() {
+	DescribeTable("should evaluate",
+		func(src, name, ty string, expected any) {
+			expr, err := shard.CompileExpr(src)
+			Expect(err).NotTo(HaveOccurred())
+			key, err := expr.Eval(name, ty)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(key).To(Equal(expected))
+		},
+		Entry("name", `name`, "a/b/c", shard.ContentKeyString, "a/b/c"),
+		Entry("arithmetic", `1 + 2 * 3 - -4 / 2`, "", shard.ContentKeyInt, int64(9)),
+		Entry("float", `float(len(name)) / 2`, "abc", shard.ContentKeyFloat, 1.5),
+		Entry("concat", `"x-" + len(name)`, "abc", shard.ContentKeyString, "x-3"),
+		Entry("split", `int(split(base(name), "_", 1))`, "dir/img_42_a", shard.ContentKeyInt, int64(42)),
+		Entry("split from end", `split(name, "_", -1)`, "img_42_a", shard.ContentKeyString, "a"),
+		Entry("substr", `substr(name, -3)`, "sample00123", shard.ContentKeyString, "123"),
+		Entry("substr clamped", `substr(name, 2, 100)`, "abc", shard.ContentKeyString, "c"),
+		Entry("ternary", `has_prefix(name, "hard") ? 1 : 0`, "hard-001", shard.ContentKeyInt, int64(1)),
+		Entry("logical", `len(name) > 2 && !has_suffix(name, "x") || name == "x"`, "abc", shard.ContentKeyInt, int64(1)),
+		Entry("min max abs", `max(min(3, 5), abs(-4))`, "", shard.ContentKeyInt, int64(4)),
+		Entry("string compare", `name < "b"`, "a", shard.ContentKeyString, "true"),
+	)
+
+	DescribeTable("should fail to compile",
+		func(src string) {
+			_, err := shard.CompileExpr(src)
+			Expect(err).To(HaveOccurred())
+		},
+		Entry("empty", ``),
+		Entry("unbalanced", `(1 + 2`),
+		Entry("unknown identifier", `size`),
+		Entry("unknown function", `exec("rm")`),
+		Entry("wrong number of args", `len(name, name)`),
+		Entry("unterminated string", `"abc`),
+		Entry("trailing garbage", `1 2`),
+		Entry("too long", strings.Repeat("1+", 600)+"1"),
+		Entry("too deep", strings.Repeat("(", 100)+"1"+strings.Repeat(")", 100)),
+	)
+
+	It("should fail to evaluate", func() {
+		for _, src := range []string{`1 / (len(name) - 3)`, `name * 2`, `int(name)`} {
+			expr, err := shard.CompileExpr(src)
+			Expect(err).NotTo(HaveOccurred())
+			_, err = expr.Eval("abc", shard.ContentKeyInt)
+			Expect(err).To(HaveOccurred(), src)
+		}
+	})
+
+	It("should produce deterministic hash-based order", func() {
+		expr, err := shard.CompileExpr(`hash(name) % 1000`)
+		Expect(err).NotTo(HaveOccurred())
+		k1, err := expr.Eval("sample-1", shard.ContentKeyInt)
+		Expect(err).NotTo(HaveOccurred())
+		k2, _ := expr.Eval("sample-1", shard.ContentKeyInt)
+		Expect(k1).To(Equal(k2))
+		Expect(k1).To(BeNumerically(">=", 0))
+	})
+})
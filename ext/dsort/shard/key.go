@@ -47,6 +47,10 @@ type (
 		ty  string // one of contentKeyTypes: {"int", "string", ... } - see above
 		ext string // file with this extension provides sorting key (of the type `ty`)
 	}
+	exprKeyExtractor struct {
+		expr *Expr  // user-defined expression of the record name (see expr.go)
+		ty   string // resulting key type (one of contentKeyTypes)
+	}
 
 	ErrSortingKeyType struct {
 		ty string
@@ -93,6 +97,29 @@ func (*nameKeyExtractor) ExtractKey(ske *SingleKeyExtractor) (any, error) {
 	return ske.name, nil
 }
 
+//////////////////////
+// exprKeyExtractor //
+//////////////////////
+
+func NewExprKeyExtractor(src, ty string) (KeyExtractor, error) {
+	if err := ValidateContentKeyTy(ty); err != nil {
+		return nil, err
+	}
+	expr, err := CompileExpr(src)
+	if err != nil {
+		return nil, err
+	}
+	return &exprKeyExtractor{expr: expr, ty: ty}, nil
+}
+
+func (*exprKeyExtractor) PrepareExtractor(name string, r cos.ReadSizer, _ string) (cos.ReadSizer, *SingleKeyExtractor, bool) {
+	return r, &SingleKeyExtractor{name: name}, false
+}
+
+func (ke *exprKeyExtractor) ExtractKey(ske *SingleKeyExtractor) (any, error) {
+	return ke.expr.Eval(ske.name, ke.ty)
+}
+
 /////////////////////////
 // contentKeyExtractor //
 /////////////////////////
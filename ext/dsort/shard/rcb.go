@@ -8,6 +8,7 @@ package shard
 import (
 	"archive/tar"
 	"archive/zip"
+	"io"
 
 	"github.com/NVIDIA/aistore/cmn/archive"
 	"github.com/NVIDIA/aistore/cmn/cos"
@@ -47,6 +48,15 @@ func (c *rcbCtx) xtar(_ string, reader cos.ReadCloseSizer, hdr any) (bool /*stop
 	header, ok := hdr.(*tar.Header)
 	debug.Assert(ok)
 
+	reader, err := c.xform(reader, header.Name)
+	if err != nil {
+		return true, err
+	}
+	if size := reader.Size(); size != header.Size {
+		header.Size = size
+		delete(header.PAXRecords, "size")
+	}
+
 	bmeta := cos.MustMarshal(header)
 	c.offset += c.parent.MetadataSize()
 	if header.Format == tar.FormatPAX {
@@ -108,6 +118,11 @@ func (c *rcbCtx) xzip(_ string, reader cos.ReadCloseSizer, hdr any) (bool /*stop
 	header, ok := hdr.(*zip.FileHeader)
 	debug.Assert(ok)
 
+	reader, err := c.xform(reader, header.Name)
+	if err != nil {
+		return true, err
+	}
+
 	metadata := zipFileHeader{
 		Name:    header.Name,
 		Comment: header.Comment,
@@ -135,6 +150,29 @@ func (c *rcbCtx) xzip(_ string, reader cos.ReadCloseSizer, hdr any) (bool /*stop
 	return err != nil /*stop*/, err
 }
 
+// transform record content (iff configured and matching), and stage the result in memory -
+// the transformed size must be known prior to writing tar header
+func (c *rcbCtx) xform(reader cos.ReadCloseSizer, name string) (cos.ReadCloseSizer, error) {
+	xf := c.extractor.Transformer()
+	if xf == nil || !xf.Match(name) {
+		return reader, nil
+	}
+	r, err := xf.Transform(reader, reader.Size(), name)
+	if err != nil {
+		reader.Close()
+		return nil, err
+	}
+	sgl := core.T.PageMM().NewSGL(max(r.Size(), 0))
+	_, err = io.Copy(sgl, r) // (SGL implements io.ReaderFrom)
+	r.Close()
+	reader.Close()
+	if err != nil {
+		sgl.Free()
+		return nil, err
+	}
+	return cos.NewReaderWithArgs(cos.ReaderArgs{R: sgl, Size: sgl.Size(), DeferCb: sgl.Free}), nil
+}
+
 // common method to extract compressed tar using `ar` (archive reader)
 func (c *rcbCtx) extract(lom *core.LOM, ar archive.Reader) error {
 	workFQN := fs.CSM.Gen(lom, ct.DsortFileType, "") // tarFQN
@@ -56,6 +56,13 @@ type (
 
 	RecordExtractor interface {
 		RecordWithBuffer(args *extractRecordArgs) (int64, error)
+		Transformer() Transformer
+	}
+
+	// transforms record objects on the fly, during shard extraction (e.g., via ETL)
+	Transformer interface {
+		Match(recordName string) bool
+		Transform(r io.Reader, size int64, recordName string) (cos.ReadCloseSizer, error)
 	}
 
 	RecordManager struct {
@@ -65,6 +72,7 @@ type (
 
 		extractCreator  RW
 		keyExtractor    KeyExtractor
+		xformer         Transformer // optional
 		contents        *sync.Map
 		extractionPaths *sync.Map // Keys correspond to all paths to record contents on disk.
 
@@ -91,6 +99,9 @@ func NewRecordManager(bck cmn.Bck, extractCreator RW, keyExtractor KeyExtractor,
 	}
 }
 
+func (recm *RecordManager) SetTransformer(xf Transformer) { recm.xformer = xf }
+func (recm *RecordManager) Transformer() Transformer      { return recm.xformer }
+
 func (recm *RecordManager) RecordWithBuffer(args *extractRecordArgs) (size int64, err error) {
 	var (
 		storeType        string
@@ -105,6 +105,12 @@ func (trw *tarRW) Extract(lom *core.LOM, r cos.ReadReaderAt, extractor RecordExt
 		return 0, 0, err
 	}
 	c := &rcbCtx{parent: trw, tw: nil, extractor: extractor, shardName: lom.ObjName, toDisk: toDisk, fromTar: true}
+	if extractor.Transformer() != nil {
+		// transformed records cannot be read at their original offsets -
+		// rewrite the shard locally (same as compressed tar)
+		err = c.extract(lom, ar)
+		return c.extractedSize, c.extractedCount, err
+	}
 	buf, slab := core.T.PageMM().AllocSize(lom.Lsize())
 	c.buf = buf
 
@@ -0,0 +1,100 @@
+// Package dsort provides distributed massively parallel resharding for very large datasets.
+/*
+ * Copyright (c) 2024, NVIDIA CORPORATION. All rights reserved.
+ */
+package dsort
+
+import (
+	"fmt"
+
+	"github.com/NVIDIA/aistore/api/apc"
+	"github.com/NVIDIA/aistore/core/meta"
+	"github.com/NVIDIA/aistore/ext/dsort/shard"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Network topology", func() {
+	// 3 racks in 2 zones, 4 targets per rack, interleaved
+	newTmap := func(labeled bool) meta.NodeMap {
+		tmap := make(meta.NodeMap, 12)
+		for i := range 12 {
+			si := &meta.Snode{DaeID: fmt.Sprintf("t%d", i), DaeType: apc.Target}
+			if labeled {
+				rack := i % 3
+				si.Topo = meta.Topo(fmt.Sprintf("z%d", rack/2), fmt.Sprintf("r%d", rack))
+			}
+			tmap[si.DaeID] = si
+		}
+		return tmap
+	}
+
+	It("should order targets by rack and zone", func() {
+		for _, salt := range []uint64{1, 2, 3, 12345} {
+			order := _torder(salt, newTmap(true))
+			Expect(order).To(HaveLen(12))
+
+			// each rack is contiguous
+			seen := make(map[string]bool, 3)
+			for i, si := range order {
+				if i > 0 && order[i-1].Topo == si.Topo {
+					continue
+				}
+				Expect(seen[si.Topo]).To(BeFalse(), "rack %s is not contiguous: %v", si.Topo, order)
+				seen[si.Topo] = true
+			}
+			Expect(seen).To(HaveLen(3))
+
+			// the first round of record distribution is intra-rack
+			for i := 0; i < len(order); i += 2 {
+				Expect(order[i].CrossRack(order[i+1])).To(BeFalse())
+			}
+		}
+	})
+
+	It("should not change the order of unlabeled targets", func() {
+		a, b := _torder(42, newTmap(false)), _torder(42, newTmap(false))
+		for i := range a {
+			Expect(a[i].ID()).To(Equal(b[i].ID()))
+		}
+		Expect(_torder(43, newTmap(false))).NotTo(Equal(a))
+	})
+
+	It("should create intra-rack shards first", func() {
+		var (
+			tmap       = newTmap(true)
+			tsi        = tmap["t0"] // rack r0
+			recordSize = func(r *shard.Record) int64 { return r.TotalSize() }
+		)
+		newShard := func(name string, tids ...string) *shard.Shard {
+			s := &shard.Shard{Name: name, Records: shard.NewRecords(len(tids))}
+			for i, tid := range tids {
+				s.Records.Insert(&shard.Record{
+					Key:      name + tid,
+					Name:     fmt.Sprintf("%s-%d", name, i),
+					DaemonID: tid,
+					Objects:  []*shard.RecordObj{{Size: 100}},
+				})
+			}
+			return s
+		}
+		shards := []*shard.Shard{
+			newShard("s0", "t1", "t2"),       // 2 cross-rack
+			newShard("s1", "t0", "t3"),       // intra-rack
+			newShard("s2", "t1", "t3", "t4"), // 2 cross-rack
+			newShard("s3", "t5"),             // 1 cross-rack
+		}
+		xrack := orderByTopo(tsi, shards, tmap, recordSize)
+		Expect(xrack).To(BeEquivalentTo(500))
+		names := make([]string, 0, len(shards))
+		for _, s := range shards {
+			names = append(names, s.Name)
+		}
+		Expect(names).To(Equal([]string{"s1", "s3", "s0", "s2"}))
+
+		// unlabeled: no change
+		shards = []*shard.Shard{newShard("s0", "t1"), newShard("s1", "t0")}
+		Expect(orderByTopo(&meta.Snode{DaeID: "t0"}, shards, tmap, recordSize)).To(BeZero())
+		Expect(shards[0].Name).To(Equal("s0"))
+	})
+})
@@ -0,0 +1,41 @@
+// Package dsort provides distributed massively parallel resharding for very large datasets.
+/*
+ * Copyright (c) 2024, NVIDIA CORPORATION. All rights reserved.
+ */
+package dsort
+
+import (
+	"io"
+	"time"
+
+	"github.com/NVIDIA/aistore/cmn/cos"
+	"github.com/NVIDIA/aistore/ext/dsort/shard"
+	"github.com/NVIDIA/aistore/ext/etl"
+)
+
+// etlXformer streams record objects through the (running) ETL during extraction - see ETLSpec
+
+// interface guard
+var _ shard.Transformer = (*etlXformer)(nil)
+
+type etlXformer struct {
+	comm    etl.Communicator
+	exts    []string
+	timeout time.Duration
+}
+
+func newETLXformer(spec *ETLSpec) (*etlXformer, error) {
+	comm, err := etl.GetCommunicator(spec.Name)
+	if err != nil {
+		return nil, err
+	}
+	return &etlXformer{comm: comm, exts: spec.Exts, timeout: spec.Timeout.D()}, nil
+}
+
+func (xf *etlXformer) Match(recordName string) bool {
+	return len(xf.exts) == 0 || cos.StringInSlice(cos.Ext(recordName), xf.exts)
+}
+
+func (xf *etlXformer) Transform(r io.Reader, size int64, recordName string) (cos.ReadCloseSizer, error) {
+	return xf.comm.StreamTransform(r, size, recordName, xf.timeout)
+}
@@ -71,6 +71,7 @@ type (
 		CommTypeX string       `json:"communication"` // enum commTypes
 		ArgTypeX  string       `json:"argument"`      // enum argTypes
 		Timeout   cos.Duration `json:"timeout"`
+		Cache     *CacheConf   `json:"cache,omitempty"` // (optional) cache inline-transformed objects
 	}
 	InitSpecMsg struct {
 		InitMsgBase
@@ -98,6 +99,12 @@ type (
 	}
 )
 
+// Inline transformation results can be cached on the targets - see cache.go
+type CacheConf struct {
+	TTL     cos.Duration `json:"ttl,omitempty"`      // evict cached results older than (zero: never)
+	MaxSize cos.SizeIEC  `json:"max_size,omitempty"` // max total size, per target (zero: unlimited)
+}
+
 type (
 	InfoList []Info
 	Info     struct {
@@ -208,6 +215,17 @@ func (m *InitMsgBase) validate(detail string) error {
 		return cmn.NewErrETLf(errCtx, ferr, err, detail)
 	}
 
+	if m.Cache != nil {
+		if m.CommTypeX == Hpull {
+			err := fmt.Errorf("caching transformed objects is not supported with comm-type %q", Hpull)
+			return cmn.NewErrETLf(errCtx, ferr, err, detail)
+		}
+		if m.Cache.TTL < 0 || m.Cache.MaxSize < 0 {
+			err := fmt.Errorf("invalid cache config %+v (ttl and max_size must be non-negative)", *m.Cache)
+			return cmn.NewErrETLf(errCtx, ferr, err, detail)
+		}
+	}
+
 	//
 	// ArgTypeFQN ("fqn") can also be globally disallowed
 	//
@@ -39,6 +39,8 @@ type etlBootstrapper struct {
 	uri             string
 	originalPodName string
 	originalCommand []string
+	cache           *Cache // (optional) see cache.go
+	health          Health // see health.go
 }
 
 func (b *etlBootstrapper) createPodSpec() (err error) {
@@ -0,0 +1,308 @@
+// Package etl provides utilities to initialize and use transformation pods.
+/*
+ * Copyright (c) 2024, NVIDIA CORPORATION. All rights reserved.
+ */
+package etl
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/cmn/atomic"
+	"github.com/NVIDIA/aistore/cmn/cos"
+	"github.com/NVIDIA/aistore/cmn/nlog"
+	"github.com/NVIDIA/aistore/core"
+	"github.com/NVIDIA/aistore/core/meta"
+	"github.com/NVIDIA/aistore/fs"
+	"github.com/NVIDIA/aistore/hk"
+	"github.com/OneOfOne/xxhash"
+	jsoniter "github.com/json-iterator/go"
+)
+
+// Inline transformation cache (see CacheConf).
+// Transformed objects are stored in a hidden (per bucket, per mountpath) namespace - fs.ETLCacheType -
+// under "<etl name>/<object name>". Each entry starts with its key: the digest of the source object's
+// (version, checksum, size, mtime), the ETL hash, and the (pruned) request query; the key is followed
+// by the (length-prefixed) transformer's response headers and the transformed content.
+// - ETL hash is the digest of the init message and environment (code, dependencies) - re-initializing
+//   the same-named ETL with a different spec or code does not serve stale results;
+// - key mismatch (e.g., when the source object gets overwritten) is a miss - the entry gets replaced;
+// - housekeeping evicts entries older than TTL and, when over max_size, the oldest ones;
+// - space cleanup removes cached entries of the ETLs that are not running.
+
+const (
+	cacheKeyLen  = 16 // hex-encoded xxhash
+	cacheHlenLen = 8  // hex-encoded length of the response headers that follow the key
+	cacheIval    = 5 * time.Minute
+	cacheWorkTag = "etl-cache"
+)
+
+type (
+	Cache struct {
+		comm Communicator
+		conf CacheConf
+		name string
+		hash string
+		size atomic.Int64 // total size of the cached entries (as of the last housekeeping run, plus added)
+	}
+	// tee inline-transformed response => cache workfile
+	cacheWriter struct {
+		http.ResponseWriter
+		fh     *os.File
+		status int
+		hdone  bool // response headers written
+	}
+	cacheEntry struct {
+		fqn   string
+		mtime int64
+		size  int64
+	}
+)
+
+func newCache(msg *InitSpecMsg, env map[string]string) *Cache {
+	h := xxhash.New64()
+	h.Write(cos.MustMarshal(msg))
+	keys := make([]string, 0, len(env))
+	for k := range env {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		h.WriteString(k)
+		h.WriteString("\x00")
+		h.WriteString(env[k])
+		h.WriteString("\x00")
+	}
+	return &Cache{conf: *msg.Cache, name: msg.IDX, hash: strconv.FormatUint(h.Sum64(), 16)}
+}
+
+func (c *Cache) hkName() string { return "etl-cache-" + c.name + hk.NameSuffix }
+
+func (c *Cache) key(r *http.Request, lom *core.LOM, mtime time.Time) string {
+	h := xxhash.New64()
+	h.WriteString(c.hash)
+	h.WriteString(lom.Version())
+	h.WriteString(lom.Checksum().Value())
+	h.WriteString(strconv.FormatInt(lom.Lsize(), 10))
+	h.WriteString(strconv.FormatInt(mtime.UnixNano(), 10))
+	h.WriteString(pruneQuery(r.URL.RawQuery))
+	return fmt.Sprintf("%0*x", cacheKeyLen, h.Sum64())
+}
+
+func (c *Cache) fqn(lom *core.LOM) string {
+	return lom.Mountpath().MakePathFQN(lom.Bucket(), fs.ETLCacheType, c.name+"/"+lom.ObjName)
+}
+
+// InlineTransform serves the cached result, if present and current;
+// otherwise, transforms the object and caches the result.
+func (c *Cache) InlineTransform(w http.ResponseWriter, r *http.Request, lom *core.LOM) error {
+	lom.Lock(false)
+	err := lom.Load(true /*cache it*/, true /*locked*/)
+	var mtime time.Time
+	if err == nil {
+		_, _, mtime, err = lom.Fstat(false)
+	}
+	lom.Unlock(false)
+	if err != nil {
+		// (e.g., remote object that is not present in the cluster)
+		return c.comm.InlineTransform(w, r, lom)
+	}
+
+	var (
+		key = c.key(r, lom, mtime)
+		fqn = c.fqn(lom)
+	)
+	if c.serve(w, lom, fqn, key) {
+		return nil
+	}
+	if c.conf.MaxSize > 0 && c.size.Load() >= int64(c.conf.MaxSize) {
+		return c.comm.InlineTransform(w, r, lom) // full (until the next housekeeping run)
+	}
+
+	// miss
+	wfqn := fs.CSM.Gen(lom, fs.WorkfileType, cacheWorkTag)
+	fh, err := cos.CreateFile(wfqn)
+	if err == nil {
+		_, err = fh.WriteString(key)
+	}
+	if err != nil {
+		nlog.Warningln("etl-cache", c.name, "failed to create", wfqn, "err:", err)
+		if fh != nil {
+			cos.Close(fh)
+			cos.RemoveFile(wfqn)
+		}
+		return c.comm.InlineTransform(w, r, lom)
+	}
+	cw := &cacheWriter{ResponseWriter: w, fh: fh, status: http.StatusOK}
+	err = c.comm.InlineTransform(cw, r, lom)
+	c.put(cw, wfqn, fqn, err)
+	return err
+}
+
+func (c *Cache) serve(w http.ResponseWriter, lom *core.LOM, fqn, key string) bool {
+	fh, err := os.Open(fqn)
+	if err != nil {
+		return false
+	}
+	defer fh.Close()
+	var buf [cacheKeyLen + cacheHlenLen]byte
+	if _, err := io.ReadFull(fh, buf[:]); err != nil || string(buf[:cacheKeyLen]) != key {
+		return false
+	}
+	hlen, err := strconv.ParseInt(string(buf[cacheKeyLen:]), 16, 32)
+	if err != nil {
+		return false
+	}
+	var (
+		hdr  http.Header
+		hbuf = make([]byte, hlen)
+	)
+	if _, err := io.ReadFull(fh, hbuf); err != nil {
+		return false
+	}
+	if err := jsoniter.Unmarshal(hbuf, &hdr); err != nil {
+		return false
+	}
+	finfo, err := fh.Stat()
+	if err != nil {
+		return false
+	}
+	size := finfo.Size() - cacheKeyLen - cacheHlenLen - hlen
+	for k, v := range hdr {
+		w.Header()[k] = v
+	}
+	w.Header().Set(cos.HdrContentLength, strconv.FormatInt(size, 10))
+	if _, err := io.Copy(w, fh); err != nil && cmn.Rom.FastV(4, cos.SmoduleETL) {
+		nlog.Warningln("etl-cache", c.name, "failed to serve", fqn, "err:", err)
+	}
+
+	// same accounting as the transformation itself (see `doRequest`)
+	xctn := c.comm.Xact()
+	xctn.InObjsAdd(1, lom.Lsize())
+	xctn.OutObjsAdd(1, size)
+	return true
+}
+
+func (c *Cache) put(cw *cacheWriter, wfqn, fqn string, err error) {
+	cw.writeHdr() // (in case of empty response)
+
+	if cw.fh == nil { // failed writing
+		cos.RemoveFile(wfqn)
+		return
+	}
+	size, _ := cw.fh.Seek(0, io.SeekCurrent)
+	errClose := cw.fh.Close()
+	if err != nil || errClose != nil || cw.status != http.StatusOK {
+		cos.RemoveFile(wfqn)
+		return
+	}
+	if err := cos.Rename(wfqn, fqn); err != nil {
+		nlog.Warningln("etl-cache", c.name, "failed to cache", fqn, "err:", err)
+		cos.RemoveFile(wfqn)
+		return
+	}
+	c.size.Add(size)
+}
+
+// remove expired entries; enforce max size (oldest first)
+func (c *Cache) housekeep(int64) time.Duration {
+	var (
+		entries []cacheEntry
+		total   int64
+		now     = time.Now().UnixNano()
+		ttl     = int64(c.conf.TTL)
+		avail   = fs.GetAvail()
+	)
+	core.T.Bowner().Get().Range(nil, nil, func(bck *meta.Bck) bool {
+		for _, mi := range avail {
+			dir := mi.MakePathFQN(bck.Bucket(), fs.ETLCacheType, c.name)
+			filepath.WalkDir(dir, func(fqn string, de os.DirEntry, err error) error {
+				if err != nil || de.IsDir() {
+					return nil
+				}
+				finfo, err := de.Info()
+				if err != nil {
+					return nil
+				}
+				mtime := finfo.ModTime().UnixNano()
+				if ttl > 0 && mtime+ttl < now {
+					cos.RemoveFile(fqn)
+					return nil
+				}
+				entries = append(entries, cacheEntry{fqn: fqn, mtime: mtime, size: finfo.Size()})
+				total += finfo.Size()
+				return nil
+			})
+		}
+		return false
+	})
+	if maxSize := int64(c.conf.MaxSize); maxSize > 0 && total > maxSize {
+		sort.Slice(entries, func(i, j int) bool { return entries[i].mtime < entries[j].mtime })
+		for i := 0; i < len(entries) && total > maxSize; i++ {
+			if err := cos.RemoveFile(entries[i].fqn); err == nil {
+				total -= entries[i].size
+			}
+		}
+	}
+	c.size.Store(total)
+	return cacheIval
+}
+
+/////////////////
+// cacheWriter //
+/////////////////
+
+// response headers are final upon the first WriteHeader (or Write) - cache them
+// (excepting those that are not the transformer's to decide)
+func (cw *cacheWriter) writeHdr() {
+	if cw.hdone {
+		return
+	}
+	cw.hdone = true
+	if cw.fh == nil {
+		return
+	}
+	hdr := cw.ResponseWriter.Header().Clone()
+	for _, k := range []string{cos.HdrContentLength, "Transfer-Encoding", "Connection", "Date"} {
+		hdr.Del(k)
+	}
+	b := cos.MustMarshal(hdr)
+	if _, err := fmt.Fprintf(cw.fh, "%0*x", cacheHlenLen, len(b)); err == nil {
+		if _, err = cw.fh.Write(b); err == nil {
+			return
+		}
+	}
+	cos.Close(cw.fh)
+	cw.fh = nil
+}
+
+func (cw *cacheWriter) WriteHeader(status int) {
+	cw.status = status
+	cw.writeHdr()
+	cw.ResponseWriter.WriteHeader(status)
+}
+
+func (cw *cacheWriter) Write(b []byte) (int, error) {
+	cw.writeHdr()
+	n, err := cw.ResponseWriter.Write(b)
+	if cw.fh != nil && n > 0 {
+		if _, errW := cw.fh.Write(b[:n]); errW != nil {
+			cos.Close(cw.fh)
+			cw.fh = nil
+		}
+	}
+	return n, err
+}
+
+func (cw *cacheWriter) Flush() {
+	if f, ok := cw.ResponseWriter.(http.Flusher); ok {
+		f.Flush()
+	}
+}
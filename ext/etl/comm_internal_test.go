@@ -37,7 +37,9 @@ func TestETLTransform(t *testing.T) {
 var _ = Describe("CommunicatorTest", func() {
 	var (
 		tmpDir            string
+		lom               *core.LOM
 		comm              Communicator
+		inline            func(w http.ResponseWriter, r *http.Request, lom *core.LOM) error
 		transformerServer *httptest.Server
 		targetServer      *httptest.Server
 		proxyServer       *httptest.Server
@@ -74,7 +76,7 @@ var _ = Describe("CommunicatorTest", func() {
 		// cluster.InitLomLocker(tMock)
 
 		// Create an object.
-		lom := &core.LOM{ObjName: objName}
+		lom = &core.LOM{ObjName: objName}
 		err = lom.InitBck(clusterBck.Bucket())
 		Expect(err).NotTo(HaveOccurred())
 		err = createRandomFile(lom.FQN, dataSize)
@@ -86,11 +88,15 @@ var _ = Describe("CommunicatorTest", func() {
 
 		// Initialize the HTTP servers.
 		transformerServer = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+			w.Header().Set(cos.HdrContentType, "application/x-transformed")
 			_, err := w.Write(transformData)
 			Expect(err).NotTo(HaveOccurred())
 		}))
 		targetServer = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-			err := comm.InlineTransform(w, r, lom)
+			if inline == nil {
+				inline = comm.InlineTransform
+			}
+			err := inline(w, r, lom)
 			Expect(err).NotTo(HaveOccurred())
 		}))
 		proxyServer = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
@@ -99,6 +105,7 @@ var _ = Describe("CommunicatorTest", func() {
 	})
 
 	AfterEach(func() {
+		inline = nil
 		_ = os.RemoveAll(tmpDir)
 		proxyServer.Close()
 		transformerServer.Close()
@@ -139,6 +146,40 @@ var _ = Describe("CommunicatorTest", func() {
 			Expect(b).To(Equal(transformData))
 		})
 	}
+
+	It("should serve cached transformation with headers and stats", func() {
+		pod := &corev1.Pod{}
+		pod.SetName("somename")
+
+		xctn := mock.NewXact(apc.ActETLInline)
+		boot := &etlBootstrapper{
+			msg:  InitSpecMsg{InitMsgBase: InitMsgBase{CommTypeX: Hrev, IDX: "cached"}},
+			pod:  pod,
+			uri:  transformerServer.URL,
+			xctn: xctn,
+		}
+		comm = newCommunicator(nil, boot)
+		fs.CSM.Reg(fs.WorkfileType, &fs.WorkfileContentResolver{}, true)
+		fs.CSM.Reg(fs.ETLCacheType, &fs.ETLCacheContentResolver{}, true)
+		cache := &Cache{comm: comm, name: boot.msg.IDX, hash: "hash"}
+		inline = cache.InlineTransform
+
+		for i := range 2 {
+			resp, err := http.Get(targetServer.URL)
+			Expect(err).NotTo(HaveOccurred())
+			b, err := cos.ReadAll(resp.Body)
+			resp.Body.Close()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(b).To(Equal(transformData))
+			Expect(resp.Header.Get(cos.HdrContentType)).To(Equal("application/x-transformed"))
+			Expect(xctn.OutObjs()).To(BeEquivalentTo(i + 1))
+			if i == 0 {
+				transformerServer.Close() // the second one must be a hit
+			}
+		}
+		Expect(xctn.InObjs()).To(BeEquivalentTo(1)) // (Hrev does not count inputs)
+		Expect(xctn.OutBytes()).To(BeEquivalentTo(2 * dataSize))
+	})
 })
 
 // Creates a file with random content.
@@ -56,6 +56,17 @@ type (
 		// See also, and separately: on-the-fly transformation as part of a user (e.g. training model) GET request handling
 		OfflineTransform(lom *core.LOM, timeout time.Duration) (cos.ReadCloseSizer, error)
 
+		// StreamTransform transforms arbitrary named content that is not (yet) an object -
+		// e.g., dsort records in the middle of shard extraction.
+		// Only `pushComm` (with the default or URL argument type) supports it.
+		StreamTransform(r io.Reader, size int64, name string, timeout time.Duration) (cos.ReadCloseSizer, error)
+
+		// Cache returns inline transformation cache or nil, if not configured (see CacheConf)
+		Cache() *Cache
+
+		// Health returns local (per-node) ETL health - see health.go
+		Health() *Health
+
 		Stop()
 
 		CommStats
@@ -147,12 +158,18 @@ func (c *baseComm) String() string {
 }
 
 func (c *baseComm) Xact() core.Xact { return c.boot.xctn }
+func (c *baseComm) Cache() *Cache   { return c.boot.cache }
+func (c *baseComm) Health() *Health { return &c.boot.health }
 func (c *baseComm) ObjCount() int64 { return c.boot.xctn.Objs() }
 func (c *baseComm) InBytes() int64  { return c.boot.xctn.InBytes() }
 func (c *baseComm) OutBytes() int64 { return c.boot.xctn.OutBytes() }
 
 func (c *baseComm) Stop() { c.boot.xctn.Finish() }
 
+func (c *baseComm) StreamTransform(io.Reader, int64, string, time.Duration) (cos.ReadCloseSizer, error) {
+	return nil, fmt.Errorf("%s: stream transform requires %q communication type", c, Hpush)
+}
+
 func (c *baseComm) getWithTimeout(url string, size int64, timeout time.Duration) (r cos.ReadCloseSizer, err error) {
 	if err := c.boot.xctn.AbortErr(); err != nil {
 		return nil, err
@@ -337,6 +354,61 @@ func (pc *pushComm) OfflineTransform(lom *core.LOM, timeout time.Duration) (r co
 	return
 }
 
+func (pc *pushComm) StreamTransform(r io.Reader, size int64, name string, timeout time.Duration) (cos.ReadCloseSizer, error) {
+	if pc.boot.msg.ArgTypeX == ArgTypeFQN {
+		return nil, fmt.Errorf("%s: stream transform is not supported with %q argument type", pc, ArgTypeFQN)
+	}
+	if err := pc.boot.xctn.AbortErr(); err != nil {
+		return nil, err
+	}
+	var (
+		ctx    = context.Background()
+		cancel = func() {}
+		u      = pc.boot.uri + (&url.URL{Path: "/" + name}).EscapedPath()
+	)
+	if timeout != 0 {
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPut, u, r)
+	if err != nil {
+		cancel()
+		return nil, err
+	}
+	if len(pc.command) != 0 {
+		q := req.URL.Query()
+		q["command"] = []string{"bash", "-c", strings.Join(pc.command, " ")}
+		req.URL.RawQuery = q.Encode()
+	}
+	req.ContentLength = size
+	req.Header.Set(cos.HdrContentType, cos.ContentBinary)
+
+	resp, err := core.T.DataClient().Do(req) //nolint:bodyclose // closed by the caller
+	if err != nil {
+		cancel()
+		return nil, err
+	}
+	if resp.StatusCode >= http.StatusBadRequest {
+		b, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		resp.Body.Close()
+		cancel()
+		return nil, fmt.Errorf("%s: failed to transform %q: status %d: %s", pc, name, resp.StatusCode, strings.TrimSpace(string(b)))
+	}
+	if cmn.Rom.FastV(5, cos.SmoduleETL) {
+		nlog.Infoln(Hpush, name)
+	}
+	args := cos.ReaderArgs{
+		R:      resp.Body,
+		Size:   resp.ContentLength,
+		ReadCb: func(n int, _ error) { pc.boot.xctn.InObjsAdd(0, int64(n)) },
+		DeferCb: func() {
+			cancel()
+			pc.boot.xctn.InObjsAdd(1, 0)
+			pc.boot.xctn.OutObjsAdd(1, size)
+		},
+	}
+	return cos.NewReaderWithArgs(args), nil
+}
+
 //////////////////
 // redirectComm: implements Hpull
 //////////////////
@@ -0,0 +1,117 @@
+// Package etl provides utilities to initialize and use transformation pods.
+/*
+ * Copyright (c) 2024, NVIDIA CORPORATION. All rights reserved.
+ */
+package etl
+
+import (
+	"encoding/json"
+	"sync"
+	"time"
+
+	"github.com/NVIDIA/aistore/cmn/atomic"
+	"github.com/NVIDIA/aistore/cmn/mono"
+)
+
+// Per-node ETL health, as observed by inline transformations:
+// - `UnhealthyErrs` consecutive failures to transform mark the local ETL unhealthy;
+// - while unhealthy, the target routes inline transform requests to healthy peers,
+//   forwarding the object's content (see ais/tgtetl.go);
+// - once every `ProbeIval` a single request is let through to probe the local ETL -
+//   success marks it healthy again.
+
+const (
+	UnhealthyErrs = 3
+	ProbeIval     = 10 * time.Second
+)
+
+// enum NodeStatus.Status
+const (
+	NodeHealthy   = "healthy"
+	NodeUnhealthy = "unhealthy"
+)
+
+type (
+	NodeStatus struct {
+		TargetID  string `json:"target_id"`
+		Status    string `json:"status"`               // enum { NodeHealthy, NodeUnhealthy }
+		LastErr   string `json:"last_error,omitempty"` // most recent failure to transform
+		Errs      int64  `json:"consecutive_errors"`
+		Fallbacks int64  `json:"fallbacks"`        // inline transforms routed to peers
+		Served    int64  `json:"served_for_peers"` // transformed on behalf of peers
+	}
+	StatusByTarget []*NodeStatus
+
+	// ETL details: init message and per-node status
+	Details struct {
+		InitMsg json.RawMessage `json:"init_msg"`
+		Nodes   StatusByTarget  `json:"nodes"`
+	}
+
+	Health struct {
+		lastErr   string
+		errs      int64
+		probed    int64 // mono time of the last probe
+		mu        sync.Mutex
+		fallbacks atomic.Int64
+		served    atomic.Int64
+	}
+)
+
+func (d *Details) Msg() (InitMsg, error) { return UnmarshalInitMsg(d.InitMsg) }
+
+////////////
+// Health //
+////////////
+
+func (h *Health) Healthy() bool {
+	h.mu.Lock()
+	ok := h.errs < UnhealthyErrs
+	h.mu.Unlock()
+	return ok
+}
+
+// same as above but also returns true when it is time to probe unhealthy ETL
+func (h *Health) Ok() bool {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if h.errs < UnhealthyErrs {
+		return true
+	}
+	now := mono.NanoTime()
+	if time.Duration(now-h.probed) < ProbeIval {
+		return false
+	}
+	h.probed = now
+	return true
+}
+
+func (h *Health) Success() {
+	h.mu.Lock()
+	h.errs, h.lastErr = 0, ""
+	h.mu.Unlock()
+}
+
+func (h *Health) Failure(err error) {
+	h.mu.Lock()
+	h.errs++
+	h.lastErr = err.Error()
+	if h.errs == UnhealthyErrs {
+		h.probed = mono.NanoTime()
+	}
+	h.mu.Unlock()
+}
+
+func (h *Health) IncFallback() { h.fallbacks.Inc() }
+func (h *Health) IncServed()   { h.served.Inc() }
+
+func (h *Health) Status(tid string) *NodeStatus {
+	ns := &NodeStatus{TargetID: tid, Status: NodeHealthy, Fallbacks: h.fallbacks.Load(), Served: h.served.Load()}
+	h.mu.Lock()
+	ns.Errs, ns.LastErr = h.errs, h.lastErr
+	h.mu.Unlock()
+	if ns.Errs >= UnhealthyErrs {
+		ns.Status = NodeUnhealthy
+	}
+	return ns
+}
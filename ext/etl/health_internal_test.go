@@ -0,0 +1,73 @@
+// Package etl provides utilities to initialize and use transformation pods.
+/*
+ * Copyright (c) 2024, NVIDIA CORPORATION. All rights reserved.
+ */
+package etl
+
+import (
+	"errors"
+
+	"github.com/NVIDIA/aistore/cmn/cos"
+	"github.com/NVIDIA/aistore/cmn/mono"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Health", func() {
+	It("should mark ETL unhealthy after consecutive failures", func() {
+		h := &Health{}
+		errTransform := errors.New("connection refused")
+		for range UnhealthyErrs - 1 {
+			h.Failure(errTransform)
+			Expect(h.Ok()).To(BeTrue())
+		}
+		h.Success()
+		Expect(h.Status("t1").Errs).To(BeZero())
+
+		for range UnhealthyErrs {
+			h.Failure(errTransform)
+		}
+		Expect(h.Healthy()).To(BeFalse())
+		Expect(h.Ok()).To(BeFalse())
+		ns := h.Status("t1")
+		Expect(ns.Status).To(Equal(NodeUnhealthy))
+		Expect(ns.Errs).To(BeEquivalentTo(UnhealthyErrs))
+		Expect(ns.LastErr).To(Equal(errTransform.Error()))
+	})
+
+	It("should probe unhealthy ETL once per interval", func() {
+		h := &Health{}
+		for range UnhealthyErrs {
+			h.Failure(errors.New("fail"))
+		}
+		h.probed = mono.NanoTime() - int64(ProbeIval) // (time to probe)
+		Expect(h.Ok()).To(BeTrue())
+		Expect(h.Ok()).To(BeFalse())
+
+		h.Success()
+		Expect(h.Ok()).To(BeTrue())
+		Expect(h.Status("t1").Status).To(Equal(NodeHealthy))
+	})
+
+	It("should count fallbacks and unmarshal details", func() {
+		h := &Health{}
+		h.IncFallback()
+		h.IncFallback()
+		h.IncServed()
+		ns := h.Status("t1")
+		Expect(ns.Fallbacks).To(BeEquivalentTo(2))
+		Expect(ns.Served).To(BeEquivalentTo(1))
+
+		msg := &InitCodeMsg{InitMsgBase: InitMsgBase{IDX: "xform", CommTypeX: Hpush}, Code: []byte("code")}
+		details := &Details{InitMsg: cos.MustMarshal(msg), Nodes: StatusByTarget{ns}}
+		b := cos.MustMarshal(details)
+		out := &Details{}
+		Expect(cos.JSON.Unmarshal(b, out)).NotTo(HaveOccurred())
+		initMsg, err := out.Msg()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(initMsg.Name()).To(Equal("xform"))
+		Expect(initMsg.MsgType()).To(Equal(Code))
+		Expect(out.Nodes).To(HaveLen(1))
+		Expect(*out.Nodes[0]).To(Equal(*ns))
+	})
+})
@@ -20,6 +20,7 @@ import (
 	"github.com/NVIDIA/aistore/core"
 	"github.com/NVIDIA/aistore/core/meta"
 	"github.com/NVIDIA/aistore/ext/etl/runtime"
+	"github.com/NVIDIA/aistore/hk"
 	"github.com/NVIDIA/aistore/xact/xreg"
 	corev1 "k8s.io/api/core/v1"
 	k8sErrors "k8s.io/apimachinery/pkg/api/errors"
@@ -285,6 +286,9 @@ func start(msg *InitSpecMsg, xid string, opts StartOpts, config *cmn.Config) (er
 	}
 
 	boot.setupXaction(xid)
+	if msg.Cache != nil {
+		boot.cache = newCache(msg, opts.Env)
+	}
 
 	// finally, add Communicator to the runtime registry
 	comm := newCommunicator(newAborter(msg.IDX), boot)
@@ -292,6 +296,10 @@ func start(msg *InitSpecMsg, xid string, opts StartOpts, config *cmn.Config) (er
 		return
 	}
 	core.T.Sowner().Listeners().Reg(comm)
+	if c := boot.cache; c != nil {
+		c.comm = comm
+		hk.Reg(c.hkName(), c.housekeep, cacheIval)
+	}
 	return
 }
 
@@ -319,6 +327,9 @@ func Stop(id string, errCause error) error {
 
 	if c := reg.del(id); c != nil {
 		core.T.Sowner().Listeners().Unreg(c)
+		if cache := c.Cache(); cache != nil {
+			hk.Unreg(cache.hkName())
+		}
 	}
 
 	c.Stop()
@@ -38,6 +38,10 @@ const (
 	WorkfileType = "wk"
 	ECSliceType  = "ec"
 	ECMetaType   = "mt"
+	TrashType    = "tr"
+	DedupType    = "dd"
+	ETLCacheType = "et"
+	CustomMDType = "cm"
 )
 
 type (
@@ -178,6 +182,10 @@ type (
 	WorkfileContentResolver struct{}
 	ECSliceContentResolver  struct{}
 	ECMetaContentResolver   struct{}
+	TrashContentResolver    struct{}
+	DedupContentResolver    struct{}
+	ETLCacheContentResolver struct{}
+	CustomMDContentResolver struct{}
 )
 
 func (*ObjectContentResolver) PermToMove() bool                   { return true }
@@ -252,3 +260,82 @@ func (*ECMetaContentResolver) GenUniqueFQN(base, _ string) string { return base
 func (*ECMetaContentResolver) ParseUniqueFQN(base string) (orig string, old, ok bool) {
 	return base, false, true
 }
+
+// Soft-deleted objects: original name followed by the (hex-encoded) deletion time
+// that is generated by the caller and passed in as prefix.
+// Trash is neither rebalanced nor evicted - the content gets removed by the
+// purge xaction upon expiration of the bucket's retention (see cmn.TrashConf).
+
+const trashSepa = '.'
+
+func (*TrashContentResolver) PermToMove() bool    { return false }
+func (*TrashContentResolver) PermToEvict() bool   { return false }
+func (*TrashContentResolver) PermToProcess() bool { return false }
+
+func (*TrashContentResolver) GenUniqueFQN(base, prefix string) string {
+	return base + string(trashSepa) + prefix
+}
+
+func (*TrashContentResolver) ParseUniqueFQN(base string) (orig string, old, ok bool) {
+	orig, _, ok = ParseTrashName(base)
+	return orig, false, ok
+}
+
+// ParseTrashName splits trashed name into the original one and its deletion time (in nanoseconds).
+func ParseTrashName(name string) (orig string, deleted int64, ok bool) {
+	i := strings.LastIndexByte(name, trashSepa)
+	if i <= 0 || i == len(name)-1 {
+		return "", 0, false
+	}
+	deleted, err := strconv.ParseInt(name[i+1:], 16, 64)
+	if err != nil || deleted <= 0 {
+		return "", 0, false
+	}
+	return name[:i], deleted, true
+}
+
+// TrashTag formats deletion time for CSM.Gen (see ParseTrashName).
+func TrashTag(deleted int64) string { return strconv.FormatInt(deleted, 16) }
+
+// Deduplicated content (see cmn.DedupConf): one entry per unique content, named by its checksum
+// and hard-linked with all the objects that have this content. Entries are neither rebalanced nor
+// evicted - storage cleanup removes those that are no longer referenced.
+
+func (*DedupContentResolver) PermToMove() bool                   { return false }
+func (*DedupContentResolver) PermToEvict() bool                  { return false }
+func (*DedupContentResolver) PermToProcess() bool                { return false }
+func (*DedupContentResolver) GenUniqueFQN(base, _ string) string { return base }
+
+func (*DedupContentResolver) ParseUniqueFQN(base string) (orig string, old, ok bool) {
+	return base, false, true
+}
+
+// DedupName returns the name of the dedup entry for a given checksum: "<type>/<xx>/<value>"
+func DedupName(cksum *cos.Cksum) string {
+	ty, val := cksum.Get()
+	return filepath.Join(ty, val[:2], val)
+}
+
+// Cached results of inline transformations (see ext/etl/cache.go): "<etl name>/<object name>".
+// Entries are not rebalanced - a miss on the new location simply transforms the object again.
+
+func (*ETLCacheContentResolver) PermToMove() bool                   { return false }
+func (*ETLCacheContentResolver) PermToEvict() bool                  { return true }
+func (*ETLCacheContentResolver) PermToProcess() bool                { return false }
+func (*ETLCacheContentResolver) GenUniqueFQN(base, _ string) string { return base }
+
+func (*ETLCacheContentResolver) ParseUniqueFQN(base string) (orig string, old, ok bool) {
+	return base, false, true
+}
+
+// Custom metadata sidecars (see core/lom_sidecar.go): one per object replica, under the object's
+// name, on the replica's mountpath. Sidecars follow their objects - storage cleanup removes orphans.
+
+func (*CustomMDContentResolver) PermToMove() bool                   { return false }
+func (*CustomMDContentResolver) PermToEvict() bool                  { return false }
+func (*CustomMDContentResolver) PermToProcess() bool                { return false }
+func (*CustomMDContentResolver) GenUniqueFQN(base, _ string) string { return base }
+
+func (*CustomMDContentResolver) ParseUniqueFQN(base string) (orig string, old, ok bool) {
+	return base, false, true
+}
@@ -70,8 +70,15 @@ func (mi *Mountpath) RemoveDeleted(who string) (rerr error) {
 // MoveToDeleted removes directory in steps:
 // 1. Synchronously gets temporary directory name
 // 2. Synchronously renames old folder to temporary directory
-func (mi *Mountpath) MoveToDeleted(dir string) (err error) {
-	var base, tmpBase, tmpDst string
+func (mi *Mountpath) MoveToDeleted(dir string) error {
+	_, err := mi.moveToDeleted(dir, false /*keep*/)
+	return err
+}
+
+// returns the new location, if moved; when out of space, removes the directory
+// right away - unless `keep` is true (in which case the caller will do it)
+func (mi *Mountpath) moveToDeleted(dir string, keep bool) (tmpDst string, err error) {
+	var base, tmpBase string
 	err = cos.Stat(dir)
 	if err != nil {
 		if os.IsNotExist(err) {
@@ -84,7 +91,7 @@ func (mi *Mountpath) MoveToDeleted(dir string) (err error) {
 		cs          = Cap()
 		errCap, oos = cs.Err(), cs.IsOOS()
 	)
-	if errCap != nil {
+	if errCap != nil && !keep {
 		goto rm // not moving - removing
 	}
 	base = filepath.Base(dir)
@@ -99,8 +106,9 @@ func (mi *Mountpath) MoveToDeleted(dir string) (err error) {
 
 	tmpDst = filepath.Join(tmpBase, strconv.FormatInt(mono.NanoTime(), 10))
 	if err = os.Rename(dir, tmpDst); err == nil {
-		return // ok
+		return tmpDst, nil // ok
 	}
+	tmpDst = ""
 
 	if cos.IsErrOOS(err) {
 		oos = true
@@ -114,7 +122,7 @@ rm:
 	if oos {
 		nlog.Errorf("%s %s: OOS (%v)", mi, cs.String(), err)
 	}
-	return err
+	return "", err
 }
 
 func (mi *Mountpath) ClearMDs(inclBMD bool) (rerr error) {
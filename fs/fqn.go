@@ -35,6 +35,10 @@ const (
 	WorkfileAppend       = "append"         // APPEND to object (as file)
 	WorkfileAppendToArch = "append-to-arch" // APPEND to existing archive
 	WorkfileCreateArch   = "create-arch"    // CREATE multi-object archive
+	WorkfileRestore      = "restore"        // restore soft-deleted object from another mountpath
+	WorkfileCOW          = "cow"            // copy-on-write: materialize data shared with cloned object(s)
+	WorkfileDedup        = "dedup"          // link deduplicated object to already stored content
+	WorkfilePatch        = "patch"          // write range into existing object (PATCH)
 )
 
 type ParsedFQN struct {
@@ -8,6 +8,7 @@ import (
 	"os"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/NVIDIA/aistore/api/apc"
 	"github.com/NVIDIA/aistore/cmn"
@@ -392,6 +393,25 @@ func TestMakeAndParseFQN(t *testing.T) {
 	}
 }
 
+func TestTrashName(t *testing.T) {
+	var (
+		resolver = &fs.TrashContentResolver{}
+		deleted  = time.Now().UnixNano()
+	)
+	for _, objName := range []string{"object", "dir/object", "dir/object.tar.gz", "dir.ext/object.1"} {
+		trashed := resolver.GenUniqueFQN(objName, fs.TrashTag(deleted))
+		orig, ts, ok := fs.ParseTrashName(trashed)
+		if !ok || orig != objName || ts != deleted {
+			t.Errorf("%q: got (%q, %d, %t), want (%q, %d, true)", trashed, orig, ts, ok, objName, deleted)
+		}
+	}
+	for _, name := range []string{"object", "object.", ".123", "object.xyz", "object.-1"} {
+		if _, _, ok := fs.ParseTrashName(name); ok {
+			t.Errorf("%q: expected to fail parsing", name)
+		}
+	}
+}
+
 func BenchmarkParseFQN(b *testing.B) {
 	var (
 		mpath = "/tmp/mpath"
@@ -24,7 +24,7 @@ import (
 	"github.com/NVIDIA/aistore/cmn/fname"
 	"github.com/NVIDIA/aistore/cmn/nlog"
 	"github.com/NVIDIA/aistore/ios"
-	"github.com/OneOfOne/xxhash"
+	"github.com/NVIDIA/aistore/sys"
 )
 
 const bidUnknownTTL = 2 * time.Minute // comment below; TODO: unify and move to config along w/ lom cache
@@ -64,6 +64,8 @@ type (
 		flags      uint64    // bit flags (set/get atomic)
 		PathDigest uint64    // (HRW logic)
 		capacity   Capacity
+		fsSize     uint64 // filesystem size (bytes) as of the last ComputeDiskSize or DetectResize
+		NumaNode   int    // NUMA node of the disk(s), or -1 when unknown or mixed (see NumaConf.DiskLocal)
 	}
 	MPI map[string]*Mountpath
 
@@ -80,6 +82,7 @@ type (
 		// mountpaths
 		available ratomic.Pointer[MPI]
 		disabled  ratomic.Pointer[MPI]
+		replaced  ratomic.Pointer[Replaced] // (see ReplaceMpath)
 
 		// capacity
 		cs        CapStatus
@@ -118,7 +121,8 @@ func NewMountpath(mpath string, label ios.Label) (*Mountpath, error) {
 	mi := &Mountpath{
 		Path:       cleanMpath,
 		Label:      label,
-		PathDigest: xxhash.Checksum64S(cos.UnsafeB(cleanMpath), cos.MLCG32),
+		PathDigest: pathDigest(cleanMpath),
+		NumaNode:   -1,
 	}
 	err = mi.resolveFS()
 	return mi, err
@@ -338,6 +342,15 @@ func (mi *Mountpath) createBckDirs(bck *cmn.Bck, nilbmd bool) (int, error) {
 
 func (mi *Mountpath) _setDisks(fsdisks ios.FsDisks) {
 	mi.Disks = fsdisks.ToSlice()
+	mi.NumaNode = -1
+	for i, disk := range mi.Disks {
+		id := sys.DiskNumaNode(disk)
+		if i > 0 && id != mi.NumaNode {
+			mi.NumaNode = -1
+			break
+		}
+		mi.NumaNode = id
+	}
 }
 
 // CapRefresh: available/used capacity
@@ -945,7 +958,19 @@ func CreateBucket(bck *cmn.Bck, nilbmd bool) (errs []error) {
 }
 
 // NOTE: caller must evict LOM cache
-func DestroyBucket(op string, bck *cmn.Bck, bid uint64) (err error) {
+func DestroyBucket(op string, bck *cmn.Bck, bid uint64) error {
+	_, err := destroyBucket(op, bck, bid, false /*keep*/)
+	return err
+}
+
+// same as above, except for moving bucket directories to 'deleted' even when
+// out of space - for the caller to remove them (in the background)
+// and return their respective (new) locations
+func MoveBucketToDeleted(op string, bck *cmn.Bck, bid uint64) ([]string, error) {
+	return destroyBucket(op, bck, bid, true /*keep*/)
+}
+
+func destroyBucket(op string, bck *cmn.Bck, bid uint64, keep bool) (deleted []string, err error) {
 	var (
 		n     int
 		avail = GetAvail()
@@ -966,23 +991,27 @@ func DestroyBucket(op string, bck *cmn.Bck, bid uint64) (err error) {
 					now = time.Now()
 				}
 				if mtime.After(now) || now.Sub(mtime) < bidUnknownTTL {
-					return fmt.Errorf("%s %q: unknown BID with %q age below ttl (%v)", op, bck, bdir, mtime)
+					return deleted, fmt.Errorf("%s %q: unknown BID with %q age below ttl (%v)", op, bck, bdir, mtime)
 				}
 			}
 		}
 
 		dir := mi.makeDelPathBck(bck)
-		if errMv := mi.MoveToDeleted(dir); errMv != nil {
+		tmpDst, errMv := mi.moveToDeleted(dir, keep)
+		if errMv != nil {
 			nlog.Errorf("%s %q: failed to rm dir %q: %v", op, bck, dir, errMv)
 			mfs.hc.FSHC(errMv, mi, "")
-		} else {
-			n++
+			continue
 		}
+		if tmpDst != "" {
+			deleted = append(deleted, tmpDst)
+		}
+		n++
 	}
 	if n < count {
 		err = fmt.Errorf("%s %q: failed to destroy %d out of %d dirs", op, bck, count-n, count)
 	}
-	return
+	return deleted, err
 }
 
 func RenameBucketDirs(bckFrom, bckTo *cmn.Bck) (err error) {
@@ -1106,11 +1135,40 @@ func ComputeDiskSize() {
 		avail     = GetAvail()
 	)
 	for _, mi := range avail {
-		totalSize += mi.diskSize()
+		size := mi.diskSize()
+		ratomic.StoreUint64(&mi.fsSize, size)
+		totalSize += size
 	}
 	mfs.totalSize.Store(totalSize)
 }
 
+// detect online resizing (growth or shrinkage) of the mountpaths' filesystems -
+// e.g., LVM or cloud volume resize followed by resize2fs or xfs_growfs;
+// returns resized mountpaths, if any, in which case also updates the total and
+// expires cached capacity status (see CapPeriodic)
+func DetectResize() (resized []string) {
+	var (
+		totalSize uint64
+		avail     = GetAvail()
+	)
+	for _, mi := range avail {
+		size := mi.diskSize()
+		if size == 0 {
+			size = ratomic.LoadUint64(&mi.fsSize) // (error logged)
+		}
+		if prev := ratomic.SwapUint64(&mi.fsSize, size); prev != 0 && prev != size {
+			nlog.Warningln(mi.String(), "resized:", cos.ToSizeIEC(int64(prev), 2), "=>", cos.ToSizeIEC(int64(size), 2))
+			resized = append(resized, mi.Path)
+		}
+		totalSize += size
+	}
+	if len(resized) > 0 {
+		mfs.totalSize.Store(totalSize)
+		ExpireCapCache()
+	}
+	return resized
+}
+
 func GetDiskSize() uint64 { return mfs.totalSize.Load() }
 
 // bucket and bucket+prefix on-disk sizing
@@ -5,6 +5,7 @@
 package fs
 
 import (
+	"errors"
 	"fmt"
 	"os"
 	"syscall"
@@ -49,3 +50,9 @@ func DirectOpen(path string, flag int, perm os.FileMode) (*os.File, error) {
 
 	return file, nil
 }
+
+// DropCache: no-op (compare with DirectOpen - F_NOCACHE).
+func DropCache(*os.File) error { return nil }
+
+// CloneFile: not supported (compare with Linux FICLONE).
+func CloneFile(string, string) error { return errors.ErrUnsupported }
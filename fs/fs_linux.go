@@ -11,6 +11,10 @@ import (
 	"path/filepath"
 	"strings"
 	"syscall"
+
+	"github.com/NVIDIA/aistore/cmn/cos"
+
+	"golang.org/x/sys/unix"
 )
 
 const procmounts = "/proc/mounts"
@@ -88,3 +92,31 @@ outer:
 func DirectOpen(path string, flag int, perm os.FileMode) (*os.File, error) {
 	return os.OpenFile(path, syscall.O_DIRECT|flag, perm)
 }
+
+// DropCache advises the OS to evict (clean) cached pages of a given file,
+// so that subsequent reads go to disk.
+func DropCache(file *os.File) error {
+	return unix.Fadvise(int(file.Fd()), 0, 0, unix.FADV_DONTNEED)
+}
+
+// CloneFile creates `dst` that shares data extents with `src` (reflink, copy-on-write);
+// fails with ENOTSUP, EXDEV, EINVAL, etc. when the filesystem does not support it.
+func CloneFile(src, dst string) error {
+	sfh, err := os.Open(src)
+	if err != nil {
+		return err
+	}
+	defer sfh.Close()
+	dfh, err := os.OpenFile(dst, os.O_CREATE|os.O_WRONLY|os.O_EXCL, cos.PermRWR)
+	if err != nil {
+		return err
+	}
+	err = unix.IoctlFileClone(int(dfh.Fd()), int(sfh.Fd()))
+	if errc := dfh.Close(); err == nil {
+		err = errc
+	}
+	if err != nil {
+		os.Remove(dst)
+	}
+	return err
+}
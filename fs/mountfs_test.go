@@ -305,6 +305,73 @@ func TestMoveMarkers(t *testing.T) {
 	}
 }
 
+func TestMountpathDetectResize(t *testing.T) {
+	initFS()
+
+	mpath := "/tmp/clouder"
+	tools.AddMpath(t, mpath)
+	fs.ComputeDiskSize()
+	size := fs.GetDiskSize()
+	tassert.Errorf(t, size > 0, "expected non-zero disk size")
+
+	// same filesystem, same size
+	resized := fs.DetectResize()
+	tassert.Errorf(t, len(resized) == 0, "unexpected resize %v", resized)
+	tassert.Errorf(t, fs.GetDiskSize() == size, "disk size changed: %d vs %d", fs.GetDiskSize(), size)
+}
+
+func TestMountpathReplace(t *testing.T) {
+	config := cmn.GCO.BeginUpdate()
+	config.TestFSP.Count = 3
+	cmn.GCO.CommitUpdate(config)
+	initFS()
+
+	mp1, mp2, mp3 := t.TempDir(), t.TempDir(), t.TempDir()
+	tools.AddMpath(t, mp1)
+	tools.AddMpath(t, mp2)
+
+	const num = 1000
+	placement := make(map[string]string, num)
+	for range num {
+		uname := trand.String(16)
+		mi, _, err := fs.Hrw(cos.UnsafeB(uname))
+		tassert.CheckFatal(t, err)
+		placement[uname] = mi.Path
+	}
+
+	_, _, err := fs.NewReplacement(mp1, mp2, "")
+	tassert.Errorf(t, err != nil, "expected replacing with an already attached mountpath to fail")
+
+	srcMi, dstMi, err := fs.NewReplacement(mp1, mp3, "")
+	tassert.CheckFatal(t, err)
+	tassert.Fatalf(t, dstMi.PathDigest == srcMi.PathDigest && dstMi.InheritedDigest(), "expected inherited digest")
+	tassert.Errorf(t, !srcMi.InheritedDigest(), "%s: unexpected inherited digest", srcMi)
+
+	var called bool
+	err = fs.ReplaceMpath("daeID", srcMi, dstMi, func() { called = true })
+	tassert.CheckFatal(t, err)
+	tassert.Errorf(t, called, "expected callback")
+	tools.AssertMountpathCount(t, 2, 0)
+
+	avail := fs.GetAvail()
+	_, ok := avail[mp1]
+	tassert.Errorf(t, !ok, "%q must be replaced", mp1)
+
+	// placement remains the same, mp1 => mp3
+	for uname, mpath := range placement {
+		mi, _, err := fs.Hrw(cos.UnsafeB(uname))
+		tassert.CheckFatal(t, err)
+		if mpath == mp1 {
+			mpath = mp3
+		}
+		tassert.Fatalf(t, mi.Path == mpath, "%q: expected %q, got %q", uname, mpath, mi.Path)
+	}
+
+	err = fs.ReplaceMpath("daeID", srcMi, dstMi, func() {})
+	tassert.Errorf(t, err != nil, "expected replacing no longer available mountpath to fail")
+	tools.AssertMountpathCount(t, 2, 0)
+}
+
 func initFS() {
 	fs.TestNew(mock.NewIOS())
 }
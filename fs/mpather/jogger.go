@@ -60,6 +60,20 @@ type (
 		PerBucket             bool     // num joggers = (num mountpaths) x (num buckets)
 		SkipGloballyMisplaced bool     // skip globally misplaced
 		Throttle              bool     // true: pace itself depending on disk utilization
+
+		// (single bucket only) walk in lexicographical order and keep track of progress - see Jgroup.Progress;
+		// optionally, resume after the given positions: mountpath => bucket-relative path (as per Progress)
+		Sorted     bool
+		StartAfter map[string]string
+		// (Sorted only) when visiting an object completes asynchronously (e.g., upon transmission):
+		// used instead of VisitObj and must call `done` exactly once - with nil upon success
+		VisitObjAsync func(lom *core.LOM, buf []byte, done func(err error)) error
+
+		// (optional) run only on the specified subset of available mountpaths
+		Mpaths cos.StrSet
+		// (optional) callback upon successful completion of a given mountpath's traversal
+		// (not called when the mountpath's jogger fails or gets aborted)
+		DoneMpath func(mi *fs.Mountpath)
 	}
 
 	// Jgroup runs jogger per mountpath which walk the entire bucket and
@@ -85,6 +99,8 @@ type (
 		stopCh    cos.StopCh
 		bufs      [][]byte
 		num       int64
+		prog      *jprog // when opts.Sorted
+		after     string // resume point (skipping up to and including)
 	}
 
 	joggerSyncGroup struct {
@@ -103,6 +119,8 @@ func NewJoggerGroup(opts *JgroupOpts, config *cmn.Config, smi *fs.Mountpath) *Jg
 		jg      = &Jgroup{wg: wg}
 	)
 	debug.Assert(!opts.IncludeCopy || (opts.IncludeCopy && opts.DoLoad > noLoad))
+	debug.Assert(!opts.Sorted || (len(opts.Buckets) == 0 && !opts.Bck.IsQuery()))
+	debug.Assert(opts.VisitObjAsync == nil || opts.Sorted)
 
 	opts.onFinish = jg.markFinished
 
@@ -130,6 +148,9 @@ func NewJoggerGroup(opts *JgroupOpts, config *cmn.Config, smi *fs.Mountpath) *Jg
 	default:
 		joggers = make(map[string]*jogger, la)
 		for _, mi := range avail {
+			if opts.Mpaths != nil && !opts.Mpaths.Contains(mi.Path) {
+				continue
+			}
 			joggers[mi.Path] = newJogger(ctx, opts, mi, config)
 		}
 	}
@@ -198,15 +219,24 @@ func newJogger(ctx context.Context, opts *JgroupOpts, mi *fs.Mountpath, config *
 		config:    config,
 		syncGroup: syncGroup,
 	}
-	if opts.Prefix != "" {
+	if opts.Prefix != "" || opts.Sorted {
 		j.bdir = mi.MakePathCT(&j.opts.Bck, fs.ObjectType) // this mountpath's bucket dir that contains objects
-		j.objPrefix = filepath.Join(j.bdir, opts.Prefix)
+		if opts.Prefix != "" {
+			j.objPrefix = filepath.Join(j.bdir, opts.Prefix)
+		}
+	}
+	if opts.Sorted {
+		j.prog = &jprog{}
+		j.after = opts.StartAfter[mi.Path]
 	}
 	j.stopCh.Init()
 	return
 }
 
 func (j *jogger) run() (err error) {
+	if unpin := pinNuma(j.mi, j.config); unpin != nil {
+		defer unpin()
+	}
 	if err = j.mi.CheckFS(); err != nil {
 		nlog.Errorln(err)
 		core.T.FSHC(err, j.mi, "")
@@ -234,6 +264,9 @@ func (j *jogger) run() (err error) {
 	default:
 		_, err = j.runBck(&j.opts.Bck)
 	}
+	if err == nil && j.opts.DoneMpath != nil && j.checkStopped() == nil {
+		j.opts.DoneMpath(j.mi)
+	}
 
 ex:
 	// cleanup
@@ -292,7 +325,7 @@ func (j *jogger) runBck(bck *cmn.Bck) (aborted bool, err error) {
 		Mi:       j.mi,
 		CTs:      j.opts.CTs,
 		Callback: j.jog,
-		Sorted:   false,
+		Sorted:   j.opts.Sorted,
 	}
 	opts.Bck.Copy(bck)
 
@@ -328,16 +361,35 @@ func (j *jogger) jog(fqn string, de fs.DirEntry) error {
 		}
 	}
 	if de.IsDir() {
+		if j.after != "" && j.skipDir(fqn) {
+			return filepath.SkipDir
+		}
 		return nil
 	}
+	var rel string
+	if j.prog != nil {
+		rel = j.rel(fqn)
+		if j.after != "" {
+			if cmpWalk(rel, j.after) <= 0 {
+				return nil
+			}
+			j.after = "" // past the resume point
+		}
+	}
 
 	if err := j.checkStopped(); err != nil {
 		return err
 	}
 
-	var bufPosition int
+	var (
+		bufPosition int
+		e           *jentry
+	)
+	if j.prog != nil {
+		e = j.prog.push(rel) // (in walk order)
+	}
 	if j.syncGroup == nil {
-		if err := j.visitFQN(fqn, j.getBuf(0)); err != nil {
+		if err := j.visit(fqn, j.getBuf(0), e); err != nil {
 			return err
 		}
 	} else {
@@ -345,15 +397,17 @@ func (j *jogger) jog(fqn string, de fs.DirEntry) error {
 		case bufPosition = <-j.syncGroup.sema:
 			break
 		case <-j.ctx.Done():
+			if e != nil {
+				j.prog.done(e, j.ctx.Err())
+			}
 			return j.ctx.Err()
 		}
-
 		j.syncGroup.group.Go(func() error {
 			defer func() {
 				// NOTE: There is no need to select j.ctx.Done() as put to this chanel is immediate.
 				j.syncGroup.sema <- bufPosition
 			}()
-			return j.visitFQN(fqn, j.getBuf(bufPosition))
+			return j.visit(fqn, j.getBuf(bufPosition), e)
 		})
 	}
 
@@ -368,7 +422,16 @@ func (j *jogger) jog(fqn string, de fs.DirEntry) error {
 	return nil
 }
 
-func (j *jogger) visitFQN(fqn string, buf []byte) error {
+// visit and, unless done asynchronously (see VisitObjAsync), complete the progress entry
+func (j *jogger) visit(fqn string, buf []byte, e *jentry) error {
+	err := j.visitFQN(fqn, buf, e)
+	if e != nil && !e.async {
+		j.prog.done(e, err)
+	}
+	return err
+}
+
+func (j *jogger) visitFQN(fqn string, buf []byte, e *jentry) error {
 	ct, err := core.NewCTFromFQN(fqn, core.T.Bowner())
 	if err != nil {
 		return err
@@ -389,7 +452,10 @@ func (j *jogger) visitFQN(fqn string, buf []byte) error {
 	case fs.ObjectType:
 		lom := core.AllocLOM("")
 		lom.InitCT(ct)
-		err := j.visitObj(lom, buf)
+		err := j.visitObj(lom, buf, e)
+		if err != nil && !cmn.IsErrObjLevel(err) && !cmn.IsErrBucketLevel(err) && !cmn.IsErrAborted(err) {
+			err = cmn.NewErrWithObject(err, lom.Cname()) // (the offending object - see cmn.AbortReason)
+		}
 		// NOTE: j.opts.visitObj() callback implementations must either finish
 		// synchronously or pass lom.LIF to another goroutine
 		core.FreeLOM(lom)
@@ -402,7 +468,7 @@ func (j *jogger) visitFQN(fqn string, buf []byte) error {
 	return nil
 }
 
-func (j *jogger) visitObj(lom *core.LOM, buf []byte) (err error) {
+func (j *jogger) visitObj(lom *core.LOM, buf []byte, e *jentry) (err error) {
 	switch j.opts.DoLoad {
 	case noLoad:
 		goto visit
@@ -420,6 +486,10 @@ func (j *jogger) visitObj(lom *core.LOM, buf []byte) (err error) {
 		return nil
 	}
 visit:
+	if e != nil && j.opts.VisitObjAsync != nil {
+		e.async = true
+		return j.opts.VisitObjAsync(lom, buf, func(err error) { j.prog.done(e, err) })
+	}
 	return j.opts.VisitObj(lom, buf)
 }
 
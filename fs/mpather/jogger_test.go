@@ -10,7 +10,9 @@ import (
 	"fmt"
 	"math/rand/v2"
 	"os"
+	"sort"
 	"strings"
+	"sync"
 	"testing"
 	"time"
 
@@ -310,3 +312,151 @@ func TestJoggerGroupMultiContentTypes(t *testing.T) {
 	err := jg.Stop()
 	tassert.CheckFatal(t, err)
 }
+
+func TestJoggerGroupResume(t *testing.T) {
+	var (
+		desc = tools.ObjectsDesc{
+			CTs: []tools.ContentTypeDesc{
+				{Type: fs.ObjectType, ContentCnt: 300},
+			},
+			MountpathsCnt: 3,
+			ObjectSize:    cos.KiB,
+		}
+		out   = tools.PrepareObjects(t, desc)
+		mu    sync.Mutex
+		order = make(map[string][]string, desc.MountpathsCnt) // mountpath => objects, in walk order
+	)
+	defer os.RemoveAll(out.Dir)
+
+	rel := func(lom *core.LOM) (string, string) {
+		mi := lom.Mountpath()
+		return mi.Path, strings.TrimPrefix(lom.FQN, mi.MakePathCT(lom.Bucket(), fs.ObjectType)+"/")
+	}
+
+	// 1. full (serial) walk
+	opts := &mpather.JgroupOpts{
+		Bck:    out.Bck,
+		CTs:    []string{fs.ObjectType},
+		Sorted: true,
+		VisitObj: func(lom *core.LOM, _ []byte) error {
+			mpath, name := rel(lom)
+			mu.Lock()
+			order[mpath] = append(order[mpath], name)
+			mu.Unlock()
+			return nil
+		},
+	}
+	jg := mpather.NewJoggerGroup(opts, cmn.GCO.Get(), nil)
+	jg.Run()
+	<-jg.ListenFinished()
+	tassert.CheckFatal(t, jg.Stop())
+
+	after := make(map[string]string, len(order))
+	for mpath, names := range order {
+		tassert.Errorf(t, sort.StringsAreSorted(names), "%s: expecting sorted walk", mpath)
+		tassert.Errorf(t, jg.Progress()[mpath] == names[len(names)-1], "%s: invalid progress %q", mpath, jg.Progress()[mpath])
+		after[mpath] = names[len(names)/2]
+	}
+
+	// 2. resume (in parallel) from the middle
+	visited := make(cos.StrSet, len(out.FQNs[fs.ObjectType]))
+	opts = &mpather.JgroupOpts{
+		Bck:        out.Bck,
+		CTs:        []string{fs.ObjectType},
+		Parallel:   4,
+		Sorted:     true,
+		StartAfter: after,
+		VisitObj: func(lom *core.LOM, _ []byte) error {
+			mpath, name := rel(lom)
+			mu.Lock()
+			visited.Add(mpath + "|" + name)
+			mu.Unlock()
+			return nil
+		},
+	}
+	jg = mpather.NewJoggerGroup(opts, cmn.GCO.Get(), nil)
+	jg.Run()
+	<-jg.ListenFinished()
+	tassert.CheckFatal(t, jg.Stop())
+
+	var expected int
+	for mpath, names := range order {
+		for i, name := range names {
+			ok := visited.Contains(mpath + "|" + name)
+			tassert.Errorf(t, ok == (i > len(names)/2), "%s: %q (%d) visited=%t", mpath, name, i, ok)
+		}
+		expected += len(names) - len(names)/2 - 1
+		tassert.Errorf(t, jg.Progress()[mpath] == names[len(names)-1], "%s: invalid progress %q", mpath, jg.Progress()[mpath])
+	}
+	tassert.Errorf(t, len(visited) == expected, "expected %d objects visited, got %d", expected, len(visited))
+}
+
+func TestJoggerGroupAsyncProgress(t *testing.T) {
+	var (
+		desc = tools.ObjectsDesc{
+			CTs: []tools.ContentTypeDesc{
+				{Type: fs.ObjectType, ContentCnt: 100},
+			},
+			MountpathsCnt: 2,
+			ObjectSize:    cos.KiB,
+		}
+		out = tools.PrepareObjects(t, desc)
+	)
+	defer os.RemoveAll(out.Dir)
+
+	type pending struct {
+		name string
+		done func(error)
+	}
+	walk := func() (*mpather.Jgroup, map[string][]pending) {
+		var (
+			mu  sync.Mutex
+			inq = make(map[string][]pending, desc.MountpathsCnt) // mountpath => in-flight, in walk order
+		)
+		opts := &mpather.JgroupOpts{
+			Bck:    out.Bck,
+			CTs:    []string{fs.ObjectType},
+			Sorted: true,
+			VisitObjAsync: func(lom *core.LOM, _ []byte, done func(error)) error {
+				mi := lom.Mountpath()
+				name := strings.TrimPrefix(lom.FQN, mi.MakePathCT(lom.Bucket(), fs.ObjectType)+"/")
+				mu.Lock()
+				inq[mi.Path] = append(inq[mi.Path], pending{name, done})
+				mu.Unlock()
+				return nil
+			},
+		}
+		jg := mpather.NewJoggerGroup(opts, cmn.GCO.Get(), nil)
+		jg.Run()
+		<-jg.ListenFinished()
+		tassert.CheckFatal(t, jg.Stop())
+		return jg, inq
+	}
+
+	// 1. walk completes before the objects do: no progress until the first one completes
+	jg, inq := walk()
+	for mpath, q := range inq {
+		for i := len(q) - 1; i > 0; i-- {
+			q[i].done(nil)
+		}
+		_, ok := jg.Progress()[mpath]
+		tassert.Errorf(t, !ok, "%s: unexpected progress %q", mpath, jg.Progress()[mpath])
+		q[0].done(nil)
+		tassert.Errorf(t, jg.Progress()[mpath] == q[len(q)-1].name, "%s: invalid progress %q", mpath, jg.Progress()[mpath])
+	}
+
+	// 2. failure: the position stays put; objects that are gone do not count
+	jg, inq = walk()
+	for mpath, q := range inq {
+		q[0].done(nil)
+		q[1].done(os.ErrNotExist)
+		q[2].done(nil)
+		q[4].done(errors.New("failed to send"))
+		for i := 5; i < len(q); i++ {
+			q[i].done(nil)
+		}
+		q[3].done(nil)
+		tassert.Errorf(t, jg.Progress()[mpath] == q[2].name, "%s: invalid progress %q (expecting %q)",
+			mpath, jg.Progress()[mpath], q[2].name)
+	}
+}
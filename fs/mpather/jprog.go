@@ -0,0 +1,143 @@
+// Package mpather provides per-mountpath concepts.
+/*
+ * Copyright (c) 2024, NVIDIA CORPORATION. All rights reserved.
+ */
+package mpather
+
+import (
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/cmn/cos"
+)
+
+// Progress of the sorted (JgroupOpts.Sorted) walk: for each mountpath, the bucket-relative
+// path of the last visited object such that all objects preceding it (in the walk order)
+// have been visited as well. In-flight objects - visited in parallel (JgroupOpts.Parallel > 1)
+// and/or asynchronously (JgroupOpts.VisitObjAsync) - are queued in the walk order, and the
+// position advances only when the head of the queue completes.
+// Once visiting fails, the position stays put (and in-flight objects are no longer tracked);
+// objects that are no longer present (cmn.IsErrObjLevel) do not count as failures.
+//
+// Note that the walk order is path component-wise (directory by directory), which is not
+// the same as plain lexicographical order of the full paths, e.g.: "a/b" precedes "a-b".
+
+type (
+	jprog struct {
+		last  string
+		q     []*jentry // in flight
+		mu    sync.Mutex
+		stuck bool // failed to visit
+	}
+	jentry struct {
+		rel   string
+		done  bool
+		async bool // completed via VisitObjAsync callback
+	}
+)
+
+// Progress returns current positions (mountpath => bucket-relative path), to be
+// used with JgroupOpts.StartAfter
+func (jg *Jgroup) Progress() map[string]string {
+	m := make(map[string]string, len(jg.joggers))
+	for _, j := range jg.joggers {
+		if j.prog == nil {
+			continue
+		}
+		last := j.prog.get()
+		if last == "" {
+			last = j.opts.StartAfter[j.mi.Path] // (not past the resume point yet)
+		}
+		if last != "" {
+			m[j.mi.Path] = last
+		}
+	}
+	return m
+}
+
+func (j *jogger) rel(fqn string) string {
+	if l := len(j.bdir); len(fqn) > l && fqn[l] == filepath.Separator && strings.HasPrefix(fqn, j.bdir) {
+		return fqn[l+1:]
+	}
+	return ""
+}
+
+// skip directories that precede the resume point (and do not contain it)
+func (j *jogger) skipDir(fqn string) bool {
+	rel := j.rel(fqn)
+	if rel == "" {
+		return false
+	}
+	return cmpWalk(rel, j.after) < 0 && !strings.HasPrefix(j.after, rel+cos.PathSeparator)
+}
+
+// compare two bucket-relative paths in the walk order (see above)
+func cmpWalk(a, b string) int {
+	for {
+		ia, ib := strings.IndexByte(a, filepath.Separator), strings.IndexByte(b, filepath.Separator)
+		ca, cb := a, b
+		if ia >= 0 {
+			ca = a[:ia]
+		}
+		if ib >= 0 {
+			cb = b[:ib]
+		}
+		if c := strings.Compare(ca, cb); c != 0 {
+			return c
+		}
+		switch {
+		case ia < 0 && ib < 0:
+			return 0
+		case ia < 0:
+			return -1
+		case ib < 0:
+			return 1
+		}
+		a, b = a[ia+1:], b[ib+1:]
+	}
+}
+
+///////////
+// jprog //
+///////////
+
+func (p *jprog) get() (last string) {
+	p.mu.Lock()
+	last = p.last
+	p.mu.Unlock()
+	return
+}
+
+func (p *jprog) push(rel string) (e *jentry) {
+	e = &jentry{rel: rel}
+	p.mu.Lock()
+	if !p.stuck {
+		p.q = append(p.q, e)
+	}
+	p.mu.Unlock()
+	return
+}
+
+func (p *jprog) done(e *jentry, err error) {
+	p.mu.Lock()
+	if err != nil && !cmn.IsErrObjLevel(err) {
+		p.stuck = true
+		clear(p.q)
+		p.q = nil
+		p.mu.Unlock()
+		return
+	}
+	e.done = true
+	var i int
+	for ; i < len(p.q) && p.q[i].done; i++ {
+		p.last = p.q[i].rel
+	}
+	if i > 0 {
+		n := copy(p.q, p.q[i:])
+		clear(p.q[n:])
+		p.q = p.q[:n]
+	}
+	p.mu.Unlock()
+}
@@ -0,0 +1,39 @@
+// Package mpather provides per-mountpath concepts.
+/*
+ * Copyright (c) 2024, NVIDIA CORPORATION. All rights reserved.
+ */
+package mpather
+
+import (
+	"runtime"
+
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/cmn/nlog"
+	"github.com/NVIDIA/aistore/fs"
+	"github.com/NVIDIA/aistore/sys"
+)
+
+// pinNuma locks the calling goroutine to its OS thread and restricts the latter
+// to the CPUs of the mountpath's (disk-local) NUMA node - see cmn.NumaConf.DiskLocal.
+// Returns nil when not configured or not possible; otherwise, the caller must unpin.
+func pinNuma(mi *fs.Mountpath, config *cmn.Config) (unpin func()) {
+	if !config.NUMA.DiskLocal || mi.NumaNode < 0 {
+		return nil
+	}
+	cpus, err := sys.NodeCPUs(mi.NumaNode)
+	if err != nil {
+		nlog.Warningln(mi.String(), err)
+		return nil
+	}
+	runtime.LockOSThread()
+	restore, err := sys.PinThread(cpus)
+	if err != nil {
+		runtime.UnlockOSThread()
+		nlog.Warningln(mi.String(), "failed to pin:", err)
+		return nil
+	}
+	return func() {
+		restore()
+		runtime.UnlockOSThread()
+	}
+}
@@ -95,6 +95,9 @@ func newWorker(opts *WorkerGroupOpts, mi *fs.Mountpath) (w *worker) {
 
 func (w *worker) work() error {
 	var buf []byte
+	if unpin := pinNuma(w.mi, cmn.GCO.Get()); unpin != nil {
+		defer unpin()
+	}
 	if w.opts.Slab != nil {
 		buf = w.opts.Slab.Alloc()
 		defer w.opts.Slab.Free(buf)
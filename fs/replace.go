@@ -0,0 +1,172 @@
+// Package fs provides mountpath and FQN abstractions and methods to resolve/map stored content
+/*
+ * Copyright (c) 2024, NVIDIA CORPORATION. All rights reserved.
+ */
+package fs
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/cmn/cos"
+	"github.com/NVIDIA/aistore/cmn/nlog"
+	"github.com/NVIDIA/aistore/ios"
+	"github.com/OneOfOne/xxhash"
+)
+
+// Mountpath replacement (apc.ActMountpathReplace):
+// - the new mountpath inherits the HRW digest of the one it replaces, so that object
+//   placement (across all mountpaths) remains unchanged, and the content can be copied
+//   directly, path to path - no resilvering and, of course, no (cluster) rebalancing;
+// - the inherited digest is persisted (see volume.VMD) and survives restarts;
+// - once swapped, and until the new mountpath catches up with the old one (see EndReplace),
+//   the latter's content remains accessible (see core.LOM.RestoreToLocation), while
+//   deletions from the new one get recorded - to not bring them back.
+
+// post-swap (catch-up) state of the mountpath replacement
+type Replaced struct {
+	Src, Dst *Mountpath
+	unlinked map[string]struct{} // Dst FQNs removed since the swap
+	mu       sync.Mutex
+}
+
+func pathDigest(cleanMpath string) uint64 {
+	return xxhash.Checksum64S(cos.UnsafeB(cleanMpath), cos.MLCG32)
+}
+
+// true when the HRW digest was inherited from a replaced mountpath
+func (mi *Mountpath) InheritedDigest() bool { return mi.PathDigest != pathDigest(mi.Path) }
+
+// NewReplacement validates the (src, dst) pair and returns the src (available) mountpath
+// and the dst one - not attached, with the inherited digest
+func NewReplacement(src, dst string, label ios.Label) (srcMi, dstMi *Mountpath, err error) {
+	cleanSrc, err := cmn.ValidateMpath(src)
+	if err != nil {
+		return nil, nil, err
+	}
+	avail, disabled := Get()
+	srcMi, ok := avail[cleanSrc]
+	if !ok {
+		if _, ok = disabled[cleanSrc]; ok {
+			return nil, nil, fmt.Errorf("cannot replace disabled mountpath %q (hint: enable it first)", cleanSrc)
+		}
+		return nil, nil, cmn.NewErrMpathNotFound(src, "" /*fqn*/, false /*disabled*/)
+	}
+	if srcMi.IsAnySet(FlagWaitingDD) {
+		return nil, nil, fmt.Errorf("cannot replace %s: being disabled or detached", srcMi)
+	}
+	if label.IsNil() {
+		label = srcMi.Label
+	}
+	if dstMi, err = NewMountpath(dst, label); err != nil {
+		return nil, nil, err
+	}
+	if _, ok := disabled[dstMi.Path]; ok {
+		return nil, nil, fmt.Errorf("%s exists and is currently disabled", dstMi)
+	}
+
+	config := cmn.GCO.Get()
+	mfs.mu.Lock()
+	err = dstMi._validate(avail, config)
+	mfs.mu.Unlock()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// enough space to hold the entire (used) capacity of the src
+	if !config.TestingEnv() {
+		var cs, cd Capacity
+		if cs, err = srcMi.getCapacity(config, true /*refresh*/); err != nil {
+			return nil, nil, err
+		}
+		if cd, err = dstMi.getCapacity(config, true); err != nil {
+			return nil, nil, err
+		}
+		if cd.Avail < cs.Used {
+			return nil, nil, fmt.Errorf("insufficient space: %s has %s available while %s has %s used",
+				dstMi, cos.ToSizeIEC(int64(cd.Avail), 2), srcMi, cos.ToSizeIEC(int64(cs.Used), 2))
+		}
+	}
+
+	dstMi.PathDigest = srcMi.PathDigest
+	return srcMi, dstMi, nil
+}
+
+// ReplaceMpath atomically replaces (available) src mountpath with dst (see NewReplacement)
+func ReplaceMpath(tid string, srcMi, dstMi *Mountpath, cb func()) error {
+	config := cmn.GCO.Get()
+
+	mfs.mu.Lock()
+	defer mfs.mu.Unlock()
+
+	avail := GetAvail()
+	mi, ok := avail[srcMi.Path]
+	if !ok || mi.IsAnySet(FlagWaitingDD) {
+		return fmt.Errorf("%s is no longer available", srcMi)
+	}
+	if dstMi.PathDigest != mi.PathDigest {
+		return fmt.Errorf("%s: HRW digest mismatch (%s)", dstMi, mi) // (unlikely)
+	}
+	availableCopy := _cloneOne(avail)
+	delete(availableCopy, mi.Path)
+	if err := dstMi._validate(availableCopy, config); err != nil {
+		return err
+	}
+	if mfs.fsIDs[mi.FsID] == mi.Path {
+		delete(mfs.fsIDs, mi.FsID)
+	}
+	if err := dstMi.AddEnabled(tid, availableCopy, config, nil /*blockDevs*/); err != nil {
+		mfs.fsIDs[mi.FsID] = mi.Path
+		return err
+	}
+	mfs.ios.RemoveMpath(mi.Path, config.TestingEnv())
+	if err := removeXattr(mi.Path, nodeXattrID); err != nil {
+		nlog.Warningln(err)
+	}
+	moveMarkers(availableCopy, mi)
+	mfs.replaced.Store(&Replaced{Src: mi, Dst: dstMi, unlinked: make(map[string]struct{}, 16)})
+	putAvailMPI(availableCopy)
+
+	nlog.Infof("replaced mountpath %s with %s", mi, dstMi)
+	cb()
+	return nil
+}
+
+// GetReplaced returns the mountpath replacement that's being caught up with, if any
+func GetReplaced() *Replaced { return mfs.replaced.Load() }
+
+// EndReplace terminates the catch-up (see ReplaceMpath)
+func EndReplace(r *Replaced) { mfs.replaced.CompareAndSwap(r, nil) }
+
+// Unlinked records removal of the (new mountpath's) fqn, if replacement is in progress;
+// the caller is expected to hold the corresponding (object) write lock
+func Unlinked(fqn string) {
+	if r := mfs.replaced.Load(); r != nil && r.owns(fqn) {
+		r.mu.Lock()
+		r.unlinked[fqn] = struct{}{}
+		r.mu.Unlock()
+	}
+}
+
+func (r *Replaced) owns(fqn string) bool {
+	return strings.HasPrefix(fqn, r.Dst.Path) && len(fqn) > len(r.Dst.Path) && fqn[len(r.Dst.Path)] == '/'
+}
+
+// IsUnlinked returns true if the (new mountpath's) fqn was removed since the swap
+func (r *Replaced) IsUnlinked(fqn string) bool {
+	r.mu.Lock()
+	_, ok := r.unlinked[fqn]
+	r.mu.Unlock()
+	return ok
+}
+
+// SrcFQN returns the old mountpath's counterpart of the (new mountpath's) fqn,
+// or empty string if the latter was removed since the swap
+func (r *Replaced) SrcFQN(fqn string) string {
+	if !r.owns(fqn) || r.IsUnlinked(fqn) {
+		return ""
+	}
+	return r.Src.Path + fqn[len(r.Dst.Path):]
+}
@@ -5,6 +5,8 @@
 package fs
 
 import (
+	"strings"
+
 	"github.com/NVIDIA/aistore/cmn/cos"
 	"github.com/NVIDIA/aistore/cmn/nlog"
 	"golang.org/x/sys/unix"
@@ -45,3 +47,32 @@ func removeXattr(fqn, attrName string) error {
 	}
 	return nil
 }
+
+// CopyXattrs copies all (user-namespace) xattrs, including object metadata
+func CopyXattrs(src, dst string) error {
+	size, err := unix.Listxattr(src, nil)
+	if err != nil || size == 0 {
+		return err
+	}
+	names := make([]byte, size)
+	if size, err = unix.Listxattr(src, names); err != nil {
+		return err
+	}
+	for _, name := range strings.Split(string(names[:size]), "\x00") {
+		if !strings.HasPrefix(name, "user.") {
+			continue
+		}
+		n, err := unix.Getxattr(src, name, nil)
+		if err != nil {
+			return err
+		}
+		b := make([]byte, n)
+		if n, err = unix.Getxattr(src, name, b); err != nil {
+			return err
+		}
+		if err := SetXattr(dst, name, b[:n]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -116,6 +116,9 @@ func (r *mncXact) visitObj(lom *core.LOM, buf []byte) (err error) {
 		n      = lom.NumCopies()
 		copies = r.p.args.Copies
 	)
+	if ovr := lom.CopiesOverride(); ovr > 0 {
+		copies = min(ovr, fs.NumAvail()) // per-object override takes precedence
+	}
 	switch {
 	case n == copies:
 		return nil
@@ -150,6 +153,8 @@ func (r *mncXact) visitObj(lom *core.LOM, buf []byte) (err error) {
 		nlog.Infof("%s: %s, copies %d=>%d, size=%d", r.Base.Name(), lom.Cname(), n, copies, size)
 	}
 	r.ObjsAdd(1, size)
+	r.DiskReadAdd(size)
+	r.DiskWriteAdd(size)
 	if cnt := r.Objs(); cnt%128 == 0 { // TODO: configurable
 		cs := fs.Cap()
 		if errCap := cs.Err(); errCap != nil {
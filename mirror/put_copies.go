@@ -76,11 +76,11 @@ func (p *putFactory) Start() error {
 	slab, err := core.T.PageMM().GetSlab(memsys.MaxPageSlabSize) // TODO: estimate
 	debug.AssertNoErr(err)
 
-	bck, mirror := lom.Bck(), lom.MirrorConf()
-	if !mirror.Enabled {
+	bck, mirror, copies := lom.Bck(), lom.MirrorConf(), lom.ExpCopies()
+	if copies < 2 {
 		return fmt.Errorf("%s: mirroring disabled, nothing to do", bck)
 	}
-	if err = fs.ValidateNCopies(core.T.String(), int(mirror.Copies)); err != nil {
+	if err = fs.ValidateNCopies(core.T.String(), copies); err != nil {
 		nlog.Errorln(err)
 		return err
 	}
@@ -124,7 +124,7 @@ func (p *putFactory) WhenPrevIsRunning(xprev xreg.Renewable) (xreg.WPR, error) {
 
 // (one worker per mountpath)
 func (r *XactPut) do(lom *core.LOM, buf []byte) {
-	copies := int(lom.Bprops().Mirror.Copies)
+	copies := lom.ExpCopies() // (per-object override or bucket's mirror config)
 
 	lom.Lock(true)
 	size, err := addCopies(lom, copies, buf)
@@ -134,6 +134,8 @@ func (r *XactPut) do(lom *core.LOM, buf []byte) {
 		r.AddErr(err, 5, cos.SmoduleMirror)
 	} else {
 		r.ObjsAdd(1, size)
+		r.DiskReadAdd(size)
+		r.DiskWriteAdd(size)
 	}
 	r.DecPending() // (see IncPending below)
 	core.FreeLOM(lom)
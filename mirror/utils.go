@@ -76,6 +76,16 @@ func addCopies(lom *core.LOM, copies int, buf []byte) (size int64, err error) {
 	return
 }
 
+// SyncCopies adds or removes copies to match the expected number (lom.ExpCopies) - e.g.,
+// upon setting or removing per-object replication override; must be called under w-lock
+func SyncCopies(lom *core.LOM, buf []byte) (size int64, err error) {
+	copies := min(lom.ExpCopies(), fs.NumAvail())
+	if lom.NumCopies() > copies {
+		return delCopies(lom, copies)
+	}
+	return addCopies(lom, copies, buf)
+}
+
 func drainWorkCh(workCh chan core.LIF) (n int) {
 	for {
 		select {
@@ -15,6 +15,7 @@ import (
 	"github.com/NVIDIA/aistore/core/meta"
 	"github.com/NVIDIA/aistore/core/mock"
 	"github.com/NVIDIA/aistore/fs"
+	"github.com/NVIDIA/aistore/mirror"
 	"github.com/NVIDIA/aistore/tools/readers"
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
@@ -126,6 +127,52 @@ var _ = Describe("Mirror", func() {
 			Expect(copyLOM.HasCopies()).To(BeTrue())
 		})
 	})
+
+	Describe("SyncCopies", func() {
+		It("should honor per-object replication override", func() {
+			createTestFile(bucketPath, testObjectName, testObjectSize)
+			lom := newBasicLom(defaultObjFQN)
+			Expect(lom.IsHRW()).To(BeTrue())
+			lom.SetSize(testObjectSize)
+			lom.SetAtimeUnix(time.Now().UnixNano())
+			Expect(lom.Persist()).NotTo(HaveOccurred())
+			Expect(lom.ExpCopies()).To(Equal(2)) // bucket's mirror config
+
+			lom.Lock(true)
+			defer lom.Unlock(true)
+
+			// override: single copy
+			lom.SetCustomKey(cmn.CopiesObjMD, "1")
+			Expect(lom.Persist()).NotTo(HaveOccurred())
+			Expect(lom.ExpCopies()).To(Equal(1))
+			_, err := mirror.SyncCopies(lom, nil)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(lom.NumCopies()).To(Equal(1))
+
+			// override: more copies than mountpaths
+			lom.SetCustomKey(cmn.CopiesObjMD, "3")
+			Expect(lom.Persist()).NotTo(HaveOccurred())
+			buf := make([]byte, cos.KiB*32)
+			size, err := mirror.SyncCopies(lom, buf)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(size).To(BeEquivalentTo(testObjectSize))
+			Expect(lom.NumCopies()).To(Equal(2))
+			Expect(expectedCopyFQN).To(BeARegularFile())
+
+			// reload: the override is in the object's metadata
+			newLOM := newBasicLom(defaultObjFQN)
+			Expect(newLOM.Load(false, true)).NotTo(HaveOccurred())
+			Expect(newLOM.CopiesOverride()).To(Equal(3))
+
+			// lowered back
+			lom.SetCustomKey(cmn.CopiesObjMD, "1")
+			Expect(lom.Persist()).NotTo(HaveOccurred())
+			_, err = mirror.SyncCopies(lom, nil)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(lom.NumCopies()).To(Equal(1))
+			Expect(expectedCopyFQN).NotTo(BeAnExistingFile())
+		})
+	})
 })
 
 func createTestFile(filePath, objName string, size int64) {
@@ -8,6 +8,7 @@ import (
 	"strconv"
 	"strings"
 	"sync"
+	ratomic "sync/atomic"
 	"time"
 
 	"github.com/NVIDIA/aistore/cmn"
@@ -36,6 +37,8 @@ type Listener interface {
 	UUID() string
 	SetAborted()
 	Aborted() bool
+	SetAbortReason(*cmn.AbortReason)
+	AbortReason() *cmn.AbortReason
 	Status() *Status
 	SetStats(daeID string, stats any)
 	NodeStats() *NodeStats
@@ -87,17 +90,19 @@ type (
 		addedTime   atomic.Int64     // Time when `nl` is added
 
 		// runtime
-		EndTimeX atomic.Int64 // timestamp when finished
-		AbortedX atomic.Bool  // sets if the xaction is Aborted
-		Errs     cos.Errs     // reported error and count
+		EndTimeX atomic.Int64                     // timestamp when finished
+		AbortedX atomic.Bool                      // sets if the xaction is Aborted
+		reason   ratomic.Pointer[cmn.AbortReason] // (the first reported) reason of abort
+		Errs     cos.Errs                         // reported error and count
 	}
 
 	Status struct {
-		Kind     string `json:"kind"`     // xaction kind
-		UUID     string `json:"uuid"`     // xaction UUID
-		ErrMsg   string `json:"err"`      // error
-		EndTimeX int64  `json:"end_time"` // time xaction ended
-		AbortedX bool   `json:"aborted"`  // true if aborted
+		AbortReason *cmn.AbortReason `json:"abort_reason,omitempty"` // who aborted and why
+		Kind        string           `json:"kind"`                   // xaction kind
+		UUID        string           `json:"uuid"`                   // xaction UUID
+		ErrMsg      string           `json:"err"`                    // error
+		EndTimeX    int64            `json:"end_time"`               // time xaction ended
+		AbortedX    bool             `json:"aborted"`                // true if aborted
 	}
 	StatusVec []Status
 )
@@ -130,6 +135,7 @@ func (nlb *ListenerBase) Notifiers() meta.NodeMap         { return nlb.Srcs }
 func (nlb *ListenerBase) UUID() string                    { return nlb.Common.UUID }
 func (nlb *ListenerBase) Aborted() bool                   { return nlb.AbortedX.Load() }
 func (nlb *ListenerBase) SetAborted()                     { nlb.AbortedX.CAS(false, true) }
+func (nlb *ListenerBase) AbortReason() *cmn.AbortReason   { return nlb.reason.Load() }
 func (nlb *ListenerBase) EndTime() int64                  { return nlb.EndTimeX.Load() }
 func (nlb *ListenerBase) Finished() bool                  { return nlb.EndTime() > 0 }
 func (nlb *ListenerBase) ProgressInterval() time.Duration { return nlb.progress }
@@ -212,8 +218,14 @@ func (nlb *ListenerBase) NodesTardy(periodicNotifTime time.Duration) (nodes meta
 	return
 }
 
+// (first one wins)
+func (nlb *ListenerBase) SetAbortReason(reason *cmn.AbortReason) {
+	nlb.reason.CompareAndSwap(nil, reason)
+}
+
 func (nlb *ListenerBase) Status() *Status {
-	return &Status{Kind: nlb.Kind(), UUID: nlb.UUID(), EndTimeX: nlb.EndTimeX.Load(), AbortedX: nlb.Aborted()}
+	return &Status{Kind: nlb.Kind(), UUID: nlb.UUID(), EndTimeX: nlb.EndTimeX.Load(), AbortedX: nlb.Aborted(),
+		AbortReason: nlb.AbortReason()}
 }
 
 func (nlb *ListenerBase) _name() *strings.Builder {
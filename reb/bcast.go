@@ -83,9 +83,11 @@ func (reb *Reb) pingTarget(tsi *meta.Snode, rargs *rebArgs) (ok bool) {
 		}
 		if !cos.IsUnreachable(err, code) {
 			nlog.Errorf("%s: health(%s) returned %v(%d) - aborting", logHdr, tname, err, code)
+			reb.xlog(core.XlogError, tsi, "health returned %v(%d)", err, code)
 			return
 		}
 		nlog.Warningf("%s: waiting for %s, err %v(%d)", logHdr, tname, err, code)
+		reb.xlog(core.XlogRetry, tsi, "waiting for target to respond: %v(%d)", err, code)
 		time.Sleep(sleep)
 		nver := core.T.Sowner().Get().Version
 		if nver > ver {
@@ -93,6 +95,7 @@ func (reb *Reb) pingTarget(tsi *meta.Snode, rargs *rebArgs) (ok bool) {
 		}
 	}
 	nlog.Errorf("%s: timed out waiting for %s", logHdr, tname)
+	reb.xlog(core.XlogError, tsi, "timed out waiting for target to respond")
 	return
 }
 
@@ -119,6 +122,7 @@ func (reb *Reb) rxReady(tsi *meta.Snode, rargs *rebArgs) (ok bool) {
 	}
 	logHdr, tname := reb.logHdr(rargs.id, rargs.smap), tsi.StringEx()
 	nlog.Errorln(logHdr, "timed out waiting for", tname, "to reach", stages[rebStageTraverse], "stage")
+	reb.xlog(core.XlogError, tsi, "timed out waiting for target to reach %s stage", stages[rebStageTraverse])
 	return
 }
 
@@ -173,6 +177,7 @@ func (reb *Reb) waitFinExtended(tsi *meta.Snode, rargs *rebArgs) (ok bool) {
 		curwt += sleepRetry
 	}
 	nlog.Errorf("%s: timed out waiting for %s to reach %s", logHdr, tsi.StringEx(), stages[rebStageFin])
+	reb.xlog(core.XlogError, tsi, "timed out waiting for target to reach %s stage", stages[rebStageFin])
 	return
 }
 
@@ -226,6 +231,7 @@ func (reb *Reb) checkStage(tsi *meta.Snode, rargs *rebArgs, desiredStage uint32)
 	// let the target to catch-up
 	if status.RebID < reb.RebID() {
 		nlog.Warningf("%s: %s runs older (g%d) global rebalance - keep waiting...", logHdr, tname, status.RebID)
+		reb.xlog(core.XlogWarn, tsi, "target runs older (g%d) global rebalance", status.RebID)
 		return
 	}
 	// Remote target has aborted its running rebalance with the same ID.
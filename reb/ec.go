@@ -44,6 +44,21 @@ import (
 //      - broadcast new metadata to all targets in `Daemons` field for them to
 //        update their metafiles. Targets do not overwrite their metafiles with a new
 //        one. They update only `Daemons` and `FullReplica` fields.
+//
+// Slices (EC-encoded objects) are moved directly, without reconstructing the object:
+// 5. A jogger on a target that holds a slice but is not the 'main' one:
+//    - calculates the object's new HRW placement (main target + data + parity)
+//    - does nothing if the local target is part of it
+//    - otherwise, sends the slice to a placement target that holds no CTs of the object
+//      (see sliceDest for the assignment that all senders compute independently)
+// 6. A target on receiving the slice:
+//    - refuses it if it already has a CT of the same (or newer) generation
+//    - saves the slice, validates its checksum, and updates `Daemons`
+//    - notifies the old owner and all other CT holders (rebActSliceMoved)
+// 7. On notification, the old owner removes its slice and metafile (ownership transferred),
+//    while others update `Daemons`; the main target also validates that the object has
+//    all its data and parity slices and, if it does not, re-encodes the object.
+//    Until then (e.g., upon failure to deliver), the old owner keeps the slice.
 
 func (reb *Reb) runECjoggers() {
 	var (
@@ -135,7 +150,11 @@ func (reb *Reb) sendFromDisk(ct *core.CT, meta *ec.Metadata, target *meta.Snode,
 		return
 	}
 
-	// transmit
+	return reb._sendCT(ct, meta, target, lom, roc, action)
+}
+
+func (reb *Reb) _sendCT(ct *core.CT, meta *ec.Metadata, target *meta.Snode, lom *core.LOM, roc cos.ReadOpenCloser,
+	action uint32) (err error) {
 	ntfn := stageNtfn{daemonID: core.T.SID(), stage: rebStageTraverse, rebID: reb.rebID.Load(), md: meta, action: action}
 	o := transport.AllocSend()
 	o.Hdr = transport.ObjHdr{ObjName: ct.ObjectName(), ObjAttrs: cmn.ObjAttrs{Size: meta.Size}}
@@ -155,6 +174,7 @@ func (reb *Reb) sendFromDisk(ct *core.CT, meta *ec.Metadata, target *meta.Snode,
 	}
 	xreb := reb.xctn()
 	xreb.OutObjsAdd(1, o.Hdr.ObjAttrs.Size)
+	xreb.DiskReadAdd(o.Hdr.ObjAttrs.Size)
 	return
 }
 
@@ -311,9 +331,9 @@ func (reb *Reb) walkEC(fqn string, de fs.DirEntry) error {
 		return nil
 	}
 
-	// Skip a CT if this target is not the 'main' one
+	// Not the 'main' target: move the slice, if need be
 	if md.FullReplica != core.T.SID() {
-		return nil
+		return reb.moveSlice(ct, md)
 	}
 
 	smap := reb.smap.Load()
@@ -341,3 +361,71 @@ func (reb *Reb) walkEC(fqn string, de fs.DirEntry) error {
 	}
 	return reb.sendFromDisk(ct, md, hrwTarget)
 }
+
+// Sends local slice directly to its new location (if any) - see sliceDest.
+func (reb *Reb) moveSlice(ct *core.CT, md *ec.Metadata) error {
+	if md.SliceID == 0 || md.IsCopy {
+		return nil
+	}
+	smap := reb.smap.Load()
+	hrwList, err := smap.HrwTargetList(ct.UnamePtr(), md.Data+md.Parity+1)
+	if err != nil {
+		return nil // not enough targets - keep the slice
+	}
+	target := sliceDest(md, hrwList, core.T.SID())
+	if target == nil {
+		return nil
+	}
+	fqn := ct.Make(fs.ECSliceType)
+	if err := cos.Stat(fqn); err != nil {
+		nlog.Warningf("%s no CT for metadata[%d]: %s", core.T, md.SliceID, fqn)
+		return nil
+	}
+	if ct, err = core.NewCTFromFQN(fqn, core.T.Bowner()); err != nil {
+		return nil
+	}
+	fh, err := cos.NewFileHandle(fqn)
+	if err != nil {
+		return err
+	}
+	return reb._sendCT(ct, md, target, nil, fh, rebActMoveSlice)
+}
+
+// Returns the target that must receive the local slice, or nil if the slice stays.
+// Given the object's new HRW placement (`hrwList`, main target first), the slice holders
+// that are not part of it are matched (in the order of slice IDs) with the placement
+// targets that hold nothing - the same assignment that all holders compute
+// independently, without talking to each other.
+// Slice holders that _are_ part of the placement stay, including the new main target
+// that resolves the conflict when the main replica arrives (see renameLocalCT).
+func sliceDest(md *ec.Metadata, hrwList meta.Nodes, tid string) *meta.Snode {
+	placed := make(cos.StrSet, len(hrwList))
+	for _, tsi := range hrwList {
+		placed.Add(tsi.ID())
+	}
+	if placed.Contains(tid) {
+		return nil
+	}
+	var (
+		sliceID = uint16(md.SliceID)
+		rank    int
+	)
+	for id, sid := range md.Daemons {
+		if id == tid || id == md.FullReplica || sid == 0 || placed.Contains(id) {
+			continue
+		}
+		if sid < sliceID || (sid == sliceID && id < tid) {
+			rank++
+		}
+	}
+	for _, tsi := range hrwList[1:] {
+		if _, ok := md.Daemons[tsi.ID()]; ok {
+			continue
+		}
+		if rank == 0 {
+			return tsi
+		}
+		rank--
+	}
+	return nil
+}
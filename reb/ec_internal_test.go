@@ -0,0 +1,57 @@
+// Package reb provides global cluster-wide rebalance upon adding/removing storage nodes.
+/*
+ * Copyright (c) 2024, NVIDIA CORPORATION. All rights reserved.
+ */
+package reb
+
+import (
+	"github.com/NVIDIA/aistore/cmn/cos"
+	"github.com/NVIDIA/aistore/core/meta"
+	"github.com/NVIDIA/aistore/ec"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("SliceDest", func() {
+	nodes := func(ids ...string) meta.Nodes {
+		sis := make(meta.Nodes, 0, len(ids))
+		for _, id := range ids {
+			sis = append(sis, &meta.Snode{DaeID: id})
+		}
+		return sis
+	}
+	// main t1, slices 1..4 on t2..t5
+	newMD := func(sliceID int) *ec.Metadata {
+		return &ec.Metadata{
+			Data:        2,
+			Parity:      2,
+			SliceID:     sliceID,
+			FullReplica: "t1",
+			Daemons:     cos.MapStrUint16{"t1": 0, "t2": 1, "t3": 2, "t4": 3, "t5": 4},
+		}
+	}
+
+	It("should keep slices that are part of the placement", func() {
+		hrwList := nodes("t1", "t5", "t4", "t3", "t2")
+		for i, tid := range []string{"t2", "t3", "t4", "t5"} {
+			Expect(sliceDest(newMD(i+1), hrwList, tid)).To(BeNil())
+		}
+	})
+
+	It("should move displaced slices to distinct empty targets", func() {
+		// t3 and t5 are gone (e.g., in maintenance), t6 and t7 are new
+		hrwList := nodes("t1", "t7", "t2", "t6", "t4")
+		dst3 := sliceDest(newMD(2), hrwList, "t3")
+		dst5 := sliceDest(newMD(4), hrwList, "t5")
+		Expect(dst3).NotTo(BeNil())
+		Expect(dst5).NotTo(BeNil())
+		Expect(dst3.ID()).To(Equal("t7"))
+		Expect(dst5.ID()).To(Equal("t6"))
+	})
+
+	It("should keep the slice when there are no empty targets", func() {
+		// t5 is gone, the new main target (t6) is the only one without slices
+		hrwList := nodes("t6", "t1", "t2", "t3", "t4")
+		Expect(sliceDest(newMD(4), hrwList, "t5")).To(BeNil())
+	})
+})
@@ -53,6 +53,13 @@ const (
 	rebStageAbort // one of targets aborts the rebalancing (never set, only sent)
 )
 
+// traversal pass enum (see rebalance.hot_first)
+const (
+	passAll = iota
+	passHot
+	passCold
+)
+
 const maxWackTargets = 4
 
 var stages = map[uint32]string{
@@ -106,6 +113,12 @@ type (
 		smap *meta.Smap
 		opts fs.WalkOpts
 		ver  int64
+		temp struct {
+			hotAge time.Duration // zero: no prioritization (single pass in walk order)
+			since  int64         // atime (unix nano) threshold: hot objects are accessed at or after
+			start  int64         // beginning of the hot pass
+			pass   int           // enum below
+		}
 	}
 	rebArgs struct {
 		smap   *meta.Smap
@@ -542,6 +555,9 @@ func (reb *Reb) runNoEC(rargs *rebArgs) error {
 			joggerBase: joggerBase{m: reb, xreb: reb.xctn(), wg: wg},
 			smap:       rargs.smap, ver: ver,
 		}
+		if rargs.config.Rebalance.HotFirst {
+			rl.temp.hotAge = rargs.config.Rebalance.HotAgeOrDflt()
+		}
 		wg.Add(1)
 		go rl.jog(mi)
 	}
@@ -613,6 +629,7 @@ func (reb *Reb) rebWaitAck(rargs *rebArgs) (errCnt int) {
 		}
 		if cnt > 0 {
 			nlog.Warningf("%s: timed out waiting for %d ACK%s", logHdr, cnt, cos.Plural(cnt))
+			xreb.Xlog(core.XlogWarn, "", "timed out waiting for %d ACK%s", cnt, cos.Plural(cnt))
 		}
 		if xreb.IsAborted() {
 			return
@@ -637,6 +654,7 @@ func (reb *Reb) rebWaitAck(rargs *rebArgs) (errCnt int) {
 			break
 		}
 		nlog.Warningf("%s: retransmitted %d, more wack...", logHdr, cnt)
+		xreb.Xlog(core.XlogRetry, "", "retransmitted %d object%s", cnt, cos.Plural(cnt))
 	}
 
 	return
@@ -737,6 +755,10 @@ func (reb *Reb) fini(rargs *rebArgs, logHdr string, err error) {
 		debug.AssertNoErr(e)
 		nlog.Infoln(string(s))
 	}
+	if ext := xreb.ExtStats(); ext.HotObjs+ext.ColdObjs > 0 {
+		nlog.Infoln(logHdr, "transmitted hot:", ext.HotObjs, cos.ToSizeIEC(ext.HotBytes, 2),
+			"cold:", ext.ColdObjs, cos.ToSizeIEC(ext.ColdBytes, 2))
+	}
 	reb.stages.stage.Store(rebStageDone)
 	reb.stages.cleanup()
 
@@ -763,9 +785,42 @@ func (rj *rebJogger) jog(mi *fs.Mountpath) {
 		rj.opts.Sorted = false
 	}
 	bmd := core.T.Bowner().Get()
+	if rj.temp.hotAge == 0 {
+		bmd.Range(nil, nil, rj.walkBck)
+		return
+	}
+
+	// two passes: recently accessed ("hot") objects first, all the rest next
+	now := time.Now()
+	rj.temp.since = now.Add(-rj.temp.hotAge).UnixNano()
+	rj.temp.start = now.UnixNano()
+	rj.temp.pass = passHot
+	bmd.Range(nil, nil, rj.walkBck)
+	if rj.xreb.IsAborted() {
+		return
+	}
+	if cmn.Rom.FastV(4, cos.SmoduleReb) {
+		nlog.Infoln(rj.xreb.Name(), mi.String(), "done with hot objects")
+	}
+	rj.temp.pass = passCold
 	bmd.Range(nil, nil, rj.walkBck)
 }
 
+// returns whether the object is hot and whether to skip it in the current pass:
+// the hot pass skips cold objects, the cold pass - those that were hot (and therefore sent)
+// during the hot pass; objects accessed since the hot pass started may get sent twice (harmless)
+func (rj *rebJogger) classify(lom *core.LOM) (hot, skip bool) {
+	if err := lom.Load(false /*cache it*/, false /*locked*/); err != nil {
+		return false, rj.temp.pass == passHot // (leaving it to the cold pass)
+	}
+	atime := lom.AtimeUnix()
+	hot = atime >= rj.temp.since
+	if rj.temp.pass == passHot {
+		return hot, !hot
+	}
+	return hot, hot && atime < rj.temp.start
+}
+
 func (rj *rebJogger) walkBck(bck *meta.Bck) bool {
 	rj.opts.Bck.Copy(bck.Bucket())
 	err := fs.Walk(&rj.opts)
@@ -785,6 +840,7 @@ func (rj *rebJogger) objSentCallback(hdr *transport.ObjHdr, _ io.ReadCloser, arg
 	rj.m.inQueue.Dec()
 	if err == nil {
 		rj.xreb.OutObjsAdd(1, hdr.ObjAttrs.Size) // NOTE: double-counts retransmissions
+		rj.xreb.DiskReadAdd(hdr.ObjAttrs.Size)
 		return
 	}
 	// log err
@@ -797,6 +853,7 @@ func (rj *rebJogger) objSentCallback(hdr *transport.ObjHdr, _ io.ReadCloser, arg
 			nlog.Errorf("%s: %s failed to send %s: %v", core.T, rj.xreb.Name(), lom, err)
 		}
 	}
+	rj.xreb.Xlog(core.XlogError, "", "failed to send: %v", err)
 }
 
 func (rj *rebJogger) visitObj(fqn string, de fs.DirEntry) error {
@@ -836,6 +893,13 @@ func (rj *rebJogger) _lwalk(lom *core.LOM, fqn string) error {
 	if tsi.ID() == core.T.SID() {
 		return cmn.ErrSkip
 	}
+	var hot bool
+	if rj.temp.pass != passAll {
+		var skip bool
+		if hot, skip = rj.classify(lom); skip {
+			return cmn.ErrSkip
+		}
+	}
 
 	// skip objects that were already sent via GFN (due to probabilistic filtering
 	// false-positives, albeit rare, are still possible)
@@ -852,12 +916,15 @@ func (rj *rebJogger) _lwalk(lom *core.LOM, fqn string) error {
 	}
 
 	// transmit (unlock via transport completion => roc.Close)
+	size := lom.Lsize()
 	rj.m.addLomAck(lom)
 	if err := rj.doSend(lom, tsi, roc); err != nil {
 		rj.m.delLomAck(lom, 0, false /*free LOM*/)
 		return err
 	}
-
+	if rj.temp.pass != passAll {
+		rj.xreb.AddTemp(hot, size)
+	}
 	return nil
 }
 
@@ -19,9 +19,11 @@ const (
 )
 const rebMsgKindSize = 1
 const (
-	rebActRebCT    = iota // a CT moved to a correct target (regular rebalance)
-	rebActMoveCT          // a CT moved from a target after slice conflict (a target received a CT and it had another CT)
-	rebActUpdateMD        // a new MD to update existing local one
+	rebActRebCT      = iota // a CT moved to a correct target (regular rebalance)
+	rebActMoveCT            // a CT moved from a target after slice conflict (a target received a CT and it had another CT)
+	rebActUpdateMD          // a new MD to update existing local one
+	rebActMoveSlice         // a slice moved directly to its new HRW location (ownership not yet transferred)
+	rebActSliceMoved        // the slice (above) has been received and validated: new owner => all CT holders, including the old owner
 )
 
 type (
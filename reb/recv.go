@@ -348,13 +348,25 @@ func (reb *Reb) recvECData(hdr *transport.ObjHdr, unpacker *cos.ByteUnpack, read
 			core.T.Snode(), req.rebID, reb.rebID.Load())
 		return nil
 	}
-	if req.action == rebActUpdateMD {
+	switch req.action {
+	case rebActUpdateMD:
 		err := receiveMD(req, hdr)
 		if err != nil {
 			nlog.Errorf("failed to receive MD for %s: %v", hdr.Cname(), err)
 			nlog.Errorf("Warning: (g%d, %s) ignoring, proceeding anyway...", req.rebID, core.T) // TODO: revisit
 		}
 		return nil
+	case rebActMoveSlice:
+		if err := reb.receiveSlice(req, hdr, reader); err != nil {
+			// the sender keeps the slice
+			nlog.Errorf("failed to receive slice for %s: %v", hdr.Cname(), err)
+		}
+		return nil
+	case rebActSliceMoved:
+		if err := reb.receiveSliceMoved(req, hdr); err != nil {
+			nlog.Errorf("failed to transfer slice ownership for %s: %v", hdr.Cname(), err)
+		}
+		return nil
 	}
 	if err := reb.receiveCT(req, hdr, reader); err != nil {
 		nlog.Errorf("failed to receive CT for %s: %v", hdr.Cname(), err)
@@ -362,3 +374,151 @@ func (reb *Reb) recvECData(hdr *transport.ObjHdr, unpacker *cos.ByteUnpack, read
 	}
 	return nil
 }
+
+// Receive a slice that changes owner (see moveSlice): save it, validate its checksum,
+// and notify the old owner (that will then remove its slice) and all other CT holders.
+func (reb *Reb) receiveSlice(req *stageNtfn, hdr *transport.ObjHdr, reader io.Reader) error {
+	ct, err := core.NewCTFromBO(&hdr.Bck, hdr.ObjName, core.T.Bowner(), fs.ECSliceType)
+	if err != nil {
+		return err
+	}
+	ctMeta := ct.Clone(fs.ECMetaType)
+	if md, err := ec.LoadMetadata(ctMeta.FQN()); err == nil && md.Generation >= req.md.Generation {
+		return fmt.Errorf("%s already has CT [%d] of the object (generation %d vs %d)",
+			core.T, md.SliceID, md.Generation, req.md.Generation)
+	}
+
+	md := req.md
+	delete(md.Daemons, req.daemonID)
+	md.Daemons[core.T.SID()] = uint16(md.SliceID)
+	var (
+		cksum *cos.CksumHash
+		args  = &ec.WriteArgs{Reader: reader, MD: md.NewPack(), Xact: reb.xctn()}
+	)
+	if md.CksumValue != "" && md.CksumType != cos.ChecksumNone {
+		cksum = cos.NewCksumHash(md.CksumType)
+		args.Reader = io.TeeReader(reader, cksum.H)
+	}
+	if err := ec.WriteSliceAndMeta(hdr, args); err != nil {
+		return err
+	}
+	if cksum != nil {
+		cksum.Finalize()
+		if expected := cos.NewCksum(md.CksumType, md.CksumValue); !cksum.Equal(expected) {
+			err := cos.NewErrDataCksum(&cksum.Cksum, expected, ct.Cname())
+			if errRm := cos.RemoveFile(ct.FQN()); errRm != nil {
+				nlog.Errorln(err, "nested err: failed to remove", ct.FQN(), "[", errRm, "]")
+			}
+			if errRm := cos.RemoveFile(ctMeta.FQN()); errRm != nil {
+				nlog.Errorln(err, "nested err: failed to remove", ctMeta.FQN(), "[", errRm, "]")
+			}
+			return err
+		}
+	}
+	reb.xctn().InObjsAdd(1, hdr.ObjAttrs.Size)
+	reb.xctn().DiskWriteAdd(hdr.ObjAttrs.Size)
+
+	// transfer ownership
+	var (
+		ntfn  = stageNtfn{daemonID: core.T.SID(), stage: rebStageTraverse, rebID: reb.rebID.Load(), md: md, action: rebActSliceMoved}
+		nodes = md.RemoteTargets()
+	)
+	if tsi := core.T.Sowner().Get().GetTarget(req.daemonID); tsi != nil {
+		nodes = append(nodes, tsi)
+	}
+	for _, tsi := range nodes {
+		if reb.xctn().IsAborted() {
+			break
+		}
+		reb.onAir.Inc()
+		o := transport.AllocSend()
+		o.Hdr = transport.ObjHdr{ObjName: ct.ObjectName(), ObjAttrs: cmn.ObjAttrs{Size: 0}}
+		o.Hdr.Bck.Copy(ct.Bck().Bucket())
+		o.Hdr.Opaque = ntfn.NewPack(rebMsgEC)
+		o.Callback = reb.transportECCB
+		if errSend := reb.dm.Send(o, nil, tsi); errSend != nil && err == nil {
+			err = fmt.Errorf("failed to send updated metafile: %v", errSend)
+		}
+	}
+	return err
+}
+
+// Slice ownership transfer (see receiveSlice):
+// - old owner removes its slice and metafile;
+// - others replace the old owner with the new one (in `Daemons`);
+// - main target, in addition, validates that the object has all its slices.
+func (*Reb) receiveSliceMoved(req *stageNtfn, hdr *transport.ObjHdr) error {
+	ct, err := core.NewCTFromBO(&hdr.Bck, hdr.ObjName, core.T.Bowner(), fs.ECSliceType)
+	if err != nil {
+		return err
+	}
+	ctMeta := ct.Clone(fs.ECMetaType)
+	md, err := ec.LoadMetadata(ctMeta.FQN())
+	if err != nil {
+		if os.IsNotExist(err) {
+			err = nil
+		}
+		return err
+	}
+	if md.Generation != req.md.Generation {
+		return nil
+	}
+
+	// old owner
+	if _, ok := req.md.Daemons[core.T.SID()]; !ok && md.SliceID == req.md.SliceID && md.FullReplica != core.T.SID() {
+		ct.Lock(true)
+		err = cos.RemoveFile(ct.FQN())
+		if errRm := cos.RemoveFile(ctMeta.FQN()); errRm != nil && err == nil {
+			err = errRm
+		}
+		ct.Unlock(true)
+		return err
+	}
+
+	sliceID := uint16(req.md.SliceID)
+	for tid, sid := range md.Daemons {
+		if sid == sliceID && tid != req.daemonID {
+			delete(md.Daemons, tid)
+		}
+	}
+	md.Daemons[req.daemonID] = sliceID
+	if err := ctMeta.Write(bytes.NewReader(md.NewPack()), -1, "" /*work fqn*/); err != nil {
+		return err
+	}
+	if md.FullReplica == core.T.SID() {
+		validateSlices(md, hdr)
+	}
+	return nil
+}
+
+// (main target) re-encode the object if any of its data or parity slices is missing
+func validateSlices(md *ec.Metadata, hdr *transport.ObjHdr) {
+	if md.IsCopy {
+		return
+	}
+	var (
+		cnt  = md.Data + md.Parity
+		seen = make(map[uint16]struct{}, cnt)
+	)
+	for _, sid := range md.Daemons {
+		if sid != 0 && int(sid) <= cnt {
+			seen[sid] = struct{}{}
+		}
+	}
+	if len(seen) == cnt {
+		return
+	}
+	nlog.Warningf("%s: %s has %d out of %d slices - re-encoding", core.T, hdr.Cname(), len(seen), cnt)
+	lom := core.AllocLOM(hdr.ObjName)
+	defer core.FreeLOM(lom)
+	if err := lom.InitBck(&hdr.Bck); err != nil {
+		nlog.Errorln(err)
+		return
+	}
+	if err := lom.Load(false /*cache it*/, false /*locked*/); err != nil {
+		return // (not yet received the main replica)
+	}
+	if err := ec.ECM.EncodeObject(lom, nil); err != nil && err != ec.ErrorECDisabled {
+		nlog.Errorln("failed to re-encode", lom.Cname(), "err:", err)
+	}
+}
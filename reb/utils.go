@@ -38,6 +38,19 @@ func (reb *Reb) AbortLocal(olderSmapV int64, err error) {
 func (reb *Reb) xctn() *xs.Rebalance        { return reb.xreb.Load() }
 func (reb *Reb) setXact(xctn *xs.Rebalance) { reb.xreb.Store(xctn) }
 
+// capture the event in the xaction log (in addition to regular logging)
+func (reb *Reb) xlog(level string, tsi *meta.Snode, format string, a ...any) {
+	xreb := reb.xctn()
+	if xreb == nil {
+		return
+	}
+	var sid string
+	if tsi != nil {
+		sid = tsi.ID()
+	}
+	xreb.Xlog(level, sid, format, a...)
+}
+
 func (reb *Reb) logHdr(rebID int64, smap *meta.Smap, initializing ...bool) string {
 	var sb strings.Builder
 
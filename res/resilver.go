@@ -23,6 +23,7 @@ import (
 	"github.com/NVIDIA/aistore/fs"
 	"github.com/NVIDIA/aistore/fs/mpather"
 	"github.com/NVIDIA/aistore/memsys"
+	"github.com/NVIDIA/aistore/volume"
 	"github.com/NVIDIA/aistore/xact"
 	"github.com/NVIDIA/aistore/xact/xreg"
 	"github.com/NVIDIA/aistore/xact/xs"
@@ -42,6 +43,7 @@ type (
 		Rmi               *fs.Mountpath
 		Action            string
 		PostDD            func(rmi *fs.Mountpath, action string, xres *xs.Resilver, err error)
+		Mpaths            cos.StrSet // (optional) subset of mountpaths to resilver (see volume.ResilverScope)
 		SkipGlobMisplaced bool
 		SingleRmiJogger   bool
 	}
@@ -108,6 +110,7 @@ func (res *Res) RunResilver(args Args) {
 			VisitCT:               jctx.visitCT,
 			Slab:                  slab,
 			SkipGloballyMisplaced: args.SkipGlobMisplaced,
+			Mpaths:                args.Mpaths,
 		}
 	)
 	debug.AssertNoErr(err)
@@ -117,8 +120,17 @@ func (res *Res) RunResilver(args Args) {
 		jg = mpather.NewJoggerGroup(opts, config, args.Rmi)
 		nlog.Infof("%s, action %q, jogger->(%q)", xres.Name(), args.Action, args.Rmi)
 	} else {
+		// record per-mountpath completions (fast-path: see volume.ResilverScope)
+		layout := volume.Layout()
+		opts.DoneMpath = func(mi *fs.Mountpath) {
+			if err := volume.MarkResilvered(core.T.SID(), layout, mi); err != nil {
+				nlog.Warningln(xres.Name(), "failed to mark", mi.String(), "resilvered:", err)
+			}
+		}
 		jg = mpather.NewJoggerGroup(opts, config, nil)
-		if args.Rmi != nil {
+		if args.Mpaths != nil {
+			nlog.Infof("%s, mountpaths %v, num %d", xres.Name(), args.Mpaths.ToSlice(), jg.Num())
+		} else if args.Rmi != nil {
 			nlog.Infof("%s, action %q, rmi %s, num %d", xres.Name(), args.Action, args.Rmi, jg.Num())
 		} else {
 			nlog.Infof("%s, num %d", xres.Name(), jg.Num())
@@ -249,6 +261,8 @@ func (jg *joggerCtx) visitObj(lom *core.LOM, buf []byte) (errHrw error) {
 		lom.Unlock(true)
 		if copied && errHrw == nil {
 			jg.xres.ObjsAdd(1, size)
+			jg.xres.DiskReadAdd(size)
+			jg.xres.DiskWriteAdd(size)
 		}
 	}()
 
@@ -10,6 +10,7 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"strings"
 	"sync"
 	"time"
 
@@ -22,6 +23,7 @@ import (
 	"github.com/NVIDIA/aistore/core"
 	"github.com/NVIDIA/aistore/core/meta"
 	"github.com/NVIDIA/aistore/fs"
+	"github.com/NVIDIA/aistore/mirror"
 	"github.com/NVIDIA/aistore/stats"
 	"github.com/NVIDIA/aistore/xact"
 	"github.com/NVIDIA/aistore/xact/xreg"
@@ -34,6 +36,9 @@ type (
 		StatsT  stats.Tracker
 		Buckets []cmn.Bck // optional list of specific buckets to cleanup
 		WG      *sync.WaitGroup
+		// optional: returns true if the named ETL is running on this target;
+		// when nil, cached inline transformations (fs.ETLCacheType) are left alone
+		ETLRunning func(etlName string) bool
 	}
 	XactCln struct {
 		xact.Base
@@ -347,7 +352,7 @@ func (j *clnJ) jogBck() (size int64, err error) {
 	opts := &fs.WalkOpts{
 		Mi:       j.mi,
 		Bck:      j.bck,
-		CTs:      []string{fs.WorkfileType, fs.ObjectType, fs.ECSliceType, fs.ECMetaType},
+		CTs:      []string{fs.WorkfileType, fs.ObjectType, fs.ECSliceType, fs.ECMetaType, fs.DedupType, fs.ETLCacheType, fs.CustomMDType},
 		Callback: j.walk,
 		Sorted:   false,
 	}
@@ -410,6 +415,25 @@ func (j *clnJ) visitCT(parsedFQN *fs.ParsedFQN, fqn string) {
 			return
 		}
 		j.oldWork = append(j.oldWork, fqn)
+	case fs.DedupType:
+		// dedup entries: remove those that were not used for a while (see core/ldedup.go)
+		if core.IsDedupEntryUnused(fqn, j.now, j.config.LRU.DontEvictTime.D()) {
+			j.oldWork = append(j.oldWork, fqn)
+		}
+	case fs.ETLCacheType:
+		// cached inline transformations: remove those of the ETLs that are not running (see ext/etl/cache.go)
+		if j.ini.ETLRunning == nil {
+			return
+		}
+		etlName, _, _ := strings.Cut(parsedFQN.ObjName, "/")
+		if !j.ini.ETLRunning(etlName) {
+			j.oldWork = append(j.oldWork, fqn)
+		}
+	case fs.CustomMDType:
+		// custom metadata sidecars: remove orphans (see core/lom_sidecar.go)
+		if core.IsCustomMDOrphan(fqn, &j.bck, j.now, j.config.LRU.DontEvictTime.D()) {
+			j.oldWork = append(j.oldWork, fqn)
+		}
 	default:
 		debug.Assertf(false, "Unsupported content type: %s", parsedFQN.ContentType)
 	}
@@ -464,6 +488,9 @@ func (j *clnJ) visitObj(fqn string, lom *core.LOM) {
 		if lom.HasCopies() {
 			j.rmExtraCopies(lom)
 		}
+		if lom.HasRefs() {
+			j.syncRefs(lom)
+		}
 		return
 	}
 	if lom.IsCopy() {
@@ -501,6 +528,31 @@ func (j *clnJ) rmExtraCopies(lom *core.LOM) {
 		err = fmt.Errorf("%s: failed delete redundant copies of %s: %v", j, lom, err)
 		j.ini.Xaction.AddErr(err, 5, cos.SmoduleSpace)
 	}
+	// copies in excess of the per-object replication override, if any
+	if n := lom.CopiesOverride(); n > 0 && lom.NumCopies() > n {
+		if _, err := mirror.SyncCopies(lom, nil /*buf: removing only*/); err != nil {
+			err = fmt.Errorf("%s: failed to remove copies of %s in excess of %d: %v", j, lom, n, err)
+			j.ini.Xaction.AddErr(err, 5, cos.SmoduleSpace)
+		}
+	}
+}
+
+// reconcile (possibly stale) count of cloned objects sharing the data (see core/lcow.go)
+func (j *clnJ) syncRefs(lom *core.LOM) {
+	if !lom.TryLock(true) {
+		return // must be busy
+	}
+	defer lom.Unlock(true)
+	if err := lom.Load(false /*cache it*/, true /*locked*/); err != nil {
+		if !cos.IsNotExist(err, 0) {
+			j.ini.Xaction.AddErr(err)
+		}
+		return
+	}
+	if _, err := lom.SyncRefs(); err != nil {
+		err = fmt.Errorf("%s: failed to sync refs of %s: %v", j, lom, err)
+		j.ini.Xaction.AddErr(err, 5, cos.SmoduleSpace)
+	}
 }
 
 func (j *clnJ) walk(fqn string, de fs.DirEntry) error {
@@ -606,6 +658,7 @@ func (j *clnJ) rmLeftovers() (size int64, err error) {
 
 func (j *clnJ) yieldTerm() error {
 	xcln := j.ini.Xaction
+	xcln.Yield()
 	select {
 	case errCause := <-xcln.ChanAbort():
 		return cmn.NewErrAborted(xcln.Name(), "", errCause)
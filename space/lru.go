@@ -462,6 +462,7 @@ func (j *lruJ) evictSize() (err error) {
 
 func (j *lruJ) yieldTerm() error {
 	xlru := j.ini.Xaction
+	xlru.Yield()
 	select {
 	case errCause := <-xlru.ChanAbort():
 		return cmn.NewErrAborted(xlru.Name(), "", errCause)
@@ -9,6 +9,7 @@ import (
 	"strings"
 
 	"github.com/NVIDIA/aistore/api/apc"
+	"github.com/NVIDIA/aistore/cmn"
 	"github.com/NVIDIA/aistore/cmn/cos"
 	"github.com/NVIDIA/aistore/core"
 	"github.com/NVIDIA/aistore/core/meta"
@@ -42,6 +43,15 @@ type (
 		GetStats() *Node
 		GetStatsV322() *NodeV322 // [backward compatibility]
 
+		GetStatsHistory(since, until int64) *History // [since, until] Unix time (nanoseconds)
+
+		AddTop(bck *cmn.Bck, objName string, size int64) // top-N traffic (see TopReport)
+		GetTop(n int) *TopReport
+
+		GetCapHistory() *CapHistory // (target) capacity snapshots (see CapReport)
+
+		AddLatHist(bck *cmn.Bck, op string, lat int64) // per-bucket latency histograms (enum LatHist*)
+
 		ResetStats(errorsOnly bool)
 		GetMetricNames() cos.StrKVs // (name, kind) pairs
 
@@ -0,0 +1,338 @@
+// Package stats provides methods and functionality to register, track, log,
+// and StatsD-notify statistics that, for the most part, include "counter" and "latency" kinds.
+/*
+ * Copyright (c) 2024, NVIDIA CORPORATION. All rights reserved.
+ */
+package stats
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/cmn/atomic"
+	"github.com/NVIDIA/aistore/cmn/mono"
+	"github.com/NVIDIA/aistore/core"
+	"github.com/NVIDIA/aistore/core/meta"
+	"github.com/NVIDIA/aistore/fs"
+)
+
+// Capacity planning (target snapshots, cluster-wide report):
+// - each target periodically takes a snapshot of its used and available capacity,
+//   total and per bucket (on-disk), and retains snapshots for `config.Periodic.StatsHistory` -
+//   same retention as metrics history (see hist.go) but coarser resolution (see capInterval)
+//   since sizing buckets on disk is relatively expensive; for the same reason, buckets get
+//   sized at most once every capHistBckIval (samples in between carry no per-bucket sizes);
+// - given all targets' snapshots, any gateway computes growth rates (least-squares fit)
+//   and projects time to reach high watermark, out-of-space, and full capacity (see CapReport).
+
+const (
+	capHistMaxSamples = 256
+	capHistMinIval    = time.Minute
+	capHistBckIval    = 10 * time.Minute // (fs.OnDiskSize all buckets)
+
+	CapPlanHorizon = 30 * 24 * time.Hour // advise when projected to reach a threshold within
+	EtaNever       = time.Duration(-1)   // not growing or insufficient history
+)
+
+type (
+	// REST API: target's capacity history
+	CapSample struct {
+		Bcks  map[string]uint64 `json:"buckets,omitempty"` // on-disk size by bucket (cname); nil when not sized
+		Time  int64             `json:"t,string"`          // Unix time (nanoseconds)
+		Used  uint64            `json:"used,string"`       // bytes
+		Avail uint64            `json:"avail,string"`      // ditto
+	}
+	CapHistory struct {
+		Samples  []CapSample   `json:"samples"`
+		Interval time.Duration `json:"interval"`
+	}
+
+	// REST API: cluster-wide capacity planning report
+	// (time to reach a given threshold at the current growth rate: zero - already reached;
+	// EtaNever - not growing or insufficient history)
+	CapForecast struct {
+		Used      uint64        `json:"used,string"`
+		Total     uint64        `json:"total,string"`
+		GrowthBps float64       `json:"growth_bps"` // bytes per second
+		ToHighWM  time.Duration `json:"to_highwm"`
+		ToOOS     time.Duration `json:"to_oos"`
+		ToFull    time.Duration `json:"to_full"`
+	}
+	TargetCapPlan struct {
+		TargetID string        `json:"target_id"`
+		Span     time.Duration `json:"span"` // history time span
+		CapForecast
+	}
+	BckCapPlan struct {
+		Bck       string  `json:"bucket"`
+		Size      uint64  `json:"size,string"` // on-disk, all targets
+		GrowthBps float64 `json:"growth_bps"`
+	}
+	CapReport struct {
+		Targets []*TargetCapPlan `json:"targets"` // sorted by time to out-of-space
+		Buckets []*BckCapPlan    `json:"buckets"` // sorted by growth rate, descending
+		Advice  []string         `json:"advice,omitempty"`
+		Cluster CapForecast      `json:"cluster"`
+		HighWM  int64            `json:"highwm"`       // config.Space.HighWM
+		OOS     int64            `json:"out_of_space"` // config.Space.OOS
+		Time    int64            `json:"t,string"`
+	}
+
+	capHist struct {
+		ring     []CapSample
+		interval time.Duration
+		last     int64 // mono time of the last snapshot
+		lastBck  int64 // mono time buckets were last sized (see capHistBckIval)
+		head     int
+		size     int
+		mu       sync.RWMutex
+		running  atomic.Bool
+	}
+)
+
+// resolution: as fine as the retention (and capHistMaxSamples) allows, but not finer than capHistMinIval
+func capInterval(config *cmn.Config) (retention, interval time.Duration) {
+	retention = config.Periodic.StatsHistory.D()
+	interval = max(retention/capHistMaxSamples, capHistMinIval)
+	return retention, interval
+}
+
+/////////////
+// capHist //
+/////////////
+
+// (target) called periodically - see Trunner.log
+func (ch *capHist) maybe(t core.Target, config *cmn.Config) {
+	retention, interval := capInterval(config)
+	if retention == 0 {
+		ch.mu.Lock()
+		ch.ring, ch.size, ch.head = nil, 0, 0
+		ch.mu.Unlock()
+		return
+	}
+	if ch.last != 0 && mono.Since(ch.last) < interval {
+		return
+	}
+	if !ch.running.CAS(false, true) {
+		return
+	}
+	ch.last = mono.NanoTime()
+	go ch.snap(t, retention, interval)
+}
+
+func (ch *capHist) snap(t core.Target, retention, interval time.Duration) {
+	var (
+		cs = fs.Cap()
+		s  = CapSample{Used: cs.TotalUsed, Avail: cs.TotalAvail}
+	)
+	if now := mono.NanoTime(); ch.bckDue(now, interval) {
+		s.Bcks = make(map[string]uint64, 8)
+		t.Bowner().Get().Range(nil, nil, func(bck *meta.Bck) bool {
+			s.Bcks[bck.Cname("")] = fs.OnDiskSize(bck.Bucket(), "")
+			return false
+		})
+		ch.lastBck = now
+	}
+	s.Time = time.Now().UnixNano()
+
+	ch.mu.Lock()
+	if capacity := max(int(retention/interval), 2); capacity != len(ch.ring) || interval != ch.interval {
+		ch.resize(capacity)
+		ch.interval = interval
+	}
+	ch.ring[ch.head] = s
+	ch.head = (ch.head + 1) % len(ch.ring)
+	ch.size = min(ch.size+1, len(ch.ring))
+	ch.mu.Unlock()
+
+	ch.running.Store(false)
+}
+
+// (snap goroutine) whether it's time to size buckets
+func (ch *capHist) bckDue(now int64, interval time.Duration) bool {
+	return ch.lastBck == 0 || time.Duration(now-ch.lastBck) >= max(interval, capHistBckIval)
+}
+
+// (under lock) keep the most recent samples
+func (ch *capHist) resize(capacity int) {
+	ring := make([]CapSample, capacity)
+	n := min(ch.size, capacity)
+	for i := range n {
+		ring[i] = ch.ring[(ch.head-n+i+len(ch.ring))%len(ch.ring)]
+	}
+	ch.ring, ch.size, ch.head = ring, n, n%capacity
+}
+
+func (ch *capHist) get() *CapHistory {
+	ch.mu.RLock()
+	out := &CapHistory{Interval: ch.interval, Samples: make([]CapSample, 0, ch.size)}
+	for i := range ch.size {
+		out.Samples = append(out.Samples, ch.ring[(ch.head-ch.size+i+len(ch.ring))%len(ch.ring)])
+	}
+	ch.mu.RUnlock()
+	return out
+}
+
+///////////////
+// CapReport //
+///////////////
+
+func NewCapReport(hists map[string]*CapHistory, config *cmn.Config) *CapReport {
+	var (
+		rep = &CapReport{
+			Targets: make([]*TargetCapPlan, 0, len(hists)),
+			HighWM:  config.Space.HighWM,
+			OOS:     config.Space.OOS,
+			Time:    time.Now().UnixNano(),
+		}
+		bcks = make(map[string]*BckCapPlan, 8)
+	)
+	for tid, hist := range hists {
+		tp := &TargetCapPlan{TargetID: tid}
+		rep.Targets = append(rep.Targets, tp)
+		n := len(hist.Samples)
+		if n == 0 {
+			tp.CapForecast.eta(rep.HighWM, rep.OOS)
+			continue
+		}
+		last := &hist.Samples[n-1]
+		tp.Used, tp.Total = last.Used, last.Used+last.Avail
+		tp.Span = time.Duration(last.Time - hist.Samples[0].Time)
+		tp.GrowthBps = growth(hist.Samples, func(s *CapSample) (uint64, bool) { return s.Used, true })
+		tp.CapForecast.eta(rep.HighWM, rep.OOS)
+
+		rep.Cluster.Used += tp.Used
+		rep.Cluster.Total += tp.Total
+		rep.Cluster.GrowthBps += tp.GrowthBps
+
+		// per bucket: this target's share, as of the most recent sample that has bucket sizes
+		for i := n - 1; i >= 0; i-- {
+			if hist.Samples[i].Bcks == nil {
+				continue
+			}
+			for cname, size := range hist.Samples[i].Bcks {
+				bp, ok := bcks[cname]
+				if !ok {
+					bp = &BckCapPlan{Bck: cname}
+					bcks[cname] = bp
+				}
+				bp.Size += size
+				bp.GrowthBps += growth(hist.Samples, func(s *CapSample) (uint64, bool) { v, ok := s.Bcks[cname]; return v, ok })
+			}
+			break
+		}
+	}
+	rep.Cluster.eta(rep.HighWM, rep.OOS)
+
+	sort.Slice(rep.Targets, func(i, j int) bool {
+		ti, tj := rep.Targets[i], rep.Targets[j]
+		if ti.ToOOS != tj.ToOOS {
+			return tj.ToOOS == EtaNever || (ti.ToOOS != EtaNever && ti.ToOOS < tj.ToOOS)
+		}
+		return ti.TargetID < tj.TargetID
+	})
+	rep.Buckets = make([]*BckCapPlan, 0, len(bcks))
+	for _, bp := range bcks {
+		rep.Buckets = append(rep.Buckets, bp)
+	}
+	sort.Slice(rep.Buckets, func(i, j int) bool {
+		bi, bj := rep.Buckets[i], rep.Buckets[j]
+		if bi.GrowthBps != bj.GrowthBps {
+			return bi.GrowthBps > bj.GrowthBps
+		}
+		return bi.Bck < bj.Bck
+	})
+	rep.advise()
+	return rep
+}
+
+func (rep *CapReport) advise() {
+	var enough bool
+	for _, tp := range rep.Targets {
+		if tp.Span > 0 {
+			enough = true
+		}
+		if tp.ToOOS >= 0 && tp.ToOOS <= CapPlanHorizon {
+			rep.Advice = append(rep.Advice, fmt.Sprintf("target %s: projected to run out of space (%d%%) %s",
+				tp.TargetID, rep.OOS, _eta(tp.ToOOS)))
+		} else if tp.ToHighWM >= 0 && tp.ToHighWM <= CapPlanHorizon {
+			rep.Advice = append(rep.Advice, fmt.Sprintf("target %s: projected to reach high watermark (%d%%) %s",
+				tp.TargetID, rep.HighWM, _eta(tp.ToHighWM)))
+		}
+	}
+	if !enough {
+		rep.Advice = append(rep.Advice, "insufficient capacity history (see config.periodic.stats_history)")
+		return
+	}
+	if c := &rep.Cluster; c.ToHighWM >= 0 && c.ToHighWM <= CapPlanHorizon {
+		rep.Advice = append(rep.Advice, fmt.Sprintf("cluster: projected to reach high watermark (%d%%) %s - consider adding capacity",
+			rep.HighWM, _eta(c.ToHighWM)))
+	}
+}
+
+func _eta(d time.Duration) string {
+	if d == 0 {
+		return "now"
+	}
+	return "in " + d.Round(time.Minute).String()
+}
+
+// time to reach high watermark, OOS, and full capacity
+func (f *CapForecast) eta(highwm, oos int64) {
+	f.ToHighWM = f._eta(highwm)
+	f.ToOOS = f._eta(oos)
+	f.ToFull = f._eta(100)
+}
+
+func (f *CapForecast) _eta(pct int64) time.Duration {
+	if f.Total == 0 {
+		return EtaNever
+	}
+	threshold := float64(f.Total) * float64(pct) / 100
+	if float64(f.Used) >= threshold {
+		return 0
+	}
+	if f.GrowthBps <= 0 {
+		return EtaNever
+	}
+	secs := (threshold - float64(f.Used)) / f.GrowthBps
+	if secs > float64(100*365*24*time.Hour/time.Second) { // (effectively, never)
+		return EtaNever
+	}
+	return time.Duration(secs * float64(time.Second))
+}
+
+// least-squares slope (bytes per second) over the samples that have the value
+func growth(samples []CapSample, value func(*CapSample) (uint64, bool)) float64 {
+	var (
+		n                int
+		t0               = samples[0].Time
+		sx, sy, sxx, sxy float64
+		xmin, xmax       = -1.0, -1.0
+	)
+	for i := range samples {
+		s := &samples[i]
+		v, ok := value(s)
+		if !ok {
+			continue
+		}
+		x, y := float64(s.Time-t0)/float64(time.Second), float64(v)
+		if xmin < 0 {
+			xmin = x
+		}
+		xmax = x
+		sx += x
+		sy += y
+		sxx += x * x
+		sxy += x * y
+		n++
+	}
+	if n < 2 || xmax <= xmin {
+		return 0
+	}
+	nf := float64(n)
+	return (nf*sxy - sx*sy) / (nf*sxx - sx*sx)
+}
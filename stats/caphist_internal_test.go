@@ -0,0 +1,204 @@
+// Package stats provides methods and functionality to register, track, log,
+// and StatsD-notify statistics that, for the most part, include "counter" and "latency" kinds.
+/*
+ * Copyright (c) 2024, NVIDIA CORPORATION. All rights reserved.
+ */
+package stats
+
+import (
+	"math"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/cmn/cos"
+	"github.com/NVIDIA/aistore/tools/tassert"
+)
+
+const capGiB = uint64(1024 * 1024 * 1024)
+
+// samples taken every `ival`, starting at `used` and growing by `step` bytes per sample;
+// bucket "ais://b" (if present) holds half of the used capacity
+func capSamples(n int, ival time.Duration, used, step, total uint64, withBck func(i int) bool) []CapSample {
+	var (
+		samples = make([]CapSample, n)
+		t0      = time.Now().Add(-time.Duration(n) * ival).UnixNano()
+	)
+	for i := range samples {
+		u := used + uint64(i)*step
+		samples[i] = CapSample{Time: t0 + int64(i)*int64(ival), Used: u, Avail: total - u}
+		if withBck != nil && withBck(i) {
+			samples[i].Bcks = map[string]uint64{"ais://b": u / 2}
+		}
+	}
+	return samples
+}
+
+func TestCapGrowth(t *testing.T) {
+	used := func(s *CapSample) (uint64, bool) { return s.Used, true }
+
+	// linear: exact slope
+	samples := capSamples(10, time.Minute, capGiB, 60*cos.MiB, 10*capGiB, nil)
+	bps := growth(samples, used)
+	tassert.Errorf(t, math.Abs(bps-cos.MiB) < 1e-3, "expected %d bytes/s, got %f", cos.MiB, bps)
+
+	// shrinking
+	for i := range samples {
+		samples[i].Used = 2*capGiB - uint64(i)*60*cos.MiB
+	}
+	bps = growth(samples, used)
+	tassert.Errorf(t, math.Abs(bps+cos.MiB) < 1e-3, "expected %d bytes/s, got %f", -cos.MiB, bps)
+
+	// only the samples that have the value (bucket sized every other time)
+	samples = capSamples(10, time.Minute, capGiB, 60*cos.MiB, 10*capGiB, func(i int) bool { return i%2 == 0 })
+	bck := func(s *CapSample) (uint64, bool) { v, ok := s.Bcks["ais://b"]; return v, ok }
+	bps = growth(samples, bck)
+	tassert.Errorf(t, math.Abs(bps-cos.MiB/2) < 1e-3, "expected %d bytes/s, got %f", cos.MiB/2, bps)
+
+	// insufficient history
+	tassert.Errorf(t, growth(samples[:1], used) == 0, "expected zero growth given a single sample")
+	samples = capSamples(10, time.Minute, capGiB, 60*cos.MiB, 10*capGiB, func(i int) bool { return i == 3 })
+	tassert.Errorf(t, growth(samples, bck) == 0, "expected zero growth given a single bucket sample")
+}
+
+func TestCapEta(t *testing.T) {
+	tests := []struct {
+		name   string
+		f      CapForecast
+		pct    int64
+		expect time.Duration
+	}{
+		{"unknown total", CapForecast{}, 90, EtaNever},
+		{"reached", CapForecast{Used: 95, Total: 100, GrowthBps: 1}, 90, 0},
+		{"not growing", CapForecast{Used: 50, Total: 100}, 90, EtaNever},
+		{"shrinking", CapForecast{Used: 50, Total: 100, GrowthBps: -1}, 90, EtaNever},
+		{"growing", CapForecast{Used: 50, Total: 100, GrowthBps: 2}, 90, 20 * time.Second},
+		{"effectively never", CapForecast{Used: 0, Total: math.MaxInt64, GrowthBps: 1}, 100, EtaNever},
+	}
+	for _, test := range tests {
+		eta := test.f._eta(test.pct)
+		tassert.Errorf(t, eta == test.expect, "%s: expected %v, got %v", test.name, test.expect, eta)
+	}
+
+	f := CapForecast{Used: 50, Total: 100, GrowthBps: 1}
+	f.eta(90, 95)
+	tassert.Errorf(t, f.ToHighWM == 40*time.Second && f.ToOOS == 45*time.Second && f.ToFull == 50*time.Second,
+		"unexpected forecast: %+v", f)
+
+	tassert.Errorf(t, _eta(0) == "now", "expected 'now', got %q", _eta(0))
+	tassert.Errorf(t, _eta(90*time.Minute+10*time.Second) == "in 1h30m0s", "got %q", _eta(90*time.Minute+10*time.Second))
+}
+
+func TestCapHistRing(t *testing.T) {
+	ch := &capHist{}
+	ch.resize(4)
+	add := func(i int) {
+		ch.ring[ch.head] = CapSample{Time: int64(i)}
+		ch.head = (ch.head + 1) % len(ch.ring)
+		ch.size = min(ch.size+1, len(ch.ring))
+	}
+	times := func() (out []int64) {
+		for _, s := range ch.get().Samples {
+			out = append(out, s.Time)
+		}
+		return out
+	}
+	equal := func(a, b []int64) bool {
+		if len(a) != len(b) {
+			return false
+		}
+		for i := range a {
+			if a[i] != b[i] {
+				return false
+			}
+		}
+		return true
+	}
+	for i := range 6 {
+		add(i)
+	}
+	tassert.Fatalf(t, equal(times(), []int64{2, 3, 4, 5}), "expected the most recent 4, got %v", times())
+
+	// shrink: keep the most recent
+	ch.resize(2)
+	tassert.Fatalf(t, equal(times(), []int64{4, 5}), "expected [4 5], got %v", times())
+	add(6)
+	tassert.Fatalf(t, equal(times(), []int64{5, 6}), "expected [5 6], got %v", times())
+
+	// grow: keep all
+	ch.resize(8)
+	tassert.Fatalf(t, equal(times(), []int64{5, 6}), "expected [5 6], got %v", times())
+	add(7)
+	tassert.Fatalf(t, equal(times(), []int64{5, 6, 7}), "expected [5 6 7], got %v", times())
+}
+
+func TestCapHistBckDue(t *testing.T) {
+	var (
+		ch  = &capHist{}
+		now = int64(time.Hour)
+	)
+	tassert.Fatalf(t, ch.bckDue(now, time.Minute), "expected to size buckets the first time")
+	ch.lastBck = now
+	tassert.Errorf(t, !ch.bckDue(now+int64(time.Minute), time.Minute), "expected throttling")
+	tassert.Errorf(t, ch.bckDue(now+int64(capHistBckIval), time.Minute), "expected to size buckets")
+	// coarser snapshots: every time
+	tassert.Errorf(t, ch.bckDue(now+int64(time.Hour), time.Hour), "expected to size buckets")
+}
+
+func TestCapReport(t *testing.T) {
+	config := &cmn.Config{}
+	config.Space.HighWM, config.Space.OOS = 90, 95
+
+	hists := map[string]*CapHistory{
+		// 5 GiB used out of 10, growing 1 GiB per hour: OOS in 4.5h
+		"t-fast": {Samples: capSamples(13, 5*time.Minute, 4*capGiB, capGiB/12, 10*capGiB, func(int) bool { return true })},
+		// not growing
+		"t-flat": {Samples: capSamples(13, 5*time.Minute, capGiB, 0, 10*capGiB, func(int) bool { return true })},
+		// slower: 5 GiB used out of 10, growing 1 GiB per day
+		"t-slow": {Samples: capSamples(13, 5*time.Minute, 5*capGiB-capGiB/24, capGiB/(24*12), 10*capGiB, nil)},
+		// no history yet
+		"t-new": {},
+	}
+	rep := NewCapReport(hists, config)
+
+	tassert.Fatalf(t, len(rep.Targets) == 4, "expected 4 targets, got %d", len(rep.Targets))
+	order := make([]string, 0, 4)
+	for _, tp := range rep.Targets {
+		order = append(order, tp.TargetID)
+	}
+	// sorted by time to OOS, "never" last (and then by ID)
+	tassert.Errorf(t, strings.Join(order, ",") == "t-fast,t-slow,t-flat,t-new", "unexpected order: %v", order)
+	fast := rep.Targets[0]
+	tassert.Errorf(t, fast.ToOOS > 4*time.Hour && fast.ToOOS < 5*time.Hour, "unexpected t-fast forecast: %+v", fast.CapForecast)
+	tassert.Errorf(t, rep.Targets[2].ToOOS == EtaNever && rep.Targets[3].ToOOS == EtaNever, "expected never")
+
+	// cluster: sums
+	c := &rep.Cluster
+	tassert.Errorf(t, c.Total == 30*capGiB, "expected cluster total 30GiB, got %d", c.Total)
+	tassert.Errorf(t, c.GrowthBps > rep.Targets[0].GrowthBps, "cluster growth must include all targets")
+
+	// buckets: from the targets that have them (half of used)
+	tassert.Fatalf(t, len(rep.Buckets) == 1, "expected 1 bucket, got %d", len(rep.Buckets))
+	bp := rep.Buckets[0]
+	size := hists["t-fast"].Samples[12].Used/2 + hists["t-flat"].Samples[12].Used/2
+	tassert.Errorf(t, bp.Size == size, "expected bucket size %d, got %d", size, bp.Size)
+	tassert.Errorf(t, math.Abs(bp.GrowthBps-rep.Targets[0].GrowthBps/2) < 1, "unexpected bucket growth %f", bp.GrowthBps)
+
+	// advice: t-fast and t-slow (both within CapPlanHorizon), and the cluster
+	var oos, hwm int
+	for _, a := range rep.Advice {
+		switch {
+		case strings.Contains(a, "out of space"):
+			oos++
+		case strings.Contains(a, "high watermark"):
+			hwm++
+		}
+	}
+	tassert.Errorf(t, oos == 2, "expected two targets projected to run out of space, got %v", rep.Advice)
+	tassert.Errorf(t, hwm == 1, "expected cluster-level high watermark advice, got %v", rep.Advice)
+
+	// insufficient history
+	rep = NewCapReport(map[string]*CapHistory{"t-new": {}}, config)
+	tassert.Fatalf(t, len(rep.Advice) == 1 && strings.Contains(rep.Advice[0], "insufficient"), "unexpected advice: %v", rep.Advice)
+}
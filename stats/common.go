@@ -94,6 +94,28 @@ const (
 	ErrDownloadCount  = errPrefix + "dl.n"
 	ErrPutMirrorCount = errPrefix + "put.mirror.n"
 
+	// requests aborted upon exceeding client-supplied deadline (apc.HdrDeadline)
+	ErrDeadlineCount = errPrefix + "deadline.n"
+
+	// request shadowing (see cmn.ShadowConf)
+	ShadowCount        = "shadow.n"
+	ShadowDropCount    = "shadow.drop.n"    // not shadowed: too many in flight
+	ShadowDivergeCount = "shadow.diverge.n" // sampled shadow GETs that differ from production
+	ErrShadowCount     = errPrefix + ShadowCount
+
+	// workload capture (see cmn.CaptureConf)
+	CaptureCount     = "capture.n"
+	CaptureDropCount = "capture.drop.n" // not recorded: too many pending, or max trace size reached
+
+	// zstd-compressed intra-cluster control-plane payloads (see cmn.HTTPConf.CompressAbove)
+	CplaneZstdCount     = "cplane.zstd.n"
+	CplaneZstdSavedSize = "cplane.zstd.saved.size" // uncompressed minus compressed, times number of recipients
+
+	// log shipping (see cmn.LogShipConf)
+	LogShipCount     = "log.ship.n"      // shipped lines
+	LogShipDropCount = "log.ship.drop.n" // dropped lines (queue full)
+	ErrLogShipCount  = errPrefix + "log.ship.n"
+
 	// KindLatency
 	// latency stats have numSamples used to compute average latency
 	GetLatency         = "get.ns"
@@ -135,11 +157,16 @@ type (
 		ticker    *time.Ticker
 		core      *coreStats
 		ctracker  copyTracker // to avoid making it at runtime
+		hist      hist        // metrics history (see config.Periodic.StatsHistory)
+		top       top         // top-N objects and prefixes (see TopReport)
+		chist     capHist     // (target) capacity snapshots (see CapReport)
+		lhist     latHists    // per-bucket latency histograms (see config.Periodic.LatencyBuckets)
 		sorted    []string    // sorted names
 		name      string      // this stats-runner's name
 		prev      string      // prev ctracker.write
 		next      int64       // mono.Nano
 		mem       sys.MemStat
+		shipped   nlog.ShipStats // log shipping: last seen (to compute deltas)
 		startedUp atomic.Bool
 	}
 )
@@ -254,6 +281,74 @@ func (r *runner) regCommon(snode *meta.Snode) {
 			Help: "number of n-way mirroring errors",
 		},
 	)
+	r.reg(snode, ErrDeadlineCount, KindCounter,
+		&Extra{
+			Help: "number of requests aborted upon exceeding client-supplied deadline",
+		},
+	)
+
+	// request shadowing
+	r.reg(snode, ShadowCount, KindCounter,
+		&Extra{
+			Help: "number of requests duplicated to shadow cluster",
+		},
+	)
+	r.reg(snode, ShadowDropCount, KindCounter,
+		&Extra{
+			Help: "number of requests not duplicated to shadow cluster due to too many in flight",
+		},
+	)
+	r.reg(snode, ShadowDivergeCount, KindCounter,
+		&Extra{
+			Help: "number of sampled shadow GET responses that differ from production",
+		},
+	)
+	r.reg(snode, ErrShadowCount, KindCounter,
+		&Extra{
+			Help: "number of failed shadow requests",
+		},
+	)
+
+	// workload capture
+	r.reg(snode, CaptureCount, KindCounter,
+		&Extra{
+			Help: "number of requests recorded into workload trace",
+		},
+	)
+	r.reg(snode, CaptureDropCount, KindCounter,
+		&Extra{
+			Help: "number of sampled requests not recorded into workload trace (too many pending or max size reached)",
+		},
+	)
+
+	// control-plane compression
+	r.reg(snode, CplaneZstdCount, KindCounter,
+		&Extra{
+			Help: "number of zstd-compressed intra-cluster control-plane payloads (cluster metadata, dsort records)",
+		},
+	)
+	r.reg(snode, CplaneZstdSavedSize, KindSize,
+		&Extra{
+			Help: "total number of bytes saved by compressing intra-cluster control-plane payloads",
+		},
+	)
+
+	// log shipping
+	r.reg(snode, LogShipCount, KindCounter,
+		&Extra{
+			Help: "number of log lines shipped to the configured central endpoint (see log.ship)",
+		},
+	)
+	r.reg(snode, LogShipDropCount, KindCounter,
+		&Extra{
+			Help: "number of log lines dropped (not shipped) because the endpoint was too slow or unavailable",
+		},
+	)
+	r.reg(snode, ErrLogShipCount, KindCounter,
+		&Extra{
+			Help: "number of failed log shipping requests",
+		},
+	)
 
 	// basic latencies
 	r.reg(snode, GetLatency, KindLatency,
@@ -431,12 +526,15 @@ waitStartup:
 		startTime         = mono.NanoTime() // uptime henceforth
 		lastDateTimestamp = startTime       // RFC822
 	)
+	r.logShip(config)
 	for {
 		select {
 		case <-r.ticker.C:
 			now := mono.NanoTime()
 			config = cmn.GCO.Get()
 			logger.log(now, time.Duration(now-startTime) /*uptime*/, config)
+			r.hist.add(time.Now(), r.ctracker, config)
+			r.lhist.reconf(config)
 
 			// 1. "High number of"
 			lastNgr = r.checkNgr(now, lastNgr, goMaxProcs)
@@ -462,7 +560,10 @@ waitStartup:
 				lastDateTimestamp = now
 			}
 
-			// 4. kalive alert
+			// 4. log shipping
+			r.logShip(config)
+
+			// 5. kalive alert
 			n := r.Get(ErrKaliveCount)
 			if n != kaliveErrs {
 				// raise
@@ -484,6 +585,42 @@ waitStartup:
 
 func (r *runner) StartedUp() bool { return r.startedUp.Load() }
 
+// (re)configure log shipping upon config change; update the respective metrics
+func (r *runner) logShip(config *cmn.Config) {
+	var (
+		conf = &config.Log.Ship
+		cur  = nlog.Shipping()
+	)
+	if conf.URL == "" {
+		if cur != nil {
+			nlog.Ship(nil)
+		}
+	} else {
+		args := nlog.ShipArgs{
+			URL:       conf.URL,
+			Format:    conf.Format,
+			Node:      r.node.Snode().ID(),
+			BatchSize: conf.BatchSize,
+			FlushTime: conf.FlushTime.D(),
+			QueueSize: conf.QueueSize,
+		}
+		if cur == nil || *cur != args {
+			nlog.Ship(&args)
+		}
+	}
+	st := nlog.GetShipStats()
+	if d := st.Shipped - r.shipped.Shipped; d > 0 {
+		r.Add(LogShipCount, d)
+	}
+	if d := st.Dropped - r.shipped.Dropped; d > 0 {
+		r.Add(LogShipDropCount, d)
+	}
+	if d := st.Errors - r.shipped.Errors; d > 0 {
+		r.Add(ErrLogShipCount, d)
+	}
+	r.shipped = st
+}
+
 // - check OOM, and
 // - set NodeStateFlags with both capacity and memory flags
 func (r *runner) _mem(mm *memsys.MMSA, set, clr cos.NodeStateFlags) {
@@ -510,6 +647,15 @@ func (r *runner) GetStats() *Node {
 	return &Node{Tracker: ctracker}
 }
 
+func (r *runner) GetStatsHistory(since, until int64) *History { return r.hist.get(since, until) }
+
+func (r *runner) AddTop(bck *cmn.Bck, objName string, size int64) { r.top.add(bck, objName, size) }
+func (r *runner) GetTop(n int) *TopReport                         { return r.top.get(n) }
+
+func (r *runner) GetCapHistory() *CapHistory { return r.chist.get() }
+
+func (r *runner) AddLatHist(bck *cmn.Bck, op string, lat int64) { r.lhist.add(bck, op, lat) }
+
 func (r *runner) GetStatsV322() (out *NodeV322) {
 	ds := r.GetStats()
 
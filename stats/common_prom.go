@@ -40,6 +40,8 @@ type (
 		sgl       *memsys.SGL
 		statsTime time.Duration
 		cmu       sync.RWMutex // ctracker vs Prometheus Collect()
+
+		latDescs map[string]*prometheus.Desc // per-bucket latency histograms by op (see lathist.go)
 	}
 )
 
@@ -72,6 +74,16 @@ func (*coreStats) initStatsdOrProm(_ *meta.Snode, parent *runner) {
 	prometheus.MustRegister(parent) // as prometheus.Collector
 }
 
+// target only
+func (s *coreStats) initLatHist(snode *meta.Snode) {
+	s.latDescs = make(map[string]*prometheus.Desc, 2)
+	for _, op := range []string{LatHistGet, LatHistPut} {
+		fullqn := prometheus.BuildFQName("ais", snode.Type(), op+"_latency_seconds")
+		help := "per-bucket " + strings.ToUpper(op) + "(object) latency histogram (see config.Periodic.LatencyBuckets)"
+		s.latDescs[op] = prometheus.NewDesc(fullqn, help, []string{"bucket"}, dfltLabels)
+	}
+}
+
 // vs Collect()
 func (s *coreStats) promRLock()   { s.cmu.RLock() }
 func (s *coreStats) promRUnlock() { s.cmu.RUnlock() }
@@ -278,6 +290,9 @@ func (r *runner) Describe(ch chan<- *prometheus.Desc) {
 	for _, desc := range r.core.promDesc {
 		ch <- desc
 	}
+	for _, desc := range r.core.latDescs {
+		ch <- desc
+	}
 }
 
 func (r *runner) Collect(ch chan<- prometheus.Metric) {
@@ -329,6 +344,13 @@ func (r *runner) Collect(ch chan<- prometheus.Metric) {
 		ch <- m
 	}
 	r.core.promRUnlock()
+
+	// latency histograms
+	for _, s := range r.lhist.snap() {
+		m, err := prometheus.NewConstHistogram(r.core.latDescs[s.op], s.count, s.sum, s.buckets, s.bck)
+		debug.AssertNoErr(err)
+		ch <- m
+	}
 }
 
 func (r *runner) Stop(err error) {
@@ -80,11 +80,14 @@ func (s *coreStats) statsdDisabled() bool { return s.statsdC == nil }
 func (*coreStats) promLock()   {}
 func (*coreStats) promUnlock() {}
 
+func (*coreStats) initLatHist(*meta.Snode) {} // (Prometheus only)
+
 // init StatsD (not Prometheus)
 func (s *coreStats) initStatsdOrProm(snode *meta.Snode, _ *runner) {
 	var (
-		port  = 8125  // StatsD default port, see https://github.com/etsy/stats
-		probe = false // test-probe StatsD server at init time
+		port   = 8125  // StatsD default port, see https://github.com/etsy/stats
+		probe  = false // test-probe StatsD server at init time
+		format = statsdFormat()
 	)
 	if portStr := os.Getenv("AIS_STATSD_PORT"); portStr != "" {
 		if portNum, err := cmn.ParsePort(portStr); err != nil {
@@ -101,16 +104,39 @@ func (s *coreStats) initStatsdOrProm(snode *meta.Snode, _ *runner) {
 			probe = probeBool
 		}
 	}
-	id := strings.ReplaceAll(snode.ID(), ":", "_") // ":" delineates name and value for StatsD
-	statsD, err := statsd.New("localhost", port, "ais"+snode.Type()+"."+id, probe)
+	if _, err := statsd.ParseFormat(os.Getenv("AIS_STATSD_FORMAT")); err != nil {
+		nlog.Errorln(err)
+	}
+	statsD, err := statsd.New("localhost", port, statsdPrefix(snode), probe)
 	if err != nil {
 		nlog.Errorf("Starting up without StatsD: %v", err)
 	} else {
 		nlog.Infoln("Using StatsD")
 	}
+	if format != statsd.Plain {
+		statsD.SetTagged(&statsd.Tagged{
+			Format: format,
+			Tags:   []statsd.Tag{{Name: "node", Value: snode.ID()}, {Name: "role", Value: snode.Type()}},
+		})
+	}
 	s.statsdC = statsD
 }
 
+// AIS_STATSD_FORMAT: "plain" (default), "dogstatsd", or "influx" (see statsd.ParseFormat)
+func statsdFormat() statsd.Format {
+	format, _ := statsd.ParseFormat(os.Getenv("AIS_STATSD_FORMAT"))
+	return format
+}
+
+// tagged formats: node ID is a tag (rather than part of the metric name)
+func statsdPrefix(snode *meta.Snode) string {
+	prefix := "ais" + snode.Type()
+	if statsdFormat() == statsd.Plain {
+		prefix += "." + strings.ReplaceAll(snode.ID(), ":", "_") // ":" delineates name and value for StatsD
+	}
+	return prefix
+}
+
 func (s *coreStats) updateUptime(d time.Duration) {
 	v := s.Tracker[Uptime]
 	ratomic.StoreInt64(&v.Value, d.Nanoseconds())
@@ -297,9 +323,12 @@ func (s *coreStats) reset(errorsOnly bool) {
 // naming convention: ".n" for the count and ".ns" for duration (nanoseconds)
 // compare with coreStats.initProm()
 func (r *runner) reg(snode *meta.Snode, name, kind string, _ *Extra) {
-	v := &statsValue{kind: kind}
+	var (
+		v      = &statsValue{kind: kind}
+		prefix = statsdPrefix(snode)
+	)
 	f := func(units string) string {
-		return fmt.Sprintf("%s.%s.%s.%s", "ais"+snode.Type(), snode.ID(), v.label.comm, units)
+		return fmt.Sprintf("%s.%s.%s", prefix, v.label.comm, units)
 	}
 	debug.Assert(!strings.Contains(name, ":"), name)
 	switch kind {
@@ -331,7 +360,7 @@ func (r *runner) reg(snode *meta.Snode, name, kind string, _ *Extra) {
 			v.label.comm = "uptime"
 			v.label.stpr = f("seconds")
 		} else {
-			v.label.stpr = fmt.Sprintf("%s.%s.%s", "ais"+snode.Type(), snode.ID(), v.label.comm)
+			v.label.stpr = fmt.Sprintf("%s.%s", prefix, v.label.comm)
 		}
 	}
 	r.core.Tracker[name] = v
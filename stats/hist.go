@@ -0,0 +1,119 @@
+// Package stats provides methods and functionality to register, track, log,
+// and StatsD-notify statistics that, for the most part, include "counter" and "latency" kinds.
+/*
+ * Copyright (c) 2018-2024, NVIDIA CORPORATION. All rights reserved.
+ */
+package stats
+
+import (
+	"sync"
+	"time"
+
+	"github.com/NVIDIA/aistore/cmn"
+)
+
+// On-node metrics history: fixed-size ring buffer of periodic (stats_time resolution) samples
+// retained for `config.Periodic.StatsHistory`. To keep the footprint small, each sample
+// stores values indexed by metric name (see `hist.names`) - the latter only grows
+// (e.g., when new disks get registered) so that older samples simply have fewer values.
+
+type (
+	// REST API
+	HistPoint struct {
+		Time    int64            `json:"t,string"` // Unix time (nanoseconds)
+		Tracker map[string]int64 `json:"tracker"`  // non-zero values only (compare w/ copyTracker)
+	}
+	History struct {
+		Points   []HistPoint   `json:"points"`
+		Interval time.Duration `json:"interval"` // resolution (config.Periodic.StatsTime)
+	}
+
+	histSample struct {
+		vals []int64
+		time int64
+	}
+	hist struct {
+		index    map[string]int // name => position in histSample.vals
+		names    []string
+		ring     []histSample
+		interval time.Duration
+		head     int // next to write
+		size     int // num valid samples
+		mu       sync.RWMutex
+	}
+)
+
+func (h *hist) add(now time.Time, ctracker copyTracker, config *cmn.Config) {
+	retention, interval := config.Periodic.StatsHistory.D(), config.Periodic.StatsTime.D()
+	if retention == 0 || interval == 0 {
+		if h.ring != nil {
+			h.mu.Lock()
+			h.ring, h.size, h.head = nil, 0, 0
+			h.mu.Unlock()
+		}
+		return
+	}
+
+	h.mu.Lock()
+	if capacity := int(retention / interval); capacity != len(h.ring) || interval != h.interval {
+		h.resize(max(capacity, 1))
+		h.interval = interval
+	}
+	if h.index == nil {
+		h.index = make(map[string]int, len(ctracker))
+	}
+	for name := range ctracker {
+		if _, ok := h.index[name]; !ok {
+			h.index[name] = len(h.names)
+			h.names = append(h.names, name)
+		}
+	}
+
+	sample := &h.ring[h.head]
+	if cap(sample.vals) < len(h.names) {
+		sample.vals = make([]int64, len(h.names))
+	} else {
+		sample.vals = sample.vals[:len(h.names)]
+		clear(sample.vals)
+	}
+	for name, v := range ctracker {
+		sample.vals[h.index[name]] = v.Value
+	}
+	sample.time = now.UnixNano()
+
+	h.head = (h.head + 1) % len(h.ring)
+	h.size = min(h.size+1, len(h.ring))
+	h.mu.Unlock()
+}
+
+// (under lock) keep the most recent samples
+func (h *hist) resize(capacity int) {
+	ring := make([]histSample, capacity)
+	n := min(h.size, capacity)
+	for i := range n {
+		j := (h.head - n + i + len(h.ring)) % len(h.ring)
+		ring[i] = h.ring[j]
+	}
+	h.ring, h.size, h.head = ring, n, n%capacity
+}
+
+// [since, until] inclusive; zero `until` means "now"
+func (h *hist) get(since, until int64) *History {
+	h.mu.RLock()
+	out := &History{Interval: h.interval, Points: make([]HistPoint, 0, 16)}
+	for i := range h.size {
+		sample := &h.ring[(h.head-h.size+i+len(h.ring))%len(h.ring)]
+		if sample.time < since || (until != 0 && sample.time > until) {
+			continue
+		}
+		point := HistPoint{Time: sample.time, Tracker: make(map[string]int64, len(sample.vals))}
+		for j, val := range sample.vals {
+			if val != 0 {
+				point.Tracker[h.names[j]] = val
+			}
+		}
+		out.Points = append(out.Points, point)
+	}
+	h.mu.RUnlock()
+	return out
+}
@@ -0,0 +1,153 @@
+// Package stats provides methods and functionality to register, track, log,
+// and StatsD-notify statistics that, for the most part, include "counter" and "latency" kinds.
+/*
+ * Copyright (c) 2024, NVIDIA CORPORATION. All rights reserved.
+ */
+package stats
+
+import (
+	"testing"
+	"time"
+
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/cmn/cos"
+	"github.com/NVIDIA/aistore/tools/tassert"
+)
+
+var histT0 = time.Unix(1000, 0)
+
+func histConfig(retention, ival time.Duration) *cmn.Config {
+	config := &cmn.Config{}
+	config.Periodic.StatsHistory = cos.Duration(retention)
+	config.Periodic.StatsTime = cos.Duration(ival)
+	return config
+}
+
+// i-th sample: taken at histT0 + i*ival, "get.n" = i+1, and "disk.n" = i (that is,
+// zero and omitted in the very first sample) - the latter registered at `late`
+func histAdd(h *hist, from, to, late int, config *cmn.Config) {
+	ival := config.Periodic.StatsTime.D()
+	for i := from; i < to; i++ {
+		ctracker := copyTracker{"get.n": copyValue{int64(i + 1)}}
+		if i >= late {
+			ctracker["disk.n"] = copyValue{int64(i)}
+		}
+		h.add(histT0.Add(time.Duration(i)*ival), ctracker, config)
+	}
+}
+
+// expecting consecutive samples [first, first+n)
+func histCheck(t *testing.T, name string, out *History, first, n, late int, ival time.Duration) {
+	t.Helper()
+	tassert.Fatalf(t, len(out.Points) == n, "%s: expected %d points, got %d", name, n, len(out.Points))
+	for k, point := range out.Points {
+		i := first + k
+		tm := histT0.Add(time.Duration(i) * ival).UnixNano()
+		tassert.Errorf(t, point.Time == tm, "%s: point %d: expected time %d, got %d", name, k, tm, point.Time)
+		tassert.Errorf(t, point.Tracker["get.n"] == int64(i+1), "%s: point %d: expected get.n=%d, got %d",
+			name, k, i+1, point.Tracker["get.n"])
+		_, ok := point.Tracker["disk.n"]
+		tassert.Errorf(t, ok == (i >= late && i != 0), "%s: point %d: unexpected disk.n presence (%t)", name, k, ok)
+	}
+}
+
+func TestHistAdd(t *testing.T) {
+	tests := []struct {
+		name      string
+		retention time.Duration
+		ival      time.Duration
+		n         int // samples to add
+		late      int // when "disk.n" gets registered
+		first     int // oldest retained sample
+		size      int
+	}{
+		{name: "empty", retention: 10 * time.Second, ival: time.Second, n: 0, size: 0},
+		{name: "partial", retention: 10 * time.Second, ival: time.Second, n: 3, size: 3},
+		{name: "full", retention: 10 * time.Second, ival: time.Second, n: 10, size: 10},
+		{name: "wraparound", retention: 10 * time.Second, ival: time.Second, n: 25, first: 15, size: 10},
+		{name: "new-metric", retention: 10 * time.Second, ival: time.Second, n: 14, late: 8, first: 4, size: 10},
+		{name: "min-capacity", retention: time.Second, ival: 10 * time.Second, n: 5, first: 4, size: 1},
+		{name: "disabled", retention: 0, ival: time.Second, n: 5, size: 0},
+		{name: "no-interval", retention: 10 * time.Second, ival: 0, n: 5, size: 0},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var (
+				h      hist
+				config = histConfig(test.retention, test.ival)
+			)
+			histAdd(&h, 0, test.n, test.late, config)
+			out := h.get(0, 0)
+			tassert.Errorf(t, out.Interval == h.interval, "expected interval %v, got %v", h.interval, out.Interval)
+			histCheck(t, test.name, out, test.first, test.size, test.late, test.ival)
+		})
+	}
+}
+
+func TestHistResize(t *testing.T) {
+	tests := []struct {
+		name      string
+		n         int // samples added prior to resizing
+		retention time.Duration
+		ival      time.Duration
+		first     int // oldest retained sample once the next one gets added
+		size      int
+	}{
+		{name: "shrink", n: 10, retention: 4 * time.Second, ival: time.Second, first: 7, size: 4},
+		{name: "shrink-wrapped", n: 17, retention: 3 * time.Second, ival: time.Second, first: 15, size: 3},
+		{name: "grow", n: 10, retention: 20 * time.Second, ival: time.Second, first: 0, size: 11},
+		{name: "grow-wrapped", n: 17, retention: 20 * time.Second, ival: time.Second, first: 7, size: 11},
+		{name: "same-capacity", n: 5, retention: 20 * time.Second, ival: 2 * time.Second, first: 0, size: 6},
+		{name: "interval", n: 10, retention: 10 * time.Second, ival: 2 * time.Second, first: 6, size: 5},
+		{name: "disable", n: 10, retention: 0, ival: time.Second, size: 0},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var h hist
+			histAdd(&h, 0, test.n, 0, histConfig(10*time.Second, time.Second))
+
+			// next sample at the new resolution (timing-wise, continuing the previous one)
+			config := histConfig(test.retention, test.ival)
+			tm := histT0.Add(time.Duration(test.n) * time.Second)
+			h.add(tm, copyTracker{"get.n": copyValue{int64(test.n + 1)}, "disk.n": copyValue{int64(test.n)}}, config)
+
+			out := h.get(0, 0)
+			tassert.Errorf(t, out.Interval == test.ival, "expected interval %v, got %v", test.ival, out.Interval)
+			tassert.Errorf(t, (h.ring == nil) == (test.retention == 0), "unexpected ring size %d", len(h.ring))
+			histCheck(t, test.name, out, test.first, test.size, 0, time.Second)
+		})
+	}
+}
+
+func TestHistGet(t *testing.T) {
+	const n = 10
+	var (
+		h      hist
+		ival   = time.Second
+		config = histConfig(n*ival, ival)
+		at     = func(i int) int64 { return histT0.Add(time.Duration(i) * ival).UnixNano() }
+	)
+	histAdd(&h, 0, n+5, 3, config) // retaining [5, 15)
+
+	tests := []struct {
+		name         string
+		since, until int64
+		first, size  int
+	}{
+		{name: "all", first: 5, size: 10},
+		{name: "since", since: at(12), first: 12, size: 3},
+		{name: "since-between", since: at(12) + 1, first: 13, size: 2},
+		{name: "until", until: at(7), first: 5, size: 3},
+		{name: "until-between", until: at(7) - 1, first: 5, size: 2},
+		{name: "range", since: at(8), until: at(10), first: 8, size: 3},
+		{name: "single", since: at(9), until: at(9), first: 9, size: 1},
+		{name: "evicted", since: at(0), until: at(4), size: 0},
+		{name: "future", since: at(20), size: 0},
+		{name: "inverted", since: at(10), until: at(8), size: 0},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			histCheck(t, test.name, h.get(test.since, test.until), test.first, test.size, 3, ival)
+		})
+	}
+}
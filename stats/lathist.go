@@ -0,0 +1,190 @@
+// Package stats provides methods and functionality to register, track, log,
+// and StatsD-notify statistics that, for the most part, include "counter" and "latency" kinds.
+/*
+ * Copyright (c) 2024, NVIDIA CORPORATION. All rights reserved.
+ */
+package stats
+
+import (
+	"sort"
+	"sync"
+	ratomic "sync/atomic"
+	"time"
+
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/cmn/atomic"
+	"github.com/NVIDIA/aistore/cmn/cos"
+	"github.com/NVIDIA/aistore/cmn/nlog"
+	"github.com/OneOfOne/xxhash"
+)
+
+// Per-bucket GET and PUT latency histograms (target only, Prometheus only):
+// - buckets (upper bounds) are configured via `config.Periodic.LatencyBuckets` - empty: disabled;
+// - reconfiguring the buckets resets all histograms;
+// - to bound cardinality, buckets beyond the first `latHistMaxBcks` are accounted under
+//   the `LatHistOther` label;
+// - to keep GET and PUT from contending on a single lock, histograms are sharded by bucket;
+//   reconfiguration takes all shards' locks (so that bounds can be read under any one of them).
+
+// enum: latency histogram ops
+const (
+	LatHistGet = "get"
+	LatHistPut = "put"
+)
+
+const (
+	LatHistOther   = "_other" // (bucket label)
+	latHistMaxBcks = 1024
+	latHistShards  = 16
+)
+
+type (
+	latHist struct {
+		counts []int64 // non-cumulative; the last one is +Inf
+		sum    int64   // nanoseconds
+	}
+	bckLatHist struct {
+		get, put latHist
+	}
+	latHistShard struct {
+		m  map[string]*bckLatHist // by bucket (cname)
+		mu sync.RWMutex
+	}
+	latHists struct {
+		shards  [latHistShards]latHistShard
+		bounds  []time.Duration // (under all shards' locks)
+		conf    string          // (current config.Periodic.LatencyBuckets)
+		num     atomic.Int32    // total number of buckets
+		mu      sync.Mutex      // reconf
+		enabled bool            // target only
+	}
+
+	// (collect)
+	latHistSnap struct {
+		bck     string
+		op      string
+		buckets map[float64]uint64 // cumulative, by upper bound in seconds
+		count   uint64
+		sum     float64 // seconds
+	}
+)
+
+func (h *latHist) init(n int) { h.counts = make([]int64, n+1) }
+
+func (lh *latHists) reconf(config *cmn.Config) {
+	if !lh.enabled {
+		return
+	}
+	lh.mu.Lock()
+	defer lh.mu.Unlock()
+	if lh.conf == config.Periodic.LatencyBuckets {
+		return
+	}
+	bounds, err := cmn.ParseLatencyBuckets(config.Periodic.LatencyBuckets)
+	if err != nil {
+		nlog.Errorln("latency histograms:", err) // (unlikely - validated)
+		return
+	}
+	for i := range lh.shards {
+		lh.shards[i].mu.Lock()
+	}
+	lh.conf, lh.bounds = config.Periodic.LatencyBuckets, bounds
+	for i := range lh.shards {
+		sh := &lh.shards[i]
+		sh.m = nil
+		if len(bounds) > 0 {
+			sh.m = make(map[string]*bckLatHist, 4)
+		}
+	}
+	lh.num.Store(0)
+	for i := range lh.shards {
+		lh.shards[i].mu.Unlock()
+	}
+}
+
+func (lh *latHists) shard(cname string) *latHistShard {
+	return &lh.shards[xxhash.Checksum64S(cos.UnsafeB(cname), cos.MLCG32)%latHistShards]
+}
+
+func (lh *latHists) add(bck *cmn.Bck, op string, lat int64) {
+	var (
+		cname = bck.Cname("")
+		sh    = lh.shard(cname)
+	)
+	sh.mu.RLock()
+	if sh.m == nil {
+		sh.mu.RUnlock()
+		return
+	}
+	bh, ok := sh.m[cname]
+	if !ok {
+		sh.mu.RUnlock()
+		if bh = lh.newBck(cname, sh); bh == nil {
+			return
+		}
+		// (note: may now be in a different shard - bounds are the same)
+		sh.mu.RLock()
+	}
+	h := &bh.get
+	if op == LatHistPut {
+		h = &bh.put
+	}
+	i := sort.Search(len(lh.bounds), func(i int) bool { return int64(lh.bounds[i]) >= lat })
+	if i < len(h.counts) { // (in case buckets got reconfigured in between)
+		ratomic.AddInt64(&h.counts[i], 1)
+		ratomic.AddInt64(&h.sum, lat)
+	}
+	sh.mu.RUnlock()
+}
+
+func (lh *latHists) newBck(cname string, sh *latHistShard) *bckLatHist {
+	if lh.num.Load() >= latHistMaxBcks {
+		cname, sh = LatHistOther, lh.shard(LatHistOther)
+	}
+	sh.mu.Lock()
+	defer sh.mu.Unlock()
+	if sh.m == nil {
+		return nil // (disabled in between)
+	}
+	if bh, ok := sh.m[cname]; ok {
+		return bh
+	}
+	bh := &bckLatHist{}
+	bh.get.init(len(lh.bounds))
+	bh.put.init(len(lh.bounds))
+	sh.m[cname] = bh
+	lh.num.Inc()
+	return bh
+}
+
+func (lh *latHists) snap() (out []latHistSnap) {
+	for i := range lh.shards {
+		sh := &lh.shards[i]
+		sh.mu.RLock()
+		for cname, bh := range sh.m {
+			if s, ok := bh.get.snap(cname, LatHistGet, lh.bounds); ok {
+				out = append(out, s)
+			}
+			if s, ok := bh.put.snap(cname, LatHistPut, lh.bounds); ok {
+				out = append(out, s)
+			}
+		}
+		sh.mu.RUnlock()
+	}
+	return out
+}
+func (h *latHist) snap(cname, op string, bounds []time.Duration) (s latHistSnap, ok bool) {
+	var cnt uint64
+	s.buckets = make(map[float64]uint64, len(bounds))
+	for i, b := range bounds {
+		cnt += uint64(ratomic.LoadInt64(&h.counts[i]))
+		s.buckets[b.Seconds()] = cnt
+	}
+	cnt += uint64(ratomic.LoadInt64(&h.counts[len(bounds)]))
+	if cnt == 0 {
+		return s, false
+	}
+	s.bck, s.op, s.count = cname, op, cnt
+	s.sum = time.Duration(ratomic.LoadInt64(&h.sum)).Seconds()
+	return s, true
+}
@@ -0,0 +1,167 @@
+// Package stats provides methods and functionality to register, track, log,
+// and StatsD-notify statistics that, for the most part, include "counter" and "latency" kinds.
+/*
+ * Copyright (c) 2024, NVIDIA CORPORATION. All rights reserved.
+ */
+package stats
+
+import (
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/NVIDIA/aistore/api/apc"
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/tools/tassert"
+)
+
+func newLatHists(t *testing.T, buckets string) *latHists {
+	lh := &latHists{enabled: true}
+	lh.reconf(latHistConfig(buckets))
+	tassert.Fatalf(t, lh.conf == buckets, "expected %q, got %q", buckets, lh.conf)
+	return lh
+}
+
+func latHistConfig(buckets string) *cmn.Config {
+	config := &cmn.Config{}
+	config.Periodic.LatencyBuckets = buckets
+	return config
+}
+
+func latHistFind(snaps []latHistSnap, cname, op string) (latHistSnap, bool) {
+	for _, s := range snaps {
+		if s.bck == cname && s.op == op {
+			return s, true
+		}
+	}
+	return latHistSnap{}, false
+}
+
+func TestLatHistPlacement(t *testing.T) {
+	var (
+		lh  = newLatHists(t, "1ms,10ms,100ms")
+		bck = cmn.Bck{Name: "lat", Provider: apc.AIS, Ns: cmn.NsGlobal}
+	)
+	for _, lat := range []time.Duration{time.Microsecond, time.Millisecond, 5 * time.Millisecond, 50 * time.Millisecond, time.Second} {
+		lh.add(&bck, LatHistGet, int64(lat))
+	}
+	lh.add(&bck, LatHistPut, int64(2*time.Millisecond))
+
+	snaps := lh.snap()
+	tassert.Fatalf(t, len(snaps) == 2, "expected get and put, got %d", len(snaps))
+
+	get, ok := latHistFind(snaps, bck.Cname(""), LatHistGet)
+	tassert.Fatalf(t, ok, "missing %s %s", bck.Cname(""), LatHistGet)
+	// cumulative; upper bounds are inclusive
+	expected := map[float64]uint64{0.001: 2, 0.01: 3, 0.1: 4}
+	for le, cnt := range expected {
+		tassert.Errorf(t, get.buckets[le] == cnt, "le=%v: expected %d, got %d", le, cnt, get.buckets[le])
+	}
+	tassert.Errorf(t, get.count == 5, "expected count 5 (including +Inf), got %d", get.count)
+	sum := (time.Microsecond + time.Millisecond + 5*time.Millisecond + 50*time.Millisecond + time.Second).Seconds()
+	tassert.Errorf(t, get.sum == sum, "expected sum %v, got %v", sum, get.sum)
+
+	put, ok := latHistFind(snaps, bck.Cname(""), LatHistPut)
+	tassert.Fatalf(t, ok, "missing %s %s", bck.Cname(""), LatHistPut)
+	tassert.Errorf(t, put.count == 1 && put.buckets[0.001] == 0 && put.buckets[0.01] == 1, "unexpected put: %+v", put)
+}
+
+func TestLatHistOther(t *testing.T) {
+	lh := newLatHists(t, "10ms")
+	for i := range latHistMaxBcks + 10 {
+		bck := cmn.Bck{Name: "b" + strconv.Itoa(i), Provider: apc.AIS, Ns: cmn.NsGlobal}
+		lh.add(&bck, LatHistGet, int64(time.Millisecond))
+	}
+	snaps := lh.snap()
+	tassert.Fatalf(t, len(snaps) == latHistMaxBcks+1, "expected %d, got %d", latHistMaxBcks+1, len(snaps))
+	other, ok := latHistFind(snaps, LatHistOther, LatHistGet)
+	tassert.Fatalf(t, ok, "missing %q", LatHistOther)
+	tassert.Errorf(t, other.count == 10, "expected 10 under %q, got %d", LatHistOther, other.count)
+
+	// already tracked buckets remain tracked
+	bck := cmn.Bck{Name: "b0", Provider: apc.AIS, Ns: cmn.NsGlobal}
+	lh.add(&bck, LatHistGet, int64(time.Millisecond))
+	s, _ := latHistFind(lh.snap(), bck.Cname(""), LatHistGet)
+	tassert.Errorf(t, s.count == 2, "expected 2, got %d", s.count)
+}
+
+func TestLatHistReconf(t *testing.T) {
+	var (
+		lh  = newLatHists(t, "1ms,10ms")
+		bck = cmn.Bck{Name: "lat", Provider: apc.AIS, Ns: cmn.NsGlobal}
+	)
+	lh.add(&bck, LatHistGet, int64(5*time.Millisecond))
+
+	// same config: no reset
+	lh.reconf(latHistConfig("1ms,10ms"))
+	tassert.Fatalf(t, len(lh.snap()) == 1, "expected no reset")
+
+	// different buckets: reset
+	lh.reconf(latHistConfig("2ms,20ms,200ms"))
+	tassert.Fatalf(t, len(lh.snap()) == 0, "expected reset")
+	lh.add(&bck, LatHistGet, int64(5*time.Millisecond))
+	snaps := lh.snap()
+	tassert.Fatalf(t, len(snaps) == 1, "expected 1, got %d", len(snaps))
+	tassert.Errorf(t, len(snaps[0].buckets) == 3 && snaps[0].buckets[0.02] == 1 && snaps[0].buckets[0.002] == 0,
+		"unexpected buckets: %+v", snaps[0].buckets)
+
+	// disabled
+	lh.reconf(latHistConfig(""))
+	lh.add(&bck, LatHistGet, int64(5*time.Millisecond))
+	tassert.Errorf(t, len(lh.snap()) == 0, "expected disabled")
+
+	// not a target
+	lh = &latHists{}
+	lh.reconf(latHistConfig("1ms"))
+	lh.add(&bck, LatHistGet, int64(time.Millisecond))
+	tassert.Errorf(t, len(lh.snap()) == 0, "expected disabled")
+}
+
+// all adds get accounted for across shards, with concurrent reconfiguration (run with -race)
+func TestLatHistConcurrent(t *testing.T) {
+	const (
+		numWorkers = 16
+		numAdds    = 1000
+		numBcks    = 40
+	)
+	var (
+		lh = newLatHists(t, "1ms,10ms")
+		wg sync.WaitGroup
+	)
+	for w := range numWorkers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range numAdds {
+				bck := cmn.Bck{Name: "b" + strconv.Itoa((w+i)%numBcks), Provider: apc.AIS, Ns: cmn.NsGlobal}
+				lh.add(&bck, LatHistGet, int64(i)*int64(time.Microsecond))
+			}
+		}()
+	}
+	wg.Wait()
+	var total uint64
+	snaps := lh.snap()
+	for _, s := range snaps {
+		total += s.count
+	}
+	tassert.Errorf(t, len(snaps) == numBcks, "expected %d buckets, got %d", numBcks, len(snaps))
+	tassert.Errorf(t, total == numWorkers*numAdds, "expected %d, got %d", numWorkers*numAdds, total)
+
+	// reconfigure while adding
+	for w := range numWorkers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range numAdds {
+				bck := cmn.Bck{Name: "b" + strconv.Itoa((w+i)%numBcks), Provider: apc.AIS, Ns: cmn.NsGlobal}
+				lh.add(&bck, LatHistPut, int64(i)*int64(time.Microsecond))
+			}
+		}()
+	}
+	for i := range 10 {
+		lh.reconf(latHistConfig(strconv.Itoa(i+1) + "ms"))
+		lh.snap()
+	}
+	wg.Wait()
+}
@@ -1,6 +1,6 @@
 // Package statsd provides a client to send basic statd metrics (timer, counter and gauge) to listening UDP StatsD server.
 /*
- * Copyright (c) 2018-2023, NVIDIA CORPORATION. All rights reserved.
+ * Copyright (c) 2018-2024, NVIDIA CORPORATION. All rights reserved.
  */
 package statsd
 
@@ -29,6 +29,18 @@ const (
 	PersistentCounter
 )
 
+// Format is the StatsD wire format
+type Format int
+
+const (
+	// Plain is the original (untagged) StatsD format: "<prefix>.<bucket>.<name>:<value>|<type>"
+	Plain Format = iota
+	// DogStatsD extension: "<prefix>.<name>:<value>|<type>|#<tag>:<value>,..."
+	DogStatsD
+	// Influx (Telegraf) line protocol tags: "<prefix>.<name>,<tag>=<value>,...:<value>|<type>"
+	Influx
+)
+
 const (
 	numErrsLog    = 100 // log one every so many
 	numTestProbes = 10  // num UDP probes at startup (optional)
@@ -37,10 +49,26 @@ const (
 type (
 	// Client implements a StatsD client
 	Client struct {
-		conn   *net.UDPConn
-		server *net.UDPAddr // resolved StatsD server addr
-		prefix string       // e.g. aistarget<ID>
-		opened bool         // true if the connection with StatsD is successfully opened
+		conn      *net.UDPConn
+		server    *net.UDPAddr // resolved StatsD server addr
+		prefix    string       // e.g. aistarget<ID>
+		bucketTag string       // (see Tagged.BucketTag)
+		tags      string       // constant tags, preformatted
+		format    Format
+		opened    bool // true if the connection with StatsD is successfully opened
+	}
+
+	// Tag is a name-value pair that gets attached to metrics (tagged formats only)
+	Tag struct {
+		Name  string
+		Value string
+	}
+
+	// Tagged configures tagged metrics (see SetTagged)
+	Tagged struct {
+		Tags      []Tag  // constant tags added to every metric, e.g. node ID or loader ID
+		BucketTag string // when non-empty, StatsD "bucket" (see Send) becomes a tag by that name (rather than part of the metric name)
+		Format    Format
 	}
 
 	// Metric is a generic structure for all type of StatsD metrics
@@ -54,6 +82,9 @@ type (
 var (
 	smm           *memsys.MMSA
 	errcnt, msize int64
+
+	// characters that have special meaning in (any of) the supported formats
+	tagSanitizer = strings.NewReplacer(":", "_", ",", "_", "|", "_", "=", "_", "#", "_", " ", "_", "@", "_")
 )
 
 // New returns a UDP client that we then use to send metrics to the specified IP:port
@@ -70,7 +101,7 @@ func New(ip string, port int, prefix string, probe bool) (*Client, error) {
 		return &Client{}, err
 	}
 	smm = memsys.ByteMM()
-	client := &Client{conn, server, prefix, true /*opened*/}
+	client := &Client{conn: conn, server: server, prefix: prefix, opened: true}
 	if !probe {
 		return client, nil
 	}
@@ -81,6 +112,48 @@ func New(ip string, port int, prefix string, probe bool) (*Client, error) {
 	return client, nil
 }
 
+// ParseFormat parses StatsD format name: "plain" (or empty), "dogstatsd", or "influx"
+func ParseFormat(s string) (Format, error) {
+	switch strings.ToLower(s) {
+	case "", "plain":
+		return Plain, nil
+	case "dogstatsd", "datadog":
+		return DogStatsD, nil
+	case "influx", "influxdb", "telegraf":
+		return Influx, nil
+	}
+	return Plain, fmt.Errorf("invalid StatsD format %q (expecting one of: plain, dogstatsd, influx)", s)
+}
+
+// SetTagged switches the client to tagged metrics; must be called prior to sending.
+// With tags, the prefix is expected to be generic (e.g. "aistarget") - instance-specific
+// IDs go into tags to avoid metric-name explosion.
+func (c *Client) SetTagged(tagged *Tagged) {
+	c.format = tagged.Format
+	if c.format == Plain {
+		return
+	}
+	c.bucketTag = tagged.BucketTag
+	c.tags = c.fmtTags(tagged.Tags)
+}
+
+// DogStatsD: "name:value,..."; Influx: ",name=value,..."
+func (c *Client) fmtTags(tags []Tag) string {
+	var sb strings.Builder
+	for i, tag := range tags {
+		name, value := tagSanitizer.Replace(tag.Name), tagSanitizer.Replace(tag.Value)
+		if c.format == Influx {
+			sb.WriteString("," + name + "=" + value)
+			continue
+		}
+		if i > 0 {
+			sb.WriteByte(',')
+		}
+		sb.WriteString(name + ":" + value)
+	}
+	return sb.String()
+}
+
 // NOTE: a single failed probe disables StatsD for the entire runtime
 func (c *Client) probeUDP() (err error) {
 	var (
@@ -187,7 +260,17 @@ func (c Client) AppMetric(m Metric, sgl *memsys.SGL) {
 	default:
 		debug.Assertf(false, "unknown type %+v", m.Type)
 	}
-	_, err = fmt.Fprintf(sgl, "%s:%s%v|%s", m.Name /*slabel*/, prefix, m.Value, t)
+	switch c.format {
+	case DogStatsD:
+		_, err = fmt.Fprintf(sgl, "%s:%s%v|%s", m.Name /*slabel*/, prefix, m.Value, t)
+		if err == nil && c.tags != "" {
+			_, err = fmt.Fprintf(sgl, "|#%s", c.tags)
+		}
+	case Influx:
+		_, err = fmt.Fprintf(sgl, "%s%s:%s%v|%s", m.Name, c.tags, prefix, m.Value, t)
+	default:
+		_, err = fmt.Fprintf(sgl, "%s:%s%v|%s", m.Name /*slabel*/, prefix, m.Value, t)
+	}
 	debug.AssertNoErr(err)
 }
 
@@ -212,6 +295,10 @@ func (c Client) appendM(m Metric, sgl *memsys.SGL, bucket string, aggCnt int64)
 	if sgl.Len() > 0 {
 		sgl.WriteByte('\n')
 	}
+	if c.format != Plain {
+		c.appendTagged(m, sgl, bucket, aggCnt, prefix, t)
+		return
+	}
 	if aggCnt != 1 {
 		_, err = fmt.Fprintf(sgl, "%s.%s.%s:%s%v|%s|@%f",
 			c.prefix, bucket, m.Name, prefix, m.Value, t, float64(1)/float64(aggCnt))
@@ -220,3 +307,32 @@ func (c Client) appendM(m Metric, sgl *memsys.SGL, bucket string, aggCnt int64)
 	}
 	debug.AssertNoErr(err)
 }
+
+func (c Client) appendTagged(m Metric, sgl *memsys.SGL, bucket string, aggCnt int64, prefix, t string) {
+	var (
+		name = c.prefix + "." + bucket + "." + m.Name
+		tags = c.tags
+	)
+	if c.bucketTag != "" {
+		name = c.prefix + "." + m.Name
+		tags = c.fmtTags([]Tag{{c.bucketTag, bucket}})
+		switch {
+		case c.tags == "":
+		case c.format == Influx:
+			tags = c.tags + tags
+		default:
+			tags = c.tags + "," + tags
+		}
+	}
+	if c.format == Influx {
+		fmt.Fprintf(sgl, "%s%s:%s%v|%s", name, tags, prefix, m.Value, t)
+	} else {
+		fmt.Fprintf(sgl, "%s:%s%v|%s", name, prefix, m.Value, t)
+	}
+	if aggCnt != 1 {
+		fmt.Fprintf(sgl, "|@%f", float64(1)/float64(aggCnt))
+	}
+	if c.format == DogStatsD && tags != "" {
+		fmt.Fprintf(sgl, "|#%s", tags)
+	}
+}
@@ -106,6 +106,48 @@ func TestClient(t *testing.T) {
 		"test.three.gauge.onemore:789|g")
 }
 
+func TestClientTagged(t *testing.T) {
+	s, err := startServer()
+	if err != nil {
+		t.Fatal("Failed to start server", err)
+	}
+	defer s.Close()
+
+	tests := []struct {
+		format statsd.Format
+		exp    string
+	}{
+		{statsd.DogStatsD, "test.latency:123|ms|@0.500000|#loader:host-1,bucket:ais_//abc,op:get\n" +
+			"test.count:2|c|@0.500000|#loader:host-1,bucket:ais_//abc,op:get"},
+		{statsd.Influx, "test.latency,loader=host-1,bucket=ais_//abc,op=get:123|ms|@0.500000\n" +
+			"test.count,loader=host-1,bucket=ais_//abc,op=get:2|c|@0.500000"},
+	}
+	for _, test := range tests {
+		c, err := statsd.New(self, port, prefix, false)
+		if err != nil {
+			t.Fatal("Failed to create client", err)
+		}
+		c.SetTagged(&statsd.Tagged{
+			Format:    test.format,
+			BucketTag: "op",
+			Tags:      []statsd.Tag{{Name: "loader", Value: "host-1"}, {Name: "bucket", Value: "ais://abc"}},
+		})
+		c.Send("get", 2,
+			statsd.Metric{Type: statsd.Timer, Name: "latency", Value: 123},
+			statsd.Metric{Type: statsd.Counter, Name: "count", Value: 2},
+		)
+		checkMsg(t, s, test.exp)
+		c.Close()
+	}
+
+	if f, err := statsd.ParseFormat("DogStatsD"); err != nil || f != statsd.DogStatsD {
+		t.Fatalf("ParseFormat: %v, %v", f, err)
+	}
+	if _, err := statsd.ParseFormat("graphite"); err == nil {
+		t.Fatal("expected error parsing invalid format")
+	}
+}
+
 // server is the UDP server routine used for testing
 // it receives UDP requests and throw them away
 // stops when a message is received from the stop channel
@@ -46,6 +46,28 @@ const (
 	VerChangeCount = "ver.change.n"
 	VerChangeSize  = "ver.change.size"
 
+	// hedged reads (see mirror.hedge_delay)
+	GetHedgeCount       = "get.hedge.n"        // issued
+	GetHedgeWonCount    = "get.hedge.won.n"    // hedge (second read) completed first
+	GetHedgeCancelCount = "get.hedge.cancel.n" // original read completed first; hedge discarded
+
+	// utilization-based PUT admission (see disk.put_throttle_wm)
+	PutThrottleCount    = "put.throttle.n"             // delayed and admitted
+	ErrPutThrottleCount = errPrefix + "put.throttle.n" // still over the watermark - rejected (429)
+
+	// bucket validation webhook (see bucket prop `hook`)
+	HookCount     = "hook.n"             // webhook calls
+	HookDenyCount = "hook.deny.n"        // denied PUTs and DELETEs
+	ErrHookCount  = errPrefix + "hook.n" // webhook failures (unreachable, timed out, 5xx)
+
+	// deduplication (see bucket prop `dedup`)
+	DedupCount = "dedup.n"    // PUTs that referenced already stored content
+	DedupSize  = "dedup.size" // bytes not stored (ditto)
+
+	// incomplete multipart uploads and appends (see apc.ActAbortIncomplete)
+	AbortIncompleteCount = "incomplete.abort.n"    // aborted uploads and removed leftovers
+	AbortIncompleteSize  = "incomplete.abort.size" // reclaimed space
+
 	// errors
 	ErrCksumCount = errPrefix + "cksum.n"
 	ErrCksumSize  = errPrefix + "cksum.size"
@@ -90,9 +112,14 @@ const (
 	// core
 	RemoteDeletedDelCount = core.RemoteDeletedDelCount // compare w/ common `DeleteCount`
 
-	LcacheCollisionCount = core.LcacheCollisionCount
-	LcacheEvictedCount   = core.LcacheEvictedCount
-	LcacheFlushColdCount = core.LcacheFlushColdCount
+	LcacheCollisionCount  = core.LcacheCollisionCount
+	LcacheEvictedCount    = core.LcacheEvictedCount
+	LcacheFlushColdCount  = core.LcacheFlushColdCount
+	LcacheFlushAtimeCount = core.LcacheFlushAtimeCount
+
+	RAMCacheHitCount     = core.RAMCacheHitCount
+	RAMCacheMissCount    = core.RAMCacheMissCount
+	RAMCacheEvictedCount = core.RAMCacheEvictedCount
 
 	// variable label used for prometheus disk metrics
 	diskMetricLabel = "disk"
@@ -152,6 +179,9 @@ func (r *Trunner) Init() *atomic.Bool {
 	r.core.init(numTargetStats)
 
 	r.regCommon(r.t.Snode())
+	r.core.initLatHist(r.t.Snode())
+	r.lhist.enabled = r.IsPrometheus()
+	r.lhist.reconf(cmn.GCO.Get())
 
 	r.ctracker = make(copyTracker, numTargetStats) // these two are allocated once and only used in serial context
 	r.lines = make([]string, 0, 16)
@@ -277,6 +307,68 @@ func (r *Trunner) RegMetrics(snode *meta.Snode) {
 		},
 	)
 
+	// hedged reads
+	r.reg(snode, GetHedgeCount, KindCounter,
+		&Extra{
+			Help: "GET: number of hedged reads, i.e., second reads of a mirrored object issued when the first one exceeds mirror.hedge_delay",
+		},
+	)
+	r.reg(snode, GetHedgeWonCount, KindCounter,
+		&Extra{
+			Help: "GET: number of hedged reads that completed first (and were served)",
+		},
+	)
+	r.reg(snode, GetHedgeCancelCount, KindCounter,
+		&Extra{
+			Help: "GET: number of hedged reads that were discarded because the original read completed first",
+		},
+	)
+	r.reg(snode, PutThrottleCount, KindCounter,
+		&Extra{
+			Help: "PUT: number of requests delayed due to high utilization of the destination mountpath (disk)",
+		},
+	)
+	r.reg(snode, ErrPutThrottleCount, KindCounter,
+		&Extra{
+			Help: "PUT: number of requests rejected (429) due to sustained high utilization of the destination mountpath (disk)",
+		},
+	)
+	r.reg(snode, HookCount, KindCounter,
+		&Extra{
+			Help: "number of bucket validation webhook calls (pre-PUT and pre-DELETE)",
+		},
+	)
+	r.reg(snode, HookDenyCount, KindCounter,
+		&Extra{
+			Help: "number of PUT and DELETE requests denied by bucket validation webhook",
+		},
+	)
+	r.reg(snode, ErrHookCount, KindCounter,
+		&Extra{
+			Help: "number of bucket validation webhook failures (webhook unreachable, timed out, or returned 5xx)",
+		},
+	)
+	r.reg(snode, DedupCount, KindCounter,
+		&Extra{
+			Help: "number of deduplicated objects, i.e. PUTs that referenced already stored content (see bucket dedup)",
+		},
+	)
+	r.reg(snode, DedupSize, KindSize,
+		&Extra{
+			Help: "total size of deduplicated objects (bytes that did not have to be stored)",
+		},
+	)
+	r.reg(snode, AbortIncompleteCount, KindCounter,
+		&Extra{
+			Help: "number of aborted incomplete multipart uploads and removed leftovers of abandoned writes (see space.abort_incomplete)",
+		},
+	)
+	r.reg(snode, AbortIncompleteSize, KindSize,
+		&Extra{
+			Help: "total size of storage reclaimed by aborting incomplete multipart uploads and appends",
+		},
+	)
+
 	r.reg(snode, PutLatency, KindLatency,
 		&Extra{
 			Help: "PUT: average time (milliseconds) over the last periodic.stats_time interval",
@@ -451,6 +543,26 @@ func (r *Trunner) RegMetrics(snode *meta.Snode) {
 			Help: "number of times a LOM from cache was written to stable storage (core, internal)",
 		},
 	)
+	r.reg(snode, LcacheFlushAtimeCount, KindCounter,
+		&Extra{
+			Help: "number of object access times written to stable storage in batches (see bucket atime policy)",
+		},
+	)
+	r.reg(snode, RAMCacheHitCount, KindCounter,
+		&Extra{
+			Help: "GET: number of objects served from the in-memory cache of small hot objects (see bucket ram_cache)",
+		},
+	)
+	r.reg(snode, RAMCacheMissCount, KindCounter,
+		&Extra{
+			Help: "GET: number of in-memory (ram_cache) cache misses",
+		},
+	)
+	r.reg(snode, RAMCacheEvictedCount, KindCounter,
+		&Extra{
+			Help: "number of objects evicted from the in-memory (ram_cache) cache",
+		},
+	)
 }
 
 func (r *Trunner) RegDiskMetrics(snode *meta.Snode, disk string) {
@@ -597,6 +709,9 @@ func (r *Trunner) log(now int64, uptime time.Duration, config *cmn.Config) {
 		fs.DiskStats(r.disk.stats, nil /*fs.TcdfExt*/, config, true /*refresh cap*/)
 	}
 
+	// capacity snapshot (see CapReport)
+	r.chist.maybe(r.t, config)
+
 	// 4. append disk stats to log subject to (idle) filtering (see related: `ignoreIdle`)
 	r.logDiskStats(now)
 
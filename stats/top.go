@@ -0,0 +1,338 @@
+// Package stats provides methods and functionality to register, track, log,
+// and StatsD-notify statistics that, for the most part, include "counter" and "latency" kinds.
+/*
+ * Copyright (c) 2024, NVIDIA CORPORATION. All rights reserved.
+ */
+package stats
+
+import (
+	"container/heap"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/cmn/cos"
+	"github.com/OneOfOne/xxhash"
+)
+
+// Top-N traffic report: the most requested objects and prefixes (virtual directories),
+// by request rate and by bytes, over a trailing window (TopWindow).
+// Memory is bounded: the window is divided into topSlots time slots, each maintaining
+// fixed-capacity Space-Saving sketches (Metwally et al.) - hence, the counts are
+// approximate (may be overestimated) for the entries that have been evicted and re-added.
+//
+// The datapath (GET and PUT) only increments plain counters in one of topShards shards
+// (selected by object name); the counters get folded into the sketches periodically:
+// when the shard fills up (topShardCap), when its time slot ends, and upon get.
+
+const (
+	TopWindow   = 5 * time.Minute
+	DfltTopN    = 10
+	topSlots    = 5
+	topCapacity = 256 // max entries per sketch
+	topShards   = 32
+	topShardCap = 128 // max objects per shard in between folds
+)
+
+type (
+	// REST API
+	TopEntry struct {
+		Name  string  `json:"name"`         // bucket/object or bucket/prefix
+		Count int64   `json:"count,string"` // number of requests (GET and PUT) within the window
+		Bytes int64   `json:"bytes,string"` // bytes transferred within the window
+		Rate  float64 `json:"rate"`         // requests per second (over the window)
+	}
+	TopReport struct {
+		ObjsByRate   []*TopEntry   `json:"objs_by_rate"`
+		ObjsByBytes  []*TopEntry   `json:"objs_by_bytes"`
+		PrefsByRate  []*TopEntry   `json:"prefixes_by_rate"`
+		PrefsByBytes []*TopEntry   `json:"prefixes_by_bytes"`
+		Window       time.Duration `json:"window"`
+	}
+
+	// Space-Saving sketch: min-heap by weight
+	ssEntry struct {
+		key    string
+		weight int64
+		idx    int
+	}
+	ssHeap   []*ssEntry
+	ssSketch struct {
+		m map[string]*ssEntry
+		h ssHeap
+	}
+
+	topSlot struct {
+		objsCnt, objsSize, prefCnt, prefSize ssSketch
+		epoch                                int64
+	}
+
+	// sharded (datapath) counters
+	topCnt struct {
+		cnt, size int64
+	}
+	topShard struct {
+		objs, prefs map[string]*topCnt
+		epoch       int64
+		mu          sync.Mutex
+	}
+
+	top struct {
+		shards [topShards]topShard
+		slots  [topSlots]topSlot
+		mu     sync.Mutex // protects slots
+	}
+)
+
+// interface guard
+var _ heap.Interface = (*ssHeap)(nil)
+
+/////////
+// top //
+/////////
+
+func slotEpoch(now time.Time) int64 { return now.UnixNano() / int64(TopWindow/topSlots) }
+
+func (t *top) add(bck *cmn.Bck, objName string, size int64) {
+	var (
+		uname  = bck.Cname(objName)
+		prefix = bck.Cname("")
+		epoch  = slotEpoch(time.Now())
+		shard  = &t.shards[xxhash.Checksum64S(cos.UnsafeB(uname), cos.MLCG32)%topShards]
+	)
+	if i := strings.LastIndexByte(objName, '/'); i > 0 {
+		prefix = bck.Cname(objName[:i+1])
+	}
+	shard.mu.Lock()
+	if shard.epoch != epoch || len(shard.objs) >= topShardCap {
+		t.fold(shard)
+		shard.epoch = epoch
+	}
+	shard.inc(uname, prefix, size)
+	shard.mu.Unlock()
+}
+
+// fold shard's counters into the sketches of the shard's time slot
+// (caller must lock the shard)
+func (t *top) fold(shard *topShard) {
+	if len(shard.objs) == 0 {
+		return
+	}
+	t.mu.Lock()
+	slot := &t.slots[shard.epoch%topSlots]
+	if slot.epoch < shard.epoch {
+		slot.reset(shard.epoch)
+	}
+	if slot.epoch == shard.epoch { // (otherwise, outside the window)
+		for name, c := range shard.objs {
+			slot.objsCnt.add(name, c.cnt)
+			if c.size > 0 {
+				slot.objsSize.add(name, c.size)
+			}
+		}
+		for name, c := range shard.prefs {
+			slot.prefCnt.add(name, c.cnt)
+			if c.size > 0 {
+				slot.prefSize.add(name, c.size)
+			}
+		}
+	}
+	t.mu.Unlock()
+	clear(shard.objs)
+	clear(shard.prefs)
+}
+
+func (t *top) get(n int) *TopReport {
+	var (
+		objsCnt  = make(map[string]int64, topCapacity)
+		objsSize = make(map[string]int64, topCapacity)
+		prefCnt  = make(map[string]int64, topCapacity)
+		prefSize = make(map[string]int64, topCapacity)
+		epoch    = slotEpoch(time.Now())
+	)
+	for i := range t.shards {
+		shard := &t.shards[i]
+		shard.mu.Lock()
+		t.fold(shard)
+		shard.mu.Unlock()
+	}
+	t.mu.Lock()
+	for i := range t.slots {
+		slot := &t.slots[i]
+		if slot.epoch <= epoch-topSlots || slot.epoch == 0 {
+			continue // outside the window
+		}
+		slot.objsCnt.sum(objsCnt)
+		slot.objsSize.sum(objsSize)
+		slot.prefCnt.sum(prefCnt)
+		slot.prefSize.sum(prefSize)
+	}
+	t.mu.Unlock()
+
+	rep := &TopReport{Window: TopWindow}
+	rep.ObjsByRate = topEntries(objsCnt, objsSize, n, false)
+	rep.ObjsByBytes = topEntries(objsCnt, objsSize, n, true)
+	rep.PrefsByRate = topEntries(prefCnt, prefSize, n, false)
+	rep.PrefsByBytes = topEntries(prefCnt, prefSize, n, true)
+	return rep
+}
+
+//////////////
+// topShard //
+//////////////
+
+func (shard *topShard) inc(uname, prefix string, size int64) {
+	if shard.objs == nil {
+		shard.objs = make(map[string]*topCnt, topShardCap)
+		shard.prefs = make(map[string]*topCnt, 16)
+	}
+	_inc(shard.objs, uname, size)
+	_inc(shard.prefs, prefix, size)
+}
+
+func _inc(m map[string]*topCnt, name string, size int64) {
+	c, ok := m[name]
+	if !ok {
+		c = &topCnt{}
+		m[name] = c
+	}
+	c.cnt++
+	c.size += size
+}
+
+func (slot *topSlot) reset(epoch int64) {
+	slot.objsCnt.reset()
+	slot.objsSize.reset()
+	slot.prefCnt.reset()
+	slot.prefSize.reset()
+	slot.epoch = epoch
+}
+
+func topEntries(cnt, size map[string]int64, n int, byBytes bool) []*TopEntry {
+	src := cnt
+	if byBytes {
+		src = size
+	}
+	entries := make([]*TopEntry, 0, len(src))
+	for name := range src {
+		entries = append(entries, &TopEntry{Name: name, Count: cnt[name], Bytes: size[name]})
+	}
+	return sortTop(entries, n, byBytes, TopWindow)
+}
+
+func sortTop(entries []*TopEntry, n int, byBytes bool, window time.Duration) []*TopEntry {
+	sort.Slice(entries, func(i, j int) bool {
+		ei, ej := entries[i], entries[j]
+		if byBytes && ei.Bytes != ej.Bytes {
+			return ei.Bytes > ej.Bytes
+		}
+		if ei.Count != ej.Count {
+			return ei.Count > ej.Count
+		}
+		return ei.Name < ej.Name
+	})
+	if n > 0 && len(entries) > n {
+		entries = entries[:n]
+	}
+	for _, e := range entries {
+		e.Rate = float64(e.Count) / window.Seconds()
+	}
+	return entries
+}
+
+// MergeTop aggregates per-target reports into a cluster-wide one
+func MergeTop(reps []*TopReport, n int) *TopReport {
+	merge := func(get func(*TopReport) []*TopEntry, byBytes bool) []*TopEntry {
+		all := make(map[string]*TopEntry, n*len(reps))
+		for _, rep := range reps {
+			for _, e := range get(rep) {
+				if a, ok := all[e.Name]; ok {
+					a.Count += e.Count
+					a.Bytes += e.Bytes
+				} else {
+					all[e.Name] = &TopEntry{Name: e.Name, Count: e.Count, Bytes: e.Bytes}
+				}
+			}
+		}
+		entries := make([]*TopEntry, 0, len(all))
+		for _, e := range all {
+			entries = append(entries, e)
+		}
+		return sortTop(entries, n, byBytes, TopWindow)
+	}
+	return &TopReport{
+		ObjsByRate:   merge(func(r *TopReport) []*TopEntry { return r.ObjsByRate }, false),
+		ObjsByBytes:  merge(func(r *TopReport) []*TopEntry { return r.ObjsByBytes }, true),
+		PrefsByRate:  merge(func(r *TopReport) []*TopEntry { return r.PrefsByRate }, false),
+		PrefsByBytes: merge(func(r *TopReport) []*TopEntry { return r.PrefsByBytes }, true),
+		Window:       TopWindow,
+	}
+}
+
+//////////////
+// ssSketch //
+//////////////
+
+func (s *ssSketch) add(key string, weight int64) {
+	if e, ok := s.m[key]; ok {
+		e.weight += weight
+		heap.Fix(&s.h, e.idx)
+		return
+	}
+	if s.m == nil {
+		s.m = make(map[string]*ssEntry, topCapacity)
+	}
+	if len(s.h) < topCapacity {
+		e := &ssEntry{key: key, weight: weight}
+		s.m[key] = e
+		heap.Push(&s.h, e)
+		return
+	}
+	// evict the minimum and inherit its weight (the Space-Saving overestimate)
+	e := s.h[0]
+	delete(s.m, e.key)
+	e.key = key
+	e.weight += weight
+	s.m[key] = e
+	heap.Fix(&s.h, 0)
+}
+
+func (s *ssSketch) sum(out map[string]int64) {
+	for key, e := range s.m {
+		out[key] += e.weight
+	}
+}
+
+func (s *ssSketch) reset() {
+	clear(s.m)
+	s.h = s.h[:0]
+}
+
+////////////
+// ssHeap //
+////////////
+
+func (h ssHeap) Len() int           { return len(h) }
+func (h ssHeap) Less(i, j int) bool { return h[i].weight < h[j].weight }
+
+func (h ssHeap) Swap(i, j int) {
+	h[i], h[j] = h[j], h[i]
+	h[i].idx, h[j].idx = i, j
+}
+
+func (h *ssHeap) Push(x any) {
+	e := x.(*ssEntry)
+	e.idx = len(*h)
+	*h = append(*h, e)
+}
+
+func (h *ssHeap) Pop() any {
+	old := *h
+	n := len(old)
+	e := old[n-1]
+	old[n-1] = nil
+	*h = old[:n-1]
+	return e
+}
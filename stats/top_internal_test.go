@@ -0,0 +1,122 @@
+// Package stats provides methods and functionality to register, track, log,
+// and StatsD-notify statistics that, for the most part, include "counter" and "latency" kinds.
+/*
+ * Copyright (c) 2024, NVIDIA CORPORATION. All rights reserved.
+ */
+package stats
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+
+	"github.com/NVIDIA/aistore/api/apc"
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/tools/tassert"
+)
+
+var topBck = cmn.Bck{Name: "top", Provider: apc.AIS, Ns: cmn.NsGlobal}
+
+func TestTopCounts(t *testing.T) {
+	tp := &top{}
+	for range 100 {
+		tp.add(&topBck, "dir/hot", 10)
+	}
+	for i := range 50 {
+		tp.add(&topBck, fmt.Sprintf("dir/warm-%d", i%5), 1000)
+	}
+	tp.add(&topBck, "cold", 1)
+
+	rep := tp.get(3)
+	tassert.Fatalf(t, len(rep.ObjsByRate) == 3, "expected top-3, got %d", len(rep.ObjsByRate))
+	hot := rep.ObjsByRate[0]
+	tassert.Errorf(t, hot.Name == topBck.Cname("dir/hot") && hot.Count == 100 && hot.Bytes == 1000,
+		"unexpected top object by rate: %+v", hot)
+	warm := rep.ObjsByBytes[0]
+	tassert.Errorf(t, warm.Count == 10 && warm.Bytes == 10*1000, "unexpected top object by bytes: %+v", warm)
+
+	tassert.Fatalf(t, len(rep.PrefsByRate) == 2, "expected 2 prefixes, got %d", len(rep.PrefsByRate))
+	dir := rep.PrefsByRate[0]
+	tassert.Errorf(t, dir.Name == topBck.Cname("dir/") && dir.Count == 150 && dir.Bytes == 100*10+50*1000,
+		"unexpected top prefix: %+v", dir)
+	root := rep.PrefsByRate[1]
+	tassert.Errorf(t, root.Name == topBck.Cname("") && root.Count == 1, "unexpected bucket-level prefix: %+v", root)
+}
+
+// all adds get accounted for regardless of sharding and (intermediate) folding
+func TestTopConcurrent(t *testing.T) {
+	const (
+		numWorkers = 16
+		numAdds    = 2000
+		numObjs    = 64 // (total < topCapacity: the counts must be exact)
+	)
+	var (
+		tp = &top{}
+		wg sync.WaitGroup
+	)
+	for w := range numWorkers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range numAdds {
+				k := (w + i) % numObjs
+				tp.add(&topBck, fmt.Sprintf("d%d/o%d", k%4, k), 1)
+				if i%500 == 0 {
+					tp.get(DfltTopN)
+				}
+			}
+		}()
+	}
+	wg.Wait()
+
+	rep := tp.get(numObjs)
+	var total, bytes int64
+	for _, e := range rep.ObjsByRate {
+		total += e.Count
+		bytes += e.Bytes
+	}
+	tassert.Errorf(t, total == numWorkers*numAdds && bytes == total, "expected %d, got count %d, bytes %d",
+		numWorkers*numAdds, total, bytes)
+	tassert.Errorf(t, len(rep.PrefsByRate) == 4, "expected 4 prefixes, got %d", len(rep.PrefsByRate))
+}
+
+// memory stays bounded while heavy hitters remain on top
+func TestTopBounded(t *testing.T) {
+	tp := &top{}
+	for i := range 100 * topCapacity {
+		tp.add(&topBck, fmt.Sprintf("unique-%d", i), 1)
+		if i%10 == 0 {
+			tp.add(&topBck, "heavy", 1)
+		}
+	}
+	for i := range tp.shards {
+		tassert.Errorf(t, len(tp.shards[i].objs) <= topShardCap, "shard %d: %d objects", i, len(tp.shards[i].objs))
+	}
+	rep := tp.get(1)
+	for i := range tp.slots {
+		slot := &tp.slots[i]
+		tassert.Errorf(t, len(slot.objsCnt.m) <= topCapacity, "slot %d: %d objects", i, len(slot.objsCnt.m))
+	}
+	tassert.Errorf(t, rep.ObjsByRate[0].Name == topBck.Cname("heavy"), "expected heavy hitter on top, got %+v",
+		rep.ObjsByRate[0])
+}
+
+func TestMergeTop(t *testing.T) {
+	var reps []*TopReport
+	for i := range 3 {
+		tp := &top{}
+		for range 10 {
+			tp.add(&topBck, "shared", 1)
+		}
+		for range i + 1 {
+			tp.add(&topBck, fmt.Sprintf("own-%d", i), 100)
+		}
+		reps = append(reps, tp.get(DfltTopN))
+	}
+	rep := MergeTop(reps, 2)
+	tassert.Fatalf(t, len(rep.ObjsByRate) == 2, "expected top-2, got %d", len(rep.ObjsByRate))
+	tassert.Errorf(t, rep.ObjsByRate[0].Name == topBck.Cname("shared") && rep.ObjsByRate[0].Count == 30,
+		"unexpected merged top: %+v", rep.ObjsByRate[0])
+	tassert.Errorf(t, rep.ObjsByBytes[0].Name == topBck.Cname("own-2") && rep.ObjsByBytes[0].Bytes == 300,
+		"unexpected merged top by bytes: %+v", rep.ObjsByBytes[0])
+}
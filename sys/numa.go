@@ -0,0 +1,126 @@
+// Package sys provides methods to read system information
+/*
+ * Copyright (c) 2024, NVIDIA CORPORATION. All rights reserved.
+ */
+package sys
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// NUMA topology and CPU affinity
+//
+// Go does not allow to pin goroutines - only OS threads. Therefore:
+// - process-wide affinity (SetAffinity) applies to all threads, current and future,
+//   and thus to all goroutines including HTTP handlers;
+// - PinThread restricts the calling thread that must be locked (runtime.LockOSThread)
+//   by a long-running goroutine, e.g. a per-mountpath jogger.
+
+type (
+	NumaNode struct {
+		CPUs string `json:"cpus"` // CPU list, e.g. "0-15,32-47"
+		ID   int    `json:"id"`
+		cpus []int
+	}
+	NumaInfo struct {
+		NICs     map[string]int `json:"nics,omitempty"` // network interface => NUMA node
+		Affinity string         `json:"affinity"`       // effective (current) CPU list of this process
+		Nodes    []NumaNode     `json:"nodes"`
+	}
+)
+
+var ErrNoNuma = errors.New("NUMA topology not available")
+
+var (
+	topo     []NumaNode
+	nics     map[string]int
+	topoOnce sync.Once
+)
+
+func _topo() {
+	topo, nics = readTopology()
+}
+
+// Numa returns NUMA topology (nil if not available) and effective affinity.
+func Numa() *NumaInfo {
+	topoOnce.Do(_topo)
+	if len(topo) == 0 {
+		return nil
+	}
+	info := &NumaInfo{Nodes: topo, NICs: nics}
+	if cpus, err := Affinity(); err == nil {
+		info.Affinity = FormatCPUList(cpus)
+	}
+	return info
+}
+
+// NodeCPUs returns the union of CPUs of the specified NUMA nodes.
+func NodeCPUs(nodes ...int) ([]int, error) {
+	topoOnce.Do(_topo)
+	if len(topo) == 0 {
+		return nil, ErrNoNuma
+	}
+	var cpus []int
+	for _, id := range nodes {
+		idx := slices.IndexFunc(topo, func(n NumaNode) bool { return n.ID == id })
+		if idx < 0 {
+			return nil, fmt.Errorf("NUMA node %d does not exist (have %d node(s))", id, len(topo))
+		}
+		cpus = append(cpus, topo[idx].cpus...)
+	}
+	slices.Sort(cpus)
+	return slices.Compact(cpus), nil
+}
+
+// ParseCPUList parses Linux "cpulist" format, e.g. "0-3,8,10-11".
+func ParseCPUList(s string) ([]int, error) {
+	var cpus []int
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return nil, nil
+	}
+	for _, part := range strings.Split(s, ",") {
+		lo, hi, isRange := strings.Cut(strings.TrimSpace(part), "-")
+		first, err := strconv.Atoi(lo)
+		if err != nil || first < 0 {
+			return nil, fmt.Errorf("invalid CPU list %q", s)
+		}
+		last := first
+		if isRange {
+			if last, err = strconv.Atoi(hi); err != nil || last < first {
+				return nil, fmt.Errorf("invalid CPU list %q", s)
+			}
+		}
+		for c := first; c <= last; c++ {
+			cpus = append(cpus, c)
+		}
+	}
+	slices.Sort(cpus)
+	return slices.Compact(cpus), nil
+}
+
+// FormatCPUList is the inverse of ParseCPUList (expects sorted input).
+func FormatCPUList(cpus []int) string {
+	var sb strings.Builder
+	for i := 0; i < len(cpus); {
+		j := i
+		for j+1 < len(cpus) && cpus[j+1] == cpus[j]+1 {
+			j++
+		}
+		if sb.Len() > 0 {
+			sb.WriteByte(',')
+		}
+		sb.WriteString(strconv.Itoa(cpus[i]))
+		if j > i {
+			sb.WriteByte('-')
+			sb.WriteString(strconv.Itoa(cpus[j]))
+		}
+		i = j + 1
+	}
+	return sb.String()
+}
@@ -0,0 +1,25 @@
+// Package sys provides methods to read system information
+/*
+ * Copyright (c) 2024, NVIDIA CORPORATION. All rights reserved.
+ */
+package sys
+
+import "errors"
+
+var errAffinity = errors.New("Darwin: CPU affinity is not supported")
+
+func readTopology() ([]NumaNode, map[string]int) { return nil, nil }
+
+func DiskNumaNode(string) int { return -1 }
+
+func Affinity() ([]int, error) { return nil, errAffinity }
+
+func SetAffinity([]int) error { return errAffinity }
+
+func PinThread([]int) (func(), error) { return nil, errAffinity }
+
+func NicIRQs(string) []int { return nil }
+
+func DiskIRQs(string) []int { return nil }
+
+func SetIRQAffinity(_, _ []int) (int, error) { return 0, errAffinity }
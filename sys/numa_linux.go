@@ -0,0 +1,190 @@
+// Package sys provides methods to read system information
+/*
+ * Copyright (c) 2024, NVIDIA CORPORATION. All rights reserved.
+ */
+package sys
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/NVIDIA/aistore/cmn/cos"
+	"golang.org/x/sys/unix"
+)
+
+const (
+	sysNodes = "/sys/devices/system/node/"
+	sysNet   = "/sys/class/net/"
+	sysBlock = "/sys/class/block/"
+	procTask = proc + "self/task/"
+	procIRQ  = proc + "irq/"
+)
+
+func readTopology() (nodes []NumaNode, nics map[string]int) {
+	dirents, err := os.ReadDir(sysNodes)
+	if err != nil {
+		return nil, nil
+	}
+	for _, de := range dirents {
+		name := de.Name()
+		if !strings.HasPrefix(name, "node") {
+			continue
+		}
+		id, err := strconv.Atoi(name[4:])
+		if err != nil {
+			continue
+		}
+		line, err := cos.ReadOneLine(sysNodes + name + "/cpulist")
+		if err != nil {
+			continue
+		}
+		cpus, err := ParseCPUList(line)
+		if err != nil || len(cpus) == 0 { // (memory-only node)
+			continue
+		}
+		nodes = append(nodes, NumaNode{ID: id, CPUs: FormatCPUList(cpus), cpus: cpus})
+	}
+	slices.SortFunc(nodes, func(a, b NumaNode) int { return a.ID - b.ID })
+
+	// physical network interfaces
+	if dirents, err = os.ReadDir(sysNet); err == nil {
+		for _, de := range dirents {
+			if id := devNumaNode(sysNet + de.Name()); id >= 0 {
+				if nics == nil {
+					nics = make(map[string]int, 4)
+				}
+				nics[de.Name()] = id
+			}
+		}
+	}
+	return nodes, nics
+}
+
+// DiskNumaNode returns NUMA node of a given block device (e.g. "nvme0n1", "sda1"), or -1 if unknown.
+func DiskNumaNode(disk string) int { return devNumaNode(sysBlock + disk) }
+
+// walk up the resolved sysfs device path looking for `numa_node` (e.g., of the PCI parent)
+func devNumaNode(sysPath string) int {
+	dir, err := filepath.EvalSymlinks(sysPath)
+	if err != nil || !strings.HasPrefix(dir, "/sys/devices/") {
+		return -1
+	}
+	for ; dir != "/sys/devices" && dir != "/"; dir = filepath.Dir(dir) {
+		id, err := cos.ReadOneInt64(filepath.Join(dir, "numa_node"))
+		if err != nil {
+			continue
+		}
+		if id < 0 {
+			return -1
+		}
+		return int(id)
+	}
+	return -1
+}
+
+// Affinity returns CPU list of the calling thread (and, unless pinned, of the process).
+func Affinity() ([]int, error) {
+	var set unix.CPUSet
+	if err := unix.SchedGetaffinity(0, &set); err != nil {
+		return nil, err
+	}
+	return fromSet(&set), nil
+}
+
+// SetAffinity restricts all threads of the process (and threads created later on) to `cpus`.
+func SetAffinity(cpus []int) error {
+	set := toSet(cpus)
+	dirents, err := os.ReadDir(procTask)
+	if err != nil {
+		return err
+	}
+	for _, de := range dirents {
+		tid, err := strconv.Atoi(de.Name())
+		if err != nil {
+			continue
+		}
+		if err := unix.SchedSetaffinity(tid, set); err != nil && err != unix.ESRCH { // (thread exited)
+			return err
+		}
+	}
+	return nil
+}
+
+// PinThread restricts the calling (locked) OS thread to `cpus`;
+// returns a function to restore the previous affinity prior to runtime.UnlockOSThread.
+func PinThread(cpus []int) (restore func(), err error) {
+	var prev unix.CPUSet
+	if err = unix.SchedGetaffinity(0, &prev); err != nil {
+		return nil, err
+	}
+	if err = unix.SchedSetaffinity(0, toSet(cpus)); err != nil {
+		return nil, err
+	}
+	return func() { unix.SchedSetaffinity(0, &prev) }, nil //nolint:errcheck // (best effort)
+}
+
+func toSet(cpus []int) *unix.CPUSet {
+	var set unix.CPUSet
+	for _, c := range cpus {
+		set.Set(c)
+	}
+	return &set
+}
+
+func fromSet(set *unix.CPUSet) (cpus []int) {
+	for c, n := 0, set.Count(); len(cpus) < n; c++ {
+		if set.IsSet(c) {
+			cpus = append(cpus, c)
+		}
+	}
+	return cpus
+}
+
+// NicIRQs returns IRQs of a given network interface (e.g. "eth0").
+func NicIRQs(nic string) []int { return devIRQs(sysNet + nic) }
+
+// DiskIRQs returns IRQs of a given block device (e.g. "nvme0n1", "sda1").
+func DiskIRQs(disk string) []int { return devIRQs(sysBlock + disk) }
+
+// walk up the resolved sysfs device path looking for MSI(-X) vectors or, failing that, legacy `irq`
+func devIRQs(sysPath string) (irqs []int) {
+	dir, err := filepath.EvalSymlinks(sysPath)
+	if err != nil || !strings.HasPrefix(dir, "/sys/devices/") {
+		return nil
+	}
+	for ; dir != "/sys/devices" && dir != "/"; dir = filepath.Dir(dir) {
+		if dirents, err := os.ReadDir(filepath.Join(dir, "msi_irqs")); err == nil {
+			for _, de := range dirents {
+				if irq, err := strconv.Atoi(de.Name()); err == nil {
+					irqs = append(irqs, irq)
+				}
+			}
+			if len(irqs) > 0 {
+				slices.Sort(irqs)
+				return irqs
+			}
+		}
+		if irq, err := cos.ReadOneInt64(filepath.Join(dir, "irq")); err == nil && irq > 0 {
+			return []int{int(irq)}
+		}
+	}
+	return nil
+}
+
+// SetIRQAffinity steers `irqs` to `cpus` and returns the number of IRQs steered;
+// (the kernel refuses to change affinity of managed interrupts, e.g. NVMe per-queue vectors)
+func SetIRQAffinity(irqs, cpus []int) (n int, err error) {
+	list := []byte(FormatCPUList(cpus))
+	for _, irq := range irqs {
+		erw := os.WriteFile(procIRQ+strconv.Itoa(irq)+"/smp_affinity_list", list, 0o644)
+		if erw == nil {
+			n++
+		} else if err == nil {
+			err = erw
+		}
+	}
+	return n, err
+}
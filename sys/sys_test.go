@@ -113,3 +113,56 @@ func TestProc(t *testing.T) {
 	tassert.Errorf(t, newStats.CPU.Percent > 0.0, "Process must use some CPU. Usage: %g", stats.CPU.Percent)
 	t.Logf("Process CPU usage: %6.2f%%", newStats.CPU.Percent)
 }
+
+func TestCPUList(t *testing.T) {
+	for s, expected := range map[string]string{
+		"0":            "0",
+		"0-3":          "0-3",
+		"0-3,8-11":     "0-3,8-11",
+		"3,1,2,0":      "0-3",
+		"0-2, 2-4, 7":  "0-4,7",
+		"16-31,48-63 ": "16-31,48-63",
+	} {
+		cpus, err := sys.ParseCPUList(s)
+		tassert.CheckFatal(t, err)
+		tassert.Errorf(t, sys.FormatCPUList(cpus) == expected, "%q: expected %q, got %q", s, expected, sys.FormatCPUList(cpus))
+	}
+	for _, s := range []string{"a", "3-1", "-1", "0-", "0,,1"} {
+		_, err := sys.ParseCPUList(s)
+		tassert.Errorf(t, err != nil, "expected error parsing %q", s)
+	}
+}
+
+func TestNuma(t *testing.T) {
+	checkSkipOS(t, "darwin")
+	info := sys.Numa()
+	if info == nil {
+		t.Skip(sys.ErrNoNuma)
+	}
+	t.Logf("NUMA: %+v", info)
+	cpus, err := sys.NodeCPUs(info.Nodes[0].ID)
+	tassert.CheckFatal(t, err)
+	tassert.Errorf(t, sys.FormatCPUList(cpus) == info.Nodes[0].CPUs, "%v vs %s", cpus, info.Nodes[0].CPUs)
+
+	// pin and restore
+	runtime.LockOSThread()
+	defer runtime.UnlockOSThread()
+	prev, err := sys.Affinity()
+	tassert.CheckFatal(t, err)
+	restore, err := sys.PinThread(prev[:1])
+	tassert.CheckFatal(t, err)
+	curr, _ := sys.Affinity()
+	tassert.Errorf(t, len(curr) == 1 && curr[0] == prev[0], "expected %d, got %v", prev[0], curr)
+	restore()
+	curr, _ = sys.Affinity()
+	tassert.Errorf(t, len(curr) == len(prev), "failed to restore affinity: %v vs %v", curr, prev)
+}
+
+func TestIRQs(t *testing.T) {
+	checkSkipOS(t, "darwin")
+	// virtual device - no IRQs
+	irqs := sys.NicIRQs("lo")
+	tassert.Errorf(t, len(irqs) == 0, "loopback: unexpected IRQs %v", irqs)
+	irqs = sys.NicIRQs("no-such-nic")
+	tassert.Errorf(t, len(irqs) == 0, "non-existing interface: unexpected IRQs %v", irqs)
+}
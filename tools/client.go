@@ -187,8 +187,7 @@ func GetProxyReadiness(proxyURL string) error {
 
 func CreateBucket(tb testing.TB, proxyURL string, bck cmn.Bck, props *cmn.BpropsToSet, cleanup bool) {
 	bp := BaseAPIParams(proxyURL)
-	err := api.CreateBucket(bp, bck, props)
-	tassert.CheckFatal(tb, err)
+	RetryTransient(tb, func() error { return api.CreateBucket(bp, bck, props) })
 	if cleanup {
 		tb.Cleanup(func() {
 			DestroyBucket(tb, proxyURL, bck)
@@ -170,6 +170,10 @@ func PrepareObjects(t *testing.T, desc ObjectsDesc) *ObjectsOut {
 	fs.CSM.Reg(fs.ObjectType, &fs.ObjectContentResolver{}, true)
 	fs.CSM.Reg(fs.ECSliceType, &fs.ECSliceContentResolver{}, true)
 	fs.CSM.Reg(fs.ECMetaType, &fs.ECMetaContentResolver{}, true)
+	fs.CSM.Reg(fs.TrashType, &fs.TrashContentResolver{}, true)
+	fs.CSM.Reg(fs.DedupType, &fs.DedupContentResolver{}, true)
+	fs.CSM.Reg(fs.ETLCacheType, &fs.ETLCacheContentResolver{}, true)
+	fs.CSM.Reg(fs.CustomMDType, &fs.CustomMDContentResolver{}, true)
 
 	dir := t.TempDir()
 
@@ -0,0 +1,200 @@
+// Package tools provides common tools and utilities for all unit and integration tests
+/*
+ * Copyright (c) 2024, NVIDIA CORPORATION. All rights reserved.
+ */
+package tools
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/NVIDIA/aistore/api"
+	"github.com/NVIDIA/aistore/api/env"
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/cmn/cos"
+	"github.com/NVIDIA/aistore/core/meta"
+	"github.com/NVIDIA/aistore/tools/tassert"
+	"github.com/NVIDIA/aistore/tools/tlog"
+)
+
+// Test harness:
+// - RetryTransient: retry (with backoff) operations that fail due to transient cluster states,
+//   e.g. cluster starting up, primary election in progress, node restarting (see IsTransientErr);
+// - quarantine: skip known-flaky tests listed in the env.AIS.TestQuarantine file unless
+//   env.AIS.TestRunQuarantined is set; a quarantined test also quarantines its subtests;
+// - artifacts: when env.AIS.TestArtifacts is set, collect Smap, BMD, and node logs
+//   of a failed test into the <artifacts-dir>/<test-name> directory.
+// Both quarantine and artifacts are enabled for all tests that call CheckSkip.
+
+const dfltRetryTimeout = time.Minute
+
+type quarantine struct {
+	tests map[string]string // test name (or path.Match pattern) => reason
+	err   error
+	once  sync.Once
+}
+
+var qtine quarantine
+
+func IsTransientErr(err error) bool {
+	if cos.IsRetriableConnErr(err) {
+		return true
+	}
+	var herr *cmn.ErrHTTP
+	if !errors.As(err, &herr) {
+		return false
+	}
+	switch herr.Status {
+	case http.StatusServiceUnavailable, http.StatusTooManyRequests, http.StatusBadGateway:
+		return true
+	default:
+		return false
+	}
+}
+
+func RetryTransient(tb testing.TB, f func() error, timeouts ...time.Duration) {
+	timeout := dfltRetryTimeout
+	if len(timeouts) > 0 {
+		timeout = timeouts[0]
+	}
+	tassert.CheckRetry(tb, timeout, IsTransientErr, f)
+}
+
+////////////////
+// quarantine //
+////////////////
+
+// CheckQuarantine skips the test if quarantined (see also CheckSkip)
+func CheckQuarantine(tb testing.TB) {
+	if cos.IsParseBool(os.Getenv(env.AIS.TestRunQuarantined)) {
+		return
+	}
+	fname := os.Getenv(env.AIS.TestQuarantine)
+	if fname == "" {
+		return
+	}
+	qtine.once.Do(func() { qtine.tests, qtine.err = loadQuarantine(fname) })
+	tassert.CheckFatal(tb, qtine.err)
+
+	if reason, ok := qtine.lookup(tb.Name()); ok {
+		tb.Skipf("%s is quarantined: %s", tb.Name(), reason)
+	}
+}
+
+func (q *quarantine) lookup(name string) (string, bool) {
+	for {
+		for pattern, reason := range q.tests {
+			if match, _ := path.Match(pattern, name); match {
+				return reason, true
+			}
+		}
+		i := strings.LastIndexByte(name, '/')
+		if i < 0 {
+			return "", false
+		}
+		name = name[:i] // parent test
+	}
+}
+
+// one test per line: name [reason]; '#' starts a comment
+func loadQuarantine(fname string) (map[string]string, error) {
+	fh, err := os.Open(fname)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", env.AIS.TestQuarantine, err)
+	}
+	defer fh.Close()
+	var (
+		tests   = make(map[string]string, 8)
+		scanner = bufio.NewScanner(fh)
+	)
+	for scanner.Scan() {
+		line := scanner.Text()
+		if i := strings.IndexByte(line, '#'); i >= 0 {
+			line = line[:i]
+		}
+		fields := strings.Fields(line)
+		if len(fields) == 0 {
+			continue
+		}
+		if _, err := path.Match(fields[0], ""); err != nil {
+			return nil, fmt.Errorf("%s: invalid test name %q: %w", fname, fields[0], err)
+		}
+		reason := strings.Join(fields[1:], " ")
+		if reason == "" {
+			reason = "flaky"
+		}
+		tests[fields[0]] = reason
+	}
+	return tests, scanner.Err()
+}
+
+///////////////
+// artifacts //
+///////////////
+
+func collectOnFailure(tb testing.TB) {
+	dir := os.Getenv(env.AIS.TestArtifacts)
+	if dir == "" {
+		return
+	}
+	tb.Cleanup(func() {
+		if !tb.Failed() {
+			return
+		}
+		tdir := filepath.Join(dir, strings.ReplaceAll(tb.Name(), "/", "_"))
+		if err := collectArtifacts(tdir); err != nil {
+			tlog.Logf("Warning: %s: failed to collect artifacts: %v\n", tb.Name(), err)
+		} else {
+			tlog.Logf("%s: artifacts collected in %s\n", tb.Name(), tdir)
+		}
+	})
+}
+
+func collectArtifacts(dir string) error {
+	if err := cos.CreateDir(dir); err != nil {
+		return err
+	}
+	bp := BaseAPIParams(GetPrimaryURL())
+	smap, err := api.GetClusterMap(bp)
+	if err != nil {
+		return err
+	}
+	if err := dumpJSON(filepath.Join(dir, "smap.json"), smap); err != nil {
+		return err
+	}
+	var bmd *meta.BMD
+	if bmd, err = api.GetBMD(bp); err == nil {
+		err = dumpJSON(filepath.Join(dir, "bmd.json"), bmd)
+	}
+	for _, nodeMap := range []meta.NodeMap{smap.Pmap, smap.Tmap} {
+		for _, node := range nodeMap {
+			if errV := dumpLog(bp, node, filepath.Join(dir, node.ID()+".log")); errV != nil {
+				err = errV
+			}
+		}
+	}
+	return err
+}
+
+func dumpJSON(fname string, v any) error {
+	return os.WriteFile(fname, cos.MustMarshal(v), cos.PermRWR)
+}
+
+func dumpLog(bp api.BaseParams, node *meta.Snode, fname string) error {
+	fh, err := os.Create(fname)
+	if err != nil {
+		return err
+	}
+	_, err = api.GetDaemonLog(bp, node, api.GetLogInput{Writer: fh})
+	cos.Close(fh)
+	return err
+}
@@ -87,6 +87,9 @@ var (
 	}
 	LoggedUserToken string
 
+	profile    *api.Profile // via AIS_PROFILE (see api/profile.go)
+	errProfile error        // (reported by InitCluster - not all tests need a cluster)
+
 	gctx g
 )
 
@@ -98,11 +101,20 @@ var (
 func init() {
 	gctx.Log = tlog.Logf
 
-	if cos.IsHTTPS(os.Getenv(env.AIS.Endpoint)) {
+	switch {
+	case cos.IsHTTPS(os.Getenv(env.AIS.Endpoint)):
 		// fill-in from env
 		cmn.EnvToTLS(&tlsArgs)
 		gctx.Client = cmn.NewClientTLS(transportArgs, tlsArgs, false /*intra-cluster*/)
-	} else {
+	case os.Getenv(env.AIS.Endpoint) == "" && os.Getenv(env.AIS.Profile) != "":
+		profile, errProfile = api.LoadProfile("")
+		if profile != nil && profile.IsHTTPS() {
+			tlsArgs = profile.TLSArgs()
+			gctx.Client = cmn.NewClientTLS(transportArgs, tlsArgs, false /*intra-cluster*/)
+		} else {
+			gctx.Client = cmn.NewClient(transportArgs)
+		}
+	default:
 		gctx.Client = cmn.NewClient(transportArgs)
 	}
 }
@@ -156,6 +168,8 @@ func InitLocalCluster() {
 			cliAISURL = "http://" + cliAISURL
 		}
 		proxyURL = cliAISURL
+	} else if profile != nil {
+		proxyURL = profile.Endpoint
 	}
 
 	err := InitCluster(proxyURL, clusterType)
@@ -185,7 +199,13 @@ func InitLocalCluster() {
 // InitCluster initializes the environment necessary for testing against an AIS cluster.
 // NOTE: the function is also used for testing by NVIDIA/ais-k8s Operator
 func InitCluster(proxyURL string, clusterType ClusterType) (err error) {
+	if errProfile != nil {
+		return errProfile
+	}
 	LoggedUserToken, _ = authn.LoadToken("") // ignore error as not all tests require token
+	if LoggedUserToken == "" && profile != nil {
+		LoggedUserToken, _ = profile.LoadToken()
+	}
 	proxyURLReadOnly = proxyURL
 	testClusterType = clusterType
 	if err = initProxyURL(); err != nil {
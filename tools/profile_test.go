@@ -0,0 +1,86 @@
+// Package tools provides common tools and utilities for all unit and integration tests
+/*
+ * Copyright (c) 2024, NVIDIA CORPORATION. All rights reserved.
+ */
+package tools_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/NVIDIA/aistore/api"
+	"github.com/NVIDIA/aistore/tools/tassert"
+)
+
+func TestLoadProfiles(t *testing.T) {
+	var (
+		dir       = t.TempDir()
+		fpath     = filepath.Join(dir, "profiles")
+		tokenFile = filepath.Join(dir, "prod.token")
+	)
+	tassert.CheckFatal(t, os.WriteFile(tokenFile, []byte(`{"token": "abc"}`), 0o600))
+	tassert.CheckFatal(t, os.WriteFile(fpath, []byte(`{
+		"default": "prod",
+		"profiles": {
+			"prod":  {"endpoint": "https://ais.example.com:51080", "token_file": "`+tokenFile+`", "ca": "/etc/ssl/ca.pem"},
+			"local": {"endpoint": "http://localhost:8080", "token": "xyz", "timeout": "2m", "dial_timeout": "5s"},
+			"none":  {"endpoint": "http://localhost:8080", "timeout": "-1s"}
+		}
+	}`), 0o600))
+
+	profiles, err := api.LoadProfiles(fpath)
+	tassert.CheckFatal(t, err)
+
+	// default
+	prof, err := profiles.Get("")
+	tassert.CheckFatal(t, err)
+	tassert.Fatalf(t, prof.Name == "prod" && prof.IsHTTPS(), "expected default https profile 'prod', got %+v", prof)
+	token, err := prof.LoadToken()
+	tassert.CheckFatal(t, err)
+	tassert.Errorf(t, token == "abc", "expected token from token_file, got %q", token)
+	tassert.Errorf(t, prof.TLSArgs().ClientCA == "/etc/ssl/ca.pem", "expected CA, got %+v", prof.TLSArgs())
+	tassert.Errorf(t, prof.TransportArgs().Timeout == 10*time.Minute, "expected default timeout, got %v", prof.TransportArgs().Timeout)
+
+	// by name
+	prof, err = profiles.Get("local")
+	tassert.CheckFatal(t, err)
+	token, err = prof.LoadToken()
+	tassert.CheckFatal(t, err)
+	tassert.Errorf(t, token == "xyz", "expected token value, got %q", token)
+	cargs := prof.TransportArgs()
+	tassert.Errorf(t, cargs.Timeout == 2*time.Minute && cargs.DialTimeout == 5*time.Second, "unexpected timeouts %+v", cargs)
+
+	prof, err = profiles.Get("none")
+	tassert.CheckFatal(t, err)
+	tassert.Errorf(t, prof.TransportArgs().Timeout == 0, "expected no timeout, got %v", prof.TransportArgs().Timeout)
+
+	_, err = profiles.Get("nonexistent")
+	tassert.Errorf(t, err != nil, "expected error for nonexistent profile")
+}
+
+func TestLoadProfilesInvalid(t *testing.T) {
+	dir := t.TempDir()
+	for i, content := range []string{
+		`{"profiles": {"p": {"endpoint": ""}}}`,
+		`{"profiles": {"p": {"endpoint": "ftp://host"}}}`,
+		`{"default": "q", "profiles": {"p": {"endpoint": "http://host"}}}`,
+		`{"profiles": {`,
+	} {
+		fpath := filepath.Join(dir, "profiles"+string(rune('0'+i)))
+		tassert.CheckFatal(t, os.WriteFile(fpath, []byte(content), 0o600))
+		_, err := api.LoadProfiles(fpath)
+		tassert.Errorf(t, err != nil, "%d: expected error loading %s", i, content)
+	}
+	_, err := api.LoadProfiles(filepath.Join(dir, "nonexistent"))
+	tassert.Errorf(t, err != nil, "expected error loading nonexistent profiles file")
+
+	// no default
+	fpath := filepath.Join(dir, "nodefault")
+	tassert.CheckFatal(t, os.WriteFile(fpath, []byte(`{"profiles": {"p": {"endpoint": "http://host"}}}`), 0o600))
+	profiles, err := api.LoadProfiles(fpath)
+	tassert.CheckFatal(t, err)
+	_, err = profiles.Get("")
+	tassert.Errorf(t, err != nil, "expected error: no profile specified and no default")
+}
@@ -78,6 +78,7 @@ type (
 		SGL        *memsys.SGL // When Type == sg
 		Path, Name string      // When Type == file; path and name of file to be created (if not already existing)
 		Size       int64
+		Prefix     []byte // When Type == sg: payload prefix, e.g. aisloader's generation stamp (must not exceed Size)
 	}
 )
 
@@ -227,7 +228,7 @@ func NewRandFile(filepath, name string, size int64, cksumType string) (Reader, e
 		}
 	} else {
 		// Write random file
-		cksumHash, err = copyRandWithHash(f, size, cksumType)
+		cksumHash, err = copyRandWithHash(f, size, cksumType, nil)
 	}
 	if err == nil {
 		_, err = f.Seek(0, io.SeekStart)
@@ -272,9 +273,13 @@ func (r *fileReader) Cksum() *cos.Cksum {
 //////////////
 
 func NewSG(sgl *memsys.SGL, size int64, cksumType string) (Reader, error) {
+	return newSG(sgl, size, cksumType, nil)
+}
+
+func newSG(sgl *memsys.SGL, size int64, cksumType string, prefix []byte) (Reader, error) {
 	var cksum *cos.Cksum
 	if size > 0 {
-		cksumHash, err := copyRandWithHash(sgl, size, cksumType)
+		cksumHash, err := copyRandWithHash(sgl, size, cksumType, prefix)
 		if err != nil {
 			return nil, err
 		}
@@ -348,7 +353,7 @@ func New(p Params, cksumType string) (Reader, error) {
 	switch p.Type {
 	case TypeSG:
 		debug.Assert(p.SGL != nil)
-		return NewSG(p.SGL, p.Size, cksumType)
+		return newSG(p.SGL, p.Size, cksumType, p.Prefix)
 	case TypeRand:
 		return NewRand(p.Size, cksumType)
 	case TypeFile:
@@ -361,9 +366,9 @@ func New(p Params, cksumType string) (Reader, error) {
 }
 
 // copyRandWithHash reads data from random source and writes it to a writer while
-// optionally computing xxhash
+// optionally computing xxhash; the (optional) prefix replaces the leading random bytes
 // See related: memsys_test.copyRand
-func copyRandWithHash(w io.Writer, size int64, cksumType string) (*cos.CksumHash, error) {
+func copyRandWithHash(w io.Writer, size int64, cksumType string, prefix []byte) (*cos.CksumHash, error) {
 	var (
 		cksum   *cos.CksumHash
 		rem     = size
@@ -378,6 +383,9 @@ func copyRandWithHash(w io.Writer, size int64, cksumType string) (*cos.CksumHash
 	for i := int64(0); i <= size/blkSize; i++ {
 		n := int(min(blkSize, rem))
 		cryptorand.Read(buf[:n])
+		if i == 0 && len(prefix) > 0 {
+			copy(buf[:n], prefix)
+		}
 		m, err := w.Write(buf[:n])
 		if err != nil {
 			return nil, err
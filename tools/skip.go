@@ -46,6 +46,7 @@ func ShortSkipf(tb testing.TB, a ...any) {
 
 func CheckSkip(tb testing.TB, args *SkipTestArgs) {
 	var smap *meta.Smap
+	CheckQuarantine(tb)
 	if args.RequiresRemoteCluster && RemoteCluster.UUID == "" {
 		tb.Skipf("%s requires remote cluster", tb.Name())
 	}
@@ -132,4 +133,6 @@ func CheckSkip(tb testing.TB, args *SkipTestArgs) {
 			tb.Skipf("%s requires at least %d mountpaths (have %d)", tb.Name(), args.MinMountpaths, l)
 		}
 	}
+
+	collectOnFailure(tb)
 }
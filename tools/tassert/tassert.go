@@ -16,6 +16,11 @@ import (
 	"time"
 )
 
+const (
+	retryMinSleep = 100 * time.Millisecond
+	retryMaxSleep = 5 * time.Second
+)
+
 var (
 	fatalities = make(map[string]struct{})
 	mu         sync.Mutex
@@ -87,6 +92,29 @@ func Errorf(tb testing.TB, cond bool, format string, args ...any) {
 	}
 }
 
+// CheckRetry calls `f` until it succeeds, fails with an error that is not `transient`,
+// or `timeout` expires - and then, unless succeeded, fails the test with the last error.
+// In between retries, backs off exponentially.
+func CheckRetry(tb testing.TB, timeout time.Duration, transient func(error) bool, f func() error) {
+	var (
+		err      error
+		sleep    = retryMinSleep
+		deadline = time.Now().Add(timeout)
+	)
+	for i := 1; ; i++ {
+		if err = f(); err == nil {
+			return
+		}
+		if !transient(err) || time.Now().Add(sleep).After(deadline) {
+			break
+		}
+		fmt.Printf("--- %s: retry #%d in %v: %v\n", tb.Name(), i, sleep, err)
+		time.Sleep(sleep)
+		sleep = min(2*sleep, retryMaxSleep)
+	}
+	CheckFatal(tb, err)
+}
+
 // TODO: Make this a range over `errCh` post closing it ?
 func SelectErr(tb testing.TB, errCh chan error, verb string, errIsFatal bool) {
 	if num := len(errCh); num > 0 {
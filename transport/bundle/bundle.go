@@ -386,9 +386,11 @@ func (sb *Streams) Resync() {
 			continue
 		}
 
-		dstURL := si.URL(sb.network) + transport.ObjURLPath(sb.trname) // direct destination URL
 		nrobin := &robin{stsdest: make(stsdest, sb.multiplier)}
 		for k := range sb.multiplier {
+			// direct destination URL (multi-homed peer: next data endpoint for each stream;
+			// NOTE: striping across peer's data NICs only when multiplier > 1)
+			dstURL := si.URL(sb.network) + transport.ObjURLPath(sb.trname)
 			ns := transport.NewObjStream(sb.client, dstURL, id /*dstID*/, &sb.extra)
 			nrobin.stsdest[k] = ns
 		}
@@ -34,6 +34,21 @@ func dialTimeout(addr string) (net.Conn, error) {
 	return fasthttp.DialTimeout(addr, 10*time.Second)
 }
 
+// ditto, with DSCP marking (see cmn.DSCPConf)
+func dialTOS(addr string, tos int) (net.Conn, error) {
+	conn, err := dialTimeout(addr)
+	if err != nil {
+		return nil, err
+	}
+	if tcpconn, ok := conn.(*net.TCPConn); ok {
+		if rawconn, err := tcpconn.SyscallConn(); err == nil {
+			args := cmn.TransportArgs{TOS: tos}
+			rawconn.Control(args.ConnControl(rawconn))
+		}
+	}
+	return conn, nil
+}
+
 // intra-cluster networking: fasthttp client
 func NewIntraDataClient() Client {
 	config := cmn.GCO.Get()
@@ -51,6 +66,9 @@ func NewIntraDataClient() Client {
 		ReadBufferSize:  rbuf,
 		WriteBufferSize: wbuf,
 	}
+	if tos := config.NetTOS(cmn.NetIntraData); tos > 0 {
+		cl.Dial = func(addr string) (net.Conn, error) { return dialTOS(addr, tos) }
+	}
 	if config.Net.HTTP.UseHTTPS {
 		tlsConfig, err := cmn.NewTLS(config.Net.HTTP.ToTLS(), true /*intra-cluster*/) // streams
 		if err != nil {
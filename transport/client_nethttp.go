@@ -46,6 +46,7 @@ func NewIntraDataClient() (client *http.Client) {
 		SndRcvBufSize:   tcpbuf,
 		WriteBufferSize: wbuf,
 		ReadBufferSize:  rbuf,
+		TOS:             config.NetTOS(cmn.NetIntraData),
 	}
 	if config.Net.HTTP.UseHTTPS {
 		client = cmn.NewClientTLS(cargs, config.Net.HTTP.ToTLS(), true /*intra-cluster*/) // streams
@@ -0,0 +1,96 @@
+// Package volume provides volume (a.k.a. pool of disks) abstraction and methods to configure, store,
+// and validate the corresponding metadata. AIS volume is built on top of mountpaths (fs package).
+/*
+ * Copyright (c) 2024, NVIDIA CORPORATION. All rights reserved.
+ */
+package volume
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+
+	"github.com/NVIDIA/aistore/cmn/cos"
+	"github.com/NVIDIA/aistore/fs"
+	"github.com/OneOfOne/xxhash"
+)
+
+// Resilver fast-path.
+// Volume layout - the set of available mountpaths - determines HRW placement of objects.
+// Upon completing the traversal of a given mountpath, resilver records the mountpath's fingerprint:
+// (layout, mountpath's filesystem ID) digest. At startup, only mountpaths with a different (or no)
+// fingerprint may contain misplaced objects - in particular, an interrupted resilver
+// resumes only on the mountpaths it did not get to finish.
+
+// Layout returns the digest of the current volume layout.
+func Layout() string {
+	var (
+		avail = fs.GetAvail()
+		paths = make([]string, 0, len(avail))
+	)
+	for mpath := range avail {
+		paths = append(paths, mpath)
+	}
+	sort.Strings(paths)
+	h := xxhash.New64()
+	for _, mpath := range paths {
+		h.WriteString(mpath)
+		h.WriteString("\x00")
+	}
+	return strconv.FormatUint(h.Sum64(), 36)
+}
+
+func mpathFingerprint(layout string, mi *fs.Mountpath) string {
+	return fmt.Sprintf("%s-%x-%x", layout, mi.FsID[0], mi.FsID[1])
+}
+
+// ResilverScope returns available mountpaths that must be resilvered (see above).
+func ResilverScope(tid string) (cos.StrSet, error) {
+	vmdMu.Lock()
+	defer vmdMu.Unlock()
+	vmd, err := loadVMD(tid, nil)
+	if err != nil {
+		return nil, err
+	}
+	if vmd == nil {
+		return nil, errors.New("VMD not found")
+	}
+	var (
+		avail  = fs.GetAvail()
+		layout = Layout()
+		scope  = make(cos.StrSet, len(avail))
+	)
+	for mpath, mi := range avail {
+		md, ok := vmd.Mountpaths[mpath]
+		if !ok || md.Fingerprint != mpathFingerprint(layout, mi) {
+			scope.Add(mpath)
+		}
+	}
+	return scope, nil
+}
+
+// MarkResilvered records that a given mountpath has been traversed by resilver
+// that started with the specified layout - unless the layout has changed since.
+func MarkResilvered(tid, layout string, mi *fs.Mountpath) error {
+	vmdMu.Lock()
+	defer vmdMu.Unlock()
+	if layout != Layout() {
+		return nil
+	}
+	vmd, err := loadVMD(tid, nil)
+	if err != nil || vmd == nil {
+		return err
+	}
+	md, ok := vmd.Mountpaths[mi.Path]
+	if !ok || !md.Enabled {
+		return nil
+	}
+	fp := mpathFingerprint(layout, mi)
+	if md.Fingerprint == fp {
+		return nil
+	}
+	md.Fingerprint = fp
+	vmd.Version++ // (all mountpaths must carry the same VMD version)
+	return vmd.persist()
+}
@@ -98,6 +98,8 @@ func NewFromMPI(tid string) (vmd *VMD, err error) {
 		curVersion          uint64
 		available, disabled = fs.Get()
 	)
+	vmdMu.Lock()
+	defer vmdMu.Unlock()
 	vmd, err = loadVMD(tid, nil)
 	if err != nil {
 		nlog.Warningln(err) // TODO: handle
@@ -162,6 +164,9 @@ func initMPI(tid string, config *cmn.Config, blockDevs ios.BlockDevices, vmd *VM
 	for mpath, fsMpathMD := range vmd.Mountpaths {
 		var mi *fs.Mountpath
 		mi, err = fs.NewMountpath(mpath, fsMpathMD.Label)
+		if mi != nil && fsMpathMD.Digest != 0 {
+			mi.PathDigest = fsMpathMD.Digest // (replaced mountpath)
+		}
 		if !fsMpathMD.Enabled {
 			if pass == 2 {
 				mi.Fs = fsMpathMD.Fs
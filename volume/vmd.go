@@ -8,6 +8,7 @@ package volume
 import (
 	"fmt"
 	"path/filepath"
+	"sync"
 
 	"github.com/NVIDIA/aistore/cmn"
 	"github.com/NVIDIA/aistore/cmn/cos"
@@ -30,6 +31,13 @@ type (
 		FsType  string    `json:"fs_type"`
 		FsID    cos.FsID  `json:"fs_id"`
 		Enabled bool      `json:"enabled"`
+
+		// volume layout fingerprint as of the most recent completed traversal of this mountpath
+		// by resilver (see Fingerprint and ResilverScope)
+		Fingerprint string `json:"fingerprint,omitempty"`
+
+		// HRW digest inherited from the replaced mountpath, if any (see fs.ReplaceMpath)
+		Digest uint64 `json:"digest,string,omitempty"`
 	}
 
 	// VMD is AIS target's volume metadata structure
@@ -42,6 +50,9 @@ type (
 	}
 )
 
+// serializes runtime VMD updates
+var vmdMu sync.Mutex
+
 func _mpathGreaterEq(curr, prev *VMD, mpath string) bool {
 	currMd, currOk := curr.Mountpaths[mpath]
 	prevMd, prevOk := prev.Mountpaths[mpath]
@@ -71,7 +82,7 @@ func (*VMD) JspOpts() jsp.Options {
 }
 
 func (vmd *VMD) addMountpath(mi *fs.Mountpath, enabled bool) {
-	vmd.Mountpaths[mi.Path] = &fsMpathMD{
+	md := &fsMpathMD{
 		Path:    mi.Path,
 		Label:   mi.Label,
 		Fs:      mi.Fs,
@@ -79,6 +90,10 @@ func (vmd *VMD) addMountpath(mi *fs.Mountpath, enabled bool) {
 		FsID:    mi.FsID,
 		Enabled: enabled,
 	}
+	if mi.InheritedDigest() {
+		md.Digest = mi.PathDigest
+	}
+	vmd.Mountpaths[mi.Path] = md
 }
 
 func (vmd *VMD) load(mpath string) (err error) {
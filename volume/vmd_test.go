@@ -25,6 +25,8 @@ func TestVMD(t *testing.T) {
 
 	t.Run("CreateNewVMD", func(t *testing.T) { testVMDCreate(t, mpaths, daemonID) })
 	t.Run("VMDPersist", func(t *testing.T) { testVMDPersist(t, daemonID) })
+	t.Run("ResilverScope", func(t *testing.T) { testResilverScope(t, mpaths, daemonID) })
+	t.Run("InheritedDigest", func(t *testing.T) { testVMDInheritedDigest(t, mpaths, daemonID) })
 }
 
 func testVMDCreate(t *testing.T, mpaths fs.MPI, daemonID string) {
@@ -56,3 +58,67 @@ func testVMDPersist(t *testing.T, daemonID string) {
 	tassert.Errorf(t, reflect.DeepEqual(newVMD.Mountpaths, vmd.Mountpaths),
 		"expected VMDs to be equal. got: %+v vs %+v", newVMD, vmd)
 }
+
+func testResilverScope(t *testing.T, mpaths fs.MPI, daemonID string) {
+	_, err := volume.NewFromMPI(daemonID)
+	tassert.CheckFatal(t, err)
+
+	scope, err := volume.ResilverScope(daemonID)
+	tassert.CheckFatal(t, err)
+	tassert.Errorf(t, len(scope) == len(mpaths), "expected all %d mountpaths in scope, got %d", len(mpaths), len(scope))
+
+	var (
+		layout = volume.Layout()
+		done   = 0
+	)
+	for _, mi := range mpaths {
+		vmd, err := volume.LoadVMDTest()
+		tassert.CheckFatal(t, err)
+		tassert.CheckFatal(t, volume.MarkResilvered(daemonID, layout, mi))
+		nvmd, err := volume.LoadVMDTest()
+		tassert.CheckFatal(t, err)
+		tassert.Errorf(t, nvmd.Version == vmd.Version+1, "expected VMD version %d, got %d", vmd.Version+1, nvmd.Version)
+		if done++; done == len(mpaths)/2 {
+			break
+		}
+	}
+	// stale layout: no-op
+	for _, mi := range mpaths {
+		tassert.CheckFatal(t, volume.MarkResilvered(daemonID, "stale", mi))
+	}
+	scope, err = volume.ResilverScope(daemonID)
+	tassert.CheckFatal(t, err)
+	tassert.Errorf(t, len(scope) == len(mpaths)-done, "expected %d mountpaths in scope, got %d", len(mpaths)-done, len(scope))
+
+	// new VMD version (e.g., upon attach) resets all
+	_, err = volume.NewFromMPI(daemonID)
+	tassert.CheckFatal(t, err)
+	scope, err = volume.ResilverScope(daemonID)
+	tassert.CheckFatal(t, err)
+	tassert.Errorf(t, len(scope) == len(mpaths), "expected all %d mountpaths in scope, got %d", len(mpaths), len(scope))
+}
+
+// (see fs.ReplaceMpath)
+func testVMDInheritedDigest(t *testing.T, mpaths fs.MPI, daemonID string) {
+	var replaced *fs.Mountpath
+	for _, mi := range mpaths {
+		replaced = mi
+		break
+	}
+	orig := replaced.PathDigest
+	replaced.PathDigest = orig + 1
+	defer func() { replaced.PathDigest = orig }()
+
+	_, err := volume.NewFromMPI(daemonID)
+	tassert.CheckFatal(t, err)
+	vmd, err := volume.LoadVMDTest()
+	tassert.CheckFatal(t, err)
+
+	for mpath, md := range vmd.Mountpaths {
+		if mpath == replaced.Path {
+			tassert.Errorf(t, md.Digest == orig+1, "%q: expected inherited digest %d, got %d", mpath, orig+1, md.Digest)
+		} else {
+			tassert.Errorf(t, md.Digest == 0, "%q: unexpected digest %d", mpath, md.Digest)
+		}
+	}
+}
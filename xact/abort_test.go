@@ -0,0 +1,48 @@
+// Package xact provides core functionality for the AIStore eXtended Actions (xactions).
+/*
+ * Copyright (c) 2024, NVIDIA CORPORATION. All rights reserved.
+ */
+package xact_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/NVIDIA/aistore/api/apc"
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/cmn/cos"
+	"github.com/NVIDIA/aistore/core"
+	"github.com/NVIDIA/aistore/tools/tassert"
+	"github.com/NVIDIA/aistore/xact"
+)
+
+func TestAbortReason(t *testing.T) {
+	cos.InitShortID(0)
+	abort := func(err error) *core.Snap {
+		xctn := &xact.Base{}
+		xctn.InitBase(cos.GenUUID(), apc.ActCopyBck, nil)
+		tassert.Fatalf(t, xctn.Abort(err), "expected to abort")
+		snap := &core.Snap{}
+		xctn.ToSnap(snap)
+		tassert.Fatalf(t, snap.AbortReason != nil, "expected abort reason in the snapshot")
+		return snap
+	}
+
+	// user
+	snap := abort(nil)
+	tassert.Errorf(t, snap.AbortReason.Initiator == cmn.AbortByUser, "expected user-initiated, got %+v", snap.AbortReason)
+	tassert.Errorf(t, snap.AbortErr == cmn.ErrXactUserAbort.Error(), "unexpected abort-err %q", snap.AbortErr)
+
+	// offending object
+	errIO := errors.New("input/output error")
+	snap = abort(cmn.NewErrWithObject(errIO, "ais://src/a/b"))
+	reason := snap.AbortReason
+	tassert.Errorf(t, reason.Object == "ais://src/a/b", "expected offending object, got %+v", reason)
+	tassert.Errorf(t, len(reason.Chain) == 1 && reason.Chain[0] == errIO.Error(), "unexpected error chain %v", reason.Chain)
+
+	// propagated from another node: the original reason prevails
+	orig := &cmn.AbortReason{Initiator: "t[abc]", Cause: "out of space", Object: "ais://dst/c"}
+	snap = abort(cmn.WithAbortReason(cmn.ErrXactICNotifAbort, orig))
+	tassert.Errorf(t, snap.AbortReason == orig, "expected %+v, got %+v", orig, snap.AbortReason)
+	tassert.Errorf(t, snap.AbortErr == cmn.ErrXactICNotifAbort.Error(), "unexpected abort-err %q", snap.AbortErr)
+}
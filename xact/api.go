@@ -40,6 +40,7 @@ const (
 	MaxProbingFreq   = 30 * time.Second   // as the name implies
 	MinPollTime      = 2 * time.Second    // ditto
 	MaxPollTime      = 2 * time.Minute    // can grow up to
+	MaxLongPoll      = time.Minute        // max server-side wait per (single) status request (see apc.QparamWait)
 
 	// number of consecutive 'idle' xaction states, with possible numeric
 	// values translating as follows:
@@ -49,6 +50,12 @@ const (
 	NumConsecutiveIdle = 2
 )
 
+// priority classes (see Descriptor.Prio)
+const (
+	PrioNormal     = iota // (default)
+	PrioBackground        // preemptible: pauses under foreground (user) load - see xreg/preempt.go
+)
+
 type (
 	// either xaction ID or Kind must be specified
 	// is getting passed via ActMsg.Value w/ MorphMarshal extraction
@@ -63,6 +70,8 @@ type (
 		Timeout     time.Duration // max time to wait
 		Force       bool          // force
 		OnlyRunning bool          // only for running xactions
+
+		Reason *cmn.AbortReason // (stop) propagated reason of abort
 	}
 
 	// simplified JSON-tagged version of the above
@@ -73,6 +82,7 @@ type (
 		Kind        string    `json:"kind"`
 		DaemonID    string    `json:"node,omitempty"`
 		Buckets     []cmn.Bck `json:"buckets,omitempty"`
+		Log         bool      `json:"log,omitempty"` // include captured log events (core.Snap.Log)
 	}
 
 	// primarily: `api.QueryXactionSnaps`
@@ -101,6 +111,9 @@ type (
 		// xaction returns extended xaction-specific stats
 		// (see related: `Snap.Ext` in core/xaction.go)
 		ExtendedStats bool
+
+		// priority class: PrioNormal or PrioBackground (the enum above)
+		Prio int
 	}
 )
 
@@ -121,8 +134,8 @@ var Table = map[string]Descriptor{
 	apc.ActETLInline: {Scope: ScopeG, Startable: false, AbortRebRes: true},
 
 	// (one bucket) | (all buckets)
-	apc.ActLRU:          {DisplayName: "lru-eviction", Scope: ScopeGB, Startable: true},
-	apc.ActStoreCleanup: {DisplayName: "cleanup", Scope: ScopeGB, Startable: true},
+	apc.ActLRU:          {DisplayName: "lru-eviction", Scope: ScopeGB, Startable: true, Prio: PrioBackground},
+	apc.ActStoreCleanup: {DisplayName: "cleanup", Scope: ScopeGB, Startable: true, Prio: PrioBackground},
 	apc.ActSummaryBck: {
 		DisplayName: "summary",
 		Scope:       ScopeGB,
@@ -131,8 +144,34 @@ var Table = map[string]Descriptor{
 		Metasync:    false,
 	},
 
+	// abort incomplete multipart uploads and appends, remove their leftovers
+	apc.ActAbortIncomplete: {Scope: ScopeGB, Access: apc.AceObjDELETE, Startable: true},
+
+	// remove destroyed bucket's data (in the background)
+	apc.ActReclaimBck: {DisplayName: "reclaim-space", Scope: ScopeGB, Startable: false, RefreshCap: true, Prio: PrioBackground},
+
 	// single target (node)
 	apc.ActResilver: {Scope: ScopeT, Startable: true, Resilver: true},
+	apc.ActBurnIn:   {Scope: ScopeT, Startable: false, ConflictRebRes: true, ExtendedStats: true},
+
+	// old disk => new disk (see apc.MpathReplaceMsg)
+	apc.ActMountpathReplace: {
+		DisplayName:    "replace-mountpath",
+		Scope:          ScopeT,
+		Startable:      false,
+		ConflictRebRes: true,
+		ExtendedStats:  true,
+	},
+
+	// misplaced objects, stale copies, and orphaned workfiles (see apc.AuditReport)
+	apc.ActAuditPlacement: {
+		DisplayName:    "audit",
+		Scope:          ScopeGB,
+		Startable:      true,
+		ConflictRebRes: true,
+		ExtendedStats:  true,
+		Prio:           PrioBackground,
+	},
 
 	// on-demand EC and n-way replication
 	// (non-startable, triggered by PUT => erasure-coded or mirrored bucket)
@@ -260,6 +299,12 @@ var Table = map[string]Descriptor{
 	// cache management, internal usage
 	apc.ActLoadLomCache:   {DisplayName: "warm-up-metadata", Scope: ScopeB, Startable: true},
 	apc.ActInvalListCache: {Scope: ScopeB, Access: apc.AceObjLIST, Startable: false},
+
+	// soft delete: remove expired (or, when forced, all) soft-deleted objects
+	apc.ActPurgeTrash: {Scope: ScopeB, Access: apc.AceObjDELETE, Startable: true, Prio: PrioBackground},
+
+	// custom metadata: xattr <=> sidecar (see feat.CustomMDSidecar)
+	apc.ActMigrateCustomMD: {Scope: ScopeB, Access: apc.AceObjUpdate, Startable: true},
 }
 
 func IsValidKind(kind string) bool {
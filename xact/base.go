@@ -34,9 +34,10 @@ type (
 		sutime atomic.Int64
 		eutime atomic.Int64
 		abort  struct {
-			ch   chan error
-			err  ratomic.Pointer[error]
-			done atomic.Bool
+			ch     chan error
+			err    ratomic.Pointer[error]
+			reason ratomic.Pointer[cmn.AbortReason]
+			done   atomic.Bool
 		}
 		stats struct {
 			objs     atomic.Int64 // locally processed
@@ -45,8 +46,20 @@ type (
 			outbytes atomic.Int64
 			inobjs   atomic.Int64 // receive
 			inbytes  atomic.Int64
+			// resource accounting
+			rbytes atomic.Int64 // disk read
+			wbytes atomic.Int64 // disk write
+			nbytes atomic.Int64 // network (both directions)
+			cpu    atomic.Int64 // nanoseconds
 		}
-		err cos.Errs
+		// background (preemptible) xactions - see xreg/preempt.go
+		preempt struct {
+			since atomic.Int64 // paused since (Unix nanoseconds); zero when not paused
+			total atomic.Int64 // cumulative paused time, not counting the current pause
+			cnt   atomic.Int64 // number of pauses
+		}
+		err  cos.Errs
+		xlog xlog // captured log events (see xlog.go)
 	}
 	Marked struct {
 		Xact        core.Xact
@@ -132,6 +145,9 @@ func (xctn *Base) AbortErr() error {
 	return cmn.NewErrAborted(xctn.Name(), "base.abort-err.timeout", nil)
 }
 
+// AbortReason returns the reason of abort (nil if not aborted).
+func (xctn *Base) AbortReason() *cmn.AbortReason { return xctn.abort.reason.Load() }
+
 func (xctn *Base) AbortedAfter(d time.Duration) (err error) {
 	sleep := cos.ProbingFrequency(d)
 	for elapsed := time.Duration(0); elapsed < d; elapsed += sleep {
@@ -148,19 +164,28 @@ func (xctn *Base) Abort(err error) bool {
 		return false
 	}
 
+	var reason *cmn.AbortReason
 	if err == nil {
 		err = cmn.ErrXactUserAbort // NOTE: only user can cause no-errors abort
-	} else if errAborted := cmn.AsErrAborted(err); errAborted != nil {
-		if errCause := errAborted.Unwrap(); errCause != nil {
-			err = errCause
+	} else {
+		reason, err = cmn.AsAbortReason(err) // propagated from another node
+		if errAborted := cmn.AsErrAborted(err); errAborted != nil {
+			if errCause := errAborted.Unwrap(); errCause != nil {
+				err = errCause
+			}
 		}
 	}
+	if reason == nil {
+		reason = cmn.NewAbortReason(err)
+	}
+	xctn.abort.reason.Store(reason)
 	perr := xctn.abort.err.Swap(&err)
 	debug.Assert(perr == nil, xctn.String())
 	debug.Assert(len(xctn.abort.ch) == 0, xctn.String()) // CAS above
 
 	xctn.abort.ch <- err
 	close(xctn.abort.ch)
+	xctn.xlog.add(core.XlogError, "", "aborted: "+err.Error())
 
 	if xctn.Kind() != apc.ActList {
 		nlog.InfoDepth(1, xctn.Name(), err)
@@ -179,6 +204,7 @@ func (xctn *Base) AddErr(err error, logExtra ...int) {
 	debug.Assert(err != nil)
 
 	xctn.err.Add(err)
+	xctn.xlog.add(core.XlogError, "", err.Error())
 	// just add
 	if len(logExtra) == 0 {
 		return
@@ -316,6 +342,7 @@ func (xctn *Base) Finish() {
 		return
 	}
 	xctn.eutime.Store(time.Now().UnixNano())
+	xctn.Resume()
 	if aborted = xctn.IsAborted(); aborted {
 		if perr := xctn.abort.err.Load(); perr != nil {
 			err = *perr
@@ -363,6 +390,7 @@ func (xctn *Base) OutObjsAdd(cnt int, size int64) {
 	xctn.stats.outobjs.Add(int64(cnt))
 	if size > 0 { // not unsized
 		xctn.stats.outbytes.Add(size)
+		xctn.stats.nbytes.Add(size)
 	}
 }
 
@@ -374,6 +402,79 @@ func (xctn *Base) InObjsAdd(cnt int, size int64) {
 	debug.Assert(size >= 0, xctn.String()) // "unsized" is caller's responsibility
 	xctn.stats.inobjs.Add(int64(cnt))
 	xctn.stats.inbytes.Add(size)
+	xctn.stats.nbytes.Add(size)
+}
+
+// resource accounting: disk and network bytes, CPU time
+// (transmit and receive (above) are counted as network traffic automatically)
+func (xctn *Base) DiskReadAdd(size int64)  { xctn.stats.rbytes.Add(size) }
+func (xctn *Base) DiskWriteAdd(size int64) { xctn.stats.wbytes.Add(size) }
+func (xctn *Base) NetAdd(size int64)       { xctn.stats.nbytes.Add(size) }
+func (xctn *Base) CPUAdd(d time.Duration)  { xctn.stats.cpu.Add(int64(d)) }
+
+// cumulative I/O activity (bytes) - the basis for CPU attribution
+func (xctn *Base) ResActivity() int64 {
+	return xctn.stats.rbytes.Load() + xctn.stats.wbytes.Load() + xctn.stats.nbytes.Load()
+}
+
+func (xctn *Base) ToRes(res *core.Res) {
+	res.DiskRead = xctn.stats.rbytes.Load()
+	res.DiskWrite = xctn.stats.wbytes.Load()
+	res.Net = xctn.stats.nbytes.Load()
+	res.CPU = xctn.stats.cpu.Load()
+}
+
+//
+// preemption: background xactions pause (yield) under foreground load
+//
+
+const yieldSleep = 100 * time.Millisecond
+
+// Preempt pauses the xaction (see xreg/preempt.go) - the pause takes effect
+// at the xaction's next safe point (see Yield)
+func (xctn *Base) Preempt(reason string) bool {
+	if xctn.Finished() || !xctn.preempt.since.CAS(0, time.Now().UnixNano()) {
+		return false
+	}
+	if xctn.Finished() { // (racing with Finish)
+		xctn.preempt.since.Store(0)
+		return false
+	}
+	xctn.preempt.cnt.Inc()
+	xctn.xlog.add(core.XlogInfo, "", "paused: "+reason)
+	nlog.Infoln(xctn.Name(), "paused:", reason)
+	return true
+}
+
+func (xctn *Base) Resume() bool {
+	since := xctn.preempt.since.Swap(0)
+	if since == 0 {
+		return false
+	}
+	d := time.Duration(time.Now().UnixNano() - since)
+	xctn.preempt.total.Add(int64(d))
+	xctn.xlog.add(core.XlogInfo, "", "resumed after "+d.String())
+	nlog.Infoln(xctn.Name(), "resumed after", d)
+	return true
+}
+
+func (xctn *Base) IsPaused() bool { return xctn.preempt.since.Load() != 0 }
+
+// Yield blocks while the xaction is paused; to be called by background xactions
+// between units of work (e.g., objects) and without holding any locks
+func (xctn *Base) Yield() {
+	for xctn.IsPaused() && !xctn.IsAborted() {
+		time.Sleep(yieldSleep)
+	}
+}
+
+// cumulative paused time (including the current pause, if any) and number of pauses
+func (xctn *Base) pausedTime() (time.Duration, int64) {
+	total := xctn.preempt.total.Load()
+	if since := xctn.preempt.since.Load(); since != 0 {
+		total += time.Now().UnixNano() - since
+	}
+	return time.Duration(total), xctn.preempt.cnt.Load()
 }
 
 // provided for external use to fill-in xaction-specific `SnapExt` part
@@ -384,15 +485,20 @@ func (xctn *Base) ToSnap(snap *core.Snap) {
 	snap.EndTime = xctn.EndTime()
 	if err := xctn.AbortErr(); err != nil {
 		snap.AbortErr = err.Error()
+		snap.AbortReason = xctn.AbortReason()
 		snap.AbortedX = true
 	}
 	snap.Err = xctn.err.Error() // TODO: a (verbose) option to respond with xctn.err.JoinErr() :NOTE
+	snap.Log, snap.LogDropped = xctn.xlog.get()
+	snap.PausedX = xctn.IsPaused()
+	snap.PausedTime, snap.PausedCnt = xctn.pausedTime()
 	if b := xctn.Bck(); b != nil {
 		snap.Bck = b.Clone()
 	}
 
 	// counters
 	xctn.ToStats(&snap.Stats)
+	xctn.ToRes(&snap.Res)
 }
 
 func (xctn *Base) ToStats(stats *core.Stats) {
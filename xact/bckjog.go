@@ -24,6 +24,9 @@ func (r *BckJog) Init(id, kind string, bck *meta.Bck, opts *mpather.JgroupOpts,
 
 func (r *BckJog) Run() { r.joggers.Run() }
 
+// (requires mpather.JgroupOpts.Sorted)
+func (r *BckJog) Progress() map[string]string { return r.joggers.Progress() }
+
 func (r *BckJog) Wait() error {
 	select {
 	case errCause := <-r.ChanAbort():
@@ -0,0 +1,87 @@
+// Package xact provides core functionality for the AIStore eXtended Actions (xactions).
+/*
+ * Copyright (c) 2024, NVIDIA CORPORATION. All rights reserved.
+ */
+package xact_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/NVIDIA/aistore/api/apc"
+	"github.com/NVIDIA/aistore/cmn/cos"
+	"github.com/NVIDIA/aistore/core"
+	"github.com/NVIDIA/aistore/tools/tassert"
+	"github.com/NVIDIA/aistore/xact"
+)
+
+func TestPreemptResume(t *testing.T) {
+	cos.InitShortID(0)
+	tassert.Fatalf(t, xact.Table[apc.ActLRU].Prio == xact.PrioBackground, "expected LRU to be background")
+	tassert.Fatalf(t, xact.Table[apc.ActCopyBck].Prio == xact.PrioNormal, "expected copy-bucket to be normal")
+
+	xctn := &xact.Base{}
+	xctn.InitBase(cos.GenUUID(), apc.ActLRU, nil)
+
+	tassert.Fatalf(t, xctn.Preempt("testing"), "expected to pause")
+	tassert.Fatalf(t, !xctn.Preempt("testing"), "expected no-op when already paused")
+
+	yielded := make(chan struct{})
+	go func() {
+		xctn.Yield()
+		close(yielded)
+	}()
+	select {
+	case <-yielded:
+		t.Fatal("expected to yield while paused")
+	case <-time.After(300 * time.Millisecond):
+	}
+
+	snap := &core.Snap{}
+	xctn.ToSnap(snap)
+	tassert.Errorf(t, snap.IsPaused() && snap.PausedCnt == 1, "expected paused once, got %+v", snap)
+	tassert.Errorf(t, snap.PausedTime >= 300*time.Millisecond, "expected paused time >= 300ms, got %v", snap.PausedTime)
+
+	tassert.Fatalf(t, xctn.Resume(), "expected to resume")
+	select {
+	case <-yielded:
+	case <-time.After(time.Second):
+		t.Fatal("expected to stop yielding upon resume")
+	}
+	tassert.Errorf(t, !xctn.Resume(), "expected no-op when not paused")
+
+	snap = &core.Snap{}
+	xctn.ToSnap(snap)
+	tassert.Errorf(t, !snap.IsPaused() && snap.PausedCnt == 1, "expected resumed, got %+v", snap)
+
+	var found bool
+	for _, e := range snap.Log {
+		found = found || e.Msg == "paused: testing"
+	}
+	tassert.Errorf(t, found, "expected captured pause event, got %+v", snap.Log)
+}
+
+func TestPreemptAbort(t *testing.T) {
+	cos.InitShortID(0)
+	xctn := &xact.Base{}
+	xctn.InitBase(cos.GenUUID(), apc.ActStoreCleanup, nil)
+
+	xctn.Preempt("testing")
+	yielded := make(chan struct{})
+	go func() {
+		xctn.Yield()
+		close(yielded)
+	}()
+	xctn.Abort(errors.New("testing"))
+	select {
+	case <-yielded:
+	case <-time.After(time.Second):
+		t.Fatal("expected to stop yielding upon abort")
+	}
+
+	xact.IncFinished = func() {}
+	xctn.Finish()
+	tassert.Errorf(t, !xctn.IsPaused(), "expected finished xaction not to be paused")
+	tassert.Errorf(t, !xctn.Preempt("testing"), "expected finished xaction not to pause")
+}
@@ -0,0 +1,41 @@
+// Package xact provides core functionality for the AIStore eXtended Actions (xactions).
+/*
+ * Copyright (c) 2024, NVIDIA CORPORATION. All rights reserved.
+ */
+package xact_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/NVIDIA/aistore/api/apc"
+	"github.com/NVIDIA/aistore/cmn/cos"
+	"github.com/NVIDIA/aistore/core"
+	"github.com/NVIDIA/aistore/tools/tassert"
+	"github.com/NVIDIA/aistore/xact"
+)
+
+func TestResAccounting(t *testing.T) {
+	cos.InitShortID(0)
+	xctn := &xact.Base{}
+	xctn.InitBase(cos.GenUUID(), apc.ActCopyBck, nil)
+
+	xctn.OutObjsAdd(1, 100) // transmit and receive count as network traffic
+	xctn.InObjsAdd(1, 200)
+	xctn.NetAdd(50)
+	xctn.DiskReadAdd(1000)
+	xctn.DiskWriteAdd(2000)
+	xctn.CPUAdd(time.Second)
+
+	snap := &core.Snap{}
+	xctn.ToSnap(snap)
+	res := snap.Res
+	tassert.Errorf(t, res.Net == 350, "expected net 350, got %d", res.Net)
+	tassert.Errorf(t, res.DiskRead == 1000 && res.DiskWrite == 2000, "unexpected disk %+v", res)
+	tassert.Errorf(t, res.CPU == int64(time.Second), "expected 1s cpu, got %d", res.CPU)
+	tassert.Errorf(t, xctn.ResActivity() == 3350, "expected activity 3350, got %d", xctn.ResActivity())
+
+	// unsized transmit
+	xctn.OutObjsAdd(1, -1)
+	tassert.Errorf(t, xctn.ResActivity() == 3350, "expected unchanged activity, got %d", xctn.ResActivity())
+}
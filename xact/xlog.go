@@ -0,0 +1,90 @@
+// Package xact provides core functionality for the AIStore eXtended Actions (xactions).
+/*
+ * Copyright (c) 2024, NVIDIA CORPORATION. All rights reserved.
+ */
+package xact
+
+import (
+	"fmt"
+	"regexp"
+	"sync"
+	"time"
+
+	"github.com/NVIDIA/aistore/core"
+)
+
+// Per-xaction log capture:
+// - bounded ring buffer of structured events (warnings, per-target errors, retries) -
+//   in addition to (not instead of) regular logging;
+// - errors (Base.AddErr) and abort (Base.Abort) are captured automatically;
+// - returned with the xaction's snapshot upon request (QueryMsg.Log) - see api.GetXactionLog;
+// - when full, the oldest events get overwritten (and counted as dropped);
+// - messages are truncated and redacted (credentials in URLs, secrets, tokens, signatures).
+
+const (
+	xlogMaxEntries = 64
+	xlogMaxMsg     = 512
+)
+
+type xlog struct {
+	ring    []core.XactLogEntry
+	next    int // oldest entry when the ring is full
+	dropped int
+	mu      sync.Mutex
+}
+
+var (
+	reURLCreds  = regexp.MustCompile(`://[^/\s:@]+:[^/\s@]+@`)
+	reSecretKV  = regexp.MustCompile(`(?i)((?:password|passwd|secret|token|signature|credential|access[_-]?key|x-amz-signature|x-amz-credential|sig)[\w-]*["']?\s*[=:]\s*["']?)[^\s&"',;]+`)
+	reBearerTok = regexp.MustCompile(`(?i)(bearer\s+)[^\s"',;]+`)
+)
+
+const redacted = "***"
+
+func redact(s string) string {
+	s = reURLCreds.ReplaceAllString(s, "://"+redacted+"@")
+	s = reSecretKV.ReplaceAllString(s, "${1}"+redacted)
+	return reBearerTok.ReplaceAllString(s, "${1}"+redacted)
+}
+
+func (x *xlog) add(level, node, msg string) {
+	if len(msg) > xlogMaxMsg {
+		msg = msg[:xlogMaxMsg] + "..."
+	}
+	e := core.XactLogEntry{Time: time.Now(), Level: level, Node: node, Msg: redact(msg)}
+	x.mu.Lock()
+	if len(x.ring) < xlogMaxEntries {
+		x.ring = append(x.ring, e)
+	} else {
+		x.ring[x.next] = e
+		x.next = (x.next + 1) % xlogMaxEntries
+		x.dropped++
+	}
+	x.mu.Unlock()
+}
+
+// oldest first
+func (x *xlog) get() ([]core.XactLogEntry, int) {
+	x.mu.Lock()
+	defer x.mu.Unlock()
+	if len(x.ring) == 0 {
+		return nil, 0
+	}
+	out := make([]core.XactLogEntry, 0, len(x.ring))
+	out = append(out, x.ring[x.next:]...)
+	out = append(out, x.ring[:x.next]...)
+	return out, x.dropped
+}
+
+//
+// Base
+//
+
+// Xlog captures a structured event; `node` is the peer node (if any) the event refers to
+func (xctn *Base) Xlog(level, node, format string, a ...any) {
+	msg := format
+	if len(a) > 0 {
+		msg = fmt.Sprintf(format, a...)
+	}
+	xctn.xlog.add(level, node, msg)
+}
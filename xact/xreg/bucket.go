@@ -73,33 +73,32 @@ func RenewECEncode(bck *meta.Bck, uuid, phase string) RenewRes {
 	return RenewBucketXact(apc.ActECEncode, bck, Args{Custom: &ECEncodeArgs{Phase: phase}, UUID: uuid})
 }
 
-func RenewMakeNCopies(uuid, tag string) {
+// (re)mirror all mirror-enabled buckets; with `all` = true, also visit all the other ones
+// to (re)mirror objects with per-object overrides (see cmn.CopiesObjMD)
+func RenewMakeNCopies(uuid, tag string, all bool) {
 	var (
 		cfg      = cmn.GCO.Get()
 		bmd      = core.T.Bowner().Get()
 		provider = apc.AIS
-	)
-	bmd.Range(&provider, nil, func(bck *meta.Bck) bool {
-		if bck.Props.Mirror.Enabled {
-			rns := RenewBckMakeNCopies(bck, uuid, tag, int(bck.Props.Mirror.Copies))
+		renew    = func(bck *meta.Bck) bool {
+			copies := 1
+			if bck.Props.Mirror.Enabled {
+				copies = int(bck.Props.Mirror.Copies)
+			} else if !all {
+				return false
+			}
+			rns := RenewBckMakeNCopies(bck, uuid, tag, copies)
 			if rns.Err == nil && !rns.IsRunning() {
 				xact.GoRunW(rns.Entry.Get())
 			}
+			return false
 		}
-		return false
-	})
+	)
+	bmd.Range(&provider, nil, renew)
 	// TODO: remais
 	for name := range cfg.Backend.Providers {
 		ns := cfg.Backend.Providers[name]
-		bmd.Range(&name, &ns, func(bck *meta.Bck) bool {
-			if bck.Props.Mirror.Enabled {
-				rns := RenewBckMakeNCopies(bck, uuid, tag, int(bck.Props.Mirror.Copies))
-				if rns.Err == nil && !rns.IsRunning() {
-					xact.GoRunW(rns.Entry.Get())
-				}
-			}
-			return false
-		})
+		bmd.Range(&name, &ns, renew)
 	}
 }
 
@@ -116,6 +115,15 @@ func RenewBckLoadLomCache(uuid string, bck *meta.Bck) RenewRes {
 	return RenewBucketXact(apc.ActLoadLomCache, bck, Args{UUID: uuid})
 }
 
+func RenewMigrateCustomMD(uuid string, bck *meta.Bck) RenewRes {
+	return RenewBucketXact(apc.ActMigrateCustomMD, bck, Args{UUID: uuid})
+}
+
+// all: purge all soft-deleted objects (and not only those that have expired)
+func RenewPurgeTrash(uuid string, bck *meta.Bck, all bool) RenewRes {
+	return RenewBucketXact(apc.ActPurgeTrash, bck, Args{UUID: uuid, Custom: all})
+}
+
 func RenewPutMirror(lom *core.LOM) RenewRes {
 	return RenewBucketXact(apc.ActPutCopies, lom.Bck(), Args{Custom: lom})
 }
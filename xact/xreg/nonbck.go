@@ -1,17 +1,48 @@
 // Package xreg provides registry and (renew, find) functions for AIS eXtended Actions (xactions).
 /*
- * Copyright (c) 2018-2023, NVIDIA CORPORATION. All rights reserved.
+ * Copyright (c) 2018-2024, NVIDIA CORPORATION. All rights reserved.
  */
 package xreg
 
 import (
 	"github.com/NVIDIA/aistore/api/apc"
+	"github.com/NVIDIA/aistore/cmn/cos"
 	"github.com/NVIDIA/aistore/cmn/debug"
 	"github.com/NVIDIA/aistore/core"
 	"github.com/NVIDIA/aistore/core/meta"
+	"github.com/NVIDIA/aistore/fs"
 	"github.com/NVIDIA/aistore/xact"
 )
 
+type BurnInArgs struct {
+	Msg  *apc.BurnInMsg
+	Done func(*apc.BurnInReport) // (optional) upon termination
+}
+
+// (see apc.ActMountpathReplace)
+type MpathReplaceArgs struct {
+	Msg  *apc.MpathReplaceMsg
+	Src  *fs.Mountpath // old (available)
+	Dst  *fs.Mountpath // new (not yet attached; inherits Src HRW digest - see fs.NewReplacement)
+	Swap func() error  // make Dst take over (see fs.ReplaceMpath)
+}
+
+// (see apc.ActAbortIncomplete)
+type AbortIncompleteArgs struct {
+	// abort in-memory (S3 API) multipart uploads initiated before the cutoff;
+	// return the number of aborted uploads and the total size of their parts
+	Abort func(bck *meta.Bck, cutoff int64) (n, size int64)
+	// whether a given multipart upload is in progress (and owns its workfiles)
+	Active func(uploadID string) bool
+	Stats  cos.StatsUpdater
+	Cutoff int64 // Unix time (nanoseconds)
+}
+
+// (see apc.ActReclaimBck)
+type ReclaimArgs struct {
+	Dirs []string // bucket directories previously moved to 'deleted' (see fs.MoveBucketToDeleted)
+}
+
 func RegNonBckXact(entry Renewable) {
 	debug.Assert(!xact.IsSameScope(entry.Kind(), xact.ScopeB))
 	dreg.nonbckXacts[entry.Kind()] = entry // no locking: all reg-s are done at init time
@@ -29,6 +60,22 @@ func RenewResilver(id string) core.Xact {
 	return rns.Entry.Get()
 }
 
+func RenewBurnIn(id string, args *BurnInArgs) RenewRes {
+	e := dreg.nonbckXacts[apc.ActBurnIn].New(Args{UUID: id, Custom: args}, nil)
+	return dreg.renew(e, nil)
+}
+
+func RenewMpathReplace(id string, args *MpathReplaceArgs) RenewRes {
+	e := dreg.nonbckXacts[apc.ActMountpathReplace].New(Args{UUID: id, Custom: args}, nil)
+	return dreg.renew(e, nil)
+}
+
+// fix: one of the apc.AuditFix* actions
+func RenewAuditPlacement(id string, bck *meta.Bck, fix string) RenewRes {
+	e := dreg.nonbckXacts[apc.ActAuditPlacement].New(Args{UUID: id, Custom: fix}, bck)
+	return dreg.renew(e, bck)
+}
+
 func RenewElection() RenewRes {
 	e := dreg.nonbckXacts[apc.ActElection].New(Args{}, nil)
 	return dreg.renew(e, nil)
@@ -44,6 +91,16 @@ func RenewStoreCleanup(id string) RenewRes {
 	return dreg.renew(e, nil)
 }
 
+func RenewAbortIncomplete(id string, bck *meta.Bck, args *AbortIncompleteArgs) RenewRes {
+	e := dreg.nonbckXacts[apc.ActAbortIncomplete].New(Args{UUID: id, Custom: args}, bck)
+	return dreg.renew(e, bck)
+}
+
+func RenewReclaim(id string, bck *meta.Bck, args *ReclaimArgs) RenewRes {
+	e := dreg.nonbckXacts[apc.ActReclaimBck].New(Args{UUID: id, Custom: args}, bck)
+	return dreg.renew(e, bck)
+}
+
 func RenewDownloader(xid string, bck *meta.Bck) RenewRes {
 	e := dreg.nonbckXacts[apc.ActDownload].New(Args{UUID: xid, Custom: bck}, nil)
 	return dreg.renew(e, nil)
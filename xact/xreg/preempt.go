@@ -0,0 +1,139 @@
+// Package xreg provides registry and (renew, find) functions for AIS eXtended Actions (xactions).
+/*
+ * Copyright (c) 2024, NVIDIA CORPORATION. All rights reserved.
+ */
+package xreg
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/NVIDIA/aistore/api/apc"
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/fs"
+	"github.com/NVIDIA/aistore/hk"
+	"github.com/NVIDIA/aistore/xact"
+)
+
+// Preemption of background xactions (xact.PrioBackground) under foreground (user) load:
+// - periodically check the load against config `disk.preempt_util_wm` (max mountpath
+//   utilization) and `disk.preempt_get_latency` (average GET latency since the last check);
+// - when either is exceeded, pause all running background xactions; the pause takes effect
+//   at the xaction's next safe point (see xact.Base.Yield);
+// - resume only when the load drops below the respective threshold minus hysteresis;
+// - pauses and resumptions are captured in the xaction's log and reported in its snapshot
+//   (core.Snap.PausedX, PausedTime, PausedCnt);
+// - exception: xactions that free up space (LRU, cleanup, etc.) are never paused when used
+//   capacity is at or above high watermark - otherwise, the very load (e.g., PUTs) that keeps
+//   disks busy would also starve eviction and drive the target out of space.
+
+const (
+	preemptIval = 2 * time.Second
+
+	preemptHystUtil = 10 // utilization: resume below (watermark - 10)%
+	preemptHystLat  = 4  // latency: resume below 3/4 of the threshold
+)
+
+type (
+	preempter struct {
+		fg      func() (cnt, ns int64) // cumulative number of GETs and their total latency
+		reason  string                 // non-empty when loaded
+		lastCnt int64
+		lastNs  int64
+	}
+	preemptible interface {
+		Preempt(reason string) bool
+		Resume() bool
+	}
+)
+
+func RegPreempter(fg func() (cnt, ns int64)) {
+	pr := &preempter{fg: fg}
+	hk.Reg("x-preempt"+hk.NameSuffix, pr.housekeep, preemptIval)
+}
+
+func (pr *preempter) housekeep(int64) time.Duration {
+	config := cmn.GCO.Get()
+	reason, calm := pr.load(&config.Disk)
+	switch {
+	case reason != "":
+		pr.reason = reason
+	case calm:
+		pr.reason = ""
+	}
+
+	var (
+		cs     = fs.Cap()
+		lowCap = capLow(&cs)
+		e      = &dreg.entries
+	)
+	e.mtx.RLock()
+	for _, entry := range e.active {
+		xctn := entry.Get()
+		if xctn == nil || xctn.Finished() || xact.Table[xctn.Kind()].Prio != xact.PrioBackground {
+			continue
+		}
+		x, ok := xctn.(preemptible)
+		if !ok {
+			continue
+		}
+		if pr.reason != "" && !(lowCap && freesSpace(xctn.Kind())) {
+			x.Preempt(pr.reason)
+		} else {
+			x.Resume()
+		}
+	}
+	e.mtx.RUnlock()
+	return preemptIval
+}
+
+// returns non-empty reason when loaded, and calm = true when the load is below thresholds
+// minus hysteresis (in-between: keep the current state)
+func (pr *preempter) load(c *cmn.DiskConf) (reason string, calm bool) {
+	calm = true
+
+	// GET latency since the last check
+	if pr.fg != nil {
+		cnt, ns := pr.fg()
+		dcnt, dns := cnt-pr.lastCnt, ns-pr.lastNs
+		pr.lastCnt, pr.lastNs = cnt, ns
+		if thresh := c.PreemptGetLatency.D(); thresh > 0 && dcnt > 0 {
+			lat := time.Duration(dns / dcnt)
+			switch {
+			case lat >= thresh:
+				reason = "GET latency " + lat.String()
+			case lat >= thresh-thresh/preemptHystLat:
+				calm = false
+			}
+		}
+	}
+
+	// max mountpath utilization
+	if wm := c.PreemptUtilWM; wm > 0 && reason == "" {
+		utils := fs.GetAllMpathUtils()
+		for mpath := range fs.GetAvail() {
+			util := utils.Get(mpath)
+			switch {
+			case util >= wm:
+				return fmt.Sprintf("mountpath %s utilization %d%%", mpath, util), false
+			case util >= wm-preemptHystUtil:
+				calm = false
+			}
+		}
+	}
+	return reason, calm && reason == ""
+}
+
+// at or above high watermark, or OOS
+func capLow(cs *fs.CapStatus) bool {
+	return !cs.IsNil() && (cs.IsOOS() || int64(cs.PctMax) >= cs.HighWM)
+}
+
+func freesSpace(kind string) bool {
+	switch kind {
+	case apc.ActLRU, apc.ActStoreCleanup, apc.ActReclaimBck, apc.ActPurgeTrash:
+		return true
+	default:
+		return false
+	}
+}
@@ -0,0 +1,66 @@
+// Package xreg provides registry and (renew, find) functions for AIS eXtended Actions (xactions).
+/*
+ * Copyright (c) 2024, NVIDIA CORPORATION. All rights reserved.
+ */
+package xreg
+
+import (
+	"testing"
+	"time"
+
+	"github.com/NVIDIA/aistore/api/apc"
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/cmn/cos"
+	"github.com/NVIDIA/aistore/fs"
+	"github.com/NVIDIA/aistore/tools/tassert"
+)
+
+func TestPreemptLoad(t *testing.T) {
+	var (
+		cnt, ns int64
+		pr      = &preempter{fg: func() (int64, int64) { return cnt, ns }}
+		c       = &cmn.DiskConf{PreemptGetLatency: cos.Duration(100 * time.Millisecond)}
+	)
+	tests := []struct {
+		name   string
+		lat    time.Duration // average latency of the GETs since the last check
+		loaded bool
+		calm   bool
+	}{
+		{"idle", 10 * time.Millisecond, false, true},
+		{"above threshold", 150 * time.Millisecond, true, false},
+		{"hysteresis", 80 * time.Millisecond, false, false},
+		{"at threshold", 100 * time.Millisecond, true, false},
+		{"below hysteresis", 70 * time.Millisecond, false, true},
+	}
+	for _, test := range tests {
+		cnt += 10
+		ns += 10 * int64(test.lat)
+		reason, calm := pr.load(c)
+		tassert.Errorf(t, (reason != "") == test.loaded, "%s: expected loaded=%t, got %q", test.name, test.loaded, reason)
+		tassert.Errorf(t, calm == test.calm, "%s: expected calm=%t", test.name, test.calm)
+	}
+
+	// no GETs since the last check
+	reason, calm := pr.load(c)
+	tassert.Errorf(t, reason == "" && calm, "expected calm when idle, got %q, %t", reason, calm)
+}
+
+func TestPreemptFreesSpace(t *testing.T) {
+	tests := []struct {
+		cs     fs.CapStatus
+		lowCap bool
+	}{
+		{fs.CapStatus{}, false}, // (not yet known)
+		{fs.CapStatus{HighWM: 90, OOS: 95, PctMax: 50, TotalUsed: 1, TotalAvail: 1}, false},
+		{fs.CapStatus{HighWM: 90, OOS: 95, PctMax: 90, TotalUsed: 1, TotalAvail: 1}, true},
+		{fs.CapStatus{HighWM: 90, OOS: 95, PctMax: 99, TotalUsed: 1, TotalAvail: 1}, true},
+	}
+	for _, test := range tests {
+		tassert.Errorf(t, capLow(&test.cs) == test.lowCap, "%s: expected low capacity %t", test.cs.String(), test.lowCap)
+	}
+	for _, kind := range []string{apc.ActLRU, apc.ActStoreCleanup, apc.ActReclaimBck, apc.ActPurgeTrash} {
+		tassert.Errorf(t, freesSpace(kind), "expected %q to be exempt from preemption at high watermark", kind)
+	}
+	tassert.Errorf(t, !freesSpace(apc.ActAuditPlacement), "expected %q to remain preemptible", apc.ActAuditPlacement)
+}
@@ -0,0 +1,87 @@
+// Package xreg provides registry and (renew, find) functions for AIS eXtended Actions (xactions).
+/*
+ * Copyright (c) 2024, NVIDIA CORPORATION. All rights reserved.
+ */
+package xreg
+
+import (
+	"os"
+	"time"
+
+	"github.com/NVIDIA/aistore/core"
+	"github.com/NVIDIA/aistore/hk"
+	"github.com/NVIDIA/aistore/sys"
+)
+
+// Approximate per-xaction CPU accounting (see core.Res):
+// - periodically sample the (process-wide) CPU time;
+// - split the delta between xactions in proportion to their respective I/O activity
+//   (bytes read, written, and transferred) during the same interval;
+// - foreground (user) I/O, if provided by the caller, participates in the split
+//   but is not attributed, so that idle-ish xactions don't get blamed for user traffic.
+
+const resIval = 10 * time.Second
+
+type resSampler struct {
+	fg      func() int64     // cumulative foreground (user GET, PUT, etc.) bytes
+	last    map[string]int64 // xaction ID => cumulative activity at the time of the last sample
+	lastFg  int64
+	lastCPU uint64 // milliseconds
+	pid     int
+}
+
+func RegResSampler(fg func() int64) {
+	rs := &resSampler{fg: fg, last: make(map[string]int64, 16), pid: os.Getpid()}
+	hk.Reg("x-res"+hk.NameSuffix, rs.housekeep, resIval)
+}
+
+func (rs *resSampler) housekeep(int64) time.Duration {
+	ps, err := sys.ProcessStats(rs.pid)
+	if err != nil || ps.CPU.Total == 0 {
+		return resIval
+	}
+	var (
+		xctns  = make([]core.Xact, 0, 8)
+		deltas = make([]int64, 0, 8)
+		total  int64
+		last   = make(map[string]int64, len(rs.last))
+		e      = &dreg.entries
+	)
+	e.mtx.RLock()
+	for _, entry := range e.active {
+		xctn := entry.Get()
+		if xctn == nil {
+			continue
+		}
+		prev, ok := rs.last[xctn.ID()]
+		if xctn.Finished() && !ok {
+			continue
+		}
+		act := xctn.ResActivity()
+		last[xctn.ID()] = act
+		if d := act - prev; d > 0 {
+			xctns = append(xctns, xctn)
+			deltas = append(deltas, d)
+			total += d
+		}
+	}
+	e.mtx.RUnlock()
+	rs.last = last
+
+	var fg int64
+	if rs.fg != nil {
+		fg = rs.fg()
+		if d := fg - rs.lastFg; d > 0 && rs.lastCPU > 0 {
+			total += d
+		}
+	}
+	cpu := ps.CPU.Total
+	if rs.lastCPU > 0 && cpu > rs.lastCPU && total > 0 {
+		elapsed := float64(time.Duration(cpu-rs.lastCPU) * time.Millisecond)
+		for i, xctn := range xctns {
+			xctn.CPUAdd(time.Duration(elapsed * float64(deltas[i]) / float64(total)))
+		}
+	}
+	rs.lastCPU, rs.lastFg = cpu, fg
+	return resIval
+}
@@ -0,0 +1,182 @@
+// Package xs is a collection of eXtended actions (xactions), including multi-object
+// operations, list-objects, (cluster) rebalance and (target) resilver, ETL, and more.
+/*
+ * Copyright (c) 2024, NVIDIA CORPORATION. All rights reserved.
+ */
+package xs
+
+import (
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/NVIDIA/aistore/api/apc"
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/cmn/cos"
+	"github.com/NVIDIA/aistore/cmn/nlog"
+	"github.com/NVIDIA/aistore/core"
+	"github.com/NVIDIA/aistore/core/meta"
+	"github.com/NVIDIA/aistore/fs"
+	"github.com/NVIDIA/aistore/fs/mpather"
+	"github.com/NVIDIA/aistore/stats"
+	"github.com/NVIDIA/aistore/xact"
+	"github.com/NVIDIA/aistore/xact/xreg"
+)
+
+// Abort incomplete (stale) multipart uploads and appends in a given bucket or all buckets:
+// - first, abort (in-memory) multipart uploads initiated before the cutoff time, and remove their parts;
+// - second, remove multipart and append workfiles that were not modified since the cutoff -
+//   the leftovers of abandoned uploads and appends; skip those owned by an upload in progress,
+//   and appends that are being flushed (locked).
+// Other workfiles (in-flight PUTs, rebalance, EC, etc.) are never touched - those of the previous
+// runs are removed by space cleanup (see space/cleanup.go).
+
+type (
+	aicFactory struct {
+		xreg.RenewBase
+		xctn *XactAbortIncomplete
+	}
+	XactAbortIncomplete struct {
+		args *xreg.AbortIncompleteArgs
+		xact.BckJog
+	}
+)
+
+// interface guard
+var (
+	_ core.Xact      = (*XactAbortIncomplete)(nil)
+	_ xreg.Renewable = (*aicFactory)(nil)
+)
+
+////////////////
+// aicFactory //
+////////////////
+
+func (*aicFactory) New(args xreg.Args, bck *meta.Bck) xreg.Renewable {
+	p := &aicFactory{RenewBase: xreg.RenewBase{Args: args, Bck: bck}}
+	return p
+}
+
+func (p *aicFactory) Start() error {
+	args := p.Args.Custom.(*xreg.AbortIncompleteArgs)
+	p.xctn = newXactAbortIncomplete(p.UUID(), p.Bck, args)
+	go p.xctn.Run(nil)
+	return nil
+}
+
+func (*aicFactory) Kind() string     { return apc.ActAbortIncomplete }
+func (p *aicFactory) Get() core.Xact { return p.xctn }
+
+func (*aicFactory) WhenPrevIsRunning(xreg.Renewable) (xreg.WPR, error) { return xreg.WprUse, nil }
+
+/////////////////////////
+// XactAbortIncomplete //
+/////////////////////////
+
+func newXactAbortIncomplete(uuid string, bck *meta.Bck, args *xreg.AbortIncompleteArgs) (r *XactAbortIncomplete) {
+	r = &XactAbortIncomplete{args: args}
+	mpopts := &mpather.JgroupOpts{
+		CTs:     []string{fs.WorkfileType},
+		VisitCT: r.visit,
+	}
+	if bck != nil {
+		mpopts.Bck.Copy(bck.Bucket())
+	}
+	r.BckJog.Init(uuid, apc.ActAbortIncomplete, bck, mpopts, cmn.GCO.Get())
+	return
+}
+
+func (r *XactAbortIncomplete) Run(*sync.WaitGroup) {
+	if r.args.Abort != nil {
+		n, size := r.args.Abort(r.Bck(), r.args.Cutoff)
+		r.reclaimed(n, size)
+	}
+	r.BckJog.Run()
+	nlog.Infoln(r.Name())
+	err := r.BckJog.Wait()
+	if err != nil {
+		r.AddErr(err)
+	}
+	r.Finish()
+}
+
+func (r *XactAbortIncomplete) visit(ct *core.CT, _ []byte) error {
+	var (
+		fqn             = ct.FQN()
+		prefix, rest, _ = strings.Cut(filepath.Base(fqn), ".")
+	)
+	switch {
+	case prefix == fs.WorkfileAppend:
+		// skip if the object is busy (e.g., append being flushed)
+		lom := core.AllocLOM(filepath.Join(filepath.Dir(ct.ObjectName()), aicObjName(fqn)))
+		defer core.FreeLOM(lom)
+		if lom.InitBck(ct.Bucket()) != nil || !lom.TryLock(true) {
+			return nil
+		}
+		defer lom.Unlock(true)
+	case isMptWorkfile(prefix, rest):
+		if r.args.Active != nil && r.args.Active(prefix) {
+			return nil
+		}
+	default:
+		return nil
+	}
+	finfo, err := os.Lstat(fqn)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil
+		}
+		return err
+	}
+	if finfo.ModTime().UnixNano() > r.args.Cutoff {
+		return nil
+	}
+	if err := cos.RemoveFile(fqn); err != nil {
+		return err
+	}
+	r.reclaimed(1, finfo.Size())
+	return nil
+}
+
+// multipart upload workfile: "<upload-id>.<part-number | complete>.<obj-name>" (see ais/tgts3mpt.go)
+func isMptWorkfile(prefix, rest string) bool {
+	if !cos.IsValidUUID(prefix) {
+		return false
+	}
+	part, _, ok := strings.Cut(rest, ".")
+	if !ok {
+		return false
+	}
+	if part == "complete" {
+		return true
+	}
+	_, err := strconv.ParseUint(part, 10, 32)
+	return err == nil
+}
+
+// object (base) name of the append workfile
+func aicObjName(fqn string) string {
+	orig, _, _ := fs.CSM.Resolver(fs.WorkfileType).ParseUniqueFQN(filepath.Base(fqn))
+	return orig
+}
+
+func (r *XactAbortIncomplete) reclaimed(n, size int64) {
+	if n == 0 {
+		return
+	}
+	r.ObjsAdd(int(n), size)
+	r.args.Stats.AddMany(
+		cos.NamedVal64{Name: stats.AbortIncompleteCount, Value: n},
+		cos.NamedVal64{Name: stats.AbortIncompleteSize, Value: size},
+	)
+}
+
+func (r *XactAbortIncomplete) Snap() (snap *core.Snap) {
+	snap = &core.Snap{}
+	r.ToSnap(snap)
+
+	snap.IdleX = r.IsIdle()
+	return
+}
@@ -423,6 +423,7 @@ func (r *XactArch) _fini(wi *archwi) (ecode int, err error) {
 	ecode, err = core.T.FinalizeObj(wi.archlom, wi.fqn, r, cmn.OwtArchive)
 	core.FreeLOM(wi.archlom)
 	r.ObjsAdd(1, size-wi.appendPos)
+	r.DiskWriteAdd(size - wi.appendPos)
 
 	return
 }
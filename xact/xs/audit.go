@@ -0,0 +1,364 @@
+// Package xs is a collection of eXtended actions (xactions), including multi-object
+// operations, list-objects, (cluster) rebalance and (target) resilver, ETL, and more.
+/*
+ * Copyright (c) 2024, NVIDIA CORPORATION. All rights reserved.
+ */
+package xs
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"github.com/NVIDIA/aistore/api/apc"
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/cmn/cos"
+	"github.com/NVIDIA/aistore/cmn/nlog"
+	"github.com/NVIDIA/aistore/core"
+	"github.com/NVIDIA/aistore/core/meta"
+	"github.com/NVIDIA/aistore/fs"
+	"github.com/NVIDIA/aistore/fs/mpather"
+	"github.com/NVIDIA/aistore/xact"
+	"github.com/NVIDIA/aistore/xact/xreg"
+)
+
+// Placement audit (apc.ActAuditPlacement) of a given bucket or all buckets:
+// - finds objects stored at non-HRW mountpaths that are not registered copies (misplaced),
+//   copies that the main replica does not list (stale), objects that belong to other targets,
+//   and workfiles left behind by the target's previous runs;
+// - reports the findings (apc.AuditReport) via the xaction's extended stats;
+// - optionally, fixes them (apc.AuditFix*); a fix that does not apply to a given finding
+//   (e.g., deleting a misplaced object that has no main replica) is skipped and reported;
+// - does not fix objects of erasure-coded buckets and objects that belong to other targets,
+//   and does not run concurrently with rebalance and resilver (see xact.Table).
+
+type (
+	audFactory struct {
+		xreg.RenewBase
+		xctn *XactAudit
+	}
+	XactAudit struct {
+		rep *apc.AuditReport
+		fix string
+		mu  sync.Mutex
+		xact.BckJog
+	}
+)
+
+// interface guard
+var (
+	_ core.Xact      = (*XactAudit)(nil)
+	_ xreg.Renewable = (*audFactory)(nil)
+)
+
+var (
+	errAuditNA       = errors.New("not applicable")
+	errAuditDiverged = errors.New("differs from the main replica")
+)
+
+////////////////
+// audFactory //
+////////////////
+
+func (*audFactory) New(args xreg.Args, bck *meta.Bck) xreg.Renewable {
+	return &audFactory{RenewBase: xreg.RenewBase{Args: args, Bck: bck}}
+}
+
+func (p *audFactory) Start() error {
+	p.xctn = newXactAudit(p.UUID(), p.Bck, p.Args.Custom.(string))
+	go p.xctn.Run(nil)
+	return nil
+}
+
+func (*audFactory) Kind() string     { return apc.ActAuditPlacement }
+func (p *audFactory) Get() core.Xact { return p.xctn }
+
+func (p *audFactory) WhenPrevIsRunning(prevEntry xreg.Renewable) (xreg.WPR, error) {
+	var (
+		prev = prevEntry.(*audFactory)
+		fix  = p.Args.Custom.(string)
+	)
+	if prev.xctn.fix != fix {
+		return 0, fmt.Errorf("%s (fix %q) is already running - cannot start audit with fix %q",
+			prev.xctn, prev.xctn.fix, fix)
+	}
+	return xreg.WprUse, cmn.NewErrXactUsePrev(prevEntry.Get().String())
+}
+
+///////////////
+// XactAudit //
+///////////////
+
+func newXactAudit(uuid string, bck *meta.Bck, fix string) (r *XactAudit) {
+	r = &XactAudit{
+		rep: &apc.AuditReport{Counts: make(map[string]*apc.AuditCount, 4), Fix: fix},
+		fix: fix,
+	}
+	mpopts := &mpather.JgroupOpts{
+		CTs:      []string{fs.ObjectType, fs.WorkfileType},
+		VisitObj: r.visitObj,
+		VisitCT:  r.visitCT,
+	}
+	if bck != nil {
+		mpopts.Bck.Copy(bck.Bucket())
+	}
+	r.BckJog.Init(uuid, apc.ActAuditPlacement, bck, mpopts, cmn.GCO.Get())
+	return
+}
+
+func (r *XactAudit) Run(*sync.WaitGroup) {
+	r.BckJog.Run()
+	if r.fix == apc.AuditFixNone {
+		nlog.Infoln(r.Name(), "(report only)")
+	} else {
+		nlog.Infoln(r.Name(), "fix:", r.fix)
+	}
+	err := r.BckJog.Wait()
+	if err != nil {
+		r.AddErr(err)
+	}
+	r.Finish()
+	if n := r.Report().Found(); n > 0 {
+		nlog.Warningln(r.Name(), "found", n, "misplaced, stale, or orphaned")
+	}
+}
+
+func (r *XactAudit) visitObj(lom *core.LOM, _ []byte) error {
+	r.Yield()
+	if _, local, err := lom.HrwTarget(core.T.Sowner().Get()); err == nil && !local {
+		var errNA error
+		if r.fix != apc.AuditFixNone {
+			errNA = errAuditNA // (rebalance)
+		}
+		size, _, _, _ := lom.Fstat(false /*get-atime*/)
+		r.add(apc.AuditWrongTarget, lom, size, "", errNA)
+		return nil
+	}
+	if lom.IsHRW() {
+		return nil
+	}
+
+	lom.Lock(false)
+	kind, hrwExists, same, err := r.classify(lom)
+	lom.Unlock(false)
+	if err != nil || kind == "" {
+		return nil // (not found, or a legit copy)
+	}
+	size := lom.Lsize()
+
+	var fixed string
+	switch {
+	case r.fix == apc.AuditFixNone:
+	case lom.ECEnabled():
+		err = errAuditNA
+	case r.fix == apc.AuditFixMove:
+		if hrwExists {
+			err = errAuditNA
+		} else {
+			fixed, err = r.move(lom)
+		}
+	case r.fix == apc.AuditFixDelete:
+		switch {
+		case !hrwExists:
+			err = errAuditNA // (the only replica)
+		case !same:
+			err = errAuditDiverged // (possibly, newer)
+		default:
+			fixed, err = r.remove(lom)
+		}
+	case r.fix == apc.AuditFixAdopt:
+		if hrwExists && same {
+			fixed, err = r.adopt(lom)
+		} else {
+			err = errAuditNA
+		}
+	}
+	r.add(kind, lom, size, fixed, err)
+	return nil
+}
+
+// under rlock; returns empty kind when the object is a properly registered copy
+func (*XactAudit) classify(lom *core.LOM) (kind string, hrwExists, same bool, err error) {
+	if err = lom.Load(false /*cache it*/, true /*locked*/); err != nil {
+		return
+	}
+	kind = apc.AuditMisplaced
+	if lom.IsCopy() {
+		kind = apc.AuditStaleCopy
+	}
+	hlom := core.AllocLOM(lom.ObjName)
+	defer core.FreeLOM(hlom)
+	if err = hlom.InitBck(lom.Bucket()); err != nil {
+		return
+	}
+	if errH := hlom.Load(false /*cache it*/, true /*locked*/); errH != nil {
+		if !cos.IsNotExist(errH, 0) {
+			err = errH
+		}
+		return
+	}
+	if _, ok := hlom.GetCopies()[lom.FQN]; ok {
+		return "", true, true, nil
+	}
+	return kind, true, hlom.CheckEq(lom) == nil, nil
+}
+
+// restore the main replica from the misplaced one, and remove the latter
+func (*XactAudit) move(lom *core.LOM) (string, error) {
+	hlom := core.AllocLOM(lom.ObjName)
+	defer core.FreeLOM(hlom)
+	if err := hlom.InitBck(lom.Bucket()); err != nil {
+		return "", err
+	}
+	if !hlom.RestoreToLocation() {
+		return "", cos.NewErrNotFound(core.T, lom.Cname())
+	}
+	hlom.Lock(true)
+	defer hlom.Unlock(true)
+	if err := hlom.Load(false /*cache it*/, true /*locked*/); err != nil {
+		return "", err
+	}
+	if _, ok := hlom.GetCopies()[lom.FQN]; !ok {
+		if err := cos.RemoveFile(lom.FQN); err != nil {
+			return "", err
+		}
+	}
+	return apc.AuditFixMove, nil
+}
+
+func (*XactAudit) remove(lom *core.LOM) (string, error) {
+	hlom := core.AllocLOM(lom.ObjName)
+	defer core.FreeLOM(hlom)
+	if err := hlom.InitBck(lom.Bucket()); err != nil {
+		return "", err
+	}
+	hlom.Lock(true)
+	defer hlom.Unlock(true)
+	// re-check under wlock
+	if err := hlom.Load(false /*cache it*/, true /*locked*/); err != nil {
+		return "", err
+	}
+	if _, ok := hlom.GetCopies()[lom.FQN]; ok {
+		return "", errAuditNA
+	}
+	if hlom.CheckEq(lom) != nil {
+		return "", errAuditDiverged
+	}
+	if err := cos.RemoveFile(lom.FQN); err != nil {
+		return "", err
+	}
+	return apc.AuditFixDelete, nil
+}
+
+func (*XactAudit) adopt(lom *core.LOM) (string, error) {
+	hlom := core.AllocLOM(lom.ObjName)
+	defer core.FreeLOM(hlom)
+	if err := hlom.InitBck(lom.Bucket()); err != nil {
+		return "", err
+	}
+	hlom.Lock(true)
+	defer hlom.Unlock(true)
+	if err := hlom.Load(false /*cache it*/, true /*locked*/); err != nil {
+		return "", err
+	}
+	if _, ok := hlom.GetCopies()[lom.FQN]; ok {
+		return "", errAuditNA
+	}
+	if err := hlom.AddCopy(lom.FQN, lom.Mountpath()); err != nil {
+		return "", err
+	}
+	if err := hlom.Persist(); err != nil {
+		return "", err
+	}
+	return apc.AuditFixAdopt, nil
+}
+
+// workfiles of the previous runs (compare with space cleanup and abort-incomplete)
+func (r *XactAudit) visitCT(ct *core.CT, _ []byte) error {
+	r.Yield()
+	fqn := ct.FQN()
+	if _, old, ok := fs.CSM.Resolver(fs.WorkfileType).ParseUniqueFQN(filepath.Base(fqn)); !ok || !old {
+		return nil
+	}
+	finfo, err := os.Lstat(fqn)
+	if err != nil {
+		return nil
+	}
+	var fixed string
+	switch r.fix {
+	case apc.AuditFixNone:
+	case apc.AuditFixDelete:
+		if err = cos.RemoveFile(fqn); err == nil {
+			fixed = apc.AuditFixDelete
+		}
+	default:
+		err = errAuditNA
+	}
+	r.addEntry(&apc.AuditEntry{Kind: apc.AuditWorkfile, FQN: fqn, Size: finfo.Size(), Fixed: fixed}, err)
+	return nil
+}
+
+func (r *XactAudit) add(kind string, lom *core.LOM, size int64, fixed string, err error) {
+	r.addEntry(&apc.AuditEntry{Kind: kind, FQN: lom.FQN, Cname: lom.Cname(), Size: size, Fixed: fixed}, err)
+	if fixed != "" {
+		nlog.Infoln(r.Name(), fixed, kind, lom.FQN)
+	}
+}
+
+func (r *XactAudit) addEntry(e *apc.AuditEntry, err error) {
+	if err != nil {
+		e.Err = err.Error()
+		if err != errAuditNA && err != errAuditDiverged {
+			r.AddErr(err, 4, cos.SmoduleXs)
+		}
+	}
+	r.ObjsAdd(1, e.Size)
+
+	r.mu.Lock()
+	c, ok := r.rep.Counts[e.Kind]
+	if !ok {
+		c = &apc.AuditCount{}
+		r.rep.Counts[e.Kind] = c
+	}
+	c.Found++
+	c.Size += e.Size
+	if e.Fixed != "" {
+		c.Fixed++
+	}
+	if len(r.rep.Entries) < apc.MaxAuditEntries {
+		r.rep.Entries = append(r.rep.Entries, e)
+	} else {
+		r.rep.Truncated = true
+	}
+	r.mu.Unlock()
+}
+
+// (a copy)
+func (r *XactAudit) Report() *apc.AuditReport {
+	r.mu.Lock()
+	rep := &apc.AuditReport{
+		Counts:    make(map[string]*apc.AuditCount, len(r.rep.Counts)),
+		Fix:       r.rep.Fix,
+		Entries:   make([]*apc.AuditEntry, len(r.rep.Entries)),
+		Truncated: r.rep.Truncated,
+	}
+	for kind, c := range r.rep.Counts {
+		cc := *c
+		rep.Counts[kind] = &cc
+	}
+	copy(rep.Entries, r.rep.Entries)
+	r.mu.Unlock()
+	rep.Sort()
+	return rep
+}
+
+func (r *XactAudit) Snap() (snap *core.Snap) {
+	snap = &core.Snap{}
+	r.ToSnap(snap)
+
+	snap.Ext = r.Report()
+	snap.IdleX = r.IsIdle()
+	return
+}
@@ -437,6 +437,8 @@ func (r *XactBlobDl) write(sgl *memsys.SGL) (err error) {
 
 	r.woff += size
 	r.ObjsAdd(0, size)
+	r.NetAdd(size)
+	r.DiskWriteAdd(size)
 	sgl.Reset()
 	return nil
 }
@@ -0,0 +1,325 @@
+// Package xs is a collection of eXtended actions (xactions), including multi-object
+// operations, list-objects, (cluster) rebalance and (target) resilver, ETL, and more.
+/*
+ * Copyright (c) 2024, NVIDIA CORPORATION. All rights reserved.
+ */
+package xs
+
+import (
+	cryptorand "crypto/rand"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/NVIDIA/aistore/api/apc"
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/cmn/atomic"
+	"github.com/NVIDIA/aistore/cmn/cos"
+	"github.com/NVIDIA/aistore/cmn/mono"
+	"github.com/NVIDIA/aistore/cmn/nlog"
+	"github.com/NVIDIA/aistore/core"
+	"github.com/NVIDIA/aistore/core/meta"
+	"github.com/NVIDIA/aistore/fs"
+	"github.com/NVIDIA/aistore/memsys"
+	"github.com/NVIDIA/aistore/xact"
+	"github.com/NVIDIA/aistore/xact/xreg"
+	"github.com/OneOfOne/xxhash"
+)
+
+// Burn-in (self-test): synthetic write => read => verify load across all mountpaths.
+// - each mountpath runs `apc.BurnInMsg.Workers` workers that repeatedly write, fsync,
+//   read back (with the page cache dropped), and verify (xxhash) an object of the configured size;
+// - objects are written and read back in slab-size chunks (memory usage does not depend on the size);
+// - temp files are written under the mountpath's $deleted (see fs.TempDir)
+//   and are removed upon completion or, in the worst case, upon restart;
+// - the resulting report (apc.BurnInReport) includes per-mountpath throughput, latency,
+//   and error counts, and the pass/fail verdict (apc.BurnInReport.Judge).
+
+const binErrSleep = 100 * time.Millisecond // (back off upon I/O error)
+
+type (
+	binFactory struct {
+		xreg.RenewBase
+		xctn *XactBurnIn
+	}
+	XactBurnIn struct {
+		msg    *apc.BurnInMsg
+		done   func(*apc.BurnInReport)
+		mpaths []*binMpath
+		xact.Base
+	}
+	binMpath struct {
+		mi     *fs.Mountpath
+		dir    string
+		wbytes atomic.Int64
+		rbytes atomic.Int64
+		wlat   atomic.Int64 // cumulative
+		rlat   atomic.Int64 // ditto
+		nw     atomic.Int64
+		nr     atomic.Int64
+		errs   atomic.Int64
+		cerrs  atomic.Int64 // checksum mismatch
+	}
+)
+
+// interface guard
+var (
+	_ core.Xact      = (*XactBurnIn)(nil)
+	_ xreg.Renewable = (*binFactory)(nil)
+)
+
+////////////////
+// binFactory //
+////////////////
+
+func (*binFactory) New(args xreg.Args, _ *meta.Bck) xreg.Renewable {
+	return &binFactory{RenewBase: xreg.RenewBase{Args: args}}
+}
+
+func (p *binFactory) Start() error {
+	args := p.Args.Custom.(*xreg.BurnInArgs)
+	xctn, err := newBurnIn(p.UUID(), args)
+	if err != nil {
+		return err
+	}
+	p.xctn = xctn
+	go xctn.Run(nil)
+	return nil
+}
+
+func (*binFactory) Kind() string     { return apc.ActBurnIn }
+func (p *binFactory) Get() core.Xact { return p.xctn }
+
+func (*binFactory) WhenPrevIsRunning(prevEntry xreg.Renewable) (xreg.WPR, error) {
+	return xreg.WprUse, cmn.NewErrXactUsePrev(prevEntry.Get().String())
+}
+
+////////////////
+// XactBurnIn //
+////////////////
+
+func newBurnIn(id string, args *xreg.BurnInArgs) (*XactBurnIn, error) {
+	avail := fs.GetAvail()
+	if len(avail) == 0 {
+		return nil, cmn.ErrNoMountpaths
+	}
+	r := &XactBurnIn{msg: args.Msg, done: args.Done, mpaths: make([]*binMpath, 0, len(avail))}
+	r.InitBase(id, apc.ActBurnIn, nil)
+	for _, mi := range avail {
+		bm := &binMpath{mi: mi, dir: mi.TempDir(apc.ActBurnIn + "-" + id)}
+		if err := cos.CreateDir(bm.dir); err != nil {
+			r.cleanup()
+			return nil, err
+		}
+		r.mpaths = append(r.mpaths, bm)
+	}
+	return r, nil
+}
+
+func (r *XactBurnIn) Run(*sync.WaitGroup) {
+	var (
+		wg       sync.WaitGroup
+		deadline = mono.NanoTime() + r.msg.Duration.D().Nanoseconds()
+	)
+	nlog.Infoln(r.Name(), "duration", r.msg.Duration, "objsize", cos.ToSizeIEC(r.msg.ObjSize, 0),
+		"workers/mpath", r.msg.Workers, "mpaths", len(r.mpaths))
+	for _, bm := range r.mpaths {
+		for i := range r.msg.Workers {
+			wg.Add(1)
+			go r.work(bm, i, deadline, &wg)
+		}
+	}
+	wg.Wait()
+	r.cleanup()
+	r.Finish()
+
+	rep := r.Report()
+	if rep.Passed {
+		nlog.Infoln(r.Name(), "passed")
+	} else {
+		nlog.Errorln(r.Name(), "failed:", rep.Reason)
+	}
+	if r.done != nil {
+		r.done(rep)
+	}
+}
+
+func (r *XactBurnIn) cleanup() {
+	for _, bm := range r.mpaths {
+		if err := os.RemoveAll(bm.dir); err != nil {
+			nlog.Errorln(r.Name(), "failed to cleanup:", err)
+		}
+	}
+}
+
+func (r *XactBurnIn) work(bm *binMpath, idx int, deadline int64, wg *sync.WaitGroup) {
+	var (
+		size        = r.msg.ObjSize
+		mm          = core.T.PageMM()
+		wbuf, wslab = mm.AllocSize(memsys.MaxPageSlabSize)
+		rbuf, rslab = mm.AllocSize(memsys.MaxPageSlabSize)
+		fqn         = filepath.Join(bm.dir, strconv.Itoa(idx))
+	)
+	defer func() {
+		wslab.Free(wbuf)
+		rslab.Free(rbuf)
+		wg.Done()
+	}()
+	if _, err := cryptorand.Read(wbuf); err != nil {
+		r.AddErr(err)
+		return
+	}
+
+	for seq := uint64(0); !r.IsAborted() && mono.NanoTime() < deadline; seq++ {
+		cksum, err := bm.write(fqn, wbuf, size, seq)
+		if err != nil {
+			r.ioerr(bm, err)
+			continue
+		}
+		rcksum, err := bm.read(fqn, rbuf, size)
+		if err != nil {
+			r.ioerr(bm, err)
+			continue
+		}
+		if rcksum != cksum {
+			bm.cerrs.Inc()
+			r.AddErr(cos.NewErrDataCksum(cos.NewCksum(cos.ChecksumXXHash, strconv.FormatUint(cksum, 16)),
+				cos.NewCksum(cos.ChecksumXXHash, strconv.FormatUint(rcksum, 16)), fqn))
+		}
+		r.ObjsAdd(1, size)
+	}
+	os.Remove(fqn)
+}
+
+func (r *XactBurnIn) ioerr(bm *binMpath, err error) {
+	bm.errs.Inc()
+	r.AddErr(err, 0)
+	time.Sleep(binErrSleep)
+}
+
+func (r *XactBurnIn) Report() *apc.BurnInReport {
+	rep := &apc.BurnInReport{
+		Xid:     r.ID(),
+		Msg:     *r.msg,
+		Started: r.StartTime(),
+		Mpaths:  make([]*apc.BurnInMpath, 0, len(r.mpaths)),
+	}
+	for _, bm := range r.mpaths {
+		rep.Mpaths = append(rep.Mpaths, bm.toReport(r.msg.Workers))
+	}
+	if !r.Finished() {
+		rep.Running = true
+		return rep
+	}
+	rep.Ended = r.EndTime()
+	if r.IsAborted() {
+		rep.Reason = "aborted"
+		if err := r.AbortErr(); err != nil {
+			rep.Reason += ": " + err.Error()
+		}
+	} else {
+		rep.Reason = rep.Judge()
+	}
+	rep.Passed = rep.Reason == ""
+	return rep
+}
+
+func (r *XactBurnIn) Snap() (snap *core.Snap) {
+	snap = &core.Snap{}
+	r.ToSnap(snap)
+
+	snap.Ext = r.Report()
+	snap.IdleX = r.IsIdle()
+	return
+}
+
+//////////////
+// binMpath //
+//////////////
+
+// write `size` bytes chunk by chunk, whereby each chunk is the same (random) `buf`
+// stamped with `seq` and its offset (so that the content differs every time);
+// returns the checksum of the written content
+func (bm *binMpath) write(fqn string, buf []byte, size int64, seq uint64) (uint64, error) {
+	started := mono.NanoTime()
+	file, err := os.OpenFile(fqn, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, cos.PermRWR)
+	if err != nil {
+		return 0, err
+	}
+	h := xxhash.NewS64(cos.MLCG32)
+	for off := int64(0); off < size && err == nil; {
+		chunk := buf[:min(int64(len(buf)), size-off)]
+		if len(chunk) >= 16 {
+			binary.BigEndian.PutUint64(chunk, seq)
+			binary.BigEndian.PutUint64(chunk[8:], uint64(off))
+		}
+		h.Write(chunk)
+		_, err = file.Write(chunk)
+		off += int64(len(chunk))
+	}
+	if err == nil {
+		err = file.Sync()
+	}
+	if err == nil {
+		err = fs.DropCache(file)
+	}
+	if errC := file.Close(); err == nil {
+		err = errC
+	}
+	if err != nil {
+		return 0, err
+	}
+	bm.wlat.Add(mono.SinceNano(started))
+	bm.wbytes.Add(size)
+	bm.nw.Inc()
+	return h.Sum64(), nil
+}
+
+// read back and checksum
+func (bm *binMpath) read(fqn string, buf []byte, size int64) (uint64, error) {
+	started := mono.NanoTime()
+	file, err := os.Open(fqn)
+	if err != nil {
+		return 0, err
+	}
+	h := xxhash.NewS64(cos.MLCG32)
+	n, err := io.CopyBuffer(h, file, buf)
+	if err == nil && n != size {
+		err = fmt.Errorf("%s: unexpected size %d (expecting %d)", fqn, n, size)
+	}
+	if errC := file.Close(); err == nil {
+		err = errC
+	}
+	if err != nil {
+		return 0, err
+	}
+	bm.rlat.Add(mono.SinceNano(started))
+	bm.rbytes.Add(size)
+	bm.nr.Inc()
+	return h.Sum64(), nil
+}
+
+// throughput: effective (concurrent) rate, i.e., bytes per cumulative latency times number of workers
+func (bm *binMpath) toReport(workers int) *apc.BurnInMpath {
+	mp := &apc.BurnInMpath{
+		Mpath:      bm.mi.Path,
+		WriteBytes: bm.wbytes.Load(),
+		ReadBytes:  bm.rbytes.Load(),
+		Errors:     bm.errs.Load(),
+		CksumErrs:  bm.cerrs.Load(),
+	}
+	if nw, wlat := bm.nw.Load(), bm.wlat.Load(); nw > 0 && wlat > 0 {
+		mp.WriteLat = wlat / nw
+		mp.WriteBps = int64(float64(mp.WriteBytes) * float64(workers) * float64(time.Second) / float64(wlat))
+	}
+	if nr, rlat := bm.nr.Load(), bm.rlat.Load(); nr > 0 && rlat > 0 {
+		mp.ReadLat = rlat / nr
+		mp.ReadBps = int64(float64(mp.ReadBytes) * float64(workers) * float64(time.Second) / float64(rlat))
+	}
+	return mp
+}
@@ -0,0 +1,118 @@
+// Package xs is a collection of eXtended actions (xactions), including multi-object
+// operations, list-objects, (cluster) rebalance and (target) resilver, ETL, and more.
+/*
+ * Copyright (c) 2024, NVIDIA CORPORATION. All rights reserved.
+ */
+package xs
+
+import (
+	"sync"
+
+	"github.com/NVIDIA/aistore/api/apc"
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/cmn/cos"
+	"github.com/NVIDIA/aistore/cmn/nlog"
+	"github.com/NVIDIA/aistore/core"
+	"github.com/NVIDIA/aistore/core/meta"
+	"github.com/NVIDIA/aistore/fs"
+	"github.com/NVIDIA/aistore/fs/mpather"
+	"github.com/NVIDIA/aistore/xact"
+	"github.com/NVIDIA/aistore/xact/xreg"
+)
+
+// Migrate objects' custom metadata between xattrs and per-object sidecar files
+// in accordance with the bucket's current configuration (see feat.CustomMDSidecar).
+// Objects that already conform are skipped; stats count migrated objects only.
+
+type (
+	cmdFactory struct {
+		xreg.RenewBase
+		xctn *XactMigrateCustomMD
+	}
+	XactMigrateCustomMD struct {
+		xact.BckJog
+	}
+)
+
+// interface guard
+var (
+	_ core.Xact      = (*XactMigrateCustomMD)(nil)
+	_ xreg.Renewable = (*cmdFactory)(nil)
+)
+
+////////////////
+// cmdFactory //
+////////////////
+
+func (*cmdFactory) New(args xreg.Args, bck *meta.Bck) xreg.Renewable {
+	p := &cmdFactory{RenewBase: xreg.RenewBase{Args: args, Bck: bck}}
+	return p
+}
+
+func (p *cmdFactory) Start() error {
+	p.xctn = newXactMigrateCustomMD(p.UUID(), p.Bck)
+	go p.xctn.Run(nil)
+	return nil
+}
+
+func (*cmdFactory) Kind() string     { return apc.ActMigrateCustomMD }
+func (p *cmdFactory) Get() core.Xact { return p.xctn }
+
+func (*cmdFactory) WhenPrevIsRunning(xreg.Renewable) (xreg.WPR, error) { return xreg.WprUse, nil }
+
+/////////////////////////
+// XactMigrateCustomMD //
+/////////////////////////
+
+func newXactMigrateCustomMD(uuid string, bck *meta.Bck) (r *XactMigrateCustomMD) {
+	r = &XactMigrateCustomMD{}
+	mpopts := &mpather.JgroupOpts{
+		CTs:      []string{fs.ObjectType},
+		VisitObj: r.visit,
+	}
+	mpopts.Bck.Copy(bck.Bucket())
+	r.BckJog.Init(uuid, apc.ActMigrateCustomMD, bck, mpopts, cmn.GCO.Get())
+	return
+}
+
+func (r *XactMigrateCustomMD) Run(*sync.WaitGroup) {
+	r.BckJog.Run()
+	nlog.Infoln(r.Name())
+	err := r.BckJog.Wait()
+	if err != nil {
+		r.AddErr(err)
+	}
+	r.Finish()
+}
+
+func (r *XactMigrateCustomMD) visit(lom *core.LOM, _ []byte) error {
+	lom.Lock(true)
+	defer lom.Unlock(true)
+
+	if err := lom.Load(false /*cache it*/, true /*locked*/); err != nil {
+		if cos.IsNotExist(err, 0) {
+			return nil
+		}
+		return err
+	}
+	if lom.IsCopy() {
+		return nil // (migrated along with the main replica)
+	}
+	migrated, err := lom.MigrateCustomMD()
+	if err != nil {
+		r.AddErr(err, 4, cos.SmoduleXs)
+		return nil
+	}
+	if migrated {
+		r.ObjsAdd(1, lom.Lsize())
+	}
+	return nil
+}
+
+func (r *XactMigrateCustomMD) Snap() (snap *core.Snap) {
+	snap = &core.Snap{}
+	r.ToSnap(snap)
+
+	snap.IdleX = r.IsIdle()
+	return
+}
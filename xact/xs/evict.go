@@ -6,12 +6,17 @@
 package xs
 
 import (
+	"net/http"
+	"strings"
 	"sync"
+	"time"
 
 	"github.com/NVIDIA/aistore/api/apc"
 	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/cmn/atomic"
 	"github.com/NVIDIA/aistore/cmn/cos"
 	"github.com/NVIDIA/aistore/cmn/debug"
+	"github.com/NVIDIA/aistore/cmn/nlog"
 	"github.com/NVIDIA/aistore/core"
 	"github.com/NVIDIA/aistore/core/meta"
 	"github.com/NVIDIA/aistore/xact"
@@ -29,9 +34,32 @@ type (
 		lrit
 		xact.Base
 		config *cmn.Config
+		batch  *delBatch // when deleting from a remote backend that implements core.BatchDeleter
+	}
+	// batched backend deletion
+	delBatch struct {
+		bd      core.BatchDeleter
+		names   []string
+		ecodes  []int
+		errs    []error
+		batches atomic.Int64
+		retries atomic.Int64
+		failed  atomic.Int64
+		mu      sync.Mutex // (workers)
+		flushMu sync.Mutex // (one batch at a time)
+	}
+
+	// extended x-delete statistics (batched deletion only)
+	ExtDelStats struct {
+		Batches int64 `json:"del.batch.n,string"`   // number of backend calls
+		Retries int64 `json:"del.retry.n,string"`   // number of retried objects
+		Failed  int64 `json:"del.failed.n,string"`  // number of objects that failed to delete
+		Pending int64 `json:"del.pending.n,string"` // currently batched
 	}
 )
 
+const delBatchRetryAfter = time.Second
+
 //
 // evict/delete; utilizes mult-object lr-iterator
 //
@@ -61,6 +89,12 @@ func newEvictDelete(xargs *xreg.Args, kind string, bck *meta.Bck, msg *apc.ListR
 		return nil, err
 	}
 	ed.InitBase(xargs.UUID, kind, bck)
+	// (write-back buckets defer backend deletions and batch them when due - see cmn.WriteBackConf)
+	if kind == apc.ActDeleteObjects && bck.IsRemote() && !bck.Props.WriteBack.Enabled {
+		if bd, ok := core.T.Backend(bck).(core.BatchDeleter); ok {
+			ed.batch = &delBatch{bd: bd, names: make([]string, 0, core.MaxDeleteBatch)}
+		}
+	}
 	return ed, nil
 }
 
@@ -71,10 +105,21 @@ func (r *evictDelete) Run(wg *sync.WaitGroup) {
 		r.AddErr(err, 5, cos.SmoduleXs) // duplicated?
 	}
 	r.lrit.wait()
+	if r.batch != nil && !r.IsAborted() {
+		r.batch.mu.Lock()
+		names := r.batch.names
+		r.batch.names = make([]string, 0, core.MaxDeleteBatch)
+		r.batch.mu.Unlock()
+		r.flush(names)
+	}
 	r.Finish()
 }
 
 func (r *evictDelete) do(lom *core.LOM, lrit *lrit) {
+	if r.batch != nil {
+		r.add(lom.ObjName)
+		return
+	}
 	ecode, err := core.T.DeleteObject(lom, r.Kind() == apc.ActEvictObjects)
 	if err == nil { // done
 		r.ObjsAdd(1, lom.Lsize(true))
@@ -90,10 +135,139 @@ eret:
 	r.AddErr(err, 5, cos.SmoduleXs)
 }
 
+//
+// batched backend deletion: first backend, then in-cluster copies (if any)
+//
+
+func (r *evictDelete) add(objName string) {
+	b := r.batch
+	b.mu.Lock()
+	b.names = append(b.names, objName)
+	if len(b.names) < core.MaxDeleteBatch {
+		b.mu.Unlock()
+		return
+	}
+	names := b.names
+	b.names = make([]string, 0, core.MaxDeleteBatch)
+	b.mu.Unlock()
+
+	r.flush(names)
+}
+
+func (r *evictDelete) flush(names []string) {
+	if len(names) == 0 {
+		return
+	}
+	b := r.batch
+	b.flushMu.Lock()
+	defer b.flushMu.Unlock()
+
+	if cap(b.ecodes) < len(names) {
+		b.ecodes, b.errs = make([]int, core.MaxDeleteBatch), make([]error, core.MaxDeleteBatch)
+	}
+	ecodes, errs := b.ecodes[:len(names)], b.errs[:len(names)]
+	clear(ecodes)
+	clear(errs)
+
+	// (same special corner-case retry as in t.DeleteObject)
+	ecode, err := r.call(names, ecodes, errs)
+	if err != nil && isRetriableDel(ecode, err) {
+		nlog.Errorln(r.Name(), "failed to delete batch of", len(names), "objects:", err, ecode, "- retrying...")
+		time.Sleep(delBatchRetryAfter)
+		ecode, err = r.call(names, ecodes, errs)
+	}
+	if err != nil {
+		r.AddErr(err, 0)
+		b.failed.Add(int64(len(names)))
+		return
+	}
+
+	// retry (once) individual objects that failed with retriable errors
+	var idx []int
+	for i := range names {
+		if errs[i] != nil && isRetriableDel(ecodes[i], errs[i]) {
+			idx = append(idx, i)
+		}
+	}
+	if len(idx) > 0 {
+		var (
+			retry  = make([]string, len(idx))
+			rcodes = make([]int, len(idx))
+			rerrs  = make([]error, len(idx))
+		)
+		for j, i := range idx {
+			retry[j] = names[i]
+		}
+		b.retries.Add(int64(len(idx)))
+		time.Sleep(delBatchRetryAfter)
+		if _, err := r.call(retry, rcodes, rerrs); err == nil {
+			for j, i := range idx {
+				ecodes[i], errs[i] = rcodes[j], rerrs[j]
+			}
+		}
+	}
+
+	// in-cluster copies
+	for i, name := range names {
+		if r.IsAborted() {
+			return
+		}
+		r.delLocal(name, ecodes[i], errs[i])
+	}
+}
+
+func (r *evictDelete) call(names []string, ecodes []int, errs []error) (int, error) {
+	r.batch.batches.Inc()
+	return r.batch.bd.DeleteObjs(r.Bck(), names, ecodes, errs)
+}
+
+func (r *evictDelete) delLocal(name string, ecode int, err error) {
+	notFound := err != nil && (cos.IsNotExist(err, ecode) || cmn.IsErrObjNought(err))
+	if err != nil && !notFound {
+		r.batch.failed.Inc()
+		r.AddErr(err, 5, cos.SmoduleXs)
+		return
+	}
+	lom := core.AllocLOM(name)
+	defer core.FreeLOM(lom)
+	if errV := lom.InitBck(r.Bck().Bucket()); errV != nil {
+		r.AddErr(errV, 0)
+		return
+	}
+	_, errV := core.T.DeleteLocal(lom)
+	switch {
+	case errV != nil:
+		r.batch.failed.Inc()
+		r.AddErr(errV, 5, cos.SmoduleXs)
+	case notFound:
+		// not found remotely (unlike range and prefix, an error in list mode)
+		if r.lrp == lrpList {
+			r.AddErr(err, 5, cos.SmoduleXs)
+		}
+	default:
+		r.ObjsAdd(1, lom.Lsize(true))
+	}
+}
+
+func isRetriableDel(ecode int, err error) bool {
+	return ecode == http.StatusServiceUnavailable || strings.Contains(err.Error(), "try again")
+}
+
 func (r *evictDelete) Snap() (snap *core.Snap) {
 	snap = &core.Snap{}
 	r.ToSnap(snap)
 
+	if b := r.batch; b != nil {
+		b.mu.Lock()
+		pending := int64(len(b.names))
+		b.mu.Unlock()
+		snap.Ext = &ExtDelStats{
+			Batches: b.batches.Load(),
+			Retries: b.retries.Load(),
+			Failed:  b.failed.Load(),
+			Pending: pending,
+		}
+	}
 	snap.IdleX = r.IsIdle()
 	return
 }
@@ -20,6 +20,9 @@ func Xreg(xeleOnly bool) {
 	xreg.RegNonBckXact(&resFactory{})
 	xreg.RegNonBckXact(&rebFactory{})
 	xreg.RegNonBckXact(&etlFactory{})
+	xreg.RegNonBckXact(&binFactory{})
+	xreg.RegNonBckXact(&mprFactory{})
+	xreg.RegNonBckXact(&audFactory{})
 
 	xreg.RegBckXact(&bmvFactory{})
 	xreg.RegBckXact(&evdFactory{kind: apc.ActEvictObjects})
@@ -27,9 +30,13 @@ func Xreg(xeleOnly bool) {
 	xreg.RegBckXact(&prfFactory{})
 
 	xreg.RegNonBckXact(&nsummFactory{})
+	xreg.RegNonBckXact(&aicFactory{})
+	xreg.RegNonBckXact(&rclFactory{})
 
 	xreg.RegBckXact(&proFactory{})
 	xreg.RegBckXact(&llcFactory{})
+	xreg.RegBckXact(&tpgFactory{})
+	xreg.RegBckXact(&cmdFactory{})
 
 	xreg.RegBckXact(&tcbFactory{kind: apc.ActCopyBck})
 	xreg.RegBckXact(&tcbFactory{kind: apc.ActETLBck})
@@ -55,6 +55,11 @@ type (
 		IsAborted() bool
 		Finished() bool
 	}
+	// optional pacing of list and range iterations (see prefetch)
+	lrpacer interface {
+		// called prior to executing idx-th (local) object in the list or range
+		pace(idx int64) (skip, stop bool)
+	}
 
 	// running concurrency
 	lrpair struct {
@@ -74,6 +79,10 @@ type (
 		prefix string
 		lrp    int // { lrpList, ... } enum
 
+		// pacing (optional)
+		pacer lrpacer
+		idx   int64 // current position in the list or range
+
 		// running concurrency
 		workCh  chan lrpair
 		workers []*lrworker
@@ -218,10 +227,11 @@ func (r *lrit) done() bool { return r.parent.IsAborted() || r.parent.Finished()
 
 func (r *lrit) _list(wi lrwi, smap *meta.Smap) error {
 	r.lrp = lrpList
-	for _, objName := range r.msg.ObjNames {
+	for i, objName := range r.msg.ObjNames {
 		if r.done() {
 			break
 		}
+		r.idx = int64(i)
 		lom := core.AllocLOM(objName)
 		done, err := r.do(lom, wi, smap)
 		if err != nil {
@@ -237,10 +247,12 @@ func (r *lrit) _list(wi lrwi, smap *meta.Smap) error {
 
 func (r *lrit) _range(wi lrwi, smap *meta.Smap) error {
 	r.pt.InitIter()
+	r.idx = -1
 	for objName, hasNext := r.pt.Next(); hasNext; objName, hasNext = r.pt.Next() {
 		if r.done() {
 			return nil
 		}
+		r.idx++
 		lom := core.AllocLOM(objName)
 		done, err := r.do(lom, wi, smap)
 		if err != nil {
@@ -334,6 +346,11 @@ func (r *lrit) do(lom *core.LOM, wi lrwi, smap *meta.Smap) (bool /*this lom done
 			return true, nil
 		}
 	}
+	if r.pacer != nil {
+		if skip, stop := r.pacer.pace(r.idx); skip || stop {
+			return true, nil
+		}
+	}
 
 	if r.workers == nil {
 		wi.do(lom, r)
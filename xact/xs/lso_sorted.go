@@ -0,0 +1,71 @@
+// Package xs is a collection of eXtended actions (xactions), including multi-object
+// operations, list-objects, (cluster) rebalance and (target) resilver, ETL, and more.
+/*
+ * Copyright (c) 2024, NVIDIA CORPORATION. All rights reserved.
+ */
+package xs
+
+import (
+	"context"
+	"path/filepath"
+
+	"github.com/NVIDIA/aistore/api/apc"
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/cmn/debug"
+	"github.com/NVIDIA/aistore/core/meta"
+	"github.com/NVIDIA/aistore/fs"
+	"golang.org/x/sync/errgroup"
+)
+
+// ListSorted walks local objects of a given bucket (and prefix) and returns the
+// top-N entries in the requested order (see apc.LsoSort) - a single page;
+// unlike paginated list-objects (LsoXact), it is executed synchronously, in the
+// context of the caller
+//   - since the order is by size or mtime (not by name), mountpaths are traversed
+//     in parallel, unsorted, with each mountpath selecting its own top-N (merged below)
+func ListSorted(bck *meta.Bck, msg *apc.LsoMsg) (*cmn.LsoRes, error) {
+	debug.Assert(msg.Sort != nil && msg.PageSize > 0)
+	var (
+		wi         = newWalkInfo(msg, noopCb)
+		avail      = fs.GetAvail()
+		topns      = make([]*cmn.LsoTopN, 0, len(avail))
+		group, ctx = errgroup.WithContext(context.Background())
+	)
+	for _, mi := range avail {
+		topn := cmn.NewLsoTopN(msg.Sort, int(msg.PageSize))
+		topns = append(topns, topn)
+		opts := &fs.WalkOpts{Mi: mi, CTs: []string{fs.ObjectType}, Prefix: msg.Prefix}
+		opts.Bck.Copy(bck.Bucket())
+		opts.Callback = func(fqn string, de fs.DirEntry) error {
+			select {
+			case <-ctx.Done():
+				return cmn.NewErrAborted(mi.String(), "list-sorted", nil)
+			default:
+			}
+			if de.IsDir() {
+				return wi.processDir(fqn)
+			}
+			entry, err := wi.callback(fqn, de)
+			if err == nil && entry != nil {
+				topn.Add(entry)
+			}
+			return err
+		}
+		group.Go(func() error {
+			if err := fs.Walk(opts); err != nil && err != filepath.SkipDir {
+				return err
+			}
+			return nil
+		})
+	}
+	if err := group.Wait(); err != nil {
+		return nil, err
+	}
+	topn := cmn.NewLsoTopN(msg.Sort, int(msg.PageSize))
+	for _, t := range topns {
+		for _, e := range t.Sorted() {
+			topn.Add(e)
+		}
+	}
+	return &cmn.LsoRes{UUID: msg.UUID, Entries: topn.Sorted()}, nil
+}
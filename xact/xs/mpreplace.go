@@ -0,0 +1,452 @@
+// Package xs is a collection of eXtended actions (xactions), including multi-object
+// operations, list-objects, (cluster) rebalance and (target) resilver, ETL, and more.
+/*
+ * Copyright (c) 2024, NVIDIA CORPORATION. All rights reserved.
+ */
+package xs
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	ratomic "sync/atomic"
+	"time"
+
+	"github.com/NVIDIA/aistore/api/apc"
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/cmn/atomic"
+	"github.com/NVIDIA/aistore/cmn/cos"
+	"github.com/NVIDIA/aistore/cmn/mono"
+	"github.com/NVIDIA/aistore/cmn/nlog"
+	"github.com/NVIDIA/aistore/core"
+	"github.com/NVIDIA/aistore/core/meta"
+	aisfs "github.com/NVIDIA/aistore/fs"
+	"github.com/NVIDIA/aistore/fs/mpather"
+	"github.com/NVIDIA/aistore/memsys"
+	"github.com/NVIDIA/aistore/xact"
+	"github.com/NVIDIA/aistore/xact/xreg"
+)
+
+// Mountpath replacement (old disk => new disk on the same target):
+// 1. copy: all buckets' content (objects, replicas, EC slices and metafiles, etc.) gets copied
+//    directly, path to path, preserving relative names and xattrs (object metadata);
+//    files that are already identical (same size and mtime) are skipped, which is why
+//    the xaction can be simply restarted after abort;
+//    repeat until the delta (written to the old mountpath in the meantime) is small
+//    (see mprSettle), then remove destination files that no longer exist in the source;
+// 2. swap: the new mountpath takes over, inheriting the old one's HRW digest (fs.ReplaceMpath),
+//    so that object placement (and the rest of the cluster) remains unaffected;
+// 3. catch-up: copy what's been written to the old mountpath since the last pass -
+//    without overwriting anything that's been written to the new one since the swap
+//    and without bringing back what's been deleted from it (see fs.Replaced);
+//    in the meantime, GET restores not-yet-copied objects on demand (see core.LOM.RestoreToLocation).
+// Each copied file is verified (xxhash) by reading it back, unless apc.MpathReplaceMsg.SkipVerify.
+// Copying is throttled by disk utilization and, optionally, by apc.MpathReplaceMsg.MaxBps.
+// The old mountpath's content is never modified - it is left to the admin to wipe or remove.
+
+const (
+	mprMaxPasses = 4  // max copy passes prior to swapping
+	mprSettle    = 64 // swap when the number of files copied in the last pass does not exceed
+)
+
+type (
+	mprFactory struct {
+		xreg.RenewBase
+		xctn *XactMpathReplace
+	}
+	XactMpathReplace struct {
+		args    *xreg.MpathReplaceArgs
+		config  *cmn.Config
+		buf     []byte
+		slab    *memsys.Slab
+		tmpdir  string
+		stage   ratomic.Value   // string
+		pstart  int64           // pacing (MaxBps): mono time
+		paced   int64           // and bytes copied since
+		swapped atomic.Int64    // Unix time (nanoseconds)
+		rp      *aisfs.Replaced // post-swap state (until catch-up completes)
+		passes  atomic.Int32
+		files   atomic.Int64
+		bytes   atomic.Int64
+		skipped atomic.Int64
+		removed atomic.Int64
+		nerr    atomic.Int64
+		xact.Base
+	}
+	// one pass
+	mprPass struct {
+		r       *XactMpathReplace
+		catchUp bool
+		files   int64
+		nerr    int64
+	}
+)
+
+var errMprAborted = errors.New("aborted")
+
+// interface guard
+var (
+	_ core.Xact      = (*XactMpathReplace)(nil)
+	_ xreg.Renewable = (*mprFactory)(nil)
+)
+
+////////////////
+// mprFactory //
+////////////////
+
+func (*mprFactory) New(args xreg.Args, _ *meta.Bck) xreg.Renewable {
+	return &mprFactory{RenewBase: xreg.RenewBase{Args: args}}
+}
+
+func (p *mprFactory) Start() error {
+	args := p.Args.Custom.(*xreg.MpathReplaceArgs)
+	r := &XactMpathReplace{args: args, config: cmn.GCO.Get()}
+	r.InitBase(p.UUID(), apc.ActMountpathReplace, nil)
+	r.tmpdir = args.Dst.TempDir(apc.ActMountpathReplace + "-" + p.UUID())
+	if err := cos.CreateDir(r.tmpdir); err != nil {
+		return err
+	}
+	r.stage.Store(apc.MpathReplaceCopy)
+	p.xctn = r
+	go r.Run(nil)
+	return nil
+}
+
+func (*mprFactory) Kind() string     { return apc.ActMountpathReplace }
+func (p *mprFactory) Get() core.Xact { return p.xctn }
+
+func (*mprFactory) WhenPrevIsRunning(prevEntry xreg.Renewable) (xreg.WPR, error) {
+	return xreg.WprUse, cmn.NewErrXactUsePrev(prevEntry.Get().String())
+}
+
+//////////////////////
+// XactMpathReplace //
+//////////////////////
+
+func (r *XactMpathReplace) Run(*sync.WaitGroup) {
+	var (
+		src, dst = r.args.Src, r.args.Dst
+		err      error
+	)
+	nlog.Infoln(r.Name(), src.String(), "=>", dst.String(), "max-bps", r.args.Msg.MaxBps)
+	r.buf, r.slab = core.T.PageMM().Alloc()
+	r.pstart = mono.NanoTime()
+
+	if err = r.copyAll(); err == nil {
+		err = r.swap()
+	}
+	if err == nil {
+		r.stage.Store(apc.MpathReplaceCatchUp)
+		pass := &mprPass{r: r, catchUp: true}
+		if err = pass.run(); err == nil {
+			r.stage.Store(apc.MpathReplaceDone)
+		}
+	}
+	if err != nil && err != errMprAborted {
+		r.Abort(err)
+	}
+	if r.rp != nil {
+		aisfs.EndReplace(r.rp)
+	}
+
+	r.slab.Free(r.buf)
+	if errR := os.RemoveAll(r.tmpdir); errR != nil {
+		nlog.Warningln(r.Name(), "failed to cleanup:", errR)
+	}
+	r.Finish()
+
+	rep := r.Report()
+	nlog.Infof("%s: stage %q, passes %d, files %d (%s), skipped %d, removed %d, errors %d", r.Name(), rep.Stage,
+		rep.Passes, rep.Files, cos.ToSizeIEC(rep.Bytes, 2), rep.Skipped, rep.Removed, rep.Errors)
+}
+
+// copy until the delta is small enough, then remove what's been deleted in the meantime
+func (r *XactMpathReplace) copyAll() error {
+	for {
+		pass := &mprPass{r: r}
+		if err := pass.run(); err != nil {
+			return err
+		}
+		if pass.files <= mprSettle || int(r.passes.Load()) >= mprMaxPasses {
+			if pass.nerr > 0 {
+				return fmt.Errorf("%s: not replacing %s - failed to copy %d file(s)", r, r.args.Src, pass.nerr)
+			}
+			break
+		}
+	}
+	return r.prune()
+}
+
+func (r *XactMpathReplace) swap() error {
+	if r.IsAborted() {
+		return errMprAborted
+	}
+	r.stage.Store(apc.MpathReplaceSwap)
+	now := time.Now().UnixNano()
+	if err := r.args.Swap(); err != nil {
+		return err
+	}
+	r.swapped.Store(now)
+	r.rp = aisfs.GetReplaced()
+	return nil
+}
+
+// remove destination files that are no longer present in the source (prior to swapping)
+func (r *XactMpathReplace) prune() error {
+	src, dst := r.args.Src, r.args.Dst
+	return r.walk(dst.Path, func(fqn string, _ fs.DirEntry) error {
+		srcFQN := src.Path + strings.TrimPrefix(fqn, dst.Path)
+		if _, err := os.Lstat(srcFQN); err == nil || !os.IsNotExist(err) {
+			return nil
+		}
+		if err := os.Remove(fqn); err != nil {
+			if !os.IsNotExist(err) {
+				r.ioerr(err)
+			}
+			return nil
+		}
+		r.removed.Inc()
+		return nil
+	})
+}
+
+// walk all buckets, skipping work files
+func (r *XactMpathReplace) walk(root string, cb func(string, fs.DirEntry) error) error {
+	dentries, err := os.ReadDir(root)
+	if err != nil {
+		return err
+	}
+	wkdir := string(filepath.Separator) + "%" + aisfs.WorkfileType
+	for _, de := range dentries {
+		if !de.IsDir() || !strings.HasPrefix(de.Name(), "@") { // (see fs.makePathBuf)
+			continue
+		}
+		err := filepath.WalkDir(filepath.Join(root, de.Name()), func(path string, d fs.DirEntry, err error) error {
+			if err != nil {
+				if os.IsNotExist(err) {
+					return nil
+				}
+				r.ioerr(err)
+				return nil
+			}
+			if r.IsAborted() {
+				return errMprAborted
+			}
+			if d.IsDir() {
+				if strings.HasSuffix(path, wkdir) {
+					return filepath.SkipDir
+				}
+				return nil
+			}
+			if !d.Type().IsRegular() {
+				return nil
+			}
+			return cb(path, d)
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (r *XactMpathReplace) ioerr(err error) {
+	r.nerr.Inc()
+	r.AddErr(err, 0)
+}
+
+// throttle: source disk utilization and, optionally, max throughput
+func (r *XactMpathReplace) throttle(size int64) {
+	if aisfs.GetMpathUtil(r.args.Src.Path) >= r.config.Disk.DiskUtilHighWM {
+		time.Sleep(mpather.ThrottleMinDur)
+	}
+	maxBps := r.args.Msg.MaxBps
+	if maxBps <= 0 {
+		return
+	}
+	r.paced += size
+	expected := time.Duration(float64(r.paced) / float64(maxBps) * float64(time.Second))
+	for elapsed := mono.Since(r.pstart); elapsed < expected && !r.IsAborted(); elapsed = mono.Since(r.pstart) {
+		time.Sleep(min(expected-elapsed, mpather.ThrottleMaxDur))
+	}
+}
+
+func (r *XactMpathReplace) Report() *apc.MpathReplaceReport {
+	rep := &apc.MpathReplaceReport{
+		Xid:     r.ID(),
+		Msg:     *r.args.Msg,
+		Started: r.StartTime(),
+		Stage:   r.stage.Load().(string),
+		Passes:  int(r.passes.Load()),
+		Files:   r.files.Load(),
+		Bytes:   r.bytes.Load(),
+		Skipped: r.skipped.Load(),
+		Removed: r.removed.Load(),
+		Errors:  r.nerr.Load(),
+	}
+	if swapped := r.swapped.Load(); swapped != 0 {
+		rep.Swapped = time.Unix(0, swapped)
+	}
+	if !r.Finished() {
+		rep.Running = true
+		return rep
+	}
+	rep.Ended = r.EndTime()
+	if r.IsAborted() {
+		rep.Reason = "aborted"
+		if err := r.AbortErr(); err != nil {
+			rep.Reason += ": " + err.Error()
+		}
+	}
+	return rep
+}
+
+func (r *XactMpathReplace) Snap() (snap *core.Snap) {
+	snap = &core.Snap{}
+	r.ToSnap(snap)
+
+	snap.Ext = r.Report()
+	snap.IdleX = r.IsIdle()
+	return
+}
+
+/////////////
+// mprPass //
+/////////////
+
+func (p *mprPass) run() error {
+	var (
+		r      = p.r
+		nerr   = r.nerr.Load()
+		passes = r.passes.Inc()
+	)
+	err := r.walk(r.args.Src.Path, p.do)
+	p.nerr = r.nerr.Load() - nerr
+	if err != nil {
+		return err
+	}
+	nlog.Infoln(r.Name(), "pass", passes, "catch-up", p.catchUp, "copied", p.files, "errors", p.nerr)
+	return nil
+}
+
+func (p *mprPass) do(srcFQN string, de fs.DirEntry) error {
+	r := p.r
+	sfi, err := de.Info()
+	if err != nil {
+		if !os.IsNotExist(err) {
+			r.ioerr(err)
+		}
+		return nil
+	}
+	dstFQN := r.args.Dst.Path + strings.TrimPrefix(srcFQN, r.args.Src.Path)
+	if p.catchUp && r.rp != nil && r.rp.IsUnlinked(dstFQN) {
+		return nil // (deleted since the swap)
+	}
+	if dfi, err := os.Lstat(dstFQN); err == nil {
+		if dfi.Size() == sfi.Size() && dfi.ModTime().Equal(sfi.ModTime()) {
+			r.skipped.Inc()
+			return nil
+		}
+		// (written since the swap)
+		if p.catchUp && dfi.ModTime().UnixNano() >= r.swapped.Load() {
+			return nil
+		}
+	}
+
+	tmp := filepath.Join(r.tmpdir, strconv.FormatInt(r.files.Load(), 36))
+	if err := p.copy(srcFQN, tmp, sfi); err != nil {
+		if errR := os.Remove(tmp); errR != nil && !os.IsNotExist(errR) {
+			nlog.Warningln(r.Name(), errR)
+		}
+		if !os.IsNotExist(err) { // (removed in the meantime)
+			r.ioerr(err)
+		}
+		return nil
+	}
+	if p.catchUp {
+		err = p.commitLive(tmp, dstFQN)
+	} else {
+		err = cos.Rename(tmp, dstFQN)
+	}
+	if err != nil {
+		r.ioerr(err)
+		return nil
+	}
+
+	p.files++
+	r.files.Inc()
+	r.bytes.Add(sfi.Size())
+	r.ObjsAdd(1, sfi.Size())
+	r.DiskReadAdd(sfi.Size())
+	r.DiskWriteAdd(sfi.Size())
+	r.throttle(sfi.Size())
+	return nil
+}
+
+// copy file content, xattrs, and mtime; verify
+func (p *mprPass) copy(srcFQN, tmp string, sfi fs.FileInfo) error {
+	r := p.r
+	cksumType := cos.ChecksumXXHash
+	if r.args.Msg.SkipVerify {
+		cksumType = cos.ChecksumNone
+	}
+	_, cksum, err := cos.CopyFile(srcFQN, tmp, r.buf, cksumType)
+	if err != nil {
+		return err
+	}
+	if err := aisfs.CopyXattrs(srcFQN, tmp); err != nil {
+		return err
+	}
+	if err := os.Chtimes(tmp, sfi.ModTime(), sfi.ModTime()); err != nil {
+		return err
+	}
+	if cksum == nil {
+		return nil
+	}
+	fh, err := os.Open(tmp)
+	if err != nil {
+		return err
+	}
+	_, vcksum, err := cos.CopyAndChecksum(io.Discard, fh, nil, cksumType)
+	cos.Close(fh)
+	if err != nil {
+		return err
+	}
+	if !cksum.Equal(&vcksum.Cksum) {
+		return cos.NewErrDataCksum(&cksum.Cksum, &vcksum.Cksum, srcFQN)
+	}
+	return nil
+}
+
+// (catch-up) the new mountpath is live: lock objects and do not overwrite those written
+// (or restore those deleted) since the swap
+func (p *mprPass) commitLive(tmp, dstFQN string) error {
+	var (
+		r      = p.r
+		parsed aisfs.ParsedFQN
+	)
+	if err := parsed.Init(dstFQN); err != nil || parsed.ContentType != aisfs.ObjectType {
+		return cos.Rename(tmp, dstFQN)
+	}
+	lom := core.AllocLOM("")
+	defer core.FreeLOM(lom)
+	if err := lom.InitFQN(dstFQN, nil); err != nil {
+		return err
+	}
+	lom.Lock(true)
+	defer lom.Unlock(true)
+	if r.rp != nil && r.rp.IsUnlinked(dstFQN) {
+		return os.Remove(tmp)
+	}
+	if dfi, err := os.Lstat(dstFQN); err == nil && dfi.ModTime().UnixNano() >= r.swapped.Load() {
+		return os.Remove(tmp)
+	}
+	lom.UncacheUnless()
+	return cos.Rename(tmp, dstFQN)
+}
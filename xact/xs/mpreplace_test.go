@@ -0,0 +1,115 @@
+// Package xs_test contains xs unit test.
+/*
+ * Copyright (c) 2024, NVIDIA CORPORATION. All rights reserved.
+ */
+package xs_test
+
+import (
+	"crypto/rand"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/NVIDIA/aistore/api/apc"
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/cmn/cos"
+	"github.com/NVIDIA/aistore/core"
+	"github.com/NVIDIA/aistore/core/meta"
+	"github.com/NVIDIA/aistore/core/mock"
+	"github.com/NVIDIA/aistore/fs"
+	"github.com/NVIDIA/aistore/tools"
+	"github.com/NVIDIA/aistore/tools/tassert"
+	"github.com/NVIDIA/aistore/xact/xreg"
+	"github.com/NVIDIA/aistore/xact/xs"
+)
+
+func TestMpathReplace(t *testing.T) {
+	const size = 16 * cos.KiB
+	var (
+		bck = meta.NewBck("replace", apc.AIS, cmn.NsGlobal,
+			&cmn.Bprops{Cksum: cmn.CksumConf{Type: cos.ChecksumNone}, BID: 0xa1b2c3d4})
+		bmd = mock.NewBaseBownerMock(bck)
+	)
+	fs.TestNew(mock.NewIOS())
+	t.Cleanup(func() { fs.TestNew(nil) })
+	fs.CSM.Reg(fs.ObjectType, &fs.ObjectContentResolver{}, true)
+	fs.CSM.Reg(fs.WorkfileType, &fs.WorkfileContentResolver{}, true)
+	core.T = mock.NewTarget(bmd)
+	xreg.TestReset()
+	xs.Xreg(false)
+	defer xreg.AbortAll(nil)
+	cos.InitShortID(0)
+
+	src, dst := t.TempDir(), t.TempDir()
+	tools.AddMpath(t, src)
+
+	newLOM := func(name string) *core.LOM {
+		lom := &core.LOM{ObjName: name}
+		tassert.CheckFatal(t, lom.InitBck(bck.Bucket()))
+		return lom
+	}
+	put := func(name string) {
+		lom := newLOM(name)
+		_, err := cos.SaveReader(lom.FQN, rand.Reader, make([]byte, size), cos.ChecksumNone, size)
+		tassert.CheckFatal(t, err)
+		lom.SetSize(size)
+		lom.IncVersion()
+		lom.SetAtimeUnix(time.Now().UnixNano())
+		tassert.CheckFatal(t, lom.Persist())
+	}
+	exists := func(name string) bool {
+		lom := newLOM(name)
+		return lom.Load(false /*cache it*/, false /*locked*/) == nil
+	}
+	for _, name := range []string{"keep", "deleted"} {
+		put(name)
+	}
+
+	srcMi, dstMi, err := fs.NewReplacement(src, dst, "")
+	tassert.CheckFatal(t, err)
+
+	var restored bool
+	swap := func() error {
+		// written to the old mountpath after the last copy pass
+		put("late")
+		if err := fs.ReplaceMpath(core.T.SID(), srcMi, dstMi, func() { core.UncacheMountpath(srcMi) }); err != nil {
+			return err
+		}
+		// deleted from the new mountpath prior to catch-up
+		lom := newLOM("deleted")
+		lom.Lock(true)
+		err := lom.Load(false /*cache it*/, true /*locked*/)
+		if err == nil {
+			err = lom.RemoveObj()
+		}
+		lom.Unlock(true)
+		if err != nil {
+			return err
+		}
+		// read prior to catch-up
+		restored = newLOM("late").RestoreToLocation()
+		return nil
+	}
+	args := &xreg.MpathReplaceArgs{Msg: &apc.MpathReplaceMsg{Src: src, Dst: dst}, Src: srcMi, Dst: dstMi, Swap: swap}
+	rns := xreg.RenewMpathReplace(cos.GenUUID(), args)
+	tassert.CheckFatal(t, rns.Err)
+	r := rns.Entry.Get().(*xs.XactMpathReplace)
+
+	for deadline := time.Now().Add(10 * time.Second); !r.Finished(); time.Sleep(10 * time.Millisecond) {
+		tassert.Fatalf(t, time.Now().Before(deadline), "%s: timed out", r)
+	}
+	rep := r.Report()
+	tassert.Fatalf(t, rep.Stage == apc.MpathReplaceDone && rep.Errors == 0, "unexpected report: %+v", rep)
+
+	tassert.Errorf(t, restored, "expected object written to the old mountpath to be restored on demand")
+	tassert.Errorf(t, exists("keep") && exists("late"), "expected objects to be copied over")
+	tassert.Errorf(t, !exists("deleted"), "expected object deleted after the swap to remain deleted")
+	tassert.Errorf(t, fs.GetReplaced() == nil, "expected replacement to be done")
+
+	// the old mountpath's content is never modified
+	for _, name := range []string{"keep", "deleted", "late"} {
+		fqn := srcMi.MakePathFQN(bck.Bucket(), fs.ObjectType, name)
+		_, err := os.Stat(fqn)
+		tassert.Errorf(t, err == nil, "%s: %v", fqn, err)
+	}
+}
@@ -8,6 +8,8 @@ package xs
 // core next-page and next-remote-page methods for object listing
 
 import (
+	"time"
+
 	"github.com/NVIDIA/aistore/api/apc"
 	"github.com/NVIDIA/aistore/cmn"
 	"github.com/NVIDIA/aistore/cmn/debug"
@@ -35,6 +37,7 @@ func newNpgCtx(bck *meta.Bck, msg *apc.LsoMsg, cb lomVisitedCb, ctx *core.LsoInv
 		},
 		ctx: ctx,
 	}
+	npg.wi.flt, _ = msg.Filter.Compile() // validated (by proxy)
 	return
 }
 
@@ -83,15 +86,16 @@ func (npg *npgCtx) cb(fqn string, de fs.DirEntry) error {
 func (npg *npgCtx) nextPageR(nentries cmn.LsoEntries, inclStatusLocalMD bool) (lst *cmn.LsoRes, err error) {
 	debug.Assert(!npg.wi.msg.IsFlagSet(apc.LsObjCached))
 	lst = &cmn.LsoRes{Entries: nentries}
+	msg, project := npg.lsmsgR()
 	if npg.ctx != nil {
 		if npg.ctx.Lom == nil {
 			_, err = core.T.Backend(npg.bck).GetBucketInv(npg.bck, npg.ctx)
 		}
 		if err == nil {
-			err = core.T.Backend(npg.bck).ListObjectsInv(npg.bck, npg.wi.msg, lst, npg.ctx)
+			err = core.T.Backend(npg.bck).ListObjectsInv(npg.bck, msg, lst, npg.ctx)
 		}
 	} else {
-		_, err = core.T.Backend(npg.bck).ListObjects(npg.bck, npg.wi.msg, lst)
+		_, err = core.T.Backend(npg.bck).ListObjects(npg.bck, msg, lst)
 	}
 	if err != nil {
 		freeLsoEntries(nentries)
@@ -99,6 +103,9 @@ func (npg *npgCtx) nextPageR(nentries cmn.LsoEntries, inclStatusLocalMD bool) (l
 	}
 	debug.Assert(lst.UUID == "" || lst.UUID == npg.wi.msg.UUID)
 	lst.UUID = npg.wi.msg.UUID
+	if npg.wi.flt != nil {
+		npg.filter(lst, project)
+	}
 
 	if inclStatusLocalMD {
 		err = npg.populate(lst)
@@ -143,3 +150,67 @@ func (npg *npgCtx) populate(lst *cmn.LsoRes) error {
 	}
 	return nil
 }
+
+//
+// server-side filtering of remote pages (note: continuation token remains intact)
+//
+
+// size and mtime filtering may require listing more props than requested
+func (npg *npgCtx) lsmsgR() (*apc.LsoMsg, bool /*project*/) {
+	msg := npg.wi.msg
+	if !msg.Filter.NeedsProps() {
+		return msg, false
+	}
+	var (
+		needMtime = npg.wi.flt.NeedsMtime() // (remote backends report last-modified as custom metadata)
+		nameOnly  = msg.IsFlagSet(apc.LsNameOnly)
+		haveMtime = msg.WantProp(apc.GetPropsCustom) && !nameOnly && !msg.IsFlagSet(apc.LsNameSize)
+	)
+	if !nameOnly && (!needMtime || haveMtime) {
+		return msg, false
+	}
+	clone := msg.Clone()
+	clone.ClearFlag(apc.LsNameOnly)
+	if needMtime {
+		clone.ClearFlag(apc.LsNameSize)
+		clone.AddProps(apc.GetPropsCustom)
+	} else {
+		clone.SetFlag(apc.LsNameSize)
+	}
+	return clone, true
+}
+
+func (npg *npgCtx) filter(lst *cmn.LsoRes, project bool) {
+	var (
+		msg     = npg.wi.msg
+		flt     = npg.wi.flt
+		entries = lst.Entries[:0]
+	)
+	for _, e := range lst.Entries {
+		if !e.IsDir() {
+			var mtime time.Time
+			if flt.NeedsMtime() && e.Custom != "" {
+				if v, ok := cmn.S2CustomMD(e.Custom, "")[cmn.LastModified]; ok {
+					mtime, _ = time.Parse(time.RFC3339, v)
+				}
+			}
+			if !flt.Match(e.Name, e.Size, mtime) {
+				continue
+			}
+		}
+		if project {
+			// back to the originally requested props
+			switch {
+			case msg.IsFlagSet(apc.LsNameOnly):
+				e.Size, e.Checksum, e.Version, e.Custom = 0, "", "", ""
+			case msg.IsFlagSet(apc.LsNameSize):
+				e.Checksum, e.Version, e.Custom = "", "", ""
+			case !msg.WantProp(apc.GetPropsCustom):
+				e.Custom = ""
+			}
+		}
+		entries = append(entries, e)
+	}
+	clear(lst.Entries[len(entries):])
+	lst.Entries = entries
+}
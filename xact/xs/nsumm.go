@@ -297,6 +297,8 @@ func (r *XactNsumm) cloneRes(dst, src *cmn.BsummResult) {
 
 	dst.ObjCount.Present = ratomic.LoadUint64(&src.ObjCount.Present)
 	dst.TotalSize.PresentObjs = ratomic.LoadUint64(&src.TotalSize.PresentObjs)
+	dst.ObjCount.Shared = ratomic.LoadUint64(&src.ObjCount.Shared)
+	dst.TotalSize.SharedObjs = ratomic.LoadUint64(&src.TotalSize.SharedObjs)
 
 	if r.listRemote {
 		dst.ObjCount.Remote = ratomic.LoadUint64(&src.ObjCount.Remote)
@@ -339,6 +341,10 @@ func (r *XactNsumm) visitObj(lom *core.LOM, _ []byte) error {
 		ratomic.CompareAndSwapInt64(&res.ObjSize.Max, cmax, size)
 	}
 	ratomic.AddUint64(&res.TotalSize.PresentObjs, uint64(size))
+	if lom.HasRefs() { // copy-on-write clone (see core/lcow.go)
+		ratomic.AddUint64(&res.ObjCount.Shared, 1)
+		ratomic.AddUint64(&res.TotalSize.SharedObjs, uint64(size))
+	}
 
 	// generic stats (same as base.LomAdd())
 	r.ObjsAdd(1, size)
@@ -50,6 +50,7 @@ type (
 			num     atomic.Int32
 			mu      sync.Mutex
 		}
+		pacer     *prfPacer // epoch-ahead (optional)
 		latestVer bool
 	}
 )
@@ -68,6 +69,10 @@ func (p *prfFactory) Start() (err error) {
 		}
 	}
 
+	if p.msg.Ahead < 0 || p.msg.Deadline < 0 {
+		return fmt.Errorf("invalid epoch-ahead prefetch: ahead %d, deadline %v (must be non-negative)", p.msg.Ahead, p.msg.Deadline)
+	}
+
 	b := p.Bck
 	if err = b.Init(core.T.Bowner()); err != nil {
 		return err
@@ -93,6 +98,12 @@ func newPrefetch(xargs *xreg.Args, kind string, bck *meta.Bck, msg *apc.Prefetch
 	if err != nil {
 		return nil, err
 	}
+	if msg.IsPaced() {
+		if r.pacer, err = newPrfPacer(r, msg); err != nil {
+			return nil, err
+		}
+		r.lrit.pacer = r.pacer
+	}
 	r.InitBase(xargs.UUID, kind, bck)
 	r.latestVer = bck.VersionConf().ValidateWarmGet || msg.LatestVer
 
@@ -107,6 +118,9 @@ func (r *prefetch) Run(wg *sync.WaitGroup) {
 
 	wg.Done()
 
+	if r.pacer != nil {
+		r.pacer.start()
+	}
 	err := r.lrit.run(r, core.T.Sowner().Get())
 	if err != nil {
 		r.AddErr(err, 5, cos.SmoduleXs) // duplicated?
@@ -123,6 +137,9 @@ func (r *prefetch) Run(wg *sync.WaitGroup) {
 			r._wait1h()
 		}
 	}
+	if r.pacer != nil {
+		nlog.Infoln(r.Name(), "epoch-ahead: skipped", r.pacer.skipped.Load(), "object(s) behind the cursor")
+	}
 
 	r.Finish()
 }
@@ -169,6 +186,8 @@ func (r *prefetch) do(lom *core.LOM, lrit *lrit) {
 		ecode, err = core.T.GetCold(context.Background(), lom, cmn.OwtGetPrefetchLock)
 		if err == nil { // done
 			r.ObjsAdd(1, lom.Lsize())
+			r.NetAdd(lom.Lsize())
+			r.DiskWriteAdd(lom.Lsize())
 		}
 	}
 
@@ -0,0 +1,130 @@
+// Package xs is a collection of eXtended actions (xactions), including multi-object
+// operations, list-objects, (cluster) rebalance and (target) resilver, ETL, and more.
+/*
+ * Copyright (c) 2024, NVIDIA CORPORATION. All rights reserved.
+ */
+package xs
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/NVIDIA/aistore/api/apc"
+	"github.com/NVIDIA/aistore/cmn/atomic"
+	"github.com/NVIDIA/aistore/cmn/mono"
+	"github.com/NVIDIA/aistore/core"
+)
+
+// Epoch-ahead prefetch (see apc.PrefetchMsg.Ahead and Deadline).
+// The ordered list (or range) is the client's access order; the client periodically reports
+// its position in this order (cursor), and the prefetcher executes idx-th object when either:
+// - idx < cursor + ahead, or
+// - idx < total * elapsed / deadline (i.e., keeping up with the deadline regardless of the cursor).
+// Objects behind the cursor are skipped - the client has already read them.
+
+const prfPaceIval = time.Second // max time to wait between re-checks
+
+type prfPacer struct {
+	r        *prefetch
+	kick     chan struct{}
+	cursor   atomic.Int64
+	skipped  atomic.Int64
+	ahead    int64
+	total    int64
+	started  int64 // mono
+	deadline time.Duration
+}
+
+// interface guard
+var _ lrpacer = (*prfPacer)(nil)
+
+func newPrfPacer(r *prefetch, msg *apc.PrefetchMsg) (*prfPacer, error) {
+	p := &prfPacer{r: r, ahead: msg.Ahead, deadline: msg.Deadline.D(), kick: make(chan struct{}, 1)}
+	switch r.lrit.lrp {
+	case lrpList:
+		p.total = int64(len(msg.ObjNames))
+	case lrpRange:
+		p.total = r.lrit.pt.Count()
+	default:
+		return nil, errors.New("epoch-ahead prefetch requires ordered list of object names or range template (not prefix)")
+	}
+	return p, nil
+}
+
+func (p *prfPacer) start() { p.started = mono.NanoTime() }
+
+func (p *prfPacer) setCursor(cursor int64) {
+	for {
+		prev := p.cursor.Load()
+		if cursor <= prev {
+			return // (reordered or duplicated reports)
+		}
+		if p.cursor.CAS(prev, cursor) {
+			break
+		}
+	}
+	select {
+	case p.kick <- struct{}{}:
+	default:
+	}
+}
+
+// returns the position up to which (exclusive) objects can be prefetched now,
+// and the time when the deadline-driven horizon will advance past `idx`
+func (p *prfPacer) horizon(idx int64) (h int64, wait time.Duration) {
+	wait = prfPaceIval
+	if p.ahead > 0 {
+		h = p.cursor.Load() + p.ahead
+	}
+	if p.deadline > 0 {
+		elapsed := mono.Since(p.started)
+		if elapsed >= p.deadline {
+			return p.total, 0
+		}
+		h = max(h, int64(float64(p.total)*float64(elapsed)/float64(p.deadline)))
+		if h <= idx {
+			at := time.Duration(float64(p.deadline) * float64(idx+1) / float64(p.total))
+			wait = min(wait, max(at-elapsed, time.Millisecond))
+		}
+	}
+	return h, wait
+}
+
+func (p *prfPacer) pace(idx int64) (skip, stop bool) {
+	for {
+		if idx < p.cursor.Load() {
+			p.skipped.Inc()
+			return true, false
+		}
+		h, wait := p.horizon(idx)
+		if idx < h {
+			return false, false
+		}
+		timer := time.NewTimer(wait)
+		select {
+		case <-p.kick:
+		case <-timer.C:
+		case <-p.r.ChanAbort():
+			timer.Stop()
+			return false, true
+		}
+		timer.Stop()
+	}
+}
+
+// SetPrefetchCursor delivers client-reported cursor to the running (epoch-ahead) prefetch.
+func SetPrefetchCursor(xctn core.Xact, cursor int64) error {
+	r, ok := xctn.(*prefetch)
+	if !ok {
+		return fmt.Errorf("%s is not a prefetch job", xctn.Name())
+	}
+	if r.pacer == nil {
+		return fmt.Errorf("%s: not an epoch-ahead prefetch (neither 'ahead' nor 'deadline' specified)", r.Name())
+	}
+	if cursor < 0 {
+		return fmt.Errorf("%s: invalid cursor %d", r.Name(), cursor)
+	}
+	r.pacer.setCursor(cursor)
+	return nil
+}
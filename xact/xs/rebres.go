@@ -10,6 +10,7 @@ import (
 
 	"github.com/NVIDIA/aistore/api/apc"
 	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/cmn/atomic"
 	"github.com/NVIDIA/aistore/cmn/cos"
 	"github.com/NVIDIA/aistore/cmn/debug"
 	"github.com/NVIDIA/aistore/cmn/nlog"
@@ -33,6 +34,18 @@ type (
 
 	Rebalance struct {
 		xact.Base
+		// transmitted by temperature class (see rebalance.hot_first)
+		hot, cold struct {
+			objs  atomic.Int64
+			bytes atomic.Int64
+		}
+	}
+	// extended rebalance statistics (rebalance.hot_first only)
+	ExtRebStats struct {
+		HotObjs   int64 `json:"hot.objs,string"`   // recently accessed (rebalance.hot_age)
+		HotBytes  int64 `json:"hot.bytes,string"`  //
+		ColdObjs  int64 `json:"cold.objs,string"`  // all the rest
+		ColdBytes int64 `json:"cold.bytes,string"` //
 	}
 	Resilver struct {
 		xact.Base
@@ -104,9 +117,33 @@ func (xreb *Rebalance) Snap() (snap *core.Snap) {
 	// (TODO: revisit)
 	snap.Stats.Objs = snap.Stats.OutObjs
 	snap.Stats.Bytes = snap.Stats.OutBytes
+
+	if ext := xreb.ExtStats(); ext.HotObjs+ext.ColdObjs > 0 {
+		snap.Ext = ext
+	}
 	return
 }
 
+// count transmitted object by its temperature class
+func (xreb *Rebalance) AddTemp(hot bool, size int64) {
+	if hot {
+		xreb.hot.objs.Inc()
+		xreb.hot.bytes.Add(size)
+	} else {
+		xreb.cold.objs.Inc()
+		xreb.cold.bytes.Add(size)
+	}
+}
+
+func (xreb *Rebalance) ExtStats() *ExtRebStats {
+	return &ExtRebStats{
+		HotObjs:   xreb.hot.objs.Load(),
+		HotBytes:  xreb.hot.bytes.Load(),
+		ColdObjs:  xreb.cold.objs.Load(),
+		ColdBytes: xreb.cold.bytes.Load(),
+	}
+}
+
 //////////////
 // Resilver //
 //////////////
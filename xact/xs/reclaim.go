@@ -0,0 +1,134 @@
+// Package xs is a collection of eXtended actions (xactions), including multi-object
+// operations, list-objects, (cluster) rebalance and (target) resilver, ETL, and more.
+/*
+ * Copyright (c) 2024, NVIDIA CORPORATION. All rights reserved.
+ */
+package xs
+
+import (
+	"os"
+	"path/filepath"
+	"sync"
+
+	"github.com/NVIDIA/aistore/api/apc"
+	"github.com/NVIDIA/aistore/cmn/nlog"
+	"github.com/NVIDIA/aistore/core"
+	"github.com/NVIDIA/aistore/core/meta"
+	"github.com/NVIDIA/aistore/fs"
+	"github.com/NVIDIA/aistore/xact"
+	"github.com/NVIDIA/aistore/xact/xreg"
+)
+
+// Reclaim space: remove destroyed bucket's data in the background.
+// - destroy-bucket renames the bucket's directories into mountpaths' 'deleted'
+//   (see fs.MoveBucketToDeleted) and returns right away;
+// - this xaction then removes the content - one goroutine per directory (mountpath) -
+//   counting removed files and their sizes as it goes (see `ais show job reclaim-space`);
+// - when aborted, the remaining content stays in 'deleted' until the next space cleanup.
+
+const rclAbortCheck = 256 // check abort every so many files
+
+type (
+	rclFactory struct {
+		xreg.RenewBase
+		xctn *XactReclaim
+	}
+	XactReclaim struct {
+		dirs []string
+		xact.Base
+	}
+)
+
+// interface guard
+var (
+	_ core.Xact      = (*XactReclaim)(nil)
+	_ xreg.Renewable = (*rclFactory)(nil)
+)
+
+////////////////
+// rclFactory //
+////////////////
+
+func (*rclFactory) New(args xreg.Args, bck *meta.Bck) xreg.Renewable {
+	return &rclFactory{RenewBase: xreg.RenewBase{Args: args, Bck: bck}}
+}
+
+func (p *rclFactory) Start() error {
+	args := p.Args.Custom.(*xreg.ReclaimArgs)
+	p.xctn = &XactReclaim{dirs: args.Dirs}
+	p.xctn.InitBase(p.UUID(), apc.ActReclaimBck, p.Bck)
+	go p.xctn.Run(nil)
+	return nil
+}
+
+func (*rclFactory) Kind() string     { return apc.ActReclaimBck }
+func (p *rclFactory) Get() core.Xact { return p.xctn }
+
+// (each destroyed bucket gets its own)
+func (*rclFactory) WhenPrevIsRunning(xreg.Renewable) (xreg.WPR, error) {
+	return xreg.WprKeepAndStartNew, nil
+}
+
+/////////////////
+// XactReclaim //
+/////////////////
+
+func (r *XactReclaim) Run(*sync.WaitGroup) {
+	nlog.Infoln(r.Name(), "dirs:", len(r.dirs))
+	var wg sync.WaitGroup
+	for _, dir := range r.dirs {
+		wg.Add(1)
+		go r.rmdir(dir, &wg)
+	}
+	wg.Wait()
+	r.Finish()
+}
+
+func (r *XactReclaim) rmdir(dir string, wg *sync.WaitGroup) {
+	var n int
+	defer wg.Done()
+	err := filepath.WalkDir(dir, func(fqn string, de os.DirEntry, err error) error {
+		if err != nil {
+			if os.IsNotExist(err) {
+				return nil
+			}
+			return err
+		}
+		if de.IsDir() {
+			return nil
+		}
+		if n++; n%rclAbortCheck == 0 {
+			r.Yield()
+			if r.IsAborted() {
+				return r.AbortErr()
+			}
+		}
+		finfo, err := de.Info()
+		if err != nil {
+			return nil
+		}
+		if err := os.Remove(fqn); err != nil {
+			if os.IsNotExist(err) {
+				return nil
+			}
+			return err
+		}
+		r.ObjsAdd(1, finfo.Size())
+		return nil
+	})
+	if err == nil {
+		err = fs.RemoveAll(dir)
+	}
+	if err != nil && !r.IsAborted() {
+		r.AddErr(err)
+		nlog.Warningln(r.Name(), "failed to remove", dir, "err:", err, "(will be removed by space cleanup)")
+	}
+}
+
+func (r *XactReclaim) Snap() (snap *core.Snap) {
+	snap = &core.Snap{}
+	r.ToSnap(snap)
+
+	snap.IdleX = r.IsIdle()
+	return
+}
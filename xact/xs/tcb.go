@@ -37,6 +37,7 @@ type (
 		phase string // (see "transition")
 		args  *xreg.TCBArgs
 		owt   cmn.OWT
+		prev  *etlCkpt // resuming offline transform (see tcb_ckpt)
 	}
 	XactTCB struct {
 		p      *tcbFactory
@@ -44,9 +45,12 @@ type (
 		rxlast atomic.Int64 // finishing
 		xact.BckJog
 		prune    prune
+		flt      *apc.ObjMatcher // (optional) filter
+		bwlim    *cos.BwLimiter  // (optional) bandwidth limit
 		nam, str string
 		wg       sync.WaitGroup // starting up
 		refc     atomic.Int32   // finishing
+		ckpt     *tcbCkpt       // (offline transform only)
 	}
 )
 
@@ -79,6 +83,15 @@ func (p *tcbFactory) Start() error {
 	p.owt = cmn.OwtCopy
 	if p.kind == apc.ActETLBck {
 		p.owt = cmn.OwtTransform
+		if msg := p.args.Msg; !msg.DryRun {
+			if msg.Resume != "" {
+				p.prev, err = loadEtlCkpt(config, msg.Resume, p.args.BckFrom, p.args.BckTo, msg.Transform.Name, msg.Prefix)
+				if err != nil {
+					return err
+				}
+			}
+			gcEtlCkpts(config, msg.Resume)
+		}
 	}
 
 	smap := core.T.Sowner().Get()
@@ -178,8 +191,23 @@ func newTCB(p *tcbFactory, slab *memsys.Slab, config *cmn.Config, smap *meta.Sma
 		Throttle: true, // always trottling
 	}
 	mpopts.Bck.Copy(p.args.BckFrom.Bucket())
+	if p.kind == apc.ActETLBck && !p.args.Msg.DryRun {
+		r.ckpt = &tcbCkpt{prev: p.prev, fpath: etlCkptPath(config, p.UUID())}
+		mpopts.Sorted = true
+		mpopts.VisitObjAsync = r.doAsync // (progress = objects transformed _and_ delivered)
+		if p.prev != nil {
+			mpopts.StartAfter = p.prev.Mpaths
+		}
+	}
 	r.BckJog.Init(p.UUID(), p.kind, p.args.BckTo, mpopts, config)
 
+	var err error
+	r.flt, err = p.args.Msg.Filter.Compile()
+	debug.AssertNoErr(err) // validated (by proxy)
+	if p.args.Msg.MaxBW > 0 && !p.args.Msg.DryRun {
+		r.bwlim = cos.NewBwLimiter(p.args.Msg.MaxBW)
+	}
+
 	if p.args.Msg.Sync {
 		debug.Assert(p.args.Msg.Prepend == "", p.args.Msg.Prepend) // validated (cli, P)
 		{
@@ -209,9 +237,17 @@ func (r *XactTCB) Run(wg *sync.WaitGroup) {
 	if r.p.args.Msg.Sync {
 		r.prune.run() // the 2nd jgroup
 	}
+	if r.ckpt != nil {
+		r.ckpt.stopCh.Init()
+		r.ckpt.wg.Add(1)
+		go r.ckptRun()
+	}
 	nlog.Infoln(r.Name())
 
 	err := r.BckJog.Wait()
+	if r.ckpt != nil {
+		r.ckptFini()
+	}
 
 	if r.dm != nil {
 		o := transport.AllocSend()
@@ -256,11 +292,24 @@ func (r *XactTCB) qcb(tot time.Duration) core.QuiRes {
 	return core.QuiInactiveCB
 }
 
-func (r *XactTCB) do(lom *core.LOM, buf []byte) (err error) {
+func (r *XactTCB) do(lom *core.LOM, buf []byte) error { return r._do(lom, buf, nil) }
+
+// `done` gets called upon completion, including the (asynchronous) transmission to the destination target
+func (r *XactTCB) doAsync(lom *core.LOM, buf []byte, done func(error)) error {
+	return r._do(lom, buf, done)
+}
+
+func (r *XactTCB) _do(lom *core.LOM, buf []byte, done func(error)) (err error) {
 	var (
 		args   = r.p.args // TCBArgs
 		toName = args.Msg.ToName(lom.ObjName)
 	)
+	if r.flt != nil && !matchLOM(r.flt, lom) {
+		if done != nil {
+			done(nil)
+		}
+		return nil
+	}
 	if cmn.Rom.FastV(5, cos.SmoduleXs) {
 		nlog.Infoln(r.Base.Name()+":", lom.Cname(), "=>", args.BckTo.Cname(toName))
 	}
@@ -276,14 +325,19 @@ func (r *XactTCB) do(lom *core.LOM, buf []byte) (err error) {
 		coiParams.DryRun = args.Msg.DryRun
 		coiParams.LatestVer = args.Msg.LatestVer
 		coiParams.Sync = args.Msg.Sync
+		coiParams.COW = args.Msg.COW
+		coiParams.Sent = done
 	}
-	_, err = core.T.CopyObject(lom, r.dm, coiParams)
+	size, err := core.T.CopyObject(lom, r.dm, coiParams)
 	core.FreeCOI(coiParams)
 	switch {
 	case err == nil:
 		if args.Msg.Sync {
 			r.prune.filter.Insert(cos.UnsafeB(lom.Uname()))
 		}
+		if r.bwlim != nil && size > 0 {
+			r.bwlim.Wait(size)
+		}
 	case cos.IsNotExist(err, 0):
 		// do nothing
 	case cos.IsErrOOS(err):
@@ -354,13 +408,22 @@ func (r *XactTCB) _str() (s string) {
 		s = ", prefix " + r.p.args.Msg.Prefix
 	}
 	if msg.Prepend != "" {
-		s = ", prepend " + r.p.args.Msg.Prepend
+		s += ", prepend " + r.p.args.Msg.Prepend
 	}
 	if msg.LatestVer {
-		s = ", latest-ver"
+		s += ", latest-ver"
 	}
 	if msg.Sync {
-		s = ", synchronize"
+		s += ", synchronize"
+	}
+	if msg.COW {
+		s += ", copy-on-write"
+	}
+	if !msg.Filter.IsEmpty() {
+		s += ", filtered"
+	}
+	if msg.MaxBW > 0 {
+		s += ", max-bw " + cos.ToSizeIEC(msg.MaxBW, 0) + "/s"
 	}
 	return s
 }
@@ -0,0 +1,180 @@
+// Package xs is a collection of eXtended actions (xactions), including multi-object
+// operations, list-objects, (cluster) rebalance and (target) resilver, ETL, and more.
+/*
+ * Copyright (c) 2024, NVIDIA CORPORATION. All rights reserved.
+ */
+package xs
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/cmn/cos"
+	"github.com/NVIDIA/aistore/cmn/fname"
+	"github.com/NVIDIA/aistore/cmn/jsp"
+	"github.com/NVIDIA/aistore/cmn/nlog"
+	"github.com/NVIDIA/aistore/core/meta"
+)
+
+// Offline transform (bucket-to-bucket, apc.ActETLBck) periodically checkpoints its progress,
+// namely: per-mountpath position of the (sorted) walk - see mpather.Jgroup.Progress.
+// The position advances only when the preceding objects are transformed _and_ delivered
+// (see mpather.JgroupOpts.VisitObjAsync).
+// The checkpoint is kept when the job fails (and upon target restart, for that matter) and
+// gets removed when the job completes or is aborted by user. Checkpoints of failed jobs
+// that don't get resumed are garbage collected after etlCkptMaxAge.
+// Interrupted job can be resumed via apc.TCBMsg.Resume, whereby each target skips objects
+// that precede its checkpointed positions.
+// A target that has no checkpoint (e.g., joined the cluster after the job was interrupted)
+// transforms all its objects.
+
+const (
+	etlCkptIval   = time.Minute
+	etlCkptMaxAge = 7 * 24 * time.Hour
+)
+
+type (
+	// persistent
+	etlCkpt struct {
+		Xid     string            `json:"xid"`
+		BckFrom cmn.Bck           `json:"bck_from"`
+		BckTo   cmn.Bck           `json:"bck_to"`
+		ETL     string            `json:"etl"`
+		Prefix  string            `json:"prefix"`
+		Mpaths  map[string]string `json:"mpaths"` // mountpath => last transformed (bucket-relative) path
+		Objs    int64             `json:"objs"`   // cumulative (across resumptions)
+		Bytes   int64             `json:"bytes"`  // ditto
+		Time    int64             `json:"time"`
+	}
+	// runtime
+	tcbCkpt struct {
+		prev   *etlCkpt // resuming from
+		fpath  string
+		stopCh cos.StopCh
+		wg     sync.WaitGroup
+	}
+)
+
+func etlCkptPath(config *cmn.Config, xid string) string {
+	return filepath.Join(config.ConfigDir, fname.EtlCkptDir, xid)
+}
+
+// load and validate checkpoint of the job to resume (bckTo is optional); nil if not found
+func loadEtlCkpt(config *cmn.Config, xid string, bckFrom, bckTo *meta.Bck, etlName, prefix string) (*etlCkpt, error) {
+	ckpt := &etlCkpt{}
+	if _, err := jsp.Load(etlCkptPath(config, xid), ckpt, jsp.Plain()); err != nil {
+		if os.IsNotExist(err) {
+			nlog.Warningln("checkpoint", xid, "not found - transforming all objects")
+			return nil, nil
+		}
+		return nil, err
+	}
+	if !ckpt.BckFrom.Equal(bckFrom.Bucket()) || (bckTo != nil && !ckpt.BckTo.Equal(bckTo.Bucket())) ||
+		ckpt.ETL != etlName || ckpt.Prefix != prefix {
+		return nil, fmt.Errorf("cannot resume %s: checkpointed job (%s => %s, etl %q, prefix %q) does not match",
+			xid, ckpt.BckFrom.Cname(""), ckpt.BckTo.Cname(""), ckpt.ETL, ckpt.Prefix)
+	}
+	return ckpt, nil
+}
+
+// remove stale checkpoints (other than the one to resume from)
+func gcEtlCkpts(config *cmn.Config, resume string) {
+	dir := filepath.Join(config.ConfigDir, fname.EtlCkptDir)
+	des, err := os.ReadDir(dir)
+	if err != nil {
+		return
+	}
+	now := time.Now()
+	for _, de := range des {
+		if de.IsDir() || de.Name() == resume {
+			continue
+		}
+		finfo, err := de.Info()
+		if err != nil || now.Sub(finfo.ModTime()) < etlCkptMaxAge {
+			continue
+		}
+		if err := cos.RemoveFile(filepath.Join(dir, de.Name())); err != nil {
+			nlog.Errorln("failed to remove stale checkpoint:", err)
+		} else {
+			nlog.Infoln("removed stale checkpoint", de.Name())
+		}
+	}
+}
+
+func (r *XactTCB) ckptRun() {
+	ticker := time.NewTicker(etlCkptIval)
+	defer func() {
+		ticker.Stop()
+		r.ckpt.wg.Done()
+	}()
+	for {
+		select {
+		case <-ticker.C:
+			r.checkpoint()
+		case <-r.ckpt.stopCh.Listen():
+			return
+		}
+	}
+}
+
+func (r *XactTCB) checkpoint() {
+	var (
+		args = r.p.args
+		ckpt = &etlCkpt{
+			Xid:     r.ID(),
+			BckFrom: *args.BckFrom.Bucket(),
+			BckTo:   *args.BckTo.Bucket(),
+			ETL:     args.Msg.Transform.Name,
+			Prefix:  args.Msg.Prefix,
+			Mpaths:  r.BckJog.Progress(),
+			Objs:    r.Objs(),
+			Bytes:   r.Bytes(),
+			Time:    time.Now().UnixNano(),
+		}
+		prev = r.ckpt.prev
+	)
+	if prev != nil {
+		ckpt.Objs += prev.Objs
+		ckpt.Bytes += prev.Bytes
+		for mpath, last := range prev.Mpaths {
+			if _, ok := ckpt.Mpaths[mpath]; !ok {
+				ckpt.Mpaths[mpath] = last // (mountpath currently unavailable)
+			}
+		}
+	}
+	if err := jsp.Save(r.ckpt.fpath, ckpt, jsp.Plain(), nil); err != nil {
+		nlog.Errorln(r.Name(), "failed to checkpoint:", err)
+		return
+	}
+	// superseded
+	if prev != nil && prev.Xid != r.ID() {
+		r.rmCkpt(prev.Xid)
+		prev.Xid = r.ID()
+	}
+}
+
+// stop checkpointing; remove the checkpoint(s) unless failed
+func (r *XactTCB) ckptFini() {
+	r.ckpt.stopCh.Close()
+	r.ckpt.wg.Wait()
+	if r.IsAborted() && !errors.Is(r.AbortErr(), cmn.ErrXactUserAbort) {
+		r.checkpoint()
+		nlog.Infoln(r.Name(), "failed - checkpoint kept for resumption:", r.ckpt.fpath)
+		return
+	}
+	r.rmCkpt(r.ID())
+	if prev := r.ckpt.prev; prev != nil && prev.Xid != r.ID() {
+		r.rmCkpt(prev.Xid)
+	}
+}
+
+func (r *XactTCB) rmCkpt(xid string) {
+	if err := cos.RemoveFile(etlCkptPath(r.Config, xid)); err != nil {
+		nlog.Errorln(r.Name(), "failed to remove checkpoint:", err)
+	}
+}
@@ -0,0 +1,167 @@
+// Package xs is a collection of eXtended actions (xactions), including multi-object
+// operations, list-objects, (cluster) rebalance and (target) resilver, ETL, and more.
+/*
+ * Copyright (c) 2024, NVIDIA CORPORATION. All rights reserved.
+ */
+package xs
+
+import (
+	"errors"
+	"io"
+	"math/rand/v2"
+	"sync"
+	"time"
+
+	"github.com/NVIDIA/aistore/api/apc"
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/cmn/cos"
+	"github.com/NVIDIA/aistore/core"
+	"github.com/NVIDIA/aistore/core/meta"
+	"github.com/NVIDIA/aistore/fs"
+	"github.com/NVIDIA/aistore/fs/mpather"
+)
+
+// Pre-run estimate of the offline transform (apc.ActETLBck) on this target:
+// 1. walk the source bucket to count matching objects and bytes (when resuming, only those
+//    that follow the checkpointed positions);
+// 2. transform a random sample of the objects (discarding the output), with the same
+//    parallelism as the job itself: (number of mountpaths) x etlBucketParallelCnt;
+// 3. project the total time from the sampled transformation rate (bytes/s or, for
+//    zero-size samples, objects/s).
+
+const DefaultEstimateSamples = 16
+
+type tcbEstimate struct {
+	flt     *apc.ObjMatcher
+	samples []string
+	est     apc.TCBEstimate
+	mu      sync.Mutex
+}
+
+func EstimateTCB(bck *meta.Bck, msg *apc.TCBMsg, dp core.DP, nsamples int, config *cmn.Config) (*apc.TCBEstimate, error) {
+	var (
+		e   = &tcbEstimate{samples: make([]string, 0, nsamples)}
+		err error
+	)
+	if nsamples <= 0 {
+		return nil, errors.New("invalid number of samples (expecting positive)")
+	}
+	if e.flt, err = msg.Filter.Compile(); err != nil {
+		return nil, err
+	}
+	mpopts := &mpather.JgroupOpts{
+		CTs:      []string{fs.ObjectType},
+		VisitObj: e.visit(nsamples),
+		Prefix:   msg.Prefix,
+		DoLoad:   mpather.Load,
+	}
+	mpopts.Bck.Copy(bck.Bucket())
+	if msg.Resume != "" {
+		ckpt, err := loadEtlCkpt(config, msg.Resume, bck, nil /*bckTo*/, msg.Transform.Name, msg.Prefix)
+		if err != nil {
+			return nil, err
+		}
+		if ckpt != nil {
+			mpopts.Sorted, mpopts.StartAfter = true, ckpt.Mpaths
+		}
+	}
+
+	// 1. count and sample
+	jg := mpather.NewJoggerGroup(mpopts, config, nil)
+	jg.Run()
+	<-jg.ListenFinished()
+	if err := jg.Stop(); err != nil {
+		return nil, err
+	}
+	if len(e.samples) == 0 {
+		return &e.est, nil
+	}
+
+	// 2. transform samples
+	var (
+		wg       sync.WaitGroup
+		errs     cos.Errs
+		parallel = min(len(e.samples), max(jg.Num(), 1)*etlBucketParallelCnt)
+		ch       = make(chan string, len(e.samples))
+		started  = time.Now()
+	)
+	for _, name := range e.samples {
+		ch <- name
+	}
+	close(ch)
+	for range parallel {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for name := range ch {
+				if err := e.transform(bck, name, dp); err != nil {
+					errs.Add(err)
+					return
+				}
+			}
+		}()
+	}
+	wg.Wait()
+	if errs.Cnt() > 0 {
+		return nil, &errs
+	}
+	elapsed := time.Since(started)
+	e.est.SampleTime = cos.Duration(elapsed)
+
+	// 3. project
+	est := &e.est
+	switch {
+	case est.SampleSize > 0:
+		est.ETA = cos.Duration(float64(elapsed) * float64(est.Size) / float64(est.SampleSize))
+	case est.SampleCount > 0:
+		est.ETA = cos.Duration(float64(elapsed) * float64(est.ObjCount) / float64(est.SampleCount))
+	}
+	return est, nil
+}
+
+// (reservoir sampling)
+func (e *tcbEstimate) visit(nsamples int) func(*core.LOM, []byte) error {
+	return func(lom *core.LOM, _ []byte) error {
+		if e.flt != nil && !matchLOM(e.flt, lom) {
+			return nil
+		}
+		e.mu.Lock()
+		e.est.ObjCount++
+		e.est.Size += lom.Lsize()
+		if len(e.samples) < nsamples {
+			e.samples = append(e.samples, lom.ObjName)
+		} else if i := rand.Int64N(e.est.ObjCount); i < int64(nsamples) {
+			e.samples[i] = lom.ObjName
+		}
+		e.mu.Unlock()
+		return nil
+	}
+}
+
+func (e *tcbEstimate) transform(bck *meta.Bck, name string, dp core.DP) error {
+	lom := core.AllocLOM(name)
+	defer core.FreeLOM(lom)
+	if err := lom.InitBck(bck.Bucket()); err != nil {
+		return err
+	}
+	if err := lom.Load(false, false); err != nil {
+		if cos.IsNotExist(err, 0) {
+			return nil // (removed in the meantime)
+		}
+		return err
+	}
+	r, _, err := dp.Reader(lom, false, false)
+	if err != nil {
+		return err
+	}
+	_, err = io.Copy(io.Discard, r)
+	cos.Close(r)
+	if err != nil {
+		return err
+	}
+	e.mu.Lock()
+	e.est.SampleCount++
+	e.est.SampleSize += lom.Lsize()
+	e.mu.Unlock()
+	return nil
+}
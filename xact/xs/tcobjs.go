@@ -46,8 +46,10 @@ type (
 		owt cmn.OWT
 	}
 	tcowi struct {
-		r   *XactTCObjs
-		msg *cmn.TCOMsg
+		r     *XactTCObjs
+		msg   *cmn.TCOMsg
+		flt   *apc.ObjMatcher
+		bwlim *cos.BwLimiter
 		// finishing
 		refc atomic.Int32
 	}
@@ -136,6 +138,14 @@ func (r *XactTCObjs) Snap() (snap *core.Snap) {
 
 func (r *XactTCObjs) Begin(msg *cmn.TCOMsg) {
 	wi := &tcowi{r: r, msg: msg}
+	if flt, err := msg.Filter.Compile(); err != nil {
+		nlog.Errorln(r.Name(), "txn", msg.TxnUUID, err) // (unlikely - validated by proxy)
+	} else {
+		wi.flt = flt
+	}
+	if msg.MaxBW > 0 && !msg.DryRun {
+		wi.bwlim = cos.NewBwLimiter(msg.MaxBW)
+	}
 	r.pending.mtx.Lock()
 	r.pending.m[msg.TxnUUID] = wi
 	r.wiCnt.Inc()
@@ -308,6 +318,9 @@ func (r *XactTCObjs) _put(hdr *transport.ObjHdr, objReader io.Reader, lom *core.
 ///////////
 
 func (wi *tcowi) do(lom *core.LOM, lrit *lrit) {
+	if wi.flt != nil && !wi.match(lom) {
+		return
+	}
 	var (
 		objNameTo = wi.msg.ToName(lom.ObjName)
 		buf, slab = core.T.PageMM().Alloc()
@@ -330,7 +343,7 @@ func (wi *tcowi) do(lom *core.LOM, lrit *lrit) {
 		coiParams.LatestVer = wi.msg.LatestVer
 		coiParams.Sync = wi.msg.Sync
 	}
-	_, err := core.T.CopyObject(lom, wi.r.p.dm, coiParams)
+	size, err := core.T.CopyObject(lom, wi.r.p.dm, coiParams)
 	core.FreeCOI(coiParams)
 	slab.Free(buf)
 
@@ -338,11 +351,25 @@ func (wi *tcowi) do(lom *core.LOM, lrit *lrit) {
 		if !cos.IsNotExist(err, 0) || lrit.lrp == lrpList {
 			wi.r.AddErr(err, 5, cos.SmoduleXs)
 		}
-	} else if cmn.Rom.FastV(5, cos.SmoduleXs) {
+		return
+	}
+	if wi.bwlim != nil && size > 0 {
+		wi.bwlim.Wait(size)
+	}
+	if cmn.Rom.FastV(5, cos.SmoduleXs) {
 		nlog.Infoln(wi.r.Name()+":", lom.Cname(), "=>", wi.r.args.BckTo.Cname(objNameTo))
 	}
 }
 
+// size and time filtering requires object metadata: in-cluster objects only
+// (when copying remote buckets, the proxy filters listed pages - see ais/plstcx)
+func (wi *tcowi) match(lom *core.LOM) bool {
+	if err := lom.Load(true /*cache it*/, false /*locked*/); err != nil {
+		return wi.flt.MatchName(lom.ObjName)
+	}
+	return matchLOM(wi.flt, lom)
+}
+
 //
 // remove objects not present at the source (when synchronizing bckFrom => bckTo)
 // TODO: probabilistic filtering
@@ -0,0 +1,131 @@
+// Package xs is a collection of eXtended actions (xactions), including multi-object
+// operations, list-objects, (cluster) rebalance and (target) resilver, ETL, and more.
+/*
+ * Copyright (c) 2024, NVIDIA CORPORATION. All rights reserved.
+ */
+package xs
+
+import (
+	"os"
+	"sync"
+	"time"
+
+	"github.com/NVIDIA/aistore/api/apc"
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/cmn/cos"
+	"github.com/NVIDIA/aistore/cmn/nlog"
+	"github.com/NVIDIA/aistore/core"
+	"github.com/NVIDIA/aistore/core/meta"
+	"github.com/NVIDIA/aistore/fs"
+	"github.com/NVIDIA/aistore/fs/mpather"
+	"github.com/NVIDIA/aistore/xact"
+	"github.com/NVIDIA/aistore/xact/xreg"
+)
+
+// Purge soft-deleted objects (see fs.TrashType) that are older than the bucket's
+// trash retention or, when forced, all of them.
+
+type (
+	tpgFactory struct {
+		xreg.RenewBase
+		xctn *XactTrashPurge
+	}
+	XactTrashPurge struct {
+		xact.BckJog
+		cutoff int64 // deletion time (Unix nanoseconds); purge older
+	}
+)
+
+// interface guard
+var (
+	_ core.Xact      = (*XactTrashPurge)(nil)
+	_ xreg.Renewable = (*tpgFactory)(nil)
+)
+
+////////////////
+// tpgFactory //
+////////////////
+
+func (*tpgFactory) New(args xreg.Args, bck *meta.Bck) xreg.Renewable {
+	p := &tpgFactory{RenewBase: xreg.RenewBase{Args: args, Bck: bck}}
+	return p
+}
+
+func (p *tpgFactory) Start() error {
+	all, _ := p.Args.Custom.(bool)
+	p.xctn = newXactTrashPurge(p.UUID(), p.Bck, all)
+	go p.xctn.Run(nil)
+	return nil
+}
+
+func (*tpgFactory) Kind() string     { return apc.ActPurgeTrash }
+func (p *tpgFactory) Get() core.Xact { return p.xctn }
+
+func (*tpgFactory) WhenPrevIsRunning(xreg.Renewable) (xreg.WPR, error) { return xreg.WprUse, nil }
+
+////////////////////
+// XactTrashPurge //
+////////////////////
+
+func newXactTrashPurge(uuid string, bck *meta.Bck, all bool) (r *XactTrashPurge) {
+	r = &XactTrashPurge{cutoff: time.Now().UnixNano()}
+	if !all {
+		r.cutoff -= int64(bck.Props.Trash.RetentionD())
+	}
+	mpopts := &mpather.JgroupOpts{
+		CTs:     []string{fs.TrashType},
+		VisitCT: r.visit,
+	}
+	mpopts.Bck.Copy(bck.Bucket())
+	r.BckJog.Init(uuid, apc.ActPurgeTrash, bck, mpopts, cmn.GCO.Get())
+	return
+}
+
+func (r *XactTrashPurge) Run(*sync.WaitGroup) {
+	r.BckJog.Run()
+	nlog.Infoln(r.Name())
+	err := r.BckJog.Wait()
+	if err != nil {
+		r.AddErr(err)
+	}
+	r.Finish()
+}
+
+func (r *XactTrashPurge) visit(ct *core.CT, _ []byte) error {
+	r.Yield()
+	objName, deleted, ok := fs.ParseTrashName(ct.ObjectName())
+	if !ok || deleted > r.cutoff {
+		return nil
+	}
+	// skip the ones that are being restored right now
+	lom := core.AllocLOM(objName)
+	defer core.FreeLOM(lom)
+	if err := lom.InitBck(ct.Bucket()); err != nil {
+		return err
+	}
+	if !lom.TryLock(true) {
+		return nil
+	}
+	defer lom.Unlock(true)
+
+	finfo, err := os.Lstat(ct.FQN())
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil
+		}
+		return err
+	}
+	if err := cos.RemoveFile(ct.FQN()); err != nil {
+		return err
+	}
+	r.ObjsAdd(1, finfo.Size())
+	return nil
+}
+
+func (r *XactTrashPurge) Snap() (snap *core.Snap) {
+	snap = &core.Snap{}
+	r.ToSnap(snap)
+
+	snap.IdleX = r.IsIdle()
+	return
+}
@@ -66,6 +66,10 @@ func (wi *walkInfo) setWanted(e *cmn.LsoEnt, lom *core.LOM) {
 			e.Location = lom.Location()
 		case apc.GetPropsCopies:
 			e.Copies = int16(lom.NumCopies())
+		case apc.GetPropsMtime:
+			if mtime := lomMtime(lom); !mtime.IsZero() {
+				e.Mtime = mtime.UnixNano()
+			}
 
 		case apc.GetPropsEC:
 			// TODO?: risk of significant slow-down loading EC metafiles
@@ -8,6 +8,7 @@ package xs
 import (
 	"path/filepath"
 	"strings"
+	"time"
 
 	"github.com/NVIDIA/aistore/api/apc"
 	"github.com/NVIDIA/aistore/cmn"
@@ -28,6 +29,7 @@ type (
 		smap         *meta.Smap
 		msg          *apc.LsoMsg
 		lomVisitedCb lomVisitedCb
+		flt          *apc.ObjMatcher // (optional) server-side filtering
 		markerDir    string
 		wanted       cos.BitFlags
 	}
@@ -45,6 +47,7 @@ func newWalkInfo(msg *apc.LsoMsg, lomVisitedCb lomVisitedCb) (wi *walkInfo) {
 		msg:          msg,
 		wanted:       wanted(msg),
 	}
+	wi.flt, _ = msg.Filter.Compile() // validated (by proxy)
 	if msg.ContinuationToken != "" { // marker is always a filename
 		wi.markerDir = filepath.Dir(msg.ContinuationToken)
 		if wi.markerDir == "." {
@@ -97,6 +100,27 @@ func (wi *walkInfo) ls(lom *core.LOM, status uint16) (e *cmn.LsoEnt) {
 	return
 }
 
+// (size and mtime - the latter only when needed)
+func matchLOM(flt *apc.ObjMatcher, lom *core.LOM) bool {
+	var mtime time.Time
+	if flt.NeedsMtime() {
+		mtime = lomMtime(lom)
+	}
+	return flt.Match(lom.ObjName, lom.Lsize(), mtime)
+}
+
+// last modified, as reported by remote backend or, if unavailable,
+// the time the object was written (zero when unknown)
+func lomMtime(lom *core.LOM) time.Time {
+	if v, ok := lom.GetCustomKey(cmn.LastModified); ok {
+		if mtime, err := time.Parse(time.RFC3339, v); err == nil {
+			return mtime
+		}
+	}
+	_, _, mtime, _ := lom.Fstat(false /*get-atime*/)
+	return mtime
+}
+
 // NOTE: slow path
 func checkRemoteMD(lom *core.LOM, e *cmn.LsoEnt) {
 	if !lom.Bucket().HasVersioningMD() {
@@ -133,6 +157,9 @@ func (wi *walkInfo) _cb(lom *core.LOM, fqn string) (*cmn.LsoEnt, error) {
 	if !wi.match(lom.ObjName) {
 		return nil, nil
 	}
+	if wi.flt != nil && !wi.flt.MatchName(lom.ObjName) {
+		return nil, nil
+	}
 	if err := lom.PostInit(); err != nil {
 		return nil, err
 	}
@@ -151,7 +178,7 @@ func (wi *walkInfo) _cb(lom *core.LOM, fqn string) (*cmn.LsoEnt, error) {
 	}
 
 	// shortcut #1: name-only optimizes-out loading md (NOTE: won't show misplaced and copies)
-	if wi.msg.IsFlagSet(apc.LsNameOnly) {
+	if wi.msg.IsFlagSet(apc.LsNameOnly) && !wi.msg.Filter.NeedsProps() {
 		if !isOK(status) {
 			return nil, nil
 		}
@@ -164,6 +191,9 @@ func (wi *walkInfo) _cb(lom *core.LOM, fqn string) (*cmn.LsoEnt, error) {
 		}
 		return nil, err
 	}
+	if wi.flt != nil && !matchLOM(wi.flt, lom) {
+		return nil, nil
+	}
 	if local && lom.IsCopy() {
 		// still may change below
 		status = apc.LocIsCopy
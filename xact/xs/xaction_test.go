@@ -8,6 +8,7 @@ import (
 	"errors"
 	"fmt"
 	"os"
+	"path/filepath"
 	"sync"
 	"testing"
 	"time"
@@ -119,6 +120,93 @@ func TestXactionRenewPrefetch(t *testing.T) {
 	tassert.Errorf(t, len(res) > 0, "expected xactions to be created")
 }
 
+func TestPrefetchEpochAhead(t *testing.T) {
+	var (
+		bmd = mock.NewBaseBownerMock()
+		bck = meta.NewBck(
+			"test", apc.GCP, cmn.NsGlobal,
+			&cmn.Bprops{Cksum: cmn.CksumConf{Type: cos.ChecksumXXHash}},
+		)
+		tMock = mock.NewTarget(bmd)
+	)
+	core.T = tMock
+	xreg.TestReset()
+	bmd.Add(bck)
+
+	_ = cos.CreateDir("/tmp/prefetch")
+	_, err := fs.Add("/tmp/prefetch", tMock.SID())
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "ignoring:", err)
+	}
+	xreg.RegBckXact(&xs.TestXFactory{})
+	defer xreg.AbortAll(nil)
+	cos.InitShortID(0)
+
+	// epoch-ahead requires ordered list or range
+	msg := &apc.PrefetchMsg{ListRange: apc.ListRange{Template: "prefix-"}, Ahead: 10}
+	rns := xreg.RenewPrefetch(cos.GenUUID(), bck, msg)
+	tassert.Errorf(t, rns.Err != nil, "expected epoch-ahead prefetch by prefix to fail")
+
+	msg = &apc.PrefetchMsg{ListRange: apc.ListRange{Template: "shard-{0000..0999}.tar"}, Ahead: -1}
+	rns = xreg.RenewPrefetch(cos.GenUUID(), bck, msg)
+	tassert.Errorf(t, rns.Err != nil, "expected negative 'ahead' to fail")
+
+	msg = &apc.PrefetchMsg{ListRange: apc.ListRange{Template: "shard-{0000..0999}.tar"}, Ahead: 10}
+	rns = xreg.RenewPrefetch(cos.GenUUID(), bck, msg)
+	tassert.CheckFatal(t, rns.Err)
+	tassert.CheckError(t, xs.SetPrefetchCursor(rns.Entry.Get(), 5))
+	tassert.Errorf(t, xs.SetPrefetchCursor(rns.Entry.Get(), -1) != nil, "expected negative cursor to fail")
+
+	msg = &apc.PrefetchMsg{ListRange: apc.ListRange{ObjNames: []string{"a", "b"}}}
+	rns = xreg.RenewPrefetch(cos.GenUUID(), bck, msg)
+	tassert.CheckFatal(t, rns.Err)
+	tassert.Errorf(t, xs.SetPrefetchCursor(rns.Entry.Get(), 1) != nil, "expected cursor to fail (not epoch-ahead)")
+}
+
+func TestXactionBurnIn(t *testing.T) {
+	var (
+		bmd   = mock.NewBaseBownerMock()
+		tMock = mock.NewTarget(bmd)
+		done  = make(chan *apc.BurnInReport, 1)
+	)
+	core.T = tMock
+	xreg.TestReset()
+	xs.Xreg(false)
+	defer xreg.AbortAll(nil)
+	cos.InitShortID(0)
+
+	_ = cos.CreateDir("/tmp/burnin")
+	_, err := fs.Add("/tmp/burnin", tMock.SID())
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "ignoring:", err)
+	}
+
+	msg := &apc.BurnInMsg{Duration: cos.Duration(time.Second), ObjSize: 64 * cos.KiB}
+	tassert.CheckFatal(t, msg.Validate())
+	rns := xreg.RenewBurnIn(cos.GenUUID(), &xreg.BurnInArgs{Msg: msg, Done: func(rep *apc.BurnInReport) { done <- rep }})
+	tassert.CheckFatal(t, rns.Err)
+
+	// one at a time
+	rns2 := xreg.RenewBurnIn(cos.GenUUID(), &xreg.BurnInArgs{Msg: msg})
+	tassert.Errorf(t, cmn.IsErrXactUsePrev(rns2.Err), "expected burn-in to be already running, got %v", rns2.Err)
+
+	select {
+	case rep := <-done:
+		tassert.Errorf(t, rep.Passed && !rep.Running, "expected burn-in to pass: %q", rep.Reason)
+		tassert.Fatalf(t, len(rep.Mpaths) > 0, "expected per-mountpath results")
+		for _, mp := range rep.Mpaths {
+			tassert.Errorf(t, mp.WriteBytes > 0 && mp.ReadBytes > 0, "%s: expected non-zero write and read bytes", mp.Mpath)
+			tassert.Errorf(t, mp.WriteBps > 0 && mp.ReadBps > 0, "%s: expected non-zero throughput", mp.Mpath)
+		}
+
+		// same results, unachievable threshold
+		rep.Msg.MinThroughput = cos.TiB
+		tassert.Errorf(t, rep.Judge() != "", "expected burn-in to fail the minimum throughput check")
+	case <-time.After(10 * time.Second):
+		t.Fatal("burn-in timed out")
+	}
+}
+
 func TestXactionAbortAll(t *testing.T) {
 	var (
 		bmd     = mock.NewBaseBownerMock()
@@ -333,3 +421,131 @@ func TestBeid(t *testing.T) {
 		fmt.Printf("Warning: failed to reproduce %d time%s out of %d\n", cnt, cos.Plural(cnt), num)
 	}
 }
+
+func TestXactionReclaim(t *testing.T) {
+	var (
+		dir = t.TempDir()
+		bck = meta.NewBck("destroyed", apc.AIS, cmn.NsGlobal)
+		num = 10
+	)
+	xreg.TestReset()
+	xs.Xreg(false)
+	defer xreg.AbortAll(nil)
+	cos.InitShortID(0)
+
+	sub := dir + "/a/b"
+	tassert.CheckFatal(t, cos.CreateDir(sub))
+	for i := range num {
+		err := os.WriteFile(fmt.Sprintf("%s/obj-%d", sub, i), make([]byte, 100), cos.PermRWR)
+		tassert.CheckFatal(t, err)
+	}
+
+	rns := xreg.RenewReclaim(cos.GenUUID(), bck, &xreg.ReclaimArgs{Dirs: []string{dir}})
+	tassert.CheckFatal(t, rns.Err)
+	xctn := rns.Entry.Get()
+	for !xctn.Finished() {
+		time.Sleep(10 * time.Millisecond)
+	}
+	snap := xctn.Snap()
+	tassert.Errorf(t, snap.Stats.Objs == int64(num), "expected %d removed files, got %d", num, snap.Stats.Objs)
+	tassert.Errorf(t, snap.Stats.Bytes == int64(num*100), "expected %d reclaimed bytes, got %d", num*100, snap.Stats.Bytes)
+	_, err := os.Stat(dir)
+	tassert.Errorf(t, os.IsNotExist(err), "expected %q to be removed, err: %v", dir, err)
+}
+
+type auditSowner struct{ smap meta.Smap }
+
+func (o *auditSowner) Get() *meta.Smap             { return &o.smap }
+func (*auditSowner) Listeners() meta.SmapListeners { return nil }
+
+// fix=delete removes only redundant (identical) misplaced replicas
+func TestXactionAuditDelete(t *testing.T) {
+	var (
+		bck = meta.NewBck("audit", apc.AIS, cmn.NsGlobal,
+			&cmn.Bprops{Cksum: cmn.CksumConf{Type: cos.ChecksumXXHash}, BID: 0xa0d17})
+		tMock = mock.NewTarget(mock.NewBaseBownerMock(bck))
+		so    = &auditSowner{}
+	)
+	si := tMock.Snode()
+	so.smap.Tmap = meta.NodeMap{si.ID(): si}
+	so.smap.InitDigests()
+	tMock.SO = so
+	core.T = tMock
+
+	fs.TestNew(mock.NewIOS())
+	fs.CSM.Reg(fs.ObjectType, &fs.ObjectContentResolver{}, true)
+	fs.CSM.Reg(fs.WorkfileType, &fs.WorkfileContentResolver{}, true)
+	for range 2 {
+		_, err := fs.Add(t.TempDir(), tMock.SID())
+		tassert.CheckFatal(t, err)
+	}
+	defer fs.TestNew(nil)
+	if errs := fs.CreateBucket(bck.Bucket(), false /*nilbmd*/); len(errs) > 0 {
+		tassert.CheckFatal(t, errs[0])
+	}
+
+	// main replica and its misplaced counterpart (the other mountpath)
+	put := func(fqn string, data []byte) {
+		tassert.CheckFatal(t, cos.CreateDir(filepath.Dir(fqn)))
+		tassert.CheckFatal(t, os.WriteFile(fqn, data, cos.PermRWR))
+		lom := &core.LOM{}
+		tassert.CheckFatal(t, lom.InitFQN(fqn, bck.Bucket()))
+		lom.SetSize(int64(len(data)))
+		lom.IncVersion()
+		ckh := cos.NewCksumHash(cos.ChecksumXXHash)
+		ckh.H.Write(data)
+		ckh.Finalize()
+		lom.SetCksum(ckh.Clone())
+		lom.SetAtimeUnix(time.Now().UnixNano())
+		tassert.CheckFatal(t, lom.Persist())
+		lom.Uncache()
+	}
+	misplaced := func(objName string) string {
+		lom := &core.LOM{ObjName: objName}
+		tassert.CheckFatal(t, lom.InitBck(bck.Bucket()))
+		for _, mi := range fs.GetAvail() {
+			if mi != lom.Mountpath() {
+				return mi.MakePathFQN(bck.Bucket(), fs.ObjectType, objName)
+			}
+		}
+		t.Fatal("expecting two mountpaths")
+		return ""
+	}
+
+	var (
+		same      = []byte("identical content")
+		diverged  = misplaced("diverged")
+		redundant = misplaced("redundant")
+	)
+	for _, objName := range []string{"diverged", "redundant"} {
+		lom := &core.LOM{ObjName: objName}
+		tassert.CheckFatal(t, lom.InitBck(bck.Bucket()))
+		put(lom.FQN, same)
+	}
+	put(redundant, same)
+	put(diverged, []byte("newer and different content"))
+
+	xreg.TestReset()
+	xs.Xreg(false)
+	defer xreg.AbortAll(nil)
+	cos.InitShortID(0)
+
+	rns := xreg.RenewAuditPlacement(cos.GenUUID(), bck, apc.AuditFixDelete)
+	tassert.CheckFatal(t, rns.Err)
+	xctn := rns.Entry.Get()
+	for !xctn.Finished() {
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	tassert.Errorf(t, cos.Stat(redundant) != nil, "expecting redundant misplaced replica %q to be deleted", redundant)
+	tassert.Errorf(t, cos.Stat(diverged) == nil, "expecting diverged misplaced replica %q to remain", diverged)
+
+	rep := xctn.(*xs.XactAudit).Report()
+	c := rep.Counts[apc.AuditMisplaced]
+	tassert.Fatalf(t, c != nil && c.Found == 2 && c.Fixed == 1, "expecting 2 misplaced (1 fixed), got %+v", c)
+	for _, e := range rep.Entries {
+		if e.FQN == diverged {
+			tassert.Errorf(t, e.Fixed == "" && e.Err != "", "expecting diverged replica to be reported, got %+v", e)
+		}
+	}
+}